@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/asheshgoplani/agent-deck/internal/session"
@@ -31,6 +32,17 @@ func TestApplyCLIYoloOverride(t *testing.T) {
 		}
 	})
 
+	t.Run("enabled for hermes sets override", func(t *testing.T) {
+		inst := session.NewInstanceWithTool("hermes-test", "/tmp/test", "hermes")
+		if err := applyCLIYoloOverride(inst, true); err != nil {
+			t.Fatalf("applyCLIYoloOverride() error = %v", err)
+		}
+		opts := inst.GetHermesOptions()
+		if opts == nil || opts.YoloMode == nil || !*opts.YoloMode {
+			t.Fatalf("HermesOptions.YoloMode = %v, want true override", opts)
+		}
+	})
+
 	t.Run("disabled is a no-op", func(t *testing.T) {
 		inst := session.NewInstanceWithTool("gemini-test", "/tmp/test", "gemini")
 		if err := applyCLIYoloOverride(inst, false); err != nil {
@@ -747,3 +759,197 @@ func onlyAddedSessionPath(t *testing.T, profile string) string {
 	}
 	return instances[0].ProjectPath
 }
+
+func TestAdd_TemplateFillsUnsetFlags(t *testing.T) {
+	home, _, profile := setupAddDefaultPathTest(t)
+	tplPath := filepath.Join(home, "tpl-project")
+	if err := os.MkdirAll(tplPath, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeAddUserConfig(t, home, `
+[templates.review]
+tool = "claude"
+group = "reviews"
+path = "`+tplPath+`"
+dangerous_mode = true
+extra_args = ["--agent", "reviewer"]
+`)
+
+	handleAdd(profile, []string{"--template", "review", "--title", "tpl", "--quiet"})
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		t.Fatalf("NewStorageWithProfile: %v", err)
+	}
+	defer storage.Close()
+	instances, _, err := storage.LoadWithGroups()
+	if err != nil || len(instances) != 1 {
+		t.Fatalf("LoadWithGroups: %d sessions, err=%v", len(instances), err)
+	}
+	inst := instances[0]
+	if inst.Tool != "claude" || inst.GroupPath != "reviews" || inst.ProjectPath != tplPath {
+		t.Fatalf("got tool=%q group=%q path=%q", inst.Tool, inst.GroupPath, inst.ProjectPath)
+	}
+	if opts := inst.GetClaudeOptions(); opts == nil || !opts.SkipPermissions {
+		t.Fatalf("template dangerous_mode not applied: %+v", opts)
+	}
+	if len(inst.ExtraArgs) != 2 || inst.ExtraArgs[1] != "reviewer" {
+		t.Fatalf("extra args = %v", inst.ExtraArgs)
+	}
+}
+
+func TestAdd_TemplateExplicitFlagsWin(t *testing.T) {
+	home, cwd, profile := setupAddDefaultPathTest(t)
+	writeAddUserConfig(t, home, `
+[templates.review]
+tool = "claude"
+group = "reviews"
+`)
+
+	handleAdd(profile, []string{"--template", "review", "-c", "codex", "-g", "mine", "--title", "tpl", "--quiet", "."})
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		t.Fatalf("NewStorageWithProfile: %v", err)
+	}
+	defer storage.Close()
+	instances, _, err := storage.LoadWithGroups()
+	if err != nil || len(instances) != 1 {
+		t.Fatalf("LoadWithGroups: %d sessions, err=%v", len(instances), err)
+	}
+	inst := instances[0]
+	if inst.Tool != "codex" || inst.GroupPath != "mine" || inst.ProjectPath != cwd {
+		t.Fatalf("got tool=%q group=%q path=%q, want flags to win", inst.Tool, inst.GroupPath, inst.ProjectPath)
+	}
+}
+
+func TestLookupAddTemplate_UnknownListsAvailable(t *testing.T) {
+	home, _, _ := setupAddDefaultPathTest(t)
+	writeAddUserConfig(t, home, "[templates.alpha]\ntool = \"claude\"\n[templates.beta]\ntool = \"codex\"\n")
+
+	_, err := lookupAddTemplate("gamma")
+	if err == nil || !strings.Contains(err.Error(), "alpha, beta") {
+		t.Fatalf("err = %v, want available names listed", err)
+	}
+}
+
+func TestAdd_TagFlagsNormalizedAndPersisted(t *testing.T) {
+	_, cwd, profile := setupAddDefaultPathTest(t)
+
+	handleAdd(profile, []string{"--title", "tagged", "--tag", "Urgent", "--tag", "#client-x", "--tag", "urgent", "--quiet", cwd})
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		t.Fatalf("NewStorageWithProfile: %v", err)
+	}
+	defer storage.Close()
+	instances, _, err := storage.LoadWithGroups()
+	if err != nil || len(instances) != 1 {
+		t.Fatalf("LoadWithGroups: %d sessions, err=%v", len(instances), err)
+	}
+	if got := strings.Join(instances[0].Tags, ","); got != "client-x,urgent" {
+		t.Fatalf("tags = %q, want client-x,urgent", got)
+	}
+
+	kept := filterInstancesByTags(instances, []string{"urgent"})
+	if len(kept) != 1 {
+		t.Fatalf("tag filter kept %d sessions, want 1", len(kept))
+	}
+	if kept := filterInstancesByTags(instances, []string{"urgent", "other"}); len(kept) != 0 {
+		t.Fatalf("all listed tags must match, kept %d", len(kept))
+	}
+}
+
+// seedGroupSessionDefaults stores a group with the given new-session defaults
+// in the profile's state DB.
+func seedGroupSessionDefaults(t *testing.T, profile, group string, d session.GroupSessionDefaults) {
+	t.Helper()
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		t.Fatalf("NewStorageWithProfile: %v", err)
+	}
+	defer storage.Close()
+	tree := session.NewGroupTreeWithGroups(nil, nil)
+	tree.CreateGroup(group)
+	tree.SetSessionDefaultsForGroup(group, d)
+	if err := storage.SaveWithGroups(nil, tree); err != nil {
+		t.Fatalf("SaveWithGroups: %v", err)
+	}
+}
+
+func TestAdd_GroupSessionDefaultsFillUnsetFlags(t *testing.T) {
+	_, cwd, profile := setupAddDefaultPathTest(t)
+	on := true
+	seedGroupSessionDefaults(t, profile, "work", session.GroupSessionDefaults{
+		Tool:          "codex",
+		Model:         "gpt-5.5",
+		DangerousMode: &on,
+	})
+
+	handleAdd(profile, []string{"-g", "work", "--title", "gd", "--quiet", cwd})
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		t.Fatalf("NewStorageWithProfile: %v", err)
+	}
+	defer storage.Close()
+	instances, _, err := storage.LoadWithGroups()
+	if err != nil || len(instances) != 1 {
+		t.Fatalf("LoadWithGroups: %d sessions, err=%v", len(instances), err)
+	}
+	inst := instances[0]
+	if inst.Tool != "codex" {
+		t.Fatalf("tool = %q, want group default codex", inst.Tool)
+	}
+	if got := inst.LaunchModelInfo().ModelID; got != "gpt-5.5" {
+		t.Fatalf("model = %q, want group default gpt-5.5", got)
+	}
+	if opts := inst.GetCodexOptions(); opts == nil || opts.YoloMode == nil || !*opts.YoloMode {
+		t.Fatalf("dangerous_mode should enable codex YOLO, got %+v", opts)
+	}
+}
+
+func TestAdd_GroupDefaultModelSkippedForOtherTool(t *testing.T) {
+	_, cwd, profile := setupAddDefaultPathTest(t)
+	seedGroupSessionDefaults(t, profile, "work", session.GroupSessionDefaults{
+		Tool:  "codex",
+		Model: "gpt-5.5",
+	})
+
+	handleAdd(profile, []string{"-g", "work", "-c", "claude", "--title", "gd", "--quiet", cwd})
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		t.Fatalf("NewStorageWithProfile: %v", err)
+	}
+	defer storage.Close()
+	instances, _, err := storage.LoadWithGroups()
+	if err != nil || len(instances) != 1 {
+		t.Fatalf("LoadWithGroups: %d sessions, err=%v", len(instances), err)
+	}
+	inst := instances[0]
+	if inst.Tool != "claude" {
+		t.Fatalf("tool = %q, want explicit claude", inst.Tool)
+	}
+	if got := inst.LaunchModelInfo().ModelID; got != "" {
+		t.Fatalf("model = %q, want codex default model not applied to claude", got)
+	}
+}
+
+func TestAdd_MCPSetExpandsMembers(t *testing.T) {
+	home, _, _ := setupAddDefaultPathTest(t)
+	writeAddUserConfig(t, home, "[mcp_sets]\nweb-dev = [\"playwright\", \"github\"]\ndata = [\"memory\", \"github\"]\n")
+
+	got, err := expandAddMCPSets([]string{"github"}, []string{"web-dev", "data"})
+	if err != nil {
+		t.Fatalf("expandAddMCPSets: %v", err)
+	}
+	if strings.Join(got, ",") != "github,playwright,memory" {
+		t.Fatalf("mcps = %v, want explicit MCPs first, then set members without duplicates", got)
+	}
+
+	_, err = expandAddMCPSets(nil, []string{"missing"})
+	if err == nil || !strings.Contains(err.Error(), "data, web-dev") {
+		t.Fatalf("err = %v, want available sets listed", err)
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleArchive stops a session and moves it to the archive, or — with
+// --idle — archives every session the [archive] idle_after threshold (or
+// --after) says has gone quiet. Archived sessions keep their tool session
+// IDs, so `unarchive` + `session start` resumes the same conversation.
+func handleArchive(profile string, args []string) {
+	fs := flag.NewFlagSet("archive", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	idle := fs.Bool("idle", false, "Archive every session idle longer than [archive] idle_after")
+	after := fs.String("after", "", "Idle threshold for --idle, overriding [archive] idle_after (e.g. 72h, 7d)")
+	dryRun := fs.Bool("dry-run", false, "With --idle: list what would be archived without stopping anything")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck archive <id|title>")
+		fmt.Println("       agent-deck archive --idle [--after <duration>] [--dry-run]")
+		fmt.Println()
+		fmt.Println("Stop a session and move it to the archive. The Claude/Codex/Gemini")
+		fmt.Println("session ID is kept; `agent-deck unarchive` brings it back.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck archive my-project")
+		fmt.Println("  agent-deck archive --idle --after 7d --dry-run")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	identifier := fs.Arg(0)
+	if *idle == (identifier != "") {
+		out.Error("pass either a session ID/title or --idle", ErrCodeInvalidOperation)
+		if !*jsonOutput {
+			fs.Usage()
+		}
+		os.Exit(1)
+	}
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	if *idle {
+		archiveIdleSessions(out, storage, instances, groups, *after, *dryRun)
+		return
+	}
+
+	inst, errMsg, errCode := ResolveSession(identifier, instances)
+	if inst == nil {
+		out.Error(fmt.Sprintf("%s (profile '%s')", errMsg, storage.Profile()), errCode)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+	if inst.IsArchived() {
+		out.Error(fmt.Sprintf("session '%s' is already archived", inst.Title), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	if err := archiveForCLI(inst, time.Now()); err != nil {
+		out.Error(fmt.Sprintf("failed to archive: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	if err := saveSessionData(storage, instances, groups); err != nil {
+		out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	out.Success(fmt.Sprintf("Archived session: %s", inst.Title), map[string]interface{}{
+		"success":     true,
+		"id":          inst.ID,
+		"title":       inst.Title,
+		"archived_at": inst.ArchivedAt,
+	})
+}
+
+// archiveForCLI captures the tool session IDs from the live pane (they may
+// not have reached storage yet) before tearing tmux down, then archives.
+func archiveForCLI(inst *session.Instance, now time.Time) error {
+	if inst.Exists() {
+		inst.SyncSessionIDsFromTmux()
+	}
+	return session.ArchiveInstance(inst, inst.KillAndWait, now)
+}
+
+func archiveIdleSessions(out *CLIOutput, storage *session.Storage, instances []*session.Instance, groups []*session.GroupData, after string, dryRun bool) {
+	settings := session.GetArchiveSettings()
+	if after != "" {
+		if _, err := session.ParseArchiveIdleAfter(after); err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		settings.IdleAfter = after
+	}
+	if settings.GetIdleAfter() <= 0 {
+		out.Error("no idle threshold: set [archive] idle_after in config.toml or pass --after", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	session.RefreshInstancesForCLIStatus(instances)
+	for _, inst := range instances {
+		_ = inst.UpdateStatus()
+	}
+	now := time.Now()
+	candidates := session.IdleArchiveCandidates(instances, settings, now)
+
+	var archived, titles, archivedTitles []string
+	var failed []map[string]string
+	for _, inst := range candidates {
+		titles = append(titles, inst.Title)
+		if dryRun {
+			continue
+		}
+		if err := archiveForCLI(inst, now); err != nil {
+			failed = append(failed, map[string]string{"id": inst.ID, "title": inst.Title, "error": err.Error()})
+			continue
+		}
+		archived = append(archived, inst.ID)
+		archivedTitles = append(archivedTitles, inst.Title)
+		_ = session.WriteSessionLifecycleEvent(session.SessionLifecycleEvent{
+			InstanceID: inst.ID,
+			Action:     session.ReasonIdleAutoArchived,
+			Reason:     fmt.Sprintf("agent-deck archive --idle (idle_after=%s)", settings.GetIdleAfter()),
+		})
+	}
+	if len(archived) > 0 {
+		if err := saveSessionData(storage, instances, groups); err != nil {
+			out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	}
+
+	var human string
+	switch {
+	case len(candidates) == 0:
+		human = fmt.Sprintf("No sessions idle longer than %s", settings.GetIdleAfter())
+	case dryRun:
+		human = fmt.Sprintf("Would archive %d session(s):\n  %s", len(titles), strings.Join(titles, "\n  "))
+	default:
+		human = fmt.Sprintf("Archived %d session(s):\n  %s", len(archived), strings.Join(archivedTitles, "\n  "))
+		for _, f := range failed {
+			human += fmt.Sprintf("\nFailed to archive %s: %s", f["title"], f["error"])
+		}
+	}
+	out.Print(human, map[string]interface{}{
+		"success":    len(failed) == 0,
+		"dry_run":    dryRun,
+		"idle_after": settings.GetIdleAfter().String(),
+		"candidates": titles,
+		"archived":   archived,
+		"failed":     failed,
+	})
+	if len(failed) > 0 {
+		os.Exit(1)
+	}
+}
+
+// handleUnarchive clears a session's archive flag. It does not restart tmux;
+// `agent-deck session start` resumes the preserved conversation.
+func handleUnarchive(profile string, args []string) {
+	fs := flag.NewFlagSet("unarchive", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck unarchive <id|title>")
+		fmt.Println()
+		fmt.Println("Move an archived session back to the active list. Start it with")
+		fmt.Println("`agent-deck session start` to resume the preserved conversation.")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	identifier := fs.Arg(0)
+	if identifier == "" {
+		out.Error("session ID or title is required", ErrCodeNotFound)
+		if !*jsonOutput {
+			fs.Usage()
+		}
+		os.Exit(1)
+	}
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	inst, errMsg, errCode := ResolveSession(identifier, instances)
+	if inst == nil {
+		out.Error(fmt.Sprintf("%s (profile '%s')", errMsg, storage.Profile()), errCode)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+	if !inst.IsArchived() {
+		out.Error(fmt.Sprintf("session '%s' is not archived", inst.Title), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	inst.ArchivedAt = time.Time{}
+	if err := saveSessionData(storage, instances, groups); err != nil {
+		out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	out.Success(fmt.Sprintf("Unarchived session: %s (start it with: agent-deck session start %s)", inst.Title, inst.ID), map[string]interface{}{
+		"success": true,
+		"id":      inst.ID,
+		"title":   inst.Title,
+	})
+}
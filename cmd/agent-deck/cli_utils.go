@@ -124,6 +124,19 @@ func splitFirstWord(raw string) (string, string) {
 	return s, ""
 }
 
+// applyGroupRule moves a new session into the group of the first matching
+// [[group_rules]] entry. An explicit -g/--group always wins; otherwise a rule
+// beats the inferred (parent or cwd-derived) group, since it is deliberate
+// configuration. Call once the title, path and tool are final.
+func applyGroupRule(inst *session.Instance, cfg *session.UserConfig, explicitGroupProvided bool) {
+	if explicitGroupProvided {
+		return
+	}
+	if group := cfg.MatchGroupRule(inst.ProjectPath, inst.Tool, inst.Title); group != "" {
+		inst.GroupPath = group
+	}
+}
+
 // resolveGroupSelection picks the group for a new session using a fixed
 // priority order. Priority (issue #972):
 //  1. Explicit -g/--group always wins.
@@ -192,6 +205,81 @@ func resolveAddPath(rawPathArg string) (string, error) {
 	return filepath.Abs(session.ExpandPath(rawPathArg))
 }
 
+// lookupAddTemplate resolves `agent-deck add --template <name>` against
+// [templates.<name>] in config.toml, listing the configured names on a miss.
+func lookupAddTemplate(name string) (session.SessionTemplate, error) {
+	cfg, err := session.LoadUserConfig()
+	if err != nil {
+		return session.SessionTemplate{}, fmt.Errorf("failed to load config: %w", err)
+	}
+	if t, ok := cfg.GetTemplate(name); ok {
+		return t, nil
+	}
+	names := cfg.TemplateNames()
+	if len(names) == 0 {
+		return session.SessionTemplate{}, fmt.Errorf("template %q not found: no [templates.<name>] defined in config.toml", name)
+	}
+	return session.SessionTemplate{}, fmt.Errorf("template %q not found (available: %s)", name, strings.Join(names, ", "))
+}
+
+// expandAddMCPSets appends the members of each [mcp_sets] entry named by
+// `add --mcp-set` to mcps, skipping MCPs already listed.
+func expandAddMCPSets(mcps, setNames []string) ([]string, error) {
+	if len(setNames) == 0 {
+		return mcps, nil
+	}
+	cfg, err := session.LoadUserConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	seen := make(map[string]bool, len(mcps))
+	for _, name := range mcps {
+		seen[name] = true
+	}
+	for _, setName := range setNames {
+		members, ok := cfg.GetMCPSet(setName)
+		if !ok {
+			names := cfg.MCPSetNames()
+			if len(names) == 0 {
+				return nil, fmt.Errorf("MCP set %q not found: no [mcp_sets] defined in config.toml", setName)
+			}
+			return nil, fmt.Errorf("MCP set %q not found (available: %s)", setName, strings.Join(names, ", "))
+		}
+		for _, name := range members {
+			if !seen[name] {
+				seen[name] = true
+				mcps = append(mcps, name)
+			}
+		}
+	}
+	return mcps, nil
+}
+
+// applyGroupSessionDefaults fills the `add` inputs that neither explicit
+// flags nor the template set from the group's session defaults. Models are
+// tool-specific, so the default model only applies when the session runs the
+// group's default tool.
+func applyGroupSessionDefaults(d session.GroupSessionDefaults, command, model *string, mcps *[]string, template *session.SessionTemplate, yolo *bool, yoloExplicit bool) {
+	if *command == "" {
+		*command = d.Tool
+	}
+	tool, _, _, _ := resolveSessionCommand(*command, "")
+	if *model == "" && d.Model != "" && d.Tool != "" {
+		if defaultTool, _, _, _ := resolveSessionCommand(d.Tool, ""); defaultTool == tool {
+			*model = d.Model
+		}
+	}
+	if len(*mcps) == 0 {
+		*mcps = d.MCPs
+	}
+	if template.DangerousMode == nil {
+		template.DangerousMode = d.DangerousMode
+	}
+	if !yoloExplicit && template.Yolo == nil && d.DangerousMode != nil {
+		*yolo = *d.DangerousMode && yoloCapableTool(tool)
+	}
+}
+
 // CLIOutput handles consistent output formatting across all CLI commands
 type CLIOutput struct {
 	jsonMode  bool
@@ -468,3 +556,18 @@ func FormatPath(path string) string {
 	}
 	return path
 }
+
+// filterInstancesByTags keeps the instances that carry every tag in tags.
+// An empty tag list keeps everything.
+func filterInstancesByTags(instances []*session.Instance, tags []string) []*session.Instance {
+	if len(tags) == 0 {
+		return instances
+	}
+	kept := make([]*session.Instance, 0, len(instances))
+	for _, inst := range instances {
+		if inst.HasAllTags(tags) {
+			kept = append(kept, inst)
+		}
+	}
+	return kept
+}
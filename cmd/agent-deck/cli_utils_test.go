@@ -4,6 +4,8 @@ import (
 	"flag"
 	"reflect"
 	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
 func TestNormalizeArgs(t *testing.T) {
@@ -369,6 +371,28 @@ func TestResolveGroupSelection(t *testing.T) {
 	}
 }
 
+func TestApplyGroupRule(t *testing.T) {
+	cfg := &session.UserConfig{GroupRules: []session.GroupRule{{Group: "bots", Tool: "claude", Title: "^ci-"}}}
+
+	inst := &session.Instance{Title: "ci-nightly", Tool: "claude", ProjectPath: "/src/api", GroupPath: "src"}
+	applyGroupRule(inst, cfg, false)
+	if inst.GroupPath != "bots" {
+		t.Errorf("GroupPath = %q, want rule group bots", inst.GroupPath)
+	}
+
+	inst = &session.Instance{Title: "ci-nightly", Tool: "claude", ProjectPath: "/src/api", GroupPath: "ops"}
+	applyGroupRule(inst, cfg, true)
+	if inst.GroupPath != "ops" {
+		t.Errorf("GroupPath = %q, want explicit -g group ops kept", inst.GroupPath)
+	}
+
+	inst = &session.Instance{Title: "dev", Tool: "claude", ProjectPath: "/src/api", GroupPath: "src"}
+	applyGroupRule(inst, cfg, false)
+	if inst.GroupPath != "src" {
+		t.Errorf("GroupPath = %q, want derived group src when no rule matches", inst.GroupPath)
+	}
+}
+
 func TestShouldInheritParentGroup(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"math"
 	"os"
 
 	"github.com/asheshgoplani/agent-deck/internal/costs"
@@ -33,6 +34,35 @@ func handleCosts(profile string, args []string) {
 	}
 }
 
+// budgetConfigFromSettings converts [costs.budgets] (dollars) into the
+// microdollar limits costs.BudgetChecker works in.
+func budgetConfigFromSettings(bc session.BudgetSettings) costs.BudgetConfig {
+	toMicro := func(usd float64) int64 { return int64(math.Round(usd * 1_000_000)) }
+	cfg := costs.BudgetConfig{
+		DailyLimit:      toMicro(bc.DailyLimit),
+		WeeklyLimit:     toMicro(bc.WeeklyLimit),
+		MonthlyLimit:    toMicro(bc.MonthlyLimit),
+		DailyTokenLimit: bc.DailyTokenLimit,
+	}
+	if len(bc.Groups) > 0 {
+		cfg.GroupLimits = make(map[string]int64)
+		cfg.GroupTokenLimits = make(map[string]int64)
+		for name, g := range bc.Groups {
+			cfg.GroupLimits[name] = toMicro(g.DailyLimit)
+			cfg.GroupTokenLimits[name] = g.DailyTokenLimit
+		}
+	}
+	if len(bc.Sessions) > 0 {
+		cfg.SessionLimits = make(map[string]int64)
+		cfg.SessionTokenLimits = make(map[string]int64)
+		for key, sb := range bc.Sessions {
+			cfg.SessionLimits[key] = toMicro(sb.TotalLimit)
+			cfg.SessionTokenLimits[key] = sb.TotalTokenLimit
+		}
+	}
+	return cfg
+}
+
 // openCostStore creates a cost store from the profile's database.
 func openCostStore(profile string) (*costs.Store, *session.Storage) {
 	storage, err := session.NewStorageWithProfile(profile)
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleExport writes session definitions to a portable JSON/YAML file that
+// `agent-deck import` can recreate on another machine or profile.
+func handleExport(profile string, args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	output := fs.String("output", "", "Write to this file instead of stdout")
+	outputShort := fs.String("o", "", "Write to this file (short)")
+	format := fs.String("format", "", "json or yaml (default: from the output extension, else json)")
+	group := fs.String("group", "", "Only export sessions in this group (and its subgroups)")
+	includeArchived := fs.Bool("archived", false, "Include archived sessions")
+	var tagFlags []string
+	fs.Func("tag", "Only export sessions carrying this tag (repeatable)", func(v string) error {
+		tagFlags = append(tagFlags, v)
+		return nil
+	})
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck export [id|title ...] [options]")
+		fmt.Println()
+		fmt.Println("Export session definitions (tool, command, path, group, worktree, and")
+		fmt.Println("conversation IDs) so `agent-deck import` can recreate them elsewhere.")
+		fmt.Println("With no sessions named, every session matching the filters is exported.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck export -o deck.json")
+		fmt.Println("  agent-deck export --group work --format yaml > work.yaml")
+		fmt.Println("  agent-deck export api-server web-client -o pair.json")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	outPath := *output
+	if outPath == "" {
+		outPath = *outputShort
+	}
+	tags, err := session.NormalizeTags(tagFlags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid --tag: %v\n", err)
+		os.Exit(1)
+	}
+
+	instances, err := readSessionData(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	selected, err := selectExportInstances(instances, fs.Args(), *group, tags, *includeArchived)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmtName := *format
+	if fmtName == "" {
+		fmtName = exportFormatFromPath(outPath)
+	}
+	data, err := session.MarshalDeckExport(session.BuildDeckExport(selected, time.Now()), fmtName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if outPath == "" || outPath == "-" {
+		_, _ = os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(outPath, data, 0o600); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: write %s: %v\n", outPath, err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d session(s) to %s\n", len(selected), outPath)
+}
+
+// selectExportInstances resolves named sessions (all when none are named)
+// and applies the --group / --tag / --archived filters.
+func selectExportInstances(instances []*session.Instance, identifiers []string, group string, tags []string, includeArchived bool) ([]*session.Instance, error) {
+	candidates := instances
+	if len(identifiers) > 0 {
+		candidates = make([]*session.Instance, 0, len(identifiers))
+		for _, id := range identifiers {
+			inst, errMsg, _ := ResolveSession(id, instances)
+			if inst == nil {
+				return nil, fmt.Errorf("%s", errMsg)
+			}
+			candidates = append(candidates, inst)
+		}
+	}
+	group = strings.Trim(group, "/")
+	kept := make([]*session.Instance, 0, len(candidates))
+	for _, inst := range candidates {
+		if inst.IsArchived() && !includeArchived && len(identifiers) == 0 {
+			continue
+		}
+		if group != "" && inst.GroupPath != group && !strings.HasPrefix(inst.GroupPath, group+"/") {
+			continue
+		}
+		if !inst.HasAllTags(tags) {
+			continue
+		}
+		kept = append(kept, inst)
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("no sessions match")
+	}
+	return kept, nil
+}
+
+func exportFormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	default:
+		return "json"
+	}
+}
+
+// handleImport recreates sessions from an `agent-deck export` file. Imported
+// sessions are stopped; starting one resumes its exported conversation.
+func handleImport(profile string, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	group := fs.String("group", "", "Nest imported sessions under this group")
+	dryRun := fs.Bool("dry-run", false, "Show what would be imported without saving")
+	allowDuplicates := fs.Bool("allow-duplicates", false, "Import sessions even when an equivalent one already exists")
+	pathMap := map[string]string{}
+	fs.Func("map", "Rewrite a path prefix, OLD=NEW (repeatable), e.g. /home/alice=~", func(v string) error {
+		from, to, ok := strings.Cut(v, "=")
+		if !ok || from == "" || to == "" {
+			return fmt.Errorf("expected OLD=NEW, got %q", v)
+		}
+		pathMap[from] = to
+		return nil
+	})
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck import <file|-> [options]")
+		fmt.Println()
+		fmt.Println("Create sessions from an `agent-deck export` file (JSON or YAML). Sessions")
+		fmt.Println("are created stopped; the first start resumes the exported conversation.")
+		fmt.Println("Sessions that already exist (same conversation, or same title and path)")
+		fmt.Println("are skipped.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck import deck.json")
+		fmt.Println("  agent-deck import team.yaml --group shared --map /home/alice=~")
+		fmt.Println("  ssh old-box agent-deck export | agent-deck import -")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	source := fs.Arg(0)
+	if source == "" {
+		out.Error("export file is required (use - for stdin)", ErrCodeInvalidOperation)
+		if !*jsonOutput {
+			fs.Usage()
+		}
+		os.Exit(1)
+	}
+
+	var data []byte
+	var err error
+	if source == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		out.Error(fmt.Sprintf("read %s: %v", source, err), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	doc, err := session.ParseDeckExport(data)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	userConfig, _ := session.LoadUserConfig()
+	opts := session.DeckImportOptions{Group: strings.Trim(*group, "/"), PathMap: pathMap}
+	created, skipped := importDeckSessions(doc, instances, opts, userConfig, *allowDuplicates)
+
+	if !*dryRun && len(created) > 0 {
+		instances = append(instances, created...)
+		groupTree := session.NewGroupTreeWithGroups(instances, groups)
+		if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+			out.Error(fmt.Sprintf("failed to save sessions: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	}
+
+	rows := make([]map[string]string, 0, len(created))
+	var human strings.Builder
+	verb := "Imported"
+	if *dryRun {
+		verb = "Would import"
+	}
+	fmt.Fprintf(&human, "%s %d session(s) into profile '%s'", verb, len(created), storage.Profile())
+	for _, inst := range created {
+		rows = append(rows, map[string]string{"id": inst.ID, "title": inst.Title, "group": inst.GroupPath, "path": inst.ProjectPath, "tool": inst.Tool})
+		fmt.Fprintf(&human, "\n  %s  [%s]  %s", inst.Title, inst.GroupPath, FormatPath(inst.ProjectPath))
+		if _, statErr := os.Stat(inst.ProjectPath); statErr != nil && inst.SSHHost == "" {
+			fmt.Fprintf(&human, "  (path missing — use --map)")
+		}
+	}
+	for _, title := range skipped {
+		fmt.Fprintf(&human, "\n  skipped %s (already exists)", title)
+	}
+	out.Success(human.String(), map[string]interface{}{
+		"success":  true,
+		"dry_run":  *dryRun,
+		"imported": rows,
+		"skipped":  skipped,
+	})
+}
+
+// importDeckSessions builds instances for every exported session, skipping
+// those that duplicate an existing (or earlier imported) session unless
+// allowDuplicates is set. It returns the new instances and skipped titles.
+func importDeckSessions(doc session.DeckExport, existing []*session.Instance, opts session.DeckImportOptions, config *session.UserConfig, allowDuplicates bool) ([]*session.Instance, []string) {
+	var created []*session.Instance
+	var skipped []string
+	seen := append([]*session.Instance(nil), existing...)
+	for _, s := range doc.Sessions {
+		inst := s.NewInstance(opts, config)
+		if !allowDuplicates && session.FindDeckImportDuplicate(seen, inst) != nil {
+			skipped = append(skipped, inst.Title)
+			continue
+		}
+		created = append(created, inst)
+		seen = append(seen, inst)
+	}
+	return created, skipped
+}
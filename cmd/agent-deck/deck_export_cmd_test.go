@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestSelectExportInstances_Filters(t *testing.T) {
+	api := session.NewInstanceWithGroupAndTool("api", "/p/api", "work/backend", "claude")
+	api.Tags = []string{"prod"}
+	web := session.NewInstanceWithGroupAndTool("web", "/p/web", "work", "claude")
+	old := session.NewInstanceWithGroupAndTool("old", "/p/old", "work", "shell")
+	old.ArchivedAt = time.Now()
+	other := session.NewInstanceWithGroupAndTool("notes", "/p/notes", "personal", "shell")
+	all := []*session.Instance{api, web, old, other}
+
+	got, err := selectExportInstances(all, nil, "work", nil, false)
+	if err != nil || len(got) != 2 {
+		t.Fatalf("group filter: got %d (%v), want api+web without the archived one", len(got), err)
+	}
+	got, _ = selectExportInstances(all, nil, "work", nil, true)
+	if len(got) != 3 {
+		t.Fatalf("--archived should include archived sessions, got %d", len(got))
+	}
+	got, _ = selectExportInstances(all, nil, "", []string{"prod"}, false)
+	if len(got) != 1 || got[0] != api {
+		t.Fatalf("tag filter: got %v", got)
+	}
+	got, err = selectExportInstances(all, []string{"old"}, "", nil, false)
+	if err != nil || len(got) != 1 || got[0] != old {
+		t.Fatalf("naming an archived session exports it: got %v, %v", got, err)
+	}
+	if _, err := selectExportInstances(all, []string{"missing"}, "", nil, false); err == nil {
+		t.Fatal("unknown session should error")
+	}
+	if _, err := selectExportInstances(all, nil, "nope", nil, false); err == nil {
+		t.Fatal("empty selection should error")
+	}
+}
+
+func TestImportDeckSessions_SkipsDuplicates(t *testing.T) {
+	existing := session.NewInstanceWithTool("api", "/p/api", "claude")
+	doc := session.DeckExport{Version: session.DeckExportVersion, Sessions: []session.DeckExportSession{
+		{Title: "api", Tool: "claude", Path: "/p/api"},
+		{Title: "web", Tool: "shell", Path: "/p/web"},
+		{Title: "web", Tool: "shell", Path: "/p/web"},
+	}}
+
+	created, skipped := importDeckSessions(doc, []*session.Instance{existing}, session.DeckImportOptions{}, nil, false)
+	if len(created) != 1 || created[0].Title != "web" {
+		t.Fatalf("created = %v, want only web", created)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("skipped = %v, want existing api and the repeated web", skipped)
+	}
+
+	created, skipped = importDeckSessions(doc, []*session.Instance{existing}, session.DeckImportOptions{}, nil, true)
+	if len(created) != 3 || len(skipped) != 0 {
+		t.Fatalf("--allow-duplicates: created %d skipped %v", len(created), skipped)
+	}
+}
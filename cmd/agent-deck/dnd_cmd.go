@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleDND is the dispatch entry for `agent-deck dnd`. DND is deck-wide (not
+// per-profile): it silences the tmux notification bar and web push for every
+// running agent-deck process while still recording what was missed.
+func handleDND(args []string) {
+	if err := runDND(os.Stdout, args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// runDND is the testable seam for handleDND.
+//
+// Forms:
+//
+//	agent-deck dnd on [--for 90m]      silence alerts (default: [notifications].dnd_default_minutes)
+//	agent-deck dnd off [--json]        resume alerts and print the missed-while-away digest
+//	agent-deck dnd status [--json]     show the DND window and pending digest
+//	agent-deck dnd digest [--clear]    print (and optionally clear) the digest
+func runDND(stdout io.Writer, args []string) error {
+	usage := func() {
+		fmt.Fprintln(stdout, "Usage: agent-deck dnd <on|off|status|digest> [options]")
+		fmt.Fprintln(stdout)
+		fmt.Fprintln(stdout, "  on [--for <duration>]   Suppress notification bar and web push alerts")
+		fmt.Fprintln(stdout, "  off [--json]            Resume alerts and print what was missed")
+		fmt.Fprintln(stdout, "  status [--json]         Show whether DND is active")
+		fmt.Fprintln(stdout, "  digest [--clear]        Print the missed-while-away digest")
+	}
+	if len(args) == 0 {
+		args = []string{"status"}
+	}
+
+	switch args[0] {
+	case "on":
+		fs := flag.NewFlagSet("dnd on", flag.ContinueOnError)
+		dur := fs.Duration("for", 0, "how long to stay in DND (e.g. 45m, 2h)")
+		if err := fs.Parse(normalizeArgs(fs, args[1:])); err != nil {
+			return err
+		}
+		if *dur < 0 {
+			return fmt.Errorf("--for must be positive")
+		}
+		st, err := session.EnableDND(*dur)
+		if err != nil {
+			return fmt.Errorf("enable dnd: %w", err)
+		}
+		fmt.Fprintf(stdout, "Do-not-disturb on until %s\n", st.Until.Format("15:04 (Jan 2)"))
+		return nil
+
+	case "off":
+		fs := flag.NewFlagSet("dnd off", flag.ContinueOnError)
+		asJSON := fs.Bool("json", false, "emit the digest as JSON")
+		if err := fs.Parse(normalizeArgs(fs, args[1:])); err != nil {
+			return err
+		}
+		missed, err := session.DisableDND()
+		if err != nil {
+			return fmt.Errorf("disable dnd: %w", err)
+		}
+		if *asJSON {
+			return writeDNDDigestJSON(stdout, missed)
+		}
+		fmt.Fprintln(stdout, "Do-not-disturb off")
+		printDNDDigest(stdout, missed)
+		return nil
+
+	case "status":
+		fs := flag.NewFlagSet("dnd status", flag.ContinueOnError)
+		asJSON := fs.Bool("json", false, "emit state as JSON")
+		if err := fs.Parse(normalizeArgs(fs, args[1:])); err != nil {
+			return err
+		}
+		st, err := session.LoadDNDState()
+		if err != nil {
+			return fmt.Errorf("read dnd state: %w", err)
+		}
+		active := st.Active(time.Now())
+		if *asJSON {
+			out := struct {
+				Active bool                     `json:"active"`
+				Until  *time.Time               `json:"until,omitempty"`
+				Missed []session.DNDMissedEvent `json:"missed"`
+			}{Active: active, Missed: st.Missed}
+			if active {
+				out.Until = &st.Until
+			}
+			if out.Missed == nil {
+				out.Missed = []session.DNDMissedEvent{}
+			}
+			return json.NewEncoder(stdout).Encode(out)
+		}
+		if active {
+			fmt.Fprintf(stdout, "Do-not-disturb: on until %s (%s left)\n",
+				st.Until.Format("15:04 (Jan 2)"), time.Until(st.Until).Round(time.Minute))
+		} else {
+			fmt.Fprintln(stdout, "Do-not-disturb: off")
+		}
+		fmt.Fprintf(stdout, "Missed while away: %d\n", len(st.Missed))
+		return nil
+
+	case "digest":
+		fs := flag.NewFlagSet("dnd digest", flag.ContinueOnError)
+		asJSON := fs.Bool("json", false, "emit the digest as JSON")
+		clearDigest := fs.Bool("clear", false, "clear the digest after printing")
+		if err := fs.Parse(normalizeArgs(fs, args[1:])); err != nil {
+			return err
+		}
+		st, err := session.LoadDNDState()
+		if err != nil {
+			return fmt.Errorf("read dnd state: %w", err)
+		}
+		if *asJSON {
+			if err := writeDNDDigestJSON(stdout, st.Missed); err != nil {
+				return err
+			}
+		} else {
+			printDNDDigest(stdout, st.Missed)
+		}
+		if *clearDigest {
+			return session.ClearDNDDigest()
+		}
+		return nil
+
+	case "help", "--help", "-h":
+		usage()
+		return nil
+	default:
+		usage()
+		return fmt.Errorf("unknown dnd subcommand %q", args[0])
+	}
+}
+
+func writeDNDDigestJSON(stdout io.Writer, missed []session.DNDMissedEvent) error {
+	if missed == nil {
+		missed = []session.DNDMissedEvent{}
+	}
+	return json.NewEncoder(stdout).Encode(missed)
+}
+
+func printDNDDigest(stdout io.Writer, missed []session.DNDMissedEvent) {
+	if len(missed) == 0 {
+		fmt.Fprintln(stdout, "Nothing missed while away.")
+		return
+	}
+	fmt.Fprintf(stdout, "Missed while away (%d):\n", len(missed))
+	for _, ev := range missed {
+		title := ev.Title
+		if title == "" {
+			title = ev.SessionID
+		}
+		fmt.Fprintf(stdout, "  %s  %-8s %s\n", ev.Time.Format("15:04"), ev.Status, title)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestRunDND_OnStatusOff(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AGENT_DECK_HOME", "")
+	session.ClearUserConfigCache()
+	t.Cleanup(session.ClearUserConfigCache)
+
+	var out bytes.Buffer
+	if err := runDND(&out, []string{"on", "--for", "20m"}); err != nil {
+		t.Fatalf("dnd on: %v", err)
+	}
+	if !strings.Contains(out.String(), "Do-not-disturb on until") {
+		t.Fatalf("unexpected on output: %q", out.String())
+	}
+
+	out.Reset()
+	if err := runDND(&out, []string{"status"}); err != nil {
+		t.Fatalf("dnd status: %v", err)
+	}
+	if !strings.Contains(out.String(), "Do-not-disturb: on") {
+		t.Fatalf("status should report on: %q", out.String())
+	}
+
+	if _, err := session.RecordDNDMissed(session.DNDMissedEvent{SessionID: "s1", Title: "worker", Status: "waiting"}); err != nil {
+		t.Fatal(err)
+	}
+
+	out.Reset()
+	if err := runDND(&out, []string{"off"}); err != nil {
+		t.Fatalf("dnd off: %v", err)
+	}
+	if !strings.Contains(out.String(), "Missed while away (1)") || !strings.Contains(out.String(), "worker") {
+		t.Fatalf("off must print the digest: %q", out.String())
+	}
+	if session.IsDNDActive() {
+		t.Fatal("DND still active after off")
+	}
+}
+
+func TestRunDND_RejectsUnknownSubcommand(t *testing.T) {
+	var out bytes.Buffer
+	if err := runDND(&out, []string{"maybe"}); err == nil {
+		t.Fatal("expected error for unknown subcommand")
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// handleDoctor checks the environment (tmux and its features, agent
+// binaries, config resolution, MCP pool, storage integrity; see
+// runEnvironmentChecks), then reconciles the profile's session records with
+// the agentdeck_* tmux sessions actually running: records whose tmux session
+// is gone, duplicate tmux sessions for one record, and orphaned tmux sessions
+// no record claims. Tmux issues are reported; --fix (or answering the prompt)
+// re-links, kills duplicates, and imports orphans.
+func handleDoctor(profile string, args []string) {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	fix := fs.Bool("fix", false, "Repair every repairable issue without prompting")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck doctor [options]")
+		fmt.Println()
+		fmt.Println("Check tmux (version, pipe-pane, sync output, status-left-length), the")
+		fmt.Println("claude/gemini binaries, the Claude config dir, the MCP socket pool and")
+		fmt.Println("the state database, printing a fix for each problem. Then check session")
+		fmt.Println("records against running tmux sessions:")
+		fmt.Println("  missing    a running/waiting/errored session whose tmux session is gone")
+		fmt.Println("             (re-linked when another tmux session carries its ID)")
+		fmt.Println("  duplicate  an extra tmux session for a session (killed)")
+		fmt.Println("  orphan     an agentdeck_* tmux session with no record (imported into")
+		fmt.Println("             the \"recovered\" group)")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	checks := runEnvironmentChecks(storage, instances)
+	envFailed := false
+	for _, c := range checks {
+		if c.Status == doctorFail {
+			envFailed = true
+		}
+	}
+
+	// Without tmux there is nothing to reconcile; the environment report
+	// already says so.
+	var live []tmux.AgentDeckSessionInfo
+	if checks[0].Status != doctorFail {
+		live, err = tmux.ListAgentDeckSessionsWithIdentity()
+		if err != nil {
+			out.Error(fmt.Sprintf("failed to list tmux sessions: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	}
+	issues := session.DiagnoseTmuxSessions(instances, live, storage.Profile())
+
+	repairable := 0
+	for _, issue := range issues {
+		if issue.Repairable() {
+			repairable++
+		}
+	}
+
+	apply := *fix
+	if !*jsonOutput {
+		fmt.Println("Environment:")
+		for _, c := range checks {
+			line := fmt.Sprintf("  %-4s  %-24s %s", strings.ToUpper(c.Status), c.Name, c.Detail)
+			if c.Status == doctorFail {
+				fmt.Fprintln(os.Stderr, line)
+			} else {
+				fmt.Println(line)
+			}
+			if c.Fix != "" {
+				fmt.Printf("        %-24s fix: %s\n", "", c.Fix)
+			}
+		}
+		fmt.Println()
+		fmt.Printf("Profile: %s (%d sessions, %d agentdeck tmux sessions)\n\n", storage.Profile(), len(instances), len(live))
+		if len(issues) == 0 {
+			fmt.Println("No tmux drift found.")
+			if envFailed {
+				os.Exit(1)
+			}
+			return
+		}
+		for _, issue := range issues {
+			fmt.Printf("  %-9s %s\n", strings.ToUpper(string(issue.Kind)), issue.Describe())
+		}
+		fmt.Println()
+		if !apply && repairable > 0 && term.IsTerminal(int(os.Stdin.Fd())) {
+			fmt.Printf("Repair %d issue(s)? (y/N): ", repairable)
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			apply = yesAnswer(strings.TrimSpace(strings.ToLower(answer)))
+		}
+	}
+
+	type result struct {
+		Kind     string `json:"kind"`
+		ID       string `json:"id,omitempty"`
+		Title    string `json:"title,omitempty"`
+		Tmux     string `json:"tmux_session"`
+		RelinkTo string `json:"relink_to,omitempty"`
+		Repaired bool   `json:"repaired"`
+		Error    string `json:"error,omitempty"`
+	}
+	results := make([]result, 0, len(issues))
+	dirty := false
+	failed := false
+	for _, issue := range issues {
+		r := result{Kind: string(issue.Kind), Tmux: issue.TmuxName, RelinkTo: issue.RelinkTo}
+		if issue.Instance != nil {
+			r.ID, r.Title = issue.Instance.ID, issue.Instance.Title
+		}
+		if apply && issue.Repairable() {
+			imported, err := session.RepairTmuxIssue(issue)
+			if err != nil {
+				r.Error = err.Error()
+				failed = true
+			} else {
+				r.Repaired = true
+				if imported != nil {
+					instances = append(instances, imported)
+					r.ID, r.Title = imported.ID, imported.Title
+				}
+				dirty = dirty || issue.Kind != session.TmuxIssueDuplicate
+			}
+		}
+		results = append(results, r)
+	}
+
+	if dirty {
+		if err := saveSessionData(storage, instances, groups); err != nil {
+			out.Error(fmt.Sprintf("failed to save sessions: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+	}
+
+	if *jsonOutput {
+		out.Print("", map[string]interface{}{
+			"profile": storage.Profile(),
+			"checks":  checks,
+			"issues":  results,
+		})
+	} else if apply {
+		for _, r := range results {
+			switch {
+			case r.Error != "":
+				fmt.Fprintf(os.Stderr, "  failed    %s: %s\n", r.Tmux, r.Error)
+			case r.Repaired:
+				fmt.Printf("  repaired  %s %s\n", r.Kind, r.Tmux)
+			}
+		}
+	} else if repairable > 0 {
+		fmt.Println("Run `agent-deck doctor --fix` to repair.")
+	}
+
+	if envFailed || failed || (!apply && len(issues) > 0) {
+		os.Exit(1)
+	}
+}
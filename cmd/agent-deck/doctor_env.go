@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/mcppool"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// Doctor check outcomes. Only doctorFail makes `agent-deck doctor` exit 1.
+const (
+	doctorOK   = "ok"
+	doctorWarn = "warn"
+	doctorFail = "fail"
+	doctorSkip = "skip"
+)
+
+// minTmuxMajor/minTmuxMinor is the oldest tmux agent-deck's session options
+// fully work on: allow-passthrough, extended-keys and the "sync" terminal
+// feature (DEC mode 2026 synchronized output) all arrived in 3.2.
+const (
+	minTmuxMajor = 3
+	minTmuxMinor = 2
+)
+
+// wantStatusLeftLength matches what the TUI sets globally at startup; tmux's
+// default of 10 (or a tmux.conf override) truncates the notification bar.
+const wantStatusLeftLength = 120
+
+// doctorCheck is one line of the environment report.
+type doctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// runEnvironmentChecks validates the host for agent-deck: tmux and the
+// features sessions rely on, the agent binaries, config resolution, the MCP
+// socket pool, and the profile's state database. instances decides whether a
+// missing agent binary is fatal (a session uses it) or informational.
+func runEnvironmentChecks(storage *session.Storage, instances []*session.Instance) []doctorCheck {
+	var checks []doctorCheck
+	checks = append(checks, tmuxChecks(tmux.ProbeCapabilities(), runtime.GOOS)...)
+
+	cfg, cfgErr := session.LoadUserConfig()
+	checks = append(checks, configCheck(cfgErr))
+
+	used := make(map[string]bool)
+	for _, inst := range instances {
+		used[inst.Tool] = true
+	}
+	checks = append(checks,
+		agentBinaryCheck("claude", used["claude"], "install Claude Code: npm install -g @anthropic-ai/claude-code"),
+		agentBinaryCheck("gemini", used["gemini"], "install Gemini CLI: npm install -g @google/gemini-cli"),
+		claudeConfigDirCheck(),
+		mcpPoolCheck(cfg, mcppool.ScanSockets()),
+		storageCheck(storage),
+	)
+	return checks
+}
+
+// tmuxChecks reports the tmux binary, its version, and the features agent-deck
+// needs from it.
+func tmuxChecks(caps tmux.Capabilities, goos string) []doctorCheck {
+	if !caps.Installed {
+		return []doctorCheck{{
+			Name: "tmux", Status: doctorFail, Detail: "not found on PATH",
+			Fix: "install tmux 3.2 or newer (brew install tmux / apt install tmux)",
+		}}
+	}
+
+	version := doctorCheck{Name: "tmux", Status: doctorOK, Detail: caps.Version}
+	switch {
+	case caps.Version == "":
+		version.Status, version.Detail = doctorWarn, "version not recognized from `tmux -V`"
+	case !tmux.VersionAtLeast(caps.Version, minTmuxMajor, minTmuxMinor):
+		version.Status = doctorWarn
+		version.Detail = caps.Version + " is older than 3.2; passthrough, extended keys and synchronized output are unavailable"
+		version.Fix = "upgrade tmux to 3.2 or newer"
+	case goos == "darwin" && tmux.IsVulnerableVersion(caps.Version):
+		version.Status = doctorWarn
+		version.Detail = caps.Version + " has a control-mode crash (tmux #4980)"
+		version.Fix = "brew upgrade tmux once a patched release is out"
+	}
+	checks := []doctorCheck{version}
+
+	pipe := doctorCheck{Name: "tmux pipe-pane", Status: doctorOK, Detail: "available"}
+	if !caps.PipePane {
+		pipe.Status = doctorFail
+		pipe.Detail = "this tmux has no pipe-pane; transcripts and output streaming will not work"
+		pipe.Fix = "install a standard tmux build"
+	}
+	checks = append(checks, pipe)
+
+	sync := doctorCheck{Name: "tmux sync output", Status: doctorOK, Detail: "mode 2026 supported"}
+	if caps.Version != "" && !tmux.VersionAtLeast(caps.Version, minTmuxMajor, minTmuxMinor) {
+		sync.Status = doctorWarn
+		sync.Detail = "mode 2026 needs tmux 3.2+; agent redraws may flicker"
+		sync.Fix = "upgrade tmux to 3.2 or newer"
+	} else if caps.Version == "" {
+		sync.Status, sync.Detail = doctorSkip, "tmux version unknown"
+	}
+	checks = append(checks, sync)
+
+	status := doctorCheck{Name: "tmux status-left-length", Status: doctorOK}
+	switch {
+	case !caps.ServerRunning:
+		status.Status, status.Detail = doctorSkip, "no tmux server running"
+	case caps.StatusLeftLength < 0:
+		status.Status, status.Detail = doctorWarn, "could not read the option"
+	case caps.StatusLeftLength < wantStatusLeftLength:
+		status.Status = doctorWarn
+		status.Detail = fmt.Sprintf("%d; the status bar notifications are truncated", caps.StatusLeftLength)
+		status.Fix = fmt.Sprintf("start the TUI (it sets %d), or set -g status-left-length %d in ~/.tmux.conf", wantStatusLeftLength, wantStatusLeftLength)
+	default:
+		status.Detail = fmt.Sprintf("%d", caps.StatusLeftLength)
+	}
+	return append(checks, status)
+}
+
+// configCheck reports whether config.toml parses.
+func configCheck(err error) doctorCheck {
+	path, _ := session.GetUserConfigPath()
+	if err != nil {
+		return doctorCheck{
+			Name: "config.toml", Status: doctorFail, Detail: err.Error(),
+			Fix: "fix the syntax error in " + path,
+		}
+	}
+	if _, statErr := os.Stat(path); statErr != nil {
+		return doctorCheck{Name: "config.toml", Status: doctorOK, Detail: "not present; using defaults"}
+	}
+	return doctorCheck{Name: "config.toml", Status: doctorOK, Detail: path}
+}
+
+// agentBinaryCheck looks up the configured command for tool. The command may
+// be a shell alias or wrapper that only the login shell knows, so a miss is a
+// warning unless a session in this profile actually runs the tool.
+func agentBinaryCheck(tool string, inUse bool, installHint string) doctorCheck {
+	command := session.GetToolCommand(tool)
+	bin := command
+	if fields := strings.Fields(command); len(fields) > 0 {
+		bin = fields[0]
+	}
+	if path, err := exec.LookPath(bin); err == nil {
+		return doctorCheck{Name: tool, Status: doctorOK, Detail: path}
+	}
+	check := doctorCheck{Name: tool, Detail: fmt.Sprintf("%q not found on PATH", bin), Fix: installHint}
+	switch {
+	case inUse:
+		check.Status = doctorFail
+		if bin != tool {
+			check.Fix = fmt.Sprintf("make %q resolvable outside your shell, or set [%s].command to the binary's path", bin, tool)
+		}
+	case bin != tool:
+		check.Status = doctorWarn
+		check.Detail += " (a shell alias is not visible to tmux)"
+		check.Fix = fmt.Sprintf("set [%s].command to a binary or script on PATH", tool)
+	default:
+		check.Status = doctorSkip
+		check.Detail = "not installed; no session in this profile uses it"
+		check.Fix = ""
+	}
+	return check
+}
+
+// claudeConfigDirCheck reports the resolved Claude config dir and where the
+// setting came from (CLAUDE_CONFIG_DIR, profile or global config, default).
+func claudeConfigDirCheck() doctorCheck {
+	dir, source := session.GetClaudeConfigDirSourceForGroup("")
+	detail := fmt.Sprintf("%s (from %s)", dir, source)
+	if _, err := os.Stat(dir); err != nil {
+		fix := "run claude once to create it"
+		switch source {
+		case "env":
+			fix = "unset CLAUDE_CONFIG_DIR or point it at an existing directory"
+		case "profile", "global":
+			fix = "fix config_dir in config.toml or create the directory"
+		}
+		return doctorCheck{Name: "claude config dir", Status: doctorWarn, Detail: detail + " does not exist", Fix: fix}
+	}
+	return doctorCheck{Name: "claude config dir", Status: doctorOK, Detail: detail}
+}
+
+// mcpPoolCheck reports the MCP socket pool: disabled, or how many of its
+// sockets accept connections.
+func mcpPoolCheck(cfg *session.UserConfig, sockets []mcppool.SocketStatus) doctorCheck {
+	if cfg == nil || !cfg.MCPPool.Enabled {
+		return doctorCheck{Name: "mcp pool", Status: doctorSkip, Detail: "disabled"}
+	}
+	var dead []string
+	for _, s := range sockets {
+		if !s.Alive {
+			dead = append(dead, s.Name)
+		}
+	}
+	switch {
+	case len(sockets) == 0:
+		return doctorCheck{
+			Name: "mcp pool", Status: doctorWarn, Detail: "enabled, but no pool sockets exist",
+			Fix: "start the TUI (agent-deck) to launch the pool",
+		}
+	case len(dead) > 0:
+		return doctorCheck{
+			Name: "mcp pool", Status: doctorWarn,
+			Detail: fmt.Sprintf("%d/%d sockets not responding: %s", len(dead), len(sockets), strings.Join(dead, ", ")),
+			Fix:    "restart the TUI; stale sockets are replaced when the pool starts",
+		}
+	}
+	return doctorCheck{Name: "mcp pool", Status: doctorOK, Detail: fmt.Sprintf("%d sockets responding", len(sockets))}
+}
+
+// storageCheck runs SQLite's quick_check on the profile's state database.
+func storageCheck(storage *session.Storage) doctorCheck {
+	db := storage.GetDB()
+	if db == nil {
+		return doctorCheck{Name: "storage", Status: doctorSkip, Detail: "no database open"}
+	}
+	problems, err := db.IntegrityCheck()
+	switch {
+	case err != nil:
+		return doctorCheck{Name: "storage", Status: doctorFail, Detail: err.Error(), Fix: "check permissions on " + storage.Path()}
+	case len(problems) > 0:
+		return doctorCheck{
+			Name: "storage", Status: doctorFail,
+			Detail: fmt.Sprintf("%s: %s", storage.Path(), strings.Join(problems, "; ")),
+			Fix:    fmt.Sprintf("quit agent-deck and restore %s.bak, or `agent-deck export` what still loads", storage.Path()),
+		}
+	}
+	return doctorCheck{Name: "storage", Status: doctorOK, Detail: storage.Path()}
+}
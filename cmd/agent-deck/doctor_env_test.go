@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/mcppool"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+func checkByName(t *testing.T, checks []doctorCheck, name string) doctorCheck {
+	t.Helper()
+	for _, c := range checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("no %q check in %+v", name, checks)
+	return doctorCheck{}
+}
+
+func TestTmuxChecks(t *testing.T) {
+	missing := tmuxChecks(tmux.Capabilities{StatusLeftLength: -1}, "linux")
+	if len(missing) != 1 || missing[0].Status != doctorFail || missing[0].Fix == "" {
+		t.Fatalf("missing tmux = %+v, want a single failing check with a fix", missing)
+	}
+
+	old := tmuxChecks(tmux.Capabilities{Installed: true, Version: "3.1c", PipePane: true, StatusLeftLength: -1}, "linux")
+	if c := checkByName(t, old, "tmux"); c.Status != doctorWarn {
+		t.Errorf("tmux 3.1c version check = %s, want warn", c.Status)
+	}
+	if c := checkByName(t, old, "tmux sync output"); c.Status != doctorWarn {
+		t.Errorf("tmux 3.1c sync check = %s, want warn", c.Status)
+	}
+	if c := checkByName(t, old, "tmux status-left-length"); c.Status != doctorSkip {
+		t.Errorf("no server: status-left-length check = %s, want skip", c.Status)
+	}
+
+	current := tmuxChecks(tmux.Capabilities{Installed: true, Version: "3.5a", ServerRunning: true, StatusLeftLength: 10}, "linux")
+	if c := checkByName(t, current, "tmux"); c.Status != doctorOK {
+		t.Errorf("tmux 3.5a on linux = %s, want ok", c.Status)
+	}
+	if c := checkByName(t, current, "tmux pipe-pane"); c.Status != doctorFail {
+		t.Errorf("no pipe-pane = %s, want fail", c.Status)
+	}
+	if c := checkByName(t, current, "tmux status-left-length"); c.Status != doctorWarn || c.Fix == "" {
+		t.Errorf("status-left-length 10 = %+v, want warn with a fix", c)
+	}
+
+	darwin := tmuxChecks(tmux.Capabilities{Installed: true, Version: "3.5a", PipePane: true, StatusLeftLength: -1}, "darwin")
+	if c := checkByName(t, darwin, "tmux"); c.Status != doctorWarn {
+		t.Errorf("vulnerable tmux on darwin = %s, want warn", c.Status)
+	}
+}
+
+func TestMCPPoolCheck(t *testing.T) {
+	enabled := &session.UserConfig{MCPPool: session.MCPPoolSettings{Enabled: true}}
+
+	if c := mcpPoolCheck(&session.UserConfig{}, nil); c.Status != doctorSkip {
+		t.Errorf("disabled pool = %s, want skip", c.Status)
+	}
+	if c := mcpPoolCheck(enabled, nil); c.Status != doctorWarn {
+		t.Errorf("enabled pool without sockets = %s, want warn", c.Status)
+	}
+	sockets := []mcppool.SocketStatus{{Name: "exa", Alive: true}, {Name: "slack"}}
+	if c := mcpPoolCheck(enabled, sockets); c.Status != doctorWarn || c.Detail != "1/2 sockets not responding: slack" {
+		t.Errorf("stale socket = %+v, want warn naming slack", c)
+	}
+	if c := mcpPoolCheck(enabled, sockets[:1]); c.Status != doctorOK {
+		t.Errorf("healthy pool = %s, want ok", c.Status)
+	}
+}
@@ -23,8 +23,28 @@ func applyCLIYoloOverride(inst *session.Instance, enabled bool) error {
 		if err := inst.SetCodexOptions(opts); err != nil {
 			return err
 		}
+	case "hermes":
+		yolo := true
+		opts := inst.GetHermesOptions()
+		if opts == nil {
+			opts = &session.HermesOptions{}
+		}
+		opts.YoloMode = &yolo
+		if err := inst.SetHermesOptions(opts); err != nil {
+			return err
+		}
 	default:
-		return fmt.Errorf("--yolo only works with Gemini or Codex sessions")
+		return fmt.Errorf("--yolo only works with Gemini, Codex or Hermes sessions")
 	}
 	return nil
 }
+
+// yoloCapableTool reports whether tool has a YOLO mode, i.e. whether a
+// template's yolo or a group's default dangerous mode applies to it.
+func yoloCapableTool(tool string) bool {
+	switch tool {
+	case "gemini", "codex", "hermes":
+		return true
+	}
+	return false
+}
@@ -440,6 +440,10 @@ func handleGroupShow(profile string, args []string) {
 		"max_concurrent": g.MaxConcurrent,
 		"sessions":       sessionCount,
 	}
+	defaults := groupTree.SessionDefaultsForGroup(groupPath)
+	if !defaults.IsZero() {
+		jsonData["session_defaults"] = defaults
+	}
 
 	var b strings.Builder
 	fmt.Fprintf(&b, "Group: %s\n", groupPath)
@@ -447,6 +451,19 @@ func handleGroupShow(profile string, args []string) {
 	fmt.Fprintf(&b, "  Default path:   %s\n", orNone(groupTree.DefaultPathForGroup(groupPath)))
 	fmt.Fprintf(&b, "  Max concurrent: %d\n", g.MaxConcurrent)
 	fmt.Fprintf(&b, "  Sessions:       %d\n", sessionCount)
+	if !defaults.IsZero() {
+		fmt.Fprintf(&b, "  Default tool:   %s\n", orNone(defaults.Tool))
+		fmt.Fprintf(&b, "  Default model:  %s\n", orNone(defaults.Model))
+		fmt.Fprintf(&b, "  Default MCPs:   %s\n", orNone(strings.Join(defaults.MCPs, ", ")))
+		dangerous := "inherit"
+		if defaults.DangerousMode != nil {
+			dangerous = "off"
+			if *defaults.DangerousMode {
+				dangerous = "on"
+			}
+		}
+		fmt.Fprintf(&b, "  Dangerous mode: %s\n", dangerous)
+	}
 
 	if *resolved {
 		// Force a fresh config.toml parse — `group show --resolved` is a
@@ -643,6 +660,16 @@ func handleGroupUpdate(profile string, args []string) {
 	// v1.9.1: -1 sentinel means "flag not set; leave existing value alone".
 	// 0 = unlimited, 1 = serial, N>=2 = bounded cap.
 	maxConcurrent := fs.Int("max-concurrent", -1, "Cap simultaneous running sessions in this group (0=unlimited, 1=serial, N=cap)")
+	// New-session defaults: each is optional and only replaces its own field.
+	defaultTool := fs.String("default-tool", "", "Default tool for new sessions in this group (claude, codex, or a [tools.X] name)")
+	defaultModel := fs.String("default-model", "", "Default model for new sessions in this group")
+	var defaultMCPs []string
+	fs.Func("default-mcp", "MCP attached to new sessions in this group (can specify multiple times)", func(s string) error {
+		defaultMCPs = append(defaultMCPs, s)
+		return nil
+	})
+	defaultDangerous := fs.String("default-dangerous-mode", "", "Dangerous/YOLO mode for new sessions in this group: on, off or inherit")
+	clearSessionDefaults := fs.Bool("clear-session-defaults", false, "Clear the group's new-session defaults (tool, model, MCPs, dangerous mode)")
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
 	quiet := fs.Bool("quiet", false, "Minimal output")
 	quietShort := fs.Bool("q", false, "Minimal output (short)")
@@ -659,6 +686,9 @@ func handleGroupUpdate(profile string, args []string) {
 		fmt.Println("  agent-deck group update mobile --default-path /path/to/repo")
 		fmt.Println("  agent-deck group update mobile --clear-default-path")
 		fmt.Println("  agent-deck group update mobile --max-concurrent 2")
+		fmt.Println("  agent-deck group update mobile --default-tool claude --default-model opus --default-mcp github")
+		fmt.Println("  agent-deck group update mobile --default-dangerous-mode on")
+		fmt.Println("  agent-deck group update mobile --clear-session-defaults")
 	}
 
 	args = reorderGroupArgs(args)
@@ -679,10 +709,39 @@ func handleGroupUpdate(profile string, args []string) {
 	// At least one mutation must be requested.
 	pathFlagSet := *defaultPath != "" || *clearDefaultPath
 	maxFlagSet := *maxConcurrent >= 0
-	if !pathFlagSet && !maxFlagSet {
-		out.Error("specify at least one of --default-path, --clear-default-path, or --max-concurrent", ErrCodeInvalidOperation)
+	defaultsFlagSet := *defaultTool != "" || *defaultModel != "" || len(defaultMCPs) > 0 || *defaultDangerous != "" || *clearSessionDefaults
+	if !pathFlagSet && !maxFlagSet && !defaultsFlagSet {
+		out.Error("specify at least one of --default-path, --clear-default-path, --max-concurrent, --default-tool, --default-model, --default-mcp, --default-dangerous-mode, or --clear-session-defaults", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	var dangerousMode *bool
+	switch strings.ToLower(strings.TrimSpace(*defaultDangerous)) {
+	case "", "inherit":
+	case "on":
+		v := true
+		dangerousMode = &v
+	case "off":
+		v := false
+		dangerousMode = &v
+	default:
+		out.Error(fmt.Sprintf("invalid --default-dangerous-mode %q (want on, off or inherit)", *defaultDangerous), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	// Validate defaults the way `add` validates -c and --mcp, so a typo fails
+	// here instead of on every later `add -g`.
+	if tool := strings.TrimSpace(*defaultTool); tool != "" && tool != "shell" && detectTool(tool) == "shell" {
+		out.Error(fmt.Sprintf("unknown tool %q for --default-tool (want a built-in tool or a [tools.<name>] entry)", tool), ErrCodeInvalidOperation)
 		os.Exit(1)
 	}
+	if len(defaultMCPs) > 0 {
+		availableMCPs := session.GetAvailableMCPs()
+		for _, mcpName := range defaultMCPs {
+			if _, ok := availableMCPs[mcpName]; !ok {
+				out.Error(fmt.Sprintf("MCP '%s' not found in config.toml", mcpName), ErrCodeNotFound)
+				os.Exit(1)
+			}
+		}
+	}
 	if *defaultPath != "" && *clearDefaultPath {
 		out.Error("--default-path and --clear-default-path are mutually exclusive", ErrCodeInvalidOperation)
 		os.Exit(1)
@@ -730,6 +789,28 @@ func handleGroupUpdate(profile string, args []string) {
 		}
 	}
 
+	if defaultsFlagSet {
+		if g := groupTree.Groups[groupPath]; g != nil {
+			d := g.SessionDefaults
+			if *clearSessionDefaults {
+				d = session.GroupSessionDefaults{}
+			}
+			if *defaultTool != "" {
+				d.Tool = strings.TrimSpace(*defaultTool)
+			}
+			if *defaultModel != "" {
+				d.Model = strings.TrimSpace(*defaultModel)
+			}
+			if len(defaultMCPs) > 0 {
+				d.MCPs = defaultMCPs
+			}
+			if *defaultDangerous != "" {
+				d.DangerousMode = dangerousMode
+			}
+			groupTree.SetSessionDefaultsForGroup(groupPath, d)
+		}
+	}
+
 	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeNotFound)
 		os.Exit(1)
@@ -752,10 +833,11 @@ func handleGroupUpdate(profile string, args []string) {
 	}
 
 	out.Success(fmt.Sprintf("Updated group: %s", groupPath), map[string]interface{}{
-		"success":        true,
-		"path":           groupPath,
-		"default_path":   currentDefaultPath,
-		"max_concurrent": currentMax,
+		"success":          true,
+		"path":             groupPath,
+		"default_path":     currentDefaultPath,
+		"max_concurrent":   currentMax,
+		"session_defaults": groupTree.Groups[groupPath].SessionDefaults,
 	})
 }
 
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/asheshgoplani/agent-deck/internal/agentpaths"
+	"github.com/asheshgoplani/agent-deck/internal/git"
 	"github.com/asheshgoplani/agent-deck/internal/logging"
 	"github.com/asheshgoplani/agent-deck/internal/session"
 )
@@ -41,6 +42,9 @@ type hookPayload struct {
 	// every tool call. Empty when the agent doesn't send a cwd (older Claude
 	// Code) — treated as "present" so behavior is unchanged.
 	Cwd string `json:"cwd"`
+	// TranscriptPath is sent by Claude Code (not Cursor/Gemini) on every
+	// event; postToolUseStatus uses it to tell Claude's PostToolUse apart.
+	TranscriptPath string `json:"transcript_path"`
 	// StopHookActive is Claude Code's flag: true when this Stop is a
 	// continuation induced by a previous Stop-hook block. Issue #1225 uses it
 	// to bound consecutive inbox-drain blocks so the conductor cannot loop
@@ -132,6 +136,24 @@ func mapEventToStatus(event string) string {
 	}
 }
 
+// postToolUseStatus decides what a PostToolUse event means given the status
+// it follows. After a permission wait it means the user approved and the
+// agent is working again ("running"). Otherwise Claude's PostToolUse is a
+// mid-turn tool boundary and changes nothing, while other tools keep their
+// mapped status (Cursor's postToolUse → waiting).
+func postToolUseStatus(payload hookPayload, prev *session.HookStatus, mapped string) string {
+	if prev != nil && prev.Status == "waiting" {
+		switch normalizeHookEventKey(prev.Event) {
+		case "permissionrequest", "notification":
+			return "running"
+		}
+	}
+	if payload.TranscriptPath != "" {
+		return ""
+	}
+	return mapped
+}
+
 // handleHookHandler processes a Claude Code hook event.
 // Reads JSON from stdin, maps the event to a status, and writes a status file.
 // Always exits 0 to avoid blocking Claude Code.
@@ -184,6 +206,10 @@ func handleHookHandler() {
 		}
 	}
 
+	if normalizeHookEventKey(payload.HookEventName) == "posttooluse" {
+		status = postToolUseStatus(payload, session.ReadHookStatusFile(instanceID), status)
+	}
+
 	if status == "" {
 		// Unknown or unhandled event, nothing to write
 		return
@@ -216,6 +242,13 @@ func handleHookHandler() {
 	// existing agent-deck adjective-noun title).
 	applyClaudeTitleSync(instanceID, sessionID)
 
+	// Snapshot the checkout before the agent acts on a new prompt, so its
+	// turn can be rolled back from the TUI. The prompt hook is async, and a
+	// snapshot lands well before the agent's first edit.
+	if isPromptSubmitEvent(payload.HookEventName) {
+		snapshotBeforePrompt(instanceID, payload.Cwd)
+	}
+
 	// Write cost event if this hook contains usage data
 	logCostDebug("hook event=%s instance=%s status=%s", payload.HookEventName, instanceID, status)
 	writeCostEvent(instanceID, data)
@@ -252,6 +285,30 @@ func handleHookHandler() {
 	}
 }
 
+func isPromptSubmitEvent(event string) bool {
+	switch normalizeHookEventKey(event) {
+	case "userpromptsubmit", "beforesubmitprompt", "beforeagent":
+		return true
+	}
+	return false
+}
+
+// snapshotBeforePrompt takes the automatic pre-prompt snapshot of the
+// checkout in cwd, when enabled in [snapshots]. Directories outside git
+// are skipped quietly.
+func snapshotBeforePrompt(instanceID, cwd string) {
+	if cwd == "" {
+		return
+	}
+	config, _ := session.LoadUserConfig()
+	if config == nil || !config.Snapshots.GetAuto() {
+		return
+	}
+	if _, _, err := git.CreateSnapshot(cwd, instanceID, "before prompt", config.Snapshots.GetKeep()); err != nil {
+		hookHandlerLog.Debug("pre_prompt_snapshot_skipped", slog.String("instance", instanceID), slog.String("error", err.Error()))
+	}
+}
+
 // parentIsDSP reports whether the parent process (typically the claude binary)
 // was launched with --dangerously-skip-permissions. Returns true if the
 // AGENTDECK_DSP_MODE env var is explicitly set, or, on Linux/WSL, if the
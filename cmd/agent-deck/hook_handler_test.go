@@ -298,3 +298,34 @@ func TestParentIsDSP_NoOverrideNoFlag(t *testing.T) {
 		t.Skipf("parentIsDSP() returned true unexpectedly; the test runner's parent appears to have --dangerously-skip-permissions in its cmdline. Skipping the negative assertion.")
 	}
 }
+
+func TestPostToolUseStatus(t *testing.T) {
+	claude := hookPayload{HookEventName: "PostToolUse", TranscriptPath: "/tmp/t.jsonl"}
+	cursor := hookPayload{HookEventName: "postToolUse"}
+	permWait := &session.HookStatus{Status: "waiting", Event: "PermissionRequest"}
+	promptWait := &session.HookStatus{Status: "waiting", Event: "Notification"}
+	stopped := &session.HookStatus{Status: "waiting", Event: "Stop"}
+	running := &session.HookStatus{Status: "running", Event: "UserPromptSubmit"}
+
+	tests := []struct {
+		name    string
+		payload hookPayload
+		prev    *session.HookStatus
+		want    string
+	}{
+		{"claude after approved permission", claude, permWait, "running"},
+		{"claude after permission notification", claude, promptWait, "running"},
+		{"claude mid-turn tool call", claude, running, ""},
+		{"claude with no prior status", claude, nil, ""},
+		{"claude after stop", claude, stopped, ""},
+		{"cursor keeps mapped status", cursor, running, "waiting"},
+		{"cursor after permission wait", cursor, permWait, "running"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := postToolUseStatus(tt.payload, tt.prev, mapEventToStatus(tt.payload.HookEventName)); got != tt.want {
+				t.Errorf("postToolUseStatus = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
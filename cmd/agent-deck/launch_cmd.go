@@ -299,6 +299,9 @@ func handleLaunch(profile string, args []string) {
 		out.Error(err.Error(), ErrCodeNotFound)
 		os.Exit(1)
 	}
+	// Launch writes only its own row (InsertSessionAndVerify) and may then
+	// wait on the agent for a while; don't hold up other processes' saves.
+	storage.Unlock()
 
 	// Resolve parent session if specified.
 	// Issue #972: when no explicit -g is passed, prefer the cwd-derived
@@ -399,6 +402,9 @@ func handleLaunch(profile string, args []string) {
 		newInstance.Command = sessionCommandResolved
 	}
 
+	ruleCfg, _ := session.LoadUserConfig()
+	applyGroupRule(newInstance, ruleCfg, explicitGroupProvided)
+
 	// Apply --channel flags (claude only — channels is a Claude Code CLI flag).
 	if len(channelFlags) > 0 {
 		if newInstance.Tool != "claude" {
@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"math"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -257,6 +256,27 @@ func main() {
 		case "rename", "mv":
 			handleRename(profile, args[1:])
 			return
+		case "archive":
+			handleArchive(profile, args[1:])
+			return
+		case "unarchive":
+			handleUnarchive(profile, args[1:])
+			return
+		case "trash":
+			handleTrash(profile, args[1:])
+			return
+		case "doctor":
+			handleDoctor(profile, args[1:])
+			return
+		case "export":
+			handleExport(profile, args[1:])
+			return
+		case "import":
+			handleImport(profile, args[1:])
+			return
+		case "report":
+			handleReport(profile, args[1:])
+			return
 		case "status":
 			handleStatus(profile, args[1:])
 			return
@@ -359,6 +379,15 @@ func main() {
 		case "inbox":
 			handleInbox(args[1:])
 			return
+		case "dnd":
+			handleDND(args[1:])
+			return
+		case "popup":
+			handlePopup(profile, args[1:])
+			return
+		case "schedule":
+			handleSchedule(args[1:])
+			return
 		case "feedback":
 			handleFeedback(args[1:])
 			return
@@ -371,11 +400,32 @@ func main() {
 		}
 	}
 
+	// --safe-mode: boot a minimal TUI for users whose deck crashes at
+	// startup. Everything optional (reviver, update prompt, cost watcher,
+	// maintenance worker, watchers, MCP pool, notifications) is skipped so
+	// they can still get in and fix config/storage. The web server is
+	// skipped too: `agent-deck web --safe-mode` runs the TUI alone.
+	var safeModeEnabled bool
+	safeModeEnabled, args = extractSafeModeFlag(args)
+	if safeModeEnabled {
+		ui.SetSafeMode(true)
+		if webEnabled {
+			if webHeadless {
+				fmt.Fprintln(os.Stderr, "Error: --safe-mode disables the web server, so it cannot be combined with --no-tui.")
+				os.Exit(1)
+			}
+			fmt.Fprintln(os.Stderr, "Note: --safe-mode skips the web server; starting the TUI only.")
+			webEnabled = false
+		}
+	}
+
 	// Startup reviver scan (v1.7.8, REPORT-D). Fire-and-forget — rebuilds
 	// control pipes for any instance whose tmux server is alive but whose
 	// pipe got killed by e.g. an SSH logout scope cleanup. Runs in the
 	// background so it never blocks TUI boot. See .planning/v178-ssh-reviver/PLAN.md.
-	go reviveOnStartup(profile)
+	if !safeModeEnabled {
+		go reviveOnStartup(profile)
+	}
 
 	// Block TUI launch inside a managed session to prevent infinite nesting.
 	// CLI commands (add, session start/stop, mcp attach, etc.) still work fine.
@@ -420,13 +470,12 @@ func main() {
 	ui.SetVersion(Version)
 
 	// Initialize theme from config (resolves "system" to actual dark/light)
-	theme := session.ResolveTheme()
-	ui.InitTheme(theme)
+	ui.InitTheme(session.ResolveThemeName())
 
 	// Check for updates and prompt user before launching TUI. Headless web
 	// mode (--no-tui) skips this — it's an interactive prompt that would
 	// hang a non-TTY process.
-	if !webHeadless {
+	if !webHeadless && !safeModeEnabled {
 		if promptForUpdate() {
 			// Update was performed, exit so user can restart with new version
 			return
@@ -503,6 +552,19 @@ func main() {
 		os.Exit(0)
 	}()
 
+	// Profile switcher (TUI): registered before every other deferred cleanup
+	// so it runs last, once the terminal, web server and logs are released.
+	var switchToProfile string
+	defer func() {
+		if switchToProfile == "" {
+			return
+		}
+		if err := relaunchOnProfile(switchToProfile, webEnabled, webArgs); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to switch to profile %q: %v\n", switchToProfile, err)
+			os.Exit(1)
+		}
+	}()
+
 	// Set up structured logging (JSONL format with rotation)
 	// When AGENTDECK_DEBUG is set, logs go to the XDG cache debug.log.
 	// When not set, logs are discarded to avoid TUI interference
@@ -688,7 +750,7 @@ func main() {
 			}
 		}
 		pricer := costs.NewPricer(pricerCfg)
-		if cacheDir != "" {
+		if cacheDir != "" && !safeModeEnabled {
 			_ = pricer.LoadCache()
 
 			// Start daily price fetcher
@@ -701,16 +763,7 @@ func main() {
 		// Set up budget checker
 		var budgetCfg costs.BudgetConfig
 		if userCfg != nil {
-			bc := userCfg.Costs.Budgets
-			budgetCfg.DailyLimit = int64(math.Round(bc.DailyLimit * 1_000_000))
-			budgetCfg.WeeklyLimit = int64(math.Round(bc.WeeklyLimit * 1_000_000))
-			budgetCfg.MonthlyLimit = int64(math.Round(bc.MonthlyLimit * 1_000_000))
-			if len(bc.Groups) > 0 {
-				budgetCfg.GroupLimits = make(map[string]int64)
-				for name, g := range bc.Groups {
-					budgetCfg.GroupLimits[name] = int64(math.Round(g.DailyLimit * 1_000_000))
-				}
-			}
+			budgetCfg = budgetConfigFromSettings(userCfg.Costs.Budgets)
 		}
 		budgetChecker := costs.NewBudgetChecker(budgetCfg, costStore)
 
@@ -722,7 +775,7 @@ func main() {
 		// Start cost event watcher (for Claude hook events)
 		costEventsDir := getCostEventsDir()
 		costWatcher, watchErr := costs.NewCostEventWatcher(costEventsDir)
-		if watchErr == nil {
+		if watchErr == nil && !safeModeEnabled {
 			go costWatcher.Start()
 			defer costWatcher.Stop()
 
@@ -746,7 +799,7 @@ func main() {
 		}
 
 		// Run retention cleanup on startup
-		if userCfg != nil {
+		if userCfg != nil && !safeModeEnabled {
 			retDays := userCfg.Costs.GetRetentionDays()
 			if retDays > 0 {
 				_, _ = costStore.PurgeOlderThan(retDays)
@@ -851,14 +904,17 @@ func main() {
 	// Start maintenance worker (background goroutine, respects config toggle)
 	maintenanceCtx, maintenanceCancel := context.WithCancel(context.Background())
 	defer maintenanceCancel()
-	session.StartMaintenanceWorker(maintenanceCtx, func(result session.MaintenanceResult) {
-		p.Send(ui.MaintenanceCompleteMsg{Result: result})
-	})
+	if !safeModeEnabled {
+		session.StartMaintenanceWorker(maintenanceCtx, func(result session.MaintenanceResult) {
+			p.Send(ui.MaintenanceCompleteMsg{Result: result})
+		})
+	}
 
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
+	switchToProfile = homeModel.PendingProfileSwitch()
 }
 
 // globalFlagSubcommands lists every token that main()'s dispatch switch treats
@@ -869,6 +925,7 @@ func main() {
 var globalFlagSubcommands = map[string]bool{
 	"add": true, "list": true, "ls": true, "remove": true, "rm": true,
 	"rename": true, "mv": true, "status": true, "profile": true, "update": true,
+	"archive": true, "unarchive": true, "trash": true, "doctor": true, "export": true, "import": true, "report": true,
 	"session": true, "mcp": true, "plugin": true, "skill": true, "mcp-proxy": true,
 	"group": true, "try": true, "launch": true, "conductor": true,
 	"telegram-doctor": true, "watcher": true, "openclaw": true, "oc": true,
@@ -963,6 +1020,25 @@ func extractGroupFlag(args []string) (string, []string) {
 	return group, remaining
 }
 
+// extractSafeModeFlag extracts --safe-mode from args, returning whether it was
+// set and the remaining args. Like -g/--group it only applies to the TUI path.
+func extractSafeModeFlag(args []string) (bool, []string) {
+	enabled := false
+	remaining := make([]string, 0, len(args))
+	for _, arg := range args {
+		switch {
+		case arg == "--safe-mode":
+			enabled = true
+		case strings.HasPrefix(arg, "--safe-mode="):
+			v := strings.TrimPrefix(arg, "--safe-mode=")
+			enabled = v == "true" || v == "1"
+		default:
+			remaining = append(remaining, arg)
+		}
+	}
+	return enabled, remaining
+}
+
 // extractSelectFlag extracts --select <session-id-or-title> from args (#709).
 // Unlike -g / --group, --select does NOT scope the TUI to one group — it only
 // positions the cursor on a matching session while keeping every group visible.
@@ -1031,6 +1107,8 @@ func reorderArgsForFlagParsing(args []string) []string {
 		"ssh":            true,
 		"remote-path":    true,
 		"tmux-socket":    true,
+		"template":       true,
+		"tag":            true,
 	}
 
 	var flags []string
@@ -1217,6 +1295,19 @@ func handleAdd(profile string, args []string) {
 		mcpFlags = append(mcpFlags, s)
 		return nil
 	})
+	var mcpSetFlags []string
+	fs.Func("mcp-set", "Attach every MCP in [mcp_sets.<name>] (can specify multiple times)", func(s string) error {
+		mcpSetFlags = append(mcpSetFlags, s)
+		return nil
+	})
+
+	// Tag flag - can be specified multiple times. Normalized and validated
+	// before the session is created (see session.NormalizeTags).
+	var tagFlags []string
+	fs.Func("tag", "Tag the session (can specify multiple times)", func(s string) error {
+		tagFlags = append(tagFlags, s)
+		return nil
+	})
 
 	// Plugin channel flag - can be specified multiple times; requires -c claude.
 	// Persisted on Instance.Channels and emitted as --channels <csv> on every
@@ -1263,7 +1354,7 @@ func handleAdd(profile string, args []string) {
 	// Resume session flag
 	resumeSession := fs.String("resume-session", "", "Claude session ID to resume (skips new session creation)")
 	modelID := fs.String("model", "", "Model ID/version to use for this session (claude, codex, gemini, opencode)")
-	yoloMode := fs.Bool("yolo", false, "Enable YOLO mode for Gemini or Codex sessions")
+	yoloMode := fs.Bool("yolo", false, "Enable YOLO mode for Gemini, Codex or Hermes sessions")
 	geminiYoloMode := fs.Bool("gemini-yolo", false, "Enable YOLO mode (alias for --yolo)")
 
 	// Socket isolation (v1.7.50+, issue #687). Overrides the installation-
@@ -1278,6 +1369,12 @@ func handleAdd(profile string, args []string) {
 	// Empty = fall through to conductor/group/env/profile/global/default.
 	account := fs.String("account", "", "Named account slot (resolves via [profiles.<account>.claude].config_dir; #924)")
 
+	// Session template from [templates.<name>]: fills every flag the user did
+	// not pass (tool, group, path, model, MCPs, worktree policy, sandbox,
+	// claude flags). Explicit flags always win.
+	templateName := fs.String("template", "", "Session template from [templates.<name>] in config.toml (explicit flags override it)")
+	initialPrompt := fs.String("initial-prompt", "", "First instruction, sent once the agent is ready after the session starts")
+
 	fs.Usage = func() {
 		fmt.Println("Usage: agent-deck add [path] [options]")
 		fmt.Println()
@@ -1299,6 +1396,7 @@ func handleAdd(profile string, args []string) {
 		fmt.Println("  agent-deck -p work add               # Add to 'work' profile")
 		fmt.Println("  agent-deck add -t \"Sub-task\" --parent \"Main Project\"  # Create sub-session")
 		fmt.Println("  agent-deck add -t \"Research\" -c claude --mcp memory --mcp sequential-thinking /tmp/x")
+		fmt.Println("  agent-deck add -c claude --mcp-set web-dev .  # Attach every MCP in [mcp_sets] web-dev")
 		fmt.Println("  agent-deck add -t \"Bot\" -c claude --channel plugin:telegram@user/repo .  # subscribe to plugin channel")
 		fmt.Println("  agent-deck add -c opencode --wrapper \"nvim +'terminal {command}' +'startinsert'\" .")
 		fmt.Println("  agent-deck add -c \"codex --dangerously-bypass-approvals-and-sandbox\" .")
@@ -1306,6 +1404,9 @@ func handleAdd(profile string, args []string) {
 		fmt.Println("  agent-deck add -c claude -g work .   # -c is shorthand for --cmd")
 		fmt.Println("  agent-deck add -g ard --no-parent -c claude .")
 		fmt.Println("  agent-deck add --quick -c claude .   # Quick session; TUI shows Claude's live task description")
+		fmt.Println("  agent-deck add --template review .   # Apply [templates.review] from config.toml")
+		fmt.Println("  agent-deck add -g work .             # Tool/model/MCPs default to the group's (see group update)")
+		fmt.Println("  agent-deck add -c claude --initial-prompt \"Fix the failing tests\" .")
 		fmt.Println()
 		fmt.Println("Worktree Examples:")
 		fmt.Println("  agent-deck add -w feature/login .    # Create worktree for existing branch")
@@ -1329,6 +1430,69 @@ func handleAdd(profile string, args []string) {
 	// Path argument is optional; if omitted with -g/--group, we'll try group default_path.
 	// Fix: sanitize input to remove surrounding quotes that cause issues.
 	rawPathArg := strings.Trim(fs.Arg(0), "'\"")
+
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	// Sets count as explicit MCPs, so they replace template and group MCPs
+	// the same way --mcp does.
+	mcpFlags, mcpSetErr := expandAddMCPSets(mcpFlags, mcpSetFlags)
+	if mcpSetErr != nil {
+		fmt.Printf("Error: %v\n", mcpSetErr)
+		os.Exit(1)
+	}
+
+	var template session.SessionTemplate
+	if name := strings.TrimSpace(*templateName); name != "" {
+		t, err := lookupAddTemplate(name)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		template = t
+		if *command == "" && *commandShort == "" {
+			*command = template.Tool
+		}
+		if *group == "" && *groupShort == "" {
+			*group = template.Group
+		}
+		if rawPathArg == "" {
+			rawPathArg = template.Path
+		}
+		if *modelID == "" {
+			*modelID = template.Model
+		}
+		if len(mcpFlags) == 0 {
+			mcpFlags = template.MCPs
+		}
+		if len(extraArgFlags) == 0 {
+			for _, tok := range template.ExtraArgs {
+				if err := session.ValidateClaudeExtraArgToken(tok); err != nil {
+					fmt.Printf("Error: template %q extra_args: %v\n", name, err)
+					os.Exit(1)
+				}
+			}
+			extraArgFlags = template.ExtraArgs
+		}
+		if !setFlags["sandbox"] && template.Sandbox != nil {
+			*sandbox = *template.Sandbox
+		}
+		if template.GetWorktree() == "on" && *worktreeBranch == "" && *worktreeBranchLong == "" {
+			fmt.Printf("Error: template %q creates sessions in a worktree; pass -w <branch>\n", name)
+			os.Exit(1)
+		}
+		if !setFlags["yolo"] && !setFlags["gemini-yolo"] && template.Yolo != nil {
+			tool := detectTool(firstNonEmpty(*command, *commandShort))
+			*yoloMode = *template.Yolo && yoloCapableTool(tool)
+		}
+	}
+
+	tags, err := session.NormalizeTags(tagFlags)
+	if err != nil {
+		fmt.Printf("Error: --tag: %v\n", err)
+		os.Exit(1)
+	}
+
 	explicitPathProvided := rawPathArg != ""
 	path := ""
 
@@ -1351,15 +1515,6 @@ func handleAdd(profile string, args []string) {
 		os.Exit(1)
 	}
 
-	// Validate --resume-session requires Claude
-	if *resumeSession != "" {
-		tool := firstNonEmpty(sessionCommandTool, detectTool(sessionCommandInput))
-		if tool != "claude" {
-			fmt.Println("Error: --resume-session only works with Claude sessions (-c claude)")
-			os.Exit(1)
-		}
-	}
-
 	// Load existing sessions with profile
 	storage, err := session.NewStorageWithProfile(profile)
 	if err != nil {
@@ -1419,6 +1574,25 @@ func handleAdd(profile string, args []string) {
 		sessionGroup = resolveGroupPathForAdd(groupTree, sessionGroup)
 	}
 
+	// Group session defaults (`group update --default-tool/...`) fill only
+	// what neither explicit flags nor the template set.
+	if sessionGroup != "" {
+		commandBefore := sessionCommandInput
+		applyGroupSessionDefaults(groupTree.SessionDefaultsForGroup(sessionGroup), &sessionCommandInput, modelID, &mcpFlags, &template, yoloMode, setFlags["yolo"] || setFlags["gemini-yolo"])
+		if sessionCommandInput != commandBefore {
+			sessionCommandTool, sessionCommandResolved, sessionWrapperResolved, sessionCommandNote = resolveSessionCommand(sessionCommandInput, *wrapper)
+		}
+	}
+
+	// Validate --resume-session requires Claude
+	if *resumeSession != "" {
+		tool := firstNonEmpty(sessionCommandTool, detectTool(sessionCommandInput))
+		if tool != "claude" {
+			fmt.Println("Error: --resume-session only works with Claude sessions (-c claude)")
+			os.Exit(1)
+		}
+	}
+
 	if explicitPathProvided {
 		path, err = resolveAddPath(rawPathArg)
 		if err != nil {
@@ -1611,6 +1785,7 @@ func handleAdd(profile string, args []string) {
 	if *titleLock || *noTitleSync {
 		newInstance.TitleLocked = true
 	}
+	newInstance.Tags = tags
 
 	// Set command if provided
 	if sessionCommandInput != "" {
@@ -1618,6 +1793,9 @@ func handleAdd(profile string, args []string) {
 		newInstance.Command = sessionCommandResolved
 	}
 
+	ruleCfg, _ := session.LoadUserConfig()
+	applyGroupRule(newInstance, ruleCfg, explicitGroupProvided)
+
 	// Apply --channel flags (claude only — channels is a Claude Code CLI flag).
 	if len(channelFlags) > 0 {
 		if newInstance.Tool != "claude" {
@@ -1668,6 +1846,17 @@ func handleAdd(profile string, args []string) {
 		newInstance.Account = trimmed
 	}
 
+	// Template claude flags (dangerous_mode, auto_mode, ...) seed the
+	// per-session options; the --model override below layers on top.
+	if template.HasClaudeOverrides() && newInstance.Tool == "claude" {
+		userConfig, _ := session.LoadUserConfig()
+		opts := session.NewClaudeOptions(userConfig)
+		template.ApplyClaudeOptions(opts)
+		if err := newInstance.SetClaudeOptions(opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to apply template options: %v\n", err)
+		}
+	}
+
 	// Apply per-session model override after command/tool resolution so the
 	// tool-specific option field is populated correctly.
 	selectedModelID := strings.TrimSpace(*modelID)
@@ -1718,6 +1907,8 @@ func handleAdd(profile string, args []string) {
 		}
 	}
 
+	newInstance.InitialPrompt = strings.TrimSpace(*initialPrompt)
+
 	if err := applyCLIYoloOverride(newInstance, *yoloMode || *geminiYoloMode); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
@@ -1784,6 +1975,12 @@ func handleAdd(profile string, args []string) {
 	if len(mcpFlags) > 0 {
 		humanLines = append(humanLines, fmt.Sprintf("  MCPs:    %s", strings.Join(mcpFlags, ", ")))
 	}
+	if len(tags) > 0 {
+		humanLines = append(humanLines, fmt.Sprintf("  Tags:    %s", strings.Join(tags, ", ")))
+	}
+	if newInstance.InitialPrompt != "" {
+		humanLines = append(humanLines, "  Prompt:  sent once the agent is ready after start")
+	}
 	if parentInstance != nil {
 		humanLines = append(humanLines, fmt.Sprintf("  Parent:  %s (%s)", parentInstance.Title, parentInstance.ID[:8]))
 	}
@@ -1833,6 +2030,12 @@ func handleAdd(profile string, args []string) {
 	if len(mcpFlags) > 0 {
 		jsonData["mcps"] = mcpFlags
 	}
+	if len(tags) > 0 {
+		jsonData["tags"] = tags
+	}
+	if newInstance.InitialPrompt != "" {
+		jsonData["initial_prompt"] = newInstance.InitialPrompt
+	}
 	if parentInstance != nil {
 		jsonData["parent_id"] = parentInstance.ID
 		jsonData["parent_title"] = parentInstance.Title
@@ -1888,6 +2091,11 @@ func handleList(profile string, args []string) {
 	fs := flag.NewFlagSet("list", flag.ExitOnError)
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
 	allProfiles := fs.Bool("all", false, "List sessions from all profiles")
+	var tagFlags []string
+	fs.Func("tag", "Only list sessions carrying this tag (can specify multiple times; all must match)", func(s string) error {
+		tagFlags = append(tagFlags, s)
+		return nil
+	})
 
 	fs.Usage = func() {
 		fmt.Println("Usage: agent-deck list [options]")
@@ -1901,14 +2109,21 @@ func handleList(profile string, args []string) {
 		fmt.Println("  agent-deck list                    # List from default profile")
 		fmt.Println("  agent-deck -p work list            # List from 'work' profile")
 		fmt.Println("  agent-deck list --all              # List from all profiles")
+		fmt.Println("  agent-deck list --tag urgent       # Only sessions tagged 'urgent'")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
 		os.Exit(1)
 	}
 
+	tagFilter, err := session.NormalizeTags(tagFlags)
+	if err != nil {
+		fmt.Printf("Error: --tag: %v\n", err)
+		os.Exit(1)
+	}
+
 	if *allProfiles {
-		handleListAllProfiles(*jsonOutput)
+		handleListAllProfiles(*jsonOutput, tagFilter)
 		return
 	}
 
@@ -1923,8 +2138,13 @@ func handleList(profile string, args []string) {
 		fmt.Printf("Error: failed to load sessions: %v\n", err)
 		os.Exit(1)
 	}
+	instances = filterInstancesByTags(instances, tagFilter)
 
 	if len(instances) == 0 {
+		if len(tagFilter) > 0 {
+			fmt.Printf("No sessions tagged %s in profile '%s'.\n", strings.Join(tagFilter, ", "), storage.Profile())
+			return
+		}
 		fmt.Printf("No sessions found in profile '%s'.\n", storage.Profile())
 		return
 	}
@@ -1932,25 +2152,27 @@ func handleList(profile string, args []string) {
 	if *jsonOutput {
 		// JSON output for scripting
 		type sessionJSON struct {
-			ID            string    `json:"id"`
-			Title         string    `json:"title"`
-			Path          string    `json:"path"`
-			Group         string    `json:"group"`
-			Tool          string    `json:"tool"`
-			Command       string    `json:"command,omitempty"`
-			ModelID       string    `json:"model_id,omitempty"`
-			Model         string    `json:"model,omitempty"`
-			ModelVersion  string    `json:"model_version,omitempty"`
-			Status        string    `json:"status"`
-			Substate      string    `json:"substate,omitempty"` // Honest Status v2: additive refinement
-			TmuxSession   string    `json:"tmux_session,omitempty"`
-			Profile       string    `json:"profile"`
-			CreatedAt     time.Time `json:"created_at"`
-			SSHHost       string    `json:"ssh_host,omitempty"`
-			SSHRemotePath string    `json:"ssh_remote_path,omitempty"`
-			Channels      []string  `json:"channels,omitempty"`
-			ExtraArgs     []string  `json:"extra_args,omitempty"`
-			Color         string    `json:"color,omitempty"` // issue #391
+			ID            string     `json:"id"`
+			Title         string     `json:"title"`
+			Path          string     `json:"path"`
+			Group         string     `json:"group"`
+			Tool          string     `json:"tool"`
+			Command       string     `json:"command,omitempty"`
+			ModelID       string     `json:"model_id,omitempty"`
+			Model         string     `json:"model,omitempty"`
+			ModelVersion  string     `json:"model_version,omitempty"`
+			Status        string     `json:"status"`
+			Substate      string     `json:"substate,omitempty"` // Honest Status v2: additive refinement
+			TmuxSession   string     `json:"tmux_session,omitempty"`
+			Profile       string     `json:"profile"`
+			CreatedAt     time.Time  `json:"created_at"`
+			SSHHost       string     `json:"ssh_host,omitempty"`
+			SSHRemotePath string     `json:"ssh_remote_path,omitempty"`
+			Channels      []string   `json:"channels,omitempty"`
+			ExtraArgs     []string   `json:"extra_args,omitempty"`
+			Color         string     `json:"color,omitempty"` // issue #391
+			Tags          []string   `json:"tags,omitempty"`
+			ArchivedAt    *time.Time `json:"archived_at,omitempty"`
 		}
 		// Warm tmux pane-title cache + load hook statuses so the CLI
 		// reports the same Status the TUI and /api/menu do (issue #610).
@@ -1974,6 +2196,11 @@ func handleList(profile string, args []string) {
 				Channels:      inst.Channels,
 				ExtraArgs:     inst.ExtraArgs,
 				Color:         inst.Color,
+				Tags:          inst.Tags,
+			}
+			if inst.IsArchived() {
+				at := inst.ArchivedAt
+				sj.ArchivedAt = &at
 			}
 			if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil {
 				sj.TmuxSession = tmuxSess.Name
@@ -2016,7 +2243,7 @@ func handleList(profile string, args []string) {
 }
 
 // handleListAllProfiles lists sessions from all profiles
-func handleListAllProfiles(jsonOutput bool) {
+func handleListAllProfiles(jsonOutput bool, tagFilter []string) {
 	profiles, err := session.ListProfiles()
 	if err != nil {
 		fmt.Printf("Error: failed to list profiles: %v\n", err)
@@ -2040,6 +2267,7 @@ func handleListAllProfiles(jsonOutput bool) {
 			CreatedAt     time.Time `json:"created_at"`
 			SSHHost       string    `json:"ssh_host,omitempty"`
 			SSHRemotePath string    `json:"ssh_remote_path,omitempty"`
+			Tags          []string  `json:"tags,omitempty"`
 		}
 		var allSessions []sessionJSON
 
@@ -2052,7 +2280,7 @@ func handleListAllProfiles(jsonOutput bool) {
 			if err != nil {
 				continue
 			}
-			for _, inst := range instances {
+			for _, inst := range filterInstancesByTags(instances, tagFilter) {
 				allSessions = append(allSessions, sessionJSON{
 					ID:            inst.ID,
 					Title:         inst.Title,
@@ -2064,6 +2292,7 @@ func handleListAllProfiles(jsonOutput bool) {
 					CreatedAt:     inst.CreatedAt,
 					SSHHost:       inst.SSHHost,
 					SSHRemotePath: inst.SSHRemotePath,
+					Tags:          inst.Tags,
 				})
 			}
 		}
@@ -2088,6 +2317,7 @@ func handleListAllProfiles(jsonOutput bool) {
 		if err != nil {
 			continue
 		}
+		instances = filterInstancesByTags(instances, tagFilter)
 
 		if len(instances) == 0 {
 			continue
@@ -2168,6 +2398,12 @@ func handleRemove(profile string, args []string) {
 	removedID := inst.ID
 	removedTitle := inst.Title
 
+	// Keep the row (and its conversation IDs) in the trash before removing it;
+	// `agent-deck trash restore` brings it back. Never blocks the remove.
+	if err := storage.TrashInstance(inst); err != nil && !*jsonOutput {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
 	// Always attempt to kill the tmux session, even if Exists() returns false.
 	// The saved status may be stale (e.g., "error" in DB but tmux session still alive).
 	// KillAndWait is safe to call on non-existent sessions (returns error which we handle).
@@ -2285,7 +2521,7 @@ func handleRename(profile string, args []string) {
 		os.Exit(1)
 	}
 
-	storage, instances, groups, err := loadSessionData(profile)
+	storage, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeInvalidOperation)
 		os.Exit(1)
@@ -2321,8 +2557,10 @@ func handleRename(profile string, args []string) {
 		os.Exit(1)
 	}
 
-	groupTree := session.NewGroupTreeWithGroups(instances, groups)
-	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+	// Targeted write: a rename touches one row, so it must not rewrite the
+	// whole table from this process's snapshot (and clobber a concurrent
+	// TUI's edits to other sessions).
+	if err := storage.WriteTitle(inst.ID, inst.Title, inst.TitleLocked); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
 		os.Exit(1)
 	}
@@ -3083,6 +3321,8 @@ func printHelp() {
 	fmt.Println("  -p, --profile <name>   Use specific profile (default: 'default')")
 	fmt.Println("  -g, --group <name>     Launch TUI scoped to a specific group")
 	fmt.Println("  --select <id|title>    Launch TUI with cursor on a specific session (all groups stay visible)")
+	fmt.Println("  --safe-mode            Launch TUI with watchers, MCP pool, search, web, notifications and")
+	fmt.Println("                         background workers disabled (recover from startup crashes)")
 	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  (none)           Start the TUI")
@@ -3092,6 +3332,12 @@ func printHelp() {
 	fmt.Println("  list, ls         List all sessions")
 	fmt.Println("  remove, rm       Remove a session")
 	fmt.Println("  rename, mv       Rename a session")
+	fmt.Println("  archive          Stop a session and archive it (or --idle sweep)")
+	fmt.Println("  unarchive        Restore an archived session")
+	fmt.Println("  trash            List, restore, or purge deleted sessions")
+	fmt.Println("  export           Export session definitions to JSON/YAML")
+	fmt.Println("  import <file>    Recreate sessions from an export file")
+	fmt.Println("  report           Usage/cost report from session transcripts")
 	fmt.Println("  status           Show session status summary")
 	fmt.Println("  session          Manage session lifecycle")
 	fmt.Println("  mcp              Manage MCP servers")
@@ -3105,7 +3351,11 @@ func printHelp() {
 	fmt.Println("  web              Start TUI with web UI server running alongside")
 	fmt.Println("  remote           Manage remote agent-deck instances")
 	fmt.Println("  conductor        Manage conductor meta-agent orchestration")
+	fmt.Println("  doctor           Check tmux, agents, config, MCP pool and storage; repair tmux drift")
 	fmt.Println("  telegram-doctor  Audit channel-owning sessions for telegram drops (#1138)")
+	fmt.Println("  dnd              Toggle deck-wide do-not-disturb (on/off/status/digest)")
+	fmt.Println("  popup            Session quick-switcher in a tmux popup (bind it to a tmux key)")
+	fmt.Println("  schedule         Start sessions on a cron schedule (list/add/remove/run)")
 	fmt.Println("  profile          Manage profiles")
 	fmt.Println("  update           Check for and install updates")
 	fmt.Println("  debug-dump       Dump debug ring buffer to file for sharing")
@@ -478,3 +478,29 @@ func TestEnsureTmuxInPath(t *testing.T) {
 		}
 	})
 }
+
+func TestExtractSafeModeFlag(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		wantEnabled   bool
+		wantRemaining []string
+	}{
+		{"no flag", []string{"-g", "work"}, false, []string{"-g", "work"}},
+		{"bare flag", []string{"--safe-mode"}, true, []string{}},
+		{"with group", []string{"--safe-mode", "-g", "work"}, true, []string{"-g", "work"}},
+		{"explicit true", []string{"--safe-mode=true"}, true, []string{}},
+		{"explicit false", []string{"--safe-mode=false"}, false, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enabled, remaining := extractSafeModeFlag(tt.args)
+			if enabled != tt.wantEnabled {
+				t.Errorf("enabled = %v, want %v", enabled, tt.wantEnabled)
+			}
+			if !slices.Equal(remaining, tt.wantRemaining) {
+				t.Errorf("remaining = %#v, want %#v", remaining, tt.wantRemaining)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/asheshgoplani/agent-deck/internal/mcppool"
 	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
@@ -29,6 +30,8 @@ func handleMCP(profile string, args []string) {
 		handleMCPDetach(profile, args[1:])
 	case "server":
 		handleMCPServer(args[1:])
+	case "status":
+		handleMCPStatus(args[1:])
 	case "help", "-h", "--help":
 		printMCPHelp()
 	default:
@@ -50,6 +53,7 @@ func printMCPHelp() {
 	fmt.Println("  attach <id> <mcp>   Attach an MCP to a session")
 	fmt.Println("  detach <id> <mcp>   Detach an MCP from a session")
 	fmt.Println("  server <cmd>        Manage HTTP MCP servers (start/stop/status)")
+	fmt.Println("  status [mcp]        Show socket pool proxies (uptime, clients, errors)")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  agent-deck mcp list                        # List available MCPs")
@@ -58,6 +62,7 @@ func printMCPHelp() {
 	fmt.Println("  agent-deck mcp attach my-project exa       # Attach exa to my-project (local)")
 	fmt.Println("  agent-deck mcp attach my-project exa --global     # Attach globally")
 	fmt.Println("  agent-deck mcp detach my-project exa       # Detach exa from my-project")
+	fmt.Println("  agent-deck mcp status                      # Show socket pool proxies")
 	fmt.Println("  agent-deck mcp server status               # Show HTTP server status")
 	fmt.Println("  agent-deck mcp server start slack          # Start HTTP server for slack MCP")
 }
@@ -463,7 +468,7 @@ func handleMCPAttach(profile string, args []string) {
 	// Restart if requested
 	restarted := false
 	if *restart && inst.SupportsMCPAgentRestart() {
-		if err := inst.Restart(); err != nil {
+		if err := inst.RestartWithReason(session.RestartReasonMCPChange); err != nil {
 			// Don't fail the whole operation, just warn
 			if !*jsonOutput && !quietMode {
 				fmt.Fprintf(os.Stderr, "Warning: failed to restart session: %v\n", err)
@@ -619,7 +624,7 @@ func handleMCPDetach(profile string, args []string) {
 	// Restart if requested
 	restarted := false
 	if *restart && inst.SupportsMCPAgentRestart() {
-		if err := inst.Restart(); err != nil {
+		if err := inst.RestartWithReason(session.RestartReasonMCPChange); err != nil {
 			// Don't fail the whole operation, just warn
 			if !*jsonOutput && !quietMode {
 				fmt.Fprintf(os.Stderr, "Warning: failed to restart session: %v\n", err)
@@ -972,3 +977,114 @@ func handleMCPServerStatus(args []string) {
 
 	fmt.Printf("\nTotal: %d HTTP MCPs\n", len(servers))
 }
+
+// handleMCPStatus shows the socket pool proxies as reported by the agent-deck
+// instance that owns the pool. The pool lives in the TUI process, so this
+// reads the snapshot it writes next to the sockets.
+func handleMCPStatus(args []string) {
+	fs := flag.NewFlagSet("mcp status", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck mcp status [mcp-name]")
+		fmt.Println()
+		fmt.Println("Show pooled MCP proxies: status, uptime, connected sessions,")
+		fmt.Println("restart count and recent errors. Restart or stop a proxy from")
+		fmt.Println("the TUI MCP pool dashboard.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	quietMode := *quiet || *quietShort
+	out := NewCLIOutput(*jsonOutput, quietMode)
+	mcpName := fs.Arg(0)
+
+	snap, err := mcppool.ReadStatus()
+	if err != nil && !os.IsNotExist(err) {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	live := snap.Live()
+
+	proxies := []mcppool.ProxyInfo{}
+	if live {
+		for _, p := range snap.Proxies {
+			if mcpName == "" || p.Name == mcpName {
+				proxies = append(proxies, p)
+			}
+		}
+		if mcpName != "" && len(proxies) == 0 {
+			out.Error(fmt.Sprintf("MCP '%s' is not in the pool", mcpName), ErrCodeNotFound)
+			os.Exit(2)
+		}
+	}
+
+	if *jsonOutput {
+		result := map[string]interface{}{
+			"pool_running": live,
+			"proxies":      proxies,
+		}
+		if live {
+			result["pid"] = snap.PID
+			result["updated_at"] = snap.UpdatedAt
+		}
+		out.Print("", result)
+		return
+	}
+
+	if !live {
+		if !quietMode {
+			fmt.Println("No agent-deck instance is running the MCP pool.")
+			fmt.Println("The pool starts with the TUI when [mcp_pool] enabled = true in config.toml.")
+		}
+		if mcpName != "" {
+			os.Exit(2)
+		}
+		return
+	}
+
+	if quietMode {
+		for _, p := range proxies {
+			fmt.Printf("%s\t%s\n", p.Name, p.Status)
+		}
+		return
+	}
+
+	fmt.Printf("MCP Pool (agent-deck pid %d):\n", snap.PID)
+	fmt.Println()
+	fmt.Printf("%-20s %-18s %-9s %-8s %-9s %s\n", "NAME", "STATUS", "UPTIME", "CLIENTS", "RESTARTS", "LAST ERROR")
+	fmt.Println(strings.Repeat("-", 90))
+
+	for _, p := range proxies {
+		status := p.Status
+		if p.External {
+			status += " (ext)"
+		}
+		uptime := "-"
+		if !p.RunningSince.IsZero() {
+			uptime = formatDuration(time.Since(p.RunningSince))
+		}
+		lastErr := "-"
+		if n := len(p.RecentErrors); n > 0 {
+			e := p.RecentErrors[n-1]
+			lastErr = fmt.Sprintf("%s ago: %s", formatDuration(time.Since(e.At)), e.Message)
+		}
+		fmt.Printf("%-20s %-18s %-9s %-8d %-9d %s\n",
+			truncateString(p.Name, 20),
+			status,
+			uptime,
+			p.Clients,
+			p.Restarts,
+			truncateString(lastErr, 40),
+		)
+	}
+
+	fmt.Printf("\nTotal: %d pooled MCPs\n", len(proxies))
+}
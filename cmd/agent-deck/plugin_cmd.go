@@ -117,12 +117,11 @@ func handlePluginAttached(profile string, args []string) {
 	identifier, jsonOutput, quiet := parsePluginAttachedFlags(args)
 	out := NewCLIOutput(jsonOutput, quiet)
 
-	storage, instances, _, err := loadSessionData(profile)
+	instances, err := readSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
 		os.Exit(1)
 	}
-	_ = storage
 
 	inst := resolvePluginSession(out, instances, identifier)
 	if inst == nil {
@@ -240,7 +239,7 @@ func pluginAttachOrDetach(profile string, args []string, op string) {
 			return
 		}
 		fmt.Println("Restarting session to apply enabledPlugins...")
-		if err := inst.Restart(); err != nil {
+		if err := inst.RestartWithReason(session.RestartReasonConfigChange); err != nil {
 			out.Error(fmt.Sprintf("restart failed: %s", err.Error()), ErrCodeNotFound)
 			os.Exit(1)
 		}
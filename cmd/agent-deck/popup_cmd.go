@@ -0,0 +1,144 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+	"github.com/asheshgoplani/agent-deck/internal/ui"
+)
+
+// handlePopup implements `agent-deck popup`: a session quick-switcher meant
+// to be bound to a tmux key. Without --inline it opens itself in a tmux
+// display-popup over the current client; with --inline (what runs inside the
+// popup) it shows the picker in the current terminal and switch-clients to
+// the chosen session.
+func handlePopup(profile string, args []string) {
+	fs := flag.NewFlagSet("popup", flag.ExitOnError)
+	inline := fs.Bool("inline", false, "Show the picker in this terminal instead of opening a tmux popup")
+	width := fs.String("width", "70%", "Popup width (cells or percentage)")
+	height := fs.String("height", "60%", "Popup height (cells or percentage)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck popup [options]")
+		fmt.Println()
+		fmt.Println("Pick a session in a tmux popup and switch the current client to it.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Bind it to a key in ~/.tmux.conf:")
+		fmt.Println("  bind-key g display-popup -E -w 70% -h 60% \"agent-deck popup --inline\"")
+		fmt.Println("  bind-key g run-shell \"agent-deck popup\"      # same, popup size from the flags")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	if os.Getenv("TMUX") == "" {
+		fmt.Fprintln(os.Stderr, "Error: agent-deck popup must run inside tmux")
+		os.Exit(1)
+	}
+
+	if !*inline {
+		exe, err := os.Executable()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: locate agent-deck binary: %v\n", err)
+			os.Exit(1)
+		}
+		cmd := tmux.Exec("", popupTmuxArgs(exe, profile, *width, *height)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: tmux display-popup: %s\n", strings.TrimSpace(string(out)+" "+err.Error()))
+			os.Exit(1)
+		}
+		return
+	}
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	instances, _, err := storage.LoadWithGroups()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load sessions: %v\n", err)
+		os.Exit(1)
+	}
+
+	// One tmux call up front; Exists() then reads the cache.
+	tmux.RefreshSessionCache()
+	var live []*session.Instance
+	for _, inst := range instances {
+		if ts := inst.GetTmuxSession(); ts != nil && ts.Exists() {
+			live = append(live, inst)
+		}
+	}
+
+	ui.InitTheme(session.ResolveThemeName())
+	chosen, err := ui.RunPopupPicker(live, switchClientTo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if chosen != nil {
+		// Best-effort: lets a running dashboard acknowledge the session, as
+		// the notification-bar keys do.
+		_ = tmux.WriteAckSignal(chosen.ID)
+	}
+}
+
+// popupTmuxArgs builds the display-popup argv that re-runs this binary with
+// --inline inside a popup closing when the picker exits.
+func popupTmuxArgs(exe, profile, width, height string) []string {
+	inner := []string{shellescape.Quote(exe)}
+	if profile != "" {
+		inner = append(inner, "-p", shellescape.Quote(profile))
+	}
+	inner = append(inner, "popup", "--inline")
+	return []string{"display-popup", "-E", "-w", width, "-h", height, "-T", " agent-deck ", strings.Join(inner, " ")}
+}
+
+// switchClientTo switch-clients the tmux client that opened the popup to
+// inst. That only works when inst lives on the client's own tmux server, so
+// a session on another socket ([tmux].socket_name) gets an explanation
+// instead of tmux's bare "can't find session".
+func switchClientTo(inst *session.Instance) error {
+	ts := inst.GetTmuxSession()
+	if ts == nil || ts.Name == "" {
+		return fmt.Errorf("%s has no tmux session", inst.Title)
+	}
+	if current := clientSocketName(os.Getenv("TMUX")); current != socketLabel(ts.SocketName) {
+		return fmt.Errorf("%s runs on tmux socket %q, this client is on %q: use agent-deck session attach",
+			inst.Title, socketLabel(ts.SocketName), current)
+	}
+	if out, err := tmux.Exec("", "switch-client", "-t", ts.Name).CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("switch-client: %s", msg)
+		}
+		return fmt.Errorf("switch-client: %w", err)
+	}
+	return nil
+}
+
+// clientSocketName returns the socket name in a $TMUX value
+// ("/tmp/tmux-501/default,1234,0" -> "default").
+func clientSocketName(tmuxEnv string) string {
+	path, _, _ := strings.Cut(tmuxEnv, ",")
+	return filepath.Base(path)
+}
+
+// socketLabel maps a session's -L socket name to the name tmux gives its
+// socket file; "" is tmux's default server.
+func socketLabel(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return name
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPopupTmuxArgs(t *testing.T) {
+	got := popupTmuxArgs("/usr/local/bin/agent-deck", "work team", "80%", "20")
+	want := []string{"display-popup", "-E", "-w", "80%", "-h", "20", "-T", " agent-deck ",
+		"/usr/local/bin/agent-deck -p 'work team' popup --inline"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("popup args:\n got=%q\nwant=%q", got, want)
+	}
+	if got := popupTmuxArgs("/bin/agent-deck", "", "70%", "60%"); got[len(got)-1] != "/bin/agent-deck popup --inline" {
+		t.Fatalf("default profile should not pass -p, got %q", got[len(got)-1])
+	}
+}
+
+func TestClientSocketName(t *testing.T) {
+	for env, want := range map[string]string{
+		"/tmp/tmux-501/default,1234,0":   "default",
+		"/tmp/tmux-0/agentdeck,99,3":     "agentdeck",
+		"/private/tmp/tmux-501/work,1,0": "work",
+	} {
+		if got := clientSocketName(env); got != want {
+			t.Errorf("clientSocketName(%q) = %q, want %q", env, got, want)
+		}
+	}
+	if socketLabel("") != "default" || socketLabel("agentdeck") != "agentdeck" {
+		t.Fatal("socketLabel should map the empty socket name to tmux's default server")
+	}
+}
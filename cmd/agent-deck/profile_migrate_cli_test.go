@@ -94,6 +94,31 @@ func TestSessionMoveToProfile_Basic(t *testing.T) {
 	}
 }
 
+// TestSessionMoveProfile_Positional — `session move-profile <id> <profile>`
+// is the positional spelling of `session move <id> --to-profile`.
+func TestSessionMoveProfile_Positional(t *testing.T) {
+	if testing.Short() {
+		t.Skip("subprocess CLI test skipped in short mode")
+	}
+	home := t.TempDir()
+	bootstrapProfile(t, home, "src")
+	bootstrapProfile(t, home, "dst")
+	id := addInProfile(t, home, "src", "positional-migrate", filepath.Join(home, "proj"))
+
+	stdout, stderr, code := runAgentDeck(t, home,
+		"-p", "src", "session", "move-profile", id, "dst", "--json",
+	)
+	if code != 0 {
+		t.Fatalf("move-profile failed: code=%d\nstdout: %s\nstderr: %s", code, stdout, stderr)
+	}
+	if strings.Contains(listJSONForProfile(t, home, "src"), id) {
+		t.Errorf("src still has session %s", id)
+	}
+	if !strings.Contains(listJSONForProfile(t, home, "dst"), id) {
+		t.Errorf("dst missing session %s", id)
+	}
+}
+
 func TestSessionMoveToProfile_RefusesMissingTargetProfile(t *testing.T) {
 	if testing.Short() {
 		t.Skip("subprocess CLI test skipped in short mode")
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// profileRelaunchArgs builds the argv for relaunching the TUI on profile.
+// A `web` launch keeps serving (the old server has shut down by then, so
+// the port is free); TUI-only flags such as -g and --select are dropped
+// because the group or session they name belongs to the old profile.
+func profileRelaunchArgs(argv0, profile string, webEnabled bool, webArgs []string) []string {
+	args := []string{argv0, "-p", profile}
+	if webEnabled {
+		args = append(args, "web")
+		args = append(args, webArgs...)
+	}
+	return args
+}
+
+// profileRelaunchEnv returns env with AGENTDECK_PROFILE pointed at profile.
+// main exports the current profile there, and a stale value would leak into
+// code paths that read the environment rather than the -p flag.
+func profileRelaunchEnv(env []string, profile string) []string {
+	out := make([]string, 0, len(env)+1)
+	for _, kv := range env {
+		if !strings.HasPrefix(kv, "AGENTDECK_PROFILE=") {
+			out = append(out, kv)
+		}
+	}
+	return append(out, "AGENTDECK_PROFILE="+profile)
+}
+
+// relaunchOnProfile replaces this process with a fresh agent-deck on
+// profile, in the same terminal. It is the tail of the TUI profile switcher:
+// the process is single-profile (one Storage, one state.db, one primary
+// election), so switching re-executes rather than swapping state in place.
+// It only returns on failure.
+func relaunchOnProfile(profile string, webEnabled bool, webArgs []string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locate agent-deck binary: %w", err)
+	}
+	argv := profileRelaunchArgs(os.Args[0], profile, webEnabled, webArgs)
+	return syscall.Exec(exe, argv, profileRelaunchEnv(os.Environ(), profile))
+}
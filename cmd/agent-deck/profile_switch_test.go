@@ -0,0 +1,25 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfileRelaunchArgs(t *testing.T) {
+	if got := profileRelaunchArgs("agent-deck", "work", false, nil); !reflect.DeepEqual(got, []string{"agent-deck", "-p", "work"}) {
+		t.Errorf("TUI relaunch args = %v", got)
+	}
+	got := profileRelaunchArgs("agent-deck", "work", true, []string{"--listen", ":9000"})
+	want := []string{"agent-deck", "-p", "work", "web", "--listen", ":9000"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("web relaunch args = %v, want %v", got, want)
+	}
+}
+
+func TestProfileRelaunchEnv(t *testing.T) {
+	got := profileRelaunchEnv([]string{"HOME=/h", "AGENTDECK_PROFILE=default", "TERM=xterm"}, "work")
+	want := []string{"HOME=/h", "TERM=xterm", "AGENTDECK_PROFILE=work"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("env = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/costs"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleReport aggregates token usage, cost and active time from the
+// Claude/Gemini/OpenCode transcripts of every session (archived included),
+// per group or per session, for a trailing window.
+func handleReport(profile string, args []string) {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	since := fs.String("since", "7d", "Window to report on: a duration (72h), days (7d), weeks (2w), or a date (2026-01-31)")
+	group := fs.String("group", "", "Only include sessions in this group (and its subgroups)")
+	format := fs.String("format", "table", "Output format: table, json, or csv")
+	by := fs.String("by", "group", "Row per group or per session")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck report [--since 7d] [--group X] [--by group|session] [--format table|json|csv]")
+		fmt.Println()
+		fmt.Println("Summarize AI usage from session transcripts: sessions, turns, tokens,")
+		fmt.Println("estimated cost and active time. Claude, Gemini and OpenCode sessions")
+		fmt.Println("are included; other tools have no transcript to read.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck report")
+		fmt.Println("  agent-deck report --since 30d --group clients/acme --format csv > acme.csv")
+		fmt.Println("  agent-deck report --since 2026-01-01 --by session --format json")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	now := time.Now()
+	start, err := parseReportSince(*since, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if *by != "group" && *by != "session" {
+		fmt.Fprintf(os.Stderr, "Error: --by must be group or session, got %q\n", *by)
+		os.Exit(1)
+	}
+
+	instances, err := readSessionData(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	pricer := newPricerFromConfig()
+	price := func(model string, in, out, cacheRead, cacheWrite int64) float64 {
+		return float64(pricer.ComputeCost(model, in, out, cacheRead, cacheWrite)) / 1_000_000
+	}
+	report := session.BuildUsageReport(instances, session.UsageReportOptions{
+		Since: start,
+		Group: *group,
+		By:    *by,
+	}, now, session.SessionUsageSince, price)
+
+	if err := writeUsageReport(os.Stdout, report, *format); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseReportSince resolves --since to the window start: a Go duration,
+// "Nd"/"Nw", or an absolute YYYY-MM-DD date (local midnight).
+func parseReportSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+	if n, ok := strings.CutSuffix(value, "w"); ok {
+		if weeks, err := strconv.Atoi(n); err == nil && weeks > 0 {
+			return now.AddDate(0, 0, -7*weeks), nil
+		}
+	}
+	d, err := session.ParseArchiveIdleAfter(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid --since %q (use 72h, 7d, 2w, or YYYY-MM-DD)", value)
+	}
+	return now.Add(-d), nil
+}
+
+func writeUsageReport(w io.Writer, report session.UsageReport, format string) error {
+	keyHeader := strings.ToUpper(report.By)
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case "csv":
+		cw := csv.NewWriter(w)
+		_ = cw.Write([]string{report.By, "sessions", "turns", "input_tokens", "output_tokens", "cache_read_tokens", "cache_write_tokens", "cost_usd", "active_hours"})
+		for _, r := range append(report.Rows, report.Total) {
+			_ = cw.Write([]string{
+				r.Key,
+				strconv.Itoa(r.Sessions),
+				strconv.Itoa(r.Turns),
+				strconv.FormatInt(r.InputTokens, 10),
+				strconv.FormatInt(r.OutputTokens, 10),
+				strconv.FormatInt(r.CacheReadTokens, 10),
+				strconv.FormatInt(r.CacheWriteTokens, 10),
+				strconv.FormatFloat(r.CostUSD, 'f', 4, 64),
+				strconv.FormatFloat(r.ActiveTime.Hours(), 'f', 2, 64),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	case "table", "":
+		fmt.Fprintf(w, "Usage %s → %s\n\n", report.Since.Format("2006-01-02 15:04"), report.Until.Format("2006-01-02 15:04"))
+		if len(report.Rows) == 0 {
+			fmt.Fprintln(w, "No Claude/Gemini/OpenCode activity in this window.")
+			return nil
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "%s\tSESSIONS\tTURNS\tTOKENS\tCOST\tACTIVE\n", keyHeader)
+		for _, r := range append(report.Rows, report.Total) {
+			key := r.Key
+			if key == "" {
+				key = "(ungrouped)"
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t$%.2f\t%s\n", key, r.Sessions, r.Turns,
+				costs.FormatTokens(r.TotalTokens()), r.CostUSD, formatDuration(r.ActiveTime))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown --format %q (use table, json, or csv)", format)
+	}
+}
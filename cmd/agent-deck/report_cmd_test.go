@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestParseReportSince(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"7d", now.AddDate(0, 0, -7)},
+		{"2w", now.AddDate(0, 0, -14)},
+		{"72h", now.Add(-72 * time.Hour)},
+		{"2026-01-01", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseReportSince(tt.in, now)
+		if err != nil {
+			t.Errorf("parseReportSince(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseReportSince(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "soon", "0d", "-3w"} {
+		if _, err := parseReportSince(bad, now); err == nil {
+			t.Errorf("parseReportSince(%q) should fail", bad)
+		}
+	}
+}
+
+func TestWriteUsageReport(t *testing.T) {
+	report := session.UsageReport{
+		By: "group",
+		Rows: []session.UsageReportRow{
+			{Key: "acme", Sessions: 2, Turns: 5, InputTokens: 12000, OutputTokens: 500, CostUSD: 1.25, ActiveTime: 90 * time.Minute},
+			{Key: "", Sessions: 1, Turns: 1, InputTokens: 10, CostUSD: 0.01},
+		},
+		Total: session.UsageReportRow{Key: "TOTAL", Sessions: 3, Turns: 6, InputTokens: 12010, OutputTokens: 500, CostUSD: 1.26},
+	}
+
+	var buf bytes.Buffer
+	if err := writeUsageReport(&buf, report, "table"); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"GROUP", "acme", "(ungrouped)", "$1.25", "TOTAL", "12.5K"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := writeUsageReport(&buf, report, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "group,sessions,") || !strings.HasPrefix(lines[1], "acme,2,5,12000,500,") {
+		t.Errorf("csv output:\n%s", buf.String())
+	}
+
+	if err := writeUsageReport(&buf, report, "xml"); err == nil {
+		t.Error("unknown format should fail")
+	}
+}
@@ -124,6 +124,9 @@ func handleSessionRevive(profile string, args []string) {
 		out.Error(err.Error(), ErrCodeNotFound)
 		os.Exit(1)
 	}
+	// Revive persists only the revived rows and may take a while to
+	// restart them; don't hold up other processes' saves.
+	storage.Unlock()
 
 	rev := session.NewReviver()
 
@@ -163,7 +166,7 @@ func handleSessionRevive(profile string, args []string) {
 // before TUI boot. Silently logs failures; never surfaces errors to the user
 // — this is a best-effort recovery, not a gate.
 func reviveOnStartup(profile string) {
-	_, instances, _, err := loadSessionData(profile)
+	instances, err := readSessionData(profile)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleSchedule dispatches `agent-deck schedule` subcommands. Schedules are
+// deck-wide; each one names the profile it launches into.
+func handleSchedule(args []string) {
+	if len(args) == 0 {
+		handleScheduleList(nil)
+		return
+	}
+	switch args[0] {
+	case "list", "ls":
+		handleScheduleList(args[1:])
+	case "add":
+		handleScheduleAdd(args[1:])
+	case "remove", "rm":
+		handleScheduleRemove(args[1:])
+	case "run":
+		handleScheduleRun(args[1:])
+	case "help", "-h", "--help":
+		printScheduleHelp()
+	default:
+		fmt.Printf("Unknown schedule command: %s\n", args[0])
+		fmt.Println()
+		printScheduleHelp()
+		os.Exit(1)
+	}
+}
+
+func printScheduleHelp() {
+	fmt.Println("Usage: agent-deck schedule <command> [options]")
+	fmt.Println()
+	fmt.Println("Start sessions on a cron schedule. The TUI of the schedule's profile")
+	fmt.Println("fires them, so it must be running. Schedules can also be defined as")
+	fmt.Println("[schedules.<name>] in config.toml.")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  list              List schedules with their next run")
+	fmt.Println("  add <name>        Add (or replace) a schedule")
+	fmt.Println("  remove <name>     Remove a schedule added with 'schedule add'")
+	fmt.Println("  run <name>        Launch a schedule's session now")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  agent-deck schedule add nightly --cron \"0 7 * * 1-5\" --path ~/src/api \\")
+	fmt.Println("      --prompt \"Summarize yesterday's commits\"")
+	fmt.Println("  agent-deck schedule run nightly")
+}
+
+func handleScheduleList(args []string) {
+	fs := flag.NewFlagSet("schedule list", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+
+	schedules, lastRun, err := session.LoadSchedules()
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	now := time.Now()
+	type scheduleJSON struct {
+		Name    string     `json:"name"`
+		Source  string     `json:"source"`
+		Cron    string     `json:"cron"`
+		Tool    string     `json:"tool"`
+		Path    string     `json:"path"`
+		Prompt  string     `json:"prompt,omitempty"`
+		Group   string     `json:"group,omitempty"`
+		Profile string     `json:"profile"`
+		Enabled bool       `json:"enabled"`
+		NextRun *time.Time `json:"next_run,omitempty"`
+		LastRun *time.Time `json:"last_run,omitempty"`
+		Error   string     `json:"error,omitempty"`
+	}
+	rows := make([]scheduleJSON, 0, len(schedules))
+	for _, s := range schedules {
+		row := scheduleJSON{
+			Name:    s.Name,
+			Source:  s.Source,
+			Cron:    s.Cron,
+			Tool:    s.ToolName(),
+			Path:    s.Path,
+			Prompt:  s.Prompt,
+			Group:   s.Group,
+			Profile: s.ProfileName(),
+			Enabled: s.IsEnabled(),
+		}
+		if last, ok := lastRun[s.Name]; ok {
+			row.LastRun = &last
+		}
+		if cron, err := session.ParseCron(s.Cron); err != nil {
+			row.Error = err.Error()
+		} else if s.IsEnabled() {
+			if next := cron.Next(now); !next.IsZero() {
+				row.NextRun = &next
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if *jsonOutput {
+		out.Print("", rows)
+		return
+	}
+	if len(rows) == 0 {
+		fmt.Println("No schedules. Add one with 'agent-deck schedule add' or [schedules.<name>] in config.toml.")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCRON\tTOOL\tPROFILE\tNEXT RUN\tLAST RUN\tSOURCE")
+	for _, r := range rows {
+		next := "-"
+		switch {
+		case r.Error != "":
+			next = "invalid cron"
+		case !r.Enabled:
+			next = "disabled"
+		case r.NextRun != nil:
+			next = r.NextRun.Format("2006-01-02 15:04")
+		}
+		last := "-"
+		if r.LastRun != nil {
+			last = r.LastRun.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.Name, r.Cron, r.Tool, r.Profile, next, last, r.Source)
+	}
+	_ = w.Flush()
+}
+
+func handleScheduleAdd(args []string) {
+	fs := flag.NewFlagSet("schedule add", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	cron := fs.String("cron", "", "Cron expression (\"0 7 * * 1-5\") or @hourly/@daily/@weekly/@monthly")
+	tool := fs.String("tool", "", "Tool to start (default: default_tool, then claude)")
+	toolShort := fs.String("c", "", "Tool to start (short)")
+	path := fs.String("path", "", "Project directory (default: current directory)")
+	prompt := fs.String("prompt", "", "First message to send")
+	promptShort := fs.String("m", "", "First message to send (short)")
+	group := fs.String("group", "", "Group for the launched sessions")
+	groupShort := fs.String("g", "", "Group (short)")
+	title := fs.String("title", "", "Session title prefix (default: the schedule name)")
+	profile := fs.String("profile", "", "Profile to launch into (default: default)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck schedule add <name> --cron <expr> [options]")
+		fmt.Println()
+		fmt.Println("Add a scheduled launch, replacing a schedule of the same name.")
+		fmt.Println("Each run starts a new session titled \"<title> <date> <time>\".")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 1 || *cron == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	dir := *path
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	def := session.ScheduleDef{
+		Cron:    strings.TrimSpace(*cron),
+		Tool:    firstNonEmpty(*tool, *toolShort),
+		Path:    session.ExpandPath(dir),
+		Prompt:  firstNonEmpty(*prompt, *promptShort),
+		Group:   firstNonEmpty(*group, *groupShort),
+		Title:   *title,
+		Profile: *profile,
+	}
+	name := fs.Arg(0)
+	if err := session.AddSchedule(name, def); err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	cronSched, _ := session.ParseCron(def.Cron)
+	next := cronSched.Next(time.Now())
+	data := map[string]interface{}{
+		"success": true,
+		"name":    name,
+		"cron":    def.Cron,
+		"path":    def.Path,
+	}
+	msg := fmt.Sprintf("Added schedule '%s'", name)
+	if !next.IsZero() {
+		data["next_run"] = next
+		msg += fmt.Sprintf(" (next run %s)", next.Format("2006-01-02 15:04"))
+	}
+	out.Success(msg, data)
+}
+
+func handleScheduleRemove(args []string) {
+	fs := flag.NewFlagSet("schedule remove", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 1 {
+		fmt.Println("Usage: agent-deck schedule remove <name>")
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	name := fs.Arg(0)
+	if err := session.RemoveSchedule(name); err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	out.Success(fmt.Sprintf("Removed schedule '%s'", name), map[string]interface{}{
+		"success": true,
+		"name":    name,
+	})
+}
+
+// handleScheduleRun launches a schedule's session immediately, into the
+// schedule's profile. It counts as a run, so the TUI won't fire the same
+// slot again right after.
+func handleScheduleRun(args []string) {
+	fs := flag.NewFlagSet("schedule run", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 1 {
+		fmt.Println("Usage: agent-deck schedule run <name>")
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	sched, err := session.FindSchedule(fs.Arg(0))
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	storage, instances, groupsData, err := loadSessionData(sched.ProfileName())
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	now := time.Now()
+	inst := sched.NewInstance(now)
+	instances = append(instances, inst)
+	groupTree := session.NewGroupTreeWithGroups(instances, groupsData)
+	if inst.GroupPath != "" {
+		groupTree.CreateGroupPath(inst.GroupPath)
+	}
+	if err := storage.InsertSessionAndVerify(inst, groupTree); err != nil {
+		out.Error(fmt.Sprintf("failed to save session: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	if sched.Prompt != "" {
+		err = inst.StartWithMessage(sched.Prompt)
+	} else {
+		err = inst.Start()
+	}
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to start session: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+		out.Error(fmt.Sprintf("failed to save session: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	_ = session.MarkScheduleRun(sched.Name, now)
+
+	out.Success(fmt.Sprintf("Started '%s' from schedule '%s'", inst.Title, sched.Name), map[string]interface{}{
+		"success":       true,
+		"schedule":      sched.Name,
+		"session_id":    inst.ID,
+		"session_title": inst.Title,
+		"profile":       sched.ProfileName(),
+	})
+}
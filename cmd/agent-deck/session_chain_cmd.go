@@ -0,0 +1,148 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleSessionChain makes a session wait for another one: when <after>
+// finishes a turn, the TUI sends <session> the prompt (starting it first if
+// it isn't running). The chain fires once.
+func handleSessionChain(profile string, args []string) {
+	fs := flag.NewFlagSet("session chain", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	prompt := fs.String("prompt", "", "Message to send when <after> finishes (empty: just start the session)")
+	promptShort := fs.String("m", "", "Message to send (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck session chain <session> <after> [--prompt \"...\"]")
+		fmt.Println()
+		fmt.Println("Run <session> when <after> finishes: once <after> goes from running to")
+		fmt.Println("waiting or idle, the prompt is sent to <session>, which is started first")
+		fmt.Println("if it isn't running. The chain fires once, then is removed.")
+		fmt.Println("Chains are fired by the TUI, so it must be running.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck session chain executor planner -m \"Implement the plan in PLAN.md\"")
+		fmt.Println("  agent-deck session chain reviewer executor")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	text := *prompt
+	if text == "" {
+		text = *promptShort
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	storage, instances, groupsData, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	inst, errMsg, errCode := ResolveSession(fs.Arg(0), instances)
+	if inst == nil {
+		out.Error(errMsg, errCode)
+		os.Exit(2)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+	upstream, errMsg, errCode := ResolveSession(fs.Arg(1), instances)
+	if upstream == nil {
+		out.Error(errMsg, errCode)
+		os.Exit(2)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+	if err := session.ValidateChain(inst, upstream, instances); err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	inst.Chain = session.NewSessionChain(upstream.ID, text)
+
+	groupTree := session.NewGroupTreeWithGroups(instances, groupsData)
+	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	out.Success(fmt.Sprintf("'%s' will run when '%s' finishes", inst.Title, upstream.Title), map[string]interface{}{
+		"success":       true,
+		"session_id":    inst.ID,
+		"session_title": inst.Title,
+		"after_id":      upstream.ID,
+		"after_title":   upstream.Title,
+		"prompt":        inst.Chain.Prompt,
+	})
+}
+
+// handleSessionUnchain removes a pending chain.
+func handleSessionUnchain(profile string, args []string) {
+	fs := flag.NewFlagSet("session unchain", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck session unchain <session>")
+		fmt.Println()
+		fmt.Println("Stop <session> from waiting for another session to finish.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	storage, instances, groupsData, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	inst, errMsg, errCode := ResolveSession(fs.Arg(0), instances)
+	if inst == nil {
+		out.Error(errMsg, errCode)
+		os.Exit(2)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+	if inst.Chain == nil {
+		out.Error(fmt.Sprintf("session '%s' is not chained", inst.Title), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	inst.Chain = nil
+
+	groupTree := session.NewGroupTreeWithGroups(instances, groupsData)
+	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	out.Success(fmt.Sprintf("Removed chain from '%s'", inst.Title), map[string]interface{}{
+		"success":       true,
+		"session_id":    inst.ID,
+		"session_title": inst.Title,
+	})
+}
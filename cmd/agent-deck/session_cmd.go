@@ -47,6 +47,8 @@ func handleSession(profile string, args []string) {
 		handleSessionRevive(profile, args[1:])
 	case "fork":
 		handleSessionFork(profile, args[1:])
+	case "clone":
+		handleSessionClone(profile, args[1:])
 	case "attach":
 		handleSessionAttach(profile, args[1:])
 	case "show":
@@ -57,6 +59,10 @@ func handleSession(profile string, args []string) {
 		handleSessionSetParent(profile, args[1:])
 	case "unset-parent":
 		handleSessionUnsetParent(profile, args[1:])
+	case "chain":
+		handleSessionChain(profile, args[1:])
+	case "unchain":
+		handleSessionUnchain(profile, args[1:])
 	case "update":
 		// Issue #974: users expect `session update <id> --no-parent` and
 		// `session update <id> --parent <p>` to mirror typical CRUD verbs.
@@ -72,6 +78,8 @@ func handleSession(profile string, args []string) {
 		handleSessionSwitchAccount(profile, args[1:])
 	case "move", "mv":
 		handleSessionMove(profile, args[1:])
+	case "move-profile":
+		handleSessionMoveProfile(profile, args[1:])
 	case "send":
 		handleSessionSend(profile, args[1:])
 	case "send-keys":
@@ -80,8 +88,12 @@ func handleSession(profile string, args []string) {
 		handleSessionOutput(profile, args[1:])
 	case "children":
 		handleSessionChildren(profile, args[1:])
+	case "pr":
+		handleSessionPR(profile, args[1:])
 	case "search":
 		handleSessionSearch(profile, args[1:])
+	case "import":
+		handleSessionImport(profile, args[1:])
 	case "help", "--help", "-h":
 		printSessionHelp()
 	default:
@@ -104,18 +116,24 @@ func printSessionHelp() {
 	fmt.Println("  restart [id] [--all]    Restart session (Claude: reload MCPs)")
 	fmt.Println("  revive [--all|--name]   Rebuild dead control pipes for errored sessions")
 	fmt.Println("  fork <id>               Fork Claude, OpenCode, Pi, or Codex session with context")
+	fmt.Println("  clone <id>              New session with the same setup and a fresh conversation")
 	fmt.Println("  attach <id>             Attach to session interactively")
 	fmt.Println("  show [id]               Show session details (auto-detect current if no id)")
 	fmt.Println("  current                 Show current session and profile (auto-detect)")
 	fmt.Println("  set <id> <field> <value>  Update session property")
 	fmt.Println("  switch-account <id> <account>  Switch Claude account and migrate the conversation")
 	fmt.Println("  move <id> <path>        Move session to a new path (migrates Claude history)")
+	fmt.Println("  move-profile <id> <profile>  Transfer session to another profile")
 	fmt.Println("  send <id> <message>     Send a message to a running session")
 	fmt.Println("  output <id>             Get the last response from a session")
 	fmt.Println("  children [id]           List sub-sessions with status + last completion")
+	fmt.Println("  pr <id>                 Push a worktree session's branch and open a PR (gh)")
 	fmt.Println("  search <query>          Search message content across Claude sessions")
+	fmt.Println("  import                  Import past Claude conversations as idle sessions")
 	fmt.Println("  set-parent <id> <parent>  Link session as sub-session of parent")
 	fmt.Println("  unset-parent <id>       Remove sub-session link")
+	fmt.Println("  chain <id> <after> [-m msg]  Run session (send msg) when <after> finishes")
+	fmt.Println("  unchain <id>            Remove a pending chain")
 	fmt.Println("  update <id> --no-parent          Alias for unset-parent <id>")
 	fmt.Println("  update <id> --parent <pid>       Alias for set-parent <id> <pid>")
 	fmt.Println("  set-transition-notify <id> <on|off>  Enable/disable transition notifications")
@@ -132,6 +150,7 @@ func printSessionHelp() {
 	fmt.Println("  agent-deck session restart my-project")
 	fmt.Println("  agent-deck session restart --all                # Restart all active sessions")
 	fmt.Println("  agent-deck session fork my-project -t \"my-project-fork\"")
+	fmt.Println("  agent-deck session clone my-project -t \"attempt-2\"")
 	fmt.Println("  agent-deck session attach my-project")
 	fmt.Println("  agent-deck session show                  # Auto-detect current session")
 	fmt.Println("  agent-deck session show my-project --json")
@@ -250,6 +269,12 @@ func handleSessionStart(profile string, args []string) {
 		return
 	}
 
+	// A prompt given at creation (`add --initial-prompt`) is delivered on
+	// the first start. An explicit --message replaces it.
+	if pending := inst.TakeInitialPrompt(); initialMessage == "" {
+		initialMessage = pending
+	}
+
 	// Start the session (with or without initial message)
 	if initialMessage != "" {
 		if err := inst.StartWithMessage(initialMessage); err != nil {
@@ -409,6 +434,7 @@ func handleSessionRestart(profile string, args []string) {
 	quietShort := fs.Bool("q", false, "Minimal output (short)")
 	force := fs.Bool("force", false, "Restart even if the session is already healthy and fresh (bypasses issue #30 guard)")
 	all := fs.Bool("all", false, "Restart all active sessions")
+	reasonFlag := fs.String("reason", string(session.RestartReasonManual), "Reason recorded in the restart history (manual, auto_policy, ...)")
 
 	fs.Usage = func() {
 		fmt.Println("Usage: agent-deck session restart [id|title] [options]")
@@ -426,6 +452,7 @@ func handleSessionRestart(profile string, args []string) {
 		fmt.Println("Examples:")
 		fmt.Println("  agent-deck session restart my-project")
 		fmt.Println("  agent-deck session restart --all")
+		fmt.Println("  agent-deck session restart my-project --reason auto_policy   # from a watchdog")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
@@ -435,6 +462,12 @@ func handleSessionRestart(profile string, args []string) {
 	quietMode := *quiet || *quietShort
 	out := NewCLIOutput(*jsonOutput, quietMode)
 
+	restartReason, err := session.ParseRestartReason(*reasonFlag)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
 	// Load sessions
 	storage, instances, groups, err := loadSessionData(profile)
 	if err != nil {
@@ -443,7 +476,7 @@ func handleSessionRestart(profile string, args []string) {
 	}
 
 	if *all {
-		restartAllSessions(out, storage, instances, groups)
+		restartAllSessions(out, storage, instances, groups, restartReason)
 		return
 	}
 
@@ -482,7 +515,7 @@ func handleSessionRestart(profile string, args []string) {
 	}
 
 	// Restart the session
-	if err := inst.Restart(); err != nil {
+	if err := inst.RestartWithReason(restartReason); err != nil {
 		out.Error(fmt.Sprintf("failed to restart session: %v", err), ErrCodeInvalidOperation)
 		os.Exit(1)
 	}
@@ -518,7 +551,7 @@ func handleSessionRestart(profile string, args []string) {
 }
 
 // restartAllSessions restarts every active session one by one.
-func restartAllSessions(out *CLIOutput, storage *session.Storage, instances []*session.Instance, groups []*session.GroupData) {
+func restartAllSessions(out *CLIOutput, storage *session.Storage, instances []*session.Instance, groups []*session.GroupData, reason session.RestartReason) {
 	var active []*session.Instance
 	for _, inst := range instances {
 		if inst.Exists() {
@@ -544,7 +577,7 @@ func restartAllSessions(out *CLIOutput, storage *session.Storage, instances []*s
 			fmt.Printf("Restarting %s...\n", inst.Title)
 		}
 
-		if err := inst.Restart(); err != nil {
+		if err := inst.RestartWithReason(reason); err != nil {
 			errMsg := fmt.Sprintf("failed to restart session '%s': %v", inst.Title, err)
 			if !out.jsonMode {
 				fmt.Fprintf(os.Stderr, "  Error: %s\n", errMsg)
@@ -1039,6 +1072,99 @@ func handleSessionFork(profile string, args []string) {
 	)
 }
 
+// handleSessionClone starts a new session with the source's setup (path,
+// tool, options, MCPs, group) but a fresh conversation.
+func handleSessionClone(profile string, args []string) {
+	fs := flag.NewFlagSet("session clone", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	title := fs.String("title", "", "Title for cloned session")
+	titleShort := fs.String("t", "", "Title for cloned session (short)")
+	group := fs.String("group", "", "Group for cloned session (default: source's group)")
+	groupShort := fs.String("g", "", "Group for cloned session (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck session clone <id|title> [options]")
+		fmt.Println()
+		fmt.Println("Start a new session with the same path, tool, MCPs, options and group")
+		fmt.Println("as an existing one, but a fresh conversation. Use fork to keep the context.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck session clone my-project")
+		fmt.Println("  agent-deck session clone my-project -t \"attempt-2\" -g \"experiments\"")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	identifier := fs.Arg(0)
+	quietMode := *quiet || *quietShort
+	out := NewCLIOutput(*jsonOutput, quietMode)
+
+	cloneTitle := mergeFlags(*title, *titleShort)
+	cloneGroup := mergeFlags(*group, *groupShort)
+
+	storage, instances, groupsData, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	inst, errMsg, errCode := ResolveSession(identifier, instances)
+	if inst == nil {
+		out.Error(errMsg, errCode)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	explicitTitle := cloneTitle != ""
+	if !explicitTitle {
+		cloneTitle = inst.Title + " (clone)"
+	}
+	cloned := inst.Clone(cloneTitle, cloneGroup)
+	if explicitTitle {
+		cloned.TitleLocked = true
+	}
+
+	if err := cloned.Start(); err != nil {
+		out.Error(fmt.Sprintf("failed to start cloned session: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	cloned.PostStartSync(3 * time.Second)
+
+	instances = append(instances, cloned)
+
+	groupTree := session.NewGroupTreeWithGroups(instances, groupsData)
+	cloneCfg, _ := session.LoadUserConfig()
+	groupTree.DefaultMaxConcurrent = cloneCfg.GroupDefaults.MaxConcurrent
+	if cloned.GroupPath != "" {
+		groupTree.CreateGroupPath(cloned.GroupPath)
+	}
+
+	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	out.Success(
+		fmt.Sprintf("Cloned session: %s -> %s (%s)", inst.Title, cloned.Title, TruncateID(cloned.ID)),
+		map[string]interface{}{
+			"success":   true,
+			"source_id": inst.ID,
+			"new_id":    cloned.ID,
+			"new_title": cloned.Title,
+		},
+	)
+}
+
 // handleSessionAttach attaches to a session interactively
 func handleSessionAttach(profile string, args []string) {
 	fs := flag.NewFlagSet("session attach", flag.ExitOnError)
@@ -1060,7 +1186,7 @@ func handleSessionAttach(profile string, args []string) {
 	identifier := fs.Arg(0)
 
 	// Load sessions
-	_, instances, _, err := loadSessionData(profile)
+	instances, err := readSessionData(profile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -1124,7 +1250,7 @@ func handleSessionShow(profile string, args []string) {
 	out := NewCLIOutput(*jsonOutput, quietMode)
 
 	// Load sessions
-	_, instances, _, err := loadSessionData(profile)
+	instances, err := readSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
 		os.Exit(1)
@@ -1199,6 +1325,15 @@ func handleSessionShow(profile string, args []string) {
 	if inst.Command != "" {
 		jsonData["command"] = inst.Command
 	}
+	if len(inst.Tags) > 0 {
+		jsonData["tags"] = inst.Tags
+	}
+	if inst.Chain != nil {
+		jsonData["chain"] = inst.Chain
+	}
+	if inst.Notes != "" {
+		jsonData["notes"] = inst.Notes
+	}
 
 	if session.IsClaudeCompatible(inst.Tool) {
 		jsonData["claude_session_id"] = inst.ClaudeSessionID
@@ -1238,6 +1373,14 @@ func handleSessionShow(profile string, args []string) {
 	if tmuxSession := inst.GetTmuxSession(); tmuxSession != nil {
 		jsonData["tmux_session"] = tmuxSession.Name
 	}
+	restarts := inst.GetRestartHistory()
+	if len(restarts) > 0 {
+		jsonData["restart_count"] = len(restarts)
+		jsonData["restart_history"] = restarts
+	}
+	if events := inst.GetEventLog(); len(events) > 0 {
+		jsonData["event_log"] = events
+	}
 
 	// Build human-readable output
 	var sb strings.Builder
@@ -1251,6 +1394,19 @@ func handleSessionShow(profile string, args []string) {
 	if inst.GroupPath != "" {
 		sb.WriteString(fmt.Sprintf("Group:   %s\n", inst.GroupPath))
 	}
+	if len(inst.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("Tags:    %s\n", strings.Join(inst.Tags, ", ")))
+	}
+	if inst.Chain != nil {
+		after := inst.Chain.After
+		for _, other := range instances {
+			if other.ID == after {
+				after = other.Title
+				break
+			}
+		}
+		sb.WriteString(fmt.Sprintf("Chain:   runs after %s\n", after))
+	}
 
 	sb.WriteString(fmt.Sprintf("Tool:    %s\n", inst.Tool))
 	if modelInfo.ModelID != "" {
@@ -1316,6 +1472,10 @@ func handleSessionShow(profile string, args []string) {
 		sb.WriteString("Notify:  transition events suppressed\n")
 	}
 	sb.WriteString(fmt.Sprintf("Created: %s\n", inst.CreatedAt.Format("2006-01-02 15:04:05")))
+	if n := len(restarts); n > 0 {
+		last := restarts[n-1]
+		sb.WriteString(fmt.Sprintf("Restarts: %d (last %s, %s)\n", n, last.At.Format("2006-01-02 15:04:05"), last.Reason))
+	}
 
 	if !inst.LastAccessedAt.IsZero() {
 		sb.WriteString(fmt.Sprintf("Accessed: %s\n", inst.LastAccessedAt.Format("2006-01-02 15:04:05")))
@@ -1328,6 +1488,13 @@ func handleSessionShow(profile string, args []string) {
 		}
 	}
 
+	if notes := strings.TrimRight(inst.Notes, "\n"); notes != "" {
+		sb.WriteString("Notes:\n")
+		for _, line := range strings.Split(notes, "\n") {
+			sb.WriteString("  " + line + "\n")
+		}
+	}
+
 	out.Print(sb.String(), jsonData)
 }
 
@@ -1365,6 +1532,8 @@ func handleSessionSet(profile string, args []string) {
 		fmt.Println("  extra-args         Extra claude CLI tokens (claude only; use `-- --flag value` for tokens starting with -; persisted plaintext — no secrets)")
 		fmt.Println("  model              Per-session model override (e.g. opus/sonnet/haiku or a gemini model); persists across restart (#1436). Empty clears it.")
 		fmt.Println("  color              Optional TUI row tint: '#RRGGBB' or ANSI '0'..'255' or '' (issue #391)")
+		fmt.Println("  notes              Free-form session notes (shown in the TUI preview when [preview] show_notes = true)")
+		fmt.Println("  tags               Comma-separated tags (e.g. urgent,client-x); replaces the list, '' clears")
 		fmt.Println("  claude-session-id  Claude conversation ID")
 		fmt.Println("  gemini-session-id  Gemini conversation ID")
 		fmt.Println("  account            Named account slot (#924) — resolves via [profiles.<account>.claude].config_dir; restart required")
@@ -1381,6 +1550,7 @@ func handleSessionSet(profile string, args []string) {
 		fmt.Println("  agent-deck session set my-project color \"#ff00aa\"     # truecolor hex tint")
 		fmt.Println("  agent-deck session set my-project color 203              # ANSI 256-palette pink")
 		fmt.Println("  agent-deck session set my-project color \"\"              # clear (opt-out)")
+		fmt.Println("  agent-deck session set my-project tags urgent,client-x")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
@@ -1495,14 +1665,23 @@ func maybeEmitSessionSetTelegramWarnings(out io.Writer, claudeCfgDir string, ins
 
 // loadSessionData loads storage and session data for a profile
 // The Storage.LoadWithGroups() method already handles tmux reconnection internally
+//
+// The storage's update lock is taken before the load and held until
+// saveSessionData (or storage.Close, or exit), so another process cannot
+// save in between and have its changes overwritten by this command's save.
+// Commands that only read use readSessionData instead.
 func loadSessionData(profile string) (*session.Storage, []*session.Instance, []*session.GroupData, error) {
 	storage, err := session.NewStorageWithProfile(profile)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to initialize storage: %w", err)
 	}
+	if err := storage.LockForUpdate(); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to lock sessions: %w", err)
+	}
 
 	instances, groupsData, err := storage.LoadWithGroups()
 	if err != nil {
+		storage.Unlock()
 		return nil, nil, nil, fmt.Errorf("failed to load sessions: %w", err)
 	}
 
@@ -1512,8 +1691,22 @@ func loadSessionData(profile string) (*session.Storage, []*session.Instance, []*
 	return storage, instances, groupsData, nil
 }
 
+// readSessionData is loadSessionData for commands that never save: the
+// update lock is released right after the load, so a long attach or wait
+// does not hold up other processes' saves.
+func readSessionData(profile string) ([]*session.Instance, error) {
+	storage, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		return nil, err
+	}
+	storage.Unlock()
+	return instances, nil
+}
+
 // saveSessionData saves session data with groups, preserving stored group metadata (sort_order).
+// It releases the update lock taken by loadSessionData.
 func saveSessionData(storage *session.Storage, instances []*session.Instance, groups []*session.GroupData) error {
+	defer storage.Unlock()
 	groupTree := session.NewGroupTreeWithGroups(instances, groups)
 	return storage.SaveWithGroups(instances, groupTree)
 }
@@ -1527,7 +1720,7 @@ func findSessionByTmuxAcrossProfiles() (*session.Instance, string) {
 	}
 
 	for _, p := range profiles {
-		_, instances, _, err := loadSessionData(p)
+		instances, err := readSessionData(p)
 		if err != nil {
 			continue
 		}
@@ -2140,7 +2333,7 @@ func handleSessionSend(profile string, args []string) {
 	message := strings.Join(remaining[1:], " ")
 
 	// Load sessions
-	_, instances, _, err := loadSessionData(profile)
+	instances, err := readSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
 		os.Exit(1)
@@ -2337,7 +2530,7 @@ func handleSessionSend(profile string, args []string) {
 		if err != nil {
 			// Fallback: reload session from DB in case tmux env was also stale
 			// (e.g., /clear created a new session that TUI or hooks detected)
-			if _, freshInstances, _, loadErr := loadSessionData(profile); loadErr == nil {
+			if freshInstances, loadErr := readSessionData(profile); loadErr == nil {
 				if freshInst, _, _ := ResolveSession(sessionRef, freshInstances); freshInst != nil {
 					response, err = waitForFreshOutput(freshInst, sentAt, freshInstances)
 				}
@@ -3136,7 +3329,7 @@ func streamSessionSend(inst *session.Instance, sessionRef, profile string, sentA
 	// (issue #1349 defense-in-depth #2): streaming the wrong transcript is one
 	// of the corruption symptoms the rebind bug caused.
 	var peers []*session.Instance
-	if _, initial, _, loadErr := loadSessionData(profile); loadErr == nil {
+	if initial, loadErr := readSessionData(profile); loadErr == nil {
 		peers = initial
 	}
 	deadline := time.Now().Add(opts.timeout)
@@ -3150,7 +3343,7 @@ func streamSessionSend(inst *session.Instance, sessionRef, profile string, sentA
 			break
 		}
 		// Refresh from DB in case the session was just created.
-		if _, freshInstances, _, loadErr := loadSessionData(profile); loadErr == nil {
+		if freshInstances, loadErr := readSessionData(profile); loadErr == nil {
 			peers = freshInstances
 			if fi, _, _ := ResolveSession(sessionRef, freshInstances); fi != nil {
 				resolvedInst = fi
@@ -3214,7 +3407,7 @@ func handleSessionOutput(profile string, args []string) {
 	out := NewCLIOutput(*jsonOutput, quietMode)
 
 	// Load sessions
-	_, instances, _, err := loadSessionData(profile)
+	instances, err := readSessionData(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
 		os.Exit(1)
@@ -3551,7 +3744,7 @@ func handleSessionChildren(profile string, args []string) {
 	quietMode := *quiet || *quietShort
 	out := NewCLIOutput(*jsonOutput, quietMode)
 
-	_, instances, _, err := loadSessionData(profile)
+	instances, err := readSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
 		os.Exit(1)
@@ -3627,10 +3820,16 @@ func handleSessionSearch(profile string, args []string) {
 	fs.Usage = func() {
 		fmt.Println("Usage: agent-deck session search <query> [options]")
 		fmt.Println()
-		fmt.Println("Search message content across all Claude sessions.")
+		fmt.Println("Search message content across Claude, Gemini and OpenCode conversations.")
 		fmt.Println()
 		fmt.Println("Arguments:")
-		fmt.Println("  <query>   Free-text query (case-insensitive substring match)")
+		fmt.Println("  <query>   Words to match (all must appear; prefixes match), ranked by relevance")
+		fmt.Println()
+		fmt.Println("Query syntax:")
+		fmt.Println("  \"exact phrase\"        Match the words in order")
+		fmt.Println("  project:<text>         Working directory contains <text>")
+		fmt.Println("  tool:<name>            claude, gemini or opencode")
+		fmt.Println("  after:<when>           2026-01-31, 7d, 2w or 12h; before:<when> likewise")
 		fmt.Println()
 		fmt.Println("Options:")
 		fs.PrintDefaults()
@@ -3639,6 +3838,7 @@ func handleSessionSearch(profile string, args []string) {
 		fmt.Println("  agent-deck session search \"MCP server\"")
 		fmt.Println("  agent-deck session search authentication --json")
 		fmt.Println("  agent-deck session search \"database migration\" --limit 5")
+		fmt.Println("  agent-deck session search '\"connection refused\" project:api after:7d'")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
@@ -3692,6 +3892,7 @@ func handleSessionSearch(profile string, args []string) {
 
 	type hitJSON struct {
 		SessionID string `json:"session_id"`
+		Tool      string `json:"tool"`
 		Snippet   string `json:"snippet"`
 		CWD       string `json:"cwd"`
 		Summary   string `json:"summary,omitempty"`
@@ -3705,6 +3906,7 @@ func handleSessionSearch(profile string, args []string) {
 		}
 		hits = append(hits, hitJSON{
 			SessionID: r.Entry.SessionID,
+			Tool:      r.Entry.Tool,
 			Snippet:   r.Snippet,
 			CWD:       r.Entry.CWD,
 			Summary:   r.Entry.Summary,
@@ -3727,7 +3929,7 @@ func handleSessionSearch(profile string, args []string) {
 	}
 	fmt.Printf("Found %d match(es) for %q:\n", len(hits), query)
 	for i, h := range hits {
-		fmt.Printf("%d. %s\n", i+1, h.SessionID)
+		fmt.Printf("%d. [%s] %s\n", i+1, h.Tool, h.SessionID)
 		if h.CWD != "" {
 			fmt.Printf("   cwd: %s\n", h.CWD)
 		}
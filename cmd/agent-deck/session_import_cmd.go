@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleSessionImport bulk-imports past Claude conversations as idle,
+// never-started sessions so pre-agent-deck history shows up in the deck and
+// resumes (claude --resume) on first start.
+func handleSessionImport(profile string, args []string) {
+	fs := flag.NewFlagSet("session import", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	days := fs.Int("days", 0, "Only offer conversations modified within the last N days (0 = all)")
+	project := fs.String("project", "", "Only offer conversations whose project path is under this directory")
+	group := fs.String("group", "", "Put imported sessions in this group (default: derived from project path)")
+	limit := fs.Int("limit", 0, "Offer at most N conversations (newest first, 0 = no limit)")
+	dryRun := fs.Bool("dry-run", false, "List importable conversations without importing")
+	yes := fs.Bool("yes", false, "Import every listed conversation without prompting")
+	yesShort := fs.Bool("y", false, "Import without prompting (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck session import [options]")
+		fmt.Println()
+		fmt.Println("Import past Claude conversations (from the Claude projects dir) as idle")
+		fmt.Println("sessions. Nothing is started: each session resumes its conversation the")
+		fmt.Println("first time you start it. Conversations already bound to a session are skipped.")
+		fmt.Println()
+		fmt.Println("Without --yes the candidates are listed grouped by project and you pick")
+		fmt.Println("which to import (e.g. \"all\", \"1,3,5-7\").")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck session import --dry-run")
+		fmt.Println("  agent-deck session import --days 30")
+		fmt.Println("  agent-deck session import --project ~/src/api --yes")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	autoYes := *yes || *yesShort
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	if *jsonOutput && !autoYes && !*dryRun {
+		out.Error("--json requires --yes or --dry-run (no interactive selection in JSON mode)", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	var since time.Time
+	if *days > 0 {
+		since = time.Now().AddDate(0, 0, -*days)
+	}
+	candidates, err := session.ScanClaudeHistory(session.GetClaudeConfigDir(), instances, since)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to scan Claude history: %v", err), ErrCodeNotFound)
+		os.Exit(1)
+	}
+	candidates = filterImportCandidates(candidates, *project, *limit)
+
+	if len(candidates) == 0 {
+		out.Success("No new Claude conversations to import", map[string]interface{}{
+			"candidates": candidates,
+			"imported":   0,
+		})
+		return
+	}
+
+	if *dryRun {
+		if *jsonOutput {
+			out.Success("", map[string]interface{}{"candidates": candidates, "count": len(candidates)})
+			return
+		}
+		printImportCandidates(candidates)
+		return
+	}
+
+	selected := candidates
+	if !autoYes {
+		printImportCandidates(candidates)
+		fmt.Print("\nImport which conversations? [all, none, or e.g. 1,3,5-7]: ")
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		selected, err = selectImportCandidates(candidates, answer)
+		if err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		if len(selected) == 0 {
+			fmt.Println("Nothing imported.")
+			return
+		}
+	}
+
+	userConfig, _ := session.LoadUserConfig()
+	imported := make([]map[string]string, 0, len(selected))
+	for _, c := range selected {
+		inst := session.NewClaudeHistoryInstance(c, *group, userConfig)
+		instances = append(instances, inst)
+		imported = append(imported, map[string]string{
+			"id":                inst.ID,
+			"title":             inst.Title,
+			"group":             inst.GroupPath,
+			"path":              inst.ProjectPath,
+			"claude_session_id": c.SessionID,
+		})
+	}
+
+	groupTree := session.NewGroupTreeWithGroups(instances, groups)
+	if *group != "" {
+		groupTree.CreateGroupPath(*group)
+	}
+	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+		out.Error(fmt.Sprintf("failed to save sessions: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	out.Success(fmt.Sprintf("Imported %d Claude conversation(s) as idle sessions", len(imported)), map[string]interface{}{
+		"imported": len(imported),
+		"sessions": imported,
+	})
+}
+
+// filterImportCandidates applies --project and --limit. --limit keeps the
+// newest conversations overall, then restores project grouping.
+func filterImportCandidates(candidates []session.ClaudeHistoryCandidate, project string, limit int) []session.ClaudeHistoryCandidate {
+	if project != "" {
+		root := filepath.Clean(session.ExpandPath(project))
+		kept := candidates[:0:0]
+		for _, c := range candidates {
+			if c.ProjectPath == root || strings.HasPrefix(c.ProjectPath, root+string(filepath.Separator)) {
+				kept = append(kept, c)
+			}
+		}
+		candidates = kept
+	}
+	if limit <= 0 || len(candidates) <= limit {
+		return candidates
+	}
+
+	newest := make([]session.ClaudeHistoryCandidate, len(candidates))
+	copy(newest, candidates)
+	sort.SliceStable(newest, func(a, b int) bool { return newest[a].ModTime.After(newest[b].ModTime) })
+	keep := make(map[string]bool, limit)
+	for _, c := range newest[:limit] {
+		keep[c.SessionID] = true
+	}
+	kept := make([]session.ClaudeHistoryCandidate, 0, limit)
+	for _, c := range candidates {
+		if keep[c.SessionID] {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// printImportCandidates lists candidates numbered from 1, under a header per
+// project path.
+func printImportCandidates(candidates []session.ClaudeHistoryCandidate) {
+	fmt.Printf("Found %d Claude conversation(s) not in agent-deck:\n", len(candidates))
+	lastProject := ""
+	for i, c := range candidates {
+		if c.ProjectPath != lastProject {
+			fmt.Printf("\n%s\n", FormatPath(c.ProjectPath))
+			lastProject = c.ProjectPath
+		}
+		fmt.Printf("  %3d. %-48s  %s  %s\n", i+1, c.Title(), c.ModTime.Format("2006-01-02 15:04"), TruncateID(c.SessionID))
+	}
+}
+
+// selectImportCandidates parses the interactive answer: "all"/"a", an empty
+// answer or "none"/"n", or a comma-separated list of 1-based indexes and
+// ranges ("1,3,5-7").
+func selectImportCandidates(candidates []session.ClaudeHistoryCandidate, answer string) ([]session.ClaudeHistoryCandidate, error) {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	switch answer {
+	case "", "none", "n", "no":
+		return nil, nil
+	case "all", "a", "y", "yes":
+		return candidates, nil
+	}
+
+	picked := make(map[int]bool)
+	for _, part := range strings.Split(answer, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(strings.TrimSpace(hi)); err != nil {
+				return nil, fmt.Errorf("invalid selection %q", part)
+			}
+		}
+		if start < 1 || end > len(candidates) || start > end {
+			return nil, fmt.Errorf("selection %q out of range 1-%d", part, len(candidates))
+		}
+		for n := start; n <= end; n++ {
+			picked[n-1] = true
+		}
+	}
+
+	selected := make([]session.ClaudeHistoryCandidate, 0, len(picked))
+	for i, c := range candidates {
+		if picked[i] {
+			selected = append(selected, c)
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestSelectImportCandidates(t *testing.T) {
+	candidates := make([]session.ClaudeHistoryCandidate, 7)
+	for i := range candidates {
+		candidates[i].SessionID = string(rune('a' + i))
+	}
+
+	cases := []struct {
+		answer  string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"none", "", false},
+		{"ALL\n", "abcdefg", false},
+		{"1,3,5-7", "acefg", false},
+		{" 2 , 2-3 ", "bc", false},
+		{"0", "", true},
+		{"6-8", "", true},
+		{"x", "", true},
+		{"3-1", "", true},
+	}
+	for _, tc := range cases {
+		got, err := selectImportCandidates(candidates, tc.answer)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("%q: err = %v, wantErr %v", tc.answer, err, tc.wantErr)
+		}
+		ids := ""
+		for _, c := range got {
+			ids += c.SessionID
+		}
+		if ids != tc.want {
+			t.Fatalf("%q: selected %q, want %q", tc.answer, ids, tc.want)
+		}
+	}
+}
+
+func TestFilterImportCandidates(t *testing.T) {
+	now := time.Now()
+	candidates := []session.ClaudeHistoryCandidate{
+		{SessionID: "a1", ProjectPath: "/src/api", ModTime: now.Add(-3 * time.Hour)},
+		{SessionID: "a2", ProjectPath: "/src/api", ModTime: now.Add(-5 * time.Hour)},
+		{SessionID: "x1", ProjectPath: "/src/api-extra", ModTime: now.Add(-1 * time.Hour)},
+		{SessionID: "w1", ProjectPath: "/src/web", ModTime: now.Add(-2 * time.Hour)},
+	}
+
+	got := filterImportCandidates(candidates, "/src/api", 0)
+	if len(got) != 2 || got[0].SessionID != "a1" || got[1].SessionID != "a2" {
+		t.Fatalf("project filter = %+v", got)
+	}
+
+	// --limit keeps the newest overall but preserves project order.
+	got = filterImportCandidates(candidates, "", 2)
+	if len(got) != 2 || got[0].SessionID != "x1" || got[1].SessionID != "w1" {
+		t.Fatalf("limit = %+v", got)
+	}
+}
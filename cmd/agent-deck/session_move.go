@@ -149,7 +149,7 @@ func handleSessionMove(profile string, args []string) {
 
 	restarted := false
 	if !*noRestart && inst.Exists() {
-		if err := inst.Restart(); err != nil {
+		if err := inst.RestartWithReason(session.RestartReasonConfigChange); err != nil {
 			out.Error(fmt.Sprintf("session moved, but restart failed: %v", err), ErrCodeInvalidOperation)
 			os.Exit(1)
 		}
@@ -176,6 +176,43 @@ func handleSessionMove(profile string, args []string) {
 	})
 }
 
+// handleSessionMoveProfile implements `agent-deck session move-profile <id>
+// <profile>`, the positional spelling of `session move <id> --to-profile`.
+func handleSessionMoveProfile(profile string, args []string) {
+	fs := flag.NewFlagSet("session move-profile", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	force := fs.Bool("force", false, "Migrate running sessions (tmux process keeps running)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck session move-profile <id|title> <profile> [--force]")
+		fmt.Println()
+		fmt.Println("Transfer a session to another profile, preserving all metadata and")
+		fmt.Println("associated rows (cost_events, watcher_events). A running session's tmux")
+		fmt.Println("session is re-pointed at the new profile and keeps running.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck session move-profile my-project work")
+		fmt.Println("  agent-deck -p work session move-profile api-fix default --force")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	if fs.NArg() != 2 {
+		out.Error("session move-profile requires <id|title> and <profile>", ErrCodeInvalidOperation)
+		fs.Usage()
+		os.Exit(1)
+	}
+	handleSessionMoveToProfile(profile, fs.Arg(1), fs.Arg(0), *force, out)
+}
+
 // handleSessionMoveToProfile implements `session move <id> --to-profile <name>`
 // (issue #928). The identifier is resolved against the source profile (and,
 // if missing there, the target — preserving idempotency on re-runs), then
@@ -186,7 +223,7 @@ func handleSessionMoveToProfile(sourceProfile, targetProfile, identifier string,
 	// this in the CLI layer (not in MigrateSessionsToProfile) because
 	// ResolveSession lives in cmd/agent-deck and supports title/path lookup
 	// that the storage layer does not.
-	_, srcInstances, _, err := loadSessionData(sourceProfile)
+	srcInstances, err := readSessionData(sourceProfile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
 		os.Exit(1)
@@ -201,7 +238,7 @@ func handleSessionMoveToProfile(sourceProfile, targetProfile, identifier string,
 		// MigrateSessionsToProfile.
 		if dstDir, derr := session.GetProfileDir(targetProfile); derr == nil {
 			if _, statErr := os.Stat(filepath.Join(dstDir, "state.db")); statErr == nil {
-				if _, dstInstances, _, lerr := loadSessionData(targetProfile); lerr == nil {
+				if dstInstances, lerr := readSessionData(targetProfile); lerr == nil {
 					if dstInst, _, _ := ResolveSession(identifier, dstInstances); dstInst != nil {
 						inst = dstInst
 					}
@@ -237,6 +274,12 @@ func handleSessionMoveToProfile(sourceProfile, targetProfile, identifier string,
 		os.Exit(1)
 	}
 
+	// Forced moves leave the tmux session running; point its environment at
+	// the new profile so agent-deck commands run inside it resolve there.
+	if err := inst.RetargetProfileEnv(targetProfile); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update AGENTDECK_PROFILE on tmux session: %v\n", err)
+	}
+
 	out.Success(fmt.Sprintf("Migrated %q: profile %s → %s", inst.Title, sourceProfile, targetProfile), map[string]interface{}{
 		"success":         true,
 		"id":              inst.ID,
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleSessionPR pushes a worktree session's branch and opens a pull
+// request for it with gh.
+func handleSessionPR(profile string, args []string) {
+	fs := flag.NewFlagSet("session pr", flag.ExitOnError)
+	title := fs.String("title", "", "PR title (default: session title)")
+	body := fs.String("body", "", "PR body (default: the agent's last reply as a summary)")
+	base := fs.String("base", "", "Base branch (default: the repo's default branch)")
+	draft := fs.Bool("draft", false, "Open the PR as a draft")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck session pr <session> [options]")
+		fmt.Println()
+		fmt.Println("Push a worktree session's branch and open a pull request with gh.")
+		fmt.Println("If the branch already has an open PR, its URL is printed instead.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	instances, err := readSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		os.Exit(1)
+	}
+
+	inst, errMsg, errCode := ResolveSession(fs.Arg(0), instances)
+	if inst == nil {
+		out.Error(errMsg, errCode)
+		os.Exit(2)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	url, err := inst.CreatePullRequest(session.PullRequestOptions{
+		Title: *title,
+		Body:  *body,
+		Base:  *base,
+		Draft: *draft,
+	})
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	out.Success(fmt.Sprintf("Pull request for '%s': %s", inst.Title, url), map[string]interface{}{
+		"success":       true,
+		"session_id":    inst.ID,
+		"session_title": inst.Title,
+		"branch":        inst.WorktreeBranch,
+		"url":           url,
+	})
+}
@@ -105,6 +105,9 @@ func handleSessionRemove(profile string, args []string) {
 	// resurrect the row when a concurrent rewriter loaded the instance
 	// list before our DELETE — exactly the "session remove --force
 	// reports success but row stays" failure noted in the bug report.
+	if err := storage.TrashInstance(inst); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
 	instances = dropInstance(instances, inst.ID)
 	groupTree := session.NewGroupTreeWithGroups(instances, groups)
 	if err := storage.RemoveSessionAndVerify(inst.ID, instances, groupTree); err != nil {
@@ -161,6 +164,9 @@ func removeAllErrored(
 			if pruneWorktree {
 				pruneSessionWorktree(inst)
 			}
+			if err := storage.TrashInstance(inst); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
 			if err := storage.DeleteInstance(inst.ID); err != nil {
 				out.Error(fmt.Sprintf("failed to remove session %s: %v", inst.ID, err), ErrCodeInvalidOperation)
 				os.Exit(1)
@@ -92,7 +92,7 @@ func handleSessionSendKeys(profile string, args []string) {
 
 	sessionRef := remaining[0]
 
-	_, instances, _, err := loadSessionData(profile)
+	instances, err := readSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
 		os.Exit(1)
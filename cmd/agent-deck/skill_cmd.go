@@ -21,7 +21,7 @@ func restartProjectSkillsSession(inst *session.Instance, jsonOutput, quietMode b
 	if inst == nil || !session.ShouldRestartProjectSkills(inst.Tool) {
 		return false
 	}
-	if err := inst.Restart(); err != nil {
+	if err := inst.RestartWithReason(session.RestartReasonConfigChange); err != nil {
 		if !jsonOutput && !quietMode {
 			fmt.Fprintf(os.Stderr, "Warning: failed to restart session: %v\n", err)
 		}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// handleTrash dispatches `agent-deck trash`. Deleted sessions are kept in the
+// profile's trash for [trash] retention_days (default 30) so a delete does not
+// lose the session's link to its Claude/Codex/Gemini conversation.
+func handleTrash(profile string, args []string) {
+	if len(args) == 0 {
+		handleTrashList(profile, nil)
+		return
+	}
+	switch args[0] {
+	case "list", "ls":
+		handleTrashList(profile, args[1:])
+	case "restore":
+		handleTrashRestore(profile, args[1:])
+	case "purge":
+		handleTrashPurge(profile, args[1:])
+	case "help", "--help", "-h":
+		printTrashHelp()
+	default:
+		// Flags without a subcommand (`trash --json`) list.
+		if strings.HasPrefix(args[0], "-") {
+			handleTrashList(profile, args)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Unknown trash command: %s\n", args[0])
+		printTrashHelp()
+		os.Exit(1)
+	}
+}
+
+func printTrashHelp() {
+	fmt.Println("Usage: agent-deck trash <command> [options]")
+	fmt.Println()
+	fmt.Println("Deleted sessions stay in the trash for [trash] retention_days (default 30).")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  list                    List deleted sessions (default)")
+	fmt.Println("  restore <id|title>      Put a deleted session back (stopped)")
+	fmt.Println("  purge <id|title>        Permanently delete one trash entry")
+	fmt.Println("  purge --all             Empty the trash")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  agent-deck trash")
+	fmt.Println("  agent-deck trash restore my-project")
+	fmt.Println("  agent-deck session start my-project   # resumes the conversation")
+}
+
+func handleTrashList(profile string, args []string) {
+	fs := flag.NewFlagSet("trash list", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	fs.Usage = printTrashHelp
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+
+	storage, entries := loadTrash(out, profile)
+	if *jsonOutput {
+		items := make([]map[string]interface{}, 0, len(entries))
+		for _, e := range entries {
+			items = append(items, map[string]interface{}{
+				"id":           e.ID,
+				"title":        e.Title,
+				"tool":         e.Row.Tool,
+				"path":         e.Row.ProjectPath,
+				"group":        e.Row.GroupPath,
+				"deleted_at":   e.DeletedAt,
+				"tool_session": e.ToolSessionID(),
+			})
+		}
+		out.Print("", items)
+		return
+	}
+
+	fmt.Printf("Profile: %s\n\n", storage.Profile())
+	if len(entries) == 0 {
+		fmt.Println("Trash is empty.")
+		return
+	}
+	fmt.Printf("%-*s %-*s %-10s %s\n", tableColTitle, "TITLE", tableColPath, "PATH", "DELETED", "ID")
+	fmt.Println(strings.Repeat("-", tableColTitle+tableColPath+tableColIDDisplay+13))
+	now := time.Now()
+	for _, e := range entries {
+		id := e.ID
+		if len(id) > tableColIDDisplay {
+			id = id[:tableColIDDisplay]
+		}
+		fmt.Printf("%-*s %-*s %-10s %s\n",
+			tableColTitle, truncate(e.Title, tableColTitle),
+			tableColPath, truncate(e.Row.ProjectPath, tableColPath),
+			formatDuration(now.Sub(e.DeletedAt))+" ago", id)
+	}
+	fmt.Printf("\nTotal: %d deleted sessions (kept %d days)\n",
+		len(entries), int(session.GetTrashSettings().GetRetention().Hours()/24))
+}
+
+func handleTrashRestore(profile string, args []string) {
+	fs := flag.NewFlagSet("trash restore", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck trash restore <id|title>")
+		fmt.Println()
+		fmt.Println("Put a deleted session back in the session list, stopped. Start it with")
+		fmt.Println("`agent-deck session start` to resume its conversation.")
+	}
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	if fs.NArg() != 1 {
+		out.Error("trash entry ID or title is required", ErrCodeNotFound)
+		if !*jsonOutput {
+			fs.Usage()
+		}
+		os.Exit(1)
+	}
+
+	storage, entries := loadTrash(out, profile)
+	entry := resolveTrashEntryOrExit(out, storage, entries, fs.Arg(0))
+	row, err := storage.RestoreFromTrash(entry.ID)
+	if row == nil {
+		code := ErrCodeInvalidOperation
+		if errors.Is(err, statedb.ErrRestoreConflict) {
+			code = ErrCodeAlreadyExists
+		}
+		out.Error(fmt.Sprintf("failed to restore: %v", err), code)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	out.Success(fmt.Sprintf("Restored session: %s (start it with: agent-deck session start %s)", row.Title, row.ID), map[string]interface{}{
+		"success": true,
+		"id":      row.ID,
+		"title":   row.Title,
+	})
+}
+
+func handleTrashPurge(profile string, args []string) {
+	fs := flag.NewFlagSet("trash purge", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	all := fs.Bool("all", false, "Empty the whole trash")
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck trash purge <id|title>")
+		fmt.Println("       agent-deck trash purge --all")
+		fmt.Println()
+		fmt.Println("Permanently delete trash entries. Purged sessions cannot be restored.")
+	}
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+	if *all == (fs.NArg() == 1) || fs.NArg() > 1 {
+		out.Error("pass either a trash entry ID/title or --all", ErrCodeInvalidOperation)
+		if !*jsonOutput {
+			fs.Usage()
+		}
+		os.Exit(1)
+	}
+
+	storage, entries := loadTrash(out, profile)
+	id, label := "", "all trash entries"
+	if !*all {
+		entry := resolveTrashEntryOrExit(out, storage, entries, fs.Arg(0))
+		id, label = entry.ID, entry.Title
+	}
+	n, err := storage.PurgeTrash(id)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to purge: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	out.Success(fmt.Sprintf("Purged %s (%d removed)", label, n), map[string]interface{}{
+		"success": true,
+		"purged":  n,
+	})
+}
+
+// loadTrash opens the profile's storage and reads its unexpired trash,
+// exiting on failure.
+func loadTrash(out *CLIOutput, profile string) (*session.Storage, []*statedb.TrashRow) {
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	entries, err := storage.LoadTrash()
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to read trash: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	return storage, entries
+}
+
+func resolveTrashEntryOrExit(out *CLIOutput, storage *session.Storage, entries []*statedb.TrashRow, identifier string) *statedb.TrashRow {
+	entry, errMsg, errCode := resolveTrashEntry(identifier, entries)
+	if entry == nil {
+		out.Error(fmt.Sprintf("%s (profile '%s')", errMsg, storage.Profile()), errCode)
+		if errCode == ErrCodeNotFound {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+	return entry
+}
+
+// resolveTrashEntry matches a trash entry by exact ID, exact title, or ID
+// prefix (6+ chars), mirroring ResolveSession. The same title can be in the
+// trash more than once, so a title matching several entries is ambiguous.
+func resolveTrashEntry(identifier string, entries []*statedb.TrashRow) (*statedb.TrashRow, string, string) {
+	for _, e := range entries {
+		if e.ID == identifier {
+			return e, "", ""
+		}
+	}
+	var matches []*statedb.TrashRow
+	for _, e := range entries {
+		if e.Title == identifier {
+			matches = append(matches, e)
+		}
+	}
+	if len(matches) == 0 && len(identifier) >= 6 {
+		for _, e := range entries {
+			if strings.HasPrefix(e.ID, identifier) {
+				matches = append(matches, e)
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Sprintf("no trash entry matches '%s'", identifier), ErrCodeNotFound
+	case 1:
+		return matches[0], "", ""
+	}
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		id := m.ID
+		if len(id) > 12 {
+			id = id[:12]
+		}
+		names = append(names, fmt.Sprintf("%s (%s, deleted %s)", m.Title, id, m.DeletedAt.Format("Jan 2 15:04")))
+	}
+	return nil, fmt.Sprintf("'%s' matches multiple trash entries:\n  - %s\nUse the ID instead.",
+		identifier, strings.Join(names, "\n  - ")), ErrCodeAmbiguous
+}
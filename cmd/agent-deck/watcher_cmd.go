@@ -126,7 +126,7 @@ func openWatcherDB(profile string) (*statedb.StateDB, error) {
 }
 
 // validWatcherTypes lists all supported adapter types.
-var validWatcherTypes = []string{"webhook", "ntfy", "github", "slack"}
+var validWatcherTypes = []string{"webhook", "ntfy", "github", "github-issues", "slack"}
 
 // isValidWatcherType reports whether t is a known adapter type.
 func isValidWatcherType(t string) bool {
@@ -149,11 +149,13 @@ func handleWatcherCreate(profile string, args []string) {
 	// HMAC secret from $GITHUB_WEBHOOK_SECRET or --secret-file (chmod 600).
 	secret := fs.String("secret", "", "DEPRECATED/insecure: use $GITHUB_WEBHOOK_SECRET or --secret-file")
 	secretFile := fs.String("secret-file", "", "Path to a chmod-600 file holding the github HMAC secret")
+	repos := fs.String("repos", "", "Comma-separated owner/repo list for github-issues adapter")
+	label := fs.String("label", "agent", "Issue label picked up by github-issues adapter")
 
 	fs.Usage = func() {
 		fmt.Println("Usage: agent-deck watcher create <type> --name <name> [options]")
 		fmt.Println()
-		fmt.Println("Types: webhook, ntfy, github, slack")
+		fmt.Println("Types: webhook, ntfy, github, github-issues, slack")
 		fmt.Println()
 		fmt.Println("Options:")
 		fs.PrintDefaults()
@@ -204,6 +206,11 @@ func handleWatcherCreate(profile string, args []string) {
 			os.Exit(1)
 		}
 		githubSecret = s
+	case "github-issues":
+		if strings.TrimSpace(*repos) == "" {
+			fmt.Fprintln(os.Stderr, "Error: --repos is required for github-issues adapter")
+			os.Exit(1)
+		}
 	case "slack":
 		if *topic == "" {
 			fmt.Fprintln(os.Stderr, "Error: --topic is required for slack adapter")
@@ -260,6 +267,13 @@ func handleWatcherCreate(profile string, args []string) {
 		}
 	}
 
+	if adapterType == "github-issues" {
+		if err := writeGithubIssuesWatcherSource(configPath, *repos, *label); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing watcher source: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	fmt.Printf("Created watcher: %s (type: %s)\n", *name, adapterType)
 }
 
@@ -333,6 +347,25 @@ func writeGithubWatcherSecret(dir, secret string, port int) error {
 	return nil
 }
 
+// writeGithubIssuesWatcherSource writes the github-issues poller settings
+// to watcher.toml [source]. The poller authenticates through gh, so there
+// is no secret to store.
+func writeGithubIssuesWatcherSource(dir, repos, label string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create watcher dir: %w", err)
+	}
+	var b strings.Builder
+	b.WriteString("# Auto-generated by: agent-deck watcher create github-issues\n")
+	b.WriteString("# Polls open issues labeled `label` through the gh CLI.\n\n")
+	b.WriteString("[source]\n")
+	fmt.Fprintf(&b, "repos = %q\n", repos)
+	fmt.Fprintf(&b, "label = %q\n", label)
+	b.WriteString("# interval = \"60\"   # seconds between polls\n")
+	b.WriteString("# comment = \"false\" # skip the pickup comment\n")
+	b.WriteString("# web_url = \"http://localhost:8420\" # link the routed session in the pickup comment\n")
+	return os.WriteFile(filepath.Join(dir, "watcher.toml"), []byte(b.String()), 0o600)
+}
+
 // handleWatcherStart marks a watcher as running in statedb.
 func handleWatcherStart(profile string, args []string) {
 	fs := flag.NewFlagSet("watcher start", flag.ExitOnError)
@@ -934,7 +967,7 @@ func printWatcherHelp() {
 	fmt.Println("so your running agents can act on them automatically.")
 	fmt.Println()
 	fmt.Println("Commands:")
-	fmt.Println("  create <type> --name <name>   Create a new watcher (types: webhook, ntfy, github, slack)")
+	fmt.Println("  create <type> --name <name>   Create a new watcher (types: webhook, ntfy, github, github-issues, slack)")
 	fmt.Println("  start <name>                  Mark a watcher as running (picked up by TUI engine)")
 	fmt.Println("  stop <name>                   Mark a watcher as stopped")
 	fmt.Println("  list [--json]                 List all watchers with status and event rate")
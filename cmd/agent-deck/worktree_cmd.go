@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/asheshgoplani/agent-deck/internal/git"
@@ -27,7 +28,9 @@ func handleWorktree(profile string, args []string) {
 		handleWorktreeInfo(profile, args[1:])
 	case "cleanup":
 		handleWorktreeCleanup(profile, args[1:])
-	case "finish":
+	case "usage", "du":
+		handleWorktreeUsage(profile, args[1:])
+	case "finish", "merge":
 		handleWorktreeFinish(profile, args[1:])
 	case "help", "-h", "--help":
 		printWorktreeUsage()
@@ -48,7 +51,9 @@ func printWorktreeUsage() {
 	fmt.Println("  list              List all worktrees in current repository")
 	fmt.Println("  info <session>    Show worktree info for a session")
 	fmt.Println("  finish <session>  Merge branch, remove worktree, and delete session")
+	fmt.Println("  merge <session>   Alias for finish")
 	fmt.Println("  cleanup [--force] Find and remove orphaned worktrees/sessions")
+	fmt.Println("  usage [--prune]   Disk usage of worktrees across all session repos")
 	fmt.Println()
 	fmt.Println("Global Options:")
 	fmt.Println("  -p, --profile <name>   Use specific profile")
@@ -61,8 +66,11 @@ func printWorktreeUsage() {
 	fmt.Println("  agent-deck worktree finish \"My Session\"")
 	fmt.Println("  agent-deck worktree finish \"My Session\" --no-merge")
 	fmt.Println("  agent-deck worktree finish \"My Session\" --into develop")
+	fmt.Println("  agent-deck worktree merge \"My Session\" --commit \"wip\" --strategy squash")
 	fmt.Println("  agent-deck worktree cleanup")
 	fmt.Println("  agent-deck worktree cleanup --force")
+	fmt.Println("  agent-deck worktree usage")
+	fmt.Println("  agent-deck worktree usage --prune")
 }
 
 // handleWorktreeList lists all worktrees with session associations
@@ -107,7 +115,7 @@ func handleWorktreeList(profile string, args []string) {
 	}
 
 	// Load sessions
-	_, instances, _, err := loadSessionData(profile)
+	instances, err := readSessionData(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeInvalidOperation)
 		os.Exit(1)
@@ -219,7 +227,7 @@ func handleWorktreeInfo(profile string, args []string) {
 	}
 
 	// Load sessions
-	_, instances, _, err := loadSessionData(profile)
+	instances, err := readSessionData(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
 		os.Exit(1)
@@ -283,7 +291,9 @@ func handleWorktreeCleanup(profile string, args []string) {
 		fmt.Println()
 		fmt.Println("Orphans are detected as:")
 		fmt.Println("  - Sessions with WorktreePath set but the directory doesn't exist")
-		fmt.Println("  - Worktrees that exist but no session points to them")
+		fmt.Println("  - Worktrees agent-deck created (at its [worktree] location) that no")
+		fmt.Println("    session in any profile points to, in any repo a session uses or the")
+		fmt.Println("    current repo. Worktrees made by hand are never touched.")
 		fmt.Println()
 		fmt.Println("Options:")
 		fs.PrintDefaults()
@@ -322,34 +332,54 @@ func handleWorktreeCleanup(profile string, args []string) {
 		os.Exit(1)
 	}
 
-	// Find orphaned worktrees (exist but no session points to them)
-	var orphanedWorktrees []vcs.Worktree
-	var cleanupBackend vcs.Backend
+	// Find orphaned worktrees: ones agent-deck created that no session in
+	// any profile points to. Every repo a session has a worktree in is
+	// scanned, plus the current one, so worktrees left behind by deleted
+	// sessions show up from anywhere. Worktrees made by hand are left alone.
+	claimed, err := worktreeClaims(profile, instances)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to check other profiles' sessions: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+	layout := worktreeLayout()
+	repos := make(map[string]bool)
+	for _, inst := range instances {
+		if inst.WorktreeRepoRoot != "" && (inst.WorktreeType == "" || inst.WorktreeType == string(vcs.TypeGit)) {
+			repos[inst.WorktreeRepoRoot] = true
+		}
+	}
 
+	var orphanedWorktrees []orphanWorktree
 	if backend, bErr := detectAndCreateBackend(cwd); bErr == nil {
-		cleanupBackend = backend
-		worktrees, wErr := cleanupBackend.ListWorktrees()
-		if wErr == nil {
-			// Build set of paths that sessions use
-			sessionPaths := make(map[string]bool)
-			for _, inst := range instances {
-				sessionPaths[inst.ProjectPath] = true
-				if inst.WorktreePath != "" {
-					sessionPaths[inst.WorktreePath] = true
-				}
+		if backend.Type() == vcs.TypeGit {
+			if root, err := git.GetWorktreeBaseRoot(cwd); err == nil {
+				repos[root] = true
 			}
-
-			// Check each worktree (skip the first one which is usually the main repo)
-			for i, wt := range worktrees {
-				if i == 0 {
-					continue // Skip main repo
-				}
-				if !sessionPaths[wt.Path] {
-					orphanedWorktrees = append(orphanedWorktrees, wt)
-				}
+		} else if worktrees, wErr := backend.ListWorktrees(); wErr == nil {
+			for _, wt := range git.OrphanWorktrees(toGitWorktrees(worktrees), claimed, layout) {
+				orphanedWorktrees = append(orphanedWorktrees, orphanWorktree{Path: wt.Path, Branch: wt.Branch, backend: backend})
 			}
 		}
 	}
+	repoList := make([]string, 0, len(repos))
+	for repo := range repos {
+		repoList = append(repoList, repo)
+	}
+	sort.Strings(repoList)
+	seen := make(map[string]bool)
+	for _, repo := range repoList {
+		orphans, err := git.FindOrphanWorktrees(repo, claimed, layout)
+		if err != nil {
+			continue // repo moved or deleted since the session was created
+		}
+		for _, wt := range orphans {
+			if seen[wt.Path] {
+				continue
+			}
+			seen[wt.Path] = true
+			orphanedWorktrees = append(orphanedWorktrees, orphanWorktree{RepoRoot: repo, Path: wt.Path, Branch: wt.Branch})
+		}
+	}
 
 	// JSON output
 	if *jsonOutput {
@@ -365,8 +395,9 @@ func handleWorktreeCleanup(profile string, args []string) {
 		orphanedWorktreeData := make([]map[string]string, 0, len(orphanedWorktrees))
 		for _, wt := range orphanedWorktrees {
 			orphanedWorktreeData = append(orphanedWorktreeData, map[string]string{
-				"path":   wt.Path,
-				"branch": wt.Branch,
+				"path":      wt.Path,
+				"branch":    wt.Branch,
+				"repo_root": wt.RepoRoot,
 			})
 		}
 
@@ -399,7 +430,7 @@ func handleWorktreeCleanup(profile string, args []string) {
 		}
 
 		if len(orphanedWorktrees) > 0 {
-			fmt.Println("Orphaned Worktrees (no session associated):")
+			fmt.Println("Orphaned Worktrees (created by agent-deck, no session in any profile):")
 			for _, wt := range orphanedWorktrees {
 				fmt.Printf("  - %s (branch: %s)\n", FormatPath(wt.Path), wt.Branch)
 			}
@@ -462,11 +493,7 @@ func handleWorktreeCleanup(profile string, args []string) {
 	// Remove orphaned worktrees
 	removedWorktrees := 0
 	for _, wt := range orphanedWorktrees {
-		if cleanupBackend == nil {
-			fmt.Fprintf(os.Stderr, "Warning: no VCS backend for worktree removal\n")
-			break
-		}
-		if err := cleanupBackend.RemoveWorktree(wt.Path, false); err != nil {
+		if err := wt.remove(); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to remove worktree %s: %v\n", wt.Path, err)
 			continue
 		}
@@ -478,17 +505,97 @@ func handleWorktreeCleanup(profile string, args []string) {
 		removedSessions, removedWorktrees)
 }
 
+// orphanWorktree is a worktree no session points to. Git worktrees carry the
+// repo they belong to; worktrees of other VCS backends carry the backend.
+type orphanWorktree struct {
+	RepoRoot string
+	Path     string
+	Branch   string
+	backend  vcs.Backend
+}
+
+func (o orphanWorktree) remove() error {
+	if o.backend != nil {
+		return o.backend.RemoveWorktree(o.Path, false)
+	}
+	if err := git.RemoveWorktree(o.RepoRoot, o.Path, false); err != nil {
+		return err
+	}
+	return git.PruneWorktrees(o.RepoRoot)
+}
+
+// toGitWorktrees converts a backend's worktree listing for the orphan check
+// in package git, which every backend's listing shares.
+func toGitWorktrees(worktrees []vcs.Worktree) []git.Worktree {
+	out := make([]git.Worktree, len(worktrees))
+	for i, wt := range worktrees {
+		out[i] = git.Worktree{Path: wt.Path, Branch: wt.Branch, Commit: wt.Commit, Bare: wt.Bare}
+	}
+	return out
+}
+
+// worktreeLayout returns where this config creates worktrees, which is how
+// agent-deck's worktrees are told apart from ones made by hand.
+func worktreeLayout() git.WorktreeLayout {
+	settings := session.GetWorktreeSettings()
+	return git.WorktreeLayout{Location: settings.DefaultLocation, Template: settings.Template()}
+}
+
+// worktreeClaims returns every path a session works in (project path,
+// worktree, multi-repo worktrees) across all profiles; instances are the
+// already-loaded sessions of profile. A worktree owned by another profile's
+// session must never look orphaned, so a profile that cannot be read is an
+// error rather than skipped.
+func worktreeClaims(profile string, instances []*session.Instance) ([]string, error) {
+	var claimed []string
+	for _, inst := range instances {
+		claimed = append(claimed, inst.ProjectPath, inst.WorktreePath)
+		for _, wt := range inst.MultiRepoWorktrees {
+			claimed = append(claimed, wt.WorktreePath)
+		}
+	}
+	profiles, err := session.ListProfiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+	current := session.GetEffectiveProfile(profile)
+	for _, p := range profiles {
+		if p == current {
+			continue
+		}
+		storage, err := session.NewStorageWithProfile(p)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", p, err)
+		}
+		data, _, err := storage.LoadLite()
+		storage.Close()
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", p, err)
+		}
+		for _, d := range data {
+			claimed = append(claimed, d.ProjectPath, d.WorktreePath)
+			for _, wt := range d.MultiRepoWorktrees {
+				claimed = append(claimed, wt.WorktreePath)
+			}
+		}
+	}
+	return claimed, nil
+}
+
 // handleWorktreeFinish merges a worktree branch, removes the worktree, and deletes the session
 func handleWorktreeFinish(profile string, args []string) {
 	fs := flag.NewFlagSet("worktree finish", flag.ExitOnError)
 	into := fs.String("into", "", "Target branch to merge into (default: auto-detect)")
 	noMerge := fs.Bool("no-merge", false, "Skip merge (e.g. for PR workflows)")
 	keepBranch := fs.Bool("keep-branch", false, "Don't delete local branch after finish")
+	keepSession := fs.Bool("keep-session", false, "Keep the session, re-pointed at the repo root")
+	commitMsg := fs.String("commit", "", "Commit uncommitted worktree changes with this message before merging")
+	strategyName := fs.String("strategy", "merge", "How to integrate the branch: merge, squash, or rebase")
 	force := fs.Bool("force", false, "Skip safety checks and force branch deletion")
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
 
 	fs.Usage = func() {
-		fmt.Println("Usage: agent-deck worktree finish <session> [options]")
+		fmt.Println("Usage: agent-deck worktree finish|merge <session> [options]")
 		fmt.Println()
 		fmt.Println("Merge a worktree branch, remove the worktree, and delete the session.")
 		fmt.Println()
@@ -503,6 +610,8 @@ func handleWorktreeFinish(profile string, args []string) {
 		fmt.Println("  agent-deck worktree finish \"My Feature\" --into develop")
 		fmt.Println("  agent-deck worktree finish \"My Feature\" --no-merge")
 		fmt.Println("  agent-deck worktree finish \"My Feature\" --no-merge --force")
+		fmt.Println("  agent-deck worktree merge \"My Feature\" --commit \"final touches\" --strategy squash")
+		fmt.Println("  agent-deck worktree merge \"My Feature\" --strategy rebase --keep-session")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
@@ -544,14 +653,27 @@ func handleWorktreeFinish(profile string, args []string) {
 	worktreePath := inst.WorktreePath
 	worktreeBranch := inst.WorktreeBranch
 
+	strategy, err := git.ParseMergeStrategy(*strategyName)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
 	finishBackend, err := detectAndCreateBackend(repoRoot)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to initialize VCS: %v", err), ErrCodeInvalidOperation)
 		os.Exit(1)
 	}
 
-	// Check for uncommitted changes (uses worktree path, not repoDir — stays standalone)
-	if !*force {
+	// Commit, squash and rebase rewrite git history in the worktree itself.
+	if finishBackend.Type() != vcs.TypeGit && (*commitMsg != "" || strategy != git.MergeStrategyMerge) {
+		out.Error("--commit and --strategy are only supported for git worktrees", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	// Check for uncommitted changes (uses worktree path, not repoDir — stays standalone).
+	// --commit turns a dirty worktree into a commit instead of refusing it.
+	if !*force && *commitMsg == "" {
 		dirty, err := git.HasUncommittedChanges(worktreePath)
 		if err != nil {
 			// Worktree dir might be gone already
@@ -590,16 +712,22 @@ func handleWorktreeFinish(profile string, args []string) {
 		fmt.Printf("Session:   %s\n", inst.Title)
 		fmt.Printf("Branch:    %s\n", worktreeBranch)
 		fmt.Printf("Worktree:  %s\n", FormatPath(worktreePath))
+		if *commitMsg != "" {
+			fmt.Printf("Commit:    uncommitted changes as %q\n", *commitMsg)
+		}
 		if *noMerge {
 			fmt.Printf("Merge:     skipped (--no-merge)\n")
 		} else {
-			fmt.Printf("Merge:     %s → %s\n", worktreeBranch, targetBranch)
+			fmt.Printf("Merge:     %s → %s (%s)\n", worktreeBranch, targetBranch, strategy)
 		}
 		if *keepBranch {
 			fmt.Printf("Branch:    kept (--keep-branch)\n")
 		} else {
 			fmt.Printf("Delete:    branch '%s' will be deleted\n", worktreeBranch)
 		}
+		if *keepSession {
+			fmt.Printf("Session:   kept, moved to %s\n", FormatPath(repoRoot))
+		}
 		fmt.Println()
 		fmt.Print("Proceed? [y/N]: ")
 
@@ -613,8 +741,27 @@ func handleWorktreeFinish(profile string, args []string) {
 		fmt.Println()
 	}
 
-	// Step 1: Merge (if requested)
+	// Step 1: Commit outstanding work (if requested)
+	if *commitMsg != "" {
+		committed, err := git.CommitAll(worktreePath, *commitMsg)
+		if err != nil {
+			out.Error(fmt.Sprintf("failed to commit worktree changes: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		if committed {
+			fmt.Printf("  %s Committed uncommitted changes\n", successSymbol)
+		}
+	}
+
+	// Step 2: Merge (if requested)
 	if !*noMerge {
+		if strategy != git.MergeStrategyMerge {
+			fmt.Printf("Preparing %s (%s onto %s)...\n", worktreeBranch, strategy, targetBranch)
+			if err := git.PrepareBranchForMerge(worktreePath, targetBranch, strategy, inst.Title); err != nil {
+				out.Error(fmt.Sprintf("%s failed: %v", strategy, err), ErrCodeInvalidOperation)
+				os.Exit(1)
+			}
+		}
 		fmt.Printf("Merging %s into %s...\n", worktreeBranch, targetBranch)
 
 		// Checkout target branch in main repo
@@ -638,7 +785,7 @@ func handleWorktreeFinish(profile string, args []string) {
 		fmt.Printf("  %s Merged successfully\n", successSymbol)
 	}
 
-	// Step 2: Remove worktree
+	// Step 3: Remove worktree
 	if _, statErr := os.Stat(worktreePath); !os.IsNotExist(statErr) {
 		fmt.Printf("Removing worktree at %s...\n", FormatPath(worktreePath))
 		if err := finishBackend.RemoveWorktree(worktreePath, *force); err != nil {
@@ -649,7 +796,7 @@ func handleWorktreeFinish(profile string, args []string) {
 	}
 	_ = finishBackend.PruneWorktrees()
 
-	// Step 3: Delete branch (if not --keep-branch)
+	// Step 4: Delete branch (if not --keep-branch)
 	if !*keepBranch {
 		fmt.Printf("Deleting branch %s...\n", worktreeBranch)
 		if err := finishBackend.DeleteBranch(worktreeBranch, *force); err != nil {
@@ -659,14 +806,29 @@ func handleWorktreeFinish(profile string, args []string) {
 		}
 	}
 
-	// Step 4: Kill tmux session
+	// Step 5: Kill tmux session
 	if inst.Exists() {
 		if err := inst.Kill(); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to kill tmux session: %v\n", err)
 		}
 	}
 
-	// Step 5: Remove session from agent-deck.
+	// Step 6: Keep the session (re-pointed at the repo root) or remove it.
+	if *keepSession {
+		inst.ProjectPath = repoRoot
+		inst.WorktreePath = ""
+		inst.WorktreeRepoRoot = ""
+		inst.WorktreeBranch = ""
+		inst.WorktreeType = ""
+		if err := saveSessionData(storage, instances, groups); err != nil {
+			out.Error(fmt.Sprintf("failed to save session data: %v", err), ErrCodeInvalidOperation)
+			os.Exit(1)
+		}
+		printWorktreeFinishResult(out, *jsonOutput, inst, worktreeBranch, targetBranch, strategy, !*noMerge, !*keepBranch, true)
+		return
+	}
+
+	// Step 6b: Remove session from agent-deck.
 	//
 	// #1396: this must use the targeted RemoveSessionAndVerify path (the same
 	// one `session remove` uses), NOT saveSessionData/SaveWithGroups. When the
@@ -684,23 +846,34 @@ func handleWorktreeFinish(profile string, args []string) {
 		os.Exit(1)
 	}
 
-	if *jsonOutput {
+	printWorktreeFinishResult(out, *jsonOutput, inst, worktreeBranch, targetBranch, strategy, !*noMerge, !*keepBranch, false)
+}
+
+// printWorktreeFinishResult reports the outcome of a worktree finish/merge.
+func printWorktreeFinishResult(out *CLIOutput, jsonOutput bool, inst *session.Instance, branch, target string, strategy git.MergeStrategy, merged, branchDeleted, sessionKept bool) {
+	if jsonOutput {
 		out.Print("", map[string]interface{}{
 			"success":        true,
 			"session":        inst.Title,
 			"session_id":     inst.ID,
-			"branch":         worktreeBranch,
-			"merged_into":    targetBranch,
-			"merged":         !*noMerge,
-			"branch_deleted": !*keepBranch,
+			"branch":         branch,
+			"merged_into":    target,
+			"merged":         merged,
+			"strategy":       string(strategy),
+			"branch_deleted": branchDeleted,
+			"session_kept":   sessionKept,
 		})
+		return
+	}
+	if sessionKept {
+		fmt.Printf("\n%s Finished: session '%s' kept at %s, worktree cleaned up", successSymbol, inst.Title, FormatPath(inst.ProjectPath))
 	} else {
 		fmt.Printf("\n%s Finished: session '%s' removed, worktree cleaned up", successSymbol, inst.Title)
-		if !*noMerge {
-			fmt.Printf(", branch merged into %s", targetBranch)
-		}
-		fmt.Println()
 	}
+	if merged {
+		fmt.Printf(", branch merged into %s", target)
+	}
+	fmt.Println()
 }
 
 // truncateString truncates a string to maxLen, adding "..." if truncated
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/vcs"
+)
+
+// worktreeUsageEntry is one linked worktree in the `worktree usage` view.
+type worktreeUsageEntry struct {
+	Repo       string    `json:"repo"`
+	Path       string    `json:"path"`
+	Branch     string    `json:"branch"`
+	SizeBytes  int64     `json:"size_bytes"`
+	LastCommit time.Time `json:"last_commit,omitzero"`
+	Session    string    `json:"session,omitempty"`
+	SessionID  string    `json:"session_id,omitempty"`
+	// Orphaned: agent-deck created the worktree and no session in any
+	// profile points at it. Worktrees made by hand are never orphaned.
+	Orphaned bool `json:"orphaned"`
+	// Missing: git still tracks the worktree but its directory is gone;
+	// `git worktree prune` clears these.
+	Missing bool `json:"missing,omitempty"`
+}
+
+// worktreeUsageRepos returns the repositories to scan: every repo a session
+// created a worktree in, plus extra (typically the cwd repo), de-duplicated.
+func worktreeUsageRepos(instances []*session.Instance, extra ...string) []string {
+	seen := make(map[string]bool)
+	var repos []string
+	add := func(repo string) {
+		if repo == "" {
+			return
+		}
+		repo = filepath.Clean(repo)
+		if !seen[repo] {
+			seen[repo] = true
+			repos = append(repos, repo)
+		}
+	}
+	for _, inst := range instances {
+		add(inst.WorktreeRepoRoot)
+	}
+	for _, repo := range extra {
+		add(repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
+
+// collectWorktreeUsage lists the linked worktrees of each repo with their
+// session association in this profile. Orphans are decided by
+// git.OrphanWorktrees against claimed (every profile's session paths) and
+// layout. The main worktree and bare entries are skipped — they are never
+// prune candidates. Sizes and commit dates are filled in by the caller so
+// this stays cheap to test.
+func collectWorktreeUsage(repos []string, instances []*session.Instance, claimed []string, layout git.WorktreeLayout, list func(repo string) ([]vcs.Worktree, error)) ([]worktreeUsageEntry, []error) {
+	sessionByPath := make(map[string]*session.Instance)
+	for _, inst := range instances {
+		if inst.ProjectPath != "" {
+			sessionByPath[filepath.Clean(inst.ProjectPath)] = inst
+		}
+		if inst.WorktreePath != "" {
+			sessionByPath[filepath.Clean(inst.WorktreePath)] = inst
+		}
+	}
+
+	var entries []worktreeUsageEntry
+	var errs []error
+	for _, repo := range repos {
+		worktrees, err := list(repo)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", repo, err))
+			continue
+		}
+		orphaned := make(map[string]bool)
+		for _, wt := range git.OrphanWorktrees(toGitWorktrees(worktrees), claimed, layout) {
+			orphaned[wt.Path] = true
+		}
+		for i, wt := range worktrees {
+			if i == 0 || wt.Bare {
+				continue
+			}
+			entry := worktreeUsageEntry{Repo: repo, Path: wt.Path, Branch: wt.Branch}
+			if inst := sessionByPath[filepath.Clean(wt.Path)]; inst != nil {
+				entry.Session = inst.Title
+				entry.SessionID = inst.ID
+			}
+			entry.Orphaned = orphaned[wt.Path]
+			if _, statErr := os.Stat(wt.Path); os.IsNotExist(statErr) {
+				entry.Missing = true
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, errs
+}
+
+// handleWorktreeUsage lists worktrees across every repo the profile's
+// sessions use, with disk usage, and optionally prunes the orphaned ones.
+func handleWorktreeUsage(profile string, args []string) {
+	fs := flag.NewFlagSet("worktree usage", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	prune := fs.Bool("prune", false, "Remove orphaned worktrees and run `git worktree prune`")
+	yes := fs.Bool("yes", false, "Skip the confirmation prompt when pruning")
+	noSize := fs.Bool("no-size", false, "Skip disk usage calculation (faster on large trees)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck worktree usage [options]")
+		fmt.Println()
+		fmt.Println("List linked worktrees in every repository used by this profile's sessions")
+		fmt.Println("(plus the current repository) with size, branch, session, and last commit.")
+		fmt.Println()
+		fmt.Println("With --prune, orphaned worktrees (created by agent-deck, no session in any")
+		fmt.Println("profile) without uncommitted changes are removed, and `git worktree prune`")
+		fmt.Println("clears references to deleted ones. Worktrees made by hand are never removed.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		os.Exit(1)
+	}
+
+	out := NewCLIOutput(*jsonOutput, false)
+	if *jsonOutput && *prune && !*yes {
+		out.Error("--prune with --json requires --yes (no interactive confirmation)", ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	instances, err := readSessionData(profile)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	var extra []string
+	if cwd, cwdErr := os.Getwd(); cwdErr == nil {
+		// Resolve to the main worktree so running from inside a linked
+		// worktree does not list the same repo twice.
+		if root, rootErr := git.GetMainWorktreePath(cwd); rootErr == nil {
+			extra = append(extra, root)
+		}
+	}
+	repos := worktreeUsageRepos(instances, extra...)
+	claimed, err := worktreeClaims(profile, instances)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to check other profiles' sessions: %v", err), ErrCodeInvalidOperation)
+		os.Exit(1)
+	}
+
+	backends := make(map[string]vcs.Backend)
+	entries, scanErrs := collectWorktreeUsage(repos, instances, claimed, worktreeLayout(), func(repo string) ([]vcs.Worktree, error) {
+		backend, err := detectAndCreateBackend(repo)
+		if err != nil {
+			return nil, err
+		}
+		backends[repo] = backend
+		return backend.ListWorktrees()
+	})
+	for _, scanErr := range scanErrs {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", scanErr)
+	}
+
+	var total, orphanedTotal int64
+	for i := range entries {
+		e := &entries[i]
+		if e.Missing {
+			continue
+		}
+		if !*noSize {
+			if size, sizeErr := git.DirSize(e.Path); sizeErr == nil {
+				e.SizeBytes = size
+			}
+		}
+		if t, commitErr := git.LastCommitTime(e.Path); commitErr == nil {
+			e.LastCommit = t
+		}
+		total += e.SizeBytes
+		if e.Orphaned {
+			orphanedTotal += e.SizeBytes
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].SizeBytes > entries[j].SizeBytes })
+
+	if *jsonOutput && !*prune {
+		out.Print("", map[string]interface{}{
+			"repos":                repos,
+			"worktrees":            entries,
+			"count":                len(entries),
+			"total_bytes":          total,
+			"orphaned_total_bytes": orphanedTotal,
+		})
+		return
+	}
+
+	if !*jsonOutput {
+		printWorktreeUsageTable(entries, total, orphanedTotal)
+	}
+	if !*prune {
+		return
+	}
+
+	var candidates []worktreeUsageEntry
+	for _, e := range entries {
+		if e.Orphaned && !e.Missing {
+			candidates = append(candidates, e)
+		}
+	}
+	if !*yes && !*jsonOutput && len(candidates) > 0 {
+		fmt.Printf("\nRemove %d orphaned worktree(s) (%s)? [y/N]: ", len(candidates), formatSize(orphanedTotal))
+		response, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Aborted.")
+			return
+		}
+	}
+
+	var removed, skipped []string
+	var freed int64
+	for _, e := range candidates {
+		backend := backends[e.Repo]
+		if backend == nil {
+			continue
+		}
+		if dirty, dirtyErr := git.HasUncommittedChanges(e.Path); dirtyErr == nil && dirty {
+			skipped = append(skipped, e.Path)
+			if !*jsonOutput {
+				fmt.Printf("Skipped (uncommitted changes): %s\n", FormatPath(e.Path))
+			}
+			continue
+		}
+		if rmErr := backend.RemoveWorktree(e.Path, false); rmErr != nil {
+			skipped = append(skipped, e.Path)
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove worktree %s: %v\n", e.Path, rmErr)
+			continue
+		}
+		removed = append(removed, e.Path)
+		freed += e.SizeBytes
+		if !*jsonOutput {
+			fmt.Printf("Removed worktree: %s\n", FormatPath(e.Path))
+		}
+	}
+	for _, repo := range repos {
+		if backend := backends[repo]; backend != nil {
+			if pruneErr := backend.PruneWorktrees(); pruneErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", repo, pruneErr)
+			}
+		}
+	}
+
+	if *jsonOutput {
+		out.Print("", map[string]interface{}{
+			"removed":     removed,
+			"skipped":     skipped,
+			"freed_bytes": freed,
+		})
+		return
+	}
+	fmt.Printf("\nPrune complete: removed %d worktree(s), freed %s\n", len(removed), formatSize(freed))
+}
+
+func printWorktreeUsageTable(entries []worktreeUsageEntry, total, orphanedTotal int64) {
+	if len(entries) == 0 {
+		fmt.Println("No linked worktrees found.")
+		return
+	}
+	fmt.Printf("%-40s  %-20s  %9s  %-10s  %s\n", "PATH", "BRANCH", "SIZE", "COMMITTED", "SESSION")
+	for _, e := range entries {
+		sessionStr := e.Session
+		switch {
+		case e.Missing:
+			sessionStr = "(missing — prunable)"
+		case e.Orphaned:
+			sessionStr = "(orphaned)"
+		}
+		committed := "-"
+		if !e.LastCommit.IsZero() {
+			committed = e.LastCommit.Format("2006-01-02")
+		}
+		size := "-"
+		if !e.Missing {
+			size = formatSize(e.SizeBytes)
+		}
+		fmt.Printf("%-40s  %-20s  %9s  %-10s  %s\n",
+			truncateString(FormatPath(e.Path), 40),
+			truncateString(e.Branch, 20),
+			size,
+			committed,
+			truncateString(sessionStr, 30))
+	}
+	fmt.Printf("\nTotal: %d worktree(s), %s (%s orphaned)\n", len(entries), formatSize(total), formatSize(orphanedTotal))
+}
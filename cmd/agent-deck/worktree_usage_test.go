@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/vcs"
+)
+
+func TestCollectWorktreeUsage_OrphansAndMissing(t *testing.T) {
+	tmp := t.TempDir()
+	repo := filepath.Join(tmp, "repo")
+	owned := filepath.Join(tmp, "repo-owned")
+	orphan := filepath.Join(tmp, "repo-orphan")
+	gone := filepath.Join(tmp, "repo-gone")
+	handMade := filepath.Join(tmp, "scratch")
+	other := filepath.Join(tmp, "repo-other")
+	for _, dir := range []string{repo, owned, orphan, handMade, other} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	instances := []*session.Instance{
+		{ID: "s1", Title: "owner", ProjectPath: owned, WorktreePath: owned, WorktreeRepoRoot: repo},
+	}
+	repos := worktreeUsageRepos(instances, repo, "")
+	if len(repos) != 1 || repos[0] != repo {
+		t.Fatalf("repos = %v, want [%s]", repos, repo)
+	}
+
+	// other belongs to a session in another profile.
+	claimed := []string{owned, other}
+	entries, errs := collectWorktreeUsage(append(repos, "/broken"), instances, claimed, git.WorktreeLayout{}, func(r string) ([]vcs.Worktree, error) {
+		if r == "/broken" {
+			return nil, errors.New("not a repo")
+		}
+		return []vcs.Worktree{
+			{Path: repo, Branch: "main"},
+			{Path: owned, Branch: "owned"},
+			{Path: orphan, Branch: "orphan"},
+			{Path: gone, Branch: "gone"},
+			{Path: handMade, Branch: "scratch"},
+			{Path: other, Branch: "other"},
+		}, nil
+	})
+	if len(errs) != 1 {
+		t.Fatalf("errs = %v, want one scan error", errs)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("entries = %+v, want 5 linked worktrees (main skipped)", entries)
+	}
+	byPath := map[string]worktreeUsageEntry{}
+	for _, e := range entries {
+		byPath[e.Path] = e
+	}
+	if e := byPath[owned]; e.Orphaned || e.SessionID != "s1" {
+		t.Fatalf("owned entry = %+v", e)
+	}
+	if e := byPath[orphan]; !e.Orphaned || e.Missing {
+		t.Fatalf("orphan entry = %+v", e)
+	}
+	if e := byPath[gone]; !e.Orphaned || !e.Missing {
+		t.Fatalf("gone entry = %+v", e)
+	}
+	if e := byPath[handMade]; e.Orphaned {
+		t.Fatalf("a worktree made by hand must never be orphaned: %+v", e)
+	}
+	if e := byPath[other]; e.Orphaned || e.SessionID != "" {
+		t.Fatalf("a worktree claimed by another profile must not be orphaned: %+v", e)
+	}
+}
@@ -2,6 +2,8 @@ package costs
 
 import (
 	"database/sql"
+	"fmt"
+	"sort"
 	"time"
 )
 
@@ -19,9 +21,26 @@ type BudgetResult struct {
 	UsedMicro  int64
 	LimitMicro int64
 	Percentage float64
+
+	// Scope names the budget that produced the result ("daily",
+	// "group work", "session api-server"). Set by Alerts.
+	Scope string
+	// Tokens is true when UsedMicro/LimitMicro hold token counts rather
+	// than microdollars (a *_token_limit budget).
+	Tokens bool
+}
+
+// Describe renders the result for the status bar, e.g.
+// "group work 82% of $10.00 daily" or "session api 100% of 2.0M tokens".
+func (r BudgetResult) Describe() string {
+	limit := FormatUSD(r.LimitMicro)
+	if r.Tokens {
+		limit = FormatTokens(r.LimitMicro) + " tokens"
+	}
+	return fmt.Sprintf("%s %.0f%% of %s", r.Scope, r.Percentage, limit)
 }
 
-// BudgetConfig holds budget limits in microdollars.
+// BudgetConfig holds budget limits in microdollars (token limits in tokens).
 type BudgetConfig struct {
 	DailyLimit    int64
 	WeeklyLimit   int64
@@ -29,6 +48,18 @@ type BudgetConfig struct {
 	GroupLimits   map[string]int64 // group name -> daily limit in microdollars
 	SessionLimits map[string]int64 // session ID -> total lifetime limit in microdollars
 	Timezone      *time.Location   // for determining day/week/month boundaries
+
+	DailyTokenLimit    int64            // all sessions, tokens per day
+	GroupTokenLimits   map[string]int64 // group path -> daily token limit
+	SessionTokenLimits map[string]int64 // session ID or title -> lifetime token limit
+}
+
+// BudgetTargets tells Alerts which sessions make up each budgeted group and
+// how sessions are titled, so [costs.budgets.sessions] may be keyed by
+// either session ID or title.
+type BudgetTargets struct {
+	Groups   map[string][]string // group path -> session IDs (including subgroups)
+	Sessions map[string]string   // session ID -> title
 }
 
 type BudgetChecker struct {
@@ -143,6 +174,95 @@ func (b *BudgetChecker) Check(sessionID, groupName string) BudgetResult {
 	return worst
 }
 
+// Alerts evaluates every configured budget outside a transaction (for the
+// TUI) and returns those at or past the 80% warning threshold, worst first.
+// Global and group budgets cover the current day/week/month; session
+// budgets cover the session's lifetime.
+func (b *BudgetChecker) Alerts(targets BudgetTargets) []BudgetResult {
+	tz := b.cfg.Timezone
+	if tz == nil {
+		tz = time.Local
+	}
+	var alerts []BudgetResult
+	add := func(r BudgetResult, scope string, tokens bool) {
+		if r.Action == BudgetActionNone {
+			return
+		}
+		r.Scope, r.Tokens = scope, tokens
+		alerts = append(alerts, r)
+	}
+
+	if b.cfg.DailyLimit > 0 || b.cfg.DailyTokenLimit > 0 {
+		if today, err := b.store.TotalToday(); err == nil {
+			add(evaluate(today.TotalCostMicrodollars, b.cfg.DailyLimit, "daily global limit exceeded"), "daily", false)
+			add(evaluate(today.TotalTokens(), b.cfg.DailyTokenLimit, "daily global token limit exceeded"), "daily", true)
+		}
+	}
+	if b.cfg.WeeklyLimit > 0 {
+		if week, err := b.store.TotalThisWeek(); err == nil {
+			add(evaluate(week.TotalCostMicrodollars, b.cfg.WeeklyLimit, "weekly global limit exceeded"), "weekly", false)
+		}
+	}
+	if b.cfg.MonthlyLimit > 0 {
+		if month, err := b.store.TotalThisMonth(); err == nil {
+			add(evaluate(month.TotalCostMicrodollars, b.cfg.MonthlyLimit, "monthly global limit exceeded"), "monthly", false)
+		}
+	}
+
+	for group, ids := range targets.Groups {
+		usdLimit, tokenLimit := b.cfg.GroupLimits[group], b.cfg.GroupTokenLimits[group]
+		if (usdLimit <= 0 && tokenLimit <= 0) || len(ids) == 0 {
+			continue
+		}
+		sum, err := b.store.TotalForSessionsSince(ids, startOfDay(tz))
+		if err != nil {
+			continue
+		}
+		add(evaluate(sum.TotalCostMicrodollars, usdLimit, "group daily limit exceeded"), "group "+group, false)
+		add(evaluate(sum.TotalTokens(), tokenLimit, "group daily token limit exceeded"), "group "+group, true)
+	}
+
+	for id, title := range targets.Sessions {
+		usdLimit := lookupSessionLimit(b.cfg.SessionLimits, id, title)
+		tokenLimit := lookupSessionLimit(b.cfg.SessionTokenLimits, id, title)
+		if usdLimit <= 0 && tokenLimit <= 0 {
+			continue
+		}
+		sum, err := b.store.TotalBySession(id)
+		if err != nil {
+			continue
+		}
+		name := title
+		if name == "" {
+			name = id
+		}
+		add(evaluate(sum.TotalCostMicrodollars, usdLimit, "session lifetime limit exceeded"), "session "+name, false)
+		add(evaluate(sum.TotalTokens(), tokenLimit, "session lifetime token limit exceeded"), "session "+name, true)
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		if alerts[i].Action != alerts[j].Action {
+			return alerts[i].Action > alerts[j].Action
+		}
+		if alerts[i].Percentage != alerts[j].Percentage {
+			return alerts[i].Percentage > alerts[j].Percentage
+		}
+		return alerts[i].Scope < alerts[j].Scope
+	})
+	return alerts
+}
+
+// lookupSessionLimit finds a session budget keyed by ID, falling back to title.
+func lookupSessionLimit(limits map[string]int64, id, title string) int64 {
+	if limit, ok := limits[id]; ok {
+		return limit
+	}
+	if title != "" {
+		return limits[title]
+	}
+	return 0
+}
+
 func evaluate(used, limit int64, reason string) BudgetResult {
 	if limit <= 0 {
 		return BudgetResult{Action: BudgetActionNone}
@@ -86,3 +86,50 @@ func TestBudgetCheck_UnderThreshold(t *testing.T) {
 		t.Errorf("action = %v, want None (20%%)", result.Action)
 	}
 }
+
+func TestBudgetAlerts_GroupSessionAndTokens(t *testing.T) {
+	s := testStore(t)
+	now := time.Now()
+	events := []costs.CostEvent{
+		{ID: "e1", SessionID: "s1", Timestamp: now, Model: "m", CostMicrodollars: 9_000_000, InputTokens: 500_000},
+		{ID: "e2", SessionID: "s2", Timestamp: now, Model: "m", CostMicrodollars: 2_000_000, InputTokens: 100_000},
+	}
+	for _, ev := range events {
+		if err := s.WriteCostEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b := costs.NewBudgetChecker(costs.BudgetConfig{
+		GroupLimits:        map[string]int64{"work": 10_000_000},
+		SessionTokenLimits: map[string]int64{"api": 600_000},
+		SessionLimits:      map[string]int64{"s2": 50_000_000},
+	}, s)
+	alerts := b.Alerts(costs.BudgetTargets{
+		Groups:   map[string][]string{"work": {"s1", "s2"}},
+		Sessions: map[string]string{"s1": "api", "s2": "web"},
+	})
+	if len(alerts) != 2 {
+		t.Fatalf("alerts = %+v, want group (over) + session tokens (warn)", alerts)
+	}
+	if alerts[0].Scope != "group work" || alerts[0].Action != costs.BudgetActionStop || alerts[0].Tokens {
+		t.Errorf("alerts[0] = %+v, want over-budget USD alert for group work", alerts[0])
+	}
+	if alerts[1].Scope != "session api" || alerts[1].Action != costs.BudgetActionWarn || !alerts[1].Tokens {
+		t.Errorf("alerts[1] = %+v, want token warning for session api (keyed by title)", alerts[1])
+	}
+	if got := alerts[1].Describe(); got != "session api 83% of 600.0K tokens" {
+		t.Errorf("Describe() = %q", got)
+	}
+}
+
+func TestBudgetAlerts_NoneUnderThreshold(t *testing.T) {
+	s := testStore(t)
+	if err := s.WriteCostEvent(costs.CostEvent{ID: "e1", SessionID: "s1", Timestamp: time.Now(), Model: "m", CostMicrodollars: 1_000_000}); err != nil {
+		t.Fatal(err)
+	}
+	b := costs.NewBudgetChecker(costs.BudgetConfig{DailyLimit: 50_000_000, DailyTokenLimit: 1_000_000}, s)
+	if alerts := b.Alerts(costs.BudgetTargets{}); len(alerts) != 0 {
+		t.Errorf("alerts = %+v, want none", alerts)
+	}
+}
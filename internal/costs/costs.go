@@ -28,6 +28,11 @@ type CostSummary struct {
 	EventCount            int
 }
 
+// TotalTokens returns input + output + cache read/write tokens.
+func (c CostSummary) TotalTokens() int64 {
+	return c.TotalInputTokens + c.TotalOutputTokens + c.TotalCacheReadTokens + c.TotalCacheWriteTokens
+}
+
 // SessionCost represents per-session cost totals.
 type SessionCost struct {
 	SessionID        string
@@ -49,6 +54,18 @@ func FormatUSD(microdollars int64) string {
 	return fmt.Sprintf("$%.2f", float64(microdollars)/1_000_000)
 }
 
+// FormatTokens renders a token count compactly: 950, 12.5K, 2.0M.
+func FormatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fK", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
 // RemoteCostSummary mirrors `agent-deck costs summary --json` output. #1101:
 // when an SSH remote is configured, the TUI fetches one of these per remote
 // and folds the totals into the local cost-line totals so the status bar
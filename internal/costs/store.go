@@ -130,6 +130,30 @@ func (s *Store) TopSessionsByCost(limit int) ([]SessionCost, error) {
 	return result, rows.Err()
 }
 
+// TotalsBySession returns every session's all-time cost in microdollars,
+// keyed by session ID.
+func (s *Store) TotalsBySession() (map[string]int64, error) {
+	rows, err := s.db.Query(`
+		SELECT session_id, SUM(cost_microdollars)
+		FROM cost_events
+		GROUP BY session_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]int64)
+	for rows.Next() {
+		var id string
+		var total int64
+		if err := rows.Scan(&id, &total); err != nil {
+			return nil, err
+		}
+		result[id] = total
+	}
+	return result, rows.Err()
+}
+
 // CostByModel returns total cost per model.
 func (s *Store) CostByModel() (map[string]int64, error) {
 	rows, err := s.db.Query(`
@@ -259,6 +283,21 @@ func (s *Store) GroupRunningTotal(tx *sql.Tx, sessionIDs []string, since time.Ti
 	return total, err
 }
 
+// TotalForSessionsSince returns aggregated costs for a set of sessions since
+// the given time (non-transactional counterpart of GroupRunningTotal).
+func (s *Store) TotalForSessionsSince(sessionIDs []string, since time.Time) (CostSummary, error) {
+	if len(sessionIDs) == 0 {
+		return CostSummary{}, nil
+	}
+	args := make([]any, 0, len(sessionIDs)+1)
+	for _, id := range sessionIDs {
+		args = append(args, id)
+	}
+	args = append(args, since.UTC().Format(time.RFC3339))
+	// #nosec G202 -- only "?" placeholders are concatenated; values go through args.
+	return s.querySum(`WHERE session_id IN (?`+repeatArg(len(sessionIDs)-1)+`) AND timestamp >= ?`, args...)
+}
+
 func (s *Store) querySum(where string, args ...any) (CostSummary, error) {
 	var cs CostSummary
 	err := s.db.QueryRow(`
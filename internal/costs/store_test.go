@@ -131,6 +131,23 @@ func TestStore_TopSessionsByCost(t *testing.T) {
 	}
 }
 
+func TestStore_TotalsBySession(t *testing.T) {
+	s := testStore(t)
+	now := time.Now()
+
+	_ = s.WriteCostEvent(costs.CostEvent{ID: "e1", SessionID: "s1", Timestamp: now, Model: "m", CostMicrodollars: 50000})
+	_ = s.WriteCostEvent(costs.CostEvent{ID: "e2", SessionID: "s1", Timestamp: now, Model: "m", CostMicrodollars: 25000})
+	_ = s.WriteCostEvent(costs.CostEvent{ID: "e3", SessionID: "s2", Timestamp: now, Model: "m", CostMicrodollars: 30000})
+
+	totals, err := s.TotalsBySession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(totals) != 2 || totals["s1"] != 75000 || totals["s2"] != 30000 {
+		t.Errorf("totals = %v, want s1=75000 s2=30000", totals)
+	}
+}
+
 func TestStore_Retention(t *testing.T) {
 	s := testStore(t)
 	old := time.Now().AddDate(0, 0, -100)
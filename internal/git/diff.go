@@ -0,0 +1,83 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// DiffFile is one file of a worktree diff.
+type DiffFile struct {
+	Path    string
+	Added   int
+	Deleted int
+	Patch   string // the file's section of `git diff`, headers included
+}
+
+// WorktreeDiff returns what a worktree changed relative to base: everything
+// since the merge base of base and HEAD, committed or not, split per file.
+// Untracked files are not part of git diff and are not listed.
+func WorktreeDiff(worktreePath, base string) ([]DiffFile, error) {
+	mb, err := exec.Command("git", "-C", worktreePath, "merge-base", base, "HEAD").Output()
+	if err != nil {
+		return nil, fmt.Errorf("no merge base between %s and HEAD: %w", base, err)
+	}
+	cmd := exec.Command("git", "-C", worktreePath, "diff", "--no-color", "--no-ext-diff", strings.TrimSpace(string(mb)))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return ParseDiff(string(out)), nil
+}
+
+// ParseDiff splits unified `git diff` output into per-file sections and
+// counts each file's added and deleted lines.
+func ParseDiff(diff string) []DiffFile {
+	var files []DiffFile
+	var cur *DiffFile
+	var patch strings.Builder
+	flush := func() {
+		if cur != nil {
+			cur.Patch = strings.TrimRight(patch.String(), "\n")
+			files = append(files, *cur)
+		}
+		patch.Reset()
+	}
+	inHunk := false
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			flush()
+			cur = &DiffFile{Path: diffPath(line)}
+			inHunk = false
+		}
+		if cur == nil {
+			continue
+		}
+		patch.WriteString(line)
+		patch.WriteByte('\n')
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+			if p, ok := strings.CutPrefix(line, "+++ b/"); ok {
+				cur.Path = p
+			}
+		case strings.HasPrefix(line, "+"):
+			cur.Added++
+		case strings.HasPrefix(line, "-"):
+			cur.Deleted++
+		}
+	}
+	flush()
+	return files
+}
+
+// diffPath extracts the new path from a "diff --git a/x b/x" header. Used
+// until a "+++ b/" line (absent for binary and pure renames) gives it exactly.
+func diffPath(header string) string {
+	rest := strings.TrimPrefix(header, "diff --git ")
+	if i := strings.LastIndex(rest, " b/"); i >= 0 {
+		return rest[i+3:]
+	}
+	return rest
+}
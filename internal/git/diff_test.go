@@ -0,0 +1,63 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDiff(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
+-// old
++// new
++// more
+diff --git a/logo.png b/logo.png
+new file mode 100644
+index 0000000..3333333
+Binary files /dev/null and b/logo.png differ
+`
+	files := ParseDiff(diff)
+	if len(files) != 2 {
+		t.Fatalf("files = %d, want 2", len(files))
+	}
+	if f := files[0]; f.Path != "main.go" || f.Added != 2 || f.Deleted != 1 {
+		t.Errorf("main.go = %+v", f)
+	}
+	if f := files[1]; f.Path != "logo.png" || f.Added != 0 || f.Deleted != 0 {
+		t.Errorf("logo.png = %+v", f)
+	}
+	if ParseDiff("") != nil {
+		t.Error("empty diff should parse to no files")
+	}
+}
+
+func TestWorktreeDiff(t *testing.T) {
+	dir := t.TempDir()
+	createTestRepo(t, dir)
+	runGit(t, dir, "checkout", "-b", "feature")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Test Repo\nchanged\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "commit", "-am", "edit readme")
+	// Uncommitted edits count too.
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Test Repo\nchanged\nagain\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := WorktreeDiff(dir, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Path != "README.md" || files[0].Added != 3 || files[0].Deleted != 1 {
+		t.Errorf("files = %+v", files)
+	}
+
+	if _, err := WorktreeDiff(dir, "no-such-branch"); err == nil {
+		t.Error("WorktreeDiff against a missing base should fail")
+	}
+}
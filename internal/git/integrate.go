@@ -0,0 +1,144 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// MergeStrategy selects how a worktree branch is integrated into its base
+// branch when the worktree is finished.
+type MergeStrategy string
+
+const (
+	// MergeStrategyMerge merges the branch as-is (a merge commit unless the
+	// base can fast-forward).
+	MergeStrategyMerge MergeStrategy = "merge"
+	// MergeStrategySquash collapses the branch into a single commit on top
+	// of the base, so the base fast-forwards to it.
+	MergeStrategySquash MergeStrategy = "squash"
+	// MergeStrategyRebase replays the branch's commits on top of the base,
+	// so the base fast-forwards to a linear history.
+	MergeStrategyRebase MergeStrategy = "rebase"
+)
+
+// MergeStrategies lists the supported strategies in display order.
+var MergeStrategies = []MergeStrategy{MergeStrategyMerge, MergeStrategySquash, MergeStrategyRebase}
+
+// ParseMergeStrategy validates a user-supplied strategy name. An empty name
+// means MergeStrategyMerge.
+func ParseMergeStrategy(name string) (MergeStrategy, error) {
+	switch s := MergeStrategy(strings.ToLower(strings.TrimSpace(name))); s {
+	case "":
+		return MergeStrategyMerge, nil
+	case MergeStrategyMerge, MergeStrategySquash, MergeStrategyRebase:
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown merge strategy %q (want merge, squash or rebase)", name)
+}
+
+// Next returns the strategy after s in MergeStrategies, wrapping around.
+func (s MergeStrategy) Next() MergeStrategy {
+	for i, m := range MergeStrategies {
+		if m == s {
+			return MergeStrategies[(i+1)%len(MergeStrategies)]
+		}
+	}
+	return MergeStrategyMerge
+}
+
+// CommitAll stages every change in dir (including untracked files) and
+// commits it with message. It reports whether a commit was made; a clean
+// tree is not an error.
+func CommitAll(dir, message string) (bool, error) {
+	dirty, err := HasUncommittedChanges(dir)
+	if err != nil || !dirty {
+		return false, err
+	}
+	if strings.TrimSpace(message) == "" {
+		return false, errors.New("commit message is required")
+	}
+	if out, err := exec.Command("git", "-C", dir, "add", "-A").CombinedOutput(); err != nil {
+		return false, fmt.Errorf("stage changes: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	if out, err := exec.Command("git", "-C", dir, "commit", "-m", message).CombinedOutput(); err != nil {
+		return false, fmt.Errorf("commit: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return true, nil
+}
+
+// PrepareBranchForMerge rewrites the branch checked out in worktreePath so
+// that merging it into base produces the history strategy asks for. Squash
+// collapses every commit since the merge-base into one commit with
+// squashMessage; squash and rebase then replay onto base, leaving the branch
+// a fast-forward of it. MergeStrategyMerge is a no-op.
+//
+// A failed rebase is aborted and a squash undone, so the branch is left
+// exactly as it was.
+func PrepareBranchForMerge(worktreePath, base string, strategy MergeStrategy, squashMessage string) error {
+	switch strategy {
+	case MergeStrategyMerge, "":
+		return nil
+	case MergeStrategySquash:
+		head, err := revParseInDir(worktreePath, "HEAD")
+		if err != nil {
+			return fmt.Errorf("resolve HEAD: %w", err)
+		}
+		if err := squashBranch(worktreePath, base, squashMessage); err != nil {
+			return err
+		}
+		if err := rebaseOnto(worktreePath, base); err != nil {
+			// The squash commit has the original tip's tree, so moving the
+			// branch back restores it without touching any files.
+			if out, resetErr := exec.Command("git", "-C", worktreePath, "reset", "--soft", head).CombinedOutput(); resetErr != nil {
+				return fmt.Errorf("%w; restoring the unsquashed branch (%s) failed: %s", err, head, strings.TrimSpace(string(out)))
+			}
+			return err
+		}
+		return nil
+	case MergeStrategyRebase:
+	default:
+		return fmt.Errorf("unknown merge strategy %q", strategy)
+	}
+	return rebaseOnto(worktreePath, base)
+}
+
+// squashBranch soft-resets the branch to its merge-base with base and
+// recommits the combined change. A branch with no commits ahead is left
+// untouched.
+func squashBranch(worktreePath, base, message string) error {
+	mergeBase, err := exec.Command("git", "-C", worktreePath, "merge-base", base, "HEAD").Output()
+	if err != nil {
+		return fmt.Errorf("find merge-base with %s: %w", base, err)
+	}
+	mb := strings.TrimSpace(string(mergeBase))
+	head, err := revParseInDir(worktreePath, "HEAD")
+	if err != nil {
+		return fmt.Errorf("resolve HEAD: %w", err)
+	}
+	if head == mb {
+		return nil
+	}
+	if strings.TrimSpace(message) == "" {
+		return errors.New("squash commit message is required")
+	}
+	if out, err := exec.Command("git", "-C", worktreePath, "reset", "--soft", mb).CombinedOutput(); err != nil {
+		return fmt.Errorf("squash reset: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	if out, err := exec.Command("git", "-C", worktreePath, "commit", "-m", message).CombinedOutput(); err != nil {
+		// Put the original commits back rather than leave them staged.
+		_, _ = exec.Command("git", "-C", worktreePath, "reset", "--soft", head).CombinedOutput()
+		return fmt.Errorf("squash commit: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+func rebaseOnto(worktreePath, base string) error {
+	out, err := exec.Command("git", "-C", worktreePath, "rebase", base).CombinedOutput()
+	if err != nil {
+		_, _ = exec.Command("git", "-C", worktreePath, "rebase", "--abort").CombinedOutput()
+		return fmt.Errorf("rebase onto %s failed (aborted): %s: %w", base, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
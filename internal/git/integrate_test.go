@@ -0,0 +1,130 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupDivergedWorktree returns a repo whose main branch gained a commit
+// after "feature" forked, and a worktree of feature holding two commits.
+func setupDivergedWorktree(t *testing.T) (repo, wt string) {
+	t.Helper()
+	dir := t.TempDir()
+	repo = filepath.Join(dir, "repo")
+	wt = filepath.Join(dir, "wt")
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	createTestRepo(t, repo)
+	runGit(t, repo, "worktree", "add", "-b", "feature", wt)
+
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(wt, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, wt, "add", name)
+		runGit(t, wt, "commit", "-m", "add "+name)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "main.txt"), []byte("main"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", "main.txt")
+	runGit(t, repo, "commit", "-m", "main work")
+	return repo, wt
+}
+
+func TestParseMergeStrategy(t *testing.T) {
+	for in, want := range map[string]MergeStrategy{"": MergeStrategyMerge, "Squash": MergeStrategySquash, " rebase ": MergeStrategyRebase} {
+		got, err := ParseMergeStrategy(in)
+		if err != nil || got != want {
+			t.Errorf("ParseMergeStrategy(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseMergeStrategy("octopus"); err == nil {
+		t.Error("ParseMergeStrategy(octopus) should fail")
+	}
+	if MergeStrategyRebase.Next() != MergeStrategyMerge {
+		t.Error("Next should wrap around")
+	}
+}
+
+func TestCommitAll(t *testing.T) {
+	_, wt := setupDivergedWorktree(t)
+
+	if committed, err := CommitAll(wt, "nothing"); err != nil || committed {
+		t.Fatalf("CommitAll on clean tree = %v, %v; want false, nil", committed, err)
+	}
+	if err := os.WriteFile(filepath.Join(wt, "new.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	committed, err := CommitAll(wt, "wip")
+	if err != nil || !committed {
+		t.Fatalf("CommitAll = %v, %v; want true, nil", committed, err)
+	}
+	if dirty, _ := HasUncommittedChanges(wt); dirty {
+		t.Error("worktree still dirty after CommitAll")
+	}
+	if msg := runGit(t, wt, "log", "-1", "--format=%s"); msg != "wip" {
+		t.Errorf("last commit = %q, want wip", msg)
+	}
+}
+
+func TestPrepareBranchForMerge_Squash(t *testing.T) {
+	repo, wt := setupDivergedWorktree(t)
+
+	if err := PrepareBranchForMerge(wt, "main", MergeStrategySquash, "feature work"); err != nil {
+		t.Fatalf("PrepareBranchForMerge: %v", err)
+	}
+	if n := runGit(t, wt, "rev-list", "--count", "main..feature"); n != "1" {
+		t.Errorf("commits ahead of main = %s, want 1", n)
+	}
+	if msg := runGit(t, wt, "log", "-1", "--format=%s"); msg != "feature work" {
+		t.Errorf("squash commit = %q, want %q", msg, "feature work")
+	}
+	if err := MergeBack(repo, "feature", "main"); err != nil {
+		t.Fatalf("MergeBack: %v", err)
+	}
+	if parents := runGit(t, repo, "log", "-1", "--format=%P", "main"); strings.Contains(parents, " ") {
+		t.Errorf("main tip has parents %q, want a fast-forward", parents)
+	}
+}
+
+func TestPrepareBranchForMerge_Rebase(t *testing.T) {
+	_, wt := setupDivergedWorktree(t)
+
+	if err := PrepareBranchForMerge(wt, "main", MergeStrategyRebase, ""); err != nil {
+		t.Fatalf("PrepareBranchForMerge: %v", err)
+	}
+	if n := runGit(t, wt, "rev-list", "--count", "main..feature"); n != "2" {
+		t.Errorf("commits ahead of main = %s, want 2", n)
+	}
+	if n := runGit(t, wt, "rev-list", "--count", "feature..main"); n != "0" {
+		t.Errorf("commits behind main = %s, want 0", n)
+	}
+}
+
+func TestPrepareBranchForMerge_RebaseConflictAborts(t *testing.T) {
+	for _, strategy := range []MergeStrategy{MergeStrategyRebase, MergeStrategySquash} {
+		t.Run(string(strategy), func(t *testing.T) {
+			repo, wt := setupDivergedWorktree(t)
+			if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("conflict"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			runGit(t, repo, "add", "a.txt")
+			runGit(t, repo, "commit", "-m", "conflicting a.txt")
+			before := runGit(t, wt, "rev-parse", "HEAD")
+
+			if err := PrepareBranchForMerge(wt, "main", strategy, "feature work"); err == nil {
+				t.Fatal("expected rebase conflict error")
+			}
+			if after := runGit(t, wt, "rev-parse", "HEAD"); after != before {
+				t.Errorf("HEAD moved to %s after aborted rebase, want %s", after, before)
+			}
+			if dirty, _ := HasUncommittedChanges(wt); dirty {
+				t.Error("worktree left dirty after aborted rebase")
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package git
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// WorktreeLayout is where agent-deck creates worktrees: the [worktree]
+// default_location and path_template settings.
+type WorktreeLayout struct {
+	Location string
+	Template string
+}
+
+// sessionIDMarker stands in for {session-id} while a template is resolved,
+// so the generated path ID can be matched as a pattern afterwards.
+const sessionIDMarker = "SESSIONIDMARKER"
+
+// Generated reports whether path is where agent-deck would create a worktree
+// for branch in the repository rooted at repoRoot: per the layout, or per
+// either built-in location, since the setting may have changed after the
+// worktree was made. A {session-id} in the template matches any generated
+// path ID. Detached worktrees never match: agent-deck always checks out a
+// branch.
+func (l WorktreeLayout) Generated(repoRoot, branch, path string) bool {
+	if branch == "" {
+		return false
+	}
+	want := comparablePath(path)
+	for _, location := range []string{"sibling", "subdirectory", l.Location} {
+		if location == "" {
+			continue
+		}
+		if comparablePath(GenerateWorktreePath(repoRoot, branch, location)) == want {
+			return true
+		}
+	}
+	if l.Template == "" {
+		return false
+	}
+	resolved := WorktreePath(WorktreePathOptions{
+		Branch:    branch,
+		RepoDir:   repoRoot,
+		SessionID: sessionIDMarker,
+		Template:  l.Template,
+	})
+	pattern := strings.ReplaceAll(regexp.QuoteMeta(resolved), sessionIDMarker, "[0-9a-f]{8}")
+	re, err := regexp.Compile("^" + pattern + "$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(filepath.Clean(path)) || re.MatchString(want)
+}
+
+// FindOrphanWorktrees returns the linked worktrees of the repository at
+// repoDir that OrphanWorktrees reports.
+func FindOrphanWorktrees(repoDir string, claimed []string, layout WorktreeLayout) ([]Worktree, error) {
+	worktrees, err := ListWorktrees(repoDir)
+	if err != nil {
+		return nil, err
+	}
+	return OrphanWorktrees(worktrees, claimed, layout), nil
+}
+
+// OrphanWorktrees filters a repository's worktree listing, in git's order,
+// to the linked worktrees agent-deck created (see WorktreeLayout.Generated)
+// that no path in claimed points to. Worktrees made by hand are never
+// reported, nor are the main working tree and the bare repository entry.
+// Paths are compared after resolving symlinks, so /var vs /private/var
+// style aliases still match.
+func OrphanWorktrees(worktrees []Worktree, claimed []string, layout WorktreeLayout) []Worktree {
+	claimedSet := make(map[string]bool, len(claimed))
+	for _, p := range claimed {
+		if p != "" {
+			claimedSet[comparablePath(p)] = true
+		}
+	}
+
+	var orphans []Worktree
+	for i, wt := range worktrees {
+		// git lists the main working tree (or the bare repo) first.
+		if i == 0 || wt.Bare {
+			continue
+		}
+		if claimedSet[comparablePath(wt.Path)] || !layout.Generated(worktrees[0].Path, wt.Branch, wt.Path) {
+			continue
+		}
+		orphans = append(orphans, wt)
+	}
+	return orphans
+}
+
+func comparablePath(p string) string {
+	if resolved, err := filepath.EvalSymlinks(p); err == nil {
+		return filepath.Clean(resolved)
+	}
+	if abs, err := filepath.Abs(p); err == nil {
+		return filepath.Clean(abs)
+	}
+	return filepath.Clean(p)
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindOrphanWorktrees(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	createTestRepo(t, repo)
+	claimed := GenerateWorktreePath(repo, "claimed", "sibling")
+	orphan := GenerateWorktreePath(repo, "feature/orphan", "subdirectory")
+	handMade := filepath.Join(dir, "scratch")
+	runGit(t, repo, "worktree", "add", "-b", "claimed", claimed)
+	runGit(t, repo, "worktree", "add", "-b", "feature/orphan", orphan)
+	runGit(t, repo, "worktree", "add", "-b", "scratch", handMade)
+
+	// The repo root itself is claimed too, but it is never an orphan anyway.
+	got, err := FindOrphanWorktrees(repo, []string{claimed + "/"}, WorktreeLayout{})
+	if err != nil {
+		t.Fatalf("FindOrphanWorktrees: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("orphans = %+v, want just the unclaimed agent-deck worktree", got)
+	}
+	if got[0].Branch != "feature/orphan" || comparablePath(got[0].Path) != comparablePath(orphan) {
+		t.Errorf("orphan = %+v, want %s on branch feature/orphan", got[0], orphan)
+	}
+
+	if got, _ := FindOrphanWorktrees(repo, []string{claimed, orphan}, WorktreeLayout{}); len(got) != 0 {
+		t.Errorf("orphans = %+v, want none when every agent-deck worktree is claimed", got)
+	}
+
+	if _, err := FindOrphanWorktrees(dir, nil, WorktreeLayout{}); err == nil {
+		t.Error("expected error for a non-repo directory")
+	}
+}
+
+func TestWorktreeLayoutGenerated(t *testing.T) {
+	repo := filepath.Join(t.TempDir(), "api")
+	custom := WorktreeLayout{Location: "~/worktrees", Template: "/wt/{repo-name}/{branch}-{session-id}"}
+	home, _ := os.UserHomeDir()
+
+	cases := []struct {
+		layout WorktreeLayout
+		branch string
+		path   string
+		want   bool
+	}{
+		{WorktreeLayout{}, "fix/x", repo + "-fix-x", true},
+		{WorktreeLayout{}, "fix/x", filepath.Join(repo, ".worktrees", "fix-x"), true},
+		{WorktreeLayout{}, "fix/x", repo + "-other", false},
+		{WorktreeLayout{}, "", repo + "-", false},
+		{custom, "fix/x", filepath.Join(home, "worktrees", "api", "fix-x"), true},
+		{custom, "fix/x", "/wt/api/fix-x-0a1b2c3d", true},
+		{custom, "fix/x", "/wt/api/fix-x-notanid", false},
+		{WorktreeLayout{}, "fix/x", "/wt/api/fix-x-0a1b2c3d", false},
+	}
+	for _, tc := range cases {
+		if got := tc.layout.Generated(repo, tc.branch, tc.path); got != tc.want {
+			t.Errorf("%+v.Generated(%q, %q) = %v, want %v", tc.layout, tc.branch, tc.path, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// PushBranch pushes branch to the repo's default remote and sets it as the
+// upstream, so later pushes from the worktree need no arguments. It returns
+// the remote pushed to.
+func PushBranch(repoDir, branch string) (string, error) {
+	if branch == "" {
+		return "", errors.New("no branch to push")
+	}
+	remote, err := getDefaultRemote(repoDir)
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command("git", "-C", repoDir, "push", "-u", remote, branch)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("push %s to %s failed: %s: %w", branch, remote, strings.TrimSpace(string(output)), err)
+	}
+	return remote, nil
+}
+
+// PushRemote returns the remote PushBranch would push to from repoDir, so a
+// caller can name it before pushing.
+func PushRemote(repoDir string) (string, error) {
+	return getDefaultRemote(repoDir)
+}
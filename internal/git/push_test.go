@@ -0,0 +1,39 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPushBranch(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	remote := filepath.Join(dir, "remote.git")
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	createTestRepo(t, repo)
+	runGit(t, dir, "init", "--bare", remote)
+
+	if _, err := PushBranch(repo, "feature"); err == nil || !strings.Contains(err.Error(), "no git remotes") {
+		t.Fatalf("PushBranch without a remote = %v, want no-remotes error", err)
+	}
+
+	runGit(t, repo, "remote", "add", "origin", remote)
+	createBranch(t, repo, "feature")
+	got, err := PushBranch(repo, "feature")
+	if err != nil {
+		t.Fatalf("PushBranch: %v", err)
+	}
+	if got != "origin" {
+		t.Errorf("remote = %q, want origin", got)
+	}
+	if out := runGit(t, remote, "branch", "--list", "feature"); !strings.Contains(out, "feature") {
+		t.Errorf("remote branches = %q, want feature", out)
+	}
+	if up := runGit(t, repo, "config", "--get", "branch.feature.remote"); strings.TrimSpace(up) != "origin" {
+		t.Errorf("upstream remote = %q, want origin", up)
+	}
+}
@@ -0,0 +1,222 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// snapshotRefPrefix namespaces snapshot refs. They are ordinary refs, so
+// snapshots survive gc and are shared by every worktree of the repository,
+// but no branch, tag or log lists them.
+const snapshotRefPrefix = "refs/agent-deck/snapshots/"
+
+// snapshotTimeout bounds each git call of a snapshot or rollback.
+const snapshotTimeout = 30 * time.Second
+
+// validSnapshotKey limits keys to characters that are safe in a ref name.
+var validSnapshotKey = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// Snapshot is a point-in-time copy of a checkout's working tree, tracked and
+// untracked files alike (ignored files excluded), taken without touching
+// the index or the files themselves.
+type Snapshot struct {
+	Ref    string
+	Commit string
+	// Head is the commit checked out when the snapshot was taken; a
+	// rollback moves the branch back to it.
+	Head    string
+	Reason  string
+	Created time.Time
+}
+
+// CreateSnapshot records the working tree of the checkout containing dir
+// under key (a session ID). When nothing changed since key's latest
+// snapshot, that snapshot is returned with created false. Only the newest
+// keep snapshots of key are kept; keep <= 0 keeps all.
+func CreateSnapshot(dir, key, reason string, keep int) (snap Snapshot, created bool, err error) {
+	if !validSnapshotKey.MatchString(key) {
+		return Snapshot{}, false, fmt.Errorf("invalid snapshot key %q", key)
+	}
+	top, err := snapshotGit(dir, nil, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	head, err := snapshotGit(top, nil, "rev-parse", "--verify", "HEAD^{commit}")
+	if err != nil {
+		return Snapshot{}, false, fmt.Errorf("no commit to snapshot against: %w", err)
+	}
+	tree, err := workingTree(top)
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+
+	existing, err := ListSnapshots(top, key)
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	if len(existing) > 0 && existing[0].Head == head {
+		if latestTree, err := snapshotGit(top, nil, "rev-parse", existing[0].Commit+"^{tree}"); err == nil && latestTree == tree {
+			return existing[0], false, nil
+		}
+	}
+
+	// A fixed identity: the snapshot must not fail on a repo without
+	// user.name, and is not the user's commit anyway.
+	env := []string{
+		"GIT_AUTHOR_NAME=agent-deck", "GIT_AUTHOR_EMAIL=agent-deck@localhost",
+		"GIT_COMMITTER_NAME=agent-deck", "GIT_COMMITTER_EMAIL=agent-deck@localhost",
+	}
+	commit, err := snapshotGit(top, env, "commit-tree", tree, "-p", head, "-m", "agent-deck snapshot: "+reason)
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	now := time.Now()
+	ref := fmt.Sprintf("%s%s/%d", snapshotRefPrefix, key, now.UnixNano())
+	if _, err := snapshotGit(top, nil, "update-ref", ref, commit); err != nil {
+		return Snapshot{}, false, err
+	}
+	snap = Snapshot{Ref: ref, Commit: commit, Head: head, Reason: reason, Created: now}
+
+	if keep > 0 && len(existing)+1 > keep {
+		for _, old := range existing[keep-1:] {
+			_, _ = snapshotGit(top, nil, "update-ref", "-d", old.Ref)
+		}
+	}
+	return snap, true, nil
+}
+
+// workingTree writes the checkout's current files as a tree object, staging
+// them into a copy of the index so the real one is left alone. Starting
+// from a copy keeps git's stat cache, so only changed files are hashed.
+func workingTree(top string) (string, error) {
+	indexPath, err := snapshotGit(top, nil, "rev-parse", "--path-format=absolute", "--git-path", "index")
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp("", "agent-deck-snapshot-index-*")
+	if err != nil {
+		return "", err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	if src, err := os.Open(indexPath); err == nil {
+		_, err = io.Copy(tmp, src)
+		src.Close()
+		if err != nil {
+			tmp.Close()
+			return "", err
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	env := []string{"GIT_INDEX_FILE=" + tmpPath}
+	if _, err := snapshotGit(top, env, "add", "-A"); err != nil {
+		return "", err
+	}
+	return snapshotGit(top, env, "write-tree")
+}
+
+// ListSnapshots returns key's snapshots in the checkout containing dir,
+// newest first.
+func ListSnapshots(dir, key string) ([]Snapshot, error) {
+	if !validSnapshotKey.MatchString(key) {
+		return nil, fmt.Errorf("invalid snapshot key %q", key)
+	}
+	out, err := snapshotGit(dir, nil, "for-each-ref", "--sort=-refname",
+		"--format=%(refname)%00%(objectname)%00%(parent)%00%(contents:subject)", snapshotRefPrefix+key+"/")
+	if err != nil {
+		return nil, err
+	}
+	return parseSnapshotRefs(out), nil
+}
+
+// parseSnapshotRefs parses ListSnapshots' for-each-ref output. The ref's
+// last component is the creation time in Unix nanoseconds.
+func parseSnapshotRefs(out string) []Snapshot {
+	var snaps []Snapshot
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 4 {
+			continue
+		}
+		nanos, err := strconv.ParseInt(filepath.Base(fields[0]), 10, 64)
+		if err != nil {
+			continue
+		}
+		snaps = append(snaps, Snapshot{
+			Ref:     fields[0],
+			Commit:  fields[1],
+			Head:    fields[2],
+			Reason:  strings.TrimPrefix(fields[3], "agent-deck snapshot: "),
+			Created: time.Unix(0, nanos),
+		})
+	}
+	return snaps
+}
+
+// RestoreSnapshot rolls the checkout containing dir back to snap: the
+// branch returns to snap.Head and the files to their snapshotted content,
+// with files created since removed (ignored files are kept). The current
+// state is snapshotted first under key, so a rollback can itself be undone;
+// that safety snapshot is returned.
+func RestoreSnapshot(dir, key string, snap Snapshot, keep int) (Snapshot, error) {
+	safety, _, err := CreateSnapshot(dir, key, "before rollback", keep)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("could not save the current state first: %w", err)
+	}
+	top, err := snapshotGit(dir, nil, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return safety, err
+	}
+	steps := [][]string{
+		{"reset", "-q", "--hard", snap.Head},
+		{"clean", "-q", "-f", "-d"},
+		// Index and files to the snapshot's tree, deleting what it lacks...
+		{"read-tree", "-u", "--reset", snap.Commit},
+		// ...then the index back to HEAD: files untracked at snapshot time
+		// stay untracked, and nothing is left staged.
+		{"reset", "-q"},
+	}
+	for _, args := range steps {
+		if _, err := snapshotGit(top, nil, args...); err != nil {
+			return safety, fmt.Errorf("rollback failed (restore %s to recover): %w", shortCommit(safety.Commit), err)
+		}
+	}
+	return safety, nil
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	return commit
+}
+
+// snapshotGit runs one git command in dir with extra environment, returning
+// trimmed stdout. It runs with --no-optional-locks: snapshots are taken
+// while the agent may be running git in the same checkout.
+func snapshotGit(dir string, env []string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), snapshotTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", append([]string{"--no-optional-locks", "-C", dir}, args...)...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s: %w", args[0], strings.TrimSpace(stderr.String()), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,128 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	repo := t.TempDir()
+	createTestRepo(t, repo)
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("README.md", "known good\n")
+	write("notes.txt", "untracked but wanted\n")
+	write(".gitignore", "build/\n")
+	runGit(t, repo, "add", ".gitignore")
+	runGit(t, repo, "commit", "-m", "ignore build")
+	stagedBefore := runGit(t, repo, "diff", "--cached", "--name-only")
+
+	good, created, err := CreateSnapshot(repo, "sess-1", "before prompt", 0)
+	if err != nil || !created {
+		t.Fatalf("CreateSnapshot = %+v, %v, %v", good, created, err)
+	}
+	if got := runGit(t, repo, "diff", "--cached", "--name-only"); got != stagedBefore {
+		t.Errorf("snapshot changed the index: staged %q, was %q", got, stagedBefore)
+	}
+	if again, created, err := CreateSnapshot(repo, "sess-1", "before prompt", 0); err != nil || created || again.Ref != good.Ref {
+		t.Errorf("unchanged tree: got %+v, created=%v, err=%v; want the existing snapshot", again, created, err)
+	}
+
+	// A bad agent turn: edits, a new file, a commit, and build output.
+	write("README.md", "broken\n")
+	write("junk.go", "package junk\n")
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-m", "bad")
+	if err := os.MkdirAll(filepath.Join(repo, "build"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write("build/out", "artifact\n")
+
+	safety, err := RestoreSnapshot(repo, "sess-1", good, 0)
+	if err != nil {
+		t.Fatalf("RestoreSnapshot: %v", err)
+	}
+	if got := readFile(t, filepath.Join(repo, "README.md")); got != "known good\n" {
+		t.Errorf("README.md = %q after rollback", got)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "junk.go")); !os.IsNotExist(err) {
+		t.Errorf("junk.go should be gone after rollback (stat err %v)", err)
+	}
+	if got := readFile(t, filepath.Join(repo, "notes.txt")); got != "untracked but wanted\n" {
+		t.Errorf("notes.txt = %q after rollback", got)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "build", "out")); err != nil {
+		t.Errorf("ignored files should survive rollback: %v", err)
+	}
+	if head := runGit(t, repo, "rev-parse", "HEAD"); head != good.Head {
+		t.Errorf("HEAD = %s, want %s", head, good.Head)
+	}
+	if status := runGit(t, repo, "status", "--porcelain"); !strings.Contains(status, "?? notes.txt") || strings.Contains(status, "A ") {
+		t.Errorf("status after rollback = %q; want notes.txt untracked and nothing staged", status)
+	}
+
+	snaps, err := ListSnapshots(repo, "sess-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snaps) != 2 || snaps[0].Ref != safety.Ref || snaps[0].Reason != "before rollback" || snaps[1].Reason != "before prompt" {
+		t.Fatalf("ListSnapshots = %+v; want the safety snapshot then the original", snaps)
+	}
+
+	// The rollback itself can be undone.
+	if _, err := RestoreSnapshot(repo, "sess-1", safety, 0); err != nil {
+		t.Fatalf("undo rollback: %v", err)
+	}
+	if got := readFile(t, filepath.Join(repo, "junk.go")); got != "package junk\n" {
+		t.Errorf("junk.go = %q after undoing the rollback", got)
+	}
+}
+
+func TestSnapshot_KeepPrunesOldest(t *testing.T) {
+	repo := t.TempDir()
+	createTestRepo(t, repo)
+	for i := 0; i < 4; i++ {
+		if err := os.WriteFile(filepath.Join(repo, "f.txt"), []byte(strings.Repeat("x", i+1)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := CreateSnapshot(repo, "s", "n", 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	snaps, err := ListSnapshots(repo, "s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snaps) != 2 {
+		t.Fatalf("kept %d snapshots, want 2", len(snaps))
+	}
+	if !snaps[0].Created.After(snaps[1].Created) {
+		t.Error("snapshots should be listed newest first")
+	}
+}
+
+func TestSnapshot_RejectsBadKeyAndNonRepo(t *testing.T) {
+	repo := t.TempDir()
+	createTestRepo(t, repo)
+	if _, _, err := CreateSnapshot(repo, "../heads/main", "x", 0); err == nil {
+		t.Error("a key with a path separator should be rejected")
+	}
+	if _, _, err := CreateSnapshot(t.TempDir(), "s", "x", 0); err == nil {
+		t.Error("a directory outside git should be an error")
+	}
+}
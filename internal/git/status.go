@@ -0,0 +1,160 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status is a compact summary of a working tree: how many paths have
+// uncommitted changes, and how far the branch is ahead of / behind its
+// upstream.
+type Status struct {
+	Changed     int    // paths with staged, unstaged or untracked changes
+	Ahead       int    // commits on HEAD not on the upstream
+	Behind      int    // commits on the upstream not on HEAD
+	HasUpstream bool   // whether Ahead/Behind are meaningful
+	Branch      string // checked-out branch; "" when HEAD is detached
+}
+
+// Clean reports whether there is nothing to show for s. Branch is not
+// considered.
+func (s Status) Clean() bool {
+	return s.Changed == 0 && s.Ahead == 0 && s.Behind == 0
+}
+
+// statusTimeout bounds one `git status` call, so a hung git (slow network
+// filesystem, credential helper) cannot stall StatusCache.Refresh.
+const statusTimeout = 5 * time.Second
+
+// GetStatus summarizes the working tree at dir with a single
+// `git status --porcelain=v2 --branch` call. It runs with
+// --no-optional-locks so it never takes index.lock away from the agent's own
+// git commands in the same worktree.
+func GetStatus(dir string) (Status, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "--no-optional-locks", "-C", dir, "status", "--porcelain=v2", "--branch", "--no-renames")
+	out, err := cmd.Output()
+	if err != nil {
+		return Status{}, fmt.Errorf("git status in %s: %w", dir, err)
+	}
+	return ParseStatus(string(out)), nil
+}
+
+// ParseStatus parses `git status --porcelain=v2 --branch` output.
+func ParseStatus(out string) Status {
+	var st Status
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			if head := strings.TrimPrefix(line, "# branch.head "); head != "(detached)" {
+				st.Branch = head
+			}
+		case strings.HasPrefix(line, "# branch.ab "):
+			// "# branch.ab +<ahead> -<behind>"
+			fields := strings.Fields(strings.TrimPrefix(line, "# branch.ab "))
+			if len(fields) == 2 {
+				st.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+				st.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "-"))
+				st.HasUpstream = true
+			}
+		case strings.HasPrefix(line, "#"), line == "":
+		default:
+			// "1 ...", "2 ...", "u ..." (unmerged) and "? path" (untracked).
+			st.Changed++
+		}
+	}
+	return st
+}
+
+// StatusCache memoizes GetStatus per directory so that a list of sessions,
+// many of which usually share a checkout, costs at most one git subprocess
+// per directory per TTL. Refresh is safe to call from a ticking background
+// worker; Get never spawns a process.
+type StatusCache struct {
+	ttl         time.Duration
+	concurrency int
+	getStatus   func(dir string) (Status, error)
+
+	mu      sync.Mutex
+	entries map[string]statusEntry
+}
+
+type statusEntry struct {
+	status  Status
+	ok      bool // false when dir is not a git checkout (or git failed)
+	fetched time.Time
+}
+
+// NewStatusCache returns a cache whose entries go stale after ttl.
+func NewStatusCache(ttl time.Duration) *StatusCache {
+	return &StatusCache{
+		ttl:         ttl,
+		concurrency: 4,
+		getStatus:   GetStatus,
+		entries:     make(map[string]statusEntry),
+	}
+}
+
+// Get returns the cached status for dir. ok is false when dir has not been
+// fetched yet or is not a git checkout.
+func (c *StatusCache) Get(dir string) (Status, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, found := c.entries[dir]
+	return e.status, found && e.ok
+}
+
+// Refresh re-fetches every dir whose entry is missing or older than the TTL,
+// running at most a few git processes at a time. Duplicate dirs are fetched
+// once. Failures are cached too, so non-repos are not retried every tick.
+func (c *StatusCache) Refresh(dirs []string) {
+	now := time.Now()
+	c.mu.Lock()
+	var stale []string
+	seen := make(map[string]bool, len(dirs))
+	for _, dir := range dirs {
+		if dir == "" || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		if e, found := c.entries[dir]; !found || now.Sub(e.fetched) >= c.ttl {
+			stale = append(stale, dir)
+			// Claim the slot so an overlapping Refresh does not fetch it too.
+			e.fetched = now
+			c.entries[dir] = e
+		}
+	}
+	c.mu.Unlock()
+
+	sem := make(chan struct{}, c.concurrency)
+	var wg sync.WaitGroup
+	for _, dir := range stale {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(dir string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			st, err := c.getStatus(dir)
+			c.mu.Lock()
+			c.entries[dir] = statusEntry{status: st, ok: err == nil, fetched: time.Now()}
+			c.mu.Unlock()
+		}(dir)
+	}
+	wg.Wait()
+}
+
+// Invalidate drops dir so the next Refresh fetches it immediately.
+func (c *StatusCache) Invalidate(dir string) {
+	c.mu.Lock()
+	delete(c.entries, dir)
+	c.mu.Unlock()
+}
@@ -0,0 +1,110 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseStatus(t *testing.T) {
+	out := `# branch.oid 1234
+# branch.head feature
+# branch.upstream origin/feature
+# branch.ab +2 -1
+1 .M N... 100644 100644 100644 abc abc a.go
+1 A. N... 000000 100644 100644 000 def b.go
+u UU N... 100644 100644 100644 100644 a b c conflict.go
+? new.txt
+`
+	got := ParseStatus(out)
+	want := Status{Changed: 4, Ahead: 2, Behind: 1, HasUpstream: true, Branch: "feature"}
+	if got != want {
+		t.Errorf("ParseStatus = %+v, want %+v", got, want)
+	}
+
+	noUpstream := ParseStatus("# branch.oid 1234\n# branch.head main\n")
+	if noUpstream.HasUpstream || !noUpstream.Clean() {
+		t.Errorf("ParseStatus without upstream = %+v, want clean with no upstream", noUpstream)
+	}
+
+	if detached := ParseStatus("# branch.oid 1234\n# branch.head (detached)\n"); detached.Branch != "" {
+		t.Errorf("detached HEAD Branch = %q, want empty", detached.Branch)
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	remote := filepath.Join(dir, "remote.git")
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	createTestRepo(t, repo)
+	runGit(t, dir, "init", "--bare", remote)
+	runGit(t, repo, "remote", "add", "origin", remote)
+	runGit(t, repo, "push", "-u", "origin", "main")
+
+	if err := os.WriteFile(filepath.Join(repo, "c.txt"), []byte("c"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", "c.txt")
+	runGit(t, repo, "commit", "-m", "c")
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "untracked.txt"), []byte("u"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := GetStatus(repo)
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if st != (Status{Changed: 2, Ahead: 1, HasUpstream: true, Branch: "main"}) {
+		t.Errorf("GetStatus = %+v, want 2 changed, 1 ahead", st)
+	}
+
+	if _, err := GetStatus(dir); err == nil {
+		t.Error("GetStatus on a non-repo should fail")
+	}
+}
+
+func TestStatusCache(t *testing.T) {
+	var calls atomic.Int32
+	c := NewStatusCache(time.Hour)
+	c.getStatus = func(dir string) (Status, error) {
+		calls.Add(1)
+		if dir == "/not-a-repo" {
+			return Status{}, errors.New("not a repo")
+		}
+		return Status{Changed: 1}, nil
+	}
+
+	if _, ok := c.Get("/repo"); ok {
+		t.Fatal("Get before Refresh should miss")
+	}
+	c.Refresh([]string{"/repo", "/repo", "/not-a-repo", ""})
+	if n := calls.Load(); n != 2 {
+		t.Errorf("git calls = %d, want 2 (duplicates and empty dirs skipped)", n)
+	}
+	if st, ok := c.Get("/repo"); !ok || st.Changed != 1 {
+		t.Errorf("Get(/repo) = %+v, %v; want Changed=1", st, ok)
+	}
+	if _, ok := c.Get("/not-a-repo"); ok {
+		t.Error("failed fetch should not report ok")
+	}
+
+	c.Refresh([]string{"/repo", "/not-a-repo"})
+	if n := calls.Load(); n != 2 {
+		t.Errorf("git calls after fresh Refresh = %d, want still 2", n)
+	}
+
+	c.Invalidate("/repo")
+	c.Refresh([]string{"/repo"})
+	if n := calls.Load(); n != 3 {
+		t.Errorf("git calls after Invalidate = %d, want 3", n)
+	}
+}
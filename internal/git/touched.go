@@ -0,0 +1,282 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileChange is one file's line counts in a working-tree diff. Binary files
+// carry no counts.
+type FileChange struct {
+	Path    string
+	Added   int
+	Deleted int
+	Binary  bool
+}
+
+// maxUntrackedCountBytes bounds how much of an untracked file is read to
+// count its lines; larger files are reported as binary.
+const maxUntrackedCountBytes = 1 << 20
+
+// ChangedFiles lists every file whose working-tree content differs from
+// base, committed or not, with added/deleted line counts. Untracked files
+// (outside .gitignore) count as fully added. Like GetStatus it runs with
+// --no-optional-locks and a timeout, as it is called from the background
+// worker.
+func ChangedFiles(dir, base string) ([]FileChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "git", "--no-optional-locks", "-C", dir, "diff", "--numstat", "--no-renames", "--no-ext-diff", base).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff --numstat in %s: %w", dir, err)
+	}
+	files := ParseNumstat(string(out))
+
+	untracked, err := exec.CommandContext(ctx, "git", "--no-optional-locks", "-C", dir, "ls-files", "--others", "--exclude-standard", "-z").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files in %s: %w", dir, err)
+	}
+	for _, path := range strings.Split(string(untracked), "\x00") {
+		if path == "" {
+			continue
+		}
+		files = append(files, untrackedChange(dir, path))
+	}
+	return files, nil
+}
+
+// untrackedChange counts the lines of an untracked file as additions.
+func untrackedChange(dir, path string) FileChange {
+	fc := FileChange{Path: path}
+	full := filepath.Join(dir, path)
+	info, err := os.Stat(full)
+	if err != nil || info.Size() > maxUntrackedCountBytes {
+		fc.Binary = true
+		return fc
+	}
+	data, err := os.ReadFile(full)
+	if err != nil || bytes.IndexByte(data, 0) >= 0 {
+		fc.Binary = true
+		return fc
+	}
+	fc.Added = bytes.Count(data, []byte{'\n'})
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		fc.Added++
+	}
+	return fc
+}
+
+// ParseNumstat parses `git diff --numstat` output. Binary files show "-"
+// for both counts.
+func ParseNumstat(out string) []FileChange {
+	var files []FileChange
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		fc := FileChange{Path: fields[2]}
+		if fields[0] == "-" && fields[1] == "-" {
+			fc.Binary = true
+		} else {
+			fc.Added, _ = strconv.Atoi(fields[0])
+			fc.Deleted, _ = strconv.Atoi(fields[1])
+		}
+		files = append(files, fc)
+	}
+	return files
+}
+
+// TouchedTarget names one checkout TouchedTracker follows. Base, when set,
+// is a branch whose merge base with HEAD the changes are measured from
+// (a worktree's parent branch): everything since then is the session's
+// work. Without it the tracker measures from HEAD at the first snapshot and
+// ignores what was already modified then.
+type TouchedTarget struct {
+	Key  string // caller's identifier, e.g. a session ID
+	Dir  string
+	Base string
+}
+
+// TouchedTracker keeps, per target, the files changed since a baseline
+// taken the first time the target is refreshed. Refresh is meant for a
+// ticking background worker; Get never spawns a process.
+type TouchedTracker struct {
+	ttl          time.Duration
+	concurrency  int
+	changedFiles func(dir, base string) ([]FileChange, error)
+	resolveBase  func(dir, base string) (string, error)
+
+	mu      sync.Mutex
+	entries map[string]*touchedEntry
+}
+
+type touchedEntry struct {
+	dir      string
+	base     string                // commit the diff is taken against
+	baseline map[string]FileChange // changes already present at the first snapshot
+	touched  []FileChange
+	ok       bool
+	fetched  time.Time
+}
+
+// NewTouchedTracker returns a tracker whose snapshots go stale after ttl.
+func NewTouchedTracker(ttl time.Duration) *TouchedTracker {
+	return &TouchedTracker{
+		ttl:          ttl,
+		concurrency:  4,
+		changedFiles: ChangedFiles,
+		resolveBase:  resolveTouchedBase,
+		entries:      make(map[string]*touchedEntry),
+	}
+}
+
+// resolveTouchedBase pins the commit a target is measured from: the merge
+// base with branch when given, else the current HEAD.
+func resolveTouchedBase(dir, branch string) (string, error) {
+	if branch == "" {
+		return HeadCommit(dir)
+	}
+	out, err := exec.Command("git", "-C", dir, "merge-base", branch, "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("no merge base between %s and HEAD: %w", branch, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Get returns the files touched for key, largest change first. ok is false
+// until the first snapshot lands or when the target is not a git checkout.
+func (t *TouchedTracker) Get(key string) ([]FileChange, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, found := t.entries[key]
+	if !found || !e.ok {
+		return nil, false
+	}
+	return e.touched, true
+}
+
+// Refresh re-snapshots every target whose entry is missing or older than
+// the TTL, a few git processes at a time. A target whose Dir changed starts
+// over with a new baseline. Entries for keys not in targets are dropped.
+func (t *TouchedTracker) Refresh(targets []TouchedTarget) {
+	now := time.Now()
+	t.mu.Lock()
+	live := make(map[string]bool, len(targets))
+	var stale []TouchedTarget
+	for _, tg := range targets {
+		if tg.Key == "" || tg.Dir == "" {
+			continue
+		}
+		live[tg.Key] = true
+		e, found := t.entries[tg.Key]
+		if found && e.dir != tg.Dir {
+			found = false
+		}
+		if !found {
+			e = &touchedEntry{dir: tg.Dir}
+			t.entries[tg.Key] = e
+		} else if now.Sub(e.fetched) < t.ttl {
+			continue
+		}
+		// Claim the slot so an overlapping Refresh does not fetch it too.
+		e.fetched = now
+		stale = append(stale, tg)
+	}
+	for key := range t.entries {
+		if !live[key] {
+			delete(t.entries, key)
+		}
+	}
+	t.mu.Unlock()
+
+	sem := make(chan struct{}, t.concurrency)
+	var wg sync.WaitGroup
+	for _, tg := range stale {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(tg TouchedTarget) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			t.snapshot(tg)
+		}(tg)
+	}
+	wg.Wait()
+}
+
+// snapshot takes one git snapshot of tg, establishing its baseline first
+// when this is the target's first successful snapshot.
+func (t *TouchedTracker) snapshot(tg TouchedTarget) {
+	t.mu.Lock()
+	e, found := t.entries[tg.Key]
+	if !found || e.dir != tg.Dir {
+		t.mu.Unlock()
+		return
+	}
+	base, baseline := e.base, e.baseline
+	t.mu.Unlock()
+
+	if base == "" {
+		resolved, err := t.resolveBase(tg.Dir, tg.Base)
+		if err != nil {
+			t.store(tg, "", nil, nil, false)
+			return
+		}
+		base = resolved
+	}
+	files, err := t.changedFiles(tg.Dir, base)
+	if err != nil {
+		t.store(tg, base, baseline, nil, false)
+		return
+	}
+	if baseline == nil {
+		baseline = make(map[string]FileChange)
+		if tg.Base == "" {
+			// Whatever is modified already predates the session's work.
+			for _, fc := range files {
+				baseline[fc.Path] = fc
+			}
+		}
+	}
+	t.store(tg, base, baseline, touchedSince(baseline, files), true)
+}
+
+func (t *TouchedTracker) store(tg TouchedTarget, base string, baseline map[string]FileChange, touched []FileChange, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, found := t.entries[tg.Key]
+	if !found || e.dir != tg.Dir {
+		return
+	}
+	e.base, e.baseline, e.touched, e.ok = base, baseline, touched, ok
+	e.fetched = time.Now()
+}
+
+// touchedSince keeps the changes that differ from the baseline — files the
+// session modified itself — sorted by size of change, then path.
+func touchedSince(baseline map[string]FileChange, files []FileChange) []FileChange {
+	var touched []FileChange
+	for _, fc := range files {
+		if before, ok := baseline[fc.Path]; ok && before == fc {
+			continue
+		}
+		touched = append(touched, fc)
+	}
+	sort.Slice(touched, func(i, j int) bool {
+		a, b := touched[i].Added+touched[i].Deleted, touched[j].Added+touched[j].Deleted
+		if a != b {
+			return a > b
+		}
+		return touched[i].Path < touched[j].Path
+	})
+	return touched
+}
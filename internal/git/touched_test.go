@@ -0,0 +1,97 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseNumstat(t *testing.T) {
+	out := "3\t1\ta.go\n-\t-\tlogo.png\n0\t12\tdir/old.txt\n\n"
+	got := ParseNumstat(out)
+	want := []FileChange{
+		{Path: "a.go", Added: 3, Deleted: 1},
+		{Path: "logo.png", Binary: true},
+		{Path: "dir/old.txt", Deleted: 12},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseNumstat = %+v, want %+v", got, want)
+	}
+}
+
+func TestTouchedTracker_IgnoresPreexistingChanges(t *testing.T) {
+	repo := t.TempDir()
+	createTestRepo(t, repo)
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("dirty.txt", "a\n")
+	runGit(t, repo, "add", "dirty.txt")
+	runGit(t, repo, "commit", "-m", "dirty")
+	write("dirty.txt", "a\nb\n") // modified before the session is tracked
+
+	tr := NewTouchedTracker(0)
+	target := []TouchedTarget{{Key: "s1", Dir: repo}}
+	tr.Refresh(target)
+	if files, ok := tr.Get("s1"); !ok || len(files) != 0 {
+		t.Fatalf("first snapshot = %+v, %v; want baseline with nothing touched", files, ok)
+	}
+
+	// The session's own work: an edit, a commit and a new file.
+	write("README.md", "# Test Repo\nmore\nlines\n")
+	write("new.go", "package x\n\nfunc f() {}\n")
+	write("dirty.txt", "a\nb\nc\n")
+	runGit(t, repo, "add", "README.md")
+	runGit(t, repo, "commit", "-m", "session work")
+
+	tr.Refresh(target)
+	got, ok := tr.Get("s1")
+	if !ok {
+		t.Fatal("Get after refresh: not ok")
+	}
+	want := []FileChange{
+		{Path: "README.md", Added: 3, Deleted: 1},
+		{Path: "new.go", Added: 3},
+		{Path: "dirty.txt", Added: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("touched = %+v, want %+v", got, want)
+	}
+
+	tr.Refresh(nil)
+	if _, ok := tr.Get("s1"); ok {
+		t.Error("entries for targets no longer refreshed should be dropped")
+	}
+}
+
+func TestTouchedTracker_BaseBranchCountsEverything(t *testing.T) {
+	repo := t.TempDir()
+	createTestRepo(t, repo)
+	runGit(t, repo, "checkout", "-b", "feature")
+	if err := os.WriteFile(filepath.Join(repo, "feature.go"), []byte("package x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", "feature.go")
+	runGit(t, repo, "commit", "-m", "feature")
+
+	tr := NewTouchedTracker(time.Minute)
+	tr.Refresh([]TouchedTarget{{Key: "wt", Dir: repo, Base: "main"}})
+	got, ok := tr.Get("wt")
+	want := []FileChange{{Path: "feature.go", Added: 1}}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("touched = %+v (ok=%v), want %+v", got, ok, want)
+	}
+}
+
+func TestTouchedTracker_NotARepo(t *testing.T) {
+	tr := NewTouchedTracker(time.Minute)
+	tr.Refresh([]TouchedTarget{{Key: "x", Dir: t.TempDir()}})
+	if _, ok := tr.Get("x"); ok {
+		t.Error("Get should not be ok for a directory outside git")
+	}
+}
@@ -0,0 +1,48 @@
+package git
+
+import (
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DirSize returns the apparent size in bytes of every regular file under
+// root. Symlinks are not followed, so a worktree that links node_modules or
+// a shared cache is not charged for it. Unreadable entries are skipped: the
+// result is a best-effort disk-usage figure, not an audit.
+func DirSize(root string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(root, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if info, infoErr := d.Info(); infoErr == nil {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
+
+// LastCommitTime returns the committer date of HEAD in dir. A worktree on an
+// unborn branch returns the zero time and an error.
+func LastCommitTime(dir string) (time.Time, error) {
+	out, err := exec.Command("git", "-C", dir, "log", "-1", "--format=%ct").Output()
+	if err != nil {
+		return time.Time{}, err
+	}
+	secs, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(secs, 0), nil
+}
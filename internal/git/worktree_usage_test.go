@@ -0,0 +1,30 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), make([]byte, 100), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "b.txt"), make([]byte, 50), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Symlinks are not followed, so the target is not counted twice.
+	_ = os.Symlink(filepath.Join(dir, "a.txt"), filepath.Join(dir, "link"))
+
+	size, err := DirSize(dir)
+	if err != nil {
+		t.Fatalf("DirSize: %v", err)
+	}
+	if size != 150 {
+		t.Fatalf("DirSize = %d, want 150", size)
+	}
+}
@@ -0,0 +1,158 @@
+// Package slack connects sessions to a Slack channel: the TUI posts a
+// notice (threaded per session) when a session needs input, and replies in
+// that thread or /agentdeck slash commands are typed back into the session.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the Slack Web API root.
+const DefaultBaseURL = "https://slack.com/api"
+
+// requestTimeout bounds one Web API call.
+const requestTimeout = 10 * time.Second
+
+// Client is a minimal Slack Web API client: just the two methods the
+// integration needs, over plain net/http.
+type Client struct {
+	Token   string
+	BaseURL string // DefaultBaseURL when empty; tests point it at httptest
+	HTTP    *http.Client
+}
+
+// NewClient returns a client authenticating with the bot token.
+func NewClient(token string) *Client {
+	return &Client{Token: token, BaseURL: DefaultBaseURL, HTTP: &http.Client{}}
+}
+
+// Message is one message of a thread, as returned by conversations.replies.
+type Message struct {
+	User    string `json:"user"`
+	Text    string `json:"text"`
+	TS      string `json:"ts"`
+	BotID   string `json:"bot_id,omitempty"`
+	Subtype string `json:"subtype,omitempty"`
+}
+
+// FromHuman reports whether m was written by a person (not a bot, and not
+// a join/edit/system message).
+func (m Message) FromHuman() bool {
+	return m.User != "" && m.BotID == "" && m.Subtype == ""
+}
+
+type apiResponse struct {
+	OK       bool      `json:"ok"`
+	Error    string    `json:"error,omitempty"`
+	TS       string    `json:"ts,omitempty"`
+	Messages []Message `json:"messages,omitempty"`
+}
+
+// PostMessage posts text to channel, as a reply in threadTS when it is
+// set, and returns the new message's ts.
+func (c *Client) PostMessage(ctx context.Context, channel, threadTS, text string) (string, error) {
+	body := map[string]string{"channel": channel, "text": text}
+	if threadTS != "" {
+		body["thread_ts"] = threadTS
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.call(ctx, http.MethodPost, "chat.postMessage", nil, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	return resp.TS, nil
+}
+
+// Replies returns the messages of the thread rooted at threadTS posted
+// after oldest (all of them when oldest is empty), oldest first. The root
+// message itself is included when it is newer than oldest.
+func (c *Client) Replies(ctx context.Context, channel, threadTS, oldest string) ([]Message, error) {
+	q := url.Values{"channel": {channel}, "ts": {threadTS}, "limit": {"100"}}
+	if oldest != "" {
+		q.Set("oldest", oldest)
+	}
+	resp, err := c.call(ctx, http.MethodGet, "conversations.replies", q, nil)
+	if err != nil {
+		return nil, err
+	}
+	var msgs []Message
+	for _, m := range resp.Messages {
+		if oldest == "" || TSAfter(m.TS, oldest) {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs, nil
+}
+
+func (c *Client) call(ctx context.Context, method, endpoint string, query url.Values, body io.Reader) (*apiResponse, error) {
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	u := strings.TrimRight(base, "/") + "/" + endpoint
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("slack %s: %s", endpoint, res.Status)
+	}
+	var out apiResponse
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("slack %s: decode response: %w", endpoint, err)
+	}
+	if !out.OK {
+		return nil, fmt.Errorf("slack %s: %s", endpoint, out.Error)
+	}
+	return &out, nil
+}
+
+// TSAfter reports whether Slack timestamp a ("1700000000.000200") is later
+// than b. Timestamps are compared numerically part by part; a float would
+// lose the microseconds.
+func TSAfter(a, b string) bool {
+	as, af := splitTS(a)
+	bs, bf := splitTS(b)
+	if as != bs {
+		return as > bs
+	}
+	return af > bf
+}
+
+func splitTS(ts string) (int64, int64) {
+	secs, frac, _ := strings.Cut(ts, ".")
+	s, _ := strconv.ParseInt(secs, 10, 64)
+	frac = (frac + "000000")[:6]
+	f, _ := strconv.ParseInt(frac, 10, 64)
+	return s, f
+}
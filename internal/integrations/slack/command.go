@@ -0,0 +1,125 @@
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// CommandPath is where `agent-deck web` serves the slash command; point
+// the Slack app's /agentdeck Request URL at it.
+const CommandPath = "/integrations/slack/command"
+
+// maxRequestAge rejects replayed slash-command requests, as Slack advises.
+const maxRequestAge = 5 * time.Minute
+
+// commandDeadline leaves headroom in Slack's 3s response window.
+const commandDeadline = 2500 * time.Millisecond
+
+// CommandDeliverFunc types text into the session named by ref (title, ID or
+// path, as accepted by `agent-deck session send`).
+type CommandDeliverFunc func(ctx context.Context, ref, text string) error
+
+// CommandHandler serves `/agentdeck <session> <message>`: it verifies the
+// request signature, checks the user allowlist and delivers the message.
+type CommandHandler struct {
+	cfg     session.SlackIntegrationSettings
+	deliver CommandDeliverFunc
+	now     func() time.Time
+}
+
+// NewCommandHandler returns the slash-command handler, or nil when the
+// integration is off or has no signing secret.
+func NewCommandHandler(cfg session.SlackIntegrationSettings, deliver CommandDeliverFunc) *CommandHandler {
+	if !cfg.Enabled || cfg.SigningSecret == "" {
+		return nil
+	}
+	return &CommandHandler{cfg: cfg, deliver: deliver, now: time.Now}
+}
+
+func (h *CommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 64*1024))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !VerifySignature(h.cfg.SigningSecret, r.Header.Get("X-Slack-Request-Timestamp"),
+		r.Header.Get("X-Slack-Signature"), body, h.now()) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	user := form.Get("user_id")
+	if !h.cfg.AllowsUser(user) {
+		slackLog.Info("slack_command_rejected", slog.String("user", user))
+		writeEphemeral(w, "You are not allowed to send to agent-deck sessions.")
+		return
+	}
+	ref, text, _ := strings.Cut(strings.TrimSpace(UnescapeText(form.Get("text"))), " ")
+	text = strings.TrimSpace(text)
+	if ref == "" || text == "" {
+		writeEphemeral(w, "Usage: "+form.Get("command")+" <session> <message>")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), commandDeadline)
+	defer cancel()
+	if err := h.deliver(ctx, ref, text); err != nil {
+		slackLog.Warn("slack_command_delivery_failed",
+			slog.String("session", ref),
+			slog.String("error", err.Error()))
+		writeEphemeral(w, "Could not send to "+ref+": "+err.Error())
+		return
+	}
+	writeEphemeral(w, "Sent to "+ref+".")
+}
+
+// VerifySignature checks a request against Slack's v0 signing scheme:
+// sig is "v0=" + hex(HMAC-SHA256(secret, "v0:" + ts + ":" + body)), and ts
+// must be within maxRequestAge of now.
+func VerifySignature(secret, ts, sig string, body []byte, now time.Time) bool {
+	secs, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := now.Sub(time.Unix(secs, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return false
+	}
+	got, ok := strings.CutPrefix(sig, "v0=")
+	if !ok {
+		return false
+	}
+	gotMAC, err := hex.DecodeString(got)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + ts + ":"))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), gotMAC)
+}
+
+func writeEphemeral(w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"response_type": "ephemeral", "text": text})
+}
@@ -0,0 +1,143 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/logging"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+var slackLog = logging.ForComponent(logging.CompNotif)
+
+// Notice is a session that needs input.
+type Notice struct {
+	SessionID string
+	Title     string
+	Reason    string // Attention.Reason(), e.g. "needs permission"; may be empty
+}
+
+// Text is the message posted for n.
+func (n Notice) Text() string {
+	if n.Reason != "" {
+		return fmt.Sprintf("*%s* is waiting (%s). Reply in this thread to answer.", n.Title, n.Reason)
+	}
+	return fmt.Sprintf("*%s* is waiting for input. Reply in this thread to answer.", n.Title)
+}
+
+// DeliverFunc types text into the session with the given ID.
+type DeliverFunc func(sessionID, text string) error
+
+// Integration posts notices and routes thread replies for one channel.
+type Integration struct {
+	cfg     session.SlackIntegrationSettings
+	client  *Client
+	threads *Threads
+}
+
+// New returns an integration posting with client and tracking threads.
+func New(cfg session.SlackIntegrationSettings, client *Client, threads *Threads) *Integration {
+	return &Integration{cfg: cfg, client: client, threads: threads}
+}
+
+// Threads returns the session → thread map.
+func (in *Integration) Threads() *Threads {
+	return in.threads
+}
+
+// Notify posts n: the first notice of a session starts its thread, later
+// ones are replies in it, so one session's history stays in one place.
+func (in *Integration) Notify(ctx context.Context, n Notice) error {
+	th, ok := in.threads.Get(n.SessionID)
+	if ok && th.Channel == in.cfg.ChannelID {
+		ts, err := in.client.PostMessage(ctx, th.Channel, th.TS, n.Text())
+		if err != nil {
+			return err
+		}
+		return in.threads.MarkSeen(n.SessionID, ts)
+	}
+	ts, err := in.client.PostMessage(ctx, in.cfg.ChannelID, "", n.Text())
+	if err != nil {
+		return err
+	}
+	return in.threads.Set(n.SessionID, Thread{Channel: in.cfg.ChannelID, TS: ts, LastSeen: ts})
+}
+
+// PollReplies fetches new replies in every session thread and hands those
+// from allowed users to deliver. Each reply is handled once: LastSeen moves
+// past it whether it was delivered, ignored or failed. A failed delivery is
+// reported back in the thread.
+func (in *Integration) PollReplies(ctx context.Context, deliver DeliverFunc) error {
+	var errs []error
+	for id, th := range in.threads.All() {
+		msgs, err := in.client.Replies(ctx, th.Channel, th.TS, th.LastSeen)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("session %s: %w", id, err))
+			continue
+		}
+		for _, m := range msgs {
+			if err := in.threads.MarkSeen(id, m.TS); err != nil {
+				errs = append(errs, err)
+			}
+			if !m.FromHuman() {
+				continue
+			}
+			if !in.cfg.AllowsUser(m.User) {
+				slackLog.Info("slack_reply_ignored",
+					slog.String("session", id),
+					slog.String("user", m.User))
+				continue
+			}
+			text := UnescapeText(m.Text)
+			if text == "" {
+				continue
+			}
+			if err := deliver(id, text); err != nil {
+				slackLog.Warn("slack_reply_delivery_failed",
+					slog.String("session", id),
+					slog.String("error", err.Error()))
+				msg := "Could not deliver that reply: " + err.Error()
+				if _, perr := in.client.PostMessage(ctx, th.Channel, th.TS, msg); perr != nil {
+					errs = append(errs, perr)
+				}
+				continue
+			}
+			slackLog.Info("slack_reply_delivered", slog.String("session", id))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Forget drops the session's thread; replies to it are no longer polled.
+func (in *Integration) Forget(sessionID string) error {
+	return in.threads.Remove(sessionID)
+}
+
+// UnescapeText turns Slack's message encoding back into what the user typed:
+// &amp; &lt; &gt; are decoded and <url|label> links collapse to the url.
+func UnescapeText(s string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(s, '<')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start:], '>')
+		if end < 0 {
+			break
+		}
+		b.WriteString(s[:start])
+		inner := s[start+1 : start+end]
+		if target, _, ok := strings.Cut(inner, "|"); ok {
+			inner = target
+		}
+		b.WriteString(inner)
+		s = s[start+end+1:]
+	}
+	b.WriteString(s)
+	r := strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+	return strings.TrimSpace(r.Replace(b.String()))
+}
@@ -0,0 +1,235 @@
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// fakeSlack is a Web API stand-in recording posts and serving replies.
+type fakeSlack struct {
+	mu      sync.Mutex
+	posts   []map[string]string
+	replies map[string][]Message // thread ts → messages
+	nextTS  int
+}
+
+func (f *fakeSlack) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Header.Get("Authorization") != "Bearer xoxb-test" {
+		_ = json.NewEncoder(w).Encode(apiResponse{Error: "invalid_auth"})
+		return
+	}
+	switch r.URL.Path {
+	case "/chat.postMessage":
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.posts = append(f.posts, body)
+		f.nextTS++
+		_ = json.NewEncoder(w).Encode(apiResponse{OK: true, TS: "1700000000.00000" + strconv.Itoa(f.nextTS)})
+	case "/conversations.replies":
+		_ = json.NewEncoder(w).Encode(apiResponse{OK: true, Messages: f.replies[r.URL.Query().Get("ts")]})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestIntegration(t *testing.T, fake *fakeSlack) *Integration {
+	t.Helper()
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	threads, err := LoadThreads(filepath.Join(t.TempDir(), "slack_threads.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient("xoxb-test")
+	client.BaseURL = srv.URL
+	cfg := session.SlackIntegrationSettings{
+		Enabled: true, BotToken: "xoxb-test", ChannelID: "C1", AllowedUserIDs: []string{"U1"},
+	}
+	return New(cfg, client, threads)
+}
+
+func TestNotify_ThreadsPerSession(t *testing.T) {
+	fake := &fakeSlack{}
+	in := newTestIntegration(t, fake)
+	ctx := context.Background()
+
+	if err := in.Notify(ctx, Notice{SessionID: "s1", Title: "api", Reason: "needs permission"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.Notify(ctx, Notice{SessionID: "s1", Title: "api"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.posts) != 2 {
+		t.Fatalf("posts = %d, want 2", len(fake.posts))
+	}
+	if fake.posts[0]["thread_ts"] != "" || !strings.Contains(fake.posts[0]["text"], "needs permission") {
+		t.Errorf("first notice = %v, want a top-level post with the reason", fake.posts[0])
+	}
+	if fake.posts[1]["thread_ts"] != "1700000000.000001" {
+		t.Errorf("second notice thread_ts = %q, want the first notice's ts", fake.posts[1]["thread_ts"])
+	}
+
+	// The thread map survives a reload.
+	reloaded, err := LoadThreads(in.threads.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	th, ok := reloaded.Get("s1")
+	if !ok || th.TS != "1700000000.000001" || th.LastSeen != "1700000000.000002" {
+		t.Errorf("reloaded thread = %+v, %v", th, ok)
+	}
+}
+
+func TestPollReplies_RoutesAllowedHumanRepliesOnce(t *testing.T) {
+	fake := &fakeSlack{replies: map[string][]Message{}}
+	in := newTestIntegration(t, fake)
+	ctx := context.Background()
+	if err := in.Notify(ctx, Notice{SessionID: "s1", Title: "api"}); err != nil {
+		t.Fatal(err)
+	}
+	fake.replies["1700000000.000001"] = []Message{
+		{User: "U1", Text: "1700000000.000001 root", TS: "1700000000.000001", BotID: "B1"},
+		{User: "U1", Text: "yes &amp; go", TS: "1700000001.000000"},
+		{User: "U2", Text: "rm -rf /", TS: "1700000002.000000"},
+		{BotID: "B1", Text: "bot chatter", TS: "1700000003.000000"},
+		{User: "U1", Text: "fail please", TS: "1700000004.000000"},
+	}
+
+	var got []string
+	deliver := func(id, text string) error {
+		if text == "fail please" {
+			return errors.New("tmux gone")
+		}
+		got = append(got, id+":"+text)
+		return nil
+	}
+	if err := in.PollReplies(ctx, deliver); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != "s1:yes & go" {
+		t.Errorf("delivered = %v, want [s1:yes & go]", got)
+	}
+	if last := fake.posts[len(fake.posts)-1]; !strings.Contains(last["text"], "tmux gone") {
+		t.Errorf("failed delivery not reported in thread: %v", last)
+	}
+
+	// A second poll with the same replies delivers nothing new.
+	got = nil
+	if err := in.PollReplies(ctx, deliver); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("replies re-delivered: %v", got)
+	}
+}
+
+func TestTSAfter(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"1700000000.000002", "1700000000.000001", true},
+		{"1700000000.000001", "1700000000.000001", false},
+		{"1700000001.0", "1700000000.999999", true},
+		{"1700000000.5", "1700000000.000006", true},
+		{"1700000000.000001", "", true},
+	}
+	for _, c := range cases {
+		if got := TSAfter(c.a, c.b); got != c.want {
+			t.Errorf("TSAfter(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestUnescapeText(t *testing.T) {
+	cases := map[string]string{
+		"a &lt;b&gt; &amp; c":                      "a <b> & c",
+		"see <https://example.com|example.com> ok": "see https://example.com ok",
+		"  <https://x.test>  ":                     "https://x.test",
+		"unclosed < bracket":                       "unclosed < bracket",
+	}
+	for in, want := range cases {
+		if got := UnescapeText(in); got != want {
+			t.Errorf("UnescapeText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func signedCommand(secret string, ts time.Time, form url.Values) *http.Request {
+	body := form.Encode()
+	stamp := strconv.FormatInt(ts.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + stamp + ":" + body))
+	req := httptest.NewRequest(http.MethodPost, CommandPath, strings.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", stamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestCommandHandler(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cfg := session.SlackIntegrationSettings{Enabled: true, SigningSecret: "shh", AllowedUserIDs: []string{"U1"}}
+	if NewCommandHandler(session.SlackIntegrationSettings{Enabled: true}, nil) != nil {
+		t.Fatal("handler without a signing secret should be nil")
+	}
+
+	var sent []string
+	h := NewCommandHandler(cfg, func(_ context.Context, ref, text string) error {
+		sent = append(sent, ref+"|"+text)
+		return nil
+	})
+	h.now = func() time.Time { return now }
+
+	reply := func(req *http.Request) (int, string) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		var body map[string]string
+		_ = json.NewDecoder(rec.Body).Decode(&body)
+		return rec.Code, body["text"]
+	}
+
+	form := url.Values{"command": {"/agentdeck"}, "user_id": {"U1"}, "text": {"api  run the tests"}}
+	if code, text := reply(signedCommand("shh", now, form)); code != http.StatusOK || text != "Sent to api." {
+		t.Errorf("allowed command = %d %q", code, text)
+	}
+	if len(sent) != 1 || sent[0] != "api|run the tests" {
+		t.Errorf("sent = %v", sent)
+	}
+
+	if code, _ := reply(signedCommand("wrong", now, form)); code != http.StatusUnauthorized {
+		t.Errorf("bad signature code = %d, want 401", code)
+	}
+	if code, _ := reply(signedCommand("shh", now.Add(-10*time.Minute), form)); code != http.StatusUnauthorized {
+		t.Errorf("stale request code = %d, want 401", code)
+	}
+
+	form.Set("user_id", "U2")
+	if _, text := reply(signedCommand("shh", now, form)); !strings.Contains(text, "not allowed") {
+		t.Errorf("disallowed user reply = %q", text)
+	}
+	form.Set("user_id", "U1")
+	form.Set("text", "api")
+	if _, text := reply(signedCommand("shh", now, form)); !strings.HasPrefix(text, "Usage: /agentdeck") {
+		t.Errorf("usage reply = %q", text)
+	}
+	if len(sent) != 1 {
+		t.Errorf("rejected commands were delivered: %v", sent)
+	}
+}
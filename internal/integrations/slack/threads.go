@@ -0,0 +1,117 @@
+package slack
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/asheshgoplani/agent-deck/internal/agentpaths"
+	"github.com/asheshgoplani/agent-deck/internal/atomicfile"
+)
+
+// Thread is the Slack thread a session's notices are posted in.
+type Thread struct {
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+
+	// LastSeen is the ts of the newest message already handled; replies at
+	// or before it are not routed again.
+	LastSeen string `json:"last_seen"`
+}
+
+// Threads maps session IDs to their threads. It is persisted so replies to
+// a notice keep routing across TUI restarts. Safe for concurrent use.
+type Threads struct {
+	path string
+
+	mu       sync.Mutex
+	sessions map[string]Thread
+}
+
+// DefaultThreadsPath is the runtime state file holding the thread map.
+func DefaultThreadsPath() (string, error) {
+	return agentpaths.EffectiveDataPath(filepath.Join("runtime", "slack_threads.json"), "runtime")
+}
+
+// LoadThreads reads the thread map at path. A missing file is an empty map.
+func LoadThreads(path string) (*Threads, error) {
+	t := &Threads{path: path, sessions: map[string]Thread{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &t.sessions); err != nil {
+		return nil, err
+	}
+	if t.sessions == nil {
+		t.sessions = map[string]Thread{}
+	}
+	return t, nil
+}
+
+// Get returns the session's thread.
+func (t *Threads) Get(sessionID string) (Thread, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	th, ok := t.sessions[sessionID]
+	return th, ok
+}
+
+// All returns a copy of the map.
+func (t *Threads) All() map[string]Thread {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make(map[string]Thread, len(t.sessions))
+	for id, th := range t.sessions {
+		out[id] = th
+	}
+	return out
+}
+
+// Set records the session's thread and saves the map.
+func (t *Threads) Set(sessionID string, th Thread) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sessions[sessionID] = th
+	return t.saveLocked()
+}
+
+// MarkSeen advances the session's LastSeen to ts (never backwards).
+func (t *Threads) MarkSeen(sessionID, ts string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	th, ok := t.sessions[sessionID]
+	if !ok || !TSAfter(ts, th.LastSeen) {
+		return nil
+	}
+	th.LastSeen = ts
+	t.sessions[sessionID] = th
+	return t.saveLocked()
+}
+
+// Remove forgets the session's thread, e.g. once the session is deleted.
+func (t *Threads) Remove(sessionID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.sessions[sessionID]; !ok {
+		return nil
+	}
+	delete(t.sessions, sessionID)
+	return t.saveLocked()
+}
+
+func (t *Threads) saveLocked() error {
+	data, err := json.MarshalIndent(t.sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o700); err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(t.path, data, 0o600)
+}
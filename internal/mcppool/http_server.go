@@ -8,12 +8,10 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"sync"
 	"syscall"
 	"time"
 
-	"github.com/asheshgoplani/agent-deck/internal/agentpaths"
 	"github.com/asheshgoplani/agent-deck/internal/childenv"
 	"github.com/asheshgoplani/agent-deck/internal/logging"
 )
@@ -218,14 +216,8 @@ func (s *HTTPServer) Start() error {
 	s.mu.Unlock()
 
 	// Create log file
-	logDir, err := agentpaths.EffectiveDataPath(filepath.Join("logs", "http-servers"), "logs")
-	if err != nil {
-		logDir = filepath.Join(os.TempDir(), "agent-deck", "logs", "http-servers")
-	}
-	_ = os.MkdirAll(logDir, 0700)
-	s.logFile = filepath.Join(logDir, fmt.Sprintf("%s.log", s.name))
-
-	logWriter, err := os.Create(s.logFile)
+	s.logFile = HTTPServerLogPath(s.name)
+	logWriter, err := openMCPLog(s.logFile, LogLimits{}, s.command, s.args)
 	if err != nil {
 		s.SetStatus(StatusFailed)
 		return fmt.Errorf("failed to create log file: %w", err)
@@ -284,7 +276,8 @@ func (s *HTTPServer) Start() error {
 	}
 	s.process.WaitDelay = 3 * time.Second
 
-	// Capture stderr for debugging
+	// Capture output for debugging
+	s.process.Stdout = logWriter
 	s.process.Stderr = logWriter
 
 	// Start process
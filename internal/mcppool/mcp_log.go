@@ -0,0 +1,140 @@
+package mcppool
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/asheshgoplani/agent-deck/internal/agentpaths"
+)
+
+// Per-MCP log rotation defaults, used when [mcp_pool] leaves them unset.
+const (
+	DefaultLogMaxSizeMB  = 5
+	DefaultLogMaxBackups = 3
+)
+
+// LogLimits bounds one MCP's captured output. Zero fields use the defaults.
+type LogLimits struct {
+	MaxSizeMB  int
+	MaxBackups int
+}
+
+// logDir returns the directory for one family of MCP logs ("mcppool" for
+// socket proxies, "http-servers" for HTTP MCP servers).
+func logDir(kind string) string {
+	dir, err := agentpaths.EffectiveDataPath(filepath.Join("logs", kind), "logs")
+	if err != nil {
+		dir = filepath.Join(os.TempDir(), "agent-deck", "logs", kind)
+	}
+	return dir
+}
+
+// SocketLogPath is where a pooled stdio MCP's stderr is captured.
+func SocketLogPath(name string) string {
+	return filepath.Join(logDir("mcppool"), fmt.Sprintf("%s_socket.log", name))
+}
+
+// HTTPServerLogPath is where an auto-started HTTP MCP server's stdout and
+// stderr are captured.
+func HTTPServerLogPath(name string) string {
+	return filepath.Join(logDir("http-servers"), fmt.Sprintf("%s.log", name))
+}
+
+// openMCPLog returns a size-rotated writer for path. Output is appended, so
+// the log spans restarts; a header line marks each start.
+func openMCPLog(path string, limits LogLimits, command string, args []string) (io.WriteCloser, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	if limits.MaxSizeMB <= 0 {
+		limits.MaxSizeMB = DefaultLogMaxSizeMB
+	}
+	if limits.MaxBackups <= 0 {
+		limits.MaxBackups = DefaultLogMaxBackups
+	}
+	w := &mcpLog{w: &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    limits.MaxSizeMB,
+		MaxBackups: limits.MaxBackups,
+	}}
+	header := fmt.Sprintf("=== %s started: %s\n", time.Now().Format(time.RFC3339), strings.Join(append([]string{command}, args...), " "))
+	if _, err := w.Write([]byte(header)); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// mcpLog guards a lumberjack.Logger against writes after Close. lumberjack
+// reopens its file on any write, so a stderr copy still draining after Stop
+// would otherwise leak a descriptor.
+type mcpLog struct {
+	mu     sync.Mutex
+	w      *lumberjack.Logger
+	closed bool
+}
+
+func (l *mcpLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return 0, os.ErrClosed
+	}
+	return l.w.Write(p)
+}
+
+func (l *mcpLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	return l.w.Close()
+}
+
+// maxLogTailBytes bounds how much of a log ReadLogTail reads.
+const maxLogTailBytes = 256 * 1024
+
+// ReadLogTail returns up to maxLines of the end of the log at path, oldest
+// first. Only the current file is read, not rotated backups.
+func ReadLogTail(path string, maxLines int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	start := max(info.Size()-maxLogTailBytes, 0)
+	data := make([]byte, info.Size()-start)
+	if _, err := f.ReadAt(data, start); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if start > 0 {
+		// Drop the partial first line.
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil, nil
+	}
+	if maxLines > 0 && len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	return lines, nil
+}
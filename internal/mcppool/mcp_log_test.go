@@ -0,0 +1,70 @@
+package mcppool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenMCPLog_AppendsAcrossStarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "exa_socket.log")
+
+	for i := 0; i < 2; i++ {
+		w, err := openMCPLog(path, LogLimits{}, "npx", []string{"-y", "exa"})
+		require.NoError(t, err)
+		_, err = fmt.Fprintf(w, "run %d\n", i)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		// Writes after Close must not reopen the file.
+		_, err = w.Write([]byte("late\n"))
+		assert.ErrorIs(t, err, os.ErrClosed)
+	}
+
+	lines, err := ReadLogTail(path, 0)
+	require.NoError(t, err)
+	require.Len(t, lines, 4, "both starts are kept: %q", lines)
+	assert.True(t, strings.HasPrefix(lines[0], "=== "))
+	assert.Contains(t, lines[0], "npx -y exa")
+	assert.Equal(t, "run 0", lines[1])
+	assert.Equal(t, "run 1", lines[3])
+}
+
+func TestReadLogTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.log")
+	var b strings.Builder
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	require.NoError(t, os.WriteFile(path, []byte(b.String()), 0600))
+
+	lines, err := ReadLogTail(path, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"line 7", "line 8", "line 9"}, lines)
+
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+	lines, err = ReadLogTail(path, 3)
+	require.NoError(t, err)
+	assert.Empty(t, lines)
+
+	_, err = ReadLogTail(filepath.Join(t.TempDir(), "missing.log"), 3)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestReadLogTail_LargeFileDropsPartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "big.log")
+	line := strings.Repeat("x", 999) + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat(line, 400)+"tail\n"), 0600))
+
+	lines, err := ReadLogTail(path, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "tail", lines[len(lines)-1])
+	for _, l := range lines[:len(lines)-1] {
+		require.Len(t, l, 999, "every line read must be whole")
+	}
+}
@@ -22,6 +22,12 @@ type Pool struct {
 	ctx     context.Context
 	cancel  context.CancelFunc
 	config  *PoolConfig
+
+	// errors keeps recent failures per MCP across restarts (guarded by mu).
+	errors map[string]*proxyErrors
+
+	statusMu   sync.Mutex // Serializes status snapshot writes
+	lastStatus []byte     // Proxy list behind the last snapshot written
 }
 
 type PoolConfig struct {
@@ -30,12 +36,14 @@ type PoolConfig struct {
 	ExcludeMCPs   []string
 	PoolMCPs      []string
 	FallbackStdio bool
+	LogLimits     LogLimits // Rotation for each MCP's captured output
 }
 
 func NewPool(ctx context.Context, config *PoolConfig) (*Pool, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	return &Pool{
 		proxies: make(map[string]*SocketProxy),
+		errors:  make(map[string]*proxyErrors),
 		ctx:     ctx,
 		cancel:  cancel,
 		config:  config,
@@ -54,8 +62,10 @@ func (p *Pool) Start(name, command string, args []string, env map[string]string)
 	if err != nil {
 		return err
 	}
+	p.prepareProxy(proxy)
 
 	if err := proxy.Start(); err != nil {
+		proxy.errs.add("start failed: " + err.Error())
 		return err
 	}
 
@@ -119,7 +129,27 @@ func (p *Pool) IsRunning(name string) bool {
 	return false
 }
 
-// RestartProxy stops and restarts a proxy that has died
+// Probe reports whether name is served by the pool and, if it is, whether
+// its proxy is running with a socket that accepts connections. Unlike
+// IsRunning it never restarts the proxy, so it is safe for background
+// health checks.
+func (p *Pool) Probe(name string) (pooled bool, err error) {
+	p.mu.RLock()
+	proxy, exists := p.proxies[name]
+	p.mu.RUnlock()
+	if !exists {
+		return false, nil
+	}
+	if status := proxy.GetStatus(); status != StatusRunning {
+		return true, fmt.Errorf("proxy %s", status)
+	}
+	if !isSocketAliveCheck(proxy.socketPath) {
+		return true, fmt.Errorf("socket not accepting connections")
+	}
+	return true, nil
+}
+
+// RestartProxy stops and restarts a proxy that has died or was stopped
 func (p *Pool) RestartProxy(name string) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -146,13 +176,20 @@ func (p *Pool) RestartProxy(name string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create proxy: %w", err)
 	}
+	p.prepareProxy(newProxy)
 
 	if err := newProxy.Start(); err != nil {
 		// Clean up the failed proxy to avoid leaking its context/goroutines
 		_ = newProxy.Stop()
+		newProxy.errs.add("restart failed: " + err.Error())
 		return fmt.Errorf("failed to start proxy: %w", err)
 	}
 
+	// Keep restart history so the dashboard shows cumulative counts
+	newProxy.restartCount = proxy.restartCount + 1
+	newProxy.totalFailures = proxy.totalFailures
+	newProxy.lastRestart = time.Now()
+
 	p.proxies[name] = newProxy
 	return nil
 }
@@ -204,6 +241,7 @@ func (p *Pool) Shutdown() error {
 		poolLog.Warn("shutdown_timeout")
 	}
 
+	p.removeStatus()
 	return nil
 }
 
@@ -220,6 +258,7 @@ func (p *Pool) StartHealthMonitor() {
 				return
 			case <-ticker.C:
 				p.restartFailedProxies()
+				p.writeStatus()
 			}
 		}
 	}()
@@ -320,10 +359,12 @@ func (p *Pool) RestartProxyWithRateLimit(name string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create proxy: %w", err)
 	}
+	p.prepareProxy(newProxy)
 
 	if err := newProxy.Start(); err != nil {
 		// Clean up the failed proxy to avoid leaking its context/goroutines
 		_ = newProxy.Stop()
+		newProxy.errs.add("restart failed: " + err.Error())
 
 		// Track the failure even though start failed - re-add to map so
 		// health monitor can see it and eventually mark it permanently failed
@@ -358,13 +399,28 @@ func (p *Pool) ListServers() []ProxyInfo {
 
 	list := []ProxyInfo{}
 	for _, proxy := range p.proxies {
-		list = append(list, ProxyInfo{
-			Name:       proxy.name,
-			SocketPath: proxy.socketPath,
-			Status:     proxy.GetStatus().String(),
-			Clients:    proxy.GetClientCount(),
-		})
+		status := proxy.GetStatus()
+		info := ProxyInfo{
+			Name:         proxy.name,
+			SocketPath:   proxy.socketPath,
+			Status:       status.String(),
+			Clients:      proxy.GetClientCount(),
+			External:     proxy.mcpProcess == nil,
+			Restarts:     proxy.restartCount,
+			Failures:     proxy.totalFailures,
+			RecentErrors: p.errors[proxy.name].snapshot(),
+		}
+		if !info.External && proxy.mcpProcess.Process != nil {
+			info.PID = proxy.mcpProcess.Process.Pid
+		}
+		if status == StatusRunning {
+			proxy.statusMu.RLock()
+			info.RunningSince = proxy.successSince
+			proxy.statusMu.RUnlock()
+		}
+		list = append(list, info)
 	}
+	sortProxyInfo(list)
 	return list
 }
 
@@ -382,11 +438,18 @@ func (p *Pool) GetRunningCount() int {
 	return count
 }
 
+// ProxyInfo describes one pooled MCP for status displays.
 type ProxyInfo struct {
-	Name       string
-	SocketPath string
-	Status     string
-	Clients    int
+	Name         string       `json:"name"`
+	SocketPath   string       `json:"socket_path"`
+	Status       string       `json:"status"`
+	Clients      int          `json:"clients"`
+	External     bool         `json:"external,omitempty"` // Socket owned by another agent-deck instance
+	PID          int          `json:"pid,omitempty"`
+	RunningSince time.Time    `json:"running_since,omitzero"` // Zero unless running
+	Restarts     int          `json:"restarts"`
+	Failures     int          `json:"failures"`
+	RecentErrors []ProxyError `json:"recent_errors,omitempty"`
 }
 
 // DiscoverExistingSockets scans for existing pool sockets owned by another agent-deck instance
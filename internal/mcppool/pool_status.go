@@ -0,0 +1,177 @@
+package mcppool
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// maxRecentErrors caps how many errors are kept per MCP for the dashboard.
+const maxRecentErrors = 5
+
+// ProxyError is one failure recorded for a pooled MCP.
+type ProxyError struct {
+	At      time.Time `json:"at"`
+	Message string    `json:"message"`
+}
+
+// proxyErrors is the bounded error history for one MCP. It is owned by the
+// pool, not the proxy, so it survives restarts; the proxy holds a pointer so
+// it can record its own process exits.
+type proxyErrors struct {
+	mu   sync.Mutex
+	list []ProxyError
+}
+
+func (e *proxyErrors) add(msg string) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	e.list = append(e.list, ProxyError{At: time.Now(), Message: msg})
+	if len(e.list) > maxRecentErrors {
+		e.list = e.list[len(e.list)-maxRecentErrors:]
+	}
+	e.mu.Unlock()
+}
+
+func (e *proxyErrors) snapshot() []ProxyError {
+	if e == nil {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.list) == 0 {
+		return nil
+	}
+	return append([]ProxyError(nil), e.list...)
+}
+
+// errorLog returns the error history for name, creating it on first use.
+// Callers must hold p.mu for writing.
+func (p *Pool) errorLog(name string) *proxyErrors {
+	if p.errors == nil {
+		p.errors = make(map[string]*proxyErrors)
+	}
+	e, ok := p.errors[name]
+	if !ok {
+		e = &proxyErrors{}
+		p.errors[name] = e
+	}
+	return e
+}
+
+// prepareProxy wires a new proxy to the pool's per-MCP state before Start.
+// Callers must hold p.mu for writing.
+func (p *Pool) prepareProxy(proxy *SocketProxy) {
+	proxy.errs = p.errorLog(proxy.name)
+	if p.config != nil {
+		proxy.logLimits = p.config.LogLimits
+	}
+}
+
+// StopProxy stops a pooled MCP and leaves it stopped: the health monitor only
+// restarts failed proxies. Sessions using it lose the MCP until RestartProxy
+// is called. Sockets owned by another agent-deck instance cannot be stopped
+// from here.
+func (p *Pool) StopProxy(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	proxy, exists := p.proxies[name]
+	if !exists {
+		return fmt.Errorf("proxy %s not found", name)
+	}
+	if proxy.mcpProcess == nil {
+		return fmt.Errorf("proxy %s is owned by another agent-deck instance", name)
+	}
+	poolLog.Info("proxy_stop_requested", slog.String("mcp", name))
+	return proxy.Stop()
+}
+
+// StatusSnapshot is the pool state written to disk for `agent-deck mcp
+// status`, which runs in a different process than the TUI that owns the pool.
+type StatusSnapshot struct {
+	PID       int         `json:"pid"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	Proxies   []ProxyInfo `json:"proxies"`
+}
+
+func statusFilePath() string {
+	return filepath.Join(mcpSocketDir(), "pool-status.json")
+}
+
+// writeStatus refreshes the on-disk snapshot when the pool state changed
+// since the last write. Uptime is derived from RunningSince by the reader, so
+// an idle pool does not rewrite the file on every health-monitor tick.
+func (p *Pool) writeStatus() {
+	proxies := p.ListServers()
+	data, err := json.Marshal(proxies)
+	if err != nil {
+		return
+	}
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+	if string(data) == string(p.lastStatus) {
+		return
+	}
+
+	snap := StatusSnapshot{PID: os.Getpid(), UpdatedAt: time.Now(), Proxies: proxies}
+	out, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return
+	}
+	path := statusFilePath()
+	_ = os.MkdirAll(filepath.Dir(path), 0700)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, out, 0600); err != nil {
+		poolLog.Debug("status_write_failed", slog.String("error", err.Error()))
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return
+	}
+	p.lastStatus = data
+}
+
+// removeStatus deletes the snapshot if this process wrote it.
+func (p *Pool) removeStatus() {
+	snap, err := ReadStatus()
+	if err == nil && snap.PID == os.Getpid() {
+		_ = os.Remove(statusFilePath())
+	}
+}
+
+// ReadStatus loads the snapshot written by the agent-deck instance that owns
+// the pool. It returns an os.IsNotExist error when no pool has written one.
+func ReadStatus() (*StatusSnapshot, error) {
+	data, err := os.ReadFile(statusFilePath())
+	if err != nil {
+		return nil, err
+	}
+	var snap StatusSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", statusFilePath(), err)
+	}
+	return &snap, nil
+}
+
+// Live reports whether the process that wrote the snapshot is still running.
+// A dead writer means the TUI exited without shutting the pool down.
+func (s *StatusSnapshot) Live() bool {
+	if s == nil || s.PID <= 0 {
+		return false
+	}
+	return syscall.Kill(s.PID, 0) == nil
+}
+
+func sortProxyInfo(list []ProxyInfo) {
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+}
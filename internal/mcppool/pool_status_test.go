@@ -0,0 +1,113 @@
+package mcppool
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatusTestPool(t *testing.T) *Pool {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "adps")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_DATA_HOME", dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	pool, err := NewPool(ctx, &PoolConfig{Enabled: true, PoolAll: true})
+	require.NoError(t, err)
+	return pool
+}
+
+func TestProxyErrors_KeepsMostRecent(t *testing.T) {
+	var e proxyErrors
+	for i := 0; i < maxRecentErrors+3; i++ {
+		e.add(fmt.Sprintf("err %d", i))
+	}
+	got := e.snapshot()
+	require.Len(t, got, maxRecentErrors)
+	assert.Equal(t, "err 3", got[0].Message)
+	assert.Equal(t, fmt.Sprintf("err %d", maxRecentErrors+2), got[len(got)-1].Message)
+
+	var nilLog *proxyErrors
+	nilLog.add("ignored")
+	assert.Nil(t, nilLog.snapshot())
+}
+
+func TestPool_StopProxy_LeavesProxyStopped(t *testing.T) {
+	pool := newStatusTestPool(t)
+	t.Cleanup(func() { _ = pool.Shutdown() })
+
+	require.NoError(t, pool.Start("idle", "sh", []string{"-c", "while read line; do echo $line; done"}, nil))
+	require.NoError(t, pool.StopProxy("idle"))
+
+	// broadcastResponses sees EOF after Stop; it must not flip the proxy to
+	// failed, or the health monitor would bring it straight back.
+	time.Sleep(100 * time.Millisecond)
+	pool.restartFailedProxies()
+	servers := pool.ListServers()
+	require.Len(t, servers, 1)
+	assert.Equal(t, "stopped", servers[0].Status)
+	assert.Empty(t, servers[0].RecentErrors)
+
+	require.NoError(t, pool.RestartProxy("idle"))
+	servers = pool.ListServers()
+	assert.Equal(t, "running", servers[0].Status)
+	assert.Equal(t, 1, servers[0].Restarts)
+	assert.False(t, servers[0].RunningSince.IsZero())
+	assert.NotZero(t, servers[0].PID)
+
+	assert.Error(t, pool.StopProxy("missing"))
+}
+
+func TestPool_StopProxy_RefusesExternalSocket(t *testing.T) {
+	pool := newStatusTestPool(t)
+	require.NoError(t, pool.RegisterExternalSocket("ext", "/nonexistent/mcp-ext.sock"))
+
+	err := pool.StopProxy("ext")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "another agent-deck instance")
+}
+
+func TestPool_RecordsProcessExit(t *testing.T) {
+	pool := newStatusTestPool(t)
+	t.Cleanup(func() { _ = pool.Shutdown() })
+
+	require.NoError(t, pool.Start("crashy", "sh", []string{"-c", "exit 3"}, nil))
+	require.Eventually(t, func() bool {
+		servers := pool.ListServers()
+		return len(servers) == 1 && len(servers[0].RecentErrors) == 1
+	}, 2*time.Second, 20*time.Millisecond)
+
+	servers := pool.ListServers()
+	assert.Equal(t, "failed", servers[0].Status)
+	assert.Contains(t, servers[0].RecentErrors[0].Message, "process exited: exit status")
+}
+
+func TestPool_StatusSnapshotRoundTrip(t *testing.T) {
+	pool := newStatusTestPool(t)
+	require.NoError(t, pool.RegisterExternalSocket("b-ext", "/nonexistent/b.sock"))
+	require.NoError(t, pool.RegisterExternalSocket("a-ext", "/nonexistent/a.sock"))
+
+	_, err := ReadStatus()
+	require.True(t, os.IsNotExist(err), "no snapshot before the first write, got %v", err)
+
+	pool.writeStatus()
+	snap, err := ReadStatus()
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), snap.PID)
+	require.Len(t, snap.Proxies, 2)
+	assert.Equal(t, "a-ext", snap.Proxies[0].Name, "proxies are sorted by name")
+	assert.True(t, snap.Proxies[0].External)
+
+	require.NoError(t, pool.Shutdown())
+	_, err = ReadStatus()
+	assert.True(t, os.IsNotExist(err), "shutdown removes the snapshot it wrote")
+}
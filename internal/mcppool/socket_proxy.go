@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -70,6 +71,7 @@ type SocketProxy struct {
 
 	logFile   string
 	logWriter io.WriteCloser
+	logLimits LogLimits // Rotation for logFile; set by the pool
 
 	Status        ServerStatus
 	statusMu      sync.RWMutex // Protects Status field
@@ -78,6 +80,10 @@ type SocketProxy struct {
 	totalFailures int          // Cumulative failures across all restarts
 	successSince  time.Time    // When the proxy last became StatusRunning
 
+	// errs is the pool's error history for this MCP; nil for proxies
+	// created outside a Pool.
+	errs *proxyErrors
+
 	// waitOnce guards p.mcpProcess.Wait() so exactly one goroutine reaps
 	// the child. Prior to v1.7.43, broadcastResponses() detected EOF on
 	// MCP-stdout (process died) but left the zombie unreaped until Stop()
@@ -163,6 +169,26 @@ func mcpSocketDir() string {
 	return dir
 }
 
+// SocketStatus is one pool socket found on disk.
+type SocketStatus struct {
+	Name  string
+	Path  string
+	Alive bool
+}
+
+// ScanSockets lists the pool sockets in the socket directory and whether each
+// accepts connections. A dead socket was left behind by a pool process that
+// exited without cleaning up; the next pool start replaces it.
+func ScanSockets() []SocketStatus {
+	matches, _ := filepath.Glob(filepath.Join(mcpSocketDir(), "mcp-*.sock"))
+	out := make([]SocketStatus, 0, len(matches))
+	for _, path := range matches {
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "mcp-"), ".sock")
+		out = append(out, SocketStatus{Name: name, Path: path, Alive: isSocketAlive(path)})
+	}
+	return out
+}
+
 func NewSocketProxy(ctx context.Context, name, command string, args []string, env map[string]string) (*SocketProxy, error) {
 	ctx, cancel := context.WithCancel(ctx)
 
@@ -221,14 +247,8 @@ func (p *SocketProxy) Start() error {
 		return err
 	}
 
-	logDir, err := agentpaths.EffectiveDataPath(filepath.Join("logs", "mcppool"), "logs")
-	if err != nil {
-		logDir = filepath.Join(os.TempDir(), "agent-deck", "logs", "mcppool")
-	}
-	_ = os.MkdirAll(logDir, 0700)
-	p.logFile = filepath.Join(logDir, fmt.Sprintf("%s_socket.log", p.name))
-
-	logWriter, err := os.Create(p.logFile)
+	p.logFile = SocketLogPath(p.name)
+	logWriter, err := openMCPLog(p.logFile, p.logLimits, p.command, p.args)
 	if err != nil {
 		return fmt.Errorf("failed to create log: %w", err)
 	}
@@ -438,6 +458,11 @@ func (p *SocketProxy) broadcastResponses() {
 
 		var resp JSONRPCResponse
 		if json.Unmarshal(line, &resp) != nil {
+			// Not JSON-RPC: servers that print banners or debug output
+			// to stdout. Keep it in the log alongside stderr.
+			if p.logWriter != nil {
+				_, _ = fmt.Fprintf(p.logWriter, "[stdout] %s\n", line)
+			}
 			p.broadcastToAll(line)
 			continue
 		}
@@ -456,14 +481,27 @@ func (p *SocketProxy) broadcastResponses() {
 		proxyLog.Info("broadcast_exited", slog.String("mcp", p.name))
 	}
 
-	// Mark proxy as failed so health monitor can restart it
-	p.SetStatus(StatusFailed)
+	// Mark proxy as failed so health monitor can restart it. A cancelled
+	// context means Stop() is tearing it down on purpose; leave the status
+	// to Stop so a stopped proxy is not auto-restarted.
+	stopping := p.ctx != nil && p.ctx.Err() != nil
+	if !stopping {
+		p.SetStatus(StatusFailed)
+	}
 
 	// Reap the MCP child now that its stdout is closed — otherwise it
 	// lingers as a zombie until Stop()/Restart() is called, which under
 	// "MCP attached but idle" workloads may be never (#677).
 	p.reap()
 
+	if !stopping {
+		msg := "process exited"
+		if p.mcpProcess != nil && p.mcpProcess.ProcessState != nil {
+			msg += ": " + p.mcpProcess.ProcessState.String()
+		}
+		p.errs.add(msg)
+	}
+
 	// Close all client connections so reconnecting proxies know to retry
 	p.closeAllClientsOnFailure()
 
@@ -0,0 +1,297 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"al.essio.dev/pkg/shellescape"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// Aider adapter.
+//
+// Aider (github.com/Aider-AI/aider) is a terminal pair-programming REPL:
+//
+//	aider                        # chat in the current git repo
+//	aider --model sonnet         # pick the main model
+//	aider --map-tokens 2048      # repo map budget (0 disables the map)
+//
+// It keeps no resumable session IDs, so agent-deck launches it fresh on
+// every start/restart. Conversation history is appended to
+// .aider.chat.history.md at the repo root, which ParseAiderHistory reads for
+// the analytics panel. Status detection keys off its input prompt ("> ",
+// "ask> ", "architect> ", ...) — see the tmux prompt patterns.
+
+// AiderHistoryFileName is the chat log aider writes at the repo root.
+const AiderHistoryFileName = ".aider.chat.history.md"
+
+// AiderSettings defines Aider CLI configuration.
+type AiderSettings struct {
+	// Command overrides the default binary/invocation for Aider sessions.
+	// Default: "aider"
+	Command string `toml:"command,omitempty"`
+
+	// EnvFile is a .env file specific to Aider sessions (API keys),
+	// sourced before the `aider` command runs. Optional.
+	EnvFile string `toml:"env_file,omitempty"`
+
+	// DefaultModel is the --model for new Aider sessions (e.g. "sonnet",
+	// "gpt-4o", "deepseek"). Empty uses Aider's own default.
+	DefaultModel string `toml:"default_model,omitempty"`
+
+	// MapTokens sets --map-tokens, the repo map token budget. 0 leaves
+	// Aider's default.
+	MapTokens int `toml:"map_tokens,omitempty"`
+}
+
+// AiderOptions holds launch options for Aider sessions
+type AiderOptions struct {
+	// Model overrides the main model for this session (--model)
+	Model string `json:"model,omitempty"`
+	// MapTokens overrides the repo map token budget (--map-tokens)
+	MapTokens int `json:"map_tokens,omitempty"`
+}
+
+// ToolName returns "aider"
+func (o *AiderOptions) ToolName() string {
+	return "aider"
+}
+
+// ToArgs returns command-line arguments based on options
+func (o *AiderOptions) ToArgs() []string {
+	var args []string
+	if o.Model != "" {
+		args = append(args, "--model", o.Model)
+	}
+	if o.MapTokens > 0 {
+		args = append(args, "--map-tokens", strconv.Itoa(o.MapTokens))
+	}
+	return args
+}
+
+// NewAiderOptions creates AiderOptions with defaults from config
+func NewAiderOptions(config *UserConfig) *AiderOptions {
+	opts := &AiderOptions{}
+	if config != nil {
+		opts.Model = config.Aider.DefaultModel
+		opts.MapTokens = config.Aider.MapTokens
+	}
+	return opts
+}
+
+// UnmarshalAiderOptions deserializes AiderOptions from JSON wrapper
+func UnmarshalAiderOptions(data json.RawMessage) (*AiderOptions, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var wrapper ToolOptionsWrapper
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, err
+	}
+
+	if wrapper.Tool != "aider" {
+		return nil, nil
+	}
+
+	var opts AiderOptions
+	if err := json.Unmarshal(wrapper.Options, &opts); err != nil {
+		return nil, err
+	}
+
+	return &opts, nil
+}
+
+// GetAiderOptions returns Aider-specific options, or nil if not set
+func (i *Instance) GetAiderOptions() *AiderOptions {
+	opts, err := UnmarshalAiderOptions(i.ToolOptionsJSON)
+	if err != nil {
+		return nil
+	}
+	return opts
+}
+
+// SetAiderOptions stores Aider-specific options
+func (i *Instance) SetAiderOptions(opts *AiderOptions) error {
+	if opts == nil {
+		i.ToolOptionsJSON = nil
+		return nil
+	}
+	data, err := MarshalToolOptions(opts)
+	if err != nil {
+		return err
+	}
+	i.ToolOptionsJSON = data
+	return nil
+}
+
+// buildAiderCommand builds the launch command for Aider.
+// Applies env sourcing, command override, and --model/--map-tokens from the
+// session's options (falling back to [aider] config). If baseCommand differs
+// from the bare tool name "aider", it is treated as a user-supplied
+// passthrough command and returned without flag injection.
+func (i *Instance) buildAiderCommand(baseCommand string) string {
+	if i.Tool != "aider" {
+		return baseCommand
+	}
+
+	envPrefix := i.buildEnvSourceCommand()
+
+	trimmed := strings.TrimSpace(baseCommand)
+	if trimmed != "" && trimmed != "aider" {
+		return envPrefix + trimmed
+	}
+
+	cmd := GetToolCommand("aider")
+
+	config, _ := LoadUserConfig()
+	opts := i.GetAiderOptions()
+	if opts == nil {
+		opts = NewAiderOptions(config)
+	} else if opts.Model == "" && config != nil {
+		// A cleared per-session model falls back to [aider].default_model.
+		opts.Model = config.Aider.DefaultModel
+	}
+	for _, arg := range opts.ToArgs() {
+		cmd += " " + shellescape.Quote(arg)
+	}
+
+	return envPrefix + cmd
+}
+
+// AiderHistoryPath returns the .aider.chat.history.md aider writes for this
+// session: at the git repo root, or the working directory outside a repo.
+func (i *Instance) AiderHistoryPath() string {
+	dir := i.EffectiveWorkingDir()
+	if root, err := git.GetRepoRoot(dir); err == nil && root != "" {
+		dir = root
+	}
+	return filepath.Join(dir, AiderHistoryFileName)
+}
+
+var (
+	// "# aider chat started at 2025-01-31 10:04:05"
+	aiderChatStartRe = regexp.MustCompile(`^# aider chat started at (\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})`)
+	// "> Tokens: 4.6k sent, 2.0k cache write, 1.2k cache hit, 285 received. Cost: $0.02 message, $0.05 session."
+	aiderTokensRe = regexp.MustCompile(`^> Tokens: (.+?)\. Cost: \$([\d.]+) message`)
+	aiderTokenRe  = regexp.MustCompile(`([\d.]+)([kM]?) (sent|received|cache write|cache hit)`)
+	// "> Main model: claude-3-5-sonnet-20241022 with diff edit format, ..."
+	aiderModelRe = regexp.MustCompile(`^> (?:Main )?[Mm]odel: (\S+)`)
+)
+
+// ParseAiderHistory builds analytics from an aider chat history file,
+// counting only chats started at or after since (a zero since counts the
+// whole file). Each "#### " user message is a turn; applied edits and
+// commits are reported as tool calls. EstimatedCost is aider's own
+// per-message cost.
+func ParseAiderHistory(path string, since time.Time) (*SessionAnalytics, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	analytics := &SessionAnalytics{
+		ToolCalls:     []ToolCall{},
+		Subagents:     []SubagentInfo{},
+		BillingBlocks: []BillingBlock{},
+	}
+	edits, commits := 0, 0
+	since = since.Truncate(time.Second) // chat headers have second precision
+	include := since.IsZero()
+	var chatStart time.Time
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := aiderChatStartRe.FindStringSubmatch(line); m != nil {
+			chatStart, _ = time.ParseInLocation("2006-01-02 15:04:05", m[1], time.Local)
+			include = since.IsZero() || !chatStart.Before(since)
+			if include && analytics.StartTime.IsZero() {
+				analytics.StartTime = chatStart
+			}
+			continue
+		}
+		if !include {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "#### "):
+			analytics.TotalTurns++
+		case strings.HasPrefix(line, "> Applied edit to "):
+			edits++
+		case strings.HasPrefix(line, "> Commit "):
+			commits++
+		default:
+			if m := aiderTokensRe.FindStringSubmatch(line); m != nil {
+				in, out, cacheWrite, cacheRead := parseAiderTokens(m[1])
+				analytics.InputTokens += in
+				analytics.OutputTokens += out
+				analytics.CacheWriteTokens += cacheWrite
+				analytics.CacheReadTokens += cacheRead
+				analytics.CurrentContextTokens = in + cacheRead
+				if cost, err := strconv.ParseFloat(m[2], 64); err == nil {
+					analytics.EstimatedCost += cost
+				}
+				analytics.addDailyTokens(chatStart, in+out+cacheWrite+cacheRead)
+			} else if m := aiderModelRe.FindStringSubmatch(line); m != nil {
+				analytics.Model = m[1]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if analytics.StartTime.IsZero() {
+		return nil, fmt.Errorf("no aider chats in %s", path)
+	}
+
+	if info, err := f.Stat(); err == nil {
+		analytics.LastActive = info.ModTime()
+		analytics.Duration = analytics.LastActive.Sub(analytics.StartTime)
+	}
+	if edits > 0 {
+		analytics.ToolCalls = append(analytics.ToolCalls, ToolCall{Name: "Edit", Count: edits})
+	}
+	if commits > 0 {
+		analytics.ToolCalls = append(analytics.ToolCalls, ToolCall{Name: "Commit", Count: commits})
+	}
+	return analytics, nil
+}
+
+// parseAiderTokens reads the counts in "4.6k sent, 2.0k cache write, 1.2k
+// cache hit, 285 received".
+func parseAiderTokens(s string) (sent, received, cacheWrite, cacheHit int) {
+	for _, m := range aiderTokenRe.FindAllStringSubmatch(s, -1) {
+		n, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			continue
+		}
+		switch m[2] {
+		case "k":
+			n *= 1_000
+		case "M":
+			n *= 1_000_000
+		}
+		switch m[3] {
+		case "sent":
+			sent = int(n)
+		case "received":
+			received = int(n)
+		case "cache write":
+			cacheWrite = int(n)
+		case "cache hit":
+			cacheHit = int(n)
+		}
+	}
+	return sent, received, cacheWrite, cacheHit
+}
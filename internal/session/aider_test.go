@@ -0,0 +1,152 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAiderOptions_ToArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     AiderOptions
+		expected []string
+	}{
+		{"default - no args", AiderOptions{}, nil},
+		{"model", AiderOptions{Model: "sonnet"}, []string{"--model", "sonnet"}},
+		{"model and map tokens", AiderOptions{Model: "gpt-4o", MapTokens: 2048}, []string{"--model", "gpt-4o", "--map-tokens", "2048"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.ToArgs(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ToArgs() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildAiderCommand_ConfigDefaults(t *testing.T) {
+	restore := resetUserConfigCache(t, &UserConfig{
+		Aider: AiderSettings{DefaultModel: "deepseek", MapTokens: 1024},
+	})
+	defer restore()
+
+	inst := &Instance{Tool: "aider"}
+	cmd := inst.buildAiderCommand("aider")
+	want := "aider --model deepseek --map-tokens 1024"
+	if !strings.HasSuffix(cmd, want) {
+		t.Errorf("buildAiderCommand() = %q, want suffix %q", cmd, want)
+	}
+}
+
+func TestBuildAiderCommand_LaunchModelOverridesConfig(t *testing.T) {
+	restore := resetUserConfigCache(t, &UserConfig{
+		Aider: AiderSettings{DefaultModel: "deepseek"},
+	})
+	defer restore()
+
+	inst := &Instance{Tool: "aider"}
+	if err := inst.ApplyLaunchModel("sonnet"); err != nil {
+		t.Fatalf("ApplyLaunchModel: %v", err)
+	}
+	if got := inst.buildAiderCommand("aider"); !strings.HasSuffix(got, "aider --model sonnet") {
+		t.Errorf("buildAiderCommand() = %q, want --model sonnet", got)
+	}
+
+	if err := inst.ClearLaunchModel(); err != nil {
+		t.Fatalf("ClearLaunchModel: %v", err)
+	}
+	if got := inst.buildAiderCommand("aider"); !strings.HasSuffix(got, "aider --model deepseek") {
+		t.Errorf("after clear buildAiderCommand() = %q, want config default --model deepseek", got)
+	}
+}
+
+func TestBuildAiderCommand_Passthrough(t *testing.T) {
+	restore := resetUserConfigCache(t, &UserConfig{
+		Aider: AiderSettings{DefaultModel: "deepseek"},
+	})
+	defer restore()
+
+	inst := &Instance{Tool: "aider"}
+	got := inst.buildAiderCommand("aider --architect --model o3-mini")
+	if !strings.HasSuffix(got, "aider --architect --model o3-mini") || strings.Contains(got, "deepseek") {
+		t.Errorf("buildAiderCommand passthrough = %q, want the command verbatim", got)
+	}
+}
+
+const aiderHistoryFixture = `
+# aider chat started at 2025-01-30 09:00:00
+
+> Main model: gpt-4o with diff edit format
+
+#### old question
+
+> Tokens: 1.0k sent, 100 received. Cost: $0.01 message, $0.01 session.
+
+# aider chat started at 2025-01-31 10:04:05
+
+> Aider v0.72.1
+> Main model: claude-3-5-sonnet-20241022 with diff edit format, infinite output
+
+#### add a --verbose flag
+
+I'll add the flag.
+
+> Tokens: 4.6k sent, 2.0k cache write, 1.2k cache hit, 285 received. Cost: $0.02 message, $0.02 session.
+> Applied edit to main.go
+> Applied edit to main_test.go
+> Commit 1a2b3c4 feat: Add --verbose flag
+
+#### /run go test ./...
+
+> Tokens: 1.5M sent, 1.5k received. Cost: $0.03 message, $0.05 session.
+`
+
+func TestParseAiderHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), AiderHistoryFileName)
+	if err := os.WriteFile(path, []byte(aiderHistoryFixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	since := time.Date(2025, 1, 31, 10, 4, 5, 500, time.Local)
+	a, err := ParseAiderHistory(path, since)
+	if err != nil {
+		t.Fatalf("ParseAiderHistory: %v", err)
+	}
+
+	if a.TotalTurns != 2 {
+		t.Errorf("TotalTurns = %d, want 2 (the earlier chat is excluded)", a.TotalTurns)
+	}
+	if a.InputTokens != 1_504_600 || a.OutputTokens != 1_785 {
+		t.Errorf("tokens in/out = %d/%d, want 1504600/1785", a.InputTokens, a.OutputTokens)
+	}
+	if a.CacheWriteTokens != 2_000 || a.CacheReadTokens != 1_200 {
+		t.Errorf("cache write/read = %d/%d, want 2000/1200", a.CacheWriteTokens, a.CacheReadTokens)
+	}
+	if a.EstimatedCost < 0.0499 || a.EstimatedCost > 0.0501 {
+		t.Errorf("EstimatedCost = %v, want 0.05", a.EstimatedCost)
+	}
+	if a.Model != "claude-3-5-sonnet-20241022" {
+		t.Errorf("Model = %q", a.Model)
+	}
+	want := []ToolCall{{Name: "Edit", Count: 2}, {Name: "Commit", Count: 1}}
+	if !reflect.DeepEqual(a.ToolCalls, want) {
+		t.Errorf("ToolCalls = %+v, want %+v", a.ToolCalls, want)
+	}
+
+	all, err := ParseAiderHistory(path, time.Time{})
+	if err != nil {
+		t.Fatalf("ParseAiderHistory (whole file): %v", err)
+	}
+	if all.TotalTurns != 3 {
+		t.Errorf("whole-file TotalTurns = %d, want 3", all.TotalTurns)
+	}
+
+	if _, err := ParseAiderHistory(path, time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)); err == nil {
+		t.Error("expected an error when no chat started after since")
+	}
+}
@@ -40,6 +40,20 @@ type SessionAnalytics struct {
 
 	// 5-hour billing blocks
 	BillingBlocks []BillingBlock `json:"billing_blocks"`
+
+	// Tokens (input + output + cache) per local calendar day, "2006-01-02"
+	DailyTokens map[string]int `json:"daily_tokens,omitempty"`
+}
+
+// addDailyTokens credits n tokens to the local day of t.
+func (a *SessionAnalytics) addDailyTokens(t time.Time, n int) {
+	if t.IsZero() || n == 0 {
+		return
+	}
+	if a.DailyTokens == nil {
+		a.DailyTokens = make(map[string]int)
+	}
+	a.DailyTokens[t.Local().Format("2006-01-02")] += n
 }
 
 // ToolCall represents a tool and its usage count
@@ -49,10 +63,13 @@ type ToolCall struct {
 }
 
 // SubagentInfo holds metadata about a subagent spawned during a session
+// (see FindClaudeSubagents)
 type SubagentInfo struct {
-	ID        string    `json:"id"`
-	StartTime time.Time `json:"start_time"`
-	Turns     int       `json:"turns"`
+	ID         string    `json:"id"`
+	StartTime  time.Time `json:"start_time"`
+	LastActive time.Time `json:"last_active"`
+	Turns      int       `json:"turns"`
+	Prompt     string    `json:"prompt,omitempty"` // first line of the task it was given
 }
 
 // BillingBlock represents a 5-hour billing window
@@ -177,8 +194,19 @@ type jsonlEntry struct {
 	AgentID string `json:"agent_id,omitempty"`
 }
 
-// ParseSessionJSONL parses a Claude session JSONL file and returns analytics
+// ParseSessionJSONL parses a Claude session JSONL file and returns analytics,
+// including the Task sub-agents the session spawned.
 func ParseSessionJSONL(path string) (*SessionAnalytics, error) {
+	analytics, err := ParseSessionJSONLSince(path, time.Time{})
+	if analytics != nil {
+		analytics.Subagents = FindClaudeSubagents(path)
+	}
+	return analytics, err
+}
+
+// ParseSessionJSONLSince is ParseSessionJSONL restricted to assistant turns
+// at or after since; a zero since counts the whole transcript.
+func ParseSessionJSONLSince(path string, since time.Time) (*SessionAnalytics, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -206,6 +234,9 @@ func ParseSessionJSONL(path string) (*SessionAnalytics, error) {
 		if entry.Type != "assistant" {
 			continue
 		}
+		if !since.IsZero() && entry.Timestamp.Before(since) {
+			continue
+		}
 
 		// Track timing
 		if !entry.Timestamp.IsZero() {
@@ -227,6 +258,8 @@ func ParseSessionJSONL(path string) (*SessionAnalytics, error) {
 		analytics.OutputTokens += entry.Message.Usage.OutputTokens
 		analytics.CacheReadTokens += entry.Message.Usage.CacheReadInputTokens
 		analytics.CacheWriteTokens += entry.Message.Usage.CacheCreationInputTokens
+		analytics.addDailyTokens(entry.Timestamp, entry.Message.Usage.InputTokens+entry.Message.Usage.OutputTokens+
+			entry.Message.Usage.CacheReadInputTokens+entry.Message.Usage.CacheCreationInputTokens)
 
 		// Track current context size (last turn's input + cache read)
 		// This represents the actual context window usage
@@ -1,6 +1,11 @@
 package session
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // IsArchived reports whether the session is in the user archive.
 func (i *Instance) IsArchived() bool {
@@ -32,3 +37,86 @@ func ArchiveTimeUTC(t time.Time) time.Time {
 	}
 	return t.UTC()
 }
+
+// ReasonIdleAutoArchived is the session-lifecycle.jsonl action recorded when
+// the [archive] idle_after sweep archives a session.
+const ReasonIdleAutoArchived = "idle-auto-archived"
+
+// ParseArchiveIdleAfter parses an [archive] idle_after value: a Go duration
+// ("72h", "90m") or a whole number of days ("7d"). Empty and "0" disable
+// auto-archive (returns 0); negative values are rejected.
+func ParseArchiveIdleAfter(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+	var d time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid idle_after %q: use a duration like 72h or a day count like 7d", value)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(value); err != nil {
+			return 0, fmt.Errorf("invalid idle_after %q: %w (use a duration like 72h or a day count like 7d)", value, err)
+		}
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid idle_after %q: negative durations not allowed (use 0 to disable)", value)
+	}
+	return d, nil
+}
+
+// LastActiveAt is the most recent sign of life for a session: pane output,
+// the user attaching, or a (re)start. Auto-archive measures idleness from it.
+func (i *Instance) LastActiveAt() time.Time {
+	last := i.GetLastActivityTime()
+	for _, t := range []time.Time{i.LastAccessedAt, i.LastStartedAt, i.CreatedAt} {
+		if t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
+
+// IdleArchiveCandidates returns the sessions the [archive] settings would
+// archive at now. Archived, pinned, busy and starting sessions are never
+// candidates; waiting sessions only when include_waiting is set.
+func IdleArchiveCandidates(instances []*Instance, settings ArchiveSettings, now time.Time) []*Instance {
+	idleAfter := settings.GetIdleAfter()
+	if idleAfter <= 0 {
+		return nil
+	}
+	var out []*Instance
+	for _, inst := range instances {
+		if inst == nil || inst.IsArchived() || inst.Pin != PinNone {
+			continue
+		}
+		switch inst.GetStatusThreadSafe() {
+		case StatusRunning, StatusStarting:
+			continue
+		case StatusWaiting:
+			if !settings.IncludeWaiting {
+				continue
+			}
+		}
+		if now.Sub(inst.LastActiveAt()) >= idleAfter {
+			out = append(out, inst)
+		}
+	}
+	return out
+}
+
+// ArchiveInstance stops the session with kill (inst.Kill or inst.KillAndWait)
+// and marks it archived at now. The tool session IDs are left untouched so a
+// later unarchive + start resumes the same conversation. A kill failure is
+// only an error while the tmux session still exists.
+func ArchiveInstance(inst *Instance, kill func() error, now time.Time) error {
+	if err := kill(); err != nil && inst.Exists() {
+		return err
+	}
+	inst.ArchivedAt = now.UTC()
+	return nil
+}
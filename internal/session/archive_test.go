@@ -97,3 +97,77 @@ func TestArchivedAtStorageRoundTrip(t *testing.T) {
 		t.Fatalf("archived filter after reload: got %+v", ids(arch))
 	}
 }
+
+func TestParseArchiveIdleAfter(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":    0,
+		"0":   0,
+		"72h": 72 * time.Hour,
+		"90m": 90 * time.Minute,
+		"7d":  7 * 24 * time.Hour,
+		" 2d": 48 * time.Hour,
+	}
+	for in, want := range cases {
+		got, err := ParseArchiveIdleAfter(in)
+		if err != nil || got != want {
+			t.Errorf("ParseArchiveIdleAfter(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"-1h", "xd", "soon", "-2d"} {
+		if _, err := ParseArchiveIdleAfter(bad); err == nil {
+			t.Errorf("ParseArchiveIdleAfter(%q) should error", bad)
+		}
+	}
+}
+
+func TestIdleArchiveCandidates(t *testing.T) {
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-5 * 24 * time.Hour)
+	instances := []*Instance{
+		{ID: "idle", Status: StatusIdle, CreatedAt: old},
+		{ID: "stopped", Status: StatusStopped, CreatedAt: old},
+		{ID: "running", Status: StatusRunning, CreatedAt: old},
+		{ID: "waiting", Status: StatusWaiting, CreatedAt: old},
+		{ID: "pinned", Status: StatusIdle, CreatedAt: old, Pin: PinTop},
+		{ID: "archived", Status: StatusStopped, CreatedAt: old, ArchivedAt: old},
+		{ID: "attached", Status: StatusIdle, CreatedAt: old, LastAccessedAt: now.Add(-time.Hour)},
+		{ID: "restarted", Status: StatusIdle, CreatedAt: old, LastStartedAt: now.Add(-time.Hour)},
+		nil,
+	}
+
+	if got := IdleArchiveCandidates(instances, ArchiveSettings{}, now); got != nil {
+		t.Fatalf("disabled settings should archive nothing, got %v", ids(got))
+	}
+
+	got := ids(IdleArchiveCandidates(instances, ArchiveSettings{IdleAfter: "3d"}, now))
+	if len(got) != 2 || got[0] != "idle" || got[1] != "stopped" {
+		t.Fatalf("candidates = %v, want [idle stopped]", got)
+	}
+
+	got = ids(IdleArchiveCandidates(instances, ArchiveSettings{IdleAfter: "3d", IncludeWaiting: true}, now))
+	if len(got) != 3 || got[2] != "waiting" {
+		t.Fatalf("include_waiting candidates = %v, want [idle stopped waiting]", got)
+	}
+
+	if got := IdleArchiveCandidates(instances, ArchiveSettings{IdleAfter: "6d"}, now); len(got) != 0 {
+		t.Fatalf("nothing is idle for 6d, got %v", ids(got))
+	}
+}
+
+func TestArchiveInstance_KeepsToolSessionID(t *testing.T) {
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	inst := &Instance{ID: "a", Tool: "claude", ClaudeSessionID: "uuid-1"}
+	killed := false
+	if err := ArchiveInstance(inst, func() error { killed = true; return nil }, now); err != nil {
+		t.Fatalf("ArchiveInstance: %v", err)
+	}
+	if !killed {
+		t.Fatal("ArchiveInstance must stop the session")
+	}
+	if !inst.ArchivedAt.Equal(now) {
+		t.Fatalf("ArchivedAt = %v, want %v", inst.ArchivedAt, now)
+	}
+	if inst.ClaudeSessionID != "uuid-1" {
+		t.Fatalf("ClaudeSessionID = %q, archive must preserve it for resume", inst.ClaudeSessionID)
+	}
+}
@@ -0,0 +1,98 @@
+package session
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// BranchKey identifies the repository/branch cluster a session is listed
+// under in the branch view. An empty Repo means the session is not in a git
+// checkout.
+type BranchKey struct {
+	Repo   string // repository root (worktrees resolve to their main repo)
+	Branch string // checked-out branch; "" when detached or unknown
+}
+
+// Label is the caption of the cluster's divider row.
+func (k BranchKey) Label() string {
+	if k.Repo == "" {
+		return "no git repo"
+	}
+	branch := k.Branch
+	if branch == "" {
+		branch = "(detached)"
+	}
+	return filepath.Base(k.Repo) + " ⎇ " + branch
+}
+
+// SessionItems lists every session in the tree as a session Item in group
+// order, ignoring expand/collapse state. Views that regroup sessions by
+// something other than their manual group (e.g. GroupByRepoBranch) start
+// from this instead of Flatten, which hides collapsed groups' sessions.
+// Archived groups stay hidden unless the tree's ShowArchived is set.
+func (t *GroupTree) SessionItems() []Item {
+	var items []Item
+	for _, group := range t.GroupList {
+		if t.archivedHidden(group.Path) {
+			continue
+		}
+		sessions := append([]*Instance(nil), group.Sessions...)
+		stablePinPartition(sessions)
+		for _, sess := range sessions {
+			items = append(items, Item{
+				Type:    ItemTypeSession,
+				Session: sess,
+				Level:   GetGroupLevel(group.Path) + 1,
+				Path:    group.Path,
+			})
+		}
+	}
+	return items
+}
+
+// GroupByRepoBranch regroups the session rows of items into clusters keyed by
+// repository and branch, each introduced by an ItemTypeDivider row, so that
+// several worktrees of one repo sit together regardless of manual groups.
+// Clusters are ordered by repo then branch, with non-git sessions last;
+// sessions keep their relative order within a cluster. Non-session rows are
+// dropped. Item.Path still carries the session's manual group.
+func GroupByRepoBranch(items []Item, key func(*Instance) BranchKey) []Item {
+	clusters := make(map[BranchKey][]Item)
+	var keys []BranchKey
+	for _, item := range items {
+		if item.Type != ItemTypeSession || item.Session == nil {
+			continue
+		}
+		k := key(item.Session)
+		if _, seen := clusters[k]; !seen {
+			keys = append(keys, k)
+		}
+		clusters[k] = append(clusters[k], item)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if (a.Repo == "") != (b.Repo == "") {
+			return b.Repo == "" // non-git cluster sinks to the bottom
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Branch < b.Branch
+	})
+
+	out := make([]Item, 0, len(items)+len(keys))
+	for _, k := range keys {
+		out = append(out, Item{Type: ItemTypeDivider, DividerLabel: k.Label()})
+		rows := clusters[k]
+		for i, item := range rows {
+			item.Level = 1
+			item.IsSubSession = false
+			item.IsLastSubSession = false
+			item.ParentIsLastInGroup = false
+			item.IsLastInGroup = i == len(rows)-1
+			out = append(out, item)
+		}
+	}
+	return out
+}
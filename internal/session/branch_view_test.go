@@ -0,0 +1,56 @@
+package session
+
+import "testing"
+
+func TestGroupByRepoBranch(t *testing.T) {
+	keys := map[string]BranchKey{
+		"1": {Repo: "/src/app", Branch: "main"},
+		"2": {Repo: "/src/app", Branch: "feat"},
+		"3": {},
+		"4": {Repo: "/src/app", Branch: "main"},
+		"5": {Repo: "/src/lib", Branch: ""},
+	}
+	items := []Item{
+		groupItem("a"),
+		sessItem("1", StatusIdle, "a"),
+		sessItem("3", StatusIdle, "a"),
+		groupItem("b"),
+		sessItem("2", StatusIdle, "b"),
+		sessItem("4", StatusIdle, "b"),
+		sessItem("5", StatusIdle, "b"),
+	}
+	got := GroupByRepoBranch(items, func(inst *Instance) BranchKey { return keys[inst.ID] })
+
+	want := []string{"---", "S:2", "---", "S:1", "S:4", "---", "S:5", "---", "S:3"}
+	if s := summarize(got); !eqSlice(s, want) {
+		t.Fatalf("GroupByRepoBranch = %v, want %v", s, want)
+	}
+
+	var labels []string
+	for _, it := range got {
+		if it.Type == ItemTypeDivider {
+			labels = append(labels, it.DividerLabel)
+		}
+	}
+	wantLabels := []string{"app ⎇ feat", "app ⎇ main", "lib ⎇ (detached)", "no git repo"}
+	if !eqSlice(labels, wantLabels) {
+		t.Errorf("labels = %v, want %v", labels, wantLabels)
+	}
+
+	// Sessions keep their manual group path; the last row of each cluster is
+	// marked for tree drawing.
+	if got[3].Path != "a" || got[3].IsLastInGroup || !got[4].IsLastInGroup {
+		t.Errorf("app/main rows = %+v, %+v", got[3], got[4])
+	}
+}
+
+func TestSessionItemsIgnoresCollapse(t *testing.T) {
+	tree := NewGroupTree([]*Instance{
+		{ID: "1", Title: "one", GroupPath: "a"},
+		{ID: "2", Title: "two", GroupPath: "b"},
+	})
+	tree.CollapseGroup("a")
+	if n := len(tree.SessionItems()); n != 2 {
+		t.Errorf("SessionItems = %d items, want 2 even with a collapsed group", n)
+	}
+}
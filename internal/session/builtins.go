@@ -47,12 +47,9 @@ type builtinTool struct {
 // this slice top-to-bottom and returns the first hit, so a command string that
 // contains two tool names resolves identically to the old switch.
 //
-// Two deliberate asymmetries preserved verbatim from the legacy code:
-//   - "aider" is a valid built-in NAME (isBuiltinToolName allowed it) but had NO
-//     arm in detectTool() — so detectTool("aider") returned "shell". It carries
-//     no detect patterns here, so Match() never returns "aider". (Open question
-//     for upstream: is that intended, or should aider gain a detect arm?)
-//   - "shell" is the catch-all fallback, never matched by a pattern.
+// "shell" is the catch-all fallback, never matched by a pattern. ("aider"
+// had no detect arm in the legacy switch, so commands containing it resolved
+// to shell; it gained one when aider became a first-class tool.)
 func builtinTools() []builtinTool {
 	return []builtinTool{
 		{Name: "claude", Icon: "🤖", detectSubstrings: []string{"claude"}},
@@ -64,7 +61,7 @@ func builtinTools() []builtinTool {
 		{Name: "crush", Icon: "💘", detectSubstrings: []string{"crush"}},
 		{Name: "cursor", Icon: "📝", detectSubstrings: []string{"cursor"}},
 		{Name: "hermes", Icon: "☤", detectSubstrings: []string{"hermes"}},
-		{Name: "aider", Icon: "🐚"},
+		{Name: "aider", Icon: "🐚", detectSubstrings: []string{"aider"}},
 		{Name: "shell", Icon: "🐚"},
 	}
 }
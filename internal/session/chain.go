@@ -0,0 +1,96 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Session chaining: "run B when A finishes". B carries a SessionChain
+// pointing at A; when A finishes a turn (running → waiting/idle) the TUI
+// sends B the chain prompt, starting B first if it is not running. A chain
+// fires once and is then cleared, so a plan→execute pipeline doesn't re-run
+// every time the planner is nudged again.
+
+const toolDataChainKey = "chain"
+
+// SessionChain links a session to the upstream session it waits for.
+type SessionChain struct {
+	After  string `json:"after"`            // upstream instance ID
+	Prompt string `json:"prompt,omitempty"` // sent when After finishes; empty just starts the session
+}
+
+// ChainTriggered reports whether an upstream status change should fire the
+// sessions chained after it: a turn ended (running → waiting or idle).
+// Errors don't propagate down the chain.
+func ChainTriggered(from, to Status) bool {
+	return from == StatusRunning && (to == StatusWaiting || to == StatusIdle)
+}
+
+// ChainedAfter returns the sessions waiting for upstreamID, in list order.
+func ChainedAfter(instances []*Instance, upstreamID string) []*Instance {
+	var out []*Instance
+	for _, inst := range instances {
+		if inst != nil && inst.Chain != nil && inst.Chain.After == upstreamID {
+			out = append(out, inst)
+		}
+	}
+	return out
+}
+
+// ValidateChain checks that inst may wait for upstream: not itself, and not
+// something that (transitively) already waits for inst.
+func ValidateChain(inst, upstream *Instance, instances []*Instance) error {
+	if inst.ID == upstream.ID {
+		return fmt.Errorf("a session cannot wait for itself")
+	}
+	byID := make(map[string]*Instance, len(instances))
+	for _, other := range instances {
+		byID[other.ID] = other
+	}
+	seen := map[string]bool{}
+	for cur := upstream; cur != nil && cur.Chain != nil && !seen[cur.ID]; cur = byID[cur.Chain.After] {
+		seen[cur.ID] = true
+		if cur.Chain.After == inst.ID {
+			return fmt.Errorf("'%s' already waits for '%s' (chain would loop)", upstream.Title, inst.Title)
+		}
+	}
+	return nil
+}
+
+// NewSessionChain builds a chain after upstreamID with a trimmed prompt.
+func NewSessionChain(upstreamID, prompt string) *SessionChain {
+	return &SessionChain{After: upstreamID, Prompt: strings.TrimSpace(prompt)}
+}
+
+// WriteChainToToolData merges the chain into the tool_data blob. A nil chain
+// removes the key.
+func WriteChainToToolData(td json.RawMessage, chain *SessionChain) json.RawMessage {
+	m := map[string]json.RawMessage{}
+	if len(td) > 0 {
+		_ = json.Unmarshal(td, &m)
+	}
+	if chain != nil && chain.After != "" {
+		raw, _ := json.Marshal(chain)
+		m[toolDataChainKey] = raw
+	} else {
+		delete(m, toolDataChainKey)
+	}
+	out, _ := json.Marshal(m)
+	return out
+}
+
+// ReadChainFromToolData extracts the chain from the blob. Returns nil for
+// missing/malformed/legacy rows.
+func ReadChainFromToolData(td json.RawMessage) *SessionChain {
+	if len(td) == 0 {
+		return nil
+	}
+	var blob struct {
+		Chain *SessionChain `json:"chain"`
+	}
+	if err := json.Unmarshal(td, &blob); err != nil || blob.Chain == nil || blob.Chain.After == "" {
+		return nil
+	}
+	return blob.Chain
+}
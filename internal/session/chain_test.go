@@ -0,0 +1,68 @@
+package session
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestChainTriggered(t *testing.T) {
+	cases := []struct {
+		from, to Status
+		want     bool
+	}{
+		{StatusRunning, StatusWaiting, true},
+		{StatusRunning, StatusIdle, true},
+		{StatusRunning, StatusError, false},
+		{StatusWaiting, StatusIdle, false},
+		{StatusRunning, StatusRunning, false},
+	}
+	for _, c := range cases {
+		if got := ChainTriggered(c.from, c.to); got != c.want {
+			t.Errorf("ChainTriggered(%s, %s) = %v, want %v", c.from, c.to, got, c.want)
+		}
+	}
+}
+
+func TestValidateChainAndChainedAfter(t *testing.T) {
+	plan := &Instance{ID: "plan", Title: "plan"}
+	exec := &Instance{ID: "exec", Title: "exec", Chain: NewSessionChain("plan", "  go  ")}
+	review := &Instance{ID: "review", Title: "review", Chain: NewSessionChain("exec", "")}
+	all := []*Instance{plan, exec, review}
+
+	if exec.Chain.Prompt != "go" {
+		t.Errorf("prompt should be trimmed, got %q", exec.Chain.Prompt)
+	}
+	if got := ChainedAfter(all, "plan"); len(got) != 1 || got[0] != exec {
+		t.Errorf("ChainedAfter(plan) = %v", got)
+	}
+	if err := ValidateChain(plan, plan, all); err == nil {
+		t.Error("self chain should be rejected")
+	}
+	if err := ValidateChain(plan, review, all); err == nil || !strings.Contains(err.Error(), "loop") {
+		t.Errorf("plan after review loops through exec, got %v", err)
+	}
+	other := &Instance{ID: "other", Title: "other"}
+	if err := ValidateChain(other, review, append(all, other)); err != nil {
+		t.Errorf("extending the chain should be allowed: %v", err)
+	}
+}
+
+func TestChainToolDataRoundTrip(t *testing.T) {
+	td := json.RawMessage(`{"claude_session_id":"abc"}`)
+	td = WriteChainToToolData(td, NewSessionChain("up", "continue"))
+	got := ReadChainFromToolData(td)
+	if got == nil || got.After != "up" || got.Prompt != "continue" {
+		t.Fatalf("round trip = %+v", got)
+	}
+	if !strings.Contains(string(td), `"claude_session_id":"abc"`) {
+		t.Errorf("other keys must be preserved: %s", td)
+	}
+	td = WriteChainToToolData(td, nil)
+	if ReadChainFromToolData(td) != nil || strings.Contains(string(td), "chain") {
+		t.Errorf("nil chain should remove the key: %s", td)
+	}
+	if ReadChainFromToolData(nil) != nil || ReadChainFromToolData(json.RawMessage(`{"chain":{}}`)) != nil {
+		t.Error("missing or empty chain should read as nil")
+	}
+}
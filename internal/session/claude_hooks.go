@@ -61,6 +61,11 @@ var hookEventConfigs = []struct {
 	// tracking semantics are unchanged.
 	{Event: "PermissionRequest", Async: false},
 	{Event: "Notification", Matcher: "permission_prompt|elicitation_dialog", Async: true},
+	// PostToolUse closes the permission-prompt gap: once the user approves a
+	// prompt, no other hook fires until Stop, so the status file kept saying
+	// "waiting" (false YELLOW) while Claude worked. The handler only acts on
+	// it after a permission wait; ordinary tool calls leave the status alone.
+	{Event: "PostToolUse", Async: true},
 	{Event: "SessionEnd", Async: true},
 	{Event: "PreCompact", Async: false},
 }
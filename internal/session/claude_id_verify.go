@@ -0,0 +1,238 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// claudeIDStaleGap is how much older the stored transcript must be than the
+// actively-written one before a mismatch is reported. Two transcripts written
+// within the same window are ambiguous (e.g. two panes in one project) and
+// never flagged.
+const claudeIDStaleGap = 2 * time.Minute
+
+// ClaudeIDMismatch records that the pane of a Claude session is writing a
+// different transcript than the stored ClaudeSessionID points at — typically
+// after the conversation was resumed outside agent-deck (`claude --resume` in
+// another terminal, or /resume inside the pane). Restarting with the stale ID
+// would resume the wrong conversation.
+type ClaudeIDMismatch struct {
+	StoredID   string    `json:"stored_id"`
+	ActiveID   string    `json:"active_id"`
+	StoredAt   time.Time `json:"stored_at,omitzero"` // stored transcript mtime; zero if missing
+	ActiveAt   time.Time `json:"active_at"`          // active transcript mtime
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// VerifyClaudeSessionID re-checks that the stored ClaudeSessionID is the
+// transcript this session is actually writing, correlating by project dir and
+// mtime. It never rebinds on its own — disk scans are not authoritative for
+// session identity (see syncClaudeSessionFromDisk) — it only records a
+// mismatch for the user to confirm with AdoptClaudeSessionIDMismatch.
+//
+// excludeIDs holds Claude session IDs owned by other instances so a sibling
+// session in the same project is never mistaken for this one. Returns the
+// current mismatch, or nil when the ID checks out or cannot be verified.
+func (i *Instance) VerifyClaudeSessionID(excludeIDs map[string]bool) *ClaudeIDMismatch {
+	mismatch := i.detectClaudeIDMismatch(excludeIDs, time.Now())
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if mismatch != nil && i.claudeIDMismatch != nil && i.claudeIDMismatch.ActiveID == mismatch.ActiveID {
+		// Keep the original detection time so the warning's age is honest.
+		mismatch.DetectedAt = i.claudeIDMismatch.DetectedAt
+	}
+	if mismatch != nil && (i.claudeIDMismatch == nil || i.claudeIDMismatch.ActiveID != mismatch.ActiveID) {
+		sessionLog.Info("claude_session_id_mismatch",
+			slog.String("instance", i.ID),
+			slog.String("stored_id", mismatch.StoredID),
+			slog.String("active_id", mismatch.ActiveID))
+		_ = WriteSessionIDLifecycleEvent(SessionIDLifecycleEvent{
+			InstanceID: i.ID, Tool: i.Tool, Action: "mismatch",
+			Source: "disk_verify", OldID: mismatch.StoredID, Candidate: mismatch.ActiveID,
+			Reason: "active_transcript_differs_from_stored_id",
+		})
+	}
+	i.claudeIDMismatch = mismatch
+	return mismatch
+}
+
+func (i *Instance) detectClaudeIDMismatch(excludeIDs map[string]bool, now time.Time) *ClaudeIDMismatch {
+	stored := i.storedClaudeSessionID()
+	if !IsClaudeCompatible(i.Tool) || stored == "" {
+		return nil
+	}
+	if status := i.GetStatusThreadSafe(); status != StatusRunning && status != StatusWaiting {
+		return nil
+	}
+	if ts := i.GetTmuxSession(); ts == nil || !ts.Exists() {
+		return nil
+	}
+
+	projectDir := i.claudeProjectDir()
+	if projectDir == "" {
+		return nil
+	}
+	activeID, activeAt := newestClaudeTranscript(projectDir, excludeIDs)
+	if activeID == "" || activeID == stored || now.Sub(activeAt) > 5*time.Minute {
+		return nil
+	}
+
+	var storedAt time.Time
+	if info, err := os.Stat(filepath.Join(projectDir, stored+".jsonl")); err == nil {
+		storedAt = info.ModTime()
+		if activeAt.Sub(storedAt) < claudeIDStaleGap {
+			return nil
+		}
+	}
+	if !sessionHasConversationData(i, activeID) {
+		return nil
+	}
+
+	return &ClaudeIDMismatch{
+		StoredID:   stored,
+		ActiveID:   activeID,
+		StoredAt:   storedAt,
+		ActiveAt:   activeAt,
+		DetectedAt: now,
+	}
+}
+
+// claudeProjectDir returns Claude's transcript directory for this session's
+// working dir, using the same encoding as sessionHasConversationData.
+func (i *Instance) claudeProjectDir() string {
+	configDir := GetClaudeConfigDirForInstance(i)
+	if configDir == "" {
+		configDir = filepath.Join(os.Getenv("HOME"), ".claude")
+	}
+	workDir := i.EffectiveWorkingDir()
+	if workDir == "" {
+		return ""
+	}
+	if resolved, err := filepath.EvalSymlinks(workDir); err == nil {
+		workDir = resolved
+	}
+	encoded := ConvertToClaudeDirName(workDir)
+	if encoded == "" {
+		encoded = "-"
+	}
+	return filepath.Join(configDir, "projects", encoded)
+}
+
+// newestClaudeTranscript returns the most recently modified UUID transcript
+// in projectDir, skipping agent-* files and excluded IDs.
+func newestClaudeTranscript(projectDir string, excludeIDs map[string]bool) (string, time.Time) {
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		return "", time.Time{}
+	}
+	var bestID string
+	var bestAt time.Time
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !uuidSessionFileRegex.MatchString(name) {
+			continue
+		}
+		id := name[:len(name)-len(".jsonl")]
+		if excludeIDs[id] {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(bestAt) {
+			bestID, bestAt = id, info.ModTime()
+		}
+	}
+	return bestID, bestAt
+}
+
+// ClaudeSessionIDMismatch returns the last mismatch found by
+// VerifyClaudeSessionID, or nil.
+func (i *Instance) ClaudeSessionIDMismatch() *ClaudeIDMismatch {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.claudeIDMismatch == nil {
+		return nil
+	}
+	m := *i.claudeIDMismatch
+	return &m
+}
+
+// AdoptClaudeSessionIDMismatch rebinds ClaudeSessionID to the transcript the
+// pane is actually writing. The tmux CLAUDE_SESSION_ID is updated too, since
+// UpdateClaudeSession treats it as authoritative and would otherwise revert
+// the fix on the next poll. Returns the adopted ID.
+func (i *Instance) AdoptClaudeSessionIDMismatch() (string, error) {
+	i.mu.Lock()
+	m := i.claudeIDMismatch
+	i.claudeIDMismatch = nil
+	if m == nil {
+		i.mu.Unlock()
+		return "", fmt.Errorf("no session ID mismatch detected")
+	}
+	if i.ClaudeSessionID != m.StoredID {
+		// Something else rebound the session since the check ran.
+		i.mu.Unlock()
+		return "", fmt.Errorf("session ID changed since the mismatch was detected")
+	}
+	i.ClaudeSessionID = m.ActiveID
+	i.ClaudeDetectedAt = time.Now()
+	i.mu.Unlock()
+
+	if ts := i.GetTmuxSession(); ts != nil {
+		if err := ts.SetEnvironment("CLAUDE_SESSION_ID", m.ActiveID); err != nil {
+			sessionLog.Warn("claude_session_id_adopt_env_failed",
+				slog.String("instance", i.ID), slog.String("error", err.Error()))
+		}
+	}
+	_ = WriteSessionIDLifecycleEvent(SessionIDLifecycleEvent{
+		InstanceID: i.ID, Tool: i.Tool, Action: "rebind",
+		Source: "disk_verify", OldID: m.StoredID, NewID: m.ActiveID,
+		Reason: "user_confirmed_mismatch",
+	})
+	return m.ActiveID, nil
+}
+
+// storedClaudeSessionID reads ClaudeSessionID under i.mu: verification runs
+// off the UI goroutine, alongside AdoptClaudeSessionIDMismatch.
+func (i *Instance) storedClaudeSessionID() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.ClaudeSessionID
+}
+
+// VerifyClaudeSessionIDs runs VerifyClaudeSessionID over instances, excluding
+// every ID stored by a different instance. Returns how many sessions currently
+// have a mismatch.
+func VerifyClaudeSessionIDs(instances []*Instance) int {
+	owners := make(map[string]string, len(instances))
+	for _, inst := range instances {
+		if inst == nil || !IsClaudeCompatible(inst.Tool) {
+			continue
+		}
+		if id := inst.storedClaudeSessionID(); id != "" {
+			owners[id] = inst.ID
+		}
+	}
+	count := 0
+	for _, inst := range instances {
+		if inst == nil || !IsClaudeCompatible(inst.Tool) {
+			continue
+		}
+		exclude := make(map[string]bool, len(owners))
+		for id, owner := range owners {
+			if owner != inst.ID {
+				exclude[id] = true
+			}
+		}
+		if inst.VerifyClaudeSessionID(exclude) != nil {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,93 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewestClaudeTranscript_SkipsExcludedAndNonUUID(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Now().Add(-time.Hour)
+	files := map[string]time.Duration{
+		"11111111-1111-1111-1111-111111111111.jsonl": 1 * time.Minute,
+		"22222222-2222-2222-2222-222222222222.jsonl": 3 * time.Minute, // excluded: owned by a sibling
+		"agent-33333333.jsonl":                       5 * time.Minute, // sub-agent transcript
+		"notes.txt":                                  6 * time.Minute,
+	}
+	for name, offset := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("{}\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mt := base.Add(offset)
+		if err := os.Chtimes(path, mt, mt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	id, at := newestClaudeTranscript(dir, map[string]bool{"22222222-2222-2222-2222-222222222222": true})
+	if id != "11111111-1111-1111-1111-111111111111" {
+		t.Fatalf("id = %q, want the newest non-excluded UUID transcript", id)
+	}
+	if !at.Equal(base.Add(time.Minute)) {
+		t.Fatalf("mtime = %v, want %v", at, base.Add(time.Minute))
+	}
+
+	if id, _ := newestClaudeTranscript(filepath.Join(dir, "missing"), nil); id != "" {
+		t.Fatalf("missing dir returned %q", id)
+	}
+}
+
+func TestVerifyClaudeSessionID_IgnoresNonClaude(t *testing.T) {
+	inst := &Instance{ID: "s1", Tool: "shell", ClaudeSessionID: "11111111-1111-1111-1111-111111111111"}
+	if m := inst.VerifyClaudeSessionID(nil); m != nil {
+		t.Fatalf("non-Claude session reported mismatch %+v", m)
+	}
+	if inst.ClaudeSessionIDMismatch() != nil {
+		t.Fatal("mismatch should stay nil")
+	}
+}
+
+func TestAdoptClaudeSessionIDMismatch(t *testing.T) {
+	inst := &Instance{ID: "s1", Tool: "claude", ClaudeSessionID: "old"}
+	if _, err := inst.AdoptClaudeSessionIDMismatch(); err == nil {
+		t.Fatal("adopt without a detected mismatch should fail")
+	}
+
+	// A rebind that happened after detection must not be overwritten.
+	inst.claudeIDMismatch = &ClaudeIDMismatch{StoredID: "older", ActiveID: "new"}
+	if _, err := inst.AdoptClaudeSessionIDMismatch(); err == nil {
+		t.Fatal("adopt with a stale mismatch should fail")
+	}
+	if inst.ClaudeSessionID != "old" || inst.ClaudeSessionIDMismatch() != nil {
+		t.Fatalf("stale adopt changed state: id=%q mismatch=%v", inst.ClaudeSessionID, inst.ClaudeSessionIDMismatch())
+	}
+}
+
+// TestAdoptClaudeSessionIDMismatch_ConcurrentVerify runs adopt alongside the
+// background verify pass; run with -race to catch unlocked ID access.
+func TestAdoptClaudeSessionIDMismatch_ConcurrentVerify(t *testing.T) {
+	inst := &Instance{ID: "s1", Tool: "claude", ClaudeSessionID: "old"}
+	inst.claudeIDMismatch = &ClaudeIDMismatch{StoredID: "old", ActiveID: "new"}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for n := 0; n < 100; n++ {
+			VerifyClaudeSessionIDs([]*Instance{inst})
+		}
+	}()
+	// The verify pass may clear the mismatch first; either outcome is fine
+	// as long as the stored ID matches it.
+	id, err := inst.AdoptClaudeSessionIDMismatch()
+	<-done
+	want := "old"
+	if err == nil {
+		want = "new"
+	}
+	if got := inst.storedClaudeSessionID(); got != want || (err == nil && id != "new") {
+		t.Fatalf("adopt = %q, %v; ClaudeSessionID = %q, want %q", id, err, got, want)
+	}
+}
@@ -0,0 +1,131 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ClaudeHistoryCandidate is a past Claude conversation on disk that no
+// agent-deck session is bound to yet.
+type ClaudeHistoryCandidate struct {
+	SessionID   string    `json:"session_id"`
+	ProjectPath string    `json:"project_path"`
+	Summary     string    `json:"summary,omitempty"`
+	ModTime     time.Time `json:"modified_at"`
+	FileSize    int64     `json:"file_size"`
+}
+
+// claudeImportTitleMax caps titles derived from a conversation's summary.
+const claudeImportTitleMax = 48
+
+// ScanClaudeHistory walks <claudeDir>/projects the same way the global search
+// index does and returns every conversation that can be imported: UUID
+// transcripts with a recorded cwd, not already bound to one of existing, and
+// modified at or after since (zero = no cutoff). Results are ordered by
+// project path, newest conversation first within a project.
+func ScanClaudeHistory(claudeDir string, existing []*Instance, since time.Time) ([]ClaudeHistoryCandidate, error) {
+	projectsDir := filepath.Join(claudeDir, "projects")
+	if _, err := os.Stat(projectsDir); err != nil {
+		return nil, err
+	}
+
+	bound := make(map[string]bool, len(existing))
+	for _, inst := range existing {
+		if inst != nil && inst.ClaudeSessionID != "" {
+			bound[inst.ClaudeSessionID] = true
+		}
+	}
+
+	var out []ClaudeHistoryCandidate
+	err := filepath.WalkDir(projectsDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if name := d.Name(); name == "tool-results" || name == "subagents" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isUUIDFileName(d.Name()) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().Before(since) {
+			return nil
+		}
+		entry, err := parseClaudeJSONLHead(path)
+		if err != nil || entry == nil {
+			return nil
+		}
+		id := entry.SessionID
+		if id == "" {
+			id = strings.TrimSuffix(d.Name(), ".jsonl")
+		}
+		// No cwd means we cannot tell where to resume it.
+		if entry.CWD == "" || bound[id] {
+			return nil
+		}
+		bound[id] = true // a conversation may appear under two project dirs
+		out = append(out, ClaudeHistoryCandidate{
+			SessionID:   id,
+			ProjectPath: entry.CWD,
+			Summary:     strings.TrimSpace(entry.Summary),
+			ModTime:     info.ModTime(),
+			FileSize:    info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(out, func(a, b int) bool {
+		if out[a].ProjectPath != out[b].ProjectPath {
+			return out[a].ProjectPath < out[b].ProjectPath
+		}
+		return out[a].ModTime.After(out[b].ModTime)
+	})
+	return out, nil
+}
+
+// Title returns the session title used when importing c: the first line of
+// the conversation summary, falling back to the project folder name.
+func (c ClaudeHistoryCandidate) Title() string {
+	title, _, _ := strings.Cut(c.Summary, "\n")
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return filepath.Base(c.ProjectPath)
+	}
+	if utf8.RuneCountInString(title) > claudeImportTitleMax {
+		title = string([]rune(title)[:claudeImportTitleMax-1]) + "…"
+	}
+	return title
+}
+
+// NewClaudeHistoryInstance builds an idle, never-started Claude session that
+// resumes c on first start. groupPath "" applies the first matching
+// [[group_rules]] entry, else keeps the project-derived group that
+// NewInstance assigns, so imports land grouped by project.
+func NewClaudeHistoryInstance(c ClaudeHistoryCandidate, groupPath string, config *UserConfig) *Instance {
+	inst := NewInstanceWithTool(c.Title(), c.ProjectPath, "claude")
+	if groupPath == "" {
+		groupPath = config.MatchGroupRule(inst.ProjectPath, inst.Tool, inst.Title)
+	}
+	if groupPath != "" {
+		inst.GroupPath = groupPath
+	}
+	inst.Command = "claude"
+	inst.ClaudeSessionID = c.SessionID
+	inst.ClaudeDetectedAt = c.ModTime
+
+	opts := NewClaudeOptions(config)
+	opts.SessionMode = "resume"
+	opts.ResumeSessionID = c.SessionID
+	_ = inst.SetClaudeOptions(opts)
+	return inst
+}
@@ -0,0 +1,109 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeClaudeTranscript(t *testing.T, dir, id, cwd, prompt string, mtime time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	line := fmt.Sprintf(`{"sessionId":%q,"type":"user","message":{"role":"user","content":%q},"cwd":%q}`+"\n", id, prompt, cwd)
+	path := filepath.Join(dir, id+".jsonl")
+	if err := os.WriteFile(path, []byte(line), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanClaudeHistory(t *testing.T) {
+	claudeDir := t.TempDir()
+	now := time.Now()
+	api := filepath.Join(claudeDir, "projects", "-src-api")
+	web := filepath.Join(claudeDir, "projects", "-src-web")
+
+	const (
+		apiOld   = "11111111-1111-1111-1111-111111111111"
+		apiNew   = "22222222-2222-2222-2222-222222222222"
+		webBound = "33333333-3333-3333-3333-333333333333"
+		webStale = "44444444-4444-4444-4444-444444444444"
+		noCWD    = "55555555-5555-5555-5555-555555555555"
+	)
+	writeClaudeTranscript(t, api, apiOld, "/src/api", "fix the login bug", now.Add(-2*time.Hour))
+	writeClaudeTranscript(t, api, apiNew, "/src/api", "add rate limiting", now.Add(-time.Hour))
+	writeClaudeTranscript(t, web, webBound, "/src/web", "already tracked", now.Add(-time.Hour))
+	writeClaudeTranscript(t, web, webStale, "/src/web", "ancient", now.AddDate(0, 0, -90))
+	writeClaudeTranscript(t, web, noCWD, "", "no cwd", now)
+	if err := os.WriteFile(filepath.Join(api, "agent-1234.jsonl"), []byte("{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	existing := []*Instance{{ID: "a", ClaudeSessionID: webBound}}
+	got, err := ScanClaudeHistory(claudeDir, existing, now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d candidates, want 2: %+v", len(got), got)
+	}
+	if got[0].SessionID != apiNew || got[1].SessionID != apiOld {
+		t.Fatalf("order = %s, %s; want newest first within project", got[0].SessionID, got[1].SessionID)
+	}
+	if got[0].ProjectPath != "/src/api" || got[0].Summary != "add rate limiting" {
+		t.Fatalf("candidate = %+v", got[0])
+	}
+
+	all, err := ScanClaudeHistory(claudeDir, nil, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("without cutoff got %d candidates, want 4", len(all))
+	}
+}
+
+func TestNewClaudeHistoryInstance(t *testing.T) {
+	c := ClaudeHistoryCandidate{
+		SessionID:   "22222222-2222-2222-2222-222222222222",
+		ProjectPath: "/src/api",
+		Summary:     "add rate limiting to the public endpoints and document the new headers\nmore",
+		ModTime:     time.Now().Add(-time.Hour),
+	}
+
+	inst := NewClaudeHistoryInstance(c, "", nil)
+	if inst.Status != StatusIdle || inst.Tool != "claude" {
+		t.Fatalf("status/tool = %s/%s, want idle/claude", inst.Status, inst.Tool)
+	}
+	if inst.GroupPath != GroupPathForProject("/src/api") {
+		t.Fatalf("group = %q, want project-derived group", inst.GroupPath)
+	}
+	if inst.ClaudeSessionID != c.SessionID {
+		t.Fatalf("ClaudeSessionID = %q", inst.ClaudeSessionID)
+	}
+	opts := inst.GetClaudeOptions()
+	if opts == nil || opts.SessionMode != "resume" || opts.ResumeSessionID != c.SessionID {
+		t.Fatalf("claude options = %+v, want resume of %s", opts, c.SessionID)
+	}
+	if n := len([]rune(inst.Title)); n != claudeImportTitleMax {
+		t.Fatalf("title %q has %d runes, want truncated to %d", inst.Title, n, claudeImportTitleMax)
+	}
+
+	if inst := NewClaudeHistoryInstance(ClaudeHistoryCandidate{ProjectPath: "/src/web"}, "imported", nil); inst.Title != "web" || inst.GroupPath != "imported" {
+		t.Fatalf("fallback title/group = %q/%q", inst.Title, inst.GroupPath)
+	}
+
+	cfg := &UserConfig{GroupRules: []GroupRule{{Group: "history", Path: "/src/**"}}}
+	if inst := NewClaudeHistoryInstance(c, "", cfg); inst.GroupPath != "history" {
+		t.Fatalf("group with matching rule = %q, want history", inst.GroupPath)
+	}
+	if inst := NewClaudeHistoryInstance(c, "imported", cfg); inst.GroupPath != "imported" {
+		t.Fatalf("explicit group = %q, want it to beat the rule", inst.GroupPath)
+	}
+}
@@ -0,0 +1,90 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Clone creates a stopped copy of the session's setup with a fresh
+// conversation. Unlike a fork, nothing of the source conversation carries
+// over: tool session IDs stay empty and resume fields are stripped from the
+// tool options, so the first start begins a new chat.
+//
+// The clone shares the source's project path — and with it the project's
+// MCP and skill config — plus its tool, command, options, wrapper, extra
+// args, sandbox and SSH settings, plugins, channels and parent link.
+// Worktree ownership stays with the source: the clone runs in the same
+// checkout but removing it never removes the worktree. Multi-repo sessions
+// are cloned as single-repo sessions on the primary path (their temp
+// workspace belongs to the source).
+//
+// newGroupPath "" keeps the source's group.
+func (i *Instance) Clone(newTitle, newGroupPath string) *Instance {
+	c := NewInstanceWithTool(newTitle, i.ProjectPath, i.Tool)
+	c.GroupPath = i.GroupPath
+	if newGroupPath != "" {
+		c.GroupPath = newGroupPath
+	}
+	c.Command = i.cloneCommand()
+	c.Wrapper = i.Wrapper
+	c.ExtraArgs = append([]string(nil), i.ExtraArgs...)
+	c.ToolOptionsJSON = StripResumeFields(i.ToolOptionsJSON)
+	c.Tags = append([]string(nil), i.Tags...)
+	c.Color = i.Color
+	c.Account = i.Account
+
+	if i.GeminiYoloMode != nil {
+		yolo := *i.GeminiYoloMode
+		c.GeminiYoloMode = &yolo
+	}
+	c.GeminiModel = i.GeminiModel
+	c.CopilotModel = i.CopilotModel
+	c.CopilotAllowAll = i.CopilotAllowAll
+
+	if i.Sandbox != nil {
+		sandbox := *i.Sandbox
+		c.Sandbox = &sandbox
+	}
+	c.SSHHost = i.SSHHost
+	c.SSHRemotePath = i.SSHRemotePath
+
+	c.Channels = append([]string(nil), i.Channels...)
+	c.Plugins = append([]string(nil), i.Plugins...)
+	c.PluginChannelLinkDisabled = i.PluginChannelLinkDisabled
+	c.InheritTelegramEnv = i.InheritTelegramEnv
+	c.IdleTimeoutSecs = i.IdleTimeoutSecs
+	c.ExitToShell = i.ExitToShell
+	c.LaunchShell = i.LaunchShell
+
+	if i.ParentSessionID != "" {
+		c.SetParentWithPath(i.ParentSessionID, i.ParentProjectPath)
+	}
+	if i.TmuxSocketName != c.TmuxSocketName {
+		c.TmuxSocketName = i.TmuxSocketName
+		if c.tmuxSession != nil {
+			c.tmuxSession.SocketName = i.TmuxSocketName
+		}
+	}
+
+	i.recordEvent(EventCloned, fmt.Sprintf("into %q", c.Title), nil)
+	c.recordEvent(EventCloned, fmt.Sprintf("from %q", i.Title), nil)
+	return c
+}
+
+// cloneCommand is the launch command a clone starts from. A Claude command
+// that pins a conversation — an explicit --session-id, --resume, or a fork's
+// baked first-start command — would resume (or re-fork) the source's chat,
+// so the clone falls back to the tool's plain command instead.
+func (i *Instance) cloneCommand() string {
+	if !IsClaudeCompatible(i.Tool) {
+		return i.Command
+	}
+	_, pinned := extractExplicitClaudeSessionID(i.Command)
+	if !pinned && !strings.Contains(i.Command, "--resume") && !strings.Contains(i.Command, "--fork-session") {
+		return i.Command
+	}
+	if def := GetToolDef(i.Tool); def != nil && def.Command != "" {
+		return def.Command
+	}
+	return "claude"
+}
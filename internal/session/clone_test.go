@@ -0,0 +1,112 @@
+package session
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInstanceClone_CopiesSetupNotConversation(t *testing.T) {
+	src := NewInstanceWithTool("api", "/src/api", "claude")
+	src.GroupPath = "work/backend"
+	src.Command = "claude"
+	src.ExtraArgs = []string{"--verbose"}
+	src.Tags = []string{"infra"}
+	src.Wrapper = "nice -n 10 {command}"
+	src.Plugins = []string{"telegram"}
+	src.ClaudeSessionID = "0b6f3c1e-1111-2222-3333-444455556666"
+	src.ClaudeDetectedAt = time.Now()
+	src.WorktreePath = "/src/api-wt"
+	src.WorktreeRepoRoot = "/src/api"
+	if err := src.SetClaudeOptions(&ClaudeOptions{
+		SessionMode:     "resume",
+		ResumeSessionID: src.ClaudeSessionID,
+		SkipPermissions: true,
+	}); err != nil {
+		t.Fatalf("SetClaudeOptions: %v", err)
+	}
+
+	c := src.Clone("api (clone)", "")
+
+	if c.ID == src.ID {
+		t.Fatal("clone must get its own ID")
+	}
+	if c.Title != "api (clone)" || c.ProjectPath != src.ProjectPath || c.Tool != src.Tool || c.GroupPath != src.GroupPath {
+		t.Errorf("clone identity = %q %q %q %q", c.Title, c.ProjectPath, c.Tool, c.GroupPath)
+	}
+	if c.Wrapper != src.Wrapper || !reflect.DeepEqual(c.ExtraArgs, src.ExtraArgs) ||
+		!reflect.DeepEqual(c.Tags, src.Tags) || !reflect.DeepEqual(c.Plugins, src.Plugins) {
+		t.Error("clone should copy wrapper, extra args, tags and plugins")
+	}
+	c.ExtraArgs[0] = "--changed"
+	if src.ExtraArgs[0] != "--verbose" {
+		t.Error("clone must not share slices with the source")
+	}
+
+	if c.ClaudeSessionID != "" || !c.ClaudeDetectedAt.IsZero() {
+		t.Errorf("clone carried the conversation: id=%q detected=%v", c.ClaudeSessionID, c.ClaudeDetectedAt)
+	}
+	opts := c.GetClaudeOptions()
+	if opts == nil || !opts.SkipPermissions {
+		t.Fatalf("clone should keep non-resume Claude options, got %+v", opts)
+	}
+	if opts.SessionMode != "" || opts.ResumeSessionID != "" {
+		t.Errorf("clone kept resume options: %+v", opts)
+	}
+
+	if c.WorktreePath != "" || c.WorktreeRepoRoot != "" {
+		t.Error("worktree ownership must stay with the source")
+	}
+
+	if last := c.EventLog[len(c.EventLog)-1]; last.Kind != EventCloned {
+		t.Errorf("clone's last event = %q, want %q", last.Kind, EventCloned)
+	}
+	if last := src.EventLog[len(src.EventLog)-1]; last.Kind != EventCloned {
+		t.Errorf("source's last event = %q, want %q", last.Kind, EventCloned)
+	}
+}
+
+func TestInstanceClone_GroupOverride(t *testing.T) {
+	src := NewInstanceWithTool("api", "/src/api", "shell")
+	src.GroupPath = "work"
+
+	if got := src.Clone("x", "experiments").GroupPath; got != "experiments" {
+		t.Errorf("GroupPath = %q, want the override", got)
+	}
+}
+
+func TestInstanceClone_ResetsPinnedClaudeCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{"plain", "claude --model opus", "claude --model opus"},
+		{"explicit session id", "claude --session-id 0b6f3c1e-1111-2222-3333-444455556666", "claude"},
+		{"resume", "claude --resume 0b6f3c1e-1111-2222-3333-444455556666", "claude"},
+		{"baked fork", "claude --resume abc --fork-session", "claude"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := NewInstanceWithTool("api", "/src/api", "claude")
+			src.Command = tt.command
+			if got := src.Clone("c", "").Command; got != tt.want {
+				t.Errorf("clone Command = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripResumeFields_ContinueLast(t *testing.T) {
+	raw := json.RawMessage(`{"tool":"claude","options":{"continue_last":true,"session_mode":"continue","skip_permissions":true}}`)
+	got := string(StripResumeFields(raw))
+	if strings.Contains(got, "continue_last") || strings.Contains(got, "session_mode") {
+		t.Errorf("StripResumeFields left continue fields: %s", got)
+	}
+	if !strings.Contains(got, "skip_permissions") {
+		t.Errorf("StripResumeFields dropped unrelated options: %s", got)
+	}
+}
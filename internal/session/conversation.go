@@ -0,0 +1,289 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ConversationEntryKind classifies one rendered entry of a conversation.
+type ConversationEntryKind string
+
+const (
+	ConversationUser      ConversationEntryKind = "user"
+	ConversationAssistant ConversationEntryKind = "assistant"
+	ConversationThinking  ConversationEntryKind = "thinking"
+	ConversationTool      ConversationEntryKind = "tool"
+)
+
+// ConversationEntry is one turn fragment of a Claude or Gemini conversation:
+// a prompt, a reply, a reasoning block, or a tool call with its result.
+type ConversationEntry struct {
+	Kind      ConversationEntryKind
+	Timestamp time.Time
+	// Text is the message body; for tool entries, the call's result.
+	Text string
+	// ToolName and ToolInput describe a tool call. ToolInput is a one-line
+	// summary of the arguments (the command, path or pattern when present).
+	ToolName  string
+	ToolInput string
+	ToolError bool
+}
+
+// maxToolResultChars caps a tool result kept in memory: the viewer shows
+// results collapsed by default, and a single file read can be megabytes.
+const maxToolResultChars = 4000
+
+// LoadConversation parses the session's Claude JSONL or Gemini JSON
+// conversation into display entries, oldest first.
+func (i *Instance) LoadConversation() ([]ConversationEntry, error) {
+	switch {
+	case IsClaudeCompatible(i.Tool):
+		path := i.GetJSONLPath()
+		if path == "" {
+			return nil, fmt.Errorf("no Claude conversation on disk for %q", i.Title)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return parseClaudeConversation(f)
+	case i.Tool == "gemini":
+		path, err := i.geminiSessionFile()
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read session file: %w", err)
+		}
+		return parseGeminiConversation(data)
+	default:
+		return nil, fmt.Errorf("no structured conversation for tool %q", i.Tool)
+	}
+}
+
+// parseClaudeConversation streams a Claude JSONL transcript. Tool results
+// arrive in the next user record; they are attached to their tool_use entry
+// by ID so each call renders with its output.
+func parseClaudeConversation(r io.Reader) ([]ConversationEntry, error) {
+	type contentBlock struct {
+		Type      string          `json:"type"`
+		Text      string          `json:"text"`
+		Thinking  string          `json:"thinking"`
+		ID        string          `json:"id"`
+		Name      string          `json:"name"`
+		Input     json.RawMessage `json:"input"`
+		ToolUseID string          `json:"tool_use_id"`
+		Content   json.RawMessage `json:"content"`
+		IsError   bool            `json:"is_error"`
+	}
+	type claudeRecord struct {
+		Type        string    `json:"type"`
+		Timestamp   time.Time `json:"timestamp"`
+		IsSidechain bool      `json:"isSidechain"`
+		IsMeta      bool      `json:"isMeta"`
+		Message     struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"message"`
+	}
+
+	var entries []ConversationEntry
+	toolIndex := make(map[string]int) // tool_use id -> index into entries
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			var record claudeRecord
+			if jsonErr := json.Unmarshal(line, &record); jsonErr == nil && !record.IsSidechain && !record.IsMeta &&
+				(record.Type == "user" || record.Type == "assistant") {
+				var blocks []contentBlock
+				if text, ok := rawString(record.Message.Content); ok {
+					blocks = []contentBlock{{Type: "text", Text: text}}
+				} else {
+					_ = json.Unmarshal(record.Message.Content, &blocks)
+				}
+				for _, b := range blocks {
+					switch {
+					case b.Type == "text" && strings.TrimSpace(b.Text) != "":
+						kind := ConversationAssistant
+						if record.Type == "user" {
+							// Slash-command and hook wrappers are not prompts.
+							if claudePromptText(record.Message.Content) == "" {
+								continue
+							}
+							kind = ConversationUser
+						}
+						entries = append(entries, ConversationEntry{Kind: kind, Timestamp: record.Timestamp, Text: strings.TrimSpace(b.Text)})
+					case b.Type == "thinking" && strings.TrimSpace(b.Thinking) != "":
+						entries = append(entries, ConversationEntry{Kind: ConversationThinking, Timestamp: record.Timestamp, Text: strings.TrimSpace(b.Thinking)})
+					case b.Type == "tool_use":
+						toolIndex[b.ID] = len(entries)
+						entries = append(entries, ConversationEntry{
+							Kind:      ConversationTool,
+							Timestamp: record.Timestamp,
+							ToolName:  b.Name,
+							ToolInput: summarizeToolInput(b.Input),
+						})
+					case b.Type == "tool_result":
+						idx, ok := toolIndex[b.ToolUseID]
+						if !ok {
+							continue
+						}
+						entries[idx].Text = truncateToolResult(toolResultText(b.Content))
+						entries[idx].ToolError = b.IsError
+					}
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// parseGeminiConversation reads a Gemini session file. Gemini stores each
+// reply with its thoughts and tool calls inline, so they expand into
+// entries ahead of the reply text.
+func parseGeminiConversation(data []byte) ([]ConversationEntry, error) {
+	var sess struct {
+		Messages []struct {
+			Timestamp string `json:"timestamp"`
+			Type      string `json:"type"` // "user" or "gemini"
+			Content   string `json:"content"`
+			Thoughts  []struct {
+				Subject     string `json:"subject"`
+				Description string `json:"description"`
+			} `json:"thoughts"`
+			ToolCalls []struct {
+				Name          string          `json:"name"`
+				Args          json.RawMessage `json:"args"`
+				Status        string          `json:"status"`
+				ResultDisplay json.RawMessage `json:"resultDisplay"`
+			} `json:"toolCalls"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &sess); err != nil {
+		return nil, fmt.Errorf("failed to parse session file: %w", err)
+	}
+
+	var entries []ConversationEntry
+	for _, m := range sess.Messages {
+		at, _ := time.Parse(time.RFC3339Nano, m.Timestamp)
+		switch m.Type {
+		case "user":
+			if text := strings.TrimSpace(m.Content); text != "" {
+				entries = append(entries, ConversationEntry{Kind: ConversationUser, Timestamp: at, Text: text})
+			}
+		case "gemini":
+			for _, th := range m.Thoughts {
+				text := strings.TrimSpace(strings.TrimSpace(th.Subject) + "\n" + strings.TrimSpace(th.Description))
+				if text != "" {
+					entries = append(entries, ConversationEntry{Kind: ConversationThinking, Timestamp: at, Text: text})
+				}
+			}
+			for _, tc := range m.ToolCalls {
+				result, _ := rawString(tc.ResultDisplay)
+				entries = append(entries, ConversationEntry{
+					Kind:      ConversationTool,
+					Timestamp: at,
+					ToolName:  tc.Name,
+					ToolInput: summarizeToolInput(tc.Args),
+					Text:      truncateToolResult(result),
+					ToolError: tc.Status == "error",
+				})
+			}
+			if text := strings.TrimSpace(m.Content); text != "" {
+				entries = append(entries, ConversationEntry{Kind: ConversationAssistant, Timestamp: at, Text: text})
+			}
+		}
+	}
+	return entries, nil
+}
+
+// summarizeToolInput reduces a tool call's arguments to one line: the first
+// well-known argument (command, path, pattern, ...) when present, otherwise
+// the compact JSON.
+func summarizeToolInput(raw json.RawMessage) string {
+	var args map[string]any
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return ""
+	}
+	for _, key := range []string{"command", "file_path", "absolute_path", "path", "pattern", "url", "query", "description", "prompt"} {
+		if v, ok := args[key].(string); ok && v != "" {
+			return strings.Join(strings.Fields(v), " ")
+		}
+	}
+	compact, err := json.Marshal(args)
+	if err != nil || string(compact) == "{}" {
+		return ""
+	}
+	return string(compact)
+}
+
+// toolResultText flattens a tool_result content — a string or text blocks.
+func toolResultText(raw json.RawMessage) string {
+	if text, ok := rawString(raw); ok {
+		return strings.TrimSpace(text)
+	}
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return ""
+	}
+	var parts []string
+	for _, b := range blocks {
+		if b.Type == "text" {
+			parts = append(parts, b.Text)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
+func truncateToolResult(s string) string {
+	runes := []rune(s)
+	if len(runes) <= maxToolResultChars {
+		return s
+	}
+	return string(runes[:maxToolResultChars]) + "\n… (truncated)"
+}
+
+// rawString decodes raw as a JSON string.
+func rawString(raw json.RawMessage) (string, bool) {
+	var s string
+	if len(raw) == 0 || json.Unmarshal(raw, &s) != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// geminiSessionFile locates the Gemini session file for the stored
+// GeminiSessionID, falling back to a search across projects.
+func (i *Instance) geminiSessionFile() (string, error) {
+	if i.GeminiSessionID == "" || len(i.GeminiSessionID) < 8 {
+		return "", fmt.Errorf("no Gemini session ID available for this instance")
+	}
+
+	// Filename format is session-YYYY-MM-DDTHH-MM-<uuid8>.json
+	pattern := filepath.Join(GetGeminiSessionsDir(i.ProjectPath), "session-*-"+i.GeminiSessionID[:8]+".json")
+	if files, _ := filepath.Glob(pattern); len(files) > 0 {
+		return files[0], nil
+	}
+	if fallbackPath := findGeminiSessionInAllProjects(i.GeminiSessionID); fallbackPath != "" {
+		return fallbackPath, nil
+	}
+	return "", fmt.Errorf("session file not found for ID: %s", i.GeminiSessionID)
+}
@@ -0,0 +1,77 @@
+package session
+
+import (
+	"strings"
+	"testing"
+)
+
+const conversationFixture = `{"type":"user","uuid":"u1","timestamp":"2026-01-02T10:00:00Z","message":{"role":"user","content":"why does the build fail?"}}
+{"type":"assistant","uuid":"a1","message":{"role":"assistant","content":[{"type":"thinking","thinking":"Check the compiler output first."}]}}
+{"type":"assistant","uuid":"a2","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"command":"go build ./...","description":"Build"}}]}}
+{"type":"user","uuid":"t1","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"main.go:3: undefined: foo","is_error":true}]}}
+{"type":"assistant","uuid":"s1","isSidechain":true,"message":{"role":"assistant","content":[{"type":"text","text":"subagent chatter"}]}}
+{"type":"user","uuid":"m1","isMeta":true,"message":{"role":"user","content":"Caveat: injected"}}
+{"type":"assistant","uuid":"a3","message":{"role":"assistant","content":[{"type":"text","text":"foo is undefined in main.go."}]}}
+`
+
+func TestParseClaudeConversation(t *testing.T) {
+	entries, err := parseClaudeConversation(strings.NewReader(conversationFixture))
+	if err != nil {
+		t.Fatalf("parseClaudeConversation: %v", err)
+	}
+	kinds := make([]ConversationEntryKind, len(entries))
+	for i, e := range entries {
+		kinds[i] = e.Kind
+	}
+	want := []ConversationEntryKind{ConversationUser, ConversationThinking, ConversationTool, ConversationAssistant}
+	if len(kinds) != len(want) {
+		t.Fatalf("kinds = %v, want %v (sidechain, meta and tool_result records are not entries)", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("kinds = %v, want %v", kinds, want)
+		}
+	}
+
+	tool := entries[2]
+	if tool.ToolName != "Bash" || tool.ToolInput != "go build ./..." {
+		t.Errorf("tool entry = %+v, want Bash with the command as input summary", tool)
+	}
+	if tool.Text != "main.go:3: undefined: foo" || !tool.ToolError {
+		t.Errorf("tool result not attached: %+v", tool)
+	}
+	if entries[0].Timestamp.IsZero() {
+		t.Error("user entry timestamp should be parsed")
+	}
+}
+
+func TestParseGeminiConversation(t *testing.T) {
+	data := []byte(`{"sessionId":"s","messages":[
+		{"type":"user","timestamp":"2026-01-02T10:00:00.000Z","content":"list files"},
+		{"type":"gemini","timestamp":"bad","content":"Here they are.",
+		 "thoughts":[{"subject":"Listing","description":"Use ls."}],
+		 "toolCalls":[{"name":"run_shell_command","args":{"command":"ls"},"status":"success","resultDisplay":"a.go\nb.go"}]}
+	]}`)
+	entries, err := parseGeminiConversation(data)
+	if err != nil {
+		t.Fatalf("parseGeminiConversation: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries (%+v), want user, thinking, tool, assistant", len(entries), entries)
+	}
+	if entries[2].ToolName != "run_shell_command" || entries[2].ToolInput != "ls" || entries[2].Text != "a.go\nb.go" {
+		t.Errorf("tool entry = %+v", entries[2])
+	}
+	if entries[3].Kind != ConversationAssistant || entries[3].Text != "Here they are." {
+		t.Errorf("assistant entry = %+v", entries[3])
+	}
+}
+
+func TestSummarizeToolInput(t *testing.T) {
+	if got := summarizeToolInput([]byte(`{"file_path":"/src/main.go","offset":10}`)); got != "/src/main.go" {
+		t.Errorf("file_path summary = %q", got)
+	}
+	if got := summarizeToolInput([]byte(`{"todos":[1]}`)); got != `{"todos":[1]}` {
+		t.Errorf("fallback summary = %q, want compact JSON", got)
+	}
+}
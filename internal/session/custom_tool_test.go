@@ -0,0 +1,102 @@
+package session
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+func TestToolDef_ResumeInvocation(t *testing.T) {
+	flag := &ToolDef{ResumeFlag: "--resume", SessionIDEnv: "MYAI_SESSION_ID"}
+	if !flag.SupportsResume() {
+		t.Fatal("resume_flag + session_id_env should support resume")
+	}
+	if got := flag.ResumeInvocation("my-ai", "abc"); got != "my-ai --resume abc" {
+		t.Errorf("flag ResumeInvocation = %q", got)
+	}
+
+	tmpl := &ToolDef{ResumeCommand: "{command} sessions open {session_id}", SessionIDEnv: "MYAI_SESSION_ID"}
+	if !tmpl.SupportsResume() {
+		t.Fatal("resume_command + session_id_env should support resume")
+	}
+	if got := tmpl.ResumeInvocation("my-ai --fast", "abc"); got != "my-ai --fast sessions open abc" {
+		t.Errorf("template ResumeInvocation = %q", got)
+	}
+
+	if (&ToolDef{ResumeCommand: "{command} -r {session_id}"}).SupportsResume() {
+		t.Error("resume without session_id_env cannot capture an ID and must not support resume")
+	}
+}
+
+func TestBuildGenericCommand_CommandTemplate(t *testing.T) {
+	restore := resetUserConfigCache(t, &UserConfig{
+		Tools: map[string]ToolDef{
+			"my-ai": {
+				Command:           "my-ai --workspace {path} --name {title}",
+				ResumeCommand:     "{command} sessions open {session_id}",
+				SessionIDEnv:      "MYAI_SESSION_ID",
+				OutputFormatFlag:  "--json",
+				SessionIDJsonPath: ".id",
+			},
+		},
+	})
+	defer restore()
+
+	inst := &Instance{Tool: "my-ai", Title: "fix login", ProjectPath: "/src/app"}
+	got := inst.buildGenericCommand("my-ai --workspace {path} --name {title}")
+	if !strings.Contains(got, `my-ai --workspace /src/app --name 'fix login' sessions open "$session_id"`) {
+		t.Errorf("buildGenericCommand() = %q, want expanded placeholders and the resume template", got)
+	}
+	if strings.Contains(got, "{path}") || strings.Contains(got, "{title}") {
+		t.Errorf("buildGenericCommand() left placeholders in %q", got)
+	}
+}
+
+func TestMergeToolPatterns_ReadyRegex(t *testing.T) {
+	restore := resetUserConfigCache(t, &UserConfig{
+		Tools: map[string]ToolDef{
+			"my-ai": {Command: "my-ai", ReadyRegex: `^my-ai> $`},
+		},
+	})
+	defer restore()
+
+	raw := MergeToolPatterns("my-ai")
+	if raw == nil {
+		t.Fatal("MergeToolPatterns returned nil for a tool with ready_regex")
+	}
+	resolved, err := tmux.CompilePatterns(raw)
+	if err != nil {
+		t.Fatalf("CompilePatterns: %v", err)
+	}
+	if len(resolved.PromptRegexps) != 1 {
+		t.Fatalf("PromptRegexps = %v, want the ready regex", resolved.PromptRegexps)
+	}
+	re := resolved.PromptRegexps[0]
+	if !re.MatchString("answer printed\nmy-ai> ") {
+		t.Error("ready regex should match the prompt on its own line")
+	}
+	if re.MatchString("my-ai> fix the bug\nworking...") {
+		t.Error("ready regex should not match a prompt line with typed input")
+	}
+}
+
+func TestGetToolColor(t *testing.T) {
+	restore := resetUserConfigCache(t, &UserConfig{
+		Tools: map[string]ToolDef{
+			"my-ai": {Command: "my-ai", Color: "#ff8800"},
+			"plain": {Command: "plain"},
+		},
+	})
+	defer restore()
+
+	if got := GetToolColor("my-ai"); got != "#ff8800" {
+		t.Errorf("GetToolColor(my-ai) = %q", got)
+	}
+	if got := GetToolColor("plain"); got != "" {
+		t.Errorf("GetToolColor(plain) = %q, want empty", got)
+	}
+	if got := GetToolColor("claude"); got != "" {
+		t.Errorf("GetToolColor(claude) = %q, built-ins have no config color", got)
+	}
+}
@@ -0,0 +1,217 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Deck export: a portable snapshot of session definitions — what to run and
+// where, plus the tool conversation IDs needed to resume — for moving a deck
+// between machines or sharing a project setup. Runtime state (status, tmux
+// names, timestamps) is deliberately left out; imported sessions start
+// stopped and get fresh IDs.
+
+// DeckExportVersion is bumped when the file format changes incompatibly.
+const DeckExportVersion = 1
+
+// DeckExport is the on-disk export document (JSON or YAML).
+type DeckExport struct {
+	Version    int                 `json:"version" yaml:"version"`
+	ExportedAt time.Time           `json:"exported_at" yaml:"exported_at"`
+	Sessions   []DeckExportSession `json:"sessions" yaml:"sessions"`
+}
+
+// DeckExportSession is one exported session definition.
+type DeckExportSession struct {
+	Title             string              `json:"title" yaml:"title"`
+	Tool              string              `json:"tool" yaml:"tool"`
+	Command           string              `json:"command,omitempty" yaml:"command,omitempty"`
+	Wrapper           string              `json:"wrapper,omitempty" yaml:"wrapper,omitempty"`
+	Path              string              `json:"path" yaml:"path"`
+	Group             string              `json:"group,omitempty" yaml:"group,omitempty"`
+	ExtraArgs         []string            `json:"extra_args,omitempty" yaml:"extra_args,omitempty"`
+	Tags              []string            `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Notes             string              `json:"notes,omitempty" yaml:"notes,omitempty"`
+	Color             string              `json:"color,omitempty" yaml:"color,omitempty"`
+	ClaudeSessionID   string              `json:"claude_session_id,omitempty" yaml:"claude_session_id,omitempty"`
+	GeminiSessionID   string              `json:"gemini_session_id,omitempty" yaml:"gemini_session_id,omitempty"`
+	OpenCodeSessionID string              `json:"opencode_session_id,omitempty" yaml:"opencode_session_id,omitempty"`
+	CodexSessionID    string              `json:"codex_session_id,omitempty" yaml:"codex_session_id,omitempty"`
+	SSHHost           string              `json:"ssh_host,omitempty" yaml:"ssh_host,omitempty"`
+	SSHRemotePath     string              `json:"ssh_remote_path,omitempty" yaml:"ssh_remote_path,omitempty"`
+	Worktree          *DeckExportWorktree `json:"worktree,omitempty" yaml:"worktree,omitempty"`
+}
+
+// DeckExportWorktree records where a worktree session's checkout lives.
+type DeckExportWorktree struct {
+	Path     string `json:"path" yaml:"path"`
+	RepoRoot string `json:"repo_root" yaml:"repo_root"`
+	Branch   string `json:"branch" yaml:"branch"`
+	Type     string `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
+// BuildDeckExport snapshots the definitions of instances.
+func BuildDeckExport(instances []*Instance, now time.Time) DeckExport {
+	doc := DeckExport{Version: DeckExportVersion, ExportedAt: now.UTC(), Sessions: []DeckExportSession{}}
+	for _, inst := range instances {
+		if inst == nil {
+			continue
+		}
+		s := DeckExportSession{
+			Title:             inst.Title,
+			Tool:              inst.Tool,
+			Command:           inst.Command,
+			Wrapper:           inst.Wrapper,
+			Path:              inst.ProjectPath,
+			Group:             inst.GroupPath,
+			ExtraArgs:         inst.ExtraArgs,
+			Tags:              inst.Tags,
+			Notes:             inst.Notes,
+			Color:             inst.Color,
+			ClaudeSessionID:   inst.ClaudeSessionID,
+			GeminiSessionID:   inst.GeminiSessionID,
+			OpenCodeSessionID: inst.OpenCodeSessionID,
+			CodexSessionID:    inst.CodexSessionID,
+			SSHHost:           inst.SSHHost,
+			SSHRemotePath:     inst.SSHRemotePath,
+		}
+		if inst.IsWorktree() {
+			s.Worktree = &DeckExportWorktree{
+				Path:     inst.WorktreePath,
+				RepoRoot: inst.WorktreeRepoRoot,
+				Branch:   inst.WorktreeBranch,
+				Type:     inst.WorktreeType,
+			}
+		}
+		doc.Sessions = append(doc.Sessions, s)
+	}
+	return doc
+}
+
+// MarshalDeckExport encodes doc as "json" (default) or "yaml".
+func MarshalDeckExport(doc DeckExport, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "", "json":
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case "yaml", "yml":
+		return yaml.Marshal(doc)
+	default:
+		return nil, fmt.Errorf("unknown export format %q (use json or yaml)", format)
+	}
+}
+
+// ParseDeckExport decodes an export file. YAML is a superset of JSON, so one
+// decoder handles both formats.
+func ParseDeckExport(data []byte) (DeckExport, error) {
+	var doc DeckExport
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return DeckExport{}, fmt.Errorf("parse export: %w", err)
+	}
+	if doc.Version == 0 && len(doc.Sessions) == 0 {
+		return DeckExport{}, fmt.Errorf("parse export: not an agent-deck export (no version or sessions)")
+	}
+	if doc.Version > DeckExportVersion {
+		return DeckExport{}, fmt.Errorf("export version %d is newer than this agent-deck supports (%d); upgrade first", doc.Version, DeckExportVersion)
+	}
+	for i, s := range doc.Sessions {
+		if strings.TrimSpace(s.Title) == "" || strings.TrimSpace(s.Path) == "" {
+			return DeckExport{}, fmt.Errorf("session %d: title and path are required", i+1)
+		}
+	}
+	return doc, nil
+}
+
+// DeckImportOptions adjusts exported definitions to the importing machine.
+type DeckImportOptions struct {
+	// Group, when set, nests every exported group path under it so a
+	// shared setup lands in its own subtree.
+	Group string
+	// PathMap rewrites path prefixes (old → new), e.g. a teammate's home
+	// directory to yours. The longest matching prefix wins.
+	PathMap map[string]string
+}
+
+// RemapPath applies the longest matching PathMap prefix to path.
+func (o DeckImportOptions) RemapPath(path string) string {
+	if path == "" {
+		return path
+	}
+	best, bestTo := "", ""
+	for from, to := range o.PathMap {
+		from = filepath.Clean(ExpandPath(from))
+		if (path == from || strings.HasPrefix(path, from+string(filepath.Separator))) && len(from) > len(best) {
+			best, bestTo = from, to
+		}
+	}
+	if best == "" {
+		return path
+	}
+	return filepath.Join(ExpandPath(bestTo), strings.TrimPrefix(path, best))
+}
+
+// NewInstance builds a stopped session from the exported definition. The
+// tool conversation IDs carry over, so the first start resumes them.
+func (s DeckExportSession) NewInstance(opts DeckImportOptions, config *UserConfig) *Instance {
+	group := s.Group
+	if opts.Group != "" {
+		group = strings.Trim(opts.Group+"/"+s.Group, "/")
+	}
+	path := opts.RemapPath(ExpandPath(s.Path))
+	inst := NewInstanceWithTool(s.Title, path, s.Tool)
+	if group != "" {
+		inst.GroupPath = group
+	}
+	inst.Command = s.Command
+	inst.Wrapper = s.Wrapper
+	inst.ExtraArgs = s.ExtraArgs
+	inst.Tags, _ = NormalizeTags(s.Tags)
+	inst.Notes = s.Notes
+	inst.Color = s.Color
+	inst.GeminiSessionID = s.GeminiSessionID
+	inst.OpenCodeSessionID = s.OpenCodeSessionID
+	inst.CodexSessionID = s.CodexSessionID
+	inst.SSHHost = s.SSHHost
+	inst.SSHRemotePath = s.SSHRemotePath
+	if w := s.Worktree; w != nil {
+		inst.WorktreePath = opts.RemapPath(ExpandPath(w.Path))
+		inst.WorktreeRepoRoot = opts.RemapPath(ExpandPath(w.RepoRoot))
+		inst.WorktreeBranch = w.Branch
+		inst.WorktreeType = w.Type
+	}
+	if s.ClaudeSessionID != "" {
+		inst.ClaudeSessionID = s.ClaudeSessionID
+		if IsClaudeCompatible(inst.Tool) {
+			claudeOpts := NewClaudeOptions(config)
+			claudeOpts.SessionMode = "resume"
+			claudeOpts.ResumeSessionID = s.ClaudeSessionID
+			_ = inst.SetClaudeOptions(claudeOpts)
+		}
+	}
+	return inst
+}
+
+// FindDeckImportDuplicate returns the existing session an exported one would
+// duplicate: same Claude conversation, or same title at the same path.
+func FindDeckImportDuplicate(existing []*Instance, inst *Instance) *Instance {
+	for _, e := range existing {
+		if e == nil {
+			continue
+		}
+		if inst.ClaudeSessionID != "" && e.ClaudeSessionID == inst.ClaudeSessionID {
+			return e
+		}
+		if e.Title == inst.Title && e.ProjectPath == inst.ProjectPath {
+			return e
+		}
+	}
+	return nil
+}
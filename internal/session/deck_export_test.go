@@ -0,0 +1,119 @@
+package session
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeckExport_RoundTripJSONAndYAML(t *testing.T) {
+	src := NewInstanceWithGroupAndTool("api", "/home/alice/src/api", "work/backend", "claude")
+	src.Command = "claude"
+	src.ClaudeSessionID = "11111111-2222-3333-4444-555555555555"
+	src.ExtraArgs = []string{"--agent", "reviewer"}
+	src.Tags = []string{"backend"}
+	src.Notes = "pairing with bob"
+	src.WorktreePath = "/home/alice/src/api-feat"
+	src.WorktreeRepoRoot = "/home/alice/src/api"
+	src.WorktreeBranch = "feat"
+
+	doc := BuildDeckExport([]*Instance{src, nil}, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+	if len(doc.Sessions) != 1 || doc.Version != DeckExportVersion {
+		t.Fatalf("unexpected export %+v", doc)
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		data, err := MarshalDeckExport(doc, format)
+		if err != nil {
+			t.Fatalf("%s marshal: %v", format, err)
+		}
+		got, err := ParseDeckExport(data)
+		if err != nil {
+			t.Fatalf("%s parse: %v\n%s", format, err, data)
+		}
+		s := got.Sessions[0]
+		if s.Title != "api" || s.Group != "work/backend" || s.ClaudeSessionID != src.ClaudeSessionID {
+			t.Fatalf("%s: session = %+v", format, s)
+		}
+		if s.Worktree == nil || s.Worktree.Branch != "feat" {
+			t.Fatalf("%s: worktree lost: %+v", format, s.Worktree)
+		}
+	}
+
+	if _, err := MarshalDeckExport(doc, "xml"); err == nil {
+		t.Fatal("unknown format should error")
+	}
+}
+
+func TestParseDeckExport_Rejects(t *testing.T) {
+	cases := map[string]string{
+		"not an export": `{"foo": 1}`,
+		"newer version": `{"version": 99, "sessions": []}`,
+		"missing path":  `{"version": 1, "sessions": [{"title": "x", "tool": "shell"}]}`,
+		"garbage":       `{{{`,
+	}
+	for name, doc := range cases {
+		if _, err := ParseDeckExport([]byte(doc)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestDeckExportSession_NewInstance_RemapsAndResumes(t *testing.T) {
+	s := DeckExportSession{
+		Title:           "api",
+		Tool:            "claude",
+		Command:         "claude",
+		Path:            "/home/alice/src/api",
+		Group:           "backend",
+		Tags:            []string{"#Backend"},
+		ClaudeSessionID: "sess-1",
+		Worktree:        &DeckExportWorktree{Path: "/home/alice/wt/api", RepoRoot: "/home/alice/src/api", Branch: "feat"},
+	}
+	opts := DeckImportOptions{
+		Group:   "shared",
+		PathMap: map[string]string{"/home/alice": "/home/bob", "/home/alice/wt/": "/tmp/wt"},
+	}
+	inst := s.NewInstance(opts, nil)
+
+	if inst.ProjectPath != "/home/bob/src/api" {
+		t.Fatalf("ProjectPath = %q", inst.ProjectPath)
+	}
+	if inst.WorktreePath != "/tmp/wt/api" {
+		t.Fatalf("WorktreePath = %q, longest prefix should win", inst.WorktreePath)
+	}
+	if inst.GroupPath != "shared/backend" {
+		t.Fatalf("GroupPath = %q", inst.GroupPath)
+	}
+	if inst.ClaudeSessionID != "sess-1" {
+		t.Fatalf("ClaudeSessionID = %q", inst.ClaudeSessionID)
+	}
+	if opts := inst.GetClaudeOptions(); opts == nil || opts.ResumeSessionID != "sess-1" {
+		t.Fatalf("claude options should resume the exported conversation, got %+v", opts)
+	}
+	if len(inst.Tags) != 1 || inst.Tags[0] != "backend" {
+		t.Fatalf("Tags = %v, want normalized [backend]", inst.Tags)
+	}
+	if inst.Status == StatusRunning || strings.Contains(inst.ID, "sess-1") {
+		t.Fatalf("import should create a fresh stopped instance, got %+v", inst)
+	}
+}
+
+func TestFindDeckImportDuplicate(t *testing.T) {
+	a := NewInstanceWithTool("a", "/p/a", "claude")
+	a.ClaudeSessionID = "sess-a"
+	b := NewInstanceWithTool("b", "/p/b", "shell")
+	existing := []*Instance{a, b}
+
+	byConversation := NewInstanceWithTool("renamed", "/elsewhere", "claude")
+	byConversation.ClaudeSessionID = "sess-a"
+	if FindDeckImportDuplicate(existing, byConversation) != a {
+		t.Fatal("same Claude conversation should be a duplicate")
+	}
+	if FindDeckImportDuplicate(existing, NewInstanceWithTool("b", "/p/b", "shell")) != b {
+		t.Fatal("same title and path should be a duplicate")
+	}
+	if FindDeckImportDuplicate(existing, NewInstanceWithTool("b", "/p/other", "shell")) != nil {
+		t.Fatal("same title at another path is not a duplicate")
+	}
+}
@@ -0,0 +1,139 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// desktopNotifyTimeout bounds one notifier run so a hung notify-send (no
+// notification daemon on the bus) or user hook cannot pile up goroutines.
+const desktopNotifyTimeout = 10 * time.Second
+
+// defaultDesktopNotifyStatuses are the transitions notified when
+// [notifications.desktop].statuses is unset.
+var defaultDesktopNotifyStatuses = []Status{StatusWaiting, StatusError}
+
+// DesktopNotificationsConfig controls OS notifications for status
+// transitions ([notifications.desktop]).
+type DesktopNotificationsConfig struct {
+	// Enabled turns desktop notifications on (default: false).
+	Enabled bool `toml:"enabled,omitempty"`
+
+	// Statuses lists the statuses whose transitions notify: any of
+	// "waiting", "error", "idle". Default: ["waiting", "error"].
+	Statuses []string `toml:"statuses,omitempty"`
+
+	// Command replaces the built-in notifier (osascript on macOS, notify-send
+	// on Linux). It runs through sh -c with the AGENT_DECK_NOTIFY_* variables
+	// set (see DesktopNotification.Env).
+	Command string `toml:"command,omitempty"`
+}
+
+// NotifiesOn reports whether a transition into status should notify.
+func (c DesktopNotificationsConfig) NotifiesOn(status Status) bool {
+	if len(c.Statuses) == 0 {
+		for _, s := range defaultDesktopNotifyStatuses {
+			if s == status {
+				return true
+			}
+		}
+		return false
+	}
+	for _, s := range c.Statuses {
+		if Status(strings.ToLower(strings.TrimSpace(s))) == status {
+			return true
+		}
+	}
+	return false
+}
+
+// DesktopNotification is one status transition to show on the desktop.
+type DesktopNotification struct {
+	SessionID  string
+	Title      string
+	Status     Status
+	Reason     string        // Attention.Reason() of a waiting session, or "stuck"; may be empty
+	WaitedFor  time.Duration // set for wait escalations (escalate_after_minutes)
+	StalledFor time.Duration // set for stuck sessions ([watchdog] stuck_after)
+}
+
+// Message is the notification body, e.g. "api needs permission".
+func (n DesktopNotification) Message() string {
+	switch {
+	case n.StalledFor >= time.Minute:
+		return fmt.Sprintf("%s looks stuck: no output for %d min", n.Title, int(n.StalledFor.Minutes()))
+	case n.Status == StatusWaiting && n.WaitedFor >= time.Minute:
+		return fmt.Sprintf("%s has been waiting for %d min", n.Title, int(n.WaitedFor.Minutes()))
+	case n.Status == StatusWaiting && n.Reason != "":
+		return fmt.Sprintf("%s is waiting (%s)", n.Title, n.Reason)
+	case n.Status == StatusWaiting:
+		return n.Title + " is waiting for you"
+	case n.Status == StatusError:
+		return n.Title + " hit an error"
+	default:
+		return fmt.Sprintf("%s is %s", n.Title, n.Status)
+	}
+}
+
+// Env is the environment handed to a custom notify command.
+func (n DesktopNotification) Env() []string {
+	return []string{
+		"AGENT_DECK_NOTIFY_SESSION_ID=" + n.SessionID,
+		"AGENT_DECK_NOTIFY_TITLE=" + n.Title,
+		"AGENT_DECK_NOTIFY_STATUS=" + string(n.Status),
+		"AGENT_DECK_NOTIFY_REASON=" + n.Reason,
+		"AGENT_DECK_NOTIFY_MESSAGE=" + n.Message(),
+	}
+}
+
+// SendDesktopNotification shows n with the configured notifier. Blocks until
+// the notifier exits (at most desktopNotifyTimeout); run it off the UI
+// thread.
+func SendDesktopNotification(cfg DesktopNotificationsConfig, n DesktopNotification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), desktopNotifyTimeout)
+	defer cancel()
+	cmd, err := desktopNotifyCmd(ctx, runtime.GOOS, cfg.Command, n)
+	if err != nil {
+		return err
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", cmd.Args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// desktopNotifyCmd builds the notifier command for goos.
+func desktopNotifyCmd(ctx context.Context, goos, command string, n DesktopNotification) (*exec.Cmd, error) {
+	if command != "" {
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Env = append(os.Environ(), n.Env()...)
+		return cmd, nil
+	}
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s",
+			appleScriptString(n.Message()), appleScriptString("agent-deck"))
+		return exec.CommandContext(ctx, "osascript", "-e", script), nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		urgency := "normal"
+		if n.Status == StatusError {
+			urgency = "critical"
+		}
+		return exec.CommandContext(ctx, "notify-send", "--app-name=agent-deck",
+			"--urgency="+urgency, "agent-deck", n.Message()), nil
+	default:
+		return nil, fmt.Errorf("no desktop notifier for %s: set [notifications.desktop].command", goos)
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
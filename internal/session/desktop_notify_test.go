@@ -0,0 +1,76 @@
+package session
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDesktopNotificationsConfig_NotifiesOn(t *testing.T) {
+	var def DesktopNotificationsConfig
+	if !def.NotifiesOn(StatusWaiting) || !def.NotifiesOn(StatusError) || def.NotifiesOn(StatusIdle) {
+		t.Error("default statuses should be waiting and error")
+	}
+	cfg := DesktopNotificationsConfig{Statuses: []string{" Idle "}}
+	if !cfg.NotifiesOn(StatusIdle) || cfg.NotifiesOn(StatusWaiting) {
+		t.Error("configured statuses should replace the defaults")
+	}
+}
+
+func TestDesktopNotification_Message(t *testing.T) {
+	cases := []struct {
+		n    DesktopNotification
+		want string
+	}{
+		{DesktopNotification{Title: "api", Status: StatusWaiting, Reason: "needs permission"}, "api is waiting (needs permission)"},
+		{DesktopNotification{Title: "api", Status: StatusWaiting}, "api is waiting for you"},
+		{DesktopNotification{Title: "api", Status: StatusWaiting, Reason: "needs input", WaitedFor: 45 * time.Minute}, "api has been waiting for 45 min"},
+		{DesktopNotification{Title: "api", Status: StatusError}, "api hit an error"},
+		{DesktopNotification{Title: "api", Status: StatusRunning, Reason: "stuck", StalledFor: 2 * time.Hour}, "api looks stuck: no output for 120 min"},
+		{DesktopNotification{Title: "api", Status: StatusIdle}, "api is idle"},
+	}
+	for _, c := range cases {
+		if got := c.n.Message(); got != c.want {
+			t.Errorf("Message() = %q, want %q", got, c.want)
+		}
+	}
+}
+
+func TestDesktopNotifyCmd(t *testing.T) {
+	ctx := context.Background()
+	n := DesktopNotification{SessionID: "id1", Title: `say "hi"`, Status: StatusError}
+
+	cmd, err := desktopNotifyCmd(ctx, "darwin", "", n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.Args[0] != "osascript" || !strings.Contains(cmd.Args[2], `"say \"hi\" hit an error"`) {
+		t.Errorf("darwin argv = %q", cmd.Args)
+	}
+
+	cmd, err = desktopNotifyCmd(ctx, "linux", "", n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.Args[0] != "notify-send" || cmd.Args[len(cmd.Args)-1] != `say "hi" hit an error` ||
+		!strings.Contains(strings.Join(cmd.Args, " "), "--urgency=critical") {
+		t.Errorf("linux argv = %q", cmd.Args)
+	}
+
+	if _, err := desktopNotifyCmd(ctx, "windows", "", n); err == nil {
+		t.Error("unsupported OS without a command should error")
+	}
+
+	cmd, err = desktopNotifyCmd(ctx, "windows", "my-notifier", n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.Args[0] != "sh" || cmd.Args[2] != "my-notifier" {
+		t.Errorf("custom argv = %q", cmd.Args)
+	}
+	env := strings.Join(cmd.Env, "\n")
+	if !strings.Contains(env, "AGENT_DECK_NOTIFY_SESSION_ID=id1") || !strings.Contains(env, "AGENT_DECK_NOTIFY_STATUS=error") {
+		t.Error("custom command should get the AGENT_DECK_NOTIFY_* variables")
+	}
+}
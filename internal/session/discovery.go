@@ -32,55 +32,62 @@ func DiscoverExistingTmuxSessions(existingInstances []*Instance) ([]*Instance, e
 			continue
 		}
 
-		// For orphaned agent-deck sessions, extract the original title from the tmux name
-		// Format: agentdeck_<title>_<hash> -> extract <title>
-		title := sess.DisplayName
-		groupPath := ""
-		isOrphaned := false
-		if strings.HasPrefix(sess.Name, tmux.SessionPrefix) {
-			isOrphaned = true
-			// Extract title from session name: agentdeck_<title>_<8-char-hash>
-			namePart := strings.TrimPrefix(sess.Name, tmux.SessionPrefix)
-			if lastUnderscore := strings.LastIndex(namePart, "_"); lastUnderscore > 0 {
-				title = namePart[:lastUnderscore]
-			} else {
-				title = namePart
-			}
-			// Put orphaned sessions in a "Recovered" group so user knows they were recovered
-			groupPath = "recovered"
-		}
+		inst := newDiscoveredInstance(sess)
+		_ = inst.UpdateStatus()
+		discovered = append(discovered, inst)
+	}
 
-		// Create instance for discovered session
-		projectPath := sess.WorkDir
-		if projectPath == "" {
-			projectPath = "~"
+	return discovered, nil
+}
+
+// newDiscoveredInstance builds an instance adopting an untracked tmux session.
+// Orphaned agent-deck sessions get their original title back from the tmux
+// name and land in the "recovered" group.
+func newDiscoveredInstance(sess *tmux.Session) *Instance {
+	// For orphaned agent-deck sessions, extract the original title from the tmux name
+	// Format: agentdeck_<title>_<hash> -> extract <title>
+	title := sess.DisplayName
+	groupPath := ""
+	isOrphaned := false
+	if strings.HasPrefix(sess.Name, tmux.SessionPrefix) {
+		isOrphaned = true
+		// Extract title from session name: agentdeck_<title>_<8-char-hash>
+		namePart := strings.TrimPrefix(sess.Name, tmux.SessionPrefix)
+		if lastUnderscore := strings.LastIndex(namePart, "_"); lastUnderscore > 0 {
+			title = namePart[:lastUnderscore]
+		} else {
+			title = namePart
 		}
+		// Put orphaned sessions in a "Recovered" group so user knows they were recovered
+		groupPath = "recovered"
+	}
 
-		// Enable mouse mode for proper scrolling in imported sessions
-		// Ignore errors - non-fatal, older tmux versions may not support all options
-		_ = sess.EnableMouseMode()
+	// Create instance for discovered session
+	projectPath := sess.WorkDir
+	if projectPath == "" {
+		projectPath = "~"
+	}
 
-		// Determine tool type - for orphaned agent-deck sessions, assume claude (most common)
-		tool := detectToolFromName(title)
-		if isOrphaned && tool == "shell" {
-			tool = "claude" // Most agent-deck sessions are Claude sessions
-		}
+	// Enable mouse mode for proper scrolling in imported sessions
+	// Ignore errors - non-fatal, older tmux versions may not support all options
+	_ = sess.EnableMouseMode()
 
-		inst := &Instance{
-			ID:             GenerateID(),
-			Title:          title,
-			ProjectPath:    projectPath,
-			GroupPath:      groupPath,
-			Status:         StatusIdle,
-			Tool:           tool,
-			TmuxSocketName: sess.SocketName, // Inherit from the tmux session we discovered (#687)
-			tmuxSession:    sess,
-		}
-		_ = inst.UpdateStatus()
-		discovered = append(discovered, inst)
+	// Determine tool type - for orphaned agent-deck sessions, assume claude (most common)
+	tool := detectToolFromName(title)
+	if isOrphaned && tool == "shell" {
+		tool = "claude" // Most agent-deck sessions are Claude sessions
 	}
 
-	return discovered, nil
+	return &Instance{
+		ID:             GenerateID(),
+		Title:          title,
+		ProjectPath:    projectPath,
+		GroupPath:      groupPath,
+		Status:         StatusIdle,
+		Tool:           tool,
+		TmuxSocketName: sess.SocketName, // Inherit from the tmux session we discovered (#687)
+		tmuxSession:    sess,
+	}
 }
 
 // GroupByProject groups sessions by their parent project directory
@@ -0,0 +1,254 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/atomicfile"
+)
+
+// dndFileName is the deck-wide do-not-disturb state file under the runtime
+// data dir. It is shared by every agent-deck process (TUI, web, notify
+// daemon) so a single `agent-deck dnd on` silences all of them.
+const dndFileName = "dnd.json"
+
+// defaultDNDDuration is used when neither the caller nor
+// [notifications].dnd_default_minutes supplies a duration.
+const defaultDNDDuration = time.Hour
+
+// maxDNDMissedEvents caps the digest so a long DND window over a busy fleet
+// cannot grow the state file without bound. Oldest entries are dropped first.
+const maxDNDMissedEvents = 200
+
+// Sources recorded on a DNDMissedEvent — which alert channel was suppressed.
+const (
+	DNDSourceNotificationBar = "notification_bar"
+	DNDSourcePush            = "push"
+	DNDSourceDesktop         = "desktop"
+	DNDSourceWebhook         = "webhook"
+	DNDSourceSlack           = "slack"
+)
+
+// DNDMissedEvent is one alert that was suppressed while DND was active.
+type DNDMissedEvent struct {
+	Time      time.Time `json:"time"`
+	Source    string    `json:"source"`
+	SessionID string    `json:"session_id"`
+	Title     string    `json:"title,omitempty"`
+	Status    string    `json:"status"`
+}
+
+// DNDState is the persisted do-not-disturb switch plus the "missed while away"
+// digest. Missed survives the end of the DND window so the user can review it
+// after coming back; it is only cleared by DisableDND or ClearDNDDigest.
+type DNDState struct {
+	Until     time.Time        `json:"until,omitzero"`
+	StartedAt time.Time        `json:"started_at,omitzero"`
+	Missed    []DNDMissedEvent `json:"missed,omitempty"`
+}
+
+// Active reports whether DND is in effect at now.
+func (s DNDState) Active(now time.Time) bool {
+	return !s.Until.IsZero() && now.Before(s.Until)
+}
+
+// dndMu serializes read-modify-write cycles within a process. Cross-process
+// writers are rare (a CLI toggle racing a TUI record) and the worst outcome
+// is one lost digest entry, so no file lock is taken.
+var dndMu sync.Mutex
+
+func dndStatePath() (string, error) {
+	return runtimeDataPath(dndFileName)
+}
+
+// LoadDNDState reads the DND state. A missing file is the zero state.
+func LoadDNDState() (DNDState, error) {
+	path, err := dndStatePath()
+	if err != nil {
+		return DNDState{}, err
+	}
+	return loadDNDStateFrom(path)
+}
+
+func loadDNDStateFrom(path string) (DNDState, error) {
+	var st DNDState
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, err
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return DNDState{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return st, nil
+}
+
+func saveDNDStateTo(path string, st DNDState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, data, 0o644)
+}
+
+// IsDNDActive reports whether deck-wide DND is currently in effect. Read
+// errors are treated as "not active" so a corrupt state file can never
+// silence alerts permanently.
+func IsDNDActive() bool {
+	st, err := LoadDNDState()
+	if err != nil {
+		return false
+	}
+	return st.Active(time.Now())
+}
+
+// ResolveDNDDuration returns d when positive, otherwise the configured
+// [notifications].dnd_default_minutes, otherwise one hour.
+func ResolveDNDDuration(d time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	if m := GetNotificationsSettings().DNDDefaultMinutes; m > 0 {
+		return time.Duration(m) * time.Minute
+	}
+	return defaultDNDDuration
+}
+
+// EnableDND turns DND on for d (see ResolveDNDDuration for the zero value).
+// Re-enabling while active extends the window; the pending digest is kept.
+func EnableDND(d time.Duration) (DNDState, error) {
+	dndMu.Lock()
+	defer dndMu.Unlock()
+
+	path, err := dndStatePath()
+	if err != nil {
+		return DNDState{}, err
+	}
+	st, err := loadDNDStateFrom(path)
+	if err != nil {
+		return DNDState{}, err
+	}
+	now := time.Now()
+	if !st.Active(now) {
+		st.StartedAt = now
+	}
+	st.Until = now.Add(ResolveDNDDuration(d))
+	if err := saveDNDStateTo(path, st); err != nil {
+		return DNDState{}, err
+	}
+	return st, nil
+}
+
+// DisableDND turns DND off and returns the missed-while-away digest, which is
+// cleared from disk in the same step.
+func DisableDND() ([]DNDMissedEvent, error) {
+	dndMu.Lock()
+	defer dndMu.Unlock()
+
+	path, err := dndStatePath()
+	if err != nil {
+		return nil, err
+	}
+	st, err := loadDNDStateFrom(path)
+	if err != nil {
+		return nil, err
+	}
+	missed := st.Missed
+	if err := saveDNDStateTo(path, DNDState{}); err != nil {
+		return nil, err
+	}
+	return missed, nil
+}
+
+// ClearDNDDigest drops the recorded missed events without touching the
+// DND window itself.
+func ClearDNDDigest() error {
+	dndMu.Lock()
+	defer dndMu.Unlock()
+
+	path, err := dndStatePath()
+	if err != nil {
+		return err
+	}
+	st, err := loadDNDStateFrom(path)
+	if err != nil {
+		return err
+	}
+	if len(st.Missed) == 0 {
+		return nil
+	}
+	st.Missed = nil
+	return saveDNDStateTo(path, st)
+}
+
+// RecordDNDMissed appends suppressed alerts to the digest when DND is active
+// and reports whether DND was active (callers use it to decide whether to
+// suppress). An event is skipped when the latest recorded entry for the same
+// session already carries the same status, so a session that sits in
+// "waiting" for the whole window (or is seen by both the notification bar
+// and web push) is listed once.
+func RecordDNDMissed(events ...DNDMissedEvent) (bool, error) {
+	dndMu.Lock()
+	defer dndMu.Unlock()
+
+	path, err := dndStatePath()
+	if err != nil {
+		return false, err
+	}
+	st, err := loadDNDStateFrom(path)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	if !st.Active(now) {
+		return false, nil
+	}
+	if !appendDNDMissed(&st, now, events) {
+		return true, nil
+	}
+	return true, saveDNDStateTo(path, st)
+}
+
+// appendDNDMissed merges events into st.Missed, returning whether anything
+// changed.
+func appendDNDMissed(st *DNDState, now time.Time, events []DNDMissedEvent) bool {
+	last := make(map[string]string, len(st.Missed))
+	for _, ev := range st.Missed {
+		last[ev.SessionID] = ev.Status
+	}
+	changed := false
+	for _, ev := range events {
+		if ev.SessionID == "" {
+			continue
+		}
+		if prev, ok := last[ev.SessionID]; ok && prev == ev.Status {
+			continue
+		}
+		if ev.Time.IsZero() {
+			ev.Time = now
+		}
+		st.Missed = append(st.Missed, ev)
+		last[ev.SessionID] = ev.Status
+		changed = true
+	}
+	if over := len(st.Missed) - maxDNDMissedEvents; over > 0 {
+		st.Missed = st.Missed[over:]
+	}
+	return changed
+}
+
+// FormatDNDBar is the notification-bar text shown in place of the session
+// list while DND is active.
+func FormatDNDBar(until time.Time) string {
+	return "🔕 DND until " + until.Format("15:04") + "  "
+}
@@ -0,0 +1,121 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func isolateDNDHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AGENT_DECK_HOME", "")
+	ClearUserConfigCache()
+	t.Cleanup(ClearUserConfigCache)
+}
+
+func TestDND_EnableRecordDisable(t *testing.T) {
+	isolateDNDHome(t)
+
+	if IsDNDActive() {
+		t.Fatal("DND must be off with no state file")
+	}
+	if active, err := RecordDNDMissed(DNDMissedEvent{SessionID: "a", Status: "waiting"}); err != nil || active {
+		t.Fatalf("record while off: active=%v err=%v, want false/nil", active, err)
+	}
+
+	st, err := EnableDND(30 * time.Minute)
+	if err != nil {
+		t.Fatalf("EnableDND: %v", err)
+	}
+	if !IsDNDActive() {
+		t.Fatal("DND must be active after EnableDND")
+	}
+	if d := time.Until(st.Until); d < 29*time.Minute || d > 31*time.Minute {
+		t.Fatalf("Until = %v from now, want ~30m", d)
+	}
+
+	// Same session + status seen by two channels collapses to one digest row.
+	for _, src := range []string{DNDSourceNotificationBar, DNDSourcePush} {
+		active, err := RecordDNDMissed(DNDMissedEvent{Source: src, SessionID: "a", Title: "A", Status: "waiting"})
+		if err != nil || !active {
+			t.Fatalf("record %s: active=%v err=%v", src, active, err)
+		}
+	}
+	if _, err := RecordDNDMissed(DNDMissedEvent{SessionID: "a", Status: "error"}); err != nil {
+		t.Fatalf("record status change: %v", err)
+	}
+
+	missed, err := DisableDND()
+	if err != nil {
+		t.Fatalf("DisableDND: %v", err)
+	}
+	if len(missed) != 2 || missed[0].Status != "waiting" || missed[1].Status != "error" {
+		t.Fatalf("digest = %+v, want [waiting, error] for session a", missed)
+	}
+	if missed[0].Source != DNDSourceNotificationBar {
+		t.Fatalf("first source = %q, want %q", missed[0].Source, DNDSourceNotificationBar)
+	}
+	if IsDNDActive() {
+		t.Fatal("DND must be off after DisableDND")
+	}
+	st, err = LoadDNDState()
+	if err != nil || len(st.Missed) != 0 {
+		t.Fatalf("digest not cleared: %+v err=%v", st, err)
+	}
+}
+
+func TestDND_ExpiredWindowKeepsDigest(t *testing.T) {
+	isolateDNDHome(t)
+
+	path, err := dndStatePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-time.Minute)
+	if err := saveDNDStateTo(path, DNDState{
+		Until:  past,
+		Missed: []DNDMissedEvent{{SessionID: "x", Status: "waiting", Time: past}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if IsDNDActive() {
+		t.Fatal("expired window must not be active")
+	}
+	st, err := LoadDNDState()
+	if err != nil || len(st.Missed) != 1 {
+		t.Fatalf("expired window must keep digest for review: %+v err=%v", st, err)
+	}
+	if err := ClearDNDDigest(); err != nil {
+		t.Fatal(err)
+	}
+	if st, _ := LoadDNDState(); len(st.Missed) != 0 {
+		t.Fatalf("ClearDNDDigest left %d entries", len(st.Missed))
+	}
+}
+
+func TestDND_DigestIsCapped(t *testing.T) {
+	st := DNDState{}
+	events := make([]DNDMissedEvent, 0, maxDNDMissedEvents+10)
+	for i := 0; i < maxDNDMissedEvents+10; i++ {
+		events = append(events, DNDMissedEvent{SessionID: string(rune('a'+i%26)) + time.Duration(i).String(), Status: "waiting"})
+	}
+	if !appendDNDMissed(&st, time.Now(), events) {
+		t.Fatal("expected change")
+	}
+	if len(st.Missed) != maxDNDMissedEvents {
+		t.Fatalf("len = %d, want cap %d", len(st.Missed), maxDNDMissedEvents)
+	}
+	if st.Missed[len(st.Missed)-1].SessionID != events[len(events)-1].SessionID {
+		t.Fatal("cap must drop the oldest entries, not the newest")
+	}
+}
+
+func TestResolveDNDDuration_Default(t *testing.T) {
+	isolateDNDHome(t)
+	if got := ResolveDNDDuration(0); got != defaultDNDDuration {
+		t.Fatalf("ResolveDNDDuration(0) = %v, want %v", got, defaultDNDDuration)
+	}
+	if got := ResolveDNDDuration(5 * time.Minute); got != 5*time.Minute {
+		t.Fatalf("explicit duration ignored: %v", got)
+	}
+}
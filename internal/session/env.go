@@ -558,6 +558,8 @@ func (i *Instance) getToolEnvFile() string {
 			return groupEnv
 		}
 		return config.Hermes.EnvFile
+	case "aider":
+		return config.Aider.EnvFile
 	default:
 		// Check custom tools
 		if def := GetToolDef(i.Tool); def != nil {
@@ -0,0 +1,123 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SessionEventKind classifies an entry in a session's event log.
+type SessionEventKind string
+
+const (
+	// EventCreated marks the session's creation. It is never stored:
+	// timeline views synthesize it from CreatedAt.
+	EventCreated SessionEventKind = "created"
+	// EventStarted is a successful Start()/StartWithMessage().
+	EventStarted SessionEventKind = "started"
+	// EventRestarted is a Restart()/RestartFresh(); Detail carries the
+	// RestartReason, so MCP attach/detach restarts show up as "mcp_change".
+	EventRestarted SessionEventKind = "restarted"
+	// EventStopped is a Kill() of the session's tmux pane.
+	EventStopped SessionEventKind = "stopped"
+	// EventStatus is an observed status transition ("running → error").
+	EventStatus SessionEventKind = "status"
+	// EventMCPChange is an MCP attach/detach written for the session.
+	EventMCPChange SessionEventKind = "mcp"
+	// EventForked is recorded on both sides of a fork.
+	EventForked SessionEventKind = "forked"
+	// EventCloned is recorded on both sides of a clone.
+	EventCloned SessionEventKind = "cloned"
+	// EventVerified is a run of the project's verify command; Detail is
+	// "passed: <command>" or "failed: <command>".
+	EventVerified SessionEventKind = "verified"
+	// EventRolledBack is a rollback of the session's checkout to a
+	// snapshot; Detail names the snapshot.
+	EventRolledBack SessionEventKind = "rolled_back"
+)
+
+// maxEventLog caps the per-session event log. Status transitions are the
+// bulk of it, so the cap covers a few days of an active agent's turns.
+const maxEventLog = 200
+
+// SessionEvent is one entry in a session's event log.
+type SessionEvent struct {
+	At     time.Time        `json:"at"`
+	Kind   SessionEventKind `json:"kind"`
+	Detail string           `json:"detail,omitempty"`
+	Error  string           `json:"error,omitempty"`
+}
+
+// recordEvent appends an event to the log, trimming the oldest entries past
+// maxEventLog.
+func (i *Instance) recordEvent(kind SessionEventKind, detail string, err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.recordEventLocked(kind, detail, err)
+}
+
+// recordEventLocked is recordEvent for callers already holding i.mu.
+func (i *Instance) recordEventLocked(kind SessionEventKind, detail string, err error) {
+	ev := SessionEvent{At: time.Now(), Kind: kind, Detail: detail}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	i.EventLog = append(i.EventLog, ev)
+	if over := len(i.EventLog) - maxEventLog; over > 0 {
+		i.EventLog = append([]SessionEvent(nil), i.EventLog[over:]...)
+	}
+}
+
+// recordStatusChangeLocked logs a status transition. Transitions through
+// "starting" are skipped: every start passes through it, and the started or
+// restarted event already marks that moment. Caller holds i.mu.
+func (i *Instance) recordStatusChangeLocked(from, to Status) {
+	if from == to || from == StatusStarting || to == StatusStarting {
+		return
+	}
+	i.recordEventLocked(EventStatus, fmt.Sprintf("%s → %s", from, to), nil)
+}
+
+// GetEventLog returns a copy of the event log, oldest first. It never
+// contains EventCreated; see that constant.
+func (i *Instance) GetEventLog() []SessionEvent {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if len(i.EventLog) == 0 {
+		return nil
+	}
+	return append([]SessionEvent(nil), i.EventLog...)
+}
+
+const toolDataEventLogKey = "event_log"
+
+// WriteEventLogToToolData merges event_log into the tool_data blob, in the
+// same extras zone as restart_history so older binaries preserve it. An empty
+// log removes the key.
+func WriteEventLogToToolData(td json.RawMessage, events []SessionEvent) json.RawMessage {
+	m := map[string]json.RawMessage{}
+	if len(td) > 0 {
+		_ = json.Unmarshal(td, &m)
+	}
+	if len(events) > 0 {
+		raw, _ := json.Marshal(events)
+		m[toolDataEventLogKey] = raw
+	} else {
+		delete(m, toolDataEventLogKey)
+	}
+	out, _ := json.Marshal(m)
+	return out
+}
+
+// ReadEventLogFromToolData extracts event_log from the blob. Returns nil for
+// missing/malformed/legacy rows.
+func ReadEventLogFromToolData(td json.RawMessage) []SessionEvent {
+	if len(td) == 0 {
+		return nil
+	}
+	var blob struct {
+		EventLog []SessionEvent `json:"event_log"`
+	}
+	_ = json.Unmarshal(td, &blob)
+	return blob.EventLog
+}
@@ -0,0 +1,62 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestRecordEvent_CapsAndCopies(t *testing.T) {
+	inst := &Instance{ID: "e1"}
+	for n := 0; n < maxEventLog+5; n++ {
+		inst.recordEvent(EventStarted, "", nil)
+	}
+	inst.recordRestart(RestartReasonMCPChange, true, errors.New("boom"))
+
+	log := inst.GetEventLog()
+	if len(log) != maxEventLog {
+		t.Fatalf("len = %d, want cap %d", len(log), maxEventLog)
+	}
+	last := log[len(log)-1]
+	if last.Kind != EventRestarted || last.Detail != "mcp_change (fresh)" || last.Error != "boom" {
+		t.Fatalf("last event = %+v, want restarted/mcp_change (fresh)/boom", last)
+	}
+
+	log[0].Kind = "mutated"
+	if inst.GetEventLog()[0].Kind == "mutated" {
+		t.Fatal("GetEventLog must return a copy")
+	}
+}
+
+func TestRecordStatusChange_SkipsStartingAndNoOps(t *testing.T) {
+	inst := &Instance{ID: "e2"}
+	inst.recordStatusChangeLocked(StatusIdle, StatusStarting)
+	inst.recordStatusChangeLocked(StatusStarting, StatusRunning)
+	inst.recordStatusChangeLocked(StatusRunning, StatusRunning)
+	inst.recordStatusChangeLocked(StatusRunning, StatusError)
+
+	log := inst.GetEventLog()
+	if len(log) != 1 || log[0].Kind != EventStatus || log[0].Detail != "running → error" {
+		t.Fatalf("event log = %+v, want only the running → error transition", log)
+	}
+}
+
+func TestEventLogToolDataRoundTrip(t *testing.T) {
+	base := json.RawMessage(`{"notes":"keep me"}`)
+	events := []SessionEvent{{Kind: EventMCPChange, Detail: "local: github"}}
+
+	td := WriteEventLogToToolData(base, events)
+	got := ReadEventLogFromToolData(td)
+	if len(got) != 1 || got[0].Kind != EventMCPChange || got[0].Detail != "local: github" {
+		t.Fatalf("round trip = %+v", got)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(td, &m); err != nil || m["notes"] != "keep me" {
+		t.Fatalf("other keys not preserved: %s", td)
+	}
+
+	cleared := WriteEventLogToToolData(td, nil)
+	if ReadEventLogFromToolData(cleared) != nil {
+		t.Fatalf("empty log must drop the key: %s", cleared)
+	}
+}
@@ -0,0 +1,137 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ClaudeForkPoint is a user turn a Claude fork can branch from. Forking at a
+// point keeps the conversation up to the assistant reply just before that
+// turn, so the fork starts as if the prompt had never been sent.
+type ClaudeForkPoint struct {
+	// Turn is the 1-based index of the user prompt in the conversation.
+	Turn int
+	// Prompt is the turn's text, flattened to a single line.
+	Prompt    string
+	Timestamp time.Time
+	// ResumeAt is the UUID of the last assistant record before the turn —
+	// the value for ClaudeOptions.ResumeAt.
+	ResumeAt string
+}
+
+// ClaudeForkPoints lists up to limit of the most recent user turns of the
+// session's Claude conversation, newest first. The opening prompt is never a
+// fork point: nothing precedes it, so branching there is a clone.
+func (i *Instance) ClaudeForkPoints(limit int) ([]ClaudeForkPoint, error) {
+	path := i.GetJSONLPath()
+	if path == "" {
+		return nil, fmt.Errorf("no Claude conversation on disk for %q", i.Title)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseClaudeForkPoints(f, limit)
+}
+
+// parseClaudeForkPoints streams a Claude JSONL transcript. Lines are read
+// whole with no size cap: tool results routinely exceed a scanner buffer, and
+// a transcript can run to hundreds of megabytes, so only the last limit
+// points are kept.
+func parseClaudeForkPoints(r io.Reader, limit int) ([]ClaudeForkPoint, error) {
+	type claudeMessage struct {
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+	}
+	type claudeRecord struct {
+		Type        string        `json:"type"`
+		UUID        string        `json:"uuid"`
+		Timestamp   time.Time     `json:"timestamp"`
+		IsSidechain bool          `json:"isSidechain"`
+		IsMeta      bool          `json:"isMeta"`
+		Message     claudeMessage `json:"message"`
+	}
+
+	var (
+		points        []ClaudeForkPoint
+		lastAssistant string
+		turn          int
+	)
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			var record claudeRecord
+			if jsonErr := json.Unmarshal(line, &record); jsonErr == nil && !record.IsSidechain {
+				switch record.Type {
+				case "assistant":
+					lastAssistant = record.UUID
+				case "user":
+					prompt := claudePromptText(record.Message.Content)
+					if record.IsMeta || prompt == "" {
+						break // tool results and injected context are not turns
+					}
+					turn++
+					if lastAssistant == "" {
+						break
+					}
+					points = append(points, ClaudeForkPoint{
+						Turn:      turn,
+						Prompt:    prompt,
+						Timestamp: record.Timestamp,
+						ResumeAt:  lastAssistant,
+					})
+					if limit > 0 && len(points) > limit {
+						points = points[1:]
+					}
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for l, r := 0, len(points)-1; l < r; l, r = l+1, r-1 {
+		points[l], points[r] = points[r], points[l]
+	}
+	return points, nil
+}
+
+// claudePromptText extracts the typed text of a user message — string
+// content or text blocks — as one line. Messages that only carry tool
+// results, and slash-command or hook output wrappers, yield "".
+func claudePromptText(content json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(content, &text); err != nil {
+		var blocks []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(content, &blocks); err != nil {
+			return ""
+		}
+		var parts []string
+		for _, b := range blocks {
+			if b.Type == "text" {
+				parts = append(parts, b.Text)
+			}
+		}
+		text = strings.Join(parts, " ")
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if strings.HasPrefix(text, "<command-") || strings.HasPrefix(text, "<local-command-") {
+		return ""
+	}
+	return text
+}
@@ -0,0 +1,70 @@
+package session
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const forkPointFixture = `{"type":"user","uuid":"u1","timestamp":"2026-01-02T10:00:00Z","message":{"role":"user","content":"add a --verbose flag"}}
+{"type":"assistant","uuid":"a1","message":{"role":"assistant","content":[{"type":"text","text":"Reading main.go"}]}}
+{"type":"user","uuid":"t1","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"x","content":"ok"}]}}
+{"type":"assistant","uuid":"a2","message":{"role":"assistant","content":[{"type":"text","text":"Done."}]}}
+{"type":"user","uuid":"m1","isMeta":true,"message":{"role":"user","content":"Caveat: injected"}}
+{"type":"user","uuid":"c1","message":{"role":"user","content":"<command-name>/clear</command-name>"}}
+{"type":"assistant","uuid":"s1","isSidechain":true,"message":{"role":"assistant","content":[{"type":"text","text":"subagent"}]}}
+{"type":"user","uuid":"u2","timestamp":"2026-01-02T10:05:00Z","message":{"role":"user","content":[{"type":"text","text":"now rewrite\nthe whole CLI"}]}}
+{"type":"assistant","uuid":"a3","message":{"role":"assistant","content":[{"type":"text","text":"Rewriting..."}]}}
+{"type":"user","uuid":"u3","message":{"role":"user","content":"revert that"}}
+`
+
+func TestParseClaudeForkPoints(t *testing.T) {
+	points, err := parseClaudeForkPoints(strings.NewReader(forkPointFixture), 0)
+	if err != nil {
+		t.Fatalf("parseClaudeForkPoints: %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("got %d points (%+v), want 2: the opening prompt, tool results, meta and command records are not fork points", len(points), points)
+	}
+
+	newest, older := points[0], points[1]
+	if newest.Turn != 3 || newest.Prompt != "revert that" || newest.ResumeAt != "a3" {
+		t.Errorf("newest point = %+v, want turn 3 resuming at a3", newest)
+	}
+	if older.Turn != 2 || older.Prompt != "now rewrite the whole CLI" || older.ResumeAt != "a2" {
+		t.Errorf("older point = %+v, want turn 2 resuming at a2 (sidechain records ignored)", older)
+	}
+	if older.Timestamp.IsZero() {
+		t.Error("Timestamp should be parsed")
+	}
+
+	limited, err := parseClaudeForkPoints(strings.NewReader(forkPointFixture), 1)
+	if err != nil {
+		t.Fatalf("parseClaudeForkPoints (limit 1): %v", err)
+	}
+	if len(limited) != 1 || limited[0].ResumeAt != "a3" {
+		t.Errorf("limit 1 = %+v, want only the newest point", limited)
+	}
+}
+
+func TestBuildClaudeForkCommand_ResumeAt(t *testing.T) {
+	parent := NewInstanceWithTool("parent", "/tmp/project", "claude")
+	parent.ClaudeSessionID = "0b6f3c1e-1111-2222-3333-444455556666"
+	parent.ClaudeDetectedAt = time.Now()
+
+	_, cmd, err := parent.CreateForkedInstanceWithOptions("child", "", &ClaudeOptions{ResumeAt: "9d1c2b3a-aaaa-bbbb-cccc-ddddeeeeffff"})
+	if err != nil {
+		t.Fatalf("CreateForkedInstanceWithOptions: %v", err)
+	}
+	if !strings.Contains(cmd, "--fork-session --resume-session-at 9d1c2b3a-aaaa-bbbb-cccc-ddddeeeeffff") {
+		t.Errorf("fork command should branch at the chosen message: %s", cmd)
+	}
+
+	_, tip, err := parent.CreateForkedInstanceWithOptions("child", "", &ClaudeOptions{})
+	if err != nil {
+		t.Fatalf("CreateForkedInstanceWithOptions: %v", err)
+	}
+	if strings.Contains(tip, "--resume-session-at") {
+		t.Errorf("tip fork must not pass --resume-session-at: %s", tip)
+	}
+}
@@ -3,6 +3,7 @@ package session
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
@@ -45,10 +46,62 @@ func GetGeminiMCPInfo(projectPath string) *MCPInfo {
 	return info
 }
 
+// GeminiMCPServerConfig is one settings.json mcpServers entry. Gemini keys
+// the transport off the field set: command for stdio, httpUrl for streamable
+// HTTP and url for SSE.
+type GeminiMCPServerConfig struct {
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	HTTPURL string            `json:"httpUrl,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// geminiMCPServerConfig converts a config.toml MCP to Gemini's format. Pooled
+// stdio MCPs go through the same `agent-deck mcp-proxy` socket bridge Claude
+// sessions use, so every session shares one server process.
+func geminiMCPServerConfig(name string, def MCPDef) GeminiMCPServerConfig {
+	if def.URL != "" {
+		if def.HasAutoStartServer() {
+			if err := StartHTTPServer(name, &def); err != nil {
+				mcpCatLog.Warn("http_server_start_failed", slog.String("mcp", name), slog.String("scope", "gemini"), slog.Any("error", err))
+			}
+		}
+		if def.GetTransport() == "sse" {
+			return GeminiMCPServerConfig{URL: def.URL, Headers: def.Headers}
+		}
+		return GeminiMCPServerConfig{HTTPURL: def.URL, Headers: def.Headers}
+	}
+
+	if socketCfg, used := tryPoolSocket(GetGlobalPool(), name, "gemini"); used {
+		return GeminiMCPServerConfig{Command: socketCfg.Command, Args: socketCfg.Args}
+	}
+
+	args := def.Args
+	if args == nil {
+		args = []string{}
+	}
+	env := def.Env
+	if env == nil {
+		env = map[string]string{}
+	}
+	mcpCatLog.Info("transport_stdio", slog.String("mcp", name), slog.String("scope", "gemini"))
+	return GeminiMCPServerConfig{Command: def.Command, Args: args, Env: env}
+}
+
 // WriteGeminiMCPSettings writes MCPs to ~/.gemini/settings.json
 // Preserves existing config fields (security, theme, etc.)
 // Uses a symlink-preserving atomic write (see internal/atomicfile)
 func WriteGeminiMCPSettings(enabledNames []string) error {
+	return writeGeminiMCPServers(enabledNames, false)
+}
+
+// writeGeminiMCPServers replaces settings.json's mcpServers with
+// enabledNames. With keepUnmanaged, entries not defined in config.toml are
+// kept, so regenerating the pooled entries never drops MCPs the user added
+// to settings.json by hand.
+func writeGeminiMCPServers(enabledNames []string, keepUnmanaged bool) error {
 	configFile := filepath.Join(GetGeminiConfigDir(), "settings.json")
 
 	// Read existing config (preserve other fields like security)
@@ -63,35 +116,18 @@ func WriteGeminiMCPSettings(enabledNames []string) error {
 
 	// Get available MCPs from agent-deck config.toml
 	availableMCPs := GetAvailableMCPs()
-	pool := GetGlobalPool()
 
-	mcpServers := make(map[string]MCPServerConfig)
+	mcpServers := make(map[string]interface{})
+	if existing, ok := rawConfig["mcpServers"].(map[string]interface{}); ok && keepUnmanaged {
+		for name, cfg := range existing {
+			if _, managed := availableMCPs[name]; !managed {
+				mcpServers[name] = cfg
+			}
+		}
+	}
 	for _, name := range enabledNames {
 		if def, ok := availableMCPs[name]; ok {
-			// Check if should use socket pool mode
-			if pool != nil && pool.ShouldPool(name) && pool.IsRunning(name) {
-				// Use Unix socket
-				socketPath := pool.GetSocketPath(name)
-				mcpServers[name] = MCPServerConfig{
-					Command: "nc",
-					Args:    []string{"-U", socketPath},
-				}
-			} else {
-				// Use stdio mode
-				args := def.Args
-				if args == nil {
-					args = []string{}
-				}
-				env := def.Env
-				if env == nil {
-					env = map[string]string{}
-				}
-				mcpServers[name] = MCPServerConfig{
-					Command: def.Command,
-					Args:    args,
-					Env:     env,
-				}
-			}
+			mcpServers[name] = geminiMCPServerConfig(name, def)
 		}
 	}
 
@@ -2,8 +2,11 @@ package session
 
 import (
 	"encoding/json"
+	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -271,3 +274,83 @@ func TestGetGeminiMCPNames(t *testing.T) {
 		t.Errorf("Names should be sorted, got %v", names)
 	}
 }
+
+func TestWriteGeminiMCPSettings_TransportFormats(t *testing.T) {
+	home := withTempHome(t)
+	geminiConfigDirOverride = t.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+
+	// A live pool socket left by a running TUI: CLI-mode writes must route
+	// Gemini through the shared proxy, exactly like Claude.
+	pooled := fmt.Sprintf("gemini-pool-%d", os.Getpid())
+	socketPath := filepath.Join("/tmp", fmt.Sprintf("agentdeck-mcp-%s.sock", pooled))
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	defer ln.Close()
+
+	writeConfig(t, home, fmt.Sprintf(`
+[mcp_pool]
+enabled = true
+pool_all = true
+
+[mcps.%s]
+command = "npx"
+args = ["-y", "pooled-mcp"]
+
+[mcps.remote]
+url = "https://mcp.example.com/mcp"
+headers = { Authorization = "Bearer t" }
+
+[mcps.events]
+url = "https://mcp.example.com/sse"
+transport = "sse"
+`, pooled))
+	ClearUserConfigCache()
+
+	if err := WriteGeminiMCPSettings([]string{pooled, "remote", "events"}); err != nil {
+		t.Fatalf("WriteGeminiMCPSettings: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(geminiConfigDirOverride, "settings.json"))
+	var settings struct {
+		MCPServers map[string]GeminiMCPServerConfig `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("parse settings.json: %v", err)
+	}
+
+	if got := settings.MCPServers[pooled]; got.Command != "agent-deck" || len(got.Args) != 2 || got.Args[1] != socketPath {
+		t.Errorf("pooled MCP = %+v, want agent-deck mcp-proxy %s", got, socketPath)
+	}
+	if got := settings.MCPServers["remote"]; got.HTTPURL != "https://mcp.example.com/mcp" || got.URL != "" || got.Headers["Authorization"] != "Bearer t" {
+		t.Errorf("http MCP = %+v, want httpUrl with headers", got)
+	}
+	if got := settings.MCPServers["events"]; got.URL != "https://mcp.example.com/sse" || got.HTTPURL != "" {
+		t.Errorf("sse MCP = %+v, want url", got)
+	}
+}
+
+func TestWriteGeminiMCPServers_KeepUnmanaged(t *testing.T) {
+	home := withTempHome(t)
+	geminiConfigDirOverride = t.TempDir()
+	defer func() { geminiConfigDirOverride = "" }()
+	writeConfig(t, home, "[mcps.memory]\ncommand = \"npx\"\n")
+	ClearUserConfigCache()
+
+	settingsFile := filepath.Join(geminiConfigDirOverride, "settings.json")
+	_ = os.WriteFile(settingsFile, []byte(`{"mcpServers": {"hand-added": {"command": "x"}, "memory": {"command": "old"}}}`), 0o644)
+
+	if err := writeGeminiMCPServers([]string{"memory"}, true); err != nil {
+		t.Fatalf("writeGeminiMCPServers: %v", err)
+	}
+	names := GetGeminiMCPNames()
+	if len(names) != 2 || names[0] != "hand-added" || names[1] != "memory" {
+		t.Fatalf("names = %v, want hand-added kept beside memory", names)
+	}
+	data, _ := os.ReadFile(settingsFile)
+	if !strings.Contains(string(data), `"command": "npx"`) {
+		t.Errorf("managed entry should be regenerated from config.toml: %s", data)
+	}
+}
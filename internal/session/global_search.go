@@ -38,10 +38,11 @@ const TierThresholdInstant = 100 * 1024 * 1024
 // TierThresholdBalanced is the max size for balanced tier (500MB)
 const TierThresholdBalanced = 500 * 1024 * 1024
 
-// SearchEntry represents a searchable Claude session
+// SearchEntry represents a searchable conversation
 type SearchEntry struct {
-	SessionID string    // Claude session UUID
-	FilePath  string    // Path to .jsonl file
+	SessionID string    // Tool session ID (Claude UUID, Gemini sessionId, OpenCode ses_...)
+	Tool      string    // "claude", or the ConversationSource tool that produced it
+	FilePath  string    // Path to .jsonl file (or the source's conversation file)
 	CWD       string    // Project working directory
 	Summary   string    // First user message or summary
 	ModTime   time.Time // File modification time
@@ -308,6 +309,7 @@ type claudeMessage struct {
 func parseClaudeJSONL(filePath string, data []byte, includeContent bool) (*SearchEntry, error) {
 	entry := &SearchEntry{
 		FilePath: filePath,
+		Tool:     "claude",
 	}
 
 	var contentBuilder bytes.Buffer
@@ -393,7 +395,7 @@ func parseClaudeJSONLHead(filePath string) (*SearchEntry, error) {
 	}
 	defer f.Close()
 
-	entry := &SearchEntry{FilePath: filePath}
+	entry := &SearchEntry{FilePath: filePath, Tool: "claude"}
 	scanner := bufio.NewScanner(io.LimitReader(f, 32*1024))
 	buf := make([]byte, 0, 32*1024)
 	scanner.Buffer(buf, 32*1024)
@@ -469,6 +471,7 @@ type GlobalSearchIndex struct {
 	// Configuration
 	config    GlobalSearchSettings
 	claudeDir string
+	sources   []ConversationSource // non-Claude tools (Gemini, OpenCode)
 
 	// Index data (protected by atomic pointer for lock-free reads)
 	entries atomic.Pointer[[]SearchEntry]
@@ -502,6 +505,13 @@ type GlobalSearchIndex struct {
 	lastQuery   string
 	lastResults []*SearchResult
 	lastQueryMu sync.Mutex
+
+	// Inverted index over in-memory content (TierInstant)
+	inverted invertedCache
+
+	// On-disk persistence ("" when disabled); dirty once entries change
+	cachePath  string
+	cacheDirty atomic.Bool
 }
 
 // FileTracker tracks file state for incremental updates
@@ -512,8 +522,15 @@ type FileTracker struct {
 	LastMod    time.Time
 }
 
-// NewGlobalSearchIndex creates a new search index
+// NewGlobalSearchIndex creates a new search index over Claude's projects and
+// the DefaultConversationSources.
 func NewGlobalSearchIndex(claudeDir string, config GlobalSearchSettings) (*GlobalSearchIndex, error) {
+	return NewGlobalSearchIndexWithSources(claudeDir, config, DefaultConversationSources()...)
+}
+
+// NewGlobalSearchIndexWithSources creates a search index over Claude's
+// projects plus the given conversation sources.
+func NewGlobalSearchIndexWithSources(claudeDir string, config GlobalSearchSettings, sources ...ConversationSource) (*GlobalSearchIndex, error) {
 	if !config.GetEnabled() {
 		return nil, nil
 	}
@@ -537,12 +554,16 @@ func NewGlobalSearchIndex(claudeDir string, config GlobalSearchSettings) (*Globa
 	idx := &GlobalSearchIndex{
 		config:           config,
 		claudeDir:        claudeDir,
+		sources:          sources,
 		fileTrackers:     make(map[string]*FileTracker),
 		limiter:          rate.NewLimiter(rate.Limit(config.IndexRateLimit), 5),
 		memoryLimitBytes: memLimitBytes,
 		ctx:              ctx,
 		cancel:           cancel,
 	}
+	if config.GetPersistIndex() {
+		idx.cachePath = defaultGlobalSearchCachePath()
+	}
 
 	// Initialize empty entries
 	emptyEntries := make([]SearchEntry, 0)
@@ -558,6 +579,7 @@ func NewGlobalSearchIndex(claudeDir string, config GlobalSearchSettings) (*Globa
 			return nil, err
 		}
 	}
+	totalSize += idx.measureSourceSize()
 
 	// Determine tier (respect config override)
 	switch config.Tier {
@@ -603,9 +625,11 @@ func NewGlobalSearchIndex(claudeDir string, config GlobalSearchSettings) (*Globa
 	idx.loading.Store(true)
 
 	// Start background workers
-	idx.wg.Add(2)
+	idx.wg.Add(4)
 	go idx.watcherLoop()
 	go idx.initialLoad()
+	go idx.sourceRescanLoop()
+	go idx.persistLoop()
 
 	return idx, nil
 }
@@ -646,10 +670,15 @@ func measureDataSize(projectsDir string, recentDays int) (int64, error) {
 	return totalSize, err
 }
 
-// initialLoad loads all session files on startup
+// initialLoad loads all session files on startup. Transcripts unchanged
+// since the persisted index was written are taken from it instead of being
+// re-parsed.
 func (idx *GlobalSearchIndex) initialLoad() {
 	defer idx.wg.Done()
 
+	cached := idx.loadIndexCache()
+	reused := 0
+
 	projectsDir := filepath.Join(idx.claudeDir, "projects")
 	cutoff := time.Time{}
 	if idx.config.RecentDays > 0 {
@@ -700,8 +729,10 @@ func (idx *GlobalSearchIndex) initialLoad() {
 		}
 
 		// Parse file: for metadata-only mode, read just the head (first 32KB)
-		var entry *SearchEntry
-		if !includeContent {
+		entry, hit := idx.cachedEntryFor(cached, path, info)
+		if hit {
+			reused++
+		} else if !includeContent {
 			entry, err = parseClaudeJSONLHead(path)
 		} else {
 			var data []byte
@@ -741,10 +772,20 @@ func (idx *GlobalSearchIndex) initialLoad() {
 		return nil
 	})
 
+	claudeEntries := len(entries)
+	entries = append(entries, idx.loadSourceEntries(cutoff, includeContent)...)
+
 	// Store entries and mark loading complete
 	idx.entries.Store(&entries)
 	idx.loading.Store(false)
 
+	if reused != claudeEntries || reused != len(cached) {
+		idx.cacheDirty.Store(true)
+		if err := idx.saveIndexCache(); err != nil {
+			searchLog.Warn("global_search_cache_save_failed", slog.String("error", err.Error()))
+		}
+	}
+
 	// Evict oldest entries if over memory limit
 	if idx.currentMemoryBytes.Load() > idx.memoryLimitBytes {
 		idx.evictOldestEntries()
@@ -762,6 +803,8 @@ func isUUIDFileName(name string) bool {
 func (idx *GlobalSearchIndex) watcherLoop() {
 	defer idx.wg.Done()
 
+	projectsDir := filepath.Join(idx.claudeDir, "projects")
+
 	// Debounce map
 	debounce := make(map[string]*time.Timer)
 	debounceMu := sync.Mutex{}
@@ -781,6 +824,15 @@ func (idx *GlobalSearchIndex) watcherLoop() {
 				return
 			}
 
+			// A new project directory: watch it too, so its sessions are
+			// indexed without a restart.
+			if event.Op&fsnotify.Create != 0 && filepath.Dir(event.Name) == projectsDir {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					idx.watchProjectDir(event.Name)
+					continue
+				}
+			}
+
 			// Only care about writes and creates for .jsonl files
 			if !strings.HasSuffix(event.Name, ".jsonl") {
 				continue
@@ -811,6 +863,21 @@ func (idx *GlobalSearchIndex) watcherLoop() {
 	}
 }
 
+// watchProjectDir starts watching a project directory created after startup
+// and indexes any transcripts written before the watch was in place.
+func (idx *GlobalSearchIndex) watchProjectDir(dir string) {
+	if err := idx.watcher.Add(dir); err != nil {
+		searchLog.Warn("global_search_watch_failed", slog.String("error", err.Error()))
+		return
+	}
+	dirEntries, _ := os.ReadDir(dir)
+	for _, de := range dirEntries {
+		if !de.IsDir() && isUUIDFileName(de.Name()) {
+			idx.updateFile(filepath.Join(dir, de.Name()))
+		}
+	}
+}
+
 // updateFile handles incremental update for a single file
 func (idx *GlobalSearchIndex) updateFile(path string) {
 	if !isUUIDFileName(filepath.Base(path)) {
@@ -853,6 +920,7 @@ func (idx *GlobalSearchIndex) updateFile(path string) {
 
 		if found && canSkipParse {
 			idx.entries.Store(&newEntries)
+			idx.cacheDirty.Store(true)
 			idx.trackerMu.Lock()
 			idx.fileTrackers[path] = &FileTracker{
 				Path:       path,
@@ -929,6 +997,7 @@ func (idx *GlobalSearchIndex) updateFile(path string) {
 	}
 
 	idx.entries.Store(&newEntries)
+	idx.cacheDirty.Store(true)
 
 	// Evict if over memory limit
 	if idx.currentMemoryBytes.Load() > idx.memoryLimitBytes {
@@ -1002,52 +1071,25 @@ func (idx *GlobalSearchIndex) evictOldestEntries() {
 	idx.currentMemoryBytes.Add(-freedBytes)
 }
 
-// Search performs a simple substring search
+// Search runs a ranked query (see SearchQuery for the syntax). Results are
+// ordered by BM25 relevance, with a boost for conversations whose summary
+// matches.
 func (idx *GlobalSearchIndex) Search(query string) []*SearchResult {
-	if query == "" {
+	q := ParseSearchQuery(query, time.Now())
+	if !q.HasText() && !q.hasFilters() {
 		idx.resetQueryCache()
 		return nil
 	}
 
 	if idx.tier == TierBalanced {
-		return idx.searchOnDisk(query)
+		return idx.searchOnDisk(query, q)
 	}
 
 	entries := idx.entries.Load()
 	if entries == nil {
 		return nil
 	}
-
-	queryLower := []byte(strings.ToLower(query))
-	var results []*SearchResult
-
-	for i := range *entries {
-		entry := &(*entries)[i]
-		if entry.content == nil {
-			continue
-		}
-		hasMatch := false
-		entry.content.With(func(_, lower []byte) {
-			hasMatch = bytes.Contains(lower, queryLower)
-		})
-		if !hasMatch {
-			continue
-		}
-		matches := entry.Match(query)
-		results = append(results, &SearchResult{
-			Entry:   entry,
-			Matches: matches,
-			Score:   len(matches) * 10,
-			Snippet: entry.GetSnippet(query, 60),
-		})
-	}
-
-	// Sort by score (more matches = higher score) - O(n log n)
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
-
-	return results
+	return idx.searchInstant(q, entries)
 }
 
 // fuzzySearchSource implements fuzzy.Source for our entries
@@ -1126,6 +1168,10 @@ func (idx *GlobalSearchIndex) Close() {
 	}
 	idx.wg.Wait()
 
+	if err := idx.saveIndexCache(); err != nil {
+		searchLog.Warn("global_search_cache_save_failed", slog.String("error", err.Error()))
+	}
+
 	// Release all content memory
 	emptyEntries := make([]SearchEntry, 0)
 	idx.entries.Store(&emptyEntries)
@@ -1204,15 +1250,27 @@ func extractContentText(contentRaw json.RawMessage) string {
 	return sb.String()
 }
 
-func (idx *GlobalSearchIndex) searchOnDisk(query string) []*SearchResult {
+func (idx *GlobalSearchIndex) searchOnDisk(query string, q SearchQuery) []*SearchResult {
 	entries := idx.entries.Load()
 	if entries == nil {
 		return nil
 	}
 
-	queryLower := strings.ToLower(query)
-
-	candidates := idx.queryCandidates(query, entries)
+	var candidates []*SearchEntry
+	for _, entry := range idx.queryCandidates(query, entries) {
+		if q.matchesMeta(entry) {
+			candidates = append(candidates, entry)
+		}
+	}
+	if !q.HasText() {
+		var results []*SearchResult
+		for _, entry := range candidates {
+			results = append(results, &SearchResult{Entry: entry, Score: 1, Snippet: entry.Summary})
+		}
+		sort.SliceStable(results, func(i, j int) bool { return results[i].Entry.ModTime.After(results[j].Entry.ModTime) })
+		idx.storeQueryCache(query, results)
+		return results
+	}
 
 	// Parallel search with worker pool (cap at 8 workers)
 	numWorkers := 8
@@ -1223,14 +1281,9 @@ func (idx *GlobalSearchIndex) searchOnDisk(query string) []*SearchResult {
 		return nil
 	}
 
-	type searchHit struct {
-		entry   *SearchEntry
-		count   int
-		snippet string
-	}
-
+	terms, exact := q.queryTerms()
 	jobs := make(chan *SearchEntry, len(candidates))
-	hits := make(chan searchHit, len(candidates))
+	hits := make(chan diskHit, len(candidates))
 
 	var wg sync.WaitGroup
 	for i := 0; i < numWorkers; i++ {
@@ -1238,9 +1291,14 @@ func (idx *GlobalSearchIndex) searchOnDisk(query string) []*SearchResult {
 		go func() {
 			defer wg.Done()
 			for entry := range jobs {
-				matchCount, snippet := scanFileForQuery(entry.FilePath, queryLower, 60)
-				if matchCount > 0 {
-					hits <- searchHit{entry: entry, count: matchCount, snippet: snippet}
+				var content string
+				if src := idx.sourceFor(entry.Tool); src != nil {
+					content = loadSourceSearchText(src, entry.FilePath)
+				} else {
+					content = loadClaudeSearchText(entry.FilePath)
+				}
+				if hit, ok := scoreText(entry, content, q, terms, exact); ok {
+					hits <- hit
 				}
 			}
 		}()
@@ -1256,18 +1314,35 @@ func (idx *GlobalSearchIndex) searchOnDisk(query string) []*SearchResult {
 		close(hits)
 	}()
 
-	var results []*SearchResult
+	// Every hit contains every word, so each word's document frequency is
+	// the hit count; ranking within it comes from term frequency and length.
+	var collected []diskHit
+	totalLen := 0
 	for hit := range hits {
+		collected = append(collected, hit)
+		totalLen += hit.docLen
+	}
+	if len(collected) == 0 {
+		idx.storeQueryCache(query, nil)
+		return nil
+	}
+	idf := bm25IDF(len(*entries), len(collected))
+	avgLen := float64(totalLen) / float64(len(collected))
+
+	results := make([]*SearchResult, 0, len(collected))
+	for _, hit := range collected {
+		score := 0.0
+		for _, tf := range hit.tfs {
+			score += bm25Term(float64(tf), idf, hit.docLen, avgLen)
+		}
+		score *= q.summaryBoost(hit.entry.Summary)
 		results = append(results, &SearchResult{
 			Entry:   hit.entry,
-			Score:   hit.count * 10,
+			Score:   int(score * 1000),
 			Snippet: hit.snippet,
 		})
 	}
-
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
+	sortSearchResults(results)
 
 	idx.storeQueryCache(query, results)
 	return results
@@ -1275,7 +1350,10 @@ func (idx *GlobalSearchIndex) searchOnDisk(query string) []*SearchResult {
 
 func (idx *GlobalSearchIndex) queryCandidates(query string, entries *[]SearchEntry) []*SearchEntry {
 	idx.lastQueryMu.Lock()
-	usePrev := idx.lastQuery != "" && strings.HasPrefix(query, idx.lastQuery) && len(query) > len(idx.lastQuery)
+	// Extending a query only narrows it, except while a filter is being
+	// typed ("tool" is a word, "tool:g" a filter), so those rescan.
+	usePrev := idx.lastQuery != "" && strings.HasPrefix(query, idx.lastQuery) && len(query) > len(idx.lastQuery) &&
+		!strings.Contains(query, ":")
 	if usePrev && len(idx.lastResults) > 0 {
 		candidates := make([]*SearchEntry, 0, len(idx.lastResults))
 		for _, res := range idx.lastResults {
@@ -1309,10 +1387,12 @@ func (idx *GlobalSearchIndex) resetQueryCache() {
 	idx.lastQueryMu.Unlock()
 }
 
-func scanFileForQuery(path string, queryLower string, windowSize int) (int, string) {
+// loadClaudeSearchText reads the searchable text of a Claude transcript
+// (TierBalanced keeps none of it in memory).
+func loadClaudeSearchText(path string) string {
 	file, err := os.Open(path)
 	if err != nil {
-		return 0, ""
+		return ""
 	}
 	defer file.Close()
 
@@ -1320,9 +1400,7 @@ func scanFileForQuery(path string, queryLower string, windowSize int) (int, stri
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, 10*1024*1024)
 
-	matchCount := 0
-	snippet := ""
-
+	var sb strings.Builder
 	for scanner.Scan() {
 		line := scanner.Bytes()
 		if len(line) == 0 {
@@ -1339,26 +1417,12 @@ func scanFileForQuery(path string, queryLower string, windowSize int) (int, stri
 		if err := json.Unmarshal(record.Message, &msg); err != nil {
 			continue
 		}
-		content := formatMessageContent(msg)
-		if content == "" {
-			continue
-		}
-		contentLower := strings.ToLower(content)
-		if !strings.Contains(contentLower, queryLower) {
-			continue
-		}
-
-		matchCount += strings.Count(contentLower, queryLower)
-		if snippet == "" {
-			snippet = snippetFromText(content, queryLower, windowSize)
+		if content := formatMessageContent(msg); content != "" {
+			sb.WriteString(content)
+			sb.WriteString("\n")
 		}
 	}
-
-	if err := scanner.Err(); err != nil {
-		return matchCount, snippet
-	}
-
-	return matchCount, snippet
+	return sb.String()
 }
 
 func snippetFromText(content string, queryLower string, windowSize int) string {
@@ -0,0 +1,197 @@
+package session
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/agentpaths"
+)
+
+// globalSearchCacheVersion is bumped whenever the on-disk layout or the
+// parsing of Claude transcripts changes; caches from other versions are
+// discarded and rebuilt.
+const globalSearchCacheVersion = 1
+
+// globalSearchCacheName is the index cache file under the agent-deck cache dir.
+const globalSearchCacheName = "global-search-index.gob.gz"
+
+// persistInterval is how often a changed index is written back to disk.
+var persistInterval = 2 * time.Minute
+
+// globalSearchCache is the persisted form of the Claude part of the index.
+// Gemini/OpenCode entries are cheap to re-read and are not cached.
+type globalSearchCache struct {
+	Version   int
+	ClaudeDir string
+	Tier      SearchTier
+	Entries   []cachedSearchEntry
+}
+
+type cachedSearchEntry struct {
+	SessionID string
+	FilePath  string
+	CWD       string
+	Summary   string
+	ModTime   time.Time
+	FileSize  int64
+	Content   []byte // nil in TierBalanced and for evicted entries
+}
+
+// defaultGlobalSearchCachePath returns where the index is persisted, or ""
+// when the cache dir cannot be resolved.
+func defaultGlobalSearchCachePath() string {
+	path, err := agentpaths.CachePath(globalSearchCacheName)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// loadIndexCache reads the persisted index, keyed by transcript path. A
+// missing, corrupt or mismatched (version, Claude dir, tier) cache yields nil.
+func (idx *GlobalSearchIndex) loadIndexCache() map[string]cachedSearchEntry {
+	if idx.cachePath == "" {
+		return nil
+	}
+	f, err := os.Open(idx.cachePath)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		searchLog.Warn("global_search_cache_corrupt", slog.String("error", err.Error()))
+		return nil
+	}
+	defer zr.Close()
+
+	var cache globalSearchCache
+	if err := gob.NewDecoder(zr).Decode(&cache); err != nil {
+		searchLog.Warn("global_search_cache_corrupt", slog.String("error", err.Error()))
+		return nil
+	}
+	if cache.Version != globalSearchCacheVersion || cache.ClaudeDir != idx.claudeDir || cache.Tier != idx.tier {
+		searchLog.Info("global_search_cache_stale",
+			slog.Int("version", cache.Version),
+			slog.String("tier", TierName(cache.Tier)))
+		return nil
+	}
+
+	byPath := make(map[string]cachedSearchEntry, len(cache.Entries))
+	for _, e := range cache.Entries {
+		byPath[e.FilePath] = e
+	}
+	return byPath
+}
+
+// cachedEntryFor returns the cached entry for path if the file is unchanged
+// since it was cached and the cache holds everything this tier needs.
+func (idx *GlobalSearchIndex) cachedEntryFor(cached map[string]cachedSearchEntry, path string, info os.FileInfo) (*SearchEntry, bool) {
+	c, ok := cached[path]
+	if !ok || c.FileSize != info.Size() || !c.ModTime.Equal(info.ModTime()) {
+		return nil, false
+	}
+	includeContent := idx.tier == TierInstant
+	if includeContent && len(c.Content) == 0 {
+		return nil, false
+	}
+	entry := &SearchEntry{
+		SessionID: c.SessionID,
+		Tool:      "claude",
+		FilePath:  c.FilePath,
+		CWD:       c.CWD,
+		Summary:   c.Summary,
+		ModTime:   c.ModTime,
+		FileSize:  c.FileSize,
+	}
+	if includeContent {
+		entry.setContent(c.Content)
+	}
+	return entry, true
+}
+
+// saveIndexCache writes the Claude entries to disk if anything changed since
+// the last save. The file is replaced atomically.
+func (idx *GlobalSearchIndex) saveIndexCache() error {
+	if idx.cachePath == "" || !idx.cacheDirty.Swap(false) {
+		return nil
+	}
+	entries := idx.entries.Load()
+	if entries == nil {
+		return nil
+	}
+
+	cache := globalSearchCache{Version: globalSearchCacheVersion, ClaudeDir: idx.claudeDir, Tier: idx.tier}
+	for i := range *entries {
+		e := &(*entries)[i]
+		if idx.sourceFor(e.Tool) != nil {
+			continue
+		}
+		c := cachedSearchEntry{
+			SessionID: e.SessionID,
+			FilePath:  e.FilePath,
+			CWD:       e.CWD,
+			Summary:   e.Summary,
+			ModTime:   e.ModTime,
+			FileSize:  e.FileSize,
+		}
+		if e.hasContent() {
+			c.Content = e.content.CopyData()
+		}
+		cache.Entries = append(cache.Entries, c)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(idx.cachePath), 0o700); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(idx.cachePath), globalSearchCacheName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	zw := gzip.NewWriter(tmp)
+	if err := gob.NewEncoder(zw).Encode(&cache); err != nil {
+		tmp.Close()
+		return fmt.Errorf("encode cache: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("compress cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), idx.cachePath); err != nil {
+		return fmt.Errorf("replace cache: %w", err)
+	}
+	return nil
+}
+
+// persistLoop saves the index periodically while it changes, so a crash
+// loses at most a few minutes of incremental updates (files changed since
+// are simply re-parsed on the next start).
+func (idx *GlobalSearchIndex) persistLoop() {
+	defer idx.wg.Done()
+	ticker := time.NewTicker(persistInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-idx.ctx.Done():
+			return
+		case <-ticker.C:
+			if idx.loading.Load() {
+				continue
+			}
+			if err := idx.saveIndexCache(); err != nil {
+				searchLog.Warn("global_search_cache_save_failed", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func waitGlobalSearchLoaded(t *testing.T, index *GlobalSearchIndex) {
+	t.Helper()
+	for i := 0; i < 200 && index.IsLoading(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func writeSearchTranscript(t *testing.T, path, message string, mod time.Time) {
+	t.Helper()
+	line := `{"sessionId":"a1b2c3d4-e5f6-7890-abcd-ef1234567890","type":"user","cwd":"/home/me/api","message":{"role":"user","content":"` + message + `"}}` + "\n"
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(line), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGlobalSearchIndex_PersistsAcrossRestarts(t *testing.T) {
+	claudeDir := t.TempDir()
+	path := filepath.Join(claudeDir, "projects", "-home-me-api", "a1b2c3d4-e5f6-7890-abcd-ef1234567890.jsonl")
+	mod := time.Now().Add(-time.Hour).Truncate(time.Second)
+	writeSearchTranscript(t, path, "alpha webhook", mod)
+	cfg := GlobalSearchSettings{Enabled: boolPtr(true), Tier: "instant", MemoryLimitMB: 100, IndexRateLimit: 100}
+
+	index, err := NewGlobalSearchIndexWithSources(claudeDir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitGlobalSearchLoaded(t, index)
+	index.Close()
+	if _, err := os.Stat(defaultGlobalSearchCachePath()); err != nil {
+		t.Fatalf("index should be persisted on load: %v", err)
+	}
+
+	// Same size and mtime: the cached entry is trusted, so the old text is
+	// still what gets searched. This is how we know nothing was re-parsed.
+	writeSearchTranscript(t, path, "bravo webhook", mod)
+	index, err = NewGlobalSearchIndexWithSources(claudeDir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitGlobalSearchLoaded(t, index)
+	if r := index.Search("alpha"); len(r) != 1 {
+		t.Errorf("unchanged file should come from the cache, got %d results for alpha", len(r))
+	}
+	index.Close()
+
+	// A changed file is re-parsed.
+	writeSearchTranscript(t, path, "charlie webhook!", mod.Add(time.Minute))
+	index, err = NewGlobalSearchIndexWithSources(claudeDir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer index.Close()
+	waitGlobalSearchLoaded(t, index)
+	if r := index.Search("charlie"); len(r) != 1 {
+		t.Errorf("changed file should be re-parsed, got %d results for charlie", len(r))
+	}
+}
+
+func TestGlobalSearchIndex_CacheMismatchIgnored(t *testing.T) {
+	claudeDir := t.TempDir()
+	idx := &GlobalSearchIndex{claudeDir: claudeDir, tier: TierInstant, cachePath: filepath.Join(t.TempDir(), "index.gob.gz")}
+	entries := []SearchEntry{{SessionID: "s", Tool: "claude", FilePath: "/p/s.jsonl", Summary: "hi"}}
+	idx.entries.Store(&entries)
+	idx.cacheDirty.Store(true)
+	if err := idx.saveIndexCache(); err != nil {
+		t.Fatal(err)
+	}
+	if got := idx.loadIndexCache(); len(got) != 1 || got["/p/s.jsonl"].Summary != "hi" {
+		t.Fatalf("round trip = %+v", got)
+	}
+
+	balanced := &GlobalSearchIndex{claudeDir: claudeDir, tier: TierBalanced, cachePath: idx.cachePath}
+	if got := balanced.loadIndexCache(); got != nil {
+		t.Error("cache written for another tier should be ignored")
+	}
+	other := &GlobalSearchIndex{claudeDir: t.TempDir(), tier: TierInstant, cachePath: idx.cachePath}
+	if got := other.loadIndexCache(); got != nil {
+		t.Error("cache written for another Claude dir should be ignored")
+	}
+	if err := os.WriteFile(idx.cachePath, []byte("not gzip"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := idx.loadIndexCache(); got != nil {
+		t.Error("corrupt cache should be ignored")
+	}
+}
+
+func TestGlobalSearchIndex_WatchesNewProjectDirs(t *testing.T) {
+	claudeDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(claudeDir, "projects"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := GlobalSearchSettings{Enabled: boolPtr(true), Tier: "instant", MemoryLimitMB: 100, IndexRateLimit: 100, PersistIndex: boolPtr(false)}
+	index, err := NewGlobalSearchIndexWithSources(claudeDir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer index.Close()
+	waitGlobalSearchLoaded(t, index)
+
+	writeSearchTranscript(t, filepath.Join(claudeDir, "projects", "-home-me-new", "a1b2c3d4-e5f6-7890-abcd-ef1234567890.jsonl"), "fresh project", time.Now())
+	for i := 0; i < 300 && len(index.Search("fresh")) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if r := index.Search("fresh"); len(r) != 1 {
+		t.Errorf("session in a project created after startup should be indexed, got %d results", len(r))
+	}
+}
@@ -0,0 +1,477 @@
+package session
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchQuery is a parsed global search query.
+//
+//	webhook retry             both words (prefix match: "retry" finds "retrying")
+//	"connection refused"      exact phrase, case-insensitive
+//	project:billing           working directory contains "billing"
+//	tool:gemini               only Gemini conversations
+//	after:7d  after:2026-01-31   modified within 7 days / since that date
+//	before:2026-02-01         modified before that date
+//
+// Filters with unparseable values are ignored rather than treated as text.
+type SearchQuery struct {
+	Terms   []string // lowercased words
+	Phrases []string // lowercased exact phrases
+	Project string   // lowercased CWD substring
+	Tool    string
+	After   time.Time
+	Before  time.Time
+}
+
+// ParseSearchQuery parses raw into a SearchQuery; relative after:/before:
+// values are resolved against now.
+func ParseSearchQuery(raw string, now time.Time) SearchQuery {
+	var q SearchQuery
+	rest := raw
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			break
+		}
+		if rest[0] == '"' {
+			end := strings.IndexByte(rest[1:], '"')
+			phrase := rest[1:]
+			if end >= 0 {
+				phrase = rest[1 : end+1]
+				rest = rest[end+2:]
+			} else {
+				rest = ""
+			}
+			if p := strings.Join(strings.Fields(strings.ToLower(phrase)), " "); p != "" {
+				q.Phrases = append(q.Phrases, p)
+			}
+			continue
+		}
+		word := rest
+		if i := strings.IndexFunc(rest, unicode.IsSpace); i >= 0 {
+			word, rest = rest[:i], rest[i:]
+		} else {
+			rest = ""
+		}
+		if key, value, ok := strings.Cut(word, ":"); ok && value != "" {
+			switch strings.ToLower(key) {
+			case "project":
+				q.Project = strings.ToLower(value)
+				continue
+			case "tool":
+				q.Tool = strings.ToLower(value)
+				continue
+			case "after", "since":
+				if t, ok := parseSearchDate(value, now); ok {
+					q.After = t
+				}
+				continue
+			case "before":
+				if t, ok := parseSearchDate(value, now); ok {
+					q.Before = t
+				}
+				continue
+			}
+		}
+		q.Terms = append(q.Terms, searchTokens(strings.ToLower(word))...)
+	}
+	return q
+}
+
+// parseSearchDate accepts YYYY-MM-DD (local midnight), Nd, Nw or a Go
+// duration, the latter three meaning "that long before now".
+func parseSearchDate(value string, now time.Time) (time.Time, bool) {
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, true
+	}
+	if n, ok := strings.CutSuffix(value, "w"); ok {
+		if weeks, err := strconv.Atoi(n); err == nil && weeks > 0 {
+			return now.AddDate(0, 0, -7*weeks), true
+		}
+	}
+	if d, err := ParseArchiveIdleAfter(value); err == nil && d > 0 {
+		return now.Add(-d), true
+	}
+	return time.Time{}, false
+}
+
+// HasText reports whether the query has words or phrases to match, as
+// opposed to filters alone.
+func (q SearchQuery) HasText() bool {
+	return len(q.Terms) > 0 || len(q.Phrases) > 0
+}
+
+func (q SearchQuery) hasFilters() bool {
+	return q.Project != "" || q.Tool != "" || !q.After.IsZero() || !q.Before.IsZero()
+}
+
+// IsPlain reports whether the query is bare words only, the case where a
+// fuzzy fallback makes sense.
+func (q SearchQuery) IsPlain() bool {
+	return len(q.Terms) > 0 && len(q.Phrases) == 0 && !q.hasFilters()
+}
+
+// Highlight returns the text to highlight and build snippets around: the
+// first phrase, else the first word.
+func (q SearchQuery) Highlight() string {
+	if len(q.Phrases) > 0 {
+		return q.Phrases[0]
+	}
+	if len(q.Terms) > 0 {
+		return q.Terms[0]
+	}
+	return ""
+}
+
+// matchesMeta applies the filters to an entry's metadata.
+func (q SearchQuery) matchesMeta(e *SearchEntry) bool {
+	if q.Tool != "" {
+		tool := e.Tool
+		if tool == "" {
+			tool = "claude"
+		}
+		if tool != q.Tool {
+			return false
+		}
+	}
+	if q.Project != "" && !strings.Contains(strings.ToLower(e.CWD), q.Project) {
+		return false
+	}
+	if !q.After.IsZero() && e.ModTime.Before(q.After) {
+		return false
+	}
+	if !q.Before.IsZero() && !e.ModTime.Before(q.Before) {
+		return false
+	}
+	return true
+}
+
+// searchTokens splits lowercased text into words (letters and digits).
+func searchTokens(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+// eachSearchToken calls fn for every word in lowercased content without
+// allocating a slice of them.
+func eachSearchToken(lower []byte, fn func(tok []byte)) {
+	start := -1
+	for i := 0; i < len(lower); {
+		r, size := utf8.DecodeRune(lower[i:])
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if word && start < 0 {
+			start = i
+		} else if !word && start >= 0 {
+			fn(lower[start:i])
+			start = -1
+		}
+		i += size
+	}
+	if start >= 0 {
+		fn(lower[start:])
+	}
+}
+
+// BM25 parameters (the usual defaults).
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+func bm25IDF(docs, df int) float64 {
+	return math.Log(1 + (float64(docs)-float64(df)+0.5)/(float64(df)+0.5))
+}
+
+func bm25Term(tf float64, idf float64, docLen int, avgLen float64) float64 {
+	if tf == 0 {
+		return 0
+	}
+	norm := 1 - bm25B + bm25B*float64(docLen)/math.Max(avgLen, 1)
+	return idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+}
+
+// searchPosting is one document's occurrence count for a word.
+type searchPosting struct {
+	doc int32
+	tf  int32
+}
+
+// invertedIndex maps words to the in-memory entries (TierInstant) that
+// contain them. It is rebuilt lazily when the entries snapshot changes.
+type invertedIndex struct {
+	entries  *[]SearchEntry
+	vocab    []string // sorted, for prefix expansion
+	postings map[string][]searchPosting
+	docLen   []int
+	avgLen   float64
+	docs     int // entries with content
+}
+
+func buildInvertedIndex(entries *[]SearchEntry) *invertedIndex {
+	inv := &invertedIndex{
+		entries:  entries,
+		postings: make(map[string][]searchPosting),
+		docLen:   make([]int, len(*entries)),
+	}
+	var totalLen int
+	counts := make(map[string]int32)
+	for i := range *entries {
+		e := &(*entries)[i]
+		if !e.hasContent() {
+			continue
+		}
+		clear(counts)
+		n := 0
+		e.content.With(func(_, lower []byte) {
+			eachSearchToken(lower, func(tok []byte) {
+				counts[string(tok)]++
+				n++
+			})
+		})
+		for tok, tf := range counts {
+			inv.postings[tok] = append(inv.postings[tok], searchPosting{doc: int32(i), tf: tf})
+		}
+		inv.docLen[i] = n
+		totalLen += n
+		inv.docs++
+	}
+	inv.vocab = make([]string, 0, len(inv.postings))
+	for tok := range inv.postings {
+		inv.vocab = append(inv.vocab, tok)
+	}
+	sort.Strings(inv.vocab)
+	if inv.docs > 0 {
+		inv.avgLen = float64(totalLen) / float64(inv.docs)
+	}
+	return inv
+}
+
+// termFreqs returns doc -> summed tf over every word starting with prefix
+// (exact match only when exact is set).
+func (inv *invertedIndex) termFreqs(prefix string, exact bool) map[int32]int32 {
+	freqs := make(map[int32]int32)
+	if exact {
+		for _, p := range inv.postings[prefix] {
+			freqs[p.doc] += p.tf
+		}
+		return freqs
+	}
+	for i := sort.SearchStrings(inv.vocab, prefix); i < len(inv.vocab) && strings.HasPrefix(inv.vocab[i], prefix); i++ {
+		for _, p := range inv.postings[inv.vocab[i]] {
+			freqs[p.doc] += p.tf
+		}
+	}
+	return freqs
+}
+
+// invertedCache holds the last built invertedIndex.
+type invertedCache struct {
+	mu  sync.Mutex
+	inv *invertedIndex
+}
+
+func (c *invertedCache) get(entries *[]SearchEntry) *invertedIndex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.inv == nil || c.inv.entries != entries {
+		c.inv = buildInvertedIndex(entries)
+	}
+	return c.inv
+}
+
+// queryTerms returns each scoring word with whether it must match exactly:
+// bare words prefix-match, words inside phrases match exactly.
+func (q SearchQuery) queryTerms() (terms []string, exact []bool) {
+	for _, t := range q.Terms {
+		terms = append(terms, t)
+		exact = append(exact, false)
+	}
+	for _, p := range q.Phrases {
+		for _, t := range searchTokens(p) {
+			terms = append(terms, t)
+			exact = append(exact, true)
+		}
+	}
+	return terms, exact
+}
+
+// summaryBoost favours conversations whose summary (first prompt or title)
+// mentions the query.
+func (q SearchQuery) summaryBoost(summary string) float64 {
+	lower := strings.ToLower(summary)
+	boost := 1.0
+	for _, t := range q.Terms {
+		if strings.Contains(lower, t) {
+			boost += 0.25
+		}
+	}
+	for _, p := range q.Phrases {
+		if strings.Contains(lower, p) {
+			boost += 0.5
+		}
+	}
+	return boost
+}
+
+// searchInstant ranks in-memory entries with the inverted index.
+func (idx *GlobalSearchIndex) searchInstant(q SearchQuery, entries *[]SearchEntry) []*SearchResult {
+	if !q.HasText() {
+		return filterOnlyResults(q, entries)
+	}
+	inv := idx.inverted.get(entries)
+	terms, exact := q.queryTerms()
+
+	// AND across words: a document must contain every one.
+	freqs := make([]map[int32]int32, len(terms))
+	var candidates map[int32]bool
+	for i, t := range terms {
+		freqs[i] = inv.termFreqs(t, exact[i])
+		next := make(map[int32]bool, len(freqs[i]))
+		for doc := range freqs[i] {
+			if candidates == nil || candidates[doc] {
+				next[doc] = true
+			}
+		}
+		candidates = next
+		if len(candidates) == 0 {
+			return nil
+		}
+	}
+
+	var results []*SearchResult
+	for doc := range candidates {
+		entry := &(*entries)[doc]
+		if !q.matchesMeta(entry) || !entryHasPhrases(entry, q.Phrases) {
+			continue
+		}
+		score := 0.0
+		for i := range terms {
+			score += bm25Term(float64(freqs[i][doc]), bm25IDF(inv.docs, len(freqs[i])), inv.docLen[doc], inv.avgLen)
+		}
+		score *= q.summaryBoost(entry.Summary)
+		highlight := q.Highlight()
+		results = append(results, &SearchResult{
+			Entry:   entry,
+			Matches: entry.Match(highlight),
+			Score:   int(score * 1000),
+			Snippet: entry.GetSnippet(highlight, 60),
+		})
+	}
+	sortSearchResults(results)
+	return results
+}
+
+func entryHasPhrases(e *SearchEntry, phrases []string) bool {
+	ok := true
+	e.content.With(func(_, lower []byte) {
+		for _, p := range phrases {
+			if !containsPhrase(lower, p) {
+				ok = false
+				return
+			}
+		}
+	})
+	return ok
+}
+
+// containsPhrase matches a whitespace-normalized phrase against content,
+// tolerating line breaks and repeated spaces between its words.
+func containsPhrase(lower []byte, phrase string) bool {
+	if bytes.Contains(lower, []byte(phrase)) {
+		return true
+	}
+	words := strings.Fields(phrase)
+	if len(words) < 2 {
+		return false
+	}
+	first := []byte(words[0])
+	for start := 0; ; {
+		i := bytes.Index(lower[start:], first)
+		if i < 0 {
+			return false
+		}
+		pos := start + i + len(first)
+		matched := true
+		for _, w := range words[1:] {
+			j := pos
+			for j < len(lower) && (lower[j] == ' ' || lower[j] == '\n' || lower[j] == '\t' || lower[j] == '\r') {
+				j++
+			}
+			if j == pos || !bytes.HasPrefix(lower[j:], []byte(w)) {
+				matched = false
+				break
+			}
+			pos = j + len(w)
+		}
+		if matched {
+			return true
+		}
+		start += i + 1
+	}
+}
+
+// filterOnlyResults lists every entry passing the filters, newest first,
+// for queries like "tool:gemini after:7d".
+func filterOnlyResults(q SearchQuery, entries *[]SearchEntry) []*SearchResult {
+	var results []*SearchResult
+	for i := range *entries {
+		entry := &(*entries)[i]
+		if q.matchesMeta(entry) {
+			results = append(results, &SearchResult{Entry: entry, Score: 1, Snippet: entry.Summary})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Entry.ModTime.After(results[j].Entry.ModTime) })
+	return results
+}
+
+// sortSearchResults orders by score, newest first on ties.
+func sortSearchResults(results []*SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Entry.ModTime.After(results[j].Entry.ModTime)
+	})
+}
+
+// diskHit is one on-disk conversation (TierBalanced) matching every word
+// and phrase, with per-word counts for ranking.
+type diskHit struct {
+	entry   *SearchEntry
+	tfs     []int
+	docLen  int
+	snippet string
+}
+
+// scoreText counts query words in one conversation's text.
+func scoreText(entry *SearchEntry, content string, q SearchQuery, terms []string, exact []bool) (diskHit, bool) {
+	lower := []byte(strings.ToLower(content))
+	for _, p := range q.Phrases {
+		if !containsPhrase(lower, p) {
+			return diskHit{}, false
+		}
+	}
+	hit := diskHit{entry: entry, tfs: make([]int, len(terms))}
+	eachSearchToken(lower, func(tok []byte) {
+		hit.docLen++
+		for i, t := range terms {
+			if exact[i] && string(tok) == t || !exact[i] && bytes.HasPrefix(tok, []byte(t)) {
+				hit.tfs[i]++
+			}
+		}
+	})
+	for _, tf := range hit.tfs {
+		if tf == 0 {
+			return diskHit{}, false
+		}
+	}
+	hit.snippet = snippetFromText(content, q.Highlight(), 60)
+	return hit, true
+}
@@ -0,0 +1,134 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	q := ParseSearchQuery(`Webhook "Connection   Refused" project:API tool:gemini after:7d before:2026-03-09 foo:bar`, now)
+
+	if len(q.Terms) != 3 || q.Terms[0] != "webhook" || q.Terms[1] != "foo" || q.Terms[2] != "bar" {
+		t.Errorf("Terms = %q (unknown key:value should be plain words)", q.Terms)
+	}
+	if len(q.Phrases) != 1 || q.Phrases[0] != "connection refused" {
+		t.Errorf("Phrases = %q", q.Phrases)
+	}
+	if q.Project != "api" || q.Tool != "gemini" {
+		t.Errorf("Project/Tool = %q/%q", q.Project, q.Tool)
+	}
+	if !q.After.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("After = %v", q.After)
+	}
+	if !q.Before.Equal(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Before = %v", q.Before)
+	}
+	if q.Highlight() != "connection refused" || q.IsPlain() {
+		t.Errorf("Highlight = %q, IsPlain = %v", q.Highlight(), q.IsPlain())
+	}
+
+	if q := ParseSearchQuery(`after:soon "unterminated phrase`, now); !q.After.IsZero() || len(q.Phrases) != 1 || q.Phrases[0] != "unterminated phrase" {
+		t.Errorf("bad date should be ignored and open quote should run to the end: %+v", q)
+	}
+	if q := ParseSearchQuery("after:2w", now); !q.After.Equal(now.AddDate(0, 0, -14)) || q.HasText() {
+		t.Errorf("after:2w = %+v", q)
+	}
+	if !ParseSearchQuery("react hooks", now).IsPlain() {
+		t.Error("bare words should be a plain query")
+	}
+}
+
+func TestContainsPhrase(t *testing.T) {
+	if !containsPhrase([]byte("got connection\n  refused again"), "connection refused") {
+		t.Error("phrase should match across a line break")
+	}
+	if containsPhrase([]byte("connection was refused"), "connection refused") {
+		t.Error("phrase words must be adjacent")
+	}
+}
+
+// writeSearchCorpus writes one Claude transcript per entry of convs
+// (cwd -> message), with increasing modification times.
+func writeSearchCorpus(t *testing.T, convs [][2]string) string {
+	t.Helper()
+	claudeDir := t.TempDir()
+	base := time.Now().Add(-time.Duration(len(convs)) * time.Hour)
+	for i, c := range convs {
+		projectDir := filepath.Join(claudeDir, "projects", fmt.Sprintf("-p%d", i))
+		if err := os.MkdirAll(projectDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		id := fmt.Sprintf("00000000-0000-0000-0000-%012d", i)
+		path := filepath.Join(projectDir, id+".jsonl")
+		line := fmt.Sprintf(`{"sessionId":%q,"type":"user","cwd":%q,"message":{"role":"user","content":%q}}`, id, c[0], c[1])
+		if err := os.WriteFile(path, []byte(line+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mod := base.Add(time.Duration(i) * time.Hour)
+		_ = os.Chtimes(path, mod, mod)
+	}
+	return claudeDir
+}
+
+func TestGlobalSearchIndex_RankedQueries(t *testing.T) {
+	convs := [][2]string{
+		{"/home/me/api", "the webhook failed once, retry later"},
+		{"/home/me/api", "webhook webhook webhook: retrying the webhook delivery"},
+		{"/home/me/web", "retry the webhook after connection refused"},
+		{"/home/me/web", "refused the connection to the webhook"},
+		{"/home/me/docs", "nothing relevant here"},
+	}
+	for _, tier := range []string{"instant", "balanced"} {
+		t.Run(tier, func(t *testing.T) {
+			cfg := GlobalSearchSettings{Enabled: boolPtr(true), Tier: tier, MemoryLimitMB: 100, IndexRateLimit: 100}
+			index, err := NewGlobalSearchIndexWithSources(writeSearchCorpus(t, convs), cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer index.Close()
+			for i := 0; i < 100 && index.IsLoading(); i++ {
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			cwds := func(results []*SearchResult) []string {
+				var out []string
+				for _, r := range results {
+					out = append(out, r.Entry.CWD)
+				}
+				return out
+			}
+
+			// Words are ANDed and prefix-matched ("retry" finds "retrying").
+			r := index.Search("webhook retry")
+			if len(r) != 3 {
+				t.Fatalf("webhook retry = %v, want 3", cwds(r))
+			}
+			if r[0].Entry.SessionID != "00000000-0000-0000-0000-000000000001" {
+				t.Errorf("densest match should rank first, got %v", cwds(r))
+			}
+
+			r = index.Search(`"connection refused"`)
+			if len(r) != 1 || r[0].Entry.CWD != "/home/me/web" || r[0].Snippet == "" {
+				t.Errorf("phrase = %v, want only the adjacent-words conversation", cwds(r))
+			}
+
+			if r := index.Search("webhook project:api"); len(r) != 2 {
+				t.Errorf("project filter = %v, want 2", cwds(r))
+			}
+			if r := index.Search("webhook tool:gemini"); len(r) != 0 {
+				t.Errorf("tool filter = %v, want none", cwds(r))
+			}
+			if r := index.Search("webhook after:150m"); len(r) != 1 {
+				t.Errorf("after filter = %v, want only the newest webhook conversation", cwds(r))
+			}
+			r = index.Search("project:web")
+			if len(r) != 2 || !r[0].Entry.ModTime.After(r[1].Entry.ModTime) {
+				t.Errorf("filter-only query = %v, want 2 newest first", cwds(r))
+			}
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConversationSource is a tool whose stored conversations the global search
+// index can read besides Claude. Claude's JSONL projects are indexed natively
+// (append-aware, fsnotify-driven); source conversations are re-parsed whole
+// when their file changes, picked up by a periodic rescan.
+type ConversationSource interface {
+	// Tool is the agent-deck tool name, used for result badges and resume.
+	Tool() string
+	// List returns the conversation files modified at or after cutoff
+	// (zero = all).
+	List(cutoff time.Time) []ConversationFile
+	// Parse reads one conversation: ID, working directory, summary and the
+	// message text as content.
+	Parse(path string) (*SearchEntry, error)
+}
+
+// ConversationFile is one stored conversation reported by a source.
+type ConversationFile struct {
+	Path    string
+	ModTime time.Time
+	Size    int64
+}
+
+// DefaultConversationSources returns the Gemini and OpenCode sources rooted
+// at their default storage locations.
+func DefaultConversationSources() []ConversationSource {
+	return []ConversationSource{
+		GeminiConversationSource{ConfigDir: GetGeminiConfigDir()},
+		OpenCodeConversationSource{StorageDir: GetOpenCodeStorageDir()},
+	}
+}
+
+// searchSummary trims a first user message to the summary length used for
+// Claude entries.
+func searchSummary(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > 200 {
+		return s[:200] + "..."
+	}
+	return s
+}
+
+// GeminiConversationSource reads Gemini CLI chats from
+// <ConfigDir>/tmp/<project_hash>/chats/session-*.json.
+type GeminiConversationSource struct {
+	ConfigDir string
+}
+
+func (GeminiConversationSource) Tool() string { return "gemini" }
+
+func (s GeminiConversationSource) List(cutoff time.Time) []ConversationFile {
+	paths, _ := filepath.Glob(filepath.Join(s.ConfigDir, "tmp", "*", "chats", "session-*.json"))
+	return statConversationFiles(paths, cutoff)
+}
+
+func (GeminiConversationSource) Parse(path string) (*SearchEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var chat struct {
+		SessionID string `json:"sessionId"`
+		Messages  []struct {
+			Type    string          `json:"type"`
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &chat); err != nil {
+		return nil, err
+	}
+
+	entry := &SearchEntry{SessionID: chat.SessionID, FilePath: path, Tool: "gemini"}
+	// Newer Gemini CLI versions record the project root next to chats/; the
+	// directory name itself is a one-way hash of the path.
+	if root, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(path)), ".project_root")); err == nil {
+		entry.CWD = strings.TrimSpace(string(root))
+	}
+
+	var content strings.Builder
+	for _, m := range chat.Messages {
+		text := extractContentText(m.Content)
+		if text == "" {
+			continue
+		}
+		switch m.Type {
+		case "user":
+			if entry.Summary == "" {
+				entry.Summary = searchSummary(text)
+			}
+			content.WriteString("User: ")
+		case "gemini":
+			content.WriteString("Assistant: ")
+		default:
+			continue
+		}
+		content.WriteString(text)
+		content.WriteString("\n")
+	}
+	if content.Len() > 0 {
+		entry.setContent([]byte(content.String()))
+	}
+	return entry, nil
+}
+
+// OpenCodeConversationSource reads OpenCode sessions from StorageDir (see
+// GetOpenCodeStorageDir): session/<project>/<id>.json for metadata, and
+// message/<id>/ plus part/<message>/ for the text.
+type OpenCodeConversationSource struct {
+	StorageDir string
+}
+
+func (OpenCodeConversationSource) Tool() string { return "opencode" }
+
+// List reports each session's info file. New messages do not rewrite the
+// info file, so ModTime is the newer of it and the session's message dir.
+func (s OpenCodeConversationSource) List(cutoff time.Time) []ConversationFile {
+	paths, _ := filepath.Glob(filepath.Join(s.StorageDir, "session", "*", "*.json"))
+	files := statConversationFiles(paths, time.Time{})
+	out := files[:0]
+	for _, f := range files {
+		id := strings.TrimSuffix(filepath.Base(f.Path), ".json")
+		if info, err := os.Stat(filepath.Join(s.StorageDir, "message", id)); err == nil && info.ModTime().After(f.ModTime) {
+			f.ModTime = info.ModTime()
+		}
+		if !cutoff.IsZero() && f.ModTime.Before(cutoff) {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func (s OpenCodeConversationSource) Parse(path string) (*SearchEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var info struct {
+		ID        string `json:"id"`
+		Title     string `json:"title"`
+		Directory string `json:"directory"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	entry := &SearchEntry{SessionID: info.ID, FilePath: path, CWD: info.Directory, Summary: searchSummary(info.Title), Tool: "opencode"}
+
+	msgFiles, _ := filepath.Glob(filepath.Join(s.StorageDir, "message", info.ID, "*.json"))
+	messages := make([]openCodeMessage, 0, len(msgFiles))
+	for _, f := range msgFiles {
+		raw, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		var m openCodeMessage
+		if json.Unmarshal(raw, &m) == nil && m.ID != "" {
+			messages = append(messages, m)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Time.Created < messages[j].Time.Created })
+
+	var content strings.Builder
+	for _, m := range messages {
+		text := openCodeMessageText(s.StorageDir, m.ID)
+		if text == "" {
+			continue
+		}
+		switch m.Role {
+		case "user":
+			if entry.Summary == "" {
+				entry.Summary = searchSummary(text)
+			}
+			content.WriteString("User: ")
+		case "assistant":
+			content.WriteString("Assistant: ")
+		}
+		content.WriteString(text)
+		content.WriteString("\n")
+	}
+	if content.Len() > 0 {
+		entry.setContent([]byte(content.String()))
+	}
+	return entry, nil
+}
+
+// openCodeMessageText joins the text parts of one OpenCode message.
+func openCodeMessageText(storageDir, messageID string) string {
+	partFiles, _ := filepath.Glob(filepath.Join(storageDir, "part", messageID, "*.json"))
+	sort.Strings(partFiles) // part IDs are time-ordered
+	var texts []string
+	for _, f := range partFiles {
+		raw, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		var p struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		}
+		if json.Unmarshal(raw, &p) == nil && p.Type == "text" && strings.TrimSpace(p.Text) != "" {
+			texts = append(texts, p.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func statConversationFiles(paths []string, cutoff time.Time) []ConversationFile {
+	files := make([]ConversationFile, 0, len(paths))
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if !cutoff.IsZero() && info.ModTime().Before(cutoff) {
+			continue
+		}
+		files = append(files, ConversationFile{Path: p, ModTime: info.ModTime(), Size: info.Size()})
+	}
+	return files
+}
+
+// sourceRescanInterval is how often source conversations are re-listed for
+// changes; sources have no fsnotify watch (one dir per project adds up).
+var sourceRescanInterval = 30 * time.Second
+
+func (idx *GlobalSearchIndex) sourceFor(tool string) ConversationSource {
+	for _, src := range idx.sources {
+		if src.Tool() == tool {
+			return src
+		}
+	}
+	return nil
+}
+
+func (idx *GlobalSearchIndex) sourceCutoff() time.Time {
+	if idx.config.RecentDays > 0 {
+		return time.Now().AddDate(0, 0, -idx.config.RecentDays)
+	}
+	return time.Time{}
+}
+
+// measureSourceSize sums the conversation files of every source, for tier
+// detection alongside measureDataSize.
+func (idx *GlobalSearchIndex) measureSourceSize() int64 {
+	var total int64
+	cutoff := idx.sourceCutoff()
+	for _, src := range idx.sources {
+		for _, f := range src.List(cutoff) {
+			total += f.Size
+		}
+	}
+	return total
+}
+
+// parseSourceFile parses f with src and stamps the index bookkeeping fields.
+// Content is dropped in metadata-only mode (TierBalanced).
+func parseSourceFile(src ConversationSource, f ConversationFile, includeContent bool) *SearchEntry {
+	entry, err := src.Parse(f.Path)
+	if err != nil || entry == nil || entry.SessionID == "" {
+		return nil
+	}
+	entry.Tool = src.Tool()
+	entry.ModTime = f.ModTime
+	entry.FileSize = f.Size
+	if !includeContent {
+		entry.content = nil
+	}
+	return entry
+}
+
+// loadSourceEntries parses every source conversation for initialLoad.
+func (idx *GlobalSearchIndex) loadSourceEntries(cutoff time.Time, includeContent bool) []SearchEntry {
+	var entries []SearchEntry
+	for _, src := range idx.sources {
+		for _, f := range src.List(cutoff) {
+			if idx.ctx.Err() != nil {
+				return entries
+			}
+			entry := parseSourceFile(src, f, includeContent)
+			if entry == nil {
+				continue
+			}
+			if entry.hasContent() {
+				idx.currentMemoryBytes.Add(entry.content.Size())
+			}
+			entries = append(entries, *entry)
+			idx.trackSourceFile(f)
+		}
+	}
+	return entries
+}
+
+func (idx *GlobalSearchIndex) trackSourceFile(f ConversationFile) {
+	idx.trackerMu.Lock()
+	idx.fileTrackers[f.Path] = &FileTracker{Path: f.Path, LastOffset: f.Size, LastSize: f.Size, LastMod: f.ModTime}
+	idx.trackerMu.Unlock()
+}
+
+// sourceRescanLoop re-lists sources periodically and re-parses conversations
+// whose file changed since they were indexed.
+func (idx *GlobalSearchIndex) sourceRescanLoop() {
+	defer idx.wg.Done()
+	if len(idx.sources) == 0 {
+		return
+	}
+	ticker := time.NewTicker(sourceRescanInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-idx.ctx.Done():
+			return
+		case <-ticker.C:
+			if !idx.loading.Load() {
+				idx.rescanSources()
+			}
+		}
+	}
+}
+
+func (idx *GlobalSearchIndex) rescanSources() {
+	includeContent := idx.tier == TierInstant
+	for _, src := range idx.sources {
+		for _, f := range src.List(idx.sourceCutoff()) {
+			idx.trackerMu.RLock()
+			tracker := idx.fileTrackers[f.Path]
+			idx.trackerMu.RUnlock()
+			if tracker != nil && tracker.LastMod.Equal(f.ModTime) && tracker.LastSize == f.Size {
+				continue
+			}
+			if entry := parseSourceFile(src, f, includeContent); entry != nil {
+				idx.replaceEntry(*entry)
+			}
+			idx.trackSourceFile(f)
+		}
+	}
+	if idx.currentMemoryBytes.Load() > idx.memoryLimitBytes {
+		idx.evictOldestEntries()
+	}
+	idx.resetQueryCache()
+}
+
+// replaceEntry swaps in entry for the one with the same FilePath, or appends
+// it, keeping the content memory accounting in step.
+func (idx *GlobalSearchIndex) replaceEntry(entry SearchEntry) {
+	old := idx.entries.Load()
+	next := make([]SearchEntry, 0, len(*old)+1)
+	found := false
+	for _, e := range *old {
+		if e.FilePath == entry.FilePath {
+			if e.hasContent() {
+				idx.currentMemoryBytes.Add(-e.content.Size())
+			}
+			next = append(next, entry)
+			found = true
+			continue
+		}
+		next = append(next, e)
+	}
+	if !found {
+		next = append(next, entry)
+	}
+	if entry.hasContent() {
+		idx.currentMemoryBytes.Add(entry.content.Size())
+	}
+	idx.entries.Store(&next)
+}
+
+// loadSourceSearchText is loadClaudeSearchText for source conversations.
+func loadSourceSearchText(src ConversationSource, path string) string {
+	entry, err := src.Parse(path)
+	if err != nil || entry == nil {
+		return ""
+	}
+	return entry.ContentString()
+}
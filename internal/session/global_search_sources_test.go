@@ -0,0 +1,154 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeGeminiChatFixture(t *testing.T, configDir string) {
+	t.Helper()
+	projectDir := filepath.Join(configDir, "tmp", "abc123hash")
+	writeOpenCodeFixture(t, projectDir, ".project_root", "/home/me/webapp\n")
+	writeOpenCodeFixture(t, projectDir, "chats/session-2026-03-01T10-00-4d8fcb4d.json", `{
+		"sessionId": "4d8fcb4d-1111-2222-3333-444455556666",
+		"messages": [
+			{"type": "user", "content": "why does the kubernetes ingress return 502"},
+			{"type": "gemini", "content": [{"text": "The backend service port does not match."}]},
+			{"type": "info", "content": "ignored"}
+		]
+	}`)
+}
+
+func writeOpenCodeSearchFixture(t *testing.T, storageDir string) {
+	t.Helper()
+	writeOpenCodeFixture(t, storageDir, "session/proj1/ses_abc.json",
+		`{"id":"ses_abc","title":"Refactor billing","directory":"/home/me/billing"}`)
+	writeOpenCodeFixture(t, storageDir, "message/ses_abc/msg_1.json",
+		`{"id":"msg_1","role":"user","time":{"created":1700000000000}}`)
+	writeOpenCodeFixture(t, storageDir, "message/ses_abc/msg_2.json",
+		`{"id":"msg_2","role":"assistant","time":{"created":1700000001000}}`)
+	writeOpenCodeFixture(t, storageDir, "part/msg_1/prt_1.json", `{"type":"text","text":"split the stripe webhook handler"}`)
+	writeOpenCodeFixture(t, storageDir, "part/msg_2/prt_2.json", `{"type":"tool","tool":"edit"}`)
+	writeOpenCodeFixture(t, storageDir, "part/msg_2/prt_3.json", `{"type":"text","text":"Moved it into webhooks.go"}`)
+}
+
+func TestGeminiConversationSource(t *testing.T) {
+	dir := t.TempDir()
+	writeGeminiChatFixture(t, dir)
+	src := GeminiConversationSource{ConfigDir: dir}
+
+	files := src.List(time.Time{})
+	if len(files) != 1 {
+		t.Fatalf("List = %+v, want 1 chat", files)
+	}
+	entry, err := src.Parse(files[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.SessionID != "4d8fcb4d-1111-2222-3333-444455556666" || entry.Tool != "gemini" || entry.CWD != "/home/me/webapp" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if !strings.HasPrefix(entry.Summary, "why does the kubernetes") {
+		t.Errorf("Summary = %q", entry.Summary)
+	}
+	content := entry.ContentString()
+	if !strings.Contains(content, "Assistant: The backend service port") || strings.Contains(content, "ignored") {
+		t.Errorf("content = %q", content)
+	}
+	if got := src.List(time.Now().Add(time.Hour)); len(got) != 0 {
+		t.Errorf("cutoff in the future should list nothing, got %d", len(got))
+	}
+}
+
+func TestOpenCodeConversationSource(t *testing.T) {
+	dir := t.TempDir()
+	writeOpenCodeSearchFixture(t, dir)
+	src := OpenCodeConversationSource{StorageDir: dir}
+
+	files := src.List(time.Time{})
+	if len(files) != 1 {
+		t.Fatalf("List = %+v, want 1 session", files)
+	}
+	entry, err := src.Parse(files[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.SessionID != "ses_abc" || entry.CWD != "/home/me/billing" || entry.Summary != "Refactor billing" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if content := entry.ContentString(); content != "User: split the stripe webhook handler\nAssistant: Moved it into webhooks.go\n" {
+		t.Errorf("content = %q", content)
+	}
+}
+
+func TestGlobalSearchIndex_Sources(t *testing.T) {
+	for _, tier := range []string{"instant", "balanced"} {
+		t.Run(tier, func(t *testing.T) {
+			claudeDir := t.TempDir()
+			projectDir := filepath.Join(claudeDir, "projects", "-home-me-api")
+			_ = os.MkdirAll(projectDir, 0o755)
+			_ = os.WriteFile(filepath.Join(projectDir, "a1b2c3d4-e5f6-7890-abcd-ef1234567890.jsonl"),
+				[]byte(`{"sessionId":"a1b2c3d4-e5f6-7890-abcd-ef1234567890","type":"user","cwd":"/home/me/api","message":{"role":"user","content":"add a webhook retry queue"}}`), 0o644)
+			geminiDir, openCodeDir := t.TempDir(), t.TempDir()
+			writeGeminiChatFixture(t, geminiDir)
+			writeOpenCodeSearchFixture(t, openCodeDir)
+
+			cfg := GlobalSearchSettings{Enabled: boolPtr(true), Tier: tier, MemoryLimitMB: 100, IndexRateLimit: 100}
+			index, err := NewGlobalSearchIndexWithSources(claudeDir, cfg,
+				GeminiConversationSource{ConfigDir: geminiDir}, OpenCodeConversationSource{StorageDir: openCodeDir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer index.Close()
+			for i := 0; i < 100 && index.IsLoading(); i++ {
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			if index.EntryCount() != 3 {
+				t.Fatalf("EntryCount = %d, want 3 (claude + gemini + opencode)", index.EntryCount())
+			}
+			tools := map[string]bool{}
+			for _, r := range index.Search("webhook") {
+				tools[r.Entry.Tool] = true
+				if r.Snippet == "" {
+					t.Errorf("%s result has no snippet", r.Entry.Tool)
+				}
+			}
+			if !tools["claude"] || !tools["opencode"] || tools["gemini"] {
+				t.Errorf("webhook matched tools %v, want claude and opencode", tools)
+			}
+			if r := index.Search("kubernetes ingress"); len(r) != 1 || r[0].Entry.Tool != "gemini" || r[0].Entry.SessionID != "4d8fcb4d-1111-2222-3333-444455556666" {
+				t.Errorf("gemini search = %+v", r)
+			}
+		})
+	}
+}
+
+func TestGlobalSearchIndex_RescanSources(t *testing.T) {
+	geminiDir := t.TempDir()
+	cfg := GlobalSearchSettings{Enabled: boolPtr(true), Tier: "instant", MemoryLimitMB: 100, IndexRateLimit: 100}
+	index, err := NewGlobalSearchIndexWithSources(t.TempDir(), cfg, GeminiConversationSource{ConfigDir: geminiDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer index.Close()
+	for i := 0; i < 100 && index.IsLoading(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if index.EntryCount() != 0 {
+		t.Fatalf("EntryCount = %d before any chats exist", index.EntryCount())
+	}
+
+	writeGeminiChatFixture(t, geminiDir)
+	index.rescanSources()
+	if r := index.Search("ingress"); len(r) != 1 {
+		t.Errorf("new chat should be searchable after a rescan, got %d results", len(r))
+	}
+	index.rescanSources() // unchanged file: no duplicate entry
+	if index.EntryCount() != 1 {
+		t.Errorf("EntryCount = %d after second rescan, want 1", index.EntryCount())
+	}
+}
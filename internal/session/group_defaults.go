@@ -0,0 +1,109 @@
+package session
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// GroupSessionDefaults pre-populate sessions created in a group, both in the
+// TUI new-session dialog and for `agent-deck add -g`. They sit below explicit
+// flags and templates: they only fill what neither set. Set with
+// `agent-deck group update --default-tool/--default-model/...`.
+type GroupSessionDefaults struct {
+	// Tool is a built-in tool ("claude", "codex", ...) or a [tools.X] name.
+	Tool string `json:"tool,omitempty"`
+	// DangerousMode sets Claude's dangerous mode and, for gemini/codex/hermes,
+	// YOLO mode. nil => inherit the tool's config default.
+	DangerousMode *bool `json:"dangerous_mode,omitempty"`
+	// MCPs lists [mcps.X] catalog names attached to new sessions.
+	MCPs []string `json:"mcps,omitempty"`
+	// Model is the per-session model override. Models are tool-specific, so it
+	// only applies to sessions running Tool.
+	Model string `json:"model,omitempty"`
+}
+
+// IsZero reports whether no default is set. encoding/json's omitzero uses it.
+func (d GroupSessionDefaults) IsZero() bool {
+	return d.Tool == "" && d.DangerousMode == nil && len(d.MCPs) == 0 && d.Model == ""
+}
+
+// Template returns the defaults as a session template, so they apply through
+// the same code paths as [templates.<name>]. Model is dropped when no Tool is
+// set, since it could not be matched to a tool.
+func (d GroupSessionDefaults) Template() SessionTemplate {
+	model := d.Model
+	if d.Tool == "" {
+		model = ""
+	}
+	return SessionTemplate{
+		Tool:          d.Tool,
+		Model:         model,
+		MCPs:          d.MCPs,
+		DangerousMode: d.DangerousMode,
+		Yolo:          d.DangerousMode,
+	}
+}
+
+// marshal encodes the defaults for the groups.session_defaults column; ""
+// when none are set.
+func (d GroupSessionDefaults) marshal() string {
+	if d.IsZero() {
+		return ""
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// parseGroupSessionDefaults decodes the groups.session_defaults column.
+// Empty or malformed values yield no defaults.
+func parseGroupSessionDefaults(raw string) GroupSessionDefaults {
+	var d GroupSessionDefaults
+	if strings.TrimSpace(raw) == "" {
+		return d
+	}
+	if err := json.Unmarshal([]byte(raw), &d); err != nil {
+		return GroupSessionDefaults{}
+	}
+	return d
+}
+
+// SessionDefaultsForGroup returns the new-session defaults for a group. Each
+// field unset on the group is taken from the nearest ancestor that sets it, so
+// a subgroup only needs to override what differs from its parent.
+func (t *GroupTree) SessionDefaultsForGroup(groupPath string) GroupSessionDefaults {
+	var d GroupSessionDefaults
+	for path := groupPath; path != ""; path = getParentPath(path) {
+		group, ok := t.Groups[path]
+		if !ok {
+			continue
+		}
+		g := group.SessionDefaults
+		if d.Tool == "" {
+			d.Tool = g.Tool
+		}
+		if d.DangerousMode == nil {
+			d.DangerousMode = g.DangerousMode
+		}
+		if len(d.MCPs) == 0 {
+			d.MCPs = g.MCPs
+		}
+		if d.Model == "" {
+			d.Model = g.Model
+		}
+	}
+	return d
+}
+
+// SetSessionDefaultsForGroup replaces a group's own new-session defaults.
+// Returns false when the group does not exist.
+func (t *GroupTree) SetSessionDefaultsForGroup(groupPath string, d GroupSessionDefaults) bool {
+	group, ok := t.Groups[groupPath]
+	if !ok {
+		return false
+	}
+	group.SessionDefaults = d
+	return true
+}
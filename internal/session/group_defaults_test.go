@@ -0,0 +1,70 @@
+package session
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSessionDefaultsForGroup_InheritsFromAncestors(t *testing.T) {
+	tree := NewGroupTree(nil)
+	tree.CreateGroup("work")
+	tree.CreateSubgroup("work", "api")
+	on := true
+	tree.SetSessionDefaultsForGroup("work", GroupSessionDefaults{
+		Tool:          "claude",
+		Model:         "opus",
+		MCPs:          []string{"github"},
+		DangerousMode: &on,
+	})
+	tree.SetSessionDefaultsForGroup("work/api", GroupSessionDefaults{Model: "sonnet"})
+
+	got := tree.SessionDefaultsForGroup("work/api")
+	if got.Tool != "claude" || got.Model != "sonnet" {
+		t.Fatalf("tool/model = %q/%q, want claude/sonnet", got.Tool, got.Model)
+	}
+	if !reflect.DeepEqual(got.MCPs, []string{"github"}) || got.DangerousMode == nil || !*got.DangerousMode {
+		t.Fatalf("inherited MCPs/dangerous mode = %v/%v", got.MCPs, got.DangerousMode)
+	}
+	if d := tree.SessionDefaultsForGroup("personal"); !d.IsZero() {
+		t.Fatalf("unknown group defaults = %+v, want zero", d)
+	}
+	if tree.SetSessionDefaultsForGroup("missing", GroupSessionDefaults{Tool: "codex"}) {
+		t.Fatal("SetSessionDefaultsForGroup on a missing group should return false")
+	}
+}
+
+func TestGroupSessionDefaults_MarshalRoundTrip(t *testing.T) {
+	off := false
+	d := GroupSessionDefaults{Tool: "gemini", MCPs: []string{"memory"}, DangerousMode: &off}
+	got := parseGroupSessionDefaults(d.marshal())
+	if !reflect.DeepEqual(got, d) {
+		t.Fatalf("round trip = %+v, want %+v", got, d)
+	}
+	if (GroupSessionDefaults{}).marshal() != "" {
+		t.Fatal("zero defaults should marshal to an empty column")
+	}
+	if !parseGroupSessionDefaults("{not json").IsZero() {
+		t.Fatal("malformed column should yield no defaults")
+	}
+}
+
+func TestGroupSessionDefaults_TemplateDropsModelWithoutTool(t *testing.T) {
+	if m := (GroupSessionDefaults{Model: "opus"}).Template().Model; m != "" {
+		t.Fatalf("model = %q, want dropped without a tool", m)
+	}
+	if m := (GroupSessionDefaults{Tool: "claude", Model: "opus"}).Template().Model; m != "opus" {
+		t.Fatalf("model = %q, want opus", m)
+	}
+}
+
+func TestGroupData_OmitsEmptySessionDefaults(t *testing.T) {
+	data, err := json.Marshal(GroupData{Name: "work", Path: "work"})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if strings.Contains(string(data), "session_defaults") {
+		t.Fatalf("JSON = %s, want no session_defaults key", data)
+	}
+}
@@ -0,0 +1,85 @@
+package session
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GroupRule is one [[group_rules]] entry: sessions created from the CLI or
+// imported without an explicit group are placed in Group when every
+// condition the rule sets matches. A rule with no conditions never matches.
+//
+//	[[group_rules]]
+//	group = "work/api"
+//	path  = "~/work/api/**"
+//	tool  = "claude"
+//	title = "^review-"
+type GroupRule struct {
+	// Group is the group path to assign.
+	Group string `toml:"group"`
+	// Path is a glob matched against the session's project path. "~" and
+	// environment variables are expanded; a trailing "/**" matches the
+	// directory itself and everything below it.
+	Path string `toml:"path,omitempty"`
+	// Tool matches the session's tool exactly (e.g. "claude", "shell").
+	Tool string `toml:"tool,omitempty"`
+	// Title is a regular expression matched against the session title.
+	Title string `toml:"title,omitempty"`
+}
+
+// Matches reports whether the rule applies to a session with the given
+// project path, tool and title. Malformed globs or regexps never match.
+func (r GroupRule) Matches(projectPath, tool, title string) bool {
+	if r.Group == "" || (r.Path == "" && r.Tool == "" && r.Title == "") {
+		return false
+	}
+	if r.Tool != "" && !strings.EqualFold(r.Tool, tool) {
+		return false
+	}
+	if r.Path != "" && !matchRulePath(r.Path, projectPath) {
+		return false
+	}
+	if r.Title != "" {
+		re, err := regexp.Compile(r.Title)
+		if err != nil || !re.MatchString(title) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchRulePath(pattern, projectPath string) bool {
+	if projectPath == "" {
+		return false
+	}
+	pattern = filepath.Clean(ExpandPath(pattern))
+	projectPath = filepath.Clean(projectPath)
+	if prefix, ok := strings.CutSuffix(pattern, string(filepath.Separator)+"**"); ok {
+		// Match the prefix itself or any descendant; the prefix may be a glob.
+		for p := projectPath; ; p = filepath.Dir(p) {
+			if ok, _ := filepath.Match(prefix, p); ok {
+				return true
+			}
+			if parent := filepath.Dir(p); parent == p {
+				return false
+			}
+		}
+	}
+	ok, _ := filepath.Match(pattern, projectPath)
+	return ok
+}
+
+// MatchGroupRule returns the canonical group path of the first
+// [[group_rules]] entry that matches, or "" when none does.
+func (c *UserConfig) MatchGroupRule(projectPath, tool, title string) string {
+	if c == nil {
+		return ""
+	}
+	for _, rule := range c.GroupRules {
+		if rule.Matches(projectPath, tool, title) {
+			return canonicalGroupPath(rule.Group)
+		}
+	}
+	return ""
+}
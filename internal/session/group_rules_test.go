@@ -0,0 +1,60 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGroupRuleMatches(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home dir")
+	}
+	api := filepath.Join(home, "work", "api")
+
+	cases := []struct {
+		name  string
+		rule  GroupRule
+		path  string
+		tool  string
+		title string
+		want  bool
+	}{
+		{"subtree includes root", GroupRule{Group: "g", Path: "~/work/api/**"}, api, "shell", "x", true},
+		{"subtree includes descendants", GroupRule{Group: "g", Path: "~/work/api/**"}, filepath.Join(api, "cmd", "srv"), "shell", "x", true},
+		{"subtree excludes siblings", GroupRule{Group: "g", Path: "~/work/api/**"}, filepath.Join(home, "work", "apix"), "shell", "x", false},
+		{"glob child", GroupRule{Group: "g", Path: "~/work/*"}, api, "shell", "x", true},
+		{"glob is not recursive", GroupRule{Group: "g", Path: "~/work/*"}, filepath.Join(api, "cmd"), "shell", "x", false},
+		{"tool only", GroupRule{Group: "g", Tool: "claude"}, "/p", "Claude", "x", true},
+		{"all conditions must match", GroupRule{Group: "g", Tool: "claude", Title: "^review-"}, "/p", "claude", "fix-bug", false},
+		{"title regexp", GroupRule{Group: "g", Title: "^review-"}, "/p", "claude", "review-auth", true},
+		{"bad regexp never matches", GroupRule{Group: "g", Title: "("}, "/p", "claude", "(", false},
+		{"no conditions never matches", GroupRule{Group: "g"}, "/p", "claude", "x", false},
+	}
+	for _, tc := range cases {
+		if got := tc.rule.Matches(tc.path, tc.tool, tc.title); got != tc.want {
+			t.Errorf("%s: Matches = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestMatchGroupRuleFirstWins(t *testing.T) {
+	cfg := &UserConfig{GroupRules: []GroupRule{
+		{Group: "Reviews", Title: "^review-"},
+		{Group: "agents/claude", Tool: "claude"},
+	}}
+	if got := cfg.MatchGroupRule("/p", "claude", "review-api"); got != "Reviews" {
+		t.Errorf("MatchGroupRule = %q, want Reviews", got)
+	}
+	if got := cfg.MatchGroupRule("/p", "claude", "fix"); got != "agents/claude" {
+		t.Errorf("MatchGroupRule = %q, want agents/claude", got)
+	}
+	if got := cfg.MatchGroupRule("/p", "shell", "fix"); got != "" {
+		t.Errorf("MatchGroupRule = %q, want no match", got)
+	}
+	var nilCfg *UserConfig
+	if got := nilCfg.MatchGroupRule("/p", "claude", "x"); got != "" {
+		t.Errorf("nil config matched %q", got)
+	}
+}
@@ -0,0 +1,59 @@
+package session
+
+// groupSortCycle is the order the TUI sort hotkey steps through the
+// within-group sort modes (see SortInstancesByActionable).
+var groupSortCycle = []string{"creation", "accessed", "actionable", "created", "cost", "resources"}
+
+// isGroupSortMode reports whether mode is a known within-group sort mode.
+func isGroupSortMode(mode string) bool {
+	for _, m := range groupSortCycle {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// NextGroupSortMode returns the mode after mode in the sort cycle, wrapping
+// back to "creation". Unknown modes restart the cycle.
+func NextGroupSortMode(mode string) string {
+	for i, m := range groupSortCycle {
+		if m == mode {
+			return groupSortCycle[(i+1)%len(groupSortCycle)]
+		}
+	}
+	return groupSortCycle[0]
+}
+
+// GroupSortLabel returns a short human-readable name for a sort mode (for
+// status hints).
+func GroupSortLabel(mode string) string {
+	switch mode {
+	case "accessed":
+		return "last accessed"
+	case "actionable":
+		return "status"
+	case "created":
+		return "newest"
+	case "cost":
+		return "cost"
+	case "resources":
+		return "memory"
+	default:
+		return "manual"
+	}
+}
+
+// SetCachedCost records the session's total spend in microdollars.
+func (i *Instance) SetCachedCost(micros int64) {
+	i.mu.Lock()
+	i.costMicros = micros
+	i.mu.Unlock()
+}
+
+// CachedCost returns the spend recorded by SetCachedCost, zero when unknown.
+func (i *Instance) CachedCost() int64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.costMicros
+}
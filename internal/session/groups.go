@@ -69,6 +69,16 @@ type Group struct {
 	// (default for newly-created groups); N>=2 = bounded parallelism. Negative
 	// values are treated as unlimited (explicit opt-out).
 	MaxConcurrent int
+	// NotificationsMuted keeps the group's sessions, subgroups included, off
+	// the notification bar and its Ctrl+b number keys.
+	NotificationsMuted bool
+	// SessionDefaults pre-populate new sessions created in this group; unset
+	// fields fall back to the parent group (see SessionDefaultsForGroup).
+	SessionDefaults GroupSessionDefaults
+	// Archived hides the group, subgroups included, from the main tree unless
+	// the tree's ShowArchived is set, and keeps its sessions out of status
+	// counts and notifications. Sessions and their history are untouched.
+	Archived bool
 }
 
 // GroupTree manages hierarchical session organization
@@ -83,6 +93,10 @@ type GroupTree struct {
 	// command/UI layer from [group_defaults].max_concurrent before a create; an
 	// explicit `group create --max-concurrent` flag still wins per-group.
 	DefaultMaxConcurrent *int
+
+	// ShowArchived makes Flatten include archived groups. It is a view
+	// setting and is never persisted.
+	ShowArchived bool
 }
 
 // actionablePriority maps a session.Status to an "attention-needed" rank
@@ -148,8 +162,12 @@ func pinZone(inst *Instance) int {
 // pinned rows, leaving the load-time order (creation or actionable, per the
 // group_sort config) — and any live K/J manual order — of the normal band
 // untouched. This is what makes a pin edit take effect live instead of only
-// after a restart.
+// after a restart. The exception is the "cost" and "resources" modes, whose
+// order is only known once the TUI loads costs or samples usage: there the
+// normal band re-sorts on every render.
 func stablePinPartition(insts []*Instance) {
+	mode := currentGroupSortMode()
+	live := mode == "cost" || mode == "resources"
 	sort.SliceStable(insts, func(i, j int) bool {
 		zi, zj := pinZone(insts[i]), pinZone(insts[j])
 		if zi != zj {
@@ -162,6 +180,9 @@ func stablePinPartition(insts []*Instance) {
 		if zi != 1 {
 			return insts[i].Order < insts[j].Order
 		}
+		if live {
+			return groupSortLess(mode, insts[i], insts[j])
+		}
 		// Normal (1) band is already sorted at load (creation Order, or actionable
 		// per group_sort); return false so SliceStable leaves its relative order
 		// untouched.
@@ -169,6 +190,50 @@ func stablePinPartition(insts []*Instance) {
 	})
 }
 
+// resourceLess orders the "resources" group sort: the biggest memory user
+// first, then the busiest CPU, then Order. Sessions without a sample sort as
+// zero.
+func resourceLess(a, b *Instance) bool {
+	ua, ub := a.CachedResourceUsage(), b.CachedResourceUsage()
+	if ua.MemBytes != ub.MemBytes {
+		return ua.MemBytes > ub.MemBytes
+	}
+	if ua.CPUPercent != ub.CPUPercent {
+		return ua.CPUPercent > ub.CPUPercent
+	}
+	return a.Order < b.Order
+}
+
+// groupSortLess orders two normal-band sessions for the given mode. Every mode
+// falls back to Order, so ties keep their creation / K/J manual order.
+func groupSortLess(mode string, a, b *Instance) bool {
+	switch mode {
+	case "resources":
+		return resourceLess(a, b)
+	case "actionable":
+		pa, pb := actionablePriority(a.Status), actionablePriority(b.Status)
+		if pa != pb {
+			return pa < pb
+		}
+		if !a.LastAccessedAt.Equal(b.LastAccessedAt) {
+			return a.LastAccessedAt.After(b.LastAccessedAt)
+		}
+	case "accessed":
+		if !a.LastAccessedAt.Equal(b.LastAccessedAt) {
+			return a.LastAccessedAt.After(b.LastAccessedAt)
+		}
+	case "created":
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+	case "cost":
+		if ca, cb := a.CachedCost(), b.CachedCost(); ca != cb {
+			return ca > cb
+		}
+	}
+	return a.Order < b.Order
+}
+
 // SortInstancesByActionable sorts the given slice in place according to the
 // active within-group sort mode (see SetGroupSortMode), while honoring
 // per-session pins (pin-sessions feature). The outermost key is the pin zone
@@ -178,6 +243,10 @@ func stablePinPartition(insts []*Instance) {
 //     K/J manual order unchanged.
 //   - "actionable" (issue #857): status→recency tiers apply before Order so
 //     the most recently actionable sessions surface first.
+//   - "accessed": LastAccessedAt desc.
+//   - "created": CreatedAt desc (newest first).
+//   - "cost": total spend desc (see SetCachedCost).
+//   - "resources": memory, then CPU, descending (see resourceLess).
 //
 // Pin-top and pin-bottom bands are always ordered by Order alone (fully fixed
 // — status and recency are ignored, so K/J reordering still works inside a
@@ -206,42 +275,54 @@ func SortInstancesByActionable(insts []*Instance) {
 		if zi != 1 {
 			return insts[i].Order < insts[j].Order
 		}
-		// Normal band. In actionable mode (issue #857) the status→recency tiers
-		// apply before Order; in creation mode (default) Order alone decides, so
-		// sessions keep their creation order (or K/J manual order).
-		if mode == "actionable" {
-			pi, pj := actionablePriority(insts[i].Status), actionablePriority(insts[j].Status)
-			if pi != pj {
-				return pi < pj
-			}
-			ai, aj := insts[i].LastAccessedAt, insts[j].LastAccessedAt
-			if !ai.Equal(aj) {
-				return ai.After(aj)
-			}
-		}
-		return insts[i].Order < insts[j].Order
+		// Normal band: the mode's own keys, then Order. In creation mode
+		// (default) Order alone decides, so sessions keep their creation order
+		// (or K/J manual order).
+		return groupSortLess(mode, insts[i], insts[j])
 	})
 }
 
-// groupSortMode caches the active within-group sort mode ("creation" or
-// "actionable"). It is refreshed from LoadUserConfig on every config (re)load,
+// groupSortMode caches the group_sort config mode (see groupSortCycle for the
+// valid values). It is refreshed from LoadUserConfig on every config (re)load,
 // so SortInstancesByActionable can read it without a disk hit and without
 // threading a parameter through the tree constructors. Defaults to "creation"
 // until SetGroupSortMode is first called.
 var groupSortMode atomic.Value // holds string
 
-// SetGroupSortMode updates the cached within-group sort mode. Any value other
-// than "actionable" normalizes to "creation".
+// groupSortOverride holds the mode picked with the TUI sort hotkey. It takes
+// precedence over groupSortMode and is kept separately so a config hot-reload
+// does not undo the user's choice.
+var groupSortOverride atomic.Value // holds string
+
+// SetGroupSortMode updates the cached within-group sort mode. Unknown values
+// normalize to "creation".
 func SetGroupSortMode(mode string) {
-	if mode != "actionable" {
+	if !isGroupSortMode(mode) {
 		mode = "creation"
 	}
 	groupSortMode.Store(mode)
 }
 
-// currentGroupSortMode returns the cached mode, defaulting to "creation" when
-// it has never been set.
+// SetGroupSortOverride sets the TUI-chosen sort mode, which wins over the
+// group_sort config. An empty or unknown mode clears the override.
+func SetGroupSortOverride(mode string) {
+	if !isGroupSortMode(mode) {
+		mode = ""
+	}
+	groupSortOverride.Store(mode)
+}
+
+// GroupSortMode returns the active within-group sort mode for display code.
+func GroupSortMode() string {
+	return currentGroupSortMode()
+}
+
+// currentGroupSortMode returns the override when set, else the cached config
+// mode, defaulting to "creation" when neither has been set.
 func currentGroupSortMode() string {
+	if v, ok := groupSortOverride.Load().(string); ok && v != "" {
+		return v
+	}
 	if v, ok := groupSortMode.Load().(string); ok && v != "" {
 		return v
 	}
@@ -312,13 +393,16 @@ func NewGroupTreeWithGroups(instances []*Instance, storedGroups []*GroupData) *G
 	// First, create groups from stored data (preserves empty groups)
 	for _, gd := range storedGroups {
 		group := &Group{
-			Name:          gd.Name,
-			Path:          gd.Path,
-			Expanded:      gd.Expanded,
-			Sessions:      []*Instance{},
-			Order:         gd.Order,
-			DefaultPath:   gd.DefaultPath,
-			MaxConcurrent: gd.MaxConcurrent,
+			Name:               gd.Name,
+			Path:               gd.Path,
+			Expanded:           gd.Expanded,
+			Sessions:           []*Instance{},
+			Order:              gd.Order,
+			DefaultPath:        gd.DefaultPath,
+			MaxConcurrent:      gd.MaxConcurrent,
+			NotificationsMuted: gd.NotificationsMuted,
+			SessionDefaults:    gd.SessionDefaults,
+			Archived:           gd.Archived,
 		}
 		tree.Groups[gd.Path] = group
 		tree.Expanded[gd.Path] = gd.Expanded
@@ -553,6 +637,10 @@ func (t *GroupTree) Flatten() []Item {
 	items := []Item{}
 
 	for _, group := range t.GroupList {
+		if t.archivedHidden(group.Path) {
+			continue
+		}
+
 		// Calculate group nesting level from path
 		groupLevel := GetGroupLevel(group.Path)
 
@@ -739,6 +827,23 @@ func (t *GroupTree) CollapseGroup(path string) {
 	}
 }
 
+// SetAllExpanded expands or collapses every group.
+func (t *GroupTree) SetAllExpanded(expanded bool) {
+	for path, group := range t.Groups {
+		group.Expanded = expanded
+		t.Expanded[path] = expanded
+	}
+}
+
+// ExpandOnly expands the given groups, plus their parents so they are
+// visible, and collapses every other group.
+func (t *GroupTree) ExpandOnly(paths []string) {
+	t.SetAllExpanded(false)
+	for _, path := range paths {
+		t.ExpandGroupWithParents(path)
+	}
+}
+
 // MoveGroupUp moves a group up in the order (only within siblings at same level)
 func (t *GroupTree) MoveGroupUp(path string) {
 	parentPath := getParentPath(path)
@@ -1287,6 +1392,24 @@ func (t *GroupTree) MoveGroupTo(sourcePath, destParentPath string) error {
 	return nil
 }
 
+// ReparentTargets lists the parents the group at path can be moved under
+// with MoveGroupTo: "" for the top level, then every group that is not the
+// group itself, one of its descendants, or its current parent.
+func (t *GroupTree) ReparentTargets(path string) []string {
+	current := getParentPath(path)
+	var targets []string
+	if current != "" {
+		targets = append(targets, "")
+	}
+	for _, g := range t.GroupList {
+		if g.Path == path || g.Path == current || strings.HasPrefix(g.Path, path+"/") {
+			continue
+		}
+		targets = append(targets, g.Path)
+	}
+	return targets
+}
+
 // DeleteGroup deletes a group, all its subgroups, and moves all sessions to default
 func (t *GroupTree) DeleteGroup(path string) []*Instance {
 	group, exists := t.Groups[path]
@@ -1346,6 +1469,14 @@ func (t *GroupTree) DeleteGroup(path string) []*Instance {
 	return allMovedSessions
 }
 
+// ResortSessions re-applies the within-group sort to every group, e.g. after
+// the sort mode changes at runtime.
+func (t *GroupTree) ResortSessions() {
+	for _, group := range t.Groups {
+		SortInstancesByActionable(group.Sessions)
+	}
+}
+
 // GetAllInstances returns all instances in order
 func (t *GroupTree) GetAllInstances() []*Instance {
 	instances := []*Instance{}
@@ -1525,12 +1656,15 @@ func (t *GroupTree) ShallowCopyForSave() *GroupTree {
 	groupListCopy := make([]*Group, len(t.GroupList))
 	for i, g := range t.GroupList {
 		groupListCopy[i] = &Group{
-			Name:          g.Name,
-			Path:          g.Path,
-			Expanded:      g.Expanded,
-			Order:         g.Order,
-			DefaultPath:   g.DefaultPath,
-			MaxConcurrent: g.MaxConcurrent,
+			Name:               g.Name,
+			Path:               g.Path,
+			Expanded:           g.Expanded,
+			Order:              g.Order,
+			DefaultPath:        g.DefaultPath,
+			MaxConcurrent:      g.MaxConcurrent,
+			NotificationsMuted: g.NotificationsMuted,
+			SessionDefaults:    g.SessionDefaults,
+			Archived:           g.Archived,
 			// Don't copy Sessions - not needed for save, only metadata is saved
 		}
 	}
@@ -1658,3 +1792,66 @@ func (t *GroupTree) updateGroupDefaultPath(groupPath string) {
 		group.DefaultPath = resolveGroupDefaultPath(group.DefaultPath)
 	}
 }
+
+// ToggleGroupNotificationsMuted flips the group's notification mute and
+// returns the new state. ok is false when the group does not exist.
+func (t *GroupTree) ToggleGroupNotificationsMuted(groupPath string) (muted, ok bool) {
+	group, exists := t.Groups[groupPath]
+	if !exists {
+		return false, false
+	}
+	group.NotificationsMuted = !group.NotificationsMuted
+	return group.NotificationsMuted, true
+}
+
+// MutedGroupPaths returns the paths of groups muted for notifications.
+func (t *GroupTree) MutedGroupPaths() []string {
+	var paths []string
+	for _, g := range t.GroupList {
+		if g.NotificationsMuted {
+			paths = append(paths, g.Path)
+		}
+	}
+	return paths
+}
+
+// ToggleGroupArchived flips the group's archived flag and returns the new
+// state. ok is false when the group does not exist.
+func (t *GroupTree) ToggleGroupArchived(groupPath string) (archived, ok bool) {
+	group, exists := t.Groups[groupPath]
+	if !exists {
+		return false, false
+	}
+	group.Archived = !group.Archived
+	return group.Archived, true
+}
+
+// ArchivedGroupPaths returns the paths of archived groups.
+func (t *GroupTree) ArchivedGroupPaths() []string {
+	var paths []string
+	for _, g := range t.GroupList {
+		if g.Archived {
+			paths = append(paths, g.Path)
+		}
+	}
+	return paths
+}
+
+// archivedHidden reports whether the group at path is left out of the tree
+// view: it or one of its ancestors is archived and ShowArchived is off.
+func (t *GroupTree) archivedHidden(path string) bool {
+	if t.ShowArchived {
+		return false
+	}
+	for p := path; p != ""; {
+		if g, ok := t.Groups[p]; ok && g.Archived {
+			return true
+		}
+		idx := strings.LastIndex(p, "/")
+		if idx == -1 {
+			break
+		}
+		p = p[:idx]
+	}
+	return false
+}
@@ -173,3 +173,32 @@ func TestMoveGroupTo_DefaultGroupForbidden(t *testing.T) {
 		t.Error("expected error moving the default group")
 	}
 }
+
+// TestReparentTargets lists the top level and every group outside the
+// source's subtree, minus its current parent.
+func TestReparentTargets(t *testing.T) {
+	tree := NewGroupTree([]*Instance{})
+	tree.CreateGroup("work")
+	tree.CreateSubgroup("work", "alpha")
+	tree.CreateSubgroup("work/alpha", "deep")
+	tree.CreateGroup("personal")
+
+	got := map[string]bool{}
+	for _, p := range tree.ReparentTargets("work/alpha") {
+		got[p] = true
+	}
+	if !got[""] || !got["personal"] {
+		t.Errorf("ReparentTargets = %v, want the top level and personal", got)
+	}
+	for _, bad := range []string{"work", "work/alpha", "work/alpha/deep"} {
+		if got[bad] {
+			t.Errorf("ReparentTargets should not offer %q (current parent, self or descendant)", bad)
+		}
+	}
+
+	for _, p := range tree.ReparentTargets("personal") {
+		if p == "" {
+			t.Error("a top-level group should not be offered the top level")
+		}
+	}
+}
@@ -386,6 +386,36 @@ func TestExpandGroupWithParents(t *testing.T) {
 	}
 }
 
+func TestSetAllExpandedAndExpandOnly(t *testing.T) {
+	instances := []*Instance{
+		{ID: "1", Title: "a", GroupPath: "alpha"},
+		{ID: "2", Title: "b", GroupPath: "beta/inner"},
+		{ID: "3", Title: "c", GroupPath: "gamma"},
+	}
+	tree := NewGroupTree(instances)
+
+	tree.SetAllExpanded(false)
+	for path, g := range tree.Groups {
+		if g.Expanded || tree.Expanded[path] {
+			t.Errorf("%s should be collapsed after SetAllExpanded(false)", path)
+		}
+	}
+	tree.SetAllExpanded(true)
+	for path, g := range tree.Groups {
+		if !g.Expanded || !tree.Expanded[path] {
+			t.Errorf("%s should be expanded after SetAllExpanded(true)", path)
+		}
+	}
+
+	tree.ExpandOnly([]string{"beta/inner"})
+	want := map[string]bool{"alpha": false, "beta": true, "beta/inner": true, "gamma": false}
+	for path, expanded := range want {
+		if got := tree.Groups[path].Expanded; got != expanded {
+			t.Errorf("ExpandOnly: %s expanded = %v, want %v", path, got, expanded)
+		}
+	}
+}
+
 func TestRenameGroup(t *testing.T) {
 	instances := []*Instance{
 		{ID: "1", Title: "session-1", GroupPath: "old-name"},
@@ -2209,6 +2239,106 @@ func TestGroupSortMode_DefaultAndSet(t *testing.T) {
 	}
 }
 
+func TestGroupSortResources_LiveByMemory(t *testing.T) {
+	t.Cleanup(func() { SetGroupSortMode("creation") })
+	SetGroupSortMode("resources")
+	now := time.Now()
+
+	instances := []*Instance{
+		{ID: "a", Title: "a", GroupPath: "g", Order: 0},
+		{ID: "b", Title: "b", GroupPath: "g", Order: 1},
+		{ID: "c", Title: "c", GroupPath: "g", Order: 2, Pin: PinTop},
+	}
+	tree := NewGroupTree(instances)
+	order := func() []string {
+		var ids []string
+		for _, it := range tree.Flatten() {
+			if it.Type == ItemTypeSession {
+				ids = append(ids, it.Session.ID)
+			}
+		}
+		return ids
+	}
+	if got := order(); !equalStrings(got, []string{"c", "a", "b"}) {
+		t.Fatalf("unsampled sessions keep Order below pins; got %v", got)
+	}
+
+	// Usage arrives after the tree is built: the next render picks it up.
+	instances[1].SetResourceUsage(ResourceUsage{MemBytes: 6 << 30, SampledAt: now})
+	instances[0].SetResourceUsage(ResourceUsage{MemBytes: 200 << 20, SampledAt: now})
+	if got := order(); !equalStrings(got, []string{"c", "b", "a"}) {
+		t.Fatalf("resources mode should put the biggest memory user first (pins stay on top); got %v", got)
+	}
+}
+
+func TestGroupSortOverride_ResortSessions(t *testing.T) {
+	t.Cleanup(func() {
+		SetGroupSortMode("creation")
+		SetGroupSortOverride("")
+	})
+	SetGroupSortMode("creation")
+	now := time.Now()
+
+	instances := []*Instance{
+		{ID: "a", GroupPath: "g", Order: 0, CreatedAt: now.Add(-3 * time.Hour), LastAccessedAt: now.Add(-time.Hour)},
+		{ID: "b", GroupPath: "g", Order: 1, CreatedAt: now.Add(-2 * time.Hour), LastAccessedAt: now},
+		{ID: "c", GroupPath: "g", Order: 2, CreatedAt: now.Add(-time.Hour), LastAccessedAt: now.Add(-2 * time.Hour)},
+	}
+	instances[0].SetCachedCost(5_000_000)
+	instances[2].SetCachedCost(9_000_000)
+	tree := NewGroupTree(instances)
+	order := func() []string {
+		var ids []string
+		for _, it := range tree.Flatten() {
+			if it.Type == ItemTypeSession {
+				ids = append(ids, it.Session.ID)
+			}
+		}
+		return ids
+	}
+
+	for _, tc := range []struct {
+		mode string
+		want []string
+	}{
+		{"accessed", []string{"b", "a", "c"}},
+		{"created", []string{"c", "b", "a"}},
+		{"cost", []string{"c", "a", "b"}},
+		{"creation", []string{"a", "b", "c"}},
+	} {
+		SetGroupSortOverride(tc.mode)
+		tree.ResortSessions()
+		if got := order(); !equalStrings(got, tc.want) {
+			t.Errorf("override %q: got %v, want %v", tc.mode, got, tc.want)
+		}
+	}
+
+	// A config reload must not undo the TUI choice.
+	SetGroupSortOverride("created")
+	SetGroupSortMode("actionable")
+	if got := GroupSortMode(); got != "created" {
+		t.Errorf("override should win over group_sort; got %q", got)
+	}
+	SetGroupSortOverride("")
+	if got := GroupSortMode(); got != "actionable" {
+		t.Errorf("clearing the override should fall back to group_sort; got %q", got)
+	}
+}
+
+func TestNextGroupSortMode_Cycles(t *testing.T) {
+	mode, seen := "creation", []string{}
+	for range groupSortCycle {
+		mode = NextGroupSortMode(mode)
+		seen = append(seen, mode)
+	}
+	if want := []string{"accessed", "actionable", "created", "cost", "resources", "creation"}; !equalStrings(seen, want) {
+		t.Errorf("cycle = %v, want %v", seen, want)
+	}
+	if got := NextGroupSortMode("bogus"); got != "creation" {
+		t.Errorf("unknown mode should restart the cycle, got %q", got)
+	}
+}
+
 func TestSortInstancesByActionable_CreationOrderDefault(t *testing.T) {
 	t.Cleanup(func() { SetGroupSortMode("creation") })
 	SetGroupSortMode("creation")
@@ -2312,3 +2442,83 @@ func TestFlatten_OrphanSubSessionsDeterministic_TiedOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestGroupTree_ToggleGroupNotificationsMuted(t *testing.T) {
+	tree := NewGroupTree(nil)
+	tree.CreateGroup("experiments")
+	tree.CreateGroup("work")
+
+	muted, ok := tree.ToggleGroupNotificationsMuted("experiments")
+	if !ok || !muted {
+		t.Fatalf("toggle = (%v, %v), want (true, true)", muted, ok)
+	}
+	if got := tree.MutedGroupPaths(); len(got) != 1 || got[0] != "experiments" {
+		t.Fatalf("MutedGroupPaths() = %v, want [experiments]", got)
+	}
+	if !tree.ShallowCopyForSave().GroupList[0].NotificationsMuted {
+		t.Error("the save copy must carry the mute")
+	}
+
+	// Round trip through the stored form.
+	stored := []*GroupData{{Name: "experiments", Path: "experiments", NotificationsMuted: true}}
+	if got := NewGroupTreeWithGroups(nil, stored).MutedGroupPaths(); len(got) != 1 {
+		t.Errorf("mute lost when loading stored groups: %v", got)
+	}
+
+	if muted, _ := tree.ToggleGroupNotificationsMuted("experiments"); muted {
+		t.Error("second toggle should unmute")
+	}
+	if _, ok := tree.ToggleGroupNotificationsMuted("missing"); ok {
+		t.Error("toggling an unknown group should report !ok")
+	}
+}
+
+func TestGroupTree_ArchivedGroupsHiddenFromFlatten(t *testing.T) {
+	instances := []*Instance{
+		{ID: "1", Title: "old", GroupPath: "clients/acme"},
+		{ID: "2", Title: "live", GroupPath: "work"},
+	}
+	tree := NewGroupTree(instances)
+	tree.CreateSubgroup("clients/acme", "legacy")
+
+	shown := func() map[string]bool {
+		out := map[string]bool{}
+		for _, it := range tree.Flatten() {
+			if it.Type == ItemTypeGroup {
+				out[it.Path] = true
+			}
+		}
+		return out
+	}
+
+	archived, ok := tree.ToggleGroupArchived("clients/acme")
+	if !ok || !archived {
+		t.Fatalf("toggle = (%v, %v), want (true, true)", archived, ok)
+	}
+	got := shown()
+	if got["clients/acme"] || got["clients/acme/legacy"] || !got["clients"] || !got["work"] {
+		t.Fatalf("Flatten groups = %v, want the archived group and its subgroups hidden", got)
+	}
+	if n := len(tree.SessionItems()); n != 1 {
+		t.Errorf("SessionItems() = %d sessions, want 1 (archived group's session hidden)", n)
+	}
+
+	tree.ShowArchived = true
+	if got := shown(); !got["clients/acme"] || !got["clients/acme/legacy"] {
+		t.Errorf("Flatten with ShowArchived = %v, want archived groups listed", got)
+	}
+
+	if got := tree.ArchivedGroupPaths(); len(got) != 1 || got[0] != "clients/acme" {
+		t.Fatalf("ArchivedGroupPaths() = %v, want [clients/acme]", got)
+	}
+	if got := tree.ShallowCopyForSave().ArchivedGroupPaths(); len(got) != 1 {
+		t.Error("the save copy must carry the archived flag")
+	}
+	stored := []*GroupData{{Name: "acme", Path: "acme", Archived: true}}
+	if got := NewGroupTreeWithGroups(nil, stored).ArchivedGroupPaths(); len(got) != 1 {
+		t.Errorf("archived flag lost when loading stored groups: %v", got)
+	}
+	if _, ok := tree.ToggleGroupArchived("missing"); ok {
+		t.Error("toggling an unknown group should report !ok")
+	}
+}
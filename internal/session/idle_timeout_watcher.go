@@ -35,13 +35,17 @@ var idleLog = logging.ForComponent(logging.CompSession)
 // SessionLifecycleEvent is a single row in session-lifecycle.jsonl.
 type SessionLifecycleEvent struct {
 	InstanceID string `json:"instance_id"`
-	Action     string `json:"action"` // currently only "idle-timeout-expired"
+	Action     string `json:"action"` // "idle-timeout-expired", "idle-auto-archived" or "stuck-detected"
 	Reason     string `json:"reason,omitempty"`
 	Timestamp  int64  `json:"ts"`
 }
 
 const ReasonIdleTimeoutExpired = "idle-timeout-expired"
 
+// ReasonStuckDetected is the session-lifecycle.jsonl action recorded when the
+// TUI watchdog marks a session stuck ([watchdog] stuck_after).
+const ReasonStuckDetected = "stuck-detected"
+
 var sessionLifecycleLogMu sync.Mutex
 
 // GetSessionLifecycleLogPath returns ~/.agent-deck/logs/session-lifecycle.jsonl.
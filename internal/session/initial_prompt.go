@@ -0,0 +1,57 @@
+package session
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const toolDataInitialPromptKey = "initial_prompt"
+
+// TakeInitialPrompt returns the pending initial prompt and clears it, so it
+// is delivered at most once. Callers persist the instance afterwards.
+func (i *Instance) TakeInitialPrompt() string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	prompt := i.InitialPrompt
+	i.InitialPrompt = ""
+	return prompt
+}
+
+// HasInitialPrompt reports whether an initial prompt is still pending.
+func (i *Instance) HasInitialPrompt() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.InitialPrompt != ""
+}
+
+// WriteInitialPromptToToolData merges the pending prompt into the tool_data
+// blob. An empty prompt removes the key.
+func WriteInitialPromptToToolData(td json.RawMessage, prompt string) json.RawMessage {
+	m := map[string]json.RawMessage{}
+	if len(td) > 0 {
+		_ = json.Unmarshal(td, &m)
+	}
+	if prompt = strings.TrimSpace(prompt); prompt != "" {
+		raw, _ := json.Marshal(prompt)
+		m[toolDataInitialPromptKey] = raw
+	} else {
+		delete(m, toolDataInitialPromptKey)
+	}
+	out, _ := json.Marshal(m)
+	return out
+}
+
+// ReadInitialPromptFromToolData extracts the pending prompt from the blob.
+// Returns "" for missing/malformed/legacy rows.
+func ReadInitialPromptFromToolData(td json.RawMessage) string {
+	if len(td) == 0 {
+		return ""
+	}
+	var blob struct {
+		InitialPrompt string `json:"initial_prompt"`
+	}
+	if err := json.Unmarshal(td, &blob); err != nil {
+		return ""
+	}
+	return blob.InitialPrompt
+}
@@ -0,0 +1,39 @@
+package session
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInitialPromptToolDataRoundTrip(t *testing.T) {
+	td := json.RawMessage(`{"chain":{"after":"x"}}`)
+	td = WriteInitialPromptToToolData(td, "  fix the flaky test  ")
+	if got := ReadInitialPromptFromToolData(td); got != "fix the flaky test" {
+		t.Errorf("ReadInitialPromptFromToolData = %q", got)
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(td, &m); err != nil || m["chain"] == nil {
+		t.Errorf("other tool_data keys must survive, got %s", td)
+	}
+
+	td = WriteInitialPromptToToolData(td, "")
+	if got := ReadInitialPromptFromToolData(td); got != "" {
+		t.Errorf("empty prompt should remove the key, got %q", got)
+	}
+	if got := ReadInitialPromptFromToolData(json.RawMessage(`not json`)); got != "" {
+		t.Errorf("malformed blob should read as empty, got %q", got)
+	}
+}
+
+func TestTakeInitialPrompt(t *testing.T) {
+	inst := &Instance{InitialPrompt: "hello"}
+	if !inst.HasInitialPrompt() {
+		t.Fatal("prompt should be pending")
+	}
+	if got := inst.TakeInitialPrompt(); got != "hello" {
+		t.Errorf("TakeInitialPrompt = %q", got)
+	}
+	if inst.HasInitialPrompt() || inst.TakeInitialPrompt() != "" {
+		t.Error("prompt must be delivered at most once")
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -72,6 +71,20 @@ const (
 	SubstateAuth401           = tmux.SubstateAuth401
 )
 
+// Attention labels what a waiting session needs from the user (see
+// tmux.Attention): answering a question, reviewing finished work, or looking
+// at an error. Re-exported for the same reason as Substate.
+type Attention = tmux.Attention
+
+const (
+	AttentionNone         = tmux.AttentionNone
+	AttentionPermission   = tmux.AttentionPermission
+	AttentionPlanApproval = tmux.AttentionPlanApproval
+	AttentionQuestion     = tmux.AttentionQuestion
+	AttentionError        = tmux.AttentionError
+	AttentionDone         = tmux.AttentionDone
+)
+
 const wrapperPlaceholder = "{command}"
 
 // PinMode anchors a session to a fixed slot within its group, exempt from the
@@ -220,6 +233,21 @@ type Instance struct {
 	Notes             string    `json:"notes,omitempty"`
 	lastPromptModTime time.Time // mtime cache for updateGeminiLatestPrompt (not serialized)
 
+	// Tags are free-form labels that slice sessions across groups (e.g.
+	// "urgent", "client-x"). Kept normalized (see NormalizeTags); persisted
+	// in the tool_data blob.
+	Tags []string `json:"tags,omitempty"`
+
+	// Chain makes this session wait for another one to finish a turn (see
+	// chain.go). Nil when unchained; persisted in the tool_data blob.
+	Chain *SessionChain `json:"chain,omitempty"`
+
+	// InitialPrompt is the first instruction given at creation (`add
+	// --initial-prompt`, the new-session dialog). It stays pending until it
+	// has been sent to the agent once the tool is ready, so a session added
+	// now and started later still receives it. Persisted in the tool_data blob.
+	InitialPrompt string `json:"initial_prompt,omitempty"`
+
 	// Color is an optional user-chosen tint for this session's TUI row (issue #391).
 	// Accepts a lipgloss-compatible color spec:
 	//   - "#RRGGBB"      - truecolor hex
@@ -329,6 +357,16 @@ type Instance struct {
 	// so existing sessions are unaffected on upgrade.
 	IdleTimeoutSecs int64 `json:"idle_timeout_secs,omitempty"`
 
+	// RestartHistory records every Restart()/RestartFresh() (newest last,
+	// capped at maxRestartHistory) so flapping sessions and auto-restart
+	// policies can be audited. Guarded by mu; read via GetRestartHistory.
+	RestartHistory []RestartEvent `json:"restart_history,omitempty"`
+
+	// EventLog is the session's timeline: starts, restarts, stops, status
+	// transitions, MCP changes and forks (newest last, capped at
+	// maxEventLog). Guarded by mu; read via GetEventLog.
+	EventLog []SessionEvent `json:"event_log,omitempty"`
+
 	// IsForkAwaitingStart signals that this instance was produced by a
 	// fork builder and must run a pre-built fork command verbatim on the
 	// first Start() (#745). Claude fork targets usually store that command
@@ -391,6 +429,14 @@ type Instance struct {
 	hookSessionID  string    // Session ID from hook payload
 	hookLastUpdate time.Time // When hook status was last received
 
+	// resourceUsage is the pane process tree's CPU/RAM, sampled by the TUI.
+	// Guarded by mu; see SetResourceUsage.
+	resourceUsage ResourceUsage
+
+	// costMicros is the session's total spend, loaded by the TUI for the
+	// "cost" group sort. Guarded by mu; see SetCachedCost.
+	costMicros int64
+
 	// mu protects fields written by backgroundStatusUpdate and read by the TUI goroutine.
 	// Use GetStatus()/SetStatus() and GetTool()/SetTool() for thread-safe access.
 	// UpdateStatus() acquires the write lock internally.
@@ -401,6 +447,10 @@ type Instance struct {
 	// Not serialized - resets on load, but that's fine since we'll recheck on first poll
 	lastErrorCheck time.Time
 
+	// claudeIDMismatch is the last result of VerifyClaudeSessionID (nil when
+	// the stored ID matches the transcript being written). Guarded by mu.
+	claudeIDMismatch *ClaudeIDMismatch
+
 	// Tiered polling: skip expensive checks for idle sessions with no activity
 	lastIdleCheck     time.Time // When we last did a full check for an idle session
 	lastKnownActivity int64     // Last window_activity timestamp seen
@@ -1063,6 +1113,18 @@ func (i *Instance) ensureProfileEnv() {
 	}
 }
 
+// RetargetProfileEnv points AGENTDECK_PROFILE on the instance's live tmux
+// session at profile. Called after a cross-profile move so the moved session
+// stops resolving its old profile; the pane's already-running shell keeps its
+// exported value until the next respawn, which re-injects it. No-op when the
+// tmux session is not running.
+func (i *Instance) RetargetProfileEnv(profile string) error {
+	if i.tmuxSession == nil || !i.tmuxSession.Exists() {
+		return nil
+	}
+	return i.tmuxSession.SetEnvironment("AGENTDECK_PROFILE", profile)
+}
+
 // logClaudeConfigResolution emits the CFG-07 observability line documenting
 // which priority level resolved CLAUDE_CONFIG_DIR for this session.
 // Owns the single CFG-07 slog message literal for this package.
@@ -1189,8 +1251,13 @@ func (i *Instance) buildClaudeExtraFlags(opts *ClaudeOptions) string {
 
 	// Options-level flags
 	if opts != nil {
+		if settings := opts.ThinkingSettings(); settings != "" {
+			flags = append(flags, "--settings "+shellescape.Quote(settings))
+		}
 		if opts.SkipPermissions {
 			flags = append(flags, "--dangerously-skip-permissions")
+		} else if opts.PlanMode {
+			flags = append(flags, "--permission-mode plan")
 		} else if opts.AutoMode {
 			flags = append(flags, "--permission-mode auto")
 		} else if opts.AllowSkipPermissions {
@@ -2689,7 +2756,9 @@ func (i *Instance) updateCodexSession(excludeIDs map[string]bool, forceProbe boo
 // Also sources .env files from [shell].env_files and [tools.X].env_file
 //
 // Config fields used:
+//   - command: {path}/{title} placeholders are expanded (see expandToolCommand)
 //   - resume_flag: CLI flag to resume (e.g., "--resume")
+//   - resume_command: resume template with {command}/{session_id}, instead of resume_flag
 //   - session_id_env: tmux env var name (e.g., "VIBE_SESSION_ID")
 //   - session_id_json_path: jq path to extract ID (e.g., ".session_id")
 //   - output_format_flag: flag to get JSON output (e.g., "--output-format json")
@@ -2703,9 +2772,10 @@ func (i *Instance) buildGenericCommand(baseCommand string) string {
 	if toolDef == nil {
 		return envPrefix + baseCommand // No custom config, return with env prefix
 	}
+	baseCommand = i.expandToolCommand(baseCommand)
 
-	// Check if tool supports session resume (needs both resume_flag and session_id_env)
-	if toolDef.ResumeFlag == "" || toolDef.SessionIDEnv == "" {
+	// Check if tool supports session resume (needs a resume flag/template and session_id_env)
+	if !toolDef.SupportsResume() {
 		// No session resume support, just add dangerous flag if configured
 		if toolDef.DangerousMode && toolDef.DangerousFlag != "" {
 			return envPrefix + fmt.Sprintf("%s %s", baseCommand, toolDef.DangerousFlag)
@@ -2730,8 +2800,7 @@ func (i *Instance) buildGenericCommand(baseCommand string) string {
 	// If we have an existing session ID, just resume.
 	// The session ID env var is propagated via host-side SetEnvironment after tmux start.
 	if existingSessionID != "" {
-		return envPrefix + fmt.Sprintf("%s %s %s%s",
-			baseCommand, toolDef.ResumeFlag, existingSessionID, dangerousFlag)
+		return envPrefix + toolDef.ResumeInvocation(baseCommand, existingSessionID) + dangerousFlag
 	}
 
 	// No existing session ID - need to capture it on first run
@@ -2754,13 +2823,26 @@ func (i *Instance) buildGenericCommand(baseCommand string) string {
 	return envPrefix + fmt.Sprintf(
 		`session_id=$(%s %s "." 2>/dev/null | jq -r '%s' 2>/dev/null) || session_id=""; `+
 			`if [ -n "$session_id" ] && [ "$session_id" != "null" ]; then `+
-			`%s %s "$session_id"%s; `+
+			`%s%s; `+
 			`else %s%s; fi`,
 		baseCommand, toolDef.OutputFormatFlag, toolDef.SessionIDJsonPath,
-		baseCommand, toolDef.ResumeFlag, dangerousFlag,
+		toolDef.ResumeInvocation(baseCommand, `"$session_id"`), dangerousFlag,
 		baseCommand, dangerousFlag)
 }
 
+// expandToolCommand replaces the {path} and {title} placeholders a custom
+// tool's command template may use with the session's shell-quoted project
+// path and title.
+func (i *Instance) expandToolCommand(command string) string {
+	if !strings.Contains(command, "{path}") && !strings.Contains(command, "{title}") {
+		return command
+	}
+	return strings.NewReplacer(
+		"{path}", shellescape.Quote(i.ProjectPath),
+		"{title}", shellescape.Quote(i.Title),
+	).Replace(command)
+}
+
 // GetGenericSessionID gets session ID from tmux environment for a custom tool
 // Uses the session_id_env field from tool config
 func (i *Instance) GetGenericSessionID() string {
@@ -2804,7 +2886,7 @@ func (i *Instance) CanRestartGeneric() bool {
 		return false
 	}
 	// Can restart if we have resume support AND an existing session ID
-	if toolDef.ResumeFlag == "" || toolDef.SessionIDEnv == "" {
+	if !toolDef.SupportsResume() {
 		return false
 	}
 	return i.GetGenericSessionID() != ""
@@ -2864,14 +2946,16 @@ func (i *Instance) loadCustomPatternsFromConfig() {
 	}
 }
 
-// buildTmuxOptionOverrides returns tmux option overrides from user config,
-// adding remain-on-exit for sandbox sessions (needed for dead-pane detection).
+// buildTmuxOptionOverrides returns tmux option overrides from user config
+// ([tmux], then [groups."<path>".tmux], then [tmux.sessions]), adding
+// remain-on-exit for sandbox sessions (needed for dead-pane detection).
 // Returns nil if no overrides apply.
 func (i *Instance) buildTmuxOptionOverrides() map[string]string {
 	var overrides map[string]string
-	tmuxCfg := GetTmuxSettings()
-	if len(tmuxCfg.Options) > 0 {
-		overrides = maps.Clone(tmuxCfg.Options)
+	var tmuxCfg TmuxSettings
+	if cfg, err := LoadUserConfig(); err == nil && cfg != nil {
+		tmuxCfg = cfg.Tmux
+		overrides, _ = cfg.ResolveSessionTmuxOverrides(i.ID, i.Title, i.GroupPath)
 	}
 	if tmuxCfg.WindowStyleOverride != "" {
 		if overrides == nil {
@@ -2891,6 +2975,18 @@ func (i *Instance) buildTmuxOptionOverrides() map[string]string {
 	return overrides
 }
 
+// buildTmuxHooks returns the tmux hooks configured for this session across
+// [tmux].hooks, [groups."<path>".tmux].hooks and [tmux.sessions]. Returns nil
+// when none apply.
+func (i *Instance) buildTmuxHooks() map[string]string {
+	cfg, err := LoadUserConfig()
+	if err != nil || cfg == nil {
+		return nil
+	}
+	_, hooks := cfg.ResolveSessionTmuxOverrides(i.ID, i.Title, i.GroupPath)
+	return hooks
+}
+
 // adoptExplicitClaudeSessionID adopts an explicit `--session-id <uuid>` baked
 // into i.Command as the authoritative conversation id, correcting any stale or
 // disk-hijacked value, and returns true when an explicit id was present (the
@@ -3044,7 +3140,7 @@ func (i *Instance) ensureClaudeSessionIDFromDiskForRestart() {
 // and gate are inlined here (rather than wrapping the whole body in a
 // SpawnAttempt helper) to preserve the structural-grep contract that
 // checks Start()'s body for the #745 IsForkAwaitingStart guard.
-func (i *Instance) Start() error {
+func (i *Instance) Start() (retErr error) {
 	beforeLock := nowFn()
 	release, lockErr := acquireInstanceSpawnLock(i.ID)
 	if lockErr != nil {
@@ -3055,6 +3151,7 @@ func (i *Instance) Start() error {
 		return nil
 	}
 	defer recordInstanceSpawn(i.ID)
+	defer func() { i.recordEvent(EventStarted, "", retErr) }()
 
 	if i.tmuxSession == nil {
 		return fmt.Errorf("tmux session not initialized")
@@ -3168,6 +3265,8 @@ func (i *Instance) Start() error {
 		command = i.buildCursorCommand(i.Command, false)
 	case i.Tool == "hermes":
 		command = i.buildHermesCommand(i.Command)
+	case i.Tool == "aider":
+		command = i.buildAiderCommand(i.Command)
 	default:
 		// Check if this is a custom tool with session resume config
 		if toolDef := GetToolDef(i.Tool); toolDef != nil {
@@ -3193,6 +3292,7 @@ func (i *Instance) Start() error {
 	// Build tmux option overrides from config (e.g. allow-passthrough = "all").
 	// Sandbox sessions also get remain-on-exit for dead-pane detection.
 	i.tmuxSession.OptionOverrides = i.buildTmuxOptionOverrides()
+	i.tmuxSession.Hooks = i.buildTmuxHooks()
 	i.tmuxSession.RunCommandAsInitialProcess = i.IsSandboxed() || i.Tool != "shell"
 	i.applyLaunchSettingsFromConfig()
 
@@ -3297,7 +3397,7 @@ func (i *Instance) Start() error {
 // Issue #1040: same per-instance spawn lock as Start() — a concurrent
 // `launch -m "..."` racing with a poller-triggered Start() must not
 // produce two parallel tmux sessions.
-func (i *Instance) StartWithMessage(message string) error {
+func (i *Instance) StartWithMessage(message string) (retErr error) {
 	beforeLock := nowFn()
 	release, lockErr := acquireInstanceSpawnLock(i.ID)
 	if lockErr != nil {
@@ -3308,6 +3408,7 @@ func (i *Instance) StartWithMessage(message string) error {
 		return nil
 	}
 	defer recordInstanceSpawn(i.ID)
+	defer func() { i.recordEvent(EventStarted, "", retErr) }()
 
 	if i.tmuxSession == nil {
 		return fmt.Errorf("tmux session not initialized")
@@ -3409,6 +3510,8 @@ func (i *Instance) StartWithMessage(message string) error {
 		command = i.buildCursorCommand(i.Command, false)
 	case i.Tool == "hermes":
 		command = i.buildHermesCommand(i.Command)
+	case i.Tool == "aider":
+		command = i.buildAiderCommand(i.Command)
 	default:
 		// Check if this is a custom tool with session resume config
 		if toolDef := GetToolDef(i.Tool); toolDef != nil {
@@ -3434,6 +3537,7 @@ func (i *Instance) StartWithMessage(message string) error {
 	// Build tmux option overrides from config (e.g. allow-passthrough = "all").
 	// Sandbox sessions also get remain-on-exit for dead-pane detection.
 	i.tmuxSession.OptionOverrides = i.buildTmuxOptionOverrides()
+	i.tmuxSession.Hooks = i.buildTmuxHooks()
 	i.tmuxSession.RunCommandAsInitialProcess = i.IsSandboxed() || i.Tool != "shell"
 	i.applyLaunchSettingsFromConfig()
 
@@ -3720,6 +3824,8 @@ func debounceFlipFromRunning(prev, derived Status, tmuxRaw, hookStatus string, p
 func (i *Instance) UpdateStatus() error {
 	i.mu.Lock()
 	defer i.mu.Unlock()
+	statusAtEntry := i.Status
+	defer func() { i.recordStatusChangeLocked(statusAtEntry, i.Status) }()
 
 	// Short grace period for tmux initialization (not Claude startup)
 	// Use lastStartTime for accuracy on restarts, fallback to CreatedAt
@@ -3794,7 +3900,7 @@ func (i *Instance) UpdateStatus() error {
 
 	// COLD LOAD: CLI doesn't run StatusFileWatcher, so hookStatus is always empty.
 	// Read the hook file from disk once to give CLI the same fast path as the TUI.
-	if i.hookStatus == "" && (IsClaudeCompatible(i.Tool) || i.Tool == "codex" || i.Tool == "gemini" || i.Tool == "hermes" || i.Tool == "cursor") {
+	if i.hookStatus == "" && ToolAdapterFor(i.Tool).EmitsHookStatus() {
 		if hs := readHookStatusFile(i.ID); hs != nil {
 			i.hookStatus = hs.Status
 			i.hookEvent = hs.Event
@@ -3813,7 +3919,7 @@ func (i *Instance) UpdateStatus() error {
 	// Freshness is tool- and state-specific (e.g. Codex running vs waiting).
 	// When this path is stale/missing, control naturally falls through to tmux
 	// polling and tool-specific session sync (tmux env/process-files/disk).
-	if (IsClaudeCompatible(i.Tool) || IsCodexCompatible(i.Tool) || i.Tool == "gemini" || i.Tool == "hermes" || i.Tool == "cursor") &&
+	if ToolAdapterFor(i.Tool).EmitsHookStatus() &&
 		i.hookStatus != "" &&
 		time.Since(i.hookLastUpdate) < hookFastPathFreshnessForTool(i.Tool, i.hookStatus) {
 		switch i.hookStatus {
@@ -4809,19 +4915,29 @@ func (i *Instance) Preview() (string, error) {
 
 // PreviewFull returns all terminal output
 func (i *Instance) PreviewFull() (string, error) {
+	return i.PreviewHistory(tmux.DefaultHistoryLines)
+}
+
+// PreviewHistory returns the last `lines` lines of scrollback plus the
+// visible screen. The preview pane's scrollback mode grows lines on demand.
+func (i *Instance) PreviewHistory(lines int) (string, error) {
 	if i.tmuxSession == nil {
 		return "", fmt.Errorf("tmux session not initialized")
 	}
-
-	return i.tmuxSession.CaptureFullHistory()
+	return i.tmuxSession.CaptureHistory(lines)
 }
 
 // PreviewWindowFull returns the full scrollback of a specific tmux window.
 func (i *Instance) PreviewWindowFull(windowIndex int) (string, error) {
+	return i.PreviewWindowHistory(windowIndex, tmux.DefaultHistoryLines)
+}
+
+// PreviewWindowHistory is PreviewHistory for a specific tmux window.
+func (i *Instance) PreviewWindowHistory(windowIndex, lines int) (string, error) {
 	if i.tmuxSession == nil {
 		return "", fmt.Errorf("tmux session not initialized")
 	}
-	return i.tmuxSession.CaptureWindowFullHistory(windowIndex)
+	return i.tmuxSession.CaptureWindowHistory(windowIndex, lines)
 }
 
 // HasUpdated checks if there's new output since last check
@@ -4933,7 +5049,7 @@ func (i *Instance) prepareRestartMCPConfig() {
 	skipRegen := i.SkipMCPRegenerate
 	i.SkipMCPRegenerate = false
 
-	if IsClaudeCompatible(i.Tool) && !skipRegen {
+	if (IsClaudeCompatible(i.Tool) || i.Tool == "gemini") && !skipRegen {
 		if err := i.regenerateMCPConfig(); err != nil {
 			mcpLog.Warn("mcp_config_regen_failed", slog.String("error", err.Error()))
 		}
@@ -5580,28 +5696,10 @@ func parseGeminiLatestUserPrompt(data []byte) (string, error) {
 // getGeminiLastResponse extracts the last assistant message from Gemini's JSON file
 func (i *Instance) getGeminiLastResponse() (*ResponseOutput, error) {
 	// Require stored session ID - no fallback to file scanning
-	if i.GeminiSessionID == "" || len(i.GeminiSessionID) < 8 {
-		return nil, fmt.Errorf("no Gemini session ID available for this instance")
-	}
-
-	sessionsDir := GetGeminiSessionsDir(i.ProjectPath)
-
-	// Find file by session ID (first 8 chars in filename)
-	// Filename format is session-YYYY-MM-DDTHH-MM-<uuid8>.json
-	pattern := filepath.Join(sessionsDir, "session-*-"+i.GeminiSessionID[:8]+".json")
-	files, _ := filepath.Glob(pattern)
-
-	// Fallback: cross-project search if not found in expected location
-	if len(files) == 0 {
-		if fallbackPath := findGeminiSessionInAllProjects(i.GeminiSessionID); fallbackPath != "" {
-			files = []string{fallbackPath}
-		}
-	}
-
-	if len(files) == 0 {
-		return nil, fmt.Errorf("session file not found for ID: %s", i.GeminiSessionID)
+	sessionFile, err := i.geminiSessionFile()
+	if err != nil {
+		return nil, err
 	}
-	sessionFile := files[0]
 
 	// Read and parse the JSON file
 	data, err := os.ReadFile(sessionFile)
@@ -5859,6 +5957,7 @@ func (i *Instance) killInternal(sync bool) error {
 		}
 	}
 	i.Status = StatusStopped
+	i.recordEventLocked(EventStopped, "", tmuxErr)
 	i.mu.Unlock()
 
 	// Clean up sandbox container (only if name matches our prefix convention).
@@ -5931,6 +6030,12 @@ func (i *Instance) killInternal(sync bool) error {
 // same instance. A legitimate manual restart still proceeds because the
 // stamp from any prior spawn pre-dates the new caller's beforeLock.
 func (i *Instance) Restart() error {
+	return i.RestartWithReason(RestartReasonManual)
+}
+
+// RestartWithReason is Restart with the reason recorded in RestartHistory.
+// A restart skipped by the spawn guard is not recorded.
+func (i *Instance) RestartWithReason(reason RestartReason) (retErr error) {
 	beforeLock := nowFn()
 	release, lockErr := acquireInstanceSpawnLock(i.ID)
 	if lockErr != nil {
@@ -5941,6 +6046,7 @@ func (i *Instance) Restart() error {
 		return nil
 	}
 	defer recordInstanceSpawn(i.ID)
+	defer func() { i.recordRestart(reason, false, retErr) }()
 
 	mcpLog.Debug(
 		"restart_called",
@@ -6216,13 +6322,9 @@ func (i *Instance) Restart() error {
 		sessionID := i.GetGenericSessionID()
 
 		// The session ID env var is propagated via host-side SetEnvironment after tmux start.
-		var rawCmd string
+		rawCmd := toolDef.ResumeInvocation(i.expandToolCommand(i.Command), sessionID)
 		if toolDef.DangerousMode && toolDef.DangerousFlag != "" {
-			rawCmd = fmt.Sprintf("%s %s %s %s",
-				i.Command, toolDef.ResumeFlag, sessionID, toolDef.DangerousFlag)
-		} else {
-			rawCmd = fmt.Sprintf("%s %s %s",
-				i.Command, toolDef.ResumeFlag, sessionID)
+			rawCmd += " " + toolDef.DangerousFlag
 		}
 		resumeCmd, containerName, err := i.prepareCommand(rawCmd)
 		if err != nil {
@@ -6272,16 +6374,8 @@ func (i *Instance) Restart() error {
 	// on the restart path too (issue #59, v1.7.68).
 	i.prepareWorkerScratchConfigDirForSpawn() // also runs plugin auto-install per fix C1
 
-	var command string
-	if IsClaudeCompatible(i.Tool) && i.ClaudeSessionID != "" {
-		command = i.buildClaudeResumeCommand()
-	} else if i.Tool == "gemini" && i.GeminiSessionID != "" {
-		command = i.buildGeminiCommand("gemini")
-	} else if i.Tool == "opencode" && i.OpenCodeSessionID != "" {
-		command = i.buildOpenCodeCommand("opencode")
-	} else if IsCodexCompatible(i.Tool) && i.CodexSessionID != "" {
-		command = i.buildCodexCommand(i.Command)
-	} else {
+	command := ToolAdapterFor(i.Tool).ResumeCommand(i)
+	if command == "" {
 		// Route to appropriate command builder based on tool
 		switch {
 		case IsClaudeCompatible(i.Tool):
@@ -6306,6 +6400,8 @@ func (i *Instance) Restart() error {
 			command = i.buildCursorCommand(i.Command, true)
 		case i.Tool == "hermes":
 			command = i.buildHermesCommand(i.Command)
+		case i.Tool == "aider":
+			command = i.buildAiderCommand(i.Command)
 		default:
 			// Check if this is a custom tool with session resume config
 			if toolDef := GetToolDef(i.Tool); toolDef != nil {
@@ -6329,6 +6425,7 @@ func (i *Instance) Restart() error {
 	// Build tmux option overrides from config (e.g. allow-passthrough = "all").
 	// Sandbox sessions also get remain-on-exit for dead-pane detection.
 	i.tmuxSession.OptionOverrides = i.buildTmuxOptionOverrides()
+	i.tmuxSession.Hooks = i.buildTmuxHooks()
 	i.tmuxSession.RunCommandAsInitialProcess = i.IsSandboxed() || i.Tool != "shell"
 	i.applyLaunchSettingsFromConfig()
 
@@ -6398,7 +6495,8 @@ func (i *Instance) Restart() error {
 // RestartFresh restarts the current tool without resuming the existing tool session.
 // This recreates the tmux session and clears the stored tool session binding first,
 // so the next start gets a brand-new tool session ID.
-func (i *Instance) RestartFresh() error {
+func (i *Instance) RestartFresh() (retErr error) {
+	defer func() { i.recordRestart(RestartReasonManual, true, retErr) }()
 	i.prepareRestartMCPConfig()
 
 	i.clearSessionBindingForFreshStart()
@@ -6540,7 +6638,62 @@ func (i *Instance) SetGeminiModel(model string) error {
 
 	// Restart if the session is running so it picks up the new model
 	if i.Exists() {
-		return i.Restart()
+		return i.RestartWithReason(RestartReasonConfigChange)
+	}
+	return nil
+}
+
+// SetClaudeModel sets the Claude model and effort level for this session and
+// triggers a restart if running. An empty model falls back to
+// [claude].default_model; an empty effort leaves Claude's default thinking.
+func (i *Instance) SetClaudeModel(model, effort string) error {
+	opts := i.GetClaudeOptions()
+	if opts == nil {
+		userConfig, _ := LoadUserConfig()
+		opts = NewClaudeOptions(userConfig)
+	}
+	opts.Model = model
+	opts.Effort = effort
+	if err := i.SetClaudeOptions(opts); err != nil {
+		return err
+	}
+	sessionLog.Debug(
+		"claude_model_set",
+		slog.String("model", model),
+		slog.String("effort", effort),
+		slog.String("session_id", i.ID),
+		slog.String("title", i.Title),
+	)
+
+	if i.Exists() {
+		return i.RestartWithReason(RestartReasonConfigChange)
+	}
+	return nil
+}
+
+// SetClaudePermissionMode switches this session's permission mode (see
+// ClaudePermissionModes) and triggers a restart if running.
+func (i *Instance) SetClaudePermissionMode(mode string) error {
+	opts := i.GetClaudeOptions()
+	if opts == nil {
+		userConfig, _ := LoadUserConfig()
+		opts = NewClaudeOptions(userConfig)
+	}
+	if err := opts.SetPermissionMode(mode); err != nil {
+		return err
+	}
+	if err := i.SetClaudeOptions(opts); err != nil {
+		return err
+	}
+	sessionLog.Debug(
+		"claude_permission_mode_set",
+		slog.String("mode", mode),
+		slog.String("session_id", i.ID),
+		slog.String("title", i.Title),
+	)
+
+	if i.Exists() {
+		return i.RestartWithReason(RestartReasonConfigChange)
 	}
 	return nil
 }
@@ -6548,7 +6701,7 @@ func (i *Instance) SetGeminiModel(model string) error {
 // SupportsLaunchModel reports whether a newly-created session can receive an
 // explicit model override through Agent Deck's generic session creation path.
 func SupportsLaunchModel(tool string) bool {
-	return IsClaudeCompatible(tool) || tool == "gemini" || tool == "opencode" || IsCodexCompatible(tool)
+	return IsClaudeCompatible(tool) || tool == "gemini" || tool == "opencode" || IsCodexCompatible(tool) || tool == "aider"
 }
 
 // ApplyLaunchModel stores a per-session model override in the tool-specific
@@ -6587,6 +6740,14 @@ func (i *Instance) ApplyLaunchModel(model string) error {
 		}
 		opts.Model = model
 		return i.SetCodexOptions(opts)
+	case i.Tool == "aider":
+		opts := i.GetAiderOptions()
+		if opts == nil {
+			userConfig, _ := LoadUserConfig()
+			opts = NewAiderOptions(userConfig)
+		}
+		opts.Model = model
+		return i.SetAiderOptions(opts)
 	default:
 		return fmt.Errorf("model selection is not supported for tool %q", i.Tool)
 	}
@@ -6625,6 +6786,13 @@ func (i *Instance) ClearLaunchModel() error {
 		}
 		opts.Model = ""
 		return i.SetCodexOptions(opts)
+	case i.Tool == "aider":
+		opts := i.GetAiderOptions()
+		if opts == nil {
+			return nil
+		}
+		opts.Model = ""
+		return i.SetAiderOptions(opts)
 	default:
 		return nil
 	}
@@ -6714,7 +6882,7 @@ func (i *Instance) CanRestartFresh() bool {
 	if i.Tool == "opencode" {
 		return i.OpenCodeSessionID != ""
 	}
-	if i.Tool == "codex" {
+	if IsCodexCompatible(i.Tool) {
 		return i.CodexSessionID != ""
 	}
 	return i.CanRestartGeneric()
@@ -6852,11 +7020,17 @@ func (i *Instance) buildClaudeForkCommandForTarget(target *Instance, opts *Claud
 	// enabling proper job control (Ctrl+Z suspend / fg resume).
 	forkUUID := generateUUID()
 	target.ClaudeSessionID = forkUUID
+	resumeAt := ""
+	if opts.ResumeAt != "" {
+		// Branch from an earlier point: Claude keeps the history up to and
+		// including this assistant message and drops everything after it.
+		resumeAt = " --resume-session-at " + shellescape.Quote(opts.ResumeAt)
+	}
 	cmd := fmt.Sprintf(
 		`cd '%s' && `+
-			`%sexec claude --session-id "%s" --resume %s --fork-session%s`,
+			`%sexec claude --session-id "%s" --resume %s --fork-session%s%s`,
 		workDir,
-		bashExportPrefix, forkUUID, i.ClaudeSessionID, extraFlags)
+		bashExportPrefix, forkUUID, i.ClaudeSessionID, resumeAt, extraFlags)
 	cmd, err := i.applyWrapper(cmd)
 	if err != nil {
 		return "", err
@@ -6946,6 +7120,15 @@ func (i *Instance) CreateForkedInstanceWithOptions(
 // tool-specific fork implementation. opts is the shared fork carrier for
 // worktree fields; non-Claude tool options continue to come from global config.
 func (i *Instance) CreateForkedInstanceForTool(newTitle, newGroupPath string, opts *ClaudeOptions) (*Instance, string, error) {
+	forked, cmd, err := i.createForkedInstanceForTool(newTitle, newGroupPath, opts)
+	if err == nil && forked != nil {
+		i.recordEvent(EventForked, fmt.Sprintf("into %q", forked.Title), nil)
+		forked.recordEvent(EventForked, fmt.Sprintf("from %q", i.Title), nil)
+	}
+	return forked, cmd, err
+}
+
+func (i *Instance) createForkedInstanceForTool(newTitle, newGroupPath string, opts *ClaudeOptions) (*Instance, string, error) {
 	switch {
 	case i.Tool == "opencode":
 		workDir := i.ProjectPath
@@ -7298,6 +7481,20 @@ func (i *Instance) CachedSubstate() Substate {
 	return tmuxSess.CachedSubstate()
 }
 
+// CachedAttention returns the attention label from the last status check for
+// a waiting session, and AttentionNone for any other status so a stale label
+// never outlives the wait it described.
+func (i *Instance) CachedAttention() Attention {
+	if i.GetStatusThreadSafe() != StatusWaiting {
+		return AttentionNone
+	}
+	tmuxSess := i.GetTmuxSession()
+	if tmuxSess == nil {
+		return AttentionNone
+	}
+	return tmuxSess.CachedAttention()
+}
+
 // SetAcknowledgedFromShared applies an acknowledgment from another TUI instance
 // (read from SQLite). This transitions a YELLOW (waiting) session to GRAY (idle)
 // without requiring the user to interact with this specific TUI instance.
@@ -7501,11 +7698,27 @@ func (i *Instance) CaptureLoadedMCPs() {
 	i.LoadedMCPNames = mcpInfo.AllNames()
 }
 
-// regenerateMCPConfig regenerates .mcp.json with current pool status
-// If socket pool is running, MCPs will use socket configs (nc -U /tmp/...)
+// regenerateMCPConfig regenerates the session's MCP config (.mcp.json, or
+// settings.json for Gemini) with current pool status
+// If socket pool is running, MCPs will use socket configs (agent-deck mcp-proxy /tmp/...)
 // Otherwise, MCPs will use stdio configs (npx ...)
-// Returns error if .mcp.json write fails
+// Returns error if the config write fails
 func (i *Instance) regenerateMCPConfig() error {
+	if i.Tool == "gemini" {
+		// Gemini only has global MCPs. Rewriting them picks up pool sockets
+		// that were not ready when the session last started.
+		globalMCPs := GetGeminiMCPNames()
+		if len(globalMCPs) == 0 {
+			return nil
+		}
+		if err := writeGeminiMCPServers(globalMCPs, true); err != nil {
+			mcpLog.Debug("regen_gemini_mcp_failed", slog.String("error", err.Error()))
+			return fmt.Errorf("failed to regenerate Gemini MCP settings: %w", err)
+		}
+		mcpLog.Debug("regen_gemini_mcp_succeeded", slog.String("title", i.Title), slog.Int("mcp_count", len(globalMCPs)))
+		return nil
+	}
+
 	if i.Tool == "cursor" {
 		ClearCursorMCPCache(i.ProjectPath)
 		mcpInfo := i.GetMCPInfo()
@@ -207,3 +207,30 @@ func TestCreateForkedCodexInstance_ShellQuotesEnvPrefix(t *testing.T) {
 		t.Fatalf("AGENTDECK_TITLE must be shell-quoted via shellescape.Quote; got: %s", cmd)
 	}
 }
+
+func TestCanRestartFresh_CodexCompatibleTool(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	ClearUserConfigCache()
+	t.Cleanup(ClearUserConfigCache)
+
+	cfg := &UserConfig{
+		Tools: map[string]ToolDef{
+			"my-codex": {Command: "codex-wrapper", CompatibleWith: "codex"},
+		},
+	}
+	if err := SaveUserConfig(cfg); err != nil {
+		t.Fatalf("SaveUserConfig: %v", err)
+	}
+	ClearUserConfigCache()
+
+	inst := NewInstanceWithTool("cx", "/tmp/original", "my-codex")
+	if inst.CanRestartFresh() {
+		t.Fatal("CanRestartFresh should be false before a Codex session ID is bound")
+	}
+	inst.CodexSessionID = "eeeeeeee-2222-3333-4444-555555555555"
+	if !inst.CanRestartFresh() {
+		t.Fatal("CanRestartFresh should be true for a Codex-compatible tool with a bound session ID")
+	}
+}
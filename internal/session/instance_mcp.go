@@ -3,6 +3,7 @@ package session
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 )
 
 // ToolSupportsMCPManager reports whether the TUI/CLI MCP surfaces apply to this tool.
@@ -117,12 +118,31 @@ func (i *Instance) MCPInfoForLocalAttach() *MCPInfo {
 
 // WriteLocalMCPConfig writes catalog MCPs to this instance's project-local MCP file.
 func (i *Instance) WriteLocalMCPConfig(names []string) error {
-	return WriteLocalMCPConfigForTool(i.Tool, i.ProjectPath, names)
+	err := WriteLocalMCPConfigForTool(i.Tool, i.ProjectPath, names)
+	i.recordMCPChange("local", names, err)
+	return err
 }
 
 // WriteGlobalMCPConfig writes catalog MCPs to this instance's global MCP store.
 func (i *Instance) WriteGlobalMCPConfig(names []string) error {
-	return WriteGlobalMCPConfigForTool(i.Tool, names)
+	err := WriteGlobalMCPConfigForTool(i.Tool, names)
+	i.recordMCPChange("global", names, err)
+	return err
+}
+
+// RecordMCPChange logs an MCP set written for this session by a caller that
+// writes the config itself (the TUI MCP manager) rather than through
+// WriteLocalMCPConfig/WriteGlobalMCPConfig.
+func (i *Instance) RecordMCPChange(scope string, names []string) {
+	i.recordMCPChange(scope, names, nil)
+}
+
+func (i *Instance) recordMCPChange(scope string, names []string, err error) {
+	detail := "(none)"
+	if len(names) > 0 {
+		detail = strings.Join(names, ", ")
+	}
+	i.recordEvent(EventMCPChange, scope+": "+detail, err)
 }
 
 // InvalidateProjectMCPIntegrationsCache clears MCP read caches for this instance's project.
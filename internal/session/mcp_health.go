@@ -0,0 +1,127 @@
+package session
+
+import (
+	"net"
+	"net/url"
+	"os/exec"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/mcppool"
+)
+
+// MCPHealthState is the outcome of probing one MCP server.
+type MCPHealthState int
+
+const (
+	MCPHealthUnknown MCPHealthState = iota // not probed (e.g. not defined in config.toml)
+	MCPHealthy
+	MCPUnhealthy
+)
+
+// Symbol returns the one-character marker shown next to the MCP name.
+func (s MCPHealthState) Symbol() string {
+	switch s {
+	case MCPHealthy:
+		return "✓"
+	case MCPUnhealthy:
+		return "✗"
+	default:
+		return "?"
+	}
+}
+
+// MCPHealth is the result of probing one MCP server a session uses.
+type MCPHealth struct {
+	Name   string
+	State  MCPHealthState
+	Detail string // what was checked, or why it failed
+}
+
+// mcpProbeTimeout bounds the network dial for HTTP/SSE MCPs.
+const mcpProbeTimeout = 2 * time.Second
+
+// ProbeMCPHealth checks every MCP in info, in Global, Project, Local order.
+// Pooled MCPs are checked through the pool socket, HTTP/SSE MCPs by dialing
+// their endpoint, and other stdio MCPs by resolving their command, since
+// their process belongs to the agent. It does blocking I/O; call it off the
+// UI goroutine.
+func ProbeMCPHealth(info *MCPInfo) []MCPHealth {
+	if info == nil || !info.HasAny() {
+		return nil
+	}
+	var names []string
+	seen := make(map[string]bool)
+	for _, list := range [][]string{info.Global, info.Project, info.Local()} {
+		for _, name := range list {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	defs := GetAvailableMCPs()
+	pool := GetGlobalPool()
+	health := make([]MCPHealth, 0, len(names))
+	for _, name := range names {
+		health = append(health, probeMCP(name, defs, pool))
+	}
+	return health
+}
+
+func probeMCP(name string, defs map[string]MCPDef, pool *mcppool.Pool) MCPHealth {
+	h := MCPHealth{Name: name}
+	if pool != nil {
+		if pooled, err := pool.Probe(name); pooled {
+			if err != nil {
+				h.State, h.Detail = MCPUnhealthy, "pool: "+err.Error()
+			} else {
+				h.State, h.Detail = MCPHealthy, "pool socket accepting connections"
+			}
+			return h
+		}
+	}
+
+	def, ok := defs[name]
+	if !ok {
+		h.Detail = "not defined in config.toml"
+		return h
+	}
+	if def.IsHTTP() {
+		addr, err := mcpDialAddr(def.URL)
+		if err != nil {
+			h.State, h.Detail = MCPUnhealthy, "bad url: "+err.Error()
+			return h
+		}
+		conn, err := net.DialTimeout("tcp", addr, mcpProbeTimeout)
+		if err != nil {
+			h.State, h.Detail = MCPUnhealthy, "unreachable: "+err.Error()
+			return h
+		}
+		_ = conn.Close()
+		h.State, h.Detail = MCPHealthy, addr+" reachable"
+		return h
+	}
+	if _, err := exec.LookPath(def.Command); err != nil {
+		h.State, h.Detail = MCPUnhealthy, "command not found: "+def.Command
+		return h
+	}
+	h.State, h.Detail = MCPHealthy, "command found (runs inside the session)"
+	return h
+}
+
+// mcpDialAddr returns the host:port to dial for an HTTP/SSE MCP URL.
+func mcpDialAddr(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
@@ -0,0 +1,55 @@
+package session
+
+import (
+	"net"
+	"testing"
+)
+
+func TestProbeMCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddr := closed.Addr().String()
+	closed.Close()
+
+	defs := map[string]MCPDef{
+		"shell":   {Command: "sh"},
+		"missing": {Command: "agent-deck-no-such-mcp-binary"},
+		"web":     {URL: "http://" + ln.Addr().String() + "/mcp"},
+		"down":    {URL: "http://" + closedAddr + "/mcp"},
+	}
+	tests := []struct {
+		name string
+		want MCPHealthState
+	}{
+		{"shell", MCPHealthy},
+		{"missing", MCPUnhealthy},
+		{"web", MCPHealthy},
+		{"down", MCPUnhealthy},
+		{"unknown", MCPHealthUnknown},
+	}
+	for _, tt := range tests {
+		got := probeMCP(tt.name, defs, nil)
+		if got.State != tt.want {
+			t.Errorf("probeMCP(%q) = %v (%s), want %v", tt.name, got.State, got.Detail, tt.want)
+		}
+	}
+}
+
+func TestMCPDialAddr(t *testing.T) {
+	for in, want := range map[string]string{
+		"http://localhost:8000/mcp": "localhost:8000",
+		"https://mcp.example.com/x": "mcp.example.com:443",
+		"http://mcp.example.com":    "mcp.example.com:80",
+	} {
+		if got, err := mcpDialAddr(in); err != nil || got != want {
+			t.Errorf("mcpDialAddr(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+}
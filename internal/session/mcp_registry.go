@@ -0,0 +1,204 @@
+package session
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/asheshgoplani/agent-deck/internal/agentpaths"
+)
+
+// MCPRegistryFileName is the user's own registry of known MCP servers, next
+// to config.toml.
+const MCPRegistryFileName = "mcp-registry.toml"
+
+// mcpRegistryFetchTimeout bounds the [mcp_registry] url download.
+const mcpRegistryFetchTimeout = 10 * time.Second
+
+// builtinMCPRegistry lists well-known MCP servers so the registry browser is
+// useful without any setup.
+//
+//go:embed mcp_registry.toml
+var builtinMCPRegistry string
+
+// MCPRegistrySettings configures where the registry browser looks for MCP
+// servers beyond the built-in list and mcp-registry.toml.
+type MCPRegistrySettings struct {
+	// URL is a remote index in the mcp-registry.toml format, fetched each
+	// time the registry browser opens.
+	URL string `toml:"url,omitempty"`
+}
+
+// MCPRegistryEntry is one installable MCP server.
+type MCPRegistryEntry struct {
+	Name        string `toml:"name"`
+	Description string `toml:"description,omitempty"`
+	Homepage    string `toml:"homepage,omitempty"`
+
+	// Install is an optional one-time setup command (e.g. a docker pull),
+	// shown to the user but never run by agent-deck.
+	Install string `toml:"install,omitempty"`
+
+	// Env lists environment variables the server needs; the browser asks
+	// for their values before writing the entry to config.toml.
+	Env []string `toml:"env,omitempty"`
+
+	// MCP is the [mcps.<name>] definition written to config.toml.
+	MCP MCPDef `toml:"mcp"`
+
+	// Source is where the entry came from: "built-in", the registry file
+	// path, or the index URL.
+	Source string `toml:"-"`
+}
+
+// CommandLine renders how the server is launched, for display.
+func (e MCPRegistryEntry) CommandLine() string {
+	if e.MCP.IsHTTP() {
+		return e.MCP.GetTransport() + " " + e.MCP.URL
+	}
+	return strings.Join(append([]string{e.MCP.Command}, e.MCP.Args...), " ")
+}
+
+type mcpRegistryDoc struct {
+	Servers []MCPRegistryEntry `toml:"servers"`
+}
+
+// ParseMCPRegistry decodes a registry document and drops entries without a
+// name or a launch command/URL.
+func ParseMCPRegistry(data, source string) ([]MCPRegistryEntry, error) {
+	var doc mcpRegistryDoc
+	if _, err := toml.Decode(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	entries := make([]MCPRegistryEntry, 0, len(doc.Servers))
+	for _, e := range doc.Servers {
+		if e.Name == "" || (e.MCP.Command == "" && e.MCP.URL == "") {
+			continue
+		}
+		e.Source = source
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// GetMCPRegistryPath returns the path of the user's mcp-registry.toml.
+func GetMCPRegistryPath() (string, error) {
+	return agentpaths.EffectiveConfigPath(MCPRegistryFileName)
+}
+
+// LoadMCPRegistry returns every known MCP server sorted by name: the
+// built-in list, then mcp-registry.toml, then the [mcp_registry] url index,
+// later sources replacing earlier entries of the same name. A missing
+// registry file is not an error; other per-source failures are returned as
+// warnings alongside whatever did load.
+func LoadMCPRegistry(ctx context.Context) ([]MCPRegistryEntry, []string) {
+	byName := make(map[string]MCPRegistryEntry)
+	var warnings []string
+	merge := func(entries []MCPRegistryEntry, err error) {
+		if err != nil {
+			warnings = append(warnings, err.Error())
+		}
+		for _, e := range entries {
+			byName[e.Name] = e
+		}
+	}
+
+	merge(ParseMCPRegistry(builtinMCPRegistry, "built-in"))
+
+	if path, err := GetMCPRegistryPath(); err == nil {
+		if data, err := os.ReadFile(path); err == nil {
+			merge(ParseMCPRegistry(string(data), path))
+		} else if !errors.Is(err, os.ErrNotExist) {
+			warnings = append(warnings, err.Error())
+		}
+	}
+
+	if cfg, err := LoadUserConfig(); err == nil && cfg != nil && cfg.MCPRegistry.URL != "" {
+		merge(fetchMCPRegistry(ctx, cfg.MCPRegistry.URL))
+	}
+
+	entries := make([]MCPRegistryEntry, 0, len(byName))
+	for _, e := range byName {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, warnings
+}
+
+func fetchMCPRegistry(ctx context.Context, url string) ([]MCPRegistryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, mcpRegistryFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("mcp_registry url: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("mcp_registry url: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("mcp_registry url: %s returned %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, fmt.Errorf("mcp_registry url: %w", err)
+	}
+	return ParseMCPRegistry(string(data), url)
+}
+
+// ErrMCPExists is returned by InstallMCPFromRegistry when config.toml
+// already defines an MCP with the requested name.
+var ErrMCPExists = errors.New("MCP already defined in config.toml")
+
+// InstallMCPFromRegistry writes entry to config.toml as [mcps.<name>], with
+// env holding the values for entry.Env, so it shows up in the MCP Manager
+// and the pool.
+func InstallMCPFromRegistry(entry MCPRegistryEntry, name string, env map[string]string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("MCP name is required")
+	}
+	cfg, err := LoadUserConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if _, exists := cfg.MCPs[name]; exists {
+		return fmt.Errorf("%q: %w", name, ErrMCPExists)
+	}
+
+	def := entry.MCP
+	if def.Description == "" {
+		def.Description = entry.Description
+	}
+	if len(env) > 0 {
+		merged := make(map[string]string, len(def.Env)+len(env))
+		for k, v := range def.Env {
+			merged[k] = v
+		}
+		for k, v := range env {
+			merged[k] = v
+		}
+		def.Env = merged
+	}
+
+	if cfg.MCPs == nil {
+		cfg.MCPs = make(map[string]MCPDef)
+	}
+	cfg.MCPs[name] = def
+	if err := SaveUserConfig(cfg); err != nil {
+		delete(cfg.MCPs, name)
+		return err
+	}
+	_, _ = ReloadUserConfig()
+	return nil
+}
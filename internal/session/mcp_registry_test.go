@@ -0,0 +1,106 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPRegistry_BuiltinEntriesAreInstallable(t *testing.T) {
+	entries, err := ParseMCPRegistry(builtinMCPRegistry, "built-in")
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+	seen := map[string]bool{}
+	for _, e := range entries {
+		assert.False(t, seen[e.Name], "duplicate built-in entry %q", e.Name)
+		seen[e.Name] = true
+		assert.NotEmpty(t, e.Description, e.Name)
+		assert.NotEmpty(t, e.MCP.Command, e.Name)
+	}
+}
+
+func TestParseMCPRegistry_SkipsIncompleteEntries(t *testing.T) {
+	entries, err := ParseMCPRegistry(`
+[[servers]]
+name = "no-command"
+
+[[servers]]
+name = "remote"
+[servers.mcp]
+url = "https://mcp.example.com/mcp"
+`, "test")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "remote", entries[0].Name)
+	assert.Equal(t, "http https://mcp.example.com/mcp", entries[0].CommandLine())
+
+	_, err = ParseMCPRegistry("[[servers]\n", "broken.toml")
+	assert.ErrorContains(t, err, "broken.toml")
+}
+
+func TestLoadMCPRegistry_MergesFileAndIndex(t *testing.T) {
+	home := withTempHome(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "[[servers]]\nname = \"team-db\"\ndescription = \"from index\"\n[servers.mcp]\ncommand = \"team-db-mcp\"\n")
+	}))
+	defer srv.Close()
+	writeConfig(t, home, fmt.Sprintf("[mcp_registry]\nurl = %q\n", srv.URL))
+
+	path, err := GetMCPRegistryPath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("[[servers]]\nname = \"memory\"\ndescription = \"patched\"\n[servers.mcp]\ncommand = \"my-memory\"\n"), 0o600))
+
+	entries, warnings := LoadMCPRegistry(context.Background())
+	assert.Empty(t, warnings)
+	byName := map[string]MCPRegistryEntry{}
+	for _, e := range entries {
+		byName[e.Name] = e
+	}
+	assert.Equal(t, "my-memory", byName["memory"].MCP.Command, "the registry file replaces the built-in entry")
+	assert.Equal(t, path, byName["memory"].Source)
+	assert.Equal(t, "from index", byName["team-db"].Description)
+	assert.Contains(t, byName, "exa", "built-in entries are kept")
+}
+
+func TestLoadMCPRegistry_IndexFailureIsAWarning(t *testing.T) {
+	home := withTempHome(t)
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	writeConfig(t, home, fmt.Sprintf("[mcp_registry]\nurl = %q\n", srv.URL))
+
+	entries, warnings := LoadMCPRegistry(context.Background())
+	assert.NotEmpty(t, entries)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "404")
+}
+
+func TestInstallMCPFromRegistry(t *testing.T) {
+	home := withTempHome(t)
+	writeConfig(t, home, "[mcps.memory]\ncommand = \"npx\"\n")
+
+	entry := MCPRegistryEntry{
+		Name:        "exa",
+		Description: "Web search",
+		Env:         []string{"EXA_API_KEY"},
+		MCP:         MCPDef{Command: "npx", Args: []string{"-y", "exa-mcp-server"}},
+	}
+	require.NoError(t, InstallMCPFromRegistry(entry, "search", map[string]string{"EXA_API_KEY": "k"}))
+
+	mcps := GetAvailableMCPs()
+	require.Contains(t, mcps, "memory", "existing MCPs are kept")
+	got := mcps["search"]
+	assert.Equal(t, []string{"-y", "exa-mcp-server"}, got.Args)
+	assert.Equal(t, "Web search", got.Description)
+	assert.Equal(t, map[string]string{"EXA_API_KEY": "k"}, got.Env)
+
+	err := InstallMCPFromRegistry(entry, "memory", nil)
+	assert.True(t, errors.Is(err, ErrMCPExists), "got %v", err)
+	assert.Error(t, InstallMCPFromRegistry(entry, "  ", nil))
+}
@@ -24,6 +24,7 @@ const (
 	FieldExtraArgs          = "extra-args"
 	FieldColor              = "color"
 	FieldNotes              = "notes"
+	FieldTags               = "tags"
 	FieldClaudeSessionID    = "claude-session-id"
 	FieldGeminiSessionID    = "gemini-session-id"
 	FieldOpenCodeSessionID  = "opencode-session-id"
@@ -32,9 +33,10 @@ const (
 	FieldNoTransitionNotify = "no-transition-notify"
 	FieldSkipPermissions    = "skip-permissions"
 	FieldAutoMode           = "auto-mode"
-	FieldAccount            = "account"      // #924 per-session named account slot
-	FieldIdleTimeout        = "idle-timeout" // #1143 auto-stop dormant sessions
-	FieldPin                = "pin"          // pin-sessions: anchor top/bottom of group
+	FieldPermissionMode     = "permission-mode" // plan / default / auto / dangerously-skip
+	FieldAccount            = "account"         // #924 per-session named account slot
+	FieldIdleTimeout        = "idle-timeout"    // #1143 auto-stop dormant sessions
+	FieldPin                = "pin"             // pin-sessions: anchor top/bottom of group
 	// FieldModel persists the operator's selected per-session model (#1436,
 	// follow-up to #1431). Tool-agnostic: routes to each tool's existing model
 	// store (ClaudeOptions.Model, GeminiModel, OpenCodeOptions.Model,
@@ -56,6 +58,7 @@ var ValidMutableFields = []string{
 	FieldExtraArgs,
 	FieldColor,
 	FieldNotes,
+	FieldTags,
 	FieldClaudeSessionID,
 	FieldGeminiSessionID,
 	FieldOpenCodeSessionID,
@@ -64,6 +67,7 @@ var ValidMutableFields = []string{
 	FieldNoTransitionNotify,
 	FieldSkipPermissions,
 	FieldAutoMode,
+	FieldPermissionMode,
 	FieldAccount,
 	FieldIdleTimeout,
 	FieldPin,
@@ -80,7 +84,7 @@ const (
 func RestartPolicyFor(field string) FieldRestartPolicy {
 	switch field {
 	case FieldCommand, FieldWrapper, FieldTool, FieldChannels, FieldPlugins, FieldExtraArgs, FieldPath,
-		FieldSkipPermissions, FieldAutoMode, FieldAccount, FieldModel:
+		FieldSkipPermissions, FieldAutoMode, FieldPermissionMode, FieldAccount, FieldModel:
 		return FieldRestartRequired
 	default:
 		return FieldLive
@@ -177,6 +181,14 @@ func SetField(inst *Instance, field, value string, extraArgsTokens []string) (ol
 		oldValue = inst.Notes
 		inst.Notes = value
 
+	case FieldTags:
+		oldValue = strings.Join(inst.Tags, ",")
+		tags, err := ParseTags(value)
+		if err != nil {
+			return oldValue, nil, &MutationError{Field: field, Msg: err.Error()}
+		}
+		inst.Tags = tags
+
 	case FieldColor:
 		oldValue = inst.Color
 		trimmed := strings.TrimSpace(value)
@@ -348,6 +360,12 @@ func SetField(inst *Instance, field, value string, extraArgsTokens []string) (ol
 			return oldValue, nil, err
 		}
 
+	case FieldPermissionMode:
+		oldValue, err = setClaudePermissionMode(inst, strings.TrimSpace(value))
+		if err != nil {
+			return oldValue, nil, err
+		}
+
 	case FieldAccount:
 		// #924 per-session named account slot. Stored verbatim; an
 		// unconfigured name silently falls through the resolver chain.
@@ -446,6 +464,34 @@ func setClaudeOptionBool(inst *Instance, field, value string, get func(*ClaudeOp
 	return oldVal, nil
 }
 
+// setClaudePermissionMode is setClaudeOptionBool for the permission mode,
+// which spans the skip/plan/auto bools.
+func setClaudePermissionMode(inst *Instance, mode string) (string, error) {
+	if !IsClaudeCompatible(inst.Tool) {
+		return "", &MutationError{
+			Field: FieldPermissionMode,
+			Msg:   fmt.Sprintf("%s only supported for claude-compatible tools (this session's tool is %q)", FieldPermissionMode, inst.Tool),
+		}
+	}
+	opts, err := UnmarshalClaudeOptions(inst.ToolOptionsJSON)
+	if err != nil {
+		return "", &MutationError{Field: FieldPermissionMode, Msg: fmt.Sprintf("failed to read existing claude options: %v", err)}
+	}
+	if opts == nil {
+		opts = &ClaudeOptions{}
+	}
+	oldVal := opts.PermissionMode()
+	if serr := opts.SetPermissionMode(mode); serr != nil {
+		return oldVal, &MutationError{Field: FieldPermissionMode, Msg: serr.Error()}
+	}
+	raw, merr := MarshalToolOptions(opts)
+	if merr != nil {
+		return oldVal, &MutationError{Field: FieldPermissionMode, Msg: fmt.Sprintf("failed to serialize claude options: %v", merr)}
+	}
+	inst.ToolOptionsJSON = json.RawMessage(raw)
+	return oldVal, nil
+}
+
 // makeSessionEnvPostCommit returns a closure that propagates the new session
 // ID to a running tmux session via `tmux set-environment`. nil when no
 // tmux session is bound; captures sess+socket+value so the closure can run
@@ -338,6 +338,32 @@ func TestSetField_AutoMode_PreservesSkipPermissions(t *testing.T) {
 	}
 }
 
+// permission-mode replaces whichever of skip/plan/auto was set, so the
+// modes stay mutually exclusive in the stored options.
+func TestSetField_PermissionMode(t *testing.T) {
+	inst := &Instance{Tool: "claude"}
+	if _, _, err := SetField(inst, FieldSkipPermissions, "true", nil); err != nil {
+		t.Fatalf("SetField(skip=true) failed: %v", err)
+	}
+	old, _, err := SetField(inst, FieldPermissionMode, "plan", nil)
+	if err != nil {
+		t.Fatalf("SetField(permission-mode=plan) failed: %v", err)
+	}
+	if old != ClaudePermissionSkip {
+		t.Errorf("old value = %q, want %q", old, ClaudePermissionSkip)
+	}
+	opts, _ := UnmarshalClaudeOptions(inst.ToolOptionsJSON)
+	if opts == nil || opts.SkipPermissions || !opts.PlanMode || opts.PermissionMode() != ClaudePermissionPlan {
+		t.Errorf("after permission-mode=plan got %+v", opts)
+	}
+	if _, _, err := SetField(inst, FieldPermissionMode, "yolo", nil); err == nil {
+		t.Error("expected error for unknown permission mode")
+	}
+	if _, _, err := SetField(&Instance{Tool: "shell"}, FieldPermissionMode, "plan", nil); err == nil {
+		t.Error("expected error setting permission-mode on non-claude session")
+	}
+}
+
 // Skip/auto only make sense on claude-compatible tools; SetField must
 // reject them on shell/gemini sessions instead of silently encoding flags
 // that the launcher would never emit.
@@ -3,9 +3,11 @@ package session
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 // NotificationEntry represents a waiting session in the notification bar
@@ -13,9 +15,12 @@ type NotificationEntry struct {
 	SessionID    string
 	TmuxName     string
 	Title        string
+	Tool         string
 	AssignedKey  string
 	WaitingSince time.Time
-	Status       Status // For icon rendering when show_all enabled
+	Status       Status    // For icon rendering when show_all enabled
+	Attention    Attention // What a waiting session needs (question/error/done)
+	Pinned       bool      // Session is pinned (Pin != PinNone); leads the priority order
 }
 
 // NotificationManager tracks waiting sessions for the notification bar
@@ -25,9 +30,81 @@ type NotificationManager struct {
 	showAll      bool           // Show all sessions vs only waiting
 	minimal      bool           // Show compact icon+count summary only (no names, no key bindings)
 	statusCounts map[Status]int // Per-status counts across all sessions (for minimal mode)
+	format       BarFormat
+	placement    BarPlacement
+	order        BarOrder
+	mutedGroups  []string // Group paths whose sessions (subgroups included) stay off the bar
 	mu           sync.RWMutex
 }
 
+// BarFormat customizes the notification bar text ([notifications] format,
+// prefix, separator and max_title_length). The zero Entry keeps the built-in
+// "[key] title" layout.
+type BarFormat struct {
+	// Entry is the per-session template. Placeholders: {index} (the
+	// Ctrl+b key), {icon}, {title}, {tool}, {status}.
+	Entry string
+	// Prefix leads the bar, minimal mode included.
+	Prefix string
+	// Separator goes between sessions (not used by minimal mode).
+	Separator string
+	// MaxTitle truncates titles to this many characters; 0 means no limit.
+	MaxTitle int
+}
+
+// BarPlacement is where in tmux the notification bar is drawn
+// ([notifications] placement).
+type BarPlacement string
+
+const (
+	// BarPlacementLeft replaces status-left (the default).
+	BarPlacementLeft BarPlacement = "left"
+	// BarPlacementRight prepends the bar to status-right.
+	BarPlacementRight BarPlacement = "right"
+	// BarPlacementWindow appends the bar to the current window in the window list.
+	BarPlacementWindow BarPlacement = "window"
+)
+
+// ParseBarPlacement maps a config value to a placement. Unknown values fall
+// back to BarPlacementLeft.
+func ParseBarPlacement(s string) BarPlacement {
+	switch p := BarPlacement(strings.ToLower(strings.TrimSpace(s))); p {
+	case BarPlacementRight, BarPlacementWindow:
+		return p
+	default:
+		return BarPlacementLeft
+	}
+}
+
+// BarOrder decides which sessions get the bar's Ctrl+b number keys
+// ([notifications] order).
+type BarOrder string
+
+const (
+	// BarOrderNewest lists the most recently waiting session first and
+	// renumbers the keys on every change (the default).
+	BarOrderNewest BarOrder = "newest"
+	// BarOrderPriority lists pinned sessions first, then waiting sessions
+	// longest wait first, then the rest. A session keeps its key while it
+	// stays on the bar; newcomers take the lowest free key.
+	BarOrderPriority BarOrder = "priority"
+)
+
+// ParseBarOrder maps a config value to an order. Unknown values fall back to
+// BarOrderNewest.
+func ParseBarOrder(s string) BarOrder {
+	if BarOrder(strings.ToLower(strings.TrimSpace(s))) == BarOrderPriority {
+		return BarOrderPriority
+	}
+	return BarOrderNewest
+}
+
+// DefaultBarFormat is the bar as it looked before it was configurable:
+// "⚡ [1] title [2] title".
+func DefaultBarFormat() BarFormat {
+	return BarFormat{Prefix: "⚡ ", Separator: " "}
+}
+
 // NewNotificationManager creates a new notification manager
 func NewNotificationManager(maxShown int, showAll, minimal bool) *NotificationManager {
 	if maxShown <= 0 {
@@ -39,15 +116,86 @@ func NewNotificationManager(maxShown int, showAll, minimal bool) *NotificationMa
 		showAll:      showAll,
 		minimal:      minimal,
 		statusCounts: make(map[Status]int),
+		format:       DefaultBarFormat(),
+		placement:    BarPlacementLeft,
+		order:        BarOrderNewest,
 	}
 }
 
+// SetFormat replaces the bar format. Like Configure it applies to a live
+// manager; the next FormatBar call renders with it.
+func (nm *NotificationManager) SetFormat(f BarFormat) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	nm.format = f
+}
+
 // IsMinimal reports whether this manager is in minimal (icon+count) mode.
 // home.go uses this to skip key binding updates when minimal=true.
 func (nm *NotificationManager) IsMinimal() bool {
+	nm.mu.RLock()
+	defer nm.mu.RUnlock()
 	return nm.minimal
 }
 
+// Configure applies changed [notifications] settings to a live manager, so a
+// config.toml edit takes effect without restarting the TUI. Entries past the
+// new maxShown are dropped; the next sync rebuilds the rest.
+func (nm *NotificationManager) Configure(maxShown int, showAll, minimal bool) {
+	if maxShown <= 0 {
+		maxShown = 6
+	}
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	nm.maxShown = maxShown
+	nm.showAll = showAll
+	nm.minimal = minimal
+	if len(nm.entries) > nm.maxShown {
+		nm.entries = nm.entries[:nm.maxShown]
+	}
+}
+
+// SetPlacement moves the bar to another part of the tmux status line. The
+// caller clears the old placement; the manager only renders the text.
+func (nm *NotificationManager) SetPlacement(p BarPlacement) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	nm.placement = p
+}
+
+// Placement reports where the bar is drawn.
+func (nm *NotificationManager) Placement() BarPlacement {
+	nm.mu.RLock()
+	defer nm.mu.RUnlock()
+	return nm.placement
+}
+
+// SetOrder switches how keys are assigned. It takes effect on the next sync.
+func (nm *NotificationManager) SetOrder(o BarOrder) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	nm.order = o
+}
+
+// SetMutedGroups replaces the groups whose sessions the bar skips. A muted
+// group mutes its subgroups too.
+func (nm *NotificationManager) SetMutedGroups(paths []string) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	nm.mutedGroups = append([]string(nil), paths...)
+}
+
+// InMutedGroup reports whether groupPath is one of the muted group paths or
+// nested under one.
+func InMutedGroup(muted []string, groupPath string) bool {
+	for _, p := range muted {
+		if groupPath == p || strings.HasPrefix(groupPath, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 // Add registers a session as waiting (newest goes to position [0])
 func (nm *NotificationManager) Add(inst *Instance) error {
 	nm.mu.Lock()
@@ -69,6 +217,7 @@ func (nm *NotificationManager) Add(inst *Instance) error {
 		SessionID:    inst.ID,
 		TmuxName:     tmuxName,
 		Title:        inst.Title,
+		Tool:         inst.Tool,
 		WaitingSince: time.Now(),
 	}
 
@@ -98,8 +247,10 @@ func (nm *NotificationManager) Remove(sessionID string) {
 		}
 	}
 
-	// Reassign keys
-	nm.reassignKeys()
+	// Reassign keys (priority order keeps everyone else's key)
+	if nm.order != BarOrderPriority {
+		nm.reassignKeys()
+	}
 }
 
 // reassignKeys assigns keys 1-6 based on position
@@ -174,19 +325,55 @@ func (nm *NotificationManager) FormatBar() string {
 
 	var parts []string
 	for _, e := range nm.entries {
+		title := truncateBarTitle(e.Title, nm.format.MaxTitle)
+		if nm.format.Entry != "" {
+			parts = append(parts, nm.formatEntry(e, title))
+			continue
+		}
 		var formatted string
-		if nm.showAll {
+		if glyph := AttentionIcon(e.Attention); glyph != "" {
+			// A classified wait says what it needs instead of a generic icon.
+			formatted = fmt.Sprintf("[%s] %s %s", e.AssignedKey, glyph, title)
+			if reason := e.Attention.Reason(); reason != "" {
+				formatted += " (" + reason + ")"
+			}
+		} else if nm.showAll {
 			// Show status icon when in show_all mode
 			icon := statusIcon(e.Status)
-			formatted = fmt.Sprintf("[%s] %s %s", e.AssignedKey, icon, e.Title)
+			formatted = fmt.Sprintf("[%s] %s %s", e.AssignedKey, icon, title)
 		} else {
 			// Original format without icons (backward compatible)
-			formatted = fmt.Sprintf("[%s] %s", e.AssignedKey, e.Title)
+			formatted = fmt.Sprintf("[%s] %s", e.AssignedKey, title)
 		}
 		parts = append(parts, formatted)
 	}
 
-	return "⚡ " + strings.Join(parts, " ")
+	return nm.format.Prefix + strings.Join(parts, nm.format.Separator)
+}
+
+// formatEntry expands the user's entry template for e. {icon} is the
+// attention glyph when the wait is classified, the status icon otherwise.
+func (nm *NotificationManager) formatEntry(e *NotificationEntry, title string) string {
+	icon := AttentionIcon(e.Attention)
+	if icon == "" {
+		icon = statusIcon(e.Status)
+	}
+	return strings.NewReplacer(
+		"{index}", e.AssignedKey,
+		"{icon}", icon,
+		"{title}", title,
+		"{tool}", e.Tool,
+		"{status}", string(e.Status),
+	).Replace(nm.format.Entry)
+}
+
+// truncateBarTitle shortens title to limit characters, ending in "…".
+// limit <= 0 leaves it alone.
+func truncateBarTitle(title string, limit int) string {
+	if limit <= 0 || utf8.RuneCountInString(title) <= limit {
+		return title
+	}
+	return string([]rune(title)[:limit-1]) + "…"
 }
 
 // statusColor returns the tmux fg color escape for a given status, matching the TUI palette.
@@ -227,7 +414,7 @@ func (nm *NotificationManager) formatBarMinimal() string {
 	if len(parts) == 0 {
 		return ""
 	}
-	return "⚡ " + strings.Join(parts, " │ ") + "  "
+	return nm.format.Prefix + strings.Join(parts, " │ ") + "  "
 }
 
 // statusIcon returns the Unicode icon for a given session status
@@ -248,12 +435,39 @@ func statusIcon(status Status) string {
 	}
 }
 
+// AttentionIcon returns the glyph for a waiting session's attention label:
+// "?" a question, permission or plan approval, "!" an error it stopped on,
+// "✓" finished and awaiting review, or "" when it is unclassified.
+func AttentionIcon(a Attention) string {
+	switch a {
+	case AttentionQuestion, AttentionPermission, AttentionPlanApproval:
+		return "?"
+	case AttentionError:
+		return "!"
+	case AttentionDone:
+		return "✓"
+	default:
+		return ""
+	}
+}
+
 // SyncFromInstances updates notifications based on current instance states
 // Call this periodically to sync with actual session statuses
 func (nm *NotificationManager) SyncFromInstances(instances []*Instance, currentSessionID string) (added, removed []string) {
 	nm.mu.Lock()
 	defer nm.mu.Unlock()
 
+	// Sessions in muted groups neither count nor take a key.
+	if len(nm.mutedGroups) > 0 {
+		kept := make([]*Instance, 0, len(instances))
+		for _, inst := range instances {
+			if !InMutedGroup(nm.mutedGroups, inst.GroupPath) {
+				kept = append(kept, inst)
+			}
+		}
+		instances = kept
+	}
+
 	// Always compute per-status counts across all non-current sessions (used by minimal mode)
 	counts := make(map[Status]int)
 	for _, inst := range instances {
@@ -294,6 +508,13 @@ func (nm *NotificationManager) SyncFromInstances(instances []*Instance, currentS
 		if inst, stillPresent := sessionSet[e.SessionID]; stillPresent {
 			// Update status for existing entries
 			e.Status = inst.GetStatusThreadSafe()
+			e.Attention = inst.CachedAttention()
+			e.Pinned = inst.Pin != PinNone
+			if nm.order == BarOrderPriority {
+				// Ranking by wait needs the current wait, not the one seen
+				// when the entry was added.
+				e.WaitingSince = inst.GetWaitingSince()
+			}
 			newEntries = append(newEntries, e)
 			delete(sessionSet, e.SessionID) // Don't re-add
 		} else {
@@ -312,13 +533,21 @@ func (nm *NotificationManager) SyncFromInstances(instances []*Instance, currentS
 			SessionID:    inst.ID,
 			TmuxName:     tmuxName,
 			Title:        inst.Title,
+			Tool:         inst.Tool,
 			WaitingSince: inst.GetWaitingSince(),
 			Status:       inst.GetStatusThreadSafe(),
+			Attention:    inst.CachedAttention(),
+			Pinned:       inst.Pin != PinNone,
 		}
 		nm.entries = append(nm.entries, entry)
 		added = append(added, inst.ID)
 	}
 
+	if nm.order == BarOrderPriority {
+		nm.rankByPriority()
+		return added, removed
+	}
+
 	// Sort ALL entries by WaitingSince (newest first)
 	// This ensures correct ordering regardless of how entries were added
 	sort.Slice(nm.entries, func(i, j int) bool {
@@ -335,3 +564,58 @@ func (nm *NotificationManager) SyncFromInstances(instances []*Instance, currentS
 
 	return added, removed
 }
+
+// rankByPriority keeps the maxShown most important entries (pinned, then
+// waiting longest first, then the rest newest first) and gives them stable
+// keys: an entry keeps its key, newcomers take the lowest free one. Entries
+// end up in key order, which is the order the bar shows. Called with nm.mu
+// held.
+func (nm *NotificationManager) rankByPriority() {
+	sort.SliceStable(nm.entries, func(i, j int) bool {
+		a, b := nm.entries[i], nm.entries[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		aWaiting, bWaiting := a.Status == StatusWaiting, b.Status == StatusWaiting
+		if aWaiting != bWaiting {
+			return aWaiting
+		}
+		if aWaiting {
+			return a.WaitingSince.Before(b.WaitingSince)
+		}
+		return a.WaitingSince.After(b.WaitingSince)
+	})
+	if len(nm.entries) > nm.maxShown {
+		nm.entries = nm.entries[:nm.maxShown]
+	}
+
+	taken := make(map[int]bool, len(nm.entries))
+	keyOf := func(e *NotificationEntry) int {
+		n, err := strconv.Atoi(e.AssignedKey)
+		if err != nil || n < 1 || n > nm.maxShown {
+			return 0
+		}
+		return n
+	}
+	for _, e := range nm.entries {
+		if n := keyOf(e); n > 0 && !taken[n] {
+			taken[n] = true
+		} else {
+			e.AssignedKey = ""
+		}
+	}
+	next := 1
+	for _, e := range nm.entries {
+		if e.AssignedKey != "" {
+			continue
+		}
+		for taken[next] {
+			next++
+		}
+		taken[next] = true
+		e.AssignedKey = strconv.Itoa(next)
+	}
+	sort.Slice(nm.entries, func(i, j int) bool {
+		return keyOf(nm.entries[i]) < keyOf(nm.entries[j])
+	})
+}
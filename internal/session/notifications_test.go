@@ -2,6 +2,7 @@ package session
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -92,6 +93,25 @@ func TestNotificationManager_MaxShown(t *testing.T) {
 	assert.Equal(t, "C", entries[2].Title)
 }
 
+func TestNotificationManager_Configure(t *testing.T) {
+	nm := NewNotificationManager(6, false, false)
+	for i := 0; i < 4; i++ {
+		_ = nm.Add(&Instance{ID: string(rune('a' + i)), Title: string(rune('A' + i)), Status: StatusWaiting})
+	}
+
+	nm.Configure(2, true, true)
+
+	assert.True(t, nm.IsMinimal())
+	assert.Len(t, nm.GetEntries(), 2, "entries past the new max_shown are dropped")
+
+	nm.Configure(0, false, false)
+	assert.False(t, nm.IsMinimal())
+	for i := 0; i < 6; i++ {
+		_ = nm.Add(&Instance{ID: fmt.Sprintf("n%d", i), Title: "x", Status: StatusWaiting})
+	}
+	assert.Len(t, nm.GetEntries(), 6, "max_shown <= 0 falls back to the default of 6")
+}
+
 func TestNotificationManager_FormatBar(t *testing.T) {
 	nm := NewNotificationManager(6, false, false)
 
@@ -850,3 +870,135 @@ func TestMinimalMode_StartingCountsAsRunning(t *testing.T) {
 	assert.Contains(t, bar, "#9ece6a") // running/active color
 	assert.NotEqual(t, "", bar)
 }
+
+func TestNotificationManager_FormatBarAttention(t *testing.T) {
+	nm := NewNotificationManager(6, false, false)
+	_ = nm.Add(&Instance{ID: "a", Title: "frontend", Status: StatusWaiting})
+	nm.entries[0].Attention = AttentionQuestion
+
+	bar := nm.FormatBar()
+	assert.Contains(t, bar, "[1] ? frontend (question)")
+
+	nm.entries[0].Attention = AttentionPermission
+	assert.Contains(t, nm.FormatBar(), "[1] ? frontend (needs permission)")
+
+	nm.entries[0].Attention = AttentionDone
+	assert.NotContains(t, nm.FormatBar(), "(")
+}
+
+func TestNotificationManager_FormatBarTemplate(t *testing.T) {
+	nm := NewNotificationManager(6, true, false)
+	nm.SetFormat(BarFormat{Entry: "{index}:{icon}{title}/{tool}/{status}", Prefix: "", Separator: " | ", MaxTitle: 6})
+	_ = nm.Add(&Instance{ID: "a", Title: "frontend", Tool: "claude"})
+	_ = nm.Add(&Instance{ID: "b", Title: "api", Tool: "codex"})
+	nm.entries[0].Status = StatusWaiting
+	nm.entries[1].Status = StatusRunning
+	nm.entries[1].Attention = AttentionError
+
+	assert.Equal(t, "1:◐api/codex/waiting | 2:!front…/claude/running", nm.FormatBar())
+}
+
+func TestNotificationManager_FormatBarPrefixAppliesToMinimal(t *testing.T) {
+	nm := NewNotificationManager(6, false, true)
+	f := DefaultBarFormat()
+	f.Prefix = "AD "
+	nm.SetFormat(f)
+	nm.SyncFromInstances([]*Instance{{ID: "a", Title: "x", Status: StatusRunning}}, "")
+
+	assert.True(t, strings.HasPrefix(nm.FormatBar(), "AD #[fg="), nm.FormatBar())
+}
+
+func TestTruncateBarTitle(t *testing.T) {
+	assert.Equal(t, "frontend", truncateBarTitle("frontend", 0))
+	assert.Equal(t, "frontend", truncateBarTitle("frontend", 8))
+	assert.Equal(t, "fro…", truncateBarTitle("frontend", 4))
+	assert.Equal(t, "日本…", truncateBarTitle("日本語のタイトル", 3))
+	assert.Equal(t, "…", truncateBarTitle("frontend", 1))
+}
+
+func TestParseBarPlacement(t *testing.T) {
+	assert.Equal(t, BarPlacementLeft, ParseBarPlacement(""))
+	assert.Equal(t, BarPlacementRight, ParseBarPlacement("Right"))
+	assert.Equal(t, BarPlacementWindow, ParseBarPlacement(" window "))
+	assert.Equal(t, BarPlacementLeft, ParseBarPlacement("bottom"), "unknown placements fall back to status-left")
+
+	nm := NewNotificationManager(6, false, false)
+	assert.Equal(t, BarPlacementLeft, nm.Placement())
+	nm.SetPlacement(BarPlacementWindow)
+	assert.Equal(t, BarPlacementWindow, nm.Placement())
+}
+
+func TestNotificationManager_MutedGroupsStayOffTheBar(t *testing.T) {
+	nm := NewNotificationManager(6, false, false)
+	nm.SetMutedGroups([]string{"experiments"})
+
+	prod := &Instance{ID: "p", Title: "prod", GroupPath: "work", Status: StatusWaiting}
+	exp := &Instance{ID: "e", Title: "exp", GroupPath: "experiments", Status: StatusWaiting}
+	sub := &Instance{ID: "s", Title: "sub", GroupPath: "experiments/llm", Status: StatusWaiting}
+	lookalike := &Instance{ID: "l", Title: "lookalike", GroupPath: "experiments-old", Status: StatusWaiting}
+	nm.SyncFromInstances([]*Instance{prod, exp, sub, lookalike}, "")
+
+	assert.True(t, nm.Has("p"))
+	assert.False(t, nm.Has("e"), "sessions in a muted group take no slot")
+	assert.False(t, nm.Has("s"), "muting a group mutes its subgroups")
+	assert.True(t, nm.Has("l"), "only path segments match")
+
+	nm.SetMutedGroups(nil)
+	nm.SyncFromInstances([]*Instance{prod, exp, sub, lookalike}, "")
+	assert.Equal(t, 4, nm.Count(), "unmuting brings the sessions back")
+}
+
+func TestNotificationManager_PriorityOrder(t *testing.T) {
+	nm := NewNotificationManager(3, true, false)
+	nm.SetOrder(BarOrderPriority)
+	now := time.Now()
+
+	recent := &Instance{ID: "recent", Title: "recent", Status: StatusWaiting, CreatedAt: now.Add(-time.Minute)}
+	stale := &Instance{ID: "stale", Title: "stale", Status: StatusWaiting, CreatedAt: now.Add(-time.Hour)}
+	running := &Instance{ID: "running", Title: "running", Status: StatusRunning, CreatedAt: now}
+	pinned := &Instance{ID: "pinned", Title: "pinned", Status: StatusIdle, Pin: PinTop, CreatedAt: now}
+	nm.SyncFromInstances([]*Instance{recent, stale, running, pinned}, "")
+
+	keys := func() map[string]string {
+		out := map[string]string{}
+		for _, e := range nm.GetEntries() {
+			out[e.SessionID] = e.AssignedKey
+		}
+		return out
+	}
+	assert.Equal(t, map[string]string{"pinned": "1", "stale": "2", "recent": "3"}, keys(),
+		"pinned first, then the longest wait; the running session gets no slot")
+	assert.Equal(t, "[1] ○ pinned [2] ◐ stale [3] ◐ recent", strings.TrimPrefix(nm.FormatBar(), "⚡ "))
+}
+
+func TestNotificationManager_PriorityKeysAreStable(t *testing.T) {
+	nm := NewNotificationManager(6, false, false)
+	nm.SetOrder(BarOrderPriority)
+	now := time.Now()
+	a := &Instance{ID: "a", Title: "a", Status: StatusWaiting, CreatedAt: now.Add(-time.Minute)}
+	b := &Instance{ID: "b", Title: "b", Status: StatusWaiting, CreatedAt: now.Add(-2 * time.Minute)}
+	nm.SyncFromInstances([]*Instance{a, b}, "")
+	require.Equal(t, "b", nm.GetSessionByKey("1").SessionID)
+	require.Equal(t, "a", nm.GetSessionByKey("2").SessionID)
+
+	// A session that has waited longer arrives: it ranks first but takes the
+	// free key 3 instead of renumbering a and b.
+	c := &Instance{ID: "c", Title: "c", Status: StatusWaiting, CreatedAt: now.Add(-time.Hour)}
+	nm.SyncFromInstances([]*Instance{a, b, c}, "")
+	assert.Equal(t, "b", nm.GetSessionByKey("1").SessionID)
+	assert.Equal(t, "a", nm.GetSessionByKey("2").SessionID)
+	assert.Equal(t, "c", nm.GetSessionByKey("3").SessionID)
+
+	// b leaves: its key is free and the next newcomer takes it.
+	d := &Instance{ID: "d", Title: "d", Status: StatusWaiting, CreatedAt: now}
+	nm.SyncFromInstances([]*Instance{a, c, d}, "")
+	assert.Equal(t, "d", nm.GetSessionByKey("1").SessionID)
+	assert.Equal(t, "a", nm.GetSessionByKey("2").SessionID)
+	assert.Equal(t, "c", nm.GetSessionByKey("3").SessionID)
+}
+
+func TestParseBarOrder(t *testing.T) {
+	assert.Equal(t, BarOrderNewest, ParseBarOrder(""))
+	assert.Equal(t, BarOrderPriority, ParseBarOrder("Priority"))
+	assert.Equal(t, BarOrderNewest, ParseBarOrder("alphabetical"))
+}
@@ -0,0 +1,169 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GetOpenCodeStorageDir returns OpenCode's on-disk storage root
+// ($XDG_DATA_HOME/opencode/storage, default ~/.local/share/opencode/storage).
+func GetOpenCodeStorageDir() string {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "opencode", "storage")
+}
+
+// openCodeMessage is the subset of storage/message/<session>/<msg>.json we read.
+// OpenCode records per-message token usage and the provider-reported cost.
+type openCodeMessage struct {
+	ID      string  `json:"id"`
+	Role    string  `json:"role"`
+	ModelID string  `json:"modelID"`
+	Cost    float64 `json:"cost"`
+	Time    struct {
+		Created   int64 `json:"created"`
+		Completed int64 `json:"completed"`
+	} `json:"time"`
+	Tokens struct {
+		Input     int `json:"input"`
+		Output    int `json:"output"`
+		Reasoning int `json:"reasoning"`
+		Cache     struct {
+			Read  int `json:"read"`
+			Write int `json:"write"`
+		} `json:"cache"`
+	} `json:"tokens"`
+}
+
+// openCodePart is the subset of storage/part/<msg>/<part>.json we read.
+type openCodePart struct {
+	Type string `json:"type"`
+	Tool string `json:"tool"`
+}
+
+// ParseOpenCodeSession builds analytics for an OpenCode session from its
+// message files under storageDir (see GetOpenCodeStorageDir). Each user
+// message counts as a turn; tool calls come from the assistant's tool parts.
+// EstimatedCost is OpenCode's own per-message cost, so it reflects whatever
+// provider the session used.
+func ParseOpenCodeSession(storageDir, sessionID string) (*SessionAnalytics, error) {
+	return ParseOpenCodeSessionSince(storageDir, sessionID, time.Time{})
+}
+
+// ParseOpenCodeSessionSince is ParseOpenCodeSession restricted to messages
+// created at or after since; a zero since counts the whole session.
+func ParseOpenCodeSessionSince(storageDir, sessionID string, since time.Time) (*SessionAnalytics, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("no OpenCode session ID")
+	}
+	msgFiles, err := filepath.Glob(filepath.Join(storageDir, "message", sessionID, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(msgFiles) == 0 {
+		return nil, fmt.Errorf("no messages found for OpenCode session %s", sessionID)
+	}
+
+	messages := make([]openCodeMessage, 0, len(msgFiles))
+	for _, f := range msgFiles {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		var m openCodeMessage
+		if json.Unmarshal(data, &m) != nil {
+			continue
+		}
+		if !since.IsZero() && m.Time.Created < since.UnixMilli() {
+			continue
+		}
+		messages = append(messages, m)
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Time.Created < messages[j].Time.Created })
+
+	analytics := &SessionAnalytics{
+		ToolCalls:     []ToolCall{},
+		Subagents:     []SubagentInfo{},
+		BillingBlocks: []BillingBlock{},
+	}
+	toolCounts := make(map[string]int)
+	for _, m := range messages {
+		created := time.UnixMilli(m.Time.Created)
+		if m.Time.Created > 0 {
+			if analytics.StartTime.IsZero() {
+				analytics.StartTime = created
+			}
+			last := created
+			if m.Time.Completed > 0 {
+				last = time.UnixMilli(m.Time.Completed)
+			}
+			if last.After(analytics.LastActive) {
+				analytics.LastActive = last
+			}
+		}
+
+		switch m.Role {
+		case "user":
+			analytics.TotalTurns++
+		case "assistant":
+			analytics.InputTokens += m.Tokens.Input
+			analytics.OutputTokens += m.Tokens.Output + m.Tokens.Reasoning
+			analytics.CacheReadTokens += m.Tokens.Cache.Read
+			analytics.CacheWriteTokens += m.Tokens.Cache.Write
+			analytics.EstimatedCost += m.Cost
+			if m.Time.Created > 0 {
+				analytics.addDailyTokens(created, m.Tokens.Input+m.Tokens.Output+m.Tokens.Reasoning+m.Tokens.Cache.Read+m.Tokens.Cache.Write)
+			}
+			if m.Tokens.Input > 0 || m.Tokens.Cache.Read > 0 {
+				analytics.CurrentContextTokens = m.Tokens.Input + m.Tokens.Cache.Read
+			}
+			if m.ModelID != "" {
+				analytics.Model = m.ModelID
+			}
+			countOpenCodeToolParts(storageDir, m.ID, toolCounts)
+		}
+	}
+	if !analytics.StartTime.IsZero() && !analytics.LastActive.IsZero() {
+		analytics.Duration = analytics.LastActive.Sub(analytics.StartTime)
+	}
+
+	for name, count := range toolCounts {
+		analytics.ToolCalls = append(analytics.ToolCalls, ToolCall{Name: name, Count: count})
+	}
+	sort.Slice(analytics.ToolCalls, func(i, j int) bool {
+		if analytics.ToolCalls[i].Count != analytics.ToolCalls[j].Count {
+			return analytics.ToolCalls[i].Count > analytics.ToolCalls[j].Count
+		}
+		return analytics.ToolCalls[i].Name < analytics.ToolCalls[j].Name
+	})
+	return analytics, nil
+}
+
+func countOpenCodeToolParts(storageDir, messageID string, counts map[string]int) {
+	if messageID == "" {
+		return
+	}
+	partFiles, _ := filepath.Glob(filepath.Join(storageDir, "part", messageID, "*.json"))
+	for _, f := range partFiles {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		var p openCodePart
+		if json.Unmarshal(data, &p) != nil || p.Type != "tool" || strings.TrimSpace(p.Tool) == "" {
+			continue
+		}
+		counts[p.Tool]++
+	}
+}
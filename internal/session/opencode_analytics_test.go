@@ -0,0 +1,82 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeOpenCodeFixture(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseOpenCodeSession(t *testing.T) {
+	dir := t.TempDir()
+	writeOpenCodeFixture(t, dir, "message/ses_abc/msg_1.json",
+		`{"id":"msg_1","role":"user","time":{"created":1700000000000}}`)
+	writeOpenCodeFixture(t, dir, "message/ses_abc/msg_2.json",
+		`{"id":"msg_2","role":"assistant","modelID":"claude-sonnet-4-20250514","cost":0.012,
+		  "time":{"created":1700000001000,"completed":1700000010000},
+		  "tokens":{"input":1000,"output":200,"reasoning":50,"cache":{"read":4000,"write":300}}}`)
+	writeOpenCodeFixture(t, dir, "message/ses_abc/msg_3.json",
+		`{"id":"msg_3","role":"user","time":{"created":1700000020000}}`)
+	writeOpenCodeFixture(t, dir, "message/ses_abc/msg_4.json",
+		`{"id":"msg_4","role":"assistant","modelID":"claude-sonnet-4-20250514","cost":0.008,
+		  "time":{"created":1700000021000,"completed":1700000060000},
+		  "tokens":{"input":500,"output":100,"cache":{"read":5000}}}`)
+	writeOpenCodeFixture(t, dir, "part/msg_2/prt_1.json", `{"type":"tool","tool":"bash"}`)
+	writeOpenCodeFixture(t, dir, "part/msg_2/prt_2.json", `{"type":"text","text":"hi"}`)
+	writeOpenCodeFixture(t, dir, "part/msg_4/prt_3.json", `{"type":"tool","tool":"bash"}`)
+	writeOpenCodeFixture(t, dir, "part/msg_4/prt_4.json", `{"type":"tool","tool":"edit"}`)
+	writeOpenCodeFixture(t, dir, "message/ses_abc/broken.json", `{not json`)
+
+	a, err := ParseOpenCodeSession(dir, "ses_abc")
+	if err != nil {
+		t.Fatalf("ParseOpenCodeSession: %v", err)
+	}
+	if a.TotalTurns != 2 {
+		t.Errorf("TotalTurns = %d, want 2", a.TotalTurns)
+	}
+	if a.InputTokens != 1500 || a.OutputTokens != 350 || a.CacheReadTokens != 9000 || a.CacheWriteTokens != 300 {
+		t.Errorf("tokens = in %d out %d cr %d cw %d", a.InputTokens, a.OutputTokens, a.CacheReadTokens, a.CacheWriteTokens)
+	}
+	if a.CurrentContextTokens != 5500 {
+		t.Errorf("CurrentContextTokens = %d, want 5500 (last turn input + cache read)", a.CurrentContextTokens)
+	}
+	if a.EstimatedCost < 0.0199 || a.EstimatedCost > 0.0201 {
+		t.Errorf("EstimatedCost = %v, want 0.02", a.EstimatedCost)
+	}
+	if a.Model != "claude-sonnet-4-20250514" {
+		t.Errorf("Model = %q", a.Model)
+	}
+	if a.Duration != 60*time.Second {
+		t.Errorf("Duration = %v, want 1m0s", a.Duration)
+	}
+	if len(a.ToolCalls) != 2 || a.ToolCalls[0] != (ToolCall{Name: "bash", Count: 2}) || a.ToolCalls[1] != (ToolCall{Name: "edit", Count: 1}) {
+		t.Errorf("ToolCalls = %+v", a.ToolCalls)
+	}
+}
+
+func TestParseOpenCodeSession_Missing(t *testing.T) {
+	if _, err := ParseOpenCodeSession(t.TempDir(), "ses_none"); err == nil {
+		t.Error("expected an error for a session with no messages")
+	}
+	if _, err := ParseOpenCodeSession(t.TempDir(), ""); err == nil {
+		t.Error("expected an error for an empty session ID")
+	}
+}
+
+func TestGetOpenCodeStorageDir_XDG(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/data")
+	if got := GetOpenCodeStorageDir(); got != filepath.Join("/data", "opencode", "storage") {
+		t.Errorf("GetOpenCodeStorageDir() = %q", got)
+	}
+}
@@ -72,6 +72,10 @@ func InitializeGlobalPool(ctx context.Context, config *UserConfig, sessions []*I
 		ExcludeMCPs:   config.MCPPool.ExcludeMCPs,
 		PoolMCPs:      config.MCPPool.PoolMCPs,
 		FallbackStdio: true, // Always true - see Issue #36
+		LogLimits: mcppool.LogLimits{
+			MaxSizeMB:  config.MCPPool.LogMaxSizeMB,
+			MaxBackups: config.MCPPool.LogMaxBackups,
+		},
 	}
 
 	// Create pool
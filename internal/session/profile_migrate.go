@@ -93,10 +93,32 @@ func MigrateSessionsToProfile(sourceProfile, targetProfile string, sessionIDs []
 		if err := migrateOneSession(srcStorage.GetDB(), dstStorage.GetDB(), id, opts, result); err != nil {
 			return result, err
 		}
+		if err := verifySessionMoved(srcStorage, dstStorage, id); err != nil {
+			return result, err
+		}
 	}
 	return result, nil
 }
 
+// verifySessionMoved applies the storage layer's defensive save checks to
+// both sides of a single-session move: the row must be present at dst, and
+// absent from src after RemoveSessionAndVerify's retry loop. The source side
+// matters most — a deck still running on the source profile can resurrect
+// the row with a full SaveInstances rewrite from a stale in-memory list.
+func verifySessionMoved(srcStorage, dstStorage *Storage, id string) error {
+	exists, err := dstStorage.InstanceExists(id)
+	if err != nil {
+		return fmt.Errorf("verify %s at target: %w", id, err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrInsertNotPersistent, id)
+	}
+	if err := srcStorage.RemoveSessionAndVerify(id, nil, nil); err != nil {
+		return fmt.Errorf("verify %s removed from source: %w", id, err)
+	}
+	return nil
+}
+
 // MigrateConductorToProfile moves a conductor session AND every child session
 // (where parent_session_id == conductor.ID) from src to dst, then atomically
 // rewrites ~/.agent-deck/conductor/<name>/meta.json with the new profile.
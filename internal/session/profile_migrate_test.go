@@ -656,3 +656,23 @@ func TestRequireProfileExists_RejectsBareDir(t *testing.T) {
 		t.Errorf("want nil, got %v", err)
 	}
 }
+
+func TestVerifySessionMoved(t *testing.T) {
+	src, dst := migrateTestSetup(t, "src", "dst")
+
+	// Row resurrected at src (a stale deck's rewrite) and present at dst:
+	// verification deletes it from src again.
+	seedSession(t, src.GetDB(), makeRow("sess-v", "Verify", DefaultGroupPath))
+	seedSession(t, dst.GetDB(), makeRow("sess-v", "Verify", DefaultGroupPath))
+	if err := verifySessionMoved(src, dst, "sess-v"); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if got, _ := src.GetDB().LoadInstanceByID("sess-v"); got != nil {
+		t.Error("source row should be removed by verification")
+	}
+
+	// Missing at dst: the move did not persist.
+	if err := verifySessionMoved(src, dst, "sess-missing"); !errors.Is(err, ErrInsertNotPersistent) {
+		t.Errorf("want ErrInsertNotPersistent, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package session
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// PullRequestOptions customizes CreatePullRequest. Empty fields get
+// defaults: the session title, a body built from the agent's last reply,
+// and the repo's default branch as base.
+type PullRequestOptions struct {
+	Title string
+	Body  string
+	Base  string
+	Draft bool
+}
+
+// runGH runs the gh CLI in dir with stdin; replaced in tests.
+var runGH = func(dir, stdin string, args ...string) ([]byte, error) {
+	cmd := exec.Command("gh", args...)
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("gh %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("gh %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// CreatePullRequest pushes a git worktree session's branch and opens a pull
+// request for it with gh, returning the PR URL. When the branch already has
+// an open PR, its URL is returned instead of failing.
+func (i *Instance) CreatePullRequest(opts PullRequestOptions) (string, error) {
+	if !i.IsWorktree() {
+		return "", fmt.Errorf("session %q is not a worktree session", i.Title)
+	}
+	if i.WorktreeType != "" && i.WorktreeType != "git" {
+		return "", fmt.Errorf("session %q is a %s workspace; pull requests need a git worktree", i.Title, i.WorktreeType)
+	}
+	dir := i.WorktreePath
+	branch := i.WorktreeBranch
+	if branch == "" {
+		b, err := git.GetCurrentBranch(dir)
+		if err != nil {
+			return "", err
+		}
+		branch = b
+	}
+
+	if _, err := git.PushBranch(dir, branch); err != nil {
+		return "", err
+	}
+
+	if out, err := runGH(dir, "", "pr", "view", branch, "--json", "url", "--jq", ".url"); err == nil {
+		if url := strings.TrimSpace(string(out)); url != "" {
+			return url, nil
+		}
+	}
+
+	base := opts.Base
+	if base == "" {
+		b, err := git.GetDefaultBranch(i.WorktreeRepoRoot)
+		if err != nil {
+			return "", err
+		}
+		base = b
+	}
+	title := opts.Title
+	if title == "" {
+		title = i.Title
+	}
+	body := opts.Body
+	if body == "" {
+		body = PullRequestBody(i)
+	}
+	args := []string{"pr", "create", "--head", branch, "--base", base, "--title", title, "--body-file", "-"}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+	out, err := runGH(dir, body, args...)
+	if err != nil {
+		return "", err
+	}
+	// gh prints the URL last, after any push/fork notices.
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
+
+// PullRequestBody pre-fills a PR description from the agent's last reply,
+// which at the end of a task is usually its summary of the work.
+func PullRequestBody(i *Instance) string {
+	var b strings.Builder
+	if resp, err := i.GetLastResponseBestEffort(); err == nil && strings.TrimSpace(resp.Content) != "" {
+		b.WriteString("## Summary\n\n")
+		b.WriteString(strings.TrimSpace(resp.Content))
+		b.WriteString("\n\n")
+	}
+	fmt.Fprintf(&b, "---\nOpened from agent-deck session `%s`.\n", i.Title)
+	return b.String()
+}
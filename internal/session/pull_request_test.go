@@ -0,0 +1,29 @@
+package session
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCreatePullRequest_RejectsNonWorktree(t *testing.T) {
+	inst := &Instance{Title: "plain", ProjectPath: t.TempDir()}
+	if _, err := inst.CreatePullRequest(PullRequestOptions{}); err == nil || !strings.Contains(err.Error(), "not a worktree") {
+		t.Fatalf("CreatePullRequest on a plain session = %v, want not-a-worktree error", err)
+	}
+
+	jj := &Instance{Title: "jj", WorktreePath: t.TempDir(), WorktreeBranch: "feat", WorktreeType: "jujutsu"}
+	if _, err := jj.CreatePullRequest(PullRequestOptions{}); err == nil || !strings.Contains(err.Error(), "git worktree") {
+		t.Fatalf("CreatePullRequest on a jujutsu workspace = %v, want git-worktree error", err)
+	}
+}
+
+func TestPullRequestBody_FallsBackWithoutReply(t *testing.T) {
+	inst := &Instance{Title: "api", Tool: "shell", ProjectPath: t.TempDir()}
+	body := PullRequestBody(inst)
+	if strings.Contains(body, "## Summary") {
+		t.Errorf("body without a reply should have no summary section:\n%s", body)
+	}
+	if !strings.Contains(body, "agent-deck session `api`") {
+		t.Errorf("body should name the session:\n%s", body)
+	}
+}
@@ -0,0 +1,32 @@
+package session
+
+import "time"
+
+// ResourceUsage is the CPU and memory of everything running in a session's
+// tmux pane (the agent plus its subprocesses). The TUI samples it in the
+// background; it is not persisted.
+type ResourceUsage struct {
+	CPUPercent float64 // of one core: 250 means two and a half cores busy
+	MemBytes   uint64  // resident set size
+	Procs      int
+	SampledAt  time.Time
+}
+
+// Known reports whether the usage holds a sample.
+func (u ResourceUsage) Known() bool {
+	return !u.SampledAt.IsZero()
+}
+
+// SetResourceUsage records the latest sample; the zero value clears it.
+func (i *Instance) SetResourceUsage(u ResourceUsage) {
+	i.mu.Lock()
+	i.resourceUsage = u
+	i.mu.Unlock()
+}
+
+// CachedResourceUsage returns the latest sample, zero when none.
+func (i *Instance) CachedResourceUsage() ResourceUsage {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.resourceUsage
+}
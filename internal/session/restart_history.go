@@ -0,0 +1,133 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RestartReason records why a session was restarted.
+type RestartReason string
+
+const (
+	// RestartReasonManual is a user-initiated restart (TUI R, CLI
+	// `session restart`, web restart button).
+	RestartReasonManual RestartReason = "manual"
+	// RestartReasonMCPChange is a restart to pick up attached/detached MCPs.
+	RestartReasonMCPChange RestartReason = "mcp_change"
+	// RestartReasonConfigChange is a restart to apply edited launch settings
+	// (model, extra args, plugins, skills, permission toggles).
+	RestartReasonConfigChange RestartReason = "config_change"
+	// RestartReasonAutoPolicy is a restart issued by an automatic policy
+	// rather than a person, e.g. a watchdog running
+	// `session restart --reason auto_policy`.
+	RestartReasonAutoPolicy RestartReason = "auto_policy"
+	// RestartReasonRestore brings back a deleted session (TUI ctrl+z, web
+	// undelete).
+	RestartReasonRestore RestartReason = "restore"
+)
+
+// ParseRestartReason validates a reason given on the command line.
+func ParseRestartReason(s string) (RestartReason, error) {
+	switch r := RestartReason(s); r {
+	case RestartReasonManual, RestartReasonMCPChange, RestartReasonConfigChange,
+		RestartReasonAutoPolicy, RestartReasonRestore:
+		return r, nil
+	}
+	return "", fmt.Errorf("unknown restart reason %q (want manual, mcp_change, config_change, auto_policy or restore)", s)
+}
+
+// maxRestartHistory caps the per-session history. A flapping session
+// restarted by a policy every minute must not bloat state.db.
+const maxRestartHistory = 50
+
+// RestartEvent is one Restart()/RestartFresh() call.
+type RestartEvent struct {
+	At          time.Time     `json:"at"`
+	Reason      RestartReason `json:"reason"`
+	Fresh       bool          `json:"fresh,omitempty"`
+	TmuxSession string        `json:"tmux_session,omitempty"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// recordRestart appends a restart to the history, trimming the oldest
+// entries past maxRestartHistory.
+func (i *Instance) recordRestart(reason RestartReason, fresh bool, err error) {
+	if reason == "" {
+		reason = RestartReasonManual
+	}
+	ev := RestartEvent{
+		At:     time.Now(),
+		Reason: reason,
+		Fresh:  fresh,
+	}
+	if ts := i.GetTmuxSession(); ts != nil {
+		ev.TmuxSession = ts.Name
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+
+	detail := string(reason)
+	if fresh {
+		detail += " (fresh)"
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.recordEventLocked(EventRestarted, detail, err)
+	i.RestartHistory = append(i.RestartHistory, ev)
+	if over := len(i.RestartHistory) - maxRestartHistory; over > 0 {
+		i.RestartHistory = append([]RestartEvent(nil), i.RestartHistory[over:]...)
+	}
+}
+
+// GetRestartHistory returns a copy of the restart history, oldest first.
+func (i *Instance) GetRestartHistory() []RestartEvent {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if len(i.RestartHistory) == 0 {
+		return nil
+	}
+	return append([]RestartEvent(nil), i.RestartHistory...)
+}
+
+// RestartCount returns how many restarts are on record.
+func (i *Instance) RestartCount() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.RestartHistory)
+}
+
+const toolDataRestartHistoryKey = "restart_history"
+
+// WriteRestartHistoryToToolData merges restart_history into the tool_data
+// blob, in the same extras zone as idle_timeout_secs so older binaries
+// preserve it. An empty history removes the key.
+func WriteRestartHistoryToToolData(td json.RawMessage, history []RestartEvent) json.RawMessage {
+	m := map[string]json.RawMessage{}
+	if len(td) > 0 {
+		_ = json.Unmarshal(td, &m)
+	}
+	if len(history) > 0 {
+		raw, _ := json.Marshal(history)
+		m[toolDataRestartHistoryKey] = raw
+	} else {
+		delete(m, toolDataRestartHistoryKey)
+	}
+	out, _ := json.Marshal(m)
+	return out
+}
+
+// ReadRestartHistoryFromToolData extracts restart_history from the blob.
+// Returns nil for missing/malformed/legacy rows.
+func ReadRestartHistoryFromToolData(td json.RawMessage) []RestartEvent {
+	if len(td) == 0 {
+		return nil
+	}
+	var blob struct {
+		RestartHistory []RestartEvent `json:"restart_history"`
+	}
+	_ = json.Unmarshal(td, &blob)
+	return blob.RestartHistory
+}
@@ -0,0 +1,62 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestRecordRestart_CapsAndCopies(t *testing.T) {
+	inst := &Instance{ID: "r1"}
+	for n := 0; n < maxRestartHistory+5; n++ {
+		inst.recordRestart(RestartReasonAutoPolicy, false, nil)
+	}
+	inst.recordRestart("", true, errors.New("boom"))
+
+	hist := inst.GetRestartHistory()
+	if len(hist) != maxRestartHistory || inst.RestartCount() != maxRestartHistory {
+		t.Fatalf("len = %d, want cap %d", len(hist), maxRestartHistory)
+	}
+	last := hist[len(hist)-1]
+	if last.Reason != RestartReasonManual || !last.Fresh || last.Error != "boom" {
+		t.Fatalf("last event = %+v, want manual/fresh/boom", last)
+	}
+
+	hist[0].Reason = "mutated"
+	if inst.GetRestartHistory()[0].Reason == "mutated" {
+		t.Fatal("GetRestartHistory must return a copy")
+	}
+}
+
+func TestRestartHistoryToolDataRoundTrip(t *testing.T) {
+	base := json.RawMessage(`{"notes":"keep me"}`)
+	hist := []RestartEvent{{Reason: RestartReasonMCPChange, TmuxSession: "agentdeck_x"}}
+
+	td := WriteRestartHistoryToToolData(base, hist)
+	got := ReadRestartHistoryFromToolData(td)
+	if len(got) != 1 || got[0].Reason != RestartReasonMCPChange || got[0].TmuxSession != "agentdeck_x" {
+		t.Fatalf("round trip = %+v", got)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(td, &m); err != nil || m["notes"] != "keep me" {
+		t.Fatalf("other keys not preserved: %s", td)
+	}
+
+	cleared := WriteRestartHistoryToToolData(td, nil)
+	if ReadRestartHistoryFromToolData(cleared) != nil {
+		t.Fatalf("empty history must drop the key: %s", cleared)
+	}
+}
+
+func TestParseRestartReason(t *testing.T) {
+	for _, s := range []string{"manual", "mcp_change", "config_change", "auto_policy", "restore"} {
+		if r, err := ParseRestartReason(s); err != nil || string(r) != s {
+			t.Errorf("ParseRestartReason(%q) = %q, %v", s, r, err)
+		}
+	}
+	for _, s := range []string{"", "watchdog", "Manual"} {
+		if _, err := ParseRestartReason(s); err == nil {
+			t.Errorf("ParseRestartReason(%q) should fail", s)
+		}
+	}
+}
@@ -0,0 +1,504 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/atomicfile"
+)
+
+// Scheduled launches: start a session (tool, path, prompt) on a cron-like
+// schedule, e.g. a nightly "summarize yesterday's commits" Claude run.
+// Schedules come from [schedules.<name>] in config.toml or from
+// `agent-deck schedule add`, which stores them in the runtime schedules file
+// alongside the last-run bookkeeping for every schedule. The TUI checks for
+// due schedules on its tick; `agent-deck schedule run` fires one by hand.
+
+// schedulesFileName is the deck-wide schedules state file under the runtime
+// data dir.
+const schedulesFileName = "schedules.json"
+
+// Sources reported on a Schedule.
+const (
+	ScheduleSourceConfig = "config"
+	ScheduleSourceCLI    = "cli"
+)
+
+// ScheduleDef is one scheduled launch.
+type ScheduleDef struct {
+	// Cron is a 5-field cron expression (minute hour day-of-month month
+	// day-of-week) or one of @hourly, @daily, @weekly, @monthly, @yearly.
+	Cron string `toml:"cron" json:"cron"`
+
+	// Tool to start (claude, gemini, codex, a [tools.<name>] entry, ...).
+	// Default: default_tool, then claude.
+	Tool string `toml:"tool,omitempty" json:"tool,omitempty"`
+
+	// Path is the project directory. ~ is expanded.
+	Path string `toml:"path" json:"path"`
+
+	// Prompt is sent as the first message. Empty just starts the tool.
+	Prompt string `toml:"prompt,omitempty" json:"prompt,omitempty"`
+
+	// Group for the new session. Default: derived from the path.
+	Group string `toml:"group,omitempty" json:"group,omitempty"`
+
+	// Title prefix for the new session; the run's date and time are appended.
+	// Default: the schedule name.
+	Title string `toml:"title,omitempty" json:"title,omitempty"`
+
+	// Profile whose TUI fires the schedule. Default: "default".
+	Profile string `toml:"profile,omitempty" json:"profile,omitempty"`
+
+	// Enabled toggles the schedule without deleting it. Default: true.
+	Enabled *bool `toml:"enabled,omitempty" json:"enabled,omitempty"`
+}
+
+// IsEnabled reports whether the schedule fires. Defaults to true.
+func (d ScheduleDef) IsEnabled() bool {
+	return d.Enabled == nil || *d.Enabled
+}
+
+// Schedule is a named ScheduleDef with where it was defined.
+type Schedule struct {
+	Name   string
+	Source string // ScheduleSourceConfig or ScheduleSourceCLI
+	ScheduleDef
+}
+
+// ProfileName returns the profile the schedule launches into.
+func (s Schedule) ProfileName() string {
+	if s.Profile == "" {
+		return DefaultProfile
+	}
+	return s.Profile
+}
+
+// ToolName returns the tool to start, falling back to default_tool and then
+// claude.
+func (s Schedule) ToolName() string {
+	if s.Tool != "" {
+		return s.Tool
+	}
+	if tool := GetDefaultTool(); tool != "" {
+		return tool
+	}
+	return "claude"
+}
+
+// RunTitle is the title of the session launched at now.
+func (s Schedule) RunTitle(now time.Time) string {
+	prefix := s.Title
+	if prefix == "" {
+		prefix = s.Name
+	}
+	return prefix + " " + now.Format("2006-01-02 15:04")
+}
+
+// NewInstance builds the (stopped) session for a run at now. The caller
+// starts it with StartWithMessage(s.Prompt), or Start when there is no prompt.
+func (s Schedule) NewInstance(now time.Time) *Instance {
+	tool := s.ToolName()
+	inst := NewInstanceWithTool(s.RunTitle(now), ExpandPath(s.Path), tool)
+	if s.Group != "" {
+		inst.GroupPath = s.Group
+	}
+	inst.Command = tool
+	if def := GetToolDef(tool); def != nil && def.Command != "" {
+		inst.Command = def.Command
+	}
+	return inst
+}
+
+// Validate checks that the schedule can be fired.
+func (d ScheduleDef) Validate() error {
+	if _, err := ParseCron(d.Cron); err != nil {
+		return err
+	}
+	if strings.TrimSpace(d.Path) == "" {
+		return fmt.Errorf("path is required")
+	}
+	return nil
+}
+
+// scheduleStore is the persisted form of the schedules file: schedules
+// added from the CLI plus when each schedule (from either source) last ran.
+type scheduleStore struct {
+	Schedules map[string]ScheduleDef `json:"schedules,omitempty"`
+	LastRun   map[string]time.Time   `json:"last_run,omitempty"`
+}
+
+// scheduleMu serializes read-modify-write cycles on the schedules file
+// within a process.
+var scheduleMu sync.Mutex
+
+func schedulesPath() (string, error) {
+	return runtimeDataPath(schedulesFileName)
+}
+
+func loadScheduleStore(path string) (scheduleStore, error) {
+	var st scheduleStore
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, err
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return scheduleStore{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return st, nil
+}
+
+func saveScheduleStore(path string, st scheduleStore) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, data, 0o644)
+}
+
+// mergeSchedules combines config and CLI schedules, sorted by name. A config
+// schedule shadows a CLI one of the same name.
+func mergeSchedules(config map[string]ScheduleDef, st scheduleStore) []Schedule {
+	var out []Schedule
+	for name, def := range config {
+		out = append(out, Schedule{Name: name, Source: ScheduleSourceConfig, ScheduleDef: def})
+	}
+	for name, def := range st.Schedules {
+		if _, shadowed := config[name]; shadowed {
+			continue
+		}
+		out = append(out, Schedule{Name: name, Source: ScheduleSourceCLI, ScheduleDef: def})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func configSchedules() map[string]ScheduleDef {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	return config.Schedules
+}
+
+// LoadSchedules returns every schedule with its last run (zero if it never
+// ran), sorted by name.
+func LoadSchedules() ([]Schedule, map[string]time.Time, error) {
+	path, err := schedulesPath()
+	if err != nil {
+		return nil, nil, err
+	}
+	st, err := loadScheduleStore(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mergeSchedules(configSchedules(), st), st.LastRun, nil
+}
+
+// FindSchedule returns the schedule called name.
+func FindSchedule(name string) (Schedule, error) {
+	all, _, err := LoadSchedules()
+	if err != nil {
+		return Schedule{}, err
+	}
+	for _, s := range all {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return Schedule{}, fmt.Errorf("schedule '%s' not found", name)
+}
+
+// AddSchedule stores a CLI schedule. Names defined in config.toml are
+// rejected; an existing CLI schedule of the same name is replaced.
+func AddSchedule(name string, def ScheduleDef) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("schedule name is required")
+	}
+	if err := def.Validate(); err != nil {
+		return err
+	}
+	if _, ok := configSchedules()[name]; ok {
+		return fmt.Errorf("schedule '%s' is defined in config.toml", name)
+	}
+
+	scheduleMu.Lock()
+	defer scheduleMu.Unlock()
+	path, err := schedulesPath()
+	if err != nil {
+		return err
+	}
+	st, err := loadScheduleStore(path)
+	if err != nil {
+		return err
+	}
+	if st.Schedules == nil {
+		st.Schedules = map[string]ScheduleDef{}
+	}
+	st.Schedules[name] = def
+	// A replaced schedule starts over from now rather than firing for a
+	// slot computed from the old expression.
+	delete(st.LastRun, name)
+	return saveScheduleStore(path, st)
+}
+
+// RemoveSchedule deletes a CLI schedule. Config schedules must be removed
+// from config.toml (or disabled with enabled = false).
+func RemoveSchedule(name string) error {
+	if _, ok := configSchedules()[name]; ok {
+		return fmt.Errorf("schedule '%s' is defined in config.toml; remove it there or set enabled = false", name)
+	}
+
+	scheduleMu.Lock()
+	defer scheduleMu.Unlock()
+	path, err := schedulesPath()
+	if err != nil {
+		return err
+	}
+	st, err := loadScheduleStore(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := st.Schedules[name]; !ok {
+		return fmt.Errorf("schedule '%s' not found", name)
+	}
+	delete(st.Schedules, name)
+	delete(st.LastRun, name)
+	return saveScheduleStore(path, st)
+}
+
+// MarkScheduleRun records that name ran at t.
+func MarkScheduleRun(name string, t time.Time) error {
+	scheduleMu.Lock()
+	defer scheduleMu.Unlock()
+	path, err := schedulesPath()
+	if err != nil {
+		return err
+	}
+	st, err := loadScheduleStore(path)
+	if err != nil {
+		return err
+	}
+	if st.LastRun == nil {
+		st.LastRun = map[string]time.Time{}
+	}
+	st.LastRun[name] = t
+	return saveScheduleStore(path, st)
+}
+
+// ClaimDueSchedules returns the enabled schedules of profile that are due at
+// now and records them as run, so another agent-deck process checking the
+// same file doesn't fire them again. A schedule seen for the first time only
+// records now as its baseline. Runs missed while nothing was checking fire
+// once, not once per missed slot.
+func ClaimDueSchedules(profile string, now time.Time) ([]Schedule, error) {
+	scheduleMu.Lock()
+	defer scheduleMu.Unlock()
+	path, err := schedulesPath()
+	if err != nil {
+		return nil, err
+	}
+	st, err := loadScheduleStore(path)
+	if err != nil {
+		return nil, err
+	}
+	due, changed := claimDue(mergeSchedules(configSchedules(), st), &st, profile, now)
+	if changed {
+		if err := saveScheduleStore(path, st); err != nil {
+			return nil, err
+		}
+	}
+	return due, nil
+}
+
+func claimDue(all []Schedule, st *scheduleStore, profile string, now time.Time) ([]Schedule, bool) {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	var due []Schedule
+	changed := false
+	for _, s := range all {
+		if !s.IsEnabled() || s.ProfileName() != profile {
+			continue
+		}
+		cron, err := ParseCron(s.Cron)
+		if err != nil {
+			continue
+		}
+		if st.LastRun == nil {
+			st.LastRun = map[string]time.Time{}
+		}
+		last, seen := st.LastRun[s.Name]
+		if !seen {
+			st.LastRun[s.Name] = now
+			changed = true
+			continue
+		}
+		if next := cron.Next(last); !next.IsZero() && !next.After(now) {
+			st.LastRun[s.Name] = now
+			changed = true
+			due = append(due, s)
+		}
+	}
+	return due, changed
+}
+
+// CronSchedule is a parsed cron expression. Each field is a bitset of the
+// values it matches.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar/dowStar record an unrestricted field: when both day fields are
+	// restricted, cron matches a day that satisfies either of them.
+	domStar, dowStar bool
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronDayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// ParseCron parses a 5-field cron expression (with *, lists, ranges, steps
+// and month/day names) or a macro such as @daily.
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(strings.ToLower(expr))
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron %q: want 5 fields (minute hour day month weekday) or @daily/@hourly/...", expr)
+	}
+	c := &CronSchedule{}
+	var err error
+	if c.minute, _, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron minute: %w", err)
+	}
+	if c.hour, _, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron hour: %w", err)
+	}
+	if c.dom, c.domStar, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron day of month: %w", err)
+	}
+	if c.month, _, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return nil, fmt.Errorf("invalid cron month: %w", err)
+	}
+	if c.dow, c.dowStar, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return nil, fmt.Errorf("invalid cron day of week: %w", err)
+	}
+	if c.dow&(1<<7) != 0 { // 7 is Sunday too
+		c.dow |= 1
+	}
+	return c, nil
+}
+
+func parseCronField(field string, lo, hi int, names map[string]int) (uint64, bool, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, false, fmt.Errorf("bad step in %q", part)
+			}
+			rng, step = part[:i], n
+		}
+		start, end := lo, hi
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], names); err != nil {
+				return 0, false, err
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = parseCronValue(bounds[1], names); err != nil {
+					return 0, false, err
+				}
+			} else if step > 1 {
+				end = hi // "5/15" means from 5 to the end
+			}
+		}
+		if start < lo || end > hi || start > end {
+			return 0, false, fmt.Errorf("%q out of range %d-%d", part, lo, hi)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	// Like vixie cron, a field starting with * counts as unrestricted for
+	// the day-of-month/day-of-week rule.
+	return bits, strings.HasPrefix(field, "*"), nil
+}
+
+func parseCronValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[s]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("bad value %q", s)
+	}
+	return v, nil
+}
+
+func (c *CronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if !c.domStar && !c.dowStar {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// Next returns the first time strictly after t that matches, in t's
+// location, or the zero time if nothing matches within five years (e.g.
+// "0 0 30 2 *").
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
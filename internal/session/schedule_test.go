@@ -0,0 +1,130 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	loc := time.UTC
+	at := func(y int, m time.Month, d, h, min int) time.Time { return time.Date(y, m, d, h, min, 0, 0, loc) }
+	// Thursday 2026-10-15 10:30
+	from := at(2026, 10, 15, 10, 30)
+
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", at(2026, 10, 15, 10, 45)},
+		{"0 7 * * *", at(2026, 10, 16, 7, 0)},
+		{"@hourly", at(2026, 10, 15, 11, 0)},
+		{"@daily", at(2026, 10, 16, 0, 0)},
+		{"0 9 * * mon-fri", at(2026, 10, 16, 9, 0)},
+		{"0 9 * * 1", at(2026, 10, 19, 9, 0)},
+		{"0 9 * * 7", at(2026, 10, 18, 9, 0)}, // 7 is Sunday
+		{"30 10 15 * *", at(2026, 11, 15, 10, 30)},
+		{"0 0 1 jan *", at(2027, 1, 1, 0, 0)},
+		{"5,35 10 * * *", at(2026, 10, 15, 10, 35)},
+		// Both day fields restricted: either matches (the 20th or a Monday).
+		{"0 0 20 * mon", at(2026, 10, 19, 0, 0)},
+	}
+	for _, c := range cases {
+		cron, err := ParseCron(c.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", c.expr, err)
+		}
+		if got := cron.Next(from); !got.Equal(c.want) {
+			t.Errorf("Next(%q) = %s, want %s", c.expr, got, c.want)
+		}
+	}
+
+	never, err := ParseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := never.Next(from); !got.IsZero() {
+		t.Errorf("Feb 30 should never match, got %s", got)
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "@fortnightly", "* * * foo *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) should fail", expr)
+		}
+	}
+}
+
+func TestClaimDue(t *testing.T) {
+	base := time.Date(2026, 10, 15, 6, 59, 0, 0, time.UTC)
+	all := []Schedule{
+		{Name: "nightly", ScheduleDef: ScheduleDef{Cron: "0 7 * * *", Path: "/p"}},
+		{Name: "work", ScheduleDef: ScheduleDef{Cron: "0 7 * * *", Path: "/p", Profile: "work"}},
+		{Name: "off", ScheduleDef: ScheduleDef{Cron: "0 7 * * *", Path: "/p", Enabled: boolPtr(false)}},
+	}
+	st := &scheduleStore{}
+
+	due, changed := claimDue(all, st, "", base)
+	if len(due) != 0 || !changed {
+		t.Fatalf("first sighting only records a baseline, got %d due", len(due))
+	}
+	if _, ok := st.LastRun["work"]; ok {
+		t.Error("another profile's schedule must not be touched")
+	}
+	if _, ok := st.LastRun["off"]; ok {
+		t.Error("disabled schedule must not be touched")
+	}
+
+	if due, _ := claimDue(all, st, "default", base.Add(30*time.Second)); len(due) != 0 {
+		t.Fatal("not due before 07:00")
+	}
+	// A TUI that was off for three days fires once, not three times.
+	later := base.Add(72*time.Hour + 2*time.Minute)
+	due, _ = claimDue(all, st, "default", later)
+	if len(due) != 1 || due[0].Name != "nightly" {
+		t.Fatalf("due = %+v, want nightly", due)
+	}
+	if due, _ := claimDue(all, st, "default", later.Add(time.Minute)); len(due) != 0 {
+		t.Error("a claimed run must not fire again")
+	}
+}
+
+func TestScheduleStore_AddRemove(t *testing.T) {
+	if err := AddSchedule("nightly", ScheduleDef{Cron: "bad", Path: "/p"}); err == nil {
+		t.Fatal("invalid cron should be rejected")
+	}
+	if err := AddSchedule("nightly", ScheduleDef{Cron: "@daily"}); err == nil {
+		t.Fatal("missing path should be rejected")
+	}
+	if err := AddSchedule("nightly", ScheduleDef{Cron: "@daily", Path: "/p", Prompt: "summarize"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := MarkScheduleRun("nightly", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	s, err := FindSchedule("nightly")
+	if err != nil || s.Source != ScheduleSourceCLI || s.Prompt != "summarize" {
+		t.Fatalf("FindSchedule = %+v, %v", s, err)
+	}
+	if err := RemoveSchedule("nightly"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindSchedule("nightly"); err == nil {
+		t.Error("removed schedule should be gone")
+	}
+	if err := RemoveSchedule("nightly"); err == nil {
+		t.Error("removing a missing schedule should fail")
+	}
+}
+
+func TestSchedule_RunTitleAndInstance(t *testing.T) {
+	s := Schedule{Name: "nightly", ScheduleDef: ScheduleDef{Cron: "@daily", Tool: "claude", Path: "/tmp/proj", Group: "reports"}}
+	now := time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC)
+	if got := s.RunTitle(now); got != "nightly 2026-10-15 07:00" {
+		t.Errorf("RunTitle = %q", got)
+	}
+	inst := s.NewInstance(now)
+	if inst.Tool != "claude" || inst.Command != "claude" || inst.GroupPath != "reports" || inst.ProjectPath != "/tmp/proj" {
+		t.Errorf("instance = tool %q cmd %q group %q path %q", inst.Tool, inst.Command, inst.GroupPath, inst.ProjectPath)
+	}
+}
@@ -0,0 +1,52 @@
+package session
+
+import "strings"
+
+// SlackIntegrationSettings configures the [slack] integration: a bot posts
+// to a channel when a session needs input, and replies in that session's
+// thread (or /agentdeck slash commands) are typed into the session. This is
+// independent of [conductor.slack], which bridges a channel to a conductor.
+type SlackIntegrationSettings struct {
+	// Enabled turns the integration on (default: false).
+	Enabled bool `toml:"enabled,omitempty"`
+
+	// BotToken is the bot token (xoxb-...). Scopes: chat:write and
+	// channels:history (groups:history for a private channel).
+	BotToken string `toml:"bot_token,omitempty"`
+
+	// ChannelID is the channel the notices are posted to (C01234...).
+	ChannelID string `toml:"channel_id,omitempty"`
+
+	// SigningSecret verifies slash-command requests served by `agent-deck
+	// web`. Slash commands are disabled while it is empty.
+	SigningSecret string `toml:"signing_secret,omitempty"`
+
+	// AllowedUserIDs lists the Slack users whose replies are routed to
+	// sessions. Empty routes nobody's: replies drive live agents, so the
+	// allowlist is required rather than open by default.
+	AllowedUserIDs []string `toml:"allowed_user_ids,omitempty"`
+}
+
+// Configured reports whether notices can be posted.
+func (s SlackIntegrationSettings) Configured() bool {
+	return s.Enabled && s.BotToken != "" && s.ChannelID != ""
+}
+
+// AllowsUser reports whether replies from the Slack user id are routed.
+func (s SlackIntegrationSettings) AllowsUser(id string) bool {
+	for _, allowed := range s.AllowedUserIDs {
+		if strings.TrimSpace(allowed) == id && id != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSlackIntegrationSettings returns the [slack] section from config.toml.
+func GetSlackIntegrationSettings() SlackIntegrationSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return SlackIntegrationSettings{}
+	}
+	return config.Slack
+}
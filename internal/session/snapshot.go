@@ -0,0 +1,63 @@
+package session
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+const defaultSnapshotKeep = 20
+
+// SnapshotSettings configures checkout snapshots: commits of a session's
+// working tree kept under refs/agent-deck/snapshots/, so a bad agent edit
+// can be rolled back from the TUI.
+type SnapshotSettings struct {
+	// Auto takes a snapshot before each prompt: on Claude's UserPromptSubmit
+	// hook and when the TUI sends a prompt. Unchanged trees are not
+	// snapshotted twice. Default: true
+	Auto *bool `toml:"auto,omitempty"`
+
+	// Keep is how many snapshots are kept per session; older ones are
+	// pruned. Default: 20
+	Keep int `toml:"keep,omitzero"`
+}
+
+// GetAuto reports whether snapshots are taken before each prompt.
+func (s SnapshotSettings) GetAuto() bool {
+	if s.Auto == nil {
+		return true
+	}
+	return *s.Auto
+}
+
+// GetKeep returns how many snapshots to keep per session.
+func (s SnapshotSettings) GetKeep() int {
+	if s.Keep <= 0 {
+		return defaultSnapshotKeep
+	}
+	return s.Keep
+}
+
+// TakeSnapshot snapshots the session's checkout in dir. Not logged: an
+// automatic snapshot per prompt would crowd out everything else.
+func (i *Instance) TakeSnapshot(dir, reason string, keep int) (git.Snapshot, bool, error) {
+	return git.CreateSnapshot(dir, i.ID, reason, keep)
+}
+
+// RollbackToSnapshot restores the checkout in dir to snap, after saving the
+// current state as a "before rollback" snapshot, and records the rollback
+// in the session's event log.
+func (i *Instance) RollbackToSnapshot(dir string, snap git.Snapshot, keep int) (git.Snapshot, error) {
+	safety, err := git.RestoreSnapshot(dir, i.ID, snap, keep)
+	i.recordEvent(EventRolledBack, fmt.Sprintf("to %s (%s, %s)", shortHash(snap.Commit), snap.Reason,
+		snap.Created.Format(time.DateTime)), err)
+	return safety, err
+}
+
+func shortHash(h string) string {
+	if len(h) > 8 {
+		return h[:8]
+	}
+	return h
+}
@@ -0,0 +1,15 @@
+package session
+
+import "testing"
+
+func TestSnapshotSettingsDefaults(t *testing.T) {
+	var s SnapshotSettings
+	if !s.GetAuto() || s.GetKeep() != defaultSnapshotKeep {
+		t.Errorf("defaults: auto=%v keep=%d", s.GetAuto(), s.GetKeep())
+	}
+	off := false
+	s = SnapshotSettings{Auto: &off, Keep: 5}
+	if s.GetAuto() || s.GetKeep() != 5 {
+		t.Errorf("configured: auto=%v keep=%d", s.GetAuto(), s.GetKeep())
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/asheshgoplani/agent-deck/internal/logging"
@@ -96,6 +97,15 @@ type InstanceData struct {
 	LatestPrompt string `json:"latest_prompt,omitempty"`
 	Notes        string `json:"notes,omitempty"`
 
+	// Tags mirrors Instance.Tags.
+	Tags []string `json:"tags,omitempty"`
+
+	// Chain mirrors Instance.Chain.
+	Chain *SessionChain `json:"chain,omitempty"`
+
+	// InitialPrompt mirrors Instance.InitialPrompt.
+	InitialPrompt string `json:"initial_prompt,omitempty"`
+
 	// Tool-specific launch options (generic for all tools: claude, codex, etc.)
 	ToolOptionsJSON json.RawMessage `json:"tool_options,omitempty"`
 
@@ -145,6 +155,12 @@ type InstanceData struct {
 
 	// IdleTimeoutSecs mirrors Instance.IdleTimeoutSecs (#1143). 0 = disabled.
 	IdleTimeoutSecs int64 `json:"idle_timeout_secs,omitempty"`
+
+	// RestartHistory mirrors Instance.RestartHistory.
+	RestartHistory []RestartEvent `json:"restart_history,omitempty"`
+
+	// EventLog mirrors Instance.EventLog.
+	EventLog []SessionEvent `json:"event_log,omitempty"`
 }
 
 // GroupData represents serializable group data
@@ -158,6 +174,12 @@ type GroupData struct {
 	// 0 = unlimited (legacy default for groups predating this field); 1 = serial
 	// (default for newly-created groups); N>=2 = bounded parallelism.
 	MaxConcurrent int `json:"max_concurrent,omitempty"`
+	// NotificationsMuted keeps the group's sessions off the notification bar.
+	NotificationsMuted bool `json:"notifications_muted,omitempty"`
+	// SessionDefaults pre-populate new sessions created in the group.
+	SessionDefaults GroupSessionDefaults `json:"session_defaults,omitzero"`
+	// Archived hides the group from the main tree.
+	Archived bool `json:"archived,omitempty"`
 }
 
 // Storage handles persistence of session data via SQLite.
@@ -168,6 +190,9 @@ type Storage struct {
 	dbPath  string     // Path to state.db (for change detection)
 	profile string     // The profile this storage is for
 	mu      sync.Mutex // Protects operations during transition
+
+	// updating: s holds the process's update lock (see LockForUpdate).
+	updating bool
 }
 
 // NewStorageWithProfile creates a storage instance for a specific profile.
@@ -262,8 +287,10 @@ func (s *Storage) GetDB() *statedb.StateDB {
 	return s.db
 }
 
-// Close closes the underlying database connection.
+// Close closes the underlying database connection and releases the update
+// lock, if held.
 func (s *Storage) Close() error {
+	s.Unlock()
 	if s.db != nil {
 		return s.db.Close()
 	}
@@ -310,6 +337,12 @@ func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) er
 		return fmt.Errorf("storage database not initialized")
 	}
 
+	lock, err := s.lockStorageFile(syscall.LOCK_EX)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
 	// Enforce one Claude conversation owner across persisted sessions.
 	// This protects CLI-only flows as well (the TUI already applies this in-memory).
 	UpdateClaudeSessionsWithDedup(instances)
@@ -333,12 +366,15 @@ func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) er
 		groupRows := make([]*statedb.GroupRow, 0, len(groupTree.GroupList))
 		for _, g := range groupTree.GroupList {
 			groupRows = append(groupRows, &statedb.GroupRow{
-				Path:          g.Path,
-				Name:          g.Name,
-				Expanded:      g.Expanded,
-				Order:         g.Order,
-				DefaultPath:   g.DefaultPath,
-				MaxConcurrent: g.MaxConcurrent,
+				Path:               g.Path,
+				Name:               g.Name,
+				Expanded:           g.Expanded,
+				Order:              g.Order,
+				DefaultPath:        g.DefaultPath,
+				MaxConcurrent:      g.MaxConcurrent,
+				NotificationsMuted: g.NotificationsMuted,
+				SessionDefaults:    g.SessionDefaults.marshal(),
+				Archived:           g.Archived,
 			})
 		}
 		if err := s.db.SaveGroups(groupRows); err != nil {
@@ -390,6 +426,42 @@ func (s *Storage) WriteAutoNameDescription(id, description string) error {
 	return nil
 }
 
+// WriteTitle persists a single session's title and title lock via a targeted
+// column update (see statedb.WriteTitle), so a rename lands even when a full
+// save would be skipped for an external change.
+func (s *Storage) WriteTitle(id, title string, locked bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return fmt.Errorf("storage database not initialized")
+	}
+
+	if err := s.db.WriteTitle(id, title, locked); err != nil {
+		return fmt.Errorf("failed to persist title for %s: %w", id, err)
+	}
+
+	_ = s.db.Touch()
+	return nil
+}
+
+// WriteLastAccessed persists a single session's last-accessed time via a
+// targeted column update (see statedb.WriteLastAccessed). It does not touch
+// the change marker: recency alone is not worth a reload in other processes.
+func (s *Storage) WriteLastAccessed(id string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return fmt.Errorf("storage database not initialized")
+	}
+
+	if err := s.db.WriteLastAccessed(id, at); err != nil {
+		return fmt.Errorf("failed to persist last-accessed time for %s: %w", id, err)
+	}
+	return nil
+}
+
 // InstanceExists returns true iff a row with the given id is currently
 // persisted. Used by RemoveSessionAndVerify to confirm a DELETE actually
 // landed (issue #909).
@@ -719,6 +791,11 @@ func instanceToRow(inst *Instance) (*statedb.InstanceRow, error) {
 	// the positional MarshalToolData signature so legacy binaries that don't
 	// know the key preserve it via MergeToolDataExtras.
 	toolData = WriteIdleTimeoutSecsToToolData(toolData, inst.IdleTimeoutSecs)
+	toolData = WriteRestartHistoryToToolData(toolData, inst.GetRestartHistory())
+	toolData = WriteEventLogToToolData(toolData, inst.GetEventLog())
+	toolData = WriteTagsToToolData(toolData, inst.Tags)
+	toolData = WriteChainToToolData(toolData, inst.Chain)
+	toolData = WriteInitialPromptToToolData(toolData, inst.InitialPrompt)
 
 	return &statedb.InstanceRow{
 		ID:                  inst.ID,
@@ -768,12 +845,15 @@ func (s *Storage) SaveGroupsOnly(groupTree *GroupTree) error {
 	groupRows := make([]*statedb.GroupRow, 0, len(groupTree.GroupList))
 	for _, g := range groupTree.GroupList {
 		groupRows = append(groupRows, &statedb.GroupRow{
-			Path:          g.Path,
-			Name:          g.Name,
-			Expanded:      g.Expanded,
-			Order:         g.Order,
-			DefaultPath:   g.DefaultPath,
-			MaxConcurrent: g.MaxConcurrent,
+			Path:               g.Path,
+			Name:               g.Name,
+			Expanded:           g.Expanded,
+			Order:              g.Order,
+			DefaultPath:        g.DefaultPath,
+			MaxConcurrent:      g.MaxConcurrent,
+			NotificationsMuted: g.NotificationsMuted,
+			SessionDefaults:    g.SessionDefaults.marshal(),
+			Archived:           g.Archived,
 		})
 	}
 
@@ -890,6 +970,11 @@ func (s *Storage) LoadLite() ([]*InstanceData, []*GroupData, error) {
 			AutoLinkedChannels:        autoLinkedChannels2,
 			Color:                     color2,
 			IdleTimeoutSecs:           ReadIdleTimeoutSecsFromToolData(r.ToolData),
+			RestartHistory:            ReadRestartHistoryFromToolData(r.ToolData),
+			EventLog:                  ReadEventLogFromToolData(r.ToolData),
+			Tags:                      ReadTagsFromToolData(r.ToolData),
+			Chain:                     ReadChainFromToolData(r.ToolData),
+			InitialPrompt:             ReadInitialPromptFromToolData(r.ToolData),
 		}
 	}
 
@@ -897,12 +982,15 @@ func (s *Storage) LoadLite() ([]*InstanceData, []*GroupData, error) {
 	groups := make([]*GroupData, len(dbGroups))
 	for i, g := range dbGroups {
 		groups[i] = &GroupData{
-			Path:          g.Path,
-			Name:          g.Name,
-			Expanded:      g.Expanded,
-			Order:         g.Order,
-			DefaultPath:   g.DefaultPath,
-			MaxConcurrent: g.MaxConcurrent,
+			Path:               g.Path,
+			Name:               g.Name,
+			Expanded:           g.Expanded,
+			Order:              g.Order,
+			DefaultPath:        g.DefaultPath,
+			MaxConcurrent:      g.MaxConcurrent,
+			NotificationsMuted: g.NotificationsMuted,
+			SessionDefaults:    parseGroupSessionDefaults(g.SessionDefaults),
+			Archived:           g.Archived,
 		}
 	}
 
@@ -919,6 +1007,12 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 		return []*Instance{}, nil, nil
 	}
 
+	lock, err := s.lockStorageFile(syscall.LOCK_SH)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer lock.release()
+
 	// Load from SQLite
 	dbRows, err := s.db.LoadInstances()
 	if err != nil {
@@ -1009,6 +1103,11 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 			AutoLinkedChannels:        autoLinkedChannels,
 			Color:                     color,
 			IdleTimeoutSecs:           ReadIdleTimeoutSecsFromToolData(r.ToolData),
+			RestartHistory:            ReadRestartHistoryFromToolData(r.ToolData),
+			EventLog:                  ReadEventLogFromToolData(r.ToolData),
+			Tags:                      ReadTagsFromToolData(r.ToolData),
+			Chain:                     ReadChainFromToolData(r.ToolData),
+			InitialPrompt:             ReadInitialPromptFromToolData(r.ToolData),
 		}
 	}
 
@@ -1016,12 +1115,15 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 	data.Groups = make([]*GroupData, len(dbGroups))
 	for i, g := range dbGroups {
 		data.Groups[i] = &GroupData{
-			Path:          g.Path,
-			Name:          g.Name,
-			Expanded:      g.Expanded,
-			Order:         g.Order,
-			DefaultPath:   g.DefaultPath,
-			MaxConcurrent: g.MaxConcurrent,
+			Path:               g.Path,
+			Name:               g.Name,
+			Expanded:           g.Expanded,
+			Order:              g.Order,
+			DefaultPath:        g.DefaultPath,
+			MaxConcurrent:      g.MaxConcurrent,
+			NotificationsMuted: g.NotificationsMuted,
+			SessionDefaults:    parseGroupSessionDefaults(g.SessionDefaults),
+			Archived:           g.Archived,
 		}
 	}
 
@@ -1078,6 +1180,25 @@ func GetDBPathForProfile(profile string) (string, error) {
 	return filepath.Join(profileDir, "state.db"), nil
 }
 
+// ProfileSessionCount returns how many sessions profile holds without opening
+// a full Storage (no migration, no JSON import). A profile with no state.db
+// yet has zero sessions.
+func ProfileSessionCount(profile string) (int, error) {
+	dbPath, err := GetDBPathForProfile(profile)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return 0, nil
+	}
+	db, err := statedb.Open(dbPath)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+	return db.CountInstances()
+}
+
 // GetUpdatedAt returns the last modification timestamp from SQLite metadata.
 func (s *Storage) GetUpdatedAt() (time.Time, error) {
 	s.mu.Lock()
@@ -1255,6 +1376,11 @@ func (s *Storage) convertToInstances(data *StorageData) ([]*Instance, []*GroupDa
 			AutoLinkedChannels:        instData.AutoLinkedChannels,
 			Color:                     instData.Color,
 			IdleTimeoutSecs:           instData.IdleTimeoutSecs,
+			RestartHistory:            instData.RestartHistory,
+			EventLog:                  instData.EventLog,
+			Tags:                      instData.Tags,
+			Chain:                     instData.Chain,
+			InitialPrompt:             instData.InitialPrompt,
 			Sandbox:                   instData.Sandbox,
 			SandboxContainer:          instData.SandboxContainer,
 			SSHHost:                   instData.SSHHost,
@@ -1278,6 +1404,7 @@ func (s *Storage) convertToInstances(data *StorageData) ([]*Instance, []*GroupDa
 		// respects user-defined keys (e.g. status = "2" for multi-line bar).
 		if tmuxSess != nil {
 			tmuxSess.OptionOverrides = inst.buildTmuxOptionOverrides()
+			tmuxSess.Hooks = inst.buildTmuxHooks()
 		}
 
 		// PERFORMANCE: Skip UpdateStatus at load time - use cached status from SQLite
@@ -3,6 +3,7 @@ package session
 import (
 	"path/filepath"
 	"sync"
+	"syscall"
 	"testing"
 	"time"
 
@@ -97,3 +98,75 @@ func TestConcurrentStorageWrites(t *testing.T) {
 	assert.LessOrEqual(t, holdersCount, 1,
 		"at most one session should retain the shared ClaudeSessionID after concurrent writes and dedup")
 }
+
+// TestSaveWithGroupsWaitsForStorageLock verifies a save blocks while another
+// holder (standing in for a concurrent process) has the storage lockfile, and
+// completes once it is released.
+func TestSaveWithGroupsWaitsForStorageLock(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "state.db")
+	db, err := statedb.Open(dbPath)
+	require.NoError(t, err)
+	require.NoError(t, db.Migrate())
+	t.Cleanup(func() { db.Close() })
+	s := &Storage{db: db, dbPath: dbPath, profile: "_test"}
+
+	holder := &Storage{dbPath: dbPath}
+	lock, err := holder.lockStorageFile(syscall.LOCK_EX)
+	require.NoError(t, err)
+
+	instances := []*Instance{{
+		ID: "locked-1", Title: "Locked", ProjectPath: "/tmp/locked",
+		GroupPath: "test", Tool: "shell", Status: StatusIdle, CreatedAt: time.Now(),
+	}}
+	done := make(chan error, 1)
+	go func() { done <- s.SaveWithGroups(instances, NewGroupTree(instances)) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("save completed while the storage lock was held (err=%v)", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	lock.release()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("save did not complete after the storage lock was released")
+	}
+	exists, err := s.InstanceExists("locked-1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+// TestLockForUpdateSpansLoadAndSave verifies the update lock keeps other
+// processes out from before a load until Unlock, while this process's own
+// loads and saves of the profile still go through.
+func TestLockForUpdateSpansLoadAndSave(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "state.db")
+	db, err := statedb.Open(dbPath)
+	require.NoError(t, err)
+	require.NoError(t, db.Migrate())
+	t.Cleanup(func() { db.Close() })
+	s := &Storage{db: db, dbPath: dbPath, profile: "_test"}
+	other := &Storage{db: db, dbPath: dbPath, profile: "_test"}
+
+	require.NoError(t, s.LockForUpdate())
+	// A second descriptor stands in for another process.
+	_, err = flockFile(dbPath+".lock", syscall.LOCK_SH|syscall.LOCK_NB)
+	require.Error(t, err, "another process must not get the lock between load and save")
+
+	instances, groups, err := s.LoadWithGroups()
+	require.NoError(t, err)
+	instances = append(instances, &Instance{
+		ID: "update-1", Title: "Update", ProjectPath: "/tmp/update",
+		GroupPath: "test", Tool: "shell", Status: StatusIdle, CreatedAt: time.Now(),
+	})
+	require.NoError(t, other.SaveWithGroups(instances, NewGroupTreeWithGroups(instances, groups)),
+		"a save in the locking process must not wait on its own lock")
+
+	s.Unlock()
+	lock, err := flockFile(dbPath+".lock", syscall.LOCK_EX|syscall.LOCK_NB)
+	require.NoError(t, err, "Unlock must release the lockfile")
+	lock.release()
+}
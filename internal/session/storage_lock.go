@@ -0,0 +1,125 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// storageFileLock is an advisory flock on "<state.db>.lock". Storage.mu only
+// serializes callers sharing one Storage; the TUI, web server, and every CLI
+// invocation each open their own, so a whole-table save (instances, then
+// groups, in separate transactions) could interleave with another process's
+// save or be observed half-written by a concurrent load. Saves take the lock
+// exclusive and loads take it shared, making each one atomic with respect to
+// the others across processes. LockForUpdate extends that to a whole
+// load-modify-save sequence.
+type storageFileLock struct {
+	file *os.File
+}
+
+func (l *storageFileLock) release() {
+	if l == nil || l.file == nil {
+		return
+	}
+	// Best-effort: Close drops the fd, which also releases the lock.
+	_ = syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	_ = l.file.Close()
+}
+
+// updateLock is an update lock held by this process, shared by every
+// Storage on the same lockfile.
+type updateLock struct {
+	lock *storageFileLock
+	refs int
+}
+
+// updateLocks maps lockfile paths to the update locks this process holds.
+// flock conflicts between two descriptors of the same process, so while one
+// is held, the process's own loads and saves of that profile must not take
+// the lockfile again: they are already covered by it.
+var (
+	updateLocksMu sync.Mutex
+	updateLocks   = map[string]*updateLock{}
+)
+
+// LockForUpdate takes the storage lockfile exclusively and holds it until
+// Unlock or Close, so a load, the caller's changes and the following save
+// cannot interleave with another process's save. It blocks while another
+// process holds the lock. Within this process it nests: loads and saves of
+// the same profile, through any Storage, go ahead while it is held.
+func (s *Storage) LockForUpdate() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dbPath == "" || s.updating {
+		return nil
+	}
+	lockPath := s.dbPath + ".lock"
+	updateLocksMu.Lock()
+	defer updateLocksMu.Unlock()
+	held := updateLocks[lockPath]
+	if held == nil {
+		lock, err := flockFile(lockPath, syscall.LOCK_EX)
+		if err != nil {
+			return err
+		}
+		held = &updateLock{lock: lock}
+		updateLocks[lockPath] = held
+	}
+	held.refs++
+	s.updating = true
+	return nil
+}
+
+// Unlock releases the lock taken by LockForUpdate. It is a no-op when s
+// does not hold it.
+func (s *Storage) Unlock() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.updating {
+		return
+	}
+	s.updating = false
+	lockPath := s.dbPath + ".lock"
+	updateLocksMu.Lock()
+	defer updateLocksMu.Unlock()
+	if held := updateLocks[lockPath]; held != nil {
+		if held.refs--; held.refs <= 0 {
+			held.lock.release()
+			delete(updateLocks, lockPath)
+		}
+	}
+}
+
+// lockStorageFile takes an advisory flock (syscall.LOCK_EX or LOCK_SH) on the
+// storage's lockfile, blocking until it is granted. Storage values built
+// without a database path (tests) get a nil lock, whose release is a no-op,
+// as do callers while this process holds the update lock (LockForUpdate).
+// Callers hold s.mu first and MUST defer release().
+func (s *Storage) lockStorageFile(how int) (*storageFileLock, error) {
+	if s.dbPath == "" {
+		return nil, nil
+	}
+	lockPath := s.dbPath + ".lock"
+	updateLocksMu.Lock()
+	_, held := updateLocks[lockPath]
+	updateLocksMu.Unlock()
+	if held {
+		return nil, nil
+	}
+	return flockFile(lockPath, how)
+}
+
+// flockFile opens (creating) lockPath and flocks it.
+func flockFile(lockPath string, how int) (*storageFileLock, error) {
+	f, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open storage lock %q: %w", lockPath, err)
+	}
+	if err := syscall.Flock(int(f.Fd()), how); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("flock storage: %w", err)
+	}
+	return &storageFileLock{file: f}, nil
+}
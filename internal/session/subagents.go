@@ -0,0 +1,134 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// subagentActiveWindow is how recently a sub-agent transcript must have been
+// written for the sub-agent to count as still running.
+const subagentActiveWindow = 2 * time.Minute
+
+// subagentPromptMaxLen caps SubagentInfo.Prompt; it is a list label, not the
+// full task.
+const subagentPromptMaxLen = 80
+
+// Active reports whether the sub-agent wrote to its transcript recently.
+func (s SubagentInfo) Active(now time.Time) bool {
+	return !s.LastActive.IsZero() && now.Sub(s.LastActive) < subagentActiveWindow
+}
+
+// subagentEntry is the subset of a sub-agent transcript line we read. Claude
+// stores Task sub-agents next to the parent transcript, in
+// <session-id>/subagents/agent-<agent-id>.jsonl.
+type subagentEntry struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	AgentID   string    `json:"agentId"`
+	Message   struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// FindClaudeSubagents discovers the Task sub-agents spawned by the Claude
+// session whose transcript is jsonlPath, oldest first. Sessions without
+// sub-agents (or without a transcript) yield nil.
+func FindClaudeSubagents(jsonlPath string) []SubagentInfo {
+	if jsonlPath == "" {
+		return nil
+	}
+	dir := filepath.Join(strings.TrimSuffix(jsonlPath, ".jsonl"), "subagents")
+	files, _ := filepath.Glob(filepath.Join(dir, "agent-*.jsonl"))
+	var agents []SubagentInfo
+	for _, file := range files {
+		if info, ok := parseSubagentTranscript(file); ok {
+			agents = append(agents, info)
+		}
+	}
+	sort.SliceStable(agents, func(i, j int) bool {
+		return agents[i].StartTime.Before(agents[j].StartTime)
+	})
+	return agents
+}
+
+// parseSubagentTranscript summarizes one sub-agent transcript. The ID falls
+// back to the file name when no line carries an agentId.
+func parseSubagentTranscript(path string) (SubagentInfo, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return SubagentInfo{}, false
+	}
+	defer file.Close()
+
+	info := SubagentInfo{
+		ID: strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "agent-"), ".jsonl"),
+	}
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, 0, 1024*1024)
+	scanner.Buffer(buf, 10*1024*1024)
+	for scanner.Scan() {
+		var entry subagentEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if entry.AgentID != "" {
+			info.ID = entry.AgentID
+		}
+		if !entry.Timestamp.IsZero() {
+			if info.StartTime.IsZero() || entry.Timestamp.Before(info.StartTime) {
+				info.StartTime = entry.Timestamp
+			}
+			if entry.Timestamp.After(info.LastActive) {
+				info.LastActive = entry.Timestamp
+			}
+		}
+		switch entry.Type {
+		case "assistant":
+			info.Turns++
+		case "user":
+			if info.Prompt == "" {
+				info.Prompt = subagentPrompt(entry.Message.Content)
+			}
+		}
+	}
+	if info.LastActive.IsZero() {
+		if st, err := file.Stat(); err == nil {
+			info.LastActive = st.ModTime()
+		}
+	}
+	return info, true
+}
+
+// subagentPrompt extracts the first line of a user message, whose content is
+// either a plain string or a list of typed blocks.
+func subagentPrompt(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		var blocks []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		}
+		if json.Unmarshal(raw, &blocks) != nil {
+			return ""
+		}
+		for _, b := range blocks {
+			if b.Type == "text" && b.Text != "" {
+				text = b.Text
+				break
+			}
+		}
+	}
+	text = strings.TrimSpace(text)
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i]
+	}
+	if r := []rune(text); len(r) > subagentPromptMaxLen {
+		text = string(r[:subagentPromptMaxLen-1]) + "…"
+	}
+	return text
+}
@@ -0,0 +1,46 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindClaudeSubagents(t *testing.T) {
+	dir := t.TempDir()
+	jsonlPath := filepath.Join(dir, "abc123.jsonl")
+	require.NoError(t, os.WriteFile(jsonlPath, []byte(`{"type":"assistant","message":{"usage":{"input_tokens":1}}}`), 0o644))
+
+	// No subagents directory yet.
+	assert.Empty(t, FindClaudeSubagents(jsonlPath))
+
+	subDir := filepath.Join(dir, "abc123", "subagents")
+	require.NoError(t, os.MkdirAll(subDir, 0o755))
+	later := `{"type":"user","agentId":"b2","timestamp":"2026-01-01T10:05:00Z","message":{"content":[{"type":"text","text":"Review the diff\nthoroughly"}]}}
+{"type":"assistant","agentId":"b2","timestamp":"2026-01-01T10:06:00Z","message":{}}`
+	earlier := `{"type":"user","agentId":"a1","timestamp":"2026-01-01T10:00:00Z","message":{"content":"Find the config loader"}}
+{"type":"assistant","agentId":"a1","timestamp":"2026-01-01T10:01:00Z","message":{}}
+{"type":"assistant","agentId":"a1","timestamp":"2026-01-01T10:02:00Z","message":{}}`
+	require.NoError(t, os.WriteFile(filepath.Join(subDir, "agent-b2.jsonl"), []byte(later), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(subDir, "agent-a1.jsonl"), []byte(earlier), 0o644))
+
+	agents := FindClaudeSubagents(jsonlPath)
+	require.Len(t, agents, 2)
+	assert.Equal(t, "a1", agents[0].ID)
+	assert.Equal(t, 2, agents[0].Turns)
+	assert.Equal(t, "Find the config loader", agents[0].Prompt)
+	assert.Equal(t, "b2", agents[1].ID)
+	assert.Equal(t, "Review the diff", agents[1].Prompt)
+
+	last := agents[1].LastActive
+	assert.True(t, agents[1].Active(last.Add(time.Minute)))
+	assert.False(t, agents[1].Active(last.Add(time.Hour)))
+
+	analytics, err := ParseSessionJSONL(jsonlPath)
+	require.NoError(t, err)
+	assert.Len(t, analytics.Subagents, 2)
+}
@@ -0,0 +1,130 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Session tags: free-form labels that cut across the single-parent group
+// hierarchy. Tags are stored normalized — lowercase, no leading '#', sorted,
+// deduplicated — so filters and comparisons never have to re-normalize.
+
+const toolDataTagsKey = "tags"
+
+// maxTagLength bounds a single tag so a pasted paragraph can't end up in the
+// list row or filter bar.
+const maxTagLength = 32
+
+// NormalizeTag lowercases a tag and strips surrounding whitespace and a
+// leading '#'. It returns an error when the result is empty, too long, or
+// contains characters outside [a-z0-9._:/-].
+func NormalizeTag(raw string) (string, error) {
+	tag := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(raw), "#"))
+	if tag == "" {
+		return "", fmt.Errorf("empty tag")
+	}
+	if len(tag) > maxTagLength {
+		return "", fmt.Errorf("tag %q is longer than %d characters", tag, maxTagLength)
+	}
+	for _, r := range tag {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':', r == '/':
+		default:
+			return "", fmt.Errorf("invalid tag %q — use letters, digits, and - _ . : /", tag)
+		}
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalizes every tag, drops empties, and returns the sorted,
+// deduplicated set. Nil when no tags remain.
+func NormalizeTags(raw []string) ([]string, error) {
+	var tags []string
+	for _, r := range raw {
+		if strings.TrimSpace(r) == "" {
+			continue
+		}
+		tag, err := NormalizeTag(r)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	slices.Sort(tags)
+	return slices.Compact(tags), nil
+}
+
+// ParseTags splits a comma- or space-separated tag list (the `session set
+// <id> tags` and edit-dialog form) and normalizes it. An empty string clears.
+func ParseTags(value string) ([]string, error) {
+	return NormalizeTags(strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}))
+}
+
+// HasTag reports whether the session carries tag (compared normalized).
+func (i *Instance) HasTag(tag string) bool {
+	want, err := NormalizeTag(tag)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(i.Tags, want)
+}
+
+// HasAllTags reports whether the session carries every tag in tags.
+func (i *Instance) HasAllTags(tags []string) bool {
+	for _, t := range tags {
+		if !i.HasTag(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// CollectTags returns the sorted set of tags used across instances.
+func CollectTags(instances []*Instance) []string {
+	var all []string
+	for _, inst := range instances {
+		if inst != nil {
+			all = append(all, inst.Tags...)
+		}
+	}
+	slices.Sort(all)
+	return slices.Compact(all)
+}
+
+// WriteTagsToToolData merges tags into the tool_data blob. An empty list
+// removes the key.
+func WriteTagsToToolData(td json.RawMessage, tags []string) json.RawMessage {
+	m := map[string]json.RawMessage{}
+	if len(td) > 0 {
+		_ = json.Unmarshal(td, &m)
+	}
+	if len(tags) > 0 {
+		raw, _ := json.Marshal(tags)
+		m[toolDataTagsKey] = raw
+	} else {
+		delete(m, toolDataTagsKey)
+	}
+	out, _ := json.Marshal(m)
+	return out
+}
+
+// ReadTagsFromToolData extracts tags from the blob. Returns nil for
+// missing/malformed/legacy rows.
+func ReadTagsFromToolData(td json.RawMessage) []string {
+	if len(td) == 0 {
+		return nil
+	}
+	var blob struct {
+		Tags []string `json:"tags"`
+	}
+	_ = json.Unmarshal(td, &blob)
+	return blob.Tags
+}
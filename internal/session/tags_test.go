@@ -0,0 +1,92 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTags_SortsDedupesAndLowercases(t *testing.T) {
+	tags, err := NormalizeTags([]string{" Urgent", "#client-x", "urgent", "", "ops/oncall"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"client-x", "ops/oncall", "urgent"}, tags)
+
+	tags, err = NormalizeTags(nil)
+	require.NoError(t, err)
+	assert.Nil(t, tags)
+}
+
+func TestNormalizeTag_RejectsInvalid(t *testing.T) {
+	for _, raw := range []string{"#", "has space", "semi;colon", "emoji🙂", "averyveryveryveryverylongtagnamethatgoesonandon"} {
+		_, err := NormalizeTag(raw)
+		assert.Error(t, err, "tag %q", raw)
+	}
+}
+
+func TestParseTags_CommaAndSpaceSeparated(t *testing.T) {
+	tags, err := ParseTags("urgent, client-x  ops")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"client-x", "ops", "urgent"}, tags)
+
+	tags, err = ParseTags("")
+	require.NoError(t, err)
+	assert.Nil(t, tags, "an empty value clears the tags")
+}
+
+func TestSetField_Tags(t *testing.T) {
+	inst := &Instance{Tags: []string{"old"}}
+
+	old, _, err := SetField(inst, FieldTags, "Urgent,client-x", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "old", old)
+	assert.Equal(t, []string{"client-x", "urgent"}, inst.Tags)
+	assert.True(t, inst.HasTag("#URGENT"))
+	assert.True(t, inst.HasAllTags([]string{"urgent", "client-x"}))
+	assert.False(t, inst.HasAllTags([]string{"urgent", "other"}))
+
+	_, _, err = SetField(inst, FieldTags, "bad tag!", nil)
+	var mutErr *MutationError
+	require.ErrorAs(t, err, &mutErr)
+	assert.Equal(t, []string{"client-x", "urgent"}, inst.Tags, "a rejected value leaves tags untouched")
+}
+
+func TestCollectTags(t *testing.T) {
+	got := CollectTags([]*Instance{
+		{Tags: []string{"b", "a"}},
+		nil,
+		{Tags: []string{"a", "c"}},
+		{},
+	})
+	assert.Equal(t, []string{"a", "b", "c"}, got)
+}
+
+// Tags round-trip through state.db, and clearing them must stick: the key is
+// declared in the typed tool_data schema, so MergeToolDataExtras does not
+// carry the old value forward.
+func TestStorage_TagsRoundTripAndClear(t *testing.T) {
+	s := newTestStorage(t)
+	inst := &Instance{
+		ID:          "tagged-1",
+		Title:       "tagged",
+		ProjectPath: "/tmp/tagged",
+		GroupPath:   "work",
+		Tool:        "shell",
+		Status:      StatusIdle,
+		CreatedAt:   time.Now(),
+		Tags:        []string{"client-x", "urgent"},
+	}
+	require.NoError(t, s.SaveWithGroups([]*Instance{inst}, nil))
+
+	loaded, _, err := s.LoadLite()
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+	assert.Equal(t, []string{"client-x", "urgent"}, loaded[0].Tags)
+
+	inst.Tags = nil
+	require.NoError(t, s.SaveWithGroups([]*Instance{inst}, nil))
+	loaded, _, err = s.LoadLite()
+	require.NoError(t, err)
+	assert.Empty(t, loaded[0].Tags)
+}
@@ -0,0 +1,146 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	dark "github.com/thiagokokada/dark-mode-go"
+)
+
+// builtinThemeBases maps each built-in theme to its background brightness
+// ("dark" or "light"). The palettes themselves live in internal/ui/styles.go;
+// brightness is kept here because COLORFGBG and the tmux pane styles only need
+// to know which side of the light/dark divide a theme sits on.
+// internal/tmux/tmux.go::lightThemes mirrors the light entries (import cycle).
+var builtinThemeBases = map[string]string{
+	"dark":            "dark",
+	"light":           "light",
+	"solarized-dark":  "dark",
+	"solarized-light": "light",
+	"high-contrast":   "dark",
+}
+
+// ThemePalette is a user-defined color scheme from a [themes.<name>] table.
+// Colors are "#rrggbb" hex or ANSI 256 indices ("33"); any color left empty
+// (or not parseable) falls back to the Base theme's color.
+//
+//	[themes.paper]
+//	base = "light"
+//	bg = "#fdf6e3"
+//	accent = "#268bd2"
+type ThemePalette struct {
+	// Base is the built-in theme this palette starts from and whose
+	// background brightness it shares. Defaults to "dark".
+	Base string `toml:"base,omitempty"`
+
+	Bg      string `toml:"bg,omitempty"`
+	Surface string `toml:"surface,omitempty"`
+	Border  string `toml:"border,omitempty"`
+	Text    string `toml:"text,omitempty"`
+	TextDim string `toml:"text_dim,omitempty"`
+	Accent  string `toml:"accent,omitempty"`
+	Purple  string `toml:"purple,omitempty"`
+	Cyan    string `toml:"cyan,omitempty"`
+	Green   string `toml:"green,omitempty"`
+	Yellow  string `toml:"yellow,omitempty"`
+	Orange  string `toml:"orange,omitempty"`
+	Red     string `toml:"red,omitempty"`
+	Comment string `toml:"comment,omitempty"`
+}
+
+// GetBase returns the built-in theme the palette extends, defaulting to
+// "dark" when unset or unknown.
+func (p ThemePalette) GetBase() string {
+	if _, ok := builtinThemeBases[p.Base]; ok {
+		return p.Base
+	}
+	return "dark"
+}
+
+// BuiltinThemeNames returns the built-in theme names, sorted.
+func BuiltinThemeNames() []string {
+	names := make([]string, 0, len(builtinThemeBases))
+	for name := range builtinThemeBases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsBuiltinTheme reports whether name is a built-in theme.
+func IsBuiltinTheme(name string) bool {
+	_, ok := builtinThemeBases[name]
+	return ok
+}
+
+// GetCustomTheme returns the user-defined palette called name. Built-in names
+// are never shadowed by a [themes.<name>] table.
+func GetCustomTheme(name string) (ThemePalette, bool) {
+	if IsBuiltinTheme(name) {
+		return ThemePalette{}, false
+	}
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return ThemePalette{}, false
+	}
+	p, ok := config.Themes[name]
+	return p, ok
+}
+
+// CustomThemeNames returns the names of the user-defined palettes in
+// config, sorted, skipping any that collide with a built-in.
+func CustomThemeNames(config *UserConfig) []string {
+	if config == nil {
+		return nil
+	}
+	var names []string
+	for name := range config.Themes {
+		if !IsBuiltinTheme(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ThemeBrightness returns "light" or "dark" for a concrete theme name
+// (built-in or user-defined). Unknown names are dark.
+func ThemeBrightness(name string) string {
+	if base, ok := builtinThemeBases[name]; ok {
+		return base
+	}
+	if p, ok := GetCustomTheme(name); ok {
+		return builtinThemeBases[p.GetBase()]
+	}
+	return "dark"
+}
+
+// detectSystemTheme returns "dark" or "light" for the terminal/OS appearance.
+func detectSystemTheme() string {
+	// Check the terminal's own declaration before asking the OS.
+	// COLORFGBG is set by iTerm2 and other terminals; format is "fg;bg"
+	// where bg < 8 means a dark background. This catches the common case
+	// where macOS is in light mode but the terminal profile is dark.
+	if colorfgbg := os.Getenv("COLORFGBG"); colorfgbg != "" {
+		if idx := strings.LastIndex(colorfgbg, ";"); idx >= 0 {
+			var bg int
+			if _, err := fmt.Sscanf(colorfgbg[idx+1:], "%d", &bg); err == nil {
+				if bg < 8 {
+					return "dark"
+				}
+				return "light"
+			}
+		}
+	}
+
+	isDark, err := dark.IsDarkMode()
+	if err != nil {
+		return "dark"
+	}
+	if isDark {
+		return "dark"
+	}
+	return "light"
+}
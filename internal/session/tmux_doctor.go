@@ -0,0 +1,197 @@
+package session
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// The tmux doctor reconciles instance records with the agentdeck_* sessions
+// actually running on the default tmux socket. Records and sessions drift
+// apart when a restart races another process (two panes for one instance),
+// when a record is rewritten with a stale tmux name, or when a record is
+// deleted while its pane survives. Live sessions are matched to records by
+// the AGENTDECK_INSTANCE_ID in their environment, not by name, so a session
+// whose name no longer matches its record can still be re-linked.
+
+// TmuxIssueKind classifies a drift between instance records and tmux.
+type TmuxIssueKind string
+
+const (
+	// TmuxIssueMissing is an instance that should have a tmux session but
+	// whose recorded session is gone.
+	TmuxIssueMissing TmuxIssueKind = "missing"
+	// TmuxIssueDuplicate is an extra tmux session carrying the ID of an
+	// instance whose own session is alive.
+	TmuxIssueDuplicate TmuxIssueKind = "duplicate"
+	// TmuxIssueOrphan is an agentdeck_* session no instance in this profile
+	// claims.
+	TmuxIssueOrphan TmuxIssueKind = "orphan"
+)
+
+// TmuxIssue is one drift found by DiagnoseTmuxSessions.
+type TmuxIssue struct {
+	Kind TmuxIssueKind
+	// Instance is the affected record; nil for orphans.
+	Instance *Instance
+	// TmuxName is the recorded session (missing), the extra session
+	// (duplicate), or the untracked session (orphan).
+	TmuxName string
+	// RelinkTo is, for a missing session, a live session carrying the
+	// instance's ID that the record can be re-pointed at. "" when none.
+	RelinkTo string
+	// WorkDir is the orphan's pane directory, used when importing it.
+	WorkDir string
+}
+
+// Repairable reports whether RepairTmuxIssue can fix the issue. A missing
+// session with nothing to re-link to needs a restart instead.
+func (t TmuxIssue) Repairable() bool {
+	return t.Kind != TmuxIssueMissing || t.RelinkTo != ""
+}
+
+// Describe renders the issue and its repair for CLI/TUI output.
+func (t TmuxIssue) Describe() string {
+	switch t.Kind {
+	case TmuxIssueMissing:
+		if t.RelinkTo != "" {
+			return fmt.Sprintf("%q: tmux session %s is gone, but %s belongs to it (re-link)", t.Instance.Title, t.TmuxName, t.RelinkTo)
+		}
+		return fmt.Sprintf("%q: tmux session %s is gone (restart the session to recreate it)", t.Instance.Title, t.TmuxName)
+	case TmuxIssueDuplicate:
+		return fmt.Sprintf("%q: duplicate tmux session %s alongside %s (kill the duplicate)", t.Instance.Title, t.TmuxName, t.Instance.tmuxSession.Name)
+	default:
+		return fmt.Sprintf("tmux session %s has no session record (import it)", t.TmuxName)
+	}
+}
+
+// DiagnoseTmuxSessions compares instances (one profile's records) with live,
+// the agentdeck_* sessions on the default socket. profile is the profile the
+// instances belong to; live sessions stamped with another AGENTDECK_PROFILE
+// are left alone. Instances on a non-default socket are not visible in live
+// and are skipped.
+func DiagnoseTmuxSessions(instances []*Instance, live []tmux.AgentDeckSessionInfo, profile string) []TmuxIssue {
+	byName := make(map[string]tmux.AgentDeckSessionInfo, len(live))
+	byInstance := make(map[string][]string)
+	for _, s := range live {
+		byName[s.Name] = s
+		if s.InstanceID != "" {
+			byInstance[s.InstanceID] = append(byInstance[s.InstanceID], s.Name)
+		}
+	}
+	for _, names := range byInstance {
+		sort.Strings(names)
+	}
+
+	var issues []TmuxIssue
+	claimedNames := make(map[string]bool)
+	claimedIDs := make(map[string]bool)
+	for _, inst := range instances {
+		claimedIDs[inst.ID] = true
+		if inst.tmuxSession == nil {
+			continue
+		}
+		if inst.TmuxSocketName != "" && inst.TmuxSocketName != tmux.DefaultSocketName() {
+			continue
+		}
+		own := inst.tmuxSession.Name
+		claimedNames[own] = true
+		carriers := byInstance[inst.ID]
+
+		if _, alive := byName[own]; alive {
+			for _, name := range carriers {
+				if name != own {
+					issues = append(issues, TmuxIssue{Kind: TmuxIssueDuplicate, Instance: inst, TmuxName: name})
+				}
+			}
+			continue
+		}
+
+		if !expectsTmuxSession(inst) && len(carriers) == 0 {
+			continue
+		}
+		issue := TmuxIssue{Kind: TmuxIssueMissing, Instance: inst, TmuxName: own}
+		if len(carriers) > 0 {
+			issue.RelinkTo = carriers[0]
+			claimedNames[carriers[0]] = true
+			for _, name := range carriers[1:] {
+				issues = append(issues, TmuxIssue{Kind: TmuxIssueDuplicate, Instance: inst, TmuxName: name})
+			}
+		}
+		issues = append(issues, issue)
+	}
+
+	for _, s := range live {
+		if claimedNames[s.Name] || claimedIDs[s.InstanceID] {
+			continue
+		}
+		if s.Profile != "" && profile != "" && s.Profile != profile {
+			continue
+		}
+		issues = append(issues, TmuxIssue{Kind: TmuxIssueOrphan, TmuxName: s.Name, WorkDir: s.WorkDir})
+	}
+	return issues
+}
+
+// expectsTmuxSession reports whether a record claims a live pane. Idle is
+// excluded: a session that was added but never started is idle with no tmux
+// session, and nothing on disk tells it apart from a finished one.
+func expectsTmuxSession(inst *Instance) bool {
+	if inst.IsArchived() {
+		return false
+	}
+	switch inst.Status {
+	case StatusRunning, StatusWaiting, StatusError:
+		return true
+	}
+	return false
+}
+
+// RepairTmuxIssue applies the repair for issue:
+//   - missing: re-points the instance at RelinkTo (the caller saves it);
+//   - duplicate: kills the extra tmux session;
+//   - orphan: returns a new instance adopting the session, in the
+//     "recovered" group, for the caller to add and save.
+func RepairTmuxIssue(issue TmuxIssue) (*Instance, error) {
+	switch issue.Kind {
+	case TmuxIssueMissing:
+		if issue.RelinkTo == "" {
+			return nil, fmt.Errorf("nothing to re-link %q to; restart it instead", issue.Instance.Title)
+		}
+		issue.Instance.relinkTmuxSession(issue.RelinkTo)
+		return nil, nil
+	case TmuxIssueDuplicate:
+		dup := &tmux.Session{Name: issue.TmuxName, SocketName: tmux.DefaultSocketName()}
+		return nil, dup.KillAndWait()
+	case TmuxIssueOrphan:
+		sess := &tmux.Session{
+			Name:        issue.TmuxName,
+			DisplayName: issue.TmuxName,
+			WorkDir:     issue.WorkDir,
+			SocketName:  tmux.DefaultSocketName(),
+		}
+		inst := newDiscoveredInstance(sess)
+		sess.InstanceID = inst.ID
+		// Stamp the adopted pane so later diagnoses match it by identity.
+		_ = sess.SetEnvironment("AGENTDECK_INSTANCE_ID", inst.ID)
+		return inst, nil
+	}
+	return nil, fmt.Errorf("unknown tmux issue kind %q", issue.Kind)
+}
+
+// relinkTmuxSession points the instance at the live tmux session name,
+// configured the same way Storage does when loading a record.
+func (i *Instance) relinkTmuxSession(name string) {
+	sess := tmux.ReconnectSessionLazy(name, i.Title, i.ProjectPath, i.Command, statusToString(i.Status))
+	sess.SocketName = i.TmuxSocketName
+	sess.InstanceID = i.ID
+	sess.SetInjectStatusLine(GetTmuxSettings().GetInjectStatusLine())
+	sess.SetMouse(GetTmuxSettings().GetMouse())
+	sess.SetClearOnRestart(GetTmuxSettings().ClearOnRestart)
+	sess.SetTerminalChromeEnabled(GetTerminalSettings().GetITermBadge())
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.tmuxSession = sess
+}
@@ -0,0 +1,59 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+func doctorInstance(id, tmuxName string, status Status) *Instance {
+	return &Instance{
+		ID:          id,
+		Title:       id,
+		Status:      status,
+		tmuxSession: &tmux.Session{Name: tmuxName},
+	}
+}
+
+func TestDiagnoseTmuxSessions(t *testing.T) {
+	healthy := doctorInstance("healthy", "agentdeck_healthy_1", StatusRunning)
+	relink := doctorInstance("relink", "agentdeck_relink_old", StatusWaiting)
+	gone := doctorInstance("gone", "agentdeck_gone_1", StatusError)
+	idle := doctorInstance("idle", "agentdeck_idle_1", StatusIdle)
+
+	live := []tmux.AgentDeckSessionInfo{
+		{Name: "agentdeck_healthy_1", InstanceID: "healthy"},
+		{Name: "agentdeck_healthy_2", InstanceID: "healthy"},
+		{Name: "agentdeck_relink_new", InstanceID: "relink"},
+		{Name: "agentdeck_stray_1", WorkDir: "/tmp/stray"},
+		{Name: "agentdeck_other_1", InstanceID: "x", Profile: "work"},
+	}
+
+	issues := DiagnoseTmuxSessions([]*Instance{healthy, relink, gone, idle}, live, "default")
+
+	want := []struct {
+		kind     TmuxIssueKind
+		tmux     string
+		relinkTo string
+	}{
+		{TmuxIssueDuplicate, "agentdeck_healthy_2", ""},
+		{TmuxIssueMissing, "agentdeck_relink_old", "agentdeck_relink_new"},
+		{TmuxIssueMissing, "agentdeck_gone_1", ""},
+		{TmuxIssueOrphan, "agentdeck_stray_1", ""},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %d issues %+v, want %d", len(issues), issues, len(want))
+	}
+	for n, w := range want {
+		got := issues[n]
+		if got.Kind != w.kind || got.TmuxName != w.tmux || got.RelinkTo != w.relinkTo {
+			t.Errorf("issue %d = %s %s → %q, want %s %s → %q", n, got.Kind, got.TmuxName, got.RelinkTo, w.kind, w.tmux, w.relinkTo)
+		}
+	}
+	if issues[2].Repairable() {
+		t.Error("missing session with nothing to re-link to must not be repairable")
+	}
+	if issues[3].WorkDir != "/tmp/stray" {
+		t.Errorf("orphan WorkDir = %q, want /tmp/stray", issues[3].WorkDir)
+	}
+}
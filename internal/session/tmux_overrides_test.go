@@ -0,0 +1,82 @@
+package session
+
+import (
+	"testing"
+)
+
+// TestResolveSessionTmuxOverrides_Layering locks the precedence chain for
+// per-group / per-session tmux settings: [tmux] < group ancestors (root-first)
+// < [tmux.sessions."<title>"] < [tmux.sessions."<id>"].
+func TestResolveSessionTmuxOverrides_Layering(t *testing.T) {
+	withIsolatedHomeAndConfig(t, `
+[tmux]
+options = { "history-limit" = "5000", "status" = "on" }
+hooks = { "pane-died" = "display global" }
+
+[groups."work".tmux]
+options = { "history-limit" = "20000" }
+
+[groups."work/heavy".tmux]
+options = { "history-limit" = "100000" }
+hooks = { "client-attached" = "display heavy" }
+
+[tmux.sessions."cheap".options]
+status = "off"
+
+[tmux.sessions."id-123".options]
+history-limit = "250000"
+`)
+
+	cfg, err := LoadUserConfig()
+	if err != nil {
+		t.Fatalf("LoadUserConfig: %v", err)
+	}
+
+	opts, hooks := cfg.ResolveSessionTmuxOverrides("other-id", "plain", "work/heavy")
+	if opts["history-limit"] != "100000" || opts["status"] != "on" {
+		t.Fatalf("nested group must win over parent and global: %v", opts)
+	}
+	if hooks["pane-died"] != "display global" || hooks["client-attached"] != "display heavy" {
+		t.Fatalf("hooks must merge per key across layers: %v", hooks)
+	}
+
+	opts, _ = cfg.ResolveSessionTmuxOverrides("other-id", "cheap", "work")
+	if opts["status"] != "off" || opts["history-limit"] != "20000" {
+		t.Fatalf("title override must layer on the group: %v", opts)
+	}
+
+	opts, _ = cfg.ResolveSessionTmuxOverrides("id-123", "cheap", "work")
+	if opts["history-limit"] != "250000" || opts["status"] != "off" {
+		t.Fatalf("id override must win over title and group: %v", opts)
+	}
+
+	// Returned maps must be copies: mutating them must not leak into config.
+	opts["history-limit"] = "1"
+	if cfg.Tmux.Sessions["id-123"].Options["history-limit"] != "250000" {
+		t.Fatal("ResolveSessionTmuxOverrides returned an aliased config map")
+	}
+}
+
+func TestBuildTmuxOptionOverrides_UsesGroupAndSessionLayers(t *testing.T) {
+	withIsolatedHomeAndConfig(t, `
+[groups."builds".tmux]
+options = { "history-limit" = "90000" }
+hooks = { "pane-died" = "display died" }
+`)
+
+	inst := &Instance{ID: "abc", Title: "ci", GroupPath: "builds"}
+	if got := inst.buildTmuxOptionOverrides()["history-limit"]; got != "90000" {
+		t.Fatalf("history-limit = %q, want group value 90000", got)
+	}
+	if got := inst.buildTmuxHooks()["pane-died"]; got != "display died" {
+		t.Fatalf("pane-died hook = %q, want group hook", got)
+	}
+
+	other := &Instance{ID: "def", Title: "docs", GroupPath: "docs"}
+	if got := other.buildTmuxOptionOverrides(); got["history-limit"] != "" {
+		t.Fatalf("session outside the group must not inherit its options: %v", got)
+	}
+	if got := other.buildTmuxHooks(); got != nil {
+		t.Fatalf("session outside the group must get no hooks: %v", got)
+	}
+}
@@ -0,0 +1,222 @@
+package session
+
+import (
+	"strings"
+	"sync"
+)
+
+// ToolAdapter describes the per-tool behavior the TUI and status loop key
+// off: how to tell the agent's UI has drawn, whether it reports status via
+// lifecycle hooks, where its conversation ID lives, how to resume it and
+// how to read its analytics. Callers look an
+// adapter up with ToolAdapterFor instead of comparing inst.Tool against tool
+// names, so a new agent CLI only needs an adapter registered here (or a
+// [tools.*] entry in config.toml) rather than edits across the UI.
+type ToolAdapter interface {
+	// Name is the tool name the adapter is registered under.
+	Name() string
+	// ReadyIndicators are pane substrings that mean the agent's UI is up.
+	// Empty means "any substantial output" (see PaneShowsReady).
+	ReadyIndicators() []string
+	// EmitsHookStatus reports whether the tool writes hook status files
+	// that UpdateStatus should prefer over pane scraping.
+	EmitsHookStatus() bool
+	// SlowStart reports whether launches routinely take several seconds
+	// (MCP loading), which keeps the launch animation up longer.
+	SlowStart() bool
+	// HasAnalytics reports whether the preview can show session analytics.
+	HasAnalytics() bool
+	// SessionID returns the tool conversation ID bound to inst, or "".
+	SessionID(inst *Instance) string
+	// ResumeCommand returns the command that restarts inst in its bound
+	// conversation, or "" when none is bound (or the tool's start command
+	// resumes on its own).
+	ResumeCommand(inst *Instance) string
+	// ParseAnalytics reads inst's usage for the analytics panel. Gemini
+	// reports its own summary type; other tools fill SessionAnalytics.
+	// Both nil means there is nothing to show yet.
+	ParseAnalytics(inst *Instance) (*SessionAnalytics, *GeminiSessionAnalytics, error)
+}
+
+// analyticsParser is ToolAdapter.ParseAnalytics for one tool.
+type analyticsParser func(*Instance) (*SessionAnalytics, *GeminiSessionAnalytics, error)
+
+// toolAdapter is the table-driven ToolAdapter used for built-in tools.
+type toolAdapter struct {
+	name      string
+	ready     []string
+	hooks     bool
+	slowStart bool
+	analytics analyticsParser
+	sessionID func(*Instance) string
+	resume    func(*Instance) string
+}
+
+func (a *toolAdapter) Name() string              { return a.name }
+func (a *toolAdapter) ReadyIndicators() []string { return a.ready }
+func (a *toolAdapter) EmitsHookStatus() bool     { return a.hooks }
+func (a *toolAdapter) SlowStart() bool           { return a.slowStart }
+func (a *toolAdapter) HasAnalytics() bool        { return a.analytics != nil }
+
+func (a *toolAdapter) SessionID(inst *Instance) string {
+	if a.sessionID == nil || inst == nil {
+		return ""
+	}
+	return a.sessionID(inst)
+}
+
+func (a *toolAdapter) ResumeCommand(inst *Instance) string {
+	if a.resume == nil || inst == nil || a.SessionID(inst) == "" {
+		return ""
+	}
+	return a.resume(inst)
+}
+
+func (a *toolAdapter) ParseAnalytics(inst *Instance) (*SessionAnalytics, *GeminiSessionAnalytics, error) {
+	if a.analytics == nil || inst == nil {
+		return nil, nil, nil
+	}
+	return a.analytics(inst)
+}
+
+// Analytics parsers for the built-in tools.
+var (
+	claudeAnalytics analyticsParser = func(i *Instance) (*SessionAnalytics, *GeminiSessionAnalytics, error) {
+		path := i.GetJSONLPath()
+		if path == "" {
+			return nil, nil, nil
+		}
+		a, err := ParseSessionJSONL(path)
+		return a, nil, err
+	}
+	// Gemini analytics are kept up to date by UpdateGeminiSession during
+	// UpdateStatus; this only returns the current snapshot.
+	geminiAnalytics analyticsParser = func(i *Instance) (*SessionAnalytics, *GeminiSessionAnalytics, error) {
+		return nil, i.GeminiAnalytics, nil
+	}
+	openCodeAnalytics analyticsParser = func(i *Instance) (*SessionAnalytics, *GeminiSessionAnalytics, error) {
+		if i.OpenCodeSessionID == "" {
+			return nil, nil, nil
+		}
+		a, err := ParseOpenCodeSession(GetOpenCodeStorageDir(), i.OpenCodeSessionID)
+		return a, nil, err
+	}
+	// The aider history file is shared by every run in the repo; only chats
+	// started since this session was created count.
+	aiderAnalytics analyticsParser = func(i *Instance) (*SessionAnalytics, *GeminiSessionAnalytics, error) {
+		a, err := ParseAiderHistory(i.AiderHistoryPath(), i.CreatedAt)
+		return a, nil, err
+	}
+)
+
+// claudeReadyIndicators are drawn by Claude Code once its UI is interactive.
+var claudeReadyIndicators = []string{
+	"ctrl+c to interrupt",
+	"No, and tell Claude what to do differently",
+	"\n> ",
+	"> \n",
+	"esc to interrupt",
+	"⠋", "⠙",
+	"Thinking",
+	"╭─", // Claude UI border
+}
+
+// aiderReadyIndicators are aider's input prompts, one per chat mode, plus
+// its startup banner.
+var aiderReadyIndicators = []string{
+	"\n> ",
+	"ask> ",
+	"architect> ",
+	"code> ",
+	"multi> ",
+	"Use /help <question> for help",
+}
+
+var (
+	toolAdaptersMu sync.RWMutex
+	toolAdapters   = map[string]ToolAdapter{}
+)
+
+func init() {
+	for _, a := range []*toolAdapter{
+		{
+			name: "claude", ready: claudeReadyIndicators, hooks: true, slowStart: true, analytics: claudeAnalytics,
+			sessionID: func(i *Instance) string { return i.ClaudeSessionID },
+			resume:    (*Instance).buildClaudeResumeCommand,
+		},
+		{
+			name: "gemini", ready: append(append([]string{}, claudeReadyIndicators...), "▸", "gemini>"),
+			hooks: true, slowStart: true, analytics: geminiAnalytics,
+			sessionID: func(i *Instance) string { return i.GeminiSessionID },
+			resume:    func(i *Instance) string { return i.buildGeminiCommand("gemini") },
+		},
+		{
+			name: "codex", hooks: true,
+			sessionID: func(i *Instance) string { return i.CodexSessionID },
+			resume:    func(i *Instance) string { return i.buildCodexCommand(i.Command) },
+		},
+		{name: "hermes", hooks: true},
+		{name: "cursor", hooks: true},
+		{
+			name: "opencode", analytics: openCodeAnalytics,
+			sessionID: func(i *Instance) string { return i.OpenCodeSessionID },
+			resume:    func(i *Instance) string { return i.buildOpenCodeCommand("opencode") },
+		},
+		{name: "copilot", sessionID: func(i *Instance) string { return i.CopilotSessionID }},
+		{name: "aider", ready: aiderReadyIndicators, analytics: aiderAnalytics},
+		{name: "shell"},
+	} {
+		RegisterToolAdapter(a)
+	}
+}
+
+// RegisterToolAdapter makes a available under a.Name(), replacing any
+// adapter already registered for that name.
+func RegisterToolAdapter(a ToolAdapter) {
+	toolAdaptersMu.Lock()
+	defer toolAdaptersMu.Unlock()
+	toolAdapters[a.Name()] = a
+}
+
+// ToolAdapterFor returns the adapter for tool. A [tools.*] entry that is
+// compatible_with a built-in tool gets that tool's adapter; any other custom
+// tool gets one derived from its prompt_patterns and session_id_env.
+// Unknown tools get the shell adapter. Never returns nil.
+func ToolAdapterFor(tool string) ToolAdapter {
+	toolAdaptersMu.RLock()
+	a, ok := toolAdapters[tool]
+	toolAdaptersMu.RUnlock()
+	if ok {
+		return a
+	}
+	switch {
+	case IsClaudeCompatible(tool):
+		return ToolAdapterFor("claude")
+	case IsCodexCompatible(tool):
+		return ToolAdapterFor("codex")
+	}
+	if def := GetToolDef(tool); def != nil {
+		return &toolAdapter{
+			name:      tool,
+			ready:     def.PromptPatterns,
+			sessionID: (*Instance).GetGenericSessionID,
+		}
+	}
+	return ToolAdapterFor("shell")
+}
+
+// PaneShowsReady reports whether plain (ANSI-stripped pane content) shows
+// the tool's UI is up: any ready indicator, or — for tools without
+// indicators — more than a trivial amount of output.
+func PaneShowsReady(a ToolAdapter, plain string) bool {
+	indicators := a.ReadyIndicators()
+	if len(indicators) == 0 {
+		return len(strings.TrimSpace(plain)) > 50
+	}
+	for _, s := range indicators {
+		if strings.Contains(plain, s) {
+			return true
+		}
+	}
+	return false
+}
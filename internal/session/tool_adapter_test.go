@@ -0,0 +1,118 @@
+package session
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestToolAdapterFor_BuiltinTools(t *testing.T) {
+	tests := []struct {
+		tool      string
+		hooks     bool
+		slowStart bool
+		analytics bool
+	}{
+		{"claude", true, true, true},
+		{"gemini", true, true, true},
+		{"codex", true, false, false},
+		{"hermes", true, false, false},
+		{"cursor", true, false, false},
+		{"opencode", false, false, true},
+		{"shell", false, false, false},
+	}
+	for _, tt := range tests {
+		a := ToolAdapterFor(tt.tool)
+		if a.Name() != tt.tool {
+			t.Errorf("ToolAdapterFor(%q).Name() = %q", tt.tool, a.Name())
+		}
+		if a.EmitsHookStatus() != tt.hooks || a.SlowStart() != tt.slowStart || a.HasAnalytics() != tt.analytics {
+			t.Errorf("%s: hooks=%v slowStart=%v analytics=%v, want %v/%v/%v", tt.tool,
+				a.EmitsHookStatus(), a.SlowStart(), a.HasAnalytics(), tt.hooks, tt.slowStart, tt.analytics)
+		}
+	}
+}
+
+func TestToolAdapterFor_SessionID(t *testing.T) {
+	inst := &Instance{ClaudeSessionID: "c1", GeminiSessionID: "g1", CodexSessionID: "x1"}
+	for tool, want := range map[string]string{"claude": "c1", "gemini": "g1", "codex": "x1", "shell": ""} {
+		if got := ToolAdapterFor(tool).SessionID(inst); got != want {
+			t.Errorf("%s SessionID = %q, want %q", tool, got, want)
+		}
+	}
+}
+
+func TestToolAdapterFor_CustomTools(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	ClearUserConfigCache()
+	t.Cleanup(ClearUserConfigCache)
+
+	cfg := &UserConfig{
+		Tools: map[string]ToolDef{
+			"my-claude": {Command: "claude-wrapper", CompatibleWith: "claude"},
+			"goose":     {Command: "goose", PromptPatterns: []string{"( O)>"}},
+		},
+	}
+	if err := SaveUserConfig(cfg); err != nil {
+		t.Fatalf("SaveUserConfig: %v", err)
+	}
+	ClearUserConfigCache()
+
+	if a := ToolAdapterFor("my-claude"); a.Name() != "claude" {
+		t.Errorf("compatible_with=claude adapter = %q, want claude", a.Name())
+	}
+	goose := ToolAdapterFor("goose")
+	if goose.Name() != "goose" || goose.EmitsHookStatus() {
+		t.Errorf("goose adapter = %q hooks=%v, want generic adapter without hooks", goose.Name(), goose.EmitsHookStatus())
+	}
+	if !PaneShowsReady(goose, "starting session\n( O)> ") {
+		t.Error("goose prompt pattern should mark the pane ready")
+	}
+	if ToolAdapterFor("not-configured").Name() != "shell" {
+		t.Error("unknown tool should fall back to the shell adapter")
+	}
+}
+
+func TestPaneShowsReady(t *testing.T) {
+	claude := ToolAdapterFor("claude")
+	if PaneShowsReady(claude, "Loading MCP servers...") {
+		t.Error("claude should not be ready before its UI draws")
+	}
+	if !PaneShowsReady(claude, "╭──────╮\n│ > │") {
+		t.Error("claude UI border should mark the pane ready")
+	}
+	if !PaneShowsReady(ToolAdapterFor("gemini"), "gemini> ") {
+		t.Error("gemini prompt should mark the pane ready")
+	}
+	shell := ToolAdapterFor("shell")
+	if PaneShowsReady(shell, "$ ") {
+		t.Error("shell with trivial output should not be ready")
+	}
+	if !PaneShowsReady(shell, "total 48\ndrwxr-xr-x  12 user staff  384 Oct 15 10:00 project-directory\n") {
+		t.Error("shell with substantial output should be ready")
+	}
+}
+
+func TestToolAdapter_ResumeAndAnalytics(t *testing.T) {
+	for _, tool := range []string{"claude", "gemini", "codex", "opencode", "shell", "aider"} {
+		if cmd := ToolAdapterFor(tool).ResumeCommand(&Instance{Tool: tool}); cmd != "" {
+			t.Errorf("%s without a bound conversation resumes with %q, want none", tool, cmd)
+		}
+	}
+	if cmd := ToolAdapterFor("opencode").ResumeCommand(&Instance{Tool: "opencode", OpenCodeSessionID: "ses_1"}); !strings.Contains(cmd, "ses_1") {
+		t.Errorf("opencode resume command = %q, want it to name the session", cmd)
+	}
+
+	g := &GeminiSessionAnalytics{TotalTurns: 3}
+	if a, ga, err := ToolAdapterFor("gemini").ParseAnalytics(&Instance{GeminiAnalytics: g}); a != nil || ga != g || err != nil {
+		t.Errorf("gemini ParseAnalytics = %v, %v, %v; want the current snapshot", a, ga, err)
+	}
+	if a, ga, err := ToolAdapterFor("opencode").ParseAnalytics(&Instance{}); a != nil || ga != nil || err != nil {
+		t.Errorf("opencode without a session ParseAnalytics = %v, %v, %v; want nothing", a, ga, err)
+	}
+	if a, ga, err := ToolAdapterFor("shell").ParseAnalytics(&Instance{}); a != nil || ga != nil || err != nil {
+		t.Errorf("shell ParseAnalytics = %v, %v, %v; want nothing", a, ga, err)
+	}
+}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"encoding/json"
+	"fmt"
 )
 
 // ToolOptions is the interface for tool-specific launch options
@@ -24,6 +25,9 @@ type ClaudeOptions struct {
 	// "opus", and "haiku" let Claude Code resolve the latest version; full
 	// model IDs pin a specific version.
 	Model string `json:"model,omitempty"`
+	// Effort sets the extended-thinking budget: "low", "medium", or "high"
+	// (see ClaudeEffortLevels). Empty leaves Claude's own default.
+	Effort string `json:"effort,omitempty"`
 	// SkipPermissions adds --dangerously-skip-permissions flag
 	SkipPermissions bool `json:"skip_permissions,omitempty"`
 	// AllowSkipPermissions adds --allow-dangerously-skip-permissions flag
@@ -33,6 +37,10 @@ type ClaudeOptions struct {
 	// Uses a classifier model to auto-approve safe operations while blocking risky ones.
 	// Only used when SkipPermissions is false (SkipPermissions takes precedence).
 	AutoMode bool `json:"auto_mode,omitempty"`
+	// PlanMode adds --permission-mode plan flag: Claude researches and
+	// proposes a plan without editing files. SkipPermissions takes precedence;
+	// PlanMode takes precedence over AutoMode.
+	PlanMode bool `json:"plan_mode,omitempty"`
 	// UseChrome adds --chrome flag
 	UseChrome bool `json:"use_chrome,omitempty"`
 	// UseTeammateMode adds --teammate-mode tmux flag
@@ -43,6 +51,11 @@ type ClaudeOptions struct {
 	WorktreePath     string `json:"-"`
 	WorktreeRepoRoot string `json:"-"`
 	WorktreeBranch   string `json:"-"`
+
+	// ResumeAt forks from an earlier point instead of the conversation tip:
+	// the UUID of the last assistant record to keep (see ClaudeForkPoint).
+	// Fork-only, not persisted.
+	ResumeAt string `json:"-"`
 }
 
 // ToolName returns "claude"
@@ -68,10 +81,15 @@ func (o *ClaudeOptions) ToArgs() []string {
 	if o.Model != "" {
 		args = append(args, "--model", o.Model)
 	}
+	if settings := o.ThinkingSettings(); settings != "" {
+		args = append(args, "--settings", settings)
+	}
 
 	// Permission flags (mutually exclusive, SkipPermissions takes precedence)
 	if o.SkipPermissions {
 		args = append(args, "--dangerously-skip-permissions")
+	} else if o.PlanMode {
+		args = append(args, "--permission-mode", "plan")
 	} else if o.AutoMode {
 		args = append(args, "--permission-mode", "auto")
 	} else if o.AllowSkipPermissions {
@@ -95,8 +113,13 @@ func (o *ClaudeOptions) ToArgsForFork() []string {
 	if o.Model != "" {
 		args = append(args, "--model", o.Model)
 	}
+	if settings := o.ThinkingSettings(); settings != "" {
+		args = append(args, "--settings", settings)
+	}
 	if o.SkipPermissions {
 		args = append(args, "--dangerously-skip-permissions")
+	} else if o.PlanMode {
+		args = append(args, "--permission-mode", "plan")
 	} else if o.AutoMode {
 		args = append(args, "--permission-mode", "auto")
 	} else if o.AllowSkipPermissions {
@@ -112,6 +135,74 @@ func (o *ClaudeOptions) ToArgsForFork() []string {
 	return args
 }
 
+// ClaudeEffortLevels maps each effort level to the MAX_THINKING_TOKENS
+// budget it sets, matching Claude Code's "think" / "think hard" /
+// "ultrathink" keyword budgets.
+var ClaudeEffortLevels = map[string]int{
+	"low":    4000,
+	"medium": 10000,
+	"high":   31999,
+}
+
+// ThinkingSettings returns the inline --settings JSON that applies the
+// effort level's thinking budget, or "" when Effort is unset or unknown.
+func (o *ClaudeOptions) ThinkingSettings() string {
+	budget, ok := ClaudeEffortLevels[o.Effort]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(`{"env":{"MAX_THINKING_TOKENS":"%d"}}`, budget)
+}
+
+// Claude permission modes, as shown in the permission mode dialog and
+// accepted by `session set <id> permission-mode`.
+const (
+	ClaudePermissionDefault = "default"
+	ClaudePermissionPlan    = "plan"
+	ClaudePermissionAuto    = "auto"
+	ClaudePermissionSkip    = "dangerously-skip"
+)
+
+// ClaudePermissionModes lists the permission modes from most to least
+// restrictive.
+var ClaudePermissionModes = []string{
+	ClaudePermissionPlan,
+	ClaudePermissionDefault,
+	ClaudePermissionAuto,
+	ClaudePermissionSkip,
+}
+
+// PermissionMode returns the mode the permission flags resolve to, following
+// the same precedence as ToArgs.
+func (o *ClaudeOptions) PermissionMode() string {
+	switch {
+	case o.SkipPermissions:
+		return ClaudePermissionSkip
+	case o.PlanMode:
+		return ClaudePermissionPlan
+	case o.AutoMode:
+		return ClaudePermissionAuto
+	default:
+		return ClaudePermissionDefault
+	}
+}
+
+// SetPermissionMode switches the permission flags to mode.
+// AllowSkipPermissions is left alone: it only offers the skip mode inside
+// Claude and is ignored once SkipPermissions is set.
+func (o *ClaudeOptions) SetPermissionMode(mode string) error {
+	switch mode {
+	case ClaudePermissionDefault, ClaudePermissionPlan, ClaudePermissionAuto, ClaudePermissionSkip:
+	default:
+		return fmt.Errorf("invalid permission mode %q (valid: %s, %s, %s, %s)", mode,
+			ClaudePermissionDefault, ClaudePermissionPlan, ClaudePermissionAuto, ClaudePermissionSkip)
+	}
+	o.SkipPermissions = mode == ClaudePermissionSkip
+	o.PlanMode = mode == ClaudePermissionPlan
+	o.AutoMode = mode == ClaudePermissionAuto
+	return nil
+}
+
 // NewClaudeOptions creates ClaudeOptions with defaults from config
 func NewClaudeOptions(config *UserConfig) *ClaudeOptions {
 	opts := &ClaudeOptions{
@@ -457,7 +548,7 @@ func UnmarshalCrushOptions(data json.RawMessage) (*CrushOptions, error) {
 }
 
 // StripResumeFields removes session-specific fields (resume_session_id,
-// session_mode) from serialized ToolOptionsJSON so that a new session
+// session_mode, continue_last) from serialized ToolOptionsJSON so that a new session
 // inheriting another session's settings starts fresh instead of resuming
 // the source conversation.  Other options (skip_permissions, etc.) are
 // preserved.  Returns the input unchanged when it is nil/empty or when
@@ -477,6 +568,7 @@ func StripResumeFields(raw json.RawMessage) json.RawMessage {
 
 	delete(wrapper.Options, "resume_session_id")
 	delete(wrapper.Options, "session_mode")
+	delete(wrapper.Options, "continue_last")
 
 	cleaned, err := json.Marshal(wrapper)
 	if err != nil {
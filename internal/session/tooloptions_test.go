@@ -53,6 +53,29 @@ func TestClaudeOptions_ToArgs(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name: "model with effort",
+			opts: ClaudeOptions{
+				Model:  "opus",
+				Effort: "high",
+			},
+			expected: []string{"--model", "opus", "--settings", `{"env":{"MAX_THINKING_TOKENS":"31999"}}`},
+		},
+		{
+			name: "plan mode",
+			opts: ClaudeOptions{
+				PlanMode: true,
+				AutoMode: true,
+			},
+			expected: []string{"--permission-mode", "plan"},
+		},
+		{
+			name: "unknown effort ignored",
+			opts: ClaudeOptions{
+				Effort: "extreme",
+			},
+			expected: nil,
+		},
 		{
 			name: "skip permissions only",
 			opts: ClaudeOptions{
@@ -60,8 +60,8 @@ func TestRegistry_MatchAllBranches(t *testing.T) {
 		{"cursor agent subcommand", "cursor agent", "cursor"},
 		// hermes
 		{"hermes bare", "hermes", "hermes"},
-		// aider has NO detect arm — commands containing "aider" map to shell
-		{"aider falls through to shell", "aider", "shell"},
+		// aider
+		{"aider with flags", "aider --model sonnet", "aider"},
 		// shell fallback
 		{"unknown -> shell", "vim", "shell"},
 		{"empty -> shell", "", "shell"},
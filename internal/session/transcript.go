@@ -0,0 +1,194 @@
+package session
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Transcripts are full records of a session's pane output, teed by tmux
+// pipe-pane into <profile dir>/transcripts/<session id>.log. Unlike the tmux
+// logs (which log maintenance truncates), a transcript is only ever rotated
+// to numbered backups (<id>.log.1 is the newest) and expired by age.
+
+const (
+	transcriptDirName = "transcripts"
+
+	defaultTranscriptMaxSizeMB     = 20
+	defaultTranscriptMaxFiles      = 5
+	defaultTranscriptRetentionDays = 30
+)
+
+// TranscriptSettings controls per-session output transcripts.
+type TranscriptSettings struct {
+	// Enabled tees every running session's pane output into its transcript
+	// (default: false).
+	Enabled bool `toml:"enabled,omitempty"`
+
+	// MaxSizeMB rotates a transcript once it grows past this size.
+	// Default: 20
+	MaxSizeMB int `toml:"max_size_mb,omitzero"`
+
+	// MaxFiles is the number of rotated transcripts kept per session.
+	// Default: 5
+	MaxFiles int `toml:"max_files,omitzero"`
+
+	// RetentionDays deletes transcripts (current and rotated) that have not
+	// been written for this many days. Default: 30
+	RetentionDays int `toml:"retention_days,omitzero"`
+}
+
+// GetMaxSizeBytes returns the rotation threshold in bytes.
+func (t TranscriptSettings) GetMaxSizeBytes() int64 {
+	mb := t.MaxSizeMB
+	if mb <= 0 {
+		mb = defaultTranscriptMaxSizeMB
+	}
+	return int64(mb) * 1024 * 1024
+}
+
+// GetMaxFiles returns the number of rotated transcripts kept per session.
+func (t TranscriptSettings) GetMaxFiles() int {
+	if t.MaxFiles <= 0 {
+		return defaultTranscriptMaxFiles
+	}
+	return t.MaxFiles
+}
+
+// GetRetention returns how long an unwritten transcript is kept.
+func (t TranscriptSettings) GetRetention() time.Duration {
+	days := t.RetentionDays
+	if days <= 0 {
+		days = defaultTranscriptRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// TranscriptDir returns the directory holding a profile's transcripts.
+func TranscriptDir(profile string) (string, error) {
+	dir, err := GetProfileDir(profile)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, transcriptDirName), nil
+}
+
+// TranscriptPath returns the current transcript file of a session.
+func TranscriptPath(profile, instanceID string) (string, error) {
+	dir, err := TranscriptDir(profile)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, instanceID+".log"), nil
+}
+
+// StartTranscript attaches the session's pane output to its transcript. It
+// is idempotent: a pane that is already piped is left alone. Returns true
+// when a new pipe was opened.
+func (i *Instance) StartTranscript(profile string) (bool, error) {
+	ts := i.GetTmuxSession()
+	if ts == nil || !ts.Exists() {
+		return false, nil
+	}
+	path, err := TranscriptPath(profile, i.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return false, fmt.Errorf("failed to create transcript dir: %w", err)
+	}
+	return ts.PipeOutputToFile(path)
+}
+
+// RotateTranscripts rotates oversized transcripts in dir and deletes the
+// ones not written within the retention window, which also covers sessions
+// that were deleted.
+func RotateTranscripts(dir string, settings TranscriptSettings, now time.Time) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	maxSize := settings.GetMaxSizeBytes()
+	cutoff := now.Add(-settings.GetRetention())
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if info.ModTime().Before(cutoff) {
+			_ = os.Remove(path)
+			continue
+		}
+		if strings.HasSuffix(e.Name(), ".log") && info.Size() > maxSize {
+			if err := rotateTranscript(path, settings.GetMaxFiles()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rotateTranscript shifts path.N to path.N+1 (dropping the oldest), copies
+// path to path.1 and truncates path. Copy-truncate keeps the running
+// pipe-pane writing to the same O_APPEND descriptor.
+func rotateTranscript(path string, maxFiles int) error {
+	_ = os.Remove(fmt.Sprintf("%s.%d", path, maxFiles))
+	for n := maxFiles - 1; n >= 1; n-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", path, n), fmt.Sprintf("%s.%d", path, n+1))
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+".1", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Truncate(path, 0)
+}
+
+// TranscriptFiles returns a session's transcript files, oldest first, so
+// they read as one record when concatenated. Missing files are skipped.
+func TranscriptFiles(profile, instanceID string) ([]string, error) {
+	path, err := TranscriptPath(profile, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	matches, _ := filepath.Glob(path + ".*")
+	rotated := make(map[string]int, len(matches))
+	for _, m := range matches {
+		var n int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(m, path+"."), "%d", &n); err == nil && n > 0 {
+			rotated[m] = n
+		}
+	}
+	files := make([]string, 0, len(rotated)+1)
+	for m := range rotated {
+		files = append(files, m)
+	}
+	sort.Slice(files, func(a, b int) bool { return rotated[files[a]] > rotated[files[b]] })
+	if _, err := os.Stat(path); err == nil {
+		files = append(files, path)
+	}
+	return files, nil
+}
@@ -0,0 +1,101 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTranscriptSettingsDefaults(t *testing.T) {
+	var s TranscriptSettings
+	if s.GetMaxSizeBytes() != 20*1024*1024 || s.GetMaxFiles() != 5 || s.GetRetention() != 30*24*time.Hour {
+		t.Errorf("unexpected defaults: %d %d %s", s.GetMaxSizeBytes(), s.GetMaxFiles(), s.GetRetention())
+	}
+	s = TranscriptSettings{MaxSizeMB: 1, MaxFiles: 2, RetentionDays: 1}
+	if s.GetMaxSizeBytes() != 1024*1024 || s.GetMaxFiles() != 2 || s.GetRetention() != 24*time.Hour {
+		t.Errorf("overrides not applied: %d %d %s", s.GetMaxSizeBytes(), s.GetMaxFiles(), s.GetRetention())
+	}
+}
+
+func TestRotateTranscript_KeepsMaxFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "abc.log")
+	for _, content := range []string{"one", "two", "three"} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := rotateTranscript(path, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := func(p string) string {
+		b, _ := os.ReadFile(p)
+		return string(b)
+	}
+	if got := read(path); got != "" {
+		t.Errorf("current transcript should be truncated, got %q", got)
+	}
+	if read(path+".1") != "three" || read(path+".2") != "two" {
+		t.Errorf("rotation order wrong: .1=%q .2=%q", read(path+".1"), read(path+".2"))
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("rotations beyond max_files must be dropped")
+	}
+}
+
+func TestRotateTranscripts_ExpiresOldFiles(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	old := filepath.Join(dir, "gone.log")
+	fresh := filepath.Join(dir, "kept.log")
+	for _, p := range []string{old, fresh} {
+		if err := os.WriteFile(p, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := now.Add(-48 * time.Hour)
+	if err := os.Chtimes(old, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RotateTranscripts(dir, TranscriptSettings{RetentionDays: 1}, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Error("transcript past retention should be deleted")
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Error("recent transcript should be kept")
+	}
+	if err := RotateTranscripts(filepath.Join(dir, "missing"), TranscriptSettings{}, now); err != nil {
+		t.Errorf("missing dir should be a no-op, got %v", err)
+	}
+}
+
+func TestTranscriptFiles_OldestFirst(t *testing.T) {
+	path, err := TranscriptPath("default", "sess1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{path, path + ".1", path + ".2", path + ".10"} {
+		if err := os.WriteFile(p, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, err := TranscriptFiles("default", "sess1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{path + ".10", path + ".2", path + ".1", path}
+	if len(files) != len(want) {
+		t.Fatalf("TranscriptFiles = %v", files)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("files[%d] = %s, want %s", i, files[i], want[i])
+		}
+	}
+}
@@ -728,6 +728,13 @@ func hookStatusFilePath(instanceID string) string {
 	return filepath.Join(hooksDir, instanceID+".json")
 }
 
+// ReadHookStatusFile returns the last hook status written for instanceID, or
+// nil when none exists. The hook handler uses it to interpret an event
+// relative to the state it follows.
+func ReadHookStatusFile(instanceID string) *HookStatus {
+	return readHookStatusFile(instanceID)
+}
+
 func readHookStatusFile(instanceID string) *HookStatus {
 	if strings.TrimSpace(instanceID) == "" {
 		return nil
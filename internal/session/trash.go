@@ -0,0 +1,113 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// The trash keeps deleted sessions in the profile's state.db for a retention
+// period. Deleting drops the instances row, and with it the only mapping from
+// the session to its Claude/Codex/Gemini conversation; a trash entry holds the
+// whole row so `agent-deck trash restore` (or the TUI trash view) brings the
+// session back stopped, ready to resume that conversation.
+
+const defaultTrashRetentionDays = 30
+
+// TrashSettings controls the deleted-session trash.
+type TrashSettings struct {
+	// Enabled keeps deleted sessions in the trash (default: true). false
+	// makes delete permanent again.
+	Enabled *bool `toml:"enabled,omitempty"`
+
+	// RetentionDays purges trash entries deleted more than this many days
+	// ago. Default: 30
+	RetentionDays int `toml:"retention_days,omitzero"`
+}
+
+// IsEnabled reports whether deletes go to the trash.
+func (t TrashSettings) IsEnabled() bool {
+	return t.Enabled == nil || *t.Enabled
+}
+
+// GetRetention returns how long a trash entry is kept.
+func (t TrashSettings) GetRetention() time.Duration {
+	days := t.RetentionDays
+	if days <= 0 {
+		days = defaultTrashRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// TrashInstance records inst in the trash before it is deleted. It is built
+// from the in-memory instance, which may carry a fresher tool session ID than
+// the row on disk. Callers still run their usual removal path afterwards; a
+// trash failure should be reported but must not block the delete. Also sweeps
+// entries past the retention period. No-op when the trash is disabled.
+func (s *Storage) TrashInstance(inst *Instance) error {
+	settings := GetTrashSettings()
+	if !settings.IsEnabled() {
+		return nil
+	}
+	row, err := instanceToRow(inst)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return fmt.Errorf("storage database not initialized")
+	}
+	now := time.Now()
+	if err := s.db.TrashInstance(row, now); err != nil {
+		return fmt.Errorf("failed to trash session %s: %w", inst.ID, err)
+	}
+	if _, err := s.db.PurgeTrashBefore(now.Add(-settings.GetRetention())); err != nil {
+		storageLog.Warn("trash_expire_failed", slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+// LoadTrash returns the trash entries still inside the retention period,
+// most recently deleted first. Expired entries are purged on the way.
+func (s *Storage) LoadTrash() ([]*statedb.TrashRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return nil, fmt.Errorf("storage database not initialized")
+	}
+	cutoff := time.Now().Add(-GetTrashSettings().GetRetention())
+	if _, err := s.db.PurgeTrashBefore(cutoff); err != nil {
+		return nil, fmt.Errorf("failed to expire trash: %w", err)
+	}
+	return s.db.LoadTrash()
+}
+
+// RestoreFromTrash puts the trashed session with the given id back into the
+// session list (stopped) and drops its trash entry.
+func (s *Storage) RestoreFromTrash(id string) (*statedb.InstanceRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return nil, fmt.Errorf("storage database not initialized")
+	}
+	row, err := s.db.RestoreTrashed(id)
+	if row != nil {
+		_ = s.db.Touch()
+	}
+	return row, err
+}
+
+// PurgeTrash permanently deletes the trash entry with the given id, or the
+// whole trash when id is "". Returns the number of entries removed.
+func (s *Storage) PurgeTrash(id string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return 0, fmt.Errorf("storage database not initialized")
+	}
+	return s.db.PurgeTrash(id)
+}
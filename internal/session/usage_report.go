@@ -0,0 +1,209 @@
+package session
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// SessionUsageSince returns token, turn and cost analytics for inst's tool
+// conversation, counting only activity at or after since (zero = all time).
+// Returns nil when the tool has no parser or no conversation is bound yet.
+// Gemini session files carry no per-message timestamps, so a Gemini session
+// counts in full when it was last updated within the window.
+func SessionUsageSince(inst *Instance, since time.Time) (*SessionAnalytics, error) {
+	switch ToolAdapterFor(inst.Tool).Name() {
+	case "claude":
+		path := inst.GetJSONLPath()
+		if path == "" {
+			return nil, nil
+		}
+		return ParseSessionJSONLSince(path, since)
+	case "gemini":
+		if inst.GeminiSessionID == "" {
+			return nil, nil
+		}
+		g := &GeminiSessionAnalytics{}
+		if err := UpdateGeminiAnalyticsFromDisk(inst.ProjectPath, inst.GeminiSessionID, g); err != nil {
+			return nil, err
+		}
+		if !since.IsZero() && g.LastActive.Before(since) {
+			return &SessionAnalytics{}, nil
+		}
+		model := g.Model
+		if model == "" {
+			model = "default"
+		}
+		a := &SessionAnalytics{
+			InputTokens:   g.InputTokens,
+			OutputTokens:  g.OutputTokens,
+			TotalTurns:    g.TotalTurns,
+			Duration:      g.Duration,
+			StartTime:     g.StartTime,
+			LastActive:    g.LastActive,
+			Model:         g.Model,
+			EstimatedCost: g.CalculateCost(model),
+		}
+		a.addDailyTokens(g.LastActive, g.InputTokens+g.OutputTokens)
+		return a, nil
+	case "opencode":
+		if inst.OpenCodeSessionID == "" {
+			return nil, nil
+		}
+		return ParseOpenCodeSessionSince(GetOpenCodeStorageDir(), inst.OpenCodeSessionID, since)
+	}
+	return nil, nil
+}
+
+// UsagePriceFunc prices token usage in USD for a model; returning 0 (model
+// unknown) falls back to SessionAnalytics.CalculateCost.
+type UsagePriceFunc func(model string, input, output, cacheRead, cacheWrite int64) float64
+
+// UsageReportOptions selects and groups sessions for BuildUsageReport.
+type UsageReportOptions struct {
+	Since time.Time
+	Group string // only sessions in this group (and subgroups)
+	By    string // "group" (default) or "session"
+}
+
+// UsageReportRow aggregates usage for one group or session.
+type UsageReportRow struct {
+	Key              string        `json:"key"`
+	Sessions         int           `json:"sessions"`
+	Turns            int           `json:"turns"`
+	InputTokens      int64         `json:"input_tokens"`
+	OutputTokens     int64         `json:"output_tokens"`
+	CacheReadTokens  int64         `json:"cache_read_tokens"`
+	CacheWriteTokens int64         `json:"cache_write_tokens"`
+	CostUSD          float64       `json:"cost_usd"`
+	ActiveTime       time.Duration `json:"active_time_ns"`
+}
+
+// TotalTokens returns input + output + cache tokens.
+func (r UsageReportRow) TotalTokens() int64 {
+	return r.InputTokens + r.OutputTokens + r.CacheReadTokens + r.CacheWriteTokens
+}
+
+func (r *UsageReportRow) add(o UsageReportRow) {
+	r.Sessions += o.Sessions
+	r.Turns += o.Turns
+	r.InputTokens += o.InputTokens
+	r.OutputTokens += o.OutputTokens
+	r.CacheReadTokens += o.CacheReadTokens
+	r.CacheWriteTokens += o.CacheWriteTokens
+	r.CostUSD += o.CostUSD
+	r.ActiveTime += o.ActiveTime
+}
+
+// UsageDay is the token total for one local calendar day.
+type UsageDay struct {
+	Date   string `json:"date"` // YYYY-MM-DD
+	Tokens int64  `json:"tokens"`
+}
+
+// UsageReport is the result of BuildUsageReport. Rows are sorted by cost,
+// highest first; Total sums every row. Days lists every day in the window,
+// oldest first, including days without activity.
+type UsageReport struct {
+	Since time.Time        `json:"since"`
+	Until time.Time        `json:"until"`
+	By    string           `json:"by"`
+	Rows  []UsageReportRow `json:"rows"`
+	Total UsageReportRow   `json:"total"`
+	Days  []UsageDay       `json:"days,omitempty"`
+}
+
+// BuildUsageReport parses each session's transcript via usage (normally
+// SessionUsageSince) and aggregates the sessions that had activity in the
+// window. Sessions without a parser-supplied cost are priced with price
+// (the cost tracker's pricing table) when set, else the built-in table.
+func BuildUsageReport(instances []*Instance, opts UsageReportOptions, now time.Time,
+	usage func(*Instance, time.Time) (*SessionAnalytics, error), price UsagePriceFunc,
+) UsageReport {
+	by := opts.By
+	if by != "session" {
+		by = "group"
+	}
+	group := strings.Trim(opts.Group, "/")
+	report := UsageReport{Since: opts.Since, Until: now, By: by, Rows: []UsageReportRow{}, Total: UsageReportRow{Key: "TOTAL"}}
+	rows := map[string]*UsageReportRow{}
+	daily := map[string]int64{}
+	for _, inst := range instances {
+		if inst == nil {
+			continue
+		}
+		if group != "" && inst.GroupPath != group && !strings.HasPrefix(inst.GroupPath, group+"/") {
+			continue
+		}
+		a, err := usage(inst, opts.Since)
+		if err != nil || a == nil || (a.TotalTurns == 0 && a.TotalTokens() == 0) {
+			continue
+		}
+		cost := a.EstimatedCost // provider-reported (OpenCode) or tool-table estimate (Gemini)
+		if cost == 0 && price != nil {
+			cost = price(a.Model, int64(a.InputTokens), int64(a.OutputTokens), int64(a.CacheReadTokens), int64(a.CacheWriteTokens))
+		}
+		if cost == 0 && a.TotalTokens() > 0 {
+			cost = a.CalculateCost(a.Model)
+		}
+		row := UsageReportRow{
+			Sessions:         1,
+			Turns:            a.TotalTurns,
+			InputTokens:      int64(a.InputTokens),
+			OutputTokens:     int64(a.OutputTokens),
+			CacheReadTokens:  int64(a.CacheReadTokens),
+			CacheWriteTokens: int64(a.CacheWriteTokens),
+			CostUSD:          cost,
+			ActiveTime:       a.Duration,
+		}
+		key := inst.GroupPath
+		if by == "session" {
+			key = inst.Title
+		}
+		if rows[key] == nil {
+			rows[key] = &UsageReportRow{Key: key}
+		}
+		rows[key].add(row)
+		report.Total.add(row)
+		for day, n := range a.DailyTokens {
+			daily[day] += int64(n)
+		}
+	}
+	report.Days = usageDays(opts.Since, now, daily)
+	for _, r := range rows {
+		report.Rows = append(report.Rows, *r)
+	}
+	sort.Slice(report.Rows, func(i, j int) bool {
+		if report.Rows[i].CostUSD != report.Rows[j].CostUSD {
+			return report.Rows[i].CostUSD > report.Rows[j].CostUSD
+		}
+		return report.Rows[i].Key < report.Rows[j].Key
+	})
+	return report
+}
+
+// usageDays expands daily into one entry per day from since through now.
+// With a zero since, the range starts at the earliest day seen.
+func usageDays(since, now time.Time, daily map[string]int64) []UsageDay {
+	start := since
+	if start.IsZero() {
+		for day := range daily {
+			if t, err := time.ParseInLocation("2006-01-02", day, time.Local); err == nil && (start.IsZero() || t.Before(start)) {
+				start = t
+			}
+		}
+		if start.IsZero() {
+			return nil
+		}
+	}
+	var days []UsageDay
+	end := now.Local().Format("2006-01-02")
+	for d := start.Local(); ; d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		days = append(days, UsageDay{Date: key, Tokens: daily[key]})
+		if key >= end {
+			break
+		}
+	}
+	return days
+}
@@ -0,0 +1,101 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBuildUsageReport_GroupsAndTotals(t *testing.T) {
+	instances := []*Instance{
+		{ID: "1", Title: "api", GroupPath: "acme"},
+		{ID: "2", Title: "web", GroupPath: "acme/frontend"},
+		{ID: "3", Title: "blog", GroupPath: "personal"},
+		{ID: "4", Title: "idle", GroupPath: "acme"},
+	}
+	usage := map[string]*SessionAnalytics{
+		"1": {TotalTurns: 3, InputTokens: 1000, OutputTokens: 500, EstimatedCost: 2, Duration: time.Hour},
+		"2": {TotalTurns: 1, InputTokens: 100, OutputTokens: 50, Model: "m"},
+		"3": {TotalTurns: 2, InputTokens: 10, OutputTokens: 5, EstimatedCost: 0.5},
+		"4": {},
+	}
+	fake := func(inst *Instance, _ time.Time) (*SessionAnalytics, error) { return usage[inst.ID], nil }
+	price := func(model string, in, out, _, _ int64) float64 {
+		if model == "m" {
+			return 1
+		}
+		return 0
+	}
+	now := time.Now()
+
+	r := BuildUsageReport(instances, UsageReportOptions{}, now, fake, price)
+	if len(r.Rows) != 3 {
+		t.Fatalf("rows = %+v, want 3 (idle session skipped)", r.Rows)
+	}
+	if r.Rows[0].Key != "acme" || r.Rows[1].Key != "acme/frontend" || r.Rows[2].Key != "personal" {
+		t.Errorf("row order = %q %q %q, want sorted by cost", r.Rows[0].Key, r.Rows[1].Key, r.Rows[2].Key)
+	}
+	if r.Rows[1].CostUSD != 1 {
+		t.Errorf("price func not used for session without estimate: %v", r.Rows[1].CostUSD)
+	}
+	if r.Total.Sessions != 3 || r.Total.Turns != 6 || r.Total.CostUSD != 3.5 || r.Total.ActiveTime != time.Hour {
+		t.Errorf("total = %+v", r.Total)
+	}
+
+	r = BuildUsageReport(instances, UsageReportOptions{Group: "acme", By: "session"}, now, fake, price)
+	if len(r.Rows) != 2 || r.Rows[0].Key != "api" || r.Rows[1].Key != "web" {
+		t.Errorf("acme by session = %+v", r.Rows)
+	}
+}
+
+func TestParseSessionJSONLSince(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "s.jsonl")
+	lines := `{"type":"assistant","timestamp":"2026-01-01T10:00:00Z","message":{"model":"claude-sonnet-4","usage":{"input_tokens":100,"output_tokens":10}}}
+{"type":"assistant","timestamp":"2026-01-05T10:00:00Z","message":{"model":"claude-sonnet-4","usage":{"input_tokens":200,"output_tokens":20}}}
+`
+	if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	all, err := ParseSessionJSONL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all.InputTokens != 300 {
+		t.Errorf("all-time input = %d, want 300", all.InputTokens)
+	}
+	recent, err := ParseSessionJSONLSince(path, time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recent.InputTokens != 200 || recent.OutputTokens != 20 {
+		t.Errorf("since input/output = %d/%d, want 200/20", recent.InputTokens, recent.OutputTokens)
+	}
+	if len(recent.DailyTokens) != 1 || recent.DailyTokens[time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC).Local().Format("2006-01-02")] != 220 {
+		t.Errorf("DailyTokens = %v, want 220 on Jan 5", recent.DailyTokens)
+	}
+}
+
+func TestBuildUsageReport_Days(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.ParseInLocation("2006-01-02", s, time.Local)
+		return d
+	}
+	instances := []*Instance{{ID: "1", GroupPath: "a"}, {ID: "2", GroupPath: "b"}}
+	usage := map[string]*SessionAnalytics{
+		"1": {TotalTurns: 1, InputTokens: 30, DailyTokens: map[string]int{"2026-03-01": 10, "2026-03-03": 20}},
+		"2": {TotalTurns: 1, InputTokens: 5, DailyTokens: map[string]int{"2026-03-03": 5}},
+	}
+	fake := func(inst *Instance, _ time.Time) (*SessionAnalytics, error) { return usage[inst.ID], nil }
+
+	r := BuildUsageReport(instances, UsageReportOptions{Since: day("2026-03-01")}, day("2026-03-04").Add(time.Hour), fake, nil)
+	want := []UsageDay{{"2026-03-01", 10}, {"2026-03-02", 0}, {"2026-03-03", 25}, {"2026-03-04", 0}}
+	if len(r.Days) != len(want) {
+		t.Fatalf("Days = %+v, want %+v", r.Days, want)
+	}
+	for i := range want {
+		if r.Days[i] != want[i] {
+			t.Errorf("Days[%d] = %+v, want %+v", i, r.Days[i], want[i])
+		}
+	}
+}
@@ -17,8 +17,6 @@ import (
 
 	"github.com/BurntSushi/toml"
 
-	dark "github.com/thiagokokada/dark-mode-go"
-
 	"github.com/asheshgoplani/agent-deck/internal/agentpaths"
 	"github.com/asheshgoplani/agent-deck/internal/atomicfile"
 	"github.com/asheshgoplani/agent-deck/internal/logging"
@@ -64,9 +62,18 @@ type UserConfig struct {
 	// Set an action to "" to explicitly unbind it.
 	Hotkeys map[string]string `toml:"hotkeys,omitempty"`
 
-	// Theme sets the color scheme: "dark" (default), "light", or "system"
+	// Keys is an alias for Hotkeys ([keys] reads more naturally next to the
+	// action names). Entries here override [hotkeys] for the same action.
+	Keys map[string]string `toml:"keys,omitempty"`
+
+	// Theme sets the color scheme: "dark" (default), "light",
+	// "solarized-dark", "solarized-light", "high-contrast", "system", or the
+	// name of a [themes.<name>] palette.
 	Theme string `toml:"theme,omitempty"`
 
+	// Themes defines user color palettes selectable by name via Theme.
+	Themes map[string]ThemePalette `toml:"themes,omitempty"`
+
 	// Tools defines custom AI tool configurations
 	Tools map[string]ToolDef `toml:"tools,omitempty"`
 
@@ -89,14 +96,30 @@ type UserConfig struct {
 
 	// GroupSort controls the order of sessions within a group.
 	//   "creation"   (default) — fixed creation order; honors K/J manual reorder.
+	//   "accessed"             — most recently accessed first.
 	//   "actionable"           — issue #857 status→recency→Order surfacing.
-	// Empty or unrecognized values normalize to "creation".
+	//   "created"              — newest session first.
+	//   "cost"                 — biggest total spend first, live.
+	//   "resources"            — biggest memory (then CPU) user first, live.
+	// Empty or unrecognized values normalize to "creation". The TUI sort
+	// hotkey overrides this per profile.
 	GroupSort string `toml:"group_sort,omitempty"`
 
 	// MCPs defines available MCP servers for the MCP Manager
 	// These can be attached/detached per-project via the MCP Manager (M key)
 	MCPs map[string]MCPDef `toml:"mcps,omitempty"`
 
+	// MCPSets defines named bundles of [mcps.X] entries, attached together
+	// with one toggle in the MCP Manager or `agent-deck add --mcp-set <name>`.
+	// Example:
+	// [mcp_sets]
+	// web-dev = ["playwright", "github", "slack"]
+	MCPSets map[string][]string `toml:"mcp_sets,omitempty"`
+
+	// MCPRegistry points the MCP registry browser at a remote index of
+	// installable MCP servers (see mcp_registry.go).
+	MCPRegistry MCPRegistrySettings `toml:"mcp_registry,omitempty"`
+
 	// Plugins defines available Claude Code plugins for per-session attach
 	// (RFC docs/rfc/PLUGIN_ATTACH.md). Catalog-only in v1: every name passed
 	// via `--plugin <name>` must resolve to an entry here. Each entry maps a
@@ -119,10 +142,46 @@ type UserConfig struct {
 	// config_dir = "~/.claude-my-group"
 	Groups map[string]GroupSettings `toml:"groups,omitempty"`
 
+	// GroupRules auto-assign a group to sessions created via the CLI or
+	// imported without -g/--group. First match wins. See GroupRule.
+	GroupRules []GroupRule `toml:"group_rules,omitempty"`
+
 	// GroupDefaults holds defaults applied to NEWLY-created groups only.
 	// Existing groups (loaded from state.db) are never affected.
 	GroupDefaults GroupDefaultsSettings `toml:"group_defaults,omitempty"`
 
+	// Templates defines named new-session presets, selectable in the
+	// new-session dialog and via `agent-deck add --template <name>`.
+	// Example:
+	// [templates.review]
+	// tool = "claude"
+	// mcps = ["github"]
+	// dangerous_mode = true
+	Templates map[string]SessionTemplate `toml:"templates,omitempty"`
+
+	// Schedules are cron-style session launches, keyed by name. The TUI of
+	// the schedule's profile fires them (see schedule.go).
+	// Example:
+	// [schedules.nightly-summary]
+	// cron = "0 7 * * 1-5"
+	// tool = "claude"
+	// path = "~/src/api"
+	// prompt = "Summarize yesterday's commits"
+	Schedules map[string]ScheduleDef `toml:"schedules,omitempty"`
+
+	// Webhooks are outbound endpoints POSTed on session lifecycle events
+	// (created, waiting, idle, error, deleted, restarted). See webhook.go.
+	// Example:
+	// [webhooks.slack]
+	// url = "https://hooks.slack.com/services/..."
+	// format = "slack"
+	// events = ["waiting", "error"]
+	Webhooks map[string]WebhookConfig `toml:"webhooks,omitempty"`
+
+	// Slack posts waiting notices to a channel and routes thread replies
+	// back to the sessions. See slack_integration.go.
+	Slack SlackIntegrationSettings `toml:"slack,omitempty"`
+
 	// Conductors defines optional per-conductor overrides.
 	// Keyed by conductor name (matches Instance.Title minus "conductor-" prefix).
 	// Mirrors Groups — see ConductorOverrides for the sub-table shape.
@@ -151,6 +210,9 @@ type UserConfig struct {
 	// Hermes defines Hermes Agent CLI integration settings
 	Hermes HermesSettings `toml:"hermes,omitempty"`
 
+	// Aider defines Aider CLI integration settings
+	Aider AiderSettings `toml:"aider,omitempty"`
+
 	// Worktree defines git worktree preferences
 	Worktree WorktreeSettings `toml:"worktree,omitempty"`
 
@@ -184,6 +246,24 @@ type UserConfig struct {
 	// Maintenance defines automatic maintenance worker settings
 	Maintenance MaintenanceSettings `toml:"maintenance,omitempty"`
 
+	// Archive defines automatic archival of idle sessions
+	Archive ArchiveSettings `toml:"archive,omitempty"`
+
+	// Watchdog flags running sessions that stopped producing output
+	Watchdog WatchdogSettings `toml:"watchdog,omitempty"`
+
+	// Transcripts defines opt-in per-session output transcripts
+	Transcripts TranscriptSettings `toml:"transcripts,omitempty"`
+
+	// Verify defines the per-project verify command run from the TUI
+	Verify VerifySettings `toml:"verify,omitempty"`
+
+	// Snapshots defines automatic checkout snapshots and their retention
+	Snapshots SnapshotSettings `toml:"snapshots,omitempty"`
+
+	// Trash defines how long deleted sessions are kept for restore
+	Trash TrashSettings `toml:"trash,omitempty"`
+
 	// Status defines session status detection settings
 	Status StatusSettings `toml:"status,omitempty"`
 
@@ -227,6 +307,10 @@ type UserConfig struct {
 	// from anything tmux draws. Empty/absent uses defaults; see TerminalSettings.
 	Terminal TerminalSettings `toml:"terminal,omitempty"`
 
+	// Attach defines how attaching opens a session: in this terminal, or in
+	// a window/pane of the tmux session agent-deck runs in. See AttachSettings.
+	Attach AttachSettings `toml:"attach,omitempty"`
+
 	// Web defines `agent-deck web` HTTP server settings.
 	Web WebSettings `toml:"web,omitempty"`
 
@@ -657,6 +741,12 @@ type GroupSettings struct {
 	Claude GroupClaudeSettings `toml:"claude,omitempty"`
 	// Hermes defines Hermes overrides for a specific group.
 	Hermes GroupHermesSettings `toml:"hermes,omitempty"`
+	// Tmux defines extra tmux options and hooks for sessions in this group.
+	Tmux TmuxOverrideSettings `toml:"tmux,omitempty"`
+	// WebPrivate hides this group (and every subgroup) from the web server:
+	// its sessions never appear in API responses, SSE streams, snapshots or
+	// push notifications, and ID-addressed endpoints answer 404 for them.
+	WebPrivate bool `toml:"web_private,omitempty"`
 }
 
 // GroupDefaultsSettings carries [group_defaults] — defaults stamped onto new
@@ -672,6 +762,125 @@ type GroupDefaultsSettings struct {
 	MaxConcurrent *int `toml:"max_concurrent,omitempty"`
 }
 
+// SessionTemplate is a named new-session preset from [templates.<name>].
+// Every key is optional: unset keys leave the dialog/CLI default alone, and
+// explicit CLI flags always win over the template.
+type SessionTemplate struct {
+	// Description is shown next to the template name in the dialog.
+	Description string `toml:"description,omitempty"`
+	// Tool is a built-in tool ("claude", "codex", ...) or a [tools.X] name.
+	Tool string `toml:"tool,omitempty"`
+	// Group is the group path new sessions land in.
+	Group string `toml:"group,omitempty"`
+	// Path is the default project directory (tilde-expanded).
+	Path string `toml:"path,omitempty"`
+	// Model is the per-session model override.
+	Model string `toml:"model,omitempty"`
+	// MCPs lists [mcps.X] catalog names written to the project's MCP config
+	// before the session starts.
+	MCPs []string `toml:"mcps,omitempty"`
+	// Worktree is the worktree policy: "on" | "off" | "" (use the
+	// [worktree].default_enabled default). Mirrors the [fork].docker enum.
+	Worktree string `toml:"worktree,omitempty"`
+	// Sandbox runs the session in a Docker sandbox. nil => dialog default.
+	Sandbox *bool `toml:"sandbox,omitempty"`
+	// DangerousMode, AutoMode, UseChrome and UseTeammateMode override the
+	// matching [claude] defaults. nil => inherit.
+	DangerousMode   *bool `toml:"dangerous_mode,omitempty"`
+	AutoMode        *bool `toml:"auto_mode,omitempty"`
+	UseChrome       *bool `toml:"use_chrome,omitempty"`
+	UseTeammateMode *bool `toml:"use_teammate_mode,omitempty"`
+	// ExtraArgs are extra claude CLI tokens (same rules as --extra-arg).
+	ExtraArgs []string `toml:"extra_args,omitempty"`
+	// Yolo enables YOLO mode for gemini/codex/hermes sessions.
+	Yolo *bool `toml:"yolo,omitempty"`
+}
+
+// GetWorktree returns the canonical worktree policy: "on" | "off" | "".
+func (t SessionTemplate) GetWorktree() string {
+	switch v := strings.ToLower(strings.TrimSpace(t.Worktree)); v {
+	case "on", "off":
+		return v
+	case "true", "yes":
+		return "on"
+	case "false", "no":
+		return "off"
+	default:
+		return ""
+	}
+}
+
+// HasClaudeOverrides reports whether the template sets any Claude flag.
+func (t SessionTemplate) HasClaudeOverrides() bool {
+	return t.DangerousMode != nil || t.AutoMode != nil || t.UseChrome != nil || t.UseTeammateMode != nil
+}
+
+// ApplyClaudeOptions overlays the template's Claude flags and model onto opts.
+func (t SessionTemplate) ApplyClaudeOptions(opts *ClaudeOptions) {
+	if opts == nil {
+		return
+	}
+	if t.DangerousMode != nil {
+		opts.SkipPermissions = *t.DangerousMode
+	}
+	if t.AutoMode != nil {
+		opts.AutoMode = *t.AutoMode
+	}
+	if t.UseChrome != nil {
+		opts.UseChrome = *t.UseChrome
+	}
+	if t.UseTeammateMode != nil {
+		opts.UseTeammateMode = *t.UseTeammateMode
+	}
+	if t.Model != "" {
+		opts.Model = t.Model
+	}
+}
+
+// TemplateNames returns the configured template names, sorted.
+func (c *UserConfig) TemplateNames() []string {
+	if c == nil || len(c.Templates) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(c.Templates))
+	for name := range c.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetTemplate looks up [templates.<name>].
+func (c *UserConfig) GetTemplate(name string) (SessionTemplate, bool) {
+	if c == nil {
+		return SessionTemplate{}, false
+	}
+	t, ok := c.Templates[name]
+	return t, ok
+}
+
+// MCPSetNames returns the configured MCP set names, sorted.
+func (c *UserConfig) MCPSetNames() []string {
+	if c == nil || len(c.MCPSets) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(c.MCPSets))
+	for name := range c.MCPSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetMCPSet looks up [mcp_sets].<name>.
+func (c *UserConfig) GetMCPSet(name string) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+	members, ok := c.MCPSets[name]
+	return members, ok
+}
+
 // GroupClaudeSettings defines group-specific Claude overrides.
 //
 // The key surface deliberately mirrors ConductorClaudeSettings (CFG-08
@@ -819,6 +1028,12 @@ type MCPPoolSettings struct {
 
 	// SocketWaitTimeout is seconds to wait for socket to become ready (default: 5)
 	SocketWaitTimeout int `toml:"socket_wait_timeout,omitzero"`
+
+	// LogMaxSizeMB rotates a pooled MCP's captured output at this size (default: 5)
+	LogMaxSizeMB int `toml:"log_max_size_mb,omitzero"`
+
+	// LogMaxBackups is how many rotated logs to keep per MCP (default: 3)
+	LogMaxBackups int `toml:"log_max_backups,omitzero"`
 }
 
 func (p MCPPoolSettings) GetAutoStart() bool {
@@ -956,6 +1171,15 @@ type PreviewSettings struct {
 	// in the preview pane when output is visible.
 	// Range: 0.1 - 0.9 (fraction reserved for notes). Default: 0.33
 	NotesOutputSplit float64 `toml:"notes_output_split,omitzero"`
+
+	// HighlightNewOutput marks output produced since the session was last
+	// viewed: a "+N" badge on the list row and a gutter bar on the new lines
+	// in the preview. Default: true (nil = true)
+	HighlightNewOutput *bool `toml:"highlight_new_output,omitempty"`
+
+	// CopyLines is how many trailing lines of the pane the copy-pane action
+	// puts on the clipboard. Default: 200
+	CopyLines int `toml:"copy_lines,omitempty"`
 }
 
 // AnalyticsDisplaySettings configures which analytics sections to display
@@ -975,6 +1199,15 @@ type AnalyticsDisplaySettings struct {
 
 	// ShowCost shows the estimated cost (default: false)
 	ShowCost *bool `toml:"show_cost,omitempty"`
+
+	// ShowSubagents lists the Task sub-agents the session spawned, nested
+	// under it (default: true; only shown when there are any)
+	ShowSubagents *bool `toml:"show_subagents,omitempty"`
+
+	// ShowFilesTouched lists the files the session modified, from git
+	// snapshots of its checkout, with per-file added/deleted lines
+	// (default: true; only shown in git checkouts with changes)
+	ShowFilesTouched *bool `toml:"show_files_touched,omitempty"`
 }
 
 // ExperimentsSettings defines experiment folder configuration
@@ -1007,11 +1240,54 @@ type NotificationsConfig struct {
 	// When true, key bindings (Ctrl+b 1-6) are disabled. ShowAll is ignored. (default: false)
 	Minimal bool `toml:"minimal,omitempty"`
 
+	// Placement is where the bar goes: "left" replaces status-left, "right"
+	// prepends it to status-right, "window" appends it to the current window
+	// in the window list. Use right or window when status-left belongs to a
+	// plugin. (default: "left")
+	Placement string `toml:"placement,omitempty"`
+
+	// Order decides which sessions get the Ctrl+b number keys: "newest" lists
+	// the most recently waiting first and renumbers on every change;
+	// "priority" lists pinned sessions, then the longest-waiting, and keeps
+	// each session's key while it stays on the bar. (default: "newest")
+	Order string `toml:"order,omitempty"`
+
+	// Format is the template for each session in the bar, for status-left
+	// setups the built-in "[1] title" clashes with. Placeholders: {index}
+	// (the Ctrl+b key), {icon}, {title}, {tool}, {status}. Default: built-in.
+	Format string `toml:"format,omitempty"`
+
+	// Prefix leads the bar, minimal mode included (default: "⚡ "; "" drops it)
+	Prefix *string `toml:"prefix,omitempty"`
+
+	// Separator goes between sessions in the bar (default: " ")
+	Separator *string `toml:"separator,omitempty"`
+
+	// MaxTitleLength truncates session titles in the bar with "…" (default: 0 = no limit)
+	MaxTitleLength int `toml:"max_title_length,omitzero"`
+
 	// TransitionEvents controls whether the transition daemon sends tmux messages
 	// to parent sessions when a child transitions (e.g., running → waiting).
 	// Default: true (nil = true). Set to false to suppress dispatch globally.
 	// Per-session override: Instance.NoTransitionNotify
 	TransitionEvents *bool `toml:"transition_events,omitempty"`
+
+	// DNDDefaultMinutes is how long `agent-deck dnd on` silences alerts when
+	// no --for duration is given (default: 60). See dnd.go.
+	DNDDefaultMinutes int `toml:"dnd_default_minutes,omitzero"`
+
+	// EscalateAfterMinutes turns a session's wait time in the list bold red
+	// once it has been waiting this long (default: 0 = never)
+	EscalateAfterMinutes int `toml:"escalate_after_minutes,omitzero"`
+
+	// EscalateDesktop also sends one desktop notification per wait when it
+	// crosses EscalateAfterMinutes, even with [notifications.desktop] off.
+	// Uses [notifications.desktop].command when set. (default: false)
+	EscalateDesktop bool `toml:"escalate_desktop,omitempty"`
+
+	// Desktop sends an OS notification when a session you are not attached
+	// to turns waiting or errors. See desktop_notify.go.
+	Desktop DesktopNotificationsConfig `toml:"desktop,omitempty"`
 }
 
 // GetTransitionEventsEnabled returns whether transition event dispatch is enabled.
@@ -1023,6 +1299,41 @@ func (n NotificationsConfig) GetTransitionEventsEnabled() bool {
 	return *n.TransitionEvents
 }
 
+// GetEscalateAfter returns the wait escalation threshold; 0 means off.
+func (n NotificationsConfig) GetEscalateAfter() time.Duration {
+	if n.EscalateAfterMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(n.EscalateAfterMinutes) * time.Minute
+}
+
+// GetPlacement returns the parsed bar placement (default: left).
+func (n NotificationsConfig) GetPlacement() BarPlacement {
+	return ParseBarPlacement(n.Placement)
+}
+
+// GetOrder returns the parsed key order (default: newest).
+func (n NotificationsConfig) GetOrder() BarOrder {
+	return ParseBarOrder(n.Order)
+}
+
+// GetBarFormat returns the notification bar format, with the built-in prefix
+// and separator standing in for unset ones.
+func (n NotificationsConfig) GetBarFormat() BarFormat {
+	f := DefaultBarFormat()
+	f.Entry = n.Format
+	if n.Prefix != nil {
+		f.Prefix = *n.Prefix
+	}
+	if n.Separator != nil {
+		f.Separator = *n.Separator
+	}
+	if n.MaxTitleLength > 0 {
+		f.MaxTitle = n.MaxTitleLength
+	}
+	return f
+}
+
 // InstanceSettings configures multiple agent-deck instance behavior
 type InstanceSettings struct {
 	// AllowMultiple allows running multiple agent-deck TUI instances for the same profile.
@@ -1151,6 +1462,22 @@ func (p *PreviewSettings) GetShowNotes() bool {
 	return *p.ShowNotes
 }
 
+// GetHighlightNewOutput returns whether unseen output is highlighted, defaulting to true.
+func (p *PreviewSettings) GetHighlightNewOutput() bool {
+	if p.HighlightNewOutput == nil {
+		return true
+	}
+	return *p.HighlightNewOutput
+}
+
+// GetCopyLines returns how many pane lines copy-pane copies, defaulting to 200.
+func (p *PreviewSettings) GetCopyLines() int {
+	if p.CopyLines <= 0 {
+		return 200
+	}
+	return p.CopyLines
+}
+
 // GetNotesOutputSplit returns notes/output split ratio, clamped to sane bounds.
 func (p *PreviewSettings) GetNotesOutputSplit() float64 {
 	if p.NotesOutputSplit <= 0 {
@@ -1205,6 +1532,22 @@ func (a *AnalyticsDisplaySettings) GetShowCost() bool {
 	return *a.ShowCost
 }
 
+// GetShowSubagents returns whether to list sub-agents, defaulting to true
+func (a *AnalyticsDisplaySettings) GetShowSubagents() bool {
+	if a.ShowSubagents == nil {
+		return true // Default: ON - empty for sessions without sub-agents
+	}
+	return *a.ShowSubagents
+}
+
+// GetShowFilesTouched returns whether to list touched files, defaulting to true
+func (a *AnalyticsDisplaySettings) GetShowFilesTouched() bool {
+	if a.ShowFilesTouched == nil {
+		return true // Default: ON - empty until the session changes a file
+	}
+	return *a.ShowFilesTouched
+}
+
 // GetShowOutput returns whether to show terminal output in preview
 func (c *UserConfig) GetShowOutput() bool {
 	return c.Preview.GetShowOutput()
@@ -1230,11 +1573,11 @@ func (c *UserConfig) GetSyncTitle() bool {
 	return *c.SyncTitle
 }
 
-// GetGroupSort returns the normalized within-group sort mode: "actionable" only
-// when explicitly set, otherwise "creation" (the default).
+// GetGroupSort returns the normalized within-group sort mode: the configured
+// value when it is a known mode, otherwise "creation" (the default).
 func (c *UserConfig) GetGroupSort() string {
-	if c.GroupSort == "actionable" {
-		return "actionable"
+	if isGroupSortMode(c.GroupSort) {
+		return c.GroupSort
 	}
 	return "creation"
 }
@@ -1351,6 +1694,37 @@ func (c *UserConfig) GetGroupClaudeConfigDir(groupPath string) string {
 	return ""
 }
 
+// WebPrivateGroups returns the group paths marked web_private, sorted.
+// Subgroups of a listed path are private too; see IsGroupWebPrivate.
+func (c *UserConfig) WebPrivateGroups() []string {
+	if c == nil || c.Groups == nil {
+		return nil
+	}
+	var paths []string
+	for p, g := range c.Groups {
+		if g.WebPrivate {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// IsGroupWebPrivate reports whether groupPath or any ancestor group is marked
+// web_private. A private parent hides its whole subtree so nesting a group
+// cannot accidentally expose it.
+func (c *UserConfig) IsGroupWebPrivate(groupPath string) bool {
+	if c == nil || groupPath == "" || c.Groups == nil {
+		return false
+	}
+	for p := groupPath; p != ""; p = getParentPath(p) {
+		if groupCfg, ok := c.Groups[p]; ok && groupCfg.WebPrivate {
+			return true
+		}
+	}
+	return false
+}
+
 // GetGroupClaudeEnvFile returns the group-specific Claude env file, walking
 // ancestor groups when the exact path has no override. Mirrors
 // GetGroupClaudeConfigDir's inheritance semantics so nested groups don't
@@ -1779,6 +2153,11 @@ type WorktreeSettings struct {
 	// AutoCleanup: remove worktree when session is deleted (default: true, nil = true)
 	AutoCleanup *bool `toml:"auto_cleanup,omitempty"`
 
+	// DeleteBranchOnCleanup also deletes the worktree's branch when the
+	// worktree is removed on session delete. Only fully merged branches are
+	// deleted; unmerged work is kept. Default: false
+	DeleteBranchOnCleanup bool `toml:"delete_branch_on_cleanup,omitempty"`
+
 	// DefaultEnabled controls whether worktree creation is pre-selected in
 	// new-session and fork dialogs by default.
 	// Default: false
@@ -1895,6 +2274,10 @@ type GlobalSearchSettings struct {
 	// IndexRateLimit limits files indexed per second during background indexing
 	// Lower = less CPU impact (default: 20)
 	IndexRateLimit int `toml:"index_rate_limit,omitzero"`
+
+	// PersistIndex saves the Claude index to the cache dir so startup only
+	// re-parses transcripts that changed (default: true)
+	PersistIndex *bool `toml:"persist_index,omitempty"`
 }
 
 func (g GlobalSearchSettings) GetEnabled() bool {
@@ -1904,9 +2287,20 @@ func (g GlobalSearchSettings) GetEnabled() bool {
 	return *g.Enabled
 }
 
+// GetPersistIndex returns whether the index is persisted, defaulting to true.
+func (g GlobalSearchSettings) GetPersistIndex() bool {
+	if g.PersistIndex == nil {
+		return true
+	}
+	return *g.PersistIndex
+}
+
 // ToolDef defines a custom AI tool
 type ToolDef struct {
-	// Command is the shell command to run
+	// Command is the shell command to run. It may use the {path} and {title}
+	// placeholders, replaced (shell-quoted) with the session's project path
+	// and title at launch.
+	// Example: command = "my-ai --workspace {path} --name {title}"
 	Command string `toml:"command,omitempty"`
 
 	// CompatibleWith opts this tool into compatibility behavior for a built-in
@@ -1923,18 +2317,33 @@ type ToolDef struct {
 	// Icon is the emoji/symbol to display
 	Icon string `toml:"icon,omitempty"`
 
+	// Color is the badge color for the tool name in the session list: a hex
+	// value ("#ff8800") or an ANSI color number ("208").
+	Color string `toml:"color,omitempty"`
+
 	// BusyPatterns are strings that indicate the tool is busy
 	BusyPatterns []string `toml:"busy_patterns,omitempty"`
 
 	// PromptPatterns are strings that indicate the tool is waiting for input
 	PromptPatterns []string `toml:"prompt_patterns,omitempty"`
 
+	// ReadyRegex is a regex matching the tool's input prompt (e.g. `^my-ai> $`).
+	// It is added to the prompt patterns, so the session shows as waiting
+	// whenever it matches the bottom of the pane.
+	ReadyRegex string `toml:"ready_regex,omitempty"`
+
 	// DetectPatterns are regex patterns to auto-detect this tool from terminal content
 	DetectPatterns []string `toml:"detect_patterns,omitempty"`
 
 	// ResumeFlag is the CLI flag to resume a session (e.g., "--resume")
 	ResumeFlag string `toml:"resume_flag,omitempty"`
 
+	// ResumeCommand is a template for resuming a session when a flag after the
+	// command is not enough. {command} is the tool command and {session_id}
+	// the captured session ID. Takes precedence over ResumeFlag.
+	// Example: resume_command = "{command} sessions open {session_id}"
+	ResumeCommand string `toml:"resume_command,omitempty"`
+
 	// SessionIDEnv is the tmux environment variable name storing the session ID
 	SessionIDEnv string `toml:"session_id_env,omitempty"`
 
@@ -1976,6 +2385,21 @@ type ToolDef struct {
 	SpinnerCharsExtra []string `toml:"spinner_chars_extra,omitempty"`
 }
 
+// SupportsResume reports whether sessions of this tool can be resumed: a
+// resume flag or template, plus the tmux env var carrying the session ID.
+func (t *ToolDef) SupportsResume() bool {
+	return (t.ResumeFlag != "" || t.ResumeCommand != "") && t.SessionIDEnv != ""
+}
+
+// ResumeInvocation returns the command that resumes sessionID: the
+// resume_command template when set, otherwise "<command> <resume_flag> <id>".
+func (t *ToolDef) ResumeInvocation(command, sessionID string) string {
+	if t.ResumeCommand != "" {
+		return strings.NewReplacer("{command}", command, "{session_id}", sessionID).Replace(t.ResumeCommand)
+	}
+	return fmt.Sprintf("%s %s %s", command, t.ResumeFlag, sessionID)
+}
+
 // HTTPServerConfig defines how to auto-start an HTTP MCP server
 type HTTPServerConfig struct {
 	// Command is the executable to run (e.g., "uvx", "python", "node")
@@ -2116,6 +2540,9 @@ func (p *PluginDef) ChannelID() string {
 //	[tmux]
 //	inject_status_line = false
 //	options = { "allow-passthrough" = "all", "history-limit" = "50000" }
+//
+// Groups and individual sessions can layer their own options and hooks on
+// top (see TmuxOverrideSettings and ResolveSessionTmuxOverrides).
 type TmuxSettings struct {
 	// InjectStatusLine controls whether agent-deck injects a custom status line
 	// into new tmux sessions. When false, the tmux status bar is not modified,
@@ -2197,6 +2624,18 @@ type TmuxSettings struct {
 	// These are passed to `tmux set-option -t <session>` after defaults.
 	Options map[string]string `toml:"options,omitempty"`
 
+	// Hooks is a map of tmux hook names to commands, installed with
+	// `tmux set-hook -t <session>` after options.
+	// Example: hooks = { "pane-died" = "run-shell 'notify-send died'" }
+	Hooks map[string]string `toml:"hooks,omitempty"`
+
+	// Sessions holds per-session option/hook overrides keyed by session ID
+	// or title. Applied on top of [tmux] and [groups."<path>".tmux].
+	// Example:
+	// [tmux.sessions."heavy-build".options]
+	// history-limit = "200000"
+	Sessions map[string]TmuxOverrideSettings `toml:"sessions,omitempty"`
+
 	// SocketName is the tmux `-L <name>` socket selector for every
 	// agent-deck tmux spawn (v1.7.50+, issue #687). Empty string — the
 	// default — keeps pre-v1.7.50 behavior byte-for-byte: agent-deck shares
@@ -2222,6 +2661,68 @@ type TmuxSettings struct {
 	SocketName string `toml:"socket_name,omitempty"`
 }
 
+// TmuxOverrideSettings is the options/hooks pair accepted by
+// [groups."<path>".tmux] and [tmux.sessions."<id-or-title>"]. Keys use the
+// same semantics as TmuxSettings.Options / TmuxSettings.Hooks.
+type TmuxOverrideSettings struct {
+	Options map[string]string `toml:"options,omitempty"`
+	Hooks   map[string]string `toml:"hooks,omitempty"`
+}
+
+// ResolveSessionTmuxOverrides returns the merged tmux options and hooks for a
+// session. Layers apply lowest-precedence first so later layers win per key:
+// global [tmux], group ancestors root-first, then [tmux.sessions] by title and
+// finally by ID (the ID is the stable handle, so it beats a title match).
+// Both maps are freshly allocated; either is nil when no layer sets it.
+func (c *UserConfig) ResolveSessionTmuxOverrides(id, title, groupPath string) (options, hooks map[string]string) {
+	if c == nil {
+		return nil, nil
+	}
+	layers := []TmuxOverrideSettings{{Options: c.Tmux.Options, Hooks: c.Tmux.Hooks}}
+
+	var groupChain []TmuxOverrideSettings
+	for p := groupPath; p != "" && c.Groups != nil; p = getParentPath(p) {
+		if groupCfg, ok := c.Groups[p]; ok {
+			groupChain = append(groupChain, groupCfg.Tmux)
+		}
+	}
+	for idx := len(groupChain) - 1; idx >= 0; idx-- {
+		layers = append(layers, groupChain[idx])
+	}
+
+	if title != "" && title != id {
+		if sess, ok := c.Tmux.Sessions[title]; ok {
+			layers = append(layers, sess)
+		}
+	}
+	if id != "" {
+		if sess, ok := c.Tmux.Sessions[id]; ok {
+			layers = append(layers, sess)
+		}
+	}
+
+	for _, layer := range layers {
+		options = mergeStringMap(options, layer.Options)
+		hooks = mergeStringMap(hooks, layer.Hooks)
+	}
+	return options, hooks
+}
+
+// mergeStringMap copies src onto dst (allocating dst on first use) and
+// returns it.
+func mergeStringMap(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
 // GetInjectStatusLine returns whether to inject status line, defaulting to true.
 func (t TmuxSettings) GetInjectStatusLine() bool {
 	if t.InjectStatusLine == nil {
@@ -2548,6 +3049,79 @@ type MaintenanceSettings struct {
 	Enabled bool `toml:"enabled,omitempty"`
 }
 
+// ArchiveSettings controls automatic archival of idle sessions. An archived
+// session has its tmux session killed but keeps its tool session ID, so
+// unarchiving and restarting resumes the same conversation.
+type ArchiveSettings struct {
+	// IdleAfter archives sessions with no activity for this long. Go
+	// duration syntax plus a "d" day suffix: "72h", "7d". Empty or "0"
+	// disables auto-archive (default).
+	IdleAfter string `toml:"idle_after,omitempty"`
+
+	// IncludeWaiting also archives sessions that sit in the waiting state
+	// (default: false — a waiting session is asking for the user).
+	IncludeWaiting bool `toml:"include_waiting,omitempty"`
+}
+
+// GetIdleAfter returns the parsed idle threshold, or 0 when auto-archive is
+// disabled or the value does not parse.
+func (a ArchiveSettings) GetIdleAfter() time.Duration {
+	d, err := ParseArchiveIdleAfter(a.IdleAfter)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// WatchdogSettings flags sessions that keep running without producing new
+// output, e.g. an agent stuck on a hung command all night. The TUI marks such
+// a session stuck, notifies, and optionally interrupts it.
+type WatchdogSettings struct {
+	// StuckAfter marks a running session stuck after this long without new
+	// pane output. Go duration syntax: "30m", "2h". Empty or "0" disables the
+	// watchdog (default).
+	StuckAfter string `toml:"stuck_after,omitempty"`
+
+	// Notify sends a desktop notification when a session is marked stuck
+	// (default: true, nil = true)
+	Notify *bool `toml:"notify,omitempty"`
+
+	// Action is sent to a stuck session once per stall: "escape",
+	// "interrupt" (Ctrl+C), or "" to only flag it (default)
+	Action string `toml:"action,omitempty"`
+}
+
+// GetStuckAfter returns the parsed stuck threshold, or 0 when the watchdog
+// is disabled or the value does not parse.
+func (w WatchdogSettings) GetStuckAfter() time.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(w.StuckAfter))
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// GetNotify returns whether stuck sessions notify. Defaults to true.
+func (w WatchdogSettings) GetNotify() bool {
+	if w.Notify == nil {
+		return true
+	}
+	return *w.Notify
+}
+
+// ActionKey returns the tmux key name the watchdog sends a stuck session,
+// or "" when it only flags it (unset or unknown action).
+func (w WatchdogSettings) ActionKey() string {
+	switch strings.ToLower(strings.TrimSpace(w.Action)) {
+	case "escape":
+		return "Escape"
+	case "interrupt":
+		return "C-c"
+	default:
+		return ""
+	}
+}
+
 // DisplaySettings controls TUI rendering behavior.
 type DisplaySettings struct {
 	// FullRepaint forces a full screen clear on every render cycle instead of
@@ -2593,6 +3167,17 @@ type DisplaySettings struct {
 	// every session row, not just the selected one. Default: false — opt-in to
 	// avoid crowding narrow sidebars. See renderSessionItem for the source.
 	ShowPaneTitles bool `toml:"show_pane_titles,omitempty"`
+
+	// ShowGitStatus appends a compact git badge ("±3 ↑2 ↓1": changed paths,
+	// commits ahead of / behind upstream) to rows whose project or worktree
+	// path is a git checkout. Default true; refreshed by the background
+	// worker at most every 15s per checkout.
+	ShowGitStatus *bool `toml:"show_git_status,omitempty"`
+
+	// GroupExpansion sets which groups are expanded when the TUI opens.
+	// Valid values: "saved" (default: the state left last time), "expanded",
+	// "collapsed", "waiting" (only groups holding a waiting session).
+	GroupExpansion string `toml:"group_expansion,omitempty"`
 }
 
 // GetActiveFilterExcludes returns the resolved set of statuses the % filter
@@ -2640,6 +3225,16 @@ func (d DisplaySettings) GetDefaultFilter() string {
 	return ""
 }
 
+// GetGroupExpansion returns the validated group_expansion value, falling back
+// to "saved" on empty or invalid input.
+func (d DisplaySettings) GetGroupExpansion() string {
+	switch d.GroupExpansion {
+	case "expanded", "collapsed", "waiting":
+		return d.GroupExpansion
+	}
+	return "saved"
+}
+
 // GetFullRepaint returns whether full-repaint mode is active, checking
 // the env var AGENTDECK_REPAINT=full as an override.
 func (d DisplaySettings) GetFullRepaint() bool {
@@ -2658,6 +3253,15 @@ func (d DisplaySettings) GetIncludeCwdPrefix() bool {
 	return *d.IncludeCwdPrefix
 }
 
+// GetShowGitStatus returns whether session rows show git status badges
+// (default: true).
+func (d DisplaySettings) GetShowGitStatus() bool {
+	if d.ShowGitStatus == nil {
+		return true
+	}
+	return *d.ShowGitStatus
+}
+
 // Default user config (empty maps)
 var defaultUserConfig = UserConfig{
 	Tools:   make(map[string]ToolDef),
@@ -3219,6 +3823,10 @@ func GetToolCommand(toolName string) string {
 		if config.Hermes.Command != "" {
 			return config.Hermes.Command
 		}
+	case "aider":
+		if config.Aider.Command != "" {
+			return config.Aider.Command
+		}
 	}
 	return toolName
 }
@@ -3261,6 +3869,15 @@ func GetToolIcon(toolName string) string {
 	}
 }
 
+// GetToolColor returns the configured badge color for a custom tool, or ""
+// when it has none (built-ins use the theme's tool colors).
+func GetToolColor(toolName string) string {
+	if def := GetToolDef(toolName); def != nil {
+		return def.Color
+	}
+	return ""
+}
+
 // GetToolBusyPatterns returns busy patterns for a tool (custom + built-in)
 func GetToolBusyPatterns(toolName string) []string {
 	var patterns []string
@@ -3297,13 +3914,19 @@ func MergeToolPatterns(toolName string) *tmux.RawPatterns {
 		}
 	}
 
-	// Build extras from ToolDef's *Extra fields
+	// Build extras from ToolDef's *Extra fields and ready_regex
 	var extras *tmux.RawPatterns
 	if toolDef != nil &&
-		(len(toolDef.BusyPatternsExtra) > 0 || len(toolDef.PromptPatternsExtra) > 0 || len(toolDef.SpinnerCharsExtra) > 0) {
+		(len(toolDef.BusyPatternsExtra) > 0 || len(toolDef.PromptPatternsExtra) > 0 ||
+			len(toolDef.SpinnerCharsExtra) > 0 || toolDef.ReadyRegex != "") {
+		prompts := append([]string(nil), toolDef.PromptPatternsExtra...)
+		if toolDef.ReadyRegex != "" {
+			// (?m) so ^/$ anchor to the prompt line, not the whole pane.
+			prompts = append(prompts, "re:(?m)"+toolDef.ReadyRegex)
+		}
 		extras = &tmux.RawPatterns{
 			BusyPatterns:   toolDef.BusyPatternsExtra,
-			PromptPatterns: toolDef.PromptPatternsExtra,
+			PromptPatterns: prompts,
 			SpinnerChars:   toolDef.SpinnerCharsExtra,
 		}
 	}
@@ -3336,10 +3959,12 @@ func GetWebMutationsEnabled() bool {
 //
 // Merge order (issue #434):
 //  1. Start from the `[hotkeys]` table.
-//  2. If `[tmux].detach_key` is set AND the caller has not already set
-//     `[hotkeys].detach`, layer tmux.detach_key into the hotkeys map as the
-//     "detach" action. Explicit `[hotkeys].detach` always wins so there is
-//     exactly one authoritative source of truth when both are present.
+//  2. Layer the `[keys]` alias table on top; it wins for the same action.
+//  3. If `[tmux].detach_key` is set AND the caller has not already set
+//     `[hotkeys].detach` (or `[keys].detach`), layer tmux.detach_key into the
+//     hotkeys map as the "detach" action. Explicit `[hotkeys].detach` always
+//     wins so there is exactly one authoritative source of truth when both
+//     are present.
 //
 // Returns nil only when nothing is configured in either table.
 func GetHotkeyOverrides() map[string]string {
@@ -3348,10 +3973,13 @@ func GetHotkeyOverrides() map[string]string {
 		return nil
 	}
 
-	out := make(map[string]string, len(config.Hotkeys)+1)
+	out := make(map[string]string, len(config.Hotkeys)+len(config.Keys)+1)
 	for action, key := range config.Hotkeys {
 		out[action] = key
 	}
+	for action, key := range config.Keys {
+		out[action] = key
+	}
 
 	if tmuxKey := strings.TrimSpace(config.Tmux.DetachKey); tmuxKey != "" {
 		if _, alreadySet := out[hotkeyDetachAction]; !alreadySet {
@@ -3370,52 +3998,39 @@ func GetHotkeyOverrides() map[string]string {
 // cycle (session <- ui). If the UI constant ever changes, update here too.
 const hotkeyDetachAction = "detach"
 
-// GetTheme returns the current theme, defaulting to "dark"
+// GetTheme returns the configured theme: a built-in name, a [themes.<name>]
+// palette, or "system". Anything else (including unset) is "dark".
 func GetTheme() string {
 	config, err := LoadUserConfig()
 	if err != nil || config == nil {
 		return "dark"
 	}
-	switch config.Theme {
-	case "dark", "light", "system":
+	if config.Theme == "system" || IsBuiltinTheme(config.Theme) {
+		return config.Theme
+	}
+	if _, ok := config.Themes[config.Theme]; ok && config.Theme != "" {
 		return config.Theme
-	default:
-		return "dark"
 	}
+	return "dark"
 }
 
-// ResolveTheme resolves the configured theme to "dark" or "light".
-// If theme is "system", detects the OS dark mode setting.
-// Falls back to "dark" on detection failure.
-func ResolveTheme() string {
+// ResolveThemeName resolves the configured theme to the concrete palette the
+// TUI should draw with. "system" becomes "dark" or "light" from the
+// terminal/OS appearance; other names pass through GetTheme unchanged.
+func ResolveThemeName() string {
 	theme := GetTheme()
-	if theme != "system" {
-		return theme
-	}
-	// Check the terminal's own declaration before asking the OS.
-	// COLORFGBG is set by iTerm2 and other terminals; format is "fg;bg"
-	// where bg < 8 means a dark background. This catches the common case
-	// where macOS is in light mode but the terminal profile is dark.
-	if colorfgbg := os.Getenv("COLORFGBG"); colorfgbg != "" {
-		if idx := strings.LastIndex(colorfgbg, ";"); idx >= 0 {
-			var bg int
-			if _, err := fmt.Sscanf(colorfgbg[idx+1:], "%d", &bg); err == nil {
-				if bg < 8 {
-					return "dark"
-				}
-				return "light"
-			}
-		}
+	if theme == "system" {
+		return detectSystemTheme()
 	}
+	return theme
+}
 
-	isDark, err := dark.IsDarkMode()
-	if err != nil {
-		return "dark"
-	}
-	if isDark {
-		return "dark"
-	}
-	return "light"
+// ResolveTheme resolves the configured theme to its background brightness,
+// "dark" or "light" (e.g. "solarized-light" is "light").
+// If theme is "system", detects the OS dark mode setting.
+// Falls back to "dark" on detection failure.
+func ResolveTheme() string {
+	return ThemeBrightness(ResolveThemeName())
 }
 
 // GetRemoveOrphans returns whether orphan log removal is enabled (default: true).
@@ -3620,6 +4235,44 @@ func GetMaintenanceSettings() MaintenanceSettings {
 	return config.Maintenance
 }
 
+// GetArchiveSettings returns auto-archive settings (disabled by default).
+func GetArchiveSettings() ArchiveSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return ArchiveSettings{}
+	}
+	return config.Archive
+}
+
+// GetWatchdogSettings returns stuck-session watchdog settings (disabled by
+// default).
+func GetWatchdogSettings() WatchdogSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return WatchdogSettings{}
+	}
+	return config.Watchdog
+}
+
+// GetTranscriptSettings returns transcript settings (disabled by default).
+func GetTranscriptSettings() TranscriptSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return TranscriptSettings{}
+	}
+	return config.Transcripts
+}
+
+// GetTrashSettings returns deleted-session trash settings (enabled, 30-day
+// retention by default).
+func GetTrashSettings() TrashSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return TrashSettings{}
+	}
+	return config.Trash
+}
+
 // GetStatusSettings returns status detection settings with defaults applied.
 func GetStatusSettings() StatusSettings {
 	config, err := LoadUserConfig()
@@ -3687,6 +4340,44 @@ func (t TerminalSettings) GetITermBadge() bool {
 	return *t.ITermBadge
 }
 
+// Attach modes for [attach] mode.
+const (
+	AttachModeExec   = "exec"
+	AttachModeWindow = "window"
+	AttachModePane   = "pane"
+)
+
+// AttachSettings controls where Enter opens a session.
+type AttachSettings struct {
+	// Mode is "exec" (default: the session takes over this terminal until
+	// you detach), "window" (a new window of the tmux session agent-deck
+	// runs in) or "pane" (a pane split beside the dashboard, which stays
+	// visible). "window" and "pane" only apply when agent-deck itself runs
+	// inside tmux; otherwise attaching falls back to "exec".
+	Mode string `toml:"mode,omitempty"`
+
+	// Split is where a "pane" attach opens: "right" (default) or "below".
+	Split string `toml:"split,omitempty"`
+}
+
+// GetMode returns the configured attach mode, defaulting to "exec" for empty
+// or unknown values. Matching is case-insensitive.
+func (a AttachSettings) GetMode() string {
+	switch mode := strings.ToLower(strings.TrimSpace(a.Mode)); mode {
+	case AttachModeWindow, AttachModePane:
+		return mode
+	}
+	return AttachModeExec
+}
+
+// GetSplit returns "below" when configured, otherwise "right".
+func (a AttachSettings) GetSplit() string {
+	if strings.EqualFold(strings.TrimSpace(a.Split), "below") {
+		return "below"
+	}
+	return "right"
+}
+
 // GetTerminalSettings returns terminal-chrome settings from config.
 func GetTerminalSettings() TerminalSettings {
 	config, err := LoadUserConfig()
@@ -3696,6 +4387,16 @@ func GetTerminalSettings() TerminalSettings {
 	return config.Terminal
 }
 
+// GetAttachSettings returns the [attach] settings (zero value when config is
+// missing, which resolves to the "exec" mode).
+func GetAttachSettings() AttachSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return AttachSettings{}
+	}
+	return config.Attach
+}
+
 // GetInstanceSettings returns instance behavior settings
 func GetInstanceSettings() InstanceSettings {
 	config, err := LoadUserConfig()
@@ -4350,20 +5051,28 @@ func ResolveCostLineTemplate(cfg *UserConfig, profile string) (template string,
 	return
 }
 
+// BudgetSettings configures [costs.budgets]. USD limits are dollars; token
+// limits count input + output + cache tokens. Crossing 80% of any budget
+// shows a warning in the TUI status bar, 100% an over-budget alert.
 type BudgetSettings struct {
-	DailyLimit   float64                  `toml:"daily_limit,omitzero"`
-	WeeklyLimit  float64                  `toml:"weekly_limit,omitzero"`
-	MonthlyLimit float64                  `toml:"monthly_limit,omitzero"`
-	Groups       map[string]GroupBudget   `toml:"groups,omitempty"`
-	Sessions     map[string]SessionBudget `toml:"sessions,omitempty"`
+	DailyLimit      float64                  `toml:"daily_limit,omitzero"`
+	WeeklyLimit     float64                  `toml:"weekly_limit,omitzero"`
+	MonthlyLimit    float64                  `toml:"monthly_limit,omitzero"`
+	DailyTokenLimit int64                    `toml:"daily_token_limit,omitzero"`
+	Groups          map[string]GroupBudget   `toml:"groups,omitempty"`
+	Sessions        map[string]SessionBudget `toml:"sessions,omitempty"` // keyed by session ID or title
 }
 
+// GroupBudget is a per-day budget for a group path, including its subgroups.
 type GroupBudget struct {
-	DailyLimit float64 `toml:"daily_limit,omitzero"`
+	DailyLimit      float64 `toml:"daily_limit,omitzero"`
+	DailyTokenLimit int64   `toml:"daily_token_limit,omitzero"`
 }
 
+// SessionBudget is a lifetime budget for one session.
 type SessionBudget struct {
-	TotalLimit float64 `toml:"total_limit,omitzero"`
+	TotalLimit      float64 `toml:"total_limit,omitzero"`
+	TotalTokenLimit int64   `toml:"total_token_limit,omitzero"`
 }
 
 type PricingSettings struct {
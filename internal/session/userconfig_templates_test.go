@@ -0,0 +1,108 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeTemplateConfig(t *testing.T, doc string) UserConfig {
+	t.Helper()
+	var cfg UserConfig
+	if _, err := toml.Decode(doc, &cfg); err != nil {
+		t.Fatalf("toml.Decode: %v", err)
+	}
+	return cfg
+}
+
+func TestTemplates_DecodeAndLookup(t *testing.T) {
+	cfg := decodeTemplateConfig(t, `
+[templates.review]
+description = "PR review"
+tool = "claude"
+group = "reviews"
+mcps = ["github", "memory"]
+worktree = "on"
+dangerous_mode = true
+extra_args = ["--agent", "reviewer"]
+
+[templates.spike]
+tool = "codex"
+yolo = true
+sandbox = false
+`)
+	assert.Equal(t, []string{"review", "spike"}, cfg.TemplateNames())
+
+	review, ok := cfg.GetTemplate("review")
+	require.True(t, ok)
+	assert.Equal(t, "claude", review.Tool)
+	assert.Equal(t, "reviews", review.Group)
+	assert.Equal(t, []string{"github", "memory"}, review.MCPs)
+	assert.Equal(t, "on", review.GetWorktree())
+	assert.True(t, review.HasClaudeOverrides())
+
+	spike, ok := cfg.GetTemplate("spike")
+	require.True(t, ok)
+	require.NotNil(t, spike.Sandbox)
+	assert.False(t, *spike.Sandbox)
+	assert.False(t, spike.HasClaudeOverrides())
+
+	_, ok = cfg.GetTemplate("missing")
+	assert.False(t, ok)
+}
+
+func TestTemplates_NilConfigIsEmpty(t *testing.T) {
+	var cfg *UserConfig
+	assert.Nil(t, cfg.TemplateNames())
+	_, ok := cfg.GetTemplate("any")
+	assert.False(t, ok)
+}
+
+func TestSessionTemplate_GetWorktree_Canonicalizes(t *testing.T) {
+	cases := map[string]string{
+		"ON":    "on",
+		" off ": "off",
+		"true":  "on",
+		"no":    "off",
+		"":      "",
+		"bogus": "",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, SessionTemplate{Worktree: in}.GetWorktree(), "worktree=%q", in)
+	}
+}
+
+func TestSessionTemplate_ApplyClaudeOptions_OnlyOverridesSetKeys(t *testing.T) {
+	on, off := true, false
+	opts := &ClaudeOptions{SkipPermissions: false, UseChrome: true, Model: "sonnet"}
+	SessionTemplate{DangerousMode: &on, UseTeammateMode: &off}.ApplyClaudeOptions(opts)
+
+	assert.True(t, opts.SkipPermissions)
+	assert.True(t, opts.UseChrome, "unset use_chrome keeps the inherited value")
+	assert.False(t, opts.UseTeammateMode)
+	assert.Equal(t, "sonnet", opts.Model, "empty template model keeps the inherited value")
+
+	SessionTemplate{Model: "opus"}.ApplyClaudeOptions(opts)
+	assert.Equal(t, "opus", opts.Model)
+}
+
+func TestMCPSets_DecodeAndLookup(t *testing.T) {
+	cfg := decodeTemplateConfig(t, `
+[mcp_sets]
+web-dev = ["playwright", "github", "slack"]
+data = ["memory"]
+`)
+	assert.Equal(t, []string{"data", "web-dev"}, cfg.MCPSetNames())
+
+	members, ok := cfg.GetMCPSet("web-dev")
+	require.True(t, ok)
+	assert.Equal(t, []string{"playwright", "github", "slack"}, members)
+
+	_, ok = cfg.GetMCPSet("missing")
+	assert.False(t, ok)
+
+	var nilCfg *UserConfig
+	assert.Nil(t, nilCfg.MCPSetNames())
+}
@@ -42,6 +42,23 @@ func TestDisplaySettings_GetIncludeCwdPrefix(t *testing.T) {
 	}
 }
 
+func TestDisplaySettings_GetGroupExpansion(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"", "saved"},
+		{"saved", "saved"},
+		{"expanded", "expanded"},
+		{"collapsed", "collapsed"},
+		{"waiting", "waiting"},
+		{"bogus", "saved"},
+	}
+	for _, c := range cases {
+		d := DisplaySettings{GroupExpansion: c.in}
+		if got := d.GetGroupExpansion(); got != c.want {
+			t.Errorf("GetGroupExpansion(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
 func TestDisplaySettings_IncludeCwdPrefix_TOML(t *testing.T) {
 	var cfg UserConfig
 	if _, err := toml.Decode("[display]\ninclude_cwd_prefix = false\n", &cfg); err != nil {
@@ -653,6 +670,55 @@ func TestGetTheme_Light(t *testing.T) {
 	}
 }
 
+func TestGetTheme_CustomPalette(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	isolateConfigHomeXDG(t)
+
+	agentDeckDir := filepath.Join(tempDir, ".agent-deck")
+	_ = os.MkdirAll(agentDeckDir, 0700)
+	content := `theme = "paper"
+
+[themes.paper]
+base = "solarized-light"
+bg = "#ffffff"
+`
+	if err := os.WriteFile(filepath.Join(agentDeckDir, "config.toml"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	ClearUserConfigCache()
+	t.Cleanup(ClearUserConfigCache)
+
+	if got := GetTheme(); got != "paper" {
+		t.Errorf("GetTheme() = %q, want %q", got, "paper")
+	}
+	if got := ResolveThemeName(); got != "paper" {
+		t.Errorf("ResolveThemeName() = %q, want %q", got, "paper")
+	}
+	if got := ResolveTheme(); got != "light" {
+		t.Errorf("ResolveTheme() = %q, want light (base solarized-light)", got)
+	}
+	p, ok := GetCustomTheme("paper")
+	if !ok || p.Bg != "#ffffff" {
+		t.Errorf("GetCustomTheme(paper) = %+v, %v", p, ok)
+	}
+}
+
+func TestThemeBrightness_Builtins(t *testing.T) {
+	tests := map[string]string{
+		"dark":            "dark",
+		"light":           "light",
+		"solarized-dark":  "dark",
+		"solarized-light": "light",
+		"high-contrast":   "dark",
+	}
+	for name, want := range tests {
+		if got := ThemeBrightness(name); got != want {
+			t.Errorf("ThemeBrightness(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
 func TestResolveTheme_COLORFGBGOverridesOS(t *testing.T) {
 	// Setup: explicit "system" theme so ResolveTheme falls through to
 	// auto-detection where COLORFGBG should be checked.
@@ -970,6 +1036,9 @@ func TestPreviewSettingsDefaults(t *testing.T) {
 	if cfg.GetShowNotes() {
 		t.Error("GetShowNotes should default to false")
 	}
+	if got := cfg.Preview.GetCopyLines(); got != 200 {
+		t.Errorf("GetCopyLines should default to 200, got %d", got)
+	}
 }
 
 func TestPreviewSettingsExplicitTrue(t *testing.T) {
@@ -1287,6 +1356,67 @@ max_shown = 4
 	}
 }
 
+func TestNotificationsConfig_BarFormat(t *testing.T) {
+	var config UserConfig
+	if _, err := toml.Decode(`
+[notifications]
+placement = "right"
+format = "{icon} {title}"
+prefix = ""
+max_title_length = 12
+`, &config); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+
+	f := config.Notifications.GetBarFormat()
+	want := BarFormat{Entry: "{icon} {title}", Prefix: "", Separator: " ", MaxTitle: 12}
+	if f != want {
+		t.Errorf("GetBarFormat() = %+v, want %+v", f, want)
+	}
+	if p := config.Notifications.GetPlacement(); p != BarPlacementRight {
+		t.Errorf("GetPlacement() = %q, want right", p)
+	}
+	if def := (NotificationsConfig{}).GetBarFormat(); def != DefaultBarFormat() {
+		t.Errorf("unset config should give the default format, got %+v", def)
+	}
+}
+
+func TestWatchdogSettings(t *testing.T) {
+	var config UserConfig
+	if _, err := toml.Decode(`
+[watchdog]
+stuck_after = "45m"
+notify = false
+action = "Interrupt"
+`, &config); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+
+	w := config.Watchdog
+	if got := w.GetStuckAfter(); got != 45*time.Minute {
+		t.Errorf("GetStuckAfter() = %v, want 45m", got)
+	}
+	if w.GetNotify() {
+		t.Error("notify = false should disable notifications")
+	}
+	if got := w.ActionKey(); got != "C-c" {
+		t.Errorf("ActionKey() = %q, want C-c", got)
+	}
+
+	var def WatchdogSettings
+	if def.GetStuckAfter() != 0 || !def.GetNotify() || def.ActionKey() != "" {
+		t.Errorf("unset watchdog should be off, notify, no action: %+v", def)
+	}
+	for _, bad := range []string{"0", "soon", "-5m"} {
+		if got := (WatchdogSettings{StuckAfter: bad}).GetStuckAfter(); got != 0 {
+			t.Errorf("GetStuckAfter(%q) = %v, want 0 (disabled)", bad, got)
+		}
+	}
+	if got := (WatchdogSettings{Action: "escape"}).ActionKey(); got != "Escape" {
+		t.Errorf("ActionKey(escape) = %q, want Escape", got)
+	}
+}
+
 func TestGetNotificationsSettings(t *testing.T) {
 	tempDir := t.TempDir()
 	originalHome := os.Getenv("HOME")
@@ -1595,6 +1725,38 @@ iterm_badge = true
 	}
 }
 
+func TestGetAttachSettings(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tempDir)
+	defer os.Setenv("HOME", originalHome)
+	ClearUserConfigCache()
+
+	if got := GetAttachSettings(); got.GetMode() != AttachModeExec || got.GetSplit() != "right" {
+		t.Errorf("defaults = (%q, %q), want (exec, right)", got.GetMode(), got.GetSplit())
+	}
+
+	agentDeckDir := filepath.Join(tempDir, ".agent-deck")
+	_ = os.MkdirAll(agentDeckDir, 0700)
+	configContent := `
+[attach]
+mode = "Pane"
+split = "below"
+`
+	if err := os.WriteFile(filepath.Join(agentDeckDir, "config.toml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	ClearUserConfigCache()
+
+	got := GetAttachSettings()
+	if got.GetMode() != AttachModePane || got.GetSplit() != "below" {
+		t.Errorf("configured = (%q, %q), want (pane, below)", got.GetMode(), got.GetSplit())
+	}
+	if mode := (AttachSettings{Mode: "tab"}).GetMode(); mode != AttachModeExec {
+		t.Errorf("unknown mode resolved to %q, want exec", mode)
+	}
+}
+
 func TestGetTmuxSettings_Mouse_Default(t *testing.T) {
 	// Default (no config) should return true — preserves pre-#730 behavior
 	tempDir := t.TempDir()
@@ -1964,6 +2126,26 @@ detach_key = "ctrl+d"
 `,
 			wantDetach: "ctrl+b",
 		},
+		{
+			name: "keys_alias_wins_over_hotkeys",
+			toml: `[hotkeys]
+detach = "ctrl+b"
+
+[keys]
+detach = "ctrl+x"
+`,
+			wantDetach: "ctrl+x",
+		},
+		{
+			name: "keys_alias_wins_over_tmux",
+			toml: `[keys]
+detach = "ctrl+x"
+
+[tmux]
+detach_key = "ctrl+d"
+`,
+			wantDetach: "ctrl+x",
+		},
 		{
 			name: "tmux_detach_key_whitespace_trimmed",
 			toml: `[tmux]
@@ -2592,6 +2774,10 @@ func TestUserConfig_GetGroupSort(t *testing.T) {
 		{"", "creation"},
 		{"creation", "creation"},
 		{"actionable", "actionable"},
+		{"resources", "resources"},
+		{"accessed", "accessed"},
+		{"created", "created"},
+		{"cost", "cost"},
 		{"garbage", "creation"},
 		{"ACTIONABLE", "creation"}, // case-sensitive; only exact "actionable" opts in
 	}
@@ -2669,3 +2855,25 @@ func TestSaveUserConfig_OmitsUnsetGroupSort(t *testing.T) {
 		t.Errorf("config.toml must contain a set group_sort; got:\n%s", raw)
 	}
 }
+
+func TestIsGroupWebPrivate_InheritsFromAncestors(t *testing.T) {
+	cfg := &UserConfig{Groups: map[string]GroupSettings{
+		"personal": {WebPrivate: true},
+		"work":     {DefaultPath: "/tmp/work"},
+	}}
+	cases := map[string]bool{
+		"personal":        true,
+		"personal/health": true,
+		"work":            false,
+		"work/personal":   false,
+		"":                false,
+	}
+	for path, want := range cases {
+		if got := cfg.IsGroupWebPrivate(path); got != want {
+			t.Errorf("IsGroupWebPrivate(%q) = %v, want %v", path, got, want)
+		}
+	}
+	if got := cfg.WebPrivateGroups(); len(got) != 1 || got[0] != "personal" {
+		t.Errorf("WebPrivateGroups() = %v, want [personal]", got)
+	}
+}
@@ -0,0 +1,170 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultVerifyTimeoutSeconds = 600
+	// maxVerifyOutputLines is how much of a run's output is kept: enough
+	// for a failing test's report, not a full build log.
+	maxVerifyOutputLines = 200
+)
+
+// VerifySettings configures the verify command: a per-project check (tests,
+// lint, build) the TUI runs against a session's checkout to validate the
+// agent's work.
+type VerifySettings struct {
+	// Command is run with `sh -c` in the session's worktree, or its project
+	// path when it has none, for projects without an entry in Projects.
+	// Empty: only listed projects can be verified.
+	Command string `toml:"command,omitempty"`
+
+	// Projects maps a project path (~ and $VARS expand) to its verify
+	// command. A session matches by project path or, for worktrees, by the
+	// worktree's repo root.
+	// Example:
+	// [verify.projects]
+	// "~/src/api" = "go test ./..."
+	Projects map[string]string `toml:"projects,omitempty"`
+
+	// TimeoutSeconds stops a run that takes longer. Default: 600
+	TimeoutSeconds int `toml:"timeout_seconds,omitzero"`
+}
+
+// GetTimeout returns how long one verify run may take.
+func (v VerifySettings) GetTimeout() time.Duration {
+	secs := v.TimeoutSeconds
+	if secs <= 0 {
+		secs = defaultVerifyTimeoutSeconds
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// CommandFor returns the verify command for inst: its project's entry in
+// Projects, else Command. "" means nothing is configured.
+func (v VerifySettings) CommandFor(inst *Instance) string {
+	candidates := []string{inst.ProjectPath}
+	if inst.WorktreeRepoRoot != "" {
+		candidates = append(candidates, inst.WorktreeRepoRoot)
+	}
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		for path, command := range v.Projects {
+			if filepath.Clean(ExpandPath(path)) == filepath.Clean(c) && strings.TrimSpace(command) != "" {
+				return strings.TrimSpace(command)
+			}
+		}
+	}
+	return strings.TrimSpace(v.Command)
+}
+
+// VerifyDir returns where inst's verify command runs: the worktree when
+// there is one, else the project path.
+func VerifyDir(inst *Instance) string {
+	if inst.WorktreePath != "" {
+		return inst.WorktreePath
+	}
+	return inst.ProjectPath
+}
+
+// VerifyResult is the outcome of one verify run.
+type VerifyResult struct {
+	Command  string
+	Passed   bool
+	ExitCode int // -1 when the command could not run or timed out
+	// Output is the tail of the combined stdout/stderr.
+	Output     string
+	Duration   time.Duration
+	FinishedAt time.Time
+	// Err explains a run that did not pass: the exit status, a timeout, or
+	// why the command could not start.
+	Err error
+}
+
+// RunVerify runs command with `sh -c` in dir, bounded by timeout. The run
+// is recorded in the session's event log as EventVerified, which is what
+// LastVerify reads back.
+func (i *Instance) RunVerify(command, dir string, timeout time.Duration) VerifyResult {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	// Own process group, so a timeout also stops what the command spawned
+	// (test binaries, watchers) rather than only the shell.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error { return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL) }
+	cmd.WaitDelay = 5 * time.Second
+	out, err := cmd.CombinedOutput()
+
+	res := VerifyResult{
+		Command:    command,
+		Passed:     err == nil,
+		Output:     tailLines(string(out), maxVerifyOutputLines),
+		Duration:   time.Since(start),
+		FinishedAt: time.Now(),
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		res.ExitCode = -1
+		res.Err = fmt.Errorf("timed out after %s", timeout)
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+		res.Err = fmt.Errorf("exit status %d", res.ExitCode)
+	default:
+		res.ExitCode = -1
+		res.Err = err
+	}
+
+	detail := "passed: " + command
+	if !res.Passed {
+		detail = "failed: " + command
+	}
+	i.recordEvent(EventVerified, detail, res.Err)
+	return res
+}
+
+// LastVerify returns the most recent verify run from the event log, so the
+// outcome survives restarts of the TUI.
+func (i *Instance) LastVerify() (SessionEvent, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	for j := len(i.EventLog) - 1; j >= 0; j-- {
+		if i.EventLog[j].Kind == EventVerified {
+			return i.EventLog[j], true
+		}
+	}
+	return SessionEvent{}, false
+}
+
+// VerifyPassed reports whether a verify event records a passing run.
+func VerifyPassed(ev SessionEvent) bool {
+	return ev.Kind == EventVerified && strings.HasPrefix(ev.Detail, "passed")
+}
+
+// tailLines keeps the last n lines of s.
+func tailLines(s string, n int) string {
+	s = strings.TrimRight(s, "\n")
+	idx := len(s)
+	for k := 0; k < n; k++ {
+		j := strings.LastIndexByte(s[:idx], '\n')
+		if j < 0 {
+			return s
+		}
+		idx = j
+	}
+	return s[idx+1:]
+}
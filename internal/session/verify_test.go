@@ -0,0 +1,77 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifySettings_CommandFor(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	v := VerifySettings{
+		Command: "make check",
+		Projects: map[string]string{
+			"~/src/api": "go test ./...",
+			"/srv/web":  "npm test",
+		},
+	}
+
+	api := &Instance{ProjectPath: filepath.Join(home, "src/api")}
+	if got := v.CommandFor(api); got != "go test ./..." {
+		t.Errorf("project match = %q, want the project's command", got)
+	}
+	wt := &Instance{ProjectPath: "/tmp/web-feature", WorktreePath: "/tmp/web-feature", WorktreeRepoRoot: "/srv/web/"}
+	if got := v.CommandFor(wt); got != "npm test" {
+		t.Errorf("worktree repo root match = %q, want npm test", got)
+	}
+	if got := v.CommandFor(&Instance{ProjectPath: "/elsewhere"}); got != "make check" {
+		t.Errorf("fallback = %q, want the default command", got)
+	}
+	if got := (VerifySettings{}).CommandFor(api); got != "" {
+		t.Errorf("unconfigured = %q, want empty", got)
+	}
+}
+
+func TestInstanceRunVerify(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "marker"), []byte("ok"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	inst := NewInstance("api", dir)
+
+	pass := inst.RunVerify("test -f marker && echo all good", dir, time.Minute)
+	if !pass.Passed || pass.Err != nil || pass.Output != "all good" {
+		t.Fatalf("passing run = %+v", pass)
+	}
+	if ev, ok := inst.LastVerify(); !ok || !VerifyPassed(ev) {
+		t.Errorf("LastVerify after pass = %+v, %v", ev, ok)
+	}
+
+	fail := inst.RunVerify("echo FAIL: TestX; exit 3", dir, time.Minute)
+	if fail.Passed || fail.ExitCode != 3 || !strings.Contains(fail.Output, "FAIL: TestX") {
+		t.Fatalf("failing run = %+v", fail)
+	}
+	ev, ok := inst.LastVerify()
+	if !ok || VerifyPassed(ev) || ev.Error != "exit status 3" {
+		t.Errorf("LastVerify after fail = %+v, %v", ev, ok)
+	}
+
+	slow := inst.RunVerify("sleep 5", dir, 100*time.Millisecond)
+	if slow.Passed || slow.ExitCode != -1 || slow.Err == nil || !strings.Contains(slow.Err.Error(), "timed out") {
+		t.Errorf("timed-out run = %+v", slow)
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	if got := tailLines("a\nb\nc\nd\n", 2); got != "c\nd" {
+		t.Errorf("tailLines = %q, want last two lines", got)
+	}
+	if got := tailLines("only", 5); got != "only" {
+		t.Errorf("short input = %q", got)
+	}
+}
@@ -11,7 +11,7 @@ import (
 // Persisted as meta.json in the effective watcher/<name> data directory.
 type WatcherMeta struct {
 	Name           string `json:"name"`
-	Type           string `json:"type"`                       // adapter type: "webhook", "ntfy", "github", "slack", "gmail"
+	Type           string `json:"type"`                       // adapter type: "webhook", "ntfy", "github", "github-issues", "slack", "gmail"
 	CreatedAt      string `json:"created_at"`                 // RFC3339 timestamp
 	WatchExpiry    string `json:"watch_expiry,omitempty"`     // RFC3339 UTC (gmail only) — Gmail watch() expiration
 	WatchHistoryID string `json:"watch_history_id,omitempty"` // uint64 as string (gmail only) — last processed Gmail history ID
@@ -0,0 +1,265 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Session lifecycle events delivered to [webhooks.*] endpoints.
+const (
+	WebhookEventCreated   = "created"
+	WebhookEventWaiting   = "waiting"
+	WebhookEventIdle      = "idle"
+	WebhookEventError     = "error"
+	WebhookEventDeleted   = "deleted"
+	WebhookEventRestarted = "restarted"
+)
+
+// WebhookEvents lists every event name, in lifecycle order.
+var WebhookEvents = []string{
+	WebhookEventCreated,
+	WebhookEventWaiting,
+	WebhookEventIdle,
+	WebhookEventError,
+	WebhookEventDeleted,
+	WebhookEventRestarted,
+}
+
+// Webhook body formats.
+const (
+	WebhookFormatJSON    = "json"
+	WebhookFormatSlack   = "slack"
+	WebhookFormatDiscord = "discord"
+)
+
+// webhookTimeout bounds one delivery. Webhooks are fire-and-forget; a slow
+// endpoint must not hold goroutines for long.
+const webhookTimeout = 10 * time.Second
+
+// WebhookConfig is one [webhooks.<name>] endpoint.
+type WebhookConfig struct {
+	// URL receives a POST per event.
+	URL string `toml:"url"`
+
+	// Events filters what is sent (see WebhookEvents). Empty sends all.
+	Events []string `toml:"events,omitempty"`
+
+	// Format shapes the body: "json" (default, the full WebhookPayload),
+	// "slack" ({"text": ...}) or "discord" ({"content": ...}), so incoming
+	// webhooks of those services work without a relay.
+	Format string `toml:"format,omitempty"`
+
+	// Headers are added to every request (e.g. Authorization).
+	Headers map[string]string `toml:"headers,omitempty"`
+}
+
+// Wants reports whether the endpoint subscribes to event.
+func (w WebhookConfig) Wants(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if strings.EqualFold(strings.TrimSpace(e), event) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the URL, format and event names.
+func (w WebhookConfig) Validate() error {
+	if !strings.HasPrefix(w.URL, "http://") && !strings.HasPrefix(w.URL, "https://") {
+		return fmt.Errorf("url must be http(s), got %q", w.URL)
+	}
+	switch w.Format {
+	case "", WebhookFormatJSON, WebhookFormatSlack, WebhookFormatDiscord:
+	default:
+		return fmt.Errorf("unknown format %q (want json, slack or discord)", w.Format)
+	}
+	for _, e := range w.Events {
+		known := false
+		for _, k := range WebhookEvents {
+			if strings.EqualFold(strings.TrimSpace(e), k) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown event %q (want one of %s)", e, strings.Join(WebhookEvents, ", "))
+		}
+	}
+	return nil
+}
+
+// WebhookSession describes the session an event is about.
+type WebhookSession struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	Tool           string `json:"tool"`
+	Path           string `json:"path"`
+	Group          string `json:"group,omitempty"`
+	Status         string `json:"status,omitempty"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+	Reason         string `json:"reason,omitempty"` // why a waiting session waits
+}
+
+// WebhookPayload is the JSON body of a "json" format webhook.
+type WebhookPayload struct {
+	Event     string         `json:"event"`
+	Timestamp time.Time      `json:"timestamp"`
+	Profile   string         `json:"profile"`
+	Session   WebhookSession `json:"session"`
+	Text      string         `json:"text"` // one-line human summary
+}
+
+// NewWebhookPayload builds the payload for event on inst. from is the
+// status before a status event ("" otherwise).
+func NewWebhookPayload(event, profile string, inst *Instance, from Status, now time.Time) WebhookPayload {
+	p := WebhookPayload{
+		Event:     event,
+		Timestamp: now.UTC(),
+		Profile:   profile,
+		Session: WebhookSession{
+			ID:             inst.ID,
+			Title:          inst.Title,
+			Tool:           inst.GetToolThreadSafe(),
+			Path:           inst.ProjectPath,
+			Group:          inst.GroupPath,
+			Status:         string(inst.GetStatusThreadSafe()),
+			PreviousStatus: string(from),
+		},
+	}
+	if event == WebhookEventDeleted {
+		p.Session.Status = ""
+	}
+	if event == WebhookEventWaiting {
+		p.Session.Reason = inst.CachedAttention().Reason()
+	}
+	p.Text = webhookText(p)
+	return p
+}
+
+func webhookText(p WebhookPayload) string {
+	title := p.Session.Title
+	switch p.Event {
+	case WebhookEventCreated:
+		return fmt.Sprintf("Session %q created (%s)", title, p.Session.Tool)
+	case WebhookEventWaiting:
+		if p.Session.Reason != "" {
+			return fmt.Sprintf("Session %q is waiting (%s)", title, p.Session.Reason)
+		}
+		return fmt.Sprintf("Session %q is waiting for input", title)
+	case WebhookEventIdle:
+		return fmt.Sprintf("Session %q is idle", title)
+	case WebhookEventError:
+		return fmt.Sprintf("Session %q hit an error", title)
+	case WebhookEventDeleted:
+		return fmt.Sprintf("Session %q deleted", title)
+	case WebhookEventRestarted:
+		return fmt.Sprintf("Session %q restarted", title)
+	default:
+		return fmt.Sprintf("Session %q: %s", title, p.Event)
+	}
+}
+
+// webhookBody renders p in the endpoint's format.
+func webhookBody(format string, p WebhookPayload) ([]byte, error) {
+	switch format {
+	case WebhookFormatSlack:
+		return json.Marshal(map[string]string{"text": p.Text})
+	case WebhookFormatDiscord:
+		return json.Marshal(map[string]string{"content": p.Text})
+	default:
+		return json.Marshal(p)
+	}
+}
+
+// SendWebhook POSTs p to one endpoint. A non-2xx response is an error.
+func SendWebhook(ctx context.Context, client *http.Client, w WebhookConfig, p WebhookPayload) error {
+	body, err := webhookBody(w.Format, p)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "agent-deck")
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// WebhooksFor returns the names of the endpoints subscribed to event,
+// sorted for stable delivery order.
+func WebhooksFor(hooks map[string]WebhookConfig, event string) []string {
+	var names []string
+	for name, w := range hooks {
+		if w.URL != "" && w.Wants(event) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// rejectedWebhooks remembers the last validation error logged per endpoint,
+// so a bad entry is logged once rather than on every status sweep.
+var (
+	rejectedWebhooksMu sync.Mutex
+	rejectedWebhooks   = map[string]string{}
+)
+
+// GetWebhooks returns the valid [webhooks.*] endpoints from config.toml.
+// Entries failing Validate are dropped and logged (webhook_config_invalid)
+// once per distinct error.
+func GetWebhooks() map[string]WebhookConfig {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	return validWebhooks(config.Webhooks)
+}
+
+func validWebhooks(hooks map[string]WebhookConfig) map[string]WebhookConfig {
+	rejectedWebhooksMu.Lock()
+	defer rejectedWebhooksMu.Unlock()
+	valid := make(map[string]WebhookConfig, len(hooks))
+	for name, w := range hooks {
+		err := w.Validate()
+		if err == nil {
+			valid[name] = w
+			delete(rejectedWebhooks, name)
+			continue
+		}
+		if rejectedWebhooks[name] != err.Error() {
+			rejectedWebhooks[name] = err.Error()
+			slog.Warn("webhook_config_invalid",
+				slog.String("webhook", name),
+				slog.String("error", err.Error()))
+		}
+	}
+	return valid
+}
@@ -0,0 +1,120 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookConfig_WantsAndValidate(t *testing.T) {
+	all := WebhookConfig{URL: "https://example.com/hook"}
+	if !all.Wants(WebhookEventDeleted) {
+		t.Error("no events filter should want everything")
+	}
+	some := WebhookConfig{URL: "https://example.com/hook", Events: []string{"Waiting", "error"}}
+	if !some.Wants(WebhookEventWaiting) || some.Wants(WebhookEventIdle) {
+		t.Error("events filter not applied")
+	}
+	if err := some.Validate(); err != nil {
+		t.Errorf("valid config rejected: %v", err)
+	}
+	for _, bad := range []WebhookConfig{
+		{URL: "ftp://x"},
+		{URL: "https://x", Format: "teams"},
+		{URL: "https://x", Events: []string{"finished"}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", bad)
+		}
+	}
+}
+
+func TestWebhooksFor(t *testing.T) {
+	hooks := map[string]WebhookConfig{
+		"b":     {URL: "https://b"},
+		"a":     {URL: "https://a", Events: []string{"waiting"}},
+		"nourl": {},
+	}
+	if got := WebhooksFor(hooks, WebhookEventWaiting); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("WebhooksFor(waiting) = %v", got)
+	}
+	if got := WebhooksFor(hooks, WebhookEventCreated); len(got) != 1 || got[0] != "b" {
+		t.Errorf("WebhooksFor(created) = %v", got)
+	}
+}
+
+func TestValidWebhooks_DropsInvalid(t *testing.T) {
+	got := validWebhooks(map[string]WebhookConfig{
+		"ok":      {URL: "https://example.com/hook", Events: []string{"waiting"}},
+		"ftp":     {URL: "ftp://example.com"},
+		"typo":    {URL: "https://example.com", Events: []string{"wating"}},
+		"badform": {URL: "https://example.com", Format: "teams"},
+	})
+	if len(got) != 1 || got["ok"].URL == "" {
+		t.Errorf("validWebhooks = %v, want only the valid endpoint", got)
+	}
+}
+
+func TestNewWebhookPayload(t *testing.T) {
+	inst := &Instance{ID: "id1", Title: "api", Tool: "claude", ProjectPath: "/src/api", GroupPath: "work", Status: StatusWaiting}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	p := NewWebhookPayload(WebhookEventWaiting, "default", inst, StatusRunning, now)
+	if p.Session.PreviousStatus != "running" || p.Session.Status != "waiting" || p.Profile != "default" {
+		t.Errorf("payload = %+v", p)
+	}
+	if p.Text != `Session "api" is waiting for input` {
+		t.Errorf("Text = %q", p.Text)
+	}
+	if d := NewWebhookPayload(WebhookEventDeleted, "default", inst, "", now); d.Session.Status != "" {
+		t.Error("a deleted session has no status")
+	}
+}
+
+func TestSendWebhook(t *testing.T) {
+	var gotBody map[string]any
+	var gotAuth string
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = nil
+		_ = json.Unmarshal(b, &gotBody)
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	inst := &Instance{ID: "id1", Title: "api", Tool: "claude", Status: StatusError}
+	p := NewWebhookPayload(WebhookEventError, "default", inst, StatusRunning, time.Now())
+	ctx := context.Background()
+
+	hook := WebhookConfig{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer t"}}
+	if err := SendWebhook(ctx, srv.Client(), hook, p); err != nil {
+		t.Fatal(err)
+	}
+	if gotBody["event"] != "error" || gotAuth != "Bearer t" {
+		t.Errorf("json body = %v, auth = %q", gotBody, gotAuth)
+	}
+
+	if err := SendWebhook(ctx, srv.Client(), WebhookConfig{URL: srv.URL, Format: WebhookFormatSlack}, p); err != nil {
+		t.Fatal(err)
+	}
+	if gotBody["text"] != `Session "api" hit an error` || len(gotBody) != 1 {
+		t.Errorf("slack body = %v", gotBody)
+	}
+
+	if err := SendWebhook(ctx, srv.Client(), WebhookConfig{URL: srv.URL, Format: WebhookFormatDiscord}, p); err != nil {
+		t.Fatal(err)
+	}
+	if gotBody["content"] == nil {
+		t.Errorf("discord body = %v", gotBody)
+	}
+
+	status = http.StatusInternalServerError
+	if err := SendWebhook(ctx, srv.Client(), hook, p); err == nil {
+		t.Error("non-2xx response should be an error")
+	}
+}
@@ -0,0 +1,76 @@
+package session
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanupSessionWorktree(t *testing.T) {
+	repo := issue1200InitRepo(t)
+
+	t.Run("keep leaves everything", func(t *testing.T) {
+		wt := issue1200AddWorktree(t, repo, "keep-me")
+		inst := &Instance{ID: "k", WorktreePath: wt, WorktreeRepoRoot: repo, WorktreeBranch: "keep-me"}
+		removed, deleted, err := CleanupSessionWorktree(inst, WorktreeCleanupKeep)
+		if err != nil || removed || deleted {
+			t.Fatalf("Keep = %v, %v, %v; want false, false, nil", removed, deleted, err)
+		}
+		if _, err := os.Stat(wt); err != nil {
+			t.Errorf("worktree removed under Keep: %v", err)
+		}
+	})
+
+	t.Run("remove keeps the branch", func(t *testing.T) {
+		wt := issue1200AddWorktree(t, repo, "dir-only")
+		inst := &Instance{ID: "r", WorktreePath: wt, WorktreeRepoRoot: repo, WorktreeBranch: "dir-only"}
+		removed, deleted, err := CleanupSessionWorktree(inst, WorktreeCleanupRemove)
+		if err != nil || !removed || deleted {
+			t.Fatalf("Remove = %v, %v, %v; want true, false, nil", removed, deleted, err)
+		}
+		if !sharedWtBranchExists(t, repo, "dir-only") {
+			t.Error("branch deleted under Remove")
+		}
+	})
+
+	t.Run("remove with branch deletes a merged branch", func(t *testing.T) {
+		wt := issue1200AddWorktree(t, repo, "merged")
+		inst := &Instance{ID: "m", WorktreePath: wt, WorktreeRepoRoot: repo, WorktreeBranch: "merged"}
+		removed, deleted, err := CleanupSessionWorktree(inst, WorktreeCleanupRemoveWithBranch)
+		if err != nil || !removed || !deleted {
+			t.Fatalf("RemoveWithBranch = %v, %v, %v; want true, true, nil", removed, deleted, err)
+		}
+		if sharedWtBranchExists(t, repo, "merged") {
+			t.Error("merged branch survived RemoveWithBranch")
+		}
+	})
+
+	t.Run("remove with branch keeps unmerged work", func(t *testing.T) {
+		wt := issue1200AddWorktree(t, repo, "unmerged")
+		if err := os.WriteFile(filepath.Join(wt, "work.txt"), []byte("work"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		for _, args := range [][]string{{"add", "work.txt"}, {"commit", "-m", "work"}} {
+			if out, err := exec.Command("git", append([]string{"-C", wt}, args...)...).CombinedOutput(); err != nil {
+				t.Fatalf("git %v: %v\n%s", args, err, out)
+			}
+		}
+		inst := &Instance{ID: "u", WorktreePath: wt, WorktreeRepoRoot: repo, WorktreeBranch: "unmerged"}
+		removed, deleted, err := CleanupSessionWorktree(inst, WorktreeCleanupRemoveWithBranch)
+		if !removed || deleted || err == nil {
+			t.Fatalf("RemoveWithBranch on unmerged = %v, %v, %v; want true, false, error", removed, deleted, err)
+		}
+		if !sharedWtBranchExists(t, repo, "unmerged") {
+			t.Error("unmerged branch was deleted")
+		}
+	})
+}
+
+func TestWorktreeCleanupNext(t *testing.T) {
+	if WorktreeCleanupKeep.Next() != WorktreeCleanupRemove ||
+		WorktreeCleanupRemove.Next() != WorktreeCleanupRemoveWithBranch ||
+		WorktreeCleanupRemoveWithBranch.Next() != WorktreeCleanupKeep {
+		t.Error("Next should cycle keep → remove → remove+branch → keep")
+	}
+}
@@ -87,6 +87,61 @@ func RemoveSessionWorktree(inst *Instance) (removed bool, err error) {
 	return true, nil
 }
 
+// WorktreeCleanup selects what happens to a session's worktree when the
+// session is deleted.
+type WorktreeCleanup int
+
+const (
+	// WorktreeCleanupKeep leaves the worktree directory and branch alone.
+	WorktreeCleanupKeep WorktreeCleanup = iota
+	// WorktreeCleanupRemove removes the worktree directory.
+	WorktreeCleanupRemove
+	// WorktreeCleanupRemoveWithBranch removes the worktree directory and
+	// then deletes its branch if it is fully merged.
+	WorktreeCleanupRemoveWithBranch
+)
+
+// Next cycles keep → remove → remove+branch → keep.
+func (c WorktreeCleanup) Next() WorktreeCleanup {
+	return (c + 1) % 3
+}
+
+// DefaultWorktreeCleanup derives the delete-time cleanup from the
+// [worktree] auto_cleanup and delete_branch_on_cleanup settings.
+func DefaultWorktreeCleanup() WorktreeCleanup {
+	settings := GetWorktreeSettings()
+	switch {
+	case !settings.GetAutoCleanup():
+		return WorktreeCleanupKeep
+	case settings.DeleteBranchOnCleanup:
+		return WorktreeCleanupRemoveWithBranch
+	}
+	return WorktreeCleanupRemove
+}
+
+// CleanupSessionWorktree applies mode to the session's worktree. The
+// directory goes through RemoveSessionWorktree, so the #1200 reuse guard
+// still holds; the branch is only deleted after the directory was actually
+// removed, and only with a safe (merged-only) delete so unmerged work
+// survives. It reports whether the directory and the branch were removed.
+func CleanupSessionWorktree(inst *Instance, mode WorktreeCleanup) (removed, branchDeleted bool, err error) {
+	if mode == WorktreeCleanupKeep {
+		return false, false, nil
+	}
+	removed, err = RemoveSessionWorktree(inst)
+	if err != nil || !removed || mode != WorktreeCleanupRemoveWithBranch {
+		return removed, false, err
+	}
+	branch := strings.TrimSpace(inst.WorktreeBranch)
+	if branch == "" {
+		return removed, false, nil
+	}
+	if err := git.DeleteBranch(inst.WorktreeRepoRoot, branch, false); err != nil {
+		return removed, false, err
+	}
+	return removed, true, nil
+}
+
 // RemoveSessionWorktreeUnlessShared is the shared-worktree-safe entry point
 // (issue #1449). It removes the session's worktree directory only when no OTHER
 // live session still references that worktree; otherwise it skips the
@@ -103,6 +103,14 @@ type toolDataBlob struct {
 	MultiRepoWorktrees []multiRepoWorktreeBlob `json:"multi_repo_worktrees,omitempty"`
 	// Presentation
 	Color string `json:"color,omitempty"` // issue #391 — per-session TUI row tint
+	// Tags is written by session.WriteTagsToToolData outside the positional
+	// MarshalToolData signature. Declared here so MergeToolDataExtras treats
+	// the key as typed and saving an untagged session clears it.
+	Tags []string `json:"tags,omitempty"`
+	// Chain is written by session.WriteChainToToolData, same as Tags.
+	Chain json.RawMessage `json:"chain,omitempty"`
+	// InitialPrompt is written by session.WriteInitialPromptToToolData.
+	InitialPrompt string `json:"initial_prompt,omitempty"`
 }
 
 // multiRepoWorktreeBlob is the JSON representation of a multi-repo worktree in tool_data.
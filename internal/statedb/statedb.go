@@ -96,7 +96,7 @@ func withBusyRetry(op func() error) error {
 
 // SchemaVersion tracks the current database schema version.
 // Bump this when adding migrations.
-const SchemaVersion = 13
+const SchemaVersion = 14
 
 // StateDB wraps a SQLite database for session/group persistence.
 // Thread-safe for concurrent use from multiple goroutines within one process.
@@ -248,6 +248,13 @@ type GroupRow struct {
 	// 0 = unlimited (legacy default for groups predating this field); 1 = serial
 	// (default for newly-created groups); N>=2 = bounded parallelism.
 	MaxConcurrent int
+	// NotificationsMuted keeps the group's sessions off the notification bar.
+	NotificationsMuted bool
+	// SessionDefaults is a JSON blob of per-group new-session defaults
+	// (tool, dangerous mode, MCPs, model); "" when none are set.
+	SessionDefaults string
+	// Archived hides the group from the main tree.
+	Archived bool
 }
 
 // StatusRow holds status + acknowledgment for a session.
@@ -334,6 +341,27 @@ func (s *StateDB) DB() *sql.DB {
 	return s.db
 }
 
+// IntegrityCheck runs SQLite's quick_check and returns the problems it
+// reports. An empty result means the database is structurally sound.
+func (s *StateDB) IntegrityCheck() ([]string, error) {
+	rows, err := s.db.Query(`PRAGMA quick_check`)
+	if err != nil {
+		return nil, fmt.Errorf("statedb: quick_check: %w", err)
+	}
+	defer rows.Close()
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, fmt.Errorf("statedb: quick_check: %w", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	return problems, rows.Err()
+}
+
 // Migrate creates tables if they don't exist and runs any pending migrations.
 func (s *StateDB) Migrate() error {
 	tx, err := s.db.Begin()
@@ -399,7 +427,10 @@ func (s *StateDB) Migrate() error {
 			expanded       INTEGER NOT NULL DEFAULT 1,
 			sort_order     INTEGER NOT NULL DEFAULT 0,
 			default_path   TEXT NOT NULL DEFAULT '',
-			max_concurrent INTEGER NOT NULL DEFAULT 0
+			max_concurrent INTEGER NOT NULL DEFAULT 0,
+			notifications_muted INTEGER NOT NULL DEFAULT 0,
+			session_defaults TEXT NOT NULL DEFAULT '',
+			archived       INTEGER NOT NULL DEFAULT 0
 		)
 	`); err != nil {
 		return fmt.Errorf("statedb: create groups: %w", err)
@@ -412,6 +443,21 @@ func (s *StateDB) Migrate() error {
 			return fmt.Errorf("statedb: add groups.max_concurrent: %w", err)
 		}
 	}
+	if _, err := tx.Exec(`ALTER TABLE groups ADD COLUMN notifications_muted INTEGER NOT NULL DEFAULT 0`); err != nil {
+		if !strings.Contains(err.Error(), "duplicate column") {
+			return fmt.Errorf("statedb: add groups.notifications_muted: %w", err)
+		}
+	}
+	if _, err := tx.Exec(`ALTER TABLE groups ADD COLUMN session_defaults TEXT NOT NULL DEFAULT ''`); err != nil {
+		if !strings.Contains(err.Error(), "duplicate column") {
+			return fmt.Errorf("statedb: add groups.session_defaults: %w", err)
+		}
+	}
+	if _, err := tx.Exec(`ALTER TABLE groups ADD COLUMN archived INTEGER NOT NULL DEFAULT 0`); err != nil {
+		if !strings.Contains(err.Error(), "duplicate column") {
+			return fmt.Errorf("statedb: add groups.archived: %w", err)
+		}
+	}
 
 	// instance heartbeats
 	if _, err := tx.Exec(`
@@ -444,6 +490,21 @@ func (s *StateDB) Migrate() error {
 		return fmt.Errorf("statedb: create recent_sessions: %w", err)
 	}
 
+	// trash table (schema v14): deleted sessions kept for a retention period
+	// so `agent-deck trash restore` can bring the full row — including the
+	// tool session IDs in tool_data — back. row holds the InstanceRow as JSON
+	// so new instance columns never need a matching trash migration.
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS trash (
+			id         TEXT PRIMARY KEY,
+			title      TEXT NOT NULL,
+			deleted_at INTEGER NOT NULL,
+			row        TEXT NOT NULL
+		)
+	`); err != nil {
+		return fmt.Errorf("statedb: create trash: %w", err)
+	}
+
 	// cost_events table (cost tracking)
 	if _, err := tx.Exec(`
 		CREATE TABLE IF NOT EXISTS cost_events (
@@ -648,6 +709,7 @@ func (s *StateDB) Migrate() error {
 				}
 			}
 		}
+		// v14: trash table is new (CREATE TABLE IF NOT EXISTS handles creation).
 		if _, err := tx.Exec(`
 			UPDATE metadata SET value = ? WHERE key = 'schema_version'
 		`, schemaVersion); err != nil {
@@ -668,6 +730,13 @@ func (s *StateDB) IsEmpty() (bool, error) {
 	return count == 0, nil
 }
 
+// CountInstances returns the number of rows in the instances table.
+func (s *StateDB) CountInstances() (int, error) {
+	var count int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM instances").Scan(&count)
+	return count, err
+}
+
 // --- Instance CRUD ---
 
 func archivedAtUnix(t time.Time) int64 {
@@ -1024,8 +1093,8 @@ func (s *StateDB) SaveGroups(groups []*GroupRow) error {
 	}
 
 	stmt, err := tx.Prepare(`
-		INSERT INTO groups (path, name, expanded, sort_order, default_path, max_concurrent)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO groups (path, name, expanded, sort_order, default_path, max_concurrent, notifications_muted, session_defaults, archived)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
@@ -1037,7 +1106,15 @@ func (s *StateDB) SaveGroups(groups []*GroupRow) error {
 		if g.Expanded {
 			expanded = 1
 		}
-		if _, err := stmt.Exec(g.Path, g.Name, expanded, g.Order, g.DefaultPath, g.MaxConcurrent); err != nil {
+		muted := 0
+		if g.NotificationsMuted {
+			muted = 1
+		}
+		archived := 0
+		if g.Archived {
+			archived = 1
+		}
+		if _, err := stmt.Exec(g.Path, g.Name, expanded, g.Order, g.DefaultPath, g.MaxConcurrent, muted, g.SessionDefaults, archived); err != nil {
 			return err
 		}
 	}
@@ -1048,7 +1125,7 @@ func (s *StateDB) SaveGroups(groups []*GroupRow) error {
 // LoadGroups returns all groups ordered by sort_order.
 func (s *StateDB) LoadGroups() ([]*GroupRow, error) {
 	rows, err := s.db.Query(`
-		SELECT path, name, expanded, sort_order, default_path, max_concurrent
+		SELECT path, name, expanded, sort_order, default_path, max_concurrent, notifications_muted, session_defaults, archived
 		FROM groups ORDER BY sort_order
 	`)
 	if err != nil {
@@ -1059,11 +1136,13 @@ func (s *StateDB) LoadGroups() ([]*GroupRow, error) {
 	var result []*GroupRow
 	for rows.Next() {
 		g := &GroupRow{}
-		var expanded int
-		if err := rows.Scan(&g.Path, &g.Name, &expanded, &g.Order, &g.DefaultPath, &g.MaxConcurrent); err != nil {
+		var expanded, muted, archived int
+		if err := rows.Scan(&g.Path, &g.Name, &expanded, &g.Order, &g.DefaultPath, &g.MaxConcurrent, &muted, &g.SessionDefaults, &archived); err != nil {
 			return nil, err
 		}
 		g.Expanded = expanded != 0
+		g.NotificationsMuted = muted != 0
+		g.Archived = archived != 0
 		result = append(result, g)
 	}
 	return result, rows.Err()
@@ -1135,6 +1214,40 @@ func (s *StateDB) WriteLastSentAt(id string, unixSeconds int64) error {
 	})
 }
 
+// WriteTitle persists a rename — title plus the title_locked flag that keeps
+// the Claude session-name sync from reverting it (#697) — with a targeted
+// UPDATE. Renames previously reached disk only through SaveInstances, whose
+// external-change guard skips the save while another process has written,
+// leaving the rename in memory only. Wrapped in withBusyRetry like the other
+// single-column writers.
+func (s *StateDB) WriteTitle(id, title string, locked bool) error {
+	lockedInt := 0
+	if locked {
+		lockedInt = 1
+	}
+	return withBusyRetry(func() error {
+		_, err := s.db.Exec(
+			`UPDATE instances SET title = ?, title_locked = ? WHERE id = ?`,
+			title, lockedInt, id,
+		)
+		return err
+	})
+}
+
+// WriteLastAccessed persists a session's last-accessed clock (Unix seconds)
+// with a targeted UPDATE. The value only moves forward (MAX with the stored
+// clock), so a process stamping an older attach time cannot rewind one written
+// by another.
+func (s *StateDB) WriteLastAccessed(id string, at time.Time) error {
+	return withBusyRetry(func() error {
+		_, err := s.db.Exec(
+			`UPDATE instances SET last_accessed = MAX(last_accessed, ?) WHERE id = ?`,
+			at.Unix(), id,
+		)
+		return err
+	})
+}
+
 // ReadLastSentAt returns the last_sent_at clock (Unix seconds, 0 if never sent)
 // for a session. Read-only; used by the self-heal detection pass.
 func (s *StateDB) ReadLastSentAt(id string) (int64, error) {
@@ -220,8 +220,8 @@ func TestSaveLoadGroups(t *testing.T) {
 	db := newTestDB(t)
 
 	groups := []*GroupRow{
-		{Path: "projects", Name: "Projects", Expanded: true, Order: 0},
-		{Path: "personal", Name: "Personal", Expanded: false, Order: 1, DefaultPath: "/home"},
+		{Path: "projects", Name: "Projects", Expanded: true, Order: 0, SessionDefaults: `{"tool":"codex"}`},
+		{Path: "personal", Name: "Personal", Expanded: false, Order: 1, DefaultPath: "/home", NotificationsMuted: true, Archived: true},
 	}
 
 	if err := db.SaveGroups(groups); err != nil {
@@ -241,6 +241,15 @@ func TestSaveLoadGroups(t *testing.T) {
 	if loaded[1].DefaultPath != "/home" {
 		t.Errorf("DefaultPath: %q", loaded[1].DefaultPath)
 	}
+	if loaded[0].NotificationsMuted || !loaded[1].NotificationsMuted {
+		t.Errorf("NotificationsMuted mismatch: %v, %v", loaded[0].NotificationsMuted, loaded[1].NotificationsMuted)
+	}
+	if loaded[0].SessionDefaults != `{"tool":"codex"}` || loaded[1].SessionDefaults != "" {
+		t.Errorf("SessionDefaults mismatch: %q, %q", loaded[0].SessionDefaults, loaded[1].SessionDefaults)
+	}
+	if loaded[0].Archived || !loaded[1].Archived {
+		t.Errorf("Archived mismatch: %v, %v", loaded[0].Archived, loaded[1].Archived)
+	}
 }
 
 func TestDeleteInstance(t *testing.T) {
@@ -535,6 +544,10 @@ func TestIsEmpty(t *testing.T) {
 	if empty {
 		t.Error("Expected non-empty after insert")
 	}
+
+	if n, err := db.CountInstances(); err != nil || n != 1 {
+		t.Errorf("CountInstances = %d, %v; want 1", n, err)
+	}
 }
 
 func TestMetadata(t *testing.T) {
@@ -2020,3 +2033,52 @@ func createV9SchemaDB(t *testing.T) *StateDB {
 	t.Cleanup(func() { db.Close() })
 	return db
 }
+
+func TestWriteTitleAndLastAccessedAreTargeted(t *testing.T) {
+	db := newTestDB(t)
+	created := time.Unix(1780000000, 0)
+	if err := db.SaveInstance(&InstanceRow{
+		ID:           "ren-1",
+		Title:        "old-name",
+		ProjectPath:  "/tmp/project",
+		GroupPath:    "grp",
+		Tool:         "claude",
+		Status:       "idle",
+		CreatedAt:    created,
+		LastAccessed: created.Add(time.Hour),
+		ToolData:     json.RawMessage(`{"claude_session_id":"abc"}`),
+	}); err != nil {
+		t.Fatalf("seed SaveInstance: %v", err)
+	}
+
+	if err := db.WriteTitle("ren-1", "new-name", true); err != nil {
+		t.Fatalf("WriteTitle: %v", err)
+	}
+	// An older clock must not rewind the stored one; a newer one lands.
+	if err := db.WriteLastAccessed("ren-1", created); err != nil {
+		t.Fatalf("WriteLastAccessed(older): %v", err)
+	}
+	insts, err := db.LoadInstances()
+	if err != nil {
+		t.Fatalf("LoadInstances: %v", err)
+	}
+	got := insts[0]
+	if got.Title != "new-name" || !got.TitleLocked {
+		t.Errorf("title = %q, locked = %v; want new-name, true", got.Title, got.TitleLocked)
+	}
+	if !got.LastAccessed.Equal(created.Add(time.Hour)) {
+		t.Errorf("older WriteLastAccessed rewound the clock to %v", got.LastAccessed)
+	}
+	if got.Status != "idle" || string(got.ToolData) != `{"claude_session_id":"abc"}` {
+		t.Errorf("targeted writes disturbed other columns: status=%q tool_data=%s", got.Status, got.ToolData)
+	}
+
+	later := created.Add(2 * time.Hour)
+	if err := db.WriteLastAccessed("ren-1", later); err != nil {
+		t.Fatalf("WriteLastAccessed(newer): %v", err)
+	}
+	insts, _ = db.LoadInstances()
+	if !insts[0].LastAccessed.Equal(later) {
+		t.Errorf("LastAccessed = %v, want %v", insts[0].LastAccessed, later)
+	}
+}
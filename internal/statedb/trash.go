@@ -0,0 +1,159 @@
+package statedb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTrashEntryNotFound is returned by RestoreTrashed when no trash entry has
+// the given id.
+var ErrTrashEntryNotFound = errors.New("trash entry not found")
+
+// ErrRestoreConflict is returned by RestoreTrashed when a live instance row
+// already has the trashed id (the TUI's undo restored it first).
+var ErrRestoreConflict = errors.New("a session with this id already exists")
+
+// TrashRow is one deleted session held in the trash table.
+type TrashRow struct {
+	ID        string
+	Title     string
+	DeletedAt time.Time
+	Row       *InstanceRow
+}
+
+// ToolSessionID returns the Claude/Gemini/OpenCode/Codex conversation ID the
+// trashed session would resume, or "" when none was recorded.
+func (t *TrashRow) ToolSessionID() string {
+	if t.Row == nil || len(t.Row.ToolData) == 0 {
+		return ""
+	}
+	var td toolDataBlob
+	if err := json.Unmarshal(t.Row.ToolData, &td); err != nil {
+		return ""
+	}
+	for _, id := range []string{td.ClaudeSessionID, td.GeminiSessionID, td.OpenCodeSessionID, td.CodexSessionID} {
+		if id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// TrashInstance records row as deleted at deletedAt. It does not touch the
+// instances table: callers trash first, then run their usual removal path, so
+// a failed trash write never blocks a delete. Re-trashing an id replaces the
+// earlier entry.
+func (s *StateDB) TrashInstance(row *InstanceRow, deletedAt time.Time) error {
+	payload, err := json.Marshal(row)
+	if err != nil {
+		return fmt.Errorf("encode trashed instance %s: %w", row.ID, err)
+	}
+	return withBusyRetry(func() error {
+		_, err := s.db.Exec(
+			`INSERT OR REPLACE INTO trash (id, title, deleted_at, row) VALUES (?, ?, ?, ?)`,
+			row.ID, row.Title, deletedAt.Unix(), string(payload),
+		)
+		return err
+	})
+}
+
+// LoadTrash returns every trash entry, most recently deleted first.
+func (s *StateDB) LoadTrash() ([]*TrashRow, error) {
+	rows, err := s.db.Query(`SELECT id, title, deleted_at, row FROM trash ORDER BY deleted_at DESC, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*TrashRow
+	for rows.Next() {
+		var deletedUnix int64
+		var payload string
+		r := &TrashRow{}
+		if err := rows.Scan(&r.ID, &r.Title, &deletedUnix, &payload); err != nil {
+			return nil, err
+		}
+		r.DeletedAt = time.Unix(deletedUnix, 0)
+		r.Row = &InstanceRow{}
+		if err := json.Unmarshal([]byte(payload), r.Row); err != nil {
+			return nil, fmt.Errorf("decode trashed instance %s: %w", r.ID, err)
+		}
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
+
+// RestoreTrashed re-inserts the trashed row with the given id into instances
+// and drops the trash entry. The row comes back with status "stopped": its
+// tmux session was killed on delete, and restarting it resumes the tool
+// session recorded in tool_data.
+func (s *StateDB) RestoreTrashed(id string) (*InstanceRow, error) {
+	var payload string
+	err := s.db.QueryRow(`SELECT row FROM trash WHERE id = ?`, id).Scan(&payload)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrTrashEntryNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	row := &InstanceRow{}
+	if err := json.Unmarshal([]byte(payload), row); err != nil {
+		return nil, fmt.Errorf("decode trashed instance %s: %w", id, err)
+	}
+
+	existing, err := s.LoadInstanceByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRestoreConflict, id)
+	}
+
+	row.Status = "stopped"
+	if err := withBusyRetry(func() error { return s.SaveInstance(row) }); err != nil {
+		return nil, fmt.Errorf("restore %s: %w", id, err)
+	}
+	if _, err := s.PurgeTrash(id); err != nil {
+		return row, fmt.Errorf("restored %s but failed to drop its trash entry: %w", id, err)
+	}
+	return row, nil
+}
+
+// PurgeTrash permanently drops the trash entry with the given id, or every
+// entry when id is "". Returns the number of entries removed.
+func (s *StateDB) PurgeTrash(id string) (int, error) {
+	var n int64
+	err := withBusyRetry(func() error {
+		var res sql.Result
+		var err error
+		if id == "" {
+			res, err = s.db.Exec(`DELETE FROM trash`)
+		} else {
+			res, err = s.db.Exec(`DELETE FROM trash WHERE id = ?`, id)
+		}
+		if err != nil {
+			return err
+		}
+		n, err = res.RowsAffected()
+		return err
+	})
+	return int(n), err
+}
+
+// PurgeTrashBefore drops every entry deleted before cutoff (the retention
+// sweep). Returns the number of entries removed.
+func (s *StateDB) PurgeTrashBefore(cutoff time.Time) (int, error) {
+	var n int64
+	err := withBusyRetry(func() error {
+		res, err := s.db.Exec(`DELETE FROM trash WHERE deleted_at < ?`, cutoff.Unix())
+		if err != nil {
+			return err
+		}
+		n, err = res.RowsAffected()
+		return err
+	})
+	return int(n), err
+}
@@ -0,0 +1,91 @@
+package statedb
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrashRestoreRoundTrip(t *testing.T) {
+	db := newTestDB(t)
+	row := &InstanceRow{
+		ID:          "del-1",
+		Title:       "doomed",
+		ProjectPath: "/tmp/project",
+		GroupPath:   "grp",
+		Tool:        "claude",
+		Status:      "running",
+		CreatedAt:   time.Unix(1780000000, 0),
+		ToolData:    json.RawMessage(`{"claude_session_id":"conv-123"}`),
+	}
+	if err := db.SaveInstance(row); err != nil {
+		t.Fatalf("seed SaveInstance: %v", err)
+	}
+	deletedAt := time.Unix(1780003600, 0)
+	if err := db.TrashInstance(row, deletedAt); err != nil {
+		t.Fatalf("TrashInstance: %v", err)
+	}
+
+	// The live row still exists, so restoring must refuse rather than clobber.
+	if _, err := db.RestoreTrashed("del-1"); !errors.Is(err, ErrRestoreConflict) {
+		t.Fatalf("RestoreTrashed with live row: err = %v, want ErrRestoreConflict", err)
+	}
+	if err := db.DeleteInstance("del-1"); err != nil {
+		t.Fatalf("DeleteInstance: %v", err)
+	}
+
+	entries, err := db.LoadTrash()
+	if err != nil {
+		t.Fatalf("LoadTrash: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Title != "doomed" || !entries[0].DeletedAt.Equal(deletedAt) {
+		t.Fatalf("LoadTrash = %+v, want one 'doomed' entry deleted at %v", entries, deletedAt)
+	}
+	if got := entries[0].ToolSessionID(); got != "conv-123" {
+		t.Errorf("ToolSessionID = %q, want conv-123", got)
+	}
+
+	restored, err := db.RestoreTrashed("del-1")
+	if err != nil {
+		t.Fatalf("RestoreTrashed: %v", err)
+	}
+	if restored.Status != "stopped" {
+		t.Errorf("restored status = %q, want stopped", restored.Status)
+	}
+	live, err := db.LoadInstanceByID("del-1")
+	if err != nil || live == nil {
+		t.Fatalf("LoadInstanceByID after restore = %v, %v", live, err)
+	}
+	if string(live.ToolData) != `{"claude_session_id":"conv-123"}` {
+		t.Errorf("restored tool_data = %s, want the conversation link kept", live.ToolData)
+	}
+	if entries, _ := db.LoadTrash(); len(entries) != 0 {
+		t.Errorf("trash still has %d entries after restore", len(entries))
+	}
+	if _, err := db.RestoreTrashed("del-1"); !errors.Is(err, ErrTrashEntryNotFound) {
+		t.Errorf("second RestoreTrashed: err = %v, want ErrTrashEntryNotFound", err)
+	}
+}
+
+func TestPurgeTrashBefore(t *testing.T) {
+	db := newTestDB(t)
+	now := time.Unix(1780000000, 0)
+	for i, age := range []time.Duration{time.Hour, 40 * 24 * time.Hour} {
+		row := &InstanceRow{ID: []string{"fresh", "stale"}[i], Title: "t", Tool: "shell", Status: "idle", CreatedAt: now}
+		if err := db.TrashInstance(row, now.Add(-age)); err != nil {
+			t.Fatalf("TrashInstance: %v", err)
+		}
+	}
+	n, err := db.PurgeTrashBefore(now.Add(-30 * 24 * time.Hour))
+	if err != nil || n != 1 {
+		t.Fatalf("PurgeTrashBefore = %d, %v; want 1, nil", n, err)
+	}
+	entries, _ := db.LoadTrash()
+	if len(entries) != 1 || entries[0].ID != "fresh" {
+		t.Fatalf("LoadTrash after sweep = %+v, want only 'fresh'", entries)
+	}
+	if n, err := db.PurgeTrash(""); err != nil || n != 1 {
+		t.Errorf("PurgeTrash(all) = %d, %v; want 1, nil", n, err)
+	}
+}
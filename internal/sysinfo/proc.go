@@ -0,0 +1,217 @@
+package sysinfo
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// linuxClockTicks is USER_HZ, the unit of utime/stime in /proc/<pid>/stat.
+// It is 100 on every mainstream Linux architecture.
+const linuxClockTicks = 100
+
+// ProcUsage is the summed resource usage of a process and its descendants.
+type ProcUsage struct {
+	CPUPercent float64 // of one core: 250 means two and a half cores busy
+	MemBytes   uint64  // resident set size
+	Procs      int
+}
+
+// procEntry is one row of the process table.
+type procEntry struct {
+	pid, ppid int
+	rssBytes  uint64
+	cpuSecs   float64 // cumulative CPU time (Linux)
+	cpuPct    float64 // recent CPU% straight from ps (macOS)
+}
+
+// ProcSampler measures process trees, e.g. everything running in a tmux pane.
+// On Linux CPU% comes from the CPU time used between two Sample calls, so a
+// process's first sample reports 0%; macOS reports ps's own recent average.
+// The zero value is ready to use.
+type ProcSampler struct {
+	mu      sync.Mutex
+	prevCPU map[int]float64
+	prevAt  time.Time
+}
+
+// Sample reads the process table once and returns the usage of each root
+// PID plus all its descendants. Roots that no longer exist are omitted.
+func (s *ProcSampler) Sample(roots []int) (map[int]ProcUsage, error) {
+	procs, err := listProcesses()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	elapsed := now.Sub(s.prevAt).Seconds()
+	if s.prevAt.IsZero() {
+		elapsed = 0
+	}
+	cpu := make(map[int]float64, len(procs))
+	prev := make(map[int]float64, len(procs))
+	for _, p := range procs {
+		prev[p.pid] = p.cpuSecs
+		switch {
+		case p.cpuPct > 0:
+			cpu[p.pid] = p.cpuPct
+		case elapsed > 0:
+			if before, ok := s.prevCPU[p.pid]; ok && p.cpuSecs >= before {
+				cpu[p.pid] = (p.cpuSecs - before) / elapsed * 100
+			}
+		}
+	}
+	s.prevCPU = prev
+	s.prevAt = now
+	s.mu.Unlock()
+
+	return sumProcessTrees(procs, cpu, roots), nil
+}
+
+// sumProcessTrees adds up memory and CPU for each root and its descendants.
+func sumProcessTrees(procs []procEntry, cpu map[int]float64, roots []int) map[int]ProcUsage {
+	byPID := make(map[int]procEntry, len(procs))
+	children := make(map[int][]int)
+	for _, p := range procs {
+		byPID[p.pid] = p
+		children[p.ppid] = append(children[p.ppid], p.pid)
+	}
+
+	out := make(map[int]ProcUsage, len(roots))
+	for _, root := range roots {
+		if _, ok := byPID[root]; !ok {
+			continue
+		}
+		var u ProcUsage
+		queue := []int{root}
+		seen := map[int]bool{root: true}
+		for len(queue) > 0 {
+			pid := queue[0]
+			queue = queue[1:]
+			p := byPID[pid]
+			u.MemBytes += p.rssBytes
+			u.CPUPercent += cpu[pid]
+			u.Procs++
+			for _, child := range children[pid] {
+				if !seen[child] {
+					seen[child] = true
+					queue = append(queue, child)
+				}
+			}
+		}
+		out[root] = u
+	}
+	return out
+}
+
+func listProcesses() ([]procEntry, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return listProcessesLinux()
+	case "darwin":
+		return listProcessesDarwin()
+	default:
+		return nil, fmt.Errorf("process stats not supported on %s", runtime.GOOS)
+	}
+}
+
+// listProcessesLinux walks /proc/<pid>/stat.
+func listProcessesLinux() ([]procEntry, error) {
+	dirs, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	pageSize := uint64(os.Getpagesize())
+	procs := make([]procEntry, 0, len(dirs))
+	for _, d := range dirs {
+		pid, err := strconv.Atoi(d.Name())
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile("/proc/" + d.Name() + "/stat")
+		if err != nil {
+			continue // exited since ReadDir
+		}
+		ppid, ticks, rssPages, err := ParseProcPIDStat(string(data))
+		if err != nil {
+			continue
+		}
+		procs = append(procs, procEntry{
+			pid:      pid,
+			ppid:     ppid,
+			rssBytes: rssPages * pageSize,
+			cpuSecs:  float64(ticks) / linuxClockTicks,
+		})
+	}
+	return procs, nil
+}
+
+// ParseProcPIDStat parses a /proc/<pid>/stat line for testing and returns the
+// parent PID, utime+stime in clock ticks, and the resident set in pages.
+func ParseProcPIDStat(content string) (ppid int, cpuTicks uint64, rssPages uint64, err error) {
+	// comm (field 2) is parenthesized and may contain spaces or parens, so
+	// parse from the last ')'.
+	end := strings.LastIndexByte(content, ')')
+	if end < 0 {
+		return 0, 0, 0, fmt.Errorf("no comm field")
+	}
+	fields := strings.Fields(content[end+1:])
+	// fields[0] is state (field 3): utime=14, stime=15, rss=24.
+	if len(fields) < 22 {
+		return 0, 0, 0, fmt.Errorf("too few fields")
+	}
+	if ppid, err = strconv.Atoi(fields[1]); err != nil {
+		return 0, 0, 0, err
+	}
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	rss, err := strconv.ParseInt(fields[21], 10, 64)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if rss < 0 {
+		rss = 0
+	}
+	return ppid, utime + stime, uint64(rss), nil
+}
+
+// listProcessesDarwin reads the process table from ps (rss is in KiB).
+func listProcessesDarwin() ([]procEntry, error) {
+	out, err := exec.Command("ps", "-A", "-o", "pid=,ppid=,rss=,%cpu=").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parsePSProcesses(string(out)), nil
+}
+
+// parsePSProcesses parses `ps -o pid=,ppid=,rss=,%cpu=` output.
+func parsePSProcesses(out string) []procEntry {
+	var procs []procEntry
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		f := strings.Fields(line)
+		if len(f) < 4 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(f[0])
+		ppid, err2 := strconv.Atoi(f[1])
+		rssKiB, err3 := strconv.ParseUint(f[2], 10, 64)
+		pct, err4 := strconv.ParseFloat(f[3], 64)
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			continue
+		}
+		procs = append(procs, procEntry{pid: pid, ppid: ppid, rssBytes: rssKiB * 1024, cpuPct: pct})
+	}
+	return procs
+}
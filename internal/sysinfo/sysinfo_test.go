@@ -2,6 +2,7 @@ package sysinfo
 
 import (
 	"math"
+	"os"
 	"runtime"
 	"testing"
 )
@@ -131,6 +132,74 @@ func TestParseNetDev_Empty(t *testing.T) {
 	}
 }
 
+// --- Process tree tests ---
+
+func TestParseProcPIDStat(t *testing.T) {
+	// comm with spaces and a ')' must not shift the fields.
+	content := "4853 (my (odd) cmd) S 4848 4853 4848 0 -1 4194304 82 0 0 0 150 25 0 0 20 0 1 0 2668068 2703360 310 18446744073709551615"
+	ppid, ticks, rss, err := ParseProcPIDStat(content)
+	if err != nil {
+		t.Fatalf("ParseProcPIDStat: %v", err)
+	}
+	if ppid != 4848 || ticks != 175 || rss != 310 {
+		t.Errorf("got ppid=%d ticks=%d rss=%d, want 4848 175 310", ppid, ticks, rss)
+	}
+
+	if _, _, _, err := ParseProcPIDStat("4853 (cat) R 1"); err == nil {
+		t.Error("truncated stat line should fail")
+	}
+}
+
+func TestParsePSProcesses(t *testing.T) {
+	procs := parsePSProcesses("    1     0  1024   0.0\n  200     1  2048  12.5\ngarbage\n")
+	if len(procs) != 2 {
+		t.Fatalf("got %d processes, want 2", len(procs))
+	}
+	if procs[1].pid != 200 || procs[1].ppid != 1 || procs[1].rssBytes != 2048*1024 || procs[1].cpuPct != 12.5 {
+		t.Errorf("unexpected entry: %+v", procs[1])
+	}
+}
+
+func TestSumProcessTrees(t *testing.T) {
+	procs := []procEntry{
+		{pid: 10, ppid: 1, rssBytes: 100},
+		{pid: 11, ppid: 10, rssBytes: 200}, // child of 10
+		{pid: 12, ppid: 11, rssBytes: 300}, // grandchild of 10
+		{pid: 20, ppid: 1, rssBytes: 1000},
+	}
+	cpu := map[int]float64{11: 50, 12: 25, 20: 5}
+
+	got := sumProcessTrees(procs, cpu, []int{10, 20, 99})
+	if u := got[10]; u.MemBytes != 600 || u.CPUPercent != 75 || u.Procs != 3 {
+		t.Errorf("tree 10 = %+v, want 600 bytes, 75%%, 3 procs", u)
+	}
+	if u := got[20]; u.MemBytes != 1000 || u.Procs != 1 {
+		t.Errorf("tree 20 = %+v, want 1000 bytes, 1 proc", u)
+	}
+	if _, ok := got[99]; ok {
+		t.Error("missing root should be omitted")
+	}
+}
+
+func TestProcSampler_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("process stats require Linux or macOS")
+	}
+
+	self := os.Getpid()
+	var sampler ProcSampler
+	usage, err := sampler.Sample([]int{self})
+	if err != nil {
+		t.Fatalf("Sample: %v", err)
+	}
+	if u, ok := usage[self]; !ok || u.MemBytes == 0 || u.Procs < 1 {
+		t.Errorf("own process usage = %+v (found=%v), want non-zero memory", u, ok)
+	}
+}
+
 // --- Format tests ---
 
 func TestFormatBytes(t *testing.T) {
@@ -0,0 +1,85 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// ErrNotInTmux is returned by OpenInSurroundingTmux when agent-deck itself is
+// not running inside tmux, so there is no surrounding session to split.
+var ErrNotInTmux = errors.New("terminal: agent-deck is not running inside tmux")
+
+// Where OpenInSurroundingTmux opens the session.
+const (
+	TmuxOpenWindow = "window"
+	TmuxOpenPane   = "pane"
+)
+
+// InsideTmux reports whether agent-deck runs inside a tmux client ($TMUX is
+// set), i.e. whether OpenInSurroundingTmux has a session to open into.
+func InsideTmux() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+// BuildSurroundingTmuxArgs returns the tmux argv (without the leading "tmux")
+// that opens req in the tmux session agent-deck runs in: a new window right
+// after agent-deck's own (mode "window"), or a pane split off paneID (mode
+// "pane"; below it when split is "below", otherwise to its right). paneID is
+// agent-deck's $TMUX_PANE; empty targets the client's current pane.
+//
+// The new window or pane runs BuildAttachCommand with $TMUX cleared — tmux
+// refuses to start a client inside another one otherwise — so it closes
+// when the user detaches. Returns nil when req has no attach command.
+func BuildSurroundingTmuxArgs(req AttachRequest, mode, split, paneID, title string) []string {
+	attach := BuildAttachCommand(req)
+	if attach == "" {
+		return nil
+	}
+	shellCmd := "unset TMUX; exec " + attach
+
+	var args []string
+	if mode == TmuxOpenWindow {
+		args = []string{"new-window", "-a"}
+		if paneID != "" {
+			args = append(args, "-t", paneID)
+		}
+		if title = strings.TrimSpace(title); title != "" {
+			args = append(args, "-n", title)
+		}
+	} else {
+		dir := "-h"
+		if split == "below" {
+			dir = "-v"
+		}
+		args = []string{"split-window", dir}
+		if paneID != "" {
+			args = append(args, "-t", paneID)
+		}
+	}
+	return append(args, shellCmd)
+}
+
+// OpenInSurroundingTmux opens req in a new window or pane (see
+// BuildSurroundingTmuxArgs) of the tmux session agent-deck runs in, leaving
+// the dashboard on screen. The command goes to the server named by $TMUX,
+// which is where an unadorned `tmux` invocation connects.
+func OpenInSurroundingTmux(req AttachRequest, mode, split, title string) error {
+	if !InsideTmux() {
+		return ErrNotInTmux
+	}
+	args := BuildSurroundingTmuxArgs(req, mode, split, os.Getenv("TMUX_PANE"), title)
+	if args == nil {
+		return fmt.Errorf("terminal: empty attach command (missing session name or remote host)")
+	}
+	if out, err := tmux.Exec("", args...).CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("tmux %s: %s", args[0], msg)
+		}
+		return fmt.Errorf("tmux %s: %w", args[0], err)
+	}
+	return nil
+}
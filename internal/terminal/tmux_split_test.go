@@ -0,0 +1,42 @@
+package terminal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBuildSurroundingTmuxArgs_Pane(t *testing.T) {
+	req := AttachRequest{Name: "proj", SocketName: "agentdeck"}
+	got := BuildSurroundingTmuxArgs(req, TmuxOpenPane, "", "%3", "proj")
+	want := []string{"split-window", "-h", "-t", "%3", "unset TMUX; exec tmux -L 'agentdeck' attach -t 'proj'"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("pane args:\n got=%q\nwant=%q", got, want)
+	}
+
+	got = BuildSurroundingTmuxArgs(req, TmuxOpenPane, "below", "", "proj")
+	if got[1] != "-v" || got[2] == "-t" {
+		t.Fatalf("below split without a pane id should be split-window -v with no target, got %q", got)
+	}
+}
+
+func TestBuildSurroundingTmuxArgs_Window(t *testing.T) {
+	got := BuildSurroundingTmuxArgs(AttachRequest{Name: "proj"}, TmuxOpenWindow, "", "%3", "My Session")
+	want := []string{"new-window", "-a", "-t", "%3", "-n", "My Session", "unset TMUX; exec tmux attach -t 'proj'"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("window args:\n got=%q\nwant=%q", got, want)
+	}
+}
+
+func TestBuildSurroundingTmuxArgs_EmptyRequest(t *testing.T) {
+	if got := BuildSurroundingTmuxArgs(AttachRequest{}, TmuxOpenPane, "", "%1", ""); got != nil {
+		t.Fatalf("expected nil args for an empty request, got %q", got)
+	}
+}
+
+func TestOpenInSurroundingTmux_OutsideTmux(t *testing.T) {
+	t.Setenv("TMUX", "")
+	if err := OpenInSurroundingTmux(AttachRequest{Name: "proj"}, TmuxOpenPane, "", ""); !errors.Is(err, ErrNotInTmux) {
+		t.Fatalf("expected ErrNotInTmux outside tmux, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package tmux
+
+import "testing"
+
+// Aider: tmux-layer detection tests.
+
+func TestDetectToolFromCommand_Aider(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{"bare aider", "aider", "aider"},
+		{"aider with model", "aider --model sonnet", "aider"},
+		{"aider pipx path", "/home/user/.local/bin/aider", "aider"},
+		{"python module", "python -m aider --map-tokens 2048", "aider"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectToolFromCommand(tt.command); got != tt.want {
+				t.Fatalf("detectToolFromCommand(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromptDetector_AiderPrompt(t *testing.T) {
+	d := NewPromptDetector("aider")
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"code prompt", "Aider v0.86.1\nMain model: sonnet\n\n> ", true},
+		{"ask mode prompt", "Repo-map: using 1024 tokens\n\nask> ", true},
+		{"architect prompt with typed text", "architect> refactor the parser", true},
+		{"confirmation", "Add main.go to the chat? (Y)es/(N)o [Yes]: ", true},
+		{"streaming reply after old prompt", "> fix the bug\n\nLooking at main.go, the loop\nnever terminates because", false},
+		{"waiting for model", "> fix the bug\n\nWaiting for claude-3-5-sonnet", false},
+		{"repo map scan", "> \nUpdating repo map: 40%", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.HasPrompt(tt.content); got != tt.want {
+				t.Fatalf("HasPrompt(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package tmux
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Attention labels what kind of attention a WAITING session needs, derived
+// from its recent pane output. Like Substate it is additive: it never changes
+// the canonical status, it only replaces the generic "waiting" indicator with
+// something the user can triage at a glance.
+type Attention string
+
+const (
+	// AttentionNone means the output has not been classified (no capture yet,
+	// or the session is not at a prompt).
+	AttentionNone Attention = ""
+
+	// AttentionPermission marks a session blocked on a tool-permission or
+	// approval dialog ("Do you want to proceed?", "Allow once").
+	AttentionPermission Attention = "permission"
+
+	// AttentionPlanApproval marks a session that finished planning and waits
+	// for the plan to be accepted before it starts editing.
+	AttentionPlanApproval Attention = "plan-approval"
+
+	// AttentionQuestion marks a session asking the user something: a
+	// confirmation prompt or a trailing question in the reply.
+	AttentionQuestion Attention = "question"
+
+	// AttentionError marks a session whose last turn ended on an error
+	// (stack trace, "Error:" line, failing test run).
+	AttentionError Attention = "error"
+
+	// AttentionDone marks a session that finished its turn cleanly and is
+	// awaiting review. It is the fallback when neither of the above match.
+	AttentionDone Attention = "done"
+)
+
+// Label is the short human-readable description shown next to the status.
+func (a Attention) Label() string {
+	switch a {
+	case AttentionPermission:
+		return "needs permission"
+	case AttentionPlanApproval:
+		return "awaiting plan approval"
+	case AttentionQuestion:
+		return "asking a question"
+	case AttentionError:
+		return "hit an error"
+	case AttentionDone:
+		return "finished, awaiting review"
+	default:
+		return ""
+	}
+}
+
+// Reason is the terse "why is it waiting" tag shown next to a session title
+// in the list and the notification bar. A clean finish has no reason: it is
+// the ordinary end of a turn, not something blocking the agent.
+func (a Attention) Reason() string {
+	switch a {
+	case AttentionPermission:
+		return "needs permission"
+	case AttentionPlanApproval:
+		return "plan approval"
+	case AttentionQuestion:
+		return "question"
+	case AttentionError:
+		return "error"
+	default:
+		return ""
+	}
+}
+
+// attentionTailLines is how many recent content lines the classifier reads.
+// Kept close to the error-banner window so a stale line far up the
+// scrollback does not decide the label.
+const attentionTailLines = 12
+
+// attentionPlanSubstrings are fragments of the plan-mode exit dialog
+// ("Claude has written up a plan and is ready to execute. Would you like to
+// proceed?" … "No, keep planning"). Checked before the permission and
+// question fragments, which its "would you like" would also match.
+var attentionPlanSubstrings = []string{
+	"ready to execute",
+	"keep planning",
+	"exit plan mode",
+}
+
+// attentionPermissionSubstrings are tool-approval dialog fragments rendered
+// by the supported agents (Claude permission prompts, Codex/Gemini
+// approvals). Matched case-insensitively.
+var attentionPermissionSubstrings = []string{
+	"do you want to",
+	"allow once",
+	"always allow",
+	"don't ask again",
+	"approve this",
+	"waiting for your approval",
+	"allow execution",
+	"allow command",
+}
+
+// attentionQuestionSubstrings are generic confirmation-prompt fragments
+// (shell y/n prompts, offers from the agent). Matched case-insensitively.
+var attentionQuestionSubstrings = []string{
+	"would you like",
+	"(y/n)",
+	"[y/n]",
+	"(yes/no)",
+}
+
+// attentionErrorLine matches lines that read as a failure report rather than
+// prose about errors: a leading error/fatal/panic keyword, a Python
+// traceback header, a Go test failure, or a non-zero exit code.
+var attentionErrorLine = regexp.MustCompile(`(?i)^(?:[^\w]*\s*)?(?:error|fatal|panic|exception)(?:\[[^\]]*\])?:|traceback \(most recent call last\)|^--- fail:|^fail\s|exit (?:code|status) [1-9]|^api error`)
+
+// ClassifyAttention labels the recent pane output. Precedence is plan
+// approval > permission > question > error > done: a dialog blocks the agent
+// outright, while an error the agent already reported is something to review.
+func ClassifyAttention(content string) Attention {
+	tail := attentionContentTail(content, attentionTailLines)
+	if len(tail) == 0 {
+		return AttentionNone
+	}
+
+	lowered := make([]string, len(tail))
+	for i, line := range tail {
+		lowered[i] = strings.ToLower(line)
+	}
+	for _, group := range []struct {
+		patterns  []string
+		attention Attention
+	}{
+		{attentionPlanSubstrings, AttentionPlanApproval},
+		{attentionPermissionSubstrings, AttentionPermission},
+		{attentionQuestionSubstrings, AttentionQuestion},
+	} {
+		for _, lower := range lowered {
+			for _, pat := range group.patterns {
+				if strings.Contains(lower, pat) {
+					return group.attention
+				}
+			}
+		}
+	}
+	// A reply that ends on a question. Only the last few content lines count:
+	// option lists and the prompt box usually follow the question itself.
+	for i := max(0, len(tail)-3); i < len(tail); i++ {
+		if strings.HasSuffix(tail[i], "?") {
+			return AttentionQuestion
+		}
+	}
+
+	for _, line := range tail {
+		if attentionErrorLine.MatchString(line) {
+			return AttentionError
+		}
+	}
+
+	return AttentionDone
+}
+
+// attentionContentTail returns the last n non-chrome lines of content:
+// blank lines, box-drawing borders, the input prompt, and footer hints are
+// skipped so the classifier looks at what the agent actually said.
+func attentionContentTail(content string, n int) []string {
+	lines := strings.Split(StripANSI(content), "\n")
+	var tail []string
+	for i := len(lines) - 1; i >= 0 && len(tail) < n; i-- {
+		line := strings.TrimSpace(lines[i])
+		if isAttentionChrome(line) {
+			continue
+		}
+		tail = append(tail, line)
+	}
+	// Restore top-to-bottom order.
+	for l, r := 0, len(tail)-1; l < r; l, r = l+1, r-1 {
+		tail[l], tail[r] = tail[r], tail[l]
+	}
+	return tail
+}
+
+func isAttentionChrome(line string) bool {
+	if line == "" {
+		return true
+	}
+	// Bare prompt / input box lines.
+	trimmed := strings.Trim(line, "─━═│╭╮╰╯┌┐└┘ ")
+	if trimmed == "" || trimmed == ">" || trimmed == "❯" || trimmed == "$" {
+		return true
+	}
+	lower := strings.ToLower(line)
+	return strings.Contains(lower, "for shortcuts") ||
+		strings.Contains(lower, "to cycle") ||
+		strings.Contains(lower, "bypass permissions") ||
+		strings.Contains(lower, "auto-accept edits")
+}
@@ -0,0 +1,102 @@
+package tmux
+
+import "testing"
+
+func TestClassifyAttention(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    Attention
+	}{
+		{
+			name: "permission dialog",
+			content: "╭──────────────────────────╮\n" +
+				"│ Bash command             │\n" +
+				"│ rm -rf build/            │\n" +
+				"│ Do you want to proceed?  │\n" +
+				"│ ❯ 1. Yes                 │\n" +
+				"│   2. No                  │\n" +
+				"╰──────────────────────────╯",
+			want: AttentionPermission,
+		},
+		{
+			name: "plan approval",
+			content: "Claude has written up a plan and is ready to execute. Would you like to proceed?\n" +
+				"❯ 1. Yes, and auto-accept edits\n" +
+				"  2. Yes, and manually approve edits\n" +
+				"  3. No, keep planning",
+			want: AttentionPlanApproval,
+		},
+		{
+			name:    "gemini tool approval",
+			content: "Allow execution of: 'npm test'?\n● 1. Allow once\n  2. Allow always\n",
+			want:    AttentionPermission,
+		},
+		{
+			name:    "shell y/n prompt",
+			content: "Overwrite existing config? (y/n)",
+			want:    AttentionQuestion,
+		},
+		{
+			name: "reply ends on a question above the prompt box",
+			content: "⏺ I found two configs. Should I migrate both or only prod?\n" +
+				"\n" +
+				"╭──────────╮\n│ >        │\n╰──────────╯\n" +
+				"  ? for shortcuts",
+			want: AttentionQuestion,
+		},
+		{
+			name: "go test failure",
+			content: "--- FAIL: TestParse (0.00s)\n" +
+				"FAIL\tgithub.com/x/y\t0.01s\n" +
+				"⏺ The parser test is failing on empty input.\n" +
+				"❯ ",
+			want: AttentionError,
+		},
+		{
+			name:    "python traceback",
+			content: "Traceback (most recent call last):\n  File \"x.py\", line 1\nValueError: bad\n$ ",
+			want:    AttentionError,
+		},
+		{
+			name: "clean finish",
+			content: "⏺ Updated README.md and added the changelog entry.\n" +
+				"✶ Crunched for 41s\n" +
+				"❯ ",
+			want: AttentionDone,
+		},
+		{
+			name:    "prose about errors is not an error",
+			content: "⏺ I added error handling to the loader.\n❯ ",
+			want:    AttentionDone,
+		},
+		{
+			name:    "only chrome",
+			content: "\n❯ \n  ? for shortcuts\n",
+			want:    AttentionNone,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ClassifyAttention(tc.content); got != tc.want {
+				t.Fatalf("ClassifyAttention = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAttentionReason(t *testing.T) {
+	cases := map[Attention]string{
+		AttentionPermission:   "needs permission",
+		AttentionPlanApproval: "plan approval",
+		AttentionQuestion:     "question",
+		AttentionError:        "error",
+		AttentionDone:         "",
+		AttentionNone:         "",
+	}
+	for a, want := range cases {
+		if got := a.Reason(); got != want {
+			t.Errorf("%q.Reason() = %q, want %q", a, got, want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package tmux
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Capabilities describes the host tmux as `agent-deck doctor` reports it.
+type Capabilities struct {
+	// Installed is false when no tmux binary is on PATH; every other field
+	// is then zero.
+	Installed bool
+	// Version is the parsed `tmux -V` version ("3.4", "3.6a", "master"), or
+	// "" when the output was unrecognized.
+	Version string
+	// PipePane reports whether this tmux has pipe-pane, which transcripts
+	// and the PTY output stream rely on.
+	PipePane bool
+	// ServerRunning reports whether a server is listening on the default
+	// socket. StatusLeftLength is only read from a running server.
+	ServerRunning bool
+	// StatusLeftLength is the server's global status-left-length, or -1
+	// when no server is running or the option could not be read.
+	StatusLeftLength int
+}
+
+// ProbeCapabilities inspects the host tmux without starting a server.
+func ProbeCapabilities() Capabilities {
+	caps := Capabilities{StatusLeftLength: -1}
+	if _, err := exec.LookPath("tmux"); err != nil {
+		return caps
+	}
+	caps.Installed = true
+	if raw, err := defaultTmuxVersionProbe(); err == nil {
+		caps.Version = parseTmuxVersion(raw)
+	}
+	// list-commands prints nothing (and still exits 0) for an unknown
+	// command, so the output is what tells them apart.
+	if out, err := tmuxExec(DefaultSocketName(), "list-commands", "pipe-pane").Output(); err == nil {
+		caps.PipePane = strings.HasPrefix(strings.TrimSpace(string(out)), "pipe-pane")
+	}
+	if out, err := tmuxExec(DefaultSocketName(), "show-options", "-gv", "status-left-length").Output(); err == nil {
+		caps.ServerRunning = true
+		if n, err := strconv.Atoi(strings.TrimSpace(string(out))); err == nil {
+			caps.StatusLeftLength = n
+		}
+	}
+	return caps
+}
+
+// VersionAtLeast reports whether ver (as parsed from `tmux -V`) is at least
+// major.minor. master/next builds count as new enough; unparseable versions
+// do not.
+func VersionAtLeast(ver string, major, minor int) bool {
+	if ver == "master" || ver == "next" {
+		return true
+	}
+	m, n, _, ok := splitTmuxVersion(ver)
+	if !ok {
+		return false
+	}
+	return m > major || (m == major && n >= minor)
+}
+
+// IsVulnerableVersion reports whether ver has the control-mode NULL deref
+// WarnIfVulnerableTmux warns about.
+func IsVulnerableVersion(ver string) bool {
+	return isVulnerableTmuxVersion(ver)
+}
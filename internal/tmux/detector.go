@@ -1,6 +1,7 @@
 package tmux
 
 import (
+	"regexp"
 	"strings"
 )
 
@@ -84,6 +85,9 @@ func (d *PromptDetector) HasPrompt(content string) bool {
 	case "cursor":
 		return d.hasCursorPrompt(content)
 
+	case "aider":
+		return d.hasAiderPrompt(content)
+
 	default:
 		// Generic shell - check for common prompts
 		return d.hasShellPrompt(content)
@@ -502,6 +506,25 @@ func (d *PromptDetector) hasCursorPrompt(content string) bool {
 	return d.hasCodexPromptMarker(content)
 }
 
+// aiderPromptRe matches aider's input prompt, which carries the chat mode
+// when it isn't the default "code" mode ("ask> ", "architect> ", "multi> ").
+var aiderPromptRe = regexp.MustCompile(`^(ask|architect|code|context|help|multi)?> ?`)
+
+// hasAiderPrompt detects when Aider is waiting for input. Earlier prompts stay
+// in the scrollback, so only the last non-blank line is considered: the input
+// prompt, or a "(Y)es/(N)o" confirmation (add file, create file, run command).
+func (d *PromptDetector) hasAiderPrompt(content string) bool {
+	lines := strings.Split(content, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(StripANSI(lines[i]))
+		if line == "" {
+			continue
+		}
+		return aiderPromptRe.MatchString(line) || strings.Contains(line, "(Y)es/(N)o")
+	}
+	return false
+}
+
 // hasGeminiPrompt detects if Gemini CLI is waiting for input.
 // Checks last 10 non-blank lines for known Gemini prompt patterns.
 func (d *PromptDetector) hasGeminiPrompt(content string) bool {
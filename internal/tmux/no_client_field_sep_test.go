@@ -146,8 +146,7 @@ func TestNoClientFieldSep_PaneInfoResolves(t *testing.T) {
 	const sess = "agentdeck_conductor-panecase_aabbccdd"
 	socket := makeIsolatedServerNamed(t, sess)
 
-	format := tmuxFmt("#{session_name}", "#{pane_current_command}", "#{pane_dead}", "#{window_index}", "#{pane_index}", "#{pane_title}")
-	out := listPanes(t, socket, format)
+	out := listPanes(t, socket, paneInfoFormat)
 
 	panes, _ := parseListPanesOutput(out)
 	info, ok := panes[sess]
@@ -282,8 +281,9 @@ func TestParseListWindowsOutput_FieldSepInWindowName(t *testing.T) {
 func TestParseListPanesOutput_FieldSepInPaneTitle(t *testing.T) {
 	const sess = "agentdeck_conductor-y_cafebabe"
 	weirdTitle := "Claude | working | foo"
-	// session | command | dead | window_index | pane_index | title
-	line := tmuxFmt(sess, "node", "0", "0", "1", weirdTitle)
+	// session | command | dead | window_index | pane_index | history_size |
+	// history_limit | history_bytes | cursor_y | pid | title
+	line := tmuxFmt(sess, "node", "0", "0", "1", "120", "2000", "9600", "7", "4242", weirdTitle)
 
 	panes, _ := parseListPanesOutput(line)
 	info, ok := panes[sess]
@@ -296,7 +296,16 @@ func TestParseListPanesOutput_FieldSepInPaneTitle(t *testing.T) {
 	if info.Dead {
 		t.Errorf("Dead corrupted by delimiter in title: got true")
 	}
+	if info.PID != 4242 {
+		t.Errorf("PID corrupted by delimiter in title: got %d want 4242", info.PID)
+	}
 	if info.Title != weirdTitle {
 		t.Errorf("pane_title with embedded %q not preserved: got %q want %q", tmuxFieldSep, info.Title, weirdTitle)
 	}
+	if info.HistorySize != 120 || info.CursorY != 7 || info.LineCount() != 128 {
+		t.Errorf("line count fields: history=%d cursor_y=%d LineCount=%d, want 120/7/128", info.HistorySize, info.CursorY, info.LineCount())
+	}
+	if info.HistoryLimit != 2000 || info.HistoryBytes != 9600 {
+		t.Errorf("history fields: limit=%d bytes=%d, want 2000/9600", info.HistoryLimit, info.HistoryBytes)
+	}
 }
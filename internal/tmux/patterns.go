@@ -129,6 +129,13 @@ func DefaultRawPatterns(toolName string) *RawPatterns {
 		return &RawPatterns{
 			PromptPatterns: []string{"$ ", "# ", "% "},
 		}
+	case "aider":
+		// Prompt detection lives in PromptDetector.hasAiderPrompt: aider's
+		// "> " prompt stays in the scrollback after each message, so only the
+		// last line can tell whether it is waiting.
+		return &RawPatterns{
+			BusyPatterns: []string{"Waiting for ", "Updating repo map"},
+		}
 	case "openclaw":
 		return &RawPatterns{
 			BusyPatterns:   []string{"[PROCESSING]", "[CONNECTING]", "[RECONNECTING]"},
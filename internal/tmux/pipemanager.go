@@ -280,7 +280,7 @@ func (pm *PipeManager) RefreshAllPaneInfo() (map[string]PaneInfo, map[string]map
 	// Share the producer format AND parser with the subprocess path
 	// (parseListPanesOutput): pane_title last, tmuxFieldSep-delimited. Keeps the
 	// pipe and subprocess paths from drifting in field order or delimiter.
-	output, err := pipe.SendCommand(`list-panes -a -F "` + tmuxFmt("#{session_name}", "#{pane_current_command}", "#{pane_dead}", "#{window_index}", "#{pane_index}", "#{pane_title}") + `"`)
+	output, err := pipe.SendCommand(`list-panes -a -F "` + paneInfoFormat + `"`)
 	if err != nil {
 		return nil, nil, fmt.Errorf("list-panes via pipe: %w", err)
 	}
@@ -90,6 +90,11 @@ type AttachOptions struct {
 	// that is not reliably available during attach, so a control byte is the
 	// only portable trigger (the cycling/commit UX then lives in the TUI).
 	SwitchKeyByte byte
+	// ReadOnly attaches as a read-only tmux client (`attach-session -r`):
+	// the pane renders live but tmux drops every key except detach and
+	// switch-client bindings, so nothing reaches the attached program. The
+	// detach key still works because the PTY loop intercepts it first.
+	ReadOnly bool
 }
 
 // indexSwitchKey returns the index of the switch key in data and
@@ -245,7 +250,7 @@ func (s *Session) AttachWithOptions(ctx context.Context, opts AttachOptions) (Sw
 	// Routes through s.attachCmd → s.tmuxCmdContext so the -L <SocketName>
 	// selector lands before the subcommand. Pre-v1.7.55 built argv by hand
 	// and silently attached to the user's default server (#687 follow-up).
-	cmd := s.attachCmd(ctx)
+	cmd := s.attachCmdFor(ctx, opts)
 
 	// Temporarily ignore SIGINT for the duration of the attach session.
 	// The global SIGINT handler in main.go calls os.Exit(0); suppressing
@@ -640,6 +645,14 @@ func (s *Session) attachReadOnlyCmd(ctx context.Context) *exec.Cmd {
 	return s.tmuxCmdContext(ctx, "attach-session", "-r", "-t", s.Name)
 }
 
+// attachCmdFor picks the read-write or read-only attach command for opts.
+func (s *Session) attachCmdFor(ctx context.Context, opts AttachOptions) *exec.Cmd {
+	if opts.ReadOnly {
+		return s.attachReadOnlyCmd(ctx)
+	}
+	return s.attachCmd(ctx)
+}
+
 func (s *Session) resizeCmd(cols, rows int) *exec.Cmd {
 	return s.tmuxCmd(
 		"resize-window", "-t", s.Name,
@@ -57,6 +57,21 @@ func TestSession_AttachReadOnlyCmd_WithSocket_PrependsDashL(t *testing.T) {
 	}
 }
 
+// TestSession_AttachCmdFor_ReadOnlyOption: AttachWithOptions picks the
+// read-only attach when AttachOptions.ReadOnly is set (spectate mode), and
+// keeps the socket selector either way.
+func TestSession_AttachCmdFor_ReadOnlyOption(t *testing.T) {
+	s := &Session{Name: "agentdeck_spec_abc", SocketName: "agentdeck"}
+	ro := s.attachCmdFor(context.Background(), AttachOptions{ReadOnly: true})
+	if want := []string{"tmux", "-L", "agentdeck", "attach-session", "-r", "-t", s.Name}; !reflect.DeepEqual(ro.Args, want) {
+		t.Fatalf("read-only option argv\n got:  %v\n want: %v", ro.Args, want)
+	}
+	rw := s.attachCmdFor(context.Background(), AttachOptions{})
+	if want := []string{"tmux", "-L", "agentdeck", "attach-session", "-t", s.Name}; !reflect.DeepEqual(rw.Args, want) {
+		t.Fatalf("default argv\n got:  %v\n want: %v", rw.Args, want)
+	}
+}
+
 // TestSession_ResizeCmd_WithSocket_PrependsDashL: Resize() called resize-window
 // without -L. With isolation on, the user's default server either had no
 // such session (silent no-op) or had a stale one (resized the wrong pane).
@@ -3,6 +3,7 @@ package tmux
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -25,8 +26,56 @@ type PaneInfo struct {
 	Title          string
 	CurrentCommand string
 	Dead           bool
+	// HistorySize and CursorY locate the pane's write position in its
+	// scrollback; see LineCount. HistoryLimit and HistoryBytes let
+	// LinesSince see output once the scrollback is full.
+	HistorySize  int
+	HistoryLimit int
+	HistoryBytes int
+	CursorY      int
+	// PID is the pane's root process (the shell or agent tmux started).
+	PID int
 }
 
+// LineCount is the number of lines the pane currently holds (scrollback +
+// visible rows up to the cursor). It is not a running total: once the
+// scrollback reaches history-limit tmux drops the oldest tenth of it, so the
+// count saws back down while the pane keeps printing. Use LinesSince to
+// measure output between two samples.
+func (p PaneInfo) LineCount() int {
+	return p.HistorySize + p.CursorY + 1
+}
+
+// LinesSince estimates how many lines the pane printed since prev was
+// sampled. cleared reports that the history was wiped (clear-history,
+// respawn) rather than trimmed, so the count restarts from LineCount.
+//
+// When the scrollback is full tmux trims history_limit/10 lines at a time,
+// so a drop that leaves the history at least 90% full is a trim: the lines
+// it removed are added back (assuming one trim between samples). A full
+// scrollback can also scroll without changing the line count; a change in
+// history_bytes then counts as at least one line.
+func (p PaneInfo) LinesSince(prev PaneInfo) (n int, cleared bool) {
+	switch {
+	case p.HistorySize >= prev.HistorySize:
+		n = p.LineCount() - prev.LineCount()
+	case p.HistoryLimit > 0 && p.HistorySize >= p.HistoryLimit-p.HistoryLimit/10:
+		n = p.LineCount() + max(p.HistoryLimit/10, 1) - prev.LineCount()
+	default:
+		return 0, true
+	}
+	if n <= 0 && p.HistoryBytes != prev.HistoryBytes {
+		n = 1
+	}
+	return max(n, 0), false
+}
+
+// paneInfoFormat is the list-panes -F format shared by the subprocess and
+// control-pipe producers (parsed by parseListPanesOutput). pane_title is
+// free-text so it goes LAST; every other field is a sanitized name, integer,
+// or 0/1 flag that cannot contain tmuxFieldSep.
+var paneInfoFormat = tmuxFmt("#{session_name}", "#{pane_current_command}", "#{pane_dead}", "#{window_index}", "#{pane_index}", "#{history_size}", "#{history_limit}", "#{history_bytes}", "#{cursor_y}", "#{pane_pid}", "#{pane_title}")
+
 // WindowInfo holds basic info about a tmux window within a session.
 type WindowInfo struct {
 	Index    int
@@ -125,12 +174,10 @@ func RefreshPaneInfoCache() {
 	// (#687 follow-up, v1.7.55).
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
-	// pane_title is free-text (apps set it via OSC) so it goes LAST; every
-	// other field is a sanitized name, integer, or 0/1 flag that cannot
-	// contain tmuxFieldSep. See tmuxFieldSep for why TAB is unusable here.
+	// pane_title is free-text (apps set it via OSC) so it goes LAST in
+	// paneInfoFormat. See tmuxFieldSep for why TAB is unusable here.
 	cmd := tmuxExecContext(ctx, DefaultSocketName(),
-		"list-panes", "-a", "-F",
-		tmuxFmt("#{session_name}", "#{pane_current_command}", "#{pane_dead}", "#{window_index}", "#{pane_index}", "#{pane_title}"))
+		"list-panes", "-a", "-F", paneInfoFormat)
 	output, err := cmd.Output()
 	if err != nil {
 		paneCacheMu.Lock()
@@ -150,10 +197,8 @@ func RefreshPaneInfoCache() {
 	updateWindowToolCache(windowTools)
 }
 
-// parseListPanesOutput parses `tmux list-panes -a` output in the format
-// tmuxFmt("#{session_name}", "#{pane_current_command}", "#{pane_dead}",
-// "#{window_index}", "#{pane_index}", "#{pane_title}"). pane_title is last so a
-// tmuxFieldSep inside it survives SplitN. Returns the per-session primary
+// parseListPanesOutput parses `tmux list-panes -a` output in paneInfoFormat.
+// pane_title is last so a tmuxFieldSep inside it survives SplitN. Returns the per-session primary
 // PaneInfo and the session→windowIndex→tool map. Extracted from
 // RefreshPaneInfoCache so the no-client delimiter handling is unit-testable.
 func parseListPanesOutput(output string) (map[string]PaneInfo, map[string]map[int]string) {
@@ -165,16 +210,23 @@ func parseListPanesOutput(output string) (map[string]PaneInfo, map[string]map[in
 			continue
 		}
 		// Field order: session_name | pane_current_command | pane_dead |
-		// window_index | pane_index | pane_title (pane_title last, free-text).
-		parts := strings.SplitN(line, tmuxFieldSep, 6)
-		if len(parts) != 6 {
+		// window_index | pane_index | history_size | history_limit |
+		// history_bytes | cursor_y | pane_pid | pane_title (pane_title last,
+		// free-text).
+		parts := strings.SplitN(line, tmuxFieldSep, 11)
+		if len(parts) != 11 {
 			continue
 		}
 		name := parts[0]
 		paneCommand := parts[1]
 		paneDead := parts[2]
 		windowIndex := parts[3]
-		paneTitle := parts[5]
+		historySize, _ := strconv.Atoi(parts[5])
+		historyLimit, _ := strconv.Atoi(parts[6])
+		historyBytes, _ := strconv.Atoi(parts[7])
+		cursorY, _ := strconv.Atoi(parts[8])
+		panePID, _ := strconv.Atoi(parts[9])
+		paneTitle := parts[10]
 
 		// Collect tool info for the first pane of each window (handles any base-index).
 		// list-panes outputs panes sorted by window then pane index, so first hit = primary.
@@ -204,6 +256,11 @@ func parseListPanesOutput(output string) (map[string]PaneInfo, map[string]map[in
 				Title:          paneTitle,
 				CurrentCommand: paneCommand,
 				Dead:           paneDead == "1",
+				HistorySize:    historySize,
+				HistoryLimit:   historyLimit,
+				HistoryBytes:   historyBytes,
+				CursorY:        cursorY,
+				PID:            panePID,
 			}
 		}
 	}
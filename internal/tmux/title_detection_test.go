@@ -286,3 +286,31 @@ func TestUpdateWindowToolCache(t *testing.T) {
 	windowToolCacheData = nil
 	windowToolCacheMu.Unlock()
 }
+
+func TestPaneInfoLinesSince(t *testing.T) {
+	pane := func(history, bytes, cursor int) PaneInfo {
+		return PaneInfo{HistorySize: history, HistoryLimit: 2000, HistoryBytes: bytes, CursorY: cursor}
+	}
+	tests := []struct {
+		name        string
+		prev, cur   PaneInfo
+		want        int
+		wantCleared bool
+	}{
+		{"growing", pane(100, 5000, 10), pane(130, 6500, 10), 30, false},
+		{"cursor only", pane(0, 0, 3), pane(0, 0, 8), 5, false},
+		{"no output", pane(100, 5000, 10), pane(100, 5000, 10), 0, false},
+		// Full scrollback: tmux trimmed 200 lines and 50 new ones arrived.
+		{"trimmed at limit", pane(1990, 99000, 39), pane(1840, 92000, 39), 50, false},
+		{"scrolled at same count", pane(1900, 95000, 39), pane(1900, 95100, 39), 1, false},
+		{"cleared", pane(1990, 99000, 39), pane(0, 0, 2), 0, true},
+		{"respawned", pane(500, 20000, 39), pane(10, 400, 5), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, cleared := tt.cur.LinesSince(tt.prev)
+			assert.Equal(t, tt.want, n)
+			assert.Equal(t, tt.wantCleared, cleared)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -51,18 +52,33 @@ type tmuxThemeStyle struct {
 	hintColor         string
 }
 
+// lightThemes lists the built-in agent-deck themes with a light background.
+// Mirrors internal/session/theme.go::builtinThemeBases (import cycle).
+var lightThemes = map[string]bool{
+	"light":           true,
+	"solarized-light": true,
+}
+
+// resolvedAgentDeckTheme returns the background brightness ("dark" or
+// "light") of the configured theme. A [themes.<name>] palette takes the
+// brightness of its base theme.
 func resolvedAgentDeckTheme() string {
 	type cfg struct {
-		Theme string `toml:"theme"`
+		Theme  string `toml:"theme"`
+		Themes map[string]struct {
+			Base string `toml:"base"`
+		} `toml:"themes"`
 	}
 	if configPath, err := agentpaths.EffectiveConfigPath("config.toml"); err == nil {
 		var c cfg
 		if _, err := toml.DecodeFile(configPath, &c); err == nil {
-			switch c.Theme {
-			case "light", "dark":
-				return c.Theme
-			case "system", "":
+			switch {
+			case c.Theme == "system" || c.Theme == "":
 				// fall through to OS detection
+			case lightThemes[c.Theme]:
+				return "light"
+			case lightThemes[c.Themes[c.Theme].Base]:
+				return "light"
 			default:
 				return "dark"
 			}
@@ -153,7 +169,9 @@ func (s *Session) themedStatusRight(themeStyle tmuxThemeStyle) string {
 	if switchOn {
 		hints += fmt.Sprintf(" · #[fg=%s]%s switch#[default]", themeStyle.hintColor, switchKey)
 	}
-	return fmt.Sprintf("%s │ 📁 %s | %s ", hints, s.DisplayName, s.projectDisplayName())
+	// notificationRef is empty unless the notification bar is placed in
+	// status-right ([notifications] placement).
+	return fmt.Sprintf("%s%s │ 📁 %s | %s ", notificationRef, hints, s.DisplayName, s.projectDisplayName())
 }
 
 func (s *Session) projectDisplayName() string {
@@ -577,7 +595,7 @@ func SupportsHyperlinks() bool {
 }
 
 // Tool detection patterns (used by DetectTool for initial tool identification)
-var toolDetectionOrder = []string{"claude", "gemini", "opencode", "codex", "copilot", "crush", "cursor", "hermes", "pi"}
+var toolDetectionOrder = []string{"claude", "gemini", "opencode", "codex", "copilot", "crush", "cursor", "hermes", "aider", "pi"}
 
 var toolDetectionPatterns = map[string][]*regexp.Regexp{
 	"claude": {
@@ -626,6 +644,11 @@ var toolDetectionPatterns = map[string][]*regexp.Regexp{
 		regexp.MustCompile(`(?i)\bcursor\s+agent\b`),
 		regexp.MustCompile(`(?i)cursor\s+cli\b`),
 	},
+	"aider": {
+		// Aider startup banner ("Aider v0.86.1") and mode prompts.
+		regexp.MustCompile(`(?i)\baider\s+v\d`),
+		regexp.MustCompile(`(?m)^(ask|architect)>\s`),
+	},
 }
 
 func detectToolFromCommand(command string) string {
@@ -654,6 +677,8 @@ func detectToolFromCommand(command string) string {
 			return "cursor"
 		case "hermes":
 			return "hermes"
+		case "aider":
+			return "aider"
 		case "pi":
 			return "pi"
 		}
@@ -676,6 +701,8 @@ func detectToolFromCommand(command string) string {
 		return "cursor"
 	case strings.Contains(cmdLower, "hermes"):
 		return "hermes"
+	case strings.Contains(cmdLower, "aider"):
+		return "aider"
 	case strings.Contains(cmdLower, " pi ") || strings.HasPrefix(cmdLower, "pi "):
 		return "pi"
 	default:
@@ -864,6 +891,11 @@ type Session struct {
 	// without changing the byte-stable canonical status string.
 	lastSubstate Substate
 
+	// lastAttention labels what a waiting session needs (question / error /
+	// done), classified from the same capture as lastSubstate. Surfaced via
+	// CachedAttention for the TUI row glyph and the notification bar.
+	lastAttention Attention
+
 	// hashFallbackOnce gates the one-time hash_fallback_used WARN landmark.
 	// See logging_additions.go and logging-review G8.
 	hashFallbackOnce sync.Once
@@ -874,6 +906,11 @@ type Session struct {
 	// Example: {"allow-passthrough": "all", "history-limit": "50000"}
 	OptionOverrides map[string]string
 
+	// Hooks are user-specified tmux hooks from config (hook name -> command),
+	// installed with `set-hook -t <session>` in Start() after OptionOverrides.
+	// Example: {"pane-died": "run-shell 'notify-send died'"}
+	Hooks map[string]string
+
 	// RunCommandAsInitialProcess launches Start(command) as the pane's initial
 	// process instead of sending it via SendKeysAndEnter after session creation.
 	// Sandbox sessions enable this so pane-dead detection can restart exited tools.
@@ -2057,6 +2094,11 @@ func (s *Session) Start(command string) error {
 		_ = s.tmuxCmd(args...).Run()
 	}
 
+	// Install user-specified session hooks, batched like the overrides above.
+	if len(s.Hooks) > 0 {
+		_ = s.tmuxCmd(buildSetHookArgs(s.Name, s.Hooks)...).Run()
+	}
+
 	// Configure status bar with session info for easy identification
 	// Shows: session title on left, project folder on right
 	s.ConfigureStatusBar()
@@ -2110,6 +2152,24 @@ func (s *Session) Start(command string) error {
 	return nil
 }
 
+// buildSetHookArgs returns a single batched tmux invocation installing every
+// hook on the session. Hook names are sorted so the argv is deterministic.
+func buildSetHookArgs(sessionName string, hooks map[string]string) []string {
+	names := make([]string, 0, len(hooks))
+	for name := range hooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	args := make([]string, 0, len(names)*6)
+	for idx, name := range names {
+		if idx > 0 {
+			args = append(args, ";")
+		}
+		args = append(args, "set-hook", "-t", sessionName, name, hooks[name])
+	}
+	return args
+}
+
 // hasSessionProbeTimeout bounds a `tmux has-session` existence probe. A tmux
 // server that is briefly busy (e.g. tearing down another session) can make the
 // probe hang; rather than block a status poll — or, worse, mistake the stall
@@ -2788,11 +2848,23 @@ func (s *Session) CapturePaneFresh() (string, error) {
 	return content, nil
 }
 
+// DefaultHistoryLines is how much scrollback CaptureFullHistory returns.
+// AI agent conversations can be long - 2000 lines captures ~40-80 screens of
+// content while keeping memory bounded.
+const DefaultHistoryLines = 2000
+
 // CaptureFullHistory captures the scrollback history (limited to last 2000 lines for performance)
 func (s *Session) CaptureFullHistory() (string, error) {
-	// Limit to last 2000 lines to balance content availability with memory usage
-	// AI agent conversations can be long - 2000 lines captures ~40-80 screens of content
-	cmd := s.tmuxCmd("capture-pane", "-t", s.Name, "-p", "-e", "-S", "-2000")
+	return s.CaptureHistory(DefaultHistoryLines)
+}
+
+// CaptureHistory captures the last `lines` lines of scrollback plus the
+// visible screen. lines <= 0 uses DefaultHistoryLines.
+func (s *Session) CaptureHistory(lines int) (string, error) {
+	if lines <= 0 {
+		lines = DefaultHistoryLines
+	}
+	cmd := s.tmuxCmd("capture-pane", "-t", s.Name, "-p", "-e", "-S", fmt.Sprintf("-%d", lines))
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to capture history: %w", err)
@@ -2802,8 +2874,16 @@ func (s *Session) CaptureFullHistory() (string, error) {
 
 // CaptureWindowFullHistory captures the scrollback history of a specific window (last 2000 lines).
 func (s *Session) CaptureWindowFullHistory(windowIndex int) (string, error) {
+	return s.CaptureWindowHistory(windowIndex, DefaultHistoryLines)
+}
+
+// CaptureWindowHistory is CaptureHistory for a specific window.
+func (s *Session) CaptureWindowHistory(windowIndex, lines int) (string, error) {
+	if lines <= 0 {
+		lines = DefaultHistoryLines
+	}
 	target := fmt.Sprintf("%s:%d", s.Name, windowIndex)
-	cmd := s.tmuxCmd("capture-pane", "-t", target, "-p", "-e", "-S", "-2000")
+	cmd := s.tmuxCmd("capture-pane", "-t", target, "-p", "-e", "-S", fmt.Sprintf("-%d", lines))
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to capture window %d history: %w", windowIndex, err)
@@ -2976,6 +3056,7 @@ func (s *Session) GetStatus() (string, error) {
 		// the transition daemon + TUI) cannot emit/show a stale error substate
 		// for a stopped session.
 		s.lastSubstate = SubstateNone
+		s.lastAttention = AttentionNone
 		s.mu.Unlock()
 		statusLog.Debug("session_inactive", slog.String("session", shortName))
 		return "inactive", nil
@@ -2986,6 +3067,7 @@ func (s *Session) GetStatus() (string, error) {
 		s.mu.Lock()
 		s.lastStableStatus = "inactive"
 		s.lastSubstate = SubstateNone
+		s.lastAttention = AttentionNone
 		s.mu.Unlock()
 		statusLog.Debug("pane_dead", slog.String("session", shortName))
 		return "inactive", nil
@@ -3080,6 +3162,7 @@ func (s *Session) GetStatus() (string, error) {
 			// already captured (pure string ops; no extra pane capture). This
 			// keeps lastSubstate fresh for the reporting layers.
 			s.lastSubstate = s.classifySubstate(content)
+			s.lastAttention = ClassifyAttention(content)
 
 			// Honest Status v2: a model-unavailable no-op loop ("X is currently
 			// unavailable" / "Crunched for 0s") is the Fable-down case that this
@@ -4146,6 +4229,15 @@ func (s *Session) CachedSubstate() Substate {
 	return s.lastSubstate
 }
 
+// CachedAttention returns the attention label computed by the last GetStatus
+// capture, without capturing the pane. Only meaningful while the session is
+// waiting; callers gate on status.
+func (s *Session) CachedAttention() Attention {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastAttention
+}
+
 // lastNLines splits content into lines, trims trailing blank lines, and returns
 // the last n lines. Used by busy/prompt detection to focus on recent terminal output.
 func lastNLines(content string, n int) []string {
@@ -5026,6 +5118,60 @@ func ListAgentDeckSessions() ([]string, error) {
 	return sessions, nil
 }
 
+// AgentDeckSessionInfo describes one agentdeck_* tmux session on the default
+// socket, with the agent-deck identity recorded in its environment. InstanceID
+// and Profile are "" for sessions spawned before those variables were set.
+type AgentDeckSessionInfo struct {
+	Name       string
+	WorkDir    string
+	InstanceID string // AGENTDECK_INSTANCE_ID
+	Profile    string // AGENTDECK_PROFILE
+}
+
+// ListAgentDeckSessionsWithIdentity lists agentdeck_* sessions on the default
+// socket together with their AGENTDECK_INSTANCE_ID and AGENTDECK_PROFILE, so
+// callers can match live sessions to instance records by identity rather
+// than by name.
+func ListAgentDeckSessionsWithIdentity() ([]AgentDeckSessionInfo, error) {
+	socket := DefaultSocketName()
+	output, err := tmuxExec(socket, "list-sessions", "-F", "#{session_name}\t#{pane_current_path}").Output()
+	if err != nil {
+		// No sessions exist
+		if strings.Contains(err.Error(), "no server running") ||
+			strings.Contains(err.Error(), "no sessions") {
+			return []AgentDeckSessionInfo{}, nil
+		}
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	envValue := func(name, key string) string {
+		out, err := tmuxExec(socket, "show-environment", "-t", name, key).Output()
+		if err != nil {
+			return ""
+		}
+		line := strings.TrimSpace(string(out))
+		if strings.HasPrefix(line, key+"=") {
+			return strings.TrimPrefix(line, key+"=")
+		}
+		return ""
+	}
+
+	var sessions []AgentDeckSessionInfo
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		name, workDir, _ := strings.Cut(line, "\t")
+		if !strings.HasPrefix(name, SessionPrefix) {
+			continue
+		}
+		sessions = append(sessions, AgentDeckSessionInfo{
+			Name:       name,
+			WorkDir:    workDir,
+			InstanceID: envValue(name, "AGENTDECK_INSTANCE_ID"),
+			Profile:    envValue(name, "AGENTDECK_PROFILE"),
+		})
+	}
+	return sessions, nil
+}
+
 // SetStatusLeft sets the left side of tmux status bar for a session.
 // Used by NotificationManager to display waiting session notifications.
 func SetStatusLeft(sessionName, text string) error {
@@ -5087,6 +5233,103 @@ func ClearStatusLeftGlobal() error {
 	return tmuxExec(socket, "set-option", "-gu", "status-left").Run()
 }
 
+// NotificationOption is the global tmux user option holding the notification
+// bar when it is placed in status-right or the window list instead of
+// status-left. Custom formats can reference it as #{@agentdeck_notifications}.
+const NotificationOption = "@agentdeck_notifications"
+
+// notificationRef is the format reference agent-deck splices into a user's
+// status-right or window-status-current-format.
+const notificationRef = "#{" + NotificationOption + "}"
+
+// savedGlobalFormats holds the user's global status-right and
+// window-status-current-format from before agent-deck spliced
+// notificationRef into them, keyed by option name.
+var savedGlobalFormats struct {
+	sync.Mutex
+	values map[string]string
+}
+
+// spliceNotificationRef adds notificationRef to the global option once,
+// remembering the original so restoreGlobalFormat can put it back. prepend
+// picks the side.
+func spliceNotificationRef(option string, prepend bool) error {
+	savedGlobalFormats.Lock()
+	defer savedGlobalFormats.Unlock()
+	if _, done := savedGlobalFormats.values[option]; done {
+		return nil
+	}
+	socket := DefaultSocketName()
+	out, err := tmuxExec(socket, "show-option", "-gv", option).Output()
+	if err != nil {
+		return err
+	}
+	original := strings.TrimRight(string(out), "\n")
+	value := original + notificationRef
+	if prepend {
+		value = notificationRef + original
+	}
+	if err := tmuxExec(socket, "set-option", "-g", option, value).Run(); err != nil {
+		return err
+	}
+	if savedGlobalFormats.values == nil {
+		savedGlobalFormats.values = make(map[string]string)
+	}
+	savedGlobalFormats.values[option] = original
+	return nil
+}
+
+// restoreGlobalFormat undoes spliceNotificationRef for option.
+func restoreGlobalFormat(option string) error {
+	savedGlobalFormats.Lock()
+	defer savedGlobalFormats.Unlock()
+	original, ok := savedGlobalFormats.values[option]
+	if !ok {
+		return nil
+	}
+	delete(savedGlobalFormats.values, option)
+	return tmuxExec(DefaultSocketName(), "set-option", "-g", option, original).Run()
+}
+
+// SetStatusRightGlobal shows text at the start of status-right, for users
+// whose status-left belongs to another plugin. The text goes into
+// NotificationOption, which agent-deck sessions' own status-right already
+// references; the global status-right gets the reference on first call.
+func SetStatusRightGlobal(text string) error {
+	if err := tmuxExec(DefaultSocketName(), "set-option", "-g", NotificationOption, text+" │ ").Run(); err != nil {
+		return err
+	}
+	return spliceNotificationRef("status-right", true)
+}
+
+// ClearStatusRightGlobal empties the status-right notification text and
+// restores the user's global status-right.
+func ClearStatusRightGlobal() error {
+	if err := tmuxExec(DefaultSocketName(), "set-option", "-gu", NotificationOption).Run(); err != nil {
+		return err
+	}
+	return restoreGlobalFormat("status-right")
+}
+
+// SetWindowStatusGlobal shows text after the current window's name in the
+// window list, leaving both status-left and status-right alone. Window names
+// are not touched: the text is appended through window-status-current-format.
+func SetWindowStatusGlobal(text string) error {
+	if err := tmuxExec(DefaultSocketName(), "set-option", "-g", NotificationOption, " "+text).Run(); err != nil {
+		return err
+	}
+	return spliceNotificationRef("window-status-current-format", false)
+}
+
+// ClearWindowStatusGlobal empties the window-list notification text and
+// restores the user's window-status-current-format.
+func ClearWindowStatusGlobal() error {
+	if err := tmuxExec(DefaultSocketName(), "set-option", "-gu", NotificationOption).Run(); err != nil {
+		return err
+	}
+	return restoreGlobalFormat("window-status-current-format")
+}
+
 // InitializeStatusBarOptions sets optimal status bar options for agent-deck.
 // Fixes truncation by setting adequate status-left-length globally.
 // Should be called once during startup.
@@ -5269,6 +5512,20 @@ func preserveLegacyAckSignalPath(signalFile string) {
 	_ = os.WriteFile(filepath.Join(legacyDir, ackSignalLegacyMarker), []byte{}, 0o600)
 }
 
+// WriteAckSignal records sessionID in the signal file, as the notification-bar
+// switch keys do, so a running agent-deck acknowledges the session on its next
+// tick. Used by switchers that run outside the TUI (`agent-deck popup`).
+func WriteAckSignal(sessionID string) error {
+	signalFile, err := GetAckSignalPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(signalFile), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(signalFile, []byte(sessionID+"\n"), 0o600)
+}
+
 // ReadAndClearAckSignal reads the session ID from the signal file and deletes it.
 // Returns empty string if no signal file exists or on error.
 func ReadAndClearAckSignal() string {
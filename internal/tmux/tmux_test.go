@@ -3228,3 +3228,50 @@ func TestKillSessionsWithEnvValue_NoMatch(t *testing.T) {
 
 	assert.NoError(t, exec.Command("tmux", "has-session", "-t", sess).Run(), "session should not be killed")
 }
+
+func TestBuildSetHookArgs_SortedAndBatched(t *testing.T) {
+	got := buildSetHookArgs("agentdeck_x", map[string]string{
+		"pane-died":       "display died",
+		"client-attached": "display hi",
+	})
+	want := []string{
+		"set-hook", "-t", "agentdeck_x", "client-attached", "display hi", ";",
+		"set-hook", "-t", "agentdeck_x", "pane-died", "display died",
+	}
+	if strings.Join(got, "\x00") != strings.Join(want, "\x00") {
+		t.Fatalf("buildSetHookArgs = %q, want %q", got, want)
+	}
+}
+
+func TestNotificationBar_StatusRightAndWindowPlacement(t *testing.T) {
+	skipIfNoTmuxBinary(t)
+	show := func(option string) string {
+		out, err := exec.Command("tmux", "show-option", "-gqv", option).Output()
+		require.NoError(t, err)
+		return strings.TrimRight(string(out), "\n")
+	}
+	require.NoError(t, exec.Command("tmux", "set-option", "-g", "status-right", "plugin-right").Run())
+	require.NoError(t, exec.Command("tmux", "set-option", "-g", "window-status-current-format", "#I:#W").Run())
+
+	require.NoError(t, SetStatusRightGlobal("[1] api"))
+	require.NoError(t, SetStatusRightGlobal("[1] api [2] web"))
+	assert.Equal(t, notificationRef+"plugin-right", show("status-right"), "reference is spliced in once")
+	assert.True(t, strings.HasPrefix(show(NotificationOption), "[1] api [2] web "), "latest text wins")
+
+	require.NoError(t, ClearStatusRightGlobal())
+	assert.Equal(t, "plugin-right", show("status-right"))
+	assert.Equal(t, "", show(NotificationOption))
+
+	require.NoError(t, SetWindowStatusGlobal("[1] api"))
+	assert.Equal(t, "#I:#W"+notificationRef, show("window-status-current-format"))
+	assert.Equal(t, " [1] api", show(NotificationOption))
+
+	require.NoError(t, ClearWindowStatusGlobal())
+	assert.Equal(t, "#I:#W", show("window-status-current-format"))
+}
+
+func TestThemedStatusRight_ReferencesNotificationOption(t *testing.T) {
+	s := &Session{Name: "agentdeck_x", DisplayName: "x", WorkDir: "/tmp/proj"}
+	assert.True(t, strings.HasPrefix(s.themedStatusRight(currentTmuxThemeStyle()), notificationRef),
+		"agent-deck sessions set their own status-right, so it must carry the status-right bar")
+}
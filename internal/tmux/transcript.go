@@ -0,0 +1,50 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// IsPaneOutputPiped reports whether the session's active pane already has a
+// pipe-pane command attached (tmux's #{pane_pipe}).
+func (s *Session) IsPaneOutputPiped() (bool, error) {
+	out, err := s.tmuxCmd("display-message", "-p", "-t", s.Name, "#{pane_pipe}").Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to query pane pipe: %w", err)
+	}
+	return strings.TrimSpace(string(out)) == "1", nil
+}
+
+// PipeOutputToFile appends everything the pane prints to path via tmux
+// pipe-pane. It is a no-op when the pane is already piped, so callers can
+// invoke it repeatedly (pipe-pane -o would toggle an existing pipe off
+// instead). Returns true when a new pipe was opened.
+//
+// The file is opened O_APPEND by the shell, so it can be rotated in place by
+// copying and truncating it without restarting the pipe.
+func (s *Session) PipeOutputToFile(path string) (bool, error) {
+	piped, err := s.IsPaneOutputPiped()
+	if err != nil || piped {
+		return false, err
+	}
+	if err := s.transcriptPipeCmd(path).Run(); err != nil {
+		return false, fmt.Errorf("failed to start pipe-pane: %w", err)
+	}
+	return true, nil
+}
+
+// StopOutputPipe closes the pane's pipe-pane command, if any.
+func (s *Session) StopOutputPipe() error {
+	return s.pipePaneStopCmd().Run()
+}
+
+func (s *Session) transcriptPipeCmd(path string) *exec.Cmd {
+	return s.tmuxCmd("pipe-pane", "-t", s.Name, "cat >> "+shellSingleQuote(path))
+}
+
+// shellSingleQuote quotes s for /bin/sh, which tmux uses to run pipe-pane
+// commands.
+func shellSingleQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
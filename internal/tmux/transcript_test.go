@@ -0,0 +1,18 @@
+package tmux
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranscriptPipeCmd_QuotesPath(t *testing.T) {
+	s := &Session{Name: "agentdeck_x_1"}
+	cmd := s.transcriptPipeCmd("/tmp/it's here.log")
+	got := cmd.Args[len(cmd.Args)-1]
+	if got != `cat >> '/tmp/it'\''s here.log'` {
+		t.Errorf("pipe command = %q", got)
+	}
+	if !strings.Contains(strings.Join(cmd.Args, " "), "pipe-pane -t agentdeck_x_1") {
+		t.Errorf("argv = %v", cmd.Args)
+	}
+}
@@ -32,6 +32,28 @@ func TestIsVulnerableTmuxVersion(t *testing.T) {
 	}
 }
 
+func TestVersionAtLeast(t *testing.T) {
+	cases := []struct {
+		ver  string
+		want bool
+	}{
+		{"3.2", true},
+		{"3.2a", true},
+		{"3.6a", true},
+		{"4.0", true},
+		{"master", true},
+		{"3.1c", false},
+		{"2.9", false},
+		{"", false},
+		{"garbage", false},
+	}
+	for _, c := range cases {
+		if got := VersionAtLeast(c.ver, 3, 2); got != c.want {
+			t.Errorf("VersionAtLeast(%q, 3, 2) = %v, want %v", c.ver, got, c.want)
+		}
+	}
+}
+
 func TestParseTmuxVersion(t *testing.T) {
 	cases := []struct {
 		raw  string
@@ -66,6 +66,15 @@ func TestXDGPaths_LegacyAckSignalFallbackSurvivesSignalConsumption(t *testing.T)
 	require.Equal(t, legacyAck, ackPath)
 }
 
+func TestWriteAckSignal_RoundTripsOnFreshXDGLayout(t *testing.T) {
+	isolateTmuxXDGPaths(t)
+
+	// The data dir does not exist yet; WriteAckSignal must create it.
+	require.NoError(t, WriteAckSignal("popup-session"))
+	require.Equal(t, "popup-session", ReadAndClearAckSignal())
+	require.Equal(t, "", ReadAndClearAckSignal())
+}
+
 // TestQuickSwitchScript_EnsuresAckSignalDir is a regression test for #1327.
 //
 // The quick-switch bind (Ctrl+b <number>) runs a run-shell script that echoes
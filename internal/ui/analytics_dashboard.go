@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/costs"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// analyticsDashboardWindows are the trailing windows Tab cycles through.
+var analyticsDashboardWindows = []int{7, 30}
+
+// analyticsDashboardMsg delivers the reports built by fetchAnalyticsDashboard.
+type analyticsDashboardMsg struct {
+	days     int
+	groups   session.UsageReport
+	sessions session.UsageReport
+}
+
+// analyticsDashboard is the full-screen cross-session view: tokens per day,
+// cost per group and the busiest sessions over a trailing window. Unlike the
+// per-session analytics panel it answers "which project is burning money".
+type analyticsDashboard struct {
+	width    int
+	height   int
+	days     int
+	loading  bool
+	groups   session.UsageReport
+	sessions session.UsageReport
+}
+
+func newAnalyticsDashboard(width, height int) analyticsDashboard {
+	return analyticsDashboard{width: width, height: height, days: analyticsDashboardWindows[0], loading: true}
+}
+
+// nextWindow returns the window after d.days in analyticsDashboardWindows.
+func (d analyticsDashboard) nextWindow() int {
+	for i, days := range analyticsDashboardWindows {
+		if days == d.days {
+			return analyticsDashboardWindows[(i+1)%len(analyticsDashboardWindows)]
+		}
+	}
+	return analyticsDashboardWindows[0]
+}
+
+// fetchAnalyticsDashboard parses every session's transcript once for the
+// trailing window and aggregates it per group and per session. Parsing runs
+// off the UI goroutine; transcripts can be large.
+func (h *Home) fetchAnalyticsDashboard(days int) tea.Cmd {
+	h.instancesMu.RLock()
+	instances := make([]*session.Instance, len(h.instances))
+	copy(instances, h.instances)
+	h.instancesMu.RUnlock()
+	pricer := h.costPricer
+
+	return func() tea.Msg {
+		now := time.Now()
+		y, m, dd := now.AddDate(0, 0, -(days - 1)).Date()
+		since := time.Date(y, m, dd, 0, 0, 0, 0, now.Location())
+
+		parsed := make(map[*session.Instance]*session.SessionAnalytics, len(instances))
+		usage := func(inst *session.Instance, since time.Time) (*session.SessionAnalytics, error) {
+			if a, ok := parsed[inst]; ok {
+				return a, nil
+			}
+			a, err := session.SessionUsageSince(inst, since)
+			parsed[inst] = a
+			return a, err
+		}
+		var price session.UsagePriceFunc
+		if pricer != nil {
+			price = func(model string, in, out, cacheRead, cacheWrite int64) float64 {
+				return float64(pricer.ComputeCost(model, in, out, cacheRead, cacheWrite)) / 1_000_000
+			}
+		}
+		opts := session.UsageReportOptions{Since: since}
+		groups := session.BuildUsageReport(instances, opts, now, usage, price)
+		opts.By = "session"
+		sessions := session.BuildUsageReport(instances, opts, now, usage, price)
+		return analyticsDashboardMsg{days: days, groups: groups, sessions: sessions}
+	}
+}
+
+func (d analyticsDashboard) View() string {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	labelStyle := lipgloss.NewStyle().Foreground(ColorText)
+	valueStyle := lipgloss.NewStyle().Foreground(ColorCyan).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorText).Underline(true)
+	barStyle := lipgloss.NewStyle().Foreground(ColorGreen)
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf(" Analytics — last %d days", d.days)))
+	b.WriteString("\n\n")
+
+	if d.loading {
+		b.WriteString("  " + dimStyle.Render("Reading session transcripts...") + "\n\n")
+		b.WriteString("  " + dimStyle.Render("Press q or esc to return"))
+		return b.String()
+	}
+
+	total := d.groups.Total
+	b.WriteString(fmt.Sprintf("  %s %s    %s %s    %s %s    %s %s\n\n",
+		labelStyle.Render("Cost:"), valueStyle.Render(fmt.Sprintf("$%.2f", total.CostUSD)),
+		labelStyle.Render("Tokens:"), valueStyle.Render(costs.FormatTokens(total.TotalTokens())),
+		labelStyle.Render("Sessions:"), valueStyle.Render(fmt.Sprintf("%d", total.Sessions)),
+		labelStyle.Render("Turns:"), valueStyle.Render(fmt.Sprintf("%d", total.Turns)),
+	))
+
+	barMax := d.width - 40
+	if barMax < 10 {
+		barMax = 10
+	}
+	if barMax > 50 {
+		barMax = 50
+	}
+
+	// Tokens per day
+	b.WriteString("  " + sectionStyle.Render("Tokens per Day") + "\n")
+	var maxDay int64
+	for _, day := range d.groups.Days {
+		if day.Tokens > maxDay {
+			maxDay = day.Tokens
+		}
+	}
+	if maxDay == 0 {
+		b.WriteString("  " + dimStyle.Render("(no Claude/Gemini/OpenCode activity in this window)") + "\n")
+	} else {
+		for _, day := range d.groups.Days {
+			label := day.Date
+			if t, err := time.Parse("2006-01-02", day.Date); err == nil {
+				label = t.Format("Mon Jan 02")
+			}
+			b.WriteString(fmt.Sprintf("  %-10s %s %s\n", label,
+				barStyle.Render(analyticsBar(day.Tokens, maxDay, barMax)),
+				dimStyle.Render(costs.FormatTokens(day.Tokens))))
+		}
+	}
+	b.WriteString("\n")
+
+	// Cost per group
+	b.WriteString("  " + sectionStyle.Render("Cost by Group") + "\n")
+	if len(d.groups.Rows) == 0 {
+		b.WriteString("  " + dimStyle.Render("(no usage yet)") + "\n")
+	}
+	maxCost := 0.0
+	if len(d.groups.Rows) > 0 {
+		maxCost = d.groups.Rows[0].CostUSD
+	}
+	for i, r := range d.groups.Rows {
+		if i == 8 {
+			b.WriteString("  " + dimStyle.Render(fmt.Sprintf("... %d more (agent-deck report)", len(d.groups.Rows)-i)) + "\n")
+			break
+		}
+		name := r.Key
+		if name == "" {
+			name = "(ungrouped)"
+		}
+		b.WriteString(fmt.Sprintf("  %-24s %s %s\n", truncateStr(name, 24),
+			barStyle.Render(analyticsBar(int64(r.CostUSD*100), int64(maxCost*100), barMax)),
+			valueStyle.Render(fmt.Sprintf("$%.2f", r.CostUSD))))
+	}
+	b.WriteString("\n")
+
+	// Busiest sessions, by tokens
+	b.WriteString("  " + sectionStyle.Render("Busiest Sessions") + "\n")
+	busiest := append([]session.UsageReportRow(nil), d.sessions.Rows...)
+	sort.SliceStable(busiest, func(i, j int) bool { return busiest[i].TotalTokens() > busiest[j].TotalTokens() })
+	if len(busiest) == 0 {
+		b.WriteString("  " + dimStyle.Render("(no usage yet)") + "\n")
+	}
+	for i, r := range busiest {
+		if i == 5 {
+			break
+		}
+		b.WriteString(fmt.Sprintf("  %d. %-30s %8s  %4d turns  %s\n", i+1, truncateStr(r.Key, 30),
+			costs.FormatTokens(r.TotalTokens()), r.Turns, valueStyle.Render(fmt.Sprintf("$%.2f", r.CostUSD))))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("  " + dimStyle.Render("Tab: switch window · r: refresh · q or esc: return"))
+	return b.String()
+}
+
+// analyticsBar renders value as a bar of up to width cells relative to max.
+func analyticsBar(value, max int64, width int) string {
+	if max <= 0 || value <= 0 {
+		return ""
+	}
+	n := int(value * int64(width) / max)
+	if n == 0 {
+		n = 1
+	}
+	return strings.Repeat("█", n)
+}
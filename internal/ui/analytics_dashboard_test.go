@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestAnalyticsDashboard_OpenSwitchWindowAndClose(t *testing.T) {
+	home, _ := newMultiSelectHome(t)
+
+	_, cmd := home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	if !home.showAnalyticsDash || !home.analyticsDash.loading || cmd == nil {
+		t.Fatalf("H should open the dashboard and start loading (show=%v loading=%v cmd=%v)",
+			home.showAnalyticsDash, home.analyticsDash.loading, cmd != nil)
+	}
+	if !strings.Contains(home.analyticsDash.View(), "Reading session transcripts") {
+		t.Error("loading view should be shown while transcripts are parsed")
+	}
+
+	// Shell sessions have no transcripts: the report comes back empty.
+	home.Update(cmd())
+	if home.analyticsDash.loading {
+		t.Fatal("dashboard should stop loading once the report arrives")
+	}
+	if !strings.Contains(home.analyticsDash.View(), "no Claude/Gemini/OpenCode activity") {
+		t.Error("empty window should say there is no activity")
+	}
+
+	_, cmd = home.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if home.analyticsDash.days != 30 || cmd == nil {
+		t.Fatalf("Tab should switch to the 30-day window, got %d", home.analyticsDash.days)
+	}
+	// A late result for the old window must not replace the new one.
+	home.Update(analyticsDashboardMsg{days: 7})
+	if !home.analyticsDash.loading {
+		t.Error("stale 7-day result should be ignored while 30 days load")
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if home.showAnalyticsDash {
+		t.Error("esc should close the dashboard")
+	}
+}
+
+func TestAnalyticsDashboard_View(t *testing.T) {
+	d := analyticsDashboard{width: 120, days: 7}
+	d.groups = session.UsageReport{
+		Rows: []session.UsageReportRow{
+			{Key: "clients/acme", Sessions: 2, CostUSD: 4.5},
+			{Key: "", Sessions: 1, CostUSD: 0.5},
+		},
+		Total: session.UsageReportRow{Key: "TOTAL", Sessions: 3, Turns: 12, InputTokens: 120000, CostUSD: 5},
+		Days:  []session.UsageDay{{Date: "2026-03-01", Tokens: 100000}, {Date: "2026-03-02", Tokens: 20000}},
+	}
+	d.sessions = session.UsageReport{Rows: []session.UsageReportRow{
+		{Key: "cheap-but-busy", Turns: 9, InputTokens: 90000, CostUSD: 1},
+		{Key: "pricey", Turns: 3, InputTokens: 30000, CostUSD: 4},
+	}}
+	out := d.View()
+	for _, want := range []string{"last 7 days", "$5.00", "120.0K", "clients/acme", "(ungrouped)", "Sun Mar 01", "100.0K"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "cheap-but-busy") > strings.Index(out, "pricey") {
+		t.Error("busiest sessions should be ordered by tokens, not cost")
+	}
+}
+
+func TestAnalyticsBar(t *testing.T) {
+	if got := analyticsBar(50, 100, 10); got != strings.Repeat("█", 5) {
+		t.Errorf("half bar = %q", got)
+	}
+	if got := analyticsBar(1, 1000, 10); got != "█" {
+		t.Errorf("tiny nonzero value should still draw one cell, got %q", got)
+	}
+	if analyticsBar(0, 100, 10) != "" || analyticsBar(5, 0, 10) != "" {
+		t.Error("zero value or max should draw nothing")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/asheshgoplani/agent-deck/internal/git"
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -17,6 +18,12 @@ type AnalyticsPanel struct {
 	width           int
 	height          int
 	displaySettings session.AnalyticsDisplaySettings
+
+	// Files the session modified (see files_touched.go); the list is
+	// collapsed to its header until toggled with filesTouchedKey.
+	filesTouched         []git.FileChange
+	filesTouchedExpanded bool
+	filesTouchedKey      string
 }
 
 // NewAnalyticsPanel creates a new analytics panel
@@ -41,6 +48,14 @@ func (p *AnalyticsPanel) SetGeminiAnalytics(a *session.GeminiSessionAnalytics) {
 	p.analytics = nil // Clear Claude analytics when setting Gemini
 }
 
+// SetFilesTouched sets the touched-files list and whether it is expanded.
+// toggleKey is shown in the collapsed header as the way to expand it.
+func (p *AnalyticsPanel) SetFilesTouched(files []git.FileChange, expanded bool, toggleKey string) {
+	p.filesTouched = files
+	p.filesTouchedExpanded = expanded
+	p.filesTouchedKey = toggleKey
+}
+
 // SetSize sets the panel dimensions
 func (p *AnalyticsPanel) SetSize(width, height int) {
 	p.width = width
@@ -93,6 +108,20 @@ func (p *AnalyticsPanel) View() string {
 		sectionsRendered++
 	}
 
+	// Sub-agents (default: ON, only when the session spawned any)
+	if p.displaySettings.GetShowSubagents() && len(p.analytics.Subagents) > 0 {
+		b.WriteString(p.renderSubagents(time.Now()))
+		b.WriteString("\n")
+		sectionsRendered++
+	}
+
+	// Files touched (default: ON, only when the session changed any)
+	if p.displaySettings.GetShowFilesTouched() && len(p.filesTouched) > 0 {
+		b.WriteString(p.renderFilesTouched())
+		b.WriteString("\n")
+		sectionsRendered++
+	}
+
 	// Cost estimate (default: OFF)
 	if p.displaySettings.GetShowCost() && (p.analytics.EstimatedCost > 0 || p.analytics.TotalTokens() > 0) {
 		b.WriteString(p.renderCost())
@@ -140,6 +169,13 @@ func (p *AnalyticsPanel) renderGeminiView() string {
 		sectionsRendered++
 	}
 
+	// Files touched (default: ON, only when the session changed any)
+	if p.displaySettings.GetShowFilesTouched() && len(p.filesTouched) > 0 {
+		b.WriteString(p.renderFilesTouched())
+		b.WriteString("\n")
+		sectionsRendered++
+	}
+
 	// Cost estimate (default: OFF)
 	if p.displaySettings.GetShowCost() && p.geminiAnalytics.TotalTokens() > 0 {
 		b.WriteString(p.renderGeminiCost())
@@ -316,7 +352,7 @@ func (p *AnalyticsPanel) renderEmpty() string {
 	b.WriteString("\n\n")
 	b.WriteString(dimStyle.Render("No analytics available"))
 	b.WriteString("\n")
-	b.WriteString(dimStyle.Render("(Claude/Gemini sessions only)"))
+	b.WriteString(dimStyle.Render("(Claude/Gemini/OpenCode sessions only)"))
 
 	return b.String()
 }
@@ -507,6 +543,117 @@ func (p *AnalyticsPanel) renderToolCalls() string {
 	return b.String()
 }
 
+// renderSubagents lists the session's Task sub-agents as a tree, most recent
+// last, with running ones marked
+func (p *AnalyticsPanel) renderSubagents(now time.Time) string {
+	labelStyle := lipgloss.NewStyle().Foreground(ColorText).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(ColorPurple)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	activeStyle := lipgloss.NewStyle().Foreground(ColorGreen)
+
+	agents := p.analytics.Subagents
+	active := 0
+	for _, a := range agents {
+		if a.Active(now) {
+			active++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(labelStyle.Render("Sub-agents"))
+	if active > 0 {
+		b.WriteString(activeStyle.Render(fmt.Sprintf(" %d running", active)))
+	}
+	b.WriteString("\n")
+
+	// Show the last 5; older ones are usually long finished
+	const maxAgents = 5
+	if len(agents) > maxAgents {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  ...%d earlier\n", len(agents)-maxAgents)))
+		agents = agents[len(agents)-maxAgents:]
+	}
+
+	for i, a := range agents {
+		branch := "├─"
+		if i == len(agents)-1 {
+			branch = "└─"
+		}
+		name := a.Prompt
+		if name == "" {
+			name = a.ID
+		}
+		state := fmt.Sprintf("%d turns", a.Turns)
+		if a.Active(now) {
+			state = activeStyle.Render("● ") + dimStyle.Render(state)
+		} else {
+			state = dimStyle.Render(state)
+		}
+		b.WriteString(fmt.Sprintf("  %s %s %s\n",
+			dimStyle.Render(branch),
+			nameStyle.Render(truncateStr(name, max(p.width-20, 20))),
+			state,
+		))
+	}
+
+	return b.String()
+}
+
+// renderFilesTouched renders the "Files touched (N)" header with the
+// session's total added/deleted lines and, when expanded, one row per file,
+// largest change first.
+func (p *AnalyticsPanel) renderFilesTouched() string {
+	labelStyle := lipgloss.NewStyle().Foreground(ColorText).Bold(true)
+	addStyle := lipgloss.NewStyle().Foreground(ColorGreen)
+	delStyle := lipgloss.NewStyle().Foreground(ColorRed)
+	pathStyle := lipgloss.NewStyle().Foreground(ColorText)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+
+	added, deleted := 0, 0
+	for _, f := range p.filesTouched {
+		added += f.Added
+		deleted += f.Deleted
+	}
+
+	var b strings.Builder
+	arrow := "▸"
+	if p.filesTouchedExpanded {
+		arrow = "▾"
+	}
+	b.WriteString(labelStyle.Render(fmt.Sprintf("%s Files touched (%d)", arrow, len(p.filesTouched))))
+	b.WriteString(" " + addStyle.Render(fmt.Sprintf("+%d", added)) + " " + delStyle.Render(fmt.Sprintf("-%d", deleted)))
+	if !p.filesTouchedExpanded {
+		if p.filesTouchedKey != "" {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("  (%s to expand)", p.filesTouchedKey)))
+		}
+		b.WriteString("\n")
+		return b.String()
+	}
+	b.WriteString("\n")
+
+	// Cap the list so the output section keeps room below the panel
+	maxFiles := 15
+	if p.height > 0 {
+		maxFiles = max(min(maxFiles, p.height-2), 3)
+	}
+	files := p.filesTouched
+	if len(files) > maxFiles {
+		files = files[:maxFiles]
+	}
+	for _, f := range files {
+		counts := dimStyle.Render(fmt.Sprintf("%-11s", "bin"))
+		if !f.Binary {
+			plus, minus := fmt.Sprintf("+%d", f.Added), fmt.Sprintf("-%d", f.Deleted)
+			counts = addStyle.Render(fmt.Sprintf("%5s", plus)) + " " + delStyle.Render(fmt.Sprintf("%-5s", minus))
+		}
+		b.WriteString(fmt.Sprintf("  %s %s\n", counts, pathStyle.Render(truncateStr(f.Path, max(p.width-16, 20)))))
+	}
+	if len(p.filesTouched) > len(files) {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  ...and %d more\n", len(p.filesTouched)-len(files))))
+	}
+
+	return b.String()
+}
+
 // renderCost renders the estimated cost
 func (p *AnalyticsPanel) renderCost() string {
 	labelStyle := lipgloss.NewStyle().Foreground(ColorText).Bold(true)
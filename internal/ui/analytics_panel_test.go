@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/asheshgoplani/agent-deck/internal/git"
 	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
@@ -607,3 +608,62 @@ func TestAnalyticsPanel_SetDisplaySettings(t *testing.T) {
 		t.Error("View should NOT show tools when disabled")
 	}
 }
+
+func TestAnalyticsPanel_RenderSubagents(t *testing.T) {
+	now := time.Now()
+	panel := NewAnalyticsPanel()
+	panel.SetSize(80, 40)
+	panel.SetAnalytics(&session.SessionAnalytics{
+		Subagents: []session.SubagentInfo{
+			{ID: "a1", Turns: 4, Prompt: "Find the config loader", LastActive: now.Add(-time.Hour)},
+			{ID: "b2", Turns: 1, LastActive: now},
+		},
+	})
+
+	view := panel.View()
+	for _, want := range []string{"Sub-agents", "1 running", "├─", "Find the config loader", "└─", "b2", "4 turns"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	off := false
+	panel.SetDisplaySettings(session.AnalyticsDisplaySettings{ShowSubagents: &off})
+	if strings.Contains(panel.View(), "Sub-agents") {
+		t.Error("sub-agents shown with show_subagents = false")
+	}
+}
+
+func TestAnalyticsPanel_FilesTouched(t *testing.T) {
+	panel := NewAnalyticsPanel()
+	panel.SetAnalytics(&session.SessionAnalytics{InputTokens: 10})
+	panel.SetSize(80, 40)
+	files := []git.FileChange{
+		{Path: "internal/ui/home.go", Added: 40, Deleted: 2},
+		{Path: "README.md", Added: 1},
+		{Path: "logo.png", Binary: true},
+	}
+
+	panel.SetFilesTouched(files, false, "alt+d")
+	collapsed := panel.View()
+	if !strings.Contains(collapsed, "Files touched (3)") || !strings.Contains(collapsed, "+41") || !strings.Contains(collapsed, "-2") {
+		t.Errorf("collapsed header missing count/totals:\n%s", collapsed)
+	}
+	if strings.Contains(collapsed, "internal/ui/home.go") || !strings.Contains(collapsed, "alt+d to expand") {
+		t.Errorf("collapsed section should hide files and show the toggle key:\n%s", collapsed)
+	}
+
+	panel.SetFilesTouched(files, true, "alt+d")
+	expanded := panel.View()
+	for _, want := range []string{"internal/ui/home.go", "README.md", "logo.png", "bin"} {
+		if !strings.Contains(expanded, want) {
+			t.Errorf("expanded section missing %q:\n%s", want, expanded)
+		}
+	}
+
+	off := false
+	panel.SetDisplaySettings(session.AnalyticsDisplaySettings{ShowFilesTouched: &off})
+	if strings.Contains(panel.View(), "Files touched") {
+		t.Error("show_files_touched = false should hide the section")
+	}
+}
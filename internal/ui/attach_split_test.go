@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/terminal"
+)
+
+func TestAttachSplit_OutsideTmuxReportsError(t *testing.T) {
+	t.Setenv("TMUX", "")
+	home, _, _ := armHomeWithOneSession(t)
+	called := false
+	home.surroundingTmuxSink = func(terminal.AttachRequest, string) error {
+		called = true
+		return nil
+	}
+
+	_, _ = home.handleMainKey(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+
+	if called {
+		t.Fatal("attach_split must not open a pane when agent-deck is not inside tmux")
+	}
+	if home.err == nil {
+		t.Fatal("expected an error explaining attach_split needs tmux")
+	}
+}
+
+func TestAttachSplit_StoppedSessionIsNotOpened(t *testing.T) {
+	t.Setenv("TMUX", "/tmp/tmux-test/default,1,0")
+	home, _, _ := armHomeWithOneSession(t)
+	called := false
+	home.surroundingTmuxSink = func(terminal.AttachRequest, string) error {
+		called = true
+		return nil
+	}
+
+	_, _ = home.handleMainKey(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+
+	if called {
+		t.Fatal("a session without a live tmux session must not be opened in a pane")
+	}
+	if home.err == nil {
+		t.Fatal("expected an error pointing at Enter to restart the session")
+	}
+}
+
+func TestSplitAttachMode_DefaultsToPane(t *testing.T) {
+	if got := splitAttachMode(); got != session.AttachModePane {
+		t.Fatalf("splitAttachMode() with no [attach] config = %q, want %q", got, session.AttachModePane)
+	}
+}
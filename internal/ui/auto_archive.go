@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// autoArchiveCheckInterval is how often the TUI applies [archive] idle_after.
+// Thresholds are hours or days, so a coarse sweep is plenty.
+const autoArchiveCheckInterval = 5 * time.Minute
+
+// autoArchiveIdleSessions returns a command per session the [archive]
+// settings say has been idle long enough. Each one stops the session and
+// reports back through sessionArchivedMsg, which persists the archive the
+// same way the manual archive key does.
+func (h *Home) autoArchiveIdleSessions(settings session.ArchiveSettings, now time.Time) tea.Cmd {
+	h.instancesMu.RLock()
+	candidates := session.IdleArchiveCandidates(h.instances, settings, now)
+	h.instancesMu.RUnlock()
+	if len(candidates) == 0 {
+		return nil
+	}
+	idleAfter := settings.GetIdleAfter()
+	cmds := make([]tea.Cmd, 0, len(candidates))
+	for _, inst := range candidates {
+		h.captureAutoNameBeforeStop(inst)
+		idle := now.Sub(inst.LastActiveAt())
+		cmds = append(cmds, func() tea.Msg {
+			if err := session.ArchiveInstance(inst, inst.Kill, now); err != nil {
+				return sessionArchivedMsg{sessionID: inst.ID, killErr: err, auto: true}
+			}
+			_ = session.WriteSessionLifecycleEvent(session.SessionLifecycleEvent{
+				InstanceID: inst.ID,
+				Action:     session.ReasonIdleAutoArchived,
+				Reason:     fmt.Sprintf("idle for %s (idle_after=%s)", idle.Round(time.Minute), idleAfter),
+			})
+			return sessionArchivedMsg{sessionID: inst.ID, auto: true}
+		})
+	}
+	return tea.Batch(cmds...)
+}
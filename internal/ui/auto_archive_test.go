@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestAutoArchiveIdleSessions_DisabledIsNoop(t *testing.T) {
+	home, _ := newMultiSelectHome(t)
+	if cmd := home.autoArchiveIdleSessions(session.ArchiveSettings{}, time.Now()); cmd != nil {
+		t.Fatal("no idle_after configured: sweep must not return a command")
+	}
+}
+
+func TestAutoArchiveIdleSessions_ArchivesOnlyIdleSessions(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	now := time.Now()
+	for _, inst := range insts {
+		inst.Status = session.StatusIdle
+		inst.CreatedAt = now.Add(-48 * time.Hour)
+	}
+	insts[1].Status = session.StatusRunning
+	insts[2].LastAccessedAt = now.Add(-time.Hour)
+	insts[3].ClaudeSessionID = "keep-me"
+
+	cmd := home.autoArchiveIdleSessions(session.ArchiveSettings{IdleAfter: "24h"}, now)
+	if cmd == nil {
+		t.Fatal("expected a sweep command")
+	}
+	var msgs []sessionArchivedMsg
+	var collect func(tea.Msg)
+	collect = func(msg tea.Msg) {
+		switch m := msg.(type) {
+		case tea.BatchMsg:
+			for _, c := range m {
+				collect(c())
+			}
+		case sessionArchivedMsg:
+			msgs = append(msgs, m)
+		}
+	}
+	collect(cmd())
+
+	if len(msgs) != 2 {
+		t.Fatalf("archived %d sessions, want 2 (a, d): %+v", len(msgs), msgs)
+	}
+	for _, m := range msgs {
+		if !m.auto || m.killErr != nil {
+			t.Fatalf("unexpected msg %+v", m)
+		}
+	}
+	if !insts[0].IsArchived() || !insts[3].IsArchived() {
+		t.Fatal("idle sessions a and d should be archived")
+	}
+	if insts[1].IsArchived() || insts[2].IsArchived() {
+		t.Fatal("running and recently attached sessions must not be archived")
+	}
+	if insts[3].ClaudeSessionID != "keep-me" {
+		t.Fatal("auto-archive must preserve the Claude session ID")
+	}
+
+	home.Update(msgs[0])
+	if home.maintenanceMsg == "" {
+		t.Fatal("auto-archive should surface a banner")
+	}
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/costs"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// budgetCheckInterval throttles [costs.budgets] evaluation; spend only moves
+// when a turn finishes, so the status bar does not need per-tick accuracy.
+const budgetCheckInterval = 30 * time.Second
+
+// budgetTargets maps each group path (and its ancestors) to the sessions
+// under it, and each live session ID to its title.
+func (h *Home) budgetTargets() costs.BudgetTargets {
+	targets := costs.BudgetTargets{Groups: map[string][]string{}, Sessions: map[string]string{}}
+	h.instancesMu.RLock()
+	defer h.instancesMu.RUnlock()
+	for _, inst := range h.instances {
+		if inst == nil || inst.IsArchived() {
+			continue
+		}
+		targets.Sessions[inst.ID] = inst.Title
+		parts := strings.Split(inst.GroupPath, "/")
+		for i := range parts {
+			path := strings.Join(parts[:i+1], "/")
+			targets.Groups[path] = append(targets.Groups[path], inst.ID)
+		}
+	}
+	return targets
+}
+
+// checkBudgetAlerts refreshes h.budgetAlerts and announces budgets that
+// crossed 80% or 100% since the last check with a status banner.
+func (h *Home) checkBudgetAlerts() tea.Cmd {
+	alerts := h.costBudget.Alerts(h.budgetTargets())
+	h.budgetAlerts = alerts
+
+	levels := make(map[string]costs.BudgetAction, len(alerts))
+	var crossed *costs.BudgetResult
+	for i, a := range alerts {
+		key := budgetAlertKey(a)
+		levels[key] = a.Action
+		if a.Action > h.budgetAlertLevels[key] && crossed == nil {
+			crossed = &alerts[i]
+		}
+	}
+	h.budgetAlertLevels = levels
+	if crossed == nil {
+		return nil
+	}
+
+	prefix := "Budget warning"
+	if crossed.Action == costs.BudgetActionStop {
+		prefix = "Over budget"
+	}
+	h.maintenanceMsg = fmt.Sprintf("%s: %s (press $ for costs)", prefix, crossed.Describe())
+	h.maintenanceMsgTime = time.Now()
+	return tea.Tick(30*time.Second, func(_ time.Time) tea.Msg {
+		return clearMaintenanceMsg{}
+	})
+}
+
+func budgetAlertKey(r costs.BudgetResult) string {
+	if r.Tokens {
+		return r.Scope + "#tokens"
+	}
+	return r.Scope
+}
+
+// renderBudgetAlertSegment renders the worst active budget alert for the
+// status bar, or "" when every budget is under 80%.
+func (h *Home) renderBudgetAlertSegment() string {
+	if len(h.budgetAlerts) == 0 {
+		return ""
+	}
+	worst := h.budgetAlerts[0]
+	text := "⚠ " + worst.Describe()
+	color := ColorYellow
+	if worst.Action == costs.BudgetActionStop {
+		text = "⛔ over budget: " + worst.Describe()
+		color = ColorRed
+	}
+	if more := len(h.budgetAlerts) - 1; more > 0 {
+		text += fmt.Sprintf(" (+%d)", more)
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(text)
+}
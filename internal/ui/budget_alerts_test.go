@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/costs"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+func TestCheckBudgetAlerts_BannerOnCrossing(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	sdb, err := statedb.Open(filepath.Join(t.TempDir(), "costs.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sdb.Close() })
+	store := costs.NewStore(sdb.DB())
+	home.costBudget = costs.NewBudgetChecker(costs.BudgetConfig{
+		GroupLimits: map[string]int64{"work": 10_000_000},
+	}, store)
+
+	// Session c lives in work/sub, so it counts toward the work budget.
+	if err := store.WriteCostEvent(costs.CostEvent{ID: "e1", SessionID: insts[2].ID, Timestamp: time.Now(), Model: "m", CostMicrodollars: 8_500_000}); err != nil {
+		t.Fatal(err)
+	}
+	if cmd := home.checkBudgetAlerts(); cmd == nil {
+		t.Fatal("crossing 80% should schedule a banner")
+	}
+	if !strings.Contains(home.maintenanceMsg, "Budget warning: group work 85%") {
+		t.Errorf("maintenanceMsg = %q", home.maintenanceMsg)
+	}
+	if seg := home.renderBudgetAlertSegment(); !strings.Contains(seg, "group work 85% of $10.00") {
+		t.Errorf("status segment = %q", seg)
+	}
+
+	home.maintenanceMsg = ""
+	if cmd := home.checkBudgetAlerts(); cmd != nil || home.maintenanceMsg != "" {
+		t.Error("an unchanged warning should not be re-announced")
+	}
+
+	if err := store.WriteCostEvent(costs.CostEvent{ID: "e2", SessionID: insts[0].ID, Timestamp: time.Now(), Model: "m", CostMicrodollars: 2_000_000}); err != nil {
+		t.Fatal(err)
+	}
+	home.checkBudgetAlerts()
+	if !strings.Contains(home.maintenanceMsg, "Over budget: group work") {
+		t.Errorf("maintenanceMsg after 100%% = %q", home.maintenanceMsg)
+	}
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// shortSessionID trims a UUID to its first block for display.
+func shortSessionID(id string) string {
+	if i := strings.IndexByte(id, '-'); i > 0 {
+		return id[:i]
+	}
+	return id
+}
+
+// renderClaudeIDMismatchWarning renders the preview warning shown when the
+// pane is writing a different Claude transcript than the stored session ID
+// (see session.VerifyClaudeSessionID). fixKey is the fix_session_id binding.
+// Returns "" when there is no mismatch.
+func renderClaudeIDMismatchWarning(m *session.ClaudeIDMismatch, fixKey string, width int) string {
+	if m == nil {
+		return ""
+	}
+	warnStyle := lipgloss.NewStyle().Foreground(ColorYellow).Bold(true)
+	textStyle := lipgloss.NewStyle().Foreground(ColorText)
+	keyStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(warnStyle.Render("⚠ Session ID mismatch"))
+	b.WriteString("\n")
+	detail := fmt.Sprintf("  pane is writing %s, stored ID is %s",
+		shortSessionID(m.ActiveID), shortSessionID(m.StoredID))
+	b.WriteString(textStyle.Render(cellTruncate(detail, max(width-4, 10), "…")))
+	b.WriteString("\n")
+	if fixKey != "" {
+		b.WriteString("  ")
+		b.WriteString(keyStyle.Render(fixKey))
+		b.WriteString(textStyle.Render(" adopt the active transcript"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// adoptClaudeSessionID applies a detected session ID mismatch for inst and
+// persists the new ID.
+func (h *Home) adoptClaudeSessionID(inst *session.Instance) {
+	if inst == nil || inst.ClaudeSessionIDMismatch() == nil {
+		return
+	}
+	newID, err := inst.AdoptClaudeSessionIDMismatch()
+	if err != nil {
+		h.setError(fmt.Errorf("fix session ID: %w", err))
+		return
+	}
+	h.saveInstances()
+	h.maintenanceMsg = fmt.Sprintf("Session %q now tracks Claude session %s", inst.Title, shortSessionID(newID))
+	h.maintenanceMsgTime = time.Now()
+}
@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// claudeModelChoices are the model aliases offered by the Claude model
+// dialog. "" means no per-session override ([claude].default_model, or
+// Claude's own default).
+var claudeModelChoices = []string{"", "sonnet", "opus", "haiku"}
+
+// claudeEffortChoices are the effort levels offered by the dialog, lowest
+// first. "" leaves Claude's default thinking behavior.
+var claudeEffortChoices = []string{"", "low", "medium", "high"}
+
+// claudeModelSelectedMsg is sent when the user confirms a model and effort
+type claudeModelSelectedMsg struct {
+	model      string
+	effort     string
+	instanceID string
+}
+
+// ClaudeModelDialog allows selecting the Claude model and thinking effort for
+// the current session
+type ClaudeModelDialog struct {
+	visible    bool
+	width      int
+	height     int
+	cursor     int
+	models     []string
+	effortIdx  int
+	instanceID string // ID of the session to change model for
+	current    string // Currently active model
+}
+
+// NewClaudeModelDialog creates a new Claude model selection dialog
+func NewClaudeModelDialog() *ClaudeModelDialog {
+	return &ClaudeModelDialog{}
+}
+
+// Show opens the dialog with the session's current model and effort selected.
+// A full model ID that isn't one of the aliases is kept as an extra choice.
+func (d *ClaudeModelDialog) Show(instanceID, currentModel, currentEffort string) {
+	d.visible = true
+	d.instanceID = instanceID
+	d.current = currentModel
+	d.models = append([]string(nil), claudeModelChoices...)
+	d.cursor = -1
+	for i, m := range d.models {
+		if m == currentModel {
+			d.cursor = i
+		}
+	}
+	if d.cursor < 0 {
+		d.models = append(d.models, currentModel)
+		d.cursor = len(d.models) - 1
+	}
+	d.effortIdx = 0
+	for i, e := range claudeEffortChoices {
+		if e == currentEffort {
+			d.effortIdx = i
+		}
+	}
+}
+
+// Hide closes the dialog
+func (d *ClaudeModelDialog) Hide() {
+	d.visible = false
+}
+
+// IsVisible returns whether the dialog is visible
+func (d *ClaudeModelDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions
+func (d *ClaudeModelDialog) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+}
+
+// Selected returns the highlighted model and effort.
+func (d *ClaudeModelDialog) Selected() (model, effort string) {
+	if d.cursor >= 0 && d.cursor < len(d.models) {
+		model = d.models[d.cursor]
+	}
+	return model, claudeEffortChoices[d.effortIdx]
+}
+
+// Update handles input for the dialog
+func (d *ClaudeModelDialog) Update(msg tea.KeyMsg) (*ClaudeModelDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		d.Hide()
+		return d, nil
+
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+
+	case "down", "j":
+		if d.cursor < len(d.models)-1 {
+			d.cursor++
+		}
+
+	case "left", "h":
+		if d.effortIdx > 0 {
+			d.effortIdx--
+		}
+
+	case "right", "l", "tab":
+		if d.effortIdx < len(claudeEffortChoices)-1 {
+			d.effortIdx++
+		}
+
+	case "enter":
+		model, effort := d.Selected()
+		instanceID := d.instanceID
+		d.Hide()
+		return d, func() tea.Msg {
+			return claudeModelSelectedMsg{model: model, effort: effort, instanceID: instanceID}
+		}
+	}
+
+	return d, nil
+}
+
+// claudeModelLabel is how a model choice is shown in the dialog.
+func claudeModelLabel(model string) string {
+	if model == "" {
+		return "default"
+	}
+	return model
+}
+
+// View renders the dialog
+func (d *ClaudeModelDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorComment)
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+	currentStyle := lipgloss.NewStyle().
+		Foreground(ColorGreen)
+	effortSelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorBg).
+		Background(ColorAccent).
+		Padding(0, 1)
+	effortStyle := lipgloss.NewStyle().
+		Foreground(ColorText).
+		Padding(0, 1)
+
+	dialogWidth := 50
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = d.width - 10
+		if dialogWidth < 35 {
+			dialogWidth = 35
+		}
+	}
+
+	var content strings.Builder
+
+	content.WriteString(titleStyle.Render("Select Claude Model"))
+	content.WriteString(dimStyle.Render("            [Esc] Cancel"))
+	content.WriteString("\n")
+	content.WriteString(strings.Repeat("-", dialogWidth-4))
+	content.WriteString("\n\n")
+
+	for i, model := range d.models {
+		prefix := "  "
+		if i == d.cursor {
+			prefix = "> "
+		}
+		line := prefix + claudeModelLabel(model)
+		if model == d.current {
+			line += " (current)"
+		}
+		switch {
+		case i == d.cursor:
+			content.WriteString(selectedStyle.Render(line))
+		case model == d.current:
+			content.WriteString(currentStyle.Render(line))
+		default:
+			content.WriteString(line)
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(dimStyle.Render("Effort (thinking budget):"))
+	content.WriteString("\n")
+	efforts := make([]string, 0, len(claudeEffortChoices))
+	for i, e := range claudeEffortChoices {
+		label := claudeModelLabel(e)
+		if i == d.effortIdx {
+			efforts = append(efforts, effortSelStyle.Render(label))
+		} else {
+			efforts = append(efforts, effortStyle.Render(label))
+		}
+	}
+	content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, efforts...))
+	content.WriteString("\n")
+	if budget, ok := session.ClaudeEffortLevels[claudeEffortChoices[d.effortIdx]]; ok {
+		content.WriteString(dimStyle.Render(fmt.Sprintf("  MAX_THINKING_TOKENS=%d", budget)))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(dimStyle.Render("j/k Model  h/l Effort  Enter Apply (restarts)"))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorAccent).
+		Background(ColorBg).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	dialog := dialogStyle.Render(content.String())
+
+	return lipgloss.Place(
+		d.width,
+		d.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+// claudeModelBadge is the session-list badge for a Claude session with a
+// per-session model or effort, e.g. "opus·high". Empty when neither is set.
+func claudeModelBadge(opts *session.ClaudeOptions) string {
+	if opts == nil {
+		return ""
+	}
+	parts := make([]string, 0, 2)
+	if opts.Model != "" {
+		parts = append(parts, opts.Model)
+	}
+	if _, ok := session.ClaudeEffortLevels[opts.Effort]; ok {
+		parts = append(parts, opts.Effort)
+	}
+	return strings.Join(parts, "·")
+}
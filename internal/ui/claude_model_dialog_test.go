@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestClaudeModelDialog_SelectsModelAndEffort(t *testing.T) {
+	d := NewClaudeModelDialog()
+	d.Show("inst-1", "sonnet", "")
+
+	if model, effort := d.Selected(); model != "sonnet" || effort != "" {
+		t.Fatalf("initial selection = %q/%q, want sonnet/default", model, effort)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyDown})
+	d.Update(tea.KeyMsg{Type: tea.KeyRight})
+	d.Update(tea.KeyMsg{Type: tea.KeyRight})
+	d.Update(tea.KeyMsg{Type: tea.KeyRight})
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter returned no command")
+	}
+	msg, ok := cmd().(claudeModelSelectedMsg)
+	if !ok {
+		t.Fatalf("enter produced %T, want claudeModelSelectedMsg", cmd())
+	}
+	if msg.instanceID != "inst-1" || msg.model != "opus" || msg.effort != "high" {
+		t.Errorf("selected %+v, want inst-1 opus/high", msg)
+	}
+	if d.IsVisible() {
+		t.Error("dialog still visible after enter")
+	}
+}
+
+func TestClaudeModelDialog_KeepsCustomModelID(t *testing.T) {
+	d := NewClaudeModelDialog()
+	d.Show("inst-1", "claude-opus-4-1-20250805", "medium")
+
+	if model, effort := d.Selected(); model != "claude-opus-4-1-20250805" || effort != "medium" {
+		t.Errorf("selection = %q/%q, want the pinned model ID and medium", model, effort)
+	}
+}
+
+func TestClaudeModelBadge(t *testing.T) {
+	tests := []struct {
+		opts *session.ClaudeOptions
+		want string
+	}{
+		{nil, ""},
+		{&session.ClaudeOptions{}, ""},
+		{&session.ClaudeOptions{Model: "opus"}, "opus"},
+		{&session.ClaudeOptions{Effort: "low"}, "low"},
+		{&session.ClaudeOptions{Model: "haiku", Effort: "high"}, "haiku·high"},
+	}
+	for _, tt := range tests {
+		if got := claudeModelBadge(tt.opts); got != tt.want {
+			t.Errorf("claudeModelBadge(%+v) = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// claudePermissionDescriptions explains each permission mode in the dialog.
+var claudePermissionDescriptions = map[string]string{
+	session.ClaudePermissionPlan:    "Read-only: research and propose a plan, no edits",
+	session.ClaudePermissionDefault: "Ask before edits and commands",
+	session.ClaudePermissionAuto:    "Auto-approve safe actions via classifier",
+	session.ClaudePermissionSkip:    "Never ask (--dangerously-skip-permissions)",
+}
+
+// claudePermissionSelectedMsg is sent when the user picks a permission mode
+type claudePermissionSelectedMsg struct {
+	mode       string
+	instanceID string
+}
+
+// ClaudePermissionDialog switches a Claude session between permission modes
+type ClaudePermissionDialog struct {
+	visible    bool
+	width      int
+	height     int
+	cursor     int
+	instanceID string // ID of the session to change
+	current    string // Mode the session is launched with
+}
+
+// NewClaudePermissionDialog creates a new permission mode dialog
+func NewClaudePermissionDialog() *ClaudePermissionDialog {
+	return &ClaudePermissionDialog{}
+}
+
+// Show opens the dialog with the session's current mode selected
+func (d *ClaudePermissionDialog) Show(instanceID, currentMode string) {
+	d.visible = true
+	d.instanceID = instanceID
+	d.current = currentMode
+	d.cursor = 0
+	for i, m := range session.ClaudePermissionModes {
+		if m == currentMode {
+			d.cursor = i
+		}
+	}
+}
+
+// Hide closes the dialog
+func (d *ClaudePermissionDialog) Hide() {
+	d.visible = false
+}
+
+// IsVisible returns whether the dialog is visible
+func (d *ClaudePermissionDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions
+func (d *ClaudePermissionDialog) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+}
+
+// Update handles input for the dialog
+func (d *ClaudePermissionDialog) Update(msg tea.KeyMsg) (*ClaudePermissionDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		d.Hide()
+		return d, nil
+
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+
+	case "down", "j":
+		if d.cursor < len(session.ClaudePermissionModes)-1 {
+			d.cursor++
+		}
+
+	case "enter":
+		mode := session.ClaudePermissionModes[d.cursor]
+		instanceID := d.instanceID
+		d.Hide()
+		if mode == d.current {
+			return d, nil
+		}
+		return d, func() tea.Msg {
+			return claudePermissionSelectedMsg{mode: mode, instanceID: instanceID}
+		}
+	}
+
+	return d, nil
+}
+
+// View renders the dialog
+func (d *ClaudePermissionDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorComment)
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+	currentStyle := lipgloss.NewStyle().
+		Foreground(ColorGreen)
+
+	dialogWidth := 60
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = d.width - 10
+		if dialogWidth < 35 {
+			dialogWidth = 35
+		}
+	}
+
+	var content strings.Builder
+
+	content.WriteString(titleStyle.Render("Claude Permission Mode"))
+	content.WriteString(dimStyle.Render("          [Esc] Cancel"))
+	content.WriteString("\n")
+	content.WriteString(strings.Repeat("-", dialogWidth-4))
+	content.WriteString("\n\n")
+
+	for i, mode := range session.ClaudePermissionModes {
+		prefix := "  "
+		if i == d.cursor {
+			prefix = "> "
+		}
+		line := prefix + mode
+		if mode == d.current {
+			line += " (current)"
+		}
+		switch {
+		case i == d.cursor:
+			content.WriteString(selectedStyle.Render(line))
+		case mode == d.current:
+			content.WriteString(currentStyle.Render(line))
+		default:
+			content.WriteString(line)
+		}
+		content.WriteString("\n")
+		content.WriteString(dimStyle.Render("    " + claudePermissionDescriptions[mode]))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(dimStyle.Render("j/k Navigate  Enter Apply (restarts)  Esc Cancel"))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorAccent).
+		Background(ColorBg).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	return lipgloss.Place(
+		d.width,
+		d.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialogStyle.Render(content.String()),
+	)
+}
+
+// claudePermissionMode is the mode a Claude session launches with: its own
+// options, or the [claude] config defaults when it has none (the same
+// fallback buildClaudeCommand uses).
+func claudePermissionMode(inst *session.Instance) string {
+	if opts := inst.GetClaudeOptions(); opts != nil {
+		return opts.PermissionMode()
+	}
+	cfg, _ := session.LoadUserConfig()
+	return session.NewClaudeOptions(cfg).PermissionMode()
+}
+
+// claudePermissionBadge is the session-list badge for a non-default mode.
+func claudePermissionBadge(mode string) (label string, color lipgloss.Color) {
+	switch mode {
+	case session.ClaudePermissionPlan:
+		return "PLAN", ColorCyan
+	case session.ClaudePermissionAuto:
+		return "AUTO", ColorYellow
+	case session.ClaudePermissionSkip:
+		return "SKIP-PERMS", ColorRed
+	}
+	return "", ""
+}
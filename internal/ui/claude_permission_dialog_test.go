@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestClaudePermissionDialog_EmitsOnlyChangedMode(t *testing.T) {
+	d := NewClaudePermissionDialog()
+	d.Show("inst-1", session.ClaudePermissionDefault)
+
+	if _, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("confirming the current mode should not emit a change")
+	}
+
+	d.Show("inst-1", session.ClaudePermissionDefault)
+	d.Update(tea.KeyMsg{Type: tea.KeyUp})
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on a new mode returned no command")
+	}
+	msg, ok := cmd().(claudePermissionSelectedMsg)
+	if !ok || msg.mode != session.ClaudePermissionPlan || msg.instanceID != "inst-1" {
+		t.Errorf("got %+v, want plan for inst-1", cmd())
+	}
+}
@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// paletteMaxRows caps the visible result rows; the list scrolls past it.
+const paletteMaxRows = 12
+
+// paletteChromeRows is the height of everything around the result rows
+// (border, padding, title, input, hints).
+const paletteChromeRows = 12
+
+// paletteActionLabels names the home-screen actions the command palette
+// offers. Actions missing here (detach, the palette itself, preview
+// scrolling) are not useful to run from a list.
+var paletteActionLabels = map[string]string{
+	hotkeyQuit:             "Quit",
+	hotkeyNewSession:       "New session",
+	hotkeyQuickCreate:      "Quick create session",
+	hotkeyRename:           "Rename session or group",
+	hotkeyRestart:          "Restart session",
+	hotkeyRestartFresh:     "Restart session with a new session ID",
+	hotkeyDelete:           "Delete session or group",
+	hotkeyCloseSession:     "Close session process",
+	hotkeyArchiveSession:   "Archive session",
+	hotkeyUnarchiveSession: "Unarchive session",
+	hotkeyViewArchived:     "Toggle archived view",
+	hotkeyUndoDelete:       "Undo delete",
+	hotkeyMoveToGroup:      "Move session or group to group",
+	hotkeyMCPManager:       "MCP manager",
+	hotkeyMCPPool:          "MCP pool dashboard",
+	hotkeyPluginManager:    "Plugin manager",
+	hotkeySkillsManager:    "Skills manager",
+	hotkeyTogglePreview:    "Cycle preview mode",
+	hotkeyCycleGroupView:   "Cycle group view",
+	hotkeyBranchView:       "Toggle branch view",
+	hotkeyCycleSort:        "Cycle sort order",
+	hotkeyCollapseAll:      "Collapse all groups",
+	hotkeyExpandAll:        "Expand all groups",
+	hotkeyExpandWaiting:    "Expand groups with waiting sessions",
+	hotkeyMarkUnread:       "Mark session unread",
+	hotkeyQuickApprove:     "Quick approve",
+	hotkeyPromptSession:    "Prompt session",
+	hotkeyPasteFile:        "Paste file into session",
+	hotkeyToggleYolo:       "Toggle YOLO mode",
+	hotkeyPermissionMode:   "Claude permission mode",
+	hotkeyQuickFork:        "Fork session",
+	hotkeyForkWithOptions:  "Fork session with options",
+	hotkeyCloneSession:     "Clone session (fresh conversation)",
+	hotkeyCopyOutput:       "Copy output to clipboard",
+	hotkeyCopyPane:         "Copy pane content to clipboard",
+	hotkeySendOutput:       "Send output to another session",
+	hotkeyExecShell:        "Exec shell in sandbox container",
+	hotkeyEditNotes:        "Edit notes",
+	hotkeyEditPaths:        "Edit multi-repo paths",
+	hotkeyEditSession:      "Edit session settings",
+	hotkeyWorktreeSetup:    "Re-run worktree setup script",
+	hotkeyWorktreeFinish:   "Finish worktree",
+	hotkeyCreatePR:         "Push branch and open pull request",
+	hotkeyWorktreeDiff:     "Diff worktree against base branch",
+	hotkeyCreateGroup:      "New group",
+	hotkeyMuteGroup:        "Mute/unmute group notifications",
+	hotkeyArchiveGroup:     "Archive/unarchive group",
+	hotkeyArchivedGroups:   "Show/hide archived groups",
+	hotkeySearch:           "Search sessions",
+	hotkeyHelp:             "Help",
+	hotkeySettings:         "Settings",
+	hotkeyImport:           "Import tmux sessions",
+	hotkeyReload:           "Reload from disk",
+	hotkeyWatcherPanel:     "Watcher panel",
+	hotkeyFixSessionID:     "Adopt detected Claude session ID",
+	hotkeyToggleSelect:     "Mark session for bulk actions",
+	hotkeyAnalyticsDash:    "Analytics dashboard",
+	hotkeyViewTranscript:   "View transcript",
+	hotkeyProfileSwitcher:  "Switch profile",
+	hotkeyMoveToProfile:    "Move session to profile",
+	hotkeyTrashView:        "Trash",
+	hotkeySessionTimeline:  "Session timeline",
+	hotkeyConversation:     "View conversation",
+	hotkeyOpenFile:         "Open file from preview in $EDITOR",
+	hotkeyFilesTouched:     "Expand/collapse files touched",
+	hotkeyVerify:           "Run verify command",
+	hotkeySnapshots:        "Snapshots and rollback",
+	hotkeyAttach:           "Attach to session",
+	hotkeyAttachSplit:      "Attach in a tmux pane beside the dashboard",
+	hotkeySpectate:         "Spectate session (read-only attach)",
+	hotkeyFilterAll:        "Filter: show all",
+	hotkeyFilterRunning:    "Filter: running",
+	hotkeyFilterWaiting:    "Filter: waiting",
+	hotkeyFilterIdle:       "Filter: idle",
+	hotkeyFilterError:      "Filter: error (cost dashboard when tracking costs)",
+	hotkeyFilterOpen:       "Filter: open",
+	hotkeyFilterTag:        "Filter: cycle tag",
+	hotkeySwitchSession:    "Switch session",
+	hotkeyRecentSessions:   "Recent sessions",
+}
+
+// paletteCommand is one entry of the command palette: either a hotkey action
+// or a jump to a group.
+type paletteCommand struct {
+	label     string
+	key       string // bound key shown on the right; "" for group jumps
+	action    string // hotkey action name; "" for group jumps
+	groupPath string
+}
+
+// CommandPalette is a fuzzy-searchable list of every home-screen action plus
+// a "Go to group" entry per group, so features are reachable without
+// remembering their key.
+type CommandPalette struct {
+	visible  bool
+	input    textinput.Model
+	commands []paletteCommand
+	matches  []paletteCommand
+	cursor   int
+	offset   int
+	width    int
+	height   int
+}
+
+// NewCommandPalette creates a hidden palette.
+func NewCommandPalette() *CommandPalette {
+	ti := textinput.New()
+	ti.Placeholder = "Type a command or group..."
+	ti.CharLimit = 100
+	ti.Width = 50
+	return &CommandPalette{input: ti}
+}
+
+// Show opens the palette with the actions bound in bindings (unbound actions
+// cannot be dispatched, so they are left out) and a jump entry for each of
+// groupPaths.
+func (p *CommandPalette) Show(bindings map[string]string, groupPaths []string) {
+	p.commands = p.commands[:0]
+	for _, action := range hotkeyActionOrder {
+		label, ok := paletteActionLabels[action]
+		key := actionHotkey(bindings, action)
+		if !ok || key == "" {
+			continue
+		}
+		p.commands = append(p.commands, paletteCommand{label: label, key: key, action: action})
+	}
+	for _, path := range groupPaths {
+		p.commands = append(p.commands, paletteCommand{label: "Go to group: " + path, groupPath: path})
+	}
+	p.visible = true
+	p.input.SetValue("")
+	p.input.Focus()
+	p.filter()
+}
+
+// Hide closes the palette.
+func (p *CommandPalette) Hide() {
+	p.visible = false
+	p.input.Blur()
+}
+
+// IsVisible reports whether the palette is shown.
+func (p *CommandPalette) IsVisible() bool { return p.visible }
+
+// SetSize updates the viewport used for centering.
+func (p *CommandPalette) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// Selected returns the highlighted command, or nil when nothing matches.
+func (p *CommandPalette) Selected() *paletteCommand {
+	if p.cursor < 0 || p.cursor >= len(p.matches) {
+		return nil
+	}
+	cmd := p.matches[p.cursor]
+	return &cmd
+}
+
+// paletteSource adapts the command list to fuzzy.Source.
+type paletteSource []paletteCommand
+
+func (s paletteSource) String(i int) string { return s[i].label }
+func (s paletteSource) Len() int            { return len(s) }
+
+// filter recomputes matches for the current query: every command in list
+// order when empty, otherwise fuzzy matches best first.
+func (p *CommandPalette) filter() {
+	query := strings.TrimSpace(p.input.Value())
+	if query == "" {
+		p.matches = append(p.matches[:0], p.commands...)
+	} else {
+		p.matches = p.matches[:0]
+		for _, m := range fuzzy.FindFrom(query, paletteSource(p.commands)) {
+			p.matches = append(p.matches, p.commands[m.Index])
+		}
+	}
+	p.cursor = 0
+	p.offset = 0
+}
+
+// Update handles navigation and query editing. Enter and Esc are handled by
+// the caller.
+func (p *CommandPalette) Update(msg tea.KeyMsg) (*CommandPalette, tea.Cmd) {
+	switch msg.String() {
+	case "up", "ctrl+p":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "ctrl+n":
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+	default:
+		before := p.input.Value()
+		var cmd tea.Cmd
+		p.input, cmd = p.input.Update(msg)
+		if p.input.Value() != before {
+			p.filter()
+		}
+		return p, cmd
+	}
+	if rows := p.visibleRows(); p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+rows {
+		p.offset = p.cursor - rows + 1
+	}
+	return p, nil
+}
+
+// visibleRows is how many results fit: paletteMaxRows, fewer on a short
+// terminal, never less than 3.
+func (p *CommandPalette) visibleRows() int {
+	if p.height <= 0 {
+		return paletteMaxRows
+	}
+	return max(min(paletteMaxRows, p.height-paletteChromeRows), 3)
+}
+
+// View renders the overlay, centered in the viewport.
+func (p *CommandPalette) View() string {
+	if !p.visible {
+		return ""
+	}
+	dialogWidth := fitDialogWidth(60, 40, p.width)
+	innerWidth := dialogWidth - 4
+
+	rowStyle := lipgloss.NewStyle().Foreground(ColorText)
+	selStyle := lipgloss.NewStyle().Foreground(ColorBg).Background(ColorAccent).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	selKeyStyle := lipgloss.NewStyle().Foreground(ColorBg).Background(ColorAccent)
+
+	var rows []string
+	if len(p.matches) == 0 {
+		rows = append(rows, keyStyle.Render("No matching commands"))
+	}
+	end := min(p.offset+p.visibleRows(), len(p.matches))
+	for i := p.offset; i < end; i++ {
+		c := p.matches[i]
+		label := truncateStr(c.label, max(innerWidth-lipgloss.Width(c.key)-3, 1))
+		gap := max(innerWidth-lipgloss.Width(label)-lipgloss.Width(c.key)-2, 1)
+		if i == p.cursor {
+			rows = append(rows, selStyle.Render(" "+label+strings.Repeat(" ", gap))+selKeyStyle.Render(c.key+" "))
+		} else {
+			rows = append(rows, rowStyle.Render(" "+label+strings.Repeat(" ", gap))+keyStyle.Render(c.key+" "))
+		}
+	}
+	if len(p.matches) > p.visibleRows() {
+		rows = append(rows, keyStyle.Render(fmt.Sprintf(" %d/%d", p.cursor+1, len(p.matches))))
+	}
+
+	hint := lipgloss.NewStyle().Foreground(ColorComment).Render("↑/↓ navigate │ Enter run │ Esc close")
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		DialogTitleStyle.Render("Command Palette"),
+		"",
+		p.input.View(),
+		"",
+		strings.Join(rows, "\n"),
+		"",
+		hint,
+	)
+	box := DialogBoxStyle.Width(dialogWidth).Render(content)
+	return lipgloss.Place(p.width, p.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPaletteActionLabels_CoverHotkeyActions(t *testing.T) {
+	notListed := map[string]bool{
+		hotkeyDetach:            true, // only meaningful while attached
+		hotkeyCommandPalette:    true,
+		hotkeyPreviewScrollUp:   true,
+		hotkeyPreviewScrollDown: true,
+	}
+	for _, action := range hotkeyActionOrder {
+		if _, ok := paletteActionLabels[action]; !ok && !notListed[action] {
+			t.Errorf("action %q has no command palette label", action)
+		}
+	}
+}
+
+func TestKeyMsgFromBinding_RoundTripsDefaults(t *testing.T) {
+	for action, binding := range defaultHotkeyBindings {
+		msg, ok := keyMsgFromBinding(binding)
+		if !ok {
+			t.Errorf("%s: binding %q did not parse", action, binding)
+			continue
+		}
+		found := false
+		for _, alias := range hotkeyAliases(binding) {
+			if msg.String() == alias {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: binding %q produced key %q", action, binding, msg.String())
+		}
+	}
+}
+
+func TestCommandPalette_FiltersAndSkipsUnbound(t *testing.T) {
+	p := NewCommandPalette()
+	bindings := resolveHotkeys(map[string]string{"settings": ""})
+	p.Show(bindings, []string{"work/frontend"})
+
+	for _, c := range p.matches {
+		if c.action == hotkeySettings {
+			t.Fatal("unbound settings action should not be listed")
+		}
+	}
+
+	for _, r := range "frontend" {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	sel := p.Selected()
+	if sel == nil || sel.groupPath != "work/frontend" {
+		t.Fatalf("Selected() = %+v, want the work/frontend group jump", sel)
+	}
+}
+
+func TestCommandPalette_EnterRunsAction(t *testing.T) {
+	h := NewHome()
+	h.width, h.height = 120, 40
+
+	h.handleMainKey(tea.KeyMsg{Type: tea.KeyCtrlK})
+	if !h.commandPalette.IsVisible() {
+		t.Fatal("ctrl+k should open the command palette")
+	}
+	for _, r := range "help" {
+		h.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if sel := h.commandPalette.Selected(); sel == nil || sel.action != hotkeyHelp {
+		t.Fatalf("Selected() = %+v, want help", sel)
+	}
+	h.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if h.commandPalette.IsVisible() {
+		t.Error("palette should close after running a command")
+	}
+	if !h.helpOverlay.IsVisible() {
+		t.Error("running Help from the palette should open the help overlay")
+	}
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// configDebounce coalesces the burst of events one save produces (editors
+// write a temp file, rename it over config.toml, then chmod it).
+const configDebounce = 300 * time.Millisecond
+
+// ConfigWatcher signals when config.toml changes on disk so the TUI can apply
+// the new settings without a restart. It watches the file's directory rather
+// than the file: editors and SaveUserConfig replace config.toml by rename,
+// which would orphan a watch on the old inode.
+// Follows the same pattern as StorageWatcher: goroutine + buffered channel + Close().
+type ConfigWatcher struct {
+	path      string
+	watcher   *fsnotify.Watcher
+	changeCh  chan struct{}
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewConfigWatcher starts watching the config file at path. The directory must
+// exist; a config.toml created later (e.g. by the setup wizard) is picked up.
+func NewConfigWatcher(path string) (*ConfigWatcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Add(filepath.Dir(path)); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	cw := &ConfigWatcher{
+		path:     filepath.Clean(path),
+		watcher:  w,
+		changeCh: make(chan struct{}, 1),
+		closeCh:  make(chan struct{}),
+	}
+	go cw.watchLoop()
+	return cw, nil
+}
+
+func (cw *ConfigWatcher) watchLoop() {
+	var debounce *time.Timer
+	var fire <-chan time.Time
+	defer func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+	}()
+	for {
+		select {
+		case <-cw.closeCh:
+			return
+		case event, ok := <-cw.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != cw.path || event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Rename) == 0 {
+				continue
+			}
+			if debounce == nil {
+				debounce = time.NewTimer(configDebounce)
+			} else {
+				debounce.Reset(configDebounce)
+			}
+			fire = debounce.C
+		case <-fire:
+			fire = nil
+			// Non-blocking send (drop if a reload is already pending)
+			select {
+			case cw.changeCh <- struct{}{}:
+			default:
+			}
+		case err, ok := <-cw.watcher.Errors:
+			if ok && err != nil {
+				uiLog.Warn("config_watcher_error", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// ChangeChannel returns the channel that signals a config.toml change.
+func (cw *ConfigWatcher) ChangeChannel() <-chan struct{} {
+	return cw.changeCh
+}
+
+// Close stops the watcher. Safe to call multiple times.
+func (cw *ConfigWatcher) Close() {
+	cw.closeOnce.Do(func() {
+		close(cw.closeCh)
+		_ = cw.watcher.Close()
+	})
+}
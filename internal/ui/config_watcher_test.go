@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigWatcher_DetectsRenameSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte("theme = \"dark\"\n"), 0o600))
+
+	watcher, err := NewConfigWatcher(path)
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	// Unrelated files in the same directory are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "state.json"), []byte("{}"), 0o600))
+	select {
+	case <-watcher.ChangeChannel():
+		t.Fatal("change signal for a file other than config.toml")
+	case <-time.After(2 * configDebounce):
+	}
+
+	// Atomic save: write a temp file and rename it over config.toml.
+	tmp := filepath.Join(dir, "config.toml.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("theme = \"light\"\n"), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+
+	select {
+	case <-watcher.ChangeChannel():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change signal after config.toml was replaced")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/asheshgoplani/agent-deck/internal/session"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -25,7 +26,10 @@ const (
 	ConfirmBulkRemoveErrored // bulk remove of all errored sessions (TUI Ctrl+X)
 	ConfirmArchiveSession
 	ConfirmUnarchiveSession
-	ConfirmNotice // acknowledge-only message (single OK button), e.g. protected-action blocks
+	ConfirmNotice             // acknowledge-only message (single OK button), e.g. protected-action blocks
+	ConfirmBulkDeleteSessions // delete every marked session (multi-select)
+	ConfirmTmuxDoctor         // repair tmux drift found at startup (agent-deck doctor)
+	ConfirmCreatePullRequest  // push a worktree branch and open a PR (TUI 'B')
 )
 
 // ConfirmDialog handles confirmation for destructive actions
@@ -39,13 +43,28 @@ type ConfirmDialog struct {
 	mcpCount    int  // Number of running MCPs (for quit confirmation)
 	sandboxed   bool // Whether the session uses a Docker sandbox.
 	worktree    bool // Whether the session has an associated git worktree.
+	// worktreeCleanup is what deleting does to the worktree(s); `w` cycles it.
+	worktreeCleanup session.WorktreeCleanup
 
 	remoteName string // Remote name for remote session confirmations.
 
+	// Bulk delete (ConfirmBulkDeleteSessions) carries the marked session IDs
+	// and how many of them own a worktree.
+	targetIDs     []string
+	worktreeCount int
+
 	// Notice (ConfirmNotice) carries an acknowledge-only title/body.
 	noticeTitle string
 	noticeBody  string
 
+	// Tmux doctor (ConfirmTmuxDoctor) carries the repairable issues found.
+	tmuxIssues []session.TmuxIssue
+
+	// Pull request (ConfirmCreatePullRequest) carries the branch and the
+	// remote it will be pushed to.
+	prBranch string
+	prRemote string
+
 	// focusedButton tracks which button has arrow-key focus.
 	// 0 = confirm (left), 1 = cancel (right).
 	// For ConfirmQuitWithPool: 0 = keep, 1 = shutdown.
@@ -64,6 +83,8 @@ type ConfirmDialog struct {
 	pendingLaunchModelID     string          // Optional per-session model/version override.
 	pendingParentSessionID   string
 	pendingParentProjectPath string
+	pendingMCPNames          []string // MCPs from the selected session template
+	pendingInitialPrompt     string   // Initial prompt from the new-session dialog
 }
 
 // NewConfirmDialog creates a new confirmation dialog
@@ -79,6 +100,7 @@ func (c *ConfirmDialog) ShowDeleteSession(sessionID string, sessionName string,
 	c.targetName = sessionName
 	c.sandboxed = sandboxed
 	c.worktree = worktree
+	c.worktreeCleanup = session.WorktreeCleanupRemove
 	c.buttonCount = 2
 	c.focusedButton = 1 // default to Cancel
 }
@@ -161,6 +183,20 @@ func (c *ConfirmDialog) ShowBulkRemoveErrored(count int) {
 	c.focusedButton = 1
 }
 
+// ShowBulkDeleteSessions shows confirmation for deleting every marked session.
+// worktrees is how many of them have a git worktree that will be removed.
+func (c *ConfirmDialog) ShowBulkDeleteSessions(sessionIDs []string, worktrees int) {
+	c.visible = true
+	c.confirmType = ConfirmBulkDeleteSessions
+	c.targetID = ""
+	c.targetName = ""
+	c.targetIDs = sessionIDs
+	c.worktreeCount = worktrees
+	c.worktreeCleanup = session.WorktreeCleanupRemove
+	c.buttonCount = 2
+	c.focusedButton = 1 // default to Cancel
+}
+
 // ShowDeleteGroup shows confirmation for group deletion
 func (c *ConfirmDialog) ShowDeleteGroup(groupPath, groupName string) {
 	c.visible = true
@@ -187,6 +223,36 @@ func (c *ConfirmDialog) ShowNotice(title, body string) {
 	c.focusedButton = 0
 }
 
+// ShowTmuxDoctor asks whether to repair the tmux drift found at startup.
+// issues should contain only repairable ones.
+func (c *ConfirmDialog) ShowTmuxDoctor(issues []session.TmuxIssue) {
+	c.visible = true
+	c.confirmType = ConfirmTmuxDoctor
+	c.tmuxIssues = issues
+	c.targetID = ""
+	c.targetName = ""
+	c.buttonCount = 2
+	c.focusedButton = 1
+}
+
+// ShowCreatePullRequest asks before pushing a worktree session's branch to
+// remote and opening a pull request for it.
+func (c *ConfirmDialog) ShowCreatePullRequest(sessionID, sessionName, branch, remote string) {
+	c.visible = true
+	c.confirmType = ConfirmCreatePullRequest
+	c.targetID = sessionID
+	c.targetName = sessionName
+	c.prBranch = branch
+	c.prRemote = remote
+	c.buttonCount = 2
+	c.focusedButton = 1 // default to Cancel
+}
+
+// GetTmuxIssues returns the issues carried by ConfirmTmuxDoctor.
+func (c *ConfirmDialog) GetTmuxIssues() []session.TmuxIssue {
+	return c.tmuxIssues
+}
+
 // ShowQuitWithPool shows confirmation for quitting with MCP pool running
 func (c *ConfirmDialog) ShowQuitWithPool(mcpCount int) {
 	c.visible = true
@@ -210,6 +276,8 @@ func (c *ConfirmDialog) ShowCreateDirectory(
 	launchModelID string,
 	parentSessionID string,
 	parentProjectPath string,
+	mcpNames []string,
+	initialPrompt string,
 ) {
 	c.visible = true
 	c.confirmType = ConfirmCreateDirectory
@@ -225,6 +293,8 @@ func (c *ConfirmDialog) ShowCreateDirectory(
 	c.pendingLaunchModelID = launchModelID
 	c.pendingParentSessionID = parentSessionID
 	c.pendingParentProjectPath = parentProjectPath
+	c.pendingMCPNames = mcpNames
+	c.pendingInitialPrompt = initialPrompt
 	c.buttonCount = 2
 	c.focusedButton = 1
 }
@@ -244,6 +314,16 @@ func (c *ConfirmDialog) GetPendingSession() (name, path, command, groupPath stri
 	return c.pendingSessionName, c.pendingSessionPath, c.pendingSessionCommand, c.pendingSessionGroupPath, c.pendingToolOptionsJSON, c.pendingClaudeExtraArgs, c.pendingClaudeStartQuery, c.pendingLaunchModelID, c.pendingParentSessionID, c.pendingParentProjectPath
 }
 
+// GetPendingMCPNames returns the template MCPs for the pending session.
+func (c *ConfirmDialog) GetPendingMCPNames() []string {
+	return c.pendingMCPNames
+}
+
+// GetPendingInitialPrompt returns the initial prompt for the pending session.
+func (c *ConfirmDialog) GetPendingInitialPrompt() string {
+	return c.pendingInitialPrompt
+}
+
 // Hide hides the dialog.
 func (c *ConfirmDialog) Hide() {
 	c.visible = false
@@ -253,6 +333,11 @@ func (c *ConfirmDialog) Hide() {
 	c.remoteName = ""
 	c.noticeTitle = ""
 	c.noticeBody = ""
+	c.targetIDs = nil
+	c.worktreeCount = 0
+	c.tmuxIssues = nil
+	c.prBranch = ""
+	c.prRemote = ""
 }
 
 // IsVisible returns whether the dialog is visible
@@ -265,6 +350,11 @@ func (c *ConfirmDialog) GetTargetID() string {
 	return c.targetID
 }
 
+// GetTargetIDs returns the session IDs of a bulk confirmation.
+func (c *ConfirmDialog) GetTargetIDs() []string {
+	return c.targetIDs
+}
+
 // GetConfirmType returns the type of confirmation
 func (c *ConfirmDialog) GetConfirmType() ConfirmType {
 	return c.confirmType
@@ -281,6 +371,54 @@ func (c *ConfirmDialog) SetSize(width, height int) {
 	c.height = height
 }
 
+// SetWorktreeCleanup sets what a pending delete does to worktrees.
+func (c *ConfirmDialog) SetWorktreeCleanup(mode session.WorktreeCleanup) {
+	c.worktreeCleanup = mode
+}
+
+// WorktreeCleanup returns what the pending delete will do to worktrees.
+func (c *ConfirmDialog) WorktreeCleanup() session.WorktreeCleanup {
+	return c.worktreeCleanup
+}
+
+// hasWorktreeChoice reports whether the pending delete touches a worktree,
+// i.e. whether `w` has anything to cycle.
+func (c *ConfirmDialog) hasWorktreeChoice() bool {
+	switch c.confirmType {
+	case ConfirmDeleteSession:
+		return c.worktree
+	case ConfirmBulkDeleteSessions:
+		return c.worktreeCount > 0
+	}
+	return false
+}
+
+// CycleWorktreeCleanup advances keep → remove → remove+branch for deletes
+// that touch a worktree. It reports whether the key was consumed.
+func (c *ConfirmDialog) CycleWorktreeCleanup() bool {
+	if !c.hasWorktreeChoice() {
+		return false
+	}
+	c.worktreeCleanup = c.worktreeCleanup.Next()
+	return true
+}
+
+// worktreeCleanupDetail is the bullet describing worktree handling; n is the
+// number of worktrees affected (1 for a single delete).
+func worktreeCleanupDetail(mode session.WorktreeCleanup, n int) string {
+	what := "The git worktree"
+	if n > 1 {
+		what = fmt.Sprintf("%d git worktrees", n)
+	}
+	switch mode {
+	case session.WorktreeCleanupKeep:
+		return "\n• " + what + " will be kept (w to change)"
+	case session.WorktreeCleanupRemoveWithBranch:
+		return "\n• " + what + " and merged branch will be removed (w to change)"
+	}
+	return "\n• " + what + " will be removed (w to change)"
+}
+
 // GetFocusedButton returns the currently focused button index.
 func (c *ConfirmDialog) GetFocusedButton() int {
 	return c.focusedButton
@@ -342,7 +480,7 @@ func (c *ConfirmDialog) View() string {
 		warning = fmt.Sprintf("This will permanently delete the session:\n\n  \"%s\"", c.targetName)
 		details = "• The tmux session will be terminated\n• Any running processes will be killed\n• Terminal history will be lost"
 		if c.worktree {
-			details += "\n• The git worktree directory will be removed"
+			details += worktreeCleanupDetail(c.worktreeCleanup, 1)
 		}
 		if c.sandboxed {
 			details += "\n• The Docker container will be removed"
@@ -435,6 +573,20 @@ func (c *ConfirmDialog) View() string {
 		buttons = lipgloss.JoinVertical(lipgloss.Left, buttonRow,
 			hintStyle.Render("y remove · n cancel · ←/→ navigate · Enter select · Esc"))
 
+	case ConfirmBulkDeleteSessions:
+		title = "⚠  Delete Marked Sessions?"
+		warning = fmt.Sprintf("This will permanently delete %d marked session(s).", len(c.targetIDs))
+		details = "• Their tmux sessions will be terminated\n• Running processes will be killed\n• Pinned sessions are skipped"
+		if c.worktreeCount > 0 {
+			details += worktreeCleanupDetail(c.worktreeCleanup, c.worktreeCount)
+		}
+		borderColor = ColorRed
+		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
+			renderButton("Delete All", ColorRed, c.focusedButton == 0), "  ",
+			renderButton("Cancel", ColorAccent, c.focusedButton == 1))
+		buttons = lipgloss.JoinVertical(lipgloss.Left, buttonRow,
+			hintStyle.Render("y delete · n cancel · ←/→ navigate · Enter select · Esc"))
+
 	case ConfirmDeleteGroup:
 		title = "⚠  Delete Group?"
 		warning = fmt.Sprintf("This will delete the group:\n\n  \"%s\"", c.targetName)
@@ -476,6 +628,36 @@ func (c *ConfirmDialog) View() string {
 			renderButton("OK", ColorAccent, true),
 			hintStyle.Render("Enter / Esc / o dismiss"))
 
+	case ConfirmTmuxDoctor:
+		title = "Tmux Sessions Out of Sync"
+		warning = fmt.Sprintf("Found %d tmux issue(s) that can be repaired:", len(c.tmuxIssues))
+		lines := make([]string, 0, len(c.tmuxIssues))
+		for idx, issue := range c.tmuxIssues {
+			if idx == 5 {
+				lines = append(lines, fmt.Sprintf("• …and %d more (see agent-deck doctor)", len(c.tmuxIssues)-idx))
+				break
+			}
+			lines = append(lines, "• "+issue.Describe())
+		}
+		details = strings.Join(lines, "\n")
+		borderColor = ColorYellow
+		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
+			renderButton("Repair", ColorGreen, c.focusedButton == 0), "  ",
+			renderButton("Skip", ColorAccent, c.focusedButton == 1))
+		buttons = lipgloss.JoinVertical(lipgloss.Left, buttonRow,
+			hintStyle.Render("y repair · n skip · ←/→ navigate · Enter select · Esc"))
+
+	case ConfirmCreatePullRequest:
+		title = "Open Pull Request?"
+		warning = fmt.Sprintf("Push and open a pull request for:\n\n  \"%s\"", c.targetName)
+		details = fmt.Sprintf("• Branch %s will be pushed to %s\n• A pull request will be opened with gh\n• The PR body is pre-filled from the agent's last reply", c.prBranch, c.prRemote)
+		borderColor = ColorYellow
+		buttonRow := lipgloss.JoinHorizontal(lipgloss.Center,
+			renderButton("Push & open", ColorGreen, c.focusedButton == 0), "  ",
+			renderButton("Cancel", ColorAccent, c.focusedButton == 1))
+		buttons = lipgloss.JoinVertical(lipgloss.Left, buttonRow,
+			hintStyle.Render("y push · n cancel · ←/→ navigate · Enter select · Esc"))
+
 	case ConfirmInstallHooks:
 		title = "Claude Code Hooks"
 		warning = "Agent-deck can install Claude Code lifecycle hooks\nfor real-time status detection (instant green/yellow/gray)."
@@ -65,3 +65,14 @@ func rowStatusGlyph(status session.Status, substate session.Substate, archived b
 	}
 	return icon, style
 }
+
+// attentionGlyph replaces the generic waiting glyph with the one
+// notifications use for what the session needs (session.AttentionIcon).
+// Returns "" when the status is not waiting or the output is unclassified,
+// leaving rowStatusGlyph's choice in place.
+func attentionGlyph(status session.Status, attention session.Attention) string {
+	if status != session.StatusWaiting {
+		return ""
+	}
+	return session.AttentionIcon(attention)
+}
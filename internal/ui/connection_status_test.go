@@ -68,3 +68,24 @@ func TestRowStatusGlyph(t *testing.T) {
 		})
 	}
 }
+
+func TestAttentionGlyph(t *testing.T) {
+	tests := []struct {
+		status    session.Status
+		attention session.Attention
+		want      string
+	}{
+		{session.StatusWaiting, session.AttentionQuestion, "?"},
+		{session.StatusWaiting, session.AttentionPermission, "?"},
+		{session.StatusWaiting, session.AttentionPlanApproval, "?"},
+		{session.StatusWaiting, session.AttentionError, "!"},
+		{session.StatusWaiting, session.AttentionDone, "✓"},
+		{session.StatusWaiting, session.AttentionNone, ""},
+		{session.StatusRunning, session.AttentionQuestion, ""},
+	}
+	for _, tt := range tests {
+		if got := attentionGlyph(tt.status, tt.attention); got != tt.want {
+			t.Errorf("attentionGlyph(%q, %q) = %q, want %q", tt.status, tt.attention, got, tt.want)
+		}
+	}
+}
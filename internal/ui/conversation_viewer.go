@@ -0,0 +1,464 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// conversationChromeRows is the vertical space the viewer's border, padding,
+// title and footer take; the rest of the overlay height shows transcript.
+const conversationChromeRows = 9
+
+// ConversationViewer is a read-only overlay rendering a session's Claude or
+// Gemini conversation: role-colored turns, tool calls and reasoning that
+// collapse to one line, and search. It reviews a session's history without
+// attaching to it.
+type ConversationViewer struct {
+	visible   bool
+	sessionID string
+	title     string
+	agent     string // label for assistant turns ("Claude", "Gemini")
+	loading   bool
+	err       string
+
+	entries  []session.ConversationEntry
+	expanded map[int]bool // tool / thinking entries opened by the user
+	cursor   int          // selected entry
+	offset   int          // first visible line
+
+	// Rendered lines, rebuilt when the width or an expansion changes.
+	lines     []string
+	entryLine []int // entry index -> first line in lines
+	dirty     bool
+
+	searching   bool
+	searchInput textinput.Model
+	query       string
+	matches     []int // entry indices matching query
+	matchPos    int
+
+	width  int
+	height int
+}
+
+// NewConversationViewer constructs a hidden conversation overlay.
+func NewConversationViewer() *ConversationViewer {
+	input := textinput.New()
+	input.Placeholder = "search conversation"
+	input.Prompt = "/"
+	input.CharLimit = 200
+	return &ConversationViewer{searchInput: input}
+}
+
+// Show opens the viewer for inst in a loading state; SetConversation fills
+// it once the transcript is parsed.
+func (v *ConversationViewer) Show(inst *session.Instance) {
+	v.visible = true
+	v.sessionID = inst.ID
+	v.title = inst.Title
+	v.agent = "Claude"
+	if inst.Tool == "gemini" {
+		v.agent = "Gemini"
+	}
+	v.loading = true
+	v.err = ""
+	v.entries = nil
+	v.expanded = make(map[int]bool)
+	v.cursor, v.offset = 0, 0
+	v.searching = false
+	v.searchInput.Blur()
+	v.searchInput.SetValue("")
+	v.query = ""
+	v.matches = nil
+	v.dirty = true
+}
+
+// SessionID returns the session the viewer was opened for.
+func (v *ConversationViewer) SessionID() string { return v.sessionID }
+
+// SetConversation shows the parsed entries (or the load error), scrolled to
+// the latest turn.
+func (v *ConversationViewer) SetConversation(entries []session.ConversationEntry, err error) {
+	v.loading = false
+	if err != nil {
+		v.err = err.Error()
+		return
+	}
+	v.entries = entries
+	v.dirty = true
+	v.cursor = max(0, len(entries)-1)
+	v.ensureCursorVisible()
+}
+
+// Hide closes the overlay.
+func (v *ConversationViewer) Hide() {
+	v.visible = false
+	v.searchInput.Blur()
+	v.entries = nil
+	v.lines = nil
+}
+
+// IsVisible reports whether the overlay is currently shown.
+func (v *ConversationViewer) IsVisible() bool { return v.visible }
+
+// IsSearching reports whether the search input has focus, so Esc cancels
+// the search instead of closing the viewer.
+func (v *ConversationViewer) IsSearching() bool { return v.searching }
+
+// SetSize updates the overlay dimensions.
+func (v *ConversationViewer) SetSize(width, height int) {
+	if width != v.width {
+		v.dirty = true
+	}
+	v.width = width
+	v.height = height
+}
+
+// Update handles navigation, expansion and search keys.
+func (v *ConversationViewer) Update(msg tea.KeyMsg) (*ConversationViewer, tea.Cmd) {
+	if v.searching {
+		switch msg.String() {
+		case "esc":
+			v.searching = false
+			v.searchInput.Blur()
+			return v, nil
+		case "enter":
+			v.searching = false
+			v.searchInput.Blur()
+			v.applySearch(v.searchInput.Value())
+			return v, nil
+		}
+		var cmd tea.Cmd
+		v.searchInput, cmd = v.searchInput.Update(msg)
+		return v, cmd
+	}
+
+	page := v.pageRows()
+	switch msg.String() {
+	case "up", "k":
+		v.moveCursor(-1)
+	case "down", "j":
+		v.moveCursor(1)
+	case "pgup", "ctrl+u":
+		v.offset = max(0, v.offset-page)
+	case "pgdown", "ctrl+d":
+		v.offset = min(v.maxOffset(), v.offset+page)
+	case "home", "g":
+		v.cursor = 0
+		v.ensureCursorVisible()
+	case "end", "G":
+		v.cursor = max(0, len(v.entries)-1)
+		v.ensureCursorVisible()
+	case "enter", " ", "tab":
+		if v.collapsible(v.cursor) {
+			v.expanded[v.cursor] = !v.expanded[v.cursor]
+			v.dirty = true
+			v.ensureCursorVisible()
+		}
+	case "t":
+		v.toggleAll()
+	case "/":
+		v.searching = true
+		v.searchInput.SetValue(v.query)
+		v.searchInput.CursorEnd()
+		return v, v.searchInput.Focus()
+	case "n":
+		v.jumpMatch(1)
+	case "N":
+		v.jumpMatch(-1)
+	}
+	return v, nil
+}
+
+func (v *ConversationViewer) moveCursor(delta int) {
+	if len(v.entries) == 0 {
+		return
+	}
+	v.cursor = min(len(v.entries)-1, max(0, v.cursor+delta))
+	v.ensureCursorVisible()
+}
+
+// collapsible reports whether entry idx renders collapsed by default.
+func (v *ConversationViewer) collapsible(idx int) bool {
+	if idx < 0 || idx >= len(v.entries) {
+		return false
+	}
+	k := v.entries[idx].Kind
+	return k == session.ConversationTool || k == session.ConversationThinking
+}
+
+// toggleAll expands every collapsible entry, or collapses them all when
+// any is already open.
+func (v *ConversationViewer) toggleAll() {
+	open := true
+	for k, on := range v.expanded {
+		if on {
+			open = false
+		}
+		delete(v.expanded, k)
+	}
+	if open {
+		for idx := range v.entries {
+			if v.collapsible(idx) {
+				v.expanded[idx] = true
+			}
+		}
+	}
+	v.dirty = true
+	v.ensureCursorVisible()
+}
+
+// applySearch records the entries matching query (case-insensitive across
+// text, tool name and tool input) and jumps to the first match at or after
+// the cursor.
+func (v *ConversationViewer) applySearch(query string) {
+	v.query = strings.TrimSpace(query)
+	v.matches = nil
+	v.matchPos = 0
+	if v.query == "" {
+		return
+	}
+	needle := strings.ToLower(v.query)
+	for idx, e := range v.entries {
+		hay := strings.ToLower(e.Text + "\n" + e.ToolName + "\n" + e.ToolInput)
+		if strings.Contains(hay, needle) {
+			v.matches = append(v.matches, idx)
+		}
+	}
+	if len(v.matches) == 0 {
+		return
+	}
+	for pos, idx := range v.matches {
+		if idx >= v.cursor {
+			v.matchPos = pos
+			break
+		}
+	}
+	v.showMatch()
+}
+
+func (v *ConversationViewer) jumpMatch(delta int) {
+	if len(v.matches) == 0 {
+		return
+	}
+	v.matchPos = (v.matchPos + delta + len(v.matches)) % len(v.matches)
+	v.showMatch()
+}
+
+// showMatch moves to the current match, opening it when it is collapsed so
+// the hit is actually on screen.
+func (v *ConversationViewer) showMatch() {
+	v.cursor = v.matches[v.matchPos]
+	if v.collapsible(v.cursor) && !v.expanded[v.cursor] {
+		v.expanded[v.cursor] = true
+		v.dirty = true
+	}
+	v.ensureCursorVisible()
+}
+
+// contentWidth is the text width inside the box: the screen minus margins,
+// border, padding and the two-column gutter.
+func (v *ConversationViewer) contentWidth() int {
+	return max(20, v.width-10)
+}
+
+func (v *ConversationViewer) pageRows() int {
+	return max(3, v.height-conversationChromeRows)
+}
+
+func (v *ConversationViewer) maxOffset() int {
+	v.render()
+	return max(0, len(v.lines)-v.pageRows())
+}
+
+// ensureCursorVisible scrolls so the selected entry's first line is shown.
+func (v *ConversationViewer) ensureCursorVisible() {
+	v.render()
+	if v.cursor >= len(v.entryLine) {
+		return
+	}
+	start := v.entryLine[v.cursor]
+	end := len(v.lines)
+	if v.cursor+1 < len(v.entryLine) {
+		end = v.entryLine[v.cursor+1]
+	}
+	page := v.pageRows()
+	if start < v.offset {
+		v.offset = start
+	} else if end > v.offset+page {
+		// Show the whole entry when it fits, else its start.
+		v.offset = min(start, end-page)
+	}
+	v.offset = min(v.offset, max(0, len(v.lines)-page))
+}
+
+// render rebuilds the line cache when stale.
+func (v *ConversationViewer) render() {
+	if !v.dirty {
+		return
+	}
+	v.dirty = false
+	v.lines = v.lines[:0]
+	v.entryLine = v.entryLine[:0]
+	width := v.contentWidth()
+	st := newConversationStyles()
+	for idx, e := range v.entries {
+		v.entryLine = append(v.entryLine, len(v.lines))
+		v.lines = append(v.lines, v.renderEntry(idx, e, width, st)...)
+	}
+}
+
+// conversationStyles are built per render: theme colors are assigned at
+// runtime, after package init.
+type conversationStyles struct {
+	user, assistant, tool, thinking, body, dim lipgloss.Style
+}
+
+func newConversationStyles() conversationStyles {
+	return conversationStyles{
+		user:      lipgloss.NewStyle().Foreground(ColorCyan).Bold(true),
+		assistant: lipgloss.NewStyle().Foreground(ColorGreen).Bold(true),
+		tool:      lipgloss.NewStyle().Foreground(ColorYellow),
+		thinking:  lipgloss.NewStyle().Foreground(ColorComment).Italic(true),
+		body:      lipgloss.NewStyle().Foreground(ColorText),
+		dim:       lipgloss.NewStyle().Foreground(ColorTextDim),
+	}
+}
+
+// renderEntry renders one entry as a header line plus its wrapped body.
+// Gutter markers are added at View time, so cached lines stay unselected.
+func (v *ConversationViewer) renderEntry(idx int, e session.ConversationEntry, width int, st conversationStyles) []string {
+	stamp := ""
+	if !e.Timestamp.IsZero() {
+		stamp = "  " + st.dim.Render(formatEventTime(e.Timestamp.Local()))
+	}
+	body := func(text string, style lipgloss.Style, indent string) []string {
+		var out []string
+		for _, line := range strings.Split(ansi.Wrap(text, width-len(indent), ""), "\n") {
+			out = append(out, style.Render(indent+line))
+		}
+		return out
+	}
+
+	var lines []string
+	switch e.Kind {
+	case session.ConversationUser:
+		lines = append(lines, st.user.Render("You")+stamp)
+		lines = append(lines, body(e.Text, st.body, "")...)
+	case session.ConversationAssistant:
+		lines = append(lines, st.assistant.Render(v.agent)+stamp)
+		lines = append(lines, body(e.Text, st.body, "")...)
+	case session.ConversationThinking:
+		arrow := "▸"
+		if v.expanded[idx] {
+			arrow = "▾"
+		}
+		header := fmt.Sprintf("%s thinking (%d lines)", arrow, strings.Count(e.Text, "\n")+1)
+		lines = append(lines, st.thinking.Render(header))
+		if v.expanded[idx] {
+			lines = append(lines, body(e.Text, st.thinking, "  ")...)
+		}
+	case session.ConversationTool:
+		arrow := "▸"
+		if v.expanded[idx] {
+			arrow = "▾"
+		}
+		header := st.tool.Render(arrow+" "+e.ToolName) + " " + st.dim.Render(e.ToolInput)
+		if e.ToolError {
+			header += " " + lipgloss.NewStyle().Foreground(ColorRed).Render("✕")
+		}
+		lines = append(lines, cellTruncate(header, width, "..."))
+		if v.expanded[idx] {
+			if e.ToolInput != "" {
+				lines = append(lines, body("$ "+e.ToolInput, st.tool, "  ")...)
+			}
+			if e.Text != "" {
+				lines = append(lines, body(e.Text, st.dim, "  ")...)
+			}
+		}
+	}
+	return append(lines, "")
+}
+
+// View renders the overlay, centered and sized to the terminal.
+func (v *ConversationViewer) View() string {
+	if !v.visible {
+		return ""
+	}
+	v.render()
+
+	dialogWidth := max(40, v.width-4)
+	title := DialogTitleStyle.Render("Conversation: " + v.title)
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+
+	var bodyText string
+	switch {
+	case v.loading:
+		bodyText = dimStyle.Render("Loading conversation...")
+	case v.err != "":
+		bodyText = lipgloss.NewStyle().Foreground(ColorRed).Render("⚠ " + v.err)
+	case len(v.entries) == 0:
+		bodyText = dimStyle.Render("No messages yet.")
+	default:
+		bodyText = strings.Join(v.visibleLines(), "\n")
+	}
+
+	footer := hintStyle.Render("↑/↓ turn │ PgUp/PgDn scroll │ Enter expand │ t expand all │ / search │ n/N next/prev │ Esc close")
+	if v.searching {
+		footer = v.searchInput.View()
+	} else if v.query != "" {
+		status := fmt.Sprintf("%q: no matches", v.query)
+		if len(v.matches) > 0 {
+			status = fmt.Sprintf("%q: match %d of %d", v.query, v.matchPos+1, len(v.matches))
+		}
+		footer = dimStyle.Render(status) + "\n" + footer
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, title, "", bodyText, "", footer)
+	dialog := DialogBoxStyle.Width(dialogWidth).Render(content)
+	return lipgloss.Place(v.width, v.height, lipgloss.Center, lipgloss.Center, dialog)
+}
+
+// visibleLines returns the page of cached lines at offset, with a gutter
+// marking the selected entry and search hits.
+func (v *ConversationViewer) visibleLines() []string {
+	page := v.pageRows()
+	end := min(len(v.lines), v.offset+page)
+	selStart, selEnd := -1, -1
+	if v.cursor < len(v.entryLine) {
+		selStart = v.entryLine[v.cursor]
+		selEnd = len(v.lines)
+		if v.cursor+1 < len(v.entryLine) {
+			selEnd = v.entryLine[v.cursor+1] - 1 // skip the separator line
+		}
+	}
+	matchStarts := make(map[int]bool, len(v.matches))
+	for _, idx := range v.matches {
+		if idx < len(v.entryLine) {
+			matchStarts[v.entryLine[idx]] = true
+		}
+	}
+
+	gutterSel := lipgloss.NewStyle().Foreground(ColorAccent).Render("▌")
+	gutterMatch := lipgloss.NewStyle().Foreground(ColorYellow).Render("●")
+	out := make([]string, 0, page)
+	for ln := v.offset; ln < end; ln++ {
+		gutter := " "
+		switch {
+		case ln >= selStart && ln < selEnd:
+			gutter = gutterSel
+		case matchStarts[ln]:
+			gutter = gutterMatch
+		}
+		out = append(out, gutter+" "+v.lines[ln])
+	}
+	return out
+}
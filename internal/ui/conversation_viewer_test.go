@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func conversationViewerFixture() *ConversationViewer {
+	v := NewConversationViewer()
+	v.SetSize(100, 40)
+	v.Show(&session.Instance{ID: "s1", Title: "api", Tool: "claude"})
+	v.SetConversation([]session.ConversationEntry{
+		{Kind: session.ConversationUser, Text: "why does the build fail?"},
+		{Kind: session.ConversationTool, ToolName: "Bash", ToolInput: "go build ./...", Text: "undefined: foo", ToolError: true},
+		{Kind: session.ConversationAssistant, Text: "foo is undefined in main.go."},
+	}, nil)
+	return v
+}
+
+func TestConversationViewer_ToolCallsCollapsedUntilToggled(t *testing.T) {
+	v := conversationViewerFixture()
+	view := v.View()
+	for _, want := range []string{"You", "Claude", "Bash", "go build ./...", "foo is undefined"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "undefined: foo") {
+		t.Error("tool result should be hidden while the call is collapsed")
+	}
+
+	if v.cursor != 2 {
+		t.Fatalf("viewer should open on the latest entry, cursor = %d", v.cursor)
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyUp})
+	v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(v.View(), "undefined: foo") {
+		t.Error("Enter on a tool call should expand its result")
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if strings.Contains(v.View(), "undefined: foo") {
+		t.Error("t with an open entry should collapse everything")
+	}
+}
+
+func TestConversationViewer_SearchJumpsAndExpands(t *testing.T) {
+	v := conversationViewerFixture()
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if !v.IsSearching() {
+		t.Fatal("/ should open the search input")
+	}
+	for _, r := range "FOO" {
+		v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if len(v.matches) != 2 {
+		t.Fatalf("matches = %v, want the tool call and the reply", v.matches)
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if v.cursor != 1 || !v.expanded[1] {
+		t.Errorf("n should wrap to the tool call and expand it (cursor=%d)", v.cursor)
+	}
+	if !strings.Contains(v.View(), "match 1 of 2") {
+		t.Error("footer should show the match position")
+	}
+}
+
+func TestConversationViewer_LoadError(t *testing.T) {
+	v := NewConversationViewer()
+	v.SetSize(80, 30)
+	v.Show(&session.Instance{ID: "s1", Title: "api", Tool: "claude"})
+	if !strings.Contains(v.View(), "Loading") {
+		t.Error("viewer should show a loading state before the transcript arrives")
+	}
+	v.SetConversation(nil, errors.New("no transcript"))
+	if !strings.Contains(v.View(), "no transcript") {
+		t.Error("load errors should be shown in the viewer")
+	}
+}
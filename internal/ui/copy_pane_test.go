@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestTailLines(t *testing.T) {
+	in := "one\ntwo\nthree\nfour\n\n   \n\n"
+	if got := tailLines(in, 2); got != "three\nfour" {
+		t.Fatalf("tailLines(2) = %q, want the last two non-blank-trailing lines", got)
+	}
+	if got := tailLines(in, 10); got != "one\ntwo\nthree\nfour" {
+		t.Fatalf("tailLines(10) = %q", got)
+	}
+	if got := tailLines("\n\n", 5); got != "" {
+		t.Fatalf("blank pane should give empty content, got %q", got)
+	}
+}
+
+func TestCopySessionPane_NotRunning(t *testing.T) {
+	home := NewHome()
+	inst := session.NewInstanceWithTool("idle-shell", "/tmp/copy", "shell")
+	msg, ok := home.copySessionPane(inst, -1)().(copyResultMsg)
+	if !ok || msg.err == nil || !strings.Contains(msg.err.Error(), "not running") {
+		t.Fatalf("copy of a session without a live pane should report it, got %#v", msg)
+	}
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"log/slog"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// notifyDesktopTransitions sends a desktop notification for each session
+// that just turned into a configured status ([notifications.desktop]),
+// except the session the user is attached to. Runs on the background status
+// worker, so it keeps working while the TUI is suspended by an attach.
+// Deck-wide DND suppresses the notifications and records them in the
+// "missed while away" digest instead.
+func (h *Home) notifyDesktopTransitions(instances []*session.Instance) {
+	cfg := session.GetNotificationsSettings().Desktop
+	if !cfg.Enabled {
+		h.desktopNotifyTracker.reset()
+		return
+	}
+
+	var notes []session.DesktopNotification
+	muted := h.mutedGroupPaths()
+	attachedID, attachedKnown := "", false
+	for _, tr := range h.desktopNotifyTracker.observe(instances) {
+		if tr.kind != sessionStatusChanged || !cfg.NotifiesOn(tr.to) || tr.inst.IsArchived() {
+			continue
+		}
+		if session.InMutedGroup(muted, tr.inst.GroupPath) {
+			continue
+		}
+		if !attachedKnown {
+			// Only ask tmux when something would actually notify.
+			attachedID, attachedKnown = h.getAttachedSessionID(), true
+		}
+		if tr.inst.ID == attachedID {
+			continue
+		}
+		notes = append(notes, session.DesktopNotification{
+			SessionID: tr.inst.ID,
+			Title:     tr.inst.Title,
+			Status:    tr.to,
+			Reason:    tr.inst.CachedAttention().Reason(),
+		})
+	}
+	if len(notes) == 0 {
+		return
+	}
+
+	missed := make([]session.DNDMissedEvent, 0, len(notes))
+	for _, n := range notes {
+		missed = append(missed, session.DNDMissedEvent{
+			Source:    session.DNDSourceDesktop,
+			SessionID: n.SessionID,
+			Title:     n.Title,
+			Status:    string(n.Status),
+		})
+	}
+	if active, err := session.RecordDNDMissed(missed...); err != nil {
+		notifLog.Warn("desktop_dnd_record_failed", slog.String("error", err.Error()))
+	} else if active {
+		notifLog.Debug("desktop_suppressed_dnd", slog.Int("notifications", len(notes)))
+		return
+	}
+
+	for _, n := range notes {
+		go func(n session.DesktopNotification) {
+			if err := session.SendDesktopNotification(cfg, n); err != nil {
+				notifLog.Warn("desktop_notify_failed",
+					slog.String("session", n.SessionID),
+					slog.String("error", err.Error()))
+			}
+		}(n)
+	}
+}
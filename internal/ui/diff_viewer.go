@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// worktreeDiffMsg delivers the diff loaded by openWorktreeDiff.
+type worktreeDiffMsg struct {
+	sessionID string
+	files     []git.DiffFile
+	err       error
+}
+
+// diffViewer is the full-screen worktree diff: one file at a time, with
+// n/p to move between files and j/k to scroll the patch.
+type diffViewer struct {
+	width     int
+	height    int
+	sessionID string
+	title     string
+	base      string
+	loading   bool
+	err       error
+	files     []git.DiffFile
+	file      int // index into files
+	scroll    int // first patch line shown
+}
+
+func newDiffViewer(width, height int, inst *session.Instance, base string) diffViewer {
+	return diffViewer{width: width, height: height, sessionID: inst.ID, title: inst.Title, base: base, loading: true}
+}
+
+// openWorktreeDiff shows the diff of a worktree session against its repo's
+// default branch. git runs off the UI goroutine.
+func (h *Home) openWorktreeDiff(inst *session.Instance) tea.Cmd {
+	if !inst.IsWorktree() {
+		h.setError(fmt.Errorf("session '%s' is not a worktree", inst.Title))
+		return nil
+	}
+	base := "main"
+	if detected, err := git.GetDefaultBranch(inst.WorktreeRepoRoot); err == nil {
+		base = detected
+	}
+	h.diffViewer = newDiffViewer(h.width, h.height, inst, base)
+	h.showDiffViewer = true
+
+	id, path := inst.ID, inst.WorktreePath
+	return func() tea.Msg {
+		files, err := git.WorktreeDiff(path, base)
+		return worktreeDiffMsg{sessionID: id, files: files, err: err}
+	}
+}
+
+// bodyHeight is the number of patch lines that fit under the header.
+func (d diffViewer) bodyHeight() int {
+	return max(d.height-6, 3)
+}
+
+func (d diffViewer) patchLines() []string {
+	if d.file >= len(d.files) {
+		return nil
+	}
+	return strings.Split(d.files[d.file].Patch, "\n")
+}
+
+// handleKey applies a key to the viewer and reports whether it should close.
+func (d *diffViewer) handleKey(key string) (closed bool) {
+	maxScroll := max(len(d.patchLines())-d.bodyHeight(), 0)
+	switch key {
+	case "q", "esc":
+		return true
+	case "n", "tab", "right", "l":
+		if d.file < len(d.files)-1 {
+			d.file++
+			d.scroll = 0
+		}
+	case "p", "shift+tab", "left", "h":
+		if d.file > 0 {
+			d.file--
+			d.scroll = 0
+		}
+	case "j", "down":
+		d.scroll = min(d.scroll+1, maxScroll)
+	case "k", "up":
+		d.scroll = max(d.scroll-1, 0)
+	case "ctrl+d", "pgdown", " ":
+		d.scroll = min(d.scroll+d.bodyHeight()/2, maxScroll)
+	case "ctrl+u", "pgup":
+		d.scroll = max(d.scroll-d.bodyHeight()/2, 0)
+	case "g", "home":
+		d.scroll = 0
+	case "G", "end":
+		d.scroll = maxScroll
+	}
+	return false
+}
+
+func (d diffViewer) View() string {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	addStyle := lipgloss.NewStyle().Foreground(ColorGreen)
+	delStyle := lipgloss.NewStyle().Foreground(ColorRed)
+	hunkStyle := lipgloss.NewStyle().Foreground(ColorCyan)
+	fileStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorText)
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf(" Diff — %s vs %s", d.title, d.base)))
+	b.WriteString("\n\n")
+
+	switch {
+	case d.loading:
+		b.WriteString("  " + dimStyle.Render("Running git diff...") + "\n\n")
+		b.WriteString("  " + dimStyle.Render("Press q or esc to return"))
+		return b.String()
+	case d.err != nil:
+		b.WriteString("  " + delStyle.Render(d.err.Error()) + "\n\n")
+		b.WriteString("  " + dimStyle.Render("Press q or esc to return"))
+		return b.String()
+	case len(d.files) == 0:
+		b.WriteString("  " + dimStyle.Render("No changes against "+d.base+" (untracked files are not shown)") + "\n\n")
+		b.WriteString("  " + dimStyle.Render("Press q or esc to return"))
+		return b.String()
+	}
+
+	var added, deleted int
+	for _, f := range d.files {
+		added += f.Added
+		deleted += f.Deleted
+	}
+	f := d.files[d.file]
+	b.WriteString(fmt.Sprintf("  %s  %s %s   %s\n",
+		fileStyle.Render(fmt.Sprintf("[%d/%d] %s", d.file+1, len(d.files), truncateStr(f.Path, max(d.width-40, 20)))),
+		addStyle.Render(fmt.Sprintf("+%d", f.Added)), delStyle.Render(fmt.Sprintf("-%d", f.Deleted)),
+		dimStyle.Render(fmt.Sprintf("(%d files, +%d -%d)", len(d.files), added, deleted))))
+	b.WriteString("\n")
+
+	lines := d.patchLines()
+	end := min(d.scroll+d.bodyHeight(), len(lines))
+	for _, line := range lines[d.scroll:end] {
+		line = truncateStr(strings.ReplaceAll(line, "\t", "    "), max(d.width-4, 20))
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"),
+			strings.HasPrefix(line, "diff --git"), strings.HasPrefix(line, "index "):
+			line = dimStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			line = hunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			line = addStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			line = delStyle.Render(line)
+		}
+		b.WriteString("  " + line + "\n")
+	}
+	for i := end - d.scroll; i < d.bodyHeight(); i++ {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("  " + dimStyle.Render("n/p: next/prev file · j/k: scroll · g/G: top/bottom · q or esc: return"))
+	return b.String()
+}
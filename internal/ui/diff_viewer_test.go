@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+func testDiffViewer() diffViewer {
+	long := make([]string, 40)
+	for i := range long {
+		long[i] = "+line"
+	}
+	return diffViewer{
+		width: 100, height: 20, title: "api", base: "main",
+		files: []git.DiffFile{
+			{Path: "a.go", Added: 40, Patch: "diff --git a/a.go b/a.go\n@@ -0,0 +1,40 @@\n" + strings.Join(long, "\n")},
+			{Path: "b.go", Deleted: 1, Patch: "diff --git a/b.go b/b.go\n@@ -1 +0,0 @@\n-gone"},
+		},
+	}
+}
+
+func TestDiffViewer_FileNavigationAndScroll(t *testing.T) {
+	d := testDiffViewer()
+
+	d.handleKey("G")
+	if want := len(d.patchLines()) - d.bodyHeight(); d.scroll != want {
+		t.Errorf("G scroll = %d, want %d", d.scroll, want)
+	}
+	d.handleKey("j")
+	if want := len(d.patchLines()) - d.bodyHeight(); d.scroll != want {
+		t.Errorf("scroll past the end = %d, want clamped %d", d.scroll, want)
+	}
+
+	d.handleKey("n")
+	if d.file != 1 || d.scroll != 0 {
+		t.Errorf("after n: file %d scroll %d, want 1 0", d.file, d.scroll)
+	}
+	d.handleKey("n")
+	if d.file != 1 {
+		t.Errorf("n on the last file moved to %d", d.file)
+	}
+	d.handleKey("p")
+	if d.file != 0 {
+		t.Errorf("after p: file %d, want 0", d.file)
+	}
+	if !d.handleKey("q") || !d.handleKey("esc") {
+		t.Error("q and esc should close the viewer")
+	}
+}
+
+func TestDiffViewer_View(t *testing.T) {
+	d := testDiffViewer()
+	d.handleKey("n")
+	out := d.View()
+	for _, want := range []string{"api vs main", "[2/2] b.go", "(2 files, +40 -1)", "-gone"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+
+	empty := diffViewer{width: 80, height: 20, title: "api", base: "main"}
+	if !strings.Contains(empty.View(), "No changes against main") {
+		t.Error("empty diff should say there are no changes")
+	}
+}
@@ -77,6 +77,8 @@ func (d *EditSessionDialog) Show(inst *session.Instance) {
 			pillOptions: []string{string(session.PinNone), string(session.PinTop), string(session.PinBottom)},
 			pillLabels:  []string{"Off", "Top", "Bottom"},
 			pillCursor:  pinCursorFor(inst.Pin)},
+		{key: session.FieldTags, label: "Tags — comma-separated", kind: editFieldText,
+			input: mkInput("urgent, client-x", 256, strings.Join(inst.Tags, ", "))},
 	}
 	if session.IsClaudeCompatible(inst.Tool) {
 		skip, auto := readClaudeFlags(inst)
@@ -273,6 +275,8 @@ func fieldInitialValue(inst *session.Instance, field string) string {
 		return strings.Join(inst.ExtraArgs, " ")
 	case session.FieldPlugins:
 		return strings.Join(inst.Plugins, ",")
+	case session.FieldTags:
+		return strings.Join(inst.Tags, ", ")
 	case session.FieldSkipPermissions:
 		skip, _ := readClaudeFlags(inst)
 		return strconv.FormatBool(skip)
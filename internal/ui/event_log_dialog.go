@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// eventLogVisibleRows caps how many timeline rows the overlay shows at once;
+// the rest scroll.
+const eventLogVisibleRows = 18
+
+// EventLogDialog is an overlay showing one session's timeline (starts,
+// restarts, stops, status transitions, MCP changes, forks), newest first,
+// so "when did this error and what changed before that" is one keypress.
+type EventLogDialog struct {
+	visible bool
+	title   string
+	events  []session.SessionEvent // newest first; the last row is "created"
+	offset  int
+	width   int
+	height  int
+}
+
+// NewEventLogDialog constructs a hidden timeline overlay.
+func NewEventLogDialog() *EventLogDialog {
+	return &EventLogDialog{}
+}
+
+// Show opens the timeline for inst, with a synthesized EventCreated row
+// from CreatedAt as the oldest entry.
+func (d *EventLogDialog) Show(inst *session.Instance) {
+	log := inst.GetEventLog()
+	events := make([]session.SessionEvent, 0, len(log)+1)
+	for idx := len(log) - 1; idx >= 0; idx-- {
+		events = append(events, log[idx])
+	}
+	if !inst.CreatedAt.IsZero() {
+		events = append(events, session.SessionEvent{At: inst.CreatedAt, Kind: session.EventCreated})
+	}
+	d.visible = true
+	d.title = inst.Title
+	d.events = events
+	d.offset = 0
+}
+
+// Hide closes the overlay.
+func (d *EventLogDialog) Hide() {
+	d.visible = false
+}
+
+// IsVisible reports whether the overlay is currently shown.
+func (d *EventLogDialog) IsVisible() bool { return d.visible }
+
+// SetSize updates the dialog viewport for centering.
+func (d *EventLogDialog) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+}
+
+// Update scrolls the timeline.
+func (d *EventLogDialog) Update(msg tea.KeyMsg) *EventLogDialog {
+	maxOffset := max(0, len(d.events)-eventLogVisibleRows)
+	switch msg.String() {
+	case "up", "k":
+		if d.offset > 0 {
+			d.offset--
+		}
+	case "down", "j":
+		if d.offset < maxOffset {
+			d.offset++
+		}
+	case "pgup", "ctrl+u":
+		d.offset = max(0, d.offset-eventLogVisibleRows)
+	case "pgdown", "ctrl+d":
+		d.offset = min(maxOffset, d.offset+eventLogVisibleRows)
+	case "home", "g":
+		d.offset = 0
+	case "end", "G":
+		d.offset = maxOffset
+	}
+	return d
+}
+
+// View renders the overlay, centered in the viewport.
+func (d *EventLogDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	dialogWidth := fitDialogWidth(72, 44, d.width)
+	title := DialogTitleStyle.Render("Timeline: " + d.title)
+
+	timeStyle := lipgloss.NewStyle().Foreground(ColorText)
+	detailStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	errStyle := lipgloss.NewStyle().Foreground(ColorRed)
+
+	end := min(len(d.events), d.offset+eventLogVisibleRows)
+	rows := make([]string, 0, end-d.offset)
+	for _, ev := range d.events[d.offset:end] {
+		line := timeStyle.Render(formatEventTime(ev.At)) + "  " +
+			eventKindStyle(ev).Render(fmt.Sprintf("%-9s", ev.Kind))
+		if ev.Detail != "" {
+			line += " " + detailStyle.Render(ev.Detail)
+		}
+		if ev.Error != "" {
+			line += "  " + errStyle.Render("✕ "+ev.Error)
+		}
+		rows = append(rows, cellTruncate(line, max(10, dialogWidth-4), "..."))
+	}
+
+	position := ""
+	if len(d.events) > eventLogVisibleRows {
+		position = detailStyle.Render(fmt.Sprintf("%d–%d of %d", d.offset+1, end, len(d.events)))
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	hint := hintStyle.Render("↑/↓ scroll │ Esc close")
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		"",
+		strings.Join(rows, "\n"),
+		"",
+		position,
+		hint,
+	)
+
+	dialog := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(content)
+
+	return lipgloss.Place(
+		d.width,
+		d.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+// formatEventTime shows the clock time for today's events and the date
+// otherwise, so a multi-day timeline stays readable.
+func formatEventTime(t time.Time) string {
+	now := time.Now()
+	if y, m, dd := t.Date(); y == now.Year() && m == now.Month() && dd == now.Day() {
+		return t.Format("15:04:05")
+	}
+	return t.Format("Jan 02 15:04")
+}
+
+// eventKindStyle colors a timeline row's kind. Transitions into error stand
+// out; everything else is colored by kind.
+func eventKindStyle(ev session.SessionEvent) lipgloss.Style {
+	style := lipgloss.NewStyle().Bold(true)
+	switch ev.Kind {
+	case session.EventStatus:
+		if strings.HasSuffix(ev.Detail, string(session.StatusError)) {
+			return style.Foreground(ColorRed)
+		}
+		return style.Foreground(ColorCyan)
+	case session.EventRestarted:
+		return style.Foreground(ColorOrange)
+	case session.EventStopped:
+		return style.Foreground(ColorTextDim)
+	case session.EventMCPChange:
+		return style.Foreground(ColorPurple)
+	case session.EventRolledBack:
+		return style.Foreground(ColorOrange)
+	case session.EventVerified:
+		if !session.VerifyPassed(ev) {
+			return style.Foreground(ColorRed)
+		}
+		return style.Foreground(ColorGreen)
+	default:
+		return style.Foreground(ColorGreen)
+	}
+}
@@ -0,0 +1,305 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// FileRef is a file mentioned in a session's output, optionally with the
+// line (and column) it points at. Path is absolute.
+type FileRef struct {
+	Path string
+	Line int
+	Col  int
+}
+
+// maxFileRefs caps the picker: refactor output can mention hundreds of
+// files, and the most recent mentions are the ones worth jumping to.
+const maxFileRefs = 50
+
+// fileRefPattern matches path-like tokens with an extension, optionally
+// followed by :line or :line:col (the compiler / grep / stack-trace form).
+// Bare words like "v1.2" are dropped later by the existence check.
+var fileRefPattern = regexp.MustCompile(`(?:^|[\s'"(\[<=])((?:~/|\.{1,2}/|/)?[\w@+\-.]+(?:/[\w@+\-.]+)*\.[A-Za-z0-9]+)(?::(\d+))?(?::(\d+))?`)
+
+// extractFileRefs pulls file references out of text, newest first, keeping
+// only those that resolve to a regular file. Relative paths resolve against
+// baseDir, the session's working directory. Repeated mentions of the same
+// path and line are listed once.
+func extractFileRefs(text, baseDir string) []FileRef {
+	text = tmux.StripANSI(text)
+	lines := strings.Split(text, "\n")
+	home, _ := os.UserHomeDir()
+
+	var refs []FileRef
+	seen := make(map[string]bool)
+	for i := len(lines) - 1; i >= 0 && len(refs) < maxFileRefs; i-- {
+		matches := fileRefPattern.FindAllStringSubmatch(lines[i], -1)
+		for j := len(matches) - 1; j >= 0; j-- {
+			m := matches[j]
+			path := m[1]
+			switch {
+			case strings.HasPrefix(path, "~/") && home != "":
+				path = filepath.Join(home, path[2:])
+			case !filepath.IsAbs(path):
+				path = filepath.Join(baseDir, path)
+			}
+			if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			line, _ := strconv.Atoi(m[2])
+			col, _ := strconv.Atoi(m[3])
+			key := fmt.Sprintf("%s:%d", path, line)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			refs = append(refs, FileRef{Path: path, Line: line, Col: col})
+			if len(refs) == maxFileRefs {
+				break
+			}
+		}
+	}
+	return refs
+}
+
+// editorArgs builds the argument list that opens path at line in editor,
+// using the goto syntax of the editor family. Unknown editors get the
+// common "+line" form, which vi, nano, emacs and most terminal editors
+// accept.
+func editorArgs(editor string, ref FileRef) []string {
+	if ref.Line <= 0 {
+		return []string{ref.Path}
+	}
+	fields := strings.Fields(editor)
+	name := ""
+	if len(fields) > 0 {
+		name = filepath.Base(fields[0])
+	}
+	switch name {
+	case "code", "code-insiders", "cursor", "codium", "windsurf":
+		loc := fmt.Sprintf("%s:%d", ref.Path, ref.Line)
+		if ref.Col > 0 {
+			loc += fmt.Sprintf(":%d", ref.Col)
+		}
+		return []string{"--goto", loc}
+	case "subl", "zed", "hx", "helix":
+		loc := fmt.Sprintf("%s:%d", ref.Path, ref.Line)
+		if ref.Col > 0 {
+			loc += fmt.Sprintf(":%d", ref.Col)
+		}
+		return []string{loc}
+	default:
+		return []string{fmt.Sprintf("+%d", ref.Line), ref.Path}
+	}
+}
+
+// fileOpenedMsg is sent when the editor opened by openFileRef exits.
+type fileOpenedMsg struct {
+	path string
+	err  error
+}
+
+// openFileRef suspends the TUI and opens ref in $VISUAL / $EDITOR (default
+// vi), at its line when it has one.
+func openFileRef(ref FileRef) tea.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// Through sh so an $EDITOR carrying flags ("code --wait") still works.
+	args := append([]string{"-c", editor + ` "$@"`, "sh"}, editorArgs(editor, ref)...)
+	path := ref.Path
+	return tea.ExecProcess(exec.Command("sh", args...), func(err error) tea.Msg {
+		return fileOpenedMsg{path: path, err: err}
+	})
+}
+
+// startOpenFile collects the file references in the session's preview and
+// opens the only one directly, or the picker when there are several.
+func (h *Home) startOpenFile(inst *session.Instance) tea.Cmd {
+	if inst.SSHHost != "" {
+		h.setError(fmt.Errorf("%q runs on %s: its files are not local", inst.Title, inst.SSHHost))
+		return nil
+	}
+	h.previewCacheMu.RLock()
+	content := h.previewCache[inst.ID]
+	h.previewCacheMu.RUnlock()
+
+	refs := extractFileRefs(content, inst.ProjectPath)
+	switch len(refs) {
+	case 0:
+		h.setError(fmt.Errorf("no file paths found in the preview"))
+		return nil
+	case 1:
+		return openFileRef(refs[0])
+	default:
+		h.fileRefDialog.SetSize(h.width, h.height)
+		h.fileRefDialog.Show(inst.Title, inst.ProjectPath, refs)
+		return nil
+	}
+}
+
+// FileRefDialog lists the file references found in a session's preview so
+// one can be opened in the editor. Mirrors CodeBlockDialog.
+type FileRefDialog struct {
+	visible       bool
+	width, height int
+	refs          []FileRef
+	cursor        int
+	sessionTitle  string
+	baseDir       string
+}
+
+// NewFileRefDialog creates an empty file reference picker.
+func NewFileRefDialog() *FileRefDialog {
+	return &FileRefDialog{}
+}
+
+// Show opens the picker with refs for sessionTitle. baseDir shortens the
+// listed paths.
+func (d *FileRefDialog) Show(sessionTitle, baseDir string, refs []FileRef) {
+	d.visible = true
+	d.sessionTitle = sessionTitle
+	d.baseDir = baseDir
+	d.refs = refs
+	d.cursor = 0
+}
+
+// Hide closes the dialog and clears its state.
+func (d *FileRefDialog) Hide() {
+	d.visible = false
+	d.refs = nil
+	d.cursor = 0
+}
+
+// IsVisible reports whether the dialog is shown. Nil-safe like
+// CodeBlockDialog, for partially constructed Home values in tests.
+func (d *FileRefDialog) IsVisible() bool { return d != nil && d.visible }
+
+// SetSize updates the dialog dimensions for centering.
+func (d *FileRefDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// GetSelected returns the reference at the cursor, or nil when none.
+func (d *FileRefDialog) GetSelected() *FileRef {
+	if d.cursor < 0 || d.cursor >= len(d.refs) {
+		return nil
+	}
+	return &d.refs[d.cursor]
+}
+
+// Update handles navigation keys; enter/esc are handled by the parent.
+func (d *FileRefDialog) Update(msg tea.KeyMsg) (*FileRefDialog, tea.Cmd) {
+	if !d.visible || len(d.refs) == 0 {
+		return d, nil
+	}
+	switch msg.String() {
+	case "j", "down":
+		d.cursor = (d.cursor + 1) % len(d.refs)
+	case "k", "up":
+		d.cursor = (d.cursor - 1 + len(d.refs)) % len(d.refs)
+	}
+	return d, nil
+}
+
+// label renders a reference relative to the session directory, with its
+// line and column.
+func (d *FileRefDialog) label(ref FileRef) string {
+	path := ref.Path
+	if rel, err := filepath.Rel(d.baseDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		path = rel
+	}
+	if ref.Line > 0 {
+		path += ":" + strconv.Itoa(ref.Line)
+		if ref.Col > 0 {
+			path += ":" + strconv.Itoa(ref.Col)
+		}
+	}
+	return path
+}
+
+// View renders the picker, windowed around the cursor like the code-block
+// picker so a long list never overflows the terminal.
+func (d *FileRefDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	sourceStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	selectedStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(ColorText)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	footerStyle := lipgloss.NewStyle().Foreground(ColorComment).Italic(true)
+
+	dialogWidth := fitDialogWidth(70, 36, d.width)
+	innerWidth := max(1, dialogWidth-4)
+	fit := func(s string) string { return cellTruncate(s, innerWidth, "…") }
+
+	var lines []string
+	lines = append(lines, fit(titleStyle.Render("Open File")))
+	lines = append(lines, fit(sourceStyle.Render(fmt.Sprintf("Session: %q", d.sessionTitle))))
+	lines = append(lines, "")
+
+	// Same chrome as the code-block picker: header, markers and footer.
+	rows := 12
+	if d.height > 0 {
+		rows = min(rows, max(1, d.height-codeBlockDialogChrome))
+	}
+	start, end := windowBounds(d.cursor, len(d.refs), rows)
+	if start > 0 {
+		lines = append(lines, fit(dimStyle.Render(fmt.Sprintf("  ↑ %d more", start))))
+	}
+	for i := start; i < end; i++ {
+		if i == d.cursor {
+			lines = append(lines, fit("> "+selectedStyle.Render(d.label(d.refs[i]))))
+		} else {
+			lines = append(lines, fit("  "+normalStyle.Render(d.label(d.refs[i]))))
+		}
+	}
+	if end < len(d.refs) {
+		lines = append(lines, fit(dimStyle.Render(fmt.Sprintf("  ↓ %d more", len(d.refs)-end))))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, fit(footerStyle.Render("Enter open in $EDITOR | Esc cancel | j/k navigate")))
+
+	box := DialogBoxStyle.Width(dialogWidth).Render(strings.Join(lines, "\n"))
+	return centerInScreen(box, d.width, d.height)
+}
+
+// handleFileRefDialogKey handles keys while the file picker is visible.
+func (h *Home) handleFileRefDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		selected := h.fileRefDialog.GetSelected()
+		h.fileRefDialog.Hide()
+		if selected != nil {
+			return h, openFileRef(*selected)
+		}
+		return h, nil
+	case "esc", "q":
+		h.fileRefDialog.Hide()
+		return h, nil
+	default:
+		h.fileRefDialog.Update(msg)
+		return h, nil
+	}
+}
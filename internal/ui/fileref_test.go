@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeFileRefFixture(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestExtractFileRefs(t *testing.T) {
+	dir := t.TempDir()
+	writeFileRefFixture(t, dir, "internal/ui/home.go", "main.go", "README.md")
+
+	output := strings.Join([]string{
+		"\x1b[1mUpdated\x1b[0m internal/ui/home.go:42:7 and main.go",
+		"see " + filepath.Join(dir, "README.md") + ":3 for details",
+		"version v1.2.3 of missing/file.go:10",
+		"  main.go:12: undefined: foo",
+		"again internal/ui/home.go:42",
+	}, "\n")
+	if got := extractFileRefs("at internal/ui/home.go:42:7", dir); len(got) != 1 || got[0].Col != 7 {
+		t.Errorf("line:col ref = %+v, want col 7", got)
+	}
+
+	got := extractFileRefs(output, dir)
+	// Newest first; the earlier home.go:42:7 is a repeat of home.go:42.
+	want := []FileRef{
+		{Path: filepath.Join(dir, "internal/ui/home.go"), Line: 42},
+		{Path: filepath.Join(dir, "main.go"), Line: 12},
+		{Path: filepath.Join(dir, "README.md"), Line: 3},
+		{Path: filepath.Join(dir, "main.go")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extractFileRefs =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestExtractFileRefs_SkipsDirectoriesAndCaps(t *testing.T) {
+	dir := t.TempDir()
+	writeFileRefFixture(t, dir, "pkg.v2/a.go")
+
+	if got := extractFileRefs("cd pkg.v2 now", dir); len(got) != 0 {
+		t.Errorf("directory matched as a file: %+v", got)
+	}
+
+	var b strings.Builder
+	for i := 0; i < maxFileRefs+20; i++ {
+		b.WriteString("pkg.v2/a.go:" + string(rune('0'+i%10)) + string(rune('0'+i/10)) + "\n")
+	}
+	if got := extractFileRefs(b.String(), dir); len(got) != maxFileRefs {
+		t.Errorf("got %d refs, want cap %d", len(got), maxFileRefs)
+	}
+}
+
+func TestEditorArgs(t *testing.T) {
+	ref := FileRef{Path: "/p/a.go", Line: 12, Col: 4}
+	tests := []struct {
+		editor string
+		ref    FileRef
+		want   []string
+	}{
+		{"vim", ref, []string{"+12", "/p/a.go"}},
+		{"/usr/bin/nvim", ref, []string{"+12", "/p/a.go"}},
+		{"nano", ref, []string{"+12", "/p/a.go"}},
+		{"code --wait", ref, []string{"--goto", "/p/a.go:12:4"}},
+		{"hx", FileRef{Path: "/p/a.go", Line: 12}, []string{"/p/a.go:12"}},
+		{"vim", FileRef{Path: "/p/a.go"}, []string{"/p/a.go"}},
+	}
+	for _, tt := range tests {
+		if got := editorArgs(tt.editor, tt.ref); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("editorArgs(%q, %+v) = %q, want %q", tt.editor, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestFileRefDialog_NavigateAndView(t *testing.T) {
+	d := NewFileRefDialog()
+	d.SetSize(100, 30)
+	d.Show("api", "/p", []FileRef{
+		{Path: "/p/a.go", Line: 3},
+		{Path: "/p/sub/b.go", Line: 7, Col: 2},
+		{Path: "/elsewhere/c.go"},
+	})
+
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if sel := d.GetSelected(); sel == nil || sel.Path != "/p/sub/b.go" {
+		t.Fatalf("selected = %+v, want b.go", sel)
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if sel := d.GetSelected(); sel == nil || sel.Path != "/elsewhere/c.go" {
+		t.Fatalf("selected = %+v, want wrap to c.go", sel)
+	}
+
+	view := d.View()
+	for _, want := range []string{"a.go:3", "sub/b.go:7:2", "/elsewhere/c.go"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	d.Hide()
+	if d.IsVisible() || d.GetSelected() != nil {
+		t.Error("Hide should clear the dialog")
+	}
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/safego"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// filesTouchedTTL bounds how often one session's checkout is diffed. Each
+// snapshot is a `git diff --numstat` plus an untracked-file listing.
+const filesTouchedTTL = 30 * time.Second
+
+// filesTouchedTarget describes how inst's touched files are measured: a
+// worktree against the merge base with its repo's default branch (all of
+// its changes are the session's), anything else from the first snapshot.
+func filesTouchedTarget(inst *session.Instance) git.TouchedTarget {
+	tg := git.TouchedTarget{Key: inst.ID, Dir: gitStatusDir(inst)}
+	if inst.IsWorktree() && inst.WorktreeRepoRoot != "" {
+		tg.Base = "main"
+		if detected, err := git.GetDefaultBranch(inst.WorktreeRepoRoot); err == nil {
+			tg.Base = detected
+		}
+	}
+	return tg
+}
+
+// refreshFilesTouched snapshots the checkouts of sessions whose analytics
+// panel can show touched files, off the worker goroutine. Sessions are
+// tracked from the first refresh that sees them, so the baseline for
+// non-worktree sessions is the state of the checkout at that moment.
+// Called from backgroundStatusUpdate.
+func (h *Home) refreshFilesTouched(instances []*session.Instance) {
+	if h.filesTouched == nil {
+		return
+	}
+	config, _ := session.LoadUserConfig()
+	if config == nil || !config.GetShowAnalytics() {
+		return
+	}
+	settings := config.Preview.GetAnalyticsSettings()
+	if !settings.GetShowFilesTouched() {
+		return
+	}
+	if !h.filesTouchedRefreshing.CompareAndSwap(false, true) {
+		return
+	}
+	targets := make([]git.TouchedTarget, 0, len(instances))
+	known := make(map[string]git.TouchedTarget, len(instances))
+	for _, inst := range instances {
+		if inst.IsArchived() || gitStatusDir(inst) == "" || !session.ToolAdapterFor(inst.Tool).HasAnalytics() {
+			continue
+		}
+		// The default-branch lookup runs once per session, not every tick.
+		tg, ok := h.filesTouchedTargets[inst.ID]
+		if !ok || tg.Dir != gitStatusDir(inst) {
+			tg = filesTouchedTarget(inst)
+		}
+		known[inst.ID] = tg
+		targets = append(targets, tg)
+	}
+	h.filesTouchedTargets = known
+	safego.Go(uiLog, "files_touched_refresh", func() {
+		defer h.filesTouchedRefreshing.Store(false)
+		h.filesTouched.Refresh(targets)
+	})
+}
+
+// filesTouchedFor returns the cached touched files of inst, or nil when not
+// yet snapshotted.
+func (h *Home) filesTouchedFor(inst *session.Instance) []git.FileChange {
+	if h.filesTouched == nil || inst == nil {
+		return nil
+	}
+	files, _ := h.filesTouched.Get(inst.ID)
+	return files
+}
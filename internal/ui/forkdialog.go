@@ -24,6 +24,7 @@ const (
 	forkFocusName forkFocusTarget = iota
 	forkFocusGroup
 	forkFocusConductor  // conditional — only when conductors exist.
+	forkFocusForkPoint  // conditional — only when the source has Claude fork points.
 	forkFocusBranch     // conditional — only when worktree enabled.
 	forkFocusCarryState // conditional — only when worktree enabled.
 	forkFocusGitignored // conditional — only when worktree && with-state enabled.
@@ -58,6 +59,11 @@ type ForkDialog struct {
 	// Conductor parent selector
 	conductorSessions []*session.Instance
 	conductorCursor   int // 0 = None, 1..n = conductorSessions[0..n-1]
+
+	// Fork point selector: branch from before a recent user turn instead of
+	// the conversation tip (Claude only).
+	forkPoints      []session.ClaudeForkPoint
+	forkPointCursor int // 0 = latest, 1..n = forkPoints[0..n-1]
 }
 
 // NewForkDialog creates a new fork dialog
@@ -99,6 +105,9 @@ func (d *ForkDialog) focusTargets() []forkFocusTarget {
 	if d.hasConductors() {
 		targets = append(targets, forkFocusConductor)
 	}
+	if len(d.forkPoints) > 0 {
+		targets = append(targets, forkFocusForkPoint)
+	}
 	if d.worktreeCapable && d.worktreeEnabled {
 		targets = append(targets, forkFocusBranch, forkFocusCarryState)
 		if d.withStateEnabled {
@@ -143,6 +152,8 @@ func (d *ForkDialog) currentFocusName() string {
 		return "group"
 	case forkFocusConductor:
 		return "conductor"
+	case forkFocusForkPoint:
+		return "forkPoint"
 	case forkFocusBranch:
 		return "branch"
 	case forkFocusCarryState:
@@ -183,6 +194,23 @@ func (d *ForkDialog) GetParentProjectPath() string {
 	return d.conductorSessions[d.conductorCursor-1].ProjectPath
 }
 
+// SetForkPoints offers the given user turns (newest first) as fork points,
+// with the conversation tip preselected. nil hides the selector.
+func (d *ForkDialog) SetForkPoints(points []session.ClaudeForkPoint) {
+	d.forkPoints = points
+	d.forkPointCursor = 0
+	d.clampFocus()
+}
+
+// GetResumeAt returns the ClaudeOptions.ResumeAt value for the selected fork
+// point ("" = fork from the tip).
+func (d *ForkDialog) GetResumeAt() string {
+	if d.forkPointCursor == 0 || d.forkPointCursor > len(d.forkPoints) {
+		return ""
+	}
+	return d.forkPoints[d.forkPointCursor-1].ResumeAt
+}
+
 // Show displays the dialog with pre-filled values
 func (d *ForkDialog) Show(originalName, projectPath, groupPath string, conductors []*session.Instance, suggestedParentID string) {
 	d.ShowWithParentSandboxed(originalName, projectPath, groupPath, conductors, suggestedParentID, false)
@@ -223,6 +251,8 @@ func (d *ForkDialog) ShowWithParentSandboxed(originalName, projectPath, groupPat
 	// Conductor parent selector
 	d.conductorSessions = conductors
 	d.conductorCursor = 0
+	d.forkPoints = nil
+	d.forkPointCursor = 0
 	for i, c := range conductors {
 		if c.ID == suggestedParentID {
 			d.conductorCursor = i + 1
@@ -428,6 +458,11 @@ func (d *ForkDialog) Update(msg tea.Msg) (*ForkDialog, tea.Cmd) {
 					return d, nil
 				}
 				// At last item — advance past conductor to next field.
+			} else if msg.String() == "down" && cur == forkFocusForkPoint {
+				if d.forkPointCursor < len(d.forkPoints) {
+					d.forkPointCursor++
+					return d, nil
+				}
 			} else if cur == forkFocusOptions {
 				// Inside options panel — delegate.
 				return d, d.optionsPanel.Update(msg)
@@ -447,6 +482,11 @@ func (d *ForkDialog) Update(msg tea.Msg) (*ForkDialog, tea.Cmd) {
 					return d, nil
 				}
 				// At None — retreat to the previous field.
+			} else if msg.String() == "up" && cur == forkFocusForkPoint {
+				if d.forkPointCursor > 0 {
+					d.forkPointCursor--
+					return d, nil
+				}
 			} else if cur == forkFocusOptions {
 				if !d.optionsPanel.AtTop() {
 					// Inside options panel, not at top — delegate.
@@ -594,8 +634,8 @@ func (d *ForkDialog) updateFocus() {
 		d.nameInput.Focus()
 	case forkFocusGroup:
 		d.groupInput.Focus()
-	case forkFocusConductor, forkFocusCarryState, forkFocusGitignored:
-		// Conductor picker and the with-state checkboxes activate no text input.
+	case forkFocusConductor, forkFocusForkPoint, forkFocusCarryState, forkFocusGitignored:
+		// The pickers and the with-state checkboxes activate no text input.
 	case forkFocusBranch:
 		d.branchInput.Focus()
 	case forkFocusOptions:
@@ -686,6 +726,8 @@ func (d *ForkDialog) View() string {
 		conductorSection += "\n"
 	}
 
+	forkPointSection := d.forkPointView(dialogWidth, labelStyle, activeLabelStyle)
+
 	// Worktree/workspace checkbox and branch input (git and jujutsu repos)
 	worktreeSection := ""
 	if d.worktreeCapable {
@@ -781,6 +823,7 @@ func (d *ForkDialog) View() string {
 		groupLabel + "\n" +
 		"  " + d.groupInput.View() + "\n" +
 		conductorSection +
+		forkPointSection +
 		worktreeSection +
 		sandboxSection + "\n" +
 		d.optionsPanel.View() +
@@ -793,3 +836,43 @@ func (d *ForkDialog) View() string {
 	// Center the dialog on screen
 	return lipgloss.Place(d.width, d.height, lipgloss.Center, lipgloss.Center, dialog)
 }
+
+// forkPointView renders the fork point selector. Unfocused it collapses to
+// the selected point, so a long turn list doesn't crowd the dialog.
+func (d *ForkDialog) forkPointView(dialogWidth int, labelStyle, activeLabelStyle lipgloss.Style) string {
+	if len(d.forkPoints) == 0 {
+		return ""
+	}
+	focused := d.currentFocus() == forkFocusForkPoint
+	label := labelStyle.Render("  Fork from:")
+	if focused {
+		label = activeLabelStyle.Render("▶ Fork from:")
+	}
+
+	selectedStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+	itemStyle := lipgloss.NewStyle().Foreground(ColorText)
+	maxPrompt := dialogWidth - 24
+	if maxPrompt < 10 {
+		maxPrompt = 10
+	}
+	itemLabel := func(idx int) string {
+		if idx == 0 {
+			return "Latest (whole conversation)"
+		}
+		p := d.forkPoints[idx-1]
+		return fmt.Sprintf("before #%d %q", p.Turn, truncateStr(p.Prompt, maxPrompt))
+	}
+
+	out := label + "\n"
+	if !focused {
+		return out + itemStyle.Render("    "+itemLabel(d.forkPointCursor)) + "\n\n"
+	}
+	for idx := 0; idx <= len(d.forkPoints); idx++ {
+		if idx == d.forkPointCursor {
+			out += selectedStyle.Render("  ▶ "+itemLabel(idx)) + "\n"
+		} else {
+			out += itemStyle.Render("    "+itemLabel(idx)) + "\n"
+		}
+	}
+	return out + "\n"
+}
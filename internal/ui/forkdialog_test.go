@@ -748,3 +748,45 @@ func TestForkDialog_Enter_OnNameStillSubmits(t *testing.T) {
 		t.Error("Enter on name must not hide the dialog (submit is handled by the caller)")
 	}
 }
+
+func TestForkDialog_ForkPoints_SelectAndResumeAt(t *testing.T) {
+	d := NewForkDialog()
+	d.SetSize(90, 40)
+	d.Show("Test", "/path", "group", nil, "")
+	d.worktreeEnabled = false
+	if got := tabOrder(d, tea.KeyMsg{Type: tea.KeyTab}, 2); !equalStrs(got, []string{"name", "group", "options"}) {
+		t.Fatalf("without fork points the picker must not take focus, got %v", got)
+	}
+
+	d.SetForkPoints([]session.ClaudeForkPoint{
+		{Turn: 3, Prompt: "revert that", ResumeAt: "a3"},
+		{Turn: 2, Prompt: "now rewrite the whole CLI", ResumeAt: "a2"},
+	})
+	if d.GetResumeAt() != "" {
+		t.Fatal("the conversation tip should be preselected")
+	}
+	if !strings.Contains(d.View(), "Latest (whole conversation)") {
+		t.Error("collapsed picker should show the selected point")
+	}
+
+	d.setFocus(forkFocusForkPoint)
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	d.Update(down)
+	d.Update(down)
+	if got := d.GetResumeAt(); got != "a2" {
+		t.Errorf("GetResumeAt() = %q, want a2", got)
+	}
+	if view := d.View(); !strings.Contains(view, "before #3") || !strings.Contains(view, "before #2") {
+		t.Errorf("focused picker should list every point; view:\n%s", view)
+	}
+
+	d.Update(down) // past the last point: leaves the picker
+	if d.currentFocusName() != "options" || d.GetResumeAt() != "a2" {
+		t.Errorf("down at the last point should advance focus and keep the choice, focus=%s", d.currentFocusName())
+	}
+
+	d.Show("Test", "/path", "group", nil, "")
+	if d.GetResumeAt() != "" || len(d.focusTargets()) != 3 {
+		t.Error("Show should reset the fork points of the previous source")
+	}
+}
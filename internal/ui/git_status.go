@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/safego"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// gitStatusTTL bounds how often one checkout is re-queried, however many
+// sessions point at it and however often the background worker ticks.
+const gitStatusTTL = 15 * time.Second
+
+// gitStatusDir is the checkout whose status a session row shows: the
+// worktree when there is one, else the project path. Remote (SSH) sessions
+// have no local checkout.
+func gitStatusDir(inst *session.Instance) string {
+	if inst == nil || inst.IsSSH() {
+		return ""
+	}
+	if inst.WorktreePath != "" {
+		return inst.WorktreePath
+	}
+	return inst.ProjectPath
+}
+
+// refreshGitStatus re-fetches stale git status entries off the worker
+// goroutine. A refresh still running from the previous tick is not doubled
+// up. Runs while badges or the branch view need it. Called from
+// backgroundStatusUpdate.
+func (h *Home) refreshGitStatus(instances []*session.Instance) {
+	if (!h.showGitStatus && !h.branchView) || h.gitStatus == nil {
+		return
+	}
+	if !h.gitStatusRefreshing.CompareAndSwap(false, true) {
+		return
+	}
+	dirs := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		if inst.IsArchived() {
+			continue
+		}
+		dirs = append(dirs, gitStatusDir(inst))
+	}
+	safego.Go(uiLog, "git_status_refresh", func() {
+		defer h.gitStatusRefreshing.Store(false)
+		h.gitStatus.Refresh(dirs)
+	})
+}
+
+// gitStatusBadge returns the row badge text for inst, or "" when disabled,
+// not yet fetched, not a git checkout, or clean and in sync.
+func (h *Home) gitStatusBadge(inst *session.Instance) string {
+	if !h.showGitStatus || h.gitStatus == nil {
+		return ""
+	}
+	st, ok := h.gitStatus.Get(gitStatusDir(inst))
+	if !ok {
+		return ""
+	}
+	return formatGitStatusBadge(st)
+}
+
+// formatGitStatusBadge renders "±3 ↑2 ↓1", omitting zero parts.
+func formatGitStatusBadge(st git.Status) string {
+	var parts []string
+	if st.Changed > 0 {
+		parts = append(parts, fmt.Sprintf("±%d", st.Changed))
+	}
+	if st.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", st.Ahead))
+	}
+	if st.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", st.Behind))
+	}
+	return strings.Join(parts, " ")
+}
+
+// branchKey places inst in the branch view: worktrees cluster under their
+// main repository, and the branch is the one actually checked out (from the
+// status cache) with the recorded worktree branch as a fallback until the
+// first refresh lands.
+func (h *Home) branchKey(inst *session.Instance) session.BranchKey {
+	dir := gitStatusDir(inst)
+	if dir == "" {
+		return session.BranchKey{}
+	}
+	var st git.Status
+	ok := false
+	if h.gitStatus != nil {
+		st, ok = h.gitStatus.Get(dir)
+	}
+	key := session.BranchKey{Repo: filepath.Clean(inst.ProjectPath), Branch: st.Branch}
+	if inst.IsWorktree() && inst.WorktreeRepoRoot != "" {
+		key.Repo = filepath.Clean(inst.WorktreeRepoRoot)
+	}
+	if !ok {
+		if !inst.IsWorktree() {
+			// Unknown yet, or not a git checkout at all.
+			return session.BranchKey{}
+		}
+		key.Branch = inst.WorktreeBranch
+	}
+	return key
+}
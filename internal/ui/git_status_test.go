@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestFormatGitStatusBadge(t *testing.T) {
+	cases := []struct {
+		st   git.Status
+		want string
+	}{
+		{git.Status{}, ""},
+		{git.Status{Changed: 3}, "±3"},
+		{git.Status{Changed: 3, Ahead: 2, HasUpstream: true}, "±3 ↑2"},
+		{git.Status{Ahead: 1, Behind: 4, HasUpstream: true}, "↑1 ↓4"},
+	}
+	for _, tc := range cases {
+		if got := formatGitStatusBadge(tc.st); got != tc.want {
+			t.Errorf("formatGitStatusBadge(%+v) = %q, want %q", tc.st, got, tc.want)
+		}
+	}
+}
+
+func TestGitStatusDir(t *testing.T) {
+	if got := gitStatusDir(&session.Instance{ProjectPath: "/repo"}); got != "/repo" {
+		t.Errorf("plain session dir = %q, want /repo", got)
+	}
+	if got := gitStatusDir(&session.Instance{ProjectPath: "/repo", WorktreePath: "/wt"}); got != "/wt" {
+		t.Errorf("worktree session dir = %q, want /wt", got)
+	}
+	if got := gitStatusDir(&session.Instance{ProjectPath: "/repo", SSHHost: "box"}); got != "" {
+		t.Errorf("ssh session dir = %q, want empty", got)
+	}
+}
+
+func TestBranchKeyBeforeStatusFetch(t *testing.T) {
+	h := &Home{}
+	wt := &session.Instance{ProjectPath: "/wt", WorktreePath: "/wt", WorktreeRepoRoot: "/repo/", WorktreeBranch: "feat"}
+	if got := h.branchKey(wt); got != (session.BranchKey{Repo: "/repo", Branch: "feat"}) {
+		t.Errorf("worktree key = %+v, want /repo feat", got)
+	}
+	if got := h.branchKey(&session.Instance{ProjectPath: "/repo"}); got != (session.BranchKey{}) {
+		t.Errorf("unfetched plain session key = %+v, want the no-repo cluster", got)
+	}
+}
@@ -39,6 +39,7 @@ var (
 // GlobalSearchResult wraps a search result for UI display
 type GlobalSearchResult struct {
 	SessionID   string
+	Tool        string // "claude", "gemini" or "opencode" (source badge, resume target)
 	Summary     string
 	Snippet     string
 	Content     string // Full conversation content for preview
@@ -84,7 +85,7 @@ type GlobalSearch struct {
 // NewGlobalSearch creates a new global search overlay
 func NewGlobalSearch() *GlobalSearch {
 	ti := textinput.New()
-	ti.Placeholder = "Search all Claude conversations..."
+	ti.Placeholder = "Search Claude, Gemini and OpenCode conversations..."
 	ti.Focus()
 	ti.CharLimit = 100
 	ti.Width = 60
@@ -182,7 +183,7 @@ func (gs *GlobalSearch) Update(msg tea.Msg) (*GlobalSearch, tea.Cmd) {
 			index := gs.index
 			return gs, func() tea.Msg {
 				results := index.Search(query)
-				if len(results) == 0 {
+				if len(results) == 0 && session.ParseSearchQuery(query, time.Now()).IsPlain() {
 					results = index.FuzzySearch(query)
 				}
 				return globalSearchResultsMsg{query: query, results: results}
@@ -297,7 +298,7 @@ func (gs *GlobalSearch) Update(msg tea.Msg) (*GlobalSearch, tea.Cmd) {
 func (gs *GlobalSearch) applySearchResults(query string, searchResults []*session.SearchResult) {
 	// Convert to UI results (limit to 15 for split view)
 	gs.results = make([]*GlobalSearchResult, 0, min(len(searchResults), 15))
-	queryLower := strings.ToLower(query)
+	queryLower := globalSearchHighlight(query)
 	for i, sr := range searchResults {
 		if i >= 15 {
 			break
@@ -310,10 +311,14 @@ func (gs *GlobalSearch) applySearchResults(query string, searchResults []*sessio
 				content = sr.Entry.Summary
 			}
 		}
-		// Count occurrences of query in content (case-insensitive)
-		matchCount := strings.Count(strings.ToLower(content), queryLower)
+		// Count occurrences of the leading phrase or word (case-insensitive)
+		matchCount := 0
+		if queryLower != "" {
+			matchCount = strings.Count(strings.ToLower(content), queryLower)
+		}
 		gs.results = append(gs.results, &GlobalSearchResult{
 			SessionID:  sr.Entry.SessionID,
+			Tool:       sr.Entry.Tool,
 			Summary:    sr.Entry.Summary,
 			Snippet:    sr.Snippet,
 			Content:    content, // Full content for preview (fallbacks for balanced tier)
@@ -410,6 +415,7 @@ func (gs *GlobalSearch) View() string {
 			if len(title) > maxTitleLen {
 				title = title[:maxTitleLen] + "..."
 			}
+			badge := globalSearchSourceBadge(result.Tool)
 
 			// Format date
 			dateStr := gs.formatRelativeTime(result.ModTime)
@@ -422,7 +428,7 @@ func (gs *GlobalSearch) View() string {
 
 			if i == gs.cursor {
 				// Selected item - highlight
-				line := globalSelectedStyle.Render(fmt.Sprintf("› %s", title))
+				line := globalSelectedStyle.Render(fmt.Sprintf("› %s", title)) + badge
 				leftPane.WriteString(line + "\n")
 				// Show date and match count below selected
 				matchText := "match"
@@ -433,7 +439,7 @@ func (gs *GlobalSearch) View() string {
 					Foreground(ColorPurple).
 					Render(fmt.Sprintf("    %s • %d %s", dateStr, result.MatchCount, matchText)) + "\n")
 			} else {
-				line := globalResultStyle.Render(fmt.Sprintf("%s%s", prefix, title))
+				line := globalResultStyle.Render(fmt.Sprintf("%s%s", prefix, title)) + badge
 				leftPane.WriteString(line + "\n")
 			}
 		}
@@ -480,8 +486,7 @@ func (gs *GlobalSearch) View() string {
 		contentLines := gs.formatPreviewContent(content, rightWidth-2)
 
 		// Auto-scroll to first match if scroll is at 0 (initial view)
-		if gs.previewScroll == 0 && gs.query != "" {
-			queryLower := strings.ToLower(gs.query)
+		if queryLower := globalSearchHighlight(gs.query); gs.previewScroll == 0 && queryLower != "" {
 			for i, line := range contentLines {
 				if strings.Contains(strings.ToLower(line), queryLower) {
 					// Scroll to a few lines before the match for context
@@ -557,7 +562,7 @@ func (gs *GlobalSearch) View() string {
 // formatPreviewContent formats the conversation content for preview display
 func (gs *GlobalSearch) formatPreviewContent(content string, maxWidth int) []string {
 	var lines []string
-	query := gs.query // Get current search query for highlighting
+	query := globalSearchHighlight(gs.query) // Get current search term for highlighting
 
 	// Split by newlines first
 	rawLines := strings.Split(content, "\n")
@@ -654,6 +659,12 @@ func (gs *GlobalSearch) wrapText(text string, maxWidth int) []string {
 	return lines
 }
 
+// globalSearchHighlight returns the lowercased text to highlight for a query:
+// its first phrase or word, without filter syntax like tool:gemini.
+func globalSearchHighlight(query string) string {
+	return session.ParseSearchQuery(query, time.Now()).Highlight()
+}
+
 // highlightMatches highlights occurrences of query in text
 func (gs *GlobalSearch) highlightMatches(text, query string) string {
 	if query == "" || text == "" {
@@ -684,17 +695,41 @@ func (gs *GlobalSearch) highlightMatches(text, query string) string {
 	return result.String()
 }
 
+// globalSearchSourceBadge renders the tool a result came from, in the tool's
+// list color, so Gemini/OpenCode hits stand out from Claude ones.
+func globalSearchSourceBadge(tool string) string {
+	if tool == "" {
+		tool = "claude"
+	}
+	return " " + lipgloss.NewStyle().Foreground(ToolColor(tool)).Render("["+tool+"]")
+}
+
+// instanceHasToolSession reports whether inst is the agent-deck session for
+// the given tool conversation.
+func instanceHasToolSession(inst *session.Instance, tool, sessionID string) bool {
+	if tool == "" {
+		tool = "claude"
+	}
+	return sessionID != "" && session.ToolAdapterFor(tool).SessionID(inst) == sessionID
+}
+
 // MarkInAgentDeck marks which results are already in Agent Deck
 func (gs *GlobalSearch) MarkInAgentDeck(instances []*session.Instance) {
-	idMap := make(map[string]string) // sessionID -> instanceID
+	idMap := make(map[string]string) // tool + sessionID -> instanceID
 	for _, inst := range instances {
-		if inst.ClaudeSessionID != "" {
-			idMap[inst.ClaudeSessionID] = inst.ID
+		for _, tool := range []string{"claude", "gemini", "opencode"} {
+			if id := session.ToolAdapterFor(tool).SessionID(inst); id != "" {
+				idMap[tool+":"+id] = inst.ID
+			}
 		}
 	}
 
 	for _, result := range gs.results {
-		if instID, ok := idMap[result.SessionID]; ok {
+		tool := result.Tool
+		if tool == "" {
+			tool = "claude"
+		}
+		if instID, ok := idMap[tool+":"+result.SessionID]; ok {
 			result.InAgentDeck = true
 			result.InstanceID = instID
 		}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
 func TestGlobalSearchVisibility(t *testing.T) {
@@ -247,3 +249,52 @@ func TestGlobalSearchMatchCount(t *testing.T) {
 		t.Error("Expected non-empty view output")
 	}
 }
+
+func TestGlobalSearchMarkInAgentDeck_PerTool(t *testing.T) {
+	gs := NewGlobalSearch()
+	gs.results = []*GlobalSearchResult{
+		{SessionID: "shared-id", Tool: "claude"},
+		{SessionID: "shared-id", Tool: "gemini"},
+		{SessionID: "ses_oc", Tool: "opencode"},
+	}
+	claudeInst := session.NewInstanceWithGroupAndTool("c", "/tmp/c", "work", "claude")
+	claudeInst.ClaudeSessionID = "shared-id"
+	ocInst := session.NewInstanceWithGroupAndTool("o", "/tmp/o", "work", "opencode")
+	ocInst.OpenCodeSessionID = "ses_oc"
+
+	gs.MarkInAgentDeck([]*session.Instance{claudeInst, ocInst})
+	if !gs.results[0].InAgentDeck || gs.results[0].InstanceID != claudeInst.ID {
+		t.Error("claude result should map to the claude instance")
+	}
+	if gs.results[1].InAgentDeck {
+		t.Error("gemini result must not match a claude session with the same ID")
+	}
+	if !gs.results[2].InAgentDeck || gs.results[2].InstanceID != ocInst.ID {
+		t.Error("opencode result should map to the opencode instance")
+	}
+}
+
+func TestGlobalSearchView_SourceBadges(t *testing.T) {
+	gs := NewGlobalSearch()
+	gs.SetSize(140, 40)
+	gs.Show()
+	gs.results = []*GlobalSearchResult{
+		{SessionID: "11111111-aaaa", Tool: "gemini", Summary: "ingress 502"},
+		{SessionID: "22222222-bbbb", Tool: "opencode", Summary: "billing refactor"},
+	}
+	view := gs.View()
+	for _, badge := range []string{"[gemini]", "[opencode]"} {
+		if !strings.Contains(view, badge) {
+			t.Errorf("view missing source badge %s", badge)
+		}
+	}
+}
+
+func TestGlobalSearchHighlight_IgnoresFilters(t *testing.T) {
+	if got := globalSearchHighlight(`tool:gemini "Connection Refused" retry`); got != "connection refused" {
+		t.Errorf("highlight = %q, want the phrase", got)
+	}
+	if got := globalSearchHighlight("project:api after:7d"); got != "" {
+		t.Errorf("filter-only query should highlight nothing, got %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// toggleGroupArchive archives or unarchives a group. An archived group,
+// subgroups included, is hidden from the tree unless archived groups are
+// shown, its sessions leave the header status counts and send no
+// notifications. Sessions and their history are kept.
+func (h *Home) toggleGroupArchive(groupPath string) {
+	if h.groupTree == nil {
+		return
+	}
+	identity := h.captureSelectedItemIdentity()
+	archived, ok := h.groupTree.ToggleGroupArchived(groupPath)
+	if !ok {
+		h.setError(fmt.Errorf("group %q not found", groupPath))
+		return
+	}
+	h.syncMutedGroups()
+	h.refreshSessionRenderSnapshot(nil)
+	h.cachedStatusCounts.valid.Store(false)
+	h.rebuildFlatItemsPreservingSelection(identity)
+	h.saveGroupState()
+	if archived {
+		h.maintenanceMsg = fmt.Sprintf("Archived group %s", groupPath)
+	} else {
+		h.maintenanceMsg = fmt.Sprintf("Unarchived group %s", groupPath)
+	}
+	h.maintenanceMsgTime = time.Now()
+}
+
+// toggleShowArchivedGroups shows or hides archived groups in the tree.
+func (h *Home) toggleShowArchivedGroups() {
+	if h.groupTree == nil {
+		return
+	}
+	identity := h.captureSelectedItemIdentity()
+	h.showArchivedGroups = !h.showArchivedGroups
+	h.rebuildFlatItemsPreservingSelection(identity)
+	if h.showArchivedGroups {
+		h.maintenanceMsg = "Showing archived groups"
+	} else {
+		h.maintenanceMsg = "Hiding archived groups"
+	}
+	h.maintenanceMsgTime = time.Now()
+}
+
+// archivedGroupPaths returns the archived groups. Safe from any goroutine.
+func (h *Home) archivedGroupPaths() []string {
+	paths, _ := h.archivedGroups.Load().([]string)
+	return paths
+}
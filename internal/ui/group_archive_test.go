@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestGroupArchive_HidesGroupAndExcludesItsSessions(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	home.setHotkeys(resolveHotkeys(nil))
+	home.initialLoading = false
+	for _, inst := range insts {
+		inst.Status = session.StatusIdle
+	}
+	altA := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}, Alt: true}
+	altShiftA := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}, Alt: true}
+	shown := func(path string) bool {
+		for _, it := range home.flatItems {
+			if it.Type == session.ItemTypeGroup && it.Path == path {
+				return true
+			}
+		}
+		return false
+	}
+
+	// On a session row the key archives the session's group.
+	moveCursorTo(t, home, func(it session.Item) bool {
+		return it.Type == session.ItemTypeSession && it.Session == insts[0]
+	})
+	home.handleMainKey(altA)
+	if !home.groupTree.Groups["work"].Archived {
+		t.Fatal("alt+a on a session should archive its group")
+	}
+	if shown("work") || shown("work/sub") {
+		t.Fatal("an archived group and its subgroups should leave the tree")
+	}
+	if !shown("other") {
+		t.Fatal("other groups should stay in the tree")
+	}
+	if _, _, idle, _, _ := home.countSessionStatuses(); idle != 1 {
+		t.Errorf("idle count = %d, want 1 (archived group's sessions excluded)", idle)
+	}
+	if !session.InMutedGroup(home.mutedGroupPaths(), "work/sub") {
+		t.Error("sessions in an archived group should not notify")
+	}
+
+	// Showing archived groups brings it back with a marker.
+	home.handleMainKey(altShiftA)
+	if !shown("work") || !strings.Contains(home.View(), "[archived]") {
+		t.Fatal("alt+A should list archived groups with a marker")
+	}
+
+	// On the group row it unarchives.
+	moveCursorTo(t, home, func(it session.Item) bool {
+		return it.Type == session.ItemTypeGroup && it.Group != nil && it.Group.Path == "work"
+	})
+	home.handleMainKey(altA)
+	if home.groupTree.Groups["work"].Archived || len(home.mutedGroupPaths()) != 0 {
+		t.Fatal("alt+a on the archived group should unarchive it")
+	}
+}
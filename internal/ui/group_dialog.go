@@ -17,9 +17,10 @@ const (
 	GroupDialogRename
 	GroupDialogMove
 	GroupDialogRenameSession
+	GroupDialogMoveGroup
 )
 
-// GroupDialog handles group creation, renaming, and moving sessions
+// GroupDialog handles group creation, renaming, and moving sessions and groups
 type GroupDialog struct {
 	visible       bool
 	mode          GroupDialogMode
@@ -30,7 +31,7 @@ type GroupDialog struct {
 	height        int
 	groupPath     string   // Current group being edited (for rename) or parent path (for create subgroup)
 	parentName    string   // Display name of parent group (for subgroup creation)
-	groupPaths    []string // Available target group paths (for move)
+	groupPaths    []string // Available target group paths (for move; "" is the top level when moving a group)
 	selected      int      // Selected group index (for move)
 	sessionID     string   // Session ID being renamed (for rename session)
 	validationErr string   // Inline validation error displayed inside the dialog
@@ -175,6 +176,18 @@ func (g *GroupDialog) ShowMove(groupPaths []string) {
 	g.selected = 0
 }
 
+// ShowMoveGroup shows the dialog for moving the group at groupPath under
+// one of parents, where "" stands for the top level.
+func (g *GroupDialog) ShowMoveGroup(groupPath, groupName string, parents []string) {
+	g.visible = true
+	g.mode = GroupDialogMoveGroup
+	g.validationErr = ""
+	g.groupPath = groupPath
+	g.parentName = groupName
+	g.groupPaths = parents
+	g.selected = 0
+}
+
 // ShowRenameSession shows the dialog for renaming a session
 func (g *GroupDialog) ShowRenameSession(sessionID, currentName string) {
 	g.visible = true
@@ -245,8 +258,8 @@ func (g *GroupDialog) focusPath() {
 
 // Validate checks if the dialog values are valid and returns an error message if not
 func (g *GroupDialog) Validate() string {
-	if g.mode == GroupDialogMove {
-		return "" // Move mode doesn't need validation
+	if g.mode == GroupDialogMove || g.mode == GroupDialogMoveGroup {
+		return "" // Move modes don't need validation
 	}
 
 	name := strings.TrimSpace(g.nameInput.Value())
@@ -299,7 +312,8 @@ func (g *GroupDialog) HasParent() bool {
 	return g.groupPath != "" && g.mode == GroupDialogCreate
 }
 
-// GetSelectedGroup returns the selected group for move mode
+// GetSelectedGroup returns the selected group for the move modes. When moving
+// a group, "" means the top level.
 func (g *GroupDialog) GetSelectedGroup() string {
 	if g.selected >= 0 && g.selected < len(g.groupPaths) {
 		return g.groupPaths[g.selected]
@@ -315,7 +329,7 @@ func (g *GroupDialog) SetSize(width, height int) {
 
 // Update handles input
 func (g *GroupDialog) Update(msg tea.KeyMsg) (*GroupDialog, tea.Cmd) {
-	if g.mode == GroupDialogMove {
+	if g.mode == GroupDialogMove || g.mode == GroupDialogMoveGroup {
 		switch msg.String() {
 		case "up", "k":
 			if g.selected > 0 {
@@ -413,10 +427,16 @@ func (g *GroupDialog) View() string {
 	case GroupDialogRename:
 		title = "Rename Group"
 		content = g.nameInput.View()
-	case GroupDialogMove:
+	case GroupDialogMove, GroupDialogMoveGroup:
 		title = "Move to Group"
+		if g.mode == GroupDialogMoveGroup {
+			title = "Move Group Into"
+		}
 		var items []string
 		for i, groupPath := range g.groupPaths {
+			if groupPath == "" {
+				groupPath = "(top level)"
+			}
 			if i == g.selected {
 				items = append(items, lipgloss.NewStyle().
 					Foreground(ColorBg).
@@ -432,6 +452,12 @@ func (g *GroupDialog) View() string {
 			}
 		}
 		content = strings.Join(items, "\n")
+		if g.mode == GroupDialogMoveGroup {
+			source := lipgloss.NewStyle().
+				Foreground(ColorCyan).
+				Render("Group: " + g.parentName)
+			content = source + "\n\n" + content
+		}
 	case GroupDialogRenameSession:
 		title = "Rename Session"
 		content = g.nameInput.View()
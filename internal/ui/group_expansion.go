@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// collapseAllGroups collapses every group; the cursor falls back to the
+// top-level group of whatever it was on.
+func (h *Home) collapseAllGroups() {
+	h.changeGroupExpansion(func() { h.groupTree.SetAllExpanded(false) })
+}
+
+// expandAllGroups expands every group.
+func (h *Home) expandAllGroups() {
+	h.changeGroupExpansion(func() { h.groupTree.SetAllExpanded(true) })
+}
+
+// expandWaitingGroups expands only the groups holding a waiting session (and
+// their parents) and collapses the rest.
+func (h *Home) expandWaitingGroups() {
+	h.changeGroupExpansion(func() { h.groupTree.ExpandOnly(h.waitingGroupPaths()) })
+}
+
+// changeGroupExpansion applies an expand/collapse change to the tree, keeps
+// the cursor on the same row (or the closest visible group when that row is
+// now hidden), and persists the new state like a single-group toggle does.
+func (h *Home) changeGroupExpansion(apply func()) {
+	if h.groupTree == nil {
+		return
+	}
+	selectedBefore := h.captureSelectedItemIdentity()
+	fallbackGroup := h.selectedGroupPath()
+	apply()
+	h.rebuildFlatItems()
+	if !h.restoreSelectedItemIdentity(selectedBefore) {
+		h.moveCursorToVisibleGroup(fallbackGroup)
+	}
+	h.syncViewport()
+	h.saveGroupState()
+}
+
+// applyStartupGroupExpansion applies [display] group_expansion once the first
+// load has built the group tree and seeded declarative groups. "saved" keeps
+// the persisted state. The override only changes the in-memory tree; it is
+// not written at startup, so the stored state stays what the user last left
+// it as until the next group-state save.
+func (h *Home) applyStartupGroupExpansion() {
+	if h.groupTree == nil {
+		return
+	}
+	switch h.groupExpansion {
+	case "expanded":
+		h.groupTree.SetAllExpanded(true)
+	case "collapsed":
+		h.groupTree.SetAllExpanded(false)
+	case "waiting":
+		h.groupTree.ExpandOnly(h.waitingGroupPaths())
+	}
+}
+
+// waitingGroupPaths returns the group of every session waiting for input.
+func (h *Home) waitingGroupPaths() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, inst := range h.groupTree.GetAllInstances() {
+		if inst.IsArchived() || inst.GetStatusThreadSafe() != session.StatusWaiting {
+			continue
+		}
+		if !seen[inst.GroupPath] {
+			seen[inst.GroupPath] = true
+			paths = append(paths, inst.GroupPath)
+		}
+	}
+	return paths
+}
+
+// selectedGroupPath returns the group the cursor row belongs to.
+func (h *Home) selectedGroupPath() string {
+	if h.cursor < 0 || h.cursor >= len(h.flatItems) {
+		return ""
+	}
+	item := h.flatItems[h.cursor]
+	if item.Type == session.ItemTypeGroup {
+		return item.Path
+	}
+	if item.Type == session.ItemTypeWindow {
+		if inst := h.getInstanceByID(item.WindowSessionID); inst != nil {
+			return inst.GroupPath
+		}
+	}
+	return item.Path
+}
+
+// moveCursorToVisibleGroup moves the cursor to groupPath's header, or to its
+// nearest ancestor that is still visible.
+func (h *Home) moveCursorToVisibleGroup(groupPath string) {
+	best, bestLen := -1, -1
+	for i, item := range h.flatItems {
+		if item.Type != session.ItemTypeGroup {
+			continue
+		}
+		if item.Path == groupPath || strings.HasPrefix(groupPath, item.Path+"/") {
+			if len(item.Path) > bestLen {
+				best, bestLen = i, len(item.Path)
+			}
+		}
+	}
+	if best >= 0 {
+		h.cursor = best
+		return
+	}
+	if len(h.flatItems) > 0 {
+		h.cursor = max(0, min(h.cursor, len(h.flatItems)-1))
+	}
+}
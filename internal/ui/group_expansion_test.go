@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestCollapseAllGroups_CursorFallsBackToTopLevelGroup(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	home.storage = nil // Avoid touching persistence in this unit test.
+	c := insts[2]      // in work/sub
+	moveCursorTo(t, home, func(it session.Item) bool {
+		return it.Type == session.ItemTypeSession && it.Session == c
+	})
+
+	home.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}, Alt: true})
+	for path, g := range home.groupTree.Groups {
+		if g.Expanded {
+			t.Errorf("%s should be collapsed", path)
+		}
+	}
+	if item := home.flatItems[home.cursor]; item.Type != session.ItemTypeGroup || item.Path != "work" {
+		t.Errorf("cursor should land on the visible ancestor group 'work', got %+v", item)
+	}
+
+	home.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'='}, Alt: true})
+	for path, g := range home.groupTree.Groups {
+		if !g.Expanded {
+			t.Errorf("%s should be expanded", path)
+		}
+	}
+}
+
+func TestExpandWaitingGroups(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	home.storage = nil
+	c := insts[2] // in work/sub
+	c.Status = session.StatusWaiting
+
+	home.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}, Alt: true})
+	want := map[string]bool{"work": true, "work/sub": true, "other": false}
+	for path, expanded := range want {
+		if got := home.groupTree.Groups[path].Expanded; got != expanded {
+			t.Errorf("%s expanded = %v, want %v", path, got, expanded)
+		}
+	}
+}
+
+func TestApplyStartupGroupExpansion(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	insts[3].Status = session.StatusWaiting // in other
+
+	home.groupExpansion = "saved"
+	home.groupTree.CollapseGroup("work")
+	home.applyStartupGroupExpansion()
+	if home.groupTree.Groups["work"].Expanded || !home.groupTree.Groups["other"].Expanded {
+		t.Error(`"saved" should leave the stored state alone`)
+	}
+
+	home.groupExpansion = "waiting"
+	home.applyStartupGroupExpansion()
+	if home.groupTree.Groups["work"].Expanded || !home.groupTree.Groups["other"].Expanded {
+		t.Error(`"waiting" should expand only the group with a waiting session`)
+	}
+
+	home.groupExpansion = "expanded"
+	home.applyStartupGroupExpansion()
+	if !home.groupTree.Groups["work"].Expanded {
+		t.Error(`"expanded" should expand every group`)
+	}
+}
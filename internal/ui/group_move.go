@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// showMoveGroupDialog opens the dialog listing the groups g can be moved
+// under.
+func (h *Home) showMoveGroupDialog(g *session.Group) {
+	if g.Path == session.DefaultGroupPath {
+		h.setError(fmt.Errorf("the default group %q cannot be moved", session.DefaultGroupPath))
+		return
+	}
+	parents := h.groupTree.ReparentTargets(g.Path)
+	if len(parents) == 0 {
+		h.setError(fmt.Errorf("no other group to move %q into", g.Name))
+		return
+	}
+	h.groupDialog.ShowMoveGroup(g.Path, g.Name, parents)
+}
+
+// moveGroup moves the group at sourcePath, with its subgroups and sessions,
+// under destParentPath ("" for the top level). Subgroup paths and the
+// sessions' group paths are rewritten, and the moved group stays selected.
+func (h *Home) moveGroup(sourcePath, destParentPath string) error {
+	if err := h.groupTree.MoveGroupTo(sourcePath, destParentPath); err != nil {
+		return err
+	}
+	newPath := sourcePath[strings.LastIndex(sourcePath, "/")+1:]
+	if destParentPath != "" {
+		newPath = destParentPath + "/" + newPath
+	}
+	if h.groupScope == sourcePath || strings.HasPrefix(h.groupScope, sourcePath+"/") {
+		h.groupScope = newPath + h.groupScope[len(sourcePath):]
+	}
+	h.groupTree.ExpandGroupWithParents(destParentPath)
+
+	h.instancesMu.Lock()
+	h.instances = h.groupTree.GetAllInstances()
+	h.instancesMu.Unlock()
+	h.syncMutedGroups()
+	h.rebuildFlatItems()
+	for i, item := range h.flatItems {
+		if item.Type == session.ItemTypeGroup && item.Path == newPath {
+			h.cursor = i
+			break
+		}
+	}
+	h.syncViewport()
+	h.saveInstances()
+
+	h.maintenanceMsg = fmt.Sprintf("Moved group to %s", newPath)
+	h.maintenanceMsgTime = time.Now()
+	return nil
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestMoveGroup_FromGroupRowRehomesSubtree(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	home.initialLoading = false
+
+	moveCursorTo(t, home, func(it session.Item) bool {
+		return it.Type == session.ItemTypeGroup && it.Path == "work"
+	})
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	if !home.groupDialog.IsVisible() || home.groupDialog.Mode() != GroupDialogMoveGroup {
+		t.Fatal("M on a group row should open the move-group dialog")
+	}
+	for _, p := range home.groupDialog.groupPaths {
+		if p == "work" || p == "work/sub" {
+			t.Fatalf("candidate parents %v must not include the group or its subgroups", home.groupDialog.groupPaths)
+		}
+	}
+
+	home.groupDialog.selected = -1
+	for i, p := range home.groupDialog.groupPaths {
+		if p == "other" {
+			home.groupDialog.selected = i
+		}
+	}
+	if home.groupDialog.selected == -1 {
+		t.Fatalf("other not offered as a parent: %v", home.groupDialog.groupPaths)
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if home.groupDialog.IsVisible() {
+		t.Fatal("dialog should close after a successful move")
+	}
+	if _, ok := home.groupTree.Groups["other/work/sub"]; !ok {
+		t.Fatal("subgroup path should be rewritten to other/work/sub")
+	}
+	if insts[0].GroupPath != "other/work" || insts[2].GroupPath != "other/work/sub" {
+		t.Errorf("sessions not re-homed: %q, %q", insts[0].GroupPath, insts[2].GroupPath)
+	}
+	if item := home.flatItems[home.cursor]; item.Type != session.ItemTypeGroup || item.Path != "other/work" {
+		t.Errorf("cursor on %q, want the moved group", item.Path)
+	}
+}
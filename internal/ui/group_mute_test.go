@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestGroupMute_TogglesFromSessionAndGroupRows(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	home.setHotkeys(resolveHotkeys(nil))
+	home.initialLoading = false
+	altN := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}, Alt: true}
+
+	// On a session row the key mutes the session's group.
+	moveCursorTo(t, home, func(it session.Item) bool {
+		return it.Type == session.ItemTypeSession && it.Session == insts[0]
+	})
+	home.handleMainKey(altN)
+	if !home.groupTree.Groups["work"].NotificationsMuted {
+		t.Fatal("alt+n on a session should mute its group")
+	}
+	if got := home.mutedGroupPaths(); len(got) != 1 || got[0] != "work" {
+		t.Fatalf("mutedGroupPaths() = %v, want [work]", got)
+	}
+	if !strings.Contains(home.View(), "🔕") {
+		t.Error("a muted group row should show the muted marker")
+	}
+
+	// On the group row it toggles back.
+	moveCursorTo(t, home, func(it session.Item) bool {
+		return it.Type == session.ItemTypeGroup && it.Group != nil && it.Group.Path == "work"
+	})
+	home.handleMainKey(altN)
+	if home.groupTree.Groups["work"].NotificationsMuted || len(home.mutedGroupPaths()) != 0 {
+		t.Fatal("alt+n on the muted group should unmute it")
+	}
+}
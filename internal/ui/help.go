@@ -166,6 +166,7 @@ func (h *HelpOverlay) View() string {
 	// Define help sections
 	newKeys := h.keyPair(hotkeyNewSession, hotkeyQuickCreate, "n/N")
 	forkKeys := h.keyPair(hotkeyQuickFork, hotkeyForkWithOptions, "f/F")
+	cloneKey := h.key(hotkeyCloneSession, "Alt+F")
 	reorderUpKeys := "+ / K / Shift+↑"
 	reorderDownKeys := "- / J / Shift+↓"
 	indentKeys := "Shift+→/←"
@@ -182,16 +183,25 @@ func (h *HelpOverlay) View() string {
 	renameKey := h.key(hotkeyRename, "r")
 	moveKey := h.key(hotkeyMoveToGroup, "M")
 	mcpKey := h.key(hotkeyMCPManager, "m")
+	mcpPoolKey := h.key(hotkeyMCPPool, "Alt+Shift+M")
 	pluginKey := h.key(hotkeyPluginManager, "L")
 	skillsKey := h.key(hotkeySkillsManager, "s")
 	previewKey := h.key(hotkeyTogglePreview, "v")
 	groupViewKey := h.key(hotkeyCycleGroupView, "t")
+	branchViewKey := h.key(hotkeyBranchView, "Alt+B")
+	sortKey := h.key(hotkeyCycleSort, "Alt+S")
+	collapseAllKey := h.key(hotkeyCollapseAll, "Alt+-")
+	expandAllKey := h.key(hotkeyExpandAll, "Alt+=")
+	expandWaitingKey := h.key(hotkeyExpandWaiting, "Alt+W")
 	// Opt-in: empty when switch_session is unbound, so the filter drops the row.
 	switchKey := h.key(hotkeySwitchSession, "")
+	recentKey := h.key(hotkeyRecentSessions, "`")
 	unreadKey := h.key(hotkeyMarkUnread, "u")
 	quickApproveKey := h.key(hotkeyQuickApprove, "a")
 	promptSessionKey := h.key(hotkeyPromptSession, "o")
+	pasteFileKey := h.key(hotkeyPasteFile, "Alt+V")
 	copyKey := h.key(hotkeyCopyOutput, "c")
+	copyPaneKey := h.key(hotkeyCopyPane, "Alt+C")
 	sendKey := h.key(hotkeySendOutput, "x")
 	execShellKey := h.key(hotkeyExecShell, "E")
 	notesKey := h.key(hotkeyEditNotes, "e")
@@ -202,12 +212,46 @@ func (h *HelpOverlay) View() string {
 	editSessionKey := h.key(hotkeyEditSession, "P")
 	worktreeSetupKey := h.key(hotkeyWorktreeSetup, "b")
 	worktreeKey := h.key(hotkeyWorktreeFinish, "W")
+	createPRKey := h.key(hotkeyCreatePR, "B")
+	worktreeDiffKey := h.key(hotkeyWorktreeDiff, "=")
 	watcherPanelKey := h.key(hotkeyWatcherPanel, "w")
+	fixSessionIDKey := h.key(hotkeyFixSessionID, "O")
+	toggleSelectKey := h.key(hotkeyToggleSelect, "V")
+	previewScrollKeys := h.key(hotkeyPreviewScrollUp, "[") + " / " + h.key(hotkeyPreviewScrollDown, "]")
 	groupKey := h.key(hotkeyCreateGroup, "g")
+	muteGroupKey := h.key(hotkeyMuteGroup, "Alt+N")
+	archiveGroupKey := h.key(hotkeyArchiveGroup, "Alt+A")
+	showArchivedGroupsKey := h.key(hotkeyArchivedGroups, "Alt+Shift+A")
 	undoKey := h.key(hotkeyUndoDelete, "Ctrl+Z")
 	archiveKey := h.key(hotkeyArchiveSession, "A")
 	unarchiveKey := h.key(hotkeyUnarchiveSession, "Shift+U")
 	viewArchivedKey := h.key(hotkeyViewArchived, "^")
+	analyticsDashKey := h.key(hotkeyAnalyticsDash, "H")
+	filesTouchedKey := h.key(hotkeyFilesTouched, "Alt+D")
+	transcriptKey := h.key(hotkeyViewTranscript, "Ctrl+T")
+	profileKey := h.key(hotkeyProfileSwitcher, "Alt+P")
+	moveProfileKey := h.key(hotkeyMoveToProfile, "Alt+M")
+	trashKey := h.key(hotkeyTrashView, "Alt+T")
+	timelineKey := h.key(hotkeySessionTimeline, "Alt+H")
+	conversationKey := h.key(hotkeyConversation, "Alt+R")
+	openFileKey := h.key(hotkeyOpenFile, "Alt+E")
+	verifyKey := h.key(hotkeyVerify, "Alt+Shift+T")
+	snapshotsKey := h.key(hotkeySnapshots, "Alt+Z")
+	permissionModeKey := h.key(hotkeyPermissionMode, "Alt+Y")
+	attachKey := h.key(hotkeyAttach, "Enter")
+	if attachKey == defaultHotkeyBindings[hotkeyAttach] {
+		attachKey = "Enter"
+	}
+	attachSplitKey := h.key(hotkeyAttachSplit, "Alt+Enter")
+	spectateKey := h.key(hotkeySpectate, "Alt+O")
+	statusFilterKeys := joinHotkeyLabels(
+		h.key(hotkeyFilterRunning, "!"), h.key(hotkeyFilterWaiting, "@"),
+		h.key(hotkeyFilterIdle, "#"), h.key(hotkeyFilterError, "$"),
+	)
+	filterAllKey := h.key(hotkeyFilterAll, "0")
+	filterOpenKey := h.key(hotkeyFilterOpen, FilterKeyActive)
+	filterTagKey := h.key(hotkeyFilterTag, FilterKeyTag)
+	paletteKey := h.key(hotkeyCommandPalette, "Ctrl+K")
 
 	sections := []struct {
 		title string
@@ -227,7 +271,9 @@ func (h *HelpOverlay) View() string {
 				{"l / Right", "Expand / toggle"},
 				{"1-9", "Jump to root group"},
 				{"Space", "Jump mode"},
-				{"Enter", "Attach / toggle"},
+				{attachKey, "Attach / toggle"},
+				{attachSplitKey, "Attach in a tmux pane beside the dashboard"},
+				{spectateKey, "Spectate: attach read-only, keys are not sent"},
 				{"Shift+Enter", "Open session in new iTerm window (macOS)"},
 			},
 		},
@@ -247,29 +293,47 @@ func (h *HelpOverlay) View() string {
 				{renameKey, "Rename session"},
 				{restartKey, "Restart session"},
 				{restartFreshKey, "Restart with new session ID"},
+				{fixSessionIDKey, "Adopt detected Claude session ID"},
 				{deleteKey, "Delete session"},
 				{closeKey, "Close session process"},
 				{undoKey, "Undo delete"},
+				{trashKey, "Trash (restore deleted sessions)"},
+				{timelineKey, "Session timeline (starts, restarts, status changes)"},
+				{conversationKey, "View conversation (Claude/Gemini, read-only)"},
+				{permissionModeKey, "Claude permission mode (plan/default/auto/skip)"},
 				{archiveKey, "Archive session"},
 				{unarchiveKey, "Unarchive session"},
 				{viewArchivedKey, "Toggle archived view"},
-				{moveKey, "Move to group"},
+				{moveKey, "Move to group (on a group: move it under another)"},
+				{moveProfileKey, "Move to profile"},
+				{toggleSelectKey, "Mark session/group for bulk delete/move/restart/ack (Esc clears)"},
 				{mcpKey, "MCP Manager (Claude/Gemini/Cursor)"},
+				{mcpPoolKey, "MCP pool dashboard (restart/stop pooled MCPs)"},
 				{pluginKey, "Plugin Manager (Claude — RFC PLUGIN_ATTACH.md)"},
 				{skillsKey, "Skills Manager"},
-				{"$", "Cost Dashboard"},
+				{h.key(hotkeyFilterError, "$"), "Cost Dashboard"},
+				{analyticsDashKey, "Analytics Dashboard (tokens/day, cost by group, busiest sessions)"},
 				{previewKey, "Toggle preview mode (output/stats/both)"},
+				{filesTouchedKey, "Expand / collapse files touched (analytics panel)"},
 				{"< / >", "Shrink / grow preview pane by 5% (issue #1092)"},
+				{previewScrollKeys, "Scroll preview back / forward through scrollback (Esc: tail)"},
 				{unreadKey, "Mark unread"},
 				{quickApproveKey, "Quick approve (send '1' to Claude)"},
-				{promptSessionKey, "Prompt session (send a one-line prompt without attaching)"},
+				{promptSessionKey, "Prompt session (on a group or marked sessions: broadcast)"},
+				{pasteFileKey, "Paste a file's contents into the session"},
 				{reorderUpKeys, "Reorder up (auto-promote at edge)"},
 				{reorderDownKeys, "Reorder down (auto-promote at edge)"},
 				{indentKeys, "Indent / outdent (in group)"},
 				{forkKeys, "Fork session (Claude/Pi)"},
+				{cloneKey, "Clone session (same setup, fresh conversation)"},
 				{copyKey, "Copy output to clipboard"},
+				{copyPaneKey, "Copy pane content to clipboard"},
+				{transcriptKey, "View transcript in $PAGER ([transcripts] in config)"},
 				{"C", "Copy preview info (Repo / Path / Branch)"},
 				{"Y", "Copy a code block from output"},
+				{openFileKey, "Open a file:line from the preview in $EDITOR"},
+				{verifyKey, "Run the project's verify command ([verify] in config)"},
+				{snapshotsKey, "Checkout snapshots: roll back a bad agent edit"},
 				{sendKey, "Send output to session"},
 				{execShellKey, "Exec shell in sandbox container"},
 				{editPathsKey, "Edit multi-repo paths"},
@@ -282,6 +346,8 @@ func (h *HelpOverlay) View() string {
 			items: [][2]string{
 				{worktreeSetupKey, "Re-run worktree setup script"},
 				{worktreeKey, "Finish worktree (merge + cleanup)"},
+				{worktreeDiffKey, "Diff worktree against its base branch"},
+				{createPRKey, "Push branch and open a pull request (gh)"},
 				{"n → w", "Create session in worktree"},
 				{"F → w", "Fork session into worktree"},
 			},
@@ -297,28 +363,42 @@ func (h *HelpOverlay) View() string {
 			items: [][2]string{
 				{groupKey, "New group"},
 				{renameKey, "Rename group"},
+				{muteGroupKey, "Mute / unmute the group's notifications"},
+				{archiveGroupKey, "Archive / unarchive the group"},
+				{showArchivedGroupsKey, "Show / hide archived groups"},
 				{"Tab", "Toggle expand"},
+				{collapseAllKey, "Collapse all groups"},
+				{expandAllKey, "Expand all groups"},
+				{expandWaitingKey, "Expand only groups with waiting sessions"},
 			},
 		},
 		{
 			title: "SEARCH & FILTER",
 			items: [][2]string{
 				{searchKey, "Open search"},
-				{FilterKeyActive, "Filter open (hide errors)"},
+				{statusFilterKeys, "Filter running / waiting / idle / error"},
+				{filterAllKey, "Clear filters (show all)"},
+				{filterOpenKey, "Filter open (hide errors)"},
+				{filterTagKey, "Cycle tag filter (composes with status filters)"},
 				{"/waiting", "Filter waiting"},
 				{"/running", "Filter running"},
 				{"/idle", "Filter idle"},
 				{groupViewKey, "Cycle view: active-on-top / populated-on-top"},
+				{branchViewKey, "Toggle grouping by git repo / branch"},
+				{sortKey, "Cycle sort: manual / accessed / status / newest / cost / memory"},
 			},
 		},
 		{
 			title: "OTHER",
 			items: [][2]string{
+				{paletteKey, "Command palette (run any action, jump to a group)"},
 				{settingsKey, "Settings"},
 				{reloadKey, "Reload from disk"},
 				{importKey, "Import tmux sessions"},
 				{"Ctrl+Q", "Detach from session"},
 				{switchKey, "Switch session (here or attached)"},
+				{recentKey, "Recent sessions (1-9 to attach)"},
+				{profileKey, "Switch profile"},
 				{quitKey, "Quit"},
 				{helpKey, "This help"},
 			},
@@ -38,7 +38,7 @@ func TestHelpOverlayShowsNotesShortcutWhenEnabled(t *testing.T) {
 	setPreviewShowNotesConfigForTest(t, &enabled)
 
 	overlay := NewHelpOverlay()
-	overlay.SetSize(100, 80)
+	overlay.SetSize(100, 120) // notes is the last SESSIONS row
 	overlay.Show()
 
 	view := overlay.View()
@@ -34,6 +34,7 @@ import (
 	"github.com/asheshgoplani/agent-deck/internal/docker"
 	"github.com/asheshgoplani/agent-deck/internal/feedback"
 	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/integrations/slack"
 	"github.com/asheshgoplani/agent-deck/internal/jujutsu"
 	"github.com/asheshgoplani/agent-deck/internal/logging"
 	"github.com/asheshgoplani/agent-deck/internal/safego"
@@ -230,32 +231,46 @@ type Home struct {
 	globalSearch         *GlobalSearch              // Global session search across all Claude conversations
 	globalSearchIndex    *session.GlobalSearchIndex // Search index (nil if disabled)
 	newDialog            *NewDialog
-	pendingRemoteName    string                // #1353: remote target for the open new-session dialog ("" = local)
-	groupDialog          *GroupDialog          // For creating/renaming groups
-	forkDialog           *ForkDialog           // For forking sessions
-	confirmDialog        *ConfirmDialog        // For confirming destructive actions
-	helpOverlay          *HelpOverlay          // For showing keyboard shortcuts
-	mcpDialog            *MCPDialog            // For managing MCPs
-	pluginDialog         *PluginDialog         // For managing per-session Claude Code plugins (RFC PLUGIN_ATTACH.md)
-	editPathsDialog      *EditPathsDialog      // For editing multi-repo paths
-	editSessionDialog    *EditSessionDialog    // For editing session settings (title/color/notes/command/...)
-	skillDialog          *SkillDialog          // For managing project skills
-	setupWizard          *SetupWizard          // For first-run setup
-	settingsPanel        *SettingsPanel        // For editing settings
-	analyticsPanel       *AnalyticsPanel       // For displaying session analytics
-	geminiModelDialog    *GeminiModelDialog    // For selecting Gemini model
-	promptInputDialog    *PromptInputDialog    // For prompting the highlighted session from the list without attaching (#1410)
-	sessionPickerDialog  *SessionPickerDialog  // For sending output to another session
-	codeBlockDialog      *CodeBlockDialog      // For copying a fenced code block from session output (#1412)
-	sessionSwitcher      *SessionSwitcher      // In-attach session switcher (Ctrl+Tab / Ctrl+S)
-	worktreeFinishDialog *WorktreeFinishDialog // For finishing worktree sessions (merge + cleanup)
-	feedbackDialog       *FeedbackDialog       // For in-app feedback popup (Phase 2)
-	zoxidePicker         *ZoxidePicker         // Quick-open picker backed by the zoxide DB
-	feedbackState        *feedback.State       // Loaded at first show, avoids repeated disk I/O
-	feedbackSender       *feedback.Sender      // Sender constructed once in NewHome (Phase 3, per D-05)
-	watcherPanel         *WatcherPanel         // For showing watcher status and events
-	toolVisibilityPanel  *ToolVisibilityPanel  // Edits [ui].hidden_tools
-	watcherEngine        *watcher.Engine       // nil until Init (D-07: lifecycle tied to TUI startup)
+	pendingRemoteName    string                  // #1353: remote target for the open new-session dialog ("" = local)
+	groupDialog          *GroupDialog            // For creating/renaming groups
+	forkDialog           *ForkDialog             // For forking sessions
+	confirmDialog        *ConfirmDialog          // For confirming destructive actions
+	helpOverlay          *HelpOverlay            // For showing keyboard shortcuts
+	mcpDialog            *MCPDialog              // For managing MCPs
+	pluginDialog         *PluginDialog           // For managing per-session Claude Code plugins (RFC PLUGIN_ATTACH.md)
+	editPathsDialog      *EditPathsDialog        // For editing multi-repo paths
+	editSessionDialog    *EditSessionDialog      // For editing session settings (title/color/notes/command/...)
+	skillDialog          *SkillDialog            // For managing project skills
+	setupWizard          *SetupWizard            // For first-run setup
+	settingsPanel        *SettingsPanel          // For editing settings
+	analyticsPanel       *AnalyticsPanel         // For displaying session analytics
+	geminiModelDialog    *GeminiModelDialog      // For selecting Gemini model
+	claudeModelDialog    *ClaudeModelDialog      // For selecting Claude model and effort
+	claudePermDialog     *ClaudePermissionDialog // Claude permission mode (hotkeyPermissionMode)
+	promptInputDialog    *PromptInputDialog      // For prompting the highlighted session from the list without attaching (#1410)
+	pasteFileDialog      *PasteFileDialog        // File picker for pasting a file's contents into a session
+	sessionPickerDialog  *SessionPickerDialog    // For sending output to another session
+	codeBlockDialog      *CodeBlockDialog        // For copying a fenced code block from session output (#1412)
+	fileRefDialog        *FileRefDialog          // Picks a file:line from the preview to open in $EDITOR
+	snapshotDialog       *SnapshotDialog         // Lists checkout snapshots to roll a session back to
+	sessionSwitcher      *SessionSwitcher        // In-attach session switcher (Ctrl+Tab / Ctrl+S) and recent-sessions list (`)
+	worktreeFinishDialog *WorktreeFinishDialog   // For finishing worktree sessions (merge + cleanup)
+	feedbackDialog       *FeedbackDialog         // For in-app feedback popup (Phase 2)
+	zoxidePicker         *ZoxidePicker           // Quick-open picker backed by the zoxide DB
+	profilePicker        *ProfilePicker          // Profile switcher overlay (hotkeyProfileSwitcher)
+	trashDialog          *TrashDialog            // Deleted-session trash overlay (hotkeyTrashView)
+	mcpPoolDialog        *MCPPoolDialog          // Pooled MCP proxy dashboard (hotkeyMCPPool)
+	mcpLogDialog         *MCPLogDialog           // MCP log viewer over the MCP Manager or pool dashboard
+	mcpRegistryDialog    *MCPRegistryDialog      // Installable MCP servers, opened from the MCP Manager
+	eventLogDialog       *EventLogDialog         // Session timeline overlay (hotkeySessionTimeline)
+	conversationViewer   *ConversationViewer     // Read-only Claude/Gemini transcript (hotkeyConversation)
+	commandPalette       *CommandPalette         // Fuzzy action/group list (hotkeyCommandPalette)
+	pendingProfile       string                  // Profile to relaunch on after quitting (see PendingProfileSwitch)
+	feedbackState        *feedback.State         // Loaded at first show, avoids repeated disk I/O
+	feedbackSender       *feedback.Sender        // Sender constructed once in NewHome (Phase 3, per D-05)
+	watcherPanel         *WatcherPanel           // For showing watcher status and events
+	toolVisibilityPanel  *ToolVisibilityPanel    // Edits [ui].hidden_tools
+	watcherEngine        *watcher.Engine         // nil until Init (D-07: lifecycle tied to TUI startup)
 
 	// Configurable hotkeys
 	hotkeys        map[string]string // action -> configured key
@@ -281,13 +296,17 @@ type Home struct {
 	cursor              int                   // Selected item index in flatItems
 	viewOffset          int                   // First visible item index (for scrolling)
 	previewScrollOffset int                   // Lines scrolled up from tail in the preview pane (#574). 0 = tail (default). Reset on cursor move.
+	previewHistoryKey   string                // Preview key whose capture depth was grown by scrollback mode.
+	previewHistoryLines int                   // Capture depth for previewHistoryKey (see preview_scroll.go).
 	isAttaching         atomic.Bool           // Prevents View() output during attach (fixes Bubble Tea Issue #431) - atomic for thread safety
 	statusFilter        session.Status        // Filter sessions by status ("" = all, or specific status)
+	tagFilter           string                // Filter sessions by tag ("" = all); composes with statusFilter
 	groupScope          string                // Limit TUI to a specific group path ("" = all groups)
 	initialSelect       string                // Session ID or title to preselect on first load (#709). Does NOT scope groups.
 	initialSelectDone   bool                  // Guard so preselection only fires once
 	previewMode         PreviewMode           // What to show in preview pane (both, output-only, analytics-only)
 	groupViewMode       session.GroupViewMode // List partition: normal, active-on-top, populated-on-top (cycled by hotkey 't')
+	branchView          bool                  // Cluster sessions by git repo/branch instead of manual groups (toggled by hotkey 'alt+b')
 	err                 error
 	errTime             time.Time  // When error occurred (for auto-dismiss)
 	isReloading         bool       // Visual feedback during auto-reload
@@ -303,6 +322,13 @@ type Home struct {
 	previewCacheMu    sync.RWMutex         // Protects previewCache for thread-safety
 	previewFetchingID string               // ID currently being fetched (prevents duplicate fetches)
 
+	// Unseen-output tracking ("+N" list badge, gutter on new preview lines).
+	// outputSeenSelectedID is the session whose preview was showing at the
+	// last tick; leaving it marks its output as seen. See output_seen.go.
+	outputSeen           *outputSeenTracker
+	outputSeenSelectedID string
+	highlightNewOutput   bool
+
 	// Preview debouncing (PERFORMANCE: prevents subprocess spawn on every keystroke)
 	// During rapid navigation, we delay preview fetch by 150ms to let navigation settle
 	pendingPreviewKey string     // Preview key waiting for debounced fetch
@@ -332,6 +358,10 @@ type Home struct {
 	idleTimeoutWatcher  *session.IdleTimeoutWatcher
 	idleTimeoutLastTick atomic.Int64 // UnixNano
 
+	// claudeIDVerifyLastTick rate-limits Claude session ID re-verification
+	// (see session.VerifyClaudeSessionIDs) inside the status sweep.
+	claudeIDVerifyLastTick atomic.Int64 // UnixNano
+
 	// PERFORMANCE: Worker pool for output-driven status updates (Priority 2)
 	// Caps the number of goroutines spawned for %output events from control pipes
 	logUpdateChan chan *session.Instance // Buffers status update requests from PipeManager
@@ -355,6 +385,7 @@ type Home struct {
 	// Hook-based status detection (Claude Code lifecycle hooks)
 	hookWatcher        *session.StatusFileWatcher
 	pendingHooksPrompt bool // True if user should be prompted to install hooks
+	tmuxDoctorChecked  bool // Startup tmux drift check has been scheduled
 
 	// Context-% based /clear for conductor sessions with clear_on_compact
 	clearOnCompactSent map[string]time.Time // instanceID -> last /clear send time (debounce)
@@ -369,9 +400,16 @@ type Home struct {
 	// System theme watcher (active when theme="system"; nil otherwise)
 	themeWatcher *ThemeWatcher
 
+	// Config watcher: applies config.toml edits without a restart
+	configWatcher *ConfigWatcher
+
 	// Storage warning (shown if storage initialization failed)
 	storageWarning string
 
+	// safeMode mirrors SafeMode() at construction: startup side effects and
+	// background workers are skipped (see SetSafeMode).
+	safeMode bool
+
 	// Watcher warning (shown if fsnotify may not work, e.g., on 9p/NFS)
 	watcherWarning string
 
@@ -399,6 +437,60 @@ type Home struct {
 	lastLogMaintenance time.Time
 	lastLogCheck       time.Time // Fast 10-second check for oversized logs
 
+	// [archive] idle_after sweep (see auto_archive.go)
+	lastAutoArchiveCheck time.Time
+
+	// Scheduled launches check (see session_schedule.go)
+	lastScheduleCheck time.Time
+
+	// Transcripts (see transcript.go)
+	lastTranscriptCheck time.Time
+
+	// Desktop notifications (see desktop_notify.go)
+	desktopNotifyTracker statusTransitionTracker
+
+	// Wait durations and escalation (see wait_duration.go)
+	waitTracker waitTracker
+
+	// Running sessions without output past [watchdog] stuck_after (see stuck_watchdog.go)
+	stuckWatchdog stuckWatchdog
+	// Background MCP liveness probes for the selected session.
+	mcpHealth mcpHealthTracker
+
+	// Per-session CPU/RAM (see resource_usage.go); background goroutine only
+	procSampler        sysinfo.ProcSampler
+	lastResourceSample time.Time
+
+	// Sort picked with the sort hotkey ("" = follow group_sort), persisted in
+	// ui_state; lastCostRefresh is touched by the background goroutine only
+	// (see session_sort.go)
+	sortOverride    string
+	lastCostRefresh time.Time
+
+	// Outbound webhooks (see webhooks.go)
+	webhookTracker statusTransitionTracker
+
+	// Slack integration (see slack.go); worker goroutine only
+	slackTracker  statusTransitionTracker
+	lastSlackPoll time.Time
+	slackPolling  atomic.Bool
+	slackThreads  *slack.Threads
+
+	// Git status badges (see git_status.go)
+	gitStatus           *git.StatusCache
+	gitStatusRefreshing atomic.Bool
+	showGitStatus       bool
+
+	// Files touched per session for the analytics panel (see files_touched.go)
+	filesTouched           *git.TouchedTracker
+	filesTouchedRefreshing atomic.Bool
+	filesTouchedTargets    map[string]git.TouchedTarget // worker goroutine only
+	filesTouchedExpanded   bool
+
+	// Verify command runs (see verify.go); UI goroutine only
+	verifyRunning map[string]bool                 // sessionID -> run in flight
+	verifyResults map[string]session.VerifyResult // sessionID -> last run in this TUI
+
 	// SQLite heartbeat: tracks when we last cleaned dead instances
 	lastDeadInstanceCleanup time.Time
 
@@ -424,11 +516,23 @@ type Home struct {
 	navigationHotUntil atomic.Int64
 	// Snapshot of status/tool used by render path to avoid per-row lock contention.
 	sessionRenderSnapshot atomic.Value // map[string]sessionRenderState
+	// Group paths muted for notifications, published for the background
+	// notification paths that cannot read the group tree.
+	mutedNotifyGroups atomic.Value // []string
+	// Archived group paths, published for the render snapshot so their
+	// sessions stay out of the header status counts.
+	archivedGroups atomic.Value // []string
+	// showArchivedGroups lists archived groups in the tree. It survives
+	// group tree rebuilds and is not persisted.
+	showArchivedGroups bool
 
 	// Jump mode (vimium-style hint navigation)
 	jumpMode   bool   // True when jump mode is active
 	jumpBuffer string // Characters typed so far in jump mode
 
+	// Multi-select: IDs of sessions marked for a bulk action (see multi_select.go)
+	markedSessions map[string]bool
+
 	// Cached status counts (invalidated on instance changes)
 	cachedStatusCounts struct {
 		running, waiting, idle, stopped, errored int
@@ -451,6 +555,7 @@ type Home struct {
 	// incremental redraw drift in terminals with unicode grapheme widths
 	fullRepaint          bool
 	defaultFilter        string                  // from config.toml [display] default_filter
+	groupExpansion       string                  // from config.toml [display] group_expansion
 	activeFilterLabel    string                  // from config.toml [display] active_filter_label
 	activeFilterExcludes map[session.Status]bool // from config.toml [display] active_filter_excludes; default {error}
 
@@ -487,10 +592,12 @@ type Home struct {
 	notificationManager     *session.NotificationManager
 	notificationsEnabled    bool
 	manageTmuxNotifications bool
-	boundKeys               map[string]string // Track which key is bound (key -> "sessionID:tmuxName")
-	boundKeysMu             sync.Mutex        // Protects boundKeys for background worker access
-	lastBarText             string            // Cache to avoid updating all sessions every tick
-	lastBarTextMu           sync.Mutex        // Protects lastBarText for background worker access
+	boundKeys               map[string]string    // Track which key is bound (key -> "sessionID:tmuxName")
+	boundKeysMu             sync.Mutex           // Protects boundKeys for background worker access
+	lastBarText             string               // Cache to avoid updating all sessions every tick
+	lastBarTextMu           sync.Mutex           // Protects lastBarText for background worker access
+	lastBarPlacement        session.BarPlacement // Where lastBarText is drawn (protected by lastBarTextMu)
+	dndUntil                atomic.Int64         // UnixNano end of the active DND window, 0 when off (header badge)
 
 	// Maintenance banner (shown after background maintenance completes)
 	maintenanceMsg     string
@@ -545,6 +652,10 @@ type Home struct {
 	costStore            *costs.Store
 	costPricer           *costs.Pricer
 	costBudget           *costs.BudgetChecker
+	budgetAlerts         []costs.BudgetResult          // warn/over-budget results, worst first (see budget_alerts.go)
+	budgetAlertLevels    map[string]costs.BudgetAction // last announced level per budget, for threshold-crossing banners
+	chainLastStatus      map[string]session.Status     // status per session at the previous tick, for session chains (see session_chain.go)
+	lastBudgetCheck      time.Time
 	costToday            atomic.Int64 // microdollars
 	costYesterday        atomic.Int64 // microdollars
 	costWeek             atomic.Int64 // microdollars
@@ -558,6 +669,14 @@ type Home struct {
 	showCostDashboard    bool
 	costDashboard        costDashboard
 
+	// Analytics dashboard (hotkeyAnalyticsDash): usage across all sessions
+	showAnalyticsDash bool
+	analyticsDash     analyticsDashboard
+
+	// Worktree diff overlay (see diff_viewer.go)
+	showDiffViewer bool
+	diffViewer     diffViewer
+
 	// System stats collector (CPU, RAM, disk, etc.)
 	sysStatsCollector *sysinfo.Collector
 	sysStatsConfig    session.SystemStatsSettings
@@ -614,6 +733,10 @@ type Home struct {
 	// nil, the dispatch calls terminal.OpenSessionInNewWindow directly.
 	// See issue #1093.
 	openInNewWindowSink func(req terminal.AttachRequest) error
+	// surroundingTmuxSink is an optional override used by tests to capture
+	// window/pane attaches ([attach] mode, attach_split) without driving the
+	// surrounding tmux. When nil, terminal.OpenInSurroundingTmux runs.
+	surroundingTmuxSink func(req terminal.AttachRequest, mode string) error
 	// quickApproveSink is an optional override used by tests to capture the
 	// quick-approve (`a`) dispatch — the (instance, windowIndex) it would send
 	// "1"+Enter to — without driving real tmux. windowIndex < 0 means the
@@ -636,7 +759,10 @@ type uiState struct {
 	CursorGroupPath string `json:"cursor_group_path,omitempty"`
 	PreviewMode     int    `json:"preview_mode"`
 	StatusFilter    string `json:"status_filter,omitempty"`
+	TagFilter       string `json:"tag_filter,omitempty"`
 	GroupViewMode   int    `json:"group_view_mode,omitempty"`
+	BranchView      bool   `json:"branch_view,omitempty"`
+	SortMode        string `json:"sort_mode,omitempty"`
 }
 
 type selectedItemIdentity struct {
@@ -669,6 +795,97 @@ func (h *Home) reloadHotkeysFromConfig() {
 	h.setHotkeys(resolveHotkeys(session.GetHotkeyOverrides()))
 }
 
+// applyDisplaySettings caches the [display], [ui] and [preview] settings read
+// on every render. A nil cfg applies the defaults.
+func (h *Home) applyDisplaySettings(cfg *session.UserConfig) {
+	if cfg == nil {
+		h.fullRepaint = (session.DisplaySettings{}).GetFullRepaint()
+		h.activeFilterExcludes = (session.DisplaySettings{}).GetActiveFilterExcludes()
+		h.costLineTemplate, h.costLineHideWhenZero = session.ResolveCostLineTemplate(nil, h.profile)
+		h.previewPct = session.DefaultPreviewPct
+		h.remoteLatencyRefreshSec = (session.UISettings{}).GetRemoteLatencyRefreshSecs(0)
+		h.remoteSessionRefreshSec = (session.UISettings{}).GetRemoteSessionRefreshSecs()
+		h.footerMode = (session.UISettings{}).GetFooter()
+		h.showGitStatus = (session.DisplaySettings{}).GetShowGitStatus()
+		return
+	}
+	h.fullRepaint = cfg.Display.GetFullRepaint()
+	h.defaultFilter = cfg.Display.GetDefaultFilter()
+	h.groupExpansion = cfg.Display.GetGroupExpansion()
+	h.activeFilterLabel = cfg.Display.ActiveFilterLabel
+	h.activeFilterExcludes = cfg.Display.GetActiveFilterExcludes()
+	tmux.SetHideCwdPrefixInTitle(!cfg.Display.GetIncludeCwdPrefix())
+	h.showSessionTimestamps = cfg.Display.ShowSessionTimestamps
+	h.showPaneTitles = cfg.Display.ShowPaneTitles
+	h.showGitStatus = cfg.Display.GetShowGitStatus()
+	h.costLineTemplate, h.costLineHideWhenZero = session.ResolveCostLineTemplate(cfg, h.profile)
+	h.previewPct = cfg.UI.GetPreviewPct()
+	h.remoteLatencyRefreshSec = cfg.UI.GetRemoteLatencyRefreshSecs(cfg.SystemStats.GetRefreshSeconds())
+	h.remoteSessionRefreshSec = cfg.UI.GetRemoteSessionRefreshSecs()
+	h.footerMode = cfg.UI.GetFooter()
+	h.highlightNewOutput = cfg.Preview.GetHighlightNewOutput()
+}
+
+// applyLiveConfig applies a reloaded config.toml to the running TUI: hotkeys,
+// display settings, theme, the notification bar, the new-session dialog's
+// default tool, and log maintenance limits. Settings read on demand (analytics,
+// log limits, tool commands) already see the new file through LoadUserConfig's
+// cache; this covers what Home caches. Returns the theme watcher command when
+// the theme follows the OS.
+func (h *Home) applyLiveConfig(cfg *session.UserConfig) tea.Cmd {
+	h.reloadHotkeysFromConfig()
+	h.applyDisplaySettings(cfg)
+	h.applyNotificationSettings(session.GetNotificationsSettings())
+
+	if defaultTool := session.GetDefaultTool(); defaultTool != "" {
+		h.newDialog.SetDefaultTool(defaultTool)
+	}
+
+	// Run maintenance with the new [logs] limits on the next tick instead of
+	// waiting out the interval.
+	h.lastLogMaintenance = time.Time{}
+
+	h.stopThemeWatcher()
+	InitTheme(session.ResolveThemeName())
+	h.propagateThemeToSessions()
+	if cfg != nil && cfg.Theme == "system" {
+		return h.startThemeWatcher()
+	}
+	return nil
+}
+
+// applyNotificationSettings turns the tmux notification bar on or off and
+// reconfigures it to match [notifications].
+func (h *Home) applyNotificationSettings(ns session.NotificationsConfig) {
+	if !h.manageTmuxNotifications || h.safeMode {
+		return
+	}
+	if !ns.GetEnabled() {
+		if h.notificationsEnabled {
+			h.notificationsEnabled = false
+			clearNotificationBar(h.drawnBarPlacement())
+			h.unbindNotificationKeys()
+		}
+		return
+	}
+	if h.notificationManager == nil {
+		h.notificationManager = session.NewNotificationManager(ns.MaxShown, ns.ShowAll, ns.Minimal)
+		_ = tmux.InitializeStatusBarOptions()
+	} else {
+		wasMinimal := h.notificationManager.IsMinimal()
+		h.notificationManager.Configure(ns.MaxShown, ns.ShowAll, ns.Minimal)
+		if ns.Minimal && !wasMinimal {
+			// Minimal mode shows no per-session keys; drop the old bindings.
+			h.unbindNotificationKeys()
+		}
+	}
+	h.notificationManager.SetFormat(ns.GetBarFormat())
+	h.notificationManager.SetPlacement(ns.GetPlacement())
+	h.notificationManager.SetOrder(ns.GetOrder())
+	// The background sync redraws the bar with the new settings.
+	h.notificationsEnabled = true
+}
+
 func (h *Home) detachByte() byte {
 	return ResolvedDetachByte(session.GetHotkeyOverrides())
 }
@@ -731,6 +948,46 @@ func (h *Home) openInNewWindow(req terminal.AttachRequest, sessionExists bool) e
 	return terminal.OpenSessionInNewWindow(req)
 }
 
+// spectateSession attaches to inst as a read-only tmux client: the pane is
+// live but keystrokes never reach the agent, and the detach key returns to
+// the list. Watching is not engaging, so unlike attachSession it neither
+// acknowledges a waiting session nor marks it accessed, and the in-attach
+// switcher is off (it would re-attach read-write).
+func (h *Home) spectateSession(inst *session.Instance) tea.Cmd {
+	tmuxSess := inst.GetTmuxSession()
+	if tmuxSess == nil {
+		return nil
+	}
+	tmuxSess.EnsureConfigured()
+
+	opts := h.attachOptions()
+	opts.ReadOnly = true
+	opts.SwitchKeyByte = 0
+	h.isAttaching.Store(true)
+	return tea.Exec(attachCmd{session: tmuxSess, opts: opts}, func(err error) tea.Msg {
+		h.isAttaching.Store(false)
+		return statusUpdateMsg{}
+	})
+}
+
+// openInSurroundingTmux opens req in a new window or pane of the tmux session
+// agent-deck runs in, through the optional test sink or the real launcher.
+func (h *Home) openInSurroundingTmux(req terminal.AttachRequest, mode, title string) error {
+	if h.surroundingTmuxSink != nil {
+		return h.surroundingTmuxSink(req, mode)
+	}
+	return terminal.OpenInSurroundingTmux(req, mode, session.GetAttachSettings().GetSplit(), title)
+}
+
+// splitAttachMode is the mode the attach_split action opens sessions in: the
+// configured [attach] mode when it already is "window" or "pane", else "pane".
+func splitAttachMode() string {
+	if mode := session.GetAttachSettings().GetMode(); mode != session.AttachModeExec {
+		return mode
+	}
+	return session.AttachModePane
+}
+
 // quickApprove delivers "1"+Enter to approve a Claude permission prompt without
 // attaching. windowIndex < 0 targets the session's active window (the
 // session-row path); >= 0 targets that specific tmux window (the window-row
@@ -831,6 +1088,16 @@ func (h *Home) actionKey(action string) string {
 	return actionHotkey(h.hotkeys, action)
 }
 
+// attachKeyLabel returns the hint label for the attach action: enterLabel
+// while it is bound to Enter, otherwise the configured key ("" if unbound).
+func (h *Home) attachKeyLabel(enterLabel string) string {
+	key := h.actionKey(hotkeyAttach)
+	if key == defaultHotkeyBindings[hotkeyAttach] {
+		return enterLabel
+	}
+	return key
+}
+
 // deletedSessionEntry holds a deleted session for undo restore
 type deletedSessionEntry struct {
 	instance  *session.Instance
@@ -873,6 +1140,20 @@ type sessionForkedMsg struct {
 	err      error
 }
 
+// forkPointsMsg carries the Claude fork points of a fork dialog's source,
+// loaded off the UI thread because transcripts can be large.
+type forkPointsMsg struct {
+	sourceID string
+	points   []session.ClaudeForkPoint
+}
+
+// conversationLoadedMsg delivers a parsed conversation to the viewer.
+type conversationLoadedMsg struct {
+	sessionID string
+	entries   []session.ConversationEntry
+	err       error
+}
+
 type refreshMsg struct{}
 
 type statusUpdateMsg struct {
@@ -898,6 +1179,9 @@ type switcherCommitMsg struct {
 
 type attachReturnRefreshMsg struct{}
 
+// configChangedMsg signals that config.toml changed on disk
+type configChangedMsg struct{}
+
 // storageChangedMsg signals that state.db was modified externally
 type storageChangedMsg struct{}
 
@@ -993,6 +1277,17 @@ type systemThemeMsg struct {
 }
 
 // worktreeDirtyCheckMsg is sent when an async worktree dirty check completes
+// tmuxDoctorMsg carries the repairable tmux drift found at startup.
+type tmuxDoctorMsg struct {
+	issues []session.TmuxIssue
+}
+
+// tmuxDoctorRepairedMsg reports the outcome of repairing tmux drift.
+type tmuxDoctorRepairedMsg struct {
+	repaired int
+	err      error
+}
+
 type worktreeDirtyCheckMsg struct {
 	sessionID string
 	isDirty   bool
@@ -1012,6 +1307,8 @@ type worktreeFinishResultMsg struct {
 	sessionTitle string
 	targetBranch string
 	merged       bool
+	keptSession  bool   // session stays, re-pointed at repoRoot
+	repoRoot     string // set when keptSession
 	err          error
 }
 
@@ -1081,6 +1378,7 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		profile:                   actualProfile,
 		storage:                   storage,
 		storageWarning:            storageWarning,
+		safeMode:                  safeMode,
 		search:                    NewSearch(),
 		newDialog:                 NewNewDialog(),
 		groupDialog:               NewGroupDialog(),
@@ -1096,13 +1394,26 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		settingsPanel:             NewSettingsPanel(),
 		analyticsPanel:            NewAnalyticsPanel(),
 		geminiModelDialog:         NewGeminiModelDialog(),
+		claudeModelDialog:         NewClaudeModelDialog(),
+		claudePermDialog:          NewClaudePermissionDialog(),
 		promptInputDialog:         NewPromptInputDialog(),
+		pasteFileDialog:           NewPasteFileDialog(),
 		sessionPickerDialog:       NewSessionPickerDialog(),
 		codeBlockDialog:           NewCodeBlockDialog(),
+		fileRefDialog:             NewFileRefDialog(),
+		snapshotDialog:            NewSnapshotDialog(),
 		sessionSwitcher:           NewSessionSwitcher(),
 		worktreeFinishDialog:      NewWorktreeFinishDialog(),
 		feedbackDialog:            NewFeedbackDialog(),
 		zoxidePicker:              NewZoxidePicker(),
+		profilePicker:             NewProfilePicker(),
+		trashDialog:               NewTrashDialog(),
+		mcpPoolDialog:             NewMCPPoolDialog(),
+		mcpLogDialog:              NewMCPLogDialog(),
+		mcpRegistryDialog:         NewMCPRegistryDialog(),
+		eventLogDialog:            NewEventLogDialog(),
+		conversationViewer:        NewConversationViewer(),
+		commandPalette:            NewCommandPalette(),
 		feedbackSender:            feedback.NewSender(),
 		watcherPanel:              NewWatcherPanel(),
 		toolVisibilityPanel:       NewToolVisibilityPanel(),
@@ -1118,6 +1429,8 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		flatItems:                 []session.Item{},
 		previewCache:              make(map[string]string),
 		previewCacheTime:          make(map[string]time.Time),
+		outputSeen:                newOutputSeenTracker(),
+		highlightNewOutput:        true,
 		analyticsCache:            make(map[string]*session.SessionAnalytics),
 		geminiAnalyticsCache:      make(map[string]*session.GeminiSessionAnalytics),
 		analyticsCacheTime:        make(map[string]time.Time),
@@ -1131,6 +1444,10 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		lastLogActivity:           make(map[string]time.Time),
 		windowsCollapsed:          make(map[string]bool),
 		worktreeDirtyCache:        make(map[string]bool),
+		gitStatus:                 git.NewStatusCache(gitStatusTTL),
+		filesTouched:              git.NewTouchedTracker(filesTouchedTTL),
+		verifyRunning:             make(map[string]bool),
+		verifyResults:             make(map[string]session.VerifyResult),
 		worktreeDirtyCacheTs:      make(map[string]time.Time),
 		statusTrigger:             make(chan statusUpdateRequest, 1), // Buffered to avoid blocking
 		statusWorkerDone:          make(chan struct{}),
@@ -1155,33 +1472,15 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	// Cache display settings (config.toml [display]) and resolve the
 	// status-bar cost-line template once. The template + hide flag are
 	// reused on every render; see (*Home).renderStats.
-	if cfg, _ := session.LoadUserConfig(); cfg != nil {
-		h.fullRepaint = cfg.Display.GetFullRepaint()
-		h.defaultFilter = cfg.Display.GetDefaultFilter()
-		h.activeFilterLabel = cfg.Display.ActiveFilterLabel
-		h.activeFilterExcludes = cfg.Display.GetActiveFilterExcludes()
-		tmux.SetHideCwdPrefixInTitle(!cfg.Display.GetIncludeCwdPrefix())
-		h.showSessionTimestamps = cfg.Display.ShowSessionTimestamps
-		h.showPaneTitles = cfg.Display.ShowPaneTitles
+	cfg, _ := session.LoadUserConfig()
+	h.applyDisplaySettings(cfg)
+	if cfg != nil {
 		h.sysStatsConfig = cfg.SystemStats
-		h.costLineTemplate, h.costLineHideWhenZero = session.ResolveCostLineTemplate(cfg, actualProfile)
-		h.previewPct = cfg.UI.GetPreviewPct()
-		h.remoteLatencyRefreshSec = cfg.UI.GetRemoteLatencyRefreshSecs(cfg.SystemStats.GetRefreshSeconds())
-		h.remoteSessionRefreshSec = cfg.UI.GetRemoteSessionRefreshSecs()
-		h.footerMode = cfg.UI.GetFooter()
-	} else {
-		h.fullRepaint = (session.DisplaySettings{}).GetFullRepaint()
-		h.activeFilterExcludes = (session.DisplaySettings{}).GetActiveFilterExcludes()
-		h.costLineTemplate, h.costLineHideWhenZero = session.ResolveCostLineTemplate(nil, actualProfile)
-		h.previewPct = session.DefaultPreviewPct
-		h.remoteLatencyRefreshSec = (session.UISettings{}).GetRemoteLatencyRefreshSecs(0)
-		h.remoteSessionRefreshSec = (session.UISettings{}).GetRemoteSessionRefreshSecs()
-		h.footerMode = (session.UISettings{}).GetFooter()
 	}
 	h.remoteLatency = make(map[string]session.RemoteLatency)
 
 	// Initialize system stats collector if enabled
-	if h.sysStatsConfig.GetEnabled() {
+	if h.sysStatsConfig.GetEnabled() && !h.safeMode {
 		h.sysStatsCollector = sysinfo.NewCollector(h.sysStatsConfig.GetRefreshSeconds(), nil)
 	}
 
@@ -1204,9 +1503,12 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	// Initialize notification manager if enabled in config and tmux status injection is allowed.
 	// All instances manage the notification bar (they share SQLite state, so produce identical output)
 	notifSettings := session.GetNotificationsSettings()
-	if notifSettings.GetEnabled() && h.manageTmuxNotifications {
+	if notifSettings.GetEnabled() && h.manageTmuxNotifications && !h.safeMode {
 		h.notificationsEnabled = true
 		h.notificationManager = session.NewNotificationManager(notifSettings.MaxShown, notifSettings.ShowAll, notifSettings.Minimal)
+		h.notificationManager.SetFormat(notifSettings.GetBarFormat())
+		h.notificationManager.SetPlacement(notifSettings.GetPlacement())
+		h.notificationManager.SetOrder(notifSettings.GetOrder())
 
 		// Initialize tmux status bar options for proper notification display
 		// Fixes truncation (default status-left-length is only 10 chars)
@@ -1256,13 +1558,17 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	tmux.SetPipeManager(pm)
 
 	// Only the focused / attached / recently-viewed sessions hold a live pipe.
-	pm.SetWantPipe(func(name string) bool { return h.liveSet.want(name) })
+	// Safe mode wants no pipes at all: the slow status poll is the only
+	// tmux traffic, so a wedged tmux server cannot stall startup.
+	pm.SetWantPipe(func(name string) bool { return !h.safeMode && h.liveSet.want(name) })
 
 	// Live pipes are managed lazily by the reconciler: it connects the focused/
 	// attached session (and a few recent ones) and lets everything else ride the
 	// 2s status poll. This replaces the old "connect every session at startup"
 	// burst that opened ~N pipes at once and triggered attach-storm freezes.
-	go h.livePipeReconciler()
+	if !h.safeMode {
+		go h.livePipeReconciler()
+	}
 
 	// Start background status worker (Priority 1C)
 	go h.statusWorker()
@@ -1294,7 +1600,7 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	// Initialize storage watcher for auto-reload
 	// Polls SQLite metadata for external changes (CLI commands, other instances)
 	// and triggers reload with state preservation
-	if storage != nil {
+	if storage != nil && !h.safeMode {
 		watcher, err := NewStorageWatcher(storage.GetDB())
 		if err != nil {
 			uiLog.Warn("storage_watcher_init_failed", slog.String("error", err.Error()))
@@ -1306,7 +1612,7 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 
 	// Hook-based status detection (Claude Code lifecycle hooks)
 	userConfig, _ := session.LoadUserConfig()
-	hooksEnabled := userConfig == nil || userConfig.Claude.GetHooksEnabled()
+	hooksEnabled := (userConfig == nil || userConfig.Claude.GetHooksEnabled()) && !h.safeMode
 	if hooksEnabled {
 		configDir := session.GetClaudeConfigDir()
 		alreadyInstalled := session.CheckClaudeHooksInstalled(configDir)
@@ -1354,7 +1660,7 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	// No user prompt needed — config.yaml is Hermes's own config file, not a
 	// shared settings file. The shared hook watcher (h.hookWatcher) covers all
 	// tools, so start it here if Claude hooks didn't already start it.
-	if hermesCmd := strings.TrimSpace(session.GetToolCommand("hermes")); hermesCmd != "" {
+	if hermesCmd := strings.TrimSpace(session.GetToolCommand("hermes")); hermesCmd != "" && !h.safeMode {
 		// GetToolCommand may return a full command string with arguments
 		// (e.g. "hermes --gateway-url=..."). LookPath needs the binary name only.
 		// Trim first because Fields("") and Fields("   ") both return [], and
@@ -1381,7 +1687,7 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	}
 
 	// Cursor Agent CLI hooks: auto-inject silently when the cursor binary is available.
-	if cursorCmd := strings.TrimSpace(session.GetToolCommand("cursor")); cursorCmd != "" {
+	if cursorCmd := strings.TrimSpace(session.GetToolCommand("cursor")); cursorCmd != "" && !h.safeMode {
 		if cursorFields := strings.Fields(cursorCmd); len(cursorFields) > 0 {
 			cursorBin := cursorFields[0]
 			if _, err := exec.LookPath(cursorBin); err == nil {
@@ -1404,7 +1710,7 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	}
 
 	// Start system theme watcher if configured
-	if session.GetTheme() == "system" {
+	if session.GetTheme() == "system" && !h.safeMode {
 		h.themeWatcher = NewThemeWatcher(ctx)
 	}
 
@@ -1413,6 +1719,7 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	// Also initializes lastLogMaintenance and lastLogCheck so periodic checks start from now
 	h.lastLogMaintenance = time.Now()
 	h.lastLogCheck = time.Now()
+	h.lastAutoArchiveCheck = time.Now() // let statuses settle before the first idle sweep
 	safego.Go(uiLog, "startup_log_maintenance", func() {
 		logSettings := session.GetLogSettings()
 		tmux.RunLogMaintenance(logSettings.MaxSizeMB, logSettings.MaxLines, logSettings.GetRemoveOrphans())
@@ -1857,7 +2164,13 @@ func (h *Home) rebuildFlatItems() {
 	h.jumpMode = false
 	h.jumpBuffer = ""
 
+	h.groupTree.ShowArchived = h.showArchivedGroups
 	allItems := h.groupTree.Flatten()
+	if h.branchView {
+		// Branch view regroups every session by repo/branch, so manual group
+		// headers and their collapse state do not apply.
+		allItems = h.groupTree.SessionItems()
+	}
 
 	// Partition archived vs active before status filters. Group membership is
 	// resolved from the full group tree — not the flattened view — so
@@ -1869,9 +2182,9 @@ func (h *Home) rebuildFlatItems() {
 		for _, item := range allItems {
 			if item.Type == session.ItemTypeGroup {
 				// Archived view: only show groups that actually contain archived
-				// sessions. Active view: keep every group header — groups are never
-				// themselves archived, so empty groups and groups whose sessions are
-				// all archived remain part of the active list (they render as empty
+				// sessions. Active view: keep every group header — archiving a
+				// session never archives its group, so empty groups and groups whose
+				// sessions are all archived remain part of the active list (they render as empty
 				// groups, same as before anything was archived) and can sink under
 				// the view-mode divider instead of vanishing.
 				if !viewArchived || groupsWithMatches[item.Path] {
@@ -1932,6 +2245,9 @@ func (h *Home) rebuildFlatItems() {
 		h.flatItems = allItems
 	}
 
+	// Apply tag filter (composes with status filter above)
+	h.flatItems = h.applyTagFilter(h.flatItems)
+
 	// Apply group scope filter (composes with status filter above)
 	if h.groupScope != "" {
 		scoped := make([]session.Item, 0, len(h.flatItems))
@@ -1946,7 +2262,9 @@ func (h *Home) rebuildFlatItems() {
 	// Partition into top/bottom sections by view mode (active-on-top / populated-on-top).
 	// Runs after filtering/scoping but before window injection so windows follow
 	// their parent session into whichever section it lands in.
-	if h.groupViewMode != session.GroupViewNormal {
+	if h.branchView {
+		h.flatItems = session.GroupByRepoBranch(h.flatItems, h.branchKey)
+	} else if h.groupViewMode != session.GroupViewNormal {
 		// Activity is computed from the full tree (collapse-agnostic) so a
 		// collapsed-but-populated group's header is placed by its real contents,
 		// not by the (absent) session rows under a collapsed header. It honors the
@@ -2327,9 +2645,49 @@ func (h *Home) cleanupNotifications() {
 	}
 
 	// Clear global status bar (ONE call instead of per-session)
-	_ = tmux.ClearStatusLeftGlobal()
+	clearNotificationBar(h.drawnBarPlacement())
+
+	h.unbindNotificationKeys()
+}
+
+// drawnBarPlacement is where the notification bar was last drawn, status-left
+// before the first sync.
+func (h *Home) drawnBarPlacement() session.BarPlacement {
+	h.lastBarTextMu.Lock()
+	defer h.lastBarTextMu.Unlock()
+	if h.lastBarPlacement == "" {
+		return session.BarPlacementLeft
+	}
+	return h.lastBarPlacement
+}
+
+// setNotificationBar draws the bar text at placement.
+func setNotificationBar(placement session.BarPlacement, text string) {
+	switch placement {
+	case session.BarPlacementRight:
+		_ = tmux.SetStatusRightGlobal(text)
+	case session.BarPlacementWindow:
+		_ = tmux.SetWindowStatusGlobal(text)
+	default:
+		_ = tmux.SetStatusLeftGlobal(text)
+	}
+}
+
+// clearNotificationBar removes the bar from placement, restoring what the
+// user had there.
+func clearNotificationBar(placement session.BarPlacement) {
+	switch placement {
+	case session.BarPlacementRight:
+		_ = tmux.ClearStatusRightGlobal()
+	case session.BarPlacementWindow:
+		_ = tmux.ClearWindowStatusGlobal()
+	default:
+		_ = tmux.ClearStatusLeftGlobal()
+	}
+}
 
-	// Unbind all keys (with mutex protection)
+// unbindNotificationKeys removes every notification-bar key binding.
+func (h *Home) unbindNotificationKeys() {
 	h.boundKeysMu.Lock()
 	for key := range h.boundKeys {
 		_ = tmux.UnbindKey(key)
@@ -2421,10 +2779,15 @@ func (h *Home) Init() tea.Cmd {
 		h.loadSessions,
 
 		h.tick(),
+	}
+	if h.safeMode {
+		return tea.Batch(cmds...)
+	}
+	cmds = append(cmds,
 		h.reviverTick(),
 		h.checkForUpdate(),
 		h.fetchRemoteSessions,
-	}
+	)
 
 	// Start listening for storage changes
 	if h.storageWatcher != nil {
@@ -2436,6 +2799,21 @@ func (h *Home) Init() tea.Cmd {
 		cmds = append(cmds, listenForThemeChange(h.themeWatcher))
 	}
 
+	// Apply config.toml edits live (see applyLiveConfig). Started here rather
+	// than in NewHome so Homes that never run hold no inotify watch.
+	if h.configWatcher == nil {
+		if configPath, err := session.GetUserConfigPath(); err == nil {
+			if cw, err := NewConfigWatcher(configPath); err != nil {
+				uiLog.Debug("config_watcher_init_failed", slog.String("error", err.Error()))
+			} else {
+				h.configWatcher = cw
+			}
+		}
+	}
+	if h.configWatcher != nil {
+		cmds = append(cmds, listenForConfigChange(h.configWatcher))
+	}
+
 	// Start watcher engine (D-07: lifecycle tied to TUI startup)
 	cmds = append(cmds, h.startWatcherEngine())
 
@@ -2476,6 +2854,17 @@ func listenForThemeChange(tw *ThemeWatcher) tea.Cmd {
 	}
 }
 
+// listenForConfigChange waits for the next config.toml change.
+// MUST be re-issued in the Update handler for configChangedMsg to keep listening.
+func listenForConfigChange(cw *ConfigWatcher) tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-cw.ChangeChannel(); !ok {
+			return nil
+		}
+		return configChangedMsg{}
+	}
+}
+
 // listenForWatcherEvent waits for the next event from the engine's EventCh.
 // Must be re-issued after each event to keep listening (Bubble Tea cmd pattern).
 func listenForWatcherEvent(ch <-chan watcher.Event) tea.Cmd {
@@ -2566,6 +2955,8 @@ func (h *Home) startWatcherEngine() tea.Cmd {
 			adapter = &watcher.SlackAdapter{}
 		case "github":
 			adapter = &watcher.GitHubAdapter{}
+		case "github-issues":
+			adapter = &watcher.GitHubIssuesAdapter{}
 		default:
 			continue
 		}
@@ -2819,7 +3210,7 @@ func (h *Home) loadSessions() tea.Msg {
 
 	// Initialize pool AFTER sessions are loaded
 	userConfig, configErr := session.LoadUserConfig()
-	if configErr == nil && userConfig != nil && userConfig.MCPPool.Enabled {
+	if configErr == nil && userConfig != nil && userConfig.MCPPool.Enabled && !h.safeMode {
 		pool, poolErr := session.InitializeGlobalPool(h.ctx, userConfig, instances)
 		if poolErr != nil {
 			mcpUILog.Warn("pool_init_failed", slog.String("error", poolErr.Error()))
@@ -2875,6 +3266,7 @@ func (h *Home) HydrateInstancesFromStorage() error {
 	} else {
 		h.groupTree = session.NewGroupTree(instances)
 	}
+	h.syncMutedGroups()
 	return nil
 }
 
@@ -2965,9 +3357,9 @@ func (h *Home) cleanupExpiredAnimations(
 			continue
 		}
 		// Use appropriate timeout based on tool
-		// Claude and Gemini use longer timeout (MCP loading can be slow)
+		// Slow-start tools (Claude, Gemini) use a longer timeout (MCP loading can be slow)
 		timeout := defaultTimeout
-		if session.IsClaudeCompatible(inst.Tool) || inst.Tool == "gemini" {
+		if session.ToolAdapterFor(inst.Tool).SlowStart() {
 			timeout = claudeTimeout
 		}
 		if time.Since(startTime) > timeout {
@@ -2981,7 +3373,7 @@ func (h *Home) cleanupExpiredAnimations(
 }
 
 func launchAnimationMinDuration(tool string) time.Duration {
-	if session.IsClaudeCompatible(tool) || tool == "gemini" {
+	if session.ToolAdapterFor(tool).SlowStart() {
 		return minLaunchAnimationDurationClaude
 	}
 	return minLaunchAnimationDurationDefault
@@ -3057,32 +3449,8 @@ func (h *Home) hasActiveAnimation(sessionID string) bool {
 	// Strip ANSI for reliable pattern matching (preview cache now contains ANSI-rich content)
 	plainPreview := ansi.Strip(previewContent)
 
-	if animTool == "claude" || animTool == "gemini" {
-		// Claude ready indicators
-		agentReady := strings.Contains(plainPreview, "ctrl+c to interrupt") ||
-			strings.Contains(plainPreview, "No, and tell Claude what to do differently") ||
-			strings.Contains(plainPreview, "\n> ") ||
-			strings.Contains(plainPreview, "> \n") ||
-			strings.Contains(plainPreview, "esc to interrupt") ||
-			strings.Contains(plainPreview, "⠋") || strings.Contains(plainPreview, "⠙") ||
-			strings.Contains(plainPreview, "Thinking") ||
-			strings.Contains(plainPreview, "╭─") // Claude UI border
-
-		// Gemini prompts
-		if animTool == "gemini" {
-			agentReady = agentReady ||
-				strings.Contains(plainPreview, "▸") ||
-				strings.Contains(plainPreview, "gemini>")
-		}
-
-		if agentReady {
-			return false
-		}
-	} else {
-		// Non-Claude/Gemini: ready if any substantial content (>50 chars)
-		if len(strings.TrimSpace(plainPreview)) > 50 {
-			return false
-		}
+	if session.PaneShowsReady(session.ToolAdapterFor(animTool), plainPreview) {
+		return false
 	}
 
 	// Not ready yet - keep showing animation
@@ -3139,13 +3507,14 @@ func (h *Home) fetchPreview(inst *session.Instance, key string, windowIndex int)
 	if inst == nil {
 		return nil
 	}
+	lines := h.previewHistoryLinesFor(key)
 	return func() tea.Msg {
 		var content string
 		var err error
 		if windowIndex >= 0 {
-			content, err = inst.PreviewWindowFull(windowIndex)
+			content, err = inst.PreviewWindowHistory(windowIndex, lines)
 		} else {
-			content, err = inst.PreviewFull()
+			content, err = inst.PreviewHistory(lines)
 		}
 		return previewFetchedMsg{
 			previewKey: key,
@@ -3318,65 +3687,48 @@ func (h *Home) getAnalyticsForSession(inst *session.Instance) *session.SessionAn
 	return nil // Will trigger async fetch
 }
 
+// getGeminiAnalyticsForSession is getAnalyticsForSession for Gemini's
+// analytics, which are cached separately.
+func (h *Home) getGeminiAnalyticsForSession(inst *session.Instance) *session.GeminiSessionAnalytics {
+	if inst == nil {
+		return nil
+	}
+	h.analyticsCacheMu.RLock()
+	defer h.analyticsCacheMu.RUnlock()
+	if cached, ok := h.geminiAnalyticsCache[inst.ID]; ok && time.Since(h.analyticsCacheTime[inst.ID]) < analyticsCacheTTL {
+		return cached
+	}
+	return nil
+}
+
 // fetchAnalytics returns a command that asynchronously parses session analytics
 // This keeps View() pure (no blocking I/O) as per Bubble Tea best practices
 func (h *Home) fetchAnalytics(inst *session.Instance) tea.Cmd {
 	if inst == nil {
 		return nil
 	}
+	adapter := session.ToolAdapterFor(inst.GetToolThreadSafe())
+	if !adapter.HasAnalytics() {
+		return nil
+	}
 	sessionID := inst.ID
-
-	fetchTool := inst.GetToolThreadSafe()
-	switch fetchTool {
-	case "claude":
-		claudeSessionID := inst.ClaudeSessionID
-		return func() tea.Msg {
-			// Get JSONL path for this session
-			jsonlPath := inst.GetJSONLPath()
-			if jsonlPath == "" {
-				// No JSONL path available - return empty analytics
-				return analyticsFetchedMsg{
-					sessionID: sessionID,
-					analytics: nil,
-					err:       nil,
-				}
-			}
-
-			// Parse the JSONL file
-			analytics, err := session.ParseSessionJSONL(jsonlPath)
-			if err != nil {
-				uiLog.Warn(
-					"analytics_parse_failed",
-					slog.String("session_id", sessionID),
-					slog.String("claude_session_id", claudeSessionID),
-					slog.String("error", err.Error()),
-				)
-				return analyticsFetchedMsg{
-					sessionID: sessionID,
-					analytics: nil,
-					err:       err,
-				}
-			}
-
-			return analyticsFetchedMsg{
-				sessionID: sessionID,
-				analytics: analytics,
-				err:       nil,
-			}
+	return func() tea.Msg {
+		analytics, geminiAnalytics, err := adapter.ParseAnalytics(inst)
+		if err != nil {
+			uiLog.Debug(
+				"analytics_parse_failed",
+				slog.String("session_id", sessionID),
+				slog.String("tool", adapter.Name()),
+				slog.String("error", err.Error()),
+			)
+			return analyticsFetchedMsg{sessionID: sessionID, err: err}
 		}
-	case "gemini":
-		return func() tea.Msg {
-			// Gemini analytics are updated via UpdateGeminiSession which is called in background
-			// during UpdateStatus(). We just return the current snapshot.
-			return analyticsFetchedMsg{
-				sessionID:       sessionID,
-				geminiAnalytics: inst.GeminiAnalytics,
-				err:             nil,
-			}
+		return analyticsFetchedMsg{
+			sessionID:       sessionID,
+			analytics:       analytics,
+			geminiAnalytics: geminiAnalytics,
 		}
 	}
-
-	return nil
 }
 
 // getSelectedSession returns the currently selected session, or nil if a group is selected
@@ -3396,9 +3748,13 @@ func (h *Home) getSelectedSession() *session.Instance {
 
 type sessionRenderState struct {
 	status    session.Status
-	substate  session.Substate // Honest Status v2: additive refinement (model-unavailable, auth-401, ...)
+	substate  session.Substate  // Honest Status v2: additive refinement (model-unavailable, auth-401, ...)
+	attention session.Attention // What a waiting session needs (question / error / done)
 	tool      string
-	paneTitle string // Current task description from tmux pane title (stripped of spinner/done markers)
+	paneTitle string    // Current task description from tmux pane title (stripped of spinner/done markers)
+	waitStart time.Time // When the current wait began (zero unless waiting); see waitTracker
+	stuck     bool      // Running without output past [watchdog] stuck_after; see stuckWatchdog
+	archived  bool      // In an archived group; left out of the header status counts
 }
 
 // displaySessionTitle returns the label to render for a session row. For an
@@ -3502,9 +3858,13 @@ func (h *Home) refreshSessionRenderSnapshot(instances []*session.Instance) {
 			continue
 		}
 		state := sessionRenderState{
-			status:   inst.GetStatusThreadSafe(),
-			substate: inst.CachedSubstate(),
-			tool:     inst.GetToolThreadSafe(),
+			status:    inst.GetStatusThreadSafe(),
+			substate:  inst.CachedSubstate(),
+			attention: inst.CachedAttention(),
+			tool:      inst.GetToolThreadSafe(),
+			waitStart: h.waitTracker.startedAt(inst.ID),
+			stuck:     h.stuckWatchdog.isStuck(inst.ID),
+			archived:  session.InMutedGroup(h.archivedGroupPaths(), inst.GroupPath),
 		}
 		// Look up pane title from the already-refreshed tmux cache.
 		// Only RefreshPaneInfoCache (called from backgroundStatusUpdate) keeps
@@ -3642,7 +4002,8 @@ func (h *Home) statusWorker() {
 	// the Bubble Tea tick messages stop firing, but this goroutine keeps running.
 	// A timer (reset after each sweep) rather than a fixed ticker lets the cadence
 	// adapt when a sweep overruns the interval (#1366).
-	timer := time.NewTimer(baseStatusInterval)
+	base, ceiling := h.statusIntervals()
+	timer := time.NewTimer(base)
 	defer timer.Stop()
 
 	for {
@@ -3654,7 +4015,7 @@ func (h *Home) statusWorker() {
 			// Self-triggered update - runs even when TUI is paused
 			sweepStart := time.Now()
 			h.backgroundStatusUpdate()
-			timer.Reset(nextStatusInterval(time.Since(sweepStart), baseStatusInterval, maxStatusInterval))
+			timer.Reset(nextStatusInterval(time.Since(sweepStart), base, ceiling))
 			// Coalesce a queued immediate request after full sweep.
 			select {
 			case <-h.statusTrigger:
@@ -3779,6 +4140,20 @@ func (h *Home) backgroundStatusUpdate() {
 		}
 	}
 
+	// Re-verify stored Claude session IDs against the transcript each pane is
+	// actually writing. A directory listing + stat per Claude session; once a
+	// minute is plenty to catch an external resume before the next restart.
+	{
+		const claudeIDVerifyEvery = 60 * time.Second
+		nowNano := time.Now().UnixNano()
+		lastNano := h.claudeIDVerifyLastTick.Load()
+		if lastNano == 0 || time.Duration(nowNano-lastNano) >= claudeIDVerifyEvery {
+			if h.claudeIDVerifyLastTick.CompareAndSwap(lastNano, nowNano) {
+				session.VerifyClaudeSessionIDs(instances)
+			}
+		}
+	}
+
 	// PERFORMANCE: Gradually configure unconfigured sessions in background
 	// Configure one session per tick to avoid blocking the status update
 	// This ensures all sessions get configured within ~1 minute even without user interaction
@@ -3795,7 +4170,7 @@ func (h *Home) backgroundStatusUpdate() {
 	// Feed hook statuses from watcher to instances (enables hook fast path in UpdateStatus)
 	if h.hookWatcher != nil {
 		for _, inst := range instances {
-			if session.IsClaudeCompatible(inst.Tool) || inst.Tool == "codex" || inst.Tool == "gemini" || inst.Tool == "hermes" || inst.Tool == "cursor" {
+			if session.ToolAdapterFor(inst.Tool).EmitsHookStatus() {
 				if hs := h.hookWatcher.GetHookStatus(inst.ID); hs != nil {
 					inst.UpdateHookStatus(hs)
 				}
@@ -4008,6 +4383,25 @@ func (h *Home) backgroundStatusUpdate() {
 
 	}
 
+	// Desktop notifications for waiting/error transitions ([notifications.desktop])
+	h.notifyDesktopTransitions(instances)
+	// Wait durations for the list, escalation past escalate_after_minutes
+	h.trackWaitDurations(instances)
+	// Stuck-session watchdog ([watchdog])
+	h.runStuckWatchdog(instances)
+	// Per-session CPU/RAM for the preview and group_sort = "resources"
+	h.sampleResourceUsage(instances)
+	// Per-session spend while sorted by cost
+	h.refreshSessionCosts(instances)
+	// Outbound session event webhooks ([webhooks.*])
+	h.dispatchWebhooks(instances)
+	// Slack waiting notices and thread replies ([slack])
+	h.syncSlack(instances)
+	// Git status badges ([display] show_git_status)
+	h.refreshGitStatus(instances)
+	// Files touched in the analytics panel ([preview.analytics] show_files_touched)
+	h.refreshFilesTouched(instances)
+
 	// Always sync notification bar - must check for signal file (Ctrl+b N acknowledgments)
 	// even when no status changes occurred
 	notifStart := time.Now()
@@ -4096,21 +4490,61 @@ func (h *Home) syncNotificationsBackground() {
 	)
 
 	// Sync notification manager with current states
+	h.notificationManager.SetMutedGroups(h.mutedGroupPaths())
 	h.notificationManager.SyncFromInstances(instances, currentSessionID)
 
 	// Update tmux status bar directly
 	barText := h.notificationManager.FormatBar()
 
+	// Deck-wide DND: keep tracking waiting sessions for the "missed while
+	// away" digest, but show only the DND marker instead of the session list.
+	if dnd, err := session.LoadDNDState(); err == nil && dnd.Active(time.Now()) {
+		h.dndUntil.Store(dnd.Until.UnixNano())
+		if !h.notificationManager.IsMinimal() {
+			entries := h.notificationManager.GetEntries()
+			missed := make([]session.DNDMissedEvent, 0, len(entries))
+			for _, e := range entries {
+				if e.Status != session.StatusWaiting && e.Status != session.StatusError {
+					continue
+				}
+				missed = append(missed, session.DNDMissedEvent{
+					Source:    session.DNDSourceNotificationBar,
+					SessionID: e.SessionID,
+					Title:     e.Title,
+					Status:    string(e.Status),
+				})
+			}
+			if _, err := session.RecordDNDMissed(missed...); err != nil {
+				notifLog.Warn("dnd_record_failed", slog.String("error", err.Error()))
+			}
+		}
+		barText = session.FormatDNDBar(dnd.Until)
+	} else {
+		h.dndUntil.Store(0)
+	}
+
 	// Only update if changed (avoid unnecessary tmux calls)
+	// A placement change ([notifications] placement edited live) clears the
+	// old spot and redraws in the new one.
+	placement := h.notificationManager.Placement()
 	h.lastBarTextMu.Lock()
-	if barText != h.lastBarText {
+	if h.lastBarPlacement == "" {
+		h.lastBarPlacement = placement
+	}
+	moved := h.lastBarPlacement != placement
+	if barText != h.lastBarText || moved {
+		oldPlacement := h.lastBarPlacement
 		h.lastBarText = barText
+		h.lastBarPlacement = placement
 		h.lastBarTextMu.Unlock()
 
-		if barText == "" {
-			_ = tmux.ClearStatusLeftGlobal()
-		} else {
-			_ = tmux.SetStatusLeftGlobal(barText)
+		if moved {
+			clearNotificationBar(oldPlacement)
+		}
+		if barText != "" {
+			setNotificationBar(placement, barText)
+		} else if !moved {
+			clearNotificationBar(placement)
 		}
 
 		// Force immediate visual update (bypasses 15-second status-interval)
@@ -4423,11 +4857,18 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.setupWizard.SetSize(msg.Width, msg.Height)
 		h.settingsPanel.SetSize(msg.Width, msg.Height)
 		h.watcherPanel.SetSize(msg.Width, msg.Height)
+		h.diffViewer.width, h.diffViewer.height = msg.Width, msg.Height
 		if h.toolVisibilityPanel != nil {
 			h.toolVisibilityPanel.SetSize(msg.Width, msg.Height)
 		}
 		h.geminiModelDialog.SetSize(msg.Width, msg.Height)
+		h.claudeModelDialog.SetSize(msg.Width, msg.Height)
+		h.claudePermDialog.SetSize(msg.Width, msg.Height)
+		h.conversationViewer.SetSize(msg.Width, msg.Height)
+		h.fileRefDialog.SetSize(msg.Width, msg.Height)
+		h.snapshotDialog.SetSize(msg.Width, msg.Height)
 		h.promptInputDialog.SetSize(msg.Width, msg.Height)
+		h.pasteFileDialog.SetSize(msg.Width, msg.Height)
 		// Issue #1366: a resize can reveal the preview pane (single -> stacked/dual).
 		// fetchSelectedPreview self-guards to nil in single-column, so this only
 		// fetches when a preview pane is actually visible.
@@ -4461,6 +4902,14 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 				h.globalSearch, cmd = h.globalSearch.Update(msg)
 				return h, cmd
 			}
+			if h.mcpLogDialog.IsVisible() {
+				if msg.Button == tea.MouseButtonWheelUp {
+					h.mcpLogDialog.Update(tea.KeyMsg{Type: tea.KeyUp})
+				} else {
+					h.mcpLogDialog.Update(tea.KeyMsg{Type: tea.KeyDown})
+				}
+				return h, nil
+			}
 			if h.mcpDialog.IsVisible() {
 				if msg.Button == tea.MouseButtonWheelUp {
 					h.mcpDialog.ScrollUp()
@@ -4666,6 +5115,10 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 				}
+				// [display] group_expansion: startup-only override of the saved
+				// expand/collapse state. Applied after the seeding save above so
+				// that save writes back the stored state, not the override.
+				h.applyStartupGroupExpansion()
 			} else {
 				// Refresh - update existing tree with loaded sessions AND groups
 				// Preserve expanded state before recreating tree
@@ -4686,6 +5139,7 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
+			h.syncMutedGroups()
 			h.search.SetItems(h.instances)
 
 			// Re-apply pending title changes that were lost during reload.
@@ -4769,6 +5223,10 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				// Save after dedup to persist any ID changes (initial load only)
 				h.saveInstances()
+				if !h.tmuxDoctorChecked {
+					h.tmuxDoctorChecked = true
+					detectionCmds = append(detectionCmds, h.diagnoseTmuxCmd())
+				}
 			}
 			// Trigger immediate preview fetch for initial selection (mutex-protected)
 			if selected := h.getSelectedSession(); selected != nil {
@@ -4786,6 +5244,22 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case tmuxDoctorMsg:
+		// Ask once, and never on top of another prompt (hooks, setup, ...).
+		if len(msg.issues) > 0 && !h.hasModalVisible() {
+			h.confirmDialog.ShowTmuxDoctor(msg.issues)
+			h.confirmDialog.SetSize(h.width, h.height)
+		}
+		return h, nil
+
+	case tmuxDoctorRepairedMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("repaired %d tmux issue(s); %w", msg.repaired, msg.err))
+		} else {
+			h.setError(fmt.Errorf("repaired %d tmux issue(s)", msg.repaired))
+		}
+		return h, nil
+
 	case sessionCreatedMsg:
 		uiLog.Info("session_created_msg",
 			slog.Bool("has_err", msg.err != nil),
@@ -4862,6 +5336,20 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case conversationLoadedMsg:
+		if h.conversationViewer.IsVisible() && h.conversationViewer.SessionID() == msg.sessionID {
+			h.conversationViewer.SetConversation(msg.entries, msg.err)
+		}
+		return h, nil
+
+	case forkPointsMsg:
+		if h.forkDialog.IsVisible() {
+			if inst := h.getSelectedSession(); inst != nil && inst.ID == msg.sourceID {
+				h.forkDialog.SetForkPoints(msg.points)
+			}
+		}
+		return h, nil
+
 	case sessionForkedMsg:
 		// Clean up forking state for source session
 		if msg.sourceID != "" {
@@ -4993,7 +5481,14 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.rebuildFlatItems()
 		// Update search items
 		h.search.SetItems(h.instances)
-		// Explicitly delete from database to prevent resurrection on reload
+		// Keep a restorable copy in the trash (survives quitting, unlike the
+		// undo stack), then delete from the database to prevent resurrection
+		// on reload.
+		if deletedInstance != nil {
+			if err := h.storage.TrashInstance(deletedInstance); err != nil {
+				uiLog.Warn("trash_instance_err", slog.String("id", msg.deletedID), slog.String("err", err.Error()))
+			}
+		}
 		if err := h.storage.DeleteInstance(msg.deletedID); err != nil {
 			uiLog.Warn("delete_instance_db_err", slog.String("id", msg.deletedID), slog.String("err", err.Error()))
 		}
@@ -5050,6 +5545,13 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 				h.setError(fmt.Errorf("failed to persist archive: %w", err))
 				return h, nil
 			}
+			if msg.auto {
+				h.maintenanceMsg = fmt.Sprintf("Auto-archived idle session '%s' (^ to view)", inst.Title)
+				h.maintenanceMsgTime = time.Now()
+				return h, tea.Tick(30*time.Second, func(_ time.Time) tea.Msg {
+					return clearMaintenanceMsg{}
+				})
+			}
 			h.setError(fmt.Errorf("archived '%s' (^ to view)", inst.Title))
 		}
 		return h, nil
@@ -5110,6 +5612,10 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Use forceSave to bypass mtime check - restore MUST persist
 		h.forceSaveInstances()
+		// The undo brought the session back; drop its now-redundant trash entry.
+		if _, err := h.storage.PurgeTrash(msg.instance.ID); err != nil {
+			uiLog.Warn("trash_purge_err", slog.String("id", msg.instance.ID), slog.String("err", err.Error()))
+		}
 		if msg.warning != "" {
 			h.setError(fmt.Errorf("restored '%s' (%s)", msg.instance.Title, msg.warning))
 		} else {
@@ -5361,12 +5867,40 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case claudeModelSelectedMsg:
+		h.instancesMu.RLock()
+		inst := h.instanceByID[msg.instanceID]
+		h.instancesMu.RUnlock()
+		if inst != nil {
+			if err := inst.SetClaudeModel(msg.model, msg.effort); err != nil {
+				h.err = fmt.Errorf("failed to set model: %w", err)
+				h.errTime = time.Now()
+			}
+			h.forceSaveInstances()
+		}
+		return h, nil
+
+	case claudePermissionSelectedMsg:
+		h.instancesMu.RLock()
+		inst := h.instanceByID[msg.instanceID]
+		h.instancesMu.RUnlock()
+		if inst != nil {
+			if err := inst.SetClaudePermissionMode(msg.mode); err != nil {
+				h.err = fmt.Errorf("failed to set permission mode: %w", err)
+				h.errTime = time.Now()
+			}
+			h.forceSaveInstances()
+		}
+		return h, nil
+
 	case promptSubmitMsg:
 		// #1410: deliver a one-line prompt to the highlighted session without
-		// attaching, reusing the prompt-state-aware send path (the #1409/#1432
-		// composer-draft guard) so the prompt never merges with a half-typed
-		// operator draft and delivery is verified. Dispatch in a goroutine —
-		// the guard holds briefly and the verify loop polls the pane.
+		// attaching. Claude-compatible tools reuse the prompt-state-aware send
+		// path (the #1409/#1432 composer-draft guard) so the prompt never merges
+		// with a half-typed operator draft and delivery is verified; other tools
+		// get a plain send-keys + Enter, like the web /input endpoint. Dispatch
+		// as a command — the guard holds briefly and the verify loop polls the
+		// pane — and report the outcome via promptSentMsg.
 		h.instancesMu.RLock()
 		inst := h.instanceByID[msg.instanceID]
 		h.instancesMu.RUnlock()
@@ -5380,28 +5914,111 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return h, nil
 		}
 		text := msg.text
-		tmuxName := ts.Name
-		go func() {
-			if err := deliverToConductorPane(ts, text); err != nil {
-				uiLog.Warn("list_prompt_send_failed",
-					slog.String("tmux_session", tmuxName),
-					slog.String("error", err.Error()))
-			}
-		}()
-		return h, nil
+		title := inst.Title
+		guarded := session.IsClaudeCompatible(inst.Tool)
+		snapshot := promptSnapshotter(inst)
+		return h, func() tea.Msg {
+			snapshot()
+			return promptSentMsg{title: title, err: sendListPrompt(ts, guarded, text)}
+		}
 
-	case refreshMsg:
-		return h, h.loadSessions
+	case pasteFileSubmitMsg:
+		return h, h.pasteFile(msg)
 
-	case systemThemeMsg:
-		theme := "light"
-		if msg.dark {
-			theme = "dark"
+	case pasteFileSentMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("paste into %q failed: %w", msg.title, msg.err))
+			return h, nil
 		}
-		// Update COLORFGBG in our process environment so that all downstream
-		// code (ResolveTheme, ThemeColorFGBG, themeEnvExport, currentTmuxThemeStyle)
-		// sees the correct theme. Without this, the stale COLORFGBG inherited
-		// from the parent terminal at launch time takes precedence over the OS
+		h.maintenanceMsg = fmt.Sprintf("Pasted %s (%d lines) into %q", msg.name, msg.lines, msg.title)
+		h.maintenanceMsgTime = time.Now()
+		return h, tea.Tick(5*time.Second, func(_ time.Time) tea.Msg {
+			return clearMaintenanceMsg{}
+		})
+
+	case promptBroadcastMsg:
+		return h, h.broadcastPrompt(msg)
+
+	case promptBroadcastSentMsg:
+		h.reportPromptBroadcast(msg)
+		return h, tea.Tick(5*time.Second, func(_ time.Time) tea.Msg {
+			return clearMaintenanceMsg{}
+		})
+
+	case worktreeDiffMsg:
+		if h.showDiffViewer && h.diffViewer.sessionID == msg.sessionID {
+			h.diffViewer.loading = false
+			h.diffViewer.files = msg.files
+			h.diffViewer.err = msg.err
+		}
+		return h, nil
+
+	case pullRequestCreatedMsg:
+		if msg.err != nil {
+			h.maintenanceMsg = ""
+			h.setError(fmt.Errorf("pull request for %q: %w", msg.title, msg.err))
+			return h, nil
+		}
+		h.maintenanceMsg = "PR opened: " + msg.url
+		h.maintenanceMsgTime = time.Now()
+		return h, tea.Tick(10*time.Second, func(_ time.Time) tea.Msg {
+			return clearMaintenanceMsg{}
+		})
+
+	case transcriptClosedMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("transcript pager for %q: %w", msg.title, msg.err))
+		}
+		return h, nil
+
+	case verifyDoneMsg:
+		return h, h.handleVerifyDone(msg)
+
+	case snapshotsLoadedMsg:
+		h.handleSnapshotsLoaded(msg)
+		return h, nil
+
+	case snapshotTakenMsg:
+		return h, h.handleSnapshotTaken(msg)
+
+	case snapshotRestoredMsg:
+		return h, h.handleSnapshotRestored(msg)
+
+	case fileOpenedMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("editor for %s: %w", msg.path, msg.err))
+		}
+		return h, nil
+
+	case scheduleLaunchedMsg:
+		return h, h.handleScheduleLaunched(msg)
+
+	case chainFiredMsg:
+		return h, h.handleChainFired(msg)
+
+	case promptSentMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("prompt to %q not delivered: %w", msg.title, msg.err))
+			return h, nil
+		}
+		h.maintenanceMsg = fmt.Sprintf("Prompt sent to %q", msg.title)
+		h.maintenanceMsgTime = time.Now()
+		return h, tea.Tick(5*time.Second, func(_ time.Time) tea.Msg {
+			return clearMaintenanceMsg{}
+		})
+
+	case refreshMsg:
+		return h, h.loadSessions
+
+	case systemThemeMsg:
+		theme := "light"
+		if msg.dark {
+			theme = "dark"
+		}
+		// Update COLORFGBG in our process environment so that all downstream
+		// code (ResolveTheme, ThemeColorFGBG, themeEnvExport, currentTmuxThemeStyle)
+		// sees the correct theme. Without this, the stale COLORFGBG inherited
+		// from the parent terminal at launch time takes precedence over the OS
 		// dark mode change, causing sessions to stay in the wrong color scheme.
 		if msg.dark {
 			os.Setenv("COLORFGBG", "15;0")
@@ -5414,6 +6031,18 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Without this, the watcher silently disconnects.
 		return h, tea.Batch(listenForThemeChange(h.themeWatcher), tea.ClearScreen)
 
+	case configChangedMsg:
+		cfg, err := session.ReloadUserConfig()
+		if err != nil {
+			// Keep the settings already applied; a half-typed edit must not
+			// reset the TUI to defaults.
+			h.setError(fmt.Errorf("%w (keeping current settings)", err))
+			return h, listenForConfigChange(h.configWatcher)
+		}
+		uiLog.Info("config_reloaded")
+		cmd := h.applyLiveConfig(cfg)
+		return h, tea.Batch(cmd, listenForConfigChange(h.configWatcher), tea.ClearScreen)
+
 	case storageChangedMsg:
 		uiLog.Debug("reload_storage_changed", slog.String("profile", h.profile), slog.Int("instances", len(h.instances)))
 
@@ -5452,6 +6081,8 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Reconcile the attached session synchronously before the normal delayed
 		// refresh so an exited pane does not render as still running for a tick.
 		h.refreshAttachedSessionStatus(msg.attachedSessionID)
+		// Everything printed up to the detach was on screen.
+		h.outputSeen.markSeen(msg.attachedSessionID)
 
 		selectedBefore := h.captureSelectedItemIdentity()
 		h.rebuildFlatItemsPreservingSelection(selectedBefore)
@@ -5610,59 +6241,40 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, h.fetchPreview(inst, msg.previewKey, msg.windowIndex))
 			}
 
-			// Analytics fetch (for Claude/Gemini sessions with analytics enabled)
+			// Analytics fetch (for tools whose adapter parses analytics, when enabled)
 			// Use TTL cache - only fetch if cache miss/expired and not already fetching
-			tickTool := inst.GetToolThreadSafe()
-			if (tickTool == "claude" || tickTool == "gemini") && h.analyticsFetchingID != inst.ID {
-				switch tickTool {
-				case "claude":
-					cached := h.getAnalyticsForSession(inst)
-					if cached != nil {
-						// Use cached analytics
-						if h.analyticsSessionID != inst.ID {
-							h.currentAnalytics = cached
-							h.currentGeminiAnalytics = nil
-							h.analyticsSessionID = inst.ID
+			tickAdapter := session.ToolAdapterFor(inst.GetToolThreadSafe())
+			if tickAdapter.HasAnalytics() && h.analyticsFetchingID != inst.ID {
+				cached, cachedGemini := h.getAnalyticsForSession(inst), h.getGeminiAnalyticsForSession(inst)
+				if cached != nil || cachedGemini != nil {
+					// Use cached analytics
+					if h.analyticsSessionID != inst.ID {
+						h.currentAnalytics = cached
+						h.currentGeminiAnalytics = cachedGemini
+						h.analyticsSessionID = inst.ID
+						if cachedGemini != nil {
+							h.analyticsPanel.SetGeminiAnalytics(cachedGemini)
+						} else {
 							h.analyticsPanel.SetAnalytics(cached)
 						}
-					} else {
-						// Cache miss or expired - fetch new analytics
-						config, _ := session.LoadUserConfig()
-						if config != nil && config.GetShowAnalytics() {
-							h.analyticsFetchingID = inst.ID
-							cmds = append(cmds, h.fetchAnalytics(inst))
-						}
 					}
-				case "gemini":
-					// Check Gemini cache
-					var cached *session.GeminiSessionAnalytics
-					h.analyticsCacheMu.RLock()
-					if c, ok := h.geminiAnalyticsCache[inst.ID]; ok {
-						if time.Since(h.analyticsCacheTime[inst.ID]) < analyticsCacheTTL {
-							cached = c
-						}
-					}
-					h.analyticsCacheMu.RUnlock()
-
-					if cached != nil {
-						// Use cached analytics
-						if h.analyticsSessionID != inst.ID {
-							h.currentGeminiAnalytics = cached
-							h.currentAnalytics = nil
-							h.analyticsSessionID = inst.ID
-							h.analyticsPanel.SetGeminiAnalytics(cached)
-						}
-					} else {
-						// Cache miss or expired - fetch new analytics
-						config, _ := session.LoadUserConfig()
-						if config != nil && config.GetShowAnalytics() {
-							h.analyticsFetchingID = inst.ID
-							cmds = append(cmds, h.fetchAnalytics(inst))
-						}
+				} else {
+					// Cache miss or expired - fetch new analytics
+					config, _ := session.LoadUserConfig()
+					if config != nil && config.GetShowAnalytics() {
+						h.analyticsFetchingID = inst.ID
+						cmds = append(cmds, h.fetchAnalytics(inst))
 					}
 				}
 			}
 
+			// MCP liveness probe (lazy, mcpHealthTTL)
+			if session.ToolSupportsMCPManager(inst.GetToolThreadSafe()) {
+				if cmd := h.probeMCPHealth(inst.ID, inst.GetMCPInfo()); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			}
+
 			// Worktree dirty status check (lazy, 10s TTL)
 			if inst.IsWorktree() && inst.WorktreePath != "" {
 				h.worktreeDirtyMu.Lock()
@@ -5701,6 +6313,15 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.previewCacheMu.Unlock()
 		return h, nil
 
+	case analyticsDashboardMsg:
+		// Drop results for a window the user already switched away from.
+		if h.showAnalyticsDash && msg.days == h.analyticsDash.days {
+			h.analyticsDash.groups = msg.groups
+			h.analyticsDash.sessions = msg.sessions
+			h.analyticsDash.loading = false
+		}
+		return h, nil
+
 	case analyticsFetchedMsg:
 		// Async analytics parsing complete - update TTL cache
 		h.analyticsFetchingID = ""
@@ -5739,6 +6360,22 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case mcpRegistryLoadedMsg:
+		h.mcpRegistryDialog.SetEntries(msg.entries, msg.warnings)
+		return h, nil
+
+	case mcpPoolActionMsg:
+		h.mcpPoolDialog.SetNotice(msg.action+" "+msg.name, msg.err)
+		h.refreshMCPPoolDialog()
+		return h, nil
+
+	case mcpHealthMsg:
+		h.mcpHealth.store(msg.sessionID, msg.health)
+		if h.mcpDialog.IsVisible() && h.mcpDialog.GetSessionID() == msg.sessionID {
+			h.mcpDialog.SetHealth(h.mcpHealth.get(msg.sessionID))
+		}
+		return h, nil
+
 	case worktreeDirtyCheckMsg:
 		// Update worktree dirty status cache
 		if msg.err == nil {
@@ -5776,6 +6413,32 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Success: remove session from instances and clean up
 		h.worktreeFinishDialog.Hide()
 
+		if msg.keptSession {
+			h.instancesMu.RLock()
+			inst := h.instanceByID[msg.sessionID]
+			h.instancesMu.RUnlock()
+			if inst != nil {
+				inst.ProjectPath = msg.repoRoot
+				inst.WorktreePath = ""
+				inst.WorktreeRepoRoot = ""
+				inst.WorktreeBranch = ""
+				inst.WorktreeType = ""
+			}
+			h.worktreeDirtyMu.Lock()
+			delete(h.worktreeDirtyCache, msg.sessionID)
+			delete(h.worktreeDirtyCacheTs, msg.sessionID)
+			h.worktreeDirtyMu.Unlock()
+			h.invalidatePreviewCache(msg.sessionID)
+			h.forceSaveInstances()
+
+			successMsg := fmt.Sprintf("Finished worktree '%s', session moved to repo root", msg.sessionTitle)
+			if msg.merged {
+				successMsg += fmt.Sprintf(", merged into %s", msg.targetBranch)
+			}
+			h.setError(errors.New(successMsg))
+			return h, nil
+		}
+
 		h.instancesMu.Lock()
 		for i, s := range h.instances {
 			if s.ID == msg.sessionID {
@@ -5799,6 +6462,7 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		delete(h.worktreeDirtyCache, msg.sessionID)
 		delete(h.worktreeDirtyCacheTs, msg.sessionID)
 		h.worktreeDirtyMu.Unlock()
+		h.mcpHealth.forget(msg.sessionID)
 		h.logActivityMu.Lock()
 		delete(h.lastLogActivity, msg.sessionID)
 		h.logActivityMu.Unlock()
@@ -5824,6 +6488,50 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.setError(fmt.Errorf("%s", successMsg))
 		return h, nil
 
+	case sessionMovedToProfileMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("move '%s' to profile %s: %w", msg.title, msg.profile, msg.err))
+			return h, nil
+		}
+		h.instancesMu.Lock()
+		for i, s := range h.instances {
+			if s.ID == msg.sessionID {
+				h.instances = append(h.instances[:i], h.instances[i+1:]...)
+				break
+			}
+		}
+		inst := h.instanceByID[msg.sessionID]
+		delete(h.instanceByID, msg.sessionID)
+		h.instancesMu.Unlock()
+
+		h.cachedStatusCounts.valid.Store(false)
+		h.invalidatePreviewCache(msg.sessionID)
+		h.analyticsCacheMu.Lock()
+		delete(h.analyticsCache, msg.sessionID)
+		delete(h.geminiAnalyticsCache, msg.sessionID)
+		delete(h.analyticsCacheTime, msg.sessionID)
+		h.analyticsCacheMu.Unlock()
+		h.logActivityMu.Lock()
+		delete(h.lastLogActivity, msg.sessionID)
+		h.logActivityMu.Unlock()
+
+		if inst != nil {
+			h.groupTree.RemoveSession(inst)
+		}
+		h.rebuildFlatItems()
+		h.search.SetItems(h.instances)
+
+		// A save from this deck between the migration and now would have
+		// re-inserted the row from the stale in-memory list; delete and
+		// verify against our own storage now that the list is current.
+		if err := h.storage.RemoveSessionAndVerify(msg.sessionID, h.instances, h.groupTree); err != nil {
+			uiLog.Warn("move_profile_source_verify_err", slog.String("id", msg.sessionID), slog.String("err", err.Error()))
+			h.setError(fmt.Errorf("moved '%s' to profile %s, but it may reappear here: %w", msg.title, msg.profile, err))
+			return h, nil
+		}
+		h.setError(fmt.Errorf("Moved '%s' to profile %s", msg.title, msg.profile))
+		return h, nil
+
 	case copyResultMsg:
 		if msg.err != nil {
 			h.setError(msg.err)
@@ -5886,7 +6594,8 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var remoteFetchCmd tea.Cmd
 		var remoteLatencyCmd tea.Cmd
 
-		if h.groupViewMode != session.GroupViewNormal {
+		// Branch view also regroups as git status reports branch switches.
+		if h.groupViewMode != session.GroupViewNormal || h.branchView {
 			selectedBefore := h.captureSelectedItemIdentity()
 			h.rebuildFlatItemsPreservingSelection(selectedBefore)
 		}
@@ -5922,9 +6631,16 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update animation frame for launching spinner (8 frames, cycles every tick)
 		h.animationFrame = (h.animationFrame + 1) % 8
 
+		h.sampleOutputSeen()
+
 		// Refresh cost totals for header display
 		h.refreshCostTotals()
 
+		// Keep uptime and client counts live while the pool dashboard is open
+		if h.mcpPoolDialog.IsVisible() {
+			h.refreshMCPPoolDialog()
+		}
+
 		// Periodic UI state save (every 5 ticks = ~10 seconds)
 		h.uiStateSaveTicks++
 		if h.uiStateSaveTicks >= 5 {
@@ -5935,7 +6651,7 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Periodic remote session fetch (issue #1170). Cadence is configurable
 		// via [ui] remote_session_refresh_secs (default 15s); see
 		// shouldFetchRemoteSessions for the stale/in-flight gating.
-		if h.shouldFetchRemoteSessions(time.Now()) {
+		if !h.safeMode && h.shouldFetchRemoteSessions(time.Now()) {
 			h.remoteSessionsMu.Lock()
 			h.remotesFetchActive = true
 			h.remoteSessionsMu.Unlock()
@@ -5955,7 +6671,7 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		shouldLatency := !h.remoteLatencyFetchBusy &&
 			time.Since(h.lastRemoteLatencyFetch) >= time.Duration(refresh)*time.Second
 		h.remoteLatencyMu.RUnlock()
-		if shouldLatency {
+		if shouldLatency && !h.safeMode {
 			h.remoteLatencyMu.Lock()
 			h.remoteLatencyFetchBusy = true
 			h.remoteLatencyMu.Unlock()
@@ -5997,6 +6713,42 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		var autoArchiveCmd tea.Cmd
+		if !h.safeMode && time.Since(h.lastAutoArchiveCheck) >= autoArchiveCheckInterval {
+			h.lastAutoArchiveCheck = time.Now()
+			autoArchiveCmd = h.autoArchiveIdleSessions(session.GetArchiveSettings(), time.Now())
+		}
+
+		// Chains and initial prompts type into sessions: a startup side
+		// effect safe mode skips, like auto-archive and schedules.
+		var chainCmd, initialPromptCmd tea.Cmd
+		if !h.safeMode {
+			chainCmd = h.fireSessionChains()
+			initialPromptCmd = h.deliverInitialPrompts()
+		}
+
+		var scheduleCmd tea.Cmd
+		if !h.safeMode && time.Since(h.lastScheduleCheck) >= scheduleCheckInterval {
+			h.lastScheduleCheck = time.Now()
+			scheduleCmd = h.launchDueSchedules()
+		}
+
+		var transcriptCmd tea.Cmd
+		if ts := session.GetTranscriptSettings(); ts.Enabled && time.Since(h.lastTranscriptCheck) >= transcriptCheckInterval {
+			h.lastTranscriptCheck = time.Now()
+			transcriptCmd = h.maintainTranscripts(ts)
+		}
+
+		var budgetCmd tea.Cmd
+		if h.costBudget != nil && time.Since(h.lastBudgetCheck) >= budgetCheckInterval {
+			h.lastBudgetCheck = time.Now()
+			budgetCmd = h.checkBudgetAlerts()
+		}
+
+		// Periodic work started above: both returns below must carry it, as
+		// its check timestamps have already been advanced.
+		periodicCmds := []tea.Cmd{h.tick(), autoArchiveCmd, budgetCmd, chainCmd, scheduleCmd, initialPromptCmd, transcriptCmd}
+
 		// Full log maintenance (orphan cleanup, etc) every 5 minutes
 		if time.Since(h.lastLogMaintenance) >= logMaintenanceInterval {
 			h.lastLogMaintenance = time.Now()
@@ -6011,7 +6763,7 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		const updateRecheckInterval = 5 * time.Minute
 		if h.updateInfo != nil && h.updateInfo.Available && time.Since(h.lastUpdateCheck) >= updateRecheckInterval {
 			h.lastUpdateCheck = time.Now()
-			return h, tea.Batch(h.tick(), h.checkForUpdate())
+			return h, tea.Batch(append(periodicCmds, h.checkForUpdate())...)
 		}
 
 		// Clean up expired animation entries (launching, resuming, MCP loading, forking)
@@ -6066,7 +6818,7 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 				h.previewCacheMu.Unlock()
 			}
 		}
-		cmds := []tea.Cmd{h.tick(), previewCmd, remoteFetchCmd, remoteLatencyCmd}
+		cmds := append(periodicCmds, previewCmd, remoteFetchCmd, remoteLatencyCmd)
 		if h.fullRepaint {
 			cmds = append(cmds, tea.ClearScreen)
 		}
@@ -6171,26 +6923,10 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 					h.err = err
 					h.errTime = time.Now()
 				}
-				_, _ = session.ReloadUserConfig()
-				h.reloadHotkeysFromConfig()
-				h.showSessionTimestamps = config.Display.ShowSessionTimestamps
-				h.showPaneTitles = config.Display.ShowPaneTitles
-
-				// Apply theme changes live
-				h.stopThemeWatcher()
-				resolvedTheme := session.ResolveTheme()
-				InitTheme(resolvedTheme)
-				h.propagateThemeToSessions()
-				var themeCmd tea.Cmd
-				if config.Theme == "system" {
-					themeCmd = h.startThemeWatcher()
+				if reloaded, err := session.ReloadUserConfig(); err == nil && reloaded != nil {
+					config = reloaded
 				}
-
-				// Apply default tool to new dialog
-				if defaultTool := session.GetDefaultTool(); defaultTool != "" {
-					h.newDialog.SetDefaultTool(defaultTool)
-				}
-
+				themeCmd := h.applyLiveConfig(config)
 				if themeCmd != nil {
 					return h, tea.Batch(themeCmd, tea.ClearScreen)
 				}
@@ -6223,6 +6959,18 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if h.confirmDialog.IsVisible() {
 			return h.handleConfirmDialogKey(msg)
 		}
+		if h.mcpRegistryDialog.IsVisible() {
+			return h.handleMCPRegistryDialogKey(msg)
+		}
+		if h.mcpLogDialog.IsVisible() {
+			switch msg.String() {
+			case "esc", "q":
+				h.mcpLogDialog.Hide()
+			default:
+				h.mcpLogDialog.Update(msg)
+			}
+			return h, nil
+		}
 		if h.mcpDialog.IsVisible() {
 			return h.handleMCPDialogKey(msg)
 		}
@@ -6243,11 +6991,26 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 			h.geminiModelDialog = d
 			return h, cmd
 		}
+		if h.claudeModelDialog.IsVisible() {
+			d, cmd := h.claudeModelDialog.Update(msg)
+			h.claudeModelDialog = d
+			return h, cmd
+		}
+		if h.claudePermDialog.IsVisible() {
+			d, cmd := h.claudePermDialog.Update(msg)
+			h.claudePermDialog = d
+			return h, cmd
+		}
 		if h.promptInputDialog.IsVisible() {
 			d, cmd := h.promptInputDialog.Update(msg)
 			h.promptInputDialog = d
 			return h, cmd
 		}
+		if h.pasteFileDialog.IsVisible() {
+			d, cmd := h.pasteFileDialog.Update(msg)
+			h.pasteFileDialog = d
+			return h, cmd
+		}
 		if h.sessionSwitcher.IsVisible() {
 			return h.handleSessionSwitcherKey(msg)
 		}
@@ -6257,6 +7020,12 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if h.codeBlockDialog.IsVisible() {
 			return h.handleCodeBlockDialogKey(msg)
 		}
+		if h.fileRefDialog.IsVisible() {
+			return h.handleFileRefDialogKey(msg)
+		}
+		if h.snapshotDialog.IsVisible() {
+			return h.handleSnapshotDialogKey(msg)
+		}
 		if h.worktreeFinishDialog.IsVisible() {
 			return h.handleWorktreeFinishDialogKey(msg)
 		}
@@ -6268,6 +7037,39 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if h.zoxidePicker.IsVisible() {
 			return h.handleZoxidePickerKey(msg)
 		}
+		if h.profilePicker.IsVisible() {
+			return h.handleProfilePickerKey(msg)
+		}
+		if h.trashDialog.IsVisible() {
+			return h.handleTrashDialogKey(msg)
+		}
+		if h.mcpPoolDialog.IsVisible() {
+			return h.handleMCPPoolDialogKey(msg)
+		}
+		if h.eventLogDialog.IsVisible() {
+			switch msg.String() {
+			case "esc", "q", defaultHotkeyBindings[hotkeySessionTimeline]:
+				h.eventLogDialog.Hide()
+			default:
+				h.eventLogDialog.Update(msg)
+			}
+			return h, nil
+		}
+		if h.conversationViewer.IsVisible() {
+			if !h.conversationViewer.IsSearching() {
+				switch msg.String() {
+				case "esc", "q", defaultHotkeyBindings[hotkeyConversation]:
+					h.conversationViewer.Hide()
+					return h, nil
+				}
+			}
+			var cmd tea.Cmd
+			h.conversationViewer, cmd = h.conversationViewer.Update(msg)
+			return h, cmd
+		}
+		if h.commandPalette.IsVisible() {
+			return h.handleCommandPaletteKey(msg)
+		}
 
 		if h.showCostDashboard {
 			keyStr := msg.String()
@@ -6277,6 +7079,26 @@ func (h *Home) updateInner(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return h, nil // consume all other keys
 		}
+		if h.showDiffViewer {
+			if h.diffViewer.handleKey(msg.String()) {
+				h.showDiffViewer = false
+			}
+			return h, nil // consume all other keys
+		}
+		if h.showAnalyticsDash {
+			switch msg.String() {
+			case "q", "esc", h.actionKey(hotkeyAnalyticsDash):
+				h.showAnalyticsDash = false
+			case "tab":
+				h.analyticsDash.days = h.analyticsDash.nextWindow()
+				h.analyticsDash.loading = true
+				return h, h.fetchAnalyticsDashboard(h.analyticsDash.days)
+			case "r":
+				h.analyticsDash.loading = true
+				return h, h.fetchAnalyticsDashboard(h.analyticsDash.days)
+			}
+			return h, nil // consume all other keys
+		}
 
 		if h.notesEditing {
 			return h.handleNotesEditorKey(msg)
@@ -6362,7 +7184,7 @@ func (h *Home) handleGlobalSearchSelection(result *GlobalSearchResult) tea.Cmd {
 	// Check if session already exists in Agent Deck
 	h.instancesMu.RLock()
 	for _, inst := range h.instances {
-		if inst.ClaudeSessionID == result.SessionID {
+		if instanceHasToolSession(inst, result.Tool, result.SessionID) {
 			h.instancesMu.RUnlock()
 			// Jump to existing session
 			h.jumpToSession(inst)
@@ -6371,10 +7193,24 @@ func (h *Home) handleGlobalSearchSelection(result *GlobalSearchResult) tea.Cmd {
 	}
 	h.instancesMu.RUnlock()
 
-	// Create new session with this Claude session ID
+	// Create new session resuming this conversation
 	return h.createSessionFromGlobalSearch(result)
 }
 
+// jumpToGroup expands the group at path (and its parents) and moves the
+// cursor onto its row.
+func (h *Home) jumpToGroup(path string) {
+	h.groupTree.ExpandGroupWithParents(path)
+	h.rebuildFlatItems()
+	for i, item := range h.flatItems {
+		if item.Type == session.ItemTypeGroup && item.Path == path {
+			h.cursor = i
+			h.syncViewport()
+			return
+		}
+	}
+}
+
 // jumpToSession jumps the cursor to the specified session
 func (h *Home) jumpToSession(inst *session.Instance) {
 	// Ensure the session's group is expanded
@@ -6398,6 +7234,12 @@ func (h *Home) createSessionFromGlobalSearch(result *GlobalSearchResult) tea.Cmd
 	return func() tea.Msg {
 		// Derive title from CWD or session ID
 		title := "Claude Session"
+		switch result.Tool {
+		case "gemini":
+			title = "Gemini Session"
+		case "opencode":
+			title = "OpenCode Session"
+		}
 		projectPath := result.CWD
 		if result.CWD != "" {
 			parts := strings.Split(result.CWD, "/")
@@ -6413,6 +7255,23 @@ func (h *Home) createSessionFromGlobalSearch(result *GlobalSearchResult) tea.Cmd
 		// cursor-group (Window / RemoteGroup / placeholder flatItems) so
 		// the empty string never reaches NewInstanceWithGroupAndTool, which
 		// would otherwise override the extractGroupPath default with "".
+		switch result.Tool {
+		case "gemini", "opencode":
+			// Both resume from the stored session ID when the command is the
+			// bare tool name (see buildGeminiCommand / buildOpenCodeCommand).
+			inst := session.NewInstanceWithGroupAndTool(title, projectPath, h.resolveNewSessionGroup(), result.Tool)
+			inst.Command = result.Tool
+			if result.Tool == "gemini" {
+				inst.GeminiSessionID = result.SessionID
+			} else {
+				inst.OpenCodeSessionID = result.SessionID
+			}
+			if err := inst.Start(); err != nil {
+				return sessionCreatedMsg{err: fmt.Errorf("failed to start session: %w", err)}
+			}
+			return sessionCreatedMsg{instance: inst}
+		}
+
 		inst := session.NewInstanceWithGroupAndTool(title, projectPath, h.resolveNewSessionGroup(), "claude")
 		inst.ClaudeSessionID = result.SessionID
 
@@ -6627,7 +7486,7 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if !worktreeEnabled {
 			if _, err := os.Stat(path); os.IsNotExist(err) {
 				h.newDialog.Hide()
-				h.confirmDialog.ShowCreateDirectory(path, name, command, groupPath, toolOptionsJSON, claudeExtraArgs, claudeStartQuery, launchModelID, parentSessionID, parentProjectPath)
+				h.confirmDialog.ShowCreateDirectory(path, name, command, groupPath, toolOptionsJSON, claudeExtraArgs, claudeStartQuery, launchModelID, parentSessionID, parentProjectPath, h.newDialog.GetTemplateMCPs(), h.newDialog.GetInitialPrompt())
 				return h, nil
 			}
 		}
@@ -6683,6 +7542,8 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			launchModelID,
 			multiRepoEnabled,
 			additionalPaths,
+			h.newDialog.GetTemplateMCPs(),
+			h.newDialog.GetInitialPrompt(),
 			parentSessionID,
 			parentProjectPath,
 			tempID,
@@ -6962,12 +7823,14 @@ func (h *Home) hasModalVisible() bool {
 		h.helpOverlay.IsVisible() || h.search.IsVisible() || h.globalSearch.IsVisible() ||
 		h.newDialog.IsVisible() || h.groupDialog.IsVisible() || h.forkDialog.IsVisible() ||
 		h.confirmDialog.IsVisible() || h.mcpDialog.IsVisible() || h.pluginDialog.IsVisible() || h.skillDialog.IsVisible() ||
-		h.geminiModelDialog.IsVisible() || h.promptInputDialog.IsVisible() || h.sessionPickerDialog.IsVisible() ||
-		h.codeBlockDialog.IsVisible() ||
-		h.sessionSwitcher.IsVisible() ||
+		h.geminiModelDialog.IsVisible() || h.claudeModelDialog.IsVisible() || h.claudePermDialog.IsVisible() || h.promptInputDialog.IsVisible() || h.sessionPickerDialog.IsVisible() ||
+		h.pasteFileDialog.IsVisible() || h.codeBlockDialog.IsVisible() || h.fileRefDialog.IsVisible() ||
+		h.snapshotDialog.IsVisible() || h.sessionSwitcher.IsVisible() ||
 		h.worktreeFinishDialog.IsVisible() || h.editPathsDialog.IsVisible() ||
 		h.editSessionDialog.IsVisible() ||
-		h.zoxidePicker.IsVisible()
+		h.zoxidePicker.IsVisible() || h.profilePicker.IsVisible() ||
+		h.trashDialog.IsVisible() || h.mcpPoolDialog.IsVisible() || h.mcpLogDialog.IsVisible() || h.mcpRegistryDialog.IsVisible() || h.eventLogDialog.IsVisible() ||
+		h.conversationViewer.IsVisible() || h.commandPalette.IsVisible()
 }
 
 // markNavigationAndFetchPreview sets navigation tracking state and returns a debounced preview command
@@ -7155,6 +8018,12 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if h.hasMarkedSessions() {
+		if cmd, handled := h.handleMarkedKey(key); handled {
+			return h, cmd
+		}
+	}
+
 	switch key {
 	case "q", "ctrl+c":
 		return h.tryQuit()
@@ -7174,6 +8043,10 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			h.maintenanceMsg = ""
 			return h, nil
 		}
+		// Leave preview scrollback mode before anything else.
+		if h.resetPreviewScroll() {
+			return h, nil
+		}
 		// Double ESC to quit (#28) - for non-English keyboard users
 		// If ESC pressed twice within 500ms, quit the application
 		if time.Since(h.lastEscTime) < 500*time.Millisecond {
@@ -7209,6 +8082,12 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case defaultHotkeyBindings[hotkeyPreviewScrollUp]:
+		return h, h.scrollPreview(1)
+
+	case defaultHotkeyBindings[hotkeyPreviewScrollDown]:
+		return h, h.scrollPreview(-1)
+
 		// Vi-style pagination (#38) - half/full page scrolling
 	case "ctrl+u", "pgup": // Half page up
 		pageSize := h.getVisibleHeight() / 2
@@ -7384,6 +8263,45 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case defaultHotkeyBindings[hotkeyAttachSplit]:
+		// Open the focused session in a pane (or window, per [attach] mode)
+		// of the tmux session agent-deck runs in, keeping the dashboard up.
+		if h.cursor >= len(h.flatItems) {
+			return h, nil
+		}
+		if !terminal.InsideTmux() {
+			h.setError(fmt.Errorf("attach in a split needs agent-deck running inside tmux"))
+			return h, nil
+		}
+		item := h.flatItems[h.cursor]
+		mode := splitAttachMode()
+		switch {
+		case item.Type == session.ItemTypeSession && item.Session != nil:
+			if tmuxSess := item.Session.GetTmuxSession(); item.Session.Exists() && tmuxSess != nil && !tmuxSess.IsPaneDead() {
+				return h, h.attachSessionAs(item.Session, mode)
+			}
+			h.setError(fmt.Errorf("session %q is not running; press Enter to restart it", item.Session.Title))
+		case item.Type == session.ItemTypeRemoteSession && item.RemoteSession != nil:
+			if req, ok := buildRemoteAttachRequest(item.RemoteName, item.RemoteSession.ID, ""); ok {
+				if err := h.openInSurroundingTmux(req, mode, item.RemoteSession.Title); err != nil {
+					h.setError(fmt.Errorf("open remote in tmux %s: %w", mode, err))
+				}
+			}
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeySpectate]:
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				if tmuxSess := item.Session.GetTmuxSession(); item.Session.Exists() && tmuxSess != nil && !tmuxSess.IsPaneDead() {
+					return h, h.spectateSession(item.Session)
+				}
+				h.setError(fmt.Errorf("session %q is not running; nothing to spectate", item.Session.Title))
+			}
+		}
+		return h, nil
+
 	case "enter":
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
@@ -7394,7 +8312,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					if tmuxSess != nil && tmuxSess.IsPaneDead() {
 						if !h.hasActiveAnimation(item.Session.ID) {
 							h.resumingSessions[item.Session.ID] = time.Now()
-							return h, h.restartSession(item.Session)
+							return h, h.restartSession(item.Session, session.RestartReasonManual)
 						}
 						return h, nil
 					}
@@ -7403,7 +8321,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				// Session exited (tmux session gone) — auto-restart it.
 				if !h.hasActiveAnimation(item.Session.ID) {
 					h.resumingSessions[item.Session.ID] = time.Now()
-					return h, h.restartSession(item.Session)
+					return h, h.restartSession(item.Session, session.RestartReasonManual)
 				}
 			} else if item.Type == session.ItemTypeGroup {
 				// Toggle group on enter
@@ -7654,6 +8572,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				if err := h.mcpDialog.Show(item.Session.ProjectPath, item.Session.ID, item.Session.Tool); err != nil {
 					h.setError(err)
 				}
+				h.mcpDialog.SetHealth(h.mcpHealth.get(item.Session.ID))
 			}
 		}
 		return h, nil
@@ -7725,11 +8644,15 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return h, nil
 
 	case "M", "shift+m":
-		// Move session to different group
+		// Move session to different group; on a group row, move the group
+		// (with its subgroups and sessions) under another group.
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
-			if item.Type == session.ItemTypeSession {
+			switch {
+			case item.Type == session.ItemTypeSession:
 				h.groupDialog.ShowMove(h.scopedGroupPaths())
+			case item.Type == session.ItemTypeGroup && item.Group != nil:
+				h.showMoveGroupDialog(item.Group)
 			}
 		}
 		return h, nil
@@ -8023,6 +8946,9 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		conductors := h.activeConductorSessions()
 		suggestedParentID := h.suggestConductorParent()
 		h.newDialog.ShowInGroup(groupPath, groupName, defaultPath, conductors, suggestedParentID)
+		if h.groupTree != nil {
+			h.newDialog.ApplyGroupDefaults(h.groupTree.SessionDefaultsForGroup(groupPath))
+		}
 		return h, nil
 
 	case "N":
@@ -8041,12 +8967,107 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		h.zoxidePicker.Show()
 		return h, nil
 
+	case defaultHotkeyBindings[hotkeyProfileSwitcher]:
+		h.profilePicker.SetSize(h.width, h.height)
+		h.profilePicker.Show(h.profile)
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyMoveToProfile]:
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				h.profilePicker.SetSize(h.width, h.height)
+				h.profilePicker.ShowMove(h.profile, item.Session.ID, item.Session.Title)
+			}
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyPermissionMode]:
+		if inst := h.getSelectedSession(); inst != nil && session.IsClaudeCompatible(inst.Tool) {
+			h.claudePermDialog.SetSize(h.width, h.height)
+			h.claudePermDialog.Show(inst.ID, claudePermissionMode(inst))
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyCloneSession]:
+		if inst := h.getSelectedSession(); inst != nil {
+			// Block clone during animations, like fork, so the copy never
+			// races a half-started source.
+			if h.hasActiveAnimation(inst.ID) {
+				h.setError(fmt.Errorf("session is starting, please wait..."))
+				return h, nil
+			}
+			return h, h.cloneSession(inst)
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyConversation]:
+		if inst := h.getSelectedSession(); inst != nil && (session.IsClaudeCompatible(inst.Tool) || inst.Tool == "gemini") {
+			h.conversationViewer.SetSize(h.width, h.height)
+			h.conversationViewer.Show(inst)
+			return h, loadConversationCmd(inst)
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyVerify]:
+		if inst := h.getSelectedSession(); inst != nil {
+			return h, h.startVerify(inst)
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeySnapshots]:
+		if inst := h.getSelectedSession(); inst != nil {
+			return h, h.openSnapshots(inst)
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyFilesTouched]:
+		h.filesTouchedExpanded = !h.filesTouchedExpanded
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyOpenFile]:
+		if inst := h.getSelectedSession(); inst != nil {
+			return h, h.startOpenFile(inst)
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeySessionTimeline]:
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				h.eventLogDialog.SetSize(h.width, h.height)
+				h.eventLogDialog.Show(item.Session)
+			}
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyRecentSessions]:
+		h.openRecentSessions()
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyCommandPalette]:
+		h.commandPalette.SetSize(h.width, h.height)
+		h.commandPalette.Show(h.hotkeys, h.groupTree.GetGroupPaths())
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyTrashView]:
+		h.trashDialog.SetSize(h.width, h.height)
+		h.trashDialog.Show(h.storage.LoadTrash())
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyMCPPool]:
+		h.showMCPPoolDialog()
+		return h, nil
+
 	case "d":
 		// Show confirmation dialog before deletion (prevents accidental deletion)
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
 			if item.Type == session.ItemTypeSession && item.Session != nil {
 				h.confirmDialog.ShowDeleteSession(item.Session.ID, item.Session.Title, item.Session.IsSandboxed(), item.Session.IsWorktree())
+				if item.Session.IsWorktree() {
+					h.confirmDialog.SetWorktreeCleanup(session.DefaultWorktreeCleanup())
+				}
 			} else if item.Type == session.ItemTypeRemoteSession && item.RemoteSession != nil {
 				h.confirmDialog.ShowDeleteRemoteSession(item.RemoteName, item.RemoteSession.ID, item.RemoteSession.Title)
 			} else if item.Type == session.ItemTypeGroup && item.Path == session.DefaultGroupPath {
@@ -8196,25 +9217,82 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case defaultHotkeyBindings[hotkeyWorktreeDiff]:
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				return h, h.openWorktreeDiff(item.Session)
+			}
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyCreatePR]:
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				h.confirmCreatePullRequest(item.Session)
+			}
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyViewTranscript]:
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				return h, h.openTranscript(item.Session)
+			}
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyAnalyticsDash]:
+		h.showAnalyticsDash = true
+		h.analyticsDash = newAnalyticsDashboard(h.width, h.height)
+		return h, h.fetchAnalyticsDashboard(h.analyticsDash.days)
+
+	case defaultHotkeyBindings[hotkeyToggleSelect]:
+		h.toggleMarkAtCursor()
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyFixSessionID]:
+		// Adopt the transcript the pane is actually writing after a detected
+		// Claude session ID mismatch (external `claude --resume`).
+		if h.cursor < len(h.flatItems) {
+			if item := h.flatItems[h.cursor]; item.Type == session.ItemTypeSession && item.Session != nil {
+				h.adoptClaudeSessionID(item.Session)
+			}
+		}
+		return h, nil
+
 	case defaultHotkeyBindings[hotkeyPromptSession]:
 		// #1410: open a one-line prompt input for the highlighted session and
-		// send it via the prompt-state-aware send path WITHOUT attaching. Gated
-		// to Claude-compatible tools — the composer-draft guard (#1409) and the
-		// delivery verify are Claude-shaped — and to running sessions, since the
-		// prompt goes into the live tmux pane. The guarded send targets the
-		// session's default pane, so a window sub-row routes to its parent
-		// session (gated on that window's detected tool, like quickApprove).
+		// send it WITHOUT attaching. Gated to running sessions, since the prompt
+		// goes into the live tmux pane. Claude-compatible tools get the
+		// prompt-state-aware send path; every other tool gets plain send-keys
+		// (see promptSubmitMsg). Delivery targets the session's default pane,
+		// so a window sub-row routes to its parent session.
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
 			switch item.Type {
 			case session.ItemTypeWindow:
-				if session.IsClaudeCompatible(item.WindowTool) {
-					h.openPromptInput(h.getInstanceByID(item.WindowSessionID))
-				}
+				h.openPromptInput(h.getInstanceByID(item.WindowSessionID))
 			case session.ItemTypeSession:
-				if item.Session != nil && session.IsClaudeCompatible(item.Session.Tool) {
-					h.openPromptInput(item.Session)
-				}
+				h.openPromptInput(item.Session)
+			case session.ItemTypeGroup:
+				h.openBroadcastPrompt(h.sessionIDsInGroup(item.Path), "group "+item.Path)
+			}
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyPasteFile]:
+		// Pick a local file and send its contents to the highlighted running
+		// session, without attaching. Window sub-rows route to their session.
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			switch item.Type {
+			case session.ItemTypeWindow:
+				h.openPasteFile(h.getInstanceByID(item.WindowSessionID))
+			case session.ItemTypeSession:
+				h.openPasteFile(item.Session)
 			}
 		}
 		return h, nil
@@ -8242,6 +9320,34 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		h.saveUIState()
 		return h, h.fetchSelectedPreview()
 
+	case defaultHotkeyBindings[hotkeyCycleSort]:
+		// Cycle the within-group sort (manual → last accessed → status → newest
+		// → cost → memory); persisted per profile in ui_state.
+		return h, h.cycleSortMode()
+
+	case defaultHotkeyBindings[hotkeyCollapseAll]:
+		h.collapseAllGroups()
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyExpandAll]:
+		h.expandAllGroups()
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyExpandWaiting]:
+		h.expandWaitingGroups()
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyBranchView]:
+		// Toggle clustering by git repo/branch; branches come from the git
+		// status cache, which refreshGitStatus keeps warm while this is on.
+		selectedBefore := h.captureSelectedItemIdentity()
+		h.branchView = !h.branchView
+		h.rebuildFlatItemsPreservingSelection(selectedBefore)
+		h.skipDivider(1)
+		h.syncViewport()
+		h.saveUIState()
+		return h, h.fetchSelectedPreview()
+
 	case "y":
 		// Toggle YOLO mode for Gemini or Codex sessions (requires restart)
 		if h.cursor < len(h.flatItems) {
@@ -8309,7 +9415,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					if inst.GetStatusThreadSafe() == session.StatusRunning ||
 						inst.GetStatusThreadSafe() == session.StatusWaiting {
 						h.resumingSessions[inst.ID] = time.Now()
-						return h, h.restartSession(inst)
+						return h, h.restartSession(inst, session.RestartReasonConfigChange)
 					}
 				}
 			}
@@ -8329,7 +9435,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				if item.Session.CanRestart() {
 					// Track as resuming for animation (before async call starts)
 					h.resumingSessions[item.Session.ID] = time.Now()
-					return h, h.restartSession(item.Session)
+					return h, h.restartSession(item.Session, session.RestartReasonManual)
 				}
 			} else if item.Type == session.ItemTypeRemoteSession && item.RemoteSession != nil {
 				return h, h.restartRemoteSession(item.RemoteName, item.RemoteSession.ID, item.RemoteSession.Title)
@@ -8364,6 +9470,53 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case defaultHotkeyBindings[hotkeyMuteGroup]:
+		// On a session row this mutes the session's group.
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			switch {
+			case item.Type == session.ItemTypeGroup && item.Group != nil:
+				h.toggleGroupMute(item.Group.Path)
+			case item.Type == session.ItemTypeSession && item.Session != nil:
+				h.toggleGroupMute(item.Session.GroupPath)
+			}
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyArchiveGroup]:
+		// On a session row this archives the session's group.
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			switch {
+			case item.Type == session.ItemTypeGroup && item.Group != nil:
+				h.toggleGroupArchive(item.Group.Path)
+			case item.Type == session.ItemTypeSession && item.Session != nil:
+				h.toggleGroupArchive(item.Session.GroupPath)
+			}
+		}
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyArchivedGroups]:
+		h.toggleShowArchivedGroups()
+		return h, nil
+
+	case defaultHotkeyBindings[hotkeyCopyPane]:
+		// Copy the tail of the pane itself (what the output preview shows),
+		// for when the last response is not what you want or the tool keeps
+		// no transcript. Window sub-rows copy their own window.
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			switch item.Type {
+			case session.ItemTypeSession:
+				return h, h.copySessionPane(item.Session, -1)
+			case session.ItemTypeWindow:
+				if inst := h.getInstanceByID(item.WindowSessionID); inst != nil {
+					return h, h.copySessionPane(inst, item.WindowIndex)
+				}
+			}
+		}
+		return h, nil
+
 	case "C", "shift+c":
 		// Copy preview pane info (Repo / Path / Branch) to system clipboard (#791).
 		// Pairs with `c` (copy session output): same fallback chain, different payload.
@@ -8406,6 +9559,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "e":
 		if config, _ := session.LoadUserConfig(); config != nil && !config.GetShowNotes() {
+			h.setError(fmt.Errorf("session notes are off — enable \"Show Notes\" in settings (%s) or set [preview] show_notes = true", h.actionKey(hotkeySettings)))
 			return h, nil
 		}
 		if h.getLayoutMode() == LayoutModeSingle {
@@ -8421,10 +9575,19 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return h, nil
 
 	case "ctrl+g":
-		// Open Gemini model selection dialog (only for Gemini sessions)
-		if inst := h.getSelectedSession(); inst != nil && inst.Tool == "gemini" {
+		// Open the model selection dialog (Gemini, or Claude with effort)
+		inst := h.getSelectedSession()
+		switch {
+		case inst == nil:
+		case inst.Tool == "gemini":
 			cmd := h.geminiModelDialog.Show(inst.ID, inst.GeminiModel)
 			return h, cmd
+		case session.IsClaudeCompatible(inst.Tool):
+			model, effort := "", ""
+			if opts := inst.GetClaudeOptions(); opts != nil {
+				model, effort = opts.Model, opts.Effort
+			}
+			h.claudeModelDialog.Show(inst.ID, model, effort)
 		}
 		return h, nil
 
@@ -8438,7 +9601,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		h.undoStack = h.undoStack[:len(h.undoStack)-1]
 		inst := entry.instance
 		return h, func() tea.Msg {
-			err := inst.Restart()
+			err := inst.RestartWithReason(session.RestartReasonRestore)
 			return sessionRestoredMsg{
 				instance: inst,
 				err:      err,
@@ -8515,8 +9678,14 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return h, nil
 
 	case "0":
-		// Clear status filter (show all)
+		// Clear status and tag filters (show all)
 		h.statusFilter = ""
+		h.tagFilter = ""
+		h.rebuildFlatItems()
+		return h, nil
+
+	case FilterKeyTag, "shift+7":
+		h.cycleTagFilter()
 		h.rebuildFlatItems()
 		return h, nil
 
@@ -8643,6 +9812,22 @@ func (h *Home) handleConfirmDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case ConfirmTmuxDoctor:
+		switch msg.String() {
+		case "y", "Y":
+			return h, h.confirmTmuxDoctor()
+		case "enter":
+			if h.confirmDialog.GetFocusedButton() == 0 {
+				return h, h.confirmTmuxDoctor()
+			}
+			h.confirmDialog.Hide()
+			return h, nil
+		case "n", "N", "esc":
+			h.confirmDialog.Hide()
+			return h, nil
+		}
+		return h, nil
+
 	case ConfirmInstallHooks:
 		switch msg.String() {
 		case "y", "Y":
@@ -8660,6 +9845,9 @@ func (h *Home) handleConfirmDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	default:
 		// Handle delete/close confirmations (session/group/remote)
 		switch msg.String() {
+		case "w":
+			h.confirmDialog.CycleWorktreeCleanup()
+			return h, nil
 		case "y", "Y":
 			return h, h.confirmAction()
 		case "enter":
@@ -8683,8 +9871,9 @@ func (h *Home) confirmAction() tea.Cmd {
 	case ConfirmDeleteSession:
 		sessionID := h.confirmDialog.GetTargetID()
 		if inst := h.getInstanceByID(sessionID); inst != nil {
+			cleanup := h.confirmDialog.WorktreeCleanup()
 			h.confirmDialog.Hide()
-			return h.deleteSession(inst)
+			return h.deleteSession(inst, cleanup)
 		}
 	case ConfirmCloseSession:
 		sessionID := h.confirmDialog.GetTargetID()
@@ -8704,6 +9893,12 @@ func (h *Home) confirmAction() tea.Cmd {
 			h.confirmDialog.Hide()
 			return h.unarchiveSession(inst)
 		}
+	case ConfirmCreatePullRequest:
+		sessionID := h.confirmDialog.GetTargetID()
+		if inst := h.getInstanceByID(sessionID); inst != nil {
+			h.confirmDialog.Hide()
+			return h.createPullRequest(inst)
+		}
 	case ConfirmDeleteGroup:
 		groupPath := h.confirmDialog.GetTargetID()
 		h.groupTree.DeleteGroup(groupPath)
@@ -8733,6 +9928,11 @@ func (h *Home) confirmAction() tea.Cmd {
 	case ConfirmBulkRemoveErrored:
 		h.confirmDialog.Hide()
 		return h.bulkRemoveErrored()
+	case ConfirmBulkDeleteSessions:
+		ids := h.confirmDialog.GetTargetIDs()
+		cleanup := h.confirmDialog.WorktreeCleanup()
+		h.confirmDialog.Hide()
+		return h.bulkDeleteSessions(ids, cleanup)
 	}
 	h.confirmDialog.Hide()
 	return nil
@@ -8762,6 +9962,8 @@ func (h *Home) confirmCreateDirectory() tea.Cmd {
 		pendingLaunchModelID,
 		false,
 		nil,
+		h.confirmDialog.GetPendingMCPNames(),
+		h.confirmDialog.GetPendingInitialPrompt(),
 		parentSessionID,
 		parentProjectPath,
 		"",    // no placeholder — non-worktree sessions are fast
@@ -8875,6 +10077,10 @@ func (h *Home) performFinalShutdown(shutdownPool bool) tea.Cmd {
 		}
 		// Close theme watcher
 		h.stopThemeWatcher()
+		// Close config watcher
+		if h.configWatcher != nil {
+			h.configWatcher.Close()
+		}
 		// Close global search index
 		if h.globalSearchIndex != nil {
 			h.globalSearchIndex.Close()
@@ -9251,6 +10457,12 @@ func (h *Home) handleMCPDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 
 			if targetInst != nil {
+				scopes := h.mcpDialog.ChangedScopes()
+				for _, scope := range []string{"local", "global", "user"} {
+					if names, ok := scopes[scope]; ok {
+						targetInst.RecordMCPChange(scope, names)
+					}
+				}
 				mcpUILog.Debug("dialog_restarting_session", slog.String("session_id", targetInst.ID))
 				// Track as MCP loading for animation in preview pane
 				h.mcpLoadingSessions[targetInst.ID] = time.Now()
@@ -9258,7 +10470,7 @@ func (h *Home) handleMCPDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				targetInst.SkipMCPRegenerate = true
 				// Restart the session to apply MCP changes
 				h.mcpDialog.Hide()
-				return h, h.restartSession(targetInst)
+				return h, h.restartSession(targetInst, session.RestartReasonMCPChange)
 			} else {
 				mcpUILog.Debug("dialog_session_not_found", slog.String("session_id", sessionID))
 			}
@@ -9271,6 +10483,13 @@ func (h *Home) handleMCPDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		h.mcpDialog.Hide()
 		return h, nil
 
+	case "ctrl+l":
+		h.showMCPLog(h.mcpDialog.SelectedName())
+		return h, nil
+
+	case "ctrl+b":
+		return h, h.showMCPRegistry()
+
 	default:
 		h.mcpDialog.Update(msg)
 		return h, nil
@@ -9316,7 +10535,7 @@ func (h *Home) handlePluginDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		h.pluginDialog.Hide()
 
 		if targetInst.CanRestart() && !h.hasActiveAnimation(targetInst.ID) {
-			return h, h.restartSession(targetInst)
+			return h, h.restartSession(targetInst, session.RestartReasonConfigChange)
 		}
 		return h, nil
 
@@ -9469,7 +10688,7 @@ func (h *Home) handleEditSessionDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			uiLog.Debug("edit_session_auto_restart", slog.String("session_id", sessionID))
 			h.resumingSessions[sessionID] = time.Now()
-			return h, h.restartSession(inst)
+			return h, h.restartSession(inst, session.RestartReasonConfigChange)
 		}
 		return h, nil
 
@@ -9532,7 +10751,7 @@ func (h *Home) applyMultiRepoPathChanges(inst *session.Instance, newPaths []stri
 
 		h.saveInstances()
 
-		err := current.Restart()
+		err := current.RestartWithReason(session.RestartReasonConfigChange)
 		return sessionRestartedMsg{sessionID: id, err: err}
 	}
 }
@@ -9568,7 +10787,7 @@ func (h *Home) handleSkillDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			targetInst := h.getInstanceByID(sessionID)
 			if targetInst != nil && session.ShouldRestartProjectSkills(targetInst.Tool) {
 				h.skillDialog.Hide()
-				return h, h.restartSession(targetInst)
+				return h, h.restartSession(targetInst, session.RestartReasonConfigChange)
 			}
 		}
 		h.skillDialog.Hide()
@@ -9633,7 +10852,9 @@ func (h *Home) handleGroupDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		case GroupDialogMove:
 			targetGroupPath := h.groupDialog.GetSelectedGroup()
-			if targetGroupPath != "" && h.cursor < len(h.flatItems) {
+			if targetGroupPath != "" && h.hasMarkedSessions() {
+				h.bulkMoveMarked(targetGroupPath)
+			} else if targetGroupPath != "" && h.cursor < len(h.flatItems) {
 				item := h.flatItems[h.cursor]
 				if item.Type == session.ItemTypeSession {
 					h.groupTree.MoveSessionToGroup(item.Session, targetGroupPath)
@@ -9644,6 +10865,11 @@ func (h *Home) handleGroupDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					h.saveInstances()
 				}
 			}
+		case GroupDialogMoveGroup:
+			if err := h.moveGroup(h.groupDialog.GetGroupPath(), h.groupDialog.GetSelectedGroup()); err != nil {
+				h.groupDialog.SetError(err.Error())
+				return h, nil
+			}
 		case GroupDialogRenameSession:
 			newName := h.groupDialog.GetValue()
 			if newName != "" {
@@ -9700,7 +10926,11 @@ func (h *Home) handleGroupDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					// Invalidate preview cache since title changed
 					h.invalidatePreviewCache(sessionID)
 					h.rebuildFlatItems()
-					h.saveInstances()
+					if inst := h.getInstanceByID(sessionID); inst != nil {
+						if err := h.persistTitle(inst); err != nil {
+							h.setError(err)
+						}
+					}
 				}
 			}
 		}
@@ -9738,6 +10968,7 @@ func (h *Home) handleForkDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Get fork parameters from dialog including worktree settings
 		title, groupPath, branchName, worktreeEnabled := h.forkDialog.GetValuesWithWorktree()
 		opts := h.forkDialog.GetOptions()
+		opts.ResumeAt = h.forkDialog.GetResumeAt()
 		h.clearError() // Clear any previous error
 
 		// Find the currently selected session
@@ -9958,7 +11189,10 @@ func (h *Home) saveUIState() {
 	state := uiState{
 		PreviewMode:   int(h.previewMode),
 		StatusFilter:  string(h.statusFilter),
+		TagFilter:     h.tagFilter,
 		GroupViewMode: int(h.groupViewMode),
+		BranchView:    h.branchView,
+		SortMode:      h.sortOverride,
 	}
 
 	// Capture cursor position
@@ -10012,10 +11246,16 @@ func (h *Home) loadUIState() {
 	// Apply preview mode, status filter, and group view mode immediately
 	h.previewMode = PreviewMode(state.PreviewMode)
 	h.statusFilter = session.Status(state.StatusFilter)
+	h.tagFilter = state.TagFilter
 	h.groupViewMode = session.GroupViewMode(state.GroupViewMode)
 	if h.groupViewMode < session.GroupViewNormal || h.groupViewMode >= session.GroupViewModeCount {
 		h.groupViewMode = session.GroupViewNormal
 	}
+	h.branchView = state.BranchView
+	if state.SortMode != "" {
+		h.sortOverride = state.SortMode
+		session.SetGroupSortOverride(state.SortMode)
+	}
 
 	// Defer cursor restoration until flatItems are populated
 	h.pendingCursorRestore = &state
@@ -10032,6 +11272,8 @@ func (h *Home) createSessionInGroupWithWorktreeAndOptions(
 	launchModelID string,
 	multiRepoEnabled bool,
 	additionalPaths []string,
+	mcpNames []string,
+	initialPrompt string,
 	parentSessionID, parentProjectPath string,
 	tempID string,
 	autoName bool,
@@ -10120,6 +11362,9 @@ func (h *Home) createSessionInGroupWithWorktreeAndOptions(
 			inst.StartupQuery = claudeStartQuery
 		}
 
+		// Delivered by deliverInitialPrompts once the launch animation ends.
+		inst.InitialPrompt = initialPrompt
+
 		// Apply sandbox config.
 		if sandboxEnabled {
 			inst.Sandbox = session.NewSandboxConfig("")
@@ -10215,6 +11460,15 @@ func (h *Home) createSessionInGroupWithWorktreeAndOptions(
 			inst.SetParentWithPath(parentSessionID, parentProjectPath)
 		}
 
+		// Template MCPs go into the project-local config before Start so the
+		// tool picks them up on its first launch. Best-effort: an unknown MCP
+		// name must not block the session itself.
+		if len(mcpNames) > 0 {
+			if err := session.WriteLocalMCPConfigForTool(inst.Tool, inst.ProjectPath, mcpNames); err != nil {
+				uiLog.Warn("template_mcp_write_failed", slog.String("error", err.Error()))
+			}
+		}
+
 		uiLog.Info("session_create_starting",
 			slog.String("tool", inst.Tool),
 			slog.String("path", inst.ProjectPath),
@@ -10434,6 +11688,30 @@ func (h *Home) quickForkSession(source *session.Instance) tea.Cmd {
 	return result.cmd
 }
 
+// cloneSession starts a copy of source's setup — path, tool, options, MCPs
+// and group — with a fresh conversation. Unlike a fork nothing of the
+// source chat carries over, so it suits a parallel attempt at the same task.
+func (h *Home) cloneSession(source *session.Instance) tea.Cmd {
+	if source == nil {
+		return nil
+	}
+	h.instancesMu.RLock()
+	title := ensureUniqueSessionTitle(source.Title+" (clone)", h.instances)
+	h.instancesMu.RUnlock()
+
+	return func() tea.Msg {
+		inst := source.Clone(title, "")
+		uiLog.Info("session_clone_starting",
+			slog.String("source", source.ID),
+			slog.String("tool", inst.Tool),
+		)
+		if err := inst.Start(); err != nil {
+			return sessionCreatedMsg{err: fmt.Errorf("failed to start clone: %w", err)}
+		}
+		return sessionCreatedMsg{instance: inst}
+	}
+}
+
 // quickCreateSession creates a session instantly with auto-generated name and smart defaults.
 // When the cursor is on a session, it inherits that session's path and tool settings
 // (duplicate-like behavior per community feedback). When on a group header, it uses
@@ -10545,6 +11823,8 @@ func (h *Home) quickCreateSession() tea.Cmd {
 		"",         // no claude startup query (recent-session path)
 		"",         // no explicit model override
 		false, nil, // no multi-repo
+		nil,    // no template MCPs
+		"",     // no initial prompt
 		"", "", // no parent
 		"",   // no placeholder
 		true, // quick-create → auto-named handle
@@ -10600,6 +11880,154 @@ func (h *Home) handleZoxidePickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// handleProfilePickerKey switches profiles by quitting cleanly and leaving
+// the chosen profile in pendingProfile for main to relaunch on. The process
+// is single-profile (one Storage, one state.db, one primary election), so a
+// fresh start is what keeps the two profiles' state apart. The MCP pool is
+// left running for the next deck to reconnect to.
+func (h *Home) handleProfilePickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		h.profilePicker.Hide()
+		return h, nil
+	case "enter":
+		selected := h.profilePicker.Selected()
+		h.profilePicker.Hide()
+		if selected == "" || selected == h.profilePicker.Current() {
+			return h, nil
+		}
+		if id := h.profilePicker.MoveSessionID(); id != "" {
+			return h, h.moveSessionToProfile(id, selected)
+		}
+		h.pendingProfile = selected
+		h.isQuitting = true
+		return h, h.performQuit(false)
+	default:
+		h.profilePicker.Update(msg)
+		return h, nil
+	}
+}
+
+// handleCommandPaletteKey runs the highlighted palette entry. Actions are
+// dispatched as a press of their bound key, so they behave exactly as from
+// the keyboard (including the selection they act on).
+func (h *Home) handleCommandPaletteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", h.actionKey(hotkeyCommandPalette):
+		h.commandPalette.Hide()
+		return h, nil
+	case "enter":
+		selected := h.commandPalette.Selected()
+		h.commandPalette.Hide()
+		if selected == nil {
+			return h, nil
+		}
+		if selected.groupPath != "" {
+			h.jumpToGroup(selected.groupPath)
+			return h, h.markNavigationAndFetchPreview()
+		}
+		keyMsg, ok := keyMsgFromBinding(selected.key)
+		if !ok {
+			h.setError(fmt.Errorf("cannot run %q: unrecognized key %q", selected.label, selected.key))
+			return h, nil
+		}
+		return h.handleMainKey(keyMsg)
+	default:
+		_, cmd := h.commandPalette.Update(msg)
+		return h, cmd
+	}
+}
+
+// handleTrashDialogKey restores or purges the highlighted trash entry. A
+// restore writes the row straight back to state.db, so the session list is
+// reloaded from storage the same way ctrl+r does.
+func (h *Home) handleTrashDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		h.trashDialog.Hide()
+		return h, nil
+	case "enter", "r":
+		entry := h.trashDialog.Selected()
+		if entry == nil {
+			return h, nil
+		}
+		row, err := h.storage.RestoreFromTrash(entry.ID)
+		if row == nil {
+			h.trashDialog.SetError(err)
+			return h, nil
+		}
+		if err != nil {
+			uiLog.Warn("trash_restore_cleanup_failed", slog.String("id", entry.ID), slog.String("error", err.Error()))
+		}
+		h.trashDialog.Remove(entry.ID)
+		h.trashDialog.Hide()
+		h.setError(fmt.Errorf("restored '%s' (stopped; start it to resume)", row.Title))
+		state := h.preserveState()
+		return h, func() tea.Msg {
+			instances, groups, err := h.storage.LoadWithGroups()
+			return loadSessionsMsg{
+				instances:    instances,
+				groups:       groups,
+				err:          err,
+				restoreState: &state,
+			}
+		}
+	case "x", "d":
+		entry := h.trashDialog.Selected()
+		if entry == nil {
+			return h, nil
+		}
+		if _, err := h.storage.PurgeTrash(entry.ID); err != nil {
+			h.trashDialog.SetError(err)
+			return h, nil
+		}
+		h.trashDialog.SetError(nil)
+		h.trashDialog.Remove(entry.ID)
+		return h, nil
+	default:
+		h.trashDialog.Update(msg)
+		return h, nil
+	}
+}
+
+// sessionMovedToProfileMsg reports the outcome of moveSessionToProfile.
+type sessionMovedToProfileMsg struct {
+	sessionID string
+	title     string
+	profile   string
+	err       error
+}
+
+// moveSessionToProfile transfers a session's row to profile's state.db. A
+// running session is moved too (the migration's Force): its tmux session
+// keeps running and is re-pointed at the new profile. The in-memory removal
+// happens when the result arrives, in Update.
+func (h *Home) moveSessionToProfile(id, profile string) tea.Cmd {
+	inst := h.getInstanceByID(id)
+	if inst == nil {
+		return nil
+	}
+	source := h.profile
+	title := inst.Title
+	return func() tea.Msg {
+		_, err := session.MigrateSessionsToProfile(source, profile, []string{id},
+			session.ProfileMigrateOptions{Force: true})
+		if err == nil {
+			if rerr := inst.RetargetProfileEnv(profile); rerr != nil {
+				uiLog.Warn("move_profile_retarget_err", slog.String("id", id), slog.String("err", rerr.Error()))
+			}
+		}
+		return sessionMovedToProfileMsg{sessionID: id, title: title, profile: profile, err: err}
+	}
+}
+
+// PendingProfileSwitch returns the profile picked in the profile switcher,
+// or "" when the deck quit normally. main relaunches on it after the TUI
+// exits.
+func (h *Home) PendingProfileSwitch() string {
+	return h.pendingProfile
+}
+
 // quickCreateSessionAt creates a session rooted at the given path with an
 // auto-generated name and the user's configured default tool, bypassing
 // cursor-context tool inheritance so the zoxide flow always lands on the
@@ -10628,6 +12056,8 @@ func (h *Home) quickCreateSessionAt(projectPath string) tea.Cmd {
 		"",  // no claude startup query
 		"",  // no explicit model override
 		false, nil,
+		nil,
+		"", // no initial prompt
 		"", "",
 		"",
 		true, // quick-create → auto-named handle
@@ -10704,13 +12134,38 @@ func (h *Home) forkSessionWithDialog(source *session.Instance) tea.Cmd {
 	if source == nil {
 		return nil
 	}
-	// Pre-populate dialog with source session info
-	conductors := h.activeConductorSessions()
-	suggestedParentID := h.suggestConductorParent()
-	h.forkDialog.ShowWithParentSandboxed(source.Title, source.ProjectPath, source.GroupPath, conductors, suggestedParentID, source.IsSandboxed())
-	return nil
+	// Pre-populate dialog with source session info
+	conductors := h.activeConductorSessions()
+	suggestedParentID := h.suggestConductorParent()
+	h.forkDialog.ShowWithParentSandboxed(source.Title, source.ProjectPath, source.GroupPath, conductors, suggestedParentID, source.IsSandboxed())
+	if !session.IsClaudeCompatible(source.Tool) {
+		return nil
+	}
+	// Offer recent user turns as fork points ("branch from before the agent
+	// went off the rails"). A missing or unreadable transcript just leaves
+	// the dialog forking from the tip.
+	sourceID := source.ID
+	return func() tea.Msg {
+		points, err := source.ClaudeForkPoints(maxForkPoints)
+		if err != nil {
+			uiLog.Debug("fork_points_unavailable", slog.String("id", sourceID), slog.String("error", err.Error()))
+			return nil
+		}
+		return forkPointsMsg{sourceID: sourceID, points: points}
+	}
+}
+
+// loadConversationCmd parses inst's conversation off the UI thread.
+func loadConversationCmd(inst *session.Instance) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := inst.LoadConversation()
+		return conversationLoadedMsg{sessionID: inst.ID, entries: entries, err: err}
+	}
 }
 
+// maxForkPoints caps how many recent user turns the fork dialog lists.
+const maxForkPoints = 8
+
 type forkWithStateWorktreeDeps struct {
 	statPath                  func(string) (os.FileInfo, error)
 	mkdirAll                  func(string, os.FileMode) error
@@ -11281,6 +12736,7 @@ type sessionClosedMsg struct {
 type sessionArchivedMsg struct {
 	sessionID string
 	killErr   error
+	auto      bool // archived by the [archive] idle_after sweep
 }
 
 type sessionUnarchivedMsg struct {
@@ -11294,12 +12750,14 @@ type sessionRestoredMsg struct {
 	warning  string
 }
 
-// deleteSession deletes a session
-func (h *Home) deleteSession(inst *session.Instance) tea.Cmd {
+// deleteSession deletes a session. cleanup decides whether a worktree session's
+// worktree directory (and merged branch) goes with it.
+func (h *Home) deleteSession(inst *session.Instance, cleanup session.WorktreeCleanup) tea.Cmd {
 	id := inst.ID
 	isWorktree := inst.IsWorktree()
 	worktreePath := inst.WorktreePath
 	worktreeRepoRoot := inst.WorktreeRepoRoot
+	worktreeBranch := inst.WorktreeBranch
 	isMultiRepo := inst.IsMultiRepo()
 	multiRepoTempDir := inst.MultiRepoTempDir
 	multiRepoWorktrees := inst.MultiRepoWorktrees
@@ -11329,8 +12787,13 @@ func (h *Home) deleteSession(inst *session.Instance) tea.Cmd {
 			// is never os.RemoveAll'd. Only genuine agent-deck-created linked
 			// worktrees are removed; a reused repo is left intact and merely
 			// dropped from the registry.
-			snap := &session.Instance{ID: id, WorktreePath: worktreePath, WorktreeRepoRoot: worktreeRepoRoot}
-			switch removed, err := session.RemoveSessionWorktree(snap); {
+			snap := &session.Instance{ID: id, WorktreePath: worktreePath, WorktreeRepoRoot: worktreeRepoRoot, WorktreeBranch: worktreeBranch}
+			switch removed, _, err := session.CleanupSessionWorktree(snap, cleanup); {
+			case cleanup == session.WorktreeCleanupKeep:
+				uiLog.Info("worktree_remove_skipped", slog.String("path", worktreePath), slog.String("reason", "kept by user choice"))
+			case err != nil && removed:
+				// Directory is gone; only the safe branch delete refused (unmerged work).
+				uiLog.Info("worktree_branch_kept", slog.String("branch", worktreeBranch), slog.String("err", err.Error()))
 			case err != nil:
 				uiLog.Warn("worktree_remove_err", slog.String("path", worktreePath), slog.String("err", err.Error()))
 			case !removed:
@@ -11424,6 +12887,30 @@ func (h *Home) persistArchived(inst *session.Instance) error {
 	return db.SetArchived(inst.ID, inst.ArchivedAt)
 }
 
+// persistTitle writes a renamed instance's title and title lock with a
+// targeted single-row UPDATE, bypassing saveInstances() for the same reason as
+// persistArchived: a rename skipped by the external-change guard would only
+// survive in memory until the next reload.
+func (h *Home) persistTitle(inst *session.Instance) error {
+	if h.storage == nil {
+		return nil
+	}
+	return h.storage.WriteTitle(inst.ID, inst.Title, inst.TitleLocked)
+}
+
+// persistLastAccessed writes the instance's last-accessed time with a targeted
+// UPDATE so recency-sorted path suggestions see it without a full save.
+// Failures are logged only; the next full save carries the value anyway.
+func (h *Home) persistLastAccessed(inst *session.Instance) {
+	if h.storage == nil {
+		return
+	}
+	if err := h.storage.WriteLastAccessed(inst.ID, inst.LastAccessedAt); err != nil {
+		uiLog.Warn("last_accessed_persist_failed",
+			slog.String("id", inst.ID), slog.String("error", err.Error()))
+	}
+}
+
 // archiveSession stops a session and marks it archived.
 func (h *Home) archiveSession(inst *session.Instance) tea.Cmd {
 	// Snapshot the live Claude task description on the UI goroutine before the
@@ -11497,7 +12984,8 @@ type mcpRestartedMsg struct {
 }
 
 // restartSession restarts a dead/errored session by creating a new tmux session.
-func (h *Home) restartSession(inst *session.Instance) tea.Cmd {
+// reason is recorded in the session's restart history.
+func (h *Home) restartSession(inst *session.Instance, reason session.RestartReason) tea.Cmd {
 	id := inst.ID
 	mcpUILog.Debug(
 		"restart_session_called",
@@ -11519,7 +13007,7 @@ func (h *Home) restartSession(inst *session.Instance) tea.Cmd {
 			return sessionRestartedMsg{sessionID: id, err: err}
 		}
 
-		err := current.Restart()
+		err := current.RestartWithReason(reason)
 		mcpUILog.Debug("restart_session_result", slog.String("id", id), slog.Any("error", err))
 		return sessionRestartedMsg{
 			sessionID: id,
@@ -11673,8 +13161,16 @@ func (h *Home) restartRemoteSession(remoteName, sessionID, title string) tea.Cmd
 	}
 }
 
-// attachSession attaches to a session using custom PTY with Ctrl+Q detection
+// attachSession attaches to a session in the configured [attach] mode.
 func (h *Home) attachSession(inst *session.Instance) tea.Cmd {
+	return h.attachSessionAs(inst, session.GetAttachSettings().GetMode())
+}
+
+// attachSessionAs attaches to a session. In "exec" mode (and whenever
+// agent-deck is not inside tmux) it takes over the terminal using the custom
+// PTY with Ctrl+Q detection; "window" and "pane" open the session beside the
+// dashboard in the surrounding tmux session instead and return nil.
+func (h *Home) attachSessionAs(inst *session.Instance, mode string) tea.Cmd {
 	tmuxSess := inst.GetTmuxSession()
 	if tmuxSess == nil {
 		return nil
@@ -11729,6 +13225,17 @@ func (h *Home) attachSession(inst *session.Instance) tea.Cmd {
 		statusLog.Debug("acknowledged_on_attach", slog.String("title", inst.Title))
 	}
 
+	if mode != session.AttachModeExec && terminal.InsideTmux() {
+		req := terminal.AttachRequest{Name: tmuxSess.Name, SocketName: tmuxSess.SocketName}
+		if err := h.openInSurroundingTmux(req, mode, inst.Title); err != nil {
+			h.setError(fmt.Errorf("open in tmux %s: %w", mode, err))
+			return nil
+		}
+		// No detach comes back to us, so record the access now.
+		h.persistLastAccessed(inst)
+		return nil
+	}
+
 	// Use tea.Exec with a custom command that runs our Attach method
 	// On return, immediately update all session statuses (don't reload from storage
 	// which would lose the tmux session state)
@@ -11747,6 +13254,7 @@ func (h *Home) attachSession(inst *session.Instance) tea.Cmd {
 
 		// Update last accessed time to detach time (more accurate than attach time)
 		inst.MarkAccessed()
+		h.persistLastAccessed(inst)
 
 		// NOTE: We don't acknowledge on detach anymore.
 		// Acknowledgment happens on ATTACH (only if session was waiting/yellow).
@@ -12007,6 +13515,73 @@ func (r remoteAttachCmd) SetStdin(reader io.Reader)  {}
 func (r remoteAttachCmd) SetStdout(writer io.Writer) {}
 func (r remoteAttachCmd) SetStderr(writer io.Writer) {}
 
+// diagnoseTmuxCmd compares the loaded sessions with the agentdeck_* tmux
+// sessions in the background (the same check as `agent-deck doctor`) and
+// reports the repairable issues.
+func (h *Home) diagnoseTmuxCmd() tea.Cmd {
+	h.instancesMu.RLock()
+	instances := make([]*session.Instance, len(h.instances))
+	copy(instances, h.instances)
+	h.instancesMu.RUnlock()
+	profile := h.profile
+	if h.storage != nil {
+		profile = h.storage.Profile()
+	}
+	return func() tea.Msg {
+		live, err := tmux.ListAgentDeckSessionsWithIdentity()
+		if err != nil {
+			uiLog.Debug("tmux_doctor_list_failed", slog.String("error", err.Error()))
+			return nil
+		}
+		var repairable []session.TmuxIssue
+		for _, issue := range session.DiagnoseTmuxSessions(instances, live, profile) {
+			if issue.Repairable() {
+				repairable = append(repairable, issue)
+			}
+		}
+		return tmuxDoctorMsg{issues: repairable}
+	}
+}
+
+// confirmTmuxDoctor handles the "yes" action for ConfirmTmuxDoctor: repairs
+// each issue, adds imported orphans to the tree, and saves.
+func (h *Home) confirmTmuxDoctor() tea.Cmd {
+	issues := h.confirmDialog.GetTmuxIssues()
+	h.confirmDialog.Hide()
+
+	repaired := 0
+	var errs []error
+	var imported []*session.Instance
+	for _, issue := range issues {
+		inst, err := session.RepairTmuxIssue(issue)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		repaired++
+		if inst != nil {
+			imported = append(imported, inst)
+		}
+	}
+
+	h.instancesMu.Lock()
+	h.instances = append(h.instances, imported...)
+	for _, inst := range imported {
+		h.instanceByID[inst.ID] = inst
+	}
+	h.instancesMu.Unlock()
+	for _, inst := range imported {
+		h.groupTree.AddSession(inst)
+	}
+	h.cachedStatusCounts.valid.Store(false)
+	h.rebuildFlatItems()
+	h.search.SetItems(h.instances)
+	h.forceSaveInstances()
+
+	result := tmuxDoctorRepairedMsg{repaired: repaired, err: errors.Join(errs...)}
+	return func() tea.Msg { return result }
+}
+
 // importSessions imports existing tmux sessions
 func (h *Home) importSessions() tea.Msg {
 	discovered, err := session.DiscoverExistingTmuxSessions(h.instances)
@@ -12052,6 +13627,9 @@ func (h *Home) countSessionStatuses() (running, waiting, idle, stopped, errored
 		snapshot = h.getSessionRenderSnapshot()
 	}
 	for _, state := range snapshot {
+		if state.archived {
+			continue
+		}
 		switch state.status {
 		case session.StatusRunning:
 			running++
@@ -12245,6 +13823,10 @@ func (h *Home) renderFilterBar() string {
 		}
 	}
 
+	if h.tagFilter != "" {
+		pills = append(pills, activePillStyle.Render(FilterKeyTag+" "+h.tagFilter))
+	}
+
 	hint := h.renderFilterBarHint()
 
 	// Join pills with spaces (leading space replaces Padding)
@@ -12262,6 +13844,11 @@ func (h *Home) updateSizes() {
 	h.groupDialog.SetSize(h.width, h.height)
 	h.confirmDialog.SetSize(h.width, h.height)
 	h.geminiModelDialog.SetSize(h.width, h.height)
+	h.claudeModelDialog.SetSize(h.width, h.height)
+	h.claudePermDialog.SetSize(h.width, h.height)
+	h.conversationViewer.SetSize(h.width, h.height)
+	h.fileRefDialog.SetSize(h.width, h.height)
+	h.snapshotDialog.SetSize(h.width, h.height)
 	if h.sessionSwitcher != nil {
 		// The switcher is a centered full-screen overlay; keep it sized so a
 		// resize while it is open (notably from the overview, where it can stay
@@ -12357,6 +13944,12 @@ func (h *Home) View() string {
 	if h.confirmDialog.IsVisible() {
 		return h.confirmDialog.View()
 	}
+	if h.mcpRegistryDialog.IsVisible() {
+		return h.mcpRegistryDialog.View()
+	}
+	if h.mcpLogDialog.IsVisible() {
+		return h.mcpLogDialog.View()
+	}
 	if h.mcpDialog.IsVisible() {
 		return h.mcpDialog.View()
 	}
@@ -12375,6 +13968,12 @@ func (h *Home) View() string {
 	if h.geminiModelDialog.IsVisible() {
 		return h.geminiModelDialog.View()
 	}
+	if h.claudeModelDialog.IsVisible() {
+		return h.claudeModelDialog.View()
+	}
+	if h.claudePermDialog.IsVisible() {
+		return h.claudePermDialog.View()
+	}
 	if h.sessionSwitcher.IsVisible() {
 		return h.sessionSwitcher.View()
 	}
@@ -12384,6 +13983,12 @@ func (h *Home) View() string {
 	if h.codeBlockDialog.IsVisible() {
 		return h.codeBlockDialog.View()
 	}
+	if h.fileRefDialog.IsVisible() {
+		return h.fileRefDialog.View()
+	}
+	if h.snapshotDialog.IsVisible() {
+		return h.snapshotDialog.View()
+	}
 	if h.worktreeFinishDialog.IsVisible() {
 		return h.worktreeFinishDialog.View()
 	}
@@ -12393,9 +13998,33 @@ func (h *Home) View() string {
 	if h.zoxidePicker.IsVisible() {
 		return h.zoxidePicker.View()
 	}
+	if h.profilePicker.IsVisible() {
+		return h.profilePicker.View()
+	}
+	if h.trashDialog.IsVisible() {
+		return h.trashDialog.View()
+	}
+	if h.mcpPoolDialog.IsVisible() {
+		return h.mcpPoolDialog.View()
+	}
+	if h.eventLogDialog.IsVisible() {
+		return h.eventLogDialog.View()
+	}
+	if h.conversationViewer.IsVisible() {
+		return h.conversationViewer.View()
+	}
+	if h.commandPalette.IsVisible() {
+		return h.commandPalette.View()
+	}
 	if h.showCostDashboard {
 		return h.costDashboard.View()
 	}
+	if h.showAnalyticsDash {
+		return h.analyticsDash.View()
+	}
+	if h.showDiffViewer {
+		return h.diffViewer.View()
+	}
 
 	// Reuse viewBuilder to reduce allocations (reset and pre-allocate)
 	h.viewBuilder.Reset()
@@ -12499,6 +14128,9 @@ func (h *Home) View() string {
 		costStyle := lipgloss.NewStyle().Foreground(ColorCyan)
 		stats += statsSep + costStyle.Render(rendered)
 	}
+	if budget := h.renderBudgetAlertSegment(); budget != "" {
+		stats += statsSep + budget
+	}
 
 	// System stats segment (CPU, RAM, etc.)
 	if h.sysStatsCollector != nil {
@@ -12510,6 +14142,13 @@ func (h *Home) View() string {
 		}
 	}
 
+	// Do-not-disturb segment (see session/dnd.go). Cached by the background
+	// notification sync so View() never touches the state file.
+	if until := h.dndUntil.Load(); until > 0 && time.Now().UnixNano() < until {
+		dndStyle := lipgloss.NewStyle().Foreground(ColorPurple)
+		stats += statsSep + dndStyle.Render("🔕 DND until "+time.Unix(0, until).Format("15:04"))
+	}
+
 	// Version badge (right-aligned, subtle inline style - no border to keep single line)
 	versionStyle := lipgloss.NewStyle().
 		Foreground(ColorComment).
@@ -12609,6 +14248,8 @@ func (h *Home) View() string {
 	var helpBar string
 	if h.insertMode {
 		helpBar = h.renderInsertModeBar()
+	} else if h.hasMarkedSessions() {
+		helpBar = h.renderMarkedBar()
 	} else {
 		helpBar = h.renderHelpBar()
 	}
@@ -12639,6 +14280,12 @@ func (h *Home) View() string {
 		b.WriteString(warnStyle.Render(h.storageWarning))
 	}
 
+	if h.safeMode {
+		warnStyle := lipgloss.NewStyle().Foreground(ColorYellow)
+		b.WriteString("\n")
+		b.WriteString(warnStyle.Render(safeModeBanner))
+	}
+
 	if h.watcherWarning != "" {
 		warnStyle := lipgloss.NewStyle().Foreground(ColorYellow)
 		b.WriteString("\n")
@@ -12669,6 +14316,9 @@ func (h *Home) View() string {
 	if h.promptInputDialog.IsVisible() {
 		rendered = h.promptInputDialog.View(rendered)
 	}
+	if h.pasteFileDialog.IsVisible() {
+		rendered = h.pasteFileDialog.View(rendered)
+	}
 	return rendered
 }
 
@@ -13326,6 +14976,21 @@ func renderLaunchModelInfoLines(b *strings.Builder, inst *session.Instance) {
 	b.WriteString("\n")
 }
 
+// renderResumeHintLine renders the "Resume: R restart with session resume"
+// hint for tools whose restart picks the conversation back up.
+func (h *Home) renderResumeHintLine(b *strings.Builder) {
+	restartKey := h.actionKey(hotkeyRestart)
+	if restartKey == "" {
+		return
+	}
+	hintStyle := lipgloss.NewStyle().Foreground(ColorText).Italic(true)
+	keyStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+	b.WriteString(hintStyle.Render("Resume:  "))
+	b.WriteString(keyStyle.Render(restartKey))
+	b.WriteString(hintStyle.Render(" restart with session resume"))
+	b.WriteString("\n")
+}
+
 // renderForkHintLine renders the fork keyboard hint line.
 func (h *Home) renderForkHintLine(b *strings.Builder) {
 	quickForkKey := h.actionKey(hotkeyQuickFork)
@@ -13351,9 +15016,10 @@ func (h *Home) renderForkHintLine(b *strings.Builder) {
 	b.WriteString("\n")
 }
 
-// renderSimpleMCPLine renders MCPs without sync status (for Gemini and other tools).
+// renderSimpleMCPLine renders MCPs without sync status (for Gemini and other tools),
+// each followed by its liveness mark from health when probed.
 // Width-aware truncation shows "(+N more)" when MCPs don't fit.
-func renderSimpleMCPLine(b *strings.Builder, mcpInfo *session.MCPInfo, width int) {
+func renderSimpleMCPLine(b *strings.Builder, mcpInfo *session.MCPInfo, health map[string]session.MCPHealth, width int) {
 	if mcpInfo == nil || !mcpInfo.HasAny() {
 		return
 	}
@@ -13363,13 +15029,13 @@ func renderSimpleMCPLine(b *strings.Builder, mcpInfo *session.MCPInfo, width int
 
 	var mcpParts []string
 	for _, name := range mcpInfo.Global {
-		mcpParts = append(mcpParts, valueStyle.Render(name+" (g)"))
+		mcpParts = append(mcpParts, valueStyle.Render(name+" (g)")+mcpHealthMark(health, name))
 	}
 	for _, name := range mcpInfo.Project {
-		mcpParts = append(mcpParts, valueStyle.Render(name+" (p)"))
+		mcpParts = append(mcpParts, valueStyle.Render(name+" (p)")+mcpHealthMark(health, name))
 	}
 	for _, mcp := range mcpInfo.LocalMCPs {
-		mcpParts = append(mcpParts, valueStyle.Render(mcp.Name+" (l)"))
+		mcpParts = append(mcpParts, valueStyle.Render(mcp.Name+" (l)")+mcpHealthMark(health, mcp.Name))
 	}
 
 	if len(mcpParts) == 0 {
@@ -13652,13 +15318,18 @@ func (h *Home) renderHelpBarCompact() string {
 		}
 	} else if h.cursor >= 0 && h.cursor < len(h.flatItems) {
 		item := h.flatItems[h.cursor]
+		attachKey := h.attachKeyLabel("⏎")
 		if item.Type == session.ItemTypeGroup {
-			contextHints = append(contextHints, h.helpKeyShort("⏎", "Toggle"))
+			if attachKey != "" {
+				contextHints = append(contextHints, h.helpKeyShort(attachKey, "Toggle"))
+			}
 			if newQuickKey != "" {
 				contextHints = append(contextHints, h.helpKeyShort(newQuickKey, "New"))
 			}
 		} else {
-			contextHints = append(contextHints, h.helpKeyShort("⏎", "Attach"))
+			if attachKey != "" {
+				contextHints = append(contextHints, h.helpKeyShort(attachKey, "Attach"))
+			}
 			if newQuickKey != "" {
 				contextHints = append(contextHints, h.helpKeyShort(newQuickKey, "New"))
 			}
@@ -13832,7 +15503,9 @@ func (h *Home) renderHelpBarFull() string {
 			}
 		} else {
 			contextTitle = "Session"
-			primaryHints = append(primaryHints, h.helpKey("Enter", "Attach"))
+			if attachKey := h.attachKeyLabel("Enter"); attachKey != "" {
+				primaryHints = append(primaryHints, h.helpKey(attachKey, "Attach"))
+			}
 			if newQuickKey != "" {
 				primaryHints = append(primaryHints, h.helpKey(newQuickKey, "New/Quick"))
 			}
@@ -14118,7 +15791,7 @@ func (h *Home) curatedContextHints(item session.Item) []footerHint {
 		} else {
 			// Live/attachable session: attach first, then restart, then the
 			// most relevant follow-up (fork while forkable, else new).
-			add("⏎", "attach")
+			add(h.attachKeyLabel("⏎"), "attach")
 			add(h.actionKey(hotkeyRestart), "restart")
 			if s.CanFork() {
 				add(h.actionKey(hotkeyQuickFork), "fork")
@@ -14129,13 +15802,13 @@ func (h *Home) curatedContextHints(item session.Item) []footerHint {
 
 	case session.ItemTypeWindow:
 		// A tmux window row attaches just like its session.
-		add("⏎", "attach")
+		add(h.attachKeyLabel("⏎"), "attach")
 
 	case session.ItemTypeRemoteSession:
 		// A remote session row attaches over SSH just like a local session;
 		// without this case the curated footer dropped the attach hint for
 		// remote rows (PR #1289 review nit 1).
-		add("⏎", "attach")
+		add(h.attachKeyLabel("⏎"), "attach")
 	}
 
 	return hints
@@ -14542,6 +16215,12 @@ func (h *Home) renderGroupItem(
 	if stats.waiting > 0 {
 		statusStr += " " + GroupStatusWaiting.Render(fmt.Sprintf("◐ %d", stats.waiting))
 	}
+	if group.NotificationsMuted {
+		statusStr += countStyle.Render(" 🔕")
+	}
+	if group.Archived {
+		statusStr += countStyle.Render(" [archived]")
+	}
 
 	// Build the row: [hotkey gutter][indent][expand] [name](count) [status]
 	row := fmt.Sprintf(
@@ -14718,6 +16397,9 @@ func (h *Home) renderSessionItem(
 	// the stopped glyph for archived sessions whose snapshot still carries a
 	// stale live status.
 	statusIcon, statusStyle := rowStatusGlyph(instStatus, instSubstate, inst.IsArchived())
+	if glyph := attentionGlyph(instStatus, instState.attention); glyph != "" && !inst.IsArchived() {
+		statusIcon = glyph
+	}
 
 	status := statusStyle.Render(statusIcon)
 
@@ -14757,10 +16439,16 @@ func (h *Home) renderSessionItem(
 	// Claude=orange, Gemini=purple, Codex=cyan, Aider=red
 	toolStyle := GetToolStyle(instTool)
 
-	// Selection indicator
+	// Selection indicator (▶ cursor, ✓ marked for a bulk action)
 	selectionPrefix := " "
+	if h.isSessionMarked(inst.ID) {
+		selectionPrefix = lipgloss.NewStyle().Foreground(ColorGreen).Bold(true).Render("✓")
+	}
 	if selected {
 		selectionPrefix = SessionSelectionPrefix.Render("▶")
+		if h.isSessionMarked(inst.ID) {
+			selectionPrefix = SessionSelectionPrefix.Render("✓")
+		}
 		titleStyle = SessionTitleSelStyle
 		toolStyle = SessionStatusSelStyle
 		statusStyle = SessionStatusSelStyle
@@ -14779,6 +16467,66 @@ func (h *Home) renderSessionItem(
 
 	tool := toolStyle.Render(" " + instTool)
 
+	// Waiting reason ("needs permission", "plan approval", ...) right after
+	// the title, so a blocked row reads "◐ api (needs permission)".
+	reasonBadge := ""
+	if instStatus == session.StatusWaiting && !inst.IsArchived() {
+		if reason := instState.attention.Reason(); reason != "" {
+			rStyle := lipgloss.NewStyle().Foreground(ColorYellow)
+			if selected {
+				rStyle = SessionStatusSelStyle
+			}
+			reasonBadge = rStyle.Render(" (" + reason + ")")
+		}
+	}
+	// A running session flagged by the [watchdog] reads "● api (stuck)".
+	if instStatus == session.StatusRunning && instState.stuck {
+		sStyle := lipgloss.NewStyle().Foreground(ColorRed).Bold(true)
+		if selected {
+			sStyle = SessionStatusSelStyle
+		}
+		reasonBadge = sStyle.Render(" (stuck)")
+	}
+
+	// How long the session has been waiting ("◐ api 12m"), bold red past
+	// [notifications] escalate_after_minutes so forgotten prompts stand out.
+	waitBadge := ""
+	if instStatus == session.StatusWaiting && !inst.IsArchived() && !instState.waitStart.IsZero() {
+		waited := time.Since(instState.waitStart)
+		if text := formatWaitDuration(waited); text != "" {
+			wStyle := DimStyle
+			if h.waitTracker.escalates(waited) {
+				wStyle = lipgloss.NewStyle().Foreground(ColorRed).Bold(true)
+			}
+			if selected {
+				wStyle = SessionStatusSelStyle
+			}
+			waitBadge = wStyle.Render(" " + text)
+		}
+	}
+
+	// Memory or spend column while sorting by resources or cost, so the order
+	// explains itself: "● api claude 1.4G" / "● api claude $3.20".
+	usageBadge := ""
+	usageText := ""
+	switch session.GroupSortMode() {
+	case "resources":
+		if u := inst.CachedResourceUsage(); u.Known() {
+			usageText = sysinfo.FormatBytes(u.MemBytes)
+		}
+	case "cost":
+		if c := inst.CachedCost(); c > 0 {
+			usageText = costs.FormatUSD(c)
+		}
+	}
+	if usageText != "" {
+		uStyle := DimStyle
+		if selected {
+			uStyle = SessionStatusSelStyle
+		}
+		usageBadge = uStyle.Render(" " + usageText)
+	}
+
 	// Supervisor badge for the maestro row.
 	maestroBadge := ""
 	if isMaestro {
@@ -14817,6 +16565,26 @@ func (h *Home) renderSessionItem(
 		yoloBadge = yoloStyle.Render(" [YOLO]")
 	}
 
+	// Model and permission mode badges for Claude sessions
+	modelBadge := ""
+	if session.IsClaudeCompatible(instTool) {
+		if label, color := claudePermissionBadge(claudePermissionMode(inst)); label != "" {
+			permStyle := lipgloss.NewStyle().Foreground(color).Bold(true)
+			if selected {
+				permStyle = SessionStatusSelStyle
+			}
+			modelBadge = permStyle.Render(" [" + label + "]")
+		}
+		if label := claudeModelBadge(inst.GetClaudeOptions()); label != "" {
+			label = cellTruncate(label, 18, "...")
+			modelStyle := lipgloss.NewStyle().Foreground(ColorPurple)
+			if selected {
+				modelStyle = SessionStatusSelStyle
+			}
+			modelBadge += modelStyle.Render(" [" + label + "]")
+		}
+	}
+
 	// Worktree branch badge for sessions running in git worktrees.
 	worktreeBadge := ""
 	if inst.IsWorktree() && inst.WorktreeBranch != "" {
@@ -14831,6 +16599,26 @@ func (h *Home) renderSessionItem(
 		worktreeBadge = wtStyle.Render(" [" + branch + "]")
 	}
 
+	// Git status badge (±changed ↑ahead ↓behind), from the background cache.
+	gitBadge := ""
+	if text := h.gitStatusBadge(inst); text != "" {
+		gStyle := lipgloss.NewStyle().Foreground(ColorYellow)
+		if selected {
+			gStyle = SessionStatusSelStyle
+		}
+		gitBadge = gStyle.Render(" " + text)
+	}
+
+	// Verify badge: last verify run passed/failed, or one in flight.
+	verifyBadge := ""
+	if text, color := h.verifyBadge(inst); text != "" {
+		vStyle := lipgloss.NewStyle().Foreground(color)
+		if selected {
+			vStyle = SessionStatusSelStyle
+		}
+		verifyBadge = vStyle.Render(" " + text)
+	}
+
 	// Sandbox badge for containerized sessions.
 	sandboxBadge := ""
 	if inst.IsSandboxed() {
@@ -14884,6 +16672,18 @@ func (h *Home) renderSessionItem(
 		timestampBadge = tsStyle.Render(" " + formatRelativeTime(ts))
 	}
 
+	// Unseen-output badge: lines produced since the row was last viewed.
+	newOutputBadge := ""
+	if h.highlightNewOutput {
+		if n := h.outputSeen.unseen(inst.ID); n > 0 {
+			nStyle := lipgloss.NewStyle().Foreground(ColorGreen)
+			if selected {
+				nStyle = SessionStatusSelStyle
+			}
+			newOutputBadge = nStyle.Render(" " + formatNewLinesBadge(n))
+		}
+	}
+
 	// Window expand/collapse chevron for sessions with 2+ windows
 	windowChevron := " " // space placeholder to keep status icons aligned
 	if h.sessionHasWindows(item) {
@@ -14922,10 +16722,10 @@ func (h *Home) renderSessionItem(
 		// sync with the row format that follows.
 		reserved := leftGutterWidth + cellWidth(baseIndent) + cellWidth(selectionPrefix) +
 			cellWidth(treeStyle.Render(treeConnector)) + cellWidth(windowChevron) +
-			cellWidth(status) + 1 /* space before title */ + cellWidth(tool) +
-			cellWidth(maestroBadge) + cellWidth(yoloBadge) + cellWidth(worktreeBadge) +
+			cellWidth(status) + 1 /* space before title */ + cellWidth(waitBadge) + cellWidth(reasonBadge) + cellWidth(tool) +
+			cellWidth(usageBadge) + cellWidth(maestroBadge) + cellWidth(yoloBadge) + cellWidth(modelBadge) + cellWidth(worktreeBadge) + cellWidth(gitBadge) + cellWidth(verifyBadge) +
 			cellWidth(sandboxBadge) + cellWidth(multiRepoBadge) + cellWidth(sshBadge) +
-			cellWidth(timestampBadge)
+			cellWidth(newOutputBadge) + cellWidth(timestampBadge)
 		budget := listWidth - reserved - 1 // -1 trailing margin
 		if budget > 0 && cellWidth(displayTitle) > budget {
 			displayTitle = cellTruncate(displayTitle, budget, "…")
@@ -14933,11 +16733,11 @@ func (h *Home) renderSessionItem(
 	}
 	title := titleStyle.Render(displayTitle)
 
-	// Build row: [gutter][baseIndent][selection][tree][chevron][status] [title] [tool] [badges]
+	// Build row: [gutter][baseIndent][selection][tree][chevron][status] [title][wait][reason] [tool][usage] [badges]
 	// The leading gutter (leftGutterWidth) keeps sessions aligned with group
 	// rows, which reserve the same gutter for root hotkey numbers.
 	row := fmt.Sprintf(
-		"%s%s%s%s%s%s %s%s%s%s%s%s%s%s%s",
+		"%s%s%s%s%s%s %s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s",
 		strings.Repeat(" ", leftGutterWidth),
 		baseIndent,
 		selectionPrefix,
@@ -14945,13 +16745,20 @@ func (h *Home) renderSessionItem(
 		windowChevron,
 		status,
 		title,
+		waitBadge,
+		reasonBadge,
 		tool,
+		usageBadge,
 		maestroBadge,
 		yoloBadge,
+		modelBadge,
 		worktreeBadge,
+		gitBadge,
+		verifyBadge,
 		sandboxBadge,
 		multiRepoBadge,
 		sshBadge,
+		newOutputBadge,
 		timestampBadge,
 	)
 
@@ -15761,9 +17568,14 @@ func (h *Home) renderPreviewPane(width, height int) string {
 		statusIcon = "■"
 		statusColor = ColorTextDim
 	}
+	statusText := string(selectedStatus)
+	if attention := selected.CachedAttention(); attention != session.AttentionNone {
+		statusIcon = attentionGlyph(selectedStatus, attention)
+		statusText += " · " + attention.Label()
+	}
 
 	// Header with session name and status
-	statusBadge := lipgloss.NewStyle().Foreground(statusColor).Render(statusIcon + " " + string(selectedStatus))
+	statusBadge := lipgloss.NewStyle().Foreground(statusColor).Render(statusIcon + " " + statusText)
 	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
 	b.WriteString(nameStyle.Render(selected.Title))
 	b.WriteString("  ")
@@ -15782,6 +17594,9 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	if selectedStatus == session.StatusRunning {
 		activityStr = "active now"
 	}
+	if usage := formatResourceUsage(selected.CachedResourceUsage()); usage != "" {
+		activityStr += "  ·  " + usage
+	}
 	b.WriteString(infoStyle.Render("⏱ " + activityStr))
 	b.WriteString("\n")
 
@@ -15798,8 +17613,27 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	b.WriteString(toolBadge)
 	b.WriteString(" ")
 	b.WriteString(groupBadge)
+	if tags := renderSessionTags(selected.Tags); tags != "" {
+		b.WriteString(" ")
+		b.WriteString(tags)
+	}
+	if chain := h.renderSessionChain(selected); chain != "" {
+		b.WriteString(" ")
+		b.WriteString(chain)
+	}
+	restartHistory := selected.GetRestartHistory()
+	if n := len(restartHistory); n > 0 {
+		b.WriteString(" ")
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorOrange).Render(fmt.Sprintf("↻ %d", n)))
+	}
 	b.WriteString("\n")
 
+	// Restart history (debugging flappy sessions / auto-restart policies)
+	b.WriteString(renderRestartHistorySection(restartHistory, width))
+
+	// Stored Claude session ID no longer matches the transcript being written
+	b.WriteString(renderClaudeIDMismatchWarning(selected.ClaudeSessionIDMismatch(), h.actionKey(hotkeyFixSessionID), width))
+
 	// Worktree info section (for sessions running in git worktrees)
 	if selected.IsWorktree() {
 		wtHeader := renderSectionDivider("Worktree", width-4)
@@ -15870,6 +17704,22 @@ func (h *Home) renderPreviewPane(width, height int) string {
 			b.WriteString(wtHintStyle.Render(" merge + cleanup"))
 			b.WriteString("\n")
 		}
+
+		// Diff hint
+		if diffKey := h.actionKey(hotkeyWorktreeDiff); diffKey != "" {
+			b.WriteString(wtHintStyle.Render("Diff:    "))
+			b.WriteString(wtKeyStyle.Render(diffKey))
+			b.WriteString(wtHintStyle.Render(" changes vs base branch"))
+			b.WriteString("\n")
+		}
+
+		// Pull request hint
+		if prKey := h.actionKey(hotkeyCreatePR); prKey != "" {
+			b.WriteString(wtHintStyle.Render("PR:      "))
+			b.WriteString(wtKeyStyle.Render(prKey))
+			b.WriteString(wtHintStyle.Render(" push + open pull request"))
+			b.WriteString("\n")
+		}
 	}
 
 	// Multi-repo info section
@@ -15960,18 +17810,21 @@ func (h *Home) renderPreviewPane(width, height int) string {
 
 			var mcpParts []string
 
-			// Helper to add MCP with appropriate styling
+			// Helper to add MCP with appropriate styling; the ✓/✗ mark is the
+			// last background liveness probe.
+			health := h.mcpHealth.get(selected.ID)
 			addMCP := func(name, source string) {
 				label := name + " (" + source + ")"
+				mark := mcpHealthMark(health, name)
 				if !hasLoadedMCPs {
 					// Old session without LoadedMCPNames - show all as normal (no sync info)
-					mcpParts = append(mcpParts, valueStyle.Render(label))
+					mcpParts = append(mcpParts, valueStyle.Render(label)+mark)
 				} else if loadedSet[name] {
 					// In both loaded and current - active (normal style)
-					mcpParts = append(mcpParts, valueStyle.Render(label))
+					mcpParts = append(mcpParts, valueStyle.Render(label)+mark)
 				} else {
 					// In current but not loaded - pending (needs restart)
-					mcpParts = append(mcpParts, pendingStyle.Render(label+" ⟳"))
+					mcpParts = append(mcpParts, pendingStyle.Render(label+" ⟳")+mark)
 				}
 			}
 
@@ -16116,7 +17969,7 @@ func (h *Home) renderPreviewPane(width, height int) string {
 
 			// MCPs for Gemini (global only)
 			mcpInfo := selected.GetMCPInfo()
-			renderSimpleMCPLine(&b, mcpInfo, width)
+			renderSimpleMCPLine(&b, mcpInfo, h.mcpHealth.get(selected.ID), width)
 		} else {
 			statusStyle := lipgloss.NewStyle().Foreground(ColorText)
 			b.WriteString(labelStyle.Render("Status:  "))
@@ -16140,7 +17993,7 @@ func (h *Home) renderPreviewPane(width, height int) string {
 		renderLaunchModelInfoLines(&b, selected)
 
 		mcpInfo := selected.GetMCPInfo()
-		renderSimpleMCPLine(&b, mcpInfo, width)
+		renderSimpleMCPLine(&b, mcpInfo, h.mcpHealth.get(selected.ID), width)
 	}
 
 	// OpenCode-specific info (session ID)
@@ -16203,8 +18056,9 @@ func (h *Home) renderPreviewPane(width, height int) string {
 		}
 	}
 
-	// Codex-specific info (session ID, detection)
-	if selected.Tool == "codex" {
+	// Codex-specific info (session ID, detection). Codex-compatible custom
+	// tools share the rollout-based resume/fork path, so they render here too.
+	if session.IsCodexCompatible(selected.Tool) {
 		codexHeader := renderSectionDivider("Codex", width-4)
 		b.WriteString(codexHeader)
 		b.WriteString("\n")
@@ -16213,12 +18067,16 @@ func (h *Home) renderPreviewPane(width, height int) string {
 		renderLaunchModelInfoLines(&b, selected)
 		if selected.CodexSessionID != "" {
 			renderDetectedAtLine(&b, selected.CodexDetectedAt)
+			h.renderResumeHintLine(&b)
+		}
+		if selected.CanFork() {
+			h.renderForkHintLine(&b)
 		}
 	}
 
 	// Custom tool info (tools defined in config.toml that aren't built-in)
 	if !session.IsClaudeCompatible(selected.Tool) && selected.Tool != "gemini" && selected.Tool != "opencode" &&
-		selected.Tool != "codex" {
+		!session.IsCodexCompatible(selected.Tool) {
 		if toolDef := session.GetToolDef(selected.Tool); toolDef != nil {
 			toolName := selected.Tool
 			if toolDef.Icon != "" {
@@ -16250,14 +18108,7 @@ func (h *Home) renderPreviewPane(width, height int) string {
 
 			// Resume hint when tool supports restart with session resume
 			if selected.CanRestartGeneric() {
-				if restartKey := h.actionKey(hotkeyRestart); restartKey != "" {
-					hintStyle := lipgloss.NewStyle().Foreground(ColorText).Italic(true)
-					keyStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
-					b.WriteString(hintStyle.Render("Resume:  "))
-					b.WriteString(keyStyle.Render(restartKey))
-					b.WriteString(hintStyle.Render(" restart with session resume"))
-					b.WriteString("\n")
-				}
+				h.renderResumeHintLine(&b)
 			}
 			if selected.CanRestartFresh() {
 				if restartFreshKey := h.actionKey(hotkeyRestartFresh); restartFreshKey != "" {
@@ -16277,7 +18128,7 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	// Check preview settings for what to show
 	config, _ := session.LoadUserConfig()
 	showAnalytics := config != nil && config.GetShowAnalytics() &&
-		(session.IsClaudeCompatible(selected.Tool) || selected.Tool == "gemini")
+		session.ToolAdapterFor(selected.Tool).HasAnalytics()
 	showOutput := config == nil || config.GetShowOutput() // Default to true if config fails
 	showNotes := config != nil && config.GetShowNotes()   // Default to false if config fails
 	notesOutputSplit := 0.33
@@ -16444,6 +18295,13 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	_, isSessionForking := h.forkingSessions[selected.ID]
 	isStartingUp := isSessionLaunching || isSessionResuming || isSessionForking
 
+	// Last verify run, when there was one in this TUI
+	if !isStartingUp {
+		if section := h.renderVerifySection(selected, width); section != "" {
+			b.WriteString(section)
+		}
+	}
+
 	// Analytics panel (for Claude/Gemini sessions with analytics enabled)
 	// Skip showing "Loading analytics..." during startup - let the launch animation take focus
 	if showAnalytics && !isStartingUp {
@@ -16457,6 +18315,7 @@ func (h *Home) renderPreviewPane(width, height int) string {
 			if config != nil {
 				h.analyticsPanel.SetDisplaySettings(config.Preview.GetAnalyticsSettings())
 			}
+			h.analyticsPanel.SetFilesTouched(h.filesTouchedFor(selected), h.filesTouchedExpanded, h.actionKey(hotkeyFilesTouched))
 			h.analyticsPanel.SetSize(width-4, height/2)
 			b.WriteString(h.analyticsPanel.View())
 			b.WriteString("\n")
@@ -16559,38 +18418,11 @@ func (h *Home) renderPreviewPane(width, height int) string {
 				// Strip ANSI for reliable pattern matching
 				plainPreview := ansi.Strip(previewContent)
 
-				if session.IsClaudeCompatible(selected.Tool) || selected.Tool == "gemini" {
-					// Claude/Gemini ready indicators
-					agentReady := strings.Contains(plainPreview, "ctrl+c to interrupt") ||
-						strings.Contains(plainPreview, "No, and tell Claude what to do differently") ||
-						strings.Contains(plainPreview, "\n> ") ||
-						strings.Contains(plainPreview, "> \n") ||
-						strings.Contains(plainPreview, "esc to interrupt") ||
-						strings.Contains(plainPreview, "⠋") || strings.Contains(plainPreview, "⠙") ||
-						strings.Contains(plainPreview, "Thinking") ||
-						strings.Contains(plainPreview, "╭─")
-
-					if selected.Tool == "gemini" {
-						agentReady = agentReady ||
-							strings.Contains(plainPreview, "▸") ||
-							strings.Contains(plainPreview, "gemini>")
-					}
-
-					if !agentReady {
-						if isMcpLoading {
-							showMcpLoadingAnimation = true
-						} else {
-							showLaunchingAnimation = true
-						}
-					}
-				} else {
-					// Non-Claude/Gemini: ready if substantial content
-					if len(strings.TrimSpace(plainPreview)) <= 50 {
-						if isMcpLoading {
-							showMcpLoadingAnimation = true
-						} else {
-							showLaunchingAnimation = true
-						}
+				if !session.PaneShowsReady(session.ToolAdapterFor(selected.Tool), plainPreview) {
+					if isMcpLoading {
+						showMcpLoadingAnimation = true
+					} else {
+						showLaunchingAnimation = true
 					}
 				}
 			}
@@ -16660,13 +18492,27 @@ func (h *Home) renderPreviewPane(width, height int) string {
 			maxLines = 1
 		}
 
+		// Unseen-output gutter: lines at or after newFrom were printed since
+		// the session was last viewed (see output_seen.go). Only the primary
+		// window maps onto the tracked pane line count.
+		newFrom := -1
+		if h.highlightNewOutput && pvKey == selected.ID {
+			if n := h.outputSeen.unseen(selected.ID); n > 0 {
+				newFrom = max(0, len(lines)-n)
+			}
+		}
+
 		// Track if we're truncating from the top (for indicator)
 		truncatedFromTop := len(lines) > maxLines
 		truncatedCount := 0
 		scrolledBelow := 0
 		if truncatedFromTop {
-			// Reserve one line for the "⋮ N more above" indicator
+			// Reserve one line for the "⋮ N more above" indicator, and one
+			// more for the scrollback-mode footer while scrolled up.
 			maxLines--
+			if h.previewScrollOffset > 0 {
+				maxLines--
+			}
 			if maxLines < 1 {
 				maxLines = 1
 			}
@@ -16696,9 +18542,11 @@ func (h *Home) renderPreviewPane(width, height int) string {
 			// Content fits without truncation — offset has no effect, keep state consistent.
 			h.previewScrollOffset = 0
 		}
-		_ = scrolledBelow // reserved for a future "⋮ N below" indicator; offset clamp already prevents stale state
 
 		maxWidth := width - 4
+		if newFrom >= 0 {
+			maxWidth-- // gutter column
+		}
 		if maxWidth < 10 {
 			maxWidth = 10
 		}
@@ -16718,7 +18566,8 @@ func (h *Home) renderPreviewPane(width, height int) string {
 		const maxConsecutiveEmpty = 2 // Allow up to 2 consecutive empty lines
 
 		isLightTheme := GetCurrentTheme() == ThemeLight
-		for _, line := range lines {
+		newGutter := lipgloss.NewStyle().Foreground(ColorGreen).Render("▎")
+		for lineIdx, line := range lines {
 			// Strip dangerous control characters (\r, \b, etc.) but preserve
 			// ANSI escape sequences (ESC = 0x1b) so colors and formatting
 			// from the captured terminal output pass through to display.
@@ -16760,9 +18609,21 @@ func (h *Home) renderPreviewPane(width, height int) string {
 				safeLine = cellTruncate(safeLine, maxWidth-3, "...")
 			}
 
+			if newFrom >= 0 {
+				if truncatedCount+lineIdx >= newFrom {
+					b.WriteString(newGutter)
+				} else {
+					b.WriteString(" ")
+				}
+			}
 			b.WriteString(safeLine)
 			b.WriteString("\n")
 		}
+
+		if scrolledBelow > 0 {
+			b.WriteString(renderPreviewScrollFooter(scrolledBelow, h.actionKey(hotkeyPreviewScrollDown)))
+			b.WriteString("\n")
+		}
 	}
 
 	// CRITICAL: Enforce width constraint on ALL lines to prevent overflow into left panel
@@ -17350,6 +19211,54 @@ func (h *Home) copySessionOutput(inst *session.Instance) tea.Cmd {
 	}
 }
 
+// copySessionPane returns a tea.Cmd that copies the last [preview] copy_lines
+// lines of the session's pane (windowIndex >= 0: that window's pane) to the
+// clipboard, colors stripped and trailing blank lines dropped.
+func (h *Home) copySessionPane(inst *session.Instance, windowIndex int) tea.Cmd {
+	lines := 200
+	if cfg, _ := session.LoadUserConfig(); cfg != nil {
+		lines = cfg.Preview.GetCopyLines()
+	}
+	return func() tea.Msg {
+		tmuxSession := inst.GetTmuxSession()
+		if tmuxSession == nil || !tmuxSession.Exists() {
+			return copyResultMsg{err: fmt.Errorf("session '%s' is not running", inst.Title)}
+		}
+		var raw string
+		var err error
+		if windowIndex >= 0 {
+			raw, err = tmuxSession.CaptureWindowHistory(windowIndex, lines)
+		} else {
+			raw, err = tmuxSession.CaptureHistory(lines)
+		}
+		if err != nil {
+			return copyResultMsg{err: fmt.Errorf("failed to capture pane: %w", err)}
+		}
+		content := tailLines(tmux.StripANSI(raw), lines)
+		if content == "" {
+			return copyResultMsg{err: fmt.Errorf("pane of '%s' is empty", inst.Title)}
+		}
+		result, err := clipboard.Copy(content, tmux.GetTerminalInfo().SupportsOSC52)
+		if err != nil {
+			return copyResultMsg{err: fmt.Errorf("clipboard: %w", err)}
+		}
+		return copyResultMsg{
+			sessionTitle: inst.Title,
+			lineCount:    result.LineCount,
+		}
+	}
+}
+
+// tailLines returns the last n lines of s after dropping trailing blank
+// lines (the unused rows below a pane's cursor).
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, " \t\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
 // sendOutputToSession returns a tea.Cmd that sends the source session's output to the target.
 func (h *Home) sendOutputToSession(source, target *session.Instance) tea.Cmd {
 	return func() tea.Msg {
@@ -17429,13 +19338,43 @@ func (h *Home) handleSessionPickerDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 // excluded for now — see SessionSwitcher.Show and
 // TestSessionSwitcher_RemoteSessionsUnsupported.
 func (h *Home) openSessionSwitcher(fromID string, reattachOnCancel bool) {
+	instances, subtitles := h.switcherSnapshot()
+	h.sessionSwitcher.SetSize(h.width, h.height)
+	if !h.sessionSwitcher.Show(fromID, instances, subtitles) {
+		return
+	}
+	h.sessionSwitcher.reattachOnCancel = reattachOnCancel
+	// Treat the opening Ctrl+S as the first advance so key-repeat that arrives
+	// right after the attach->TUI handoff is throttled instead of spinning.
+	h.sessionSwitcher.lastCycleAt = time.Now()
+	// Invalidate any idle-commit timer still in flight from a previous picker
+	// session, and schedule none: auto-commit arms only once the user cycles
+	// (Ctrl+S/Ctrl+A) inside this picker.
+	h.sessionSwitcher.bumpCommitGen()
+}
+
+// openRecentSessions pops the switcher as the recent-sessions list (the last
+// sessions the user attached to, numbered for 1-9 quick select). Enter or a
+// digit attaches; there is no idle auto-commit.
+func (h *Home) openRecentSessions() {
+	instances, subtitles := h.switcherSnapshot()
+	h.sessionSwitcher.SetSize(h.width, h.height)
+	if !h.sessionSwitcher.ShowRecent(instances, subtitles) {
+		h.setError(fmt.Errorf("no recently attached sessions"))
+		return
+	}
+	h.sessionSwitcher.bumpCommitGen()
+}
+
+// switcherSnapshot copies the instance list and collects each session's dim
+// conversation/pane title (e.g. the Claude conversation summary) from the same
+// render snapshot the overview uses, so switcher rows mirror the overview.
+func (h *Home) switcherSnapshot() ([]*session.Instance, map[string]string) {
 	h.instancesMu.RLock()
 	instances := make([]*session.Instance, len(h.instances))
 	copy(instances, h.instances)
 	h.instancesMu.RUnlock()
 
-	// Mirror the overview: surface each session's dim conversation/pane title
-	// (e.g. the Claude conversation summary) from the same render snapshot.
 	subtitles := make(map[string]string, len(instances))
 	for _, inst := range instances {
 		if inst == nil {
@@ -17445,19 +19384,7 @@ func (h *Home) openSessionSwitcher(fromID string, reattachOnCancel bool) {
 			subtitles[inst.ID] = pt
 		}
 	}
-
-	h.sessionSwitcher.SetSize(h.width, h.height)
-	if !h.sessionSwitcher.Show(fromID, instances, subtitles) {
-		return
-	}
-	h.sessionSwitcher.reattachOnCancel = reattachOnCancel
-	// Treat the opening Ctrl+S as the first advance so key-repeat that arrives
-	// right after the attach->TUI handoff is throttled instead of spinning.
-	h.sessionSwitcher.lastCycleAt = time.Now()
-	// Invalidate any idle-commit timer still in flight from a previous picker
-	// session, and schedule none: auto-commit arms only once the user cycles
-	// (Ctrl+S/Ctrl+A) inside this picker.
-	h.sessionSwitcher.bumpCommitGen()
+	return instances, subtitles
 }
 
 // armSwitcherCommit (re)starts the idle-commit countdown and returns the timer
@@ -17522,6 +19449,21 @@ func (h *Home) attachToSwitchTarget(id string) tea.Cmd {
 // not to leave); when opened from the overview it just closes. Ctrl+Q (the
 // detach key) always drops to the overview.
 func (h *Home) handleSessionSwitcherKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if h.sessionSwitcher.recent {
+		key := msg.String()
+		// Recent-sessions list: a digit attaches to that row; pressing the
+		// open key again steps down like Alt+Tab.
+		if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+			if h.sessionSwitcher.selectIndex(int(key[0] - '1')) {
+				return h, h.commitSessionSwitch()
+			}
+			return h, nil
+		}
+		if key == h.actionKey(hotkeyRecentSessions) {
+			h.sessionSwitcher.next()
+			return h, nil
+		}
+	}
 	switch msg.String() {
 	case "enter":
 		return h, h.commitSessionSwitch()
@@ -17567,7 +19509,7 @@ func (h *Home) handleWorktreeFinishDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd
 
 	case "confirm":
 		// Execute the finish operation
-		mergeEnabled, targetBranch, keepBranch := h.worktreeFinishDialog.GetOptions()
+		opts := h.worktreeFinishDialog.GetOptions()
 		h.worktreeFinishDialog.SetExecuting(true)
 
 		sid := h.worktreeFinishDialog.sessionID
@@ -17587,7 +19529,7 @@ func (h *Home) handleWorktreeFinishDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd
 		)
 		h.instancesMu.RUnlock()
 
-		return h, h.finishWorktree(inst, sid, sTitle, branch, repoRoot, wtPath, mergeEnabled, targetBranch, keepBranch, shared)
+		return h, h.finishWorktree(inst, sid, sTitle, branch, repoRoot, wtPath, opts, shared)
 
 	case "input":
 		// Pass through to text input
@@ -17624,15 +19566,36 @@ func (h *Home) runWorktreeSetup(inst *session.Instance) tea.Cmd {
 }
 
 // finishWorktree performs the worktree finish operation asynchronously:
-// merge branch, remove worktree, delete branch, kill session, remove from storage
-func (h *Home) finishWorktree(inst *session.Instance, sessionID, sessionTitle, branchName, repoRoot, worktreePath string, mergeEnabled bool, targetBranch string, keepBranch bool, sharedWorktree bool) tea.Cmd {
+// commit outstanding work, squash/rebase/merge the branch, remove worktree,
+// delete branch, kill session. The result message tells the handler whether
+// to remove the session from storage or re-point it at the repo root.
+func (h *Home) finishWorktree(inst *session.Instance, sessionID, sessionTitle, branchName, repoRoot, worktreePath string, opts worktreeFinishOptions, sharedWorktree bool) tea.Cmd {
+	targetBranch := opts.targetBranch
+	keepBranch := opts.keepBranch
 	return func() tea.Msg {
 		merged := false
 
+		// Step 0: Commit uncommitted changes so they survive the merge.
+		if opts.commitDirty {
+			if _, err := git.CommitAll(worktreePath, fmt.Sprintf("WIP: %s", sessionTitle)); err != nil {
+				return worktreeFinishResultMsg{
+					sessionID: sessionID, sessionTitle: sessionTitle,
+					err: fmt.Errorf("commit failed: %v", err),
+				}
+			}
+		}
+
 		// Step 1: Merge (if requested). git.MergeBack handles both regular
 		// and bare-repo layouts; in bare layouts the project root has no
-		// working tree, so checkout/merge cannot run there (#891).
-		if mergeEnabled {
+		// working tree, so checkout/merge cannot run there (#891). Squash and
+		// rebase first rewrite the branch so the merge fast-forwards.
+		if opts.merge {
+			if err := git.PrepareBranchForMerge(worktreePath, targetBranch, opts.strategy, sessionTitle); err != nil {
+				return worktreeFinishResultMsg{
+					sessionID: sessionID, sessionTitle: sessionTitle,
+					err: fmt.Errorf("%s failed: %v", opts.strategy, err),
+				}
+			}
 			if err := git.MergeBack(repoRoot, branchName, targetBranch); err != nil {
 				return worktreeFinishResultMsg{
 					sessionID: sessionID, sessionTitle: sessionTitle,
@@ -17677,6 +19640,8 @@ func (h *Home) finishWorktree(inst *session.Instance, sessionID, sessionTitle, b
 			sessionTitle: sessionTitle,
 			targetBranch: targetBranch,
 			merged:       merged,
+			keptSession:  opts.keepSession,
+			repoRoot:     repoRoot,
 		}
 	}
 }
@@ -17919,23 +19884,31 @@ func (h *Home) renderFilterBarHint() string {
 	}
 
 	hint := dim.Render("  ") +
-		mark("!", h.statusFilter == session.StatusRunning) +
-		mark("@", h.statusFilter == session.StatusWaiting) +
-		mark("#", h.statusFilter == session.StatusIdle) +
-		mark("$", h.statusFilter == session.StatusError) +
+		mark(h.actionKey(hotkeyFilterRunning), h.statusFilter == session.StatusRunning) +
+		mark(h.actionKey(hotkeyFilterWaiting), h.statusFilter == session.StatusWaiting) +
+		mark(h.actionKey(hotkeyFilterIdle), h.statusFilter == session.StatusIdle) +
+		mark(h.actionKey(hotkeyFilterError), h.statusFilter == session.StatusError) +
 		dim.Render(" filter • ") +
-		mark("0", h.statusFilter == "") +
+		mark(h.actionKey(hotkeyFilterAll), h.statusFilter == "") +
 		dim.Render(" all • ") +
-		mark(FilterKeyActive, h.statusFilter == FilterModeActive) +
+		mark(h.actionKey(hotkeyFilterOpen), h.statusFilter == FilterModeActive) +
 		dim.Render(" open • ") +
-		mark(FilterKeyArchived, h.statusFilter == FilterModeArchived) +
-		dim.Render(" archived")
+		mark(h.actionKey(hotkeyViewArchived), h.statusFilter == FilterModeArchived) +
+		dim.Render(" archived • ") +
+		mark(h.actionKey(hotkeyFilterTag), h.tagFilter != "") +
+		dim.Render(" tag")
 
 	// View-mode indicator (running-on-top / populated-on-top), only when active.
-	if h.groupViewMode != session.GroupViewNormal {
-		hint += dim.Render(" • ") + mark("t", true) + dim.Render(" "+h.groupViewMode.Label())
+	if h.branchView {
+		hint += dim.Render(" • ") + mark(h.actionKey(hotkeyBranchView), true) + dim.Render(" by branch")
+	} else if h.groupViewMode != session.GroupViewNormal {
+		hint += dim.Render(" • ") + mark(h.actionKey(hotkeyCycleGroupView), true) + dim.Render(" "+h.groupViewMode.Label())
 	} else {
-		hint += dim.Render(" • ") + mark("t", false) + dim.Render(" view")
+		hint += dim.Render(" • ") + mark(h.actionKey(hotkeyCycleGroupView), false) + dim.Render(" view")
+	}
+	// Sort indicator, only when not in manual order.
+	if mode := session.GroupSortMode(); mode != "creation" {
+		hint += dim.Render(" • ") + mark(h.actionKey(hotkeyCycleSort), true) + dim.Render(" by "+session.GroupSortLabel(mode))
 	}
 	return hint
 }
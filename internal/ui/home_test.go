@@ -1099,6 +1099,9 @@ func TestHandleMainKeyEditNotesDisabledWhenShowNotesFalse(t *testing.T) {
 	if h.notesEditingSessionID != "" {
 		t.Fatalf("notesEditingSessionID = %q, want empty", h.notesEditingSessionID)
 	}
+	if h.err == nil || !strings.Contains(h.err.Error(), "show_notes") {
+		t.Fatalf("disabled notes key should explain how to enable notes, got %v", h.err)
+	}
 }
 
 func TestHandleMainKeyEditNotesDisabledByDefault(t *testing.T) {
@@ -2880,7 +2883,7 @@ func TestRestartSessionCmdSessionMissingReturnsError(t *testing.T) {
 	inst := session.NewInstance("restart-test", "/tmp/project")
 
 	// Build command with a valid instance, then simulate reload/delete before cmd runs.
-	cmd := home.restartSession(inst)
+	cmd := home.restartSession(inst, session.RestartReasonManual)
 	home.instancesMu.Lock()
 	delete(home.instanceByID, inst.ID)
 	home.instancesMu.Unlock()
@@ -5,49 +5,93 @@ import (
 	"sort"
 	"strings"
 	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 const (
-	hotkeyQuit             = "quit"
-	hotkeyNewSession       = "new_session"
-	hotkeyQuickCreate      = "quick_create"
-	hotkeyRename           = "rename"
-	hotkeyRestart          = "restart"
-	hotkeyRestartFresh     = "restart_fresh"
-	hotkeyDelete           = "delete"
-	hotkeyCloseSession     = "close_session"
-	hotkeyArchiveSession   = "archive_session"
-	hotkeyUnarchiveSession = "unarchive_session"
-	hotkeyViewArchived     = "view_archived"
-	hotkeyUndoDelete       = "undo_delete"
-	hotkeyMoveToGroup      = "move_to_group"
-	hotkeyMCPManager       = "mcp_manager"
-	hotkeyPluginManager    = "plugin_manager"
-	hotkeySkillsManager    = "skills_manager"
-	hotkeyTogglePreview    = "toggle_preview"
-	hotkeyCycleGroupView   = "cycle_group_view"
-	hotkeyMarkUnread       = "mark_unread"
-	hotkeyQuickApprove     = "quick_approve"
-	hotkeyPromptSession    = "prompt_session" // #1410: prompt the highlighted session without attaching
-	hotkeyToggleYolo       = "toggle_yolo"
-	hotkeyQuickFork        = "quick_fork"
-	hotkeyForkWithOptions  = "fork_with_options"
-	hotkeyCopyOutput       = "copy_output"
-	hotkeySendOutput       = "send_output"
-	hotkeyExecShell        = "exec_shell"
-	hotkeyEditNotes        = "edit_notes"
-	hotkeyEditPaths        = "edit_paths"
-	hotkeyEditSession      = "edit_session"
-	hotkeyWorktreeSetup    = "worktree_setup"
-	hotkeyWorktreeFinish   = "worktree_finish"
-	hotkeyCreateGroup      = "create_group"
-	hotkeySearch           = "search"
-	hotkeyHelp             = "help"
-	hotkeySettings         = "settings"
-	hotkeyImport           = "import"
-	hotkeyReload           = "reload"
-	hotkeyDetach           = "detach"
-	hotkeyWatcherPanel     = "watcher_panel"
+	hotkeyQuit              = "quit"
+	hotkeyNewSession        = "new_session"
+	hotkeyQuickCreate       = "quick_create"
+	hotkeyRename            = "rename"
+	hotkeyRestart           = "restart"
+	hotkeyRestartFresh      = "restart_fresh"
+	hotkeyDelete            = "delete"
+	hotkeyCloseSession      = "close_session"
+	hotkeyArchiveSession    = "archive_session"
+	hotkeyUnarchiveSession  = "unarchive_session"
+	hotkeyViewArchived      = "view_archived"
+	hotkeyUndoDelete        = "undo_delete"
+	hotkeyMoveToGroup       = "move_to_group"
+	hotkeyMCPManager        = "mcp_manager"
+	hotkeyMCPPool           = "mcp_pool" // pooled MCP proxies: uptime, errors, restart/stop
+	hotkeyPluginManager     = "plugin_manager"
+	hotkeySkillsManager     = "skills_manager"
+	hotkeyTogglePreview     = "toggle_preview"
+	hotkeyCycleGroupView    = "cycle_group_view"
+	hotkeyBranchView        = "branch_view" // cluster sessions by git repo and branch
+	hotkeyCycleSort         = "cycle_sort"  // within-group sort: manual, accessed, status, newest, cost, memory
+	hotkeyCollapseAll       = "collapse_all_groups"
+	hotkeyExpandAll         = "expand_all_groups"
+	hotkeyExpandWaiting     = "expand_waiting_groups" // expand only groups holding a waiting session
+	hotkeyMarkUnread        = "mark_unread"
+	hotkeyQuickApprove      = "quick_approve"
+	hotkeyPromptSession     = "prompt_session" // #1410: prompt the highlighted session without attaching
+	hotkeyPasteFile         = "paste_file"     // send a local file's contents to the session
+	hotkeyToggleYolo        = "toggle_yolo"
+	hotkeyPermissionMode    = "permission_mode" // Claude: plan / default / auto / skip permissions
+	hotkeyQuickFork         = "quick_fork"
+	hotkeyForkWithOptions   = "fork_with_options"
+	hotkeyCloneSession      = "clone_session" // same setup, fresh conversation
+	hotkeyCopyOutput        = "copy_output"
+	hotkeyCopyPane          = "copy_pane" // last [preview] copy_lines of the pane, as shown in the preview
+	hotkeySendOutput        = "send_output"
+	hotkeyExecShell         = "exec_shell"
+	hotkeyEditNotes         = "edit_notes"
+	hotkeyEditPaths         = "edit_paths"
+	hotkeyEditSession       = "edit_session"
+	hotkeyWorktreeSetup     = "worktree_setup"
+	hotkeyWorktreeFinish    = "worktree_finish"
+	hotkeyCreatePR          = "create_pr"     // push the worktree branch and open a PR via gh
+	hotkeyWorktreeDiff      = "worktree_diff" // diff a worktree against its base branch
+	hotkeyCreateGroup       = "create_group"
+	hotkeyMuteGroup         = "mute_group"      // keep a group's sessions off the notification bar
+	hotkeyArchiveGroup      = "archive_group"   // hide a group from the tree without deleting it
+	hotkeyArchivedGroups    = "archived_groups" // show/hide archived groups in the tree
+	hotkeySearch            = "search"
+	hotkeyHelp              = "help"
+	hotkeySettings          = "settings"
+	hotkeyImport            = "import"
+	hotkeyReload            = "reload"
+	hotkeyDetach            = "detach"
+	hotkeyWatcherPanel      = "watcher_panel"
+	hotkeyFixSessionID      = "fix_session_id"      // adopt a detected Claude session ID mismatch
+	hotkeyToggleSelect      = "toggle_select"       // mark/unmark sessions for bulk actions
+	hotkeyPreviewScrollUp   = "preview_scroll_up"   // scroll the preview back through scrollback
+	hotkeyPreviewScrollDown = "preview_scroll_down" // scroll the preview toward the tail
+	hotkeyAnalyticsDash     = "analytics_dashboard" // full-screen usage across all sessions
+	hotkeyViewTranscript    = "view_transcript"     // open the session's transcript in $PAGER
+	hotkeyProfileSwitcher   = "profile_switcher"    // pick another profile and relaunch on it
+	hotkeyMoveToProfile     = "move_to_profile"     // transfer the selected session to another profile
+	hotkeyTrashView         = "trash_view"          // list deleted sessions to restore or purge
+	hotkeySessionTimeline   = "session_timeline"    // the selected session's event log
+	hotkeyConversation      = "conversation_viewer" // read-only Claude/Gemini conversation overlay
+	hotkeyOpenFile          = "open_file"           // open a file:line from the preview in $EDITOR
+	hotkeyFilesTouched      = "files_touched"       // expand/collapse the analytics panel's touched files
+	hotkeyVerify            = "verify"              // run the project's verify command on the session's checkout
+	hotkeySnapshots         = "snapshots"           // list checkout snapshots to roll the session back to
+	hotkeyAttach            = "attach"              // attach to the session / toggle the group
+	hotkeyAttachSplit       = "attach_split"        // attach in a pane/window of the surrounding tmux
+	hotkeySpectate          = "spectate"            // read-only attach: watch without sending keys
+	hotkeyFilterAll         = "filter_all"          // clear status and tag filters
+	hotkeyFilterRunning     = "filter_running"
+	hotkeyFilterWaiting     = "filter_waiting"
+	hotkeyFilterIdle        = "filter_idle"
+	hotkeyFilterError       = "filter_error" // opens the cost dashboard instead when cost tracking is on
+	hotkeyFilterOpen        = "filter_open"
+	hotkeyFilterTag         = "filter_tag"
+	hotkeyCommandPalette    = "command_palette" // fuzzy list of every action and group
+	hotkeyRecentSessions    = "recent_sessions" // last attached sessions, 1-9 to switch
 	// Session switcher. While attached it is intercepted in the tmux attach
 	// loop (see internal/tmux/pty.go AttachOptions); on the home screen it is
 	// dispatched like any other hotkey. Must resolve to a "ctrl+<letter>" chord.
@@ -74,17 +118,27 @@ var hotkeyActionOrder = []string{
 	hotkeyUndoDelete,
 	hotkeyMoveToGroup,
 	hotkeyMCPManager,
+	hotkeyMCPPool,
 	hotkeyPluginManager,
 	hotkeySkillsManager,
 	hotkeyTogglePreview,
 	hotkeyCycleGroupView,
+	hotkeyBranchView,
+	hotkeyCycleSort,
+	hotkeyCollapseAll,
+	hotkeyExpandAll,
+	hotkeyExpandWaiting,
 	hotkeyMarkUnread,
 	hotkeyQuickApprove,
 	hotkeyPromptSession,
+	hotkeyPasteFile,
 	hotkeyToggleYolo,
+	hotkeyPermissionMode,
 	hotkeyQuickFork,
 	hotkeyForkWithOptions,
+	hotkeyCloneSession,
 	hotkeyCopyOutput,
+	hotkeyCopyPane,
 	hotkeySendOutput,
 	hotkeyExecShell,
 	hotkeyEditNotes,
@@ -92,7 +146,12 @@ var hotkeyActionOrder = []string{
 	hotkeyEditSession,
 	hotkeyWorktreeSetup,
 	hotkeyWorktreeFinish,
+	hotkeyCreatePR,
+	hotkeyWorktreeDiff,
 	hotkeyCreateGroup,
+	hotkeyMuteGroup,
+	hotkeyArchiveGroup,
+	hotkeyArchivedGroups,
 	hotkeySearch,
 	hotkeyHelp,
 	hotkeySettings,
@@ -100,51 +159,120 @@ var hotkeyActionOrder = []string{
 	hotkeyReload,
 	hotkeyDetach,
 	hotkeyWatcherPanel,
+	hotkeyFixSessionID,
+	hotkeyToggleSelect,
+	hotkeyPreviewScrollUp,
+	hotkeyPreviewScrollDown,
+	hotkeyAnalyticsDash,
+	hotkeyViewTranscript,
+	hotkeyProfileSwitcher,
+	hotkeyMoveToProfile,
+	hotkeyTrashView,
+	hotkeySessionTimeline,
+	hotkeyConversation,
+	hotkeyOpenFile,
+	hotkeyFilesTouched,
+	hotkeyVerify,
+	hotkeySnapshots,
+	hotkeyAttach,
+	hotkeyAttachSplit,
+	hotkeySpectate,
+	hotkeyFilterAll,
+	hotkeyFilterRunning,
+	hotkeyFilterWaiting,
+	hotkeyFilterIdle,
+	hotkeyFilterError,
+	hotkeyFilterOpen,
+	hotkeyFilterTag,
+	hotkeyCommandPalette,
+	hotkeyRecentSessions,
 	hotkeySwitchSession,
 }
 
 var defaultHotkeyBindings = map[string]string{
-	hotkeyQuit:             "q",
-	hotkeyNewSession:       "n",
-	hotkeyQuickCreate:      "N",
-	hotkeyRename:           "r",
-	hotkeyRestart:          "R",
-	hotkeyRestartFresh:     "T",
-	hotkeyDelete:           "d",
-	hotkeyCloseSession:     "D",
-	hotkeyArchiveSession:   "A",
-	hotkeyUnarchiveSession: "shift+u",
-	hotkeyViewArchived:     "^",
-	hotkeyUndoDelete:       "ctrl+z",
-	hotkeyMoveToGroup:      "M",
-	hotkeyMCPManager:       "m",
-	hotkeyPluginManager:    "L",
-	hotkeySkillsManager:    "s",
-	hotkeyTogglePreview:    "v",
-	hotkeyCycleGroupView:   "t",
-	hotkeyMarkUnread:       "u",
-	hotkeyQuickApprove:     "a",
-	hotkeyPromptSession:    "o",
-	hotkeyToggleYolo:       "y",
-	hotkeyQuickFork:        "f",
-	hotkeyForkWithOptions:  "F",
-	hotkeyCopyOutput:       "c",
-	hotkeySendOutput:       "x",
-	hotkeyExecShell:        "E",
-	hotkeyEditNotes:        "e",
-	hotkeyEditPaths:        "p",
-	hotkeyEditSession:      "P",
-	hotkeyWorktreeSetup:    "b",
-	hotkeyWorktreeFinish:   "W",
-	hotkeyCreateGroup:      "g",
-	hotkeySearch:           "/",
-	hotkeyHelp:             "?",
-	hotkeySettings:         "S",
-	hotkeyImport:           "i",
-	hotkeyReload:           "ctrl+r",
-	hotkeyDetach:           "ctrl+q",
-	hotkeyWatcherPanel:     "w",
-	hotkeySwitchSession:    "ctrl+s",
+	hotkeyQuit:              "q",
+	hotkeyNewSession:        "n",
+	hotkeyQuickCreate:       "N",
+	hotkeyRename:            "r",
+	hotkeyRestart:           "R",
+	hotkeyRestartFresh:      "T",
+	hotkeyDelete:            "d",
+	hotkeyCloseSession:      "D",
+	hotkeyArchiveSession:    "A",
+	hotkeyUnarchiveSession:  "shift+u",
+	hotkeyViewArchived:      "^",
+	hotkeyUndoDelete:        "ctrl+z",
+	hotkeyMoveToGroup:       "M",
+	hotkeyMCPManager:        "m",
+	hotkeyMCPPool:           "alt+M",
+	hotkeyPluginManager:     "L",
+	hotkeySkillsManager:     "s",
+	hotkeyTogglePreview:     "v",
+	hotkeyCycleGroupView:    "t",
+	hotkeyBranchView:        "alt+b",
+	hotkeyCycleSort:         "alt+s",
+	hotkeyCollapseAll:       "alt+-",
+	hotkeyExpandAll:         "alt+=",
+	hotkeyExpandWaiting:     "alt+w",
+	hotkeyMarkUnread:        "u",
+	hotkeyQuickApprove:      "a",
+	hotkeyPromptSession:     "o",
+	hotkeyPasteFile:         "alt+v",
+	hotkeyToggleYolo:        "y",
+	hotkeyPermissionMode:    "alt+y",
+	hotkeyQuickFork:         "f",
+	hotkeyForkWithOptions:   "F",
+	hotkeyCloneSession:      "alt+f",
+	hotkeyCopyOutput:        "c",
+	hotkeyCopyPane:          "alt+c",
+	hotkeySendOutput:        "x",
+	hotkeyExecShell:         "E",
+	hotkeyEditNotes:         "e",
+	hotkeyEditPaths:         "p",
+	hotkeyEditSession:       "P",
+	hotkeyWorktreeSetup:     "b",
+	hotkeyWorktreeFinish:    "W",
+	hotkeyCreatePR:          "B",
+	hotkeyWorktreeDiff:      "=",
+	hotkeyCreateGroup:       "g",
+	hotkeyMuteGroup:         "alt+n",
+	hotkeyArchiveGroup:      "alt+a",
+	hotkeyArchivedGroups:    "alt+A",
+	hotkeySearch:            "/",
+	hotkeyHelp:              "?",
+	hotkeySettings:          "S",
+	hotkeyImport:            "i",
+	hotkeyReload:            "ctrl+r",
+	hotkeyDetach:            "ctrl+q",
+	hotkeyWatcherPanel:      "w",
+	hotkeyFixSessionID:      "O",
+	hotkeyToggleSelect:      "V",
+	hotkeyPreviewScrollUp:   "[",
+	hotkeyPreviewScrollDown: "]",
+	hotkeyAnalyticsDash:     "H",
+	hotkeyViewTranscript:    "ctrl+t",
+	hotkeyProfileSwitcher:   "alt+p",
+	hotkeyMoveToProfile:     "alt+m",
+	hotkeyTrashView:         "alt+t",
+	hotkeySessionTimeline:   "alt+h",
+	hotkeyConversation:      "alt+r",
+	hotkeyOpenFile:          "alt+e",
+	hotkeyFilesTouched:      "alt+d",
+	hotkeyVerify:            "alt+T",
+	hotkeySnapshots:         "alt+z",
+	hotkeyAttach:            "enter",
+	hotkeyAttachSplit:       "alt+enter",
+	hotkeySpectate:          "alt+o",
+	hotkeyFilterAll:         "0",
+	hotkeyFilterRunning:     "!",
+	hotkeyFilterWaiting:     "@",
+	hotkeyFilterIdle:        "#",
+	hotkeyFilterError:       "$",
+	hotkeyFilterOpen:        FilterKeyActive,
+	hotkeyFilterTag:         FilterKeyTag,
+	hotkeyCommandPalette:    "ctrl+k",
+	hotkeyRecentSessions:    "`",
+	hotkeySwitchSession:     "ctrl+s",
 }
 
 var hotkeyActionDefaultTriggers = map[string][]string{
@@ -231,24 +359,31 @@ func buildHotkeyLookup(bindings map[string]string) (map[string]string, map[strin
 	keyToCanonical := make(map[string]string, len(bindings))
 	blockedCanonical := make(map[string]bool)
 
-	for _, action := range hotkeyActionOrder {
-		canonical := defaultHotkeyBindings[action]
-		bound := strings.TrimSpace(bindings[action])
-		defaultTriggers := defaultTriggersForAction(action)
-		if bound == "" {
-			for _, trigger := range defaultTriggers {
-				blockedCanonical[trigger] = true
+	// Remapped actions claim their keys first, so a remap onto a key some
+	// other action has by default wins over that default.
+	for _, remapped := range []bool{true, false} {
+		for _, action := range hotkeyActionOrder {
+			canonical := defaultHotkeyBindings[action]
+			bound := strings.TrimSpace(bindings[action])
+			if (bound != "" && bound != canonical) != remapped {
+				continue
 			}
-			continue
-		}
-		if bound != canonical {
-			for _, trigger := range defaultTriggers {
-				blockedCanonical[trigger] = true
+			defaultTriggers := defaultTriggersForAction(action)
+			if bound == "" {
+				for _, trigger := range defaultTriggers {
+					blockedCanonical[trigger] = true
+				}
+				continue
 			}
-		}
-		for _, alias := range hotkeyAliases(bound) {
-			if _, exists := keyToCanonical[alias]; !exists {
-				keyToCanonical[alias] = canonical
+			if bound != canonical {
+				for _, trigger := range defaultTriggers {
+					blockedCanonical[trigger] = true
+				}
+			}
+			for _, alias := range hotkeyAliases(bound) {
+				if _, exists := keyToCanonical[alias]; !exists {
+					keyToCanonical[alias] = canonical
+				}
 			}
 		}
 	}
@@ -377,6 +512,42 @@ func actionHotkey(bindings map[string]string, action string) string {
 	return strings.TrimSpace(bindings[action])
 }
 
+// keyTypesByName maps Bubble Tea key names ("enter", "ctrl+r", "shift+tab")
+// back to their KeyType.
+var keyTypesByName = func() map[string]tea.KeyType {
+	names := make(map[string]tea.KeyType)
+	for t := tea.KeyType(-128); t < 128; t++ {
+		if t == tea.KeyRunes {
+			continue
+		}
+		if name := (tea.Key{Type: t}).String(); name != "" {
+			if _, exists := names[name]; !exists {
+				names[name] = t
+			}
+		}
+	}
+	return names
+}()
+
+// keyMsgFromBinding returns the key press a binding describes, so an action
+// can be dispatched as if its key were pressed (see the command palette).
+// Shifted letters ("shift+u") are sent as the rune Bubble Tea reports ("U").
+func keyMsgFromBinding(binding string) (tea.KeyMsg, bool) {
+	for _, alias := range hotkeyAliases(binding) {
+		name, alt := alias, false
+		if rest, ok := strings.CutPrefix(alias, "alt+"); ok && rest != "" {
+			name, alt = rest, true
+		}
+		if t, ok := keyTypesByName[name]; ok {
+			return tea.KeyMsg{Type: t, Alt: alt}, true
+		}
+		if runes := []rune(name); len(runes) == 1 {
+			return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes, Alt: alt}, true
+		}
+	}
+	return tea.KeyMsg{}, false
+}
+
 func joinHotkeyLabels(keys ...string) string {
 	filtered := make([]string, 0, len(keys))
 	for _, key := range keys {
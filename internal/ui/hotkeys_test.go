@@ -199,3 +199,29 @@ func TestNormalizeMainKeyWithConfiguredHotkeys(t *testing.T) {
 		t.Fatalf("ctrl+c should be blocked when quit is unbound, got %q", got)
 	}
 }
+
+func TestNormalizeMainKeyRemapsAttachAndFilters(t *testing.T) {
+	h := NewHome()
+	h.setHotkeys(resolveHotkeys(map[string]string{
+		"attach":         "l",
+		"filter_running": "alt+r",
+	}))
+
+	if got := h.normalizeMainKey("l"); got != "enter" {
+		t.Fatalf("l normalized to %q, want enter", got)
+	}
+	if got := h.normalizeMainKey("enter"); got != "" {
+		t.Fatalf("enter should be blocked after attach remap, got %q", got)
+	}
+	if got := h.normalizeMainKey("alt+r"); got != "!" {
+		t.Fatalf("alt+r normalized to %q, want !", got)
+	}
+	for _, blocked := range []string{"!", "shift+1"} {
+		if got := h.normalizeMainKey(blocked); got != "" {
+			t.Fatalf("%s should be blocked after filter_running remap, got %q", blocked, got)
+		}
+	}
+	if got := h.attachKeyLabel("⏎"); got != "l" {
+		t.Fatalf("attachKeyLabel = %q, want l", got)
+	}
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// deliverInitialPrompts sends the pending initial prompt (set from the
+// new-session dialog or `add --initial-prompt`) to every session whose agent
+// has become ready: the launch animation is over and the status detector
+// reports the agent running, waiting or idle. The prompt is cleared and saved
+// before it is sent, so it is delivered at most once.
+func (h *Home) deliverInitialPrompts() tea.Cmd {
+	h.instancesMu.RLock()
+	instances := make([]*session.Instance, len(h.instances))
+	copy(instances, h.instances)
+	h.instancesMu.RUnlock()
+
+	var cmds []tea.Cmd
+	for _, inst := range instances {
+		if !inst.HasInitialPrompt() || h.hasActiveAnimation(inst.ID) {
+			continue
+		}
+		switch inst.GetStatusThreadSafe() {
+		case session.StatusRunning, session.StatusWaiting, session.StatusIdle:
+		default:
+			continue
+		}
+		ts := inst.GetTmuxSession()
+		if ts == nil || ts.Name == "" || !ts.Exists() {
+			continue
+		}
+		text := inst.TakeInitialPrompt()
+		if text == "" {
+			continue
+		}
+		title := inst.Title
+		guarded := session.IsClaudeCompatible(inst.GetToolThreadSafe())
+		cmds = append(cmds, func() tea.Msg {
+			var err error
+			if guarded {
+				err = deliverToConductorPane(ts, text)
+			} else {
+				err = ts.SendKeysAndEnter(text)
+			}
+			uiLog.Info("initial_prompt_sent",
+				slog.String("tmux_session", ts.Name),
+				slog.Bool("ok", err == nil))
+			return promptSentMsg{title: title, err: err}
+		})
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	h.saveInstances()
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestDeliverInitialPrompts_WaitsForReadySession(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	a := insts[0]
+	a.InitialPrompt = "summarize the repo"
+	a.Status = session.StatusWaiting
+
+	home.launchingSessions[a.ID] = time.Now()
+	if cmd := home.deliverInitialPrompts(); cmd != nil {
+		t.Error("prompt must wait for the launch animation to end")
+	}
+	delete(home.launchingSessions, a.ID)
+
+	// The tmux session was never started, so there is no pane to type into.
+	if cmd := home.deliverInitialPrompts(); cmd != nil {
+		t.Error("prompt must wait for the tmux session to exist")
+	}
+	if !a.HasInitialPrompt() {
+		t.Error("an undelivered prompt must stay pending")
+	}
+}
+
+func TestNewDialog_InitialPrompt(t *testing.T) {
+	d := NewNewDialog()
+	d.SetSize(100, 50)
+	d.Show()
+	if d.indexOf(focusPrompt) < 0 {
+		t.Fatal("focusPrompt should be a focus target")
+	}
+	d.promptInput.SetValue("  write tests  ")
+	if got := d.GetInitialPrompt(); got != "write tests" {
+		t.Errorf("GetInitialPrompt = %q", got)
+	}
+	d.Show()
+	if got := d.GetInitialPrompt(); got != "" {
+		t.Errorf("prompt should reset when the dialog reopens, got %q", got)
+	}
+}
@@ -164,7 +164,7 @@ func ParseCSIu(data []byte) *tea.KeyMsg {
 	// Decode modifier bitmask (modifier = 1 + bitmask)
 	bitmask := modifier - 1
 	shiftHeld := (bitmask & 0x01) != 0
-	// altHeld   := (bitmask & 0x02) != 0  // reserved for future use
+	altHeld := (bitmask & 0x02) != 0
 	ctrlHeld := (bitmask & 0x04) != 0
 
 	// Map well-known control codepoints to tea key types.
@@ -178,7 +178,8 @@ func ParseCSIu(data []byte) *tea.KeyMsg {
 			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{shiftEnterMarker}}
 			return &msg
 		}
-		msg := tea.KeyMsg{Type: tea.KeyEnter}
+		// Alt survives so Alt+Enter (attach_split) stays distinct.
+		msg := tea.KeyMsg{Type: tea.KeyEnter, Alt: altHeld}
 		return &msg
 	case 9: // HT = Tab
 		if shiftHeld {
@@ -260,6 +261,7 @@ func ParseModifyOtherKeys(data []byte) *tea.KeyMsg {
 	// Reuse the same modifier logic as ParseCSIu
 	bitmask := modifier - 1
 	shiftHeld := (bitmask & 0x01) != 0
+	altHeld := (bitmask & 0x02) != 0
 	ctrlHeld := (bitmask & 0x04) != 0
 
 	switch codepoint {
@@ -269,7 +271,8 @@ func ParseModifyOtherKeys(data []byte) *tea.KeyMsg {
 			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{shiftEnterMarker}}
 			return &msg
 		}
-		msg := tea.KeyMsg{Type: tea.KeyEnter}
+		// Alt survives so Alt+Enter (attach_split) stays distinct.
+		msg := tea.KeyMsg{Type: tea.KeyEnter, Alt: altHeld}
 		return &msg
 	case 9:
 		if shiftHeld {
@@ -525,6 +528,9 @@ func (c *csiuReader) translate(final bool) []byte {
 				if msg := ParseModifyOtherKeys(seq); msg != nil {
 					switch msg.Type {
 					case tea.KeyEnter:
+						if msg.Alt {
+							out = append(out, 0x1b)
+						}
 						out = append(out, '\r')
 					case tea.KeyTab:
 						out = append(out, '\t')
@@ -565,6 +571,9 @@ func (c *csiuReader) translate(final bool) []byte {
 		// Translate to legacy bytes
 		switch msg.Type {
 		case tea.KeyEnter:
+			if msg.Alt {
+				out = append(out, 0x1b)
+			}
 			out = append(out, '\r')
 		case tea.KeyTab:
 			out = append(out, '\t')
@@ -404,6 +404,21 @@ func TestCSIuReaderModifyOtherKeys_ShiftTab(t *testing.T) {
 	}
 }
 
+// TestCSIuReader_AltEnter verifies Alt+Enter keeps its Alt bit (ESC CR, which
+// Bubble Tea reports as "alt+enter") instead of collapsing to plain Enter.
+func TestCSIuReader_AltEnter(t *testing.T) {
+	for _, input := range []string{"\x1b[13;3u", "\x1b[27;3;13~"} {
+		r := NewCSIuReader(bytes.NewReader([]byte(input)))
+		out, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("ReadAll error: %v", err)
+		}
+		if string(out) != "\x1b\r" {
+			t.Errorf("CSIuReader translated %q to %q, want %q", input, string(out), "\x1b\r")
+		}
+	}
+}
+
 // TestCSIuReaderModifyOtherKeysMixed verifies mixed modifyOtherKeys + plain input.
 func TestCSIuReaderModifyOtherKeysMixed(t *testing.T) {
 	// "x" + Shift+N via modifyOtherKeys + "y"
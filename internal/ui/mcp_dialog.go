@@ -44,6 +44,12 @@ type MCPItem struct {
 	HasServerCfg bool   // True if HTTP MCP has [mcps.X.server] config
 }
 
+// MCPSet is a named bundle of MCPs from [mcp_sets] in config.toml.
+type MCPSet struct {
+	Name    string
+	Members []string
+}
+
 // MCPDialog handles MCP management for Claude, Gemini, and Cursor Agent CLI sessions.
 // Hermes uses its own ~/.hermes/config.yaml `mcp_servers:` schema (user-scoped,
 // YAML) which is not compatible with Claude's project-scoped .mcp.json — it is
@@ -81,6 +87,12 @@ type MCPDialog struct {
 	globalChanged bool
 	userChanged   bool // USER scope changed
 
+	health map[string]session.MCPHealth // Last liveness probe per MCP name; see mcpHealthTracker
+
+	// Named MCP sets, toggled as a unit in the current scope
+	sets   []MCPSet
+	setIdx int
+
 	err           error
 	configError   string // Error message from config parsing
 	typeJumpBuf   string
@@ -105,6 +117,15 @@ func (m *MCPDialog) Show(projectPath string, sessionID string, tool string) erro
 	m.sessionID = sessionID
 	m.tool = tool
 
+	m.sets = nil
+	m.setIdx = 0
+	if cfg, err := session.LoadUserConfig(); err == nil {
+		for _, name := range cfg.MCPSetNames() {
+			members, _ := cfg.GetMCPSet(name)
+			m.sets = append(m.sets, MCPSet{Name: name, Members: members})
+		}
+	}
+
 	// Get all available MCPs from config.toml (the pool)
 	availableMCPs := session.GetAvailableMCPs()
 	allNames := session.GetAvailableMCPNames()
@@ -385,11 +406,19 @@ func (m *MCPDialog) Hide() {
 	m.globalAvailable = nil
 	m.userAttached = nil
 	m.userAvailable = nil
+	m.health = nil
+	m.sets = nil
 	m.err = nil
 	m.typeJumpBuf = ""
 	m.typeJumpUntil = time.Time{}
 }
 
+// SetHealth sets the session's last MCP liveness probe results, shown for the
+// selected MCP.
+func (m *MCPDialog) SetHealth(health map[string]session.MCPHealth) {
+	m.health = health
+}
+
 // IsVisible returns whether the dialog is visible
 func (m *MCPDialog) IsVisible() bool {
 	return m.visible
@@ -530,6 +559,101 @@ func (m *MCPDialog) Move() {
 	}
 }
 
+// scopeLists returns the current scope's Attached and Available lists, their
+// selection indexes, and its changed flag.
+func (m *MCPDialog) scopeLists() (attached, available *[]MCPItem, attachedIdx, availableIdx *int, changed *bool) {
+	switch m.scope {
+	case MCPScopeGlobal:
+		return &m.globalAttached, &m.globalAvailable, &m.globalAttachedIdx, &m.globalAvailableIdx, &m.globalChanged
+	case MCPScopeUser:
+		return &m.userAttached, &m.userAvailable, &m.userAttachedIdx, &m.userAvailableIdx, &m.userChanged
+	}
+	return &m.localAttached, &m.localAvailable, &m.localAttachedIdx, &m.localAvailableIdx, &m.localChanged
+}
+
+// SelectedSet returns the highlighted MCP set, or nil when none are configured.
+func (m *MCPDialog) SelectedSet() *MCPSet {
+	if m.setIdx < 0 || m.setIdx >= len(m.sets) {
+		return nil
+	}
+	return &m.sets[m.setIdx]
+}
+
+// setAttachment reports how many of set's members are attached and available
+// in the current scope. Members in neither list (not in config.toml, or
+// already attached at a wider scope) are not counted.
+func (m *MCPDialog) setAttachment(set MCPSet) (attachedCount, availableCount int) {
+	attached, available, _, _, _ := m.scopeLists()
+	for _, name := range set.Members {
+		if indexOfMCP(*attached, name) >= 0 {
+			attachedCount++
+		} else if indexOfMCP(*available, name) >= 0 {
+			availableCount++
+		}
+	}
+	return attachedCount, availableCount
+}
+
+// ToggleSet attaches every member of the highlighted set in the current
+// scope, or detaches them all when none are left to attach.
+func (m *MCPDialog) ToggleSet() {
+	set := m.SelectedSet()
+	if set == nil {
+		return
+	}
+	attached, available, attachedIdx, availableIdx, changed := m.scopeLists()
+	attachedCount, availableCount := m.setAttachment(*set)
+	from, to := available, attached
+	if availableCount == 0 {
+		if attachedCount == 0 {
+			return
+		}
+		from, to = attached, available
+	}
+	for _, name := range set.Members {
+		if i := indexOfMCP(*from, name); i >= 0 {
+			*to = append(*to, (*from)[i])
+			*from = append((*from)[:i], (*from)[i+1:]...)
+			*changed = true
+		}
+	}
+	*attachedIdx = min(*attachedIdx, max(len(*attached)-1, 0))
+	*availableIdx = min(*availableIdx, max(len(*available)-1, 0))
+	mcpDialogLog.Debug("mcp_set_toggled",
+		slog.String("set", set.Name),
+		slog.Int("scope", int(m.scope)),
+		slog.Bool("attached", availableCount > 0))
+}
+
+// AddAvailable lists an MCP just added to config.toml in every scope's
+// Available column and selects it in the current scope.
+func (m *MCPDialog) AddAvailable(name string, entry session.MCPRegistryEntry) {
+	item := MCPItem{Name: name, Description: entry.Description, Transport: entry.MCP.GetTransport()}
+	if entry.MCP.IsHTTP() {
+		item.HasServerCfg = entry.MCP.HasAutoStartServer()
+		item.HTTPStatus = "external"
+		if item.HasServerCfg {
+			item.HTTPStatus = "stopped"
+		}
+	}
+	m.localAvailable = append(m.localAvailable, item)
+	m.globalAvailable = append(m.globalAvailable, item)
+	m.userAvailable = append(m.userAvailable, item)
+
+	m.column = MCPColumnAvailable
+	_, available, _, availableIdx, _ := m.scopeLists()
+	*availableIdx = len(*available) - 1
+}
+
+func indexOfMCP(items []MCPItem, name string) int {
+	for i, item := range items {
+		if item.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
 func (m *MCPDialog) resetTypeJump() {
 	m.typeJumpBuf = ""
 	m.typeJumpUntil = time.Time{}
@@ -695,6 +819,29 @@ func (m *MCPDialog) Apply() error {
 	return nil
 }
 
+// ChangedScopes returns the attached MCP names for each scope Apply writes
+// ("local", "global", "user"), keyed by scope, for the session event log.
+func (m *MCPDialog) ChangedScopes() map[string][]string {
+	names := func(items []MCPItem) []string {
+		out := make([]string, len(items))
+		for i, item := range items {
+			out[i] = item.Name
+		}
+		return out
+	}
+	scopes := map[string][]string{}
+	if m.localChanged {
+		scopes["local"] = names(m.localAttached)
+	}
+	if m.globalChanged {
+		scopes["global"] = names(m.globalAttached)
+	}
+	if m.userChanged {
+		scopes["user"] = names(m.userAttached)
+	}
+	return scopes
+}
+
 // Update handles input
 func (m *MCPDialog) Update(msg tea.KeyMsg) (*MCPDialog, tea.Cmd) {
 	list, idx := m.getCurrentList()
@@ -751,6 +898,20 @@ func (m *MCPDialog) Update(msg tea.KeyMsg) (*MCPDialog, tea.Cmd) {
 		m.resetTypeJump()
 		m.Move()
 
+	case "[", "]":
+		m.resetTypeJump()
+		if len(m.sets) > 0 {
+			step := 1
+			if msg.String() == "[" {
+				step = len(m.sets) - 1
+			}
+			m.setIdx = (m.setIdx + step) % len(m.sets)
+		}
+
+	case "ctrl+s":
+		m.resetTypeJump()
+		m.ToggleSet()
+
 	default:
 		if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 {
 			m.typeJump(msg.Runes[0])
@@ -905,6 +1066,11 @@ func (m *MCPDialog) View() string {
 	default:
 		hint = hintStyle.Render("Tab scope │ ←→ column │ Type jump │ Space move │ Enter apply │ Esc cancel")
 	}
+	extraHint := "^L log │ ^B browse MCPs"
+	if len(m.sets) > 0 {
+		extraHint += " │ [ ] pick set │ ^S toggle set"
+	}
+	hint += "\n" + hintStyle.Render(extraHint)
 	if m.typeJumpBuf != "" && time.Now().Before(m.typeJumpUntil) {
 		hint += lipgloss.NewStyle().Foreground(ColorTextDim).Render("  (" + m.typeJumpBuf + ")")
 	}
@@ -965,9 +1131,15 @@ func (m *MCPDialog) View() string {
 	if showEmptyHelp {
 		parts = append(parts, m.renderEmptyStateHelp())
 	} else {
+		if line := m.renderSetsLine(); line != "" {
+			parts = append(parts, line, "")
+		}
 		parts = append(parts, columns)
 	}
 
+	if line := m.renderHealthLine(); line != "" {
+		parts = append(parts, "", line)
+	}
 	if errText != "" {
 		parts = append(parts, "", errText)
 	}
@@ -991,6 +1163,65 @@ func (m *MCPDialog) View() string {
 	)
 }
 
+// SelectedName returns the highlighted MCP in the current scope and column,
+// or "" when the list is empty.
+func (m *MCPDialog) SelectedName() string {
+	list, idx := m.getCurrentList()
+	if *idx < 0 || *idx >= len(*list) {
+		return ""
+	}
+	return (*list)[*idx].Name
+}
+
+// renderSetsLine lists the MCP sets with the highlighted one bracketed. The
+// marker shows whether its members are attached in the current scope:
+// ● all, ◐ some, ○ none.
+func (m *MCPDialog) renderSetsLine() string {
+	if len(m.sets) == 0 {
+		return ""
+	}
+	dim := lipgloss.NewStyle().Foreground(ColorTextDim)
+	focused := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	names := make([]string, len(m.sets))
+	for i, set := range m.sets {
+		attachedCount, availableCount := m.setAttachment(set)
+		marker := "○"
+		switch {
+		case attachedCount > 0 && availableCount == 0:
+			marker = "●"
+		case attachedCount > 0:
+			marker = "◐"
+		}
+		if i == m.setIdx {
+			names[i] = focused.Render("[" + marker + " " + set.Name + "]")
+		} else {
+			names[i] = dim.Render(" " + marker + " " + set.Name + " ")
+		}
+	}
+	line := dim.Render("Sets:") + " " + strings.Join(names, " ")
+	if set := m.SelectedSet(); set != nil {
+		line += "\n" + dim.Render("  "+truncatePath(strings.Join(set.Members, ", "), 56))
+	}
+	return line
+}
+
+// renderHealthLine describes the last liveness probe of the selected MCP, or
+// returns "" when it was not probed.
+func (m *MCPDialog) renderHealthLine() string {
+	r, ok := m.health[m.SelectedName()]
+	if !ok {
+		return ""
+	}
+	color := ColorTextDim
+	switch r.State {
+	case session.MCPHealthy:
+		color = ColorGreen
+	case session.MCPUnhealthy:
+		color = ColorRed
+	}
+	return lipgloss.NewStyle().Foreground(color).Render("Health: " + r.State.Symbol() + " " + r.Detail)
+}
+
 // renderEmptyStateHelp returns a helpful message when no MCPs are configured
 func (m *MCPDialog) renderEmptyStateHelp() string {
 	helpStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
@@ -1009,7 +1240,8 @@ func (m *MCPDialog) renderEmptyStateHelp() string {
 		helpStyle.Render("  command = \"npx\""),
 		helpStyle.Render("  args = [\"-y\", \"@example/mcp\"]"),
 		"",
-		helpStyle.Render("Then press M again to see them here."),
+		helpStyle.Render("Then press M again to see them here,"),
+		helpStyle.Render("or press Ctrl+B to browse known MCP servers."),
 	}
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -75,3 +76,51 @@ func TestMCPDialog_TypeJumpResetOnScopeSwitch(t *testing.T) {
 		t.Fatalf("expected jump in global list to zeta (index 0), got %d", dialog.globalAvailableIdx)
 	}
 }
+
+func TestMCPDialog_ToggleSetAttachesThenDetaches(t *testing.T) {
+	dialog := NewMCPDialog()
+	dialog.visible = true
+	dialog.scope = MCPScopeLocal
+	dialog.localAttached = []MCPItem{{Name: "github"}}
+	dialog.localAvailable = []MCPItem{{Name: "memory"}, {Name: "playwright"}, {Name: "slack"}}
+	dialog.sets = []MCPSet{
+		{Name: "data", Members: []string{"memory"}},
+		{Name: "web-dev", Members: []string{"playwright", "github", "slack", "not-configured"}},
+	}
+
+	_, _ = dialog.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+	if set := dialog.SelectedSet(); set == nil || set.Name != "web-dev" {
+		t.Fatalf("] should select web-dev, got %v", set)
+	}
+
+	// Partially attached: the toggle attaches the rest.
+	_, _ = dialog.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if got := mcpItemNames(dialog.localAttached); got != "github,playwright,slack" {
+		t.Fatalf("attached = %s, want github,playwright,slack", got)
+	}
+	if got := mcpItemNames(dialog.localAvailable); got != "memory" {
+		t.Fatalf("available = %s, want memory", got)
+	}
+	if !dialog.HasChanged() {
+		t.Fatal("toggling a set should mark the scope changed")
+	}
+
+	// Fully attached: the toggle detaches every member.
+	_, _ = dialog.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if got := mcpItemNames(dialog.localAttached); got != "" {
+		t.Fatalf("attached = %s, want empty", got)
+	}
+
+	// Other scopes are untouched.
+	if len(dialog.globalAttached) != 0 || len(dialog.globalAvailable) != 0 {
+		t.Fatal("set toggle must only affect the current scope")
+	}
+}
+
+func mcpItemNames(items []MCPItem) string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+	return strings.Join(names, ",")
+}
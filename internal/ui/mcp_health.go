@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// mcpHealthTTL is how long a session's MCP probe results stay fresh before
+// the selected session is probed again.
+const mcpHealthTTL = 30 * time.Second
+
+// mcpHealthMsg carries the probe results for one session.
+type mcpHealthMsg struct {
+	sessionID string
+	health    []session.MCPHealth
+}
+
+// mcpHealthTracker caches per-session MCP probe results. Probes run in the
+// background for the selected session, so a dead MCP shows up in the preview
+// before the agent trips over it. The zero value is ready to use.
+type mcpHealthTracker struct {
+	mu      sync.Mutex
+	results map[string][]session.MCPHealth
+	checked map[string]time.Time
+}
+
+// claim reports whether sessionID is due for a probe. A true result marks it
+// as checked so later ticks do not start a second probe while one runs.
+func (t *mcpHealthTracker) claim(sessionID string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if last, ok := t.checked[sessionID]; ok && now.Sub(last) < mcpHealthTTL {
+		return false
+	}
+	if t.checked == nil {
+		t.checked = make(map[string]time.Time)
+	}
+	t.checked[sessionID] = now
+	return true
+}
+
+func (t *mcpHealthTracker) store(sessionID string, health []session.MCPHealth) {
+	t.mu.Lock()
+	if t.results == nil {
+		t.results = make(map[string][]session.MCPHealth)
+	}
+	t.results[sessionID] = health
+	t.mu.Unlock()
+}
+
+// get returns the last results for sessionID keyed by MCP name, or nil.
+func (t *mcpHealthTracker) get(sessionID string) map[string]session.MCPHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	results := t.results[sessionID]
+	if len(results) == 0 {
+		return nil
+	}
+	byName := make(map[string]session.MCPHealth, len(results))
+	for _, r := range results {
+		byName[r.Name] = r
+	}
+	return byName
+}
+
+func (t *mcpHealthTracker) forget(sessionID string) {
+	t.mu.Lock()
+	delete(t.results, sessionID)
+	delete(t.checked, sessionID)
+	t.mu.Unlock()
+}
+
+// probeMCPHealth returns a command probing the MCPs in info for sessionID,
+// or nil when the session is not due or has no MCPs.
+func (h *Home) probeMCPHealth(sessionID string, info *session.MCPInfo) tea.Cmd {
+	if info == nil || !info.HasAny() || !h.mcpHealth.claim(sessionID, time.Now()) {
+		return nil
+	}
+	return func() tea.Msg {
+		return mcpHealthMsg{sessionID: sessionID, health: session.ProbeMCPHealth(info)}
+	}
+}
+
+// mcpHealthMark renders the ✓/✗ suffix for an MCP in the preview, or "" when
+// the MCP has not been probed.
+func mcpHealthMark(health map[string]session.MCPHealth, name string) string {
+	r, ok := health[name]
+	if !ok || r.State == session.MCPHealthUnknown {
+		return ""
+	}
+	color := ColorGreen
+	if r.State == session.MCPUnhealthy {
+		color = ColorRed
+	}
+	return " " + lipgloss.NewStyle().Foreground(color).Render(r.State.Symbol())
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestMCPHealthTracker_ClaimHonorsTTL(t *testing.T) {
+	var tr mcpHealthTracker
+	now := time.Now()
+	if !tr.claim("s1", now) {
+		t.Fatal("first claim should be due")
+	}
+	if tr.claim("s1", now.Add(time.Second)) {
+		t.Error("a second claim within the TTL should not start another probe")
+	}
+	if !tr.claim("s1", now.Add(mcpHealthTTL)) {
+		t.Error("a claim after the TTL should be due again")
+	}
+
+	tr.store("s1", []session.MCPHealth{{Name: "github", State: session.MCPUnhealthy, Detail: "pool: proxy failed"}})
+	health := tr.get("s1")
+	if mark := mcpHealthMark(health, "github"); !strings.Contains(mark, "✗") {
+		t.Errorf("mark = %q, want ✗", mark)
+	}
+	if mark := mcpHealthMark(health, "slack"); mark != "" {
+		t.Errorf("unprobed MCP mark = %q, want empty", mark)
+	}
+
+	tr.forget("s1")
+	if tr.get("s1") != nil {
+		t.Error("forget should drop the results")
+	}
+}
+
+func TestMCPDialog_ShowsHealthOfSelectedMCP(t *testing.T) {
+	d := NewMCPDialog()
+	d.visible = true
+	d.width, d.height = 120, 40
+	d.localAttached = []MCPItem{{Name: "github"}}
+	d.SetHealth(map[string]session.MCPHealth{
+		"github": {Name: "github", State: session.MCPUnhealthy, Detail: "pool: socket not accepting connections"},
+	})
+	if view := d.View(); !strings.Contains(view, "socket not accepting connections") {
+		t.Errorf("dialog should describe the selected MCP's health:\n%s", view)
+	}
+}
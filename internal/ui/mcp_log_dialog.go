@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/mcppool"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+const (
+	// mcpLogVisibleRows caps how many log lines the viewer shows at once.
+	mcpLogVisibleRows = 20
+	// mcpLogTailLines is how much of the log the viewer loads.
+	mcpLogTailLines = 500
+)
+
+// MCPLogDialog is an overlay on top of the MCP Manager or pool dashboard
+// showing the tail of a pooled MCP's captured output, so a misbehaving server
+// can be debugged without rerunning it by hand. It opens scrolled to the
+// newest line.
+type MCPLogDialog struct {
+	visible bool
+	name    string
+	path    string
+	lines   []string
+	errMsg  string
+	offset  int
+	width   int
+	height  int
+}
+
+// NewMCPLogDialog constructs a hidden log viewer.
+func NewMCPLogDialog() *MCPLogDialog {
+	return &MCPLogDialog{}
+}
+
+// Show opens the viewer on the log at path for MCP name.
+func (d *MCPLogDialog) Show(name, path string) {
+	d.visible = true
+	d.name = name
+	d.path = path
+	d.Reload()
+}
+
+// Reload re-reads the log and scrolls to the end.
+func (d *MCPLogDialog) Reload() {
+	d.lines, d.errMsg = nil, ""
+	lines, err := mcppool.ReadLogTail(d.path, mcpLogTailLines)
+	switch {
+	case os.IsNotExist(err):
+		d.errMsg = "No output captured yet. Only pooled MCPs (and auto-started HTTP servers) are logged; others run inside the session."
+	case err != nil:
+		d.errMsg = err.Error()
+	default:
+		d.lines = lines
+	}
+	d.offset = d.maxOffset()
+}
+
+// Hide closes the viewer.
+func (d *MCPLogDialog) Hide() {
+	d.visible = false
+}
+
+// IsVisible reports whether the viewer is currently shown.
+func (d *MCPLogDialog) IsVisible() bool { return d.visible }
+
+// SetSize updates the dialog viewport for centering.
+func (d *MCPLogDialog) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+}
+
+func (d *MCPLogDialog) maxOffset() int {
+	return max(0, len(d.lines)-mcpLogVisibleRows)
+}
+
+// Update scrolls the log.
+func (d *MCPLogDialog) Update(msg tea.KeyMsg) *MCPLogDialog {
+	switch msg.String() {
+	case "up", "k":
+		if d.offset > 0 {
+			d.offset--
+		}
+	case "down", "j":
+		if d.offset < d.maxOffset() {
+			d.offset++
+		}
+	case "pgup", "ctrl+u":
+		d.offset = max(0, d.offset-mcpLogVisibleRows)
+	case "pgdown", "ctrl+d":
+		d.offset = min(d.maxOffset(), d.offset+mcpLogVisibleRows)
+	case "home", "g":
+		d.offset = 0
+	case "end", "G":
+		d.offset = d.maxOffset()
+	case "r":
+		d.Reload()
+	}
+	return d
+}
+
+// View renders the overlay, centered in the viewport.
+func (d *MCPLogDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	dialogWidth := fitDialogWidth(100, 44, d.width)
+	title := DialogTitleStyle.Render("MCP Log: " + d.name)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	lineStyle := lipgloss.NewStyle().Foreground(ColorText)
+	markStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+
+	var body string
+	if d.errMsg != "" {
+		body = lipgloss.NewStyle().Foreground(ColorYellow).Width(dialogWidth - 4).Render(d.errMsg)
+	} else if len(d.lines) == 0 {
+		body = dimStyle.Render("Log is empty.")
+	} else {
+		end := min(len(d.lines), d.offset+mcpLogVisibleRows)
+		rows := make([]string, 0, end-d.offset)
+		for _, line := range d.lines[d.offset:end] {
+			style := lineStyle
+			if strings.HasPrefix(line, "=== ") {
+				style = markStyle
+			}
+			rows = append(rows, style.Render(cellTruncate(line, max(10, dialogWidth-4), "...")))
+		}
+		body = strings.Join(rows, "\n")
+	}
+
+	position := ""
+	if len(d.lines) > mcpLogVisibleRows {
+		end := min(len(d.lines), d.offset+mcpLogVisibleRows)
+		position = dimStyle.Render(fmt.Sprintf("%d–%d of %d", d.offset+1, end, len(d.lines)))
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	hint := hintStyle.Render("↑/↓ scroll │ g/G top/bottom │ r reload │ Esc back")
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		dimStyle.Render(truncatePath(d.path, dialogWidth-4)),
+		"",
+		body,
+		"",
+		position,
+		hint,
+	)
+
+	dialog := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(content)
+
+	return lipgloss.Place(
+		d.width,
+		d.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+// mcpLogPath returns where name's output is captured: the HTTP server log
+// for HTTP MCPs agent-deck starts itself, the socket proxy log otherwise.
+func mcpLogPath(name string) string {
+	if def, ok := session.GetAvailableMCPs()[name]; ok && def.HasAutoStartServer() {
+		return mcppool.HTTPServerLogPath(name)
+	}
+	return mcppool.SocketLogPath(name)
+}
+
+// showMCPLog opens the log viewer for MCP name over the current dialog.
+func (h *Home) showMCPLog(name string) {
+	if name == "" {
+		return
+	}
+	h.mcpLogDialog.SetSize(h.width, h.height)
+	h.mcpLogDialog.Show(name, mcpLogPath(name))
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMCPLogDialog_OpensAtTailAndScrolls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exa_socket.log")
+	var b strings.Builder
+	b.WriteString("=== 2026-10-16T07:00:00Z started: npx -y exa\n")
+	for i := 1; i <= 40; i++ {
+		fmt.Fprintf(&b, "stderr line %d\n", i)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewMCPLogDialog()
+	d.SetSize(140, 50)
+	d.Show("exa", path)
+	view := d.View()
+	if !strings.Contains(view, "stderr line 40") || strings.Contains(view, "stderr line 5\n") {
+		t.Fatal("viewer should open scrolled to the newest lines")
+	}
+	if !strings.Contains(view, "22–41 of 41") {
+		t.Errorf("position indicator missing from view")
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if view := d.View(); !strings.Contains(view, "started: npx -y exa") || strings.Contains(view, "stderr line 40") {
+		t.Error("g should jump to the start of the log")
+	}
+}
+
+func TestMCPLogDialog_MissingLogExplains(t *testing.T) {
+	d := NewMCPLogDialog()
+	d.SetSize(140, 50)
+	d.Show("github", filepath.Join(t.TempDir(), "github_socket.log"))
+	if !strings.Contains(d.View(), "No output captured yet") {
+		t.Error("a missing log should explain which MCPs are captured")
+	}
+}
@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/mcppool"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// MCPPoolDialog is an overlay listing every pooled MCP proxy with its uptime,
+// connected sessions, restart count and recent errors. Home performs the
+// restart/stop actions against the global pool and reports back.
+type MCPPoolDialog struct {
+	visible  bool
+	running  bool // false when the pool is disabled or not initialized
+	proxies  []mcppool.ProxyInfo
+	cursor   int
+	notice   string
+	noticeOK bool
+	width    int
+	height   int
+}
+
+// NewMCPPoolDialog constructs a hidden pool dashboard.
+func NewMCPPoolDialog() *MCPPoolDialog {
+	return &MCPPoolDialog{}
+}
+
+// Show opens the dashboard. running is false when there is no pool to show.
+func (d *MCPPoolDialog) Show(proxies []mcppool.ProxyInfo, running bool) {
+	d.visible = true
+	d.running = running
+	d.cursor = 0
+	d.notice = ""
+	d.SetProxies(proxies)
+}
+
+// Hide closes the dashboard.
+func (d *MCPPoolDialog) Hide() {
+	d.visible = false
+}
+
+// IsVisible reports whether the dashboard is currently shown.
+func (d *MCPPoolDialog) IsVisible() bool { return d.visible }
+
+// SetProxies refreshes the list, keeping the cursor on the same MCP.
+func (d *MCPPoolDialog) SetProxies(proxies []mcppool.ProxyInfo) {
+	selected := ""
+	if sel := d.Selected(); sel != nil {
+		selected = sel.Name
+	}
+	d.proxies = proxies
+	for i, p := range proxies {
+		if p.Name == selected {
+			d.cursor = i
+			return
+		}
+	}
+	if d.cursor >= len(d.proxies) {
+		d.cursor = max(len(d.proxies)-1, 0)
+	}
+}
+
+// Selected returns the highlighted proxy, or nil when the list is empty.
+func (d *MCPPoolDialog) Selected() *mcppool.ProxyInfo {
+	if d.cursor < 0 || d.cursor >= len(d.proxies) {
+		return nil
+	}
+	return &d.proxies[d.cursor]
+}
+
+// SetNotice shows a one-line result above the list; err takes precedence.
+func (d *MCPPoolDialog) SetNotice(text string, err error) {
+	d.notice, d.noticeOK = text, true
+	if err != nil {
+		d.notice, d.noticeOK = err.Error(), false
+	}
+}
+
+// SetSize updates the dialog viewport for centering.
+func (d *MCPPoolDialog) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+}
+
+// Update moves the cursor.
+func (d *MCPPoolDialog) Update(msg tea.KeyMsg) *MCPPoolDialog {
+	switch msg.String() {
+	case "up", "k", "ctrl+p":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j", "ctrl+n":
+		if d.cursor < len(d.proxies)-1 {
+			d.cursor++
+		}
+	}
+	return d
+}
+
+// View renders the overlay, centered in the viewport.
+func (d *MCPPoolDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	title := DialogTitleStyle.Render("MCP Pool")
+
+	rowStyle := lipgloss.NewStyle().Foreground(ColorText).Padding(0, 1)
+	selStyle := lipgloss.NewStyle().
+		Foreground(ColorBg).
+		Background(ColorAccent).
+		Bold(true).
+		Padding(0, 1)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	headerStyle := lipgloss.NewStyle().Foreground(ColorComment).Bold(true).Padding(0, 1)
+
+	var listBlock string
+	switch {
+	case !d.running:
+		listBlock = dimStyle.Render("The MCP pool is not running.\nEnable it with [mcp_pool] enabled = true in config.toml.")
+	case len(d.proxies) == 0:
+		listBlock = dimStyle.Render("No pooled MCPs.")
+	default:
+		now := time.Now()
+		rows := []string{headerStyle.Render(fmt.Sprintf("%-18s %-12s %-8s %-7s %s",
+			"NAME", "STATUS", "UPTIME", "CLIENTS", "RESTARTS"))}
+		for i, p := range d.proxies {
+			status := p.Status
+			if p.External {
+				status = "external"
+			}
+			uptime := "-"
+			if !p.RunningSince.IsZero() {
+				uptime = formatDuration(now.Sub(p.RunningSince).Truncate(time.Second))
+			}
+			line := fmt.Sprintf("%-18s %-12s %-8s %-7d %d",
+				truncatePath(p.Name, 18), truncatePath(status, 12), uptime, p.Clients, p.Restarts)
+			if i == d.cursor {
+				rows = append(rows, selStyle.Render(line))
+			} else {
+				rows = append(rows, rowStyle.Render(line))
+			}
+		}
+		listBlock = strings.Join(rows, "\n")
+	}
+	if d.notice != "" {
+		color, prefix := ColorGreen, "✓ "
+		if !d.noticeOK {
+			color, prefix = ColorRed, "⚠ "
+		}
+		listBlock = lipgloss.NewStyle().Foreground(color).Render(prefix+d.notice) + "\n" + listBlock
+	}
+
+	var detail []string
+	if sel := d.Selected(); sel != nil {
+		detail = append(detail, dimStyle.Render(truncatePath(sel.SocketPath, 60)))
+		if sel.External {
+			detail = append(detail, dimStyle.Render("Owned by another agent-deck instance; manage it there."))
+		} else if sel.PID > 0 {
+			detail = append(detail, dimStyle.Render(fmt.Sprintf("pid %d · %d failures", sel.PID, sel.Failures)))
+		}
+		if len(sel.RecentErrors) == 0 {
+			detail = append(detail, dimStyle.Render("No recent errors."))
+		} else {
+			errStyle := lipgloss.NewStyle().Foreground(ColorRed)
+			detail = append(detail, dimStyle.Render("Recent errors:"))
+			for i := len(sel.RecentErrors) - 1; i >= 0; i-- {
+				e := sel.RecentErrors[i]
+				age := formatTrashAge(time.Since(e.At))
+				detail = append(detail, errStyle.Render(truncatePath(fmt.Sprintf("  %s ago  %s", age, e.Message), 60)))
+			}
+		}
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	hint := hintStyle.Render("↑/↓ navigate │ r restart │ x stop │ l log │ Esc close")
+
+	parts := []string{title, "", listBlock}
+	if len(detail) > 0 {
+		parts = append(parts, "")
+		parts = append(parts, detail...)
+	}
+	parts = append(parts, "", hint)
+	content := lipgloss.JoinVertical(lipgloss.Left, parts...)
+
+	dialog := DialogBoxStyle.
+		Width(fitDialogWidth(66, 44, d.width)).
+		Render(content)
+
+	return lipgloss.Place(
+		d.width,
+		d.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+// mcpPoolActionMsg reports the outcome of a restart or stop started from the
+// pool dashboard.
+type mcpPoolActionMsg struct {
+	name   string
+	action string // "restarted" or "stopped"
+	err    error
+}
+
+// showMCPPoolDialog opens the dashboard on the global socket pool.
+func (h *Home) showMCPPoolDialog() {
+	h.mcpPoolDialog.SetSize(h.width, h.height)
+	if pool := session.GetGlobalPool(); pool != nil {
+		h.mcpPoolDialog.Show(pool.ListServers(), true)
+		return
+	}
+	h.mcpPoolDialog.Show(nil, false)
+}
+
+// refreshMCPPoolDialog reloads the proxy list while the dashboard is open.
+func (h *Home) refreshMCPPoolDialog() {
+	if pool := session.GetGlobalPool(); pool != nil {
+		h.mcpPoolDialog.SetProxies(pool.ListServers())
+	}
+}
+
+// handleMCPPoolDialogKey restarts or stops the highlighted proxy. Both block
+// on the MCP process, so they run as commands and report via mcpPoolActionMsg.
+func (h *Home) handleMCPPoolDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		h.mcpPoolDialog.Hide()
+		return h, nil
+	case "l":
+		if sel := h.mcpPoolDialog.Selected(); sel != nil {
+			h.showMCPLog(sel.Name)
+		}
+		return h, nil
+	case "r", "x":
+		pool := session.GetGlobalPool()
+		sel := h.mcpPoolDialog.Selected()
+		if pool == nil || sel == nil {
+			return h, nil
+		}
+		name := sel.Name
+		if msg.String() == "r" {
+			h.mcpPoolDialog.SetNotice("restarting "+name+"…", nil)
+			return h, func() tea.Msg {
+				return mcpPoolActionMsg{name: name, action: "restarted", err: pool.RestartProxy(name)}
+			}
+		}
+		h.mcpPoolDialog.SetNotice("stopping "+name+"…", nil)
+		return h, func() tea.Msg {
+			return mcpPoolActionMsg{name: name, action: "stopped", err: pool.StopProxy(name)}
+		}
+	default:
+		h.mcpPoolDialog.Update(msg)
+		return h, nil
+	}
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/mcppool"
+)
+
+func TestMCPPoolDialog_RefreshKeepsSelectionAndShowsErrors(t *testing.T) {
+	d := NewMCPPoolDialog()
+	d.SetSize(120, 40)
+	d.Show([]mcppool.ProxyInfo{
+		{Name: "exa", Status: "running", RunningSince: time.Now().Add(-90 * time.Second), Clients: 2},
+		{Name: "github", Status: "failed", Restarts: 3, RecentErrors: []mcppool.ProxyError{
+			{At: time.Now(), Message: "process exited: exit status 1"},
+		}},
+	}, true)
+	d.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	// A refresh that reorders the list keeps the cursor on the same MCP.
+	d.SetProxies([]mcppool.ProxyInfo{
+		{Name: "github", Status: "failed", Restarts: 3, RecentErrors: []mcppool.ProxyError{
+			{At: time.Now(), Message: "process exited: exit status 1"},
+		}},
+		{Name: "exa", Status: "running"},
+		{Name: "zeta", Status: "running"},
+	})
+	if sel := d.Selected(); sel == nil || sel.Name != "github" {
+		t.Fatalf("selected = %v, want github", sel)
+	}
+
+	view := d.View()
+	for _, want := range []string{"MCP Pool", "github", "exit status 1", "r restart"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	d.SetNotice("", errors.New("proxy github is owned by another agent-deck instance"))
+	if !strings.Contains(d.View(), "owned by another agent-deck instance") {
+		t.Error("view should show the action error")
+	}
+
+	d.SetProxies(nil)
+	if d.Selected() != nil {
+		t.Error("an empty refresh should clear the selection")
+	}
+}
+
+func TestMCPPoolDialog_HotkeyOpensWithoutPool(t *testing.T) {
+	home, _ := newMultiSelectHome(t)
+	home.setHotkeys(resolveHotkeys(nil))
+	home.initialLoading = false
+
+	home.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}, Alt: true})
+	if !home.mcpPoolDialog.IsVisible() {
+		t.Fatal("alt+M should open the MCP pool dashboard")
+	}
+	if !strings.Contains(home.View(), "not running") {
+		t.Error("dashboard should say the pool is not running when there is none")
+	}
+
+	// Actions are no-ops without a pool; Esc closes.
+	if _, cmd := home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}); cmd != nil {
+		t.Error("stop without a pool should not start a command")
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if home.mcpPoolDialog.IsVisible() {
+		t.Error("esc should close the dashboard")
+	}
+}
@@ -0,0 +1,413 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// mcpRegistryMaxRows caps the visible registry rows; the list scrolls past it.
+const mcpRegistryMaxRows = 10
+
+// MCPRegistryDialog browses known MCP servers (built-in list, the user's
+// mcp-registry.toml and an optional remote index) and installs one into
+// config.toml. It opens over the MCP Manager; Enter on a server switches to a
+// short form asking for its config name and required environment variables.
+type MCPRegistryDialog struct {
+	visible   bool
+	loading   bool
+	entries   []session.MCPRegistryEntry
+	warnings  []string
+	installed map[string]bool
+	filter    textinput.Model
+	matches   []int // indexes into entries
+	cursor    int
+	offset    int
+
+	// Install form; form is nil while browsing.
+	form      *session.MCPRegistryEntry
+	inputs    []textinput.Model // name, then one per form.Env
+	focus     int
+	formError string
+
+	width  int
+	height int
+}
+
+// mcpRegistryLoadedMsg delivers the registry, loaded off the UI goroutine
+// because the remote index is fetched over the network.
+type mcpRegistryLoadedMsg struct {
+	entries  []session.MCPRegistryEntry
+	warnings []string
+}
+
+// NewMCPRegistryDialog constructs a hidden registry browser.
+func NewMCPRegistryDialog() *MCPRegistryDialog {
+	ti := textinput.New()
+	ti.Placeholder = "Filter MCP servers..."
+	ti.CharLimit = 100
+	ti.Width = 40
+	return &MCPRegistryDialog{filter: ti}
+}
+
+// Show opens the browser in its loading state. installed holds the MCP names
+// config.toml already defines.
+func (d *MCPRegistryDialog) Show(installed map[string]bool) {
+	d.visible = true
+	d.loading = true
+	d.entries = nil
+	d.warnings = nil
+	d.installed = installed
+	d.form = nil
+	d.filter.SetValue("")
+	d.filter.Focus()
+	d.applyFilter()
+}
+
+// SetEntries fills the list once the registry has loaded.
+func (d *MCPRegistryDialog) SetEntries(entries []session.MCPRegistryEntry, warnings []string) {
+	d.loading = false
+	d.entries = entries
+	d.warnings = warnings
+	d.applyFilter()
+}
+
+// Hide closes the browser.
+func (d *MCPRegistryDialog) Hide() {
+	d.visible = false
+	d.form = nil
+	d.filter.Blur()
+}
+
+// IsVisible reports whether the browser is shown.
+func (d *MCPRegistryDialog) IsVisible() bool { return d.visible }
+
+// InForm reports whether the install form is open.
+func (d *MCPRegistryDialog) InForm() bool { return d.form != nil }
+
+// SetSize updates the viewport used for centering.
+func (d *MCPRegistryDialog) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+}
+
+// Selected returns the highlighted server, or nil when nothing matches.
+func (d *MCPRegistryDialog) Selected() *session.MCPRegistryEntry {
+	if d.cursor < 0 || d.cursor >= len(d.matches) {
+		return nil
+	}
+	return &d.entries[d.matches[d.cursor]]
+}
+
+// registrySource adapts the entries to fuzzy.Source, matching on name and
+// description.
+type registrySource []session.MCPRegistryEntry
+
+func (s registrySource) String(i int) string { return s[i].Name + " " + s[i].Description }
+func (s registrySource) Len() int            { return len(s) }
+
+func (d *MCPRegistryDialog) applyFilter() {
+	d.matches = d.matches[:0]
+	query := strings.TrimSpace(d.filter.Value())
+	if query == "" {
+		for i := range d.entries {
+			d.matches = append(d.matches, i)
+		}
+	} else {
+		for _, m := range fuzzy.FindFrom(query, registrySource(d.entries)) {
+			d.matches = append(d.matches, m.Index)
+		}
+	}
+	d.cursor = 0
+	d.offset = 0
+}
+
+// OpenForm switches to the install form for the highlighted server. Env
+// inputs start from the current environment so a key that is already
+// exported needs no retyping.
+func (d *MCPRegistryDialog) OpenForm() {
+	sel := d.Selected()
+	if sel == nil {
+		return
+	}
+	entry := *sel
+	d.form = &entry
+	d.formError = ""
+	d.filter.Blur()
+
+	name := textinput.New()
+	name.Prompt = ""
+	name.CharLimit = 64
+	name.Width = 30
+	name.SetValue(entry.Name)
+	d.inputs = []textinput.Model{name}
+	for _, key := range entry.Env {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.CharLimit = 512
+		ti.Width = 30
+		ti.EchoMode = textinput.EchoPassword
+		ti.SetValue(os.Getenv(key))
+		d.inputs = append(d.inputs, ti)
+	}
+	d.focus = 0
+	d.inputs[0].Focus()
+}
+
+// CloseForm returns from the install form to the list.
+func (d *MCPRegistryDialog) CloseForm() {
+	d.form = nil
+	d.inputs = nil
+	d.filter.Focus()
+}
+
+// FormValues returns the install form's entry, config name and env values.
+func (d *MCPRegistryDialog) FormValues() (session.MCPRegistryEntry, string, map[string]string) {
+	if d.form == nil {
+		return session.MCPRegistryEntry{}, "", nil
+	}
+	env := make(map[string]string, len(d.form.Env))
+	for i, key := range d.form.Env {
+		if v := strings.TrimSpace(d.inputs[i+1].Value()); v != "" {
+			env[key] = v
+		}
+	}
+	return *d.form, strings.TrimSpace(d.inputs[0].Value()), env
+}
+
+// SetFormError shows why an install failed, keeping the form open.
+func (d *MCPRegistryDialog) SetFormError(err error) {
+	d.formError = ""
+	if err != nil {
+		d.formError = err.Error()
+	}
+}
+
+// Update handles navigation, filtering and form editing. Enter and Esc are
+// handled by the caller.
+func (d *MCPRegistryDialog) Update(msg tea.KeyMsg) (*MCPRegistryDialog, tea.Cmd) {
+	if d.form != nil {
+		switch msg.String() {
+		case "tab", "down":
+			d.moveFocus(1)
+			return d, nil
+		case "shift+tab", "up":
+			d.moveFocus(len(d.inputs) - 1)
+			return d, nil
+		}
+		var cmd tea.Cmd
+		d.inputs[d.focus], cmd = d.inputs[d.focus].Update(msg)
+		return d, cmd
+	}
+
+	switch msg.String() {
+	case "up", "ctrl+p", "ctrl+k":
+		if d.cursor > 0 {
+			d.cursor--
+			if d.cursor < d.offset {
+				d.offset = d.cursor
+			}
+		}
+		return d, nil
+	case "down", "ctrl+n", "ctrl+j":
+		if d.cursor < len(d.matches)-1 {
+			d.cursor++
+			if d.cursor >= d.offset+mcpRegistryMaxRows {
+				d.offset = d.cursor - mcpRegistryMaxRows + 1
+			}
+		}
+		return d, nil
+	}
+	before := d.filter.Value()
+	var cmd tea.Cmd
+	d.filter, cmd = d.filter.Update(msg)
+	if d.filter.Value() != before {
+		d.applyFilter()
+	}
+	return d, cmd
+}
+
+func (d *MCPRegistryDialog) moveFocus(step int) {
+	d.inputs[d.focus].Blur()
+	d.focus = (d.focus + step) % len(d.inputs)
+	d.inputs[d.focus].Focus()
+}
+
+// View renders the overlay, centered in the viewport.
+func (d *MCPRegistryDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	dialogWidth := fitDialogWidth(72, 48, d.width)
+	textWidth := dialogWidth - 4
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+
+	var parts []string
+	if d.form != nil {
+		parts = d.formView(textWidth, dimStyle)
+		parts = append(parts, "", hintStyle.Render("Tab next field │ Enter add to config.toml │ Esc back"))
+	} else {
+		parts = d.listView(textWidth, dimStyle)
+		parts = append(parts, "", hintStyle.Render("Type filter │ ↑/↓ navigate │ Enter add │ Esc close"))
+	}
+
+	dialog := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+
+	return lipgloss.Place(
+		d.width,
+		d.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+func (d *MCPRegistryDialog) listView(textWidth int, dimStyle lipgloss.Style) []string {
+	parts := []string{DialogTitleStyle.Render("MCP Registry"), "", d.filter.View(), ""}
+
+	switch {
+	case d.loading:
+		parts = append(parts, dimStyle.Render("Loading registry..."))
+	case len(d.matches) == 0:
+		parts = append(parts, dimStyle.Render("No matching MCP servers."))
+	default:
+		rowStyle := lipgloss.NewStyle().Foreground(ColorText).Padding(0, 1)
+		selStyle := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorAccent).
+			Bold(true).
+			Padding(0, 1)
+		end := min(len(d.matches), d.offset+mcpRegistryMaxRows)
+		for i := d.offset; i < end; i++ {
+			e := d.entries[d.matches[i]]
+			mark := " "
+			if d.installed[e.Name] {
+				mark = "✓"
+			}
+			line := cellTruncate(fmt.Sprintf("%s %-20s %s", mark, e.Name, e.Description), max(10, textWidth-2), "...")
+			if i == d.cursor {
+				parts = append(parts, selStyle.Render(line))
+			} else {
+				parts = append(parts, rowStyle.Render(line))
+			}
+		}
+	}
+
+	if sel := d.Selected(); sel != nil && !d.loading {
+		parts = append(parts, "")
+		parts = append(parts, d.entryDetail(*sel, textWidth, dimStyle)...)
+	}
+	if len(d.warnings) > 0 {
+		warnStyle := lipgloss.NewStyle().Foreground(ColorYellow).Width(textWidth)
+		parts = append(parts, "")
+		for _, w := range d.warnings {
+			parts = append(parts, warnStyle.Render("⚠ "+w))
+		}
+	}
+	return parts
+}
+
+// entryDetail describes how a server is launched and set up.
+func (d *MCPRegistryDialog) entryDetail(e session.MCPRegistryEntry, textWidth int, dimStyle lipgloss.Style) []string {
+	labelStyle := lipgloss.NewStyle().Foreground(ColorCyan)
+	detail := []string{labelStyle.Render("Runs:    ") + cellTruncate(e.CommandLine(), max(10, textWidth-9), "...")}
+	if e.Install != "" {
+		detail = append(detail, labelStyle.Render("Setup:   ")+cellTruncate(e.Install, max(10, textWidth-9), "..."))
+	}
+	if len(e.Env) > 0 {
+		detail = append(detail, labelStyle.Render("Needs:   ")+strings.Join(e.Env, ", "))
+	}
+	if e.Homepage != "" {
+		detail = append(detail, dimStyle.Render(cellTruncate(e.Homepage, textWidth, "...")))
+	}
+	if d.installed[e.Name] {
+		detail = append(detail, dimStyle.Render("Already in config.toml; add it under another name to keep both."))
+	}
+	return detail
+}
+
+func (d *MCPRegistryDialog) formView(textWidth int, dimStyle lipgloss.Style) []string {
+	parts := []string{DialogTitleStyle.Render("Add MCP: " + d.form.Name), ""}
+	parts = append(parts, d.entryDetail(*d.form, textWidth, dimStyle)...)
+	parts = append(parts, "")
+
+	labels := append([]string{"Name"}, d.form.Env...)
+	labelWidth := 0
+	for _, l := range labels {
+		labelWidth = max(labelWidth, len(l))
+	}
+	for i, l := range labels {
+		style := lipgloss.NewStyle().Foreground(ColorTextDim)
+		if i == d.focus {
+			style = lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+		}
+		parts = append(parts, style.Render(fmt.Sprintf("%-*s ", labelWidth, l))+d.inputs[i].View())
+	}
+	if len(d.form.Env) > 0 {
+		parts = append(parts, "", dimStyle.Width(textWidth).Render("Env values are stored in config.toml. Leave one empty to fill it in later."))
+	}
+	if d.formError != "" {
+		parts = append(parts, "", lipgloss.NewStyle().Foreground(ColorRed).Width(textWidth).Render("⚠ "+d.formError))
+	}
+	return parts
+}
+
+// showMCPRegistry opens the registry browser over the MCP Manager and loads
+// the registry in the background.
+func (h *Home) showMCPRegistry() tea.Cmd {
+	installed := make(map[string]bool)
+	for _, name := range session.GetAvailableMCPNames() {
+		installed[name] = true
+	}
+	h.mcpRegistryDialog.SetSize(h.width, h.height)
+	h.mcpRegistryDialog.Show(installed)
+	return func() tea.Msg {
+		entries, warnings := session.LoadMCPRegistry(context.Background())
+		return mcpRegistryLoadedMsg{entries: entries, warnings: warnings}
+	}
+}
+
+// handleMCPRegistryDialogKey browses the registry and installs the chosen
+// server. A new MCP is added to the MCP Manager's Available column so it can
+// be attached right away.
+func (h *Home) handleMCPRegistryDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := h.mcpRegistryDialog
+	switch msg.String() {
+	case "esc":
+		if d.InForm() {
+			d.CloseForm()
+		} else {
+			d.Hide()
+		}
+		return h, nil
+	case "enter":
+		if !d.InForm() {
+			d.OpenForm()
+			return h, nil
+		}
+		entry, name, env := d.FormValues()
+		if err := session.InstallMCPFromRegistry(entry, name, env); err != nil {
+			d.SetFormError(err)
+			return h, nil
+		}
+		d.Hide()
+		if h.mcpDialog.IsVisible() {
+			h.mcpDialog.AddAvailable(name, entry)
+		}
+		return h, nil
+	}
+	_, cmd := d.Update(msg)
+	return h, cmd
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestMCPRegistryDialog_FilterAndForm(t *testing.T) {
+	t.Setenv("EXA_API_KEY", "from-env")
+	d := NewMCPRegistryDialog()
+	d.SetSize(120, 50)
+	d.Show(map[string]bool{"memory": true})
+	if !strings.Contains(d.View(), "Loading registry") {
+		t.Fatal("dialog should show its loading state until entries arrive")
+	}
+
+	d.SetEntries([]session.MCPRegistryEntry{
+		{Name: "exa", Description: "Web search", Env: []string{"EXA_API_KEY"}, MCP: session.MCPDef{Command: "npx", Args: []string{"-y", "exa-mcp-server"}}},
+		{Name: "memory", Description: "Knowledge graph", MCP: session.MCPDef{Command: "npx"}},
+	}, []string{"mcp_registry url: unreachable"})
+	view := d.View()
+	for _, want := range []string{"✓ memory", "npx -y exa-mcp-server", "EXA_API_KEY", "unreachable"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	for _, r := range "graph" {
+		d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if sel := d.Selected(); sel == nil || sel.Name != "memory" {
+		t.Fatalf("filter should match descriptions, selected %v", sel)
+	}
+
+	d.filter.SetValue("")
+	d.applyFilter()
+	d.OpenForm()
+	if !d.InForm() {
+		t.Fatal("OpenForm should switch to the install form")
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	entry, name, env := d.FormValues()
+	if entry.Name != "exa" || name != "exa2" || env["EXA_API_KEY"] != "from-env" {
+		t.Fatalf("form = %q %q %v, want name edited and env prefilled", entry.Name, name, env)
+	}
+
+	d.CloseForm()
+	if d.InForm() || !d.IsVisible() {
+		t.Fatal("CloseForm should return to the list")
+	}
+}
+
+func TestMCPDialog_AddAvailableSelectsNewMCP(t *testing.T) {
+	dialog := NewMCPDialog()
+	dialog.visible = true
+	dialog.scope = MCPScopeGlobal
+	dialog.globalAvailable = []MCPItem{{Name: "memory"}}
+
+	dialog.AddAvailable("search", session.MCPRegistryEntry{MCP: session.MCPDef{URL: "https://mcp.example.com"}})
+	if dialog.column != MCPColumnAvailable || dialog.SelectedName() != "search" {
+		t.Fatalf("new MCP should be selected in Available, got %q", dialog.SelectedName())
+	}
+	if len(dialog.localAvailable) != 1 || dialog.localAvailable[0].Transport != "http" {
+		t.Fatalf("new MCP should be listed in every scope with its transport: %+v", dialog.localAvailable)
+	}
+}
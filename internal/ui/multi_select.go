@@ -0,0 +1,282 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Multi-select lets delete, move-to-group, restart, acknowledge, mark-unread
+// and prompt act on many sessions at once. The toggle_select key marks the session under
+// the cursor (or every session in the group under the cursor); while anything
+// is marked those action keys apply to the marked set instead of the cursor
+// row, and Esc clears the marks. Marks are session IDs, so they survive list
+// rebuilds, filtering and reloads; IDs that no longer resolve are dropped.
+
+// hasMarkedSessions reports whether a bulk selection is active.
+func (h *Home) hasMarkedSessions() bool {
+	return len(h.markedSessions) > 0
+}
+
+// isSessionMarked reports whether id is part of the bulk selection.
+func (h *Home) isSessionMarked(id string) bool {
+	return h.markedSessions[id]
+}
+
+// clearMarkedSessions drops the bulk selection.
+func (h *Home) clearMarkedSessions() {
+	h.markedSessions = nil
+}
+
+// toggleMarkAtCursor marks or unmarks the row under the cursor. On a group
+// row every session in that group (and its subgroups) is marked, or all are
+// unmarked when they already were.
+func (h *Home) toggleMarkAtCursor() {
+	if h.cursor < 0 || h.cursor >= len(h.flatItems) {
+		return
+	}
+	item := h.flatItems[h.cursor]
+	var ids []string
+	switch {
+	case item.Type == session.ItemTypeSession && item.Session != nil:
+		ids = []string{item.Session.ID}
+	case item.Type == session.ItemTypeGroup:
+		ids = h.sessionIDsInGroup(item.Path)
+	default:
+		return
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	allMarked := true
+	for _, id := range ids {
+		if !h.markedSessions[id] {
+			allMarked = false
+			break
+		}
+	}
+	if h.markedSessions == nil {
+		h.markedSessions = make(map[string]bool)
+	}
+	for _, id := range ids {
+		if allMarked {
+			delete(h.markedSessions, id)
+		} else {
+			h.markedSessions[id] = true
+		}
+	}
+}
+
+// sessionIDsInGroup returns the IDs of sessions in groupPath or below,
+// matching the archived/live view the list is currently showing.
+func (h *Home) sessionIDsInGroup(groupPath string) []string {
+	wantArchived := h.statusFilter == FilterModeArchived
+	h.instancesMu.RLock()
+	defer h.instancesMu.RUnlock()
+	var ids []string
+	for _, inst := range h.instances {
+		if inst.IsArchived() != wantArchived {
+			continue
+		}
+		if inst.GroupPath == groupPath || strings.HasPrefix(inst.GroupPath, groupPath+"/") {
+			ids = append(ids, inst.ID)
+		}
+	}
+	return ids
+}
+
+// markedInstances resolves the bulk selection to live instances, pruning IDs
+// that were deleted or reloaded away in the meantime.
+func (h *Home) markedInstances() []*session.Instance {
+	h.instancesMu.RLock()
+	defer h.instancesMu.RUnlock()
+	out := make([]*session.Instance, 0, len(h.markedSessions))
+	for _, inst := range h.instances {
+		if h.markedSessions[inst.ID] {
+			out = append(out, inst)
+		}
+	}
+	if len(out) != len(h.markedSessions) {
+		pruned := make(map[string]bool, len(out))
+		for _, inst := range out {
+			pruned[inst.ID] = true
+		}
+		h.markedSessions = pruned
+	}
+	return out
+}
+
+// handleMarkedKey routes an action key to its bulk variant while sessions are
+// marked. handled is false for keys that keep their normal meaning.
+func (h *Home) handleMarkedKey(key string) (cmd tea.Cmd, handled bool) {
+	switch key {
+	case "esc":
+		h.clearMarkedSessions()
+		return nil, true
+
+	case defaultHotkeyBindings[hotkeyDelete]:
+		targets := h.markedInstances()
+		if len(targets) == 0 {
+			return nil, true
+		}
+		ids := make([]string, 0, len(targets))
+		worktrees := 0
+		for _, inst := range targets {
+			ids = append(ids, inst.ID)
+			if inst.IsWorktree() {
+				worktrees++
+			}
+		}
+		h.confirmDialog.ShowBulkDeleteSessions(ids, worktrees)
+		if worktrees > 0 {
+			h.confirmDialog.SetWorktreeCleanup(session.DefaultWorktreeCleanup())
+		}
+		return nil, true
+
+	case defaultHotkeyBindings[hotkeyMoveToGroup], "shift+m":
+		if len(h.markedInstances()) > 0 {
+			h.groupDialog.ShowMove(h.scopedGroupPaths())
+		}
+		return nil, true
+
+	case defaultHotkeyBindings[hotkeyRestart]:
+		return h.bulkRestartMarked(), true
+
+	case defaultHotkeyBindings[hotkeyQuickApprove]:
+		// Bulk "a" acknowledges rather than approves: sending "1"+Enter to a
+		// whole batch of panes is never what a multi-select user means.
+		h.bulkSetAcknowledged(true)
+		return nil, true
+
+	case defaultHotkeyBindings[hotkeyMarkUnread]:
+		h.bulkSetAcknowledged(false)
+		return nil, true
+
+	case defaultHotkeyBindings[hotkeyPromptSession]:
+		targets := h.markedInstances()
+		ids := make([]string, len(targets))
+		for i, inst := range targets {
+			ids[i] = inst.ID
+		}
+		h.openBroadcastPrompt(ids, "marked sessions")
+		return nil, true
+	}
+	return nil, false
+}
+
+// bulkDeleteSessions deletes every session in ids through the same path as a
+// single delete, so worktree teardown behaves identically. Pinned
+// sessions are skipped (pin-protects-from-stop).
+func (h *Home) bulkDeleteSessions(ids []string, cleanup session.WorktreeCleanup) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(ids))
+	skipped := 0
+	for _, id := range ids {
+		inst := h.getInstanceByID(id)
+		if inst == nil {
+			continue
+		}
+		if inst.Pin != session.PinNone {
+			skipped++
+			continue
+		}
+		cmds = append(cmds, h.deleteSession(inst, cleanup))
+	}
+	h.clearMarkedSessions()
+	if skipped > 0 {
+		h.setError(fmt.Errorf("skipped %d pinned session(s); unpin to delete", skipped))
+	}
+	return tea.Batch(cmds...)
+}
+
+// bulkMoveMarked moves every marked session to targetGroupPath.
+func (h *Home) bulkMoveMarked(targetGroupPath string) {
+	for _, inst := range h.markedInstances() {
+		h.groupTree.MoveSessionToGroup(inst, targetGroupPath)
+	}
+	h.instancesMu.Lock()
+	h.instances = h.groupTree.GetAllInstances()
+	h.instancesMu.Unlock()
+	h.clearMarkedSessions()
+	h.rebuildFlatItems()
+	h.saveInstances()
+}
+
+// bulkRestartMarked restarts every marked session that is not already
+// mid-animation, exactly as pressing R on each row would.
+func (h *Home) bulkRestartMarked() tea.Cmd {
+	targets := h.markedInstances()
+	cmds := make([]tea.Cmd, 0, len(targets))
+	for _, inst := range targets {
+		if !inst.CanRestart() || h.hasActiveAnimation(inst.ID) {
+			continue
+		}
+		h.resumingSessions[inst.ID] = time.Now()
+		cmds = append(cmds, h.restartSession(inst, session.RestartReasonManual))
+	}
+	h.clearMarkedSessions()
+	return tea.Batch(cmds...)
+}
+
+// bulkSetAcknowledged marks every marked session read (ack=true, as attaching
+// would) or unread (ack=false, as u does on one row).
+func (h *Home) bulkSetAcknowledged(ack bool) {
+	db := statedb.GetGlobal()
+	for _, inst := range h.markedInstances() {
+		tmuxSess := inst.GetTmuxSession()
+		if tmuxSess == nil {
+			continue
+		}
+		if ack {
+			tmuxSess.Acknowledge()
+		} else {
+			tmuxSess.ResetAcknowledged()
+		}
+		if db != nil {
+			_ = db.SetAcknowledged(inst.ID, ack)
+		}
+		inst.ForceNextStatusCheck()
+		_ = inst.UpdateStatus()
+	}
+	h.clearMarkedSessions()
+	h.saveInstances()
+}
+
+// renderMarkedBar replaces the help bar while sessions are marked, listing
+// the bulk actions with the user's bound keys.
+func (h *Home) renderMarkedBar() string {
+	borderStyle := lipgloss.NewStyle().Foreground(ColorBorder)
+	border := borderStyle.Render(repeatRune('─', max(0, h.width)))
+
+	badge := lipgloss.NewStyle().
+		Foreground(ColorBg).
+		Background(ColorAccent).
+		Bold(true).
+		Padding(0, 1).
+		Render(fmt.Sprintf("✓ %d marked", len(h.markedSessions)))
+
+	var hints []string
+	for _, a := range []struct{ action, label string }{
+		{hotkeyToggleSelect, "mark"},
+		{hotkeyDelete, "delete"},
+		{hotkeyMoveToGroup, "move"},
+		{hotkeyRestart, "restart"},
+		{hotkeyQuickApprove, "acknowledge"},
+		{hotkeyMarkUnread, "unread"},
+		{hotkeyPromptSession, "prompt"},
+	} {
+		if key := h.actionKey(a.action); key != "" {
+			hints = append(hints, key+" "+a.label)
+		}
+	}
+	hints = append(hints, "Esc clear")
+
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	line := badge + "  " + hintStyle.Render(strings.Join(hints, " · "))
+	return lipgloss.JoinVertical(lipgloss.Left, border, line)
+}
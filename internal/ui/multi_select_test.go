@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newMultiSelectHome(t *testing.T) (*Home, []*session.Instance) {
+	t.Helper()
+	home := NewHome()
+	t.Cleanup(home.cancel)
+	home.width = 120
+	home.height = 40
+
+	a := session.NewInstanceWithGroupAndTool("a", "/tmp/a", "work", "shell")
+	b := session.NewInstanceWithGroupAndTool("b", "/tmp/b", "work", "shell")
+	c := session.NewInstanceWithGroupAndTool("c", "/tmp/c", "work/sub", "shell")
+	d := session.NewInstanceWithGroupAndTool("d", "/tmp/d", "other", "shell")
+	insts := []*session.Instance{a, b, c, d}
+	home.instancesMu.Lock()
+	home.instances = insts
+	for _, inst := range insts {
+		home.instanceByID[inst.ID] = inst
+	}
+	home.instancesMu.Unlock()
+	home.groupTree = session.NewGroupTree(home.instances)
+	home.rebuildFlatItems()
+	return home, insts
+}
+
+func moveCursorTo(t *testing.T, home *Home, match func(session.Item) bool) {
+	t.Helper()
+	for i, item := range home.flatItems {
+		if match(item) {
+			home.cursor = i
+			return
+		}
+	}
+	t.Fatal("row not found")
+}
+
+func pressKey(home *Home, r rune) {
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+}
+
+func TestMultiSelect_MarkSessionAndGroup(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+
+	moveCursorTo(t, home, func(it session.Item) bool { return it.Type == session.ItemTypeGroup && it.Path == "work" })
+	pressKey(home, 'V')
+	for _, inst := range insts[:3] {
+		if !home.isSessionMarked(inst.ID) {
+			t.Fatalf("group mark should include %s (incl. subgroup)", inst.Title)
+		}
+	}
+	if home.isSessionMarked(insts[3].ID) {
+		t.Fatal("session outside the group was marked")
+	}
+
+	// Marking an already fully-marked group unmarks it.
+	pressKey(home, 'V')
+	if home.hasMarkedSessions() {
+		t.Fatalf("second press should clear the group, got %v", home.markedSessions)
+	}
+
+	moveCursorTo(t, home, func(it session.Item) bool { return it.Session == insts[3] })
+	pressKey(home, 'V')
+	if !home.isSessionMarked(insts[3].ID) || len(home.markedSessions) != 1 {
+		t.Fatalf("marked = %v, want only d", home.markedSessions)
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if home.hasMarkedSessions() {
+		t.Fatal("Esc should clear marks")
+	}
+}
+
+func TestMultiSelect_DeleteConfirmsAllMarked(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	home.markedSessions = map[string]bool{insts[0].ID: true, insts[3].ID: true, "gone": true}
+
+	pressKey(home, 'd')
+	if !home.confirmDialog.IsVisible() || home.confirmDialog.GetConfirmType() != ConfirmBulkDeleteSessions {
+		t.Fatal("d with marks should open the bulk delete confirmation")
+	}
+	if ids := home.confirmDialog.GetTargetIDs(); len(ids) != 2 {
+		t.Fatalf("target IDs = %v, want the two live marked sessions", ids)
+	}
+	if home.isSessionMarked("gone") {
+		t.Fatal("stale marks should be pruned")
+	}
+}
+
+func TestMultiSelect_MoveMarked(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	home.markedSessions = map[string]bool{insts[0].ID: true, insts[2].ID: true}
+
+	home.bulkMoveMarked("other")
+	for _, inst := range []*session.Instance{insts[0], insts[2]} {
+		if inst.GroupPath != "other" {
+			t.Fatalf("%s group = %q, want other", inst.Title, inst.GroupPath)
+		}
+	}
+	if insts[1].GroupPath != "work" {
+		t.Fatal("unmarked session moved")
+	}
+	if home.hasMarkedSessions() {
+		t.Fatal("marks should clear after the move")
+	}
+}
+
+func TestMultiSelect_UnmarkedKeysKeepNormalMeaning(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	moveCursorTo(t, home, func(it session.Item) bool { return it.Session == insts[0] })
+
+	pressKey(home, 'd')
+	if home.confirmDialog.GetConfirmType() != ConfirmDeleteSession {
+		t.Fatal("d without marks should confirm a single delete")
+	}
+}
@@ -134,6 +134,8 @@ const (
 	focusInherited             // inherited Docker settings toggle (conditional).
 	focusBranch                // branch input (conditional — only when worktree enabled).
 	focusOptions               // tool-specific options panel (conditional).
+	focusTemplate              // session template picker (conditional — only when [templates] exist).
+	focusPrompt                // initial prompt input, sent once the agent is ready.
 )
 
 // New session dialog: outer box and textinput widths stay in sync so long
@@ -159,6 +161,7 @@ type NewDialog struct {
 	pathInput             textinput.Model
 	commandInput          textinput.Model
 	modelInput            textinput.Model
+	promptInput           textinput.Model     // initial prompt (Instance.InitialPrompt)
 	claudeOptions         *ClaudeOptionsPanel // Claude-specific options (concrete for value extraction).
 	geminiOptions         *YoloOptionsPanel   // Gemini YOLO panel (concrete for value extraction).
 	codexOptions          *YoloOptionsPanel   // Codex YOLO panel (concrete for value extraction).
@@ -214,6 +217,15 @@ type NewDialog struct {
 	// Conducting parent selector.
 	conductorSessions []*session.Instance // nil when no conductors; populated by ShowInGroup
 	conductorCursor   int                 // 0 = "None", 1..N index into conductorSessions
+	// Session templates ([templates.<name>] in config.toml).
+	templateNames         []string
+	templates             map[string]session.SessionTemplate
+	templateCursor        int             // 0 = none, 1..N index into templateNames
+	templateBase          *dialogSnapshot // form state before the first template was applied
+	templateBaseGroupPath string
+	templateBaseGroupName string
+	templateBaseExtraArgs []string
+	groupMCPs             []string // MCPs from the group's session defaults (ApplyGroupDefaults)
 
 	// enterAdvances mirrors config.toml [ui] new_session_enter_advances (PR
 	// #1295). False (default) preserves today's behavior: Enter on the free-text
@@ -261,7 +273,7 @@ func displayCommandPreset(cmd string) string {
 // flag off FilterVisibleToolNames is a no-op, so the list is byte-identical to
 // before.
 func buildPresetCommands() []string {
-	presets := []string{"", "claude", "gemini", "opencode", "codex", "pi", "copilot", "crush", "cursor", "hermes"}
+	presets := []string{"", "claude", "gemini", "opencode", "codex", "pi", "copilot", "crush", "cursor", "hermes", "aider"}
 	if customTools := session.GetCustomToolNames(); len(customTools) > 0 {
 		presets = append(presets, customTools...)
 	}
@@ -356,6 +368,11 @@ func NewNewDialog() *NewDialog {
 	modelInput.Placeholder = "tool default"
 	modelInput.CharLimit = 128
 
+	// Initial prompt, delivered once the tool is ready (any tool).
+	promptInput := textinput.New()
+	promptInput.Placeholder = "optional first instruction, sent when the agent is ready"
+	promptInput.CharLimit = 4000
+
 	// Create branch input for worktree
 	branchInput := textinput.New()
 	branchInput.Placeholder = "feature/branch-name"
@@ -366,6 +383,7 @@ func NewNewDialog() *NewDialog {
 		pathInput:       pathInput,
 		commandInput:    commandInput,
 		modelInput:      modelInput,
+		promptInput:     promptInput,
 		branchInput:     branchInput,
 		branchPicker:    NewBranchPickerDialog(),
 		claudeOptions:   NewClaudeOptionsPanel(),
@@ -424,6 +442,8 @@ func (d *NewDialog) ShowInGroup(groupPath, groupName, defaultPath string, conduc
 	d.pathInput.Blur()
 	d.modelInput.SetValue("")
 	d.modelInput.Blur()
+	d.promptInput.SetValue("")
+	d.promptInput.Blur()
 	d.claudeOptions.Blur()
 	d.claudeOptions.ResetStartQuery() // #741: per-session query must not leak across openings
 	d.geminiOptions.Blur()
@@ -448,6 +468,8 @@ func (d *NewDialog) ShowInGroup(groupPath, groupName, defaultPath string, conduc
 	d.sandboxEnabled = false
 	d.inheritedExpanded = false
 	d.inheritedSettings = nil
+	d.loadTemplates(nil)
+	d.groupMCPs = nil
 	// Set path input to group's default path if provided, otherwise use current working directory.
 	if defaultPath != "" {
 		d.pathInput.SetValue(defaultPath)
@@ -481,6 +503,7 @@ func (d *NewDialog) ShowInGroup(groupPath, groupName, defaultPath string, conduc
 		if dm := preselectDefaultModel(userConfig, d.GetSelectedCommand()); dm != "" {
 			d.modelInput.SetValue(dm)
 		}
+		d.loadTemplates(userConfig)
 	}
 	d.branchInput.Placeholder = d.branchPrefix + "branch-name"
 	d.rebuildFocusTargets()
@@ -536,6 +559,7 @@ func (d *NewDialog) syncInputWidths() {
 	d.pathInput.Width = iw
 	d.commandInput.Width = iw
 	d.modelInput.Width = iw
+	d.promptInput.Width = iw
 	d.branchInput.Width = iw
 }
 
@@ -638,7 +662,7 @@ func (d *NewDialog) shouldHandleEnterLocally() bool {
 	// rows (checkboxes/conductor) and via Ctrl+S (additive, always available).
 	// Default (toggle off) preserves today's behavior: Enter here submits, so we
 	// must NOT claim it locally.
-	case focusName, focusBranch:
+	case focusName, focusBranch, focusPrompt:
 		return d.enterAdvances
 	case focusMultiRepo:
 		return d.multiRepoEnabled
@@ -947,6 +971,19 @@ func knownModelIDsForTool(tool string) []string {
 			"o3-pro",
 			"o3",
 		}
+	case tool == "aider":
+		// Aider's own model aliases; full provider IDs (e.g.
+		// "openrouter/deepseek/deepseek-r1") can be typed as well.
+		return []string{
+			"sonnet",
+			"opus",
+			"haiku",
+			"gpt-4o",
+			"o3-mini",
+			"gemini",
+			"deepseek",
+			"r1",
+		}
 	default:
 		return nil
 	}
@@ -1210,6 +1247,8 @@ func (d *NewDialog) updateModelPlaceholder() {
 		d.modelInput.Placeholder = "openai/gpt-5.5"
 	case session.IsCodexCompatible(cmd):
 		d.modelInput.Placeholder = "gpt-5.5"
+	case cmd == "aider":
+		d.modelInput.Placeholder = "sonnet"
 	default:
 		d.modelInput.Placeholder = "tool default"
 	}
@@ -1225,6 +1264,8 @@ func (d *NewDialog) modelInputHint() string {
 		return "Examples: openai/gpt-5.5, openai/gpt-5.4, anthropic/claude-sonnet-4-6"
 	case session.IsCodexCompatible(cmd):
 		return "Examples: gpt-5.5, gpt-5.4, gpt-5.3-codex, gpt-5.4-mini"
+	case cmd == "aider":
+		return "Examples: sonnet, gpt-4o, deepseek, openrouter/deepseek/deepseek-r1"
 	default:
 		return ""
 	}
@@ -1369,14 +1410,18 @@ func (d *NewDialog) rebuildFocusTargets() {
 	// (type name, tool already right, submit) is never interrupted by an advanced
 	// option. In multi-repo mode the single Path field is hidden — its path list
 	// lives under focusMultiRepo below the fold instead.
-	targets := []focusTarget{focusName, focusCommand}
+	targets := []focusTarget{focusName}
+	if len(d.templateNames) > 0 {
+		targets = append(targets, focusTemplate)
+	}
+	targets = append(targets, focusCommand)
 	if d.selectedToolSupportsModel() {
 		targets = append(targets, focusModel)
 	}
 	if !d.multiRepoEnabled {
 		targets = append(targets, focusPath)
 	}
-	targets = append(targets, focusWorktree, focusSandbox)
+	targets = append(targets, focusPrompt, focusWorktree, focusSandbox)
 	if len(d.conductorSessions) > 0 {
 		targets = append(targets, focusConductor)
 	}
@@ -1430,6 +1475,7 @@ func (d *NewDialog) updateFocus() {
 	d.pathInput.Blur()
 	d.commandInput.Blur()
 	d.modelInput.Blur()
+	d.promptInput.Blur()
 	d.branchInput.Blur()
 	d.claudeOptions.Blur()
 	d.geminiOptions.Blur()
@@ -1459,8 +1505,10 @@ func (d *NewDialog) updateFocus() {
 		}
 	case focusModel:
 		d.modelInput.Focus()
-	case focusWorktree, focusSandbox, focusConductor, focusInherited:
-		// Checkbox/toggle rows and conductor dropdown — no text input to focus.
+	case focusPrompt:
+		d.promptInput.Focus()
+	case focusWorktree, focusSandbox, focusConductor, focusInherited, focusTemplate:
+		// Checkbox/toggle rows, conductor and template pickers — no text input to focus.
 	case focusBranch:
 		d.branchInput.Focus()
 	case focusOptions:
@@ -1502,7 +1550,7 @@ func isNewDialogShiftTabKey(msg tea.KeyMsg) bool {
 // keystrokes. Single-letter shortcuts must be suppressed in this state.
 func (d *NewDialog) isTextInputFocused() bool {
 	switch d.currentTarget() {
-	case focusName, focusPath, focusModel, focusBranch:
+	case focusName, focusPath, focusModel, focusBranch, focusPrompt:
 		return true
 	case focusCommand:
 		return d.commandCursor == 0 // custom command input
@@ -1980,7 +2028,7 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 			// toggle off (default) home.go never forwards Enter here for these
 			// fields (shouldHandleEnterLocally returns false), so this branch is
 			// only reached in opt-in mode; the guard keeps it correct regardless.
-			if d.enterAdvances && (cur == focusName || cur == focusBranch) {
+			if d.enterAdvances && (cur == focusName || cur == focusBranch || cur == focusPrompt) {
 				d.moveFocus(1)
 				return d, nil
 			}
@@ -2021,6 +2069,10 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 			return d, nil
 
 		case "left":
+			if cur == focusTemplate {
+				d.cycleTemplate(-1)
+				return d, nil
+			}
 			if cur == focusCommand {
 				d.commandCursor--
 				if d.commandCursor < 0 {
@@ -2036,6 +2088,10 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 			}
 
 		case "right":
+			if cur == focusTemplate {
+				d.cycleTemplate(1)
+				return d, nil
+			}
 			if cur == focusCommand {
 				d.commandCursor = (d.commandCursor + 1) % len(d.presetCommands)
 				d.modelInput.SetValue("")
@@ -2212,8 +2268,10 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 				d.filterPathSuggestions()
 			}
 		}
-	case focusWorktree, focusSandbox, focusConductor, focusInherited:
-		// Checkbox/toggle rows and conductor dropdown — no text input to update.
+	case focusWorktree, focusSandbox, focusConductor, focusInherited, focusTemplate:
+		// Checkbox/toggle rows, conductor and template pickers — no text input to update.
+	case focusPrompt:
+		d.promptInput, cmd = d.promptInput.Update(msg)
 	case focusBranch:
 		oldBranch := d.branchInput.Value()
 		d.branchInput, cmd = d.branchInput.Update(msg)
@@ -2384,6 +2442,25 @@ func (d *NewDialog) renderSinglePathSection(content *strings.Builder, cur focusT
 	content.WriteString("\n")
 }
 
+// renderPromptSection renders the Initial prompt input. The prompt is sent
+// to the agent once the launch animation ends (see initial_prompt.go).
+func (d *NewDialog) renderPromptSection(content *strings.Builder, cur focusTarget) {
+	if cur == focusPrompt {
+		content.WriteString(lipgloss.NewStyle().Foreground(ColorCyan).Bold(true).Render("▶ Initial prompt:"))
+	} else {
+		content.WriteString(lipgloss.NewStyle().Foreground(ColorText).Render("  Initial prompt:"))
+	}
+	content.WriteString("\n")
+	content.WriteString("  ")
+	content.WriteString(d.promptInput.View())
+	content.WriteString("\n\n")
+}
+
+// GetInitialPrompt returns the trimmed Initial prompt value.
+func (d *NewDialog) GetInitialPrompt() string {
+	return strings.TrimSpace(d.promptInput.Value())
+}
+
 // renderMultiRepoSection renders the Multi-repo toggle and, when enabled, the
 // path list. It lives below the common fields (below the fold).
 func (d *NewDialog) renderMultiRepoSection(content *strings.Builder, cur focusTarget) {
@@ -2489,6 +2566,10 @@ func (d *NewDialog) View() string {
 	groupInfoStyle := lipgloss.NewStyle().Foreground(ColorPurple) // Purple for group context
 	content.WriteString(groupInfoStyle.Render("  in group: " + d.parentGroupName))
 	content.WriteString("\n")
+	if t, ok := d.selectedTemplate(); len(d.groupMCPs) > 0 && (!ok || len(t.MCPs) == 0) {
+		content.WriteString(lipgloss.NewStyle().Foreground(ColorComment).Render("  group MCPs: " + strings.Join(d.groupMCPs, ", ")))
+		content.WriteString("\n")
+	}
 
 	// Recent sessions picker
 	if d.showRecentPicker && len(d.recentSessions) > 0 {
@@ -2562,6 +2643,8 @@ func (d *NewDialog) View() string {
 	content.WriteString(d.nameInput.View())
 	content.WriteString("\n\n")
 
+	d.renderTemplateSection(&content, cur)
+
 	// Hot path (UX top-3 #3): Tool -> (Model) -> Path render right after Name.
 	// The Multi-repo toggle and its path list move below the common fields
 	// (see renderMultiRepoSection, called after the Branch input). In multi-repo
@@ -2571,6 +2654,7 @@ func (d *NewDialog) View() string {
 	if !d.multiRepoEnabled {
 		d.renderSinglePathSection(&content, cur, dialogWidth)
 	}
+	d.renderPromptSection(&content, cur)
 
 	// (Tool, Model, and the single Path field render above, right after Name —
 	// see renderCommandSection / renderModelSection / renderSinglePathSection.)
@@ -2744,6 +2828,8 @@ func (d *NewDialog) View() string {
 		} else {
 			helpText = "←→ command │ w worktree │ s sandbox │ Tab next │ ^S create │ Esc cancel"
 		}
+	} else if cur == focusTemplate {
+		helpText = "←→ template │ Tab next │ ^S create │ Esc cancel"
 	} else if cur == focusModel {
 		if d.modelSuggestionActive {
 			helpText = "↑/↓ navigate │ Space/Enter select │ Esc back │ Tab next"
@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Session templates ([templates.<name>] in config.toml) are offered as a pill
+// row under Name. Cursor 0 is "none"; picking a template first restores the
+// form to how ShowInGroup left it (templateBase) and then overlays the
+// template, so cycling through templates never accumulates stale values.
+
+// loadTemplates refreshes the template list from config and resets the
+// selection. Called from ShowInGroup after the config defaults are applied.
+func (d *NewDialog) loadTemplates(cfg *session.UserConfig) {
+	d.templateNames = cfg.TemplateNames()
+	d.templates = nil
+	if cfg != nil {
+		d.templates = cfg.Templates
+	}
+	d.templateCursor = 0
+	d.templateBase = nil
+}
+
+// selectedTemplate returns the chosen template, if any.
+func (d *NewDialog) selectedTemplate() (session.SessionTemplate, bool) {
+	if d.templateCursor <= 0 || d.templateCursor > len(d.templateNames) {
+		return session.SessionTemplate{}, false
+	}
+	t, ok := d.templates[d.templateNames[d.templateCursor-1]]
+	return t, ok
+}
+
+// GetTemplateName returns the selected template name ("" when none).
+func (d *NewDialog) GetTemplateName() string {
+	if d.templateCursor <= 0 || d.templateCursor > len(d.templateNames) {
+		return ""
+	}
+	return d.templateNames[d.templateCursor-1]
+}
+
+// GetTemplateMCPs returns the MCPs the selected template attaches, falling
+// back to the group's default MCPs when the template names none.
+func (d *NewDialog) GetTemplateMCPs() []string {
+	if t, ok := d.selectedTemplate(); ok && len(t.MCPs) > 0 {
+		return t.MCPs
+	}
+	return d.groupMCPs
+}
+
+// ApplyGroupDefaults pre-populates the form from the group's new-session
+// defaults. Call right after ShowInGroup: the values become part of the
+// template baseline, so a selected template still overrides them.
+func (d *NewDialog) ApplyGroupDefaults(defaults session.GroupSessionDefaults) {
+	d.groupMCPs = defaults.MCPs
+	if defaults.IsZero() {
+		return
+	}
+	d.applyTemplateFields(defaults.Template())
+}
+
+// cycleTemplate moves the template cursor by delta (wrapping through "none")
+// and applies the result to the form.
+func (d *NewDialog) cycleTemplate(delta int) {
+	n := len(d.templateNames) + 1
+	if n <= 1 {
+		return
+	}
+	if d.templateBase == nil {
+		d.templateBase = d.saveSnapshot()
+		d.templateBaseGroupPath = d.parentGroupPath
+		d.templateBaseGroupName = d.parentGroupName
+		d.templateBaseExtraArgs = d.claudeOptions.GetExtraArgs()
+	}
+	d.templateCursor = ((d.templateCursor+delta)%n + n) % n
+	d.applySelectedTemplate()
+}
+
+// applySelectedTemplate resets the form to the pre-template baseline (keeping
+// the typed name) and overlays the selected template.
+func (d *NewDialog) applySelectedTemplate() {
+	if d.templateBase != nil {
+		name := d.nameInput.Value()
+		d.restoreSnapshot(d.templateBase)
+		d.nameInput.SetValue(name)
+		d.parentGroupPath = d.templateBaseGroupPath
+		d.parentGroupName = d.templateBaseGroupName
+		d.claudeOptions.SetExtraArgs(d.templateBaseExtraArgs)
+	}
+	t, ok := d.selectedTemplate()
+	if !ok {
+		d.rebuildFocusTargets()
+		return
+	}
+	d.applyTemplateFields(t)
+}
+
+// applyTemplateFields overlays every field t sets onto the form.
+func (d *NewDialog) applyTemplateFields(t session.SessionTemplate) {
+	if tool := strings.TrimSpace(t.Tool); tool != "" {
+		d.commandCursor = 0
+		d.commandInput.SetValue("")
+		matched := false
+		for i, cmd := range d.presetCommands {
+			if cmd == tool {
+				d.commandCursor = i
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			d.commandInput.SetValue(tool)
+		}
+		d.modelInput.SetValue("")
+		d.updateToolOptions()
+	}
+
+	if t.Model != "" {
+		d.modelInput.SetValue(t.Model)
+		d.filterModelSuggestions()
+	}
+	if opts := d.claudeOptions.GetOptions(); opts != nil {
+		t.ApplyClaudeOptions(opts)
+		d.claudeOptions.SetFromOptions(opts)
+	}
+	if len(t.ExtraArgs) > 0 {
+		d.claudeOptions.SetExtraArgs(t.ExtraArgs)
+	}
+	if t.Yolo != nil {
+		d.geminiOptions.SetDefaults(*t.Yolo)
+		d.codexOptions.SetDefaults(*t.Yolo)
+		d.hermesOptions.SetDefaults(*t.Yolo)
+	}
+	if t.Group != "" {
+		d.parentGroupPath = t.Group
+		d.parentGroupName = t.Group
+	}
+	if t.Path != "" {
+		d.pathInput.SetValue(session.ExpandPath(t.Path))
+		d.pathSoftSelected = false
+	}
+	if t.Sandbox != nil {
+		d.sandboxEnabled = *t.Sandbox
+	}
+	// The template is a default, not an explicit toggle: worktreeToggled stays
+	// false so a non-repo path falls back to a plain session (#1185).
+	switch t.GetWorktree() {
+	case "on":
+		d.worktreeEnabled = true
+		d.worktreeToggled = false
+		if d.branchInput.Value() == "" || d.branchAutoSet {
+			d.branchAutoSet = true
+			d.autoBranchFromName()
+		}
+	case "off":
+		d.worktreeEnabled = false
+		d.worktreeToggled = false
+	}
+	d.rebuildFocusTargets()
+}
+
+// renderTemplateSection renders the template pill row. Nothing is rendered
+// when no templates are configured.
+func (d *NewDialog) renderTemplateSection(content *strings.Builder, cur focusTarget) {
+	if len(d.templateNames) == 0 {
+		return
+	}
+	labelStyle := lipgloss.NewStyle().Foreground(ColorText)
+	activeLabelStyle := lipgloss.NewStyle().Foreground(ColorCyan).Bold(true)
+	if cur == focusTemplate {
+		content.WriteString(activeLabelStyle.Render("▶ Template:"))
+	} else {
+		content.WriteString(labelStyle.Render("  Template:"))
+	}
+	content.WriteString("\n  ")
+
+	selectedStyle := lipgloss.NewStyle().Foreground(ColorBg).Background(ColorAccent).Bold(true).Padding(0, 2)
+	idleStyle := lipgloss.NewStyle().Foreground(ColorTextDim).Background(ColorSurface).Padding(0, 2)
+	buttons := make([]string, 0, len(d.templateNames)+1)
+	for i, name := range append([]string{"none"}, d.templateNames...) {
+		style := idleStyle
+		if i == d.templateCursor {
+			style = selectedStyle
+		}
+		buttons = append(buttons, style.Render(name))
+	}
+	content.WriteString(lipgloss.JoinHorizontal(lipgloss.Left, buttons...))
+	content.WriteString("\n")
+
+	if t, ok := d.selectedTemplate(); ok {
+		var details []string
+		if t.Description != "" {
+			details = append(details, t.Description)
+		}
+		if len(t.MCPs) > 0 {
+			details = append(details, "MCPs: "+strings.Join(t.MCPs, ", "))
+		}
+		if len(details) > 0 {
+			dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+			content.WriteString("  ")
+			content.WriteString(dimStyle.Render(strings.Join(details, " · ")))
+			content.WriteString("\n")
+		}
+	}
+	content.WriteString("\n")
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// showWithTemplates opens the dialog and injects templates as if they came
+// from config.toml.
+func showWithTemplates(t *testing.T, templates map[string]session.SessionTemplate) *NewDialog {
+	t.Helper()
+	d := NewNewDialog()
+	d.ShowInGroup("work", "work", "/tmp", nil, "")
+	d.SetDefaultTool("")
+	d.loadTemplates(&session.UserConfig{Templates: templates})
+	d.rebuildFocusTargets()
+	return d
+}
+
+func focusOn(t *testing.T, d *NewDialog, target focusTarget) {
+	t.Helper()
+	idx := d.indexOf(target)
+	if idx < 0 {
+		t.Fatalf("focus target %v not present in %v", target, d.focusTargets)
+	}
+	d.focusIndex = idx
+	d.updateFocus()
+}
+
+func TestNewDialog_Templates_NoRowWithoutTemplates(t *testing.T) {
+	d := showWithTemplates(t, nil)
+	if d.indexOf(focusTemplate) >= 0 {
+		t.Fatal("template row should be hidden when no templates are configured")
+	}
+	if strings.Contains(d.View(), "Template:") {
+		t.Fatal("template section rendered without templates")
+	}
+}
+
+func TestNewDialog_Templates_CycleAppliesAndResets(t *testing.T) {
+	on := true
+	d := showWithTemplates(t, map[string]session.SessionTemplate{
+		"review": {
+			Tool:          "claude",
+			Group:         "reviews",
+			MCPs:          []string{"github"},
+			DangerousMode: &on,
+			ExtraArgs:     []string{"--agent", "reviewer"},
+		},
+	})
+	if got := d.focusTargets[1]; got != focusTemplate {
+		t.Fatalf("template row should follow Name, got targets %v", d.focusTargets)
+	}
+	d.nameInput.SetValue("my-review")
+	focusOn(t, d, focusTemplate)
+
+	d.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if d.GetTemplateName() != "review" {
+		t.Fatalf("template = %q, want review", d.GetTemplateName())
+	}
+	if d.GetSelectedCommand() != "claude" {
+		t.Fatalf("tool = %q, want claude", d.GetSelectedCommand())
+	}
+	if d.GetSelectedGroup() != "reviews" {
+		t.Fatalf("group = %q, want reviews", d.GetSelectedGroup())
+	}
+	if opts := d.GetClaudeOptions(); opts == nil || !opts.SkipPermissions {
+		t.Fatal("dangerous_mode should enable skip-permissions")
+	}
+	if got := d.GetClaudeExtraArgs(); !reflect.DeepEqual(got, []string{"--agent", "reviewer"}) {
+		t.Fatalf("extra args = %v", got)
+	}
+	if !reflect.DeepEqual(d.GetTemplateMCPs(), []string{"github"}) {
+		t.Fatalf("MCPs = %v", d.GetTemplateMCPs())
+	}
+	if d.nameInput.Value() != "my-review" {
+		t.Fatal("applying a template must keep the typed name")
+	}
+
+	// Cycling back to "none" restores the pre-template form.
+	d.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if d.GetTemplateName() != "" {
+		t.Fatalf("template = %q, want none", d.GetTemplateName())
+	}
+	if d.GetSelectedCommand() != "" || d.GetSelectedGroup() != "work" {
+		t.Fatalf("none should restore tool/group, got %q / %q", d.GetSelectedCommand(), d.GetSelectedGroup())
+	}
+	if len(d.GetClaudeExtraArgs()) != 0 || d.GetTemplateMCPs() != nil {
+		t.Fatal("none should drop template extra args and MCPs")
+	}
+}
+
+func TestNewDialog_Templates_WorktreePolicy(t *testing.T) {
+	d := showWithTemplates(t, map[string]session.SessionTemplate{
+		"wt": {Worktree: "on"},
+	})
+	d.nameInput.SetValue("feat")
+	focusOn(t, d, focusTemplate)
+	d.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	if !d.IsWorktreeEnabled() {
+		t.Fatal("worktree = on should enable the worktree checkbox")
+	}
+	if d.IsWorktreeExplicit() {
+		t.Fatal("a template worktree is a default, not an explicit toggle")
+	}
+	if !strings.HasSuffix(d.branchInput.Value(), "feat") {
+		t.Fatalf("branch = %q, want auto-derived from name", d.branchInput.Value())
+	}
+	if !strings.Contains(d.View(), "Template:") {
+		t.Fatal("template section should render")
+	}
+}
+
+func TestNewDialog_ApplyGroupDefaults(t *testing.T) {
+	on := true
+	d := showWithTemplates(t, map[string]session.SessionTemplate{
+		"review": {Tool: "claude", MCPs: []string{"github"}},
+	})
+	d.ApplyGroupDefaults(session.GroupSessionDefaults{
+		Tool:          "codex",
+		Model:         "gpt-5.5",
+		MCPs:          []string{"memory"},
+		DangerousMode: &on,
+	})
+
+	if d.GetSelectedCommand() != "codex" {
+		t.Fatalf("tool = %q, want codex", d.GetSelectedCommand())
+	}
+	if d.GetLaunchModelID() != "gpt-5.5" {
+		t.Fatalf("model = %q, want gpt-5.5", d.GetLaunchModelID())
+	}
+	if !d.GetCodexYoloMode() {
+		t.Fatal("dangerous_mode should enable codex YOLO mode")
+	}
+	if !reflect.DeepEqual(d.GetTemplateMCPs(), []string{"memory"}) {
+		t.Fatalf("MCPs = %v, want group MCPs", d.GetTemplateMCPs())
+	}
+	if !strings.Contains(d.View(), "group MCPs: memory") {
+		t.Fatal("group MCPs should be shown under the group line")
+	}
+
+	// A template overrides the group defaults; "none" restores them.
+	focusOn(t, d, focusTemplate)
+	d.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if d.GetSelectedCommand() != "claude" || !reflect.DeepEqual(d.GetTemplateMCPs(), []string{"github"}) {
+		t.Fatalf("template should win, got tool %q MCPs %v", d.GetSelectedCommand(), d.GetTemplateMCPs())
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if d.GetSelectedCommand() != "codex" || !reflect.DeepEqual(d.GetTemplateMCPs(), []string{"memory"}) {
+		t.Fatalf("none should restore group defaults, got tool %q MCPs %v", d.GetSelectedCommand(), d.GetTemplateMCPs())
+	}
+
+	// Reopening the dialog drops the previous group's defaults.
+	d.ShowInGroup("other", "other", "/tmp", nil, "")
+	if d.GetTemplateMCPs() != nil {
+		t.Fatalf("MCPs = %v after reopening, want none", d.GetTemplateMCPs())
+	}
+}
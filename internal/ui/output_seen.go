@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// outputSeenTracker remembers, per session, how much pane output the user has
+// already looked at so the list can badge "+N new" and the preview can mark
+// the unseen tail. Pane samples come from the shared pane-info cache once per
+// tick — no extra tmux calls — and are summed with tmux.PaneInfo.LinesSince
+// into a running total, since the raw line count stops growing (and saws
+// down) once the scrollback reaches history-limit.
+//
+// A session's baseline is its first observed count, so nothing is badged at
+// TUI startup. The baseline advances when the user views the session: moving
+// the cursor off it in the preview or returning from an attach.
+type outputSeenTracker struct {
+	mu      sync.Mutex
+	seen    map[string]int           // sessionID -> output total at last view
+	current map[string]int           // sessionID -> running output total
+	last    map[string]tmux.PaneInfo // sessionID -> latest pane sample
+}
+
+func newOutputSeenTracker() *outputSeenTracker {
+	return &outputSeenTracker{
+		seen:    make(map[string]int),
+		current: make(map[string]int),
+		last:    make(map[string]tmux.PaneInfo),
+	}
+}
+
+// observe adds the output since the previous sample to the session's total.
+// When the history was cleared (clear-history, respawn) the total restarts
+// at the new line count and the baseline follows it down instead of hiding
+// future output.
+func (t *outputSeenTracker) observe(sessionID string, info tmux.PaneInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	total := info.LineCount()
+	if prev, ok := t.last[sessionID]; ok {
+		if n, cleared := info.LinesSince(prev); !cleared {
+			total = t.current[sessionID] + n
+		}
+	}
+	t.last[sessionID] = info
+	t.current[sessionID] = total
+	if seen, ok := t.seen[sessionID]; !ok || total < seen {
+		t.seen[sessionID] = total
+	}
+}
+
+// markSeen advances the baseline to the latest observed count.
+func (t *outputSeenTracker) markSeen(sessionID string) {
+	if sessionID == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cur, ok := t.current[sessionID]; ok {
+		t.seen[sessionID] = cur
+	}
+}
+
+// unseen returns how many lines the session produced since it was last viewed.
+func (t *outputSeenTracker) unseen(sessionID string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cur, ok := t.current[sessionID]
+	if !ok {
+		return 0
+	}
+	if n := cur - t.seen[sessionID]; n > 0 {
+		return n
+	}
+	return 0
+}
+
+// prune drops sessions that no longer exist.
+func (t *outputSeenTracker) prune(live map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id := range t.current {
+		if !live[id] {
+			delete(t.current, id)
+			delete(t.seen, id)
+			delete(t.last, id)
+		}
+	}
+}
+
+// sampleOutputSeen feeds the tracker from the pane-info cache and advances
+// the baseline of the session the cursor just left. Called once per tick.
+func (h *Home) sampleOutputSeen() {
+	h.instancesMu.RLock()
+	live := make(map[string]bool, len(h.instances))
+	for _, inst := range h.instances {
+		live[inst.ID] = true
+		ts := inst.GetTmuxSession()
+		if ts == nil {
+			continue
+		}
+		if info, ok := tmux.GetCachedPaneInfo(ts.Name); ok {
+			h.outputSeen.observe(inst.ID, info)
+		}
+	}
+	h.instancesMu.RUnlock()
+	h.outputSeen.prune(live)
+
+	selectedID := ""
+	if inst := h.getSelectedSession(); inst != nil {
+		selectedID = inst.ID
+	}
+	if selectedID != h.outputSeenSelectedID {
+		h.outputSeen.markSeen(h.outputSeenSelectedID)
+		h.outputSeenSelectedID = selectedID
+	}
+}
+
+// formatNewLinesBadge renders the list-row badge text for n unseen lines.
+func formatNewLinesBadge(n int) string {
+	if n >= 1000 {
+		return "+999"
+	}
+	return "+" + strconv.Itoa(n)
+}
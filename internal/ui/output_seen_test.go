@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// paneAt is a pane sample whose LineCount is lines (cursor on row 0).
+func paneAt(lines int) tmux.PaneInfo {
+	return tmux.PaneInfo{HistorySize: lines - 1, HistoryLimit: 2000}
+}
+
+func TestOutputSeenTracker_BaselineAndMarkSeen(t *testing.T) {
+	tr := newOutputSeenTracker()
+
+	tr.observe("a", paneAt(100))
+	if n := tr.unseen("a"); n != 0 {
+		t.Fatalf("first observation must be the baseline, got %d unseen", n)
+	}
+	tr.observe("a", paneAt(130))
+	if n := tr.unseen("a"); n != 30 {
+		t.Fatalf("unseen = %d, want 30", n)
+	}
+	tr.markSeen("a")
+	if n := tr.unseen("a"); n != 0 {
+		t.Fatalf("unseen after markSeen = %d, want 0", n)
+	}
+
+	// History cleared: baseline follows the count down.
+	tr.observe("a", paneAt(10))
+	tr.observe("a", paneAt(15))
+	if n := tr.unseen("a"); n != 5 {
+		t.Fatalf("unseen after clear = %d, want 5", n)
+	}
+
+	tr.prune(map[string]bool{})
+	if n := tr.unseen("a"); n != 0 {
+		t.Fatalf("pruned session still reports %d unseen", n)
+	}
+}
+
+func TestOutputSeenTracker_KeepsCountingAtHistoryLimit(t *testing.T) {
+	tr := newOutputSeenTracker()
+
+	tr.observe("a", paneAt(1990))
+	tr.observe("a", paneAt(2001)) // scrollback full
+	// tmux trims history_limit/10 lines; 30 more arrive before the next tick.
+	tr.observe("a", paneAt(1831))
+	if n := tr.unseen("a"); n != 41 {
+		t.Fatalf("unseen across a history trim = %d, want 41", n)
+	}
+}
+
+func TestFormatNewLinesBadge(t *testing.T) {
+	if got := formatNewLinesBadge(42); got != "+42" {
+		t.Fatalf("got %q", got)
+	}
+	if got := formatNewLinesBadge(5000); got != "+999" {
+		t.Fatalf("cap: got %q", got)
+	}
+}
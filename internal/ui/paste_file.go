@@ -0,0 +1,349 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// pasteFileMaxBytes caps what paste-file sends. Larger files are better
+// referenced by path in a prompt than typed into the composer.
+const pasteFileMaxBytes = 512 << 10
+
+// pasteFileMaxCandidates is how many completion candidates the bar lists.
+const pasteFileMaxCandidates = 8
+
+// pasteFileSubmitMsg is emitted when a file is picked for pasting. Home reads
+// it and delivers the contents to the session like a list prompt.
+type pasteFileSubmitMsg struct {
+	instanceID string
+	path       string
+}
+
+// pasteFileSentMsg reports a paste-file delivery.
+type pasteFileSentMsg struct {
+	title string
+	name  string // file base name
+	lines int
+	err   error
+}
+
+// PasteFileDialog is the inline file picker behind the paste-file action: a
+// path input at the bottom of the list with Tab completion against the local
+// filesystem. Like PromptInputDialog it sends without attaching.
+type PasteFileDialog struct {
+	input      textinput.Model
+	visible    bool
+	width      int
+	height     int
+	instanceID string
+	title      string
+	candidates []string // completions from the last Tab with several matches
+	err        string
+}
+
+// NewPasteFileDialog creates the paste-file picker (hidden).
+func NewPasteFileDialog() *PasteFileDialog {
+	ti := textinput.New()
+	ti.Placeholder = "Path of the file to paste…"
+	ti.CharLimit = 1024
+	ti.Width = 60
+	return &PasteFileDialog{input: ti}
+}
+
+// Show opens the picker for a session, starting in dir (the session's
+// project path) so files next to the code complete right away.
+func (d *PasteFileDialog) Show(instanceID, title, dir string) {
+	d.visible = true
+	d.instanceID = instanceID
+	d.title = title
+	d.candidates = nil
+	d.err = ""
+	start := ""
+	if dir != "" {
+		start = strings.TrimSuffix(dir, "/") + "/"
+	}
+	d.input.SetValue(start)
+	d.input.CursorEnd()
+	d.input.Focus()
+}
+
+// Hide closes the picker.
+func (d *PasteFileDialog) Hide() {
+	d.visible = false
+	d.input.Blur()
+	d.instanceID = ""
+	d.title = ""
+	d.candidates = nil
+	d.err = ""
+}
+
+// IsVisible reports whether the picker is open. Nil-safe, like
+// PromptInputDialog.IsVisible.
+func (d *PasteFileDialog) IsVisible() bool { return d != nil && d.visible }
+
+// SetSize updates the layout dimensions and the input width.
+func (d *PasteFileDialog) SetSize(width, height int) {
+	if d == nil {
+		return
+	}
+	d.width = width
+	d.height = height
+	d.input.Width = min(max(width-20, 20), 120)
+}
+
+// Update handles a key while the picker is visible: Tab completes the path,
+// Enter picks the file (staying open with an error when it is not a readable
+// regular file), Esc cancels.
+func (d *PasteFileDialog) Update(msg tea.KeyMsg) (*PasteFileDialog, tea.Cmd) {
+	if d == nil || !d.visible {
+		return d, nil
+	}
+	switch msg.String() {
+	case "esc":
+		d.Hide()
+		return d, nil
+	case "tab":
+		d.complete()
+		return d, nil
+	case "enter":
+		path := strings.TrimSpace(d.input.Value())
+		if path == "" {
+			d.Hide()
+			return d, nil
+		}
+		expanded := session.ExpandPath(path)
+		info, err := os.Stat(expanded)
+		switch {
+		case err != nil:
+			d.err = err.Error()
+			return d, nil
+		case info.IsDir():
+			d.err = "that is a directory; pick a file (Tab completes)"
+			return d, nil
+		case info.Size() > pasteFileMaxBytes:
+			d.err = fmt.Sprintf("file is %d KB; paste-file sends at most %d KB", info.Size()>>10, pasteFileMaxBytes>>10)
+			return d, nil
+		}
+		instanceID := d.instanceID
+		d.Hide()
+		return d, func() tea.Msg {
+			return pasteFileSubmitMsg{instanceID: instanceID, path: expanded}
+		}
+	default:
+		before := d.input.Value()
+		var cmd tea.Cmd
+		d.input, cmd = d.input.Update(msg)
+		if d.input.Value() != before {
+			d.candidates = nil
+			d.err = ""
+		}
+		return d, cmd
+	}
+}
+
+// complete extends the input to the longest prefix shared by its completions.
+// A single match is taken whole (directories get a trailing slash so the next
+// Tab descends); several are listed under the input.
+func (d *PasteFileDialog) complete() {
+	value := d.input.Value()
+	matches := completePathCandidates(value)
+	d.err = ""
+	switch len(matches) {
+	case 0:
+		d.candidates = nil
+		d.err = "no matching files"
+		return
+	case 1:
+		d.candidates = nil
+		d.input.SetValue(matches[0])
+	default:
+		d.candidates = matches
+		if prefix := commonPrefix(matches); len(prefix) > len(value) {
+			d.input.SetValue(prefix)
+		}
+	}
+	d.input.CursorEnd()
+}
+
+// completePathCandidates returns the filesystem entries input can complete
+// to, in input's own (unexpanded) form, sorted. Directories end in "/".
+// Dotfiles are only offered once the typed name starts with a dot.
+func completePathCandidates(input string) []string {
+	dirPart, base := "", input
+	if i := strings.LastIndex(input, "/"); i >= 0 {
+		dirPart, base = input[:i+1], input[i+1:]
+	}
+	dir := "."
+	if dirPart != "" {
+		dir = session.ExpandPath(dirPart)
+	} else if input == "~" {
+		return []string{"~/"}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var out []string
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, base) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".")) {
+			continue
+		}
+		candidate := dirPart + name
+		if e.IsDir() {
+			candidate += "/"
+		} else if e.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.IsDir() {
+				candidate += "/"
+			}
+		}
+		out = append(out, candidate)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// commonPrefix returns the longest prefix shared by every string in s.
+func commonPrefix(s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	prefix := s[0]
+	for _, v := range s[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// readPasteFile reads a file for paste-file, refusing anything over
+// pasteFileMaxBytes or that does not look like text.
+func readPasteFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if len(data) > pasteFileMaxBytes {
+		return "", fmt.Errorf("file is %d KB; paste-file sends at most %d KB", len(data)>>10, pasteFileMaxBytes>>10)
+	}
+	if bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data) {
+		return "", fmt.Errorf("%s is not a text file", filepath.Base(path))
+	}
+	text := strings.TrimRight(string(data), "\n")
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("%s is empty", filepath.Base(path))
+	}
+	return text, nil
+}
+
+// openPasteFile opens the paste-file picker for inst, which must be running:
+// the contents go into its live tmux pane.
+func (h *Home) openPasteFile(inst *session.Instance) {
+	if inst == nil {
+		return
+	}
+	if ts := inst.GetTmuxSession(); ts == nil || ts.Name == "" {
+		h.setError(fmt.Errorf("session %q is not running; start it before pasting", inst.Title))
+		return
+	}
+	h.pasteFileDialog.Show(inst.ID, inst.Title, inst.ProjectPath)
+}
+
+// pasteFile reads the picked file and delivers it like a list prompt
+// (sendListPrompt), which types it in chunks small enough for tmux and
+// submits it. The read happens in the command so a large file never blocks
+// the UI.
+func (h *Home) pasteFile(msg pasteFileSubmitMsg) tea.Cmd {
+	h.instancesMu.RLock()
+	inst := h.instanceByID[msg.instanceID]
+	h.instancesMu.RUnlock()
+	if inst == nil {
+		h.setError(fmt.Errorf("paste target session no longer exists"))
+		return nil
+	}
+	ts := inst.GetTmuxSession()
+	if ts == nil || ts.Name == "" {
+		h.setError(fmt.Errorf("session %q is not running; start it before pasting", inst.Title))
+		return nil
+	}
+	title := inst.Title
+	guarded := session.IsClaudeCompatible(inst.Tool)
+	path := msg.path
+	snapshot := promptSnapshotter(inst)
+	return func() tea.Msg {
+		sent := pasteFileSentMsg{title: title, name: filepath.Base(path)}
+		text, err := readPasteFile(path)
+		if err != nil {
+			sent.err = err
+			return sent
+		}
+		sent.lines = strings.Count(text, "\n") + 1
+		snapshot()
+		sent.err = sendListPrompt(ts, guarded, text)
+		return sent
+	}
+}
+
+// View overlays the picker at the bottom of the (already rendered) list body,
+// the same way PromptInputDialog.View does.
+func (d *PasteFileDialog) View(listBody string) string {
+	if d == nil || !d.visible {
+		return listBody
+	}
+
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	errStyle := lipgloss.NewStyle().Foreground(ColorRed)
+
+	lines := []string{labelStyle.Render("Paste file → " + d.title), d.input.View()}
+	if n := len(d.candidates); n > 0 {
+		shown := make([]string, 0, pasteFileMaxCandidates)
+		for _, c := range d.candidates[:min(n, pasteFileMaxCandidates)] {
+			name := filepath.Base(strings.TrimSuffix(c, "/"))
+			if strings.HasSuffix(c, "/") {
+				name += "/"
+			}
+			shown = append(shown, name)
+		}
+		more := ""
+		if n > pasteFileMaxCandidates {
+			more = fmt.Sprintf("  +%d more", n-pasteFileMaxCandidates)
+		}
+		lines = append(lines, dimStyle.Render(strings.Join(shown, "  ")+more))
+	}
+	if d.err != "" {
+		lines = append(lines, errStyle.Render(d.err))
+	}
+	lines = append(lines, dimStyle.Render("Tab Complete   Enter Paste & send   Esc Cancel"))
+
+	barWidth := d.width - 2
+	if barWidth < 1 {
+		barWidth = d.width
+	}
+	bar := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorAccent).
+		Padding(0, 1).
+		Width(barWidth).
+		Render(strings.Join(lines, "\n"))
+
+	barHeight := lipgloss.Height(bar)
+	bodyLines := strings.Split(listBody, "\n")
+	maxBody := max(d.height-barHeight, 0)
+	if len(bodyLines) > maxBody {
+		bodyLines = bodyLines[:maxBody]
+	}
+	return strings.Join(bodyLines, "\n") + "\n" + bar
+}
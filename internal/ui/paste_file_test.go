@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writePasteFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"spec.md":    "# Spec\n\nDo the thing.\n",
+		"specs.txt":  "more",
+		".hidden":    "x",
+		"bin.dat":    "a\x00b",
+		"empty.md":   "\n\n",
+		"docs/a.md":  "a",
+		"docs/b.md":  "b",
+		"notes/x.md": "x",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCompletePathCandidates(t *testing.T) {
+	dir := writePasteFixture(t)
+
+	got := completePathCandidates(dir + "/sp")
+	want := []string{dir + "/spec.md", dir + "/specs.txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("candidates = %q, want %q", got, want)
+	}
+	if got := completePathCandidates(dir + "/do"); !reflect.DeepEqual(got, []string{dir + "/docs/"}) {
+		t.Fatalf("directory completion = %q, want a trailing slash", got)
+	}
+	for _, c := range completePathCandidates(dir + "/") {
+		if strings.HasSuffix(c, ".hidden") {
+			t.Fatal("dotfiles should only complete once a dot is typed")
+		}
+	}
+	if got := completePathCandidates(dir + "/.h"); len(got) != 1 {
+		t.Fatalf("typed dot should offer dotfiles, got %q", got)
+	}
+}
+
+func TestPasteFileDialog_TabAndSubmit(t *testing.T) {
+	dir := writePasteFixture(t)
+	d := NewPasteFileDialog()
+	d.Show("inst-1", "worker", dir)
+	if d.input.Value() != dir+"/" {
+		t.Fatalf("picker should start in the project dir, got %q", d.input.Value())
+	}
+
+	d.input.SetValue(dir + "/sp")
+	d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if d.input.Value() != dir+"/spec" || len(d.candidates) != 2 {
+		t.Fatalf("tab should extend to the common prefix and list both, got %q %q", d.input.Value(), d.candidates)
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(".")})
+	d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if d.input.Value() != dir+"/spec.md" {
+		t.Fatalf("single match should complete fully, got %q", d.input.Value())
+	}
+
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || d.IsVisible() {
+		t.Fatal("enter on a file should submit and close")
+	}
+	msg, ok := cmd().(pasteFileSubmitMsg)
+	if !ok || msg.instanceID != "inst-1" || msg.path != dir+"/spec.md" {
+		t.Fatalf("submit = %#v", cmd())
+	}
+}
+
+func TestPasteFileDialog_EnterOnDirectoryStaysOpen(t *testing.T) {
+	dir := writePasteFixture(t)
+	d := NewPasteFileDialog()
+	d.Show("inst-1", "worker", dir)
+	d.input.SetValue(dir + "/docs")
+	if _, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || !d.IsVisible() {
+		t.Fatal("enter on a directory should keep the picker open")
+	}
+	if !strings.Contains(d.View(""), "directory") {
+		t.Fatalf("picker should explain the error, got %q", d.View(""))
+	}
+}
+
+func TestReadPasteFile(t *testing.T) {
+	dir := writePasteFixture(t)
+	text, err := readPasteFile(filepath.Join(dir, "spec.md"))
+	if err != nil || text != "# Spec\n\nDo the thing." {
+		t.Fatalf("readPasteFile = %q, %v", text, err)
+	}
+	if _, err := readPasteFile(filepath.Join(dir, "bin.dat")); err == nil {
+		t.Fatal("binary file should be refused")
+	}
+	if _, err := readPasteFile(filepath.Join(dir, "empty.md")); err == nil {
+		t.Fatal("blank file should be refused")
+	}
+}
+
+func TestPasteFileHotkey(t *testing.T) {
+	home, inst := armHomeWithRunningClaudeSession(t, "claude")
+	home.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}, Alt: true})
+	if !home.pasteFileDialog.IsVisible() || home.pasteFileDialog.instanceID != inst.ID {
+		t.Fatal("paste-file key should open the picker for the highlighted session")
+	}
+}
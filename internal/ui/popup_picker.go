@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// PopupPicker is the minimal session list behind `agent-deck popup`: a
+// type-to-filter list of live sessions, most recently used first, sized for a
+// tmux display-popup. Enter hands the session to switchTo; on success the
+// program exits, on failure the error is shown and the list stays open so
+// the popup does not vanish without a word.
+type PopupPicker struct {
+	input    textinput.Model
+	all      []*session.Instance
+	matches  []*session.Instance
+	cursor   int
+	offset   int
+	width    int
+	height   int
+	err      error
+	chosen   *session.Instance
+	switchTo func(*session.Instance) error
+}
+
+// NewPopupPicker lists the live sessions among instances. switchTo performs
+// the switch for the chosen one.
+func NewPopupPicker(instances []*session.Instance, switchTo func(*session.Instance) error) *PopupPicker {
+	ti := textinput.New()
+	ti.Placeholder = "Filter sessions…"
+	ti.Prompt = "› "
+	ti.CharLimit = 100
+	ti.Focus()
+	p := &PopupPicker{input: ti, all: switchableSessions(instances), switchTo: switchTo}
+	p.filter()
+	return p
+}
+
+// RunPopupPicker runs the picker full-screen in the current terminal (the
+// popup) and returns the session switched to, or nil when cancelled.
+func RunPopupPicker(instances []*session.Instance, switchTo func(*session.Instance) error) (*session.Instance, error) {
+	p := NewPopupPicker(instances, switchTo)
+	if len(p.all) == 0 {
+		return nil, fmt.Errorf("no running sessions")
+	}
+	if _, err := tea.NewProgram(p, tea.WithAltScreen()).Run(); err != nil {
+		return nil, err
+	}
+	return p.chosen, nil
+}
+
+// Init implements tea.Model.
+func (p *PopupPicker) Init() tea.Cmd { return textinput.Blink }
+
+// popupSource adapts the session list to fuzzy.Source, matching on title,
+// group and tool.
+type popupSource []*session.Instance
+
+func (s popupSource) String(i int) string {
+	return s[i].Title + " " + s[i].GroupPath + " " + s[i].Tool
+}
+func (s popupSource) Len() int { return len(s) }
+
+// filter recomputes matches for the query: every session in MRU order when
+// empty, otherwise fuzzy matches best first.
+func (p *PopupPicker) filter() {
+	query := strings.TrimSpace(p.input.Value())
+	if query == "" {
+		p.matches = append(p.matches[:0], p.all...)
+	} else {
+		p.matches = p.matches[:0]
+		for _, m := range fuzzy.FindFrom(query, popupSource(p.all)) {
+			p.matches = append(p.matches, p.all[m.Index])
+		}
+	}
+	p.cursor = 0
+	p.offset = 0
+}
+
+// visibleRows is how many sessions fit below the input and above the hint.
+func (p *PopupPicker) visibleRows() int {
+	if p.height <= 0 {
+		return 10
+	}
+	return max(p.height-4, 1)
+}
+
+// Update implements tea.Model.
+func (p *PopupPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width, p.height = msg.Width, msg.Height
+		p.input.Width = max(msg.Width-4, 10)
+		return p, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			return p, tea.Quit
+		case "up", "ctrl+p":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+		case "down", "ctrl+n":
+			if p.cursor < len(p.matches)-1 {
+				p.cursor++
+			}
+		case "enter":
+			if p.cursor >= len(p.matches) {
+				return p, nil
+			}
+			inst := p.matches[p.cursor]
+			if err := p.switchTo(inst); err != nil {
+				p.err = err
+				return p, nil
+			}
+			p.chosen = inst
+			return p, tea.Quit
+		default:
+			before := p.input.Value()
+			var cmd tea.Cmd
+			p.input, cmd = p.input.Update(msg)
+			if p.input.Value() != before {
+				p.err = nil
+				p.filter()
+			}
+			return p, cmd
+		}
+		if rows := p.visibleRows(); p.cursor < p.offset {
+			p.offset = p.cursor
+		} else if p.cursor >= p.offset+rows {
+			p.offset = p.cursor - rows + 1
+		}
+	}
+	return p, nil
+}
+
+// View implements tea.Model.
+func (p *PopupPicker) View() string {
+	selStyle := lipgloss.NewStyle().Foreground(ColorBg).Background(ColorAccent).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(ColorText)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+
+	width := p.width
+	if width <= 0 {
+		width = 60
+	}
+	lines := []string{p.input.View(), ""}
+	if len(p.matches) == 0 {
+		lines = append(lines, dimStyle.Render("  No matching sessions"))
+	}
+	end := min(p.offset+p.visibleRows(), len(p.matches))
+	for i := p.offset; i < end; i++ {
+		inst := p.matches[i]
+		detail := inst.Tool
+		if inst.GroupPath != "" {
+			detail = inst.GroupPath + " · " + detail
+		}
+		title := truncateStr(inst.Title, max(width-lipgloss.Width(detail)-6, 8))
+		gap := max(width-lipgloss.Width(title)-lipgloss.Width(detail)-5, 1)
+		if i == p.cursor {
+			lines = append(lines, statusIndicator(inst.GetStatusThreadSafe())+" "+selStyle.Render(" "+title+strings.Repeat(" ", gap)+detail+" "))
+		} else {
+			lines = append(lines, statusIndicator(inst.GetStatusThreadSafe())+" "+rowStyle.Render(" "+title+strings.Repeat(" ", gap))+dimStyle.Render(detail))
+		}
+	}
+	if p.err != nil {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(ColorRed).Render(p.err.Error()))
+	}
+	lines = append(lines, "", dimStyle.Render("↑/↓ select · Enter switch · Esc close"))
+	return strings.Join(lines, "\n")
+}
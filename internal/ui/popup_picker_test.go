@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func popupFixture() []*session.Instance {
+	api := session.NewInstanceWithGroupAndTool("api", "/tmp/api", "work", "claude")
+	web := session.NewInstanceWithGroupAndTool("web", "/tmp/web", "work", "codex")
+	docs := session.NewInstanceWithGroupAndTool("docs", "/tmp/docs", "misc", "shell")
+	stopped := session.NewInstanceWithGroupAndTool("old", "/tmp/old", "misc", "shell")
+	stopped.SetStatusThreadSafe(session.StatusStopped)
+	now := time.Now()
+	api.LastAccessedAt = now.Add(-time.Hour)
+	web.LastAccessedAt = now
+	docs.LastAccessedAt = now.Add(-2 * time.Hour)
+	return []*session.Instance{api, web, docs, stopped}
+}
+
+func TestPopupPicker_ListsLiveSessionsMRU(t *testing.T) {
+	p := NewPopupPicker(popupFixture(), func(*session.Instance) error { return nil })
+	var titles []string
+	for _, inst := range p.matches {
+		titles = append(titles, inst.Title)
+	}
+	if len(titles) != 3 || titles[0] != "web" || titles[1] != "api" || titles[2] != "docs" {
+		t.Fatalf("popup list = %v, want live sessions most recent first", titles)
+	}
+}
+
+func TestPopupPicker_FilterAndSwitch(t *testing.T) {
+	var switched *session.Instance
+	p := NewPopupPicker(popupFixture(), func(inst *session.Instance) error {
+		switched = inst
+		return nil
+	})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("doc")})
+	if len(p.matches) != 1 || p.matches[0].Title != "docs" {
+		t.Fatalf("filter 'doc' matched %d sessions", len(p.matches))
+	}
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if switched == nil || switched.Title != "docs" || p.chosen != switched {
+		t.Fatal("enter should switch to the highlighted session")
+	}
+	if cmd == nil {
+		t.Fatal("a successful switch should quit the picker")
+	}
+}
+
+func TestPopupPicker_SwitchErrorKeepsOpen(t *testing.T) {
+	p := NewPopupPicker(popupFixture(), func(*session.Instance) error { return errors.New("wrong socket") })
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || p.chosen != nil {
+		t.Fatal("a failed switch should leave the picker open")
+	}
+	if p.err == nil || !strings.Contains(tmux.StripANSI(p.View()), "wrong socket") {
+		t.Fatalf("the switch error should be shown, got %q", p.View())
+	}
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Preview scrollback mode: the preview_scroll_up/down keys slide the preview
+// window over the captured history (the same previewScrollOffset the mouse
+// wheel drives, #574) so agent output can be reviewed without attaching. When
+// the user scrolls into the oldest captured line and the capture was cut off
+// at its depth, the selected preview is re-fetched with a deeper history.
+
+// previewHistoryMaxLines caps how far scrollback mode will grow the capture.
+const previewHistoryMaxLines = 20000
+
+// previewHistoryLinesFor returns the capture depth for a preview key: the
+// grown depth for the key being scrolled, the tmux default otherwise.
+func (h *Home) previewHistoryLinesFor(key string) int {
+	if key != "" && key == h.previewHistoryKey && h.previewHistoryLines > 0 {
+		return h.previewHistoryLines
+	}
+	return tmux.DefaultHistoryLines
+}
+
+// previewScrollPage is how many lines one scroll key press moves: half the
+// preview height, like the list's ctrl+u/ctrl+d.
+func (h *Home) previewScrollPage() int {
+	return max(3, (h.height-6)/2)
+}
+
+// scrollPreview moves the preview window up (delta > 0) or down (delta < 0)
+// by delta pages and, when the top of the capture is reached, returns a
+// command that fetches a deeper history.
+func (h *Home) scrollPreview(delta int) tea.Cmd {
+	inst, key, winIdx := h.selectedPreviewTarget()
+	if inst == nil || key == "" {
+		return nil
+	}
+	h.previewScrollOffset = max(0, h.previewScrollOffset+delta*h.previewScrollPage())
+	if delta <= 0 {
+		if h.previewScrollOffset == 0 {
+			h.previewHistoryKey = "" // back at the tail: drop the grown capture depth
+		}
+		return nil
+	}
+
+	h.previewCacheMu.RLock()
+	content := h.previewCache[key]
+	fetching := h.previewFetchingID == key
+	h.previewCacheMu.RUnlock()
+	captured := strings.Count(content, "\n")
+	depth := h.previewHistoryLinesFor(key)
+	// The capture returns depth scrollback lines plus the visible screen, so
+	// a capture shorter than depth means tmux has no older history to give.
+	if fetching || captured < depth || h.previewScrollOffset+h.previewScrollPage() < captured {
+		return nil
+	}
+	if depth >= previewHistoryMaxLines {
+		return nil
+	}
+	h.previewHistoryKey = key
+	h.previewHistoryLines = min(depth*2, previewHistoryMaxLines)
+	h.previewCacheMu.Lock()
+	h.previewFetchingID = key
+	h.previewCacheMu.Unlock()
+	return h.fetchPreview(inst, key, winIdx)
+}
+
+// resetPreviewScroll returns the preview to its tail. It reports whether the
+// preview was scrolled, so Esc can consume the key only in that case.
+func (h *Home) resetPreviewScroll() bool {
+	if h.previewScrollOffset == 0 {
+		return false
+	}
+	h.previewScrollOffset = 0
+	h.previewHistoryKey = ""
+	return true
+}
+
+// renderPreviewScrollFooter is the last preview line while scrolled up: how
+// much newer output sits below and how to get back to it.
+func renderPreviewScrollFooter(below int, downKey string) string {
+	hint := "Esc tail"
+	if downKey != "" {
+		hint = downKey + " down · " + hint
+	}
+	return lipgloss.NewStyle().
+		Foreground(ColorAccent).
+		Italic(true).
+		Render(fmt.Sprintf("⋮ %d more lines below (%s)", below, hint))
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -205,3 +206,60 @@ func TestPreviewScroll_SingleLayoutMode_WheelMovesCursor(t *testing.T) {
 		t.Fatalf("single-layout WheelDown: previewScrollOffset=%d, want 0 (no preview scroll in single layout)", h.previewScrollOffset)
 	}
 }
+
+// Scrollback mode: "[" / "]" page the preview through its history and Esc
+// returns to the tail.
+func TestPreviewScroll_Keys_MoveOffsetAndEscReturnsToTail(t *testing.T) {
+	h, _ := previewScrollSessionWithLines(t, 120, 40, 50)
+	page := h.previewScrollPage()
+
+	h.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+	h.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+	if h.previewScrollOffset != 2*page {
+		t.Fatalf("offset = %d, want %d", h.previewScrollOffset, 2*page)
+	}
+	h.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+	if h.previewScrollOffset != page {
+		t.Fatalf("offset = %d, want %d", h.previewScrollOffset, page)
+	}
+
+	h.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if h.previewScrollOffset != 0 {
+		t.Fatalf("esc should return to the tail, offset = %d", h.previewScrollOffset)
+	}
+}
+
+// Scrolling into the top of a capture that filled its history depth re-fetches
+// with a deeper history; returning to the tail drops the grown depth.
+func TestPreviewScroll_Keys_GrowHistoryAtTopOfFullCapture(t *testing.T) {
+	h, inst := previewScrollSessionWithLines(t, 120, 40, tmux.DefaultHistoryLines+41)
+	h.previewScrollOffset = tmux.DefaultHistoryLines + 30
+
+	if cmd := h.scrollPreview(1); cmd == nil {
+		t.Fatal("scrolling into the top of a full capture should fetch more history")
+	}
+	if got := h.previewHistoryLinesFor(inst.ID); got != 2*tmux.DefaultHistoryLines {
+		t.Fatalf("history depth = %d, want %d", got, 2*tmux.DefaultHistoryLines)
+	}
+
+	h.resetPreviewScroll()
+	if got := h.previewHistoryLinesFor(inst.ID); got != tmux.DefaultHistoryLines {
+		t.Fatalf("reset should drop the grown depth, got %d", got)
+	}
+}
+
+func TestPreviewScroll_Keys_ShortCaptureDoesNotRefetch(t *testing.T) {
+	h, _ := previewScrollSessionWithLines(t, 120, 40, 30)
+	for i := 0; i < 5; i++ {
+		if cmd := h.scrollPreview(1); cmd != nil {
+			t.Fatal("a capture shorter than the history depth has nothing older to fetch")
+		}
+	}
+}
+
+func TestPreviewScroll_Footer(t *testing.T) {
+	got := renderPreviewScrollFooter(12, "]")
+	if !strings.Contains(got, "12 more lines below") || !strings.Contains(got, "] down") {
+		t.Fatalf("footer = %q", got)
+	}
+}
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// profileEntry is one row of the profile picker.
+type profileEntry struct {
+	name     string
+	sessions int // -1 when the count could not be read
+}
+
+// profileListFunc returns every profile with its session count. Injected via
+// the picker for deterministic tests.
+type profileListFunc func() ([]profileEntry, error)
+
+// ProfilePicker is an overlay listing the available profiles so the user can
+// switch the deck to another one without leaving the terminal. Opened with
+// ShowMove it instead picks the profile to transfer a session to.
+type ProfilePicker struct {
+	visible   bool
+	current   string
+	moveID    string // session being moved; "" in switch mode
+	moveTitle string
+	entries   []profileEntry
+	cursor    int
+	errMsg    string
+	width     int
+	height    int
+	listFn    profileListFunc
+}
+
+// NewProfilePicker constructs a picker reading profiles from disk.
+func NewProfilePicker() *ProfilePicker {
+	return &ProfilePicker{listFn: defaultProfileList}
+}
+
+func defaultProfileList() ([]profileEntry, error) {
+	names, err := session.ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]profileEntry, 0, len(names))
+	for _, name := range names {
+		n, err := session.ProfileSessionCount(name)
+		if err != nil {
+			n = -1
+		}
+		entries = append(entries, profileEntry{name: name, sessions: n})
+	}
+	return entries, nil
+}
+
+// Show opens the picker with the cursor on current, the profile this deck
+// is displaying. current is always listed, even before it has a state.db.
+func (p *ProfilePicker) Show(current string) {
+	if current == "" {
+		current = session.DefaultProfile
+	}
+	p.visible = true
+	p.current = current
+	p.moveID = ""
+	p.moveTitle = ""
+	p.cursor = 0
+	p.errMsg = ""
+
+	entries, err := p.listFn()
+	if err != nil {
+		p.errMsg = err.Error()
+	}
+	found := false
+	for _, e := range entries {
+		if e.name == current {
+			found = true
+			break
+		}
+	}
+	if !found {
+		entries = append([]profileEntry{{name: current}}, entries...)
+	}
+	p.entries = entries
+	for i, e := range entries {
+		if e.name == current {
+			p.cursor = i
+		}
+	}
+}
+
+// ShowMove opens the picker to choose the profile the session id (titled
+// title) is moved to.
+func (p *ProfilePicker) ShowMove(current, id, title string) {
+	p.Show(current)
+	p.moveID = id
+	p.moveTitle = title
+}
+
+// MoveSessionID returns the session being moved, or "" in switch mode.
+func (p *ProfilePicker) MoveSessionID() string { return p.moveID }
+
+// Hide closes the picker.
+func (p *ProfilePicker) Hide() {
+	p.visible = false
+}
+
+// IsVisible reports whether the picker is currently shown.
+func (p *ProfilePicker) IsVisible() bool { return p.visible }
+
+// Selected returns the highlighted profile, or "" when the list is empty.
+func (p *ProfilePicker) Selected() string {
+	if p.cursor < 0 || p.cursor >= len(p.entries) {
+		return ""
+	}
+	return p.entries[p.cursor].name
+}
+
+// Current returns the profile the picker was opened from.
+func (p *ProfilePicker) Current() string { return p.current }
+
+// SetSize updates the dialog viewport for centering.
+func (p *ProfilePicker) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// Update moves the cursor.
+func (p *ProfilePicker) Update(msg tea.KeyMsg) *ProfilePicker {
+	switch msg.String() {
+	case "up", "k", "ctrl+p":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j", "ctrl+n":
+		if p.cursor < len(p.entries)-1 {
+			p.cursor++
+		}
+	}
+	return p
+}
+
+// View renders the overlay, centered in the viewport.
+func (p *ProfilePicker) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	heading := "Switch Profile"
+	if p.moveID != "" {
+		heading = fmt.Sprintf("Move %q to Profile", p.moveTitle)
+	}
+	title := DialogTitleStyle.Render(heading)
+
+	rowStyle := lipgloss.NewStyle().Foreground(ColorText).Padding(0, 1)
+	selStyle := lipgloss.NewStyle().
+		Foreground(ColorBg).
+		Background(ColorAccent).
+		Bold(true).
+		Padding(0, 1)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+
+	rows := make([]string, 0, len(p.entries))
+	for i, e := range p.entries {
+		count := "? sessions"
+		switch {
+		case e.sessions == 1:
+			count = "1 session"
+		case e.sessions >= 0:
+			count = fmt.Sprintf("%d sessions", e.sessions)
+		}
+		marker := "  "
+		if e.name == p.current {
+			marker = "● "
+		}
+		line := fmt.Sprintf("%s%-20s %s", marker, e.name, count)
+		if i == p.cursor {
+			rows = append(rows, selStyle.Render(line))
+		} else {
+			rows = append(rows, rowStyle.Render(line))
+		}
+	}
+	listBlock := strings.Join(rows, "\n")
+	if p.errMsg != "" {
+		listBlock = lipgloss.NewStyle().Foreground(ColorRed).Render("⚠ "+p.errMsg) + "\n" + listBlock
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	action := "switch"
+	note := []string{
+		"Switching restarts the deck on the chosen profile;",
+		"running sessions keep running.",
+	}
+	if p.moveID != "" {
+		action = "move"
+		note = []string{
+			"The session leaves this profile; if running,",
+			"its tmux session keeps running.",
+		}
+	}
+	hint := hintStyle.Render("↑/↓ navigate │ Enter " + action + " │ Esc cancel")
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		"",
+		listBlock,
+		"",
+		dimStyle.Render(note[0]),
+		dimStyle.Render(note[1]),
+		"",
+		hint,
+	)
+
+	dialog := DialogBoxStyle.
+		Width(fitDialogWidth(50, 36, p.width)).
+		Render(content)
+
+	return lipgloss.Place(
+		p.width,
+		p.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
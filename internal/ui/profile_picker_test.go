@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestProfilePickerShowAndSelect(t *testing.T) {
+	p := &ProfilePicker{listFn: func() ([]profileEntry, error) {
+		return []profileEntry{{name: "default", sessions: 3}, {name: "work", sessions: 1}, {name: "broken", sessions: -1}}, nil
+	}}
+	p.SetSize(100, 40)
+	p.Show("work")
+
+	if got := p.Selected(); got != "work" {
+		t.Fatalf("cursor starts on %q, want the current profile", got)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := p.Selected(); got != "broken" {
+		t.Errorf("after down: %q, want broken", got)
+	}
+
+	view := p.View()
+	for _, want := range []string{"Switch Profile", "3 sessions", "1 session", "? sessions", "● work"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestProfilePickerListsCurrentWithoutStateDB(t *testing.T) {
+	p := &ProfilePicker{listFn: func() ([]profileEntry, error) {
+		return nil, errors.New("boom")
+	}}
+	p.Show("")
+	if p.Current() != "default" || p.Selected() != "default" {
+		t.Errorf("current/selected = %q/%q, want default", p.Current(), p.Selected())
+	}
+	if p.errMsg == "" {
+		t.Error("list error should be shown")
+	}
+}
+
+func TestHandleProfilePickerKeySwitch(t *testing.T) {
+	h := NewHome()
+	h.profile = "default"
+	h.profilePicker.listFn = func() ([]profileEntry, error) {
+		return []profileEntry{{name: "default"}, {name: "work"}}, nil
+	}
+	h.profilePicker.Show(h.profile)
+
+	h.handleProfilePickerKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if h.PendingProfileSwitch() != "" || h.isQuitting {
+		t.Fatal("picking the current profile must not quit")
+	}
+
+	h.profilePicker.Show(h.profile)
+	h.handleProfilePickerKey(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := h.handleProfilePickerKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if h.PendingProfileSwitch() != "work" || !h.isQuitting || cmd == nil {
+		t.Errorf("pending = %q, quitting = %v; want work and a quit", h.PendingProfileSwitch(), h.isQuitting)
+	}
+}
+
+func TestHandleProfilePickerKeyMove(t *testing.T) {
+	h := NewHome()
+	h.profile = "default"
+	h.profilePicker.listFn = func() ([]profileEntry, error) {
+		return []profileEntry{{name: "default"}, {name: "work"}}, nil
+	}
+	h.profilePicker.SetSize(100, 40)
+	h.profilePicker.ShowMove(h.profile, "sess-1", "api")
+	if view := h.profilePicker.View(); !strings.Contains(view, `Move "api" to Profile`) || !strings.Contains(view, "Enter move") {
+		t.Errorf("move view missing heading/hint:\n%s", view)
+	}
+
+	h.handleProfilePickerKey(tea.KeyMsg{Type: tea.KeyDown})
+	h.handleProfilePickerKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if h.isQuitting || h.PendingProfileSwitch() != "" {
+		t.Error("moving a session must not switch the deck's profile")
+	}
+
+	// Switch mode clears a previous move target.
+	h.profilePicker.Show(h.profile)
+	if h.profilePicker.MoveSessionID() != "" {
+		t.Error("Show should reset move mode")
+	}
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// Broadcast sends one typed prompt to many sessions: the prompt key on a group
+// row targets every running session in the group, and while sessions are
+// marked it targets the marked set. Each session gets the same delivery as a
+// single list prompt (#1410), concurrently, and one notice sums up the result.
+
+// promptBroadcastSentMsg reports a broadcast: how many sessions confirmed the
+// prompt and which (by title) did not.
+type promptBroadcastSentMsg struct {
+	sent   int
+	failed []string
+}
+
+// sendListPrompt delivers text to a session pane from the list. guarded
+// (Claude-compatible tools) uses the prompt-state-aware send path so the
+// prompt never merges with a half-typed draft; other tools get plain
+// send-keys + Enter. Runs inside a command goroutine.
+func sendListPrompt(ts *tmux.Session, guarded bool, text string) error {
+	var err error
+	if guarded {
+		err = deliverToConductorPane(ts, text)
+	} else {
+		err = ts.SendKeysAndEnter(text)
+	}
+	if err != nil {
+		uiLog.Warn("list_prompt_send_failed",
+			slog.String("tmux_session", ts.Name),
+			slog.String("error", err.Error()))
+	}
+	return err
+}
+
+// promptableInstances resolves ids to the sessions a prompt can reach: live
+// ones with a tmux session. Stopped and errored sessions are left out.
+func (h *Home) promptableInstances(ids []string) []*session.Instance {
+	h.instancesMu.RLock()
+	defer h.instancesMu.RUnlock()
+	out := make([]*session.Instance, 0, len(ids))
+	for _, id := range ids {
+		inst := h.instanceByID[id]
+		if inst == nil {
+			continue
+		}
+		switch inst.GetStatusThreadSafe() {
+		case session.StatusStopped, session.StatusError:
+			continue
+		}
+		if ts := inst.GetTmuxSession(); ts == nil || ts.Name == "" {
+			continue
+		}
+		out = append(out, inst)
+	}
+	return out
+}
+
+// openBroadcastPrompt opens the prompt input for every running session among
+// ids. label names the set ("group work/api", "3 marked sessions").
+func (h *Home) openBroadcastPrompt(ids []string, label string) {
+	targets := h.promptableInstances(ids)
+	if len(targets) == 0 {
+		h.setError(fmt.Errorf("no running sessions in %s to prompt", label))
+		return
+	}
+	targetIDs := make([]string, len(targets))
+	for i, inst := range targets {
+		targetIDs[i] = inst.ID
+	}
+	noun := "sessions"
+	if len(targets) == 1 {
+		noun = "session"
+	}
+	h.promptInputDialog.ShowBroadcast(targetIDs, fmt.Sprintf("%s (%d running %s)", label, len(targets), noun))
+}
+
+// broadcastPrompt delivers a submitted broadcast to each target that is still
+// running and clears the bulk selection it may have come from.
+func (h *Home) broadcastPrompt(msg promptBroadcastMsg) tea.Cmd {
+	h.clearMarkedSessions()
+	targets := h.promptableInstances(msg.instanceIDs)
+	if len(targets) == 0 {
+		h.setError(fmt.Errorf("none of the broadcast sessions are running any more"))
+		return nil
+	}
+	type target struct {
+		title    string
+		ts       *tmux.Session
+		guarded  bool
+		snapshot func()
+	}
+	list := make([]target, len(targets))
+	for i, inst := range targets {
+		list[i] = target{title: inst.Title, ts: inst.GetTmuxSession(), guarded: session.IsClaudeCompatible(inst.Tool),
+			snapshot: promptSnapshotter(inst)}
+	}
+	text := msg.text
+	return func() tea.Msg {
+		var (
+			wg     sync.WaitGroup
+			mu     sync.Mutex
+			result promptBroadcastSentMsg
+		)
+		for _, t := range list {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				t.snapshot()
+				err := sendListPrompt(t.ts, t.guarded, text)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					result.failed = append(result.failed, t.title)
+				} else {
+					result.sent++
+				}
+			}()
+		}
+		wg.Wait()
+		return result
+	}
+}
+
+// reportPromptBroadcast shows how the broadcast went: a notice for the
+// sessions that got it, an error naming the ones that did not.
+func (h *Home) reportPromptBroadcast(msg promptBroadcastSentMsg) {
+	if len(msg.failed) > 0 {
+		h.setError(fmt.Errorf("prompt not delivered to %s", strings.Join(msg.failed, ", ")))
+	}
+	if msg.sent > 0 {
+		h.maintenanceMsg = fmt.Sprintf("Prompt sent to %d session(s)", msg.sent)
+		h.maintenanceMsgTime = time.Now()
+	}
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newBroadcastHome is the multi-select fixture with a (work) and c (work/sub)
+// running and b (work) and d (other) stopped.
+func newBroadcastHome(t *testing.T) (*Home, []*session.Instance) {
+	t.Helper()
+	home, insts := newMultiSelectHome(t)
+	home.hotkeys = resolveHotkeys(nil)
+	for _, inst := range insts {
+		inst.SetStatusThreadSafe(session.StatusStopped)
+	}
+	for _, inst := range []*session.Instance{insts[0], insts[2]} {
+		inst.SetTmuxSessionForTest(tmux.ReconnectSessionLazy("agentdeck_broadcast_"+inst.Title, inst.ID, inst.ProjectPath, inst.Tool, "idle"))
+		inst.SetStatusThreadSafe(session.StatusIdle)
+	}
+	return home, insts
+}
+
+func TestPromptBroadcast_GroupRowTargetsRunningSessions(t *testing.T) {
+	home, insts := newBroadcastHome(t)
+
+	moveCursorTo(t, home, func(it session.Item) bool { return it.Type == session.ItemTypeGroup && it.Path == "work" })
+	pressKey(home, []rune(defaultHotkeyBindings[hotkeyPromptSession])[0])
+	d := home.promptInputDialog
+	if !d.IsVisible() {
+		t.Fatal("prompt key on a group row should open the broadcast input")
+	}
+	want := []string{insts[0].ID, insts[2].ID}
+	if strings.Join(d.broadcastIDs, ",") != strings.Join(want, ",") {
+		t.Fatalf("broadcast targets = %v, want a and c (running, incl. subgroup)", d.broadcastIDs)
+	}
+	if !strings.Contains(d.View("list"), "Broadcast → group work") {
+		t.Fatalf("bar should name the group, got %q", d.View("list"))
+	}
+
+	d.input.SetValue("run the test suite")
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("submit should emit a command")
+	}
+	msg, ok := cmd().(promptBroadcastMsg)
+	if !ok {
+		t.Fatalf("submit emitted %T, want promptBroadcastMsg", cmd())
+	}
+	if msg.text != "run the test suite" || len(msg.instanceIDs) != 2 {
+		t.Fatalf("broadcast msg = %+v", msg)
+	}
+}
+
+func TestPromptBroadcast_GroupWithoutRunningSessions(t *testing.T) {
+	home, _ := newBroadcastHome(t)
+
+	moveCursorTo(t, home, func(it session.Item) bool { return it.Type == session.ItemTypeGroup && it.Path == "other" })
+	pressKey(home, []rune(defaultHotkeyBindings[hotkeyPromptSession])[0])
+	if home.promptInputDialog.IsVisible() {
+		t.Fatal("broadcast input should not open for a group with nothing running")
+	}
+	if home.err == nil || !strings.Contains(home.err.Error(), "no running sessions") {
+		t.Fatalf("expected a no-running-sessions error, got %v", home.err)
+	}
+}
+
+func TestPromptBroadcast_MarkedSessions(t *testing.T) {
+	home, insts := newBroadcastHome(t)
+
+	for _, inst := range []*session.Instance{insts[1], insts[2]} {
+		moveCursorTo(t, home, func(it session.Item) bool { return it.Session == inst })
+		pressKey(home, 'V')
+	}
+	pressKey(home, []rune(defaultHotkeyBindings[hotkeyPromptSession])[0])
+	d := home.promptInputDialog
+	if !d.IsVisible() || len(d.broadcastIDs) != 1 || d.broadcastIDs[0] != insts[2].ID {
+		t.Fatalf("marked broadcast should target only the running marked session, got visible=%v ids=%v", d.IsVisible(), d.broadcastIDs)
+	}
+}
+
+func TestPromptBroadcast_Report(t *testing.T) {
+	home, _ := newBroadcastHome(t)
+
+	home.reportPromptBroadcast(promptBroadcastSentMsg{sent: 2, failed: []string{"b"}})
+	if !strings.Contains(home.maintenanceMsg, "Prompt sent to 2") {
+		t.Fatalf("maintenanceMsg = %q", home.maintenanceMsg)
+	}
+	if home.err == nil || !strings.Contains(home.err.Error(), "b") {
+		t.Fatalf("failed sessions should be named in the error, got %v", home.err)
+	}
+}
@@ -19,17 +19,34 @@ type promptSubmitMsg struct {
 	text       string
 }
 
+// promptSentMsg reports the outcome of a promptSubmitMsg delivery so the list
+// can confirm the send or surface why it failed.
+type promptSentMsg struct {
+	title string
+	err   error
+}
+
+// promptBroadcastMsg is emitted when a broadcast prompt (a group or the marked
+// sessions) is submitted. Home delivers text to each session the same way a
+// single promptSubmitMsg is delivered.
+type promptBroadcastMsg struct {
+	instanceIDs []string
+	text        string
+}
+
 // PromptInputDialog is a one-line input anchored at the bottom of the list that
 // sends a prompt to the highlighted session without attaching (issue #1410,
 // Lawrence-Dawson feedback). It mirrors the Search component: a focused
 // textinput.Model that consumes keys while visible and surfaces submit/cancel.
+// Opened with ShowBroadcast it targets several sessions at once instead.
 type PromptInputDialog struct {
-	input      textinput.Model
-	visible    bool
-	width      int
-	height     int
-	instanceID string
-	title      string
+	input        textinput.Model
+	visible      bool
+	width        int
+	height       int
+	instanceID   string
+	title        string
+	broadcastIDs []string // non-empty while broadcasting; instanceID is then unused
 }
 
 // NewPromptInputDialog creates the inline prompt input (hidden).
@@ -46,16 +63,25 @@ func (d *PromptInputDialog) Show(instanceID, title string) {
 	d.visible = true
 	d.instanceID = instanceID
 	d.title = title
+	d.broadcastIDs = nil
 	d.input.SetValue("")
 	d.input.Focus()
 }
 
+// ShowBroadcast opens the input targeting every session in instanceIDs;
+// label names the target set in the bar ("3 marked sessions").
+func (d *PromptInputDialog) ShowBroadcast(instanceIDs []string, label string) {
+	d.Show("", label)
+	d.broadcastIDs = instanceIDs
+}
+
 // Hide closes the input and blurs it.
 func (d *PromptInputDialog) Hide() {
 	d.visible = false
 	d.input.Blur()
 	d.instanceID = ""
 	d.title = ""
+	d.broadcastIDs = nil
 }
 
 // IsVisible reports whether the input is open. Nil-safe: some test paths and
@@ -94,11 +120,17 @@ func (d *PromptInputDialog) Update(msg tea.KeyMsg) (*PromptInputDialog, tea.Cmd)
 	case "enter":
 		text := strings.TrimSpace(d.input.Value())
 		instanceID := d.instanceID
+		broadcastIDs := d.broadcastIDs
 		if text == "" {
 			d.Hide()
 			return d, nil
 		}
 		d.Hide()
+		if len(broadcastIDs) > 0 {
+			return d, func() tea.Msg {
+				return promptBroadcastMsg{instanceIDs: broadcastIDs, text: text}
+			}
+		}
 		return d, func() tea.Msg {
 			return promptSubmitMsg{instanceID: instanceID, text: text}
 		}
@@ -124,6 +156,9 @@ func (d *PromptInputDialog) View(listBody string) string {
 		barWidth = d.width
 	}
 	label := "Prompt → " + d.title
+	if len(d.broadcastIDs) > 0 {
+		label = "Broadcast → " + d.title
+	}
 	bar := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(ColorAccent).
@@ -6,6 +6,8 @@
 package ui
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -151,16 +153,19 @@ func TestPromptHotkey_OpensInputForClaudeSession(t *testing.T) {
 	}
 }
 
-// TestPromptHotkey_NonClaudeSessionNoOp: the hotkey is inert on a non-claude
-// session (the composer-draft guard + delivery verify are claude-shaped).
-func TestPromptHotkey_NonClaudeSessionNoOp(t *testing.T) {
-	home, _ := armHomeWithRunningClaudeSession(t, "shell")
+// TestPromptHotkey_OpensInputForNonClaudeSession: non-Claude tools get the
+// prompt input too; delivery falls back to plain send-keys for them.
+func TestPromptHotkey_OpensInputForNonClaudeSession(t *testing.T) {
+	home, inst := armHomeWithRunningClaudeSession(t, "shell")
 
 	key := defaultHotkeyBindings[hotkeyPromptSession]
 	home.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
 
-	if home.promptInputDialog.IsVisible() {
-		t.Error("prompt input must not open for a non-claude session")
+	if !home.promptInputDialog.IsVisible() {
+		t.Fatal("prompt input should open for a non-claude session")
+	}
+	if home.promptInputDialog.instanceID != inst.ID {
+		t.Errorf("prompt input bound to %q, want %q", home.promptInputDialog.instanceID, inst.ID)
 	}
 }
 
@@ -191,3 +196,25 @@ func TestPromptSubmitMsg_MissingSessionErrors(t *testing.T) {
 		t.Error("prompt to a missing session should surface an error")
 	}
 }
+
+// TestPromptSentMsg_ReportsOutcome: a confirmed send shows a transient notice;
+// a failed one surfaces the delivery error instead of only logging it.
+func TestPromptSentMsg_ReportsOutcome(t *testing.T) {
+	home, _ := armHomeWithRunningClaudeSession(t, "claude")
+	home.err = nil
+
+	model, cmd := home.updateInner(promptSentMsg{title: "prompt-session"})
+	h := model.(*Home)
+	if h.err != nil || !strings.Contains(h.maintenanceMsg, "prompt-session") {
+		t.Fatalf("success should set a notice, got err=%v msg=%q", h.err, h.maintenanceMsg)
+	}
+	if cmd == nil {
+		t.Error("success notice should schedule its own dismissal")
+	}
+
+	model, _ = home.updateInner(promptSentMsg{title: "prompt-session", err: errors.New("pane gone")})
+	h = model.(*Home)
+	if h.err == nil || !strings.Contains(h.err.Error(), "pane gone") {
+		t.Fatalf("failed delivery should surface an error, got %v", h.err)
+	}
+}
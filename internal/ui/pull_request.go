@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// pullRequestCreatedMsg reports the outcome of createPullRequest.
+type pullRequestCreatedMsg struct {
+	title string
+	url   string
+	err   error
+}
+
+// confirmCreatePullRequest opens the confirm dialog for 'B', naming the
+// branch and the remote it will be pushed to. Pushing is outward-facing, so
+// it never happens on a single keypress.
+func (h *Home) confirmCreatePullRequest(inst *session.Instance) {
+	if !inst.IsWorktree() {
+		h.setError(fmt.Errorf("session '%s' is not a worktree", inst.Title))
+		return
+	}
+	branch := inst.WorktreeBranch
+	if branch == "" {
+		b, err := git.GetCurrentBranch(inst.WorktreePath)
+		if err != nil {
+			h.setError(err)
+			return
+		}
+		branch = b
+	}
+	remote, err := git.PushRemote(inst.WorktreePath)
+	if err != nil {
+		h.setError(err)
+		return
+	}
+	h.confirmDialog.ShowCreatePullRequest(inst.ID, inst.Title, branch, remote)
+}
+
+// createPullRequest pushes the worktree session's branch and opens a PR
+// with gh, the body pre-filled from the agent's last reply. Push and gh
+// run off the UI thread; a banner shows progress meanwhile. Reached only
+// through the ConfirmCreatePullRequest dialog.
+func (h *Home) createPullRequest(inst *session.Instance) tea.Cmd {
+	h.maintenanceMsg = fmt.Sprintf("Pushing %s and opening a pull request...", inst.WorktreeBranch)
+	h.maintenanceMsgTime = time.Now()
+	title := inst.Title
+	return func() tea.Msg {
+		url, err := inst.CreatePullRequest(session.PullRequestOptions{})
+		if err != nil {
+			uiLog.Warn("create_pr_failed",
+				slog.String("id", inst.ID),
+				slog.String("error", err.Error()))
+		}
+		return pullRequestCreatedMsg{title: title, url: url, err: err}
+	}
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// TestCreatePR_KeyOpensConfirmInsteadOfPushing — 'B' must only ask; the push
+// and gh call happen after the user confirms.
+func TestCreatePR_KeyOpensConfirmInsteadOfPushing(t *testing.T) {
+	repo := t.TempDir()
+	gitMustUI(t, repo, "init", "-q", "-b", "feature")
+	gitMustUI(t, repo, "remote", "add", "upstream", "https://example.invalid/repo.git")
+
+	h := newSeamATestHome()
+	h.flatItems = []session.Item{{
+		Type: session.ItemTypeSession,
+		Session: &session.Instance{
+			ID:             "pr-1",
+			Title:          "login-fix",
+			WorktreePath:   repo,
+			WorktreeBranch: "feature",
+		},
+	}}
+	h.cursor = 0
+
+	newModel, cmd := h.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+	got := newModel.(*Home)
+
+	if cmd != nil {
+		t.Fatal("'B' should not start the push before confirmation")
+	}
+	if !got.confirmDialog.IsVisible() || got.confirmDialog.GetConfirmType() != ConfirmCreatePullRequest {
+		t.Fatalf("expected ConfirmCreatePullRequest dialog, got visible=%v type=%v", got.confirmDialog.IsVisible(), got.confirmDialog.GetConfirmType())
+	}
+	got.confirmDialog.SetSize(120, 40)
+	view := got.confirmDialog.View()
+	if !strings.Contains(view, "feature") || !strings.Contains(view, "upstream") {
+		t.Fatalf("confirm dialog should name branch and remote, got:\n%s", view)
+	}
+
+	newModel, _ = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if newModel.(*Home).confirmDialog.IsVisible() {
+		t.Fatal("'n' should dismiss the dialog")
+	}
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/sysinfo"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// resourceSampleInterval throttles process-table reads; the background status
+// loop runs far more often than usage needs refreshing.
+const resourceSampleInterval = 5 * time.Second
+
+// sampleResourceUsage records the CPU and memory of each session's pane
+// process tree on its instance (see session.ResourceUsage), for the preview
+// header and group_sort = "resources". Pane PIDs come from the pane-info
+// cache, so the only extra cost is one process-table read. Runs in the
+// background status loop, at most every resourceSampleInterval.
+func (h *Home) sampleResourceUsage(instances []*session.Instance) {
+	now := time.Now()
+	if now.Sub(h.lastResourceSample) < resourceSampleInterval {
+		return
+	}
+	h.lastResourceSample = now
+
+	panePIDs := make(map[string]int, len(instances))
+	roots := make([]int, 0, len(instances))
+	for _, inst := range instances {
+		ts := inst.GetTmuxSession()
+		if ts == nil {
+			continue
+		}
+		if info, ok := tmux.GetCachedPaneInfo(ts.Name); ok && info.PID > 0 && !info.Dead {
+			panePIDs[inst.ID] = info.PID
+			roots = append(roots, info.PID)
+		}
+	}
+
+	usage, err := h.procSampler.Sample(roots)
+	if err != nil {
+		statusLog.Debug("resource_sample_failed", slog.String("error", err.Error()))
+		return
+	}
+	for _, inst := range instances {
+		u, ok := usage[panePIDs[inst.ID]]
+		if !ok {
+			inst.SetResourceUsage(session.ResourceUsage{})
+			continue
+		}
+		inst.SetResourceUsage(session.ResourceUsage{
+			CPUPercent: u.CPUPercent,
+			MemBytes:   u.MemBytes,
+			Procs:      u.Procs,
+			SampledAt:  now,
+		})
+	}
+}
+
+// formatResourceUsage renders a sample for the preview header:
+// "CPU 12% · RAM 1.4G". Empty when there is no sample.
+func formatResourceUsage(u session.ResourceUsage) string {
+	if !u.Known() {
+		return ""
+	}
+	return fmt.Sprintf("CPU %.0f%% · RAM %s", u.CPUPercent, sysinfo.FormatBytes(u.MemBytes))
+}
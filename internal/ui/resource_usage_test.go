@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+func TestFormatResourceUsage(t *testing.T) {
+	if got := formatResourceUsage(session.ResourceUsage{}); got != "" {
+		t.Errorf("no sample should render empty, got %q", got)
+	}
+	u := session.ResourceUsage{CPUPercent: 12.4, MemBytes: 3 << 29, SampledAt: time.Now()}
+	if got := formatResourceUsage(u); got != "CPU 12% · RAM 1.5G" {
+		t.Errorf("formatResourceUsage = %q", got)
+	}
+}
+
+func TestRenderSessionItem_UsageColumnInResourcesSort(t *testing.T) {
+	t.Cleanup(func() { session.SetGroupSortMode("creation") })
+	home, insts := newMultiSelectHome(t)
+	home.initialLoading = false
+	a := insts[0]
+	a.SetResourceUsage(session.ResourceUsage{MemBytes: 6 << 30, SampledAt: time.Now()})
+
+	session.SetGroupSortMode("creation")
+	if view := tmux.StripANSI(home.View()); strings.Contains(view, "6.0G") {
+		t.Errorf("memory column should only show while sorting by resources:\n%s", view)
+	}
+
+	session.SetGroupSortMode("resources")
+	if view := tmux.StripANSI(home.View()); !strings.Contains(view, "6.0G") {
+		t.Errorf("resources sort should show the memory column:\n%s", view)
+	}
+}
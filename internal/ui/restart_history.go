@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// previewRestartHistoryRows is how many of the most recent restarts the
+// preview info section lists. The full history is in `session show --json`
+// and the web API.
+const previewRestartHistoryRows = 3
+
+// renderRestartHistorySection renders the preview "Restarts" section, newest
+// first. Returns "" when the session has never been restarted.
+func renderRestartHistorySection(history []session.RestartEvent, width int) string {
+	if len(history) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(renderSectionDivider(fmt.Sprintf("Restarts (%d)", len(history)), width-4))
+	b.WriteString("\n")
+
+	timeStyle := lipgloss.NewStyle().Foreground(ColorText)
+	reasonStyle := lipgloss.NewStyle().Foreground(ColorOrange)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	errStyle := lipgloss.NewStyle().Foreground(ColorRed)
+
+	shown := 0
+	for idx := len(history) - 1; idx >= 0 && shown < previewRestartHistoryRows; idx-- {
+		ev := history[idx]
+		shown++
+		reason := string(ev.Reason)
+		if ev.Fresh {
+			reason += " (fresh)"
+		}
+		line := timeStyle.Render(formatRelativeTime(ev.At)) + "  " + reasonStyle.Render(reason)
+		if ev.TmuxSession != "" {
+			line += "  " + dimStyle.Render(ev.TmuxSession)
+		}
+		if ev.Error != "" {
+			line += "  " + errStyle.Render("✕ "+ev.Error)
+		}
+		b.WriteString(cellTruncate(line, max(10, width-4), "..."))
+		b.WriteString("\n")
+	}
+	if rest := len(history) - shown; rest > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("… %d earlier", rest)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package ui
+
+import "time"
+
+// safeMode is set by `agent-deck --safe-mode` before the Home is built. It is
+// process-wide (like Version) because the decision is made once at startup
+// and every subsystem that reads it is wired up inside NewHome*.
+var safeMode bool
+
+// SetSafeMode enables or disables safe mode for Homes created afterwards.
+//
+// Safe mode is a recovery path for users whose deck crashes during startup:
+// file/storage/hook watchers, the watcher engine, the MCP socket pool, global
+// search, tmux notification bar management, control-mode pipes, update checks
+// and remote polling are all skipped, and the status sweep runs at
+// safeModeStatusInterval. What remains is enough to browse sessions, open the
+// settings panel, and fix config or storage.
+func SetSafeMode(v bool) {
+	safeMode = v
+}
+
+// SafeMode reports whether safe mode is enabled for this process.
+func SafeMode() bool {
+	return safeMode
+}
+
+// safeModeStatusInterval is the status-sweep cadence in safe mode. Slow enough
+// that a misbehaving tmux server or a pathological session cannot keep the
+// deck busy, fast enough that the list is not obviously stale.
+const safeModeStatusInterval = 30 * time.Second
+
+// safeModeBanner is shown under the session list while safe mode is active so
+// nobody mistakes the reduced deck for a broken one.
+const safeModeBanner = "⚠ Safe mode: watchers, MCP pool, search, notifications and background workers are disabled. Restart without --safe-mode to re-enable."
+
+// statusIntervals returns the base and ceiling status-sweep cadence.
+func (h *Home) statusIntervals() (base, ceiling time.Duration) {
+	if h.safeMode {
+		return safeModeStatusInterval, safeModeStatusInterval
+	}
+	return baseStatusInterval, maxStatusInterval
+}
@@ -0,0 +1,27 @@
+package ui
+
+import "testing"
+
+func TestStatusIntervals_SafeModeSlowsSweep(t *testing.T) {
+	h := &Home{}
+	if base, ceiling := h.statusIntervals(); base != baseStatusInterval || ceiling != maxStatusInterval {
+		t.Fatalf("normal intervals = %v/%v, want %v/%v", base, ceiling, baseStatusInterval, maxStatusInterval)
+	}
+	h.safeMode = true
+	if base, ceiling := h.statusIntervals(); base != safeModeStatusInterval || ceiling != safeModeStatusInterval {
+		t.Fatalf("safe-mode intervals = %v/%v, want %v", base, ceiling, safeModeStatusInterval)
+	}
+}
+
+func TestSafeMode_CapturedAtConstruction(t *testing.T) {
+	SetSafeMode(true)
+	defer SetSafeMode(false)
+	h := NewHome()
+	defer h.cancel()
+	if !h.safeMode {
+		t.Fatal("Home built after SetSafeMode(true) should be in safe mode")
+	}
+	if h.storageWatcher != nil || h.hookWatcher != nil || h.notificationManager != nil {
+		t.Fatal("safe mode must not start storage/hook watchers or the notification manager")
+	}
+}
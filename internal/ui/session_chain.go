@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// chainFiredMsg reports a session chain that fired (see session/chain.go):
+// the downstream session was started, prompted, or both.
+type chainFiredMsg struct {
+	upstreamTitle   string
+	downstreamID    string
+	downstreamTitle string
+	started         bool
+	prompted        bool
+	err             error
+}
+
+// fireSessionChains compares each session's status with the previous tick
+// and fires the chains waiting on sessions that just finished a turn. The
+// first call only records a baseline so a TUI restart never replays old
+// transitions. Chains are one-shot: they are cleared and saved before the
+// downstream action runs.
+func (h *Home) fireSessionChains() tea.Cmd {
+	h.instancesMu.RLock()
+	instances := make([]*session.Instance, len(h.instances))
+	copy(instances, h.instances)
+	h.instancesMu.RUnlock()
+
+	baseline := h.chainLastStatus == nil
+	prev := h.chainLastStatus
+	h.chainLastStatus = make(map[string]session.Status, len(instances))
+	var cmds []tea.Cmd
+	for _, inst := range instances {
+		status := inst.GetStatusThreadSafe()
+		h.chainLastStatus[inst.ID] = status
+		if baseline || !session.ChainTriggered(prev[inst.ID], status) {
+			continue
+		}
+		for _, next := range session.ChainedAfter(instances, inst.ID) {
+			chain := *next.Chain
+			next.Chain = nil
+			cmds = append(cmds, h.runSessionChain(inst.Title, next, chain))
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	h.saveInstances()
+	return tea.Batch(cmds...)
+}
+
+// runSessionChain starts the downstream session when it isn't running
+// (delivering the prompt as its first message), otherwise sends the prompt
+// through the same guarded path as the list prompt (#1410).
+func (h *Home) runSessionChain(upstreamTitle string, next *session.Instance, chain session.SessionChain) tea.Cmd {
+	id := next.ID
+	return func() tea.Msg {
+		h.instancesMu.RLock()
+		current := h.instanceByID[id]
+		h.instancesMu.RUnlock()
+		msg := chainFiredMsg{upstreamTitle: upstreamTitle, downstreamID: id, downstreamTitle: next.Title}
+		if current == nil {
+			msg.err = fmt.Errorf("session no longer exists")
+			return msg
+		}
+
+		ts := current.GetTmuxSession()
+		if ts == nil || !ts.Exists() {
+			msg.started = true
+			msg.prompted = chain.Prompt != ""
+			if msg.prompted {
+				msg.err = current.StartWithMessage(chain.Prompt)
+			} else {
+				msg.err = current.Start()
+			}
+			return msg
+		}
+		if chain.Prompt == "" {
+			return msg
+		}
+		msg.prompted = true
+		if session.IsClaudeCompatible(current.Tool) {
+			msg.err = deliverToConductorPane(ts, chain.Prompt)
+		} else {
+			msg.err = ts.SendKeysAndEnter(chain.Prompt)
+		}
+		return msg
+	}
+}
+
+// handleChainFired surfaces the outcome of a fired chain.
+func (h *Home) handleChainFired(msg chainFiredMsg) tea.Cmd {
+	if msg.err != nil {
+		h.setError(fmt.Errorf("chain %q → %q failed: %w", msg.upstreamTitle, msg.downstreamTitle, msg.err))
+		return nil
+	}
+	if msg.started {
+		h.invalidatePreviewCache(msg.downstreamID)
+		h.saveInstances()
+	}
+	action := "notified"
+	switch {
+	case msg.started && msg.prompted:
+		action = "started and prompted"
+	case msg.started:
+		action = "started"
+	case msg.prompted:
+		action = "prompted"
+	}
+	h.maintenanceMsg = fmt.Sprintf("%q finished → %s %q", msg.upstreamTitle, action, msg.downstreamTitle)
+	h.maintenanceMsgTime = time.Now()
+	return tea.Tick(5*time.Second, func(_ time.Time) tea.Msg {
+		return clearMaintenanceMsg{}
+	})
+}
+
+// renderSessionChain renders "⛓ after <title>" for the preview header of a
+// session waiting on another one.
+func (h *Home) renderSessionChain(inst *session.Instance) string {
+	if inst.Chain == nil {
+		return ""
+	}
+	upstream := inst.Chain.After
+	if up := h.getInstanceByID(inst.Chain.After); up != nil {
+		upstream = up.Title
+	}
+	return lipgloss.NewStyle().Foreground(ColorCyan).Render("⛓ after " + upstream)
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestFireSessionChains_FiresOnceOnFinishedTurn(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	a, b := insts[0], insts[1]
+	b.Chain = session.NewSessionChain(a.ID, "run the plan")
+
+	a.Status = session.StatusRunning
+	if cmd := home.fireSessionChains(); cmd != nil {
+		t.Fatal("first pass only records a baseline")
+	}
+	if cmd := home.fireSessionChains(); cmd != nil {
+		t.Fatal("no transition, nothing to fire")
+	}
+
+	a.Status = session.StatusWaiting
+	if cmd := home.fireSessionChains(); cmd == nil {
+		t.Fatal("running → waiting should fire b's chain")
+	}
+	if b.Chain != nil {
+		t.Error("chain should be cleared once fired")
+	}
+
+	a.Status = session.StatusRunning
+	home.fireSessionChains()
+	a.Status = session.StatusIdle
+	if cmd := home.fireSessionChains(); cmd != nil {
+		t.Error("a fired chain must not fire again")
+	}
+}
+
+func TestFireSessionChains_ErrorDoesNotPropagate(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	a, b := insts[0], insts[1]
+	b.Chain = session.NewSessionChain(a.ID, "")
+
+	a.Status = session.StatusRunning
+	home.fireSessionChains()
+	a.Status = session.StatusError
+	if cmd := home.fireSessionChains(); cmd != nil || b.Chain == nil {
+		t.Error("an upstream error should leave the chain pending")
+	}
+}
+
+func TestHandleChainFired(t *testing.T) {
+	home, _ := newMultiSelectHome(t)
+	home.handleChainFired(chainFiredMsg{upstreamTitle: "plan", downstreamTitle: "exec", started: true, prompted: true})
+	if !strings.Contains(home.maintenanceMsg, `"plan" finished → started and prompted "exec"`) {
+		t.Errorf("maintenanceMsg = %q", home.maintenanceMsg)
+	}
+	home.handleChainFired(chainFiredMsg{upstreamTitle: "plan", downstreamTitle: "exec", err: errors.New("boom")})
+	if home.err == nil || !strings.Contains(home.err.Error(), "boom") {
+		t.Errorf("err = %v", home.err)
+	}
+}
+
+func TestRenderSessionChain(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	insts[1].Chain = session.NewSessionChain(insts[0].ID, "")
+	if got := home.renderSessionChain(insts[1]); !strings.Contains(got, "after a") {
+		t.Errorf("chain badge = %q, want the upstream title", got)
+	}
+	if home.renderSessionChain(insts[2]) != "" {
+		t.Error("unchained session should render nothing")
+	}
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// sessionEventKind classifies what statusTransitionTracker saw change.
+type sessionEventKind int
+
+const (
+	sessionStatusChanged sessionEventKind = iota
+	sessionAppeared
+	sessionRemoved
+	sessionRestarted
+)
+
+// statusTransition is one change seen between two status sweeps: a status
+// change (from → to), a session that appeared or was removed, or a session
+// that was started again (its LastStartedAt moved).
+type statusTransition struct {
+	kind     sessionEventKind
+	inst     *session.Instance
+	from, to session.Status
+}
+
+type trackedSession struct {
+	inst    *session.Instance
+	status  session.Status
+	started time.Time
+}
+
+// statusTransitionTracker remembers the last status of every session and
+// reports what changed. The first observation only records a baseline so a
+// TUI start never replays the current state as transitions. The zero value
+// is ready to use and safe for the background worker.
+type statusTransitionTracker struct {
+	mu   sync.Mutex
+	last map[string]trackedSession
+}
+
+func (t *statusTransitionTracker) observe(instances []*session.Instance) []statusTransition {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	baseline := t.last == nil
+	prev := t.last
+	t.last = make(map[string]trackedSession, len(instances))
+	var changed []statusTransition
+	for _, inst := range instances {
+		cur := trackedSession{inst: inst, status: inst.GetStatusThreadSafe(), started: inst.LastStartedAt}
+		t.last[inst.ID] = cur
+		if baseline {
+			continue
+		}
+		old, ok := prev[inst.ID]
+		switch {
+		case !ok:
+			changed = append(changed, statusTransition{kind: sessionAppeared, inst: inst, to: cur.status})
+			continue
+		case !old.started.IsZero() && cur.started.After(old.started):
+			changed = append(changed, statusTransition{kind: sessionRestarted, inst: inst, from: old.status, to: cur.status})
+		}
+		if old.status != cur.status {
+			changed = append(changed, statusTransition{kind: sessionStatusChanged, inst: inst, from: old.status, to: cur.status})
+		}
+	}
+	for id, old := range prev {
+		if _, ok := t.last[id]; !ok {
+			changed = append(changed, statusTransition{kind: sessionRemoved, inst: old.inst, from: old.status})
+		}
+	}
+	return changed
+}
+
+// reset forgets all statuses; the next observe is a fresh baseline.
+func (t *statusTransitionTracker) reset() {
+	t.mu.Lock()
+	t.last = nil
+	t.mu.Unlock()
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestStatusTransitionTracker(t *testing.T) {
+	_, insts := newMultiSelectHome(t)
+	a, b := insts[0], insts[1]
+	a.Status = session.StatusRunning
+	b.Status = session.StatusIdle
+
+	var tr statusTransitionTracker
+	if got := tr.observe(insts); len(got) != 0 {
+		t.Fatalf("first observation is a baseline, got %v", got)
+	}
+
+	a.Status = session.StatusWaiting
+	got := tr.observe(insts)
+	if len(got) != 1 || got[0].kind != sessionStatusChanged || got[0].inst != a ||
+		got[0].from != session.StatusRunning || got[0].to != session.StatusWaiting {
+		t.Fatalf("observe = %+v, want a running → waiting", got)
+	}
+	if got := tr.observe(insts); len(got) != 0 {
+		t.Errorf("unchanged statuses must not repeat, got %v", got)
+	}
+
+	tr.reset()
+	a.Status = session.StatusError
+	if got := tr.observe(insts); len(got) != 0 {
+		t.Errorf("observe after reset is a baseline, got %v", got)
+	}
+}
+
+func TestStatusTransitionTracker_Lifecycle(t *testing.T) {
+	_, insts := newMultiSelectHome(t)
+	a := insts[0]
+	a.LastStartedAt = time.Now().Add(-time.Hour)
+
+	var tr statusTransitionTracker
+	tr.observe(insts)
+
+	e := session.NewInstanceWithGroupAndTool("e", "/tmp/e", "work", "shell")
+	got := tr.observe(append(insts, e))
+	if len(got) != 1 || got[0].kind != sessionAppeared || got[0].inst != e {
+		t.Fatalf("observe = %+v, want e appeared", got)
+	}
+
+	// e's first start is not a restart; a's second start is.
+	e.LastStartedAt = time.Now()
+	a.LastStartedAt = time.Now()
+	got = tr.observe(append(insts, e))
+	if len(got) != 1 || got[0].kind != sessionRestarted || got[0].inst != a {
+		t.Fatalf("observe = %+v, want a restarted", got)
+	}
+
+	got = tr.observe(insts)
+	if len(got) != 1 || got[0].kind != sessionRemoved || got[0].inst != e {
+		t.Fatalf("observe = %+v, want e removed", got)
+	}
+}
+
+func TestWebhookEvent(t *testing.T) {
+	cases := []struct {
+		tr   statusTransition
+		want string
+	}{
+		{statusTransition{kind: sessionAppeared}, session.WebhookEventCreated},
+		{statusTransition{kind: sessionRemoved}, session.WebhookEventDeleted},
+		{statusTransition{kind: sessionRestarted}, session.WebhookEventRestarted},
+		{statusTransition{kind: sessionStatusChanged, to: session.StatusWaiting}, session.WebhookEventWaiting},
+		{statusTransition{kind: sessionStatusChanged, to: session.StatusIdle}, session.WebhookEventIdle},
+		{statusTransition{kind: sessionStatusChanged, to: session.StatusError}, session.WebhookEventError},
+		{statusTransition{kind: sessionStatusChanged, to: session.StatusRunning}, ""},
+	}
+	for _, c := range cases {
+		if got := webhookEvent(c.tr); got != c.want {
+			t.Errorf("webhookEvent(%+v) = %q, want %q", c.tr, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// scheduleCheckInterval is how often the TUI looks for due scheduled
+// launches. Cron has minute granularity; a run may start up to this late.
+const scheduleCheckInterval = 20 * time.Second
+
+// scheduleLaunch is one scheduled launch attempted by launchDueSchedules.
+type scheduleLaunch struct {
+	name     string
+	instance *session.Instance
+	err      error
+}
+
+// scheduleLaunchedMsg reports the scheduled launches fired on one check.
+type scheduleLaunchedMsg struct {
+	launches []scheduleLaunch
+}
+
+// launchDueSchedules claims the schedules of this profile that are due and
+// starts a session for each. Claiming records the run before anything
+// starts, so a failed launch is reported once rather than retried every
+// check.
+func (h *Home) launchDueSchedules() tea.Cmd {
+	profile := h.profile
+	return func() tea.Msg {
+		now := time.Now()
+		due, err := session.ClaimDueSchedules(profile, now)
+		if err != nil {
+			uiLog.Warn("schedule_check_failed", slog.String("error", err.Error()))
+			return nil
+		}
+		if len(due) == 0 {
+			return nil
+		}
+		msg := scheduleLaunchedMsg{}
+		for _, s := range due {
+			inst := s.NewInstance(now)
+			if s.Prompt != "" {
+				err = inst.StartWithMessage(s.Prompt)
+			} else {
+				err = inst.Start()
+			}
+			uiLog.Info("schedule_launch",
+				slog.String("schedule", s.Name),
+				slog.String("id", inst.ID),
+				slog.Bool("ok", err == nil))
+			if err != nil {
+				msg.launches = append(msg.launches, scheduleLaunch{name: s.Name, err: err})
+				continue
+			}
+			msg.launches = append(msg.launches, scheduleLaunch{name: s.Name, instance: inst})
+		}
+		return msg
+	}
+}
+
+// handleScheduleLaunched adds the started sessions to the list without
+// moving the cursor (the user didn't ask for them just now) and persists
+// them immediately, like a session created from the new-session dialog.
+func (h *Home) handleScheduleLaunched(msg scheduleLaunchedMsg) tea.Cmd {
+	var started, failed []string
+	added := false
+	for _, l := range msg.launches {
+		if l.err != nil {
+			failed = append(failed, l.name)
+			h.setError(fmt.Errorf("scheduled launch %q failed: %w", l.name, l.err))
+			continue
+		}
+		h.instancesMu.Lock()
+		h.instances = append(h.instances, l.instance)
+		h.instanceByID[l.instance.ID] = l.instance
+		h.instancesMu.Unlock()
+		h.launchingSessions[l.instance.ID] = time.Now()
+		if h.groupTree != nil {
+			h.groupTree.AddSession(l.instance)
+		}
+		started = append(started, fmt.Sprintf("%q", l.instance.Title))
+		added = true
+	}
+	if !added {
+		return nil
+	}
+
+	h.cachedStatusCounts.valid.Store(false)
+	h.reloadMu.Lock()
+	reloading := h.isReloading
+	h.reloadMu.Unlock()
+	h.forceSaveInstances()
+	if reloading {
+		// The reload in flight predates these sessions; pick them up next.
+		if h.storageWatcher != nil {
+			h.storageWatcher.TriggerReload()
+		}
+	} else {
+		h.rebuildFlatItems()
+		h.search.SetItems(h.instances)
+	}
+
+	if len(failed) > 0 {
+		return nil // keep the error visible
+	}
+	h.maintenanceMsg = "Scheduled launch started " + strings.Join(started, ", ")
+	h.maintenanceMsgTime = time.Now()
+	return tea.Tick(5*time.Second, func(_ time.Time) tea.Msg {
+		return clearMaintenanceMsg{}
+	})
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestHandleScheduleLaunched_AddsSessionWithoutMovingCursor(t *testing.T) {
+	home, _ := newMultiSelectHome(t)
+	cursor := home.cursor
+	inst := session.NewInstanceWithGroupAndTool("nightly 2026-10-15 07:00", t.TempDir(), "work", "shell")
+
+	if cmd := home.handleScheduleLaunched(scheduleLaunchedMsg{launches: []scheduleLaunch{{name: "nightly", instance: inst}}}); cmd == nil {
+		t.Fatal("expected a banner clear tick")
+	}
+	if home.getInstanceByID(inst.ID) == nil {
+		t.Fatal("launched session should be in the list")
+	}
+	if home.cursor != cursor {
+		t.Errorf("cursor moved from %d to %d", cursor, home.cursor)
+	}
+	if !strings.Contains(home.maintenanceMsg, `"nightly 2026-10-15 07:00"`) {
+		t.Errorf("maintenanceMsg = %q", home.maintenanceMsg)
+	}
+}
+
+func TestHandleScheduleLaunched_ReportsFailure(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	if cmd := home.handleScheduleLaunched(scheduleLaunchedMsg{launches: []scheduleLaunch{{name: "nightly", err: errors.New("no tmux")}}}); cmd != nil {
+		t.Error("a failed launch has nothing to add")
+	}
+	if home.err == nil || !strings.Contains(home.err.Error(), `scheduled launch "nightly" failed`) {
+		t.Errorf("err = %v", home.err)
+	}
+	if len(home.instances) != len(insts) {
+		t.Error("no session should be added")
+	}
+}
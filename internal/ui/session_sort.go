@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// sessionCostRefreshInterval throttles the cost reload while sorting by cost;
+// spend moves slowly compared to the status loop.
+const sessionCostRefreshInterval = 30 * time.Second
+
+// cycleSortMode steps the within-group sort to the next mode (manual → last
+// accessed → status → newest → cost → memory), re-sorts every group, and
+// persists the choice in this profile's UI state, where it overrides the
+// group_sort config.
+func (h *Home) cycleSortMode() tea.Cmd {
+	next := session.NextGroupSortMode(session.GroupSortMode())
+	h.sortOverride = next
+	session.SetGroupSortOverride(next)
+	if next == "cost" {
+		h.loadSessionCosts(h.groupTree.GetAllInstances())
+	}
+
+	selectedBefore := h.captureSelectedItemIdentity()
+	h.groupTree.ResortSessions()
+	h.rebuildFlatItemsPreservingSelection(selectedBefore)
+	h.skipDivider(1)
+	h.syncViewport()
+	h.saveUIState()
+	return h.fetchSelectedPreview()
+}
+
+// refreshSessionCosts keeps each instance's cached spend current while the
+// list is sorted by cost. Runs in the background status loop, at most every
+// sessionCostRefreshInterval.
+func (h *Home) refreshSessionCosts(instances []*session.Instance) {
+	if session.GroupSortMode() != "cost" {
+		return
+	}
+	now := time.Now()
+	if now.Sub(h.lastCostRefresh) < sessionCostRefreshInterval {
+		return
+	}
+	h.lastCostRefresh = now
+	h.loadSessionCosts(instances)
+}
+
+// loadSessionCosts reads every session's total spend from the cost store into
+// its instance (see session.Instance.SetCachedCost). A no-op when cost
+// tracking is off.
+func (h *Home) loadSessionCosts(instances []*session.Instance) {
+	if h.costStore == nil {
+		return
+	}
+	totals, err := h.costStore.TotalsBySession()
+	if err != nil {
+		uiLog.Debug("session_costs_load_failed", slog.String("error", err.Error()))
+		return
+	}
+	for _, inst := range instances {
+		inst.SetCachedCost(totals[inst.ID])
+	}
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+func sessionTitlesInGroup(h *Home, groupPath string) []string {
+	var titles []string
+	for _, it := range h.flatItems {
+		if it.Type == session.ItemTypeSession && it.Session != nil && it.Session.GroupPath == groupPath {
+			titles = append(titles, it.Session.Title)
+		}
+	}
+	return titles
+}
+
+func TestCycleSortMode_ReordersWithinGroups(t *testing.T) {
+	t.Cleanup(func() { session.SetGroupSortOverride("") })
+	home, insts := newMultiSelectHome(t)
+	home.storage = nil // Avoid touching persistence in this unit test.
+	home.initialLoading = false
+	a, b := insts[0], insts[1]
+	a.Order, b.Order = 0, 1
+	a.LastAccessedAt = time.Now().Add(-time.Hour)
+	b.LastAccessedAt = time.Now()
+
+	if got := sessionTitlesInGroup(home, "work"); strings.Join(got, ",") != "a,b" {
+		t.Fatalf("manual order = %v, want [a b]", got)
+	}
+
+	altS := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}, Alt: true}
+	home.handleMainKey(altS)
+	if got := session.GroupSortMode(); got != "accessed" {
+		t.Fatalf("first alt+s should sort by last accessed, got %q", got)
+	}
+	if home.sortOverride != "accessed" {
+		t.Errorf("sortOverride = %q, want accessed (persisted in ui_state)", home.sortOverride)
+	}
+	if got := sessionTitlesInGroup(home, "work"); strings.Join(got, ",") != "b,a" {
+		t.Errorf("accessed order = %v, want [b a]", got)
+	}
+	if view := tmux.StripANSI(home.View()); !strings.Contains(view, "by last accessed") {
+		t.Errorf("filter bar should name the active sort:\n%s", view)
+	}
+
+	// Cycling all the way round returns to manual order.
+	for session.GroupSortMode() != "creation" {
+		home.handleMainKey(altS)
+	}
+	if got := sessionTitlesInGroup(home, "work"); strings.Join(got, ",") != "a,b" {
+		t.Errorf("manual order after a full cycle = %v, want [a b]", got)
+	}
+}
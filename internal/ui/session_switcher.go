@@ -24,6 +24,10 @@ const switcherIdleCommit = 1 * time.Second
 // through every session; deliberate taps (~100ms+ apart) all register.
 const switcherRepeatGuard = 80 * time.Millisecond
 
+// recentSessionsLimit caps the recent-sessions list at the number of digit
+// keys, so every row has a one-keystroke shortcut.
+const recentSessionsLimit = 9
+
 // SessionSwitcher is the session switcher overlay. It opens on Ctrl+S — both
 // while attached (the tmux attach loop hands control back to the TUI) and from
 // the overview — pre-highlighted on the session you came from. Ctrl+S / Ctrl+A
@@ -37,6 +41,7 @@ type SessionSwitcher struct {
 	fromID           string            // session the picker was opened from
 	subtitles        map[string]string // sessionID -> dim conversation/pane title (matches the overview)
 	reattachOnCancel bool              // Esc re-attaches to fromID (opened while attached) vs. just closing (opened from the overview)
+	recent           bool              // opened as the recent-sessions list: numbered rows, 1-9 attach
 	// commitGen is bumped on every open/cycle/cancel so a stale idle-commit
 	// timer (scheduled before a later keypress) is ignored when it fires. It is
 	// intentionally monotonic — never reset — so a timer from a previous
@@ -91,18 +96,7 @@ func NewSessionSwitcher() *SessionSwitcher { return &SessionSwitcher{} }
 // TestSessionSwitcher_RemoteSessionsUnsupported); supporting them needs a remote
 // re-attach path and is tracked as a follow-up.
 func (s *SessionSwitcher) Show(fromID string, allInstances []*session.Instance, subtitles map[string]string) bool {
-	list := make([]*session.Instance, 0, len(allInstances))
-	for _, inst := range allInstances {
-		if inst == nil {
-			continue
-		}
-		// Mirror the send-output picker: only switchable (live) sessions.
-		switch inst.GetStatusThreadSafe() {
-		case session.StatusError, session.StatusStopped:
-			continue
-		}
-		list = append(list, inst)
-	}
+	list := switchableSessions(allInstances)
 	if len(list) < 2 {
 		// Nothing to switch between. Clear any prior selection so a switcher that
 		// was already open (e.g. live-session count just dropped below two) does
@@ -111,13 +105,6 @@ func (s *SessionSwitcher) Show(fromID string, allInstances []*session.Instance,
 		return false
 	}
 
-	// Most-recently-accessed first. The just-detached session was
-	// MarkAccessed'd on detach, so it sorts to the front — pre-selecting it
-	// means the first Ctrl+S step lands on the most-recent other session.
-	sort.SliceStable(list, func(i, j int) bool {
-		return list[i].LastAccessedAt.After(list[j].LastAccessedAt)
-	})
-
 	cursor := 0
 	for i, inst := range list {
 		if inst.ID == fromID {
@@ -131,6 +118,72 @@ func (s *SessionSwitcher) Show(fromID string, allInstances []*session.Instance,
 	s.cursor = cursor
 	s.fromID = fromID
 	s.subtitles = subtitles
+	s.recent = false
+	return true
+}
+
+// ShowRecent opens the switcher as the recent-sessions list: the last
+// recentSessionsLimit sessions the user attached to, most recent first and
+// highlighted, each numbered for a one-keystroke jump. Sessions never attached
+// (zero LastAccessedAt) are left out. Unlike Show, a single session is enough —
+// the list is for jumping back, not for cycling. It returns false (and stays
+// hidden) when no session has been attached yet.
+func (s *SessionSwitcher) ShowRecent(allInstances []*session.Instance, subtitles map[string]string) bool {
+	var list []*session.Instance
+	for _, inst := range switchableSessions(allInstances) {
+		if inst.LastAccessedAt.IsZero() {
+			continue
+		}
+		list = append(list, inst)
+		if len(list) == recentSessionsLimit {
+			break
+		}
+	}
+	if len(list) == 0 {
+		s.Hide()
+		return false
+	}
+
+	s.visible = true
+	s.sessions = list
+	s.cursor = 0
+	s.fromID = ""
+	s.subtitles = subtitles
+	s.recent = true
+	return true
+}
+
+// switchableSessions returns the live sessions in allInstances,
+// most-recently-accessed first.
+func switchableSessions(allInstances []*session.Instance) []*session.Instance {
+	list := make([]*session.Instance, 0, len(allInstances))
+	for _, inst := range allInstances {
+		if inst == nil {
+			continue
+		}
+		// Mirror the send-output picker: only switchable (live) sessions.
+		switch inst.GetStatusThreadSafe() {
+		case session.StatusError, session.StatusStopped:
+			continue
+		}
+		list = append(list, inst)
+	}
+
+	// Most-recently-accessed first. The just-detached session was
+	// MarkAccessed'd on detach, so it sorts to the front — pre-selecting it
+	// means the first Ctrl+S step lands on the most-recent other session.
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].LastAccessedAt.After(list[j].LastAccessedAt)
+	})
+	return list
+}
+
+// selectIndex highlights the i-th row (0-based) and reports whether it exists.
+func (s *SessionSwitcher) selectIndex(i int) bool {
+	if i < 0 || i >= len(s.sessions) {
+		return false
+	}
+	s.cursor = i
 	return true
 }
 
@@ -144,6 +197,7 @@ func (s *SessionSwitcher) Hide() {
 	s.fromID = ""
 	s.subtitles = nil
 	s.reattachOnCancel = false
+	s.recent = false
 	s.lastCycleAt = time.Time{}
 }
 
@@ -207,6 +261,13 @@ func (s *SessionSwitcher) View() string {
 	}
 	footerCycle := "Ctrl+S next · Ctrl+A prev"
 	footerNav := "↑/↓ browse · Enter attach · " + escHint
+	if s.recent {
+		header = "Recent sessions"
+		footerCycle = fmt.Sprintf("1-%d attach", len(s.sessions))
+		if len(s.sessions) == 1 {
+			footerCycle = "1 attach"
+		}
+	}
 
 	// Precompute each row once so we can measure the widest row (to auto-expand
 	// the dialog) and render without recomputing. label/subtitle route through
@@ -241,6 +302,9 @@ func (s *SessionSwitcher) View() string {
 			marker = "> "
 			labelStyle = selectedStyle
 		}
+		if s.recent {
+			marker += fmt.Sprintf("%d ", i+1)
+		}
 		prefix := cellWidth(marker) + cellWidth(indicator) + 1 // marker + indicator + space
 		rows[i] = switcherRow{marker: marker, labelStyle: labelStyle, indicator: indicator, title: title, subtitle: subtitle, prefix: prefix}
 
@@ -410,3 +410,79 @@ func TestCtrlS_NewDialogOpen_DoesNotOpenSwitcher(t *testing.T) {
 		t.Fatal("the new-session dialog should remain open after an empty-name Ctrl+S submit")
 	}
 }
+
+func TestSessionSwitcher_ShowRecentListsAttachedMostRecentFirst(t *testing.T) {
+	list := append(mruThree(), &session.Instance{ID: "never", Status: session.StatusRunning})
+	sw := NewSessionSwitcher()
+	if !sw.ShowRecent(list, nil) {
+		t.Fatal("expected recent list to show")
+	}
+	if got := switcherIDs(sw.sessions); len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Fatalf("recent order = %v, want [a b c] (never-attached session left out)", got)
+	}
+	if sel := sw.GetSelected(); sel == nil || sel.ID != "a" {
+		t.Fatalf("initial selection = %v, want a (the most recent)", sel)
+	}
+
+	// A single attached session is enough to jump back to.
+	if !sw.ShowRecent(list[2:], nil) {
+		t.Fatal("recent list should show with one attached session")
+	}
+	if sw.ShowRecent([]*session.Instance{{ID: "never", Status: session.StatusRunning}}, nil) || sw.IsVisible() {
+		t.Fatal("recent list must stay hidden when nothing was attached")
+	}
+}
+
+func TestSessionSwitcher_ShowRecentCapsAtLimit(t *testing.T) {
+	now := time.Now()
+	var list []*session.Instance
+	for i := 0; i < recentSessionsLimit+3; i++ {
+		list = append(list, &session.Instance{
+			ID: string(rune('a' + i)), Status: session.StatusRunning,
+			LastAccessedAt: now.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	sw := NewSessionSwitcher()
+	sw.ShowRecent(list, nil)
+	if len(sw.sessions) != recentSessionsLimit {
+		t.Fatalf("recent list has %d rows, want %d", len(sw.sessions), recentSessionsLimit)
+	}
+}
+
+func TestRecentSessions_DigitSelectsAndCommits(t *testing.T) {
+	h := &Home{sessionSwitcher: NewSessionSwitcher(), hotkeys: resolveHotkeys(nil)}
+	h.sessionSwitcher.ShowRecent(mruThree(), nil)
+
+	// Out of range: ignored, list stays open.
+	h.handleSessionSwitcherKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'9'}})
+	if !h.sessionSwitcher.IsVisible() || h.sessionSwitcher.GetSelected().ID != "a" {
+		t.Fatal("a digit past the list must not change or close the list")
+	}
+
+	// The open key steps down like Alt+Tab.
+	h.handleSessionSwitcherKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'`'}})
+	if sel := h.sessionSwitcher.GetSelected(); sel == nil || sel.ID != "b" {
+		t.Fatalf("after ` selection = %v, want b", sel)
+	}
+
+	h.handleSessionSwitcherKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}})
+	if h.sessionSwitcher.IsVisible() {
+		t.Fatal("a digit should attach to that row and close the list")
+	}
+}
+
+func TestSessionSwitcher_RecentViewNumbersRows(t *testing.T) {
+	InitTheme("dark")
+	sw := NewSessionSwitcher()
+	sw.SetSize(80, 24)
+	sw.ShowRecent(mruThree(), nil)
+	view := sw.View()
+	for _, want := range []string{"Recent sessions", "1 ", "3 ", "1-3 attach"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("recent view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Ctrl+S next") {
+		t.Error("recent view should not show the Ctrl+S cycle hint")
+	}
+}
@@ -68,8 +68,12 @@ type SettingsPanel struct {
 	toolNames  []string
 	toolValues []string
 
+	// Dynamic theme lists (built-in + [themes.<name>] palettes from config)
+	themeNames  []string
+	themeValues []string
+
 	// Setting values
-	selectedTheme       int // 0=dark, 1=light, 2=system
+	selectedTheme       int // index into themeNames/themeValues (0=dark, 1=light, 2=system)
 	selectedTool        int // index into toolNames/toolValues
 	dangerousMode       bool
 	claudeConfigDir     string
@@ -120,8 +124,8 @@ type SettingsPanel struct {
 // builtinToolNames and builtinToolValues are the built-in tools. Custom tools
 // from config are appended dynamically in LoadConfig.
 var (
-	builtinToolNames  = []string{"Claude", "Gemini", "OpenCode", "Codex", "Pi", "Copilot", "Crush", "Cursor", "Hermes"}
-	builtinToolValues = []string{"claude", "gemini", "opencode", "codex", "pi", "copilot", "crush", "cursor", "hermes"}
+	builtinToolNames  = []string{"Claude", "Gemini", "OpenCode", "Codex", "Pi", "Copilot", "Crush", "Cursor", "Hermes", "Aider"}
+	builtinToolValues = []string{"claude", "gemini", "opencode", "codex", "pi", "copilot", "crush", "cursor", "hermes", "aider"}
 )
 
 // Search tier names for radio selection
@@ -130,10 +134,11 @@ var (
 	tierValues = []string{"auto", "instant", "balanced"}
 )
 
-// Theme names for radio selection
+// Built-in theme names for radio selection. Dark/Light/System keep their
+// historical indices; user palettes are appended after these.
 var (
-	themeNames  = []string{"Dark", "Light", "System"}
-	themeValues = []string{"dark", "light", "system"}
+	builtinThemeNames  = []string{"Dark", "Light", "System", "Solarized Dark", "Solarized Light", "High Contrast"}
+	builtinThemeValues = []string{"dark", "light", "system", "solarized-dark", "solarized-light", "high-contrast"}
 )
 
 // Stats format names for radio selection
@@ -147,6 +152,8 @@ func NewSettingsPanel() *SettingsPanel {
 	return &SettingsPanel{
 		toolNames:           append(append([]string{}, builtinToolNames...), "None"),
 		toolValues:          append(append([]string{}, builtinToolValues...), ""),
+		themeNames:          append([]string{}, builtinThemeNames...),
+		themeValues:         append([]string{}, builtinThemeValues...),
 		logMaxSizeMB:        10,
 		logMaxLines:         10000,
 		removeOrphans:       true,
@@ -225,14 +232,14 @@ func (s *SettingsPanel) SetProfile(profile string) {
 
 // LoadConfig populates panel values from a UserConfig
 func (s *SettingsPanel) LoadConfig(config *session.UserConfig) {
-	// Load theme
-	switch config.Theme {
-	case "light":
-		s.selectedTheme = 1
-	case "system":
-		s.selectedTheme = 2
-	default:
-		s.selectedTheme = 0
+	// Load theme (unknown names select Dark, matching session.GetTheme)
+	s.buildThemeLists(config)
+	s.selectedTheme = 0
+	for i, val := range s.themeValues {
+		if val == config.Theme {
+			s.selectedTheme = i
+			break
+		}
 	}
 
 	// Rebuild tool lists: built-ins + custom tools + "None".
@@ -374,6 +381,17 @@ func (s *SettingsPanel) buildToolLists(config *session.UserConfig) {
 	s.toolValues = values
 }
 
+// buildThemeLists rebuilds the theme radio options: built-ins, then the
+// user's [themes.<name>] palettes in name order.
+func (s *SettingsPanel) buildThemeLists(config *session.UserConfig) {
+	s.themeNames = append([]string{}, builtinThemeNames...)
+	s.themeValues = append([]string{}, builtinThemeValues...)
+	for _, name := range session.CustomThemeNames(config) {
+		s.themeNames = append(s.themeNames, name)
+		s.themeValues = append(s.themeValues, name)
+	}
+}
+
 // GetConfig returns a UserConfig with current panel values
 func (s *SettingsPanel) GetConfig() *session.UserConfig {
 	config := &session.UserConfig{
@@ -383,8 +401,8 @@ func (s *SettingsPanel) GetConfig() *session.UserConfig {
 	}
 
 	// Theme
-	if s.selectedTheme < len(themeValues) {
-		config.Theme = themeValues[s.selectedTheme]
+	if s.selectedTheme >= 0 && s.selectedTheme < len(s.themeValues) {
+		config.Theme = s.themeValues[s.selectedTheme]
 	}
 
 	// Default tool
@@ -582,7 +600,7 @@ func (s *SettingsPanel) adjustValue(delta int) bool {
 	switch setting {
 	case SettingTheme:
 		newVal := s.selectedTheme + delta
-		if newVal >= 0 && newVal < len(themeNames) {
+		if newVal >= 0 && newVal < len(s.themeNames) {
 			s.selectedTheme = newVal
 			changed = true
 		}
@@ -853,7 +871,7 @@ func (s *SettingsPanel) View() string {
 		content.WriteString(warningStyle.Render(" (restart required)"))
 	}
 	content.WriteString("\n")
-	themeRow := s.renderRadioGroup(themeNames, s.selectedTheme, s.cursor == int(SettingTheme))
+	themeRow := s.renderRadioGroup(s.themeNames, s.selectedTheme, s.cursor == int(SettingTheme))
 	if s.cursor == int(SettingTheme) {
 		themeRow = highlightStyle.Render(themeRow)
 	}
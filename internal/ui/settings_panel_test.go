@@ -199,8 +199,8 @@ func TestSettingsPanel_LoadConfig_CustomTools(t *testing.T) {
 
 	panel.LoadConfig(config)
 
-	wantNames := []string{"Claude", "Gemini", "OpenCode", "Codex", "Pi", "Copilot", "Crush", "Cursor", "Hermes", "Openclaw", "Zeta", "None"}
-	wantValues := []string{"claude", "gemini", "opencode", "codex", "pi", "copilot", "crush", "cursor", "hermes", "openclaw", "zeta", ""}
+	wantNames := []string{"Claude", "Gemini", "OpenCode", "Codex", "Pi", "Copilot", "Crush", "Cursor", "Hermes", "Aider", "Openclaw", "Zeta", "None"}
+	wantValues := []string{"claude", "gemini", "opencode", "codex", "pi", "copilot", "crush", "cursor", "hermes", "aider", "openclaw", "zeta", ""}
 
 	if !reflect.DeepEqual(panel.toolNames, wantNames) {
 		t.Fatalf("toolNames = %#v, want %#v", panel.toolNames, wantNames)
@@ -738,6 +738,25 @@ func TestSettingsPanel_LoadConfig_Theme(t *testing.T) {
 	}
 }
 
+func TestSettingsPanel_CustomThemeSelectable(t *testing.T) {
+	panel := NewSettingsPanel()
+	panel.LoadConfig(&session.UserConfig{
+		Theme:  "paper",
+		Themes: map[string]session.ThemePalette{"paper": {Base: "light"}},
+	})
+
+	last := len(panel.themeValues) - 1
+	if panel.themeValues[last] != "paper" {
+		t.Fatalf("custom theme should be listed after the built-ins, got %v", panel.themeValues)
+	}
+	if panel.selectedTheme != last {
+		t.Errorf("selectedTheme: got %d, want %d", panel.selectedTheme, last)
+	}
+	if got := panel.GetConfig().Theme; got != "paper" {
+		t.Errorf("GetConfig().Theme = %q, want paper", got)
+	}
+}
+
 func TestSettingsPanel_GetConfig_Theme(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/integrations/slack"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// slackPollInterval is how often session threads are checked for replies.
+// conversations.replies is rate-limited per channel, so this stays coarse.
+const slackPollInterval = 10 * time.Second
+
+// slackIntegration returns the integration for cfg. The thread map is
+// loaded once and shared, so concurrent notices and polls never clobber
+// each other's writes to the state file.
+func (h *Home) slackIntegration(cfg session.SlackIntegrationSettings) (*slack.Integration, error) {
+	if h.slackThreads == nil {
+		path, err := slack.DefaultThreadsPath()
+		if err != nil {
+			return nil, err
+		}
+		threads, err := slack.LoadThreads(path)
+		if err != nil {
+			return nil, err
+		}
+		h.slackThreads = threads
+	}
+	return slack.New(cfg, slack.NewClient(cfg.BotToken), h.slackThreads), nil
+}
+
+// syncSlack posts a notice for every session that just started waiting
+// (except the attached one), forgets the threads of deleted sessions and,
+// every slackPollInterval, routes new thread replies into their sessions.
+// Runs on the background status worker so replies keep flowing while the
+// TUI is suspended by an attach. Deck-wide DND holds the notices back and
+// records them in the missed digest; replies are still routed.
+func (h *Home) syncSlack(instances []*session.Instance) {
+	cfg := session.GetSlackIntegrationSettings()
+	if !cfg.Configured() {
+		h.slackTracker.reset()
+		return
+	}
+	in, err := h.slackIntegration(cfg)
+	if err != nil {
+		notifLog.Warn("slack_threads_load_failed", slog.String("error", err.Error()))
+		return
+	}
+
+	var notices []slack.Notice
+	attachedID, attachedKnown := "", false
+	for _, tr := range h.slackTracker.observe(instances) {
+		if tr.kind == sessionRemoved {
+			if err := in.Forget(tr.inst.ID); err != nil {
+				notifLog.Warn("slack_forget_failed", slog.String("error", err.Error()))
+			}
+			continue
+		}
+		if tr.kind != sessionStatusChanged || tr.to != session.StatusWaiting || tr.inst.IsArchived() {
+			continue
+		}
+		if !attachedKnown {
+			attachedID, attachedKnown = h.getAttachedSessionID(), true
+		}
+		if tr.inst.ID == attachedID {
+			continue
+		}
+		notices = append(notices, slack.Notice{
+			SessionID: tr.inst.ID,
+			Title:     tr.inst.Title,
+			Reason:    tr.inst.CachedAttention().Reason(),
+		})
+	}
+	if len(notices) > 0 {
+		h.postSlackNotices(in, notices)
+	}
+
+	if time.Since(h.lastSlackPoll) >= slackPollInterval && h.slackPolling.CompareAndSwap(false, true) {
+		h.lastSlackPoll = time.Now()
+		go func() {
+			defer h.slackPolling.Store(false)
+			if err := in.PollReplies(context.Background(), h.deliverSlackReply); err != nil {
+				notifLog.Warn("slack_poll_failed", slog.String("error", err.Error()))
+			}
+		}()
+	}
+}
+
+func (h *Home) postSlackNotices(in *slack.Integration, notices []slack.Notice) {
+	missed := make([]session.DNDMissedEvent, 0, len(notices))
+	for _, n := range notices {
+		missed = append(missed, session.DNDMissedEvent{
+			Source:    session.DNDSourceSlack,
+			SessionID: n.SessionID,
+			Title:     n.Title,
+			Status:    string(session.StatusWaiting),
+		})
+	}
+	if active, err := session.RecordDNDMissed(missed...); err != nil {
+		notifLog.Warn("slack_dnd_record_failed", slog.String("error", err.Error()))
+	} else if active {
+		notifLog.Debug("slack_suppressed_dnd", slog.Int("notices", len(notices)))
+		return
+	}
+	go func() {
+		// Sequential, so a session's first notice creates its thread
+		// before a later one could start a second.
+		for _, n := range notices {
+			if err := in.Notify(context.Background(), n); err != nil {
+				notifLog.Warn("slack_notify_failed",
+					slog.String("session", n.SessionID),
+					slog.String("error", err.Error()))
+			}
+		}
+	}()
+}
+
+// deliverSlackReply types a thread reply into its session, the same way a
+// prompt from the session list is sent.
+func (h *Home) deliverSlackReply(sessionID, text string) error {
+	h.instancesMu.RLock()
+	inst := h.getInstanceByID(sessionID)
+	h.instancesMu.RUnlock()
+	if inst == nil {
+		return fmt.Errorf("session no longer exists")
+	}
+	ts := inst.GetTmuxSession()
+	if ts == nil || ts.Name == "" || !ts.Exists() {
+		return fmt.Errorf("session %q is not running", inst.Title)
+	}
+	if session.IsClaudeCompatible(inst.GetToolThreadSafe()) {
+		return deliverToConductorPane(ts, text)
+	}
+	return ts.SendKeysAndEnter(text)
+}
@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// SnapshotDialog is an overlay listing a session's checkout snapshots so its
+// checkout can be rolled back to one. Rolling back asks for confirmation
+// first. Home runs the git work and reports back through SetSnapshots and
+// SetError.
+type SnapshotDialog struct {
+	visible    bool
+	sessionID  string
+	title      string
+	dir        string
+	snaps      []git.Snapshot
+	cursor     int
+	confirming bool
+	busy       string // what is running ("Rolling back..."), shown instead of the hint
+	errMsg     string
+	width      int
+	height     int
+}
+
+// NewSnapshotDialog constructs a hidden snapshot view.
+func NewSnapshotDialog() *SnapshotDialog {
+	return &SnapshotDialog{}
+}
+
+// Show opens the view for a session's checkout in dir, empty until
+// SetSnapshots delivers the list.
+func (d *SnapshotDialog) Show(sessionID, title, dir string) {
+	d.visible = true
+	d.sessionID = sessionID
+	d.title = title
+	d.dir = dir
+	d.snaps = nil
+	d.cursor = 0
+	d.confirming = false
+	d.busy = "Loading..."
+	d.errMsg = ""
+}
+
+// Hide closes the view.
+func (d *SnapshotDialog) Hide() {
+	d.visible = false
+	d.confirming = false
+}
+
+// IsVisible reports whether the view is currently shown.
+func (d *SnapshotDialog) IsVisible() bool { return d.visible }
+
+// SessionID returns the session whose snapshots are shown.
+func (d *SnapshotDialog) SessionID() string { return d.sessionID }
+
+// Dir returns the checkout the snapshots belong to.
+func (d *SnapshotDialog) Dir() string { return d.dir }
+
+// SetSnapshots replaces the list (newest first) and highlights the newest:
+// the list is reloaded after a snapshot or rollback, which adds it.
+func (d *SnapshotDialog) SetSnapshots(snaps []git.Snapshot) {
+	d.snaps = snaps
+	d.cursor = 0
+	d.busy = ""
+}
+
+// SetBusy shows what is running in place of the key hints; "" clears it.
+func (d *SnapshotDialog) SetBusy(what string) { d.busy = what }
+
+// Busy reports whether git work for the dialog is still running.
+func (d *SnapshotDialog) Busy() bool { return d.busy != "" }
+
+// SetError shows err above the list.
+func (d *SnapshotDialog) SetError(err error) {
+	d.errMsg = ""
+	if err != nil {
+		d.errMsg = err.Error()
+	}
+}
+
+// Selected returns the highlighted snapshot, or nil when there is none.
+func (d *SnapshotDialog) Selected() *git.Snapshot {
+	if d.cursor < 0 || d.cursor >= len(d.snaps) {
+		return nil
+	}
+	return &d.snaps[d.cursor]
+}
+
+// Confirming reports whether the rollback confirmation is showing.
+func (d *SnapshotDialog) Confirming() bool { return d.confirming }
+
+// SetConfirming shows or dismisses the rollback confirmation.
+func (d *SnapshotDialog) SetConfirming(on bool) { d.confirming = on }
+
+// SetSize updates the dialog viewport for centering.
+func (d *SnapshotDialog) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+}
+
+// Update moves the cursor.
+func (d *SnapshotDialog) Update(msg tea.KeyMsg) *SnapshotDialog {
+	switch msg.String() {
+	case "up", "k", "ctrl+p":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j", "ctrl+n":
+		if d.cursor < len(d.snaps)-1 {
+			d.cursor++
+		}
+	}
+	return d
+}
+
+// View renders the overlay, centered in the viewport.
+func (d *SnapshotDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	title := DialogTitleStyle.Render("Snapshots · " + truncatePath(d.title, 32))
+
+	rowStyle := lipgloss.NewStyle().Foreground(ColorText).Padding(0, 1)
+	selStyle := lipgloss.NewStyle().
+		Foreground(ColorBg).
+		Background(ColorAccent).
+		Bold(true).
+		Padding(0, 1)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+
+	var listBlock string
+	if len(d.snaps) == 0 {
+		if d.busy == "" {
+			listBlock = dimStyle.Render("No snapshots yet. Press s to take one.")
+		}
+	} else {
+		rows := make([]string, 0, len(d.snaps))
+		now := time.Now()
+		for i, s := range d.snaps {
+			line := fmt.Sprintf("%-8s %-18s %s ago",
+				shortSnapshotCommit(s.Commit), truncatePath(s.Reason, 18), formatTrashAge(now.Sub(s.Created)))
+			if i == d.cursor {
+				rows = append(rows, selStyle.Render(line))
+			} else {
+				rows = append(rows, rowStyle.Render(line))
+			}
+		}
+		listBlock = strings.Join(rows, "\n")
+	}
+	if d.errMsg != "" {
+		listBlock = lipgloss.NewStyle().Foreground(ColorRed).Render("⚠ "+d.errMsg) + "\n" + listBlock
+	}
+
+	detail := ""
+	if sel := d.Selected(); sel != nil {
+		detail = dimStyle.Render(sel.Created.Format("Jan 02 15:04:05") + " · on " + shortSnapshotCommit(sel.Head))
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	hint := hintStyle.Render("↑/↓ navigate │ Enter roll back │ s snapshot now │ Esc close")
+	switch {
+	case d.busy != "":
+		hint = dimStyle.Render(d.busy)
+	case d.confirming && d.Selected() != nil:
+		hint = lipgloss.NewStyle().Foreground(ColorYellow).Bold(true).
+			Render(fmt.Sprintf("Roll back to %s? y/n", shortSnapshotCommit(d.Selected().Commit)))
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		"",
+		listBlock,
+		"",
+		detail,
+		dimStyle.Render("Rollback resets the branch and files to the"),
+		dimStyle.Render("snapshot; the current state is saved first."),
+		"",
+		hint,
+	)
+
+	dialog := DialogBoxStyle.
+		Width(fitDialogWidth(56, 40, d.width)).
+		Render(content)
+
+	return lipgloss.Place(
+		d.width,
+		d.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+func shortSnapshotCommit(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	return commit
+}
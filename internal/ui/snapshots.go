@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// snapshotsLoadedMsg delivers a session's snapshot list to the dialog.
+type snapshotsLoadedMsg struct {
+	sessionID string
+	snaps     []git.Snapshot
+	err       error
+}
+
+// snapshotTakenMsg reports an on-demand snapshot.
+type snapshotTakenMsg struct {
+	sessionID string
+	created   bool
+	err       error
+}
+
+// snapshotRestoredMsg reports a rollback.
+type snapshotRestoredMsg struct {
+	sessionID string
+	title     string
+	snap      git.Snapshot
+	err       error
+}
+
+// snapshotKeep returns the configured per-session snapshot retention.
+func snapshotKeep() int {
+	config, _ := session.LoadUserConfig()
+	if config == nil {
+		return session.SnapshotSettings{}.GetKeep()
+	}
+	return config.Snapshots.GetKeep()
+}
+
+// openSnapshots opens the snapshot view for inst and loads its list.
+func (h *Home) openSnapshots(inst *session.Instance) tea.Cmd {
+	if inst.IsSSH() {
+		h.setError(fmt.Errorf("%q runs on %s: snapshots need a local checkout", inst.Title, inst.SSHHost))
+		return nil
+	}
+	dir := gitStatusDir(inst)
+	if dir == "" {
+		h.setError(fmt.Errorf("%q has no project directory to snapshot", inst.Title))
+		return nil
+	}
+	h.snapshotDialog.Show(inst.ID, inst.Title, dir)
+	return listSnapshotsCmd(inst.ID, dir)
+}
+
+func listSnapshotsCmd(sessionID, dir string) tea.Cmd {
+	return func() tea.Msg {
+		snaps, err := git.ListSnapshots(dir, sessionID)
+		return snapshotsLoadedMsg{sessionID: sessionID, snaps: snaps, err: err}
+	}
+}
+
+// handleSnapshotDialogKey drives the snapshot view: Enter asks to roll back
+// to the highlighted snapshot and y confirms, s snapshots the checkout now.
+// Keys are ignored while git work for the dialog is running.
+func (h *Home) handleSnapshotDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := h.snapshotDialog
+	if d.Confirming() {
+		switch msg.String() {
+		case "y", "Y":
+			d.SetConfirming(false)
+			return h, h.rollbackToSnapshot(*d.Selected())
+		case "n", "N", "esc", "q":
+			d.SetConfirming(false)
+		}
+		return h, nil
+	}
+	switch msg.String() {
+	case "esc", "q":
+		d.Hide()
+		return h, nil
+	}
+	if d.Busy() {
+		return h, nil
+	}
+	switch msg.String() {
+	case "enter", "r":
+		if d.Selected() != nil {
+			d.SetError(nil)
+			d.SetConfirming(true)
+		}
+	case "s":
+		inst := h.getInstanceByID(d.SessionID())
+		if inst == nil {
+			d.SetError(fmt.Errorf("session no longer exists"))
+			return h, nil
+		}
+		d.SetError(nil)
+		d.SetBusy("Taking snapshot...")
+		id, dir := inst.ID, d.Dir()
+		return h, func() tea.Msg {
+			_, created, err := inst.TakeSnapshot(dir, "manual", snapshotKeep())
+			return snapshotTakenMsg{sessionID: id, created: created, err: err}
+		}
+	default:
+		d.Update(msg)
+	}
+	return h, nil
+}
+
+// rollbackToSnapshot restores the dialog's checkout to snap off the UI
+// goroutine.
+func (h *Home) rollbackToSnapshot(snap git.Snapshot) tea.Cmd {
+	d := h.snapshotDialog
+	inst := h.getInstanceByID(d.SessionID())
+	if inst == nil {
+		d.SetError(fmt.Errorf("session no longer exists"))
+		return nil
+	}
+	d.SetError(nil)
+	d.SetBusy("Rolling back...")
+	id, title, dir := inst.ID, inst.Title, d.Dir()
+	return func() tea.Msg {
+		_, err := inst.RollbackToSnapshot(dir, snap, snapshotKeep())
+		return snapshotRestoredMsg{sessionID: id, title: title, snap: snap, err: err}
+	}
+}
+
+// handleSnapshotsLoaded fills the dialog, if it is still showing that
+// session.
+func (h *Home) handleSnapshotsLoaded(msg snapshotsLoadedMsg) {
+	d := h.snapshotDialog
+	if !d.IsVisible() || d.SessionID() != msg.sessionID {
+		return
+	}
+	d.SetSnapshots(msg.snaps)
+	if msg.err != nil {
+		d.SetError(msg.err)
+	}
+}
+
+// handleSnapshotTaken reloads the list after an on-demand snapshot.
+func (h *Home) handleSnapshotTaken(msg snapshotTakenMsg) tea.Cmd {
+	d := h.snapshotDialog
+	if !d.IsVisible() || d.SessionID() != msg.sessionID {
+		return nil
+	}
+	if msg.err != nil {
+		d.SetBusy("")
+		d.SetError(msg.err)
+		return nil
+	}
+	if !msg.created {
+		d.SetError(fmt.Errorf("nothing changed since the latest snapshot"))
+	}
+	return listSnapshotsCmd(msg.sessionID, d.Dir())
+}
+
+// handleSnapshotRestored reports a rollback, persists the event it added to
+// the session's log, and reloads the list, which now starts with the
+// "before rollback" snapshot that undoes it.
+func (h *Home) handleSnapshotRestored(msg snapshotRestoredMsg) tea.Cmd {
+	h.saveInstances()
+	if inst := h.getInstanceByID(msg.sessionID); inst != nil && h.gitStatus != nil {
+		h.gitStatus.Invalidate(gitStatusDir(inst))
+	}
+	d := h.snapshotDialog
+	visible := d.IsVisible() && d.SessionID() == msg.sessionID
+	if msg.err != nil {
+		if visible {
+			d.SetBusy("")
+			d.SetError(msg.err)
+		} else {
+			h.setError(fmt.Errorf("rollback of %q failed: %w", msg.title, msg.err))
+		}
+		return nil
+	}
+
+	h.maintenanceMsg = fmt.Sprintf("Rolled %q back to %s (%s); its previous state is the newest snapshot",
+		msg.title, shortSnapshotCommit(msg.snap.Commit), msg.snap.Reason)
+	h.maintenanceMsgTime = time.Now()
+	cmds := []tea.Cmd{tea.Tick(5*time.Second, func(_ time.Time) tea.Msg {
+		return clearMaintenanceMsg{}
+	})}
+	if visible {
+		cmds = append(cmds, listSnapshotsCmd(msg.sessionID, d.Dir()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// promptSnapshotter returns what to run, in the send goroutine, just before a
+// prompt is delivered to inst: the automatic pre-prompt snapshot of its
+// checkout, or nothing when [snapshots] auto is off or inst has no local
+// checkout. For Claude the prompt hook snapshots too; an unchanged tree is
+// not snapshotted twice.
+func promptSnapshotter(inst *session.Instance) func() {
+	dir := gitStatusDir(inst)
+	config, _ := session.LoadUserConfig()
+	if dir == "" || (config != nil && !config.Snapshots.GetAuto()) {
+		return func() {}
+	}
+	keep := snapshotKeep()
+	return func() {
+		if _, _, err := inst.TakeSnapshot(dir, "before prompt", keep); err != nil {
+			uiLog.Debug("pre_prompt_snapshot_skipped",
+				slog.String("session", inst.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestSnapshotDialog_RollbackNeedsConfirmation(t *testing.T) {
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"-c", "init.defaultBranch=main", "init"},
+		{"-c", "user.name=t", "-c", "user.email=t@t", "commit", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	file := filepath.Join(repo, "main.go")
+	if err := os.WriteFile(file, []byte("good\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	inst := session.NewInstance("api", repo)
+	h := &Home{
+		snapshotDialog: NewSnapshotDialog(),
+		instanceByID:   map[string]*session.Instance{inst.ID: inst},
+	}
+	var run func(tea.Cmd)
+	run = func(cmd tea.Cmd) {
+		t.Helper()
+		if cmd == nil {
+			t.Fatal("expected a command")
+		}
+		switch msg := cmd().(type) {
+		case snapshotsLoadedMsg:
+			h.handleSnapshotsLoaded(msg)
+		case snapshotTakenMsg:
+			run(h.handleSnapshotTaken(msg))
+		case snapshotRestoredMsg:
+			if msg.err != nil {
+				t.Fatalf("rollback: %v", msg.err)
+			}
+			if cmd := h.handleSnapshotRestored(msg); cmd == nil {
+				t.Fatal("expected the list to be reloaded after a rollback")
+			}
+			h.handleSnapshotsLoaded(listSnapshotsCmd(msg.sessionID, h.snapshotDialog.Dir())().(snapshotsLoadedMsg))
+		default:
+			t.Fatalf("unexpected message %T", msg)
+		}
+	}
+	key := func(k string) tea.Cmd {
+		_, cmd := h.handleSnapshotDialogKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return cmd
+	}
+
+	run(h.openSnapshots(inst))
+	run(key("s"))
+	if d := h.snapshotDialog; d.Busy() || d.Selected() == nil || d.Selected().Reason != "manual" {
+		t.Fatalf("after s: busy=%v selected=%+v", d.Busy(), d.Selected())
+	}
+
+	if err := os.WriteFile(file, []byte("broken\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, cmd := h.handleSnapshotDialogKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || !h.snapshotDialog.Confirming() {
+		t.Fatal("Enter should ask for confirmation, not roll back")
+	}
+	if cmd := key("n"); cmd != nil || h.snapshotDialog.Confirming() {
+		t.Fatal("n should dismiss the confirmation")
+	}
+	h.handleSnapshotDialogKey(tea.KeyMsg{Type: tea.KeyEnter})
+	run(key("y"))
+
+	if got, _ := os.ReadFile(file); string(got) != "good\n" {
+		t.Errorf("main.go = %q after rollback, want the snapshotted content", got)
+	}
+	if sel := h.snapshotDialog.Selected(); sel == nil || sel.Reason != "before rollback" {
+		t.Errorf("newest snapshot after rollback = %+v, want the safety snapshot", sel)
+	}
+	if log := inst.GetEventLog(); len(log) == 0 || log[len(log)-1].Kind != session.EventRolledBack {
+		t.Errorf("event log = %+v, want a rollback entry", log)
+	}
+}
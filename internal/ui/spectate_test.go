@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSpectate_StoppedSessionReportsError(t *testing.T) {
+	home, inst, _ := armHomeWithOneSession(t)
+	home.setHotkeys(resolveHotkeys(nil))
+	// No tmux session at all: a has-session probe that times out on a loaded
+	// machine would count as "still running".
+	inst.SetTmuxSessionForTest(nil)
+
+	_, cmd := home.handleMainKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}, Alt: true})
+
+	if cmd != nil {
+		t.Fatal("spectating a session with no live tmux session must not start an attach")
+	}
+	if home.err == nil {
+		t.Fatal("expected an error explaining there is nothing to spectate")
+	}
+	if home.isAttaching.Load() {
+		t.Fatal("isAttaching must stay false when no attach starts")
+	}
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// stuckWatchdog flags running sessions whose pane has produced no new output
+// for [watchdog] stuck_after — an agent looping on a hung command all night.
+// "New output" is a change in the pane line count from the shared pane-info
+// cache (the signal behind the "+N new" badge), which spinner and timer
+// redraws do not move. Fed by the background status loop.
+type stuckWatchdog struct {
+	mu      sync.Mutex
+	entries map[string]stuckEntry
+}
+
+type stuckEntry struct {
+	lines      int
+	lastOutput time.Time
+	stuck      bool
+}
+
+// observe updates per-session output state and returns the sessions that
+// just became stuck. lineCount reports a session's pane line count, ok=false
+// when unknown (the session keeps its state). A stuck session stays flagged
+// until it produces output or stops running, so each stall triggers once. A
+// zero limit disables the watchdog and clears all flags.
+func (w *stuckWatchdog) observe(instances []*session.Instance, lineCount func(*session.Instance) (int, bool), limit time.Duration, now time.Time) []*session.Instance {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if limit <= 0 {
+		w.entries = nil
+		return nil
+	}
+	if w.entries == nil {
+		w.entries = make(map[string]stuckEntry)
+	}
+
+	running := make(map[string]bool, len(instances))
+	var stuck []*session.Instance
+	for _, inst := range instances {
+		if inst.GetStatusThreadSafe() != session.StatusRunning {
+			continue
+		}
+		running[inst.ID] = true
+		lines, ok := lineCount(inst)
+		if !ok {
+			continue
+		}
+		e, seen := w.entries[inst.ID]
+		if !seen || e.lines != lines {
+			w.entries[inst.ID] = stuckEntry{lines: lines, lastOutput: now}
+			continue
+		}
+		if !e.stuck && now.Sub(e.lastOutput) >= limit {
+			e.stuck = true
+			w.entries[inst.ID] = e
+			stuck = append(stuck, inst)
+		}
+	}
+	for id := range w.entries {
+		if !running[id] {
+			delete(w.entries, id)
+		}
+	}
+	return stuck
+}
+
+// isStuck reports whether the session is currently flagged stuck.
+func (w *stuckWatchdog) isStuck(id string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.entries[id].stuck
+}
+
+// stalledFor returns how long a session has gone without output; zero when
+// it is not tracked.
+func (w *stuckWatchdog) stalledFor(id string, now time.Time) time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	e, ok := w.entries[id]
+	if !ok {
+		return 0
+	}
+	return now.Sub(e.lastOutput)
+}
+
+// paneLineCount reads a session's pane line count from the pane-info cache.
+func paneLineCount(inst *session.Instance) (int, bool) {
+	ts := inst.GetTmuxSession()
+	if ts == nil {
+		return 0, false
+	}
+	info, ok := tmux.GetCachedPaneInfo(ts.Name)
+	if !ok {
+		return 0, false
+	}
+	return info.LineCount(), true
+}
+
+// runStuckWatchdog flags sessions that ran past [watchdog] stuck_after without
+// output: it logs each to session-lifecycle.jsonl, sends the configured
+// action key, and notifies on the desktop. The session the user is attached
+// to is flagged but left alone. Runs in the background status loop.
+func (h *Home) runStuckWatchdog(instances []*session.Instance) {
+	ws := session.GetWatchdogSettings()
+	now := time.Now()
+	stuck := h.stuckWatchdog.observe(instances, paneLineCount, ws.GetStuckAfter(), now)
+	if len(stuck) == 0 {
+		return
+	}
+
+	muted := h.mutedGroupPaths()
+	attachedID := h.getAttachedSessionID()
+	actionKey := ws.ActionKey()
+	var notes []session.DesktopNotification
+	for _, inst := range stuck {
+		stalled := h.stuckWatchdog.stalledFor(inst.ID, now)
+		uiLog.Warn("session_stuck",
+			slog.String("session", inst.ID),
+			slog.Duration("no_output_for", stalled),
+			slog.String("action", actionKey))
+		if err := session.WriteSessionLifecycleEvent(session.SessionLifecycleEvent{
+			InstanceID: inst.ID,
+			Action:     session.ReasonStuckDetected,
+			Reason:     fmt.Sprintf("running with no pane output for %ds", int64(stalled/time.Second)),
+		}); err != nil {
+			uiLog.Warn("session_stuck_log_failed", slog.String("session", inst.ID), slog.String("error", err.Error()))
+		}
+		if inst.ID == attachedID {
+			continue
+		}
+		if ts := inst.GetTmuxSession(); actionKey != "" && ts != nil {
+			go func(id string, ts *tmux.Session) {
+				if err := ts.SendNamedKey(actionKey); err != nil {
+					uiLog.Warn("session_stuck_action_failed", slog.String("session", id), slog.String("error", err.Error()))
+				}
+			}(inst.ID, ts)
+		}
+		if !ws.GetNotify() || inst.IsArchived() || session.InMutedGroup(muted, inst.GroupPath) {
+			continue
+		}
+		notes = append(notes, session.DesktopNotification{
+			SessionID:  inst.ID,
+			Title:      inst.Title,
+			Status:     session.StatusRunning,
+			Reason:     "stuck",
+			StalledFor: stalled,
+		})
+	}
+	if len(notes) == 0 {
+		return
+	}
+
+	missed := make([]session.DNDMissedEvent, 0, len(notes))
+	for _, n := range notes {
+		missed = append(missed, session.DNDMissedEvent{
+			Source:    session.DNDSourceDesktop,
+			SessionID: n.SessionID,
+			Title:     n.Title,
+			Status:    n.Reason,
+		})
+	}
+	if active, err := session.RecordDNDMissed(missed...); err != nil {
+		notifLog.Warn("stuck_dnd_record_failed", slog.String("error", err.Error()))
+	} else if active {
+		return
+	}
+
+	desktop := session.GetNotificationsSettings().Desktop
+	for _, n := range notes {
+		go func(n session.DesktopNotification) {
+			if err := session.SendDesktopNotification(desktop, n); err != nil {
+				notifLog.Warn("stuck_notify_failed",
+					slog.String("session", n.SessionID),
+					slog.String("error", err.Error()))
+			}
+		}(n)
+	}
+}
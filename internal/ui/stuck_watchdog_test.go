@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+func TestStuckWatchdog(t *testing.T) {
+	_, insts := newMultiSelectHome(t)
+	a, b := insts[0], insts[1]
+	a.Status = session.StatusRunning
+	b.Status = session.StatusRunning
+
+	lines := map[string]int{a.ID: 10, b.ID: 10}
+	count := func(inst *session.Instance) (int, bool) {
+		n, ok := lines[inst.ID]
+		return n, ok
+	}
+
+	var w stuckWatchdog
+	t0 := time.Now()
+	limit := 30 * time.Minute
+	w.observe(insts, count, limit, t0)
+
+	lines[b.ID] = 50 // b keeps printing
+	got := w.observe(insts, count, limit, t0.Add(31*time.Minute))
+	if len(got) != 1 || got[0] != a {
+		t.Fatalf("observe = %v, want only a stuck", got)
+	}
+	if !w.isStuck(a.ID) || w.isStuck(b.ID) {
+		t.Error("a should be flagged, b not")
+	}
+	if d := w.stalledFor(a.ID, t0.Add(31*time.Minute)); d != 31*time.Minute {
+		t.Errorf("stalledFor = %v, want 31m", d)
+	}
+	lines[b.ID] = 90
+	if got := w.observe(insts, count, limit, t0.Add(2*time.Hour)); len(got) != 0 {
+		t.Errorf("a stall triggers once, got %v", got)
+	}
+
+	lines[a.ID] = 11
+	w.observe(insts, count, limit, t0.Add(3*time.Hour))
+	if w.isStuck(a.ID) {
+		t.Error("new output should clear the stuck flag")
+	}
+
+	a.Status = session.StatusWaiting
+	w.observe(insts, count, limit, t0.Add(4*time.Hour))
+	if w.stalledFor(a.ID, t0.Add(4*time.Hour)) != 0 {
+		t.Error("leaving running should drop the session")
+	}
+
+	w.observe(insts, count, 0, t0.Add(5*time.Hour))
+	if w.isStuck(b.ID) || w.stalledFor(b.ID, t0.Add(5*time.Hour)) != 0 {
+		t.Error("a zero limit disables the watchdog")
+	}
+}
+
+func TestRenderSessionItem_ShowsStuck(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	home.initialLoading = false
+	a := insts[0]
+	a.Status = session.StatusRunning
+	home.stuckWatchdog.entries = map[string]stuckEntry{a.ID: {stuck: true}}
+	home.refreshSessionRenderSnapshot(nil)
+
+	view := tmux.StripANSI(home.View())
+	if !strings.Contains(view, a.Title+" (stuck)") {
+		t.Errorf("stuck session row should read %q:\n%s", a.Title+" (stuck)", view)
+	}
+}
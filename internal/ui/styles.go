@@ -2,12 +2,18 @@ package ui
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"sync"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
-// Theme represents the current color scheme
+// Theme is the background brightness of the active palette. Rendering code
+// that has to pick between light- and dark-background variants (diff colors,
+// contrast fixes) keys off this rather than the palette name.
 type Theme string
 
 const (
@@ -18,12 +24,15 @@ const (
 // currentTheme holds the active theme (set at init)
 var currentTheme Theme = ThemeDark
 
-// Dark Theme - Tokyo Night
-var darkColors = struct {
+// Palette is the set of colors every style is built from.
+type Palette struct {
 	Bg, Surface, Border, Text, TextDim  lipgloss.Color
 	Accent, Purple, Cyan, Green, Yellow lipgloss.Color
 	Orange, Red, Comment                lipgloss.Color
-}{
+}
+
+// Dark Theme - Tokyo Night
+var darkColors = Palette{
 	Bg:      lipgloss.Color("#1a1b26"),
 	Surface: lipgloss.Color("#24283b"),
 	Border:  lipgloss.Color("#414868"),
@@ -40,11 +49,7 @@ var darkColors = struct {
 }
 
 // Light Theme - Tokyo Night Light variant
-var lightColors = struct {
-	Bg, Surface, Border, Text, TextDim  lipgloss.Color
-	Accent, Purple, Cyan, Green, Yellow lipgloss.Color
-	Orange, Red, Comment                lipgloss.Color
-}{
+var lightColors = Palette{
 	Bg:      lipgloss.Color("#d5d6db"),
 	Surface: lipgloss.Color("#e9e9ec"),
 	Border:  lipgloss.Color("#9699a3"),
@@ -60,6 +65,67 @@ var lightColors = struct {
 	Comment: lipgloss.Color("#6a6d7c"),
 }
 
+// Solarized Dark - Ethan Schoonover's base03 background
+var solarizedDarkColors = Palette{
+	Bg:      lipgloss.Color("#002b36"),
+	Surface: lipgloss.Color("#073642"),
+	Border:  lipgloss.Color("#586e75"),
+	Text:    lipgloss.Color("#93a1a1"),
+	TextDim: lipgloss.Color("#839496"),
+	Accent:  lipgloss.Color("#268bd2"),
+	Purple:  lipgloss.Color("#6c71c4"),
+	Cyan:    lipgloss.Color("#2aa198"),
+	Green:   lipgloss.Color("#859900"),
+	Yellow:  lipgloss.Color("#b58900"),
+	Orange:  lipgloss.Color("#cb4b16"),
+	Red:     lipgloss.Color("#dc322f"),
+	Comment: lipgloss.Color("#839496"),
+}
+
+// Solarized Light - same accents on the base3 background
+var solarizedLightColors = Palette{
+	Bg:      lipgloss.Color("#fdf6e3"),
+	Surface: lipgloss.Color("#eee8d5"),
+	Border:  lipgloss.Color("#93a1a1"),
+	Text:    lipgloss.Color("#073642"),
+	TextDim: lipgloss.Color("#586e75"),
+	Accent:  lipgloss.Color("#268bd2"),
+	Purple:  lipgloss.Color("#6c71c4"),
+	Cyan:    lipgloss.Color("#2aa198"),
+	Green:   lipgloss.Color("#859900"),
+	Yellow:  lipgloss.Color("#b58900"),
+	Orange:  lipgloss.Color("#cb4b16"),
+	Red:     lipgloss.Color("#dc322f"),
+	Comment: lipgloss.Color("#586e75"),
+}
+
+// High Contrast - saturated colors on black for low-vision and washed-out
+// displays
+var highContrastColors = Palette{
+	Bg:      lipgloss.Color("#000000"),
+	Surface: lipgloss.Color("#1c1c1c"),
+	Border:  lipgloss.Color("#ffffff"),
+	Text:    lipgloss.Color("#ffffff"),
+	TextDim: lipgloss.Color("#d0d0d0"),
+	Accent:  lipgloss.Color("#00afff"),
+	Purple:  lipgloss.Color("#ff5fff"),
+	Cyan:    lipgloss.Color("#00ffff"),
+	Green:   lipgloss.Color("#00ff00"),
+	Yellow:  lipgloss.Color("#ffff00"),
+	Orange:  lipgloss.Color("#ff8700"),
+	Red:     lipgloss.Color("#ff0000"),
+	Comment: lipgloss.Color("#d0d0d0"),
+}
+
+// builtinPalettes holds the palette for each session.BuiltinThemeNames entry.
+var builtinPalettes = map[string]Palette{
+	"dark":            darkColors,
+	"light":           lightColors,
+	"solarized-dark":  solarizedDarkColors,
+	"solarized-light": solarizedLightColors,
+	"high-contrast":   highContrastColors,
+}
+
 // Active color variables (set by InitTheme)
 var (
 	ColorBg      lipgloss.Color
@@ -81,46 +147,79 @@ var (
 // Write lock held by InitTheme; read lock held by GetToolStyle (map access).
 var themeMu sync.RWMutex
 
-// InitTheme sets the active color palette based on theme name
+// InitTheme sets the active color palette based on theme name: a built-in
+// theme or a [themes.<name>] palette from config.toml. Unknown names fall
+// back to dark.
 // Must be called before any UI rendering
 func InitTheme(theme string) {
+	palette, ok := builtinPalettes[theme]
+	if !ok {
+		if custom, found := session.GetCustomTheme(theme); found {
+			palette = customPalette(custom)
+		} else {
+			theme = "dark"
+			palette = darkColors
+		}
+	}
+	brightness := Theme(session.ThemeBrightness(theme))
+
 	themeMu.Lock()
 	defer themeMu.Unlock()
-	if theme == "light" {
-		currentTheme = ThemeLight
-		ColorBg = lightColors.Bg
-		ColorSurface = lightColors.Surface
-		ColorBorder = lightColors.Border
-		ColorText = lightColors.Text
-		ColorTextDim = lightColors.TextDim
-		ColorAccent = lightColors.Accent
-		ColorPurple = lightColors.Purple
-		ColorCyan = lightColors.Cyan
-		ColorGreen = lightColors.Green
-		ColorYellow = lightColors.Yellow
-		ColorOrange = lightColors.Orange
-		ColorRed = lightColors.Red
-		ColorComment = lightColors.Comment
-	} else {
-		currentTheme = ThemeDark
-		ColorBg = darkColors.Bg
-		ColorSurface = darkColors.Surface
-		ColorBorder = darkColors.Border
-		ColorText = darkColors.Text
-		ColorTextDim = darkColors.TextDim
-		ColorAccent = darkColors.Accent
-		ColorPurple = darkColors.Purple
-		ColorCyan = darkColors.Cyan
-		ColorGreen = darkColors.Green
-		ColorYellow = darkColors.Yellow
-		ColorOrange = darkColors.Orange
-		ColorRed = darkColors.Red
-		ColorComment = darkColors.Comment
-	}
+	currentTheme = brightness
+	ColorBg = palette.Bg
+	ColorSurface = palette.Surface
+	ColorBorder = palette.Border
+	ColorText = palette.Text
+	ColorTextDim = palette.TextDim
+	ColorAccent = palette.Accent
+	ColorPurple = palette.Purple
+	ColorCyan = palette.Cyan
+	ColorGreen = palette.Green
+	ColorYellow = palette.Yellow
+	ColorOrange = palette.Orange
+	ColorRed = palette.Red
+	ColorComment = palette.Comment
 	// Reinitialize styles with new colors
 	initStyles()
 }
 
+// customPalette overlays a user palette's valid colors onto its base theme.
+func customPalette(p session.ThemePalette) Palette {
+	out := builtinPalettes[p.GetBase()]
+	overlay := func(dst *lipgloss.Color, value string) {
+		if isValidThemeColor(value) {
+			*dst = lipgloss.Color(value)
+		}
+	}
+	overlay(&out.Bg, p.Bg)
+	overlay(&out.Surface, p.Surface)
+	overlay(&out.Border, p.Border)
+	overlay(&out.Text, p.Text)
+	overlay(&out.TextDim, p.TextDim)
+	overlay(&out.Accent, p.Accent)
+	overlay(&out.Purple, p.Purple)
+	overlay(&out.Cyan, p.Cyan)
+	overlay(&out.Green, p.Green)
+	overlay(&out.Yellow, p.Yellow)
+	overlay(&out.Orange, p.Orange)
+	overlay(&out.Red, p.Red)
+	overlay(&out.Comment, p.Comment)
+	return out
+}
+
+// themeHexColor matches the "#rrggbb" form lipgloss renders in true color.
+var themeHexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// isValidThemeColor accepts "#rrggbb" or an ANSI 256 index. lipgloss silently
+// drops anything else, which would leave text uncolored rather than fall back.
+func isValidThemeColor(value string) bool {
+	if themeHexColor.MatchString(value) {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
 // GetCurrentTheme returns the active theme
 func GetCurrentTheme() Theme {
 	return currentTheme
@@ -642,6 +741,9 @@ func ToolColor(tool string) lipgloss.Color {
 	case "aider":
 		return ColorRed // Red for Aider
 	default:
+		if c := session.GetToolColor(tool); c != "" {
+			return lipgloss.Color(c) // [tools.<name>].color
+		}
 		return ColorTextDim // Default gray
 	}
 }
@@ -654,6 +756,9 @@ func GetToolStyle(tool string) lipgloss.Style {
 	if style, ok := ToolStyleCache[tool]; ok {
 		return style
 	}
+	if c := session.GetToolColor(tool); c != "" {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(c))
+	}
 	return DefaultToolStyle
 }
 
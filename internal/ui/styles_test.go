@@ -2,6 +2,10 @@ package ui
 
 import (
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
 func TestColorsDefined(t *testing.T) {
@@ -137,3 +141,46 @@ func TestToolStyleCache_ReinitializedOnThemeChange(t *testing.T) {
 	// Reset to dark for other tests
 	InitTheme("dark")
 }
+
+func TestBuiltinPalettes_CoverSessionThemes(t *testing.T) {
+	for _, name := range session.BuiltinThemeNames() {
+		if _, ok := builtinPalettes[name]; !ok {
+			t.Errorf("built-in theme %q has no palette", name)
+		}
+	}
+	if len(builtinPalettes) != len(session.BuiltinThemeNames()) {
+		t.Errorf("builtinPalettes has %d entries, session knows %d themes", len(builtinPalettes), len(session.BuiltinThemeNames()))
+	}
+}
+
+func TestInitTheme_SolarizedLight(t *testing.T) {
+	InitTheme("solarized-light")
+	defer InitTheme("dark")
+	if GetCurrentTheme() != ThemeLight {
+		t.Errorf("solarized-light should be a light theme, got %v", GetCurrentTheme())
+	}
+	if ColorBg != solarizedLightColors.Bg {
+		t.Errorf("ColorBg = %v, want %v", ColorBg, solarizedLightColors.Bg)
+	}
+}
+
+func TestCustomPalette_OverlaysBase(t *testing.T) {
+	p := customPalette(session.ThemePalette{
+		Base:   "light",
+		Bg:     "#ffffff",
+		Accent: "33",
+		Red:    "not-a-color",
+	})
+	if p.Bg != lipgloss.Color("#ffffff") {
+		t.Errorf("Bg = %v, want #ffffff", p.Bg)
+	}
+	if p.Accent != lipgloss.Color("33") {
+		t.Errorf("Accent = %v, want 33", p.Accent)
+	}
+	if p.Red != lightColors.Red {
+		t.Errorf("invalid Red should fall back to base, got %v", p.Red)
+	}
+	if p.Text != lightColors.Text {
+		t.Errorf("unset Text should come from base, got %v", p.Text)
+	}
+}
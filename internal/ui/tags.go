@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FilterKeyTag cycles the tag filter through every tag in use, then off.
+// It composes with the status filters (!@#$%) rather than replacing them.
+const FilterKeyTag = "&"
+
+// allSessionTags returns the sorted set of tags across loaded sessions.
+func (h *Home) allSessionTags() []string {
+	h.instancesMu.RLock()
+	defer h.instancesMu.RUnlock()
+	return session.CollectTags(h.instances)
+}
+
+// cycleTagFilter advances the tag filter to the next tag in use, wrapping
+// back to "no tag filter" after the last one.
+func (h *Home) cycleTagFilter() {
+	tags := h.allSessionTags()
+	if len(tags) == 0 {
+		h.tagFilter = ""
+		h.setError(fmt.Errorf("no tagged sessions — add tags in the edit dialog (%s) or with `agent-deck session set <id> tags …`", h.actionKey(hotkeyEditSession)))
+		return
+	}
+	next := 0
+	if h.tagFilter != "" {
+		next = slices.Index(tags, h.tagFilter) + 1
+	}
+	if next >= len(tags) {
+		h.tagFilter = ""
+		return
+	}
+	h.tagFilter = tags[next]
+}
+
+// applyTagFilter keeps sessions carrying h.tagFilter plus the group headers
+// (and their ancestors) that contain one. A filter that matches nothing is
+// cleared, mirroring the status filter's auto-clear.
+func (h *Home) applyTagFilter(items []session.Item) []session.Item {
+	if h.tagFilter == "" {
+		return items
+	}
+	groupsWithMatches := make(map[string]bool)
+	for _, item := range items {
+		if item.Type == session.ItemTypeSession && item.Session != nil && item.Session.HasTag(h.tagFilter) {
+			parts := strings.Split(item.Path, "/")
+			for i := range parts {
+				groupsWithMatches[strings.Join(parts[:i+1], "/")] = true
+			}
+		}
+	}
+	if len(groupsWithMatches) == 0 {
+		h.tagFilter = ""
+		return items
+	}
+	filtered := make([]session.Item, 0, len(items))
+	for _, item := range items {
+		switch item.Type {
+		case session.ItemTypeGroup:
+			if groupsWithMatches[item.Path] {
+				filtered = append(filtered, item)
+			}
+		case session.ItemTypeSession:
+			if item.Session != nil && item.Session.HasTag(h.tagFilter) {
+				filtered = append(filtered, item)
+			}
+		}
+	}
+	return filtered
+}
+
+// renderSessionTags renders tags as "#tag" labels for the preview header.
+func renderSessionTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	labels := make([]string, len(tags))
+	for i, t := range tags {
+		labels[i] = "#" + t
+	}
+	return lipgloss.NewStyle().Foreground(ColorYellow).Render(strings.Join(labels, " "))
+}
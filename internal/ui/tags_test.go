@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func visibleSessionTitles(home *Home) []string {
+	var titles []string
+	for _, item := range home.flatItems {
+		if item.Type == session.ItemTypeSession && item.Session != nil {
+			titles = append(titles, item.Session.Title)
+		}
+	}
+	sort.Strings(titles)
+	return titles
+}
+
+func TestTagFilter_CyclesTagsAndKeepsMatchingGroups(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	insts[0].Tags = []string{"urgent"}
+	insts[2].Tags = []string{"client-x", "urgent"}
+	insts[3].Tags = []string{"client-x"}
+	home.rebuildFlatItems()
+
+	pressKey(home, '&')
+	if home.tagFilter != "client-x" {
+		t.Fatalf("first press should select the first tag, got %q", home.tagFilter)
+	}
+	if got := strings.Join(visibleSessionTitles(home), ","); got != "c,d" {
+		t.Fatalf("client-x filter shows %q, want c,d", got)
+	}
+	for _, item := range home.flatItems {
+		if item.Type == session.ItemTypeGroup && item.Path != "work" && item.Path != "work/sub" && item.Path != "other" {
+			t.Fatalf("unexpected group %q under the tag filter", item.Path)
+		}
+	}
+
+	pressKey(home, '&')
+	if got := strings.Join(visibleSessionTitles(home), ","); home.tagFilter != "urgent" || got != "a,c" {
+		t.Fatalf("urgent filter = %q shows %q, want a,c", home.tagFilter, got)
+	}
+
+	pressKey(home, '&')
+	if home.tagFilter != "" || len(visibleSessionTitles(home)) != len(insts) {
+		t.Fatalf("cycling past the last tag should clear the filter, got %q", home.tagFilter)
+	}
+}
+
+func TestTagFilter_ComposesWithStatusFilterAndZeroClears(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	insts[0].Tags = []string{"urgent"}
+	insts[1].Tags = []string{"urgent"}
+	insts[0].Status = session.StatusWaiting
+	insts[1].Status = session.StatusIdle
+	home.tagFilter = "urgent"
+	home.statusFilter = session.StatusWaiting
+	home.rebuildFlatItems()
+
+	if got := strings.Join(visibleSessionTitles(home), ","); got != "a" {
+		t.Fatalf("tag+status filter shows %q, want a", got)
+	}
+
+	pressKey(home, '0')
+	if home.tagFilter != "" || home.statusFilter != "" {
+		t.Fatalf("0 should clear both filters, got tag=%q status=%q", home.tagFilter, home.statusFilter)
+	}
+}
+
+func TestTagFilter_NoTagsReportsHint(t *testing.T) {
+	home, _ := newMultiSelectHome(t)
+	pressKey(home, '&')
+	if home.tagFilter != "" || home.err == nil {
+		t.Fatal("cycling with no tagged sessions should leave the filter off and explain why")
+	}
+}
+
+func TestEditSessionDialog_TagsField(t *testing.T) {
+	inst := session.NewInstanceWithTool("tagged", "/tmp/tagged", "shell")
+	inst.Tags = []string{"urgent"}
+	d := NewEditSessionDialog()
+	d.Show(inst)
+
+	for i := range d.fields {
+		if d.fields[i].key == session.FieldTags {
+			if d.fields[i].input.Value() != "urgent" {
+				t.Fatalf("tags field = %q, want urgent", d.fields[i].input.Value())
+			}
+			d.fields[i].input.SetValue("urgent, client-x")
+		}
+	}
+	changes := d.GetChanges(inst)
+	if len(changes) != 1 || changes[0].Field != session.FieldTags || !changes[0].IsLive {
+		t.Fatalf("changes = %+v, want one live tags change", changes)
+	}
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// transcriptCheckInterval is how often the TUI attaches transcripts to
+// running sessions and rotates the transcript files. Output printed before
+// a new session's first check is still captured: pipe-pane only sees new
+// output, but a session rarely prints much in its first seconds.
+const transcriptCheckInterval = 10 * time.Second
+
+// transcriptClosedMsg is sent when the transcript pager exits.
+type transcriptClosedMsg struct {
+	title string
+	err   error
+}
+
+// maintainTranscripts pipes every running session into its transcript and
+// rotates/expires the transcript files. Runs off the UI thread; failures
+// are logged, never surfaced — a transcript is a best-effort record.
+func (h *Home) maintainTranscripts(settings session.TranscriptSettings) tea.Cmd {
+	h.instancesMu.RLock()
+	instances := make([]*session.Instance, 0, len(h.instances))
+	for _, inst := range h.instances {
+		if inst.GetTmuxSession() != nil {
+			instances = append(instances, inst)
+		}
+	}
+	h.instancesMu.RUnlock()
+
+	profile := h.profile
+	return func() tea.Msg {
+		for _, inst := range instances {
+			started, err := inst.StartTranscript(profile)
+			if err != nil {
+				uiLog.Warn("transcript_start_failed",
+					slog.String("id", inst.ID),
+					slog.String("error", err.Error()))
+			} else if started {
+				uiLog.Info("transcript_started", slog.String("id", inst.ID))
+			}
+		}
+		dir, err := session.TranscriptDir(profile)
+		if err == nil {
+			err = session.RotateTranscripts(dir, settings, time.Now())
+		}
+		if err != nil {
+			uiLog.Warn("transcript_rotate_failed", slog.String("error", err.Error()))
+		}
+		return nil
+	}
+}
+
+// openTranscript shows the session's transcript, oldest rotation first, in
+// $PAGER (default "less -R", which keeps the agent's colors).
+func (h *Home) openTranscript(inst *session.Instance) tea.Cmd {
+	files, err := session.TranscriptFiles(h.profile, inst.ID)
+	if err != nil {
+		h.setError(fmt.Errorf("transcript for %q: %w", inst.Title, err))
+		return nil
+	}
+	if len(files) == 0 {
+		if !session.GetTranscriptSettings().Enabled {
+			h.setError(fmt.Errorf("no transcript for %q: enable [transcripts] in config.toml", inst.Title))
+		} else {
+			h.setError(fmt.Errorf("no transcript for %q yet", inst.Title))
+		}
+		return nil
+	}
+
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = "less -R"
+	}
+	args := append([]string{"-c", `cat "$@" | ` + pager, "sh"}, files...)
+	title := inst.Title
+	return tea.ExecProcess(exec.Command("sh", args...), func(err error) tea.Msg {
+		return transcriptClosedMsg{title: title, err: err}
+	})
+}
@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// TrashDialog is an overlay listing the profile's deleted sessions so one can
+// be restored (or purged for good) without leaving the TUI. The entries come
+// from Storage.LoadTrash; Home performs the restore/purge and reports back.
+type TrashDialog struct {
+	visible bool
+	entries []*statedb.TrashRow
+	cursor  int
+	errMsg  string
+	width   int
+	height  int
+}
+
+// NewTrashDialog constructs a hidden trash view.
+func NewTrashDialog() *TrashDialog {
+	return &TrashDialog{}
+}
+
+// Show opens the view on entries (most recently deleted first). err, when
+// non-nil, is shown above the list.
+func (d *TrashDialog) Show(entries []*statedb.TrashRow, err error) {
+	d.visible = true
+	d.entries = entries
+	d.cursor = 0
+	d.errMsg = ""
+	if err != nil {
+		d.errMsg = err.Error()
+	}
+}
+
+// Hide closes the view.
+func (d *TrashDialog) Hide() {
+	d.visible = false
+}
+
+// IsVisible reports whether the view is currently shown.
+func (d *TrashDialog) IsVisible() bool { return d.visible }
+
+// Selected returns the highlighted entry, or nil when the trash is empty.
+func (d *TrashDialog) Selected() *statedb.TrashRow {
+	if d.cursor < 0 || d.cursor >= len(d.entries) {
+		return nil
+	}
+	return d.entries[d.cursor]
+}
+
+// Remove drops the entry with the given id after it was restored or purged.
+func (d *TrashDialog) Remove(id string) {
+	for i, e := range d.entries {
+		if e.ID == id {
+			d.entries = append(d.entries[:i], d.entries[i+1:]...)
+			break
+		}
+	}
+	if d.cursor >= len(d.entries) && d.cursor > 0 {
+		d.cursor = len(d.entries) - 1
+	}
+}
+
+// SetError shows err above the list.
+func (d *TrashDialog) SetError(err error) {
+	d.errMsg = ""
+	if err != nil {
+		d.errMsg = err.Error()
+	}
+}
+
+// SetSize updates the dialog viewport for centering.
+func (d *TrashDialog) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+}
+
+// Update moves the cursor.
+func (d *TrashDialog) Update(msg tea.KeyMsg) *TrashDialog {
+	switch msg.String() {
+	case "up", "k", "ctrl+p":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j", "ctrl+n":
+		if d.cursor < len(d.entries)-1 {
+			d.cursor++
+		}
+	}
+	return d
+}
+
+// View renders the overlay, centered in the viewport.
+func (d *TrashDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	title := DialogTitleStyle.Render("Trash")
+
+	rowStyle := lipgloss.NewStyle().Foreground(ColorText).Padding(0, 1)
+	selStyle := lipgloss.NewStyle().
+		Foreground(ColorBg).
+		Background(ColorAccent).
+		Bold(true).
+		Padding(0, 1)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+
+	var listBlock string
+	if len(d.entries) == 0 {
+		listBlock = dimStyle.Render("No deleted sessions.")
+	} else {
+		rows := make([]string, 0, len(d.entries))
+		now := time.Now()
+		for i, e := range d.entries {
+			line := fmt.Sprintf("%-24s %-8s %s ago",
+				truncatePath(e.Title, 24), e.Row.Tool, formatTrashAge(now.Sub(e.DeletedAt)))
+			if i == d.cursor {
+				rows = append(rows, selStyle.Render(line))
+			} else {
+				rows = append(rows, rowStyle.Render(line))
+			}
+		}
+		listBlock = strings.Join(rows, "\n")
+	}
+	if d.errMsg != "" {
+		listBlock = lipgloss.NewStyle().Foreground(ColorRed).Render("⚠ "+d.errMsg) + "\n" + listBlock
+	}
+
+	detail := ""
+	if sel := d.Selected(); sel != nil {
+		detail = dimStyle.Render(truncatePath(sel.Row.ProjectPath, 48))
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	hint := hintStyle.Render("↑/↓ navigate │ Enter restore │ x purge │ Esc close")
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		"",
+		listBlock,
+		"",
+		detail,
+		dimStyle.Render("Restored sessions come back stopped; starting"),
+		dimStyle.Render("one resumes its conversation."),
+		"",
+		hint,
+	)
+
+	dialog := DialogBoxStyle.
+		Width(fitDialogWidth(56, 40, d.width)).
+		Render(content)
+
+	return lipgloss.Place(
+		d.width,
+		d.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
+
+// formatTrashAge renders how long ago an entry was deleted, coarsely.
+func formatTrashAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
@@ -104,11 +104,23 @@ func newSeamATestHome() *Home {
 		settingsPanel:        NewSettingsPanel(),
 		analyticsPanel:       NewAnalyticsPanel(),
 		geminiModelDialog:    NewGeminiModelDialog(),
+		claudeModelDialog:    NewClaudeModelDialog(),
+		claudePermDialog:     NewClaudePermissionDialog(),
 		sessionPickerDialog:  NewSessionPickerDialog(),
 		codeBlockDialog:      NewCodeBlockDialog(),
 		worktreeFinishDialog: NewWorktreeFinishDialog(),
 		feedbackDialog:       NewFeedbackDialog(),
 		zoxidePicker:         NewZoxidePicker(),
+		profilePicker:        NewProfilePicker(),
+		trashDialog:          NewTrashDialog(),
+		mcpPoolDialog:        NewMCPPoolDialog(),
+		mcpLogDialog:         NewMCPLogDialog(),
+		mcpRegistryDialog:    NewMCPRegistryDialog(),
+		eventLogDialog:       NewEventLogDialog(),
+		conversationViewer:   NewConversationViewer(),
+		snapshotDialog:       NewSnapshotDialog(),
+		fileRefDialog:        NewFileRefDialog(),
+		commandPalette:       NewCommandPalette(),
 		globalSearch:         NewGlobalSearch(),
 		watcherPanel:         NewWatcherPanel(),
 		notesEditor:          newNotesEditor(),
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// verifyPreviewLines is how much of a failed run's output the preview shows.
+const verifyPreviewLines = 8
+
+// verifyDoneMsg is sent when a session's verify command finishes.
+type verifyDoneMsg struct {
+	sessionID string
+	title     string
+	result    session.VerifyResult
+}
+
+// startVerify runs the project's verify command against inst's checkout off
+// the UI goroutine. One run per session at a time.
+func (h *Home) startVerify(inst *session.Instance) tea.Cmd {
+	if inst.IsSSH() {
+		h.setError(fmt.Errorf("%q runs on %s: verify needs a local checkout", inst.Title, inst.SSHHost))
+		return nil
+	}
+	if h.verifyRunning[inst.ID] {
+		h.setError(fmt.Errorf("verify is already running for %q", inst.Title))
+		return nil
+	}
+	config, _ := session.LoadUserConfig()
+	if config == nil {
+		config = &session.UserConfig{}
+	}
+	command := config.Verify.CommandFor(inst)
+	if command == "" {
+		h.setError(fmt.Errorf("no verify command for %s: set [verify] command or [verify.projects] in config.toml", inst.ProjectPath))
+		return nil
+	}
+
+	h.verifyRunning[inst.ID] = true
+	dir, timeout := session.VerifyDir(inst), config.Verify.GetTimeout()
+	id, title := inst.ID, inst.Title
+	return func() tea.Msg {
+		return verifyDoneMsg{sessionID: id, title: title, result: inst.RunVerify(command, dir, timeout)}
+	}
+}
+
+// handleVerifyDone records a finished run, persists the event it added to
+// the session's log, and reports the outcome in the footer.
+func (h *Home) handleVerifyDone(msg verifyDoneMsg) tea.Cmd {
+	delete(h.verifyRunning, msg.sessionID)
+	h.verifyResults[msg.sessionID] = msg.result
+	h.saveInstances()
+
+	res := msg.result
+	if !res.Passed {
+		summary := res.Err.Error()
+		if last := verifySummaryLine(res.Output); last != "" {
+			summary += ": " + last
+		}
+		h.setError(fmt.Errorf("verify failed for %q (%s)", msg.title, summary))
+		return nil
+	}
+	h.maintenanceMsg = fmt.Sprintf("Verify passed for %q in %s", msg.title, res.Duration.Round(time.Second))
+	h.maintenanceMsgTime = time.Now()
+	return tea.Tick(5*time.Second, func(_ time.Time) tea.Msg {
+		return clearMaintenanceMsg{}
+	})
+}
+
+// verifyBadge returns the row badge for inst's verify state — running, or
+// the outcome of the last run from its event log — and its color.
+func (h *Home) verifyBadge(inst *session.Instance) (string, lipgloss.Color) {
+	if h.verifyRunning[inst.ID] {
+		return "⋯ verify", ColorYellow
+	}
+	ev, ok := inst.LastVerify()
+	if !ok {
+		return "", ""
+	}
+	if session.VerifyPassed(ev) {
+		return "✓ verify", ColorGreen
+	}
+	return "✗ verify", ColorRed
+}
+
+// renderVerifySection shows the selected session's last verify run in the
+// preview: command, outcome and, for a failure, the tail of its output.
+// Empty until a run finishes in this TUI (the output is not persisted).
+func (h *Home) renderVerifySection(inst *session.Instance, width int) string {
+	res, ok := h.verifyResults[inst.ID]
+	if !ok && !h.verifyRunning[inst.ID] {
+		return ""
+	}
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	passStyle := lipgloss.NewStyle().Foreground(ColorGreen).Bold(true)
+	failStyle := lipgloss.NewStyle().Foreground(ColorRed).Bold(true)
+	outputStyle := lipgloss.NewStyle().Foreground(ColorText)
+
+	var b strings.Builder
+	b.WriteString(renderSectionDivider("Verify", width-4))
+	b.WriteString("\n")
+	switch {
+	case h.verifyRunning[inst.ID]:
+		b.WriteString(dimStyle.Render("Running..."))
+	case res.Passed:
+		b.WriteString(passStyle.Render("✓ passed"))
+	default:
+		b.WriteString(failStyle.Render("✗ failed") + dimStyle.Render(" ("+res.Err.Error()+")"))
+	}
+	if ok {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  %s · %s ago", cellTruncate(res.Command, max(width-40, 10), "…"),
+			time.Since(res.FinishedAt).Round(time.Second))))
+	}
+	b.WriteString("\n")
+	if ok && !res.Passed && !h.verifyRunning[inst.ID] {
+		for _, line := range verifyOutputTail(res.Output, verifyPreviewLines) {
+			b.WriteString(outputStyle.Render(cellTruncate(line, max(width-4, 10), "…")))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// verifyOutputTail returns the last n lines of output, without colors or
+// tabs so they measure correctly in the preview.
+func verifyOutputTail(s string, n int) []string {
+	s = strings.ReplaceAll(tmux.StripANSI(s), "\t", "    ")
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// verifySummaryLine returns the last line of output with text on it —
+// usually the test runner's verdict.
+func verifySummaryLine(s string) string {
+	lines := strings.Split(s, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(tmux.StripANSI(lines[i])); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestVerifyBadge(t *testing.T) {
+	dir := t.TempDir()
+	h := &Home{verifyRunning: map[string]bool{}, verifyResults: map[string]session.VerifyResult{}}
+	inst := session.NewInstance("api", dir)
+
+	if text, _ := h.verifyBadge(inst); text != "" {
+		t.Errorf("badge before any run = %q, want none", text)
+	}
+	h.verifyRunning[inst.ID] = true
+	if text, _ := h.verifyBadge(inst); text != "⋯ verify" {
+		t.Errorf("running badge = %q", text)
+	}
+	delete(h.verifyRunning, inst.ID)
+
+	inst.RunVerify("exit 1", dir, time.Minute)
+	if text, color := h.verifyBadge(inst); text != "✗ verify" || color != ColorRed {
+		t.Errorf("failed badge = %q %v", text, color)
+	}
+	inst.RunVerify("true", dir, time.Minute)
+	if text, color := h.verifyBadge(inst); text != "✓ verify" || color != ColorGreen {
+		t.Errorf("passed badge = %q %v", text, color)
+	}
+}
+
+func TestRenderVerifySection_FailureShowsOutputTail(t *testing.T) {
+	h := &Home{verifyRunning: map[string]bool{}, verifyResults: map[string]session.VerifyResult{}}
+	inst := session.NewInstance("api", t.TempDir())
+
+	if got := h.renderVerifySection(inst, 80); got != "" {
+		t.Errorf("section without a run = %q, want empty", got)
+	}
+
+	var out strings.Builder
+	for i := 0; i < 20; i++ {
+		out.WriteString("line " + string(rune('a'+i)) + "\n")
+	}
+	out.WriteString("--- FAIL: TestSomething\n")
+	h.verifyResults[inst.ID] = session.VerifyResult{
+		Command:    "go test ./...",
+		Output:     out.String(),
+		ExitCode:   1,
+		Err:        errors.New("exit status 1"),
+		FinishedAt: time.Now(),
+	}
+	got := h.renderVerifySection(inst, 80)
+	for _, want := range []string{"✗ failed", "exit status 1", "go test ./...", "--- FAIL: TestSomething"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("section missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "line a") {
+		t.Errorf("section should only show the output tail:\n%s", got)
+	}
+	if summary := verifySummaryLine(out.String()); summary != "--- FAIL: TestSomething" {
+		t.Errorf("verifySummaryLine = %q", summary)
+	}
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// waitTracker records when each session entered StatusWaiting, so the list
+// can show how long a prompt has been pending ("◐ api 12m") and escalate
+// waits past [notifications] escalate_after_minutes. Fed by the background
+// status loop; read by the render snapshot.
+type waitTracker struct {
+	mu        sync.Mutex
+	since     map[string]time.Time
+	escalated map[string]bool // waits already escalated: one notification per wait
+	baselined bool
+	threshold atomic.Int64 // escalation threshold in nanoseconds; 0 = off
+}
+
+// observe records wait starts for the current statuses and returns the
+// sessions whose wait crossed the escalation threshold since the last call.
+//
+// The first call seeds sessions that are already waiting from tmux's own
+// record of the transition, and marks long waits escalated without returning
+// them: restarting the TUI must not fire a burst of notifications. Later
+// waits start when observe first sees them; tmux's record can be stale for
+// hook-driven tools.
+func (t *waitTracker) observe(instances []*session.Instance, now time.Time) []*session.Instance {
+	threshold := time.Duration(t.threshold.Load())
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.since == nil {
+		t.since = make(map[string]time.Time)
+		t.escalated = make(map[string]bool)
+	}
+	baseline := !t.baselined
+	t.baselined = true
+
+	waiting := make(map[string]bool, len(instances))
+	var crossed []*session.Instance
+	for _, inst := range instances {
+		if inst.GetStatusThreadSafe() != session.StatusWaiting {
+			continue
+		}
+		waiting[inst.ID] = true
+		start, ok := t.since[inst.ID]
+		if !ok {
+			start = now
+			if ts := inst.GetTmuxSession(); baseline && ts != nil {
+				if ws := ts.GetWaitingSince(); !ws.IsZero() && ws.Before(now) {
+					start = ws
+				}
+			}
+			t.since[inst.ID] = start
+		}
+		if threshold > 0 && now.Sub(start) >= threshold && !t.escalated[inst.ID] {
+			t.escalated[inst.ID] = true
+			if !baseline {
+				crossed = append(crossed, inst)
+			}
+		}
+	}
+	for id := range t.since {
+		if !waiting[id] {
+			delete(t.since, id)
+			delete(t.escalated, id)
+		}
+	}
+	return crossed
+}
+
+// startedAt returns when the session's current wait began; zero when it is
+// not waiting (or not observed yet).
+func (t *waitTracker) startedAt(id string) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.since[id]
+}
+
+// escalates reports whether a wait of d is past the escalation threshold.
+func (t *waitTracker) escalates(d time.Duration) bool {
+	threshold := time.Duration(t.threshold.Load())
+	return threshold > 0 && d >= threshold
+}
+
+// formatWaitDuration renders a wait compactly for the session row: "12m",
+// "2h", "2h05m", "3d". Waits under a minute render as "" so fresh prompts do
+// not tick a seconds counter.
+func formatWaitDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return ""
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		hours, mins := int(d.Hours()), int(d.Minutes())%60
+		if mins == 0 {
+			return fmt.Sprintf("%dh", hours)
+		}
+		return fmt.Sprintf("%dh%02dm", hours, mins)
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// trackWaitDurations updates wait starts and escalates waits that crossed
+// [notifications] escalate_after_minutes: the row turns red, and with
+// escalate_desktop one desktop notification goes out per wait. Runs in the
+// background status loop.
+func (h *Home) trackWaitDurations(instances []*session.Instance) {
+	ns := session.GetNotificationsSettings()
+	h.waitTracker.threshold.Store(int64(ns.GetEscalateAfter()))
+	crossed := h.waitTracker.observe(instances, time.Now())
+	if len(crossed) == 0 || !ns.EscalateDesktop {
+		return
+	}
+
+	muted := h.mutedGroupPaths()
+	attachedID := h.getAttachedSessionID()
+	var notes []session.DesktopNotification
+	for _, inst := range crossed {
+		if inst.ID == attachedID || inst.IsArchived() || session.InMutedGroup(muted, inst.GroupPath) {
+			continue
+		}
+		notes = append(notes, session.DesktopNotification{
+			SessionID: inst.ID,
+			Title:     inst.Title,
+			Status:    session.StatusWaiting,
+			Reason:    inst.CachedAttention().Reason(),
+			WaitedFor: time.Since(h.waitTracker.startedAt(inst.ID)),
+		})
+	}
+	if len(notes) == 0 {
+		return
+	}
+
+	missed := make([]session.DNDMissedEvent, 0, len(notes))
+	for _, n := range notes {
+		missed = append(missed, session.DNDMissedEvent{
+			Source:    session.DNDSourceDesktop,
+			SessionID: n.SessionID,
+			Title:     n.Title,
+			Status:    string(n.Status),
+		})
+	}
+	if active, err := session.RecordDNDMissed(missed...); err != nil {
+		notifLog.Warn("wait_escalation_dnd_record_failed", slog.String("error", err.Error()))
+	} else if active {
+		return
+	}
+
+	for _, n := range notes {
+		go func(n session.DesktopNotification) {
+			if err := session.SendDesktopNotification(ns.Desktop, n); err != nil {
+				notifLog.Warn("wait_escalation_notify_failed",
+					slog.String("session", n.SessionID),
+					slog.String("error", err.Error()))
+			}
+		}(n)
+	}
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+func TestWaitTracker(t *testing.T) {
+	_, insts := newMultiSelectHome(t)
+	a, b := insts[0], insts[1]
+	a.Status = session.StatusRunning
+	b.Status = session.StatusIdle
+
+	var tr waitTracker
+	tr.threshold.Store(int64(30 * time.Minute))
+	t0 := time.Now()
+	tr.observe(insts, t0)
+
+	a.Status = session.StatusWaiting
+	if got := tr.observe(insts, t0.Add(time.Minute)); len(got) != 0 {
+		t.Fatalf("fresh wait must not escalate, got %v", got)
+	}
+	if got := tr.startedAt(a.ID); !got.Equal(t0.Add(time.Minute)) {
+		t.Fatalf("startedAt = %v, want the first observation of the wait", got)
+	}
+
+	got := tr.observe(insts, t0.Add(31*time.Minute))
+	if len(got) != 1 || got[0] != a {
+		t.Fatalf("observe = %v, want a escalated after 30m", got)
+	}
+	if got := tr.observe(insts, t0.Add(2*time.Hour)); len(got) != 0 {
+		t.Errorf("a wait escalates once, got %v", got)
+	}
+
+	a.Status = session.StatusRunning
+	tr.observe(insts, t0.Add(3*time.Hour))
+	if !tr.startedAt(a.ID).IsZero() {
+		t.Error("leaving waiting must clear the wait start")
+	}
+	a.Status = session.StatusWaiting
+	tr.observe(insts, t0.Add(4*time.Hour))
+	if got := tr.observe(insts, t0.Add(5*time.Hour)); len(got) != 1 {
+		t.Errorf("a new wait escalates again, got %v", got)
+	}
+}
+
+func TestWaitTracker_BaselineDoesNotEscalate(t *testing.T) {
+	_, insts := newMultiSelectHome(t)
+	insts[0].Status = session.StatusWaiting
+
+	// Stand in for a wait start seeded from tmux's record at startup.
+	t0 := time.Now()
+	tr := waitTracker{
+		since:     map[string]time.Time{insts[0].ID: t0.Add(-time.Hour)},
+		escalated: map[string]bool{},
+	}
+	tr.threshold.Store(int64(time.Minute))
+	if got := tr.observe(insts, t0); len(got) != 0 {
+		t.Errorf("waits already past the threshold at startup must not notify, got %v", got)
+	}
+	if got := tr.observe(insts, t0.Add(time.Hour)); len(got) != 0 {
+		t.Errorf("waits already running at startup must not notify later either, got %v", got)
+	}
+	if !tr.escalates(time.Hour) || tr.escalates(30*time.Second) {
+		t.Error("escalates should compare against the threshold")
+	}
+
+	tr.threshold.Store(0)
+	if tr.escalates(24 * time.Hour) {
+		t.Error("a zero threshold disables escalation")
+	}
+}
+
+func TestFormatWaitDuration(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, ""},
+		{12 * time.Minute, "12m"},
+		{2 * time.Hour, "2h"},
+		{2*time.Hour + 5*time.Minute, "2h05m"},
+		{3*24*time.Hour + time.Hour, "3d"},
+	}
+	for _, c := range cases {
+		if got := formatWaitDuration(c.d); got != c.want {
+			t.Errorf("formatWaitDuration(%v) = %q, want %q", c.d, got, c.want)
+		}
+	}
+}
+
+func TestRenderSessionItem_ShowsWaitDuration(t *testing.T) {
+	home, insts := newMultiSelectHome(t)
+	home.initialLoading = false
+	a := insts[0]
+	a.Status = session.StatusWaiting
+	home.waitTracker.observe(insts, time.Now())
+	home.waitTracker.mu.Lock()
+	home.waitTracker.since[a.ID] = time.Now().Add(-12 * time.Minute)
+	home.waitTracker.mu.Unlock()
+	home.refreshSessionRenderSnapshot(nil)
+
+	view := tmux.StripANSI(home.View())
+	if !strings.Contains(view, a.Title+" 12m") {
+		t.Errorf("session row should show the wait duration after the title:\n%s", view)
+	}
+}
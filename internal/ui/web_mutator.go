@@ -181,7 +181,7 @@ func (m *WebMutator) RestartSession(id string) error {
 	if inst == nil {
 		return fmt.Errorf("session not found: %s", id)
 	}
-	return inst.Restart()
+	return inst.RestartWithReason(session.RestartReasonManual)
 }
 
 // DeleteSession kills a session and removes it from persistent storage.
@@ -209,6 +209,8 @@ func (m *WebMutator) DeleteSession(id string) error {
 	}
 	defer storage.Close()
 
+	// Best-effort: keep a restorable copy in the trash before the delete.
+	_ = storage.TrashInstance(inst)
 	if err := storage.DeleteInstance(id); err != nil {
 		return err
 	}
@@ -328,7 +330,7 @@ func (m *WebMutator) UndoDelete() (string, error) {
 	// tool (e.g. a tool the user has since uninstalled). Bubble the
 	// error up so the handler returns 500; the entry has already been
 	// popped, mirroring the TUI's ctrl+z semantics.
-	if err := entry.instance.Restart(); err != nil {
+	if err := entry.instance.RestartWithReason(session.RestartReasonRestore); err != nil {
 		return "", fmt.Errorf("restart session: %w", err)
 	}
 
@@ -356,6 +358,9 @@ func (m *WebMutator) UndoDelete() (string, error) {
 	if err := storage.SaveWithGroups(allInstances, m.h.groupTree); err != nil {
 		return "", fmt.Errorf("save session: %w", err)
 	}
+	// The session is live again; its trash entry would only restore a
+	// duplicate.
+	_, _ = storage.PurgeTrash(entry.instance.ID)
 	return entry.instance.ID, nil
 }
 
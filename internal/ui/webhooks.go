@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// webhookClient is shared by all deliveries; SendWebhook bounds each one.
+var webhookClient = &http.Client{}
+
+type webhookDelivery struct {
+	name    string
+	hook    session.WebhookConfig
+	payload session.WebhookPayload
+}
+
+// webhookEvent maps a tracked change to its [webhooks.*] event name, or ""
+// for changes no event covers (e.g. a session turning running).
+func webhookEvent(tr statusTransition) string {
+	switch tr.kind {
+	case sessionAppeared:
+		return session.WebhookEventCreated
+	case sessionRemoved:
+		return session.WebhookEventDeleted
+	case sessionRestarted:
+		return session.WebhookEventRestarted
+	}
+	switch tr.to {
+	case session.StatusWaiting:
+		return session.WebhookEventWaiting
+	case session.StatusIdle:
+		return session.WebhookEventIdle
+	case session.StatusError:
+		return session.WebhookEventError
+	}
+	return ""
+}
+
+// dispatchWebhooks POSTs the session events seen since the previous sweep
+// to the subscribed [webhooks.*] endpoints. Runs on the background status
+// worker. Deck-wide DND holds back the status events (waiting, idle, error)
+// and records them in the missed digest; lifecycle events still go out
+// since automation usually depends on them.
+func (h *Home) dispatchWebhooks(instances []*session.Instance) {
+	hooks := session.GetWebhooks()
+	if len(hooks) == 0 {
+		h.webhookTracker.reset()
+		return
+	}
+
+	now := time.Now()
+	var deliveries []webhookDelivery
+	var alerts []session.DNDMissedEvent
+	for _, tr := range h.webhookTracker.observe(instances) {
+		event := webhookEvent(tr)
+		if event == "" {
+			continue
+		}
+		names := session.WebhooksFor(hooks, event)
+		if len(names) == 0 {
+			continue
+		}
+		payload := session.NewWebhookPayload(event, h.profile, tr.inst, tr.from, now)
+		if tr.kind == sessionStatusChanged {
+			alerts = append(alerts, session.DNDMissedEvent{
+				Source:    session.DNDSourceWebhook,
+				SessionID: tr.inst.ID,
+				Title:     tr.inst.Title,
+				Status:    string(tr.to),
+			})
+		}
+		for _, name := range names {
+			deliveries = append(deliveries, webhookDelivery{name: name, hook: hooks[name], payload: payload})
+		}
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+
+	dndActive := false
+	if len(alerts) > 0 {
+		active, err := session.RecordDNDMissed(alerts...)
+		if err != nil {
+			notifLog.Warn("webhook_dnd_record_failed", slog.String("error", err.Error()))
+		}
+		dndActive = active
+	}
+
+	for _, d := range deliveries {
+		if dndActive && isWebhookAlertEvent(d.payload.Event) {
+			continue
+		}
+		go func(d webhookDelivery) {
+			err := session.SendWebhook(context.Background(), webhookClient, d.hook, d.payload)
+			if err != nil {
+				notifLog.Warn("webhook_failed",
+					slog.String("webhook", d.name),
+					slog.String("event", d.payload.Event),
+					slog.String("error", err.Error()))
+				return
+			}
+			notifLog.Debug("webhook_sent",
+				slog.String("webhook", d.name),
+				slog.String("event", d.payload.Event),
+				slog.String("session", d.payload.Session.ID))
+		}(d)
+	}
+}
+
+func isWebhookAlertEvent(event string) bool {
+	switch event {
+	case session.WebhookEventWaiting, session.WebhookEventIdle, session.WebhookEventError:
+		return true
+	}
+	return false
+}
@@ -4,12 +4,34 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/asheshgoplani/agent-deck/internal/git"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Focusable rows of the finish dialog's options step.
+const (
+	finishFocusMerge = iota
+	finishFocusTarget
+	finishFocusStrategy
+	finishFocusCommit
+	finishFocusKeepBranch
+	finishFocusKeepSession
+)
+
+// worktreeFinishOptions is what the finish dialog hands to Home.finishWorktree.
+type worktreeFinishOptions struct {
+	merge        bool
+	targetBranch string
+	strategy     git.MergeStrategy
+	commitDirty  bool // commit uncommitted changes before merging
+	keepBranch   bool
+	keepSession  bool // re-point the session at the repo root instead of deleting it
+}
+
 // WorktreeFinishDialog handles the two-step worktree finish flow:
-// Step 0: Configure options (merge toggle, target branch, keep branch)
+// Step 0: Configure options (merge toggle, target branch, strategy, commit,
+// keep branch, keep session)
 // Step 1: Confirm the destructive actions
 type WorktreeFinishDialog struct {
 	visible bool
@@ -29,12 +51,15 @@ type WorktreeFinishDialog struct {
 
 	// Options (step 0)
 	mergeEnabled bool
+	strategy     git.MergeStrategy
+	commitDirty  bool
 	keepBranch   bool
+	keepSession  bool
 	targetInput  textinput.Model
 
 	// Dialog state
 	step       int // 0=options, 1=confirm
-	focusIndex int // 0=merge checkbox, 1=target input, 2=keep-branch checkbox
+	focusIndex int // one of the finishFocus* rows
 }
 
 // NewWorktreeFinishDialog creates a new worktree finish dialog
@@ -47,6 +72,8 @@ func NewWorktreeFinishDialog() *WorktreeFinishDialog {
 	return &WorktreeFinishDialog{
 		targetInput:  targetInput,
 		mergeEnabled: true,
+		strategy:     git.MergeStrategyMerge,
+		commitDirty:  true,
 	}
 }
 
@@ -63,7 +90,10 @@ func (d *WorktreeFinishDialog) Show(sessionID, sessionTitle, branchName, repoRoo
 	d.isExecuting = false
 	d.errorMsg = ""
 	d.mergeEnabled = true
+	d.strategy = git.MergeStrategyMerge
+	d.commitDirty = true
 	d.keepBranch = false
+	d.keepSession = false
 	d.step = 0
 	d.focusIndex = 0
 	d.targetInput.SetValue(defaultBranch)
@@ -112,12 +142,52 @@ func (d *WorktreeFinishDialog) GetSessionID() string {
 }
 
 // GetOptions returns the current dialog options
-func (d *WorktreeFinishDialog) GetOptions() (mergeEnabled bool, targetBranch string, keepBranch bool) {
+func (d *WorktreeFinishDialog) GetOptions() worktreeFinishOptions {
+	return worktreeFinishOptions{
+		merge:        d.mergeEnabled,
+		targetBranch: d.target(),
+		strategy:     d.strategy,
+		commitDirty:  d.isDirty && d.commitDirty,
+		keepBranch:   d.keepBranch,
+		keepSession:  d.keepSession,
+	}
+}
+
+func (d *WorktreeFinishDialog) target() string {
 	target := strings.TrimSpace(d.targetInput.Value())
 	if target == "" {
 		target = d.targetInput.Placeholder
 	}
-	return d.mergeEnabled, target, d.keepBranch
+	return target
+}
+
+// focusOrder lists the rows that can currently take focus: the target and
+// strategy only matter when merging, and the commit toggle only when the
+// worktree is dirty.
+func (d *WorktreeFinishDialog) focusOrder() []int {
+	order := []int{finishFocusMerge}
+	if d.mergeEnabled {
+		order = append(order, finishFocusTarget, finishFocusStrategy)
+	}
+	if d.isDirty {
+		order = append(order, finishFocusCommit)
+	}
+	return append(order, finishFocusKeepBranch, finishFocusKeepSession)
+}
+
+// moveFocus advances focus by delta rows, wrapping around.
+func (d *WorktreeFinishDialog) moveFocus(delta int) {
+	order := d.focusOrder()
+	pos := 0
+	for i, f := range order {
+		if f == d.focusIndex {
+			pos = i
+			break
+		}
+	}
+	pos = (pos + delta + len(order)) % len(order)
+	d.focusIndex = order[pos]
+	d.updateFocus()
 }
 
 // HandleKey processes a key event and returns the action to take.
@@ -152,51 +222,38 @@ func (d *WorktreeFinishDialog) HandleKey(key string) (action string) {
 		return "close"
 
 	case "tab", "down":
-		if d.mergeEnabled {
-			d.focusIndex = (d.focusIndex + 1) % 3 // merge, target, keep-branch
-		} else {
-			// Skip target input when merge disabled
-			if d.focusIndex == 0 {
-				d.focusIndex = 2
-			} else {
-				d.focusIndex = 0
-			}
-		}
-		d.updateFocus()
+		d.moveFocus(1)
 		return ""
 
 	case "shift+tab", "up":
-		if d.mergeEnabled {
-			d.focusIndex = (d.focusIndex + 2) % 3
-		} else {
-			if d.focusIndex == 2 {
-				d.focusIndex = 0
-			} else {
-				d.focusIndex = 2
-			}
-		}
-		d.updateFocus()
+		d.moveFocus(-1)
 		return ""
 
-	case " ":
-		// Toggle checkboxes
+	case " ", "left", "right":
+		// Toggle checkboxes / cycle the strategy
 		switch d.focusIndex {
-		case 0:
+		case finishFocusMerge:
 			d.mergeEnabled = !d.mergeEnabled
-			// Tab handler already skips target input when merge is disabled
-		case 2:
+			// focusOrder already skips target/strategy when merge is disabled
+		case finishFocusStrategy:
+			d.strategy = d.strategy.Next()
+		case finishFocusCommit:
+			d.commitDirty = !d.commitDirty
+		case finishFocusKeepBranch:
 			d.keepBranch = !d.keepBranch
+		case finishFocusKeepSession:
+			d.keepSession = !d.keepSession
+		case finishFocusTarget:
+			if key != " " {
+				return "input" // cursor movement within the target input
+			}
 		}
 		return ""
 
 	case "enter":
 		// Validate and advance to confirm step
 		if d.mergeEnabled {
-			target := strings.TrimSpace(d.targetInput.Value())
-			if target == "" {
-				target = d.targetInput.Placeholder
-			}
-			if target == d.branchName {
+			if d.target() == d.branchName {
 				d.errorMsg = fmt.Sprintf("Cannot merge '%s' into itself", d.branchName)
 				return ""
 			}
@@ -207,7 +264,7 @@ func (d *WorktreeFinishDialog) HandleKey(key string) (action string) {
 	}
 
 	// Pass through to target input if focused
-	if d.focusIndex == 1 && d.mergeEnabled {
+	if d.focusIndex == finishFocusTarget && d.mergeEnabled {
 		// Let the caller handle textinput update
 		return "input"
 	}
@@ -217,14 +274,14 @@ func (d *WorktreeFinishDialog) HandleKey(key string) (action string) {
 
 // UpdateTargetInput updates the target branch text input with a message
 func (d *WorktreeFinishDialog) UpdateTargetInput(msg interface{}) {
-	if d.focusIndex == 1 && d.mergeEnabled {
+	if d.focusIndex == finishFocusTarget && d.mergeEnabled {
 		d.targetInput, _ = d.targetInput.Update(msg)
 	}
 }
 
 func (d *WorktreeFinishDialog) updateFocus() {
 	d.targetInput.Blur()
-	if d.focusIndex == 1 && d.mergeEnabled {
+	if d.focusIndex == finishFocusTarget && d.mergeEnabled {
 		d.targetInput.Focus()
 	}
 }
@@ -299,21 +356,24 @@ func (d *WorktreeFinishDialog) viewOptions(titleStyle, labelStyle, valueStyle, c
 	}
 	b.WriteString("\n\n")
 
-	// Merge checkbox
-	mergeCheck := "[ ]"
-	if d.mergeEnabled {
-		mergeCheck = "[x]"
-	}
-	if d.focusIndex == 0 {
-		b.WriteString(checkboxActiveStyle.Render(fmt.Sprintf("▶ %s Merge into target branch", mergeCheck)))
-	} else {
-		b.WriteString(checkboxStyle.Render(fmt.Sprintf("  %s Merge into target branch", mergeCheck)))
+	checkbox := func(focus int, checked bool, label string) {
+		mark := "[ ]"
+		if checked {
+			mark = "[x]"
+		}
+		if d.focusIndex == focus {
+			b.WriteString(checkboxActiveStyle.Render(fmt.Sprintf("▶ %s %s", mark, label)))
+		} else {
+			b.WriteString(checkboxStyle.Render(fmt.Sprintf("  %s %s", mark, label)))
+		}
+		b.WriteString("\n")
 	}
-	b.WriteString("\n")
 
-	// Target input (only when merge enabled)
+	checkbox(finishFocusMerge, d.mergeEnabled, "Merge into target branch")
+
+	// Target input and strategy (only when merge enabled)
 	if d.mergeEnabled {
-		if d.focusIndex == 1 {
+		if d.focusIndex == finishFocusTarget {
 			activeLabelStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
 			b.WriteString(activeLabelStyle.Render("  ▶ Target: "))
 		} else {
@@ -321,19 +381,23 @@ func (d *WorktreeFinishDialog) viewOptions(titleStyle, labelStyle, valueStyle, c
 		}
 		b.WriteString(d.targetInput.View())
 		b.WriteString("\n")
-	}
 
-	// Keep branch checkbox
-	keepCheck := "[ ]"
-	if d.keepBranch {
-		keepCheck = "[x]"
+		if d.focusIndex == finishFocusStrategy {
+			activeLabelStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+			b.WriteString(activeLabelStyle.Render("  ▶ Method: "))
+			b.WriteString(valueStyle.Render("‹ " + string(d.strategy) + " ›"))
+		} else {
+			b.WriteString(labelStyle.Render("    Method: "))
+			b.WriteString(valueStyle.Render(string(d.strategy)))
+		}
+		b.WriteString("\n")
 	}
-	if d.focusIndex == 2 {
-		b.WriteString(checkboxActiveStyle.Render(fmt.Sprintf("▶ %s Keep branch after finish", keepCheck)))
-	} else {
-		b.WriteString(checkboxStyle.Render(fmt.Sprintf("  %s Keep branch after finish", keepCheck)))
+
+	if d.isDirty {
+		checkbox(finishFocusCommit, d.commitDirty, "Commit uncommitted changes")
 	}
-	b.WriteString("\n")
+	checkbox(finishFocusKeepBranch, d.keepBranch, "Keep branch after finish")
+	checkbox(finishFocusKeepSession, d.keepSession, "Keep session (move to repo root)")
 
 	// Error line
 	if d.errorMsg != "" {
@@ -343,7 +407,7 @@ func (d *WorktreeFinishDialog) viewOptions(titleStyle, labelStyle, valueStyle, c
 	}
 
 	b.WriteString("\n")
-	b.WriteString(footerStyle.Render("Tab next | Space toggle | Enter confirm | Esc cancel"))
+	b.WriteString(footerStyle.Render("Tab next | Space toggle/cycle | Enter confirm | Esc cancel"))
 
 	dialog := boxStyle.Render(b.String())
 	return lipgloss.Place(d.width, d.height, lipgloss.Center, lipgloss.Center, dialog)
@@ -376,14 +440,22 @@ func (d *WorktreeFinishDialog) viewConfirm(titleStyle, labelStyle, errStyle, foo
 	b.WriteString(labelStyle.Render("  This will:"))
 	b.WriteString("\n")
 
-	target := strings.TrimSpace(d.targetInput.Value())
-	if target == "" {
-		target = d.targetInput.Placeholder
-	}
+	target := d.target()
 
 	actionStyle := lipgloss.NewStyle().Foreground(ColorText)
+	if d.isDirty && d.commitDirty {
+		b.WriteString(actionStyle.Render("  • Commit uncommitted changes"))
+		b.WriteString("\n")
+	}
 	if d.mergeEnabled {
-		b.WriteString(actionStyle.Render(fmt.Sprintf("  • Merge %s → %s", d.branchName, target)))
+		switch d.strategy {
+		case git.MergeStrategySquash:
+			b.WriteString(actionStyle.Render(fmt.Sprintf("  • Squash %s onto %s", d.branchName, target)))
+		case git.MergeStrategyRebase:
+			b.WriteString(actionStyle.Render(fmt.Sprintf("  • Rebase %s onto %s", d.branchName, target)))
+		default:
+			b.WriteString(actionStyle.Render(fmt.Sprintf("  • Merge %s → %s", d.branchName, target)))
+		}
 		b.WriteString("\n")
 	}
 	b.WriteString(actionStyle.Render("  • Remove worktree directory"))
@@ -392,11 +464,15 @@ func (d *WorktreeFinishDialog) viewConfirm(titleStyle, labelStyle, errStyle, foo
 		b.WriteString(actionStyle.Render(fmt.Sprintf("  • Delete branch %s", d.branchName)))
 		b.WriteString("\n")
 	}
-	b.WriteString(actionStyle.Render("  • Remove session from agent-deck"))
+	if d.keepSession {
+		b.WriteString(actionStyle.Render("  • Keep session, moved to the repo root"))
+	} else {
+		b.WriteString(actionStyle.Render("  • Remove session from agent-deck"))
+	}
 	b.WriteString("\n")
 
 	// Dirty warning
-	if d.isDirty {
+	if d.isDirty && !d.commitDirty {
 		warnStyle := lipgloss.NewStyle().Foreground(ColorYellow).Bold(true)
 		b.WriteString("\n")
 		b.WriteString(warnStyle.Render("  ⚠ Worktree has uncommitted changes!"))
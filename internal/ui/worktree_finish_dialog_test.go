@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+func TestWorktreeFinishDialog_FocusSkipsHiddenRows(t *testing.T) {
+	d := NewWorktreeFinishDialog()
+	d.Show("id", "Feature", "feature", "/repo", "/wt", "main")
+
+	var seen []int
+	for i := 0; i < 5; i++ {
+		seen = append(seen, d.focusIndex)
+		d.HandleKey("tab")
+	}
+	want := []int{finishFocusMerge, finishFocusTarget, finishFocusStrategy, finishFocusKeepBranch, finishFocusKeepSession}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("clean worktree focus order = %v, want %v", seen, want)
+		}
+	}
+	if d.focusIndex != finishFocusMerge {
+		t.Errorf("focus should wrap to merge, got %d", d.focusIndex)
+	}
+
+	// Disabling merge hides target/strategy; a dirty tree adds the commit row.
+	d.SetDirtyStatus(true)
+	d.HandleKey(" ")
+	d.HandleKey("tab")
+	if d.focusIndex != finishFocusCommit {
+		t.Errorf("focus after merge row = %d, want commit row", d.focusIndex)
+	}
+	d.HandleKey("shift+tab")
+	d.HandleKey("shift+tab")
+	if d.focusIndex != finishFocusKeepSession {
+		t.Errorf("shift+tab from merge = %d, want keep-session row", d.focusIndex)
+	}
+}
+
+func TestWorktreeFinishDialog_GetOptions(t *testing.T) {
+	d := NewWorktreeFinishDialog()
+	d.Show("id", "Feature", "feature", "/repo", "/wt", "develop")
+	d.SetDirtyStatus(true)
+
+	d.focusIndex = finishFocusStrategy
+	d.HandleKey(" ")
+	d.focusIndex = finishFocusKeepSession
+	d.HandleKey(" ")
+
+	opts := d.GetOptions()
+	if !opts.merge || opts.targetBranch != "develop" {
+		t.Errorf("merge/target = %v/%q, want true/develop", opts.merge, opts.targetBranch)
+	}
+	if opts.strategy != git.MergeStrategySquash {
+		t.Errorf("strategy = %q, want squash", opts.strategy)
+	}
+	if !opts.commitDirty || !opts.keepSession || opts.keepBranch {
+		t.Errorf("commitDirty/keepSession/keepBranch = %v/%v/%v, want true/true/false", opts.commitDirty, opts.keepSession, opts.keepBranch)
+	}
+
+	// Commit only applies to a dirty tree, and the toggle turns it off.
+	d.focusIndex = finishFocusCommit
+	d.HandleKey(" ")
+	if d.GetOptions().commitDirty {
+		t.Error("commitDirty should be off after toggling")
+	}
+	d.HandleKey(" ")
+	d.SetDirtyStatus(false)
+	if d.GetOptions().commitDirty {
+		t.Error("commitDirty should be false for a clean worktree")
+	}
+}
@@ -11,7 +11,7 @@ import (
 
 // AdapterConfig holds the configuration passed to a WatcherAdapter during Setup.
 type AdapterConfig struct {
-	// Type is the adapter type: "webhook", "ntfy", "github", "slack", "gmail"
+	// Type is the adapter type: "webhook", "ntfy", "github", "github-issues", "slack", "gmail"
 	Type string
 
 	// Name is the watcher name (used for logging and health tracking)
@@ -40,7 +40,7 @@ type WatcherAdapter interface {
 // Event is a normalized event from any watcher adapter.
 // All fields use json tags for persistence and wire format compatibility.
 type Event struct {
-	// Source is the watcher adapter type (e.g., "webhook", "ntfy", "github", "slack", "gmail")
+	// Source is the watcher adapter type (e.g., "webhook", "ntfy", "github", "github-issues", "slack", "gmail")
 	Source string `json:"source"`
 
 	// Sender is the normalized email or identifier of the event originator
@@ -0,0 +1,331 @@
+package watcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// githubIssueMarker tags the pickup comment so a restarted poller can tell
+// an issue was already acknowledged and never comments twice.
+const githubIssueMarker = "<!-- agent-deck:picked-up -->"
+
+// GitHubIssuesAdapter implements WatcherAdapter by polling GitHub for open
+// issues carrying a label (default "agent") in the configured repos. Unlike
+// GitHubAdapter it needs no public webhook endpoint: it talks to the API
+// through the gh CLI, reusing its auth. Each issue becomes one event (dedup
+// key "github-issue:<repo>#<n>"), routed by the sender rules like a webhook
+// issue ("<login>@github.com"), and gets a comment naming the event ID and
+// the conductor session it was routed to.
+type GitHubIssuesAdapter struct {
+	name     string
+	repos    []string
+	label    string
+	interval time.Duration
+	comment  bool
+	webURL   string
+
+	// gh runs the gh CLI; replaced in tests.
+	gh func(ctx context.Context, args ...string) ([]byte, error)
+	// routedSession resolves the session an event from sender lands in;
+	// replaced in tests.
+	routedSession func(sender string) (id, title string)
+
+	mu        sync.Mutex
+	announced map[string]bool // dedup keys commented on (or found commented) this run
+	lastErr   error
+}
+
+// ghIssue is the subset of the REST issue object the poller reads.
+type ghIssue struct {
+	Number      int              `json:"number"`
+	Title       string           `json:"title"`
+	Body        string           `json:"body"`
+	HTMLURL     string           `json:"html_url"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	PullRequest *json.RawMessage `json:"pull_request,omitempty"`
+	User        struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// Setup reads the poller settings from the watcher's [source] table.
+//
+// Settings:
+//   - "repos": required, comma-separated "owner/repo" list
+//   - "label": issue label to pick up (default "agent")
+//   - "interval": poll interval in seconds (default 60, minimum 15)
+//   - "comment": "false" disables the pickup comment (default on)
+//   - "web_url": base URL of the web UI; when set the pickup comment links
+//     the routed session as <web_url>/s/<session id>
+func (a *GitHubIssuesAdapter) Setup(_ context.Context, config AdapterConfig) error {
+	a.name = config.Name
+	a.repos = nil
+	for _, r := range strings.Split(config.Settings["repos"], ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if owner, repo, ok := strings.Cut(r, "/"); !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+			return fmt.Errorf("github-issues adapter: invalid repo %q (want owner/repo)", r)
+		}
+		a.repos = append(a.repos, r)
+	}
+	if len(a.repos) == 0 {
+		return errors.New("github-issues adapter requires Settings[\"repos\"]")
+	}
+
+	a.label = strings.TrimSpace(config.Settings["label"])
+	if a.label == "" {
+		a.label = "agent"
+	}
+
+	a.interval = 60 * time.Second
+	if v := config.Settings["interval"]; v != "" {
+		secs, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("github-issues adapter: invalid interval %q", v)
+		}
+		a.interval = time.Duration(max(secs, 15)) * time.Second
+	}
+	a.comment = config.Settings["comment"] != "false"
+	a.webURL = strings.TrimRight(strings.TrimSpace(config.Settings["web_url"]), "/")
+
+	if a.gh == nil {
+		a.gh = runGH
+	}
+	if a.routedSession == nil {
+		a.routedSession = conductorSessionFor
+	}
+	a.announced = map[string]bool{}
+	return nil
+}
+
+// Listen polls every interval until the context is cancelled. A failed poll
+// is remembered for HealthCheck and retried on the next tick.
+func (a *GitHubIssuesAdapter) Listen(ctx context.Context, events chan<- Event) error {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		err := a.pollOnce(ctx, events)
+		a.mu.Lock()
+		a.lastErr = err
+		a.mu.Unlock()
+		if err != nil {
+			githubLog.Warn("github_issues_poll_failed",
+				"watcher", a.name,
+				"err", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollOnce lists the labeled issues of every repo and emits them.
+func (a *GitHubIssuesAdapter) pollOnce(ctx context.Context, events chan<- Event) error {
+	var errs []error
+	for _, repo := range a.repos {
+		issues, err := a.listIssues(ctx, repo)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, issue := range issues {
+			if issue.PullRequest != nil {
+				continue // the issues endpoint also lists PRs
+			}
+			evt := a.issueEvent(repo, issue)
+			select {
+			case events <- evt:
+			case <-ctx.Done():
+				return nil
+			}
+			if a.comment {
+				if err := a.announce(ctx, repo, issue.Number, evt); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (a *GitHubIssuesAdapter) listIssues(ctx context.Context, repo string) ([]ghIssue, error) {
+	issues, err := ghList[ghIssue](ctx, a.gh, "repos/"+repo+"/issues",
+		"labels="+a.label, "state=open", "per_page=100")
+	if err != nil {
+		return nil, fmt.Errorf("list %s issues: %w", repo, err)
+	}
+	return issues, nil
+}
+
+// ghList GETs every page of a list endpoint. gh --paginate follows the Link
+// header and prints each page as its own JSON array, so the output is a
+// stream of arrays rather than a single document.
+func ghList[T any](ctx context.Context, gh func(context.Context, ...string) ([]byte, error), path string, params ...string) ([]T, error) {
+	args := []string{"api", "--paginate", "-X", "GET", path}
+	for _, p := range params {
+		args = append(args, "-f", p)
+	}
+	out, err := gh(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	var all []T
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var page []T
+		if err := dec.Decode(&page); err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+	}
+	return all, nil
+}
+
+// issueEvent normalizes an issue like normalizeIssuesEvent does a webhook
+// "labeled" delivery, with a stable dedup key so re-polls are no-ops.
+func (a *GitHubIssuesAdapter) issueEvent(repo string, issue ghIssue) Event {
+	body := issue.Body
+	if issue.HTMLURL != "" {
+		body = issue.HTMLURL + "\n\n" + body
+	}
+	return Event{
+		Source:         "github-issues",
+		Sender:         issue.User.Login + "@github.com",
+		Subject:        fmt.Sprintf("[%s] %s#%d: %s", a.label, repo, issue.Number, issue.Title),
+		Body:           body,
+		Timestamp:      issue.UpdatedAt,
+		CustomDedupKey: GitHubIssueDedupKey(repo, issue.Number),
+	}
+}
+
+// GitHubIssueDedupKey is the dedup key (and event ID) of an issue.
+func GitHubIssueDedupKey(repo string, number int) string {
+	return fmt.Sprintf("github-issue:%s#%d", repo, number)
+}
+
+// announce comments the event ID and routed session on the issue, once.
+// Existing comments are checked for githubIssueMarker the first time an
+// issue is seen, so a restart does not comment again.
+func (a *GitHubIssuesAdapter) announce(ctx context.Context, repo string, number int, evt Event) error {
+	key := evt.DedupKey()
+	a.mu.Lock()
+	done := a.announced[key]
+	a.mu.Unlock()
+	if done {
+		return nil
+	}
+
+	path := fmt.Sprintf("repos/%s/issues/%d/comments", repo, number)
+	comments, err := ghList[struct {
+		Body string `json:"body"`
+	}](ctx, a.gh, path, "per_page=100")
+	if err != nil {
+		return fmt.Errorf("read comments of %s#%d: %w", repo, number, err)
+	}
+	for _, c := range comments {
+		if strings.Contains(c.Body, githubIssueMarker) {
+			a.markAnnounced(key)
+			return nil
+		}
+	}
+
+	body := fmt.Sprintf("Picked up by agent-deck (watcher `%s`) as event `%s`.\n", a.name, key)
+	if line := a.sessionLine(evt.Sender); line != "" {
+		body += line + "\n"
+	}
+	body += fmt.Sprintf("Track it with `agent-deck watcher status %s`.\n\n%s", a.name, githubIssueMarker)
+	if _, err := a.gh(ctx, "api", "-X", "POST", path, "-f", "body="+body); err != nil {
+		return fmt.Errorf("comment on %s#%d: %w", repo, number, err)
+	}
+	a.markAnnounced(key)
+	return nil
+}
+
+// sessionLine names the session the event is routed to, linked through the
+// web UI when web_url is set. Empty when no routing rule or conductor
+// session matches; the event then waits for triage.
+func (a *GitHubIssuesAdapter) sessionLine(sender string) string {
+	id, title := a.routedSession(sender)
+	if id == "" {
+		return ""
+	}
+	if a.webURL != "" {
+		return fmt.Sprintf("Session: [%s](%s/s/%s)", title, a.webURL, url.PathEscape(id))
+	}
+	return fmt.Sprintf("Session: `%s` (`agent-deck session show %s`)", title, id)
+}
+
+// conductorSessionFor mirrors the engine's routing: the clients.json rule
+// for sender names a conductor, whose session handles the event.
+func conductorSessionFor(sender string) (id, title string) {
+	router, err := LoadFromWatcherDir()
+	if err != nil || router == nil {
+		return "", ""
+	}
+	route := router.Match(sender)
+	db := statedb.GetGlobal()
+	if route == nil || db == nil {
+		return "", ""
+	}
+	rows, err := db.LoadInstances()
+	if err != nil {
+		return "", ""
+	}
+	title = session.ConductorSessionTitle(route.Conductor)
+	for _, r := range rows {
+		if r.IsConductor && r.Title == title {
+			return r.ID, r.Title
+		}
+	}
+	return "", ""
+}
+
+func (a *GitHubIssuesAdapter) markAnnounced(key string) {
+	a.mu.Lock()
+	a.announced[key] = true
+	a.mu.Unlock()
+}
+
+// Teardown is a no-op. Listen stops on context cancellation.
+func (a *GitHubIssuesAdapter) Teardown() error {
+	return nil
+}
+
+// HealthCheck reports the error of the most recent poll, if any.
+func (a *GitHubIssuesAdapter) HealthCheck() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lastErr
+}
+
+// runGH runs the gh CLI and returns stdout; stderr is folded into the error.
+func runGH(ctx context.Context, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "gh", args...)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("gh %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("gh %s: %w", args[0], err)
+	}
+	return out, nil
+}
@@ -0,0 +1,187 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeGH answers the gh api calls the issues poller makes. List responses
+// are printed the way gh --paginate prints them: one JSON array per page
+// of pageSize items, back to back.
+type fakeGH struct {
+	pageSize int
+	mu       sync.Mutex
+	issues   map[string][]map[string]any // repo → issues
+	comments map[string][]string         // "repo#n" comments path → bodies
+	posts    []string
+}
+
+func (f *fakeGH) run(_ context.Context, args ...string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if args[1] == "--paginate" {
+		args = append(args[:1], args[2:]...)
+	}
+	path, method := args[3], args[2]
+	switch {
+	case strings.HasSuffix(path, "/issues"):
+		repo := strings.TrimSuffix(strings.TrimPrefix(path, "repos/"), "/issues")
+		return f.pages(f.issues[repo])
+	case strings.HasSuffix(path, "/comments") && method == "GET":
+		var out []map[string]string
+		for _, b := range f.comments[path] {
+			out = append(out, map[string]string{"body": b})
+		}
+		return f.pages(out)
+	case strings.HasSuffix(path, "/comments"):
+		body := strings.TrimPrefix(args[5], "body=")
+		f.comments[path] = append(f.comments[path], body)
+		f.posts = append(f.posts, path)
+		return []byte("{}"), nil
+	}
+	return nil, nil
+}
+
+func (f *fakeGH) pages(items any) ([]byte, error) {
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var all []json.RawMessage
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, err
+	}
+	size := f.pageSize
+	if size == 0 {
+		size = 100
+	}
+	out := []byte("[]\n")
+	if len(all) > 0 {
+		out = nil
+	}
+	for start := 0; start < len(all); start += size {
+		page, err := json.Marshal(all[start:min(start+size, len(all))])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, page...)
+		out = append(out, '\n')
+	}
+	return out, nil
+}
+
+func newIssuesAdapter(t *testing.T, f *fakeGH, settings map[string]string) *GitHubIssuesAdapter {
+	t.Helper()
+	a := &GitHubIssuesAdapter{gh: f.run, routedSession: func(string) (string, string) { return "", "" }}
+	if err := a.Setup(context.Background(), AdapterConfig{Type: "github-issues", Name: "gh-issues", Settings: settings}); err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestGitHubIssuesAdapter_Setup(t *testing.T) {
+	a := &GitHubIssuesAdapter{}
+	if err := a.Setup(context.Background(), AdapterConfig{Settings: map[string]string{}}); err == nil {
+		t.Error("Setup without repos should fail")
+	}
+	if err := a.Setup(context.Background(), AdapterConfig{Settings: map[string]string{"repos": "not-a-repo"}}); err == nil {
+		t.Error("Setup with a malformed repo should fail")
+	}
+	if err := a.Setup(context.Background(), AdapterConfig{Settings: map[string]string{"repos": "o/a, o/b", "interval": "5"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(a.repos) != 2 || a.label != "agent" || a.interval.Seconds() != 15 || !a.comment {
+		t.Errorf("settings = repos %v label %q interval %v comment %v", a.repos, a.label, a.interval, a.comment)
+	}
+}
+
+func TestGitHubIssuesAdapter_PollEmitsAndCommentsOnce(t *testing.T) {
+	f := &fakeGH{
+		issues: map[string][]map[string]any{
+			"acme/api": {
+				{"number": 7, "title": "Fix login", "body": "steps", "html_url": "https://github.com/acme/api/issues/7",
+					"updated_at": "2026-10-01T10:00:00Z", "user": map[string]string{"login": "alice"}},
+				{"number": 8, "title": "A PR", "pull_request": map[string]string{}, "updated_at": "2026-10-01T10:00:00Z",
+					"user": map[string]string{"login": "bob"}},
+			},
+		},
+		comments: map[string][]string{},
+	}
+	a := newIssuesAdapter(t, f, map[string]string{"repos": "acme/api"})
+
+	events := make(chan Event, 10)
+	if err := a.pollOnce(context.Background(), events); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("events = %d, want 1 (pull requests skipped)", len(events))
+	}
+	evt := <-events
+	if evt.Source != "github-issues" || evt.Sender != "alice@github.com" ||
+		evt.DedupKey() != "github-issue:acme/api#7" || !strings.Contains(evt.Subject, "acme/api#7: Fix login") {
+		t.Errorf("event = %+v", evt)
+	}
+	if len(f.posts) != 1 || !strings.Contains(f.comments[f.posts[0]][0], "github-issue:acme/api#7") {
+		t.Errorf("pickup comment = %v %v", f.posts, f.comments)
+	}
+
+	// Re-polling re-emits (the engine dedups) but does not comment again,
+	// including from a fresh adapter that finds the marker comment.
+	if err := a.pollOnce(context.Background(), events); err != nil {
+		t.Fatal(err)
+	}
+	fresh := newIssuesAdapter(t, f, map[string]string{"repos": "acme/api"})
+	if err := fresh.pollOnce(context.Background(), events); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.posts) != 1 {
+		t.Errorf("comments posted = %d, want 1", len(f.posts))
+	}
+}
+
+func TestGitHubIssuesAdapter_CommentDisabled(t *testing.T) {
+	f := &fakeGH{
+		issues: map[string][]map[string]any{
+			"acme/api": {{"number": 1, "title": "x", "updated_at": "2026-10-01T10:00:00Z", "user": map[string]string{"login": "a"}}},
+		},
+		comments: map[string][]string{},
+	}
+	a := newIssuesAdapter(t, f, map[string]string{"repos": "acme/api", "comment": "false"})
+	if err := a.pollOnce(context.Background(), make(chan Event, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.posts) != 0 {
+		t.Errorf("comment posted with comment=false: %v", f.posts)
+	}
+}
+
+func TestGitHubIssuesAdapter_PaginatesAndLinksSession(t *testing.T) {
+	var issues []map[string]any
+	for n := 1; n <= 5; n++ {
+		issues = append(issues, map[string]any{"number": n, "title": "t", "updated_at": "2026-10-01T10:00:00Z",
+			"user": map[string]string{"login": "alice"}})
+	}
+	f := &fakeGH{pageSize: 2, issues: map[string][]map[string]any{"acme/api": issues}, comments: map[string][]string{}}
+	a := newIssuesAdapter(t, f, map[string]string{"repos": "acme/api", "web_url": "http://deck.local:8420/"})
+	a.routedSession = func(sender string) (string, string) {
+		if sender != "alice@github.com" {
+			t.Errorf("routed sender = %q", sender)
+		}
+		return "abc123", "conductor-acme"
+	}
+
+	events := make(chan Event, 10)
+	if err := a.pollOnce(context.Background(), events); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 5 {
+		t.Fatalf("events = %d, want 5 across 3 pages", len(events))
+	}
+	body := f.comments["repos/acme/api/issues/5/comments"]
+	if len(body) != 1 || !strings.Contains(body[0], "[conductor-acme](http://deck.local:8420/s/abc123)") {
+		t.Errorf("pickup comment = %q, want session link", body)
+	}
+}
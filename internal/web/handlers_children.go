@@ -42,7 +42,7 @@ func (s *Server) handleSessionChildren(w http.ResponseWriter, r *http.Request, s
 		return
 	}
 
-	snapshot, err := s.menuData.LoadMenuSnapshot()
+	snapshot, err := s.loadMenuSnapshot()
 	if err != nil || snapshot == nil {
 		writeAPIError(w, http.StatusInternalServerError, ErrCodeInternalError, "failed to load session data")
 		return
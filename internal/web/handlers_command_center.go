@@ -254,7 +254,7 @@ func (s *Server) handleCommandCenterEvents(w http.ResponseWriter, r *http.Reques
 // with the latest per-session statuses and RecentlyCompleted is populated from
 // the running→done/waiting diff.
 func (s *Server) loadCommandCenterSnapshot(tracker ccStatusTracker) (*CommandCenterSnapshot, error) {
-	menu, err := s.menuData.LoadMenuSnapshot()
+	menu, err := s.loadMenuSnapshot()
 	if err != nil {
 		return nil, err
 	}
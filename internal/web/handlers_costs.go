@@ -134,8 +134,12 @@ func (s *Server) handleCostsSessions(w http.ResponseWriter, r *http.Request) {
 		Events    int     `json:"events"`
 	}
 
+	private := s.privateGroupPaths()
 	result := make([]sessionEntry, 0, len(sessions))
 	for _, sc := range sessions {
+		if isPrivateGroupPath(sc.Group, private) {
+			continue
+		}
 		result = append(result, sessionEntry{
 			SessionID: sc.SessionID,
 			Title:     sc.SessionTitle,
@@ -426,8 +430,12 @@ func (s *Server) handleCostsGroups(w http.ResponseWriter, r *http.Request) {
 		Sessions int     `json:"sessions"`
 	}
 
+	private := s.privateGroupPaths()
 	groups := make(map[string]*groupEntry)
 	for _, sc := range sessions {
+		if isPrivateGroupPath(sc.Group, private) {
+			continue
+		}
 		g := sc.Group
 		if g == "" {
 			g = "(ungrouped)"
@@ -469,6 +477,9 @@ func (s *Server) handleCostsSessionDetail(w http.ResponseWriter, r *http.Request
 		writeAPIError(w, http.StatusBadRequest, "INVALID_REQUEST", "missing id parameter")
 		return
 	}
+	if s.rejectPrivateSession(w, sessionID) {
+		return
+	}
 
 	summary, err := s.costStore.TotalBySession(sessionID)
 	if err != nil {
@@ -593,10 +604,11 @@ func (s *Server) computeBatchCosts(ids []string) map[string]float64 {
 	if len(ids) > maxBatch {
 		ids = ids[:maxBatch]
 	}
+	hidden := s.privateSessionIDs()
 	sessionCosts := make(map[string]float64, len(ids))
 	for _, id := range ids {
 		id = strings.TrimSpace(id)
-		if id == "" {
+		if id == "" || hidden[id] {
 			continue
 		}
 		summary, err := s.costStore.TotalBySession(id)
@@ -33,7 +33,7 @@ func (s *Server) handleMenuEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	snapshot, err := s.menuData.LoadMenuSnapshot()
+	snapshot, err := s.loadMenuSnapshot()
 	if err != nil {
 		writeAPIError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to load menu data")
 		return
@@ -65,7 +65,7 @@ func (s *Server) handleMenuEvents(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 	emitIfChanged := func() error {
-		nextSnapshot, err := s.menuData.LoadMenuSnapshot()
+		nextSnapshot, err := s.loadMenuSnapshot()
 		if err != nil {
 			logging.ForComponent(logging.CompWeb).Error("menu_stream_refresh_failed",
 				slog.String("error", err.Error()))
@@ -20,7 +20,7 @@ func (s *Server) handleGroupsCollection(w http.ResponseWriter, r *http.Request)
 
 	switch r.Method {
 	case http.MethodGet:
-		snapshot, err := s.menuData.LoadMenuSnapshot()
+		snapshot, err := s.loadMenuSnapshot()
 		if err != nil {
 			writeAPIError(w, http.StatusInternalServerError, ErrCodeInternalError, "failed to load group data")
 			return
@@ -80,6 +80,10 @@ func (s *Server) handleGroupByPath(w http.ResponseWriter, r *http.Request) {
 		writeAPIError(w, http.StatusBadRequest, ErrCodeBadRequest, "group path is required")
 		return
 	}
+	if isPrivateGroupPath(groupPath, s.privateGroupPaths()) {
+		writeAPIError(w, http.StatusNotFound, ErrCodeNotFound, "group not found")
+		return
+	}
 
 	switch r.Method {
 	case http.MethodPatch:
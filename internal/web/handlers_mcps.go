@@ -119,6 +119,9 @@ func (s *Server) handleSessionMCPs(w http.ResponseWriter, r *http.Request, sessi
 		writeAPIError(w, http.StatusBadRequest, ErrCodeBadRequest, "session id is required")
 		return
 	}
+	if s.rejectPrivateSession(w, sessionID) {
+		return
+	}
 	projectPath, ok := s.lookupSessionProjectPath(sessionID)
 	if !ok {
 		writeAPIError(w, http.StatusNotFound, ErrCodeNotFound, "session not found")
@@ -260,7 +263,7 @@ func (s *Server) lookupSessionProjectPath(sessionID string) (string, bool) {
 	if s.menuData == nil {
 		return "", false
 	}
-	snap, err := s.menuData.LoadMenuSnapshot()
+	snap, err := s.loadMenuSnapshot()
 	if err != nil || snap == nil {
 		return "", false
 	}
@@ -25,7 +25,7 @@ func (s *Server) handleSessionsCollection(w http.ResponseWriter, r *http.Request
 
 	switch r.Method {
 	case http.MethodGet:
-		snapshot, err := s.menuData.LoadMenuSnapshot()
+		snapshot, err := s.loadMenuSnapshot()
 		if err != nil {
 			writeAPIError(w, http.StatusInternalServerError, ErrCodeInternalError, "failed to load session data")
 			return
@@ -100,6 +100,9 @@ func (s *Server) handleSessionByAction(w http.ResponseWriter, r *http.Request) {
 		writeAPIError(w, http.StatusBadRequest, ErrCodeBadRequest, "session id is required")
 		return
 	}
+	if s.rejectPrivateSession(w, sessionID) {
+		return
+	}
 
 	action := ""
 	if len(parts) == 2 {
@@ -420,7 +423,11 @@ func (s *Server) loadArchivedMenuSnapshot() (*MenuSnapshot, error) {
 	if loader, ok := s.menuData.(interface {
 		LoadArchivedMenuSnapshot() (*MenuSnapshot, error)
 	}); ok {
-		return loader.LoadArchivedMenuSnapshot()
+		snapshot, err := loader.LoadArchivedMenuSnapshot()
+		if err != nil {
+			return nil, err
+		}
+		return filterPrivateGroups(snapshot, s.privateGroupPaths()), nil
 	}
 	return nil, fmt.Errorf("archived session list is not available")
 }
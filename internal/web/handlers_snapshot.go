@@ -0,0 +1,97 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+const (
+	// snapshotPreviewLines is how much of each pane the offline bundle keeps:
+	// enough to see what a session was last doing, small enough that a fleet
+	// of sessions stays a few hundred KB in the service-worker cache.
+	snapshotPreviewLines = 40
+	// snapshotMaxPreviews bounds the per-request capture work.
+	snapshotMaxPreviews = 50
+	// snapshotCaptureTimeout bounds all pane captures for one bundle.
+	snapshotCaptureTimeout = 3 * time.Second
+)
+
+// OfflineSnapshot is the GET /api/snapshot bundle. The service worker caches
+// the latest one so the PWA can render the last known deck state (menu,
+// statuses, pane previews) when the server is unreachable, marked stale by
+// GeneratedAt.
+type OfflineSnapshot struct {
+	GeneratedAt time.Time         `json:"generatedAt"`
+	Menu        *MenuSnapshot     `json:"menu"`
+	Previews    map[string]string `json:"previews,omitempty"`
+}
+
+func (s *Server) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+	if !s.authorizeRequest(r) {
+		writeAPIError(w, http.StatusUnauthorized, "UNAUTHORIZED", "unauthorized")
+		return
+	}
+
+	menu, err := s.loadMenuSnapshot()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to load menu data")
+		return
+	}
+	refreshSnapshotHookStatuses(menu, s.hookStatusLoader)
+
+	ctx, cancel := context.WithTimeout(r.Context(), snapshotCaptureTimeout)
+	defer cancel()
+
+	snap := OfflineSnapshot{
+		GeneratedAt: time.Now().UTC(),
+		Menu:        menu,
+		Previews:    make(map[string]string),
+	}
+	for _, item := range menu.Items {
+		if len(snap.Previews) >= snapshotMaxPreviews || ctx.Err() != nil {
+			break
+		}
+		ms := item.Session
+		if ms == nil || ms.TmuxSession == "" || !ms.ArchivedAt.IsZero() || ms.Status == session.StatusStopped {
+			continue
+		}
+		if text, err := s.previewLoader(ctx, ms); err == nil && text != "" {
+			snap.Previews[ms.ID] = text
+		}
+	}
+
+	// The bundle is per-user state: never let a shared cache keep it, and
+	// make the browser revalidate so the service worker sees every refresh.
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("X-Agentdeck-Snapshot-Generated", snap.GeneratedAt.Format(time.RFC3339))
+	writeJSON(w, http.StatusOK, snap)
+}
+
+// capturePreviewTail returns the last snapshotPreviewLines of the session's
+// pane as plain text (no ANSI), with trailing blank lines dropped.
+func capturePreviewTail(ctx context.Context, ms *MenuSession) (string, error) {
+	out, err := tmux.ExecContext(ctx, ms.TmuxSocketName,
+		"capture-pane", "-p", "-J", "-t", ms.TmuxSession, "-S", "-200").Output()
+	if err != nil {
+		return "", err
+	}
+	return tailLines(string(out), snapshotPreviewLines), nil
+}
+
+// tailLines keeps the last n lines of text after trimming trailing blanks.
+func tailLines(text string, n int) string {
+	lines := strings.Split(strings.TrimRight(text, " \t\r\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
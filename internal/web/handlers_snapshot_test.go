@@ -0,0 +1,71 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestHandleSnapshot_BundlesMenuAndPreviews(t *testing.T) {
+	srv := NewServer(Config{ListenAddr: "127.0.0.1:0"})
+	srv.hookStatusLoader = func() map[string]*session.HookStatus { return nil }
+	srv.menuData = &fakeMenuDataLoader{snapshot: &MenuSnapshot{
+		Profile: "default",
+		Items: []MenuItem{
+			{Type: "session", Session: &MenuSession{ID: "live", Title: "api", Status: session.StatusWaiting, TmuxSession: "agentdeck_api"}},
+			{Type: "session", Session: &MenuSession{ID: "stopped", Title: "old", Status: session.StatusStopped, TmuxSession: "agentdeck_old"}},
+			{Type: "session", Session: &MenuSession{ID: "archived", Title: "gone", Status: session.StatusIdle, TmuxSession: "agentdeck_gone", ArchivedAt: time.Now()}},
+		},
+	}}
+	var captured []string
+	srv.previewLoader = func(_ context.Context, ms *MenuSession) (string, error) {
+		captured = append(captured, ms.ID)
+		return "last line from " + ms.Title, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/snapshot", nil)
+	rr := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rr.Code, rr.Body.String())
+	}
+	if cc := rr.Header().Get("Cache-Control"); !strings.Contains(cc, "private") {
+		t.Fatalf("Cache-Control = %q, want private", cc)
+	}
+	var snap OfflineSnapshot
+	if err := json.Unmarshal(rr.Body.Bytes(), &snap); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if snap.Menu == nil || len(snap.Menu.Items) != 3 {
+		t.Fatalf("menu not bundled: %+v", snap.Menu)
+	}
+	if len(captured) != 1 || captured[0] != "live" {
+		t.Fatalf("captured %v, want only the live session", captured)
+	}
+	if snap.Previews["live"] != "last line from api" {
+		t.Fatalf("previews = %v", snap.Previews)
+	}
+}
+
+func TestHandleSnapshot_Unauthorized(t *testing.T) {
+	srv := NewServer(Config{ListenAddr: "127.0.0.1:0", Token: "secret"})
+	req := httptest.NewRequest(http.MethodGet, "/api/snapshot", nil)
+	rr := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", rr.Code)
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	if got := tailLines("a\nb\nc\n\n\n", 2); got != "b\nc" {
+		t.Fatalf("tailLines = %q", got)
+	}
+}
@@ -53,6 +53,18 @@ func allowWSOrigin(r *http.Request) bool {
 }
 
 func (s *Server) handleSessionWS(w http.ResponseWriter, r *http.Request) {
+	const prefix = "/ws/session/"
+	s.serveSessionWS(w, r, strings.TrimPrefix(r.URL.Path, prefix))
+}
+
+// handleSessionAttachWS serves /api/sessions/{id}/attach: the same interactive
+// tmux PTY WebSocket as /ws/session/{id}, addressed like the rest of the
+// session REST API so API clients don't need a second URL scheme.
+func (s *Server) handleSessionAttachWS(w http.ResponseWriter, r *http.Request) {
+	s.serveSessionWS(w, r, r.PathValue("id"))
+}
+
+func (s *Server) serveSessionWS(w http.ResponseWriter, r *http.Request, sessionID string) {
 	if r.Method != http.MethodGet {
 		writeAPIError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
@@ -63,14 +75,12 @@ func (s *Server) handleSessionWS(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	const prefix = "/ws/session/"
-	sessionID := strings.TrimPrefix(r.URL.Path, prefix)
 	if sessionID == "" || strings.Contains(sessionID, "/") {
 		writeAPIError(w, http.StatusBadRequest, "INVALID_REQUEST", "session id is required")
 		return
 	}
 
-	snapshot, err := s.menuData.LoadMenuSnapshot()
+	snapshot, err := s.loadMenuSnapshot()
 	if err != nil {
 		writeAPIError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to load session data")
 		return
@@ -419,3 +419,53 @@ func expectWSStatusEvent(t *testing.T, conn *websocket.Conn, event string) {
 		t.Fatalf("expected status=%q message, got: %+v", event, msg)
 	}
 }
+
+func TestWSAttachEndpointSharesSessionSocket(t *testing.T) {
+	srv := NewServer(Config{
+		ListenAddr: "127.0.0.1:0",
+		Profile:    "work",
+	})
+	srv.menuData = &fakeMenuDataLoader{
+		snapshot: &MenuSnapshot{
+			Profile: "work",
+			Items: []MenuItem{
+				{
+					Type: MenuItemTypeSession,
+					Session: &MenuSession{
+						ID: "sess-attach",
+					},
+				},
+			},
+		},
+	}
+
+	testServer := httptest.NewServer(srv.Handler())
+	defer testServer.Close()
+
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL(testServer.URL, "/api/sessions/sess-attach/attach"), nil)
+	if err != nil {
+		if resp != nil {
+			t.Fatalf("dial failed with status %d: %v", resp.StatusCode, err)
+		}
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	var msg wsServerMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("failed to read first ws message: %v", err)
+	}
+	if msg.Type != "status" || msg.Event != "connected" || msg.SessionID != "sess-attach" {
+		t.Fatalf("unexpected first ws message: %+v", msg)
+	}
+
+	_, resp, err = websocket.DefaultDialer.Dial(wsURL(testServer.URL, "/api/sessions/sess-missing/attach"), nil)
+	if err == nil {
+		t.Fatal("expected websocket dial error for unknown session")
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown session, got %+v", resp)
+	}
+}
@@ -0,0 +1,146 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// Private groups ([groups."<path>"] web_private = true) are invisible to the
+// web server. Every read path goes through loadMenuSnapshot, which drops the
+// private subtree before a handler sees it, so menu/sessions/groups/SSE,
+// /api/snapshot, the command center and push notifications all agree.
+// Endpoints addressed by session ID additionally call rejectPrivateSession so
+// a guessed or previously-seen ID cannot reach a hidden session.
+
+// defaultPrivateGroups reads the private group list from config.toml. The
+// user config is mtime-cached, so toggling web_private takes effect on the
+// next request without restarting the server.
+func defaultPrivateGroups() []string {
+	cfg, err := session.LoadUserConfig()
+	if err != nil || cfg == nil {
+		return nil
+	}
+	return cfg.WebPrivateGroups()
+}
+
+// isPrivateGroupPath reports whether groupPath equals or sits under one of
+// the private group paths.
+func isPrivateGroupPath(groupPath string, private []string) bool {
+	if groupPath == "" {
+		return false
+	}
+	for _, p := range private {
+		if groupPath == p || strings.HasPrefix(groupPath, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// filterPrivateGroups returns snapshot without private groups and their
+// sessions. The input is never mutated (MemoryMenuData hands out shared
+// clones); when nothing is private the original pointer is returned.
+func filterPrivateGroups(snapshot *MenuSnapshot, private []string) *MenuSnapshot {
+	if snapshot == nil || len(private) == 0 {
+		return snapshot
+	}
+
+	filtered := *snapshot
+	filtered.Items = make([]MenuItem, 0, len(snapshot.Items))
+	filtered.TotalGroups = 0
+	filtered.TotalSessions = 0
+	for _, item := range snapshot.Items {
+		switch {
+		case item.Type == MenuItemTypeGroup && item.Group != nil:
+			if isPrivateGroupPath(item.Group.Path, private) {
+				continue
+			}
+			filtered.TotalGroups++
+		case item.Type == MenuItemTypeSession && item.Session != nil:
+			if isPrivateGroupPath(item.Session.GroupPath, private) {
+				continue
+			}
+			filtered.TotalSessions++
+		}
+		item.Index = len(filtered.Items)
+		filtered.Items = append(filtered.Items, item)
+	}
+	return &filtered
+}
+
+// privateGroupPaths returns the current private group paths, or nil when the
+// server was built without a source (bare &Server{} in tests).
+func (s *Server) privateGroupPaths() []string {
+	if s.privateGroups == nil {
+		return nil
+	}
+	return s.privateGroups()
+}
+
+// loadMenuSnapshot is the web-facing menu read: the configured loader's
+// snapshot with private groups removed. Handlers must use this rather than
+// s.menuData.LoadMenuSnapshot directly.
+func (s *Server) loadMenuSnapshot() (*MenuSnapshot, error) {
+	snapshot, err := s.menuData.LoadMenuSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return filterPrivateGroups(snapshot, s.privateGroupPaths()), nil
+}
+
+// privateSessionIDs returns the IDs of every session in a private group, or
+// nil when no group is private.
+func (s *Server) privateSessionIDs() map[string]bool {
+	private := s.privateGroupPaths()
+	if len(private) == 0 || s.menuData == nil {
+		return nil
+	}
+	ids := make(map[string]bool)
+	collect := func(snapshot *MenuSnapshot) {
+		if snapshot == nil {
+			return
+		}
+		for _, item := range snapshot.Items {
+			if item.Session != nil && isPrivateGroupPath(item.Session.GroupPath, private) {
+				ids[item.Session.ID] = true
+			}
+		}
+	}
+	if snapshot, err := s.menuData.LoadMenuSnapshot(); err == nil {
+		collect(snapshot)
+	}
+	// Archived sessions are addressable by ID too (undelete, restore).
+	if loader, ok := s.menuData.(interface {
+		LoadArchivedMenuSnapshot() (*MenuSnapshot, error)
+	}); ok {
+		if snapshot, err := loader.LoadArchivedMenuSnapshot(); err == nil {
+			collect(snapshot)
+		}
+	}
+	return ids
+}
+
+// isPrivateSession reports whether sessionID belongs to a private group.
+// Unknown IDs are not private: the handler's own lookup reports them.
+func (s *Server) isPrivateSession(sessionID string) bool {
+	return sessionID != "" && s.privateSessionIDs()[sessionID]
+}
+
+// rejectPrivateSession writes a 404 and returns true when sessionID is in a
+// private group. 404 (not 403) so the response does not confirm the session
+// exists.
+func (s *Server) rejectPrivateSession(w http.ResponseWriter, sessionID string) bool {
+	if !s.isPrivateSession(sessionID) {
+		return false
+	}
+	writeAPIError(w, http.StatusNotFound, ErrCodeNotFound, "session not found")
+	return true
+}
+
+// menuLoaderFunc adapts a function to MenuDataLoader so components built at
+// startup (push) read through s.loadMenuSnapshot.
+type menuLoaderFunc func() (*MenuSnapshot, error)
+
+func (f menuLoaderFunc) LoadMenuSnapshot() (*MenuSnapshot, error) { return f() }
@@ -0,0 +1,83 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func privateGroupsTestServer(t *testing.T) *Server {
+	t.Helper()
+	srv := NewServer(Config{ListenAddr: "127.0.0.1:0", Profile: "test-profile"})
+	srv.menuData = &fakeMenuDataLoader{
+		snapshot: &MenuSnapshot{
+			Profile:       "test-profile",
+			TotalGroups:   3,
+			TotalSessions: 3,
+			Items: []MenuItem{
+				{Type: MenuItemTypeGroup, Path: "work", Group: &MenuGroup{Name: "work", Path: "work"}},
+				{Type: MenuItemTypeSession, Level: 1, Session: &MenuSession{ID: "sess-work", Title: "client demo", GroupPath: "work"}},
+				{Type: MenuItemTypeGroup, Path: "personal", Group: &MenuGroup{Name: "personal", Path: "personal"}},
+				{Type: MenuItemTypeSession, Level: 1, Session: &MenuSession{ID: "sess-personal", Title: "tax return", GroupPath: "personal"}},
+				{Type: MenuItemTypeGroup, Path: "personal/health", Group: &MenuGroup{Name: "health", Path: "personal/health"}},
+				{Type: MenuItemTypeSession, Level: 2, Session: &MenuSession{ID: "sess-health", Title: "doctor notes", GroupPath: "personal/health"}},
+			},
+		},
+	}
+	srv.privateGroups = func() []string { return []string{"personal"} }
+	return srv
+}
+
+func TestPrivateGroups_HiddenFromMenuAndSessions(t *testing.T) {
+	srv := privateGroupsTestServer(t)
+
+	for _, path := range []string{"/api/menu", "/api/sessions", "/api/groups"} {
+		rr := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: status %d", path, rr.Code)
+		}
+		body := rr.Body.String()
+		for _, leak := range []string{"sess-personal", "tax return", "sess-health", `"personal`} {
+			if strings.Contains(body, leak) {
+				t.Fatalf("%s leaked %q: %s", path, leak, body)
+			}
+		}
+		if path != "/api/groups" && !strings.Contains(body, "sess-work") {
+			t.Fatalf("%s dropped the public session: %s", path, body)
+		}
+	}
+}
+
+func TestPrivateGroups_IDAddressedEndpointsReturn404(t *testing.T) {
+	srv := privateGroupsTestServer(t)
+
+	for _, path := range []string{"/api/session/sess-health", "/api/sessions/sess-personal/children"} {
+		rr := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("%s: status %d, want 404 (body %s)", path, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestFilterPrivateGroups_RenumbersAndCounts(t *testing.T) {
+	srv := privateGroupsTestServer(t)
+	snap, err := srv.loadMenuSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.TotalGroups != 1 || snap.TotalSessions != 1 || len(snap.Items) != 2 {
+		t.Fatalf("filtered snapshot = %+v", snap)
+	}
+	for i, item := range snap.Items {
+		if item.Index != i {
+			t.Fatalf("item %d has index %d", i, item.Index)
+		}
+	}
+	// The loader's snapshot must not be mutated.
+	if orig, _ := srv.menuData.LoadMenuSnapshot(); len(orig.Items) != 6 {
+		t.Fatalf("source snapshot mutated: %d items", len(orig.Items))
+	}
+}
@@ -344,6 +344,9 @@ type pushService struct {
 	menuData MenuDataLoader
 	store    pushSubscriptionStore
 	sender   webPushSender
+	// privateGroups returns the web_private group paths; their sessions
+	// never produce a notification. nil means nothing is private.
+	privateGroups func() []string
 
 	pollInterval time.Duration
 	testEvery    time.Duration
@@ -356,7 +359,7 @@ type pushService struct {
 	lastStatus  map[string]string
 }
 
-func newPushService(cfg Config, menuData MenuDataLoader) (pushServiceAPI, error) {
+func newPushService(cfg Config, menuData MenuDataLoader, privateGroups func() []string) (pushServiceAPI, error) {
 	publicKey := strings.TrimSpace(cfg.PushVAPIDPublicKey)
 	privateKey := strings.TrimSpace(cfg.PushVAPIDPrivateKey)
 
@@ -378,19 +381,20 @@ func newPushService(cfg Config, menuData MenuDataLoader) (pushServiceAPI, error)
 	}
 
 	return &pushService{
-		enabled:      true,
-		publicKey:    publicKey,
-		privateKey:   privateKey,
-		subject:      subject,
-		profile:      session.GetEffectiveProfile(cfg.Profile),
-		token:        strings.TrimSpace(cfg.Token),
-		menuData:     menuData,
-		store:        store,
-		sender:       &vapidPushSender{subject: subject, publicKey: publicKey, privateKey: privateKey},
-		pollInterval: defaultPushPollInterval,
-		testEvery:    cfg.PushTestInterval,
-		triggerCh:    make(chan struct{}, 1),
-		lastStatus:   make(map[string]string),
+		enabled:       true,
+		publicKey:     publicKey,
+		privateKey:    privateKey,
+		subject:       subject,
+		profile:       session.GetEffectiveProfile(cfg.Profile),
+		token:         strings.TrimSpace(cfg.Token),
+		menuData:      menuData,
+		store:         store,
+		sender:        &vapidPushSender{subject: subject, publicKey: publicKey, privateKey: privateKey},
+		privateGroups: privateGroups,
+		pollInterval:  defaultPushPollInterval,
+		testEvery:     cfg.PushTestInterval,
+		triggerCh:     make(chan struct{}, 1),
+		lastStatus:    make(map[string]string),
 	}, nil
 }
 
@@ -497,6 +501,12 @@ func (p *pushService) syncOnce(ctx context.Context) {
 		pushLog.Error("push_snapshot_load_failed", slog.String("error", err.Error()))
 		return
 	}
+	// Filter here as well as in the loader: a notification is an outbound
+	// copy of the session title, so private groups must never reach it
+	// whatever MenuDataLoader the service was built with.
+	if p.privateGroups != nil {
+		snapshot = filterPrivateGroups(snapshot, p.privateGroups())
+	}
 
 	current := make(map[string]string)
 	sessions := make(map[string]*MenuSession)
@@ -547,11 +557,37 @@ func (p *pushService) syncOnce(ctx context.Context) {
 	p.lastStatus = current
 	p.mu.Unlock()
 
+	if len(transitions) > 0 && p.suppressForDND(transitions) {
+		return
+	}
+
 	for _, tr := range transitions {
 		p.notifySubscribers(ctx, tr)
 	}
 }
 
+// suppressForDND records transitions into the DND digest and reports whether
+// deck-wide do-not-disturb is active, in which case no push is sent.
+func (p *pushService) suppressForDND(transitions []pushTransition) bool {
+	missed := make([]session.DNDMissedEvent, 0, len(transitions))
+	for _, tr := range transitions {
+		missed = append(missed, session.DNDMissedEvent{
+			Source:    session.DNDSourcePush,
+			SessionID: tr.Session.ID,
+			Title:     tr.Session.Title,
+			Status:    tr.Status,
+		})
+	}
+	active, err := session.RecordDNDMissed(missed...)
+	if err != nil {
+		pushLog.Warn("push_dnd_record_failed", slog.String("error", err.Error()))
+	}
+	if active {
+		pushLog.Debug("push_suppressed_dnd", slog.Int("transitions", len(transitions)))
+	}
+	return active
+}
+
 func (p *pushService) notifySubscribers(ctx context.Context, tr pushTransition) {
 	if p == nil || p.store == nil || p.sender == nil || tr.Session == nil {
 		return
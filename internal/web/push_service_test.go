@@ -7,6 +7,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
 type fakePushStore struct {
@@ -566,3 +568,49 @@ func TestPushServiceSkipsWhenFocusUnknown(t *testing.T) {
 		t.Fatalf("expected no payloads when focus state is unknown, got %d", len(sender.payloads))
 	}
 }
+
+func TestPushServiceSkipsPrivateGroupSessions(t *testing.T) {
+	snapshot := func(status string) *MenuSnapshot {
+		return &MenuSnapshot{
+			Profile: "work",
+			Items: []MenuItem{
+				{Type: MenuItemTypeGroup, Group: &MenuGroup{Name: "secret", Path: "secret"}},
+				{
+					Type: MenuItemTypeSession,
+					Session: &MenuSession{
+						ID:        "sess-private",
+						Title:     "Payroll Bot",
+						GroupPath: "secret/payroll",
+						Status:    session.Status(status),
+					},
+				},
+			},
+		}
+	}
+	menu := &rotatingPushMenuData{snapshots: []*MenuSnapshot{snapshot("running"), snapshot("waiting")}}
+	store := newFakePushStore()
+	focused := false
+	_ = store.Upsert(context.Background(), pushSubscription{
+		Endpoint:      "https://push.example/sub-a",
+		ClientFocused: &focused,
+		Keys:          pushSubscriptionKeys{P256DH: "k1", Auth: "k2"},
+	})
+	sender := &fakePushSender{}
+
+	push := &pushService{
+		enabled:       true,
+		menuData:      menu,
+		store:         store,
+		sender:        sender,
+		privateGroups: func() []string { return []string{"secret"} },
+		lastStatus:    make(map[string]string),
+		pollInterval:  defaultPushPollInterval,
+	}
+
+	push.syncOnce(context.Background()) // baseline
+	push.syncOnce(context.Background()) // running -> waiting
+
+	if len(sender.payloads) != 0 {
+		t.Fatalf("expected no push for a private-group session, got %d", len(sender.payloads))
+	}
+}
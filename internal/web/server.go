@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/asheshgoplani/agent-deck/internal/costs"
+	"github.com/asheshgoplani/agent-deck/internal/integrations/slack"
 	"github.com/asheshgoplani/agent-deck/internal/logging"
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	"golang.org/x/time/rate"
@@ -159,6 +160,14 @@ type Server struct {
 	// whose hook file is present on disk. Defaults to defaultLoadHookStatuses
 	// (which reads ~/.agent-deck/hooks/) but is injectable for tests.
 	hookStatusLoader func() map[string]*session.HookStatus
+
+	// previewLoader captures the recent pane text bundled into
+	// /api/snapshot. Defaults to capturePreviewTail; injectable for tests.
+	previewLoader func(ctx context.Context, ms *MenuSession) (string, error)
+
+	// privateGroups returns the group paths hidden from the web (see
+	// private_groups.go). Defaults to defaultPrivateGroups; injectable for tests.
+	privateGroups func() []string
 }
 
 // NewServer creates a new web server with base routes and middleware.
@@ -184,10 +193,12 @@ func NewServer(cfg Config) *Server {
 		menuSubscribers:  make(map[chan struct{}]struct{}),
 		mutationLimiter:  mutationLimiter,
 		hookStatusLoader: defaultLoadHookStatuses,
+		previewLoader:    capturePreviewTail,
+		privateGroups:    defaultPrivateGroups,
 	}
 	s.baseCtx, s.cancelBase = context.WithCancel(context.Background())
 	webLog := logging.ForComponent(logging.CompWeb)
-	if pushSvc, err := newPushService(cfg, menuData); err != nil {
+	if pushSvc, err := newPushService(cfg, menuLoaderFunc(s.loadMenuSnapshot), s.privateGroupPaths); err != nil {
 		webLog.Warn("push_disabled", slog.String("error", err.Error()))
 	} else {
 		s.push = pushSvc
@@ -222,6 +233,7 @@ func NewServer(cfg Config) *Server {
 		_ = json.NewEncoder(w).Encode(resp)
 	})
 	mux.HandleFunc("/api/menu", s.handleMenu)
+	mux.HandleFunc("/api/snapshot", s.handleSnapshot)
 	mux.HandleFunc("/api/session/", s.handleSessionByID)
 	mux.HandleFunc("/api/sessions", s.handleSessionsCollection)
 	// /api/sessions/undelete is a collection-level action (Chrome-style
@@ -241,6 +253,7 @@ func NewServer(cfg Config) *Server {
 	mux.HandleFunc("/api/push/presence", s.handlePushPresence)
 	mux.HandleFunc("/events/menu", s.handleMenuEvents)
 	mux.HandleFunc("/ws/session/", s.handleSessionWS)
+	mux.HandleFunc("/api/sessions/{id}/attach", s.handleSessionAttachWS)
 
 	// Command Center (the embedded live fleet god-view — see
 	// conductor/agent-deck/COMMAND-CENTER-DESIGN.md). Two read endpoints and
@@ -271,7 +284,13 @@ func NewServer(cfg Config) *Server {
 	mux.HandleFunc("DELETE /api/sessions/{id}/mcps/{name}", s.handleSessionMCPsRouter)
 	mux.HandleFunc("PATCH /api/sessions/{id}/mcps/{name}", s.handleSessionMCPsRouter)
 
-	handler := withRecover(s.csrfProtect(mux))
+	var handler http.Handler = withRecover(s.csrfProtect(mux))
+	if slackHandler := s.slackCommandHandler(); slackHandler != nil {
+		outer := http.NewServeMux()
+		outer.Handle(slack.CommandPath, withRecover(slackHandler))
+		outer.Handle("/", handler)
+		handler = outer
+	}
 
 	s.httpServer = &http.Server{
 		Addr:              cfg.ListenAddr,
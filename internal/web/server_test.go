@@ -162,7 +162,7 @@ func TestServiceWorkerServed(t *testing.T) {
 	if !strings.Contains(rr.Body.String(), "CACHE_VERSION") {
 		t.Fatalf("expected service worker payload, got: %s", rr.Body.String())
 	}
-	if !strings.Contains(rr.Body.String(), `agentdeck-shell-v5`) {
+	if !strings.Contains(rr.Body.String(), `agentdeck-shell-v6`) {
 		t.Fatalf("expected bumped service worker cache version, got: %s", rr.Body.String())
 	}
 }
@@ -127,6 +127,10 @@ type MenuSession struct {
 
 	LoadedMCPNames []string `json:"loadedMcpNames,omitempty"`
 
+	// RestartHistory lists recorded restarts, oldest first (capped
+	// server-side). Used to debug flapping sessions and auto-restart policies.
+	RestartHistory []session.RestartEvent `json:"restartHistory,omitempty"`
+
 	// claude_analytics has no underlying struct on *Instance so the matrix
 	// keeps it MISSING; only gemini is exposed today.
 	GeminiAnalytics *session.GeminiSessionAnalytics `json:"geminiAnalytics,omitempty"`
@@ -279,6 +283,7 @@ func toMenuSession(inst *session.Instance) *MenuSession {
 		TitleLocked:        inst.TitleLocked,
 		NoTransitionNotify: inst.NoTransitionNotify,
 		LoadedMCPNames:     inst.LoadedMCPNames,
+		RestartHistory:     inst.GetRestartHistory(),
 		GeminiAnalytics:    inst.GeminiAnalytics,
 	}
 }
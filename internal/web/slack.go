@@ -0,0 +1,103 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/integrations/slack"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// slackCommandHandler returns the /agentdeck slash-command endpoint, or nil
+// when [slack] has no signing secret or web mutations are disabled. Slack
+// cannot send the web token or an Origin header, so the endpoint is served
+// outside auth and CSRF; the request signature authenticates it instead.
+func (s *Server) slackCommandHandler() http.Handler {
+	if !s.cfg.WebMutations {
+		return nil
+	}
+	h := slack.NewCommandHandler(session.GetSlackIntegrationSettings(), s.sendSlackCommand)
+	if h == nil {
+		return nil
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.checkMutationRateLimit(w) {
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// sendSlackCommand delivers a slash command through `session send`, like
+// the command center's ask: the text is one argv element, never shell
+// input, and --no-wait keeps the reply inside Slack's response window. The
+// session is resolved among the web-visible ones first, so a private group
+// cannot be reached from Slack.
+func (s *Server) sendSlackCommand(ctx context.Context, ref, text string) error {
+	id, err := s.slackSessionID(ref)
+	if err != nil {
+		return err
+	}
+	exe, err := os.Executable()
+	if err != nil || exe == "" {
+		exe = "agent-deck"
+	}
+	cmd := exec.CommandContext(ctx, exe, "-p", s.cfg.Profile, "session", "send", id, text, "--no-wait")
+	cmd.Env = os.Environ()
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// errSlackSessionNotFound is returned for unknown and private sessions
+// alike, so a reply does not confirm that a private session exists.
+var errSlackSessionNotFound = errors.New("session not found")
+
+// slackSessionID resolves ref the way `session send` does (exact title, ID
+// prefix of 6+ characters, then project path), but only among the sessions
+// loadMenuSnapshot shows, i.e. with private groups filtered out.
+func (s *Server) slackSessionID(ref string) (string, error) {
+	if s.menuData == nil {
+		return "", errSlackSessionNotFound
+	}
+	snapshot, err := s.loadMenuSnapshot()
+	if err != nil {
+		return "", err
+	}
+	var sessions []*MenuSession
+	for _, item := range snapshot.Items {
+		if item.Session != nil {
+			if item.Session.Title == ref {
+				return item.Session.ID, nil
+			}
+			sessions = append(sessions, item.Session)
+		}
+	}
+	for _, match := range []func(*MenuSession) bool{
+		func(m *MenuSession) bool { return len(ref) >= 6 && strings.HasPrefix(m.ID, ref) },
+		func(m *MenuSession) bool { return m.ProjectPath == ref },
+	} {
+		var ids []string
+		for _, m := range sessions {
+			if match(m) {
+				ids = append(ids, m.ID)
+			}
+		}
+		switch {
+		case len(ids) == 1:
+			return ids[0], nil
+		case len(ids) > 1:
+			return "", fmt.Errorf("%q matches %d sessions; use the title or full ID", ref, len(ids))
+		}
+	}
+	return "", errSlackSessionNotFound
+}
@@ -0,0 +1,77 @@
+package web
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/integrations/slack"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestSlackSessionID_SkipsPrivateGroups(t *testing.T) {
+	srv := privateGroupsTestServer(t)
+
+	if id, err := srv.slackSessionID("client demo"); err != nil || id != "sess-work" {
+		t.Fatalf("slackSessionID(public title) = %q, %v; want sess-work", id, err)
+	}
+	for _, ref := range []string{"tax return", "sess-personal", "sess-health", "doctor notes", "nope"} {
+		if id, err := srv.slackSessionID(ref); err != errSlackSessionNotFound {
+			t.Errorf("slackSessionID(%q) = %q, %v; want session not found", ref, id, err)
+		}
+	}
+}
+
+func TestSlackCommandRoute_SignedAndPrivateFiltered(t *testing.T) {
+	path, err := session.GetUserConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	config := "[slack]\nenabled = true\nsigning_secret = \"shh\"\nallowed_user_ids = [\"U1\"]\n"
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	session.ClearUserConfigCache()
+	t.Cleanup(func() {
+		_ = os.Remove(path)
+		session.ClearUserConfigCache()
+	})
+
+	srv := NewServer(Config{ListenAddr: "127.0.0.1:0", Profile: "test-profile", WebMutations: true, Token: "secret"})
+	private := privateGroupsTestServer(t)
+	srv.menuData = private.menuData
+	srv.privateGroups = private.privateGroups
+
+	post := func(secret string, text string) *httptest.ResponseRecorder {
+		body := url.Values{"command": {"/agentdeck"}, "user_id": {"U1"}, "text": {text}}.Encode()
+		stamp := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte("v0:" + stamp + ":" + body))
+		req := httptest.NewRequest(http.MethodPost, slack.CommandPath, strings.NewReader(body))
+		req.Header.Set("X-Slack-Request-Timestamp", stamp)
+		req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+		rr := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := post("wrong", "client demo hi"); rr.Code != http.StatusUnauthorized {
+		t.Fatalf("badly signed request: status %d, want 401", rr.Code)
+	}
+	rr := post("shh", "tax return hi")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "session not found") {
+		t.Fatalf("private session: status %d body %s; want session not found", rr.Code, rr.Body.String())
+	}
+}
@@ -7,7 +7,7 @@
 import { html } from 'htm/preact'
 import { Logo, Icon, ICONS } from './icons.js'
 import { menuModelSignal } from './dataModel.js'
-import { connectionSignal, profilesSignal, commandCenterSignal } from './state.js'
+import { connectionSignal, profilesSignal, commandCenterSignal, offlineSnapshotSignal } from './state.js'
 import {
   activeTabSignal, paletteOpenSignal, tweaksOpenSignal,
   railSignal, profileSignal,
@@ -37,6 +37,7 @@ export function Topbar() {
   const cc = commandCenterSignal.value
   const decisionsBadge = cc && Array.isArray(cc.decisionsWaiting) ? cc.decisionsWaiting.length : 0
 
+  const offline = offlineSnapshotSignal.value
   const connClass = conn === 'connected' ? '' : 'off'
   const connDotStyle = conn === 'connected'
     ? {}
@@ -70,6 +71,7 @@ export function Topbar() {
       <div class="top-right">
         <div class=${`conn-pill ${connClass}`}>
           <span class="dot" style=${connDotStyle}/>ws · ${conn === 'connected' ? 'live' : conn}
+          ${offline && html`<span class="stale" title="Showing the last snapshot cached before the server became unreachable"> · stale since ${formatSnapshotTime(offline.generatedAt)}</span>`}
         </div>
         ${(() => {
           const p = profilesSignal.value
@@ -112,3 +114,9 @@ export function Topbar() {
     </header>
   `
 }
+
+function formatSnapshotTime(iso) {
+  const d = new Date(iso)
+  if (!iso || isNaN(d)) return 'unknown'
+  return d.toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })
+}
@@ -10,6 +10,7 @@ import {
   connectionSignal,
   authTokenSignal,
   commandCenterSignal,
+  offlineSnapshotSignal,
 } from './state.js'
 import { addToast } from './Toast.js'
 
@@ -63,6 +64,8 @@ export function startSSE() {
         // POL-1: first SSE snapshot counts as loaded. Skeleton unmounts
         // even if the snapshot is empty — the server has spoken.
         sessionsLoadedSignal.value = true
+        offlineSnapshotSignal.value = null
+        maybeRefreshOfflineSnapshot()
       }
       connectionSignal.value = 'connected'
     } catch (_) {
@@ -137,6 +140,48 @@ export function startCommandCenterSSE() {
   // stream; we don't flip it here to avoid fighting the menu reconnect logic.
 }
 
+// ---------- Offline snapshot ----------
+// GET /api/snapshot is cached by the service worker (network-first). Fetching
+// it while connected keeps the cached copy recent; when the server is gone
+// the worker answers from cache with X-Agentdeck-Stale and the app renders
+// the last known state, clearly marked as stale.
+
+const OFFLINE_SNAPSHOT_INTERVAL_MS = 60_000
+let _lastSnapshotFetch = 0
+
+export async function refreshOfflineSnapshot() {
+  _lastSnapshotFetch = Date.now()
+  const headers = { 'Accept': 'application/json' }
+  const token = authTokenSignal.value
+  if (token) headers['Authorization'] = 'Bearer ' + token
+  let res
+  try {
+    res = await fetch('/api/snapshot', { headers })
+  } catch (_) {
+    return // no service worker and no server: nothing to show
+  }
+  if (!res.ok || res.headers.get('X-Agentdeck-Stale') !== '1') return
+  try {
+    const snap = await res.json()
+    if (snap && snap.menu && Array.isArray(snap.menu.items)) {
+      sessionsSignal.value = snap.menu.items
+      sessionsLoadedSignal.value = true
+      offlineSnapshotSignal.value = {
+        generatedAt: snap.generatedAt || '',
+        previews: snap.previews || {},
+      }
+    }
+  } catch (_) {
+    // corrupt cache entry; keep the current view
+  }
+}
+
+function maybeRefreshOfflineSnapshot() {
+  if (Date.now() - _lastSnapshotFetch >= OFFLINE_SNAPSHOT_INTERVAL_MS) {
+    refreshOfflineSnapshot()
+  }
+}
+
 // ---------- Initial menu load + SSE kick-off ----------
 
 export async function loadMenu() {
@@ -147,10 +192,12 @@ export async function loadMenu() {
     // this in the catch branch; the skeleton is the correct state when
     // we're offline.
     sessionsLoadedSignal.value = true
+    refreshOfflineSnapshot()
     startSSE()
     startCommandCenterSSE()
   } catch (_) {
     connectionSignal.value = 'disconnected'
+    refreshOfflineSnapshot()
     // Still start SSE so it can reconnect when server comes back
     startSSE()
     startCommandCenterSSE()
@@ -2,10 +2,26 @@
 // bundle's `.term-wrap` chrome. We DO NOT rewrite TerminalPanel — it owns
 // xterm.js + the WebSocket lifecycle and is mature/tested. The wrapper
 // only provides outer padding consistent with the new design.
+//
+// While the app is rendering a cached offline snapshot there is no server
+// to attach to, so the wrapper shows the snapshot's pane preview instead,
+// marked stale, rather than a terminal stuck reconnecting.
 import { html } from 'htm/preact'
 import { TerminalPanel } from '../TerminalPanel.js'
+import { offlineSnapshotSignal, selectedIdSignal } from '../state.js'
 
 export function TerminalPane() {
+  const offline = offlineSnapshotSignal.value
+  if (offline) {
+    const preview = offline.previews[selectedIdSignal.value] || ''
+    const when = offline.generatedAt ? new Date(offline.generatedAt).toLocaleString() : 'unknown'
+    return html`
+      <div class="term-wrap offline-snapshot">
+        <div class="offline-banner">Offline — last known output as of ${when}</div>
+        <pre class="offline-preview">${preview || 'No cached output for this session.'}</pre>
+      </div>
+    `
+  }
   return html`
     <div class="term-wrap">
       <${TerminalPanel}/>
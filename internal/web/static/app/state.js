@@ -181,6 +181,12 @@ export const systemStatsSignal = signal(null)
 // SSE snapshot lands; the pane handles the null case with a skeleton.
 export const commandCenterSignal = signal(null)
 
+// Offline snapshot (GET /api/snapshot served from the service-worker cache).
+// Non-null only while the app is rendering stale data because the server is
+// unreachable. Shape: { generatedAt, previews: { [sessionId]: text } }.
+// Cleared by main.js as soon as a live menu snapshot arrives.
+export const offlineSnapshotSignal = signal(null)
+
 export async function loadArchivedSessions() {
   try {
     const data = await apiFetch('GET', '/api/sessions/archived')
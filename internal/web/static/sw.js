@@ -1,5 +1,8 @@
-const CACHE_VERSION = "agentdeck-shell-v5"
+const CACHE_VERSION = "agentdeck-shell-v6"
 const SHELL_CACHE = CACHE_VERSION
+// The offline snapshot outlives shell upgrades: it is user data, not code.
+const SNAPSHOT_CACHE = "agentdeck-snapshot-v1"
+const SNAPSHOT_URL = "/api/snapshot"
 const APP_SHELL_URLS = [
   "/",
   "/manifest.webmanifest",
@@ -25,7 +28,7 @@ self.addEventListener("activate", (event) => {
       .then((keys) =>
         Promise.all(
           keys
-            .filter((key) => key !== SHELL_CACHE)
+            .filter((key) => key !== SHELL_CACHE && key !== SNAPSHOT_CACHE)
             .map((key) => caches.delete(key)),
         ),
       )
@@ -54,6 +57,16 @@ self.addEventListener("fetch", (event) => {
     return
   }
 
+  if (url.pathname === SNAPSHOT_URL) {
+    event.respondWith(handleSnapshot(req))
+    return
+  }
+
+  if (url.pathname === "/api/menu") {
+    event.respondWith(handleMenu(req))
+    return
+  }
+
   if (
     url.pathname.startsWith("/api/") ||
     url.pathname.startsWith("/events/") ||
@@ -143,6 +156,55 @@ async function handleNavigate(req) {
   }
 }
 
+// handleSnapshot is network-first: every successful bundle replaces the
+// cached one, and when the server is unreachable the last bundle is served
+// with X-Agentdeck-Stale so the app can mark it as stale.
+async function handleSnapshot(req) {
+  const cache = await caches.open(SNAPSHOT_CACHE)
+  try {
+    const fresh = await fetch(req)
+    if (fresh && fresh.ok) {
+      await cache.put(SNAPSHOT_URL, fresh.clone())
+    }
+    return fresh
+  } catch (_err) {
+    const cached = await cache.match(SNAPSHOT_URL)
+    if (cached) {
+      return withStaleHeader(cached, await cached.text())
+    }
+    return handleRuntimeRequest(req, SNAPSHOT_URL)
+  }
+}
+
+// handleMenu falls back to the menu inside the cached snapshot so a cold
+// offline load still renders the session list instead of a skeleton.
+async function handleMenu(req) {
+  try {
+    return await fetch(req)
+  } catch (_err) {
+    const cache = await caches.open(SNAPSHOT_CACHE)
+    const cached = await cache.match(SNAPSHOT_URL)
+    if (cached) {
+      try {
+        const snap = await cached.json()
+        if (snap && snap.menu) {
+          return withStaleHeader(cached, JSON.stringify(snap.menu))
+        }
+      } catch (_parseErr) {
+        // fall through to the generic offline error
+      }
+    }
+    return handleRuntimeRequest(req, "/api/menu")
+  }
+}
+
+function withStaleHeader(cached, body) {
+  const headers = new Headers(cached.headers)
+  headers.set("X-Agentdeck-Stale", "1")
+  headers.set("Content-Type", "application/json; charset=utf-8")
+  return new Response(body, { status: 200, headers })
+}
+
 async function handleRuntimeRequest(req, pathname) {
   try {
     return await fetch(req)
@@ -387,7 +387,7 @@ def poller_restart_session(sid, profile, dry_run=False):
     args = [AGENT_DECK_BIN]
     if profile:
         args += ["-p", profile]
-    args += ["session", "restart", sid]
+    args += ["session", "restart", "--reason", "auto_policy", sid]
     rc, _, err = run_cmd(args, timeout=60)
     if rc != 0:
         log.warning("poller restart failed %s rc=%d err=%s", sid, rc, err.strip()[:200])
@@ -551,7 +551,7 @@ class Watchdog:
         base = [AGENT_DECK_BIN]
         if profile:
             base += ["-p", profile]
-        rc, _, err = run_cmd(base + ["session", "restart", sid], timeout=60)
+        rc, _, err = run_cmd(base + ["session", "restart", "--reason", "auto_policy", sid], timeout=60)
         if rc != 0:
             return False, None, f"restart rc={rc}: {err.strip()[:200]}"
         # Restart can silently no-op when tmux was fully dead. Poll up to 4s first.